@@ -20,6 +20,7 @@ package account
 
 import (
 	"errors"
+	"math"
 
 	"github.com/nebulasio/go-nebulas/crypto/hash"
 	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1/vrf/secp256k1VRF"
@@ -36,6 +37,10 @@ import (
 	"github.com/nebulasio/go-nebulas/crypto"
 	"github.com/nebulasio/go-nebulas/crypto/cipher"
 	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/ed25519"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/hd"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/hsm"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/threshold"
 	"github.com/nebulasio/go-nebulas/crypto/utils"
 	"github.com/nebulasio/go-nebulas/neblet/pb"
 	"github.com/nebulasio/go-nebulas/util/logging"
@@ -46,6 +51,7 @@ import (
 const (
 	EccSecp256K1      = "ECC_SECP256K1"
 	EccSecp256K1Value = 1
+	Ed25519           = "ED25519"
 
 	DefaultKeyDir = "keydir"
 )
@@ -59,6 +65,9 @@ var (
 
 	// ErrInvalidSignerAddress sign addr not from
 	ErrInvalidSignerAddress = errors.New("transaction sign not use from address")
+
+	// ErrNoEncryptedPayload the transaction carries no encrypted payload
+	ErrNoEncryptedPayload = errors.New("transaction has no encrypted payload")
 )
 
 // Neblet interface breaks cycle import dependency and hides unused services.
@@ -78,12 +87,25 @@ type Manager struct {
 	// key encrypt alg
 	encryptAlg keystore.Algorithm
 
+	// kdf and its parameters encrypting the key files
+	kdfParams cipher.KDFParams
+
 	// key signature alg
 	signatureAlg keystore.Algorithm
 
 	// account slice
 	accounts []*account
 
+	// accounts of ledger devices
+	ledgerAccounts []*ledgerAccount
+
+	// accounts kept by remote signing services
+	remoteAccounts []*remoteAccount
+
+	// accounts of the PKCS#11 token and its module
+	hsmAccounts []*hsmAccount
+	hsmModule   *hsm.Module
+
 	mutex sync.Mutex
 }
 
@@ -93,6 +115,7 @@ func NewManager(neblet Neblet) (*Manager, error) {
 	m.ks = keystore.DefaultKS
 	m.signatureAlg = keystore.SECP256K1
 	m.encryptAlg = keystore.SCRYPT
+	m.kdfParams = cipher.StandardKDFParams
 	tmpKeyDir, err := filepath.Abs(DefaultKeyDir)
 	if err != nil {
 		return nil, err
@@ -117,6 +140,35 @@ func NewManager(neblet Neblet) (*Manager, error) {
 			if conf.SignatureCiphers[0] == EccSecp256K1 {
 				m.signatureAlg = keystore.Algorithm(EccSecp256K1Value)
 			}
+			if conf.SignatureCiphers[0] == Ed25519 {
+				m.signatureAlg = keystore.ED25519
+			}
+		}
+
+		if len(conf.KeystoreKdf) > 0 {
+			if conf.KeystoreArgon2IdThreads > math.MaxUint8 {
+				return nil, cipher.ErrKDFParamsInvalid
+			}
+			m.kdfParams = cipher.KDFParams{
+				KDF:     conf.KeystoreKdf,
+				N:       int(conf.KeystoreScryptN),
+				R:       int(conf.KeystoreScryptR),
+				P:       int(conf.KeystoreScryptP),
+				Time:    conf.KeystoreArgon2IdTime,
+				Memory:  conf.KeystoreArgon2IdMemory,
+				Threads: uint8(conf.KeystoreArgon2IdThreads),
+			}
+			// check the parameters at launch rather than at the first export.
+			if _, err := cipher.NewCipherWithParams(uint8(m.encryptAlg), m.kdfParams); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := m.connectRemoteSignerByConfig(conf); err != nil {
+			return nil, err
+		}
+		if err := m.connectHSMByConfig(conf); err != nil {
+			return nil, err
 		}
 	}
 	if err := m.refreshAccounts(); err != nil {
@@ -148,6 +200,33 @@ func (m *Manager) NewAccount(passphrase []byte) (*core.Address, error) {
 	return addr, nil
 }
 
+// ImportMnemonic derive the key at the BIP-44 path from the mnemonic and its
+// optional passphrase, keep it in keystore locked with the passphrase.
+func (m *Manager) ImportMnemonic(mnemonic, mnemonicPassphrase, hdPath string, passphrase []byte) (*core.Address, error) {
+	if m.signatureAlg != keystore.SECP256K1 {
+		return nil, crypto.ErrAlgorithmInvalid
+	}
+	priv, err := hd.NewKeyFromMnemonic(mnemonic, mnemonicPassphrase, hdPath)
+	if err != nil {
+		return nil, err
+	}
+	defer priv.Clear()
+
+	addr, err := m.setKeyStore(priv, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	path, err := m.exportFile(addr, passphrase, false)
+	if err != nil {
+		return nil, err
+	}
+
+	m.updateAccount(addr, path)
+
+	return addr, nil
+}
+
 func (m *Manager) setKeyStore(priv keystore.PrivateKey, passphrase []byte) (*core.Address, error) {
 	pub, err := priv.PublicKey().Encoded()
 	if err != nil {
@@ -177,11 +256,30 @@ func (m *Manager) Contains(addr *core.Address) bool {
 			return true
 		}
 	}
+	for _, acc := range m.ledgerAccounts {
+		if acc.addr.Equals(addr) {
+			return true
+		}
+	}
+	for _, acc := range m.remoteAccounts {
+		if acc.addr.Equals(addr) {
+			return true
+		}
+	}
+	for _, acc := range m.hsmAccounts {
+		if acc.addr.Equals(addr) {
+			return true
+		}
+	}
 	return false
 }
 
 // Unlock unlock address with passphrase
 func (m *Manager) Unlock(addr *core.Address, passphrase []byte, duration time.Duration) error {
+	// the remote signing service and the token guard their keys themselves.
+	if m.IsRemoteAccount(addr) || m.IsHSMAccount(addr) {
+		return nil
+	}
 	res, err := m.ks.ContainsAlias(addr.String())
 	if err != nil || res == false {
 		err = m.loadFile(addr, passphrase)
@@ -192,11 +290,41 @@ func (m *Manager) Unlock(addr *core.Address, passphrase []byte, duration time.Du
 	return m.ks.Unlock(addr.String(), passphrase, duration)
 }
 
+// UnlockWithUses unlock address for the duration or the given number of
+// signings, whichever comes first, 0 uses for no limit.
+func (m *Manager) UnlockWithUses(addr *core.Address, passphrase []byte, duration time.Duration, uses uint32) error {
+	if m.IsRemoteAccount(addr) || m.IsHSMAccount(addr) {
+		return nil
+	}
+	res, err := m.ks.ContainsAlias(addr.String())
+	if err != nil || res == false {
+		err = m.loadFile(addr, passphrase)
+		if err != nil {
+			return err
+		}
+	}
+	return m.ks.UnlockWithUses(addr.String(), passphrase, duration, uses)
+}
+
 // Lock lock address
 func (m *Manager) Lock(addr *core.Address) error {
+	if m.IsRemoteAccount(addr) || m.IsHSMAccount(addr) {
+		return nil
+	}
 	return m.ks.Lock(addr.String())
 }
 
+// LockAll lock all the unlocked addresses
+func (m *Manager) LockAll() {
+	m.ks.LockAll()
+}
+
+// UnlockedAccounts returns the unlock states of the unlocked addresses,
+// aliased by the address strings
+func (m *Manager) UnlockedAccounts() []*keystore.UnlockInfo {
+	return m.ks.Unlocked()
+}
+
 // Accounts returns slice of address
 func (m *Manager) Accounts() []*core.Address {
 	m.refreshAccounts()
@@ -208,6 +336,15 @@ func (m *Manager) Accounts() []*core.Address {
 	for index, a := range m.accounts {
 		addrs[index] = a.addr
 	}
+	for _, a := range m.ledgerAccounts {
+		addrs = append(addrs, a.addr)
+	}
+	for _, a := range m.remoteAccounts {
+		addrs = append(addrs, a.addr)
+	}
+	for _, a := range m.hsmAccounts {
+		addrs = append(addrs, a.addr)
+	}
 	return addrs
 }
 
@@ -248,7 +385,12 @@ func (m *Manager) Load(keyjson, passphrase []byte) (*core.Address, error) {
 	}
 	defer utils.ZeroBytes(data)
 
-	priv, err := crypto.NewPrivateKey(m.signatureAlg, data)
+	// the keys of both algorithms can be kept, they differ in length.
+	alg := keystore.SECP256K1
+	if len(data) == ed25519.PrivateKeyLength {
+		alg = keystore.ED25519
+	}
+	priv, err := crypto.NewPrivateKey(alg, data)
 	if err != nil {
 		return nil, err
 	}
@@ -298,7 +440,7 @@ func (m *Manager) Export(addr *core.Address, passphrase []byte) ([]byte, error)
 	}
 	defer utils.ZeroBytes(data)
 
-	cipher := cipher.NewCipher(uint8(m.encryptAlg))
+	cipher, err := cipher.NewCipherWithParams(uint8(m.encryptAlg), m.kdfParams)
 	if err != nil {
 		return nil, err
 	}
@@ -309,6 +451,64 @@ func (m *Manager) Export(addr *core.Address, passphrase []byte) ([]byte, error)
 	return out, nil
 }
 
+// ExportV3 export address to a key file of ethereum keystore v3, which keeps
+// the ethereum address of the key.
+func (m *Manager) ExportV3(addr *core.Address, passphrase []byte) ([]byte, error) {
+	key, err := m.ks.GetKey(addr.String(), passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer key.Clear()
+
+	priv, ok := key.(keystore.PrivateKey)
+	if !ok || priv.Algorithm() != keystore.SECP256K1 {
+		return nil, crypto.ErrAlgorithmInvalid
+	}
+	pub, err := priv.PublicKey().Encoded()
+	if err != nil {
+		return nil, err
+	}
+	data, err := key.Encoded()
+	if err != nil {
+		return nil, err
+	}
+	defer utils.ZeroBytes(data)
+
+	cipher, err := cipher.NewCipherWithParams(uint8(m.encryptAlg), m.kdfParams)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.EncryptKeyV3(ethereumAddress(pub), data, passphrase)
+}
+
+// Split splits the secp256k1 key of the address into n shares, 2t-1 holders
+// of them sign without recovering the key, see threshold.Split.
+func (m *Manager) Split(addr *core.Address, passphrase []byte, t, n int) ([]*threshold.Share, [][]byte, error) {
+	key, err := m.ks.GetKey(addr.String(), passphrase)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer key.Clear()
+
+	priv, ok := key.(keystore.PrivateKey)
+	if !ok || priv.Algorithm() != keystore.SECP256K1 {
+		return nil, nil, crypto.ErrAlgorithmInvalid
+	}
+	data, err := key.Encoded()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer utils.ZeroBytes(data)
+
+	return threshold.Split(data, t, n)
+}
+
+// ethereumAddress the hex of the last 20 bytes of the keccak256 hash of the
+// uncompressed public key without its prefix.
+func ethereumAddress(pub []byte) string {
+	return byteutils.Hex(hash.Keccak256(pub[1:])[12:])
+}
+
 // Remove remove address and encrypted private key from keystore
 func (m *Manager) Remove(addr *core.Address, passphrase []byte) error {
 	err := m.ks.Delete(addr.String(), passphrase)
@@ -321,8 +521,44 @@ func (m *Manager) Remove(addr *core.Address, passphrase []byte) error {
 
 // SignHash sign hash
 func (m *Manager) SignHash(addr *core.Address, hash byteutils.Hash, alg keystore.Algorithm) ([]byte, error) {
-	key, err := m.ks.GetUnlocked(addr.String())
-	if err != nil {
+	if signature := m.ledgerSignature(addr); signature != nil {
+		if alg != signature.Algorithm() {
+			return nil, crypto.ErrAlgorithmInvalid
+		}
+		return signature.Sign(hash)
+	}
+	if signer := m.remoteSigner(addr); signer != nil {
+		if alg != keystore.SECP256K1 {
+			return nil, crypto.ErrAlgorithmInvalid
+		}
+		return signer.SignHash(hash)
+	}
+	if signature := m.hsmSignature(addr); signature != nil {
+		if alg != signature.Algorithm() {
+			return nil, crypto.ErrAlgorithmInvalid
+		}
+		return signature.Sign(hash)
+	}
+
+	var signData []byte
+	err := m.ks.UseUnlocked(addr.String(), func(key keystore.Key) error {
+		if key.Algorithm() != alg {
+			return crypto.ErrAlgorithmInvalid
+		}
+
+		signature, err := crypto.NewSignature(alg)
+		if err != nil {
+			return err
+		}
+
+		if err := signature.InitSign(key.(keystore.PrivateKey)); err != nil {
+			return err
+		}
+
+		signData, err = signature.Sign(hash)
+		return err
+	})
+	if err == keystore.ErrNotUnlocked {
 		logging.VLog().WithFields(logrus.Fields{
 			"err":  err,
 			"addr": addr,
@@ -330,21 +566,27 @@ func (m *Manager) SignHash(addr *core.Address, hash byteutils.Hash, alg keystore
 		}).Error("Failed to get unlocked private key.")
 		return nil, ErrAccountIsLocked
 	}
-
-	signature, err := crypto.NewSignature(alg)
 	if err != nil {
 		return nil, err
 	}
+	return signData, nil
+}
 
-	if err := signature.InitSign(key.(keystore.PrivateKey)); err != nil {
-		return nil, err
+// DecryptPayload decrypt the encrypted payload of the tx by the key of addr,
+// the payload must be encrypted to its public key.
+func (m *Manager) DecryptPayload(addr *core.Address, tx *core.Transaction) ([]byte, error) {
+	if len(tx.EncryptedPayload()) == 0 {
+		return nil, ErrNoEncryptedPayload
 	}
-
-	signData, err := signature.Sign(hash)
-	if err != nil {
-		return nil, err
+	if m.ledgerSignature(addr) != nil || m.IsRemoteAccount(addr) || m.IsHSMAccount(addr) {
+		// the keys never leave the devices, which only sign.
+		return nil, keystore.ErrDecryptNotSupported
 	}
-	return signData, nil
+	plaintext, err := m.ks.Decrypt(addr.String(), tx.EncryptedPayload())
+	if err == keystore.ErrNotUnlocked {
+		return nil, ErrAccountIsLocked
+	}
+	return plaintext, err
 }
 
 // SignTransaction sign transaction with the specified algorithm
@@ -353,45 +595,99 @@ func (m *Manager) SignTransaction(addr *core.Address, tx *core.Transaction) erro
 	if !tx.From().Equals(addr) {
 		return ErrInvalidSignerAddress
 	}
-	key, err := m.ks.GetUnlocked(addr.String())
-	if err != nil {
+	if signature := m.ledgerSignature(addr); signature != nil {
+		return tx.Sign(signature)
+	}
+	if signature := m.remoteSignature(addr, transactionMetadata(tx)); signature != nil {
+		return tx.Sign(signature)
+	}
+	if signature := m.hsmSignature(addr); signature != nil {
+		return tx.Sign(signature)
+	}
+	err := m.ks.UseUnlocked(addr.String(), func(key keystore.Key) error {
+		signature, err := crypto.NewSignature(key.Algorithm())
+		if err != nil {
+			return err
+		}
+		signature.InitSign(key.(keystore.PrivateKey))
+		return tx.Sign(signature)
+	})
+	if err == keystore.ErrNotUnlocked {
 		logging.VLog().WithFields(logrus.Fields{
 			"err": err,
 			"tx":  tx,
 		}).Error("Failed to get unlocked private key to sign transaction.")
 		return ErrAccountIsLocked
 	}
+	return err
+}
 
-	signature, err := crypto.NewSignature(m.signatureAlg)
-	if err != nil {
-		return err
+// SignRotatedTransaction sign the tx from a rotated account by its active key
+func (m *Manager) SignRotatedTransaction(signer *core.Address, tx *core.Transaction) error {
+	if signature := m.ledgerSignature(signer); signature != nil {
+		return tx.SignRotated(signature)
 	}
-	signature.InitSign(key.(keystore.PrivateKey))
-	return tx.Sign(signature)
+	if signature := m.remoteSignature(signer, transactionMetadata(tx)); signature != nil {
+		return tx.SignRotated(signature)
+	}
+	if signature := m.hsmSignature(signer); signature != nil {
+		return tx.SignRotated(signature)
+	}
+	err := m.ks.UseUnlocked(signer.String(), func(key keystore.Key) error {
+		signature, err := crypto.NewSignature(key.Algorithm())
+		if err != nil {
+			return err
+		}
+		signature.InitSign(key.(keystore.PrivateKey))
+		return tx.SignRotated(signature)
+	})
+	if err == keystore.ErrNotUnlocked {
+		return ErrAccountIsLocked
+	}
+	return err
 }
 
 // SignBlock sign block with the specified algorithm
 func (m *Manager) SignBlock(addr *core.Address, block *core.Block) error {
-	key, err := m.ks.GetUnlocked(addr.String())
-	if err != nil {
+	if signature := m.ledgerSignature(addr); signature != nil {
+		return block.Sign(signature)
+	}
+	if signature := m.remoteSignature(addr, blockMetadata(block)); signature != nil {
+		return block.Sign(signature)
+	}
+	if signature := m.hsmSignature(addr); signature != nil {
+		return block.Sign(signature)
+	}
+	err := m.ks.UseUnlocked(addr.String(), func(key keystore.Key) error {
+		signature, err := crypto.NewSignature(m.signatureAlg)
+		if err != nil {
+			return err
+		}
+		signature.InitSign(key.(keystore.PrivateKey))
+		return block.Sign(signature)
+	})
+	if err == keystore.ErrNotUnlocked {
 		logging.VLog().WithFields(logrus.Fields{
 			"err":   err,
 			"block": block,
 		}).Error("Failed to get unlocked private key to sign block.")
 		return ErrAccountIsLocked
 	}
-
-	signature, err := crypto.NewSignature(m.signatureAlg)
-	if err != nil {
-		return err
-	}
-	signature.InitSign(key.(keystore.PrivateKey))
-	return block.Sign(signature)
+	return err
 }
 
 // GenerateRandomSeed generate rand
 func (m *Manager) GenerateRandomSeed(addr *core.Address, ancestorHash, parentSeed []byte) (vrfSeed, vrfProof []byte, err error) {
+	if signer := m.remoteSigner(addr); signer != nil {
+		return signer.GenerateRandomSeed(ancestorHash, parentSeed)
+	}
+	// the VRF needs the private key, which never leaves the token.
+	if m.IsHSMAccount(addr) {
+		return nil, nil, hsm.ErrKeyOnToken
+	}
 
+	// the seed is evaluated for minting a block, only signing the block
+	// counts as a use of the unlock.
 	key, err := m.ks.GetUnlocked(addr.String())
 	if err != nil {
 		logging.VLog().WithFields(logrus.Fields{
@@ -410,6 +706,7 @@ func (m *Manager) GenerateRandomSeed(addr *core.Address, ancestorHash, parentSee
 	if err != nil {
 		return nil, nil, err
 	}
+	defer utils.ZeroBytes(seckey)
 
 	signer, err := secp256k1VRF.NewVRFSignerFromRawKey(seckey)
 	if err != nil {
@@ -431,6 +728,12 @@ func (m *Manager) SignTransactionWithPassphrase(addr *core.Address, tx *core.Tra
 	if !tx.From().Equals(addr) {
 		return ErrInvalidSignerAddress
 	}
+	if signature := m.remoteSignature(addr, transactionMetadata(tx)); signature != nil {
+		return tx.Sign(signature)
+	}
+	if signature := m.hsmSignature(addr); signature != nil {
+		return tx.Sign(signature)
+	}
 	res, err := m.ks.ContainsAlias(addr.String())
 	if err != nil || res == false {
 		err = m.loadFile(addr, passphrase)
@@ -449,7 +752,7 @@ func (m *Manager) SignTransactionWithPassphrase(addr *core.Address, tx *core.Tra
 	}
 	defer key.Clear()
 
-	signature, err := crypto.NewSignature(m.signatureAlg)
+	signature, err := crypto.NewSignature(key.Algorithm())
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,159 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package account
+
+import (
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/hsm"
+	"github.com/nebulasio/go-nebulas/neblet/pb"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// hsmAccount an account whose private key is kept in a PKCS#11 token, it
+// signs on the token only.
+type hsmAccount struct {
+
+	// key address
+	addr *core.Address
+
+	// hex id of the key pair on the token
+	keyID string
+
+	// uncompressed public key
+	pub []byte
+}
+
+// ConnectHSM add the accounts of the key pairs of the ids on the token of the
+// module, the accounts of the previous module are removed.
+func (m *Manager) ConnectHSM(module *hsm.Module, keyIDs ...string) error {
+	accs := make([]*hsmAccount, 0, len(keyIDs))
+	for _, keyID := range keyIDs {
+		pub, err := module.PublicKey(keyID)
+		if err != nil {
+			return err
+		}
+		addr, err := core.NewAddressFromPublicKey(pub)
+		if err != nil {
+			return err
+		}
+		accs = append(accs, &hsmAccount{addr: addr, keyID: keyID, pub: pub})
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.hsmModule != nil && m.hsmModule != module {
+		m.hsmModule.Close()
+	}
+	m.hsmModule = module
+	m.hsmAccounts = accs
+
+	for _, acc := range accs {
+		logging.VLog().WithFields(logrus.Fields{
+			"addr":  acc.addr,
+			"token": module.Label(),
+			"keyID": acc.keyID,
+		}).Info("Connected a pkcs11 account.")
+	}
+	return nil
+}
+
+// NewHSMAccount generate a key pair of the id on the token connected and add its account
+func (m *Manager) NewHSMAccount(keyID string) (*core.Address, error) {
+	m.mutex.Lock()
+	module := m.hsmModule
+	m.mutex.Unlock()
+	if module == nil {
+		return nil, hsm.ErrModuleClosed
+	}
+
+	pub, err := module.GenerateKey(keyID)
+	if err != nil {
+		return nil, err
+	}
+	addr, err := core.NewAddressFromPublicKey(pub)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mutex.Lock()
+	m.hsmAccounts = append(m.hsmAccounts, &hsmAccount{addr: addr, keyID: keyID, pub: pub})
+	m.mutex.Unlock()
+
+	logging.VLog().WithFields(logrus.Fields{
+		"addr":  addr,
+		"token": module.Label(),
+		"keyID": keyID,
+	}).Info("Generated a pkcs11 account.")
+	return addr, nil
+}
+
+// DisconnectHSM remove the pkcs11 accounts and close the module
+func (m *Manager) DisconnectHSM() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.hsmModule != nil {
+		if err := m.hsmModule.Close(); err != nil {
+			logging.VLog().WithFields(logrus.Fields{
+				"token": m.hsmModule.Label(),
+				"err":   err,
+			}).Debug("Failed to close pkcs11 module.")
+		}
+	}
+	m.hsmModule = nil
+	m.hsmAccounts = nil
+}
+
+// IsHSMAccount returns if the key of the address is kept in a PKCS#11 token
+func (m *Manager) IsHSMAccount(addr *core.Address) bool {
+	return m.hsmSignature(addr) != nil
+}
+
+// hsmSignature return the signature signing on the token by the key of the
+// pkcs11 account, nil if the address is not a pkcs11 account.
+func (m *Manager) hsmSignature(addr *core.Address) keystore.Signature {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for _, acc := range m.hsmAccounts {
+		if acc.addr.Equals(addr) {
+			return hsm.NewSignature(m.hsmModule, acc.keyID, acc.pub)
+		}
+	}
+	return nil
+}
+
+// connectHSMByConfig open the PKCS#11 library in the chain config and connect its accounts
+func (m *Manager) connectHSMByConfig(conf *nebletpb.ChainConfig) error {
+	if len(conf.Pkcs11Library) == 0 {
+		return nil
+	}
+	module, err := hsm.Open(conf.Pkcs11Library, conf.Pkcs11TokenLabel, conf.Pkcs11Pin)
+	if err != nil {
+		return err
+	}
+	if err := m.ConnectHSM(module, conf.Pkcs11KeyIds...); err != nil {
+		module.Close()
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package account
+
+import (
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/ledger"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// ledgerAccount an account whose private key is kept in a ledger device,
+// it signs on the device only.
+type ledgerAccount struct {
+
+	// key address
+	addr *core.Address
+
+	// BIP-44 path of the key
+	path string
+
+	device *ledger.Device
+}
+
+// ConnectLedger add the account at the BIP-44 path of the first ledger device
+// connected, the user confirms the address on the device if confirm.
+func (m *Manager) ConnectLedger(path string, confirm bool) (*core.Address, error) {
+	device, err := ledger.FirstDevice()
+	if err != nil {
+		return nil, err
+	}
+	pub, err := device.PublicKey(path, confirm)
+	if err != nil {
+		device.Close()
+		return nil, err
+	}
+	addr, err := core.NewAddressFromPublicKey(pub)
+	if err != nil {
+		device.Close()
+		return nil, err
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for _, acc := range m.ledgerAccounts {
+		if acc.addr.Equals(addr) {
+			acc.device.Close()
+			acc.device = device
+			acc.path = path
+			return addr, nil
+		}
+	}
+	m.ledgerAccounts = append(m.ledgerAccounts, &ledgerAccount{
+		addr:   addr,
+		path:   path,
+		device: device,
+	})
+
+	logging.VLog().WithFields(logrus.Fields{
+		"addr":   addr,
+		"path":   path,
+		"device": device.Path(),
+	}).Info("Connected a ledger account.")
+	return addr, nil
+}
+
+// DisconnectLedger remove the ledger accounts and close their devices
+func (m *Manager) DisconnectLedger() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for _, acc := range m.ledgerAccounts {
+		if err := acc.device.Close(); err != nil {
+			logging.VLog().WithFields(logrus.Fields{
+				"addr": acc.addr,
+				"err":  err,
+			}).Debug("Failed to close ledger device.")
+		}
+	}
+	m.ledgerAccounts = nil
+}
+
+// ledgerSignature return the signature signing on the device of the ledger
+// account, nil if the address is not a ledger account.
+func (m *Manager) ledgerSignature(addr *core.Address) keystore.Signature {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for _, acc := range m.ledgerAccounts {
+		if acc.addr.Equals(addr) {
+			return ledger.NewSignature(acc.device, acc.path)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package account
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/remote"
+	"github.com/nebulasio/go-nebulas/neblet/pb"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	// ErrRemoteSignerAddress the public key returned by the remote signer does not derive the account address
+	ErrRemoteSignerAddress = errors.New("remote signer key does not match the address")
+
+	// ErrInvalidRemoteSignerCA the CA file of the remote signer has no certificate
+	ErrInvalidRemoteSignerCA = errors.New("invalid remote signer CA file")
+)
+
+// remoteAccount an account whose private key is kept by a remote signing
+// service, it signs by the service only.
+type remoteAccount struct {
+
+	// key address
+	addr *core.Address
+
+	signer *remote.Signer
+}
+
+// ConnectRemoteSigner add the accounts kept by the signing service of the
+// client, the public key of each account is fetched and checked against its address.
+func (m *Manager) ConnectRemoteSigner(client *remote.Client, addrs ...*core.Address) error {
+	accs := make([]*remoteAccount, 0, len(addrs))
+	for _, addr := range addrs {
+		signer, err := remote.NewSigner(client, addr.String())
+		if err != nil {
+			return err
+		}
+		derived, err := core.NewAddressFromPublicKey(signer.PublicKey())
+		if err != nil {
+			return err
+		}
+		if !derived.Equals(addr) {
+			return ErrRemoteSignerAddress
+		}
+		accs = append(accs, &remoteAccount{addr: addr, signer: signer})
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for _, acc := range accs {
+		replaced := false
+		for i, old := range m.remoteAccounts {
+			if old.addr.Equals(acc.addr) {
+				m.remoteAccounts[i] = acc
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			m.remoteAccounts = append(m.remoteAccounts, acc)
+		}
+
+		logging.VLog().WithFields(logrus.Fields{
+			"addr":     acc.addr,
+			"endpoint": client.Endpoint(),
+		}).Info("Connected a remote signer account.")
+	}
+	return nil
+}
+
+// IsRemoteAccount returns if the key of the address is kept by a remote signing service
+func (m *Manager) IsRemoteAccount(addr *core.Address) bool {
+	return m.remoteSigner(addr) != nil
+}
+
+// remoteSigner return the signer of the remote account, nil if the address
+// is not a remote account.
+func (m *Manager) remoteSigner(addr *core.Address) *remote.Signer {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for _, acc := range m.remoteAccounts {
+		if acc.addr.Equals(addr) {
+			return acc.signer
+		}
+	}
+	return nil
+}
+
+// remoteSignature return the signature signing by the remote signer of the
+// account with the metadata, nil if the address is not a remote account.
+func (m *Manager) remoteSignature(addr *core.Address, meta *remote.Metadata) keystore.Signature {
+	signer := m.remoteSigner(addr)
+	if signer == nil {
+		return nil
+	}
+	return signer.Signature(meta)
+}
+
+// connectRemoteSignerByConfig connect the remote signer accounts in the chain config
+func (m *Manager) connectRemoteSignerByConfig(conf *nebletpb.ChainConfig) error {
+	if len(conf.RemoteSigner) == 0 {
+		return nil
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if len(conf.RemoteSignerCa) > 0 {
+		data, err := ioutil.ReadFile(conf.RemoteSignerCa)
+		if err != nil {
+			return err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return ErrInvalidRemoteSignerCA
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if len(conf.RemoteSignerCert) > 0 || len(conf.RemoteSignerKey) > 0 {
+		cert, err := tls.LoadX509KeyPair(conf.RemoteSignerCert, conf.RemoteSignerKey)
+		if err != nil {
+			return err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	client, err := remote.NewClient(conf.RemoteSigner, conf.RemoteSignerToken, tlsConfig)
+	if err != nil {
+		return err
+	}
+	addrs := make([]*core.Address, len(conf.RemoteSignerAccounts))
+	for i, v := range conf.RemoteSignerAccounts {
+		addr, err := core.AddressParse(v)
+		if err != nil {
+			return err
+		}
+		addrs[i] = addr
+	}
+	return m.ConnectRemoteSigner(client, addrs...)
+}
+
+// transactionMetadata the fields of the tx displayed by the signing service
+func transactionMetadata(tx *core.Transaction) *remote.Metadata {
+	return &remote.Metadata{
+		Kind: remote.KindTransaction,
+		Fields: map[string]string{
+			"chain_id":  fmt.Sprint(tx.ChainID()),
+			"from":      tx.From().String(),
+			"to":        tx.To().String(),
+			"value":     tx.Value().String(),
+			"nonce":     strconv.FormatUint(tx.Nonce(), 10),
+			"type":      tx.Type(),
+			"gas_price": tx.GasPrice().String(),
+			"gas_limit": tx.GasLimit().String(),
+		},
+	}
+}
+
+// blockMetadata the fields of the block displayed by the signing service
+func blockMetadata(block *core.Block) *remote.Metadata {
+	return &remote.Metadata{
+		Kind: remote.KindBlock,
+		Fields: map[string]string{
+			"chain_id":     fmt.Sprint(block.ChainID()),
+			"height":       strconv.FormatUint(block.Height(), 10),
+			"parent_hash":  block.ParentHash().String(),
+			"coinbase":     block.Coinbase().String(),
+			"timestamp":    strconv.FormatInt(block.Timestamp(), 10),
+			"transactions": strconv.Itoa(len(block.Transactions())),
+		},
+	}
+}
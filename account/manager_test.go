@@ -20,6 +20,7 @@ package account
 
 import (
 	"crypto/md5"
+	"encoding/json"
 	"fmt"
 	"testing"
 	"time"
@@ -28,8 +29,12 @@ import (
 
 	"github.com/nebulasio/go-nebulas/core"
 	"github.com/nebulasio/go-nebulas/crypto"
+	"github.com/nebulasio/go-nebulas/crypto/cipher"
 	"github.com/nebulasio/go-nebulas/crypto/hash"
 	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/hd"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/threshold"
 	"github.com/nebulasio/go-nebulas/util"
 	"github.com/nebulasio/go-nebulas/util/byteutils"
 	"github.com/stretchr/testify/assert"
@@ -105,6 +110,45 @@ func TestManager_Unlock(t *testing.T) {
 	}
 }
 
+func TestManager_UnlockWithUses(t *testing.T) {
+	manager, _ := NewManager(nil)
+	passphrase := []byte("passphrase")
+	priv, err := crypto.NewPrivateKey(keystore.SECP256K1, nil)
+	assert.Nil(t, err)
+	addr, err := manager.setKeyStore(priv, passphrase)
+	assert.Nil(t, err)
+
+	unlocked := func() *keystore.UnlockInfo {
+		for _, info := range manager.UnlockedAccounts() {
+			if info.Alias == addr.String() {
+				return info
+			}
+		}
+		return nil
+	}
+	hash := make([]byte, 32)
+
+	assert.Nil(t, manager.UnlockWithUses(addr, passphrase, keystore.DefaultUnlockDuration, 1))
+	acc := unlocked()
+	assert.NotNil(t, acc)
+	assert.Equal(t, uint32(1), acc.Uses)
+	_, err = manager.SignHash(addr, hash, keystore.SECP256K1)
+	assert.Nil(t, err)
+	assert.Nil(t, unlocked())
+	_, err = manager.SignHash(addr, hash, keystore.SECP256K1)
+	assert.Equal(t, ErrAccountIsLocked, err)
+
+	assert.Nil(t, manager.Unlock(addr, passphrase, keystore.DefaultUnlockDuration))
+	assert.Equal(t, uint32(0), unlocked().Uses)
+	_, err = manager.SignHash(addr, hash, keystore.SECP256K1)
+	assert.Nil(t, err)
+	manager.LockAll()
+	assert.Nil(t, unlocked())
+	_, err = manager.SignHash(addr, hash, keystore.SECP256K1)
+	assert.Equal(t, ErrAccountIsLocked, err)
+	assert.Nil(t, manager.Remove(addr, passphrase))
+}
+
 func TestManager_Load(t *testing.T) {
 	manager, _ := NewManager(nil)
 	passphrase := []byte("b84c54af84672b5ae814")
@@ -189,6 +233,37 @@ func TestManager_SignTransaction(t *testing.T) {
 	}
 }
 
+func TestManager_DecryptPayload(t *testing.T) {
+	manager, _ := NewManager(nil)
+	passphrase := []byte("passphrase")
+	priv, err := crypto.NewPrivateKey(keystore.SECP256K1, nil)
+	assert.Nil(t, err)
+	addr, err := manager.setKeyStore(priv, passphrase)
+	assert.Nil(t, err)
+	pub, err := priv.PublicKey().Encoded()
+	assert.Nil(t, err)
+
+	value, _ := util.NewUint128FromInt(5)
+	gasLimit, _ := util.NewUint128FromInt(5)
+	gasPrice, _ := util.NewUint128FromInt(1)
+	tx, _ := core.NewTransaction(0, addr, addr, value, 0, core.TxPayloadBinaryType, nil, gasPrice, gasLimit)
+	_, err = manager.DecryptPayload(addr, tx)
+	assert.Equal(t, ErrNoEncryptedPayload, err)
+
+	memo := []byte("private memo")
+	payload, err := secp256k1.Encrypt(pub, memo)
+	assert.Nil(t, err)
+	assert.Nil(t, tx.SetEncryptedPayload(payload))
+	_, err = manager.DecryptPayload(addr, tx)
+	assert.Equal(t, ErrAccountIsLocked, err)
+
+	assert.Nil(t, manager.Unlock(addr, passphrase, keystore.DefaultUnlockDuration))
+	plaintext, err := manager.DecryptPayload(addr, tx)
+	assert.Nil(t, err)
+	assert.Equal(t, memo, plaintext)
+	assert.Nil(t, manager.Remove(addr, passphrase))
+}
+
 func TestManager_SignTransactionWithPassphrase(t *testing.T) {
 	manager, _ := NewManager(nil)
 	tests := []struct {
@@ -387,3 +462,108 @@ func TestCryptoPerformance(t *testing.T) {
 	err = os.Remove(acc.path)
 	assert.Nil(t, err)
 }
+
+func TestManager_ImportMnemonic(t *testing.T) {
+	manager, _ := NewManager(nil)
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	passphrase := []byte("passphrase")
+
+	_, err := manager.ImportMnemonic(mnemonic+" about", "", hd.DefaultPath, passphrase)
+	assert.Equal(t, hd.ErrInvalidMnemonic, err)
+	_, err = manager.ImportMnemonic(mnemonic, "", "44'/2718'", passphrase)
+	assert.Equal(t, hd.ErrInvalidDerivationPath, err)
+
+	addr, err := manager.ImportMnemonic(mnemonic, "", hd.DefaultPath, passphrase)
+	assert.Nil(t, err)
+	acc, err := manager.getAccount(addr)
+	assert.Nil(t, err)
+
+	// the same path of the same mnemonic always derives the same account.
+	priv, err := hd.NewKeyFromMnemonic(mnemonic, "", hd.NebulasPath(0, 0))
+	assert.Nil(t, err)
+	pub, err := priv.PublicKey().Encoded()
+	assert.Nil(t, err)
+	derived, err := core.NewAddressFromPublicKey(pub)
+	assert.Nil(t, err)
+	assert.True(t, addr.Equals(derived))
+	other, err := manager.ImportMnemonic(mnemonic, "", hd.NebulasPath(0, 1), passphrase)
+	assert.Nil(t, err)
+	assert.False(t, addr.Equals(other))
+	otherAcc, err := manager.getAccount(other)
+	assert.Nil(t, err)
+
+	for _, a := range []*account{acc, otherAcc} {
+		assert.Nil(t, manager.Remove(a.addr, passphrase))
+		assert.Nil(t, os.Remove(a.path))
+	}
+}
+
+func TestManager_ExportV3(t *testing.T) {
+	manager, _ := NewManager(nil)
+	passphrase := []byte("testpassword")
+	data, _ := byteutils.FromHex("7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d")
+	priv, err := crypto.NewPrivateKey(keystore.SECP256K1, data)
+	assert.Nil(t, err)
+	addr, err := manager.setKeyStore(priv, passphrase)
+	assert.Nil(t, err)
+
+	keyjson, err := manager.ExportV3(addr, passphrase)
+	assert.Nil(t, err)
+	v3 := make(map[string]interface{})
+	assert.Nil(t, json.Unmarshal(keyjson, &v3))
+	assert.Equal(t, float64(3), v3["version"])
+	assert.Equal(t, "008aeeda4d805471df9b2a5b0f38a0c3bcba786b", v3["address"])
+
+	loaded, err := manager.Load(keyjson, passphrase)
+	assert.Nil(t, err)
+	assert.True(t, addr.Equals(loaded))
+	assert.Nil(t, manager.Remove(addr, passphrase))
+}
+
+func TestManager_ExportArgon2id(t *testing.T) {
+	manager, _ := NewManager(nil)
+	manager.kdfParams = cipher.KDFParams{KDF: cipher.Argon2idKDF, Time: 1, Memory: 1024, Threads: 1}
+	passphrase := []byte("testpassword")
+	priv, err := crypto.NewPrivateKey(keystore.SECP256K1, nil)
+	assert.Nil(t, err)
+	addr, err := manager.setKeyStore(priv, passphrase)
+	assert.Nil(t, err)
+
+	keyjson, err := manager.Export(addr, passphrase)
+	assert.Nil(t, err)
+	v := make(map[string]interface{})
+	assert.Nil(t, json.Unmarshal(keyjson, &v))
+	assert.Equal(t, cipher.Argon2idKDF, v["crypto"].(map[string]interface{})["kdf"])
+
+	loaded, err := manager.Load(keyjson, passphrase)
+	assert.Nil(t, err)
+	assert.True(t, addr.Equals(loaded))
+
+	manager.kdfParams = cipher.KDFParams{KDF: cipher.Argon2idKDF, Memory: 8, Threads: 4}
+	_, err = manager.Export(addr, passphrase)
+	assert.Equal(t, cipher.ErrKDFParamsInvalid, err)
+	assert.Nil(t, manager.Remove(addr, passphrase))
+}
+
+func TestManager_Split(t *testing.T) {
+	manager, _ := NewManager(nil)
+	passphrase := []byte("testpassword")
+	priv, err := crypto.NewPrivateKey(keystore.SECP256K1, nil)
+	assert.Nil(t, err)
+	addr, err := manager.setKeyStore(priv, passphrase)
+	assert.Nil(t, err)
+
+	shares, commitments, err := manager.Split(addr, passphrase, 2, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(shares))
+	splitAddr, err := core.NewAddressFromPublicKey(commitments[0])
+	assert.Nil(t, err)
+	assert.True(t, addr.Equals(splitAddr))
+	for _, share := range shares {
+		assert.True(t, threshold.VerifyShare(share, commitments))
+	}
+
+	_, _, err = manager.Split(addr, []byte("wrong"), 2, 3)
+	assert.NotNil(t, err)
+	assert.Nil(t, manager.Remove(addr, passphrase))
+}
@@ -19,15 +19,51 @@
 package main
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strconv"
 
 	"github.com/nebulasio/go-nebulas/cmd/console"
+	"github.com/nebulasio/go-nebulas/consensus/dpos"
 	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/hd"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/threshold"
+	"github.com/nebulasio/go-nebulas/crypto/utils"
+	"github.com/nebulasio/go-nebulas/neblet"
 	"github.com/urfave/cli"
 )
 
 var (
+	shareListenFlag = cli.StringFlag{
+		Name:  "listen",
+		Usage: "https listen address of the share holder",
+		Value: "0.0.0.0:8690",
+	}
+
+	shareCertFlag = cli.StringFlag{
+		Name:  "cert",
+		Usage: "tls certificate file of the share holder",
+	}
+
+	shareKeyFlag = cli.StringFlag{
+		Name:  "key",
+		Usage: "tls key file of the share holder",
+	}
+
+	shareClientCAFlag = cli.StringFlag{
+		Name:  "client-ca",
+		Usage: "CA file of the node's client certificates, for mutual tls",
+	}
+
+	shareTokenFlag = cli.StringFlag{
+		Name:  "token",
+		Usage: "bearer token of the node, threshold_holder_token of the chain config",
+	}
+
 	accountCommand = cli.Command{
 		Name:     "account",
 		Usage:    "Manage accounts",
@@ -72,7 +108,69 @@ Update an existing account.`,
 				Description: `
     neb account import <keyfile>
 
-Imports an encrypted private key from <keyfile> and creates a new account.`,
+Imports an encrypted private key from <keyfile> and creates a new account.
+The keyfile can also be an ethereum keystore v3 file.`,
+			},
+			{
+				Name:      "export-v3",
+				Usage:     "Export an account to an ethereum keystore v3 file",
+				Action:    MergeFlags(accountExportV3),
+				ArgsUsage: "<address> <keyFile>",
+				Description: `
+    neb account export-v3 <address> <keyfile>
+
+Exports the encrypted private key of the account to <keyfile> in the ethereum
+keystore v3 format, locked with the same passphrase.`,
+			},
+			{
+				Name:   "new-mnemonic",
+				Usage:  "Generate a new mnemonic to back up hd accounts",
+				Action: accountNewMnemonic,
+				Description: `
+    neb account new-mnemonic
+
+Prints a new 24 words BIP-39 mnemonic. Keep it safe, all the accounts derived
+from it can be recovered with the mnemonic only.`,
+			},
+			{
+				Name:      "import-mnemonic",
+				Usage:     "Derive an account from a mnemonic",
+				Action:    MergeFlags(accountImportMnemonic),
+				ArgsUsage: "[path]",
+				Description: `
+    neb account import-mnemonic [path]
+
+Derives the private key at the BIP-44 path from a mnemonic and creates a new
+account. The path defaults to m/44'/2718'/0'/0/0.`,
+			},
+			{
+				Name:      "split",
+				Usage:     "Split the key of an account into threshold shares",
+				Action:    MergeFlags(accountSplit),
+				ArgsUsage: "<address> <threshold> <count> <dir>",
+				Description: `
+    neb account split <address> <threshold> <count> <dir>
+
+Splits the secp256k1 key of the account into <count> shares, each encrypted by
+its own passphrase into <dir>/share-<index>.json, with the commitments of the
+shares in <dir>/commitments.json. Any 2*<threshold>-1 holders of the shares
+sign together without recovering the key, see threshold_holders of the chain
+config. Remove the key file of the account once the shares are kept.`,
+			},
+			{
+				Name:      "serve-share",
+				Usage:     "Serve a threshold share to the node over https",
+				Action:    MergeFlags(accountServeShare),
+				ArgsUsage: "<shareFile>",
+				Flags:     []cli.Flag{shareListenFlag, shareCertFlag, shareKeyFlag, shareClientCAFlag, shareTokenFlag},
+				Description: `
+    neb account serve-share --cert <certFile> --key <keyFile> [--listen <addr>] (--token <token> | --client-ca <caFile>) <shareFile>
+
+Decrypts the share written by "neb account split" and serves the signing
+rounds of the node at the https listen address. The node is authenticated by
+the token or by a client certificate of the CA, one of them is required. The
+holder signs only the blocks proposed by the account in the current slot of
+the chain in the config, once per slot, and evaluates its VRF once per slot.`,
 			},
 		},
 	}
@@ -163,6 +261,187 @@ func accountImport(ctx *cli.Context) error {
 	return nil
 }
 
+// accountExportV3 export ethereum keystore v3 file
+func accountExportV3(ctx *cli.Context) error {
+	if len(ctx.Args()) != 2 {
+		FatalF("address and keyfile must be given as argument")
+	}
+	addr, err := core.AddressParse(ctx.Args().Get(0))
+	if err != nil {
+		FatalF("address parse failed:%s", err)
+	}
+
+	neb, err := makeNeb(ctx)
+	if err != nil {
+		return err
+	}
+
+	passphrase := getPassPhrase("", false)
+	keyJSON, err := neb.AccountManager().ExportV3(addr, []byte(passphrase))
+	if err != nil {
+		FatalF("key export failed:%s", err)
+	}
+	if err := ioutil.WriteFile(ctx.Args().Get(1), keyJSON, 0600); err != nil {
+		FatalF("file write failed:%s", err)
+	}
+	fmt.Printf("Export address: %s\n", addr.String())
+	return nil
+}
+
+// accountNewMnemonic generate a mnemonic
+func accountNewMnemonic(ctx *cli.Context) error {
+	entropy, err := hd.NewEntropy(hd.DefaultEntropyBits)
+	if err != nil {
+		return err
+	}
+	mnemonic, err := hd.NewMnemonic(entropy)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Mnemonic: %s\n", mnemonic)
+	return nil
+}
+
+// accountImportMnemonic derive an account from mnemonic
+func accountImportMnemonic(ctx *cli.Context) error {
+	path := ctx.Args().First()
+	if len(path) == 0 {
+		path = hd.DefaultPath
+	}
+
+	neb, err := makeNeb(ctx)
+	if err != nil {
+		return err
+	}
+
+	mnemonic, err := console.Stdin.PromptPassphrase("Mnemonic: ")
+	if err != nil {
+		FatalF("Failed to read mnemonic: %v", err)
+	}
+	mnemonicPassphrase := getPassPhrase("Please input the passphrase of the mnemonic, empty if none.", false)
+	passphrase := getPassPhrase("Your new account is locked with a passphrase. Please give a passphrase. Do not forget this passphrase.", true)
+
+	addr, err := neb.AccountManager().ImportMnemonic(mnemonic, mnemonicPassphrase, path, []byte(passphrase))
+	if err != nil {
+		FatalF("mnemonic import failed:%s", err)
+	}
+	fmt.Printf("Import address: %s\n", addr.String())
+	return nil
+}
+
+// accountSplit split the key of an account into share files
+func accountSplit(ctx *cli.Context) error {
+	if len(ctx.Args()) != 4 {
+		FatalF("address, threshold, count and dir must be given as argument")
+	}
+	addr, err := core.AddressParse(ctx.Args().Get(0))
+	if err != nil {
+		FatalF("address parse failed:%s", err)
+	}
+	t, err := strconv.Atoi(ctx.Args().Get(1))
+	if err != nil {
+		FatalF("threshold parse failed:%s", err)
+	}
+	n, err := strconv.Atoi(ctx.Args().Get(2))
+	if err != nil {
+		FatalF("count parse failed:%s", err)
+	}
+	dir := ctx.Args().Get(3)
+
+	neb, err := makeNeb(ctx)
+	if err != nil {
+		return err
+	}
+
+	passphrase := getPassPhrase("Please input the passphrase of the account", false)
+	shares, commitments, err := neb.AccountManager().Split(addr, []byte(passphrase), t, n)
+	if err != nil {
+		FatalF("key split failed:%s", err)
+	}
+	defer func() {
+		for _, share := range shares {
+			utils.ZeroBytes(share.Value)
+		}
+	}()
+
+	for _, share := range shares {
+		sharePassphrase := getPassPhrase(fmt.Sprintf("Please give a passphrase of share %d for its holder.", share.Index), true)
+		data, err := threshold.EncryptShare(share, commitments, []byte(sharePassphrase))
+		if err != nil {
+			FatalF("share encrypt failed:%s", err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, fmt.Sprintf("share-%d.json", share.Index)), data, 0600); err != nil {
+			FatalF("file write failed:%s", err)
+		}
+	}
+	data, err := threshold.MarshalCommitments(commitments)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "commitments.json"), data, 0644); err != nil {
+		FatalF("file write failed:%s", err)
+	}
+	fmt.Printf("Split address: %s into %d shares, %d holders sign\n", addr.String(), n, 2*t-1)
+	return nil
+}
+
+// accountServeShare serve a share to the node over https
+func accountServeShare(ctx *cli.Context) error {
+	shareFile := ctx.Args().First()
+	if len(shareFile) == 0 {
+		FatalF("shareFile must be given as argument")
+	}
+	if len(ctx.String(shareCertFlag.Name)) == 0 || len(ctx.String(shareKeyFlag.Name)) == 0 {
+		FatalF("tls certificate and key files must be given")
+	}
+	if len(ctx.String(shareTokenFlag.Name)) == 0 && len(ctx.String(shareClientCAFlag.Name)) == 0 {
+		FatalF("token or client CA file must be given to authenticate the node")
+	}
+	data, err := ioutil.ReadFile(shareFile)
+	if err != nil {
+		FatalF("file read failed:%s", err)
+	}
+
+	passphrase := getPassPhrase("", false)
+	share, commitments, err := threshold.DecryptShare(data, []byte(passphrase))
+	if err != nil {
+		FatalF("share decrypt failed:%s", err)
+	}
+	validator, err := core.NewAddressFromPublicKey(commitments[0])
+	if err != nil {
+		FatalF("commitments parse failed:%s", err)
+	}
+	conf := neblet.LoadConfig(config)
+	chainConfig(ctx, conf.Chain)
+	policy := dpos.NewHolderPolicy(validator, conf.Chain.ChainId)
+	holder, err := threshold.NewLocalHolder(share, commitments, policy.Approve)
+	if err != nil {
+		FatalF("share load failed:%s", err)
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caFile := ctx.String(shareClientCAFlag.Name); len(caFile) > 0 {
+		ca, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			FatalF("file read failed:%s", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			FatalF("invalid client CA file:%s", caFile)
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	server := &http.Server{
+		Addr:      ctx.String(shareListenFlag.Name),
+		Handler:   threshold.NewHolderServer(holder, ctx.String(shareTokenFlag.Name)),
+		TLSConfig: tlsConfig,
+	}
+	fmt.Printf("Serving share %d of %s at %s\n", share.Index, validator.String(), server.Addr)
+	return server.ListenAndServeTLS(ctx.String(shareCertFlag.Name), ctx.String(shareKeyFlag.Name))
+}
+
 // getPassPhrase get passphrase from consle
 func getPassPhrase(prompt string, confirmation bool) string {
 	if prompt != "" {
@@ -19,13 +19,16 @@
 package main
 
 import (
+	"bufio"
 	"fmt"
+	"os"
 	"strconv"
 
 	"bytes"
 	"encoding/json"
 
 	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
 	"github.com/urfave/cli"
 )
 
@@ -67,6 +70,85 @@ Dump the genesis config info.`,
 		Description: `
 Use "./neb dump 10" to dump 10 blocks before tail block.`,
 	}
+
+	txIndexCommand = cli.Command{
+		Name:     "txindex",
+		Usage:    "Manage the transaction index by account address",
+		Category: "BLOCKCHAIN COMMANDS",
+		Description: `
+Manage the transaction index by account address.`,
+		Subcommands: []cli.Command{
+			{
+				Name:   "backfill",
+				Usage:  "Index the transactions in blocks which are committed before the index enabled",
+				Action: MergeFlags(backfillTxIndex),
+				Description: `
+    neb txindex backfill
+
+Index the transactions on canonical chain from the indexed height to the tail block.`,
+			},
+		},
+	}
+
+	snapshotCommand = cli.Command{
+		Name:     "snapshot",
+		Usage:    "Export or import the state snapshot of a block",
+		Category: "BLOCKCHAIN COMMANDS",
+		Description: `
+Export the state of a block into a snapshot file, or start a fresh node from one.`,
+		Subcommands: []cli.Command{
+			{
+				Name:      "export",
+				Usage:     "Export the state of the canonical block at the height",
+				ArgsUsage: "<height> <snapshotPath>",
+				Action:    MergeFlags(exportSnapshot),
+				Description: `
+    neb snapshot export 1000 snapshot.dat
+
+Export the state of the block at height 1000 into snapshot.dat.`,
+			},
+			{
+				Name:      "import",
+				Usage:     "Start a fresh node from the state snapshot",
+				ArgsUsage: "<snapshotPath> <trustedBlockHash>",
+				Action:    MergeFlags(importSnapshot),
+				Description: `
+    neb snapshot import snapshot.dat 5a4ddb53...
+
+Import the snapshot if its block is the trusted one, the node syncs from the block then.`,
+			},
+		},
+	}
+
+	chainCommand = cli.Command{
+		Name:     "chain",
+		Usage:    "Export or import the blocks of the chain",
+		Category: "BLOCKCHAIN COMMANDS",
+		Description: `
+Export the canonical blocks into a portable block file, or replay one onto the chain.`,
+		Subcommands: []cli.Command{
+			{
+				Name:      "export",
+				Usage:     "Export the canonical blocks in the height range",
+				ArgsUsage: "<from> <to> <exportPath>",
+				Action:    MergeFlags(exportChain),
+				Description: `
+    neb chain export 1 10000 chain.dat
+
+Export the blocks from height 1 to 10000 into chain.dat, to 0 means the tail block.`,
+			},
+			{
+				Name:      "import",
+				Usage:     "Verify and push the blocks in the block file onto the chain",
+				ArgsUsage: "<exportPath>",
+				Action:    MergeFlags(importChain),
+				Description: `
+    neb chain import chain.dat
+
+Import the blocks in chain.dat, the ones already on chain are skipped.`,
+			},
+		},
+	}
 )
 
 func initGenesis(ctx *cli.Context) error {
@@ -124,3 +206,149 @@ func dumpblock(ctx *cli.Context) error {
 	fmt.Printf("blockchain dump: %s\n", neb.BlockChain().Dump(count))
 	return nil
 }
+
+func backfillTxIndex(ctx *cli.Context) error {
+	neb, err := makeNeb(ctx)
+	if err != nil {
+		return err
+	}
+
+	neb.Setup()
+
+	count, err := neb.BlockChain().BackfillTransactionIndex()
+	if err != nil {
+		FatalF("backfill transaction index failed: %v", err)
+	}
+	height, _ := neb.BlockChain().TransactionIndexHeight()
+	fmt.Printf("transaction index backfilled: %d blocks, indexed height %d\n", count, height)
+	return nil
+}
+
+func exportSnapshot(ctx *cli.Context) error {
+	height, err := strconv.ParseUint(ctx.Args().Get(0), 10, 64)
+	if err != nil {
+		return err
+	}
+	path := ctx.Args().Get(1)
+	if len(path) == 0 {
+		FatalF("snapshot path is required")
+	}
+
+	neb, err := makeNeb(ctx)
+	if err != nil {
+		return err
+	}
+
+	neb.Setup()
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	w := bufio.NewWriter(file)
+	block, err := neb.BlockChain().ExportSnapshot(w, height)
+	if err != nil {
+		FatalF("export snapshot failed: %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		FatalF("export snapshot failed: %v", err)
+	}
+	fmt.Printf("snapshot exported: block %s, height %d\n", block.Hash().Hex(), block.Height())
+	return nil
+}
+
+func importSnapshot(ctx *cli.Context) error {
+	path := ctx.Args().Get(0)
+	trusted, err := byteutils.FromHex(ctx.Args().Get(1))
+	if err != nil || len(trusted) == 0 {
+		FatalF("trusted block hash is required")
+	}
+
+	neb, err := makeNeb(ctx)
+	if err != nil {
+		return err
+	}
+
+	neb.Setup()
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	block, err := neb.BlockChain().ImportSnapshot(bufio.NewReader(file), trusted)
+	if err != nil {
+		FatalF("import snapshot failed: %v", err)
+	}
+	fmt.Printf("snapshot imported: block %s, height %d\n", block.Hash().Hex(), block.Height())
+	return nil
+}
+
+func exportChain(ctx *cli.Context) error {
+	from, err := strconv.ParseUint(ctx.Args().Get(0), 10, 64)
+	if err != nil {
+		return err
+	}
+	to, err := strconv.ParseUint(ctx.Args().Get(1), 10, 64)
+	if err != nil {
+		return err
+	}
+	path := ctx.Args().Get(2)
+	if len(path) == 0 {
+		FatalF("export path is required")
+	}
+
+	neb, err := makeNeb(ctx)
+	if err != nil {
+		return err
+	}
+
+	neb.Setup()
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	w := bufio.NewWriter(file)
+	if err := neb.BlockChain().Export(w, from, to); err != nil {
+		FatalF("export chain failed: %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		FatalF("export chain failed: %v", err)
+	}
+	fmt.Printf("chain exported: %s\n", path)
+	return nil
+}
+
+func importChain(ctx *cli.Context) error {
+	path := ctx.Args().First()
+	if len(path) == 0 {
+		FatalF("export path is required")
+	}
+
+	neb, err := makeNeb(ctx)
+	if err != nil {
+		return err
+	}
+
+	neb.Setup()
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	count, err := neb.BlockChain().Import(bufio.NewReader(file))
+	if err != nil {
+		FatalF("import chain failed after %d blocks: %v", count, err)
+	}
+	tail := neb.BlockChain().TailBlock()
+	fmt.Printf("chain imported: %d blocks, tail %s, height %d\n", count, tail.Hash().Hex(), tail.Height())
+	return nil
+}
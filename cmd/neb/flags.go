@@ -41,7 +41,7 @@ var (
 	// NetworkListenFlag network listen
 	NetworkListenFlag = cli.StringSliceFlag{
 		Name:  "network.listen",
-		Usage: "network listen addresses, multi-value support.",
+		Usage: "network listen addresses (host:port or multiaddr), multi-value support.",
 	}
 
 	// NetworkKeyPathFlag network key
@@ -205,6 +205,31 @@ var (
 		AppMemProfile,
 	}
 
+	// DevFlag run a dev chain
+	DevFlag = cli.BoolFlag{
+		Name:  "dev",
+		Usage: "run a local dev chain sealing blocks as soon as transactions arrive",
+	}
+
+	// DevPeriodFlag dev block period
+	DevPeriodFlag = cli.UintFlag{
+		Name:  "dev.period",
+		Usage: "dev chain seals a block every `SECONDS`, 0 to seal only when transactions arrive",
+	}
+
+	// DevAccountsFlag dev pre-funded accounts
+	DevAccountsFlag = cli.StringSliceFlag{
+		Name:  "dev.accounts",
+		Usage: "dev chain funds the accounts in genesis",
+	}
+
+	// DevFlags dev config list
+	DevFlags = []cli.Flag{
+		DevFlag,
+		DevPeriodFlag,
+		DevAccountsFlag,
+	}
+
 	// StatsEnableFlag stats enable
 	StatsEnableFlag = cli.BoolFlag{
 		Name:  "stats.enable",
@@ -287,6 +312,18 @@ func chainConfig(ctx *cli.Context, cfg *nebletpb.ChainConfig) {
 	}
 }
 
+func devConfig(ctx *cli.Context, cfg *nebletpb.ChainConfig) {
+	if ctx.GlobalIsSet(DevFlag.Name) {
+		cfg.Dev = ctx.GlobalBool(DevFlag.Name)
+	}
+	if ctx.GlobalIsSet(DevPeriodFlag.Name) {
+		cfg.DevPeriod = uint32(ctx.GlobalUint(DevPeriodFlag.Name))
+	}
+	if ctx.GlobalIsSet(DevAccountsFlag.Name) {
+		cfg.DevAccounts = ctx.GlobalStringSlice(DevAccountsFlag.Name)
+	}
+}
+
 func rpcConfig(ctx *cli.Context, cfg *nebletpb.RPCConfig) {
 	if ctx.GlobalIsSet(RPCListenFlag.Name) {
 		cfg.RpcListen = ctx.GlobalStringSlice(RPCListenFlag.Name)
@@ -29,6 +29,7 @@ import (
 
 	"github.com/nebulasio/go-nebulas/core"
 	"github.com/nebulasio/go-nebulas/neblet"
+	"github.com/nebulasio/go-nebulas/neblet/pb"
 	"github.com/nebulasio/go-nebulas/util/logging"
 	"github.com/urfave/cli"
 )
@@ -58,6 +59,7 @@ func main() {
 	app.Flags = append(app.Flags, RPCFlags...)
 	app.Flags = append(app.Flags, AppFlags...)
 	app.Flags = append(app.Flags, StatsFlags...)
+	app.Flags = append(app.Flags, DevFlags...)
 
 	sort.Sort(cli.FlagsByName(app.Flags))
 
@@ -71,6 +73,11 @@ func main() {
 		licenseCommand,
 		configCommand,
 		blockDumpCommand,
+		txIndexCommand,
+		snapshotCommand,
+		chainCommand,
+		storageCommand,
+		dbCommand,
 	}
 	sort.Sort(cli.CommandsByName(app.Commands))
 
@@ -126,12 +133,19 @@ func runNeb(ctx *cli.Context, n *neblet.Neblet) chan bool {
 }
 
 func makeNeb(ctx *cli.Context) (*neblet.Neblet, error) {
-	conf := neblet.LoadConfig(config)
+	var conf *nebletpb.Config
+	if ctx.GlobalBool(DevFlag.Name) && !ctx.GlobalIsSet("config") {
+		// a dev chain needs no config file.
+		conf = neblet.DevConfig()
+	} else {
+		conf = neblet.LoadConfig(config)
+	}
 	conf.App.Version = version
 
 	// load config from cli args
 	networkConfig(ctx, conf.Network)
 	chainConfig(ctx, conf.Chain)
+	devConfig(ctx, conf.Chain)
 	rpcConfig(ctx, conf.Rpc)
 	appConfig(ctx, conf.App)
 	statsConfig(ctx, conf.Stats)
@@ -0,0 +1,191 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/urfave/cli"
+)
+
+var (
+	storageCommand = cli.Command{
+		Name:     "storage",
+		Usage:    "Manage the storage of the node",
+		Category: "BLOCKCHAIN COMMANDS",
+		Description: `
+Manage the layout of the storage in the data directory.`,
+		Subcommands: []cli.Command{
+			{
+				Name:   "migrate",
+				Usage:  "Move the keys written before chain namespaces into the namespace of the configured chain",
+				Action: MergeFlags(migrateStorage),
+				Description: `
+    neb storage migrate
+
+Move every key of the data directory into the namespace of the configured chain id,
+the node must be stopped while migrating.`,
+			},
+			{
+				Name:      "convert",
+				Usage:     "Copy the storage into a new data directory of another backend",
+				ArgsUsage: "<backend> <datadir>",
+				Action:    MergeFlags(convertStorage),
+				Description: `
+    neb storage convert leveldb data.db.leveldb
+
+Copy every entry of the configured storage into a new leveldb data directory,
+then point datadir and storage_backend of the chain config at it.`,
+			},
+		},
+	}
+
+	dbRepairFlag = cli.BoolFlag{
+		Name:  "repair",
+		Usage: "truncate the chain back to the last consistent block",
+	}
+
+	dbCommand = cli.Command{
+		Name:     "db",
+		Usage:    "Check the chain database",
+		Category: "BLOCKCHAIN COMMANDS",
+		Description: `
+Check the chain database in the data directory.`,
+		Subcommands: []cli.Command{
+			{
+				Name:   "verify",
+				Usage:  "Walk the chain cross-checking blocks, receipts and state roots",
+				Action: MergeFlags(verifyDB),
+				Flags:  []cli.Flag{dbRepairFlag},
+				Description: `
+    neb db verify [--repair]
+
+Walk the blocks on the height index, cross-check their hashes and links, receipts
+and state roots, and report the inconsistencies found. With --repair the chain is
+truncated back to the last consistent block, the node must be stopped meanwhile.`,
+			},
+		},
+	}
+)
+
+func migrateStorage(ctx *cli.Context) error {
+	neb, err := makeNeb(ctx)
+	if err != nil {
+		return err
+	}
+
+	// open the storage directly, neblet setup refuses un-namespaced storage.
+	conf := neb.Config().Chain
+	db, err := storage.OpenBackend(conf.StorageBackend, conf.Datadir)
+	if err != nil {
+		FatalF("open storage failed: %v", err)
+	}
+	defer db.Close()
+
+	moved, err := storage.MigrateToChainStorage(db, conf.ChainId)
+	if err != nil {
+		FatalF("migrate storage failed: %v", err)
+	}
+	fmt.Printf("storage migrated: %d keys moved into chain %d\n", moved, conf.ChainId)
+	return nil
+}
+
+func convertStorage(ctx *cli.Context) error {
+	backend := ctx.Args().Get(0)
+	path := ctx.Args().Get(1)
+	if len(backend) == 0 || len(path) == 0 {
+		FatalF("backend and datadir are required")
+	}
+
+	neb, err := makeNeb(ctx)
+	if err != nil {
+		return err
+	}
+
+	conf := neb.Config().Chain
+	if path == conf.Datadir {
+		FatalF("convert storage into the datadir in use")
+	}
+	src, err := storage.OpenBackend(conf.StorageBackend, conf.Datadir)
+	if err != nil {
+		FatalF("open storage failed: %v", err)
+	}
+	defer src.Close()
+
+	dst, err := storage.OpenBackend(backend, path)
+	if err != nil {
+		FatalF("open %s storage failed: %v", backend, err)
+	}
+	defer dst.Close()
+
+	copied, err := storage.CopyBackend(src, dst)
+	if err != nil {
+		FatalF("convert storage failed: %v", err)
+	}
+	fmt.Printf("storage converted: %d entries copied into %s\n", copied, path)
+	return nil
+}
+
+func verifyDB(ctx *cli.Context) error {
+	neb, err := makeNeb(ctx)
+	if err != nil {
+		return err
+	}
+
+	conf := neb.Config().Chain
+	db, err := storage.OpenBackend(conf.StorageBackend, conf.Datadir)
+	if err != nil {
+		FatalF("open storage failed: %v", err)
+	}
+	defer db.Close()
+
+	var freezer *storage.Freezer
+	if conf.FreezerThreshold > 0 {
+		dir := conf.FreezerDir
+		if len(dir) == 0 {
+			dir = conf.Datadir + core.FreezerDirSuffix
+		}
+		if freezer, err = storage.NewFreezer(dir); err != nil {
+			FatalF("open freezer failed: %v", err)
+		}
+		defer freezer.Close()
+	}
+
+	verifier := core.NewDBVerifier(storage.NewChainStorage(db, conf.ChainId), freezer)
+	report, err := verifier.Verify()
+	if err != nil {
+		FatalF("verify db failed: %v", err)
+	}
+	for _, issue := range report.Issues {
+		fmt.Println(issue)
+	}
+	fmt.Printf("verified blocks %d to %d, %d issues, last consistent block %d %s\n",
+		report.From, report.To, len(report.Issues), report.LastConsistentHeight, report.LastConsistentHash)
+
+	if report.Consistent() || !ctx.Bool(dbRepairFlag.Name) {
+		return nil
+	}
+	if err := verifier.Truncate(report); err != nil {
+		FatalF("truncate chain failed: %v", err)
+	}
+	fmt.Printf("chain truncated back to block %d\n", report.LastConsistentHeight)
+	return nil
+}
@@ -0,0 +1,133 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package trie
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/nebulasio/go-nebulas/metrics"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+)
+
+// Metrics for trie node cache
+var (
+	metricsNodeCacheHit  = metrics.NewCounter("neb.trie.cache.hit")
+	metricsNodeCacheMiss = metrics.NewCounter("neb.trie.cache.miss")
+	metricsNodeCacheSize = metrics.NewGauge("neb.trie.cache.size")
+)
+
+// nodeCache is shared by all the tries of the process, nil if disabled.
+var (
+	nodeCache     *NodeCache
+	nodeCacheLock sync.RWMutex
+)
+
+// SetNodeCache shares the cache among all the tries, nil disables it.
+// Nodes are addressed by the hash of their content, so tries on different
+// storages can share one cache.
+func SetNodeCache(cache *NodeCache) {
+	nodeCacheLock.Lock()
+	defer nodeCacheLock.Unlock()
+	nodeCache = cache
+}
+
+func sharedNodeCache() *NodeCache {
+	nodeCacheLock.RLock()
+	defer nodeCacheLock.RUnlock()
+	return nodeCache
+}
+
+type cachedNode struct {
+	key   string
+	bytes []byte
+}
+
+// NodeCache is a LRU cache of encoded trie nodes bounded by their total size.
+type NodeCache struct {
+	mu       sync.Mutex
+	capacity int
+	size     int
+	ll       *list.List
+	items    map[string]*list.Element
+}
+
+// NewNodeCache create a cache holding at most capacity bytes of nodes.
+func NewNodeCache(capacity int) *NodeCache {
+	return &NodeCache{
+		capacity: capacity,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+// Get returns the encoded node of the hash.
+func (c *NodeCache) Get(hash []byte) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.items[byteutils.Hex(hash)]; ok {
+		c.ll.MoveToFront(e)
+		metricsNodeCacheHit.Inc(1)
+		return e.Value.(*cachedNode).bytes, true
+	}
+	metricsNodeCacheMiss.Inc(1)
+	return nil, false
+}
+
+// Add caches the encoded node of the hash, evicting the least recently used ones beyond the capacity.
+func (c *NodeCache) Add(hash []byte, bytes []byte) {
+	if len(bytes) > c.capacity {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := byteutils.Hex(hash)
+	if e, ok := c.items[key]; ok {
+		c.ll.MoveToFront(e)
+		return
+	}
+	c.items[key] = c.ll.PushFront(&cachedNode{key: key, bytes: bytes})
+	c.size += len(bytes)
+
+	for c.size > c.capacity {
+		e := c.ll.Back()
+		n := e.Value.(*cachedNode)
+		c.ll.Remove(e)
+		delete(c.items, n.key)
+		c.size -= len(n.bytes)
+	}
+	metricsNodeCacheSize.Update(int64(c.size))
+}
+
+// Len returns the number of the cached nodes.
+func (c *NodeCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ll.Len()
+}
+
+// Size returns the total size of the cached nodes in bytes.
+func (c *NodeCache) Size() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.size
+}
@@ -0,0 +1,73 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package trie
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodeCacheEviction(t *testing.T) {
+	c := NewNodeCache(10)
+	c.Add([]byte("a"), []byte("1234"))
+	c.Add([]byte("b"), []byte("1234"))
+	assert.Equal(t, 2, c.Len())
+	assert.Equal(t, 8, c.Size())
+
+	// a is used recently, b is evicted.
+	_, ok := c.Get([]byte("a"))
+	assert.True(t, ok)
+	c.Add([]byte("c"), []byte("1234"))
+	assert.Equal(t, 2, c.Len())
+	_, ok = c.Get([]byte("b"))
+	assert.False(t, ok)
+	_, ok = c.Get([]byte("a"))
+	assert.True(t, ok)
+
+	// nodes beyond the capacity are not cached.
+	c.Add([]byte("d"), []byte("12345678901"))
+	_, ok = c.Get([]byte("d"))
+	assert.False(t, ok)
+	assert.Equal(t, 8, c.Size())
+}
+
+func TestTrieNodeCache(t *testing.T) {
+	SetNodeCache(NewNodeCache(1 << 20))
+	defer SetNodeCache(nil)
+
+	stor, _ := storage.NewMemoryStorage()
+	tr, err := NewTrie(nil, stor, false)
+	assert.Nil(t, err)
+	key := hash.Sha3256([]byte("key"))
+	_, err = tr.Put(key, []byte("value"))
+	assert.Nil(t, err)
+	assert.True(t, sharedNodeCache().Len() > 0)
+
+	// the nodes are served by the cache once they are gone from storage.
+	empty, _ := storage.NewMemoryStorage()
+	assert.Nil(t, empty.Put(tr.RootHash(), []byte{}))
+	cached, err := NewTrie(tr.RootHash(), empty, false)
+	assert.Nil(t, err)
+	value, err := cached.Get(key)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("value"), value)
+}
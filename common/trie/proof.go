@@ -21,8 +21,13 @@ package trie
 import (
 	"bytes"
 	"errors"
+
+	"github.com/nebulasio/go-nebulas/storage"
 )
 
+// ErrInvalidProof the proof does not prove the value of the key
+var ErrInvalidProof = errors.New("invalid merkle proof")
+
 // MerkleProof is a path from root to the proved node
 // every element in path is the value of a node
 type MerkleProof [][][]byte
@@ -119,3 +124,23 @@ func (t *Trie) Verify(rootHash []byte, key []byte, proof MerkleProof) error {
 	}
 	return nil
 }
+
+// VerifyValue verify the merkle proof from root to the key, and that the value of the key is the given one
+func VerifyValue(rootHash []byte, key []byte, value []byte, proof MerkleProof) error {
+	if len(proof) == 0 {
+		return ErrInvalidProof
+	}
+	stor, err := storage.NewMemoryStorage()
+	if err != nil {
+		return err
+	}
+	t := &Trie{storage: stor}
+	if err := t.Verify(rootHash, key, proof); err != nil {
+		return err
+	}
+	last := proof[len(proof)-1]
+	if len(last) != 3 || len(last[0]) == 0 || last[0][0] != byte(leaf) || !bytes.Equal(last[2], value) {
+		return ErrInvalidProof
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package trie
+
+import (
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+)
+
+// LeafResolver return the roots of sub-tries referred by a leaf value,
+// e.g. the variables trie of an account in accounts trie.
+type LeafResolver func(val []byte) ([][]byte, error)
+
+// RefCounter counts the references to trie nodes from their parents and from retained roots,
+// a node is deleted from storage once nothing refers to it any more.
+type RefCounter struct {
+	storage storage.Storage
+	prefix  []byte
+}
+
+type nodeRef struct {
+	hash    []byte
+	resolve LeafResolver
+}
+
+// NewRefCounter create a RefCounter keeping counts in storage under the prefix
+func NewRefCounter(storage storage.Storage, prefix []byte) *RefCounter {
+	return &RefCounter{
+		storage: storage,
+		prefix:  prefix,
+	}
+}
+
+func (rc *RefCounter) refKey(hash []byte) []byte {
+	key := make([]byte, 0, len(rc.prefix)+len(hash))
+	key = append(key, rc.prefix...)
+	return append(key, hash...)
+}
+
+// Count return the number of references to the node
+func (rc *RefCounter) Count(hash []byte) (uint64, error) {
+	value, err := rc.storage.Get(rc.refKey(hash))
+	if err == storage.ErrKeyNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return byteutils.Uint64(value), nil
+}
+
+func (rc *RefCounter) setCount(hash []byte, count uint64) error {
+	if count == 0 {
+		return rc.storage.Del(rc.refKey(hash))
+	}
+	return rc.storage.Put(rc.refKey(hash), byteutils.FromUint64(count))
+}
+
+// children return the nodes referred by the node, including the roots of sub-tries in leaf.
+func (rc *RefCounter) children(hash []byte, resolve LeafResolver) ([]*nodeRef, error) {
+	t := &Trie{storage: rc.storage}
+	n, err := t.fetchNode(hash)
+	if err != nil {
+		return nil, err
+	}
+	return referredNodes(n, resolve)
+}
+
+// referredNodes return the nodes referred by the node, including the roots of sub-tries in leaf.
+func referredNodes(n *node, resolve LeafResolver) ([]*nodeRef, error) {
+	flag, err := n.Type()
+	if err != nil {
+		return nil, err
+	}
+
+	refs := []*nodeRef{}
+	switch flag {
+	case branch:
+		for _, child := range n.Val {
+			if len(child) > 0 {
+				refs = append(refs, &nodeRef{child, resolve})
+			}
+		}
+	case ext:
+		refs = append(refs, &nodeRef{n.Val[2], resolve})
+	case leaf:
+		if resolve == nil {
+			break
+		}
+		roots, err := resolve(n.Val[2])
+		if err != nil {
+			return nil, err
+		}
+		for _, root := range roots {
+			if len(root) > 0 {
+				refs = append(refs, &nodeRef{root, nil})
+			}
+		}
+	}
+	return refs, nil
+}
+
+// Reference add a reference to the root, the nodes under the root
+// are referenced only when the root is referred at the first time.
+func (rc *RefCounter) Reference(root []byte, resolve LeafResolver) error {
+	if len(root) == 0 {
+		return nil
+	}
+	count, err := rc.Count(root)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		children, err := rc.children(root, resolve)
+		if err != nil {
+			return err
+		}
+		for _, child := range children {
+			if err := rc.Reference(child.hash, child.resolve); err != nil {
+				return err
+			}
+		}
+	}
+	return rc.setCount(root, count+1)
+}
+
+// Dereference remove a reference to the root, delete the nodes nothing refers
+// any more and return the number of deleted nodes. Nodes never referenced are left alone.
+func (rc *RefCounter) Dereference(root []byte, resolve LeafResolver) (int, error) {
+	if len(root) == 0 {
+		return 0, nil
+	}
+	count, err := rc.Count(root)
+	if err != nil {
+		return 0, err
+	}
+	if count == 0 {
+		return 0, nil
+	}
+	if count > 1 {
+		return 0, rc.setCount(root, count-1)
+	}
+
+	children, err := rc.children(root, resolve)
+	if err != nil {
+		return 0, err
+	}
+	deleted := 0
+	for _, child := range children {
+		n, err := rc.Dereference(child.hash, child.resolve)
+		if err != nil {
+			return deleted, err
+		}
+		deleted += n
+	}
+	if err := rc.storage.Del(root); err != nil {
+		return deleted, err
+	}
+	if err := rc.setCount(root, 0); err != nil {
+		return deleted, err
+	}
+	return deleted + 1, nil
+}
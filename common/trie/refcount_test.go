@@ -0,0 +1,82 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package trie
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRefCounter(t *testing.T) {
+	stor, _ := storage.NewMemoryStorage()
+	rc := NewRefCounter(stor, []byte("ref_"))
+
+	// a sub-trie referred by leaves in the main trie.
+	sub, _ := NewTrie(nil, stor, false)
+	subRoot, err := sub.Put([]byte{0x01, 0x02}, []byte("storage"))
+	assert.Nil(t, err)
+	resolve := func(val []byte) ([][]byte, error) {
+		if string(val) == "contract" {
+			return [][]byte{subRoot}, nil
+		}
+		return nil, nil
+	}
+
+	tr, _ := NewTrie(nil, stor, false)
+	_, err = tr.Put([]byte{0x12, 0x34}, []byte("user"))
+	assert.Nil(t, err)
+	root1, err := tr.Put([]byte{0x12, 0x56}, []byte("contract"))
+	assert.Nil(t, err)
+	assert.Nil(t, rc.Reference(root1, resolve))
+
+	root2, err := tr.Put([]byte{0x12, 0x34}, []byte("user updated"))
+	assert.Nil(t, err)
+	assert.Nil(t, rc.Reference(root2, resolve))
+
+	count, err := rc.Count(subRoot)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), count)
+
+	// release the old root, the nodes shared with the new root are kept.
+	deleted, err := rc.Dereference(root1, resolve)
+	assert.Nil(t, err)
+	assert.True(t, deleted > 0)
+	_, err = stor.Get(root1)
+	assert.Equal(t, storage.ErrKeyNotFound, err)
+
+	tr2, err := NewTrie(root2, stor, false)
+	assert.Nil(t, err)
+	val, err := tr2.Get([]byte{0x12, 0x56})
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("contract"), val)
+	val, err = sub.Get([]byte{0x01, 0x02})
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("storage"), val)
+
+	// release the last root, all nodes are deleted.
+	_, err = rc.Dereference(root2, resolve)
+	assert.Nil(t, err)
+	_, err = stor.Get(subRoot)
+	assert.Equal(t, storage.ErrKeyNotFound, err)
+	count, err = rc.Count(root2)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), count)
+}
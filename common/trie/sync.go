@@ -18,6 +18,18 @@
 
 package trie
 
+import (
+	"errors"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/common/trie/pb"
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+	"github.com/nebulasio/go-nebulas/storage"
+)
+
+// ErrUnrequestedNode is returned when a node delivered to NodeSync was not requested
+var ErrUnrequestedNode = errors.New("trie node delivered was not requested")
+
 // SyncTrie data from other servers
 // Sync whole trie to build snapshot
 func (t *Trie) SyncTrie(rootHash []byte) error {
@@ -29,3 +41,124 @@ func (t *Trie) SyncTrie(rootHash []byte) error {
 func (t *Trie) SyncPath(rootHash []byte, key []byte) error {
 	return nil
 }
+
+// NodeSync schedules the download of the nodes reachable from some roots, including
+// the nodes of sub-tries referred by leaves. Each node delivered is verified against
+// the hash it was requested by before it is stored, and its children are scheduled.
+// The nodes already in storage are not requested, their children are checked instead.
+type NodeSync struct {
+	storage   storage.Storage
+	queue     []*nodeRef
+	requested map[string]*nodeRef
+	visited   map[string]bool
+	nodes     uint64
+}
+
+// NewNodeSync create a NodeSync storing the nodes into stor
+func NewNodeSync(stor storage.Storage) *NodeSync {
+	return &NodeSync{
+		storage:   stor,
+		queue:     []*nodeRef{},
+		requested: make(map[string]*nodeRef),
+		visited:   make(map[string]bool),
+	}
+}
+
+// AddRoot schedule the nodes reachable from the root
+func (s *NodeSync) AddRoot(root []byte, resolve LeafResolver) {
+	if len(root) > 0 {
+		s.queue = append(s.queue, &nodeRef{root, resolve})
+	}
+}
+
+// Missing return the hashes of at most max nodes to request, which are pending
+// until they are delivered to Process or given back by Retry.
+func (s *NodeSync) Missing(max int) ([][]byte, error) {
+	hashes := [][]byte{}
+	for len(s.queue) > 0 && len(hashes) < max {
+		ref := s.queue[len(s.queue)-1]
+		s.queue = s.queue[:len(s.queue)-1]
+		key := string(ref.hash)
+		if s.visited[key] || s.requested[key] != nil {
+			continue
+		}
+
+		bytes, err := s.storage.Get(ref.hash)
+		if err == storage.ErrKeyNotFound {
+			s.requested[key] = ref
+			hashes = append(hashes, ref.hash)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := s.expand(ref, bytes); err != nil {
+			return nil, err
+		}
+	}
+	return hashes, nil
+}
+
+// Process verify and store a node requested, and schedule its children
+func (s *NodeSync) Process(bytes []byte) error {
+	h := hash.Sha3256(bytes)
+	ref, ok := s.requested[string(h)]
+	if !ok {
+		return ErrUnrequestedNode
+	}
+	if err := s.expand(ref, bytes); err != nil {
+		return err
+	}
+	if err := s.storage.Put(h, bytes); err != nil {
+		return err
+	}
+	delete(s.requested, string(h))
+	s.nodes++
+	return nil
+}
+
+// Retry schedule again the nodes requested but not delivered
+func (s *NodeSync) Retry(hashes [][]byte) {
+	for _, h := range hashes {
+		if ref, ok := s.requested[string(h)]; ok {
+			delete(s.requested, string(h))
+			s.queue = append(s.queue, ref)
+		}
+	}
+}
+
+// Pending return the number of nodes scheduled or requested
+func (s *NodeSync) Pending() int {
+	return len(s.queue) + len(s.requested)
+}
+
+// Nodes return the number of nodes downloaded
+func (s *NodeSync) Nodes() uint64 {
+	return s.nodes
+}
+
+func (s *NodeSync) expand(ref *nodeRef, bytes []byte) error {
+	n, err := decodeNode(bytes)
+	if err != nil {
+		return err
+	}
+	children, err := referredNodes(n, ref.resolve)
+	if err != nil {
+		return err
+	}
+	s.visited[string(ref.hash)] = true
+	s.queue = append(s.queue, children...)
+	return nil
+}
+
+func decodeNode(bytes []byte) (*node, error) {
+	pb := new(triepb.Node)
+	if err := proto.Unmarshal(bytes, pb); err != nil {
+		return nil, err
+	}
+	n := new(node)
+	if err := n.FromProto(pb); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
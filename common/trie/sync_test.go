@@ -0,0 +1,99 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package trie
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodeSync(t *testing.T) {
+	stor, _ := storage.NewMemoryStorage()
+
+	sub, _ := NewTrie(nil, stor, false)
+	subRoot, err := sub.Put([]byte{0x01, 0x02}, []byte("storage"))
+	assert.Nil(t, err)
+	resolve := func(val []byte) ([][]byte, error) {
+		if string(val) == "contract" {
+			return [][]byte{subRoot}, nil
+		}
+		return nil, nil
+	}
+
+	tr, _ := NewTrie(nil, stor, false)
+	_, err = tr.Put([]byte{0x12, 0x34}, []byte("user"))
+	assert.Nil(t, err)
+	_, err = tr.Put([]byte{0x12, 0x56}, []byte("contract"))
+	assert.Nil(t, err)
+	root, err := tr.Put([]byte{0x45, 0x67}, []byte("user"))
+	assert.Nil(t, err)
+
+	synced, _ := storage.NewMemoryStorage()
+	s := NewNodeSync(synced)
+	s.AddRoot(root, resolve)
+
+	// the nodes not requested are rejected.
+	assert.Equal(t, ErrUnrequestedNode, s.Process([]byte("node")))
+
+	// the nodes given back are requested again.
+	hashes, err := s.Missing(16)
+	assert.Nil(t, err)
+	assert.Equal(t, [][]byte{root}, hashes)
+	s.Retry(hashes)
+	assert.Equal(t, 1, s.Pending())
+
+	for s.Pending() > 0 {
+		hashes, err := s.Missing(2)
+		assert.Nil(t, err)
+		assert.True(t, len(hashes) > 0 && len(hashes) <= 2)
+		for _, h := range hashes {
+			bytes, err := stor.Get(h)
+			assert.Nil(t, err)
+			assert.Nil(t, s.Process(bytes))
+		}
+	}
+	count := 0
+	err = Walk(stor, root, resolve, make(map[string]bool), func(h []byte, bytes []byte) error {
+		count++
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(count), s.Nodes())
+
+	tr2, err := NewTrie(root, synced, false)
+	assert.Nil(t, err)
+	val, err := tr2.Get([]byte{0x45, 0x67})
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("user"), val)
+	sub2, err := NewTrie(subRoot, synced, false)
+	assert.Nil(t, err)
+	val, err = sub2.Get([]byte{0x01, 0x02})
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("storage"), val)
+
+	// the nodes in storage are not requested again.
+	s = NewNodeSync(synced)
+	s.AddRoot(root, resolve)
+	hashes, err = s.Missing(16)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(hashes))
+	assert.Equal(t, 0, s.Pending())
+}
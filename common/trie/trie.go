@@ -134,10 +134,22 @@ func (t *Trie) createNode(val [][]byte) (*node, error) {
 
 // FetchNode in trie
 func (t *Trie) fetchNode(hash []byte) (*node, error) {
-	ir, err := t.storage.Get(hash)
+	cache := sharedNodeCache()
 
-	if err != nil {
-		return nil, err
+	var ir []byte
+	var ok bool
+	if cache != nil {
+		ir, ok = cache.Get(hash)
+	}
+	if !ok {
+		var err error
+		ir, err = t.storage.Get(hash)
+		if err != nil {
+			return nil, err
+		}
+		if cache != nil {
+			cache.Add(hash, ir)
+		}
 	}
 
 	pb := new(triepb.Node)
@@ -163,6 +175,9 @@ func (t *Trie) commitNode(n *node) error {
 	}
 	n.Hash = hash.Sha3256(n.Bytes)
 
+	if cache := sharedNodeCache(); cache != nil {
+		cache.Add(n.Hash, n.Bytes)
+	}
 	return t.storage.Put(n.Hash, n.Bytes)
 }
 
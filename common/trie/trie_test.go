@@ -683,3 +683,22 @@ func TestTrie_Iterator(t *testing.T) {
 	it, err = tr.Iterator(HashDomainsPrefix("b"))
 	assert.NotNil(t, err)
 }
+
+func TestVerifyValue(t *testing.T) {
+	stor, _ := storage.NewMemoryStorage()
+	tr, _ := NewTrie(nil, stor, false)
+	key1 := []byte{0x1f, 0x34, 0x56, 0x78}
+	key2 := []byte{0x1f, 0x35, 0x56, 0x78}
+	_, err := tr.Put(key1, []byte("value1"))
+	assert.Nil(t, err)
+	root, err := tr.Put(key2, []byte("value2"))
+	assert.Nil(t, err)
+
+	proof, err := tr.Prove(key1)
+	assert.Nil(t, err)
+	assert.Nil(t, VerifyValue(root, key1, []byte("value1"), proof))
+	assert.Equal(t, ErrInvalidProof, VerifyValue(root, key1, []byte("value2"), proof))
+	assert.Equal(t, ErrInvalidProof, VerifyValue(root, key1, []byte("value1"), proof[:len(proof)-1]))
+	assert.NotNil(t, VerifyValue(root, key2, []byte("value1"), proof))
+	assert.Equal(t, ErrInvalidProof, VerifyValue(root, key1, []byte("value1"), nil))
+}
@@ -0,0 +1,63 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package trie
+
+import (
+	"github.com/nebulasio/go-nebulas/storage"
+)
+
+// WalkFunc is called with the hash and the encoded bytes of each node visited
+type WalkFunc func(hash []byte, bytes []byte) error
+
+// Walk visit each node reachable from the root once, including the nodes of sub-tries
+// referred by leaves if resolve is given. The nodes in visited are skipped, and the ones
+// visited are added to it, so that the nodes shared by several roots are visited once.
+func Walk(stor storage.Storage, root []byte, resolve LeafResolver, visited map[string]bool, fn WalkFunc) error {
+	if len(root) == 0 {
+		return nil
+	}
+	stack := []*nodeRef{{root, resolve}}
+	for len(stack) > 0 {
+		ref := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if visited[string(ref.hash)] {
+			continue
+		}
+
+		bytes, err := stor.Get(ref.hash)
+		if err != nil {
+			return err
+		}
+		n, err := decodeNode(bytes)
+		if err != nil {
+			return err
+		}
+		children, err := referredNodes(n, ref.resolve)
+		if err != nil {
+			return err
+		}
+
+		visited[string(ref.hash)] = true
+		if err := fn(ref.hash, bytes); err != nil {
+			return err
+		}
+		stack = append(stack, children...)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package trie
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWalk(t *testing.T) {
+	stor, _ := storage.NewMemoryStorage()
+
+	sub, _ := NewTrie(nil, stor, false)
+	subRoot, err := sub.Put([]byte{0x01, 0x02}, []byte("storage"))
+	assert.Nil(t, err)
+	resolve := func(val []byte) ([][]byte, error) {
+		if string(val) == "contract" {
+			return [][]byte{subRoot}, nil
+		}
+		return nil, nil
+	}
+
+	tr, _ := NewTrie(nil, stor, false)
+	_, err = tr.Put([]byte{0x12, 0x34}, []byte("user"))
+	assert.Nil(t, err)
+	root, err := tr.Put([]byte{0x12, 0x56}, []byte("contract"))
+	assert.Nil(t, err)
+
+	// copy the reachable nodes into another storage.
+	copied, _ := storage.NewMemoryStorage()
+	visited := make(map[string]bool)
+	err = Walk(stor, root, resolve, visited, func(h []byte, bytes []byte) error {
+		assert.Equal(t, h, hash.Sha3256(bytes))
+		return copied.Put(h, bytes)
+	})
+	assert.Nil(t, err)
+	assert.True(t, visited[string(subRoot)])
+
+	tr2, err := NewTrie(root, copied, false)
+	assert.Nil(t, err)
+	val, err := tr2.Get([]byte{0x12, 0x34})
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("user"), val)
+	sub2, err := NewTrie(subRoot, copied, false)
+	assert.Nil(t, err)
+	val, err = sub2.Get([]byte{0x01, 0x02})
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("storage"), val)
+
+	// the nodes visited are skipped.
+	count := 0
+	err = Walk(stor, root, resolve, visited, func(h []byte, bytes []byte) error {
+		count++
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, count)
+
+	// the missing nodes fail the walk.
+	err = Walk(copied, []byte("missing"), nil, make(map[string]bool), func(h []byte, bytes []byte) error {
+		return nil
+	})
+	assert.Equal(t, storage.ErrKeyNotFound, err)
+}
@@ -19,12 +19,14 @@
 package dpos
 
 import (
+	"bytes"
 	"errors"
 	"time"
 
 	"github.com/nebulasio/go-nebulas/rpc"
 	"github.com/nebulasio/go-nebulas/rpc/pb"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc"
 
 	"github.com/nebulasio/go-nebulas/core/state"
 	"github.com/nebulasio/go-nebulas/crypto/keystore"
@@ -52,11 +54,14 @@ var (
 	ErrCannotMintWhenPending      = errors.New("cannot mint block now, waiting for cancel pending again")
 	ErrCannotMintWhenDisable      = errors.New("cannot mint block now, waiting for enable it again")
 	ErrWaitingBlockInLastSlot     = errors.New("cannot mint block now, waiting for last block")
+	ErrLocalClockDrifted          = errors.New("cannot mint block now, the local clock drifts from the peers")
 	ErrBlockMintedInNextSlot      = errors.New("cannot mint block now, there is a block minted in current slot")
 	ErrGenerateNextConsensusState = errors.New("Failed to generate next consensus state")
 	ErrDoubleBlockMinted          = errors.New("double block minted")
 	ErrAppendNewBlockFailed       = errors.New("failed to append new block to real chain")
 	ErrInvalidArgument            = errors.New("invalid argument")
+	ErrJailedProposer             = errors.New("the block proposer is jailed")
+	ErrInvalidDynastyChange       = errors.New("invalid change of the dynasty")
 )
 
 // Metrics
@@ -79,9 +84,13 @@ type Dpos struct {
 	miner                  *core.Address
 	enableRemoteSignServer bool
 	remoteSignServer       string
+	signer                 Signer
 
 	slot *lru.Cache
 
+	standby        []byteutils.Hash
+	maxMissedSlots uint64
+
 	enable  bool
 	pending bool
 }
@@ -124,7 +133,35 @@ func (dpos *Dpos) Setup(neblet core.Neblet) error {
 		dpos.miner = miner
 		dpos.enableRemoteSignServer = chainConfig.EnableRemoteSignServer
 		dpos.remoteSignServer = chainConfig.RemoteSignServer
+
+		coordinator, err := thresholdSignerByConfig(chainConfig)
+		if err != nil {
+			logging.CLog().WithFields(logrus.Fields{
+				"holders": chainConfig.ThresholdHolders,
+				"err":     err,
+			}).Error("Failed to connect the threshold share holders.")
+			return err
+		}
+		if coordinator != nil {
+			if err := dpos.SetSigner(coordinator); err != nil {
+				logging.CLog().WithFields(logrus.Fields{
+					"miner": chainConfig.Miner,
+					"err":   err,
+				}).Error("The threshold shares are not of the miner's key.")
+				return err
+			}
+		}
+	}
+
+	standby, maxMissedSlots, err := livenessConf(neblet.Genesis().GetConsensus().GetDpos())
+	if err != nil {
+		logging.CLog().WithFields(logrus.Fields{
+			"err": err,
+		}).Error("Failed to parse standby validators.")
+		return err
 	}
+	dpos.standby = standby
+	dpos.maxMissedSlots = maxMissedSlots
 
 	slot, err := lru.New(128)
 	if err != nil {
@@ -159,8 +196,10 @@ func (dpos *Dpos) EnableMining(passphrase string) error {
 
 // DisableMining stop the consensus
 func (dpos *Dpos) DisableMining() error {
-	if err := dpos.am.Lock(dpos.miner); err != nil {
-		return err
+	if dpos.signer == nil {
+		if err := dpos.am.Lock(dpos.miner); err != nil {
+			return err
+		}
 	}
 	dpos.enable = false
 	logging.CLog().Info("Disable Dpos Mining...")
@@ -176,6 +215,12 @@ func less(a *core.Block, b *core.Block) bool {
 	if a.Height() != b.Height() {
 		return a.Height() < b.Height()
 	}
+	// the winners of a slot compete, the lowest index wins.
+	if a.Height() >= core.VRFProposerHeight {
+		if c := bytes.Compare(proposerIndex(a), proposerIndex(b)); c != 0 {
+			return c > 0
+		}
+	}
 	return byteutils.Less(a.Hash(), b.Hash())
 }
 
@@ -323,20 +368,27 @@ func verifyBlockSign(miner *core.Address, block *core.Block) error {
 
 // CheckDoubleMint if double mint exists
 func (dpos *Dpos) CheckDoubleMint(block *core.Block) bool {
-	if preBlock, exist := dpos.slot.Get(block.Timestamp()); exist {
+	if preBlock, exist := dpos.slot.Get(slotKey(block)); exist {
 		if preBlock.(*core.Block).Hash().Equals(block.Hash()) == false {
 			logging.VLog().WithFields(logrus.Fields{
 				"curBlock": block,
 				"preBlock": preBlock.(*core.Block),
 			}).Warn("Found someone minted multiple blocks at same time.")
+			if err := dpos.chain.EvidencePool().Report(preBlock.(*core.Block), block); err != nil {
+				logging.VLog().WithFields(logrus.Fields{
+					"curBlock": block,
+					"preBlock": preBlock.(*core.Block),
+					"err":      err,
+				}).Debug("Failed to report double sign evidence.")
+			}
 			return true
 		}
 	}
 	return false
 }
 
-// VerifyBlock verify the block
-func (dpos *Dpos) VerifyBlock(block *core.Block) error {
+// VerifyHeader verify the block is minted by the proposer of its slot
+func (dpos *Dpos) VerifyHeader(block *core.Block) error {
 	tail := dpos.chain.TailBlock()
 	// check timestamp
 	if block.Timestamp() != block.ConsensusRoot().Timestamp {
@@ -355,7 +407,7 @@ func (dpos *Dpos) VerifyBlock(block *core.Block) error {
 		}).Debug("Failed to get miners from dynasty.")
 		return err
 	}
-	proposer, err := FindProposer(block.Timestamp(), miners)
+	proposer, err := proposerOf(block, miners)
 	if err != nil {
 		logging.VLog().WithFields(logrus.Fields{
 			"proposer": proposer,
@@ -378,6 +430,15 @@ func (dpos *Dpos) VerifyBlock(block *core.Block) error {
 		return err
 	}
 
+	// check the proposer is not jailed
+	if block.Height() >= core.DoubleSignEvidenceHeight && core.IsJailed(tail, proposer, block.Height()) {
+		logging.VLog().WithFields(logrus.Fields{
+			"proposer": miner,
+			"block":    block,
+		}).Debug("Found a block proposed by a jailed validator.")
+		return ErrJailedProposer
+	}
+
 	// check block random
 	if block.Height() >= core.RandomAvailableHeight && !block.HasRandomSeed() {
 		logging.VLog().WithFields(logrus.Fields{
@@ -387,7 +448,67 @@ func (dpos *Dpos) VerifyBlock(block *core.Block) error {
 		return core.ErrInvalidBlockRandom
 	}
 
-	dpos.slot.Add(block.Timestamp(), block)
+	dpos.slot.Add(slotKey(block), block)
+	return nil
+}
+
+// proposerOf return the proposer of the block's slot among the miners, scheduled by
+// the timestamp, or elected by the VRF lottery since VRFProposerHeight.
+func proposerOf(block *core.Block, miners []byteutils.Hash) (byteutils.Hash, error) {
+	if block.Height() >= core.VRFProposerHeight {
+		return verifyProposerProof(block, miners)
+	}
+	return FindProposer(block.Timestamp(), miners)
+}
+
+// VerifyProposer check the block is signed by the proposer of its slot in its dynasty,
+// for the header chains verified without states, see core.HeaderVerifier.
+func (dpos *Dpos) VerifyProposer(block *core.Block, dynasty []byteutils.Hash) error {
+	if block.Timestamp() != block.ConsensusRoot().Timestamp {
+		return ErrInvalidBlockTimestamp
+	}
+	elapsedSecondInMs := block.Timestamp() * SecondInMs
+	if elapsedSecondInMs <= 0 || (elapsedSecondInMs%BlockIntervalInMs) != 0 {
+		return ErrInvalidBlockInterval
+	}
+	proposer, err := proposerOf(block, dynasty)
+	if err != nil {
+		return err
+	}
+	miner, err := core.AddressParseFromBytes(proposer)
+	if err != nil {
+		return err
+	}
+	return verifyBlockSign(miner, block)
+}
+
+// VerifyDynasty check the dynasty of the block may follow the one of its parent, see
+// core.HeaderVerifier. Since ValidatorLivenessHeight an inactive member is replaced by
+// a standby validator of the genesis conf, the missed slots are kept in the state and
+// not checked. A dynasty elected since ProofOfDevotionHeight is unverifiable by headers.
+func (dpos *Dpos) VerifyDynasty(parent *core.Block, block *core.Block, parentDynasty []byteutils.Hash, dynasty []byteutils.Hash) error {
+	if bytes.Equal(parent.ConsensusRoot().DynastyRoot, block.ConsensusRoot().DynastyRoot) {
+		return nil
+	}
+	if block.Height() >= core.ProofOfDevotionHeight && epochOf(parent.Timestamp()) != epochOf(block.Timestamp()) {
+		return core.ErrUnverifiableDynasty
+	}
+	if block.Height() < core.ValidatorLivenessHeight || block.Height() >= core.VRFProposerHeight ||
+		len(dynasty) != len(parentDynasty) {
+		return ErrInvalidDynastyChange
+	}
+	allowed := make(map[string]bool)
+	for _, member := range parentDynasty {
+		allowed[member.Hex()] = true
+	}
+	for _, member := range dpos.standby {
+		allowed[member.Hex()] = true
+	}
+	for _, member := range dynasty {
+		if !allowed[member.Hex()] {
+			return ErrInvalidDynastyChange
+		}
+	}
 	return nil
 }
 
@@ -398,11 +519,22 @@ func (dpos *Dpos) generateRandomSeed(block *core.Block, adminService rpcpb.Admin
 		return err
 	}
 
+	// generate VRF hash,proof
+	vrfSeed, vrfProof, err := dpos.evaluateVRF(ancestorHash, parentSeed, adminService)
+	if err != nil {
+		return err
+	}
+	block.SetRandomSeed(vrfSeed, vrfProof)
+
+	return nil
+}
+
+// evaluateVRF evaluates the VRF of the miner over the inputs, by the remote sign server if it is enabled.
+func (dpos *Dpos) evaluateVRF(ancestorHash, parentSeed []byte, adminService rpcpb.AdminServiceClient) ([]byte, []byte, error) {
 	if dpos.enableRemoteSignServer == true {
 		if adminService == nil {
-			return ErrInvalidArgument
+			return nil, nil, ErrInvalidArgument
 		}
-		// generate VRF hash,proof
 		random, err := adminService.GenerateRandomSeed(
 			context.Background(),
 			&rpcpb.GenerateRandomSeedRequest{
@@ -411,20 +543,14 @@ func (dpos *Dpos) generateRandomSeed(block *core.Block, adminService rpcpb.Admin
 				AncestorHash: ancestorHash,
 			})
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
-		block.SetRandomSeed(random.VrfSeed, random.VrfProof)
-		return nil
+		return random.VrfSeed, random.VrfProof, nil
 	}
-
-	// generate VRF hash,proof
-	vrfSeed, vrfProof, err := dpos.am.GenerateRandomSeed(dpos.miner, ancestorHash, parentSeed)
-	if err != nil {
-		return err
+	if dpos.signer != nil {
+		return dpos.signer.GenerateRandomSeed(ancestorHash, parentSeed)
 	}
-	block.SetRandomSeed(vrfSeed, vrfProof)
-
-	return nil
+	return dpos.am.GenerateRandomSeed(dpos.miner, ancestorHash, parentSeed)
 }
 
 func (dpos *Dpos) remoteSignBlock(block *core.Block, adminService rpcpb.AdminServiceClient) error {
@@ -447,66 +573,79 @@ func (dpos *Dpos) remoteSignBlock(block *core.Block, adminService rpcpb.AdminSer
 	return nil
 }
 
-func (dpos *Dpos) unlock(passphrase string) error {
+// dialRemoteSignServer return the admin service of the remote sign server, nil if it is disabled.
+func (dpos *Dpos) dialRemoteSignServer() (rpcpb.AdminServiceClient, *grpc.ClientConn, error) {
 	if dpos.enableRemoteSignServer == false {
+		return nil, nil, nil
+	}
+	conn, err := rpc.Dial(dpos.remoteSignServer)
+	if err != nil {
+		return nil, conn, err
+	}
+	return rpcpb.NewAdminServiceClient(conn), conn, nil
+}
+
+func (dpos *Dpos) unlock(passphrase string) error {
+	if dpos.enableRemoteSignServer == false && dpos.signer == nil {
 		return dpos.am.Unlock(dpos.miner, []byte(passphrase), DefaultMaxUnlockDuration)
 	}
 	return nil
 
 }
 
-func (dpos *Dpos) newBlock(tail *core.Block, consensusState state.ConsensusState, deadlineInMs int64) (*core.Block, error) {
-	startAt := time.Now().Unix()
-	block, err := core.NewBlock(dpos.chain.ChainID(), dpos.coinbase, tail)
-	if err != nil {
-		logging.CLog().WithFields(logrus.Fields{
-			"tail":     tail,
-			"coinbase": dpos.coinbase,
-			"chainid":  dpos.chain.ChainID(),
-			"err":      err,
-		}).Error("Failed to create new block")
-		return nil, err
-	}
-
-	var adminService rpcpb.AdminServiceClient
-	if dpos.enableRemoteSignServer == true {
-		conn, err := rpc.Dial(dpos.remoteSignServer)
+// Prepare generate the random seed of the new block, and set the consensus state of its slot
+func (dpos *Dpos) Prepare(block *core.Block, consensusState state.ConsensusState) error {
+	if block.Height() >= core.RandomAvailableHeight {
+		adminService, conn, err := dpos.dialRemoteSignServer()
 		defer func() {
 			if conn != nil {
 				conn.Close()
 			}
 		}()
 		if err != nil {
-			return nil, err
+			return err
 		}
-		adminService = rpcpb.NewAdminServiceClient(conn)
-	}
-
-	if block.Height() >= core.RandomAvailableHeight {
-		err := dpos.generateRandomSeed(block, adminService)
-		if err != nil {
+		if err := dpos.generateRandomSeed(block, adminService); err != nil {
 			logging.VLog().WithFields(logrus.Fields{
 				"block": block,
 				"err":   err,
 			}).Error("Failed to generate random seed from remote.")
-			return nil, err
+			return err
 		}
 	}
 
 	block.WorldState().SetConsensusState(consensusState)
 	block.SetTimestamp(consensusState.TimeStamp())
-	block.CollectTransactions(deadlineInMs)
-	if err = block.Seal(); err != nil {
+	return nil
+}
+
+// Finalize seal the new block, dpos has nothing to add once the txs are collected
+func (dpos *Dpos) Finalize(block *core.Block) error {
+	if err := block.Seal(); err != nil {
 		logging.CLog().WithFields(logrus.Fields{
 			"block": block,
 			"err":   err,
 		}).Error("Failed to seal new block")
-		go block.ReturnTransactions()
-		return nil, err
+		return err
 	}
+	return nil
+}
 
+// Seal sign the new block by the miner, or by the remote sign server if it is enabled, or by the signer if set
+func (dpos *Dpos) Seal(block *core.Block) error {
+	adminService, conn, err := dpos.dialRemoteSignServer()
+	defer func() {
+		if conn != nil {
+			conn.Close()
+		}
+	}()
+	if err != nil {
+		return err
+	}
 	if dpos.enableRemoteSignServer == true {
 		err = dpos.remoteSignBlock(block, adminService)
+	} else if dpos.signer != nil {
+		err = dpos.signerSignBlock(block)
 	} else {
 		err = dpos.am.SignBlock(dpos.miner, block)
 	}
@@ -516,6 +655,32 @@ func (dpos *Dpos) newBlock(tail *core.Block, consensusState state.ConsensusState
 			"block": block,
 			"err":   err,
 		}).Error("Failed to sign new block")
+		return err
+	}
+	return nil
+}
+
+func (dpos *Dpos) newBlock(tail *core.Block, consensusState state.ConsensusState, deadlineInMs int64) (*core.Block, error) {
+	startAt := time.Now().Unix()
+	block, err := core.NewBlock(dpos.chain.ChainID(), dpos.coinbase, tail)
+	if err != nil {
+		logging.CLog().WithFields(logrus.Fields{
+			"tail":     tail,
+			"coinbase": dpos.coinbase,
+			"chainid":  dpos.chain.ChainID(),
+			"err":      err,
+		}).Error("Failed to create new block")
+		return nil, err
+	}
+	if err := dpos.Prepare(block, consensusState); err != nil {
+		return nil, err
+	}
+	block.CollectTransactions(deadlineInMs)
+	if err := dpos.Finalize(block); err != nil {
+		go block.ReturnTransactions()
+		return nil, err
+	}
+	if err := dpos.Seal(block); err != nil {
 		go block.ReturnTransactions()
 		return nil, err
 	}
@@ -577,6 +742,58 @@ func (dpos *Dpos) checkProposer(tail *core.Block, nowInMs int64) (state.Consensu
 		}).Debug("Failed to generate next dynasty context.")
 		return nil, ErrGenerateNextConsensusState
 	}
+	// a new dynasty is elected by the stakes of the tail.
+	if tail.Height()+1 >= core.ProofOfDevotionHeight {
+		elective, ok := consensusState.(core.ElectiveConsensusState)
+		if !ok {
+			return nil, ErrGenerateNextConsensusState
+		}
+		if err := elective.ElectDynasty(tail.Height()+1, tail.WorldState()); err != nil {
+			logging.VLog().WithFields(logrus.Fields{
+				"tail": tail,
+				"err":  err,
+			}).Debug("Failed to elect the new dynasty.")
+			return nil, ErrGenerateNextConsensusState
+		}
+	}
+	// the inactive members are replaced before the proposer of the slot is found.
+	if tail.Height()+1 >= core.ValidatorLivenessHeight {
+		liveness, ok := consensusState.(core.LivenessConsensusState)
+		if !ok {
+			return nil, ErrGenerateNextConsensusState
+		}
+		if err := liveness.TrackLiveness(tail.Height() + 1); err != nil {
+			logging.VLog().WithFields(logrus.Fields{
+				"tail": tail,
+				"err":  err,
+			}).Debug("Failed to track the liveness of the dynasty.")
+			return nil, ErrGenerateNextConsensusState
+		}
+	}
+	// a slashed validator can't propose until it is released.
+	if tail.Height()+1 >= core.DoubleSignEvidenceHeight && core.IsJailed(tail, dpos.miner.Bytes(), tail.Height()+1) {
+		logging.VLog().WithFields(logrus.Fields{
+			"tail":  tail,
+			"now":   nowInMs,
+			"slot":  slotInMs,
+			"miner": dpos.miner,
+		}).Debug("Jailed, waiting...")
+		return nil, ErrJailedProposer
+	}
+	// since VRFProposerHeight the members of the dynasty run for the slot by the VRF lottery.
+	if tail.Height()+1 >= core.VRFProposerHeight {
+		if err := dpos.electProposer(consensusState); err != nil {
+			logging.VLog().WithFields(logrus.Fields{
+				"tail":  tail,
+				"now":   nowInMs,
+				"slot":  slotInMs,
+				"miner": dpos.miner,
+				"err":   err,
+			}).Debug("Not elected for the slot, waiting...")
+			return nil, ErrInvalidBlockProposer
+		}
+		return consensusState, nil
+	}
 	if consensusState.Proposer() == nil || !consensusState.Proposer().Equals(dpos.miner.Bytes()) {
 		proposer := "nil"
 		if consensusState.Proposer() != nil {
@@ -647,6 +864,16 @@ func (dpos *Dpos) mintBlock(now int64) error {
 		return err
 	}
 
+	// a drifted clock would mint in the slots of the others.
+	if node := dpos.ns.Node(); node != nil && node.Clock().Drifted() {
+		offset, samples := node.Clock().Offset()
+		logging.CLog().WithFields(logrus.Fields{
+			"offset":  offset,
+			"samples": samples,
+		}).Error("Refused to mint block, the local clock drifts from the peers.")
+		return ErrLocalClockDrifted
+	}
+
 	miner := "nil"
 	if dpos.miner != nil {
 		miner = dpos.miner.String()
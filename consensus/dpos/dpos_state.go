@@ -60,16 +60,22 @@ var (
 	ErrCloneCandidatesTrie     = errors.New("Failed to clone candidates trie")
 	ErrCloneVoteTrie           = errors.New("Failed to clone vote trie")
 	ErrCloneMintCntTrie        = errors.New("Failed to clone mint count trie")
+	ErrCloneLivenessTrie       = errors.New("Failed to clone liveness trie")
 	ErrNotBlockForgTime        = errors.New("now is not time to forg block")
 	ErrFoundNilProposer        = errors.New("found a nil proposer")
 )
 
 // State carry context in dpos consensus
 type State struct {
-	timestamp int64
-	proposer  byteutils.Hash
+	timestamp     int64
+	proposer      byteutils.Hash
+	proposerProof []byte
 
-	dynastyTrie *trie.Trie // key: delegatee, val: delegatee
+	dynastyTrie  *trie.Trie // key: delegatee, val: delegatee
+	livenessTrie *trie.Trie // key: delegatee, val: missed slots in the dynasty
+
+	// timestamp of the parent state, the slots in between were missed.
+	parentTimestamp int64
 
 	chain     *core.BlockChain
 	consensus core.Consensus
@@ -77,20 +83,27 @@ type State struct {
 
 // NewState create a new dpos state
 func (dpos *Dpos) NewState(root *consensuspb.ConsensusRoot, stor storage.Storage, needChangeLog bool) (state.ConsensusState, error) {
-	var dynastyRoot byteutils.Hash
+	var dynastyRoot, livenessRoot byteutils.Hash
 	if root != nil {
 		dynastyRoot = root.DynastyRoot
+		livenessRoot = root.LivenessRoot
 	}
 	dynastyTrie, err := trie.NewTrie(dynastyRoot, stor, needChangeLog)
 	if err != nil {
 		return nil, err
 	}
+	livenessTrie, err := trie.NewTrie(livenessRoot, stor, needChangeLog)
+	if err != nil {
+		return nil, err
+	}
 
 	return &State{
-		timestamp: root.Timestamp,
-		proposer:  root.Proposer,
+		timestamp:     root.Timestamp,
+		proposer:      root.Proposer,
+		proposerProof: root.ProposerProof,
 
-		dynastyTrie: dynastyTrie,
+		dynastyTrie:  dynastyTrie,
+		livenessTrie: livenessTrie,
 
 		chain:     dpos.chain,
 		consensus: dpos,
@@ -130,6 +143,10 @@ func (dpos *Dpos) GenesisConsensusState(chain *core.BlockChain, conf *corepb.Gen
 	if err != nil {
 		return nil, err
 	}
+	livenessTrie, err := trie.NewTrie(nil, chain.Storage(), false)
+	if err != nil {
+		return nil, err
+	}
 	if len(conf.Consensus.Dpos.Dynasty) < ConsensusSize {
 		return nil, ErrInitialDynastyNotEnough
 	}
@@ -151,7 +168,8 @@ func (dpos *Dpos) GenesisConsensusState(chain *core.BlockChain, conf *corepb.Gen
 		timestamp: core.GenesisTimestamp,
 		proposer:  nil,
 
-		dynastyTrie: dynastyTrie,
+		dynastyTrie:  dynastyTrie,
+		livenessTrie: livenessTrie,
 
 		chain:     chain,
 		consensus: dpos,
@@ -163,10 +181,11 @@ func (ds *State) String() string {
 	if ds.proposer != nil {
 		proposer = ds.proposer.String()
 	}
-	return fmt.Sprintf(`{"timestamp": %d, "proposer": "%s", "dynasty": "%s"}`,
+	return fmt.Sprintf(`{"timestamp": %d, "proposer": "%s", "dynasty": "%s", "liveness": "%s"}`,
 		ds.timestamp,
 		proposer,
 		byteutils.Hex(ds.dynastyTrie.RootHash()),
+		byteutils.Hex(ds.livenessTrie.RootHash()),
 	)
 }
 
@@ -176,6 +195,9 @@ func (ds *State) Replay(done state.ConsensusState) error {
 	if _, err := ds.dynastyTrie.Replay(state.dynastyTrie); err != nil {
 		return err
 	}
+	if _, err := ds.livenessTrie.Replay(state.livenessTrie); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -186,11 +208,19 @@ func (ds *State) Clone() (state.ConsensusState, error) {
 	if err != nil {
 		return nil, ErrCloneDynastyTrie
 	}
+	livenessTrie, err := ds.livenessTrie.Clone()
+	if err != nil {
+		return nil, ErrCloneLivenessTrie
+	}
 	return &State{
-		timestamp: ds.timestamp,
-		proposer:  ds.proposer,
+		timestamp:     ds.timestamp,
+		proposer:      ds.proposer,
+		proposerProof: ds.proposerProof,
+
+		dynastyTrie:  dynastyTrie,
+		livenessTrie: livenessTrie,
 
-		dynastyTrie: dynastyTrie,
+		parentTimestamp: ds.parentTimestamp,
 
 		chain:     ds.chain,
 		consensus: ds.consensus,
@@ -200,9 +230,11 @@ func (ds *State) Clone() (state.ConsensusState, error) {
 // RootHash hash dpos state
 func (ds *State) RootHash() *consensuspb.ConsensusRoot {
 	return &consensuspb.ConsensusRoot{
-		DynastyRoot: ds.dynastyTrie.RootHash(),
-		Timestamp:   ds.TimeStamp(),
-		Proposer:    ds.Proposer(),
+		DynastyRoot:   ds.dynastyTrie.RootHash(),
+		Timestamp:     ds.TimeStamp(),
+		Proposer:      ds.Proposer(),
+		ProposerProof: ds.proposerProof,
+		LivenessRoot:  ds.livenessTrie.RootHash(),
 	}
 }
 
@@ -244,6 +276,12 @@ func (ds *State) Proposer() byteutils.Hash {
 	return ds.proposer
 }
 
+// SetProposer set the proposer elected by the VRF lottery, with its proof
+func (ds *State) SetProposer(proposer byteutils.Hash, proof []byte) {
+	ds.proposer = proposer
+	ds.proposerProof = proof
+}
+
 // TimeStamp return the current timestamp
 func (ds *State) TimeStamp() int64 {
 	return ds.timestamp
@@ -260,11 +298,18 @@ func (ds *State) NextConsensusState(elapsedSecond int64, worldState state.WorldS
 	if err != nil {
 		return nil, err
 	}
+	livenessTrie, err := ds.livenessTrie.Clone()
+	if err != nil {
+		return nil, err
+	}
 
 	consensusState := &State{
 		timestamp: ds.timestamp + elapsedSecond,
 
-		dynastyTrie: dynastyTrie,
+		dynastyTrie:  dynastyTrie,
+		livenessTrie: livenessTrie,
+
+		parentTimestamp: ds.timestamp,
 
 		chain:     ds.chain,
 		consensus: ds.consensus,
@@ -32,6 +32,8 @@ import (
 	"github.com/nebulasio/go-nebulas/core"
 	"github.com/nebulasio/go-nebulas/core/pb"
 	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/threshold"
 	"github.com/nebulasio/go-nebulas/neblet/pb"
 	"github.com/nebulasio/go-nebulas/net"
 	"github.com/nebulasio/go-nebulas/nf/nvm"
@@ -55,7 +57,7 @@ func mockNeb(t *testing.T) *Neb {
 	// storage, _ := storage.NewDiskStorage("test.db")
 	// storage, err := storage.NewRocksStorage("rocks.db")
 	// assert.Nil(t, err)
-	storage, _ := storage.NewMemoryStorage()
+	storage := storage.NewMemoryBackend()
 	eventEmitter := core.NewEventEmitter(1024)
 	genesisConf := MockGenesisConf()
 	dpos := NewDpos()
@@ -252,6 +254,10 @@ func (n mockNetService) SendMessageToPeer(messageName string, data []byte, prior
 
 func (n mockNetService) ClosePeer(peerID string, reason error) {}
 
+func (n mockNetService) AddPeer(addr string) (string, error) { return "", nil }
+
+func (n mockNetService) RemovePeer(peerID string) error { return nil }
+
 func (n mockNetService) BroadcastNetworkID([]byte) {}
 
 func mockBlockFromNetwork(block *core.Block) (*core.Block, error) {
@@ -297,7 +303,37 @@ func TestDpos_VerifySign(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Nil(t, manager.Unlock(miner, []byte("passphrase"), keystore.DefaultUnlockDuration))
 	assert.Nil(t, manager.SignBlock(miner, block))
-	assert.Equal(t, neb.consensus.VerifyBlock(block), ErrInvalidBlockProposer)
+	assert.Equal(t, neb.consensus.VerifyHeader(block), ErrInvalidBlockProposer)
+}
+
+func TestDpos_SetSigner(t *testing.T) {
+	neb := mockNeb(t)
+	dpos := neb.consensus.(*Dpos)
+
+	shares, commitments, err := threshold.Split(secp256k1.NewSeckey(), 2, 3)
+	assert.Nil(t, err)
+	var holders []threshold.Holder
+	for _, share := range shares {
+		holder, err := threshold.NewLocalHolder(share, commitments, func(kind string, data, payload []byte) bool { return true })
+		assert.Nil(t, err)
+		holders = append(holders, holder)
+	}
+	coordinator, err := threshold.NewCoordinator(commitments, holders...)
+	assert.Nil(t, err)
+	assert.Equal(t, ErrSignerNotMiner, dpos.SetSigner(coordinator))
+
+	miner, err := core.NewAddressFromPublicKey(coordinator.PublicKey())
+	assert.Nil(t, err)
+	dpos.miner = miner
+	assert.Nil(t, dpos.SetSigner(coordinator))
+
+	block, err := core.NewBlock(neb.chain.ChainID(), miner, neb.chain.TailBlock())
+	assert.Nil(t, err)
+	assert.Nil(t, block.Seal())
+	assert.Nil(t, dpos.Seal(block))
+	signer, err := core.RecoverSignerFromSignature(block.Alg(), block.Hash(), block.Signature())
+	assert.Nil(t, err)
+	assert.Equal(t, miner, signer)
 }
 
 func GetUnlockAddress(t *testing.T, am *account.Manager, addr string) *core.Address {
@@ -383,7 +419,7 @@ func TestCanMining(t *testing.T) {
 	assert.Equal(t, neb.consensus.Pending(), false)
 }
 
-func TestVerifyBlock(t *testing.T) {
+func TestVerifyHeader(t *testing.T) {
 	neb := mockNeb(t)
 	dpos := neb.consensus
 	tail := neb.chain.TailBlock()
@@ -404,7 +440,7 @@ func TestVerifyBlock(t *testing.T) {
 	assert.Nil(t, manager.Unlock(coinbase, []byte("passphrase"), keystore.DefaultUnlockDuration))
 	assert.Nil(t, manager.SignBlock(coinbase, block))
 
-	assert.NotNil(t, dpos.VerifyBlock(block), ErrInvalidBlockInterval)
+	assert.NotNil(t, dpos.VerifyHeader(block), ErrInvalidBlockInterval)
 
 	elapsedSecond = DynastyIntervalInMs / SecondInMs
 	consensusState, err = tail.WorldState().NextConsensusState(elapsedSecond)
@@ -414,7 +450,7 @@ func TestVerifyBlock(t *testing.T) {
 	block.SetTimestamp(tail.Timestamp() + elapsedSecond)
 	block.Seal()
 	assert.Nil(t, manager.SignBlock(coinbase, block))
-	assert.Nil(t, dpos.VerifyBlock(block))
+	assert.Nil(t, dpos.VerifyHeader(block))
 
 	elapsedSecond = (DynastySize*BlockIntervalInMs + DynastyIntervalInMs) / SecondInMs
 	consensusState, err = tail.WorldState().NextConsensusState(elapsedSecond)
@@ -424,7 +460,7 @@ func TestVerifyBlock(t *testing.T) {
 	block.SetTimestamp(tail.Timestamp() + elapsedSecond)
 	block.Seal()
 	assert.Nil(t, manager.SignBlock(coinbase, block))
-	assert.Nil(t, dpos.VerifyBlock(block))
+	assert.Nil(t, dpos.VerifyHeader(block))
 }
 
 func TestDpos_MintBlock(t *testing.T) {
@@ -625,3 +661,38 @@ func TestDoubleMint(t *testing.T) {
 	consensusState, err = chain.TailBlock().WorldState().NextConsensusState(0)
 	assert.Equal(t, err, ErrNotBlockForgTime)
 }
+
+func TestJailedProposer(t *testing.T) {
+	neb := mockNeb(t)
+	dpos := neb.consensus.(*Dpos)
+	core.SetCompatibilityOptions(neb.chain.ChainID())
+	defer core.SetCompatibilityOptions(core.TestNetID)
+	assert.Equal(t, core.LocalDoubleSignEvidenceHeight, core.DoubleSignEvidenceHeight)
+
+	jailed, err := core.AddressParse("n1GmkKH6nBMw4rrjt16RrJ9WcgvKUtAZP1s")
+	assert.Nil(t, err)
+	free, err := core.AddressParse("n1FF1nz6tarkDVwWQkMnnwFPuPKUaQTdptE")
+	assert.Nil(t, err)
+
+	// the validator is slashed in the block at the activation height.
+	tail := neb.chain.TailBlock()
+	consensusState, err := tail.WorldState().NextConsensusState(BlockIntervalInMs / SecondInMs)
+	assert.Nil(t, err)
+	block, err := core.NewBlock(neb.chain.ChainID(), jailed, tail)
+	assert.Nil(t, err)
+	assert.Equal(t, core.LocalDoubleSignEvidenceHeight, block.Height())
+	block.SetTimestamp(consensusState.TimeStamp())
+	block.WorldState().SetConsensusState(consensusState)
+	assert.Nil(t, core.Slash(block.WorldState(), []byte("evidence"), jailed, core.SlashDoubleSign, block.Height()))
+	block.Commit()
+	assert.True(t, core.IsJailed(block, jailed.Bytes(), block.Height()+1))
+
+	// the jailed validator can't propose the next block, the others still can.
+	nowInMs := block.Timestamp()*SecondInMs + BlockIntervalInMs
+	dpos.miner = jailed
+	_, err = dpos.checkProposer(block, nowInMs)
+	assert.Equal(t, ErrJailedProposer, err)
+	dpos.miner = free
+	_, err = dpos.checkProposer(block, nowInMs)
+	assert.NotEqual(t, ErrJailedProposer, err)
+}
@@ -0,0 +1,75 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package dpos
+
+import (
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/core/state"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// ElectDynasty pick the DynastySize candidates with the most stake in the parent
+// world state as the members of a new dynasty. The dynasty is kept while there
+// are not enough candidates.
+func (ds *State) ElectDynasty(height uint64, parent state.WorldState) error {
+	if epochOf(ds.parentTimestamp) == epochOf(ds.timestamp) {
+		return nil
+	}
+	candidates, err := core.CandidateRankings(parent, height)
+	if err != nil {
+		return err
+	}
+	if len(candidates) < DynastySize {
+		logging.VLog().WithFields(logrus.Fields{
+			"candidates": len(candidates),
+			"height":     height,
+		}).Debug("Not enough candidates, keep the dynasty.")
+		return nil
+	}
+
+	members, err := TraverseDynasty(ds.dynastyTrie)
+	if err != nil {
+		return err
+	}
+	for _, member := range members {
+		if _, err := ds.dynastyTrie.Del(member); err != nil {
+			return err
+		}
+	}
+	elected := []string{}
+	for _, candidate := range candidates[:DynastySize] {
+		v := candidate.Address.Bytes()
+		if _, err := ds.dynastyTrie.Put(v, v); err != nil {
+			return err
+		}
+		elected = append(elected, candidate.Address.String())
+	}
+	logging.VLog().WithFields(logrus.Fields{
+		"height":  height,
+		"dynasty": elected,
+	}).Info("Elected a new dynasty.")
+
+	members, err = TraverseDynasty(ds.dynastyTrie)
+	if err != nil {
+		return err
+	}
+	ds.proposer, err = FindProposer(ds.timestamp, members)
+	return err
+}
@@ -0,0 +1,135 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package dpos
+
+import (
+	"bytes"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/threshold"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// HolderPolicy approves a threshold share holder of the validator to sign
+// the blocks proposed by the validator in the current slot and to evaluate
+// the VRF in it, once per slot. The block is sent along with its hash, so
+// the holder checks the hash itself, a holder never signs a bare hash or two
+// blocks of the same slot.
+type HolderPolicy struct {
+	mu sync.Mutex
+
+	validator *core.Address
+	chainID   uint32
+	now       func() int64
+
+	// the slot and the hash of the last block signed.
+	blockSlot int64
+	blockHash []byte
+
+	// the slot and the inputs of the last VRF evaluated.
+	seedSlot int64
+	seedData []byte
+}
+
+// NewHolderPolicy returns the policy of the holders of the validator's key on the chain
+func NewHolderPolicy(validator *core.Address, chainID uint32) *HolderPolicy {
+	return &HolderPolicy{
+		validator: validator,
+		chainID:   chainID,
+		now:       func() int64 { return time.Now().Unix() },
+	}
+}
+
+// Approve decides whether the data of the kind is signed, see threshold.Policy
+func (p *HolderPolicy) Approve(kind string, data, payload []byte) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch kind {
+	case threshold.KindBlock:
+		return p.approveBlock(data, payload)
+	case threshold.KindRandomSeed:
+		return p.approveRandomSeed(data, payload)
+	}
+	return false
+}
+
+func (p *HolderPolicy) approveBlock(data, payload []byte) bool {
+	pbBlock := new(corepb.Block)
+	if err := proto.Unmarshal(payload, pbBlock); err != nil {
+		return false
+	}
+	blockHash, err := core.HashPbBlock(pbBlock)
+	if err != nil || !bytes.Equal(blockHash, data) {
+		return false
+	}
+	block := new(core.Block)
+	if err := block.FromProto(pbBlock); err != nil {
+		return false
+	}
+	if block.ChainID() != p.chainID || block.ConsensusRoot() == nil ||
+		!bytes.Equal(block.ConsensusRoot().Proposer, p.validator.Bytes()) {
+		p.refuse(threshold.KindBlock, "not the validator's block", block.Timestamp())
+		return false
+	}
+
+	// the block is of the current slot, minted a bit ahead of it.
+	timestamp := block.Timestamp()
+	interval := BlockIntervalInMs / SecondInMs
+	if timestamp%interval != 0 || timestamp < p.now()-interval || timestamp > p.now()+interval {
+		p.refuse(threshold.KindBlock, "not in the current slot", timestamp)
+		return false
+	}
+	slot := timestamp / interval
+	if slot < p.blockSlot || (slot == p.blockSlot && !bytes.Equal(data, p.blockHash)) {
+		p.refuse(threshold.KindBlock, "slot already signed", timestamp)
+		return false
+	}
+	p.blockSlot, p.blockHash = slot, data
+	return true
+}
+
+func (p *HolderPolicy) approveRandomSeed(data, payload []byte) bool {
+	// the payload is the ancestor hash followed by the parent seed.
+	if len(payload) <= len(data) || !bytes.Equal(hash.Sha3256(payload[:len(data)], payload[len(data):]), data) {
+		return false
+	}
+	slot := p.now() * SecondInMs / BlockIntervalInMs
+	if slot < p.seedSlot || (slot == p.seedSlot && !bytes.Equal(data, p.seedData)) {
+		p.refuse(threshold.KindRandomSeed, "slot already evaluated", p.now())
+		return false
+	}
+	p.seedSlot, p.seedData = slot, data
+	return true
+}
+
+func (p *HolderPolicy) refuse(kind, reason string, timestamp int64) {
+	logging.VLog().WithFields(logrus.Fields{
+		"kind":      kind,
+		"reason":    reason,
+		"timestamp": timestamp,
+		"validator": p.validator,
+	}).Warn("Threshold share holder refused to sign.")
+}
@@ -0,0 +1,108 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package dpos
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/threshold"
+	"github.com/stretchr/testify/assert"
+)
+
+func mockAddress(t *testing.T) *core.Address {
+	pub, err := secp256k1.GetPublicKey(secp256k1.NewSeckey())
+	assert.Nil(t, err)
+	addr, err := core.NewAddressFromPublicKey(pub)
+	assert.Nil(t, err)
+	return addr
+}
+
+func mockPolicyBlock(t *testing.T, neb *Neb, coinbase *core.Address) (*core.Block, []byte) {
+	tail := neb.chain.TailBlock()
+	consensusState, err := tail.WorldState().NextConsensusState(BlockIntervalInMs / SecondInMs)
+	assert.Nil(t, err)
+	block, err := core.NewBlock(neb.chain.ChainID(), coinbase, tail)
+	assert.Nil(t, err)
+	block.WorldState().SetConsensusState(consensusState)
+	block.SetTimestamp(consensusState.TimeStamp())
+	assert.Nil(t, block.Seal())
+
+	pbBlock, err := block.ToProto()
+	assert.Nil(t, err)
+	data, err := proto.Marshal(pbBlock)
+	assert.Nil(t, err)
+	return block, data
+}
+
+func TestHolderPolicy_Block(t *testing.T) {
+	neb := mockNeb(t)
+	interval := BlockIntervalInMs / SecondInMs
+	block, data := mockPolicyBlock(t, neb, mockAddress(t))
+	proposer, err := core.AddressParseFromBytes(block.ConsensusRoot().Proposer)
+	assert.Nil(t, err)
+
+	policy := NewHolderPolicy(proposer, neb.chain.ChainID())
+	policy.now = func() int64 { return block.Timestamp() }
+
+	// a bare hash, or a hash not of the block, is refused.
+	assert.False(t, policy.Approve(threshold.KindBlock, block.Hash(), nil))
+	assert.False(t, policy.Approve(threshold.KindBlock, hash.Sha3256([]byte("other")), data))
+	assert.False(t, policy.Approve("hash", block.Hash(), data))
+
+	// the block of another validator or chain is refused.
+	assert.False(t, NewHolderPolicy(mockAddress(t), neb.chain.ChainID()).Approve(threshold.KindBlock, block.Hash(), data))
+	assert.False(t, NewHolderPolicy(proposer, neb.chain.ChainID()+1).Approve(threshold.KindBlock, block.Hash(), data))
+
+	// the block out of the current slot is refused.
+	policy.now = func() int64 { return block.Timestamp() + 2*interval }
+	assert.False(t, policy.Approve(threshold.KindBlock, block.Hash(), data))
+	policy.now = func() int64 { return block.Timestamp() - 1 }
+	assert.True(t, policy.Approve(threshold.KindBlock, block.Hash(), data))
+	assert.True(t, policy.Approve(threshold.KindBlock, block.Hash(), data))
+
+	// another block of the same slot is a double sign.
+	fork, forkData := mockPolicyBlock(t, neb, mockAddress(t))
+	assert.NotEqual(t, block.Hash(), fork.Hash())
+	assert.False(t, policy.Approve(threshold.KindBlock, fork.Hash(), forkData))
+}
+
+func TestHolderPolicy_RandomSeed(t *testing.T) {
+	policy := NewHolderPolicy(mockAddress(t), 100)
+	now := int64(1700000000)
+	policy.now = func() int64 { return now }
+
+	ancestorHash, parentSeed := hash.Sha3256([]byte("ancestor")), []byte("parent")
+	data := hash.Sha3256(ancestorHash, parentSeed)
+	payload := append(append([]byte{}, ancestorHash...), parentSeed...)
+	assert.False(t, policy.Approve(threshold.KindRandomSeed, data, nil))
+	assert.False(t, policy.Approve(threshold.KindRandomSeed, hash.Sha3256([]byte("other")), payload))
+	assert.True(t, policy.Approve(threshold.KindRandomSeed, data, payload))
+	assert.True(t, policy.Approve(threshold.KindRandomSeed, data, payload))
+
+	// one input per slot.
+	otherData := hash.Sha3256(ancestorHash, []byte("other"))
+	otherPayload := append(append([]byte{}, ancestorHash...), []byte("other")...)
+	assert.False(t, policy.Approve(threshold.KindRandomSeed, otherData, otherPayload))
+	now += BlockIntervalInMs / SecondInMs
+	assert.True(t, policy.Approve(threshold.KindRandomSeed, otherData, otherPayload))
+}
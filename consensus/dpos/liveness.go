@@ -0,0 +1,197 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package dpos
+
+import (
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// Since core.ValidatorLivenessHeight the slots between a block and its parent
+// are counted as missed by their scheduled proposers in the liveness trie of
+// the consensus state. A member missing more than the allowed slots in a
+// dynasty is inactive, it is replaced by the first standby validator of the
+// genesis conf which is neither in the dynasty nor inactive, so the dynasty
+// keeps its size and the slots keep their owners. The counters are reset when
+// a new dynasty starts.
+
+// DefaultMaxMissedSlots the slots a member may miss in a dynasty, half of its own slots
+const DefaultMaxMissedSlots = uint64(DynastyIntervalInMs/BlockIntervalInMs/DynastySize) / 2
+
+// livenessConf return the standby validators and the allowed missed slots of the genesis conf.
+func livenessConf(conf *corepb.GenesisConsensusDpos) ([]byteutils.Hash, uint64, error) {
+	standby := []byteutils.Hash{}
+	for _, v := range conf.GetStandby() {
+		addr, err := core.AddressParse(v)
+		if err != nil {
+			return nil, 0, err
+		}
+		standby = append(standby, addr.Bytes())
+	}
+	maxMissedSlots := uint64(conf.GetMaxMissedSlots())
+	if maxMissedSlots == 0 {
+		maxMissedSlots = DefaultMaxMissedSlots
+	}
+	return standby, maxMissedSlots, nil
+}
+
+func epochOf(timestamp int64) int64 {
+	return timestamp * SecondInMs / DynastyIntervalInMs
+}
+
+// MissedSlots return the slots missed by the member in the current dynasty
+func (ds *State) MissedSlots(member byteutils.Hash) (uint64, error) {
+	v, err := ds.livenessTrie.Get(member)
+	if err == storage.ErrKeyNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return byteutils.Uint64(v), nil
+}
+
+// TrackLiveness count the slots missed since the parent state, and replace
+// the members missing too many of them. The proposer of the slot is scheduled
+// again on the new dynasty.
+func (ds *State) TrackLiveness(height uint64) error {
+	// an empty slot of the VRF lottery has no owner to blame.
+	if height >= core.VRFProposerHeight {
+		return nil
+	}
+	dpos, ok := ds.consensus.(*Dpos)
+	if !ok {
+		return ErrInvalidArgument
+	}
+
+	from := ds.parentTimestamp + BlockIntervalInMs/SecondInMs
+	if epoch := epochOf(ds.timestamp); epochOf(ds.parentTimestamp) != epoch {
+		if err := ds.resetLiveness(); err != nil {
+			return err
+		}
+		from = epoch * DynastyIntervalInMs / SecondInMs
+	}
+
+	miners, err := TraverseDynasty(ds.dynastyTrie)
+	if err != nil {
+		return err
+	}
+	for slot := from; slot < ds.timestamp; slot += BlockIntervalInMs / SecondInMs {
+		member, err := FindProposer(slot, miners)
+		if err != nil {
+			return err
+		}
+		missed, err := ds.MissedSlots(member)
+		if err != nil {
+			return err
+		}
+		missed++
+		if _, err := ds.livenessTrie.Put(member, byteutils.FromUint64(missed)); err != nil {
+			return err
+		}
+		if missed <= dpos.maxMissedSlots {
+			continue
+		}
+		replaced, err := ds.replaceMember(member, dpos.standby, dpos.maxMissedSlots)
+		if err != nil {
+			return err
+		}
+		if replaced {
+			if miners, err = TraverseDynasty(ds.dynastyTrie); err != nil {
+				return err
+			}
+		}
+	}
+
+	ds.proposer, err = FindProposer(ds.timestamp, miners)
+	return err
+}
+
+// replaceMember replace the inactive member by the next standby validator,
+// the member is kept if no standby is available.
+func (ds *State) replaceMember(member byteutils.Hash, standby []byteutils.Hash, maxMissedSlots uint64) (bool, error) {
+	if _, err := ds.dynastyTrie.Get(member); err != nil {
+		if err == storage.ErrKeyNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	for _, candidate := range standby {
+		if _, err := ds.dynastyTrie.Get(candidate); err != storage.ErrKeyNotFound {
+			if err != nil {
+				return false, err
+			}
+			continue
+		}
+		missed, err := ds.MissedSlots(candidate)
+		if err != nil {
+			return false, err
+		}
+		if missed > maxMissedSlots {
+			continue
+		}
+		if _, err := ds.dynastyTrie.Del(member); err != nil {
+			return false, err
+		}
+		if _, err := ds.dynastyTrie.Put(candidate, candidate); err != nil {
+			return false, err
+		}
+		logging.VLog().WithFields(logrus.Fields{
+			"inactive":  member.Base58(),
+			"promoted":  candidate.Base58(),
+			"timestamp": ds.timestamp,
+		}).Info("Replaced an inactive validator.")
+		return true, nil
+	}
+	logging.VLog().WithFields(logrus.Fields{
+		"inactive":  member.Base58(),
+		"timestamp": ds.timestamp,
+	}).Warn("No standby validator to replace the inactive one.")
+	return false, nil
+}
+
+// resetLiveness clear the missed slots when a new dynasty starts.
+func (ds *State) resetLiveness() error {
+	iter, err := ds.livenessTrie.Iterator(nil)
+	if err == storage.ErrKeyNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	keys := [][]byte{}
+	exist, err := iter.Next()
+	for exist {
+		keys = append(keys, iter.Key())
+		exist, err = iter.Next()
+	}
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if _, err := ds.livenessTrie.Del(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
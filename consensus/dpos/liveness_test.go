@@ -0,0 +1,114 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package dpos
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrackLiveness(t *testing.T) {
+	neb := mockNeb(t)
+	dpos := neb.consensus.(*Dpos)
+	standby, err := core.NewContractAddressFromData([]byte("standby"), byteutils.FromUint64(1))
+	assert.Nil(t, err)
+	dpos.standby = []byteutils.Hash{standby.Bytes()}
+
+	genesis := neb.chain.GenesisBlock()
+	miners, err := genesis.WorldState().Dynasty()
+	assert.Nil(t, err)
+
+	// the member of the slot 1 misses one slot too many before the slot.
+	slots := int64(DefaultMaxMissedSlots)*DynastySize + 2
+	next, err := genesis.WorldState().NextConsensusState(slots * BlockIntervalInMs / SecondInMs)
+	assert.Nil(t, err)
+	ds := next.(*State)
+	assert.Nil(t, ds.TrackLiveness(1))
+
+	missed, err := ds.MissedSlots(miners[1])
+	assert.Nil(t, err)
+	assert.Equal(t, DefaultMaxMissedSlots+1, missed)
+	missed, err = ds.MissedSlots(miners[2])
+	assert.Nil(t, err)
+	assert.Equal(t, DefaultMaxMissedSlots, missed)
+
+	dynasty, err := ds.Dynasty()
+	assert.Nil(t, err)
+	assert.Equal(t, DynastySize, len(dynasty))
+	assert.Contains(t, dynasty, standby.Bytes())
+	assert.NotContains(t, dynasty, miners[1])
+	proposer, err := FindProposer(ds.TimeStamp(), dynasty)
+	assert.Nil(t, err)
+	assert.Equal(t, proposer, ds.Proposer())
+
+	// the counters are reset by a new dynasty, only its first slot is missed.
+	next, err = ds.NextConsensusState((DynastyIntervalInMs+BlockIntervalInMs)/SecondInMs - ds.TimeStamp())
+	assert.Nil(t, err)
+	ds = next.(*State)
+	assert.Nil(t, ds.TrackLiveness(2))
+	missed, err = ds.MissedSlots(dynasty[0])
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), missed)
+	missed, err = ds.MissedSlots(dynasty[2])
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), missed)
+
+	// no standby left, the inactive member is kept.
+	dpos.standby = nil
+	next, err = genesis.WorldState().NextConsensusState(slots * BlockIntervalInMs / SecondInMs)
+	assert.Nil(t, err)
+	ds = next.(*State)
+	assert.Nil(t, ds.TrackLiveness(1))
+	dynasty, err = ds.Dynasty()
+	assert.Nil(t, err)
+	assert.Contains(t, dynasty, miners[1])
+}
+
+func TestTrackLivenessActivation(t *testing.T) {
+	neb := mockNeb(t)
+	dpos := neb.consensus.(*Dpos)
+	standby, err := core.NewContractAddressFromData([]byte("standby"), byteutils.FromUint64(1))
+	assert.Nil(t, err)
+	dpos.standby = []byteutils.Hash{standby.Bytes()}
+	core.SetCompatibilityOptions(neb.chain.ChainID())
+	defer core.SetCompatibilityOptions(core.TestNetID)
+	assert.Equal(t, core.LocalValidatorLivenessHeight, core.ValidatorLivenessHeight)
+
+	genesis := neb.chain.GenesisBlock()
+	miners, err := genesis.WorldState().Dynasty()
+	assert.Nil(t, err)
+	coinbase, err := core.AddressParseFromBytes(miners[0])
+	assert.Nil(t, err)
+
+	// the block at the activation height replaces the member missing one slot too many.
+	slots := int64(DefaultMaxMissedSlots)*DynastySize + 2
+	block, err := core.NewBlock(neb.chain.ChainID(), coinbase, genesis)
+	assert.Nil(t, err)
+	assert.Equal(t, core.LocalValidatorLivenessHeight, block.Height())
+	block.SetTimestamp(genesis.Timestamp() + slots*BlockIntervalInMs/SecondInMs)
+	assert.Nil(t, block.LinkParentBlock(neb.chain, genesis))
+
+	dynasty, err := block.WorldState().Dynasty()
+	assert.Nil(t, err)
+	assert.Contains(t, dynasty, standby.Bytes())
+	assert.NotContains(t, dynasty, miners[1])
+}
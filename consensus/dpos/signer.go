@@ -0,0 +1,131 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package dpos
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io/ioutil"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/threshold"
+	"github.com/nebulasio/go-nebulas/neblet/pb"
+)
+
+var (
+	// ErrSignerNotMiner the signer's key is not the miner's
+	ErrSignerNotMiner = errors.New("the signer is not the miner")
+
+	// ErrInvalidThresholdHolderCA the CA file of the share holders has no certificate
+	ErrInvalidThresholdHolderCA = errors.New("invalid threshold holder CA file")
+)
+
+// Signer signs the blocks and evaluates the VRF of the miner instead of the
+// account manager, e.g. a threshold.Coordinator signing by the holders of the
+// shares of the key.
+type Signer interface {
+	PublicKey() []byte
+	// SignBlock signs the hash of the block, the block is passed in proto for
+	// the signer to check what it signs.
+	SignBlock(hash, block []byte) ([]byte, error)
+	GenerateRandomSeed(ancestorHash, parentSeed []byte) ([]byte, []byte, error)
+}
+
+// SetSigner sets the signer of the miner, the remote sign server still takes
+// precedence if it is enabled.
+func (dpos *Dpos) SetSigner(signer Signer) error {
+	if signer != nil {
+		addr, err := core.NewAddressFromPublicKey(signer.PublicKey())
+		if err != nil {
+			return err
+		}
+		if !addr.Equals(dpos.miner) {
+			return ErrSignerNotMiner
+		}
+	}
+	dpos.signer = signer
+	return nil
+}
+
+// thresholdSignerByConfig returns the coordinator of the share holders in the
+// chain config, or nil if there is none.
+func thresholdSignerByConfig(conf *nebletpb.ChainConfig) (*threshold.Coordinator, error) {
+	if len(conf.ThresholdHolders) == 0 {
+		return nil, nil
+	}
+
+	data, err := ioutil.ReadFile(conf.ThresholdCommitments)
+	if err != nil {
+		return nil, err
+	}
+	commitments, err := threshold.UnmarshalCommitments(data)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if len(conf.ThresholdHolderCa) > 0 {
+		data, err := ioutil.ReadFile(conf.ThresholdHolderCa)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, ErrInvalidThresholdHolderCA
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if len(conf.ThresholdHolderCert) > 0 || len(conf.ThresholdHolderKey) > 0 {
+		cert, err := tls.LoadX509KeyPair(conf.ThresholdHolderCert, conf.ThresholdHolderKey)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	holders := make([]threshold.Holder, len(conf.ThresholdHolders))
+	for i, endpoint := range conf.ThresholdHolders {
+		holder, err := threshold.NewRemoteHolder(endpoint, conf.ThresholdHolderToken, tlsConfig)
+		if err != nil {
+			return nil, err
+		}
+		holders[i] = holder
+	}
+	return threshold.NewCoordinator(commitments, holders...)
+}
+
+func (dpos *Dpos) signerSignBlock(block *core.Block) error {
+	pbBlock, err := block.ToProto()
+	if err != nil {
+		return err
+	}
+	data, err := proto.Marshal(pbBlock)
+	if err != nil {
+		return err
+	}
+	sign, err := dpos.signer.SignBlock(block.Hash(), data)
+	if err != nil {
+		return err
+	}
+	block.SetSignature(keystore.SECP256K1, sign)
+	return nil
+}
@@ -0,0 +1,173 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package dpos
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/core/state"
+	"github.com/nebulasio/go-nebulas/crypto"
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1/vrf/secp256k1VRF"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+)
+
+// Since core.VRFProposerHeight the proposer of a slot is no longer scheduled
+// round-robin. Each member of the dynasty evaluates its VRF over the seed of
+// the epoch and the slot, and runs for the slot if the index is low enough,
+// VRFProposersPerSlot members are expected to run for each one. The proof is
+// carried by the consensus root of the block, and the lowest index among the
+// blocks competing for a slot wins the fork choice.
+
+// VRFProposersPerSlot the number of proposers expected to run for a slot
+const VRFProposersPerSlot = 2
+
+// Errors in vrf proposer election
+var (
+	ErrNotElectedProposer   = errors.New("the miner is not elected for the slot")
+	ErrInvalidProposerProof = errors.New("invalid block proposer proof")
+)
+
+// lotteryInput return the VRF inputs of the slot, the seed of the epoch is
+// derived from the dynasty and its index, which no proposer can bias.
+func lotteryInput(dynastyRoot byteutils.Hash, timestamp int64) (epochSeed []byte, slot []byte) {
+	epoch := timestamp * SecondInMs / DynastyIntervalInMs
+	return hash.Sha3256(dynastyRoot, byteutils.FromInt64(epoch)), byteutils.FromInt64(timestamp)
+}
+
+// elected check if the index wins the slot in a dynasty of the size.
+func elected(index []byte, size int) bool {
+	if size <= 0 {
+		return false
+	}
+	x := new(big.Int).SetBytes(index)
+	x.Mul(x, big.NewInt(int64(size)))
+	limit := new(big.Int).Lsh(big.NewInt(VRFProposersPerSlot), 256)
+	return x.Cmp(limit) < 0
+}
+
+// proposerIndex return the index of the proposer proof of the block, nil if it has none.
+func proposerIndex(block *core.Block) []byte {
+	index, err := secp256k1VRF.ProofToIndex(block.ConsensusRoot().ProposerProof)
+	if err != nil {
+		return nil
+	}
+	return index[:]
+}
+
+// slotKey return the key of the block in the slot cache, the winners of
+// a slot may propose one block each since core.VRFProposerHeight.
+func slotKey(block *core.Block) interface{} {
+	if block.Height() >= core.VRFProposerHeight {
+		return fmt.Sprintf("%d/%s", block.Timestamp(), byteutils.Hex(block.ConsensusRoot().Proposer))
+	}
+	return block.Timestamp()
+}
+
+// electProposer runs the miner for the slot of the consensus state, and sets
+// it as the proposer with the proof if it is elected.
+func (dpos *Dpos) electProposer(consensusState state.ConsensusState) error {
+	miners, err := consensusState.Dynasty()
+	if err != nil {
+		return err
+	}
+	isMember := false
+	for _, miner := range miners {
+		if miner.Equals(dpos.miner.Bytes()) {
+			isMember = true
+			break
+		}
+	}
+	if !isMember {
+		return ErrInvalidBlockProposer
+	}
+
+	adminService, conn, err := dpos.dialRemoteSignServer()
+	defer func() {
+		if conn != nil {
+			conn.Close()
+		}
+	}()
+	if err != nil {
+		return err
+	}
+
+	epochSeed, slot := lotteryInput(consensusState.DynastyRoot(), consensusState.TimeStamp())
+	index, proof, err := dpos.evaluateVRF(epochSeed, slot, adminService)
+	if err != nil {
+		return err
+	}
+	if !elected(index, len(miners)) {
+		return ErrNotElectedProposer
+	}
+
+	electedState, ok := consensusState.(core.ElectedConsensusState)
+	if !ok {
+		return ErrInvalidArgument
+	}
+	electedState.SetProposer(dpos.miner.Bytes(), proof)
+	return nil
+}
+
+// verifyProposerProof return the proposer of the block if it is a member of the
+// dynasty elected for the slot. The proof is verified with the public key signing
+// the block, and the signer is checked to be the proposer by verifyBlockSign.
+func verifyProposerProof(block *core.Block, miners []byteutils.Hash) (byteutils.Hash, error) {
+	proposer := byteutils.Hash(block.ConsensusRoot().Proposer)
+	isMember := false
+	for _, miner := range miners {
+		if miner.Equals(proposer) {
+			isMember = true
+			break
+		}
+	}
+	if !isMember {
+		return nil, ErrInvalidBlockProposer
+	}
+
+	signature, err := crypto.NewSignature(block.Alg())
+	if err != nil {
+		return nil, err
+	}
+	pub, err := signature.RecoverPublic(block.Hash(), block.Signature())
+	if err != nil {
+		return nil, err
+	}
+	pubdata, err := pub.Encoded()
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := secp256k1VRF.NewVRFVerifierFromRawKey(pubdata)
+	if err != nil {
+		return nil, err
+	}
+
+	epochSeed, slot := lotteryInput(block.ConsensusRoot().DynastyRoot, block.Timestamp())
+	index, err := verifier.ProofToHash(hash.Sha3256(epochSeed, slot), block.ConsensusRoot().ProposerProof)
+	if err != nil {
+		return nil, ErrInvalidProposerProof
+	}
+	if !elected(index[:], len(miners)) {
+		return nil, ErrInvalidProposerProof
+	}
+	return proposer, nil
+}
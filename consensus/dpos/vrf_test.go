@@ -0,0 +1,109 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package dpos
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestElected(t *testing.T) {
+	assert.True(t, elected(make([]byte, 32), DynastySize))
+	assert.False(t, elected(bytes.Repeat([]byte{0xff}, 32), DynastySize))
+	assert.False(t, elected(make([]byte, 32), 0))
+
+	// VRFProposersPerSlot out of the dynasty win a slot on average.
+	limit := make([]byte, 32)
+	limit[0] = byte(256 * VRFProposersPerSlot / DynastySize)
+	assert.True(t, elected(limit, DynastySize))
+	limit[0]++
+	assert.False(t, elected(limit, DynastySize))
+}
+
+func TestElectProposer(t *testing.T) {
+	neb := mockNeb(t)
+	dpos := neb.consensus.(*Dpos)
+	tail := neb.chain.TailBlock()
+	miners, err := tail.WorldState().Dynasty()
+	assert.Nil(t, err)
+
+	// the local chains elect the proposer since the third block, the second one is scheduled.
+	core.SetCompatibilityOptions(neb.chain.ChainID())
+	defer core.SetCompatibilityOptions(core.TestNetID)
+	assert.Equal(t, core.LocalVRFProposerHeight, core.VRFProposerHeight)
+	coinbase, err := core.AddressParseFromBytes(miners[0])
+	assert.Nil(t, err)
+	parent, err := core.NewBlock(neb.chain.ChainID(), coinbase, tail)
+	assert.Nil(t, err)
+	assert.Equal(t, parent.Timestamp(), slotKey(parent))
+
+	// run the dynasty for the slots until one is elected.
+	var block *core.Block
+	var proposer *core.Address
+	for slot := int64(1); slot <= 20 && block == nil; slot++ {
+		for _, miner := range miners {
+			addr, err := core.AddressParseFromBytes(miner)
+			assert.Nil(t, err)
+			assert.Nil(t, neb.am.Unlock(addr, []byte("passphrase"), keystore.DefaultUnlockDuration))
+			consensusState, err := tail.WorldState().NextConsensusState(slot * BlockIntervalInMs / SecondInMs)
+			assert.Nil(t, err)
+
+			dpos.miner = addr
+			err = dpos.electProposer(consensusState)
+			if err == ErrNotElectedProposer {
+				continue
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, miner, consensusState.Proposer())
+
+			block, err = core.NewBlock(neb.chain.ChainID(), addr, parent)
+			assert.Nil(t, err)
+			block.WorldState().SetConsensusState(consensusState)
+			block.SetTimestamp(consensusState.TimeStamp())
+			assert.Nil(t, block.Seal())
+			assert.Nil(t, neb.am.SignBlock(addr, block))
+			proposer = addr
+			break
+		}
+	}
+	assert.NotNil(t, block)
+	assert.Equal(t, core.LocalVRFProposerHeight, block.Height())
+
+	// every node verifies the proof carried by the block.
+	verified, err := verifyProposerProof(block, miners)
+	assert.Nil(t, err)
+	assert.Equal(t, proposer.Bytes(), []byte(verified))
+	assert.NotNil(t, proposerIndex(block))
+	assert.NotEqual(t, block.Timestamp(), slotKey(block))
+
+	// the proof is only valid for the proposer's key.
+	root := block.ConsensusRoot()
+	root.ProposerProof[0] ^= 0xff
+	_, err = verifyProposerProof(block, miners)
+	assert.Equal(t, ErrInvalidProposerProof, err)
+	root.ProposerProof[0] ^= 0xff
+
+	// the proposer must be a member of the dynasty.
+	_, err = verifyProposerProof(block, nil)
+	assert.Equal(t, ErrInvalidBlockProposer, err)
+}
@@ -28,9 +28,11 @@ var _ = math.Inf
 const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 
 type ConsensusRoot struct {
-	Timestamp   int64  `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	Proposer    []byte `protobuf:"bytes,2,opt,name=proposer,proto3" json:"proposer,omitempty"`
-	DynastyRoot []byte `protobuf:"bytes,3,opt,name=dynasty_root,json=dynastyRoot,proto3" json:"dynasty_root,omitempty"`
+	Timestamp     int64  `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Proposer      []byte `protobuf:"bytes,2,opt,name=proposer,proto3" json:"proposer,omitempty"`
+	DynastyRoot   []byte `protobuf:"bytes,3,opt,name=dynasty_root,json=dynastyRoot,proto3" json:"dynasty_root,omitempty"`
+	ProposerProof []byte `protobuf:"bytes,4,opt,name=proposer_proof,json=proposerProof,proto3" json:"proposer_proof,omitempty"`
+	LivenessRoot  []byte `protobuf:"bytes,5,opt,name=liveness_root,json=livenessRoot,proto3" json:"liveness_root,omitempty"`
 }
 
 func (m *ConsensusRoot) Reset()                    { *m = ConsensusRoot{} }
@@ -59,6 +61,20 @@ func (m *ConsensusRoot) GetDynastyRoot() []byte {
 	return nil
 }
 
+func (m *ConsensusRoot) GetProposerProof() []byte {
+	if m != nil {
+		return m.ProposerProof
+	}
+	return nil
+}
+
+func (m *ConsensusRoot) GetLivenessRoot() []byte {
+	if m != nil {
+		return m.LivenessRoot
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ConsensusRoot)(nil), "consensuspb.ConsensusRoot")
 }
@@ -66,14 +82,16 @@ func init() {
 func init() { proto.RegisterFile("state.proto", fileDescriptorState) }
 
 var fileDescriptorState = []byte{
-	// 134 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x2e, 0x2e, 0x49, 0x2c,
+	// 168 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe3, 0xe2, 0x2e, 0x2e, 0x49, 0x2c,
 	0x49, 0xd5, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0xe2, 0x4e, 0xce, 0xcf, 0x2b, 0x4e, 0xcd, 0x2b,
-	0x2e, 0x2d, 0x2e, 0x48, 0x52, 0xca, 0xe1, 0xe2, 0x75, 0x86, 0x71, 0x83, 0xf2, 0xf3, 0x4b, 0x84,
-	0x64, 0xb8, 0x38, 0x4b, 0x32, 0x73, 0x53, 0x8b, 0x4b, 0x12, 0x73, 0x0b, 0x24, 0x18, 0x15, 0x18,
-	0x35, 0x98, 0x83, 0x10, 0x02, 0x42, 0x52, 0x5c, 0x1c, 0x05, 0x45, 0xf9, 0x05, 0xf9, 0xc5, 0xa9,
-	0x45, 0x12, 0x4c, 0x0a, 0x8c, 0x1a, 0x3c, 0x41, 0x70, 0xbe, 0x90, 0x22, 0x17, 0x4f, 0x4a, 0x65,
-	0x5e, 0x62, 0x71, 0x49, 0x65, 0x7c, 0x51, 0x7e, 0x7e, 0x89, 0x04, 0x33, 0x58, 0x9e, 0x1b, 0x2a,
-	0x06, 0x32, 0x3c, 0x89, 0x0d, 0xec, 0x02, 0x63, 0x40, 0x00, 0x00, 0x00, 0xff, 0xff, 0x27, 0xd5,
-	0xcf, 0x41, 0x90, 0x00, 0x00, 0x00,
+	0x2e, 0x2d, 0x2e, 0x48, 0x52, 0xda, 0xc1, 0xc8, 0xc5, 0xeb, 0x0c, 0xe3, 0x07, 0xe5, 0xe7, 0x97,
+	0x08, 0xc9, 0x70, 0x71, 0x96, 0x64, 0xe6, 0xa6, 0x02, 0x75, 0xe4, 0x16, 0x48, 0x30, 0x2a, 0x30,
+	0x6a, 0x30, 0x07, 0x21, 0x04, 0x84, 0xa4, 0xb8, 0x38, 0x80, 0xa6, 0x14, 0xe4, 0x17, 0xa7, 0x16,
+	0x49, 0x30, 0x01, 0x25, 0x79, 0x82, 0xe0, 0x7c, 0x21, 0x45, 0x2e, 0x9e, 0x94, 0xca, 0xbc, 0xc4,
+	0xe2, 0x92, 0xca, 0xf8, 0x22, 0xa0, 0x49, 0x12, 0xcc, 0x60, 0x79, 0x6e, 0xa8, 0x18, 0xd8, 0x70,
+	0x55, 0x2e, 0x3e, 0x98, 0xf2, 0x78, 0x20, 0x23, 0x3f, 0x4d, 0x82, 0x05, 0xac, 0x88, 0x17, 0x26,
+	0x1a, 0x00, 0x12, 0x14, 0x52, 0xe6, 0xe2, 0xcd, 0xc9, 0x2c, 0x4b, 0xcd, 0x4b, 0x2d, 0x2e, 0x86,
+	0x18, 0xc5, 0x0a, 0x56, 0xc5, 0x03, 0x13, 0x04, 0x99, 0x95, 0xc4, 0x06, 0xf6, 0x8e, 0x31, 0x00,
+	0x98, 0x90, 0x75, 0x75, 0xdd, 0x00, 0x00, 0x00,
 }
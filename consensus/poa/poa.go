@@ -0,0 +1,465 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package poa
+
+import (
+	"bytes"
+	"errors"
+	"time"
+
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/core/state"
+	"github.com/nebulasio/go-nebulas/net"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// const
+const (
+	DefaultMaxUnlockDuration time.Duration = 1<<63 - 1
+)
+
+// Consensus Related Constants
+const (
+	SecondInMs        = int64(1000)
+	DefaultPeriodInMs = int64(5000)
+)
+
+// Errors in PoA Consensus
+var (
+	ErrMissingConfigForPoa   = errors.New("missing configuration for PoA")
+	ErrInvalidPeriod         = errors.New("invalid poa period, should be a positive multiple of 1000 ms")
+	ErrInvalidBlockTimestamp = errors.New("invalid block timestamp, should be same as consensus's timestamp")
+	ErrInvalidBlockInterval  = errors.New("invalid block interval")
+	ErrInvalidBlockProposer  = errors.New("invalid block proposer")
+	ErrCannotMintWhenPending = errors.New("cannot mint block now, waiting for cancel pending again")
+	ErrCannotMintWhenDisable = errors.New("cannot mint block now, waiting for enable it again")
+	ErrBlockMintedInSlot     = errors.New("cannot mint block now, there is a block minted in current slot")
+	ErrNoTransactionToSeal   = errors.New("cannot mint block now, waiting for transactions to seal")
+	ErrLocalClockDrifted     = errors.New("cannot mint block now, the local clock drifts from the peers")
+	ErrNotBlockForgTime      = errors.New("now is not time to forg block")
+	ErrFoundNilSigner        = errors.New("found no authorized signer")
+	ErrCloneSignersTrie      = errors.New("Failed to clone signers trie")
+	ErrSignersChanged        = errors.New("the authorized signers never change")
+)
+
+// periodOf return the period between blocks in seconds of the genesis conf
+func periodOf(conf *corepb.GenesisConsensusPoa) (int64, error) {
+	periodInMs := conf.GetPeriodInMs()
+	if periodInMs == 0 {
+		periodInMs = DefaultPeriodInMs
+	}
+	if periodInMs < 0 || periodInMs%SecondInMs != 0 {
+		return 0, ErrInvalidPeriod
+	}
+	return periodInMs / SecondInMs, nil
+}
+
+// Poa Proof-of-Authority, the signers authorized in the genesis conf take turns
+// to mint a block every period, a signer offline leaves its slot empty.
+type Poa struct {
+	quitCh chan bool
+
+	chain *core.BlockChain
+	ns    net.Service
+	am    core.AccountManager
+
+	coinbase *core.Address
+	miner    *core.Address
+
+	period  int64
+	signers int
+
+	// seal a block only when transactions are pending, in dev mode.
+	instant bool
+
+	enable  bool
+	pending bool
+}
+
+// NewPoa create Poa instance.
+func NewPoa() *Poa {
+	return &Poa{
+		quitCh:  make(chan bool, 5),
+		enable:  false,
+		pending: true,
+	}
+}
+
+// Setup a poa consensus handler
+func (poa *Poa) Setup(neblet core.Neblet) error {
+	poa.chain = neblet.BlockChain()
+	poa.ns = neblet.NetService()
+	poa.am = neblet.AccountManager()
+
+	conf := neblet.Genesis().GetConsensus().GetPoa()
+	if conf == nil || len(conf.Signers) == 0 {
+		return ErrMissingConfigForPoa
+	}
+	period, err := periodOf(conf)
+	if err != nil {
+		return err
+	}
+	poa.period = period
+	poa.signers = len(conf.Signers)
+
+	chainConfig := neblet.Config().Chain
+	if chainConfig.StartMine {
+		coinbase, err := core.AddressParse(chainConfig.Coinbase)
+		if err != nil {
+			logging.CLog().WithFields(logrus.Fields{
+				"address": chainConfig.Coinbase,
+				"err":     err,
+			}).Error("Failed to parse coinbase address.")
+			return err
+		}
+		miner, err := core.AddressParse(chainConfig.Miner)
+		if err != nil {
+			logging.CLog().WithFields(logrus.Fields{
+				"address": chainConfig.Miner,
+				"err":     err,
+			}).Error("Failed to parse miner address.")
+			return err
+		}
+		poa.coinbase = coinbase
+		poa.miner = miner
+	}
+	poa.instant = chainConfig.Dev && chainConfig.DevPeriod == 0
+	return nil
+}
+
+// Start start poa service.
+func (poa *Poa) Start() {
+	logging.CLog().Info("Starting PoA Mining...")
+	go poa.blockLoop()
+}
+
+// Stop stop poa service.
+func (poa *Poa) Stop() {
+	logging.CLog().Info("Stopping PoA Mining...")
+	poa.DisableMining()
+	poa.quitCh <- true
+}
+
+// EnableMining start the consensus
+func (poa *Poa) EnableMining(passphrase string) error {
+	if err := poa.am.Unlock(poa.miner, []byte(passphrase), DefaultMaxUnlockDuration); err != nil {
+		return err
+	}
+	poa.enable = true
+	logging.CLog().Info("Enabled PoA Mining...")
+	return nil
+}
+
+// DisableMining stop the consensus
+func (poa *Poa) DisableMining() error {
+	if err := poa.am.Lock(poa.miner); err != nil {
+		return err
+	}
+	poa.enable = false
+	logging.CLog().Info("Disable PoA Mining...")
+	return nil
+}
+
+// Enable returns is mining
+func (poa *Poa) Enable() bool {
+	return poa.enable
+}
+
+// Pending return if consensus can do mining now
+func (poa *Poa) Pending() bool {
+	return poa.pending
+}
+
+// SuspendMining pend poa mining
+func (poa *Poa) SuspendMining() {
+	logging.CLog().Info("Suspended PoA Mining.")
+	poa.pending = true
+}
+
+// ResumeMining continue poa mining
+func (poa *Poa) ResumeMining() {
+	logging.CLog().Info("Resumed PoA Mining.")
+	poa.pending = false
+}
+
+// VerifyHeader verify the block is signed by the signer in turn
+func (poa *Poa) VerifyHeader(block *core.Block) error {
+	if block.Timestamp() != block.ConsensusRoot().Timestamp {
+		return ErrInvalidBlockTimestamp
+	}
+	if block.Timestamp() <= 0 || block.Timestamp()%poa.period != 0 {
+		return ErrInvalidBlockInterval
+	}
+	signers, err := poa.chain.TailBlock().WorldState().Dynasty()
+	if err != nil {
+		return err
+	}
+	proposer, err := InTurnSigner(block.Timestamp(), poa.period, signers)
+	if err != nil {
+		return err
+	}
+	signer, err := core.RecoverSignerFromSignature(block.Alg(), block.Hash(), block.Signature())
+	if err != nil {
+		return err
+	}
+	if !proposer.Equals(signer.Bytes()) {
+		logging.VLog().WithFields(logrus.Fields{
+			"signer":   signer,
+			"expected": proposer.Base58(),
+			"block":    block,
+		}).Debug("Found a block signed out of turn.")
+		return ErrInvalidBlockProposer
+	}
+	if block.Height() >= core.RandomAvailableHeight && !block.HasRandomSeed() {
+		return core.ErrInvalidBlockRandom
+	}
+	return nil
+}
+
+// VerifyProposer check the block is signed by the signer in turn of its slot, see core.HeaderVerifier
+func (poa *Poa) VerifyProposer(block *core.Block, signers []byteutils.Hash) error {
+	if block.Timestamp() != block.ConsensusRoot().Timestamp {
+		return ErrInvalidBlockTimestamp
+	}
+	if block.Timestamp() <= 0 || block.Timestamp()%poa.period != 0 {
+		return ErrInvalidBlockInterval
+	}
+	proposer, err := InTurnSigner(block.Timestamp(), poa.period, signers)
+	if err != nil {
+		return err
+	}
+	signer, err := core.RecoverSignerFromSignature(block.Alg(), block.Hash(), block.Signature())
+	if err != nil {
+		return err
+	}
+	if !proposer.Equals(signer.Bytes()) {
+		return ErrInvalidBlockProposer
+	}
+	return nil
+}
+
+// VerifyDynasty check the signers of the block are the ones of its parent, see core.HeaderVerifier
+func (poa *Poa) VerifyDynasty(parent *core.Block, block *core.Block, parentSigners []byteutils.Hash, signers []byteutils.Hash) error {
+	if !bytes.Equal(parent.ConsensusRoot().DynastyRoot, block.ConsensusRoot().DynastyRoot) {
+		return ErrSignersChanged
+	}
+	return nil
+}
+
+// Prepare generate the random seed of the new block, and set the consensus state of its slot
+func (poa *Poa) Prepare(block *core.Block, consensusState state.ConsensusState) error {
+	if block.Height() >= core.RandomAvailableHeight {
+		ancestorHash, parentSeed, err := poa.chain.GetInputForVRFSigner(block.ParentHash(), block.Height())
+		if err != nil {
+			return err
+		}
+		vrfSeed, vrfProof, err := poa.am.GenerateRandomSeed(poa.miner, ancestorHash, parentSeed)
+		if err != nil {
+			return err
+		}
+		block.SetRandomSeed(vrfSeed, vrfProof)
+	}
+	block.WorldState().SetConsensusState(consensusState)
+	block.SetTimestamp(consensusState.TimeStamp())
+	return nil
+}
+
+// Finalize seal the new block, poa has nothing to add once the txs are collected
+func (poa *Poa) Finalize(block *core.Block) error {
+	return block.Seal()
+}
+
+// Seal sign the new block by the miner
+func (poa *Poa) Seal(block *core.Block) error {
+	return poa.am.SignBlock(poa.miner, block)
+}
+
+// ForkChoice select the longest chain, the lowest hash among the same height
+func (poa *Poa) ForkChoice() error {
+	bc := poa.chain
+	tailBlock := bc.TailBlock()
+	newTailBlock := tailBlock
+	for _, v := range bc.DetachedTailBlocks() {
+		if v.Height() > newTailBlock.Height() ||
+			(v.Height() == newTailBlock.Height() && byteutils.Less(v.Hash(), newTailBlock.Hash())) {
+			newTailBlock = v
+		}
+	}
+	if newTailBlock.Hash().Equals(tailBlock.Hash()) {
+		return nil
+	}
+	if err := bc.SetTailBlock(newTailBlock); err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"new tail": newTailBlock,
+			"old tail": tailBlock,
+			"err":      err,
+		}).Debug("Failed to set new tail block.")
+		return err
+	}
+	logging.VLog().WithFields(logrus.Fields{
+		"new tail": newTailBlock,
+		"old tail": tailBlock,
+	}).Info("change to new tail.")
+	return nil
+}
+
+// UpdateLIB a block is irreversible once more than half of the signers minted on top of it
+func (poa *Poa) UpdateLIB() {
+	lib := poa.chain.LIB()
+	tail := poa.chain.TailBlock()
+	quorum := poa.signers/2 + 1
+	signers := make(map[string]bool)
+	for cur := tail; cur != nil && !cur.Hash().Equals(lib.Hash()); cur = poa.chain.GetBlock(cur.ParentHash()) {
+		if core.CheckGenesisBlock(cur) {
+			return
+		}
+		signers[byteutils.Hex(cur.ConsensusRoot().Proposer)] = true
+		if len(signers) < quorum {
+			continue
+		}
+		if err := poa.chain.StoreLIBHashToStorage(cur); err != nil {
+			logging.VLog().WithFields(logrus.Fields{
+				"tail": tail,
+				"lib":  cur,
+			}).Debug("Failed to store latest irreversible block.")
+			return
+		}
+		poa.chain.SetLIB(cur)
+		logging.CLog().WithFields(logrus.Fields{
+			"lib.new": cur,
+			"lib.old": lib,
+			"tail":    tail,
+		}).Info("Succeed to update latest irreversible block.")
+		poa.chain.EventEmitter().Trigger(&state.Event{
+			Topic: core.TopicLibBlock,
+			Data:  cur.String(),
+		})
+		return
+	}
+}
+
+// CheckTimeout check whether the block is expired
+func (poa *Poa) CheckTimeout(block *core.Block) bool {
+	nowInMs := time.Now().Unix() * SecondInMs
+	behindInMs := nowInMs - block.Timestamp()*SecondInMs
+	if behindInMs > poa.period*SecondInMs {
+		logging.VLog().WithFields(logrus.Fields{
+			"block": block,
+			"now":   nowInMs,
+			"diff":  behindInMs,
+			"err":   "timeout - expired block",
+		}).Warn("Found a expired block.")
+		return true
+	}
+	return false
+}
+
+// CheckDoubleMint the slots of poa are not tracked
+func (poa *Poa) CheckDoubleMint(block *core.Block) bool {
+	return false
+}
+
+// NumberOfBlocksInDynasty a dynasty of poa is a round of the signers
+func (poa *Poa) NumberOfBlocksInDynasty() uint64 {
+	return uint64(poa.signers)
+}
+
+func (poa *Poa) mintBlock(now int64) error {
+	if !poa.enable {
+		return ErrCannotMintWhenDisable
+	}
+	if poa.pending {
+		return ErrCannotMintWhenPending
+	}
+	if poa.instant && poa.chain.TransactionPool().Empty() {
+		return ErrNoTransactionToSeal
+	}
+
+	tail := poa.chain.TailBlock()
+	slot := now / poa.period * poa.period
+	if slot <= tail.Timestamp() {
+		return ErrBlockMintedInSlot
+	}
+	consensusState, err := tail.WorldState().NextConsensusState(slot - tail.Timestamp())
+	if err != nil {
+		return err
+	}
+	if !consensusState.Proposer().Equals(poa.miner.Bytes()) {
+		return ErrInvalidBlockProposer
+	}
+
+	// a drifted clock would mint in the slots of the others.
+	if node := poa.ns.Node(); node != nil && node.Clock().Drifted() {
+		offset, samples := node.Clock().Offset()
+		logging.CLog().WithFields(logrus.Fields{
+			"offset":  offset,
+			"samples": samples,
+		}).Error("Refused to mint block, the local clock drifts from the peers.")
+		return ErrLocalClockDrifted
+	}
+
+	block, err := core.NewBlock(poa.chain.ChainID(), poa.coinbase, tail)
+	if err != nil {
+		return err
+	}
+	if err := poa.Prepare(block, consensusState); err != nil {
+		return err
+	}
+	// leave the rest of the period to the propagation.
+	block.CollectTransactions(now*SecondInMs + poa.period*SecondInMs/3)
+	if err := poa.Finalize(block); err != nil {
+		go block.ReturnTransactions()
+		return err
+	}
+	if err := poa.Seal(block); err != nil {
+		go block.ReturnTransactions()
+		return err
+	}
+	if err := poa.chain.BlockPool().PushAndBroadcast(block); err != nil {
+		logging.CLog().WithFields(logrus.Fields{
+			"tail":  tail,
+			"block": block,
+			"err":   err,
+		}).Error("Failed to push new minted block into block pool")
+		go block.ReturnTransactions()
+		return err
+	}
+	logging.CLog().WithFields(logrus.Fields{
+		"tail":  tail,
+		"block": block,
+	}).Info("Minted new block")
+	return nil
+}
+
+func (poa *Poa) blockLoop() {
+	logging.CLog().Info("Started PoA Mining.")
+	timeChan := time.NewTicker(time.Second).C
+	for {
+		select {
+		case now := <-timeChan:
+			poa.mintBlock(now.Unix())
+		case <-poa.quitCh:
+			logging.CLog().Info("Stopped PoA Mining.")
+			return
+		}
+	}
+}
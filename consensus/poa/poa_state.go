@@ -0,0 +1,212 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package poa
+
+import (
+	"fmt"
+
+	"github.com/nebulasio/go-nebulas/common/trie"
+	"github.com/nebulasio/go-nebulas/consensus/pb"
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/core/state"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+)
+
+// State carry context in poa consensus
+type State struct {
+	timestamp int64
+	proposer  byteutils.Hash
+	period    int64
+
+	signersTrie *trie.Trie // key: signer, val: signer
+}
+
+// NewState create a new poa state
+func (poa *Poa) NewState(root *consensuspb.ConsensusRoot, stor storage.Storage, needChangeLog bool) (state.ConsensusState, error) {
+	var signersRoot byteutils.Hash
+	if root != nil {
+		signersRoot = root.DynastyRoot
+	}
+	signersTrie, err := trie.NewTrie(signersRoot, stor, needChangeLog)
+	if err != nil {
+		return nil, err
+	}
+	return &State{
+		timestamp: root.Timestamp,
+		proposer:  root.Proposer,
+		period:    poa.period,
+
+		signersTrie: signersTrie,
+	}, nil
+}
+
+// GenesisConsensusState create a new genesis poa state
+func (poa *Poa) GenesisConsensusState(chain *core.BlockChain, conf *corepb.Genesis) (state.ConsensusState, error) {
+	poaConf := conf.GetConsensus().GetPoa()
+	if poaConf == nil || len(poaConf.Signers) == 0 {
+		return nil, ErrMissingConfigForPoa
+	}
+	period, err := periodOf(poaConf)
+	if err != nil {
+		return nil, err
+	}
+	signersTrie, err := trie.NewTrie(nil, chain.Storage(), false)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range poaConf.Signers {
+		signer, err := core.AddressParse(v)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := signersTrie.Put(signer.Bytes(), signer.Bytes()); err != nil {
+			return nil, err
+		}
+	}
+	return &State{
+		timestamp: core.GenesisTimestamp,
+		proposer:  nil,
+		period:    period,
+
+		signersTrie: signersTrie,
+	}, nil
+}
+
+func (ps *State) String() string {
+	proposer := ""
+	if ps.proposer != nil {
+		proposer = ps.proposer.String()
+	}
+	return fmt.Sprintf(`{"timestamp": %d, "proposer": "%s", "signers": "%s"}`,
+		ps.timestamp,
+		proposer,
+		byteutils.Hex(ps.signersTrie.RootHash()),
+	)
+}
+
+// Replay a poa state
+func (ps *State) Replay(done state.ConsensusState) error {
+	state := done.(*State)
+	_, err := ps.signersTrie.Replay(state.signersTrie)
+	return err
+}
+
+// Clone a poa state
+func (ps *State) Clone() (state.ConsensusState, error) {
+	signersTrie, err := ps.signersTrie.Clone()
+	if err != nil {
+		return nil, ErrCloneSignersTrie
+	}
+	return &State{
+		timestamp: ps.timestamp,
+		proposer:  ps.proposer,
+		period:    ps.period,
+
+		signersTrie: signersTrie,
+	}, nil
+}
+
+// RootHash hash poa state, the signers are kept as the dynasty
+func (ps *State) RootHash() *consensuspb.ConsensusRoot {
+	return &consensuspb.ConsensusRoot{
+		DynastyRoot: ps.signersTrie.RootHash(),
+		Timestamp:   ps.TimeStamp(),
+		Proposer:    ps.Proposer(),
+	}
+}
+
+// Dynasty return the authorized signers
+func (ps *State) Dynasty() ([]byteutils.Hash, error) {
+	return TraverseSigners(ps.signersTrie)
+}
+
+// DynastyRoot return the roothash of the authorized signers
+func (ps *State) DynastyRoot() byteutils.Hash {
+	return ps.signersTrie.RootHash()
+}
+
+// Proposer return the signer in turn
+func (ps *State) Proposer() byteutils.Hash {
+	return ps.proposer
+}
+
+// TimeStamp return the current timestamp
+func (ps *State) TimeStamp() int64 {
+	return ps.timestamp
+}
+
+// NextConsensusState return the new state after some periods elapsed
+func (ps *State) NextConsensusState(elapsedSecond int64, worldState state.WorldState) (state.ConsensusState, error) {
+	if elapsedSecond <= 0 || elapsedSecond%ps.period != 0 {
+		return nil, ErrNotBlockForgTime
+	}
+	signersTrie, err := ps.signersTrie.Clone()
+	if err != nil {
+		return nil, ErrCloneSignersTrie
+	}
+	consensusState := &State{
+		timestamp: ps.timestamp + elapsedSecond,
+		period:    ps.period,
+
+		signersTrie: signersTrie,
+	}
+	signers, err := TraverseSigners(signersTrie)
+	if err != nil {
+		return nil, err
+	}
+	consensusState.proposer, err = InTurnSigner(consensusState.timestamp, ps.period, signers)
+	if err != nil {
+		return nil, err
+	}
+	return consensusState, nil
+}
+
+// InTurnSigner return the signer of the slot at the timestamp, the signers take turns.
+func InTurnSigner(timestamp int64, period int64, signers []byteutils.Hash) (byteutils.Hash, error) {
+	if period <= 0 || timestamp%period != 0 {
+		return nil, ErrNotBlockForgTime
+	}
+	if len(signers) == 0 {
+		return nil, ErrFoundNilSigner
+	}
+	return signers[(timestamp/period)%int64(len(signers))], nil
+}
+
+// TraverseSigners return all authorized signers
+func TraverseSigners(signersTrie *trie.Trie) ([]byteutils.Hash, error) {
+	signers := []byteutils.Hash{}
+	iter, err := signersTrie.Iterator(nil)
+	if err == storage.ErrKeyNotFound {
+		return signers, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	exist, err := iter.Next()
+	for exist {
+		signers = append(signers, iter.Value())
+		exist, err = iter.Next()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return signers, nil
+}
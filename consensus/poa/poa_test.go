@@ -0,0 +1,206 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package poa
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/account"
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/neblet/pb"
+	"github.com/nebulasio/go-nebulas/net"
+	"github.com/nebulasio/go-nebulas/nf/nvm"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	received = []byte{}
+
+	// the keys are shared with the dpos tests.
+	mockSigners = []string{
+		"n1FF1nz6tarkDVwWQkMnnwFPuPKUaQTdptE",
+		"n1GmkKH6nBMw4rrjt16RrJ9WcgvKUtAZP1s",
+		"n1H4MYms9F55ehcvygwWE71J8tJC4CRr2so",
+	}
+)
+
+type Neb struct {
+	config    *nebletpb.Config
+	chain     *core.BlockChain
+	ns        net.Service
+	am        *account.Manager
+	genesis   *corepb.Genesis
+	storage   storage.Storage
+	consensus core.Consensus
+	emitter   *core.EventEmitter
+	nvm       core.NVM
+}
+
+func mockNeb(t *testing.T) *Neb {
+	storage := storage.NewMemoryBackend()
+	eventEmitter := core.NewEventEmitter(1024)
+	genesisConf := &corepb.Genesis{
+		Meta: &corepb.GenesisMeta{ChainId: 100},
+		Consensus: &corepb.GenesisConsensus{
+			Poa: &corepb.GenesisConsensusPoa{Signers: mockSigners},
+		},
+		TokenDistribution: []*corepb.GenesisTokenDistribution{
+			{Address: mockSigners[0], Value: "5000000000000000000000000"},
+		},
+	}
+	poa := NewPoa()
+	neb := &Neb{
+		genesis:   genesisConf,
+		storage:   storage,
+		emitter:   eventEmitter,
+		consensus: poa,
+		nvm:       nvm.NewNebulasVM(),
+		config: &nebletpb.Config{
+			Chain: &nebletpb.ChainConfig{
+				ChainId:    genesisConf.Meta.ChainId,
+				Keydir:     "../dpos/keydir",
+				StartMine:  true,
+				Coinbase:   mockSigners[0],
+				Miner:      mockSigners[0],
+				Passphrase: "passphrase",
+			},
+		},
+		ns: mockNetService{},
+	}
+
+	am, _ := account.NewManager(neb)
+	neb.am = am
+
+	chain, err := core.NewBlockChain(neb)
+	assert.Nil(t, err)
+	neb.chain = chain
+	assert.Nil(t, poa.Setup(neb))
+	assert.Nil(t, chain.Setup(neb))
+	neb.chain.BlockPool().RegisterInNetwork(neb.ns)
+
+	eventEmitter.Start()
+	return neb
+}
+
+func (n *Neb) Config() *nebletpb.Config            { return n.config }
+func (n *Neb) BlockChain() *core.BlockChain        { return n.chain }
+func (n *Neb) NetService() net.Service             { return n.ns }
+func (n *Neb) IsActiveSyncing() bool               { return true }
+func (n *Neb) AccountManager() core.AccountManager { return n.am }
+func (n *Neb) Genesis() *corepb.Genesis            { return n.genesis }
+func (n *Neb) Storage() storage.Storage            { return n.storage }
+func (n *Neb) EventEmitter() *core.EventEmitter    { return n.emitter }
+func (n *Neb) Consensus() core.Consensus           { return n.consensus }
+func (n *Neb) Nvm() core.NVM                       { return n.nvm }
+func (n *Neb) StartActiveSync()                    {}
+func (n *Neb) StartPprof(string) error             { return nil }
+func (n *Neb) SetGenesis(genesis *corepb.Genesis)  { n.genesis = genesis }
+
+type mockNetService struct{}
+
+func (n mockNetService) Start() error { return nil }
+func (n mockNetService) Stop()        {}
+
+func (n mockNetService) Node() *net.Node { return nil }
+
+func (n mockNetService) Sync(net.Serializable) error { return nil }
+
+func (n mockNetService) Register(...*net.Subscriber)   {}
+func (n mockNetService) Deregister(...*net.Subscriber) {}
+
+func (n mockNetService) Broadcast(name string, msg net.Serializable, priority int) {
+	pb, _ := msg.ToProto()
+	bytes, _ := proto.Marshal(pb)
+	received = bytes
+}
+func (n mockNetService) Relay(name string, msg net.Serializable, priority int) {}
+func (n mockNetService) SendMsg(name string, msg []byte, target string, priority int) error {
+	return nil
+}
+func (n mockNetService) SendMessageToPeers(messageName string, data []byte, priority int, filter net.PeerFilterAlgorithm) []string {
+	return make([]string, 0)
+}
+func (n mockNetService) SendMessageToPeer(messageName string, data []byte, priority int, peerID string) error {
+	return nil
+}
+func (n mockNetService) ClosePeer(peerID string, reason error) {}
+func (n mockNetService) BroadcastNetworkID([]byte)             {}
+func (n mockNetService) AddPeer(addr string) (string, error)   { return "", nil }
+func (n mockNetService) RemovePeer(peerID string) error        { return nil }
+
+func TestInTurnSigner(t *testing.T) {
+	signers := []byteutils.Hash{[]byte("a"), []byte("b"), []byte("c")}
+	signer, err := InTurnSigner(5, 5, signers)
+	assert.Nil(t, err)
+	assert.Equal(t, signers[1], signer)
+	signer, err = InTurnSigner(15, 5, signers)
+	assert.Nil(t, err)
+	assert.Equal(t, signers[0], signer)
+	_, err = InTurnSigner(7, 5, signers)
+	assert.Equal(t, ErrNotBlockForgTime, err)
+	_, err = InTurnSigner(5, 5, nil)
+	assert.Equal(t, ErrFoundNilSigner, err)
+
+	_, err = periodOf(&corepb.GenesisConsensusPoa{PeriodInMs: 1500})
+	assert.Equal(t, ErrInvalidPeriod, err)
+	period, err := periodOf(&corepb.GenesisConsensusPoa{})
+	assert.Nil(t, err)
+	assert.Equal(t, DefaultPeriodInMs/SecondInMs, period)
+}
+
+func TestPoa_MintBlock(t *testing.T) {
+	neb := mockNeb(t)
+	poa := neb.consensus.(*Poa)
+	signers, err := neb.chain.TailBlock().WorldState().Dynasty()
+	assert.Nil(t, err)
+	assert.Equal(t, len(mockSigners), len(signers))
+
+	// find the slots of the miner and of another signer.
+	var mine, other int64
+	for slot := poa.period; mine == 0 || other == 0; slot += poa.period {
+		signer, err := InTurnSigner(slot, poa.period, signers)
+		assert.Nil(t, err)
+		if signer.Equals(poa.miner.Bytes()) {
+			if mine == 0 {
+				mine = slot
+			}
+		} else if other == 0 {
+			other = slot
+		}
+	}
+
+	assert.Equal(t, ErrCannotMintWhenDisable, poa.mintBlock(mine))
+	assert.Nil(t, poa.EnableMining("passphrase"))
+	assert.Equal(t, ErrCannotMintWhenPending, poa.mintBlock(mine))
+	poa.ResumeMining()
+	assert.Equal(t, ErrInvalidBlockProposer, poa.mintBlock(other))
+
+	received = []byte{}
+	assert.Nil(t, poa.mintBlock(mine))
+	assert.NotEqual(t, []byte{}, received)
+	tail := neb.chain.TailBlock()
+	assert.Equal(t, mine, tail.Timestamp())
+	assert.Equal(t, poa.miner.Bytes(), tail.ConsensusRoot().Proposer)
+	assert.Nil(t, poa.VerifyHeader(tail))
+	assert.Equal(t, ErrBlockMintedInSlot, poa.mintBlock(mine+1))
+}
@@ -34,6 +34,7 @@ const UndefinedAddressType AddressType = 0x00
 const (
 	AccountAddress AddressType = 0x57 + iota
 	ContractAddress
+	MultisigAddress
 )
 
 // const
@@ -69,6 +70,9 @@ const (
 	AddressBase58Length = 35
 	// PublicKeyDataLength length of public key
 	PublicKeyDataLength = 65
+
+	// Ed25519PublicKeyDataLength length of ed25519 public key
+	Ed25519PublicKeyDataLength = 32
 )
 
 // Address design of nebulas address
@@ -101,6 +105,14 @@ Calculation formula is as follows:
 
 	0x58 is a one-byte "type code" for smart contract address, 0x19 is a one-byte fixed "padding"
 
+[Multisig Address]
+A multisig account is created by a transaction registering its owners and threshold, the address is calculated
+like a contract address with the type code 0x59:
+
+	Content = ripemd160( sha3_256( tx.from, tx.nonce ) )
+	CheckSum = sha3_256( 0x19 + 0x59 + Content )[0:4]
+	Address = base58( 0x19 + 0x59 + Content + CheckSum )
+
 
 [TODO]
 In addition to standard address with 50 characters, we also support extended address in order to ensure the security of transfers conducted by users.
@@ -167,7 +179,7 @@ func newAddress(t AddressType, args ...[]byte) (*Address, error) {
 	}
 
 	switch t {
-	case AccountAddress, ContractAddress:
+	case AccountAddress, ContractAddress, MultisigAddress:
 	default:
 		return nil, ErrInvalidArgument
 	}
@@ -186,9 +198,9 @@ func newAddress(t AddressType, args ...[]byte) (*Address, error) {
 	return &Address{address: buffer}, nil
 }
 
-// NewAddressFromPublicKey return new address from publickey bytes
+// NewAddressFromPublicKey return new address from publickey bytes, of secp256k1 or ed25519
 func NewAddressFromPublicKey(s []byte) (*Address, error) {
-	if len(s) != PublicKeyDataLength {
+	if len(s) != PublicKeyDataLength && len(s) != Ed25519PublicKeyDataLength {
 		return nil, ErrInvalidArgument
 	}
 	return newAddress(AccountAddress, s)
@@ -202,6 +214,14 @@ func NewContractAddressFromData(from ContractTxFrom, nonce ContractTxNonce) (*Ad
 	return newAddress(ContractAddress, from, nonce)
 }
 
+// NewMultisigAddressFromData return new multisig address from the creation tx's from & nonce.
+func NewMultisigAddressFromData(from ContractTxFrom, nonce ContractTxNonce) (*Address, error) {
+	if len(from) == 0 || len(nonce) == 0 {
+		return nil, ErrInvalidArgument
+	}
+	return newAddress(MultisigAddress, from, nonce)
+}
+
 // AddressParse parse address string.
 func AddressParse(s string) (*Address, error) {
 	if len(s) != AddressBase58Length || s[0] != NebulasFaith {
@@ -218,7 +238,7 @@ func AddressParseFromBytes(b []byte) (*Address, error) {
 	}
 
 	switch AddressType(b[AddressTypeIndex]) {
-	case AccountAddress, ContractAddress:
+	case AccountAddress, ContractAddress, MultisigAddress:
 	default:
 		return nil, ErrInvalidAddressType
 	}
@@ -21,6 +21,7 @@ package core
 import (
 	"fmt"
 	"reflect"
+	"runtime"
 	"sync"
 	"time"
 
@@ -32,6 +33,7 @@ import (
 	"github.com/nebulasio/go-nebulas/core/state"
 	"github.com/nebulasio/go-nebulas/crypto"
 	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
 	"github.com/nebulasio/go-nebulas/storage"
 	"github.com/nebulasio/go-nebulas/util"
 	"github.com/nebulasio/go-nebulas/util/byteutils"
@@ -50,6 +52,9 @@ var (
 	// VerifyExecutionTimeout 0 means unlimited
 	VerifyExecutionTimeout = 0
 
+	// VerifyIntegrityParallelNum num of workers verifying txs' hash and non-secp256k1 signature in a block
+	VerifyIntegrityParallelNum = runtime.NumCPU()
+
 	// BlockReward given to coinbase
 	// rule: 3% per year, 3,000,000. 1 block per 15 seconds
 	// value: 10^8 * 3% / (365*24*3600/15) * 10^18 ≈ 1.42694 * 10^18
@@ -77,10 +82,20 @@ type BlockHeader struct {
 
 	// rand
 	random *corepb.Random
+
+	// nil before DynamicGasLimit fork
+	gasLimit *util.Uint128
 }
 
 // ToProto converts domain BlockHeader to proto BlockHeader
 func (b *BlockHeader) ToProto() (proto.Message, error) {
+	var gasLimit []byte
+	if b.gasLimit != nil {
+		var err error
+		if gasLimit, err = b.gasLimit.ToFixedSizeByteSlice(); err != nil {
+			return nil, err
+		}
+	}
 	return &corepb.BlockHeader{
 		Hash:          b.hash,
 		ParentHash:    b.parentHash,
@@ -94,6 +109,7 @@ func (b *BlockHeader) ToProto() (proto.Message, error) {
 		Alg:           uint32(b.alg),
 		Sign:          b.sign,
 		Random:        b.random,
+		GasLimit:      gasLimit,
 	}, nil
 }
 
@@ -122,10 +138,22 @@ func (b *BlockHeader) FromProto(msg proto.Message) error {
 			if err := crypto.CheckAlgorithm(alg); err != nil {
 				return err
 			}
+			// blocks are signed by secp256k1 keys only.
+			if alg != keystore.SECP256K1 {
+				return ErrInvalidSignatureAlg
+			}
 
 			b.alg = alg
 			b.sign = msg.Sign
 			b.random = msg.Random
+			b.gasLimit = nil
+			if len(msg.GasLimit) > 0 {
+				gasLimit, err := util.NewUint128FromFixedSizeByteSlice(msg.GasLimit)
+				if err != nil {
+					return ErrInvalidProtoToBlockHeader
+				}
+				b.gasLimit = gasLimit
+			}
 			return nil
 		}
 		return ErrInvalidProtoToBlockHeader
@@ -148,6 +176,11 @@ type Block struct {
 	eventEmitter *EventEmitter
 	nvm          NVM
 	storage      storage.Storage
+
+	tracer *Tracer
+	traces []*ExecutionTrace
+
+	readOnly bool
 }
 
 // ToProto converts domain Block into proto Block
@@ -252,6 +285,14 @@ func NewBlock(chainID uint32, coinbase *Address, parent *Block) (*Block, error)
 		eventEmitter: parent.eventEmitter,
 		nvm:          parent.nvm,
 		storage:      parent.storage,
+		tracer:       parent.tracer,
+	}
+	if block.height >= DynamicGasLimitHeight {
+		var target *util.Uint128
+		if parent.txPool != nil {
+			target = parent.txPool.blockGasLimitTarget
+		}
+		block.header.gasLimit = CalcBlockGasLimit(parent.GasLimit(), target)
 	}
 
 	if err := block.Begin(); err != nil {
@@ -336,6 +377,11 @@ func (block *Block) Hash() byteutils.Hash {
 	return block.header.hash
 }
 
+// AnnouncementKey return the key used by network to announce the block only once per peer.
+func (block *Block) AnnouncementKey() string {
+	return block.header.hash.Hex()
+}
+
 // StateRoot return state root hash.
 func (block *Block) StateRoot() byteutils.Hash {
 	return block.header.stateRoot
@@ -376,6 +422,11 @@ func (block *Block) Height() uint64 {
 	return block.height
 }
 
+// ReadOnly return if the contracts executed in the block can only read the state, set for static calls
+func (block *Block) ReadOnly() bool {
+	return block.readOnly
+}
+
 // Transactions returns block transactions
 func (block *Block) Transactions() Transactions {
 	return block.transactions
@@ -423,13 +474,42 @@ func (block *Block) LinkParentBlock(chain *BlockChain, parentBlock *Block) error
 	if err != nil {
 		return err
 	}
+	// a new dynasty is elected by the stakes, then tracked for the missed slots.
+	// the consensus without stakes, like poa, elects nothing.
+	if parentBlock.height+1 >= ProofOfDevotionHeight {
+		if elective, ok := consensusState.(ElectiveConsensusState); ok {
+			if err := elective.ElectDynasty(parentBlock.height+1, parentBlock.WorldState()); err != nil {
+				return err
+			}
+		}
+	}
+	// the dynasty may change on the missed slots, before the proposer is elected.
+	// the consensus without dynasty, like poa, has no liveness to track.
+	if parentBlock.height+1 >= ValidatorLivenessHeight {
+		if liveness, ok := consensusState.(LivenessConsensusState); ok {
+			if err := liveness.TrackLiveness(parentBlock.height + 1); err != nil {
+				return err
+			}
+		}
+	}
+	// the elected proposer is verified by the consensus, see Consensus.VerifyBlock.
+	// the consensus without election, like poa, keeps its own proposer.
+	if parentBlock.height+1 >= VRFProposerHeight {
+		if elected, ok := consensusState.(ElectedConsensusState); ok {
+			elected.SetProposer(block.ConsensusRoot().Proposer, block.ConsensusRoot().ProposerProof)
+		}
+	}
 	block.WorldState().SetConsensusState(consensusState)
 
 	block.height = parentBlock.height + 1
+	if err := block.verifyGasLimit(parentBlock); err != nil {
+		return err
+	}
 	block.txPool = parentBlock.txPool
 	block.storage = parentBlock.storage
 	block.eventEmitter = parentBlock.eventEmitter
 	block.nvm = parentBlock.nvm
+	block.tracer = parentBlock.tracer
 
 	return nil
 }
@@ -487,6 +567,7 @@ func (block *Block) CollectTransactions(deadlineInMs int64) {
 	deadlineTimer := time.NewTimer(time.Duration(elapseInMs) * time.Millisecond)
 
 	pool := block.txPool
+	pending := pool.Pending(block.GasLimit())
 
 	packed := int64(0)
 	unpacked := int64(0)
@@ -514,6 +595,7 @@ func (block *Block) CollectTransactions(deadlineInMs int64) {
 	update := int64(0)
 	parallel := 0
 	beginAt := time.Now().UnixNano()
+	refilledAt := time.Now()
 
 	go func() {
 		for {
@@ -523,7 +605,13 @@ func (block *Block) CollectTransactions(deadlineInMs int64) {
 				return
 			}
 			try++
-			tx := pool.PopWithBlacklist(fromBlacklist, toBlacklist)
+			tx := pending.PopWithBlacklist(fromBlacklist, toBlacklist)
+			if tx == nil && pending.Len() == 0 && time.Since(refilledAt) >= PendingRefillInterval {
+				// the view is exhausted before the deadline, take the txs arrived since.
+				pending.Refill()
+				refilledAt = time.Now()
+				tx = pending.PopWithBlacklist(fromBlacklist, toBlacklist)
+			}
 			if tx == nil {
 				<-mergeCh // unlock
 				continue
@@ -576,6 +664,7 @@ func (block *Block) CollectTransactions(deadlineInMs int64) {
 						"err":   err,
 					}).Info("Failed to prepare tx.")
 					failed++
+					pending.Giveback(tx)
 
 					if err := pool.Push(tx); err != nil {
 						logging.VLog().WithFields(logrus.Fields{
@@ -595,6 +684,9 @@ func (block *Block) CollectTransactions(deadlineInMs int64) {
 				<-mergeCh // unlock
 
 				defer func() {
+					if txWorldState == nil {
+						return
+					}
 					if err := txWorldState.Close(); err != nil {
 						logging.VLog().WithFields(logrus.Fields{
 							"block": block,
@@ -606,7 +698,8 @@ func (block *Block) CollectTransactions(deadlineInMs int64) {
 
 				// step2. execute tx.
 				executeAt := time.Now().UnixNano()
-				giveback, err := block.ExecuteTransaction(tx, txWorldState)
+				ws, trace := block.traceWorldState(txWorldState, tx)
+				giveback, err := block.ExecuteTransaction(tx, ws)
 				executedAt := time.Now().UnixNano()
 				execute += executedAt - executeAt
 				if err != nil {
@@ -618,6 +711,12 @@ func (block *Block) CollectTransactions(deadlineInMs int64) {
 					unpacked++
 					failed++
 
+					// the following txs of the account can't be packed without it.
+					mergeCh <- true // lock
+					pending.Refund(tx)
+					pending.Drop(tx.from)
+					<-mergeCh // unlock
+
 					/* 					if err := txWorldState.Close(); err != nil {
 						logging.VLog().WithFields(logrus.Fields{
 							"block": block,
@@ -678,10 +777,53 @@ func (block *Block) CollectTransactions(deadlineInMs int64) {
 						"err":        err,
 						"giveback":   giveback,
 						"dependency": dependency,
-					}).Info("CheckAndUpdate invalid tx.")
-					unpacked++
+					}).Debug("Conflicted tx, re-execute it serially.")
 					conflict++
 
+					// the re-execution holds the lock the block waits for at the deadline,
+					// give the tx back if it can't run once more, as long as it ran, in time.
+					if time.Now().UnixNano()+(executedAt-executeAt) >= deadlineInMs*int64(time.Millisecond) {
+						expired++
+						pending.Refund(tx)
+						pending.Drop(tx.from)
+						if err := pool.Push(tx); err != nil {
+							logging.VLog().WithFields(logrus.Fields{
+								"block": block,
+								"tx":    tx,
+								"err":   err,
+							}).Info("Failed to giveback the tx.")
+						}
+
+						fromBlacklist.Delete(tx.from.address.Hex())
+						fromBlacklist.Delete(tx.to.address.Hex())
+						toBlacklist.Delete(tx.from.address.Hex())
+						toBlacklist.Delete(tx.to.address.Hex())
+						<-mergeCh // unlock
+						return
+					}
+
+					// the tx read a state changed by the txs merged meanwhile, run it
+					// again while holding the lock, nothing else can be merged.
+					if err := txWorldState.Close(); err != nil {
+						logging.VLog().WithFields(logrus.Fields{
+							"block": block,
+							"tx":    tx,
+							"err":   err,
+						}).Info("Failed to close tx.")
+					}
+					txWorldState = nil
+					reexecuteAt := time.Now().UnixNano()
+					dependency, trace, err = block.reexecuteTransaction(tx)
+					update += time.Now().UnixNano() - reexecuteAt
+				}
+				if err != nil {
+					logging.VLog().WithFields(logrus.Fields{
+						"tx":  tx,
+						"err": err,
+					}).Info("Failed to re-execute the conflicted tx.")
+					unpacked++
+					pending.Giveback(tx)
+
 					if err := pool.Push(tx); err != nil {
 						logging.VLog().WithFields(logrus.Fields{
 							"block": block,
@@ -704,6 +846,7 @@ func (block *Block) CollectTransactions(deadlineInMs int64) {
 				packed++
 
 				transactions = append(transactions, tx)
+				block.addTrace(trace)
 				txid := tx.Hash().String()
 				dag.AddNode(txid)
 				for _, node := range dependency {
@@ -764,6 +907,35 @@ func (block *Block) CollectTransactions(deadlineInMs int64) {
 	}).Info("CollectTransactions")
 }
 
+// reexecuteTransaction run a tx conflicted with the txs merged during its
+// execution once more, callers should hold the merge lock so that it can't
+// conflict again. It returns the txs the tx depends on.
+func (block *Block) reexecuteTransaction(tx *Transaction) ([]interface{}, *ExecutionTrace, error) {
+	txWorldState, err := block.WorldState().Prepare(tx.Hash().String())
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		if err := txWorldState.Close(); err != nil {
+			logging.VLog().WithFields(logrus.Fields{
+				"block": block,
+				"tx":    tx,
+				"err":   err,
+			}).Info("Failed to close tx.")
+		}
+	}()
+
+	ws, trace := block.traceWorldState(txWorldState, tx)
+	if _, err := block.ExecuteTransaction(tx, ws); err != nil {
+		return nil, nil, err
+	}
+	dependency, err := txWorldState.CheckAndUpdate()
+	if err != nil {
+		return nil, nil, err
+	}
+	return dependency, trace, nil
+}
+
 // Sealed return true if block seals. Otherwise return false.
 func (block *Block) Sealed() bool {
 	return block.sealed
@@ -892,15 +1064,9 @@ func (block *Block) VerifyIntegrity(chainID uint32, consensus Consensus) error {
 	}
 
 	// verify transactions integrity.
-	for _, tx := range block.transactions {
-		if err := tx.VerifyIntegrity(block.header.chainID); err != nil {
-			logging.VLog().WithFields(logrus.Fields{
-				"tx":  tx,
-				"err": err,
-			}).Info("Failed to verify tx's integrity.")
-			metricsInvalidBlock.Inc(1)
-			return err
-		}
+	if err := block.verifyTransactionsIntegrity(); err != nil {
+		metricsInvalidBlock.Inc(1)
+		return err
 	}
 
 	// verify block hash.
@@ -919,7 +1085,7 @@ func (block *Block) VerifyIntegrity(chainID uint32, consensus Consensus) error {
 	}
 
 	// verify the block is acceptable by consensus.
-	if err := consensus.VerifyBlock(block); err != nil {
+	if err := consensus.VerifyHeader(block); err != nil {
 		logging.VLog().WithFields(logrus.Fields{
 			"block": block,
 			"err":   err,
@@ -931,6 +1097,109 @@ func (block *Block) VerifyIntegrity(chainID uint32, consensus Consensus) error {
 	return nil
 }
 
+// verifyTransactionsIntegrity verify txs' hash and signature, which need no state,
+// so they are checked by parallel workers before the sequential execution.
+// The secp256k1 signatures are verified as a batch once the hashes are checked.
+func (block *Block) verifyTransactionsIntegrity() error {
+	startAt := time.Now().UnixNano()
+
+	txs := block.transactions
+	workers := VerifyIntegrityParallelNum
+	if workers > len(txs) {
+		workers = len(txs)
+	}
+
+	verify := func(tx *Transaction) error {
+		if err := tx.verifyHash(block.header.chainID); err != nil {
+			return err
+		}
+		if tx.alg == keystore.SECP256K1 && tx.signedByFrom() {
+			return nil
+		}
+		return tx.verifySign()
+	}
+
+	errs := make([]error, len(txs))
+	if workers <= 1 {
+		for i, tx := range txs {
+			if errs[i] = verify(tx); errs[i] != nil {
+				break
+			}
+		}
+	} else {
+		indexCh := make(chan int, len(txs))
+		for i := range txs {
+			indexCh <- i
+		}
+		close(indexCh)
+
+		wg := new(sync.WaitGroup)
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for idx := range indexCh {
+					errs[idx] = verify(txs[idx])
+				}
+			}()
+		}
+		wg.Wait()
+	}
+
+	// only the txs before the first invalid one need their signatures verified.
+	var (
+		indexes []int
+		hashes  [][]byte
+		sigs    [][]byte
+	)
+	for i, tx := range txs {
+		if errs[i] != nil {
+			break
+		}
+		if tx.alg == keystore.SECP256K1 && tx.signedByFrom() {
+			indexes = append(indexes, i)
+			hashes = append(hashes, tx.hash)
+			sigs = append(sigs, tx.sign)
+		}
+	}
+	if len(indexes) > 0 {
+		// the signers are recovered by the batch.
+		pubkeys := make([][]byte, len(indexes))
+		if failed, err := secp256k1.VerifyBatch(pubkeys, hashes, sigs); err != nil && failed >= 0 {
+			errs[indexes[failed]] = err
+		}
+		for j, i := range indexes {
+			if pubkeys[j] == nil {
+				break
+			}
+			signer, err := NewAddressFromPublicKey(pubkeys[j])
+			if err == nil {
+				err = txs[i].verifySigner(signer)
+			}
+			if err != nil {
+				errs[i] = err
+				break
+			}
+		}
+	}
+
+	// report the first invalid tx, the same as the sequential check.
+	for i, err := range errs {
+		if err != nil {
+			logging.VLog().WithFields(logrus.Fields{
+				"tx":  txs[i],
+				"err": err,
+			}).Info("Failed to verify tx's integrity.")
+			return err
+		}
+	}
+
+	if len(txs) != 0 {
+		metricsTxIntegrityVerifiedTime.Update((time.Now().UnixNano() - startAt) / int64(len(txs)))
+	}
+	return nil
+}
+
 // verifyState return state verify result.
 func (block *Block) verifyState() error {
 	// verify state root.
@@ -983,6 +1252,7 @@ func (block *Block) execute() error {
 	if err := block.rewardCoinbaseForMint(); err != nil {
 		return err
 	}
+	block.traces = nil
 
 	context := &verifyCtx{
 		mergeCh: make(chan bool, 1),
@@ -1013,7 +1283,8 @@ func (block *Block) execute() error {
 		}
 		<-mergeCh
 
-		if _, err = block.ExecuteTransaction(tx, txWorldState); err != nil {
+		ws, trace := block.traceWorldState(txWorldState, tx)
+		if _, err = block.ExecuteTransaction(tx, ws); err != nil {
 			return err
 		}
 
@@ -1021,6 +1292,7 @@ func (block *Block) execute() error {
 		if _, err := txWorldState.CheckAndUpdate(); err != nil {
 			return err
 		}
+		block.addTrace(trace)
 		<-mergeCh
 
 		return nil
@@ -1240,6 +1512,13 @@ func (block *Block) calHash() (byteutils.Hash, error) {
 	hasher.Write(block.header.coinbase.address)
 	hasher.Write(byteutils.FromInt64(block.header.timestamp))
 	hasher.Write(byteutils.FromUint32(block.header.chainID))
+	if block.header.gasLimit != nil {
+		gasLimit, err := block.header.gasLimit.ToFixedSizeByteSlice()
+		if err != nil {
+			return nil, err
+		}
+		hasher.Write(gasLimit)
+	}
 
 	for _, tx := range block.transactions {
 		hasher.Write(tx.Hash())
@@ -1263,7 +1542,10 @@ func LoadBlockFromStorage(hash byteutils.Hash, chain *BlockChain) (*Block, error
 		return nil, ErrNilArgument
 	}
 
-	value, err := chain.storage.Get(hash)
+	value, err := chain.bodyStorage.Get(hash)
+	if err == storage.ErrKeyNotFound {
+		value, err = chain.loadFrozenBlock(hash)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -1275,7 +1557,7 @@ func LoadBlockFromStorage(hash byteutils.Hash, chain *BlockChain) (*Block, error
 	if err = block.FromProto(pbBlock); err != nil {
 		return nil, err
 	}
-	block.worldState, err = state.NewWorldState(chain.ConsensusHandler(), chain.storage)
+	block.worldState, err = state.NewWorldState(chain.ConsensusHandler(), chain.stateStorage)
 	if err != nil {
 		return nil, err
 	}
@@ -1296,6 +1578,7 @@ func LoadBlockFromStorage(hash byteutils.Hash, chain *BlockChain) (*Block, error
 	block.eventEmitter = chain.eventEmitter
 	block.nvm = chain.nvm
 	block.storage = chain.storage
+	block.tracer = chain.tracer
 	return block, nil
 }
 
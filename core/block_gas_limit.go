@@ -0,0 +1,110 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"github.com/nebulasio/go-nebulas/util"
+)
+
+const (
+	// BlockGasLimitBoundDivisor the gas limit of a block drifts at most 1/BlockGasLimitBoundDivisor from its parent's
+	BlockGasLimitBoundDivisor = 1024
+)
+
+var (
+	// MinBlockGasLimit the lowest block gas limit, a tx with the max gas limit can always be packed.
+	MinBlockGasLimit = TransactionMaxGas
+)
+
+// GasLimit return the maximum sum of the gas limits of the txs in the block,
+// BlockGasLimit before DynamicGasLimit fork.
+func (block *Block) GasLimit() *util.Uint128 {
+	if block.header.gasLimit == nil {
+		return BlockGasLimit
+	}
+	return block.header.gasLimit
+}
+
+// gasLimitBound return the max drift of the gas limit from the parent's.
+func gasLimitBound(parent *util.Uint128) *util.Uint128 {
+	divisor, _ := util.NewUint128FromInt(BlockGasLimitBoundDivisor)
+	bound, _ := parent.Div(divisor)
+	return bound
+}
+
+// CalcBlockGasLimit return the gas limit of the child block, moving from the parent's toward
+// the target by at most 1/BlockGasLimitBoundDivisor. The parent's is kept if target is nil.
+func CalcBlockGasLimit(parent, target *util.Uint128) *util.Uint128 {
+	if target == nil || target.Cmp(parent) == 0 {
+		return parent
+	}
+	if target.Cmp(MinBlockGasLimit) < 0 {
+		target = MinBlockGasLimit
+	}
+
+	bound := gasLimitBound(parent)
+	if target.Cmp(parent) > 0 {
+		limit, err := parent.Add(bound)
+		if err != nil || limit.Cmp(target) > 0 {
+			return target
+		}
+		return limit
+	}
+	limit, err := parent.Sub(bound)
+	if err != nil || limit.Cmp(target) < 0 {
+		return target
+	}
+	return limit
+}
+
+// verifyGasLimit check the block gas limit against the parent's and the txs in the block.
+func (block *Block) verifyGasLimit(parent *Block) error {
+	if block.height < DynamicGasLimitHeight {
+		if block.header.gasLimit != nil {
+			return ErrInvalidBlockGasLimit
+		}
+		return nil
+	}
+	if block.header.gasLimit == nil {
+		return ErrInvalidBlockGasLimit
+	}
+
+	limit := block.header.gasLimit
+	if limit.Cmp(MinBlockGasLimit) < 0 {
+		return ErrInvalidBlockGasLimit
+	}
+	diff, err := limit.Sub(parent.GasLimit())
+	if err != nil {
+		diff, _ = parent.GasLimit().Sub(limit)
+	}
+	if diff.Cmp(gasLimitBound(parent.GasLimit())) > 0 {
+		return ErrInvalidBlockGasLimit
+	}
+
+	sum := util.NewUint128()
+	for _, tx := range block.transactions {
+		if sum, err = sum.Add(tx.gasLimit); err != nil {
+			return ErrBlockGasLimitExceeded
+		}
+	}
+	if sum.Cmp(limit) > 0 {
+		return ErrBlockGasLimitExceeded
+	}
+	return nil
+}
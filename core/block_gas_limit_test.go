@@ -0,0 +1,90 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalcBlockGasLimit(t *testing.T) {
+	parent, _ := util.NewUint128FromInt(1024000000000)
+	bound, _ := util.NewUint128FromInt(1000000000)
+
+	assert.Equal(t, parent, CalcBlockGasLimit(parent, nil))
+
+	up, _ := parent.Add(bound)
+	high, _ := util.NewUint128FromString("2000000000000")
+	assert.Equal(t, 0, up.Cmp(CalcBlockGasLimit(parent, high)))
+
+	down, _ := parent.Sub(bound)
+	assert.Equal(t, 0, down.Cmp(CalcBlockGasLimit(parent, MinBlockGasLimit)))
+
+	// the limit stops at the target.
+	near, _ := parent.Add(util.NewUint128FromUint(1))
+	assert.Equal(t, 0, near.Cmp(CalcBlockGasLimit(parent, near)))
+
+	// the limit never goes below the minimum.
+	assert.Equal(t, 0, MinBlockGasLimit.Cmp(CalcBlockGasLimit(MinBlockGasLimit, util.NewUint128FromUint(1))))
+}
+
+func TestBlock_VerifyGasLimit(t *testing.T) {
+	height := DynamicGasLimitHeight
+	DynamicGasLimitHeight = 2
+	defer func() { DynamicGasLimitHeight = height }()
+
+	neb := testNeb(t)
+	bc := neb.chain
+	target, _ := util.NewUint128FromString("1000000000000")
+	assert.Nil(t, bc.txPool.SetBlockGasLimitTarget(target))
+
+	parent := bc.tailBlock
+	for i := 1; i <= 3; i++ {
+		addr, err := AddressParse(MockDynasty[i])
+		assert.Nil(t, err)
+		block, err := NewBlock(bc.ChainID(), addr, bc.tailBlock)
+		assert.Nil(t, err)
+		assert.Equal(t, 0, CalcBlockGasLimit(bc.tailBlock.GasLimit(), target).Cmp(block.GasLimit()))
+		block.header.timestamp = bc.tailBlock.header.timestamp + BlockInterval
+		assert.Nil(t, block.Seal())
+		signBlock(block)
+		assert.Nil(t, bc.bkPool.Push(block))
+		assert.Equal(t, block.Hash(), bc.tailBlock.Hash())
+	}
+	assert.Equal(t, 1, bc.tailBlock.GasLimit().Cmp(parent.GasLimit()))
+
+	block, err := NewBlock(bc.ChainID(), bc.tailBlock.Coinbase(), bc.tailBlock)
+	assert.Nil(t, err)
+	assert.Nil(t, block.verifyGasLimit(bc.tailBlock))
+
+	block.header.gasLimit, _ = bc.tailBlock.GasLimit().Mul(util.NewUint128FromUint(2))
+	assert.Equal(t, ErrInvalidBlockGasLimit, block.verifyGasLimit(bc.tailBlock))
+
+	block.header.gasLimit = nil
+	assert.Equal(t, ErrInvalidBlockGasLimit, block.verifyGasLimit(bc.tailBlock))
+
+	// the txs can not use more gas than the limit.
+	block.header.gasLimit = bc.tailBlock.GasLimit()
+	tx := mockNormalTransaction(bc.chainID, 1)
+	tx.gasLimit = block.GasLimit()
+	block.transactions = append(block.transactions, tx, mockNormalTransaction(bc.chainID, 2))
+	assert.Equal(t, ErrBlockGasLimitExceeded, block.verifyGasLimit(bc.tailBlock))
+}
@@ -20,6 +20,7 @@ package core
 
 import (
 	"bytes"
+	"sort"
 	"sync"
 	"time"
 
@@ -41,6 +42,13 @@ const (
 	NoSender = ""
 )
 
+var (
+	// orphanBlockEvictInterval interval to evict the expired orphan blocks.
+	orphanBlockEvictInterval = time.Minute
+	// orphanBlockLifetime the longest time an orphan block waits for its parent.
+	orphanBlockLifetime = time.Minute * 10
+)
+
 // BlockPool a pool of all received blocks from network.
 // Blocks will be sent to Consensus when it passes signature verification.
 type BlockPool struct {
@@ -49,6 +57,8 @@ type BlockPool struct {
 	receiveDownloadBlockMessageCh chan net.Message
 	quitCh                        chan int
 
+	blocksRequestLimiter *peerRateLimiter
+
 	bc    *BlockChain
 	cache *lru.Cache
 
@@ -61,6 +71,7 @@ type linkedBlock struct {
 	chain      *BlockChain
 	hash       byteutils.Hash
 	parentHash byteutils.Hash
+	receivedAt time.Time
 
 	parentBlock *linkedBlock
 	childBlocks map[byteutils.HexHash]*linkedBlock
@@ -74,6 +85,7 @@ func NewBlockPool(size int) (*BlockPool, error) {
 		receiveDownloadBlockMessageCh: make(chan net.Message, size),
 		quitCh: make(chan int, 1),
 	}
+	bp.blocksRequestLimiter = newPeerRateLimiter(BlocksRequestRate, BlocksRequestBurst)
 	var err error
 	bp.cache, err = lru.NewWithEvict(size, func(key interface{}, value interface{}) {
 		lb := value.(*linkedBlock)
@@ -93,6 +105,8 @@ func (pool *BlockPool) RegisterInNetwork(ns net.Service) {
 	ns.Register(net.NewSubscriber(pool, pool.receiveBlockMessageCh, true, MessageTypeNewBlock, net.MessageWeightNewBlock))
 	ns.Register(net.NewSubscriber(pool, pool.receiveBlockMessageCh, false, MessageTypeBlockDownloadResponse, net.MessageWeightZero))
 	ns.Register(net.NewSubscriber(pool, pool.receiveDownloadBlockMessageCh, false, MessageTypeParentBlockDownloadRequest, net.MessageWeightZero))
+	ns.Register(net.NewSubscriber(pool, pool.receiveBlockMessageCh, false, MessageTypeBlocksResponse, net.MessageWeightZero))
+	ns.Register(net.NewSubscriber(pool, pool.receiveDownloadBlockMessageCh, false, MessageTypeBlocksRequest, net.MessageWeightZero))
 	pool.ns = ns
 }
 
@@ -143,6 +157,11 @@ func (pool *BlockPool) handleReceivedBlock(msg net.Message) {
 		return
 	}
 
+	// the sender already has the block, never announce it back.
+	if node := pool.ns.Node(); node != nil {
+		node.MarkAnnounced(msg.MessageFrom(), block.AnnouncementKey())
+	}
+
 	if msg.MessageType() == MessageTypeNewBlock &&
 		pool.bc.ConsensusHandler().CheckTimeout(block) {
 		return
@@ -237,26 +256,178 @@ func (pool *BlockPool) handleParentDownloadRequest(msg net.Message) {
 	}).Debug("Responsed to the download request.")
 }
 
+// handleBlocksRequest serves the blocks of a range, the requests of a peer beyond
+// the rate limit are dropped.
+func (pool *BlockPool) handleBlocksRequest(msg net.Message) {
+	if !pool.blocksRequestLimiter.allow(msg.MessageFrom(), time.Now()) {
+		logging.VLog().WithFields(logrus.Fields{
+			"pid": msg.MessageFrom(),
+		}).Debug("Too many blocks requests from the peer, drop it.")
+		return
+	}
+
+	request := new(corepb.GetBlocks)
+	if err := proto.Unmarshal(msg.Data(), request); err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"msgType": msg.MessageType(),
+			"msg":     msg,
+			"err":     err,
+		}).Debug("Failed to unmarshal data.")
+		return
+	}
+
+	count := int(request.Count)
+	if count > MaxBlocksPerRequest {
+		count = MaxBlocksPerRequest
+	}
+	blocks := pool.bc.GetBlocks(request.From, count, request.Reverse)
+	if len(blocks) == 0 {
+		logging.VLog().WithFields(logrus.Fields{
+			"from":    byteutils.Hex(request.From),
+			"reverse": request.Reverse,
+		}).Debug("Failed to find the blocks asked for.")
+		return
+	}
+
+	response := &corepb.Blocks{}
+	for _, block := range blocks {
+		pbBlock, err := block.ToProto()
+		if err != nil {
+			logging.VLog().WithFields(logrus.Fields{
+				"block": block,
+				"err":   err,
+			}).Debug("Failed to convert the block to proto data.")
+			return
+		}
+		response.Blocks = append(response.Blocks, pbBlock.(*corepb.Block))
+	}
+	bytes, err := proto.Marshal(response)
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"err": err,
+		}).Debug("Failed to marshal the blocks.")
+		return
+	}
+	pool.ns.SendMsg(MessageTypeBlocksResponse, bytes, msg.MessageFrom(), net.MessagePriorityNormal)
+
+	logging.VLog().WithFields(logrus.Fields{
+		"from":  blocks[0],
+		"count": len(blocks),
+	}).Debug("Responsed to the blocks request.")
+}
+
+// handleBlocksResponse pushes the blocks from the oldest, only the newest one asks
+// the sender for the ancestors still missing.
+func (pool *BlockPool) handleBlocksResponse(msg net.Message) {
+	response := new(corepb.Blocks)
+	if err := proto.Unmarshal(msg.Data(), response); err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"msgType": msg.MessageType(),
+			"msg":     msg,
+			"err":     err,
+		}).Debug("Failed to unmarshal data.")
+		return
+	}
+	if len(response.Blocks) > MaxBlocksPerRequest {
+		logging.VLog().WithFields(logrus.Fields{
+			"pid":   msg.MessageFrom(),
+			"count": len(response.Blocks),
+		}).Debug("Received too many blocks.")
+		return
+	}
+
+	blocks := []*Block{}
+	for _, pbBlock := range response.Blocks {
+		block := new(Block)
+		if err := block.FromProto(pbBlock); err != nil {
+			logging.VLog().WithFields(logrus.Fields{
+				"msgType": msg.MessageType(),
+				"msg":     msg,
+				"err":     err,
+			}).Debug("Failed to recover a block from proto data.")
+			return
+		}
+		blocks = append(blocks, block)
+	}
+	sort.Slice(blocks, func(i, j int) bool {
+		return blocks[i].Height() < blocks[j].Height()
+	})
+
+	for i, block := range blocks {
+		if node := pool.ns.Node(); node != nil {
+			node.MarkAnnounced(msg.MessageFrom(), block.AnnouncementKey())
+		}
+		sender := NoSender
+		if i == len(blocks)-1 {
+			sender = msg.MessageFrom()
+		}
+		pool.PushAndRelay(sender, block)
+	}
+
+	logging.VLog().WithFields(logrus.Fields{
+		"pid":   msg.MessageFrom(),
+		"count": len(blocks),
+	}).Debug("Received the blocks downloaded.")
+}
+
 func (pool *BlockPool) loop() {
 	logging.CLog().Info("Started BlockPool.")
 	timerChan := time.NewTicker(time.Second).C
+	evictChan := time.NewTicker(orphanBlockEvictInterval).C
 	for {
 		select {
 		case <-timerChan:
 			metricsCachedNewBlock.Update(int64(len(pool.receiveBlockMessageCh)))
 			metricsCachedDownloadBlock.Update(int64(len(pool.receiveDownloadBlockMessageCh)))
 			metricsLruPoolCacheBlock.Update(int64(pool.cache.Len()))
+		case <-evictChan:
+			pool.evictExpiredOrphanBlocks()
+			pool.blocksRequestLimiter.evict(time.Now())
 		case <-pool.quitCh:
 			logging.CLog().Info("Stopped BlockPool.")
 			return
 		case msg := <-pool.receiveBlockMessageCh:
+			if msg.MessageType() == MessageTypeBlocksResponse {
+				go pool.handleBlocksResponse(msg)
+				continue
+			}
 			go pool.handleReceivedBlock(msg)
 		case msg := <-pool.receiveDownloadBlockMessageCh:
+			if msg.MessageType() == MessageTypeBlocksRequest {
+				go pool.handleBlocksRequest(msg)
+				continue
+			}
 			go pool.handleParentDownloadRequest(msg)
 		}
 	}
 }
 
+// evictExpiredOrphanBlocks remove the blocks whose parent is still unknown after orphanBlockLifetime.
+func (pool *BlockPool) evictExpiredOrphanBlocks() {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	for _, k := range pool.cache.Keys() {
+		v, ok := pool.cache.Peek(k)
+		if !ok {
+			continue
+		}
+		lb := v.(*linkedBlock)
+		if time.Since(lb.receivedAt) <= orphanBlockLifetime {
+			continue
+		}
+
+		logging.VLog().WithFields(logrus.Fields{
+			"hash":       lb.hash.Hex(),
+			"parentHash": lb.parentHash.Hex(),
+			"receivedAt": lb.receivedAt,
+		}).Debug("Evict expired orphan block.")
+
+		metricsExpiredOrphanBlock.Inc(1)
+		pool.cache.Remove(k)
+	}
+}
+
 func mockBlockFromNetwork(block *Block) (*Block, error) {
 	pbBlock, err := block.ToProto()
 	if err != nil {
@@ -324,9 +495,10 @@ func (pool *BlockPool) PushAndBroadcast(block *Block) error {
 }
 
 func (pool *BlockPool) downloadParent(sender string, block *Block) error {
-	downloadMsg := &corepb.DownloadBlock{
-		Hash: block.Hash(),
-		Sign: block.Signature(),
+	downloadMsg := &corepb.GetBlocks{
+		From:    block.ParentHash(),
+		Count:   ChunkSize,
+		Reverse: true,
 	}
 	bytes, err := proto.Marshal(downloadMsg)
 	if err != nil {
@@ -337,7 +509,7 @@ func (pool *BlockPool) downloadParent(sender string, block *Block) error {
 		return err
 	}
 
-	pool.ns.SendMsg(MessageTypeParentBlockDownloadRequest, bytes, sender, net.MessagePriorityNormal)
+	pool.ns.SendMsg(MessageTypeBlocksRequest, bytes, sender, net.MessagePriorityNormal)
 
 	logging.VLog().WithFields(logrus.Fields{
 		"target": sender,
@@ -515,6 +687,7 @@ func newLinkedBlock(block *Block, chain *BlockChain) *linkedBlock {
 		chain:       chain,
 		hash:        block.Hash(),
 		parentHash:  block.ParentHash(),
+		receivedAt:  time.Now(),
 		parentBlock: nil,
 		childBlocks: make(map[byteutils.HexHash]*linkedBlock),
 	}
@@ -291,3 +291,80 @@ func TestHandleDownloadedBlock(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, received, data)
 }
+
+func TestHandleBlocksRequest(t *testing.T) {
+	received = []byte{}
+
+	neb := testNeb(t)
+	bc := neb.chain
+	from := mockAddress()
+	ks := keystore.DefaultKS
+	key, err := ks.GetUnlocked(from.String())
+	signature, err := crypto.NewSignature(keystore.SECP256K1)
+	assert.Nil(t, err)
+	signature.InitSign(key.(keystore.PrivateKey))
+
+	block1, err := bc.NewBlock(from)
+	assert.Nil(t, err)
+	block1.SetTimestamp(BlockInterval)
+	block1.Seal()
+	block1.Sign(signature)
+	assert.Nil(t, bc.BlockPool().Push(block1))
+	block2, err := bc.NewBlock(from)
+	assert.Nil(t, err)
+	block2.SetTimestamp(BlockInterval * 2)
+	block2.Seal()
+	block2.Sign(signature)
+	assert.Nil(t, bc.BlockPool().Push(block2))
+
+	request := func(peer string, from []byte, count uint32, reverse bool) []*corepb.Block {
+		received = []byte{}
+		data, err := proto.Marshal(&corepb.GetBlocks{From: from, Count: count, Reverse: reverse})
+		assert.Nil(t, err)
+		bc.bkPool.handleBlocksRequest(net.NewBaseMessage(MessageTypeBlocksRequest, peer, data))
+		response := new(corepb.Blocks)
+		assert.Nil(t, proto.Unmarshal(received, response))
+		return response.Blocks
+	}
+
+	// the ancestors stop at the genesis.
+	blocks := request("a", block2.Hash(), 10, true)
+	assert.Equal(t, 3, len(blocks))
+	assert.Equal(t, []byte(block2.Hash()), blocks[0].Header.Hash)
+	assert.Equal(t, []byte(bc.genesisBlock.Hash()), blocks[2].Header.Hash)
+
+	// the canonical chain towards the tail.
+	blocks = request("a", bc.genesisBlock.Hash(), 2, false)
+	assert.Equal(t, 2, len(blocks))
+	assert.Equal(t, []byte(block1.Hash()), blocks[1].Header.Hash)
+
+	// the count is limited.
+	assert.Equal(t, 3, len(request("a", bc.genesisBlock.Hash(), MaxBlocksPerRequest+1, false)))
+
+	// unknown blocks.
+	assert.Equal(t, 0, len(request("a", []byte("unknown"), 10, false)))
+
+	// the requests beyond the rate are dropped.
+	for i := 0; i < BlocksRequestBurst; i++ {
+		request("b", block2.Hash(), 1, true)
+	}
+	assert.Equal(t, 0, len(request("b", block2.Hash(), 1, true)))
+	assert.Equal(t, 1, len(request("c", block2.Hash(), 1, true)))
+}
+
+func TestEvictExpiredOrphanBlocks(t *testing.T) {
+	pool, err := NewBlockPool(8)
+	assert.Nil(t, err)
+
+	expired := newLinkedBlock(&Block{header: &BlockHeader{hash: []byte("expired"), parentHash: []byte("unknown")}}, nil)
+	expired.receivedAt = time.Now().Add(-orphanBlockLifetime - time.Second)
+	pool.cache.Add(expired.hash.Hex(), expired)
+
+	fresh := newLinkedBlock(&Block{header: &BlockHeader{hash: []byte("fresh"), parentHash: []byte("unknown")}}, nil)
+	pool.cache.Add(fresh.hash.Hex(), fresh)
+
+	pool.evictExpiredOrphanBlocks()
+	assert.Equal(t, 1, pool.cache.Len())
+	assert.False(t, pool.cache.Contains(expired.hash.Hex()))
+	assert.True(t, pool.cache.Contains(fresh.hash.Hex()))
+}
@@ -140,7 +140,18 @@ func (c *mockConsensus) Setup(neb Neblet) error {
 func (c *mockConsensus) Start() {}
 func (c *mockConsensus) Stop()  {}
 
-func (c *mockConsensus) VerifyBlock(block *Block) error {
+func (c *mockConsensus) VerifyHeader(block *Block) error {
+	return nil
+}
+func (c *mockConsensus) Prepare(block *Block, consensusState state.ConsensusState) error {
+	block.WorldState().SetConsensusState(consensusState)
+	block.SetTimestamp(consensusState.TimeStamp())
+	return nil
+}
+func (c *mockConsensus) Finalize(block *Block) error {
+	return block.Seal()
+}
+func (c *mockConsensus) Seal(block *Block) error {
 	return nil
 }
 
@@ -209,7 +220,12 @@ func (m mockManager) NewAccount([]byte) (*Address, error) { return nil, nil }
 func (m mockManager) Accounts() []*Address                { return nil }
 
 func (m mockManager) Unlock(addr *Address, passphrase []byte, expire time.Duration) error { return nil }
-func (m mockManager) Lock(addr *Address) error                                            { return nil }
+func (m mockManager) UnlockWithUses(addr *Address, passphrase []byte, expire time.Duration, uses uint32) error {
+	return nil
+}
+func (m mockManager) Lock(addr *Address) error                 { return nil }
+func (m mockManager) LockAll()                                 {}
+func (m mockManager) UnlockedAccounts() []*keystore.UnlockInfo { return nil }
 
 func (m mockManager) SignHash(addr *Address, hash byteutils.Hash, alg keystore.Algorithm) ([]byte, error) {
 	return nil, nil
@@ -217,6 +233,7 @@ func (m mockManager) SignHash(addr *Address, hash byteutils.Hash, alg keystore.A
 func (m mockManager) SignBlock(addr *Address, block *Block) error                        { return nil }
 func (m mockManager) SignTransaction(*Address, *Transaction) error                       { return nil }
 func (m mockManager) SignTransactionWithPassphrase(*Address, *Transaction, []byte) error { return nil }
+func (m mockManager) DecryptPayload(*Address, *Transaction) ([]byte, error)              { return nil, nil }
 
 func (m mockManager) Update(*Address, []byte, []byte) error   { return nil }
 func (m mockManager) Load([]byte, []byte) (*Address, error)   { return nil, nil }
@@ -258,6 +275,10 @@ func (n mockNetService) SendMessageToPeer(messageName string, data []byte, prior
 
 func (n mockNetService) ClosePeer(peerID string, reason error) {}
 
+func (n mockNetService) AddPeer(addr string) (string, error) { return "", nil }
+
+func (n mockNetService) RemovePeer(peerID string) error { return nil }
+
 func (n mockNetService) BroadcastNetworkID([]byte) {}
 
 type mockNeb struct {
@@ -329,6 +350,12 @@ func (nvm *mockNvm) CreateEngine(block *Block, tx *Transaction, contract state.A
 func (nvm *mockNvm) CheckV8Run() error {
 	return nil
 }
+func (nvm *mockNvm) CheckTypeScript(block *Block, source string) error {
+	return nil
+}
+func (nvm *mockNvm) ValidateContract(block *Block, source, sourceType string, modules map[string]string) error {
+	return nil
+}
 
 func (nvm *mockEngine) Dispose() {
 
@@ -336,6 +363,10 @@ func (nvm *mockEngine) Dispose() {
 func (nvm *mockEngine) SetExecutionLimits(uint64, uint64) error {
 	return nil
 }
+func (nvm *mockEngine) SetSourceMap(string) {
+}
+func (nvm *mockEngine) SetModules(map[string]string) {
+}
 func (nvm *mockEngine) DeployAndInit(source, sourceType, args string) (string, error) {
 	return "", nil
 }
@@ -347,8 +378,7 @@ func (nvm *mockEngine) ExecutionInstructions() uint64 {
 }
 
 func testNeb(t *testing.T) *mockNeb {
-	storage, err := storage.NewMemoryStorage()
-	assert.Nil(t, err)
+	storage := storage.NewMemoryBackend()
 	eventEmitter := NewEventEmitter(1024)
 	consensus := new(mockConsensus)
 	nvm := &mockNvm{}
@@ -742,3 +772,90 @@ func TestBlock_String(t *testing.T) {
 	bc := neb.chain
 	assert.NotNil(t, bc.genesisBlock.String())
 }
+
+func TestBlock_VerifyTransactionsIntegrity(t *testing.T) {
+	neb := testNeb(t)
+	bc := neb.chain
+
+	ks := keystore.DefaultKS
+	from := mockAddress()
+	key, err := ks.GetUnlocked(from.String())
+	assert.Nil(t, err)
+	signature, err := crypto.NewSignature(keystore.SECP256K1)
+	assert.Nil(t, err)
+	signature.InitSign(key.(keystore.PrivateKey))
+
+	txs := Transactions{}
+	for i := 1; i <= 16; i++ {
+		tx, _ := NewTransaction(bc.ChainID(), from, mockAddress(), util.NewUint128(), uint64(i), TxPayloadBinaryType, []byte("nas"), TransactionGasPrice, TransactionMaxGas)
+		assert.Nil(t, tx.Sign(signature))
+		txs = append(txs, tx)
+	}
+	block := &Block{header: &BlockHeader{chainID: bc.ChainID()}, transactions: txs}
+
+	parallelNum := VerifyIntegrityParallelNum
+	defer func() { VerifyIntegrityParallelNum = parallelNum }()
+
+	for _, num := range []int{1, 4} {
+		VerifyIntegrityParallelNum = num
+		assert.Nil(t, block.verifyTransactionsIntegrity())
+	}
+
+	// the batch reports a signature of another tx.
+	sign := txs[3].sign
+	txs[3].sign = txs[4].sign
+	for _, num := range []int{1, 4} {
+		VerifyIntegrityParallelNum = num
+		assert.Equal(t, ErrInvalidTransactionSigner, block.verifyTransactionsIntegrity())
+	}
+	txs[3].sign = sign
+
+	// tampered tx is found by all workers.
+	txs[9].nonce = 100
+	for _, num := range []int{1, 4} {
+		VerifyIntegrityParallelNum = num
+		assert.Equal(t, ErrInvalidTransactionHash, block.verifyTransactionsIntegrity())
+	}
+}
+
+func TestBlockReexecuteConflictedTx(t *testing.T) {
+	neb := testNeb(t)
+	bc := neb.chain
+	ks := keystore.DefaultKS
+	to := mockAddress()
+	block, err := bc.NewBlock(to)
+	assert.Nil(t, err)
+
+	gasLimit, _ := util.NewUint128FromInt(200000)
+	txs := []*Transaction{}
+	for i := 0; i < 2; i++ {
+		from := mockAddress()
+		key, err := ks.GetUnlocked(from.String())
+		assert.Nil(t, err)
+		signature, err := crypto.NewSignature(keystore.SECP256K1)
+		assert.Nil(t, err)
+		signature.InitSign(key.(keystore.PrivateKey))
+		tx, _ := NewTransaction(bc.ChainID(), from, to, util.NewUint128FromUint(1), 1, TxPayloadBinaryType, []byte("nas"), TransactionGasPrice, gasLimit)
+		assert.Nil(t, tx.Sign(signature))
+		txs = append(txs, tx)
+	}
+
+	// both txs transfer to the same account, the one merged later conflicts.
+	worldStates := []state.TxWorldState{}
+	for _, tx := range txs {
+		txWorldState, err := block.WorldState().Prepare(tx.Hash().String())
+		assert.Nil(t, err)
+		_, err = block.ExecuteTransaction(tx, txWorldState)
+		assert.Nil(t, err)
+		worldStates = append(worldStates, txWorldState)
+	}
+	_, err = worldStates[1].CheckAndUpdate()
+	assert.Nil(t, err)
+	_, err = worldStates[0].CheckAndUpdate()
+	assert.NotNil(t, err)
+	assert.Nil(t, worldStates[0].Close())
+
+	dependency, _, err := block.reexecuteTransaction(txs[0])
+	assert.Nil(t, err)
+	assert.Contains(t, dependency, txs[1].Hash().String())
+}
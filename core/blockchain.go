@@ -20,6 +20,7 @@ package core
 
 import (
 	"crypto/rand"
+	"encoding/json"
 	"io"
 	"strings"
 	"time"
@@ -28,7 +29,9 @@ import (
 
 	"github.com/gogo/protobuf/proto"
 	lru "github.com/hashicorp/golang-lru"
+	"github.com/nebulasio/go-nebulas/common/trie"
 	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/neblet/pb"
 	"github.com/nebulasio/go-nebulas/storage"
 	"github.com/nebulasio/go-nebulas/util"
 	"github.com/nebulasio/go-nebulas/util/byteutils"
@@ -64,7 +67,7 @@ type BlockChain struct {
 	// latest irreversible block
 	lib *Block
 
-	storage storage.Storage
+	*chainStorage
 
 	eventEmitter *EventEmitter
 
@@ -74,7 +77,29 @@ type BlockChain struct {
 
 	superNode bool
 
+	// the storage is opened read-only, nothing is written
+	readOnly bool
+
 	unsupportedKeyword string
+
+	// nil in archive mode
+	statePruner *StatePruner
+
+	// nil if state gc is disabled
+	stateGC *StateGC
+
+	// nil if the freezer is disabled
+	freezer          *storage.Freezer
+	freezerThreshold uint64
+
+	checkpoints *CheckpointManager
+	evidences   *EvidencePool
+	finality    *FinalityGadget
+
+	gasPriceOracle *GasPriceOracle
+
+	// nil unless execution tracing is enabled
+	tracer *Tracer
 }
 
 const (
@@ -90,6 +115,9 @@ const (
 
 	// LIB (latest irreversible block) in storage
 	LIB = "blockchain_lib"
+
+	// CommitJournal Key of the undo record of an interrupted block commit in storage
+	CommitJournal = "blockchain_journal"
 )
 
 // NewBlockChain create new #BlockChain instance.
@@ -98,7 +126,7 @@ func NewBlockChain(neb Neblet) (*BlockChain, error) {
 		return nil, ErrNilArgument
 	}
 
-	var gasPrice, gasLimit *util.Uint128
+	var gasPrice, gasLimit, blockMinGasPrice, blockGasLimitTarget *util.Uint128
 	var err error
 	if 0 == len(neb.Config().Chain.GasPrice) {
 		gasPrice = util.NewUint128()
@@ -118,6 +146,22 @@ func NewBlockChain(neb Neblet) (*BlockChain, error) {
 		}
 	}
 
+	if 0 == len(neb.Config().Chain.BlockMinGasPrice) {
+		blockMinGasPrice = util.NewUint128()
+	} else {
+		blockMinGasPrice, err = util.NewUint128FromString(neb.Config().Chain.BlockMinGasPrice)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if 0 < len(neb.Config().Chain.BlockGasLimitTarget) {
+		blockGasLimitTarget, err = util.NewUint128FromString(neb.Config().Chain.BlockGasLimitTarget)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	blockPool, err := NewBlockPool(128)
 	if err != nil {
 		return nil, err
@@ -132,6 +176,12 @@ func NewBlockChain(neb Neblet) (*BlockChain, error) {
 	if err := txPool.SetGasConfig(gasPrice, gasLimit); err != nil {
 		return nil, err
 	}
+	if err := txPool.SetBlockMinGasPrice(blockMinGasPrice); err != nil {
+		return nil, err
+	}
+	if err := txPool.SetBlockGasLimitTarget(blockGasLimitTarget); err != nil {
+		return nil, err
+	}
 	txPool.RegisterInNetwork(neb.NetService())
 
 	var bc = &BlockChain{
@@ -139,14 +189,34 @@ func NewBlockChain(neb Neblet) (*BlockChain, error) {
 		genesis:            neb.Genesis(),
 		bkPool:             blockPool,
 		txPool:             txPool,
-		storage:            neb.Storage(),
+		chainStorage:       newChainStorage(neb.Storage()),
 		eventEmitter:       neb.EventEmitter(),
 		nvm:                neb.Nvm(),
 		quitCh:             make(chan int, 1),
 		superNode:          neb.Config().Chain.SuperNode,
+		readOnly:           neb.Config().Chain.ReadOnly,
 		unsupportedKeyword: neb.Config().Chain.UnsupportedKeyword,
 	}
 
+	bc.checkpoints = NewCheckpointManager(bc, neb.Config().Chain.CheckpointInterval)
+	bc.checkpoints.RegisterInNetwork(neb.NetService())
+
+	bc.evidences = NewEvidencePool(bc)
+	bc.evidences.RegisterInNetwork(neb.NetService())
+
+	bc.finality = NewFinalityGadget(bc, neb.Config().Chain.EnableFinality)
+	bc.finality.RegisterInNetwork(neb.NetService())
+
+	bc.gasPriceOracle = NewGasPriceOracle(bc, GasPriceOracleBlocks)
+
+	if neb.Config().Chain.TraceExecution {
+		bc.tracer = NewTracer(bc.receiptStorage)
+	}
+
+	if size := neb.Config().Chain.TrieCacheSize; size > 0 {
+		trie.SetNodeCache(trie.NewNodeCache(int(size) << 20))
+	}
+
 	bc.cachedBlocks, err = lru.New(128)
 	if err != nil {
 		return nil, err
@@ -170,6 +240,16 @@ func (bc *BlockChain) Setup(neb Neblet) error {
 	if err := bc.CheckGenesisConfig(neb); err != nil {
 		return err
 	}
+	if err := SetSlashingSchedule(neb.Genesis().GetConsensus().GetDpos(), bc.consensusHandler.NumberOfBlocksInDynasty()); err != nil {
+		return err
+	}
+	if err := SetExecutionLimits(neb.Genesis().GetConsensus().GetExecutionLimits()); err != nil {
+		return err
+	}
+
+	if err := bc.recoverJournal(); err != nil {
+		return err
+	}
 
 	var err error
 	bc.genesisBlock, err = bc.LoadGenesisFromStorage()
@@ -193,6 +273,62 @@ func (bc *BlockChain) Setup(neb Neblet) error {
 		"block": bc.lib,
 	}).Info("Latest Irreversible Block.")
 
+	if err := bc.checkpoints.Setup(neb); err != nil {
+		return err
+	}
+	if err := bc.evidences.Setup(neb); err != nil {
+		return err
+	}
+	if err := bc.finality.Setup(neb); err != nil {
+		return err
+	}
+
+	// a read-only chain neither indexes nor prunes, it only reads the progress of the node.
+	if bc.readOnly {
+		logging.CLog().Info("BlockChain is opened read-only.")
+		return bc.setupFreezer(neb.Config().Chain.FreezerThreshold, bc.freezerDir(neb.Config().Chain))
+	}
+
+	if err := bc.initTransactionIndex(); err != nil {
+		return err
+	}
+
+	if err := bc.setupStatePruner(neb.Config().Chain); err != nil {
+		return err
+	}
+
+	if err := bc.setupFreezer(neb.Config().Chain.FreezerThreshold, bc.freezerDir(neb.Config().Chain)); err != nil {
+		return err
+	}
+
+	if interval := neb.Config().Chain.StateGcInterval; interval > 0 {
+		bc.stateGC = NewStateGC(bc, time.Duration(interval)*time.Second, neb.Config().Chain.StateGcRate)
+	}
+
+	return nil
+}
+
+// recoverJournal rolls back the block commit interrupted if any, a read-only
+// chain can't and only warns, the commit may be in progress by the node.
+func (bc *BlockChain) recoverJournal() error {
+	if bc.readOnly {
+		pending, err := bc.journal.Pending()
+		if err != nil {
+			return err
+		}
+		if pending {
+			logging.CLog().Warn("Found a block commit in progress, the blocks read may be partially stored.")
+		}
+		return nil
+	}
+
+	recovered, err := bc.journal.Recover()
+	if err != nil {
+		return err
+	}
+	if recovered {
+		logging.CLog().Warn("Rolled back an interrupted block commit.")
+	}
 	return nil
 }
 
@@ -200,12 +336,28 @@ func (bc *BlockChain) Setup(neb Neblet) error {
 func (bc *BlockChain) Start() {
 	logging.CLog().Info("Starting BlockChain...")
 
+	if !bc.readOnly {
+		bc.checkpoints.Start()
+		bc.evidences.Start()
+		bc.finality.Start()
+	}
+	if bc.stateGC != nil {
+		bc.stateGC.Start()
+	}
 	go bc.loop()
 }
 
 // Stop stop loop.
 func (bc *BlockChain) Stop() {
 	logging.CLog().Info("Stopping BlockChain...")
+	if !bc.readOnly {
+		bc.checkpoints.Stop()
+		bc.evidences.Stop()
+		bc.finality.Stop()
+	}
+	if bc.stateGC != nil {
+		bc.stateGC.Stop()
+	}
 	bc.quitCh <- 0
 }
 
@@ -215,10 +367,20 @@ func (bc *BlockChain) loop() {
 	for {
 		select {
 		case <-bc.quitCh:
+			if bc.freezer != nil {
+				bc.freezer.Close()
+			}
 			logging.CLog().Info("Stopped BlockChain.")
 			return
 		case <-timerChan:
-			bc.ConsensusHandler().UpdateLIB()
+			if !bc.readOnly {
+				bc.ConsensusHandler().UpdateLIB()
+				if err := bc.FreezeAncientBlocks(); err != nil {
+					logging.CLog().WithFields(logrus.Fields{
+						"err": err,
+					}).Error("Failed to move ancient blocks into the freezer.")
+				}
+			}
 			metricsLruCacheBlock.Update(int64(bc.cachedBlocks.Len()))
 			metricsLruTailBlock.Update(int64(bc.detachedTailBlocks.Len()))
 		}
@@ -250,23 +412,56 @@ func (bc *BlockChain) CheckGenesisConfig(neb Neblet) error {
 	}
 
 	logging.CLog().WithFields(logrus.Fields{
-		"meta.chainid":           neb.Genesis().Meta.ChainId,
-		"consensus.dpos.dynasty": neb.Genesis().Consensus.Dpos.Dynasty,
-		"token.distribution":     neb.Genesis().TokenDistribution,
+		"meta.chainid":         neb.Genesis().Meta.ChainId,
+		"consensus.validators": GenesisValidators(neb.Genesis()),
+		"token.distribution":   neb.Genesis().TokenDistribution,
 	}).Info("Genesis Configuration.")
 	return nil
 }
 
+func (bc *BlockChain) setupStatePruner(conf *nebletpb.ChainConfig) error {
+	switch conf.StateMode {
+	case "", StateModeArchive:
+		return nil
+	case StateModePruned:
+	default:
+		return ErrInvalidStateMode
+	}
+
+	pruner := NewStatePruner(bc, conf.StateRetention, conf.StatePinnedInterval)
+	if err := pruner.Setup(); err != nil {
+		return err
+	}
+	bc.statePruner = pruner
+
+	logging.CLog().WithFields(logrus.Fields{
+		"retention": pruner.retention,
+		"pinned":    pruner.pinnedInterval,
+		"pruned":    pruner.PrunedHeight(),
+	}).Info("State pruning is enabled.")
+	return nil
+}
+
 // ChainID return the chainID.
 func (bc *BlockChain) ChainID() uint32 {
 	return bc.chainID
 }
 
+// ReadOnly reports whether the blockchain is opened read-only.
+func (bc *BlockChain) ReadOnly() bool {
+	return bc.readOnly
+}
+
 // Storage return the storage.
 func (bc *BlockChain) Storage() storage.Storage {
 	return bc.storage
 }
 
+// StateStorage return the storage of the state trie nodes.
+func (bc *BlockChain) StateStorage() storage.Storage {
+	return bc.stateStorage
+}
+
 // GenesisBlock return the genesis block.
 func (bc *BlockChain) GenesisBlock() *Block {
 	return bc.genesisBlock
@@ -287,6 +482,36 @@ func (bc *BlockChain) SetLIB(lib *Block) {
 	bc.lib = lib
 }
 
+// LatestCheckpoint return the latest checkpoint, nil if there is none.
+func (bc *BlockChain) LatestCheckpoint() *Checkpoint {
+	return bc.checkpoints.Latest()
+}
+
+// CheckpointManager return the checkpoint manager.
+func (bc *BlockChain) CheckpointManager() *CheckpointManager {
+	return bc.checkpoints
+}
+
+// EvidencePool return the double sign evidence pool.
+func (bc *BlockChain) EvidencePool() *EvidencePool {
+	return bc.evidences
+}
+
+// FinalityGadget return the finality gadget.
+func (bc *BlockChain) FinalityGadget() *FinalityGadget {
+	return bc.finality
+}
+
+// GasPriceOracle return the gas price oracle.
+func (bc *BlockChain) GasPriceOracle() *GasPriceOracle {
+	return bc.gasPriceOracle
+}
+
+// Tracer return the execution tracer, nil if tracing is disabled.
+func (bc *BlockChain) Tracer() *Tracer {
+	return bc.tracer
+}
+
 // EventEmitter return the eventEmitter.
 func (bc *BlockChain) EventEmitter() *EventEmitter {
 	return bc.eventEmitter
@@ -301,25 +526,28 @@ func (bc *BlockChain) triggerRevertBlockEvent(blocks []string) {
 	}
 }
 
-func (bc *BlockChain) revertBlocks(from *Block, to *Block) error {
+// revertBlocks return the blocks in (from, to] which are abandoned, from the higher to the lower.
+func (bc *BlockChain) revertBlocks(from *Block, to *Block) ([]*Block, error) {
 	reverted := to
 	var revertTimes int64
 	blocks := []string{}
+	revertedBlocks := []*Block{}
 	for revertTimes = 0; !reverted.Hash().Equals(from.Hash()); {
 		if reverted.Hash().Equals(bc.lib.Hash()) {
-			return ErrCannotRevertLIB
+			return nil, ErrCannotRevertLIB
 		}
 
-		reverted.ReturnTransactions()
+		bc.deleteReceipts(reverted)
 		logging.VLog().WithFields(logrus.Fields{
 			"block": reverted,
 		}).Warn("A block is reverted.")
 		revertTimes++
 		blocks = append(blocks, reverted.String())
+		revertedBlocks = append(revertedBlocks, reverted)
 
 		reverted = bc.GetBlock(reverted.header.parentHash)
 		if reverted == nil {
-			return ErrMissingParentBlock
+			return nil, ErrMissingParentBlock
 		}
 	}
 	go bc.triggerRevertBlockEvent(blocks)
@@ -328,7 +556,87 @@ func (bc *BlockChain) revertBlocks(from *Block, to *Block) error {
 		metricsBlockRevertTimesGauge.Update(revertTimes)
 		metricsBlockRevertMeter.Mark(1)
 	}
-	return nil
+	return revertedBlocks, nil
+}
+
+// collectBlocks return the blocks in (from, to], from the higher to the lower.
+func (bc *BlockChain) collectBlocks(from *Block, to *Block) ([]*Block, error) {
+	blocks := []*Block{}
+	for !to.Hash().Equals(from.Hash()) {
+		blocks = append(blocks, to)
+		to = bc.GetBlock(to.header.parentHash)
+		if to == nil {
+			return nil, ErrMissingParentBlock
+		}
+	}
+	return blocks, nil
+}
+
+// reinjectTransactions give the txs in abandoned blocks back to tx pool,
+// except the ones which are packed in the adopted blocks too.
+func (bc *BlockChain) reinjectTransactions(reverted []*Block, applied []*Block) {
+	packed := make(map[byteutils.HexHash]bool)
+	for _, block := range applied {
+		for _, tx := range block.transactions {
+			packed[tx.hash.Hex()] = true
+		}
+	}
+
+	for _, block := range reverted {
+		for _, tx := range block.transactions {
+			if packed[tx.hash.Hex()] {
+				continue
+			}
+			if err := bc.txPool.Push(tx); err != nil {
+				logging.VLog().WithFields(logrus.Fields{
+					"tx":    tx.StringWithoutData(),
+					"block": block,
+					"err":   err,
+				}).Debug("Failed to reinject tx of reverted block.")
+				continue
+			}
+			metricsTxReinjected.Inc(1)
+		}
+	}
+}
+
+// ChainReorg describes a switch of the canonical chain to another fork.
+type ChainReorg struct {
+	OldTail        string   `json:"old_tail"`
+	NewTail        string   `json:"new_tail"`
+	CommonAncestor string   `json:"common_ancestor"`
+	Reverted       []string `json:"reverted"`
+	Applied        []string `json:"applied"`
+}
+
+func (bc *BlockChain) triggerChainReorgEvent(ancestor *Block, reverted []*Block, applied []*Block) {
+	reorg := &ChainReorg{
+		OldTail:        reverted[0].Hash().String(),
+		NewTail:        applied[0].Hash().String(),
+		CommonAncestor: ancestor.Hash().String(),
+		Reverted:       make([]string, len(reverted)),
+		Applied:        make([]string, len(applied)),
+	}
+	for i, v := range reverted {
+		reorg.Reverted[i] = v.Hash().String()
+	}
+	for i, v := range applied {
+		reorg.Applied[i] = v.Hash().String()
+	}
+
+	data, err := json.Marshal(reorg)
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"reorg": reorg,
+			"err":   err,
+		}).Debug("Failed to marshal chain reorg event.")
+		return
+	}
+
+	bc.eventEmitter.Trigger(&state.Event{
+		Topic: TopicChainReorg,
+		Data:  string(data),
+	})
 }
 
 func (bc *BlockChain) dropTxsInBlockFromTxPool(block *Block) {
@@ -344,6 +652,7 @@ func (bc *BlockChain) triggerNewTailEvent(blocks []*Block) {
 			Topic: TopicNewTailBlock,
 			Data:  block.String(),
 		})
+		bc.triggerNewDynastyEvent(block)
 
 		for _, v := range block.transactions {
 			events, err := block.FetchEvents(v.hash)
@@ -351,18 +660,60 @@ func (bc *BlockChain) triggerNewTailEvent(blocks []*Block) {
 				for _, e := range events {
 					bc.eventEmitter.Trigger(e)
 				}
+				bc.triggerContractEvents(block, v, events)
 			}
 		}
 	}
 }
 
+// triggerNewDynastyEvent notify the dynasty if the block starts another one.
+func (bc *BlockChain) triggerNewDynastyEvent(block *Block) {
+	parent := bc.GetBlock(block.ParentHash())
+	if parent == nil || byteutils.Equal(parent.ConsensusRoot().DynastyRoot, block.ConsensusRoot().DynastyRoot) {
+		return
+	}
+
+	dynasty, err := block.Dynasty()
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"block": block,
+			"err":   err,
+		}).Debug("Failed to get the dynasty of new tail block.")
+		return
+	}
+	event := &NewDynastyEvent{
+		Height:      block.Height(),
+		Hash:        block.Hash().String(),
+		DynastyRoot: byteutils.Hex(block.ConsensusRoot().DynastyRoot),
+		Miners:      make([]string, len(dynasty)),
+	}
+	for i, v := range dynasty {
+		event.Miners[i] = v.Base58()
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"block": block,
+			"err":   err,
+		}).Debug("Failed to marshal new dynasty event.")
+		return
+	}
+	bc.eventEmitter.Trigger(&state.Event{
+		Topic: TopicNewDynasty,
+		Data:  string(data),
+	})
+}
+
 func (bc *BlockChain) buildIndexByBlockHeight(from *Block, to *Block) error {
 	blocks := []*Block{}
 	for !to.Hash().Equals(from.Hash()) {
-		err := bc.storage.Put(byteutils.FromUint64(to.height), to.Hash())
+		err := bc.headerStorage.Put(byteutils.FromUint64(to.height), to.Hash())
 		if err != nil {
 			return err
 		}
+		bc.storeReceipts(to)
+		bc.storeContractEvents(to)
 		blocks = append(blocks, to)
 		go bc.dropTxsInBlockFromTxPool(to)
 		to = bc.GetBlock(to.header.parentHash)
@@ -389,13 +740,100 @@ func (bc *BlockChain) SetTailBlock(newTail *Block) error {
 		return err
 	}
 
-	if err := bc.revertBlocks(ancestor, oldTail); err != nil {
+	// states below the pruned height are gone, the fork cannot be switched to.
+	if bc.statePruner != nil && ancestor.height < bc.statePruner.PrunedHeight() {
+		logging.VLog().WithFields(logrus.Fields{
+			"ancestor": ancestor,
+			"pruned":   bc.statePruner.PrunedHeight(),
+			"target":   newTail,
+		}).Debug("Failed to switch to a fork below the pruned height.")
+		return ErrForkBelowPrunedState
+	}
+
+	// blocks at and below the latest checkpoint are final.
+	if cp := bc.LatestCheckpoint(); cp != nil && ancestor.height < cp.height {
+		logging.VLog().WithFields(logrus.Fields{
+			"ancestor":   ancestor,
+			"checkpoint": cp.height,
+			"target":     newTail,
+		}).Debug("Failed to switch to a fork below the latest checkpoint.")
+		return ErrForkBelowCheckpoint
+	}
+
+	// the indexes and the new tail are committed all-or-nothing.
+	bc.journal.Begin()
+	reverted, applied, err := bc.switchTail(ancestor, oldTail, newTail)
+	if err != nil {
+		bc.journal.Rollback()
+		return err
+	}
+	if err := bc.journal.Commit(); err != nil {
+		return err
+	}
+	bc.tailBlock = newTail
+
+	if bc.statePruner != nil {
+		if err := bc.statePruner.Prune(reverted, applied, newTail); err != nil {
+			// the reference counts are broken, stop pruning and count them again at next start.
+			logging.CLog().WithFields(logrus.Fields{
+				"tail": newTail,
+				"err":  err,
+			}).Error("Failed to prune states, state pruning is disabled.")
+			bc.statePruner = nil
+		}
+	}
+
+	if len(reverted) > 0 && len(applied) > 0 {
+		go bc.triggerChainReorgEvent(ancestor, reverted, applied)
+	}
+
+	logging.CLog().WithFields(logrus.Fields{
+		"tail": newTail,
+	}).Info("Succeed to update new tail.")
+
+	bc.finality.onNewTail(newTail)
+	bc.checkpoints.onNewTail(newTail)
+
+	metricsBlockHeightGauge.Update(int64(newTail.Height()))
+	metricsBlocktailHashGauge.Update(int64(byteutils.HashBytes(newTail.Hash())))
+
+	return nil
+}
+
+// switchTail reverts the blocks of the old tail down to the ancestor, applies
+// the ones of the new tail and records it.
+func (bc *BlockChain) switchTail(ancestor, oldTail, newTail *Block) ([]*Block, []*Block, error) {
+	reverted, err := bc.revertBlocks(ancestor, oldTail)
+	if err != nil {
 		logging.VLog().WithFields(logrus.Fields{
 			"from":  ancestor,
 			"to":    oldTail,
 			"range": "(from, to]",
 		}).Debug("Failed to revert blocks.")
-		return err
+		return nil, nil, err
+	}
+
+	applied, err := bc.collectBlocks(ancestor, newTail)
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"from":  ancestor,
+			"to":    newTail,
+			"range": "(from, to]",
+		}).Debug("Failed to collect blocks.")
+		return nil, nil, err
+	}
+
+	if len(reverted) > 0 {
+		// the heavier fork is adopted, give txs in abandoned blocks back to tx pool.
+		bc.reinjectTransactions(reverted, applied)
+
+		logging.CLog().WithFields(logrus.Fields{
+			"ancestor": ancestor,
+			"oldTail":  oldTail,
+			"newTail":  newTail,
+			"reverted": len(reverted),
+			"applied":  len(applied),
+		}).Warn("Chain reorganized.")
 	}
 
 	// build index by block height
@@ -405,23 +843,24 @@ func (bc *BlockChain) SetTailBlock(newTail *Block) error {
 			"to":    newTail,
 			"range": "(from, to]",
 		}).Debug("Failed to build index by block height.")
-		return err
+		return nil, nil, err
+	}
+
+	// index txs by address
+	if err := bc.updateTransactionIndex(oldTail, reverted, applied); err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"from": ancestor,
+			"to":   newTail,
+			"err":  err,
+		}).Debug("Failed to update transaction index.")
+		return nil, nil, err
 	}
 
 	// record new tail
 	if err := bc.StoreTailHashToStorage(newTail); err != nil { // Refine: rename, delete ToStorage
-		return err
+		return nil, nil, err
 	}
-	bc.tailBlock = newTail
-
-	logging.CLog().WithFields(logrus.Fields{
-		"tail": newTail,
-	}).Info("Succeed to update new tail.")
-
-	metricsBlockHeightGauge.Update(int64(newTail.Height()))
-	metricsBlocktailHashGauge.Update(int64(byteutils.HashBytes(newTail.Hash())))
-
-	return nil
+	return reverted, applied, nil
 }
 
 // GetBlockOnCanonicalChainByHeight return block in given height
@@ -431,13 +870,37 @@ func (bc *BlockChain) GetBlockOnCanonicalChainByHeight(height uint64) *Block {
 		return nil
 	}
 
-	blockHash, err := bc.storage.Get(byteutils.FromUint64(height))
+	blockHash, err := bc.headerStorage.Get(byteutils.FromUint64(height))
 	if err != nil {
 		return nil
 	}
 	return bc.GetBlock(blockHash)
 }
 
+// GetBlocks return at most count blocks starting at the block of the hash, following
+// its ancestors if reverse is set, or the canonical chain towards the tail otherwise.
+func (bc *BlockChain) GetBlocks(from byteutils.Hash, count int, reverse bool) []*Block {
+	blocks := []*Block{}
+	block := bc.GetBlock(from)
+	if block == nil || !reverse && bc.GetBlockOnCanonicalChainByHash(from) == nil {
+		return blocks
+	}
+
+	for block != nil && len(blocks) < count {
+		blocks = append(blocks, block)
+		if !reverse {
+			block = bc.GetBlockOnCanonicalChainByHeight(block.height + 1)
+			continue
+		}
+		// the parent hash of the genesis refers to itself.
+		if block.Hash().Equals(bc.genesisBlock.Hash()) {
+			break
+		}
+		block = bc.GetBlock(block.header.parentHash)
+	}
+	return blocks
+}
+
 // GetBlockOnCanonicalChainByHash check if a block is on canonical chain
 func (bc *BlockChain) GetBlockOnCanonicalChainByHash(blockHash byteutils.Hash) *Block {
 	blockByHash := bc.GetBlock(blockHash)
@@ -612,15 +1075,39 @@ func (bc *BlockChain) NewBlockFromParent(coinbase *Address, parentBlock *Block)
 
 // PutVerifiedNewBlocks put verified new blocks and tails.
 func (bc *BlockChain) putVerifiedNewBlocks(parent *Block, allBlocks, tailBlocks []*Block) error {
+	// the blocks are stored all-or-nothing.
+	bc.journal.Begin()
 	for _, v := range allBlocks {
-		bc.cachedBlocks.Add(v.Hash().Hex(), v)
 		if err := bc.StoreBlockToStorage(v); err != nil {
+			bc.journal.Rollback()
 			logging.VLog().WithFields(logrus.Fields{
 				"block": v,
 				"err":   err,
 			}).Debug("Failed to store the verified block.")
 			return err
 		}
+	}
+	if err := bc.journal.Commit(); err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"blocks": len(allBlocks),
+			"err":    err,
+		}).Debug("Failed to commit the verified blocks.")
+		return err
+	}
+
+	if bc.statePruner != nil {
+		if err := bc.statePruner.Track(allBlocks); err != nil {
+			// the reference counts are broken, stop pruning and count them again at next start.
+			logging.CLog().WithFields(logrus.Fields{
+				"blocks": len(allBlocks),
+				"err":    err,
+			}).Error("Failed to count states of new blocks, state pruning is disabled.")
+			bc.statePruner = nil
+		}
+	}
+
+	for _, v := range allBlocks {
+		bc.cachedBlocks.Add(v.Hash().Hex(), v)
 
 		logging.VLog().WithFields(logrus.Fields{
 			"block": v,
@@ -732,8 +1219,54 @@ type SimulateResult struct {
 	Err     error
 }
 
-// SimulateTransactionExecution execute transaction in sandbox and rollback all changes, used to EstimateGas and Call api.
+// SimulateTransactionExecution execute transaction in sandbox and rollback all changes, used to EstimateGas api.
 func (bc *BlockChain) SimulateTransactionExecution(tx *Transaction) (*SimulateResult, error) {
+	return bc.simulateTransactionExecution(tx, false, TransactionMaxGas)
+}
+
+// StaticCallTransaction execute transaction in sandbox like SimulateTransactionExecution,
+// but the contracts can only read the state, used to Call api and view functions.
+func (bc *BlockChain) StaticCallTransaction(tx *Transaction) (*SimulateResult, error) {
+	return bc.simulateTransactionExecution(tx, true, TransactionMaxGas)
+}
+
+// EstimateGas return the gas limit the tx requires and its simulated result. The
+// gas used by a metering pass with the max gas is usually enough, otherwise the
+// least gas limit the tx succeeds with is searched up to the max gas, since the
+// contracts may read the gas limit. The result of a failed tx is returned as is.
+func (bc *BlockChain) EstimateGas(tx *Transaction) (*SimulateResult, error) {
+	result, err := bc.SimulateTransactionExecution(tx)
+	if err != nil || result.Err != nil {
+		return result, err
+	}
+
+	lower, upper := result.GasUsed.Uint64(), TransactionMaxGas.Uint64()
+	estimated := result
+	for i := 0; i < MaxEstimateGasRounds && lower < upper; i++ {
+		limit := lower
+		if i > 0 {
+			limit = lower + (upper-lower)/2
+		}
+		r, err := bc.simulateTransactionExecution(tx, false, util.NewUint128FromUint(limit))
+		if err != nil {
+			return nil, err
+		}
+		if r.Err == nil {
+			upper, estimated = limit, r
+		} else {
+			lower = limit + 1
+		}
+	}
+
+	logging.VLog().WithFields(logrus.Fields{
+		"tx":      tx,
+		"metered": result.GasUsed,
+		"gas":     upper,
+	}).Debug("Estimated gas.")
+	return &SimulateResult{util.NewUint128FromUint(upper), estimated.Msg, nil}, nil
+}
+
+func (bc *BlockChain) simulateTransactionExecution(tx *Transaction, readOnly bool, gasLimit *util.Uint128) (*SimulateResult, error) {
 	if tx == nil {
 		return nil, ErrInvalidArgument
 	}
@@ -749,11 +1282,12 @@ func (bc *BlockChain) SimulateTransactionExecution(tx *Transaction) (*SimulateRe
 	_, _ = io.ReadFull(rand.Reader, sVrfProof)
 	block.header.random.VrfSeed = sVrfSeed
 	block.header.random.VrfProof = sVrfProof
+	block.readOnly = readOnly
 
 	defer block.RollBack()
 
 	// simulate execution.
-	return tx.simulateExecution(block)
+	return tx.simulateExecutionWithGas(block, gasLimit)
 }
 
 // Dump dump full chain.
@@ -782,26 +1316,31 @@ func (bc *BlockChain) StoreBlockToStorage(block *Block) error {
 	if err != nil {
 		return err
 	}
-	err = bc.storage.Put(block.Hash(), value)
+	err = bc.bodyStorage.Put(block.Hash(), value)
 	if err != nil {
 		return err
 	}
+	if bc.tracer != nil {
+		if err := bc.tracer.Store(block); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
 // StoreTailHashToStorage store tail block hash
 func (bc *BlockChain) StoreTailHashToStorage(block *Block) error { // ToRefine, update func to StoreTailHashToStorage
-	return bc.storage.Put([]byte(Tail), block.Hash())
+	return bc.headerStorage.Put([]byte(Tail), block.Hash())
 }
 
 // StoreLIBHashToStorage store LIB block hash
 func (bc *BlockChain) StoreLIBHashToStorage(block *Block) error {
-	return bc.storage.Put([]byte(LIB), block.Hash())
+	return bc.headerStorage.Put([]byte(LIB), block.Hash())
 }
 
 // LoadTailFromStorage load tail block
 func (bc *BlockChain) LoadTailFromStorage() (*Block, error) {
-	hash, err := bc.storage.Get([]byte(Tail))
+	hash, err := bc.headerStorage.Get([]byte(Tail))
 	if err != nil && err != storage.ErrKeyNotFound {
 		return nil, err
 	}
@@ -810,6 +1349,9 @@ func (bc *BlockChain) LoadTailFromStorage() (*Block, error) {
 		if err != nil {
 			return nil, err
 		}
+		if bc.readOnly {
+			return genesis, nil
+		}
 
 		if err := bc.StoreTailHashToStorage(genesis); err != nil {
 			return nil, err
@@ -828,6 +1370,9 @@ func (bc *BlockChain) LoadGenesisFromStorage() (*Block, error) { // ToRefine, re
 		return nil, err
 	}
 	if err == storage.ErrKeyNotFound {
+		if bc.readOnly {
+			return nil, ErrMissingGenesisBlock
+		}
 		genesis, err = NewGenesisBlock(bc.genesis, bc)
 		if err != nil {
 			return nil, err
@@ -836,7 +1381,7 @@ func (bc *BlockChain) LoadGenesisFromStorage() (*Block, error) { // ToRefine, re
 			return nil, err
 		}
 		heightKey := byteutils.FromUint64(genesis.height)
-		if err := bc.storage.Put(heightKey, genesis.Hash()); err != nil {
+		if err := bc.headerStorage.Put(heightKey, genesis.Hash()); err != nil {
 			return nil, err
 		}
 	}
@@ -845,12 +1390,15 @@ func (bc *BlockChain) LoadGenesisFromStorage() (*Block, error) { // ToRefine, re
 
 // LoadLIBFromStorage load LIB
 func (bc *BlockChain) LoadLIBFromStorage() (*Block, error) {
-	hash, err := bc.storage.Get([]byte(LIB))
+	hash, err := bc.headerStorage.Get([]byte(LIB))
 	if err != nil && err != storage.ErrKeyNotFound {
 		return nil, err
 	}
 
 	if err == storage.ErrKeyNotFound {
+		if bc.readOnly {
+			return bc.genesisBlock, nil
+		}
 		if err := bc.StoreLIBHashToStorage(bc.genesisBlock); err != nil {
 			return nil, err
 		}
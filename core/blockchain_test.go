@@ -165,6 +165,34 @@ func TestBlockChain_SimulateTransactionExecution(t *testing.T) {
 	assert.Equal(t, expectedGasUsed, result.GasUsed)
 }
 
+func TestBlockChain_EstimateGas(t *testing.T) {
+	neb := testNeb(t)
+	bc := neb.chain
+	from, to := mockAddress(), mockAddress()
+
+	payload, err := NewBinaryPayload(nil).ToBytes()
+	assert.Nil(t, err)
+	gasLimit, _ := util.NewUint128FromInt(200000)
+	tx, _ := NewTransaction(bc.ChainID(), from, to, util.NewUint128(), 1, TxPayloadBinaryType, payload, TransactionGasPrice, gasLimit)
+
+	// the result of a failed tx is returned as is.
+	result, err := bc.EstimateGas(tx)
+	assert.Nil(t, err)
+	assert.Equal(t, ErrInsufficientBalance, result.Err)
+
+	block := bc.tailBlock
+	assert.Nil(t, block.Begin())
+	acc, err := block.WorldState().GetOrCreateUserAccount(from.Bytes())
+	assert.Nil(t, err)
+	assert.Nil(t, acc.AddBalance(util.NewUint128FromUint(1000000000000000000)))
+	assert.Nil(t, block.WorldState().Commit())
+
+	result, err = bc.EstimateGas(tx)
+	assert.Nil(t, err)
+	assert.Nil(t, result.Err)
+	assert.Equal(t, util.NewUint128FromUint(20000), result.GasUsed)
+}
+
 func TestTailBlock(t *testing.T) {
 	neb := testNeb(t)
 	bc := neb.chain
@@ -262,3 +290,29 @@ func TestGetPrice(t *testing.T) {
 	bc.SetTailBlock(block)
 	assert.Equal(t, bc.GasPrice(), lowerGasPrice)
 }
+
+func TestBlockChain_ReinjectTransactions(t *testing.T) {
+	neb := testNeb(t)
+	bc := neb.chain
+
+	ks := keystore.DefaultKS
+	from := mockAddress()
+	key, err := ks.GetUnlocked(from.String())
+	assert.Nil(t, err)
+	signature, err := crypto.NewSignature(keystore.SECP256K1)
+	assert.Nil(t, err)
+	signature.InitSign(key.(keystore.PrivateKey))
+
+	gasLimit, _ := util.NewUint128FromInt(200000)
+	tx1, _ := NewTransaction(bc.ChainID(), from, from, util.NewUint128(), 1, TxPayloadBinaryType, []byte("nas"), TransactionGasPrice, gasLimit)
+	assert.Nil(t, tx1.Sign(signature))
+	tx2, _ := NewTransaction(bc.ChainID(), from, from, util.NewUint128(), 2, TxPayloadBinaryType, []byte("nas"), TransactionGasPrice, gasLimit)
+	assert.Nil(t, tx2.Sign(signature))
+
+	reverted := []*Block{{transactions: Transactions{tx1, tx2}}}
+	applied := []*Block{{transactions: Transactions{tx1}}}
+	bc.reinjectTransactions(reverted, applied)
+
+	assert.Nil(t, bc.txPool.GetTransaction(tx1.Hash()))
+	assert.NotNil(t, bc.txPool.GetTransaction(tx2.Hash()))
+}
@@ -0,0 +1,288 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"bytes"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+)
+
+// Names of the upgrades in chain config
+const (
+	ForkTransferFromContractEventRecordable        = "TransferFromContractEventRecordable"
+	ForkAcceptFuncAvailable                        = "AcceptFuncAvailable"
+	ForkRandomAvailable                            = "RandomAvailable"
+	ForkDateAvailable                              = "DateAvailable"
+	ForkRecordCallContractResult                   = "RecordCallContractResult"
+	ForkNvmMemoryLimitWithoutInject                = "NvmMemoryLimitWithoutInject"
+	ForkWsResetRecordDependency                    = "WsResetRecordDependency"
+	ForkV8JSLibVersionControl                      = "V8JSLibVersionControl"
+	ForkTransferFromContractFailureEventRecordable = "TransferFromContractFailureEventRecordable"
+	ForkNewNvmExeTimeoutConsumeGas                 = "NewNvmExeTimeoutConsumeGas"
+	ForkDynamicGasLimit                            = "DynamicGasLimit"
+	ForkVRFProposer                                = "VRFProposer"
+	ForkDoubleSignEvidence                         = "DoubleSignEvidence"
+	ForkValidatorLiveness                          = "ValidatorLiveness"
+	ForkProofOfDevotion                            = "ProofOfDevotion"
+	ForkContractCall                               = "ContractCall"
+	ForkWasm                                       = "Wasm"
+	ForkExecutionLimits                            = "ExecutionLimits"
+	ForkStorageKeys                                = "StorageKeys"
+	ForkBlockchainRandom                           = "BlockchainRandom"
+	ForkContractUpgrade                            = "ContractUpgrade"
+	ForkContractTransfer                           = "ContractTransfer"
+	ForkContractModules                            = "ContractModules"
+	ForkBlockHistory                               = "BlockHistory"
+	ForkSizedGas                                   = "SizedGas"
+	ForkContractValidation                         = "ContractValidation"
+	ForkContractABI                                = "ContractABI"
+	ForkLibSnapshot                                = "LibSnapshot"
+	ForkEd25519                                    = "Ed25519"
+	ForkMultisig                                   = "Multisig"
+	ForkKeyRotation                                = "KeyRotation"
+	ForkEncryptedPayload                           = "EncryptedPayload"
+)
+
+// Fork is an upgrade of the protocol, enabled since the height
+type Fork struct {
+	Name   string
+	Height uint64
+}
+
+// ChainConfig lists the upgrades of a chain
+type ChainConfig struct {
+	ChainID uint32
+	Forks   []*Fork
+}
+
+// chain configs of known chains
+var (
+	// MainNetChainConfig upgrades of mainnet
+	MainNetChainConfig = &ChainConfig{
+		ChainID: MainNetID,
+		Forks: []*Fork{
+			{ForkTransferFromContractEventRecordable, MainNetTransferFromContractEventRecordableHeight},
+			{ForkAcceptFuncAvailable, MainNetAcceptFuncAvailableHeight},
+			{ForkRandomAvailable, MainNetRandomAvailableHeight},
+			{ForkDateAvailable, MainNetDateAvailableHeight},
+			{ForkRecordCallContractResult, MainNetRecordCallContractResultHeight},
+			{ForkNvmMemoryLimitWithoutInject, MainNetNvmMemoryLimitWithoutInjectHeight},
+			{ForkWsResetRecordDependency, MainNetWsResetRecordDependencyHeight},
+			{ForkV8JSLibVersionControl, MainNetV8JSLibVersionControlHeight},
+			{ForkTransferFromContractFailureEventRecordable, MainNetTransferFromContractFailureEventRecordableHeight},
+			{ForkNewNvmExeTimeoutConsumeGas, MainNetNewNvmExeTimeoutConsumeGasHeight},
+		},
+	}
+
+	// TestNetChainConfig upgrades of testnet
+	TestNetChainConfig = &ChainConfig{
+		ChainID: TestNetID,
+		Forks: []*Fork{
+			{ForkTransferFromContractEventRecordable, TestNetTransferFromContractEventRecordableHeight},
+			{ForkAcceptFuncAvailable, TestNetAcceptFuncAvailableHeight},
+			{ForkRandomAvailable, TestNetRandomAvailableHeight},
+			{ForkDateAvailable, TestNetDateAvailableHeight},
+			{ForkRecordCallContractResult, TestNetRecordCallContractResultHeight},
+			{ForkNvmMemoryLimitWithoutInject, TestNetNvmMemoryLimitWithoutInjectHeight},
+			{ForkWsResetRecordDependency, TestNetWsResetRecordDependencyHeight},
+			{ForkV8JSLibVersionControl, TestNetV8JSLibVersionControlHeight},
+			{ForkTransferFromContractFailureEventRecordable, TestNetTransferFromContractFailureEventRecordableHeight},
+			{ForkNewNvmExeTimeoutConsumeGas, TestNetNewNvmExeTimeoutConsumeGasHeight},
+		},
+	}
+
+	// LocalChainConfig upgrades of local/develop chains
+	LocalChainConfig = &ChainConfig{
+		Forks: []*Fork{
+			{ForkTransferFromContractEventRecordable, LocalTransferFromContractEventRecordableHeight},
+			{ForkAcceptFuncAvailable, LocalAcceptFuncAvailableHeight},
+			{ForkRandomAvailable, LocalRandomAvailableHeight},
+			{ForkDateAvailable, LocalDateAvailableHeight},
+			{ForkRecordCallContractResult, LocalRecordCallContractResultHeight},
+			{ForkNvmMemoryLimitWithoutInject, LocalNvmMemoryLimitWithoutInjectHeight},
+			{ForkWsResetRecordDependency, LocalWsResetRecordDependencyHeight},
+			{ForkV8JSLibVersionControl, LocalV8JSLibVersionControlHeight},
+			{ForkTransferFromContractFailureEventRecordable, LocalTransferFromContractFailureEventRecordableHeight},
+			{ForkNewNvmExeTimeoutConsumeGas, LocalNewNvmExeTimeoutConsumeGasHeight},
+			{ForkDynamicGasLimit, LocalDynamicGasLimitHeight},
+			{ForkContractCall, LocalContractCallHeight},
+			{ForkWasm, LocalWasmHeight},
+			{ForkExecutionLimits, LocalExecutionLimitsHeight},
+			{ForkStorageKeys, LocalStorageKeysHeight},
+			{ForkBlockchainRandom, LocalBlockchainRandomHeight},
+			{ForkContractUpgrade, LocalContractUpgradeHeight},
+			{ForkContractTransfer, LocalContractTransferHeight},
+			{ForkContractModules, LocalContractModulesHeight},
+			{ForkBlockHistory, LocalBlockHistoryHeight},
+			{ForkSizedGas, LocalSizedGasHeight},
+			{ForkContractValidation, LocalContractValidationHeight},
+			{ForkContractABI, LocalContractABIHeight},
+			{ForkLibSnapshot, LocalLibSnapshotHeight},
+			{ForkEd25519, LocalEd25519Height},
+			{ForkMultisig, LocalMultisigHeight},
+			{ForkKeyRotation, LocalKeyRotationHeight},
+			{ForkEncryptedPayload, LocalEncryptedPayloadHeight},
+			{ForkVRFProposer, LocalVRFProposerHeight},
+			{ForkDoubleSignEvidence, LocalDoubleSignEvidenceHeight},
+			{ForkValidatorLiveness, LocalValidatorLivenessHeight},
+			{ForkProofOfDevotion, LocalProofOfDevotionHeight},
+		},
+	}
+
+	// CurrentChainConfig the chain config in use, set by SetCompatibilityOptions
+	CurrentChainConfig = TestNetChainConfig
+)
+
+// GetChainConfig return the chain config of the chain, local chains share the same config.
+func GetChainConfig(chainID uint32) *ChainConfig {
+	switch chainID {
+	case MainNetID:
+		return MainNetChainConfig
+	case TestNetID:
+		return TestNetChainConfig
+	default:
+		return LocalChainConfig
+	}
+}
+
+// Height return the height since which the upgrade is enabled, math.MaxUint64 if the upgrade is unknown.
+func (c *ChainConfig) Height(name string) uint64 {
+	for _, fork := range c.Forks {
+		if fork.Name == name {
+			return fork.Height
+		}
+	}
+	return math.MaxUint64
+}
+
+// IsActive return if the upgrade is enabled at the height
+func (c *ChainConfig) IsActive(name string, height uint64) bool {
+	return height >= c.Height(name)
+}
+
+// ActiveForks return names of the upgrades enabled at the height
+func (c *ChainConfig) ActiveForks(height uint64) []string {
+	forks := []string{}
+	for _, fork := range c.Forks {
+		if height >= fork.Height {
+			forks = append(forks, fork.Name)
+		}
+	}
+	return forks
+}
+
+// ForkID identifies the upgrades enabled at a height and the next scheduled one, the same as EIP-2124.
+// Nodes agree on the upgrades passed, and may differ in the ones not reached yet.
+type ForkID struct {
+	Hash string
+	Next uint64 // 0 if no upgrade is scheduled
+}
+
+// String return the fork id in handshake, as hash-next
+func (id *ForkID) String() string {
+	return id.Hash + "-" + strconv.FormatUint(id.Next, 10)
+}
+
+// ParseForkID parse the fork id from handshake
+func ParseForkID(s string) (*ForkID, error) {
+	parts := strings.Split(s, "-")
+	if len(parts) != 2 || len(parts[0]) == 0 {
+		return nil, ErrInvalidForkID
+	}
+	next, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return nil, ErrInvalidForkID
+	}
+	return &ForkID{Hash: parts[0], Next: next}, nil
+}
+
+// sortedForks return the upgrades in order of height, then name
+func (c *ChainConfig) sortedForks() []*Fork {
+	forks := make([]*Fork, len(c.Forks))
+	copy(forks, c.Forks)
+	sort.Slice(forks, func(i, j int) bool {
+		if forks[i].Height != forks[j].Height {
+			return forks[i].Height < forks[j].Height
+		}
+		return forks[i].Name < forks[j].Name
+	})
+	return forks
+}
+
+// forkIDs return the fork ids of the schedule, one for the heights before any upgrade and one since each upgrade height
+func (c *ChainConfig) forkIDs() []*ForkID {
+	forks := c.sortedForks()
+
+	var buf bytes.Buffer
+	ids := []*ForkID{}
+	for i := 0; i <= len(forks); i++ {
+		if i > 0 {
+			buf.WriteString(forks[i-1].Name)
+			buf.WriteString("=")
+			buf.WriteString(strconv.FormatUint(forks[i-1].Height, 10))
+			buf.WriteString(";")
+		}
+		// upgrades at the same height are enabled together.
+		if i < len(forks) && i > 0 && forks[i].Height == forks[i-1].Height {
+			continue
+		}
+		id := &ForkID{Hash: byteutils.Hex(hash.Sha3256(buf.Bytes())[:8])}
+		if i < len(forks) {
+			id.Next = forks[i].Height
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// ForkID return the fork id at the height, hashing the upgrades enabled and telling the next one.
+func (c *ChainConfig) ForkID(height uint64) *ForkID {
+	ids := c.forkIDs()
+	for _, id := range ids {
+		if id.Next == 0 || height < id.Next {
+			return id
+		}
+	}
+	return ids[len(ids)-1]
+}
+
+// CheckForkID check if the peer with the fork id is compatible with the local chain at the height.
+// The peer is compatible if it enabled the same upgrades and is not waiting for one the local chain passed,
+// or it is behind or ahead of the local chain on the same schedule.
+func (c *ChainConfig) CheckForkID(height uint64, remote *ForkID) bool {
+	for _, id := range c.forkIDs() {
+		if id.Hash != remote.Hash {
+			continue
+		}
+		if id.Next == 0 || height < id.Next {
+			// the same upgrades enabled, or the peer is ahead on the local schedule.
+			// the scheduled upgrades may differ, unless the local chain passed one the peer waits for.
+			return remote.Next == 0 || height < remote.Next
+		}
+		// the peer is behind, it must schedule the next upgrade the local chain passed.
+		return remote.Next == id.Next
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChainConfig(t *testing.T) {
+	config := GetChainConfig(MainNetID)
+	assert.Equal(t, MainNetChainConfig, config)
+	assert.Equal(t, LocalChainConfig, GetChainConfig(100))
+
+	assert.Equal(t, MainNetV8JSLibVersionControlHeight, config.Height(ForkV8JSLibVersionControl))
+	assert.Equal(t, uint64(math.MaxUint64), config.Height("unknown"))
+
+	assert.False(t, config.IsActive(ForkV8JSLibVersionControl, MainNetV8JSLibVersionControlHeight-1))
+	assert.True(t, config.IsActive(ForkV8JSLibVersionControl, MainNetV8JSLibVersionControlHeight))
+
+	assert.Equal(t, []string{}, config.ActiveForks(1))
+	assert.Equal(t, 5, len(config.ActiveForks(MainNetTransferFromContractEventRecordableHeight)))
+	assert.Equal(t, len(config.Forks), len(config.ActiveForks(math.MaxUint64)))
+
+	assert.NotEqual(t, MainNetChainConfig.ForkID(math.MaxUint64), TestNetChainConfig.ForkID(math.MaxUint64))
+	assert.Equal(t, MainNetChainConfig.ForkID(1), GetChainConfig(MainNetID).ForkID(1))
+}
+
+func TestForkID(t *testing.T) {
+	config := &ChainConfig{ChainID: 100, Forks: []*Fork{{"A", 10}, {"C", 20}, {"B", 20}}}
+
+	before := config.ForkID(0)
+	assert.Equal(t, uint64(10), before.Next)
+	assert.Equal(t, before, config.ForkID(9))
+	current := config.ForkID(10)
+	assert.Equal(t, uint64(20), current.Next)
+	assert.NotEqual(t, before.Hash, current.Hash)
+	last := config.ForkID(20)
+	assert.Equal(t, uint64(0), last.Next)
+	assert.Equal(t, last, config.ForkID(math.MaxUint64))
+
+	// the order of upgrades in the config does not matter.
+	reordered := &ChainConfig{ChainID: 100, Forks: []*Fork{{"B", 20}, {"C", 20}, {"A", 10}}}
+	assert.Equal(t, last, reordered.ForkID(20))
+
+	id, err := ParseForkID(current.String())
+	assert.Nil(t, err)
+	assert.Equal(t, current, id)
+	for _, s := range []string{"", "a1b2", "a1b2-", "-10", "a1b2-x", "a1-b2-10"} {
+		_, err := ParseForkID(s)
+		assert.Equal(t, ErrInvalidForkID, err, s)
+	}
+
+	// the peer on the same schedule, behind or ahead.
+	assert.True(t, config.CheckForkID(15, current))
+	assert.True(t, config.CheckForkID(15, before))
+	assert.True(t, config.CheckForkID(15, last))
+
+	// the peer behind does not know the upgrade passed.
+	assert.False(t, config.CheckForkID(15, &ForkID{Hash: before.Hash}))
+	assert.False(t, config.CheckForkID(15, &ForkID{Hash: before.Hash, Next: 11}))
+
+	// the peer enabled other upgrades.
+	other := &ChainConfig{ChainID: 100, Forks: []*Fork{{"A", 11}}}
+	assert.False(t, config.CheckForkID(15, other.ForkID(15)))
+	assert.False(t, other.CheckForkID(15, config.ForkID(15)))
+	assert.False(t, config.CheckForkID(15, &ForkID{Hash: "a1b2", Next: 20}))
+}
+
+func TestForkIDWithFutureFork(t *testing.T) {
+	config := &ChainConfig{ChainID: 100, Forks: []*Fork{{"A", 10}}}
+	upgraded := &ChainConfig{ChainID: 100, Forks: []*Fork{{"A", 10}, {"D", 100}}}
+
+	// a node scheduling an extra upgrade still peers before the upgrade height.
+	assert.Equal(t, config.ForkID(50).Hash, upgraded.ForkID(50).Hash)
+	assert.True(t, config.CheckForkID(50, upgraded.ForkID(50)))
+	assert.True(t, upgraded.CheckForkID(50, config.ForkID(50)))
+	assert.True(t, config.CheckForkID(99, upgraded.ForkID(50)))
+
+	// the peer waits for the upgrade the node passed without it, they split.
+	assert.False(t, config.CheckForkID(100, upgraded.ForkID(50)))
+	assert.False(t, upgraded.CheckForkID(100, config.ForkID(100)))
+	assert.False(t, config.CheckForkID(100, upgraded.ForkID(100)))
+}
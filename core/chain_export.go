@@ -0,0 +1,219 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"encoding/binary"
+	"io"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// chain export file:
+// magic | version | chain id | from | to
+// blocks: block length | block, repeated to - from + 1 times
+// the file ends with a zero block length.
+
+// Chain export constants
+const (
+	ChainExportMagic   = "NEBCHAIN"
+	ChainExportVersion = uint32(1)
+
+	// ChainExportProgressInterval is the number of blocks between two progress reports
+	ChainExportProgressInterval = 1000
+)
+
+func writeChainExportUint64(w io.Writer, v uint64) error {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, v)
+	_, err := w.Write(buf)
+	return err
+}
+
+func readChainExportUint64(r io.Reader) (uint64, error) {
+	buf := make([]byte, 8)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(buf), nil
+}
+
+// Export write the canonical blocks in [from, to] into w, to is the tail if it's 0.
+func (bc *BlockChain) Export(w io.Writer, from, to uint64) error {
+	if to == 0 {
+		to = bc.tailBlock.Height()
+	}
+	if from == 0 || from > to {
+		return ErrInvalidArgument
+	}
+	if to > bc.tailBlock.Height() {
+		return ErrCannotFindBlockAtGivenHeight
+	}
+
+	sw := &snapshotWriter{w: w}
+	if _, err := w.Write([]byte(ChainExportMagic)); err != nil {
+		return err
+	}
+	if err := sw.writeUint32(ChainExportVersion); err != nil {
+		return err
+	}
+	if err := sw.writeUint32(bc.chainID); err != nil {
+		return err
+	}
+	if err := writeChainExportUint64(w, from); err != nil {
+		return err
+	}
+	if err := writeChainExportUint64(w, to); err != nil {
+		return err
+	}
+
+	total := to - from + 1
+	for height := from; height <= to; height++ {
+		block := bc.GetBlockOnCanonicalChainByHeight(height)
+		if block == nil {
+			return ErrCannotFindBlockAtGivenHeight
+		}
+		pbBlock, err := block.ToProto()
+		if err != nil {
+			return err
+		}
+		data, err := proto.Marshal(pbBlock)
+		if err != nil {
+			return err
+		}
+		if err := sw.writeSection(data); err != nil {
+			return err
+		}
+
+		if done := height - from + 1; done%ChainExportProgressInterval == 0 {
+			logging.CLog().WithFields(logrus.Fields{
+				"done":  done,
+				"total": total,
+			}).Info("Exporting blocks.")
+		}
+	}
+	if err := sw.writeUint32(0); err != nil {
+		return err
+	}
+
+	logging.CLog().WithFields(logrus.Fields{
+		"from":  from,
+		"to":    to,
+		"total": total,
+	}).Info("Exported blocks.")
+	return nil
+}
+
+// Import push the blocks in r onto the chain in order, the ones already on chain are skipped.
+// It returns the number of blocks read.
+func (bc *BlockChain) Import(r io.Reader) (uint64, error) {
+	magic := make([]byte, len(ChainExportMagic))
+	if _, err := io.ReadFull(r, magic); err != nil {
+		return 0, err
+	}
+	if string(magic) != ChainExportMagic {
+		return 0, ErrInvalidChainExport
+	}
+	version, err := readSnapshotUint32(r)
+	if err != nil {
+		return 0, err
+	}
+	if version != ChainExportVersion {
+		return 0, ErrInvalidChainExport
+	}
+	chainID, err := readSnapshotUint32(r)
+	if err != nil {
+		return 0, err
+	}
+	if chainID != bc.chainID {
+		return 0, ErrInvalidChainExport
+	}
+	from, err := readChainExportUint64(r)
+	if err != nil {
+		return 0, err
+	}
+	to, err := readChainExportUint64(r)
+	if err != nil {
+		return 0, err
+	}
+	if from == 0 || from > to {
+		return 0, ErrInvalidChainExport
+	}
+
+	total := to - from + 1
+	done := uint64(0)
+	for {
+		data, err := readSnapshotSection(r)
+		if err != nil {
+			return done, err
+		}
+		if len(data) == 0 {
+			break
+		}
+		if done >= total {
+			return done, ErrInvalidChainExport
+		}
+
+		pbBlock := new(corepb.Block)
+		if err := proto.Unmarshal(data, pbBlock); err != nil {
+			return done, err
+		}
+		block := new(Block)
+		if err := block.FromProto(pbBlock); err != nil {
+			return done, err
+		}
+		if block.Height() != from+done {
+			return done, ErrInvalidChainExport
+		}
+		// the genesis is set up by the chain itself, it must be the same one.
+		if block.Height() == 1 {
+			if !block.Hash().Equals(bc.genesisBlock.Hash()) {
+				return done, ErrInvalidChainExport
+			}
+		} else if err := bc.bkPool.Push(block); err != nil {
+			logging.CLog().WithFields(logrus.Fields{
+				"block": block,
+				"err":   err,
+			}).Error("Failed to import block.")
+			return done, err
+		}
+		done++
+
+		if done%ChainExportProgressInterval == 0 {
+			logging.CLog().WithFields(logrus.Fields{
+				"done":  done,
+				"total": total,
+				"tail":  bc.tailBlock,
+			}).Info("Importing blocks.")
+		}
+	}
+	if done != total {
+		return done, ErrInvalidChainExport
+	}
+
+	logging.CLog().WithFields(logrus.Fields{
+		"from": from,
+		"to":   to,
+		"tail": bc.tailBlock,
+	}).Info("Imported blocks.")
+	return done, nil
+}
@@ -0,0 +1,76 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChainExport_ExportImport(t *testing.T) {
+	neb := testNeb(t)
+	bc := neb.chain
+
+	for i := 1; i <= 3; i++ {
+		addr, err := AddressParse(MockDynasty[i])
+		assert.Nil(t, err)
+		block, err := NewBlock(bc.ChainID(), addr, bc.tailBlock)
+		assert.Nil(t, err)
+		block.header.timestamp = bc.tailBlock.header.timestamp + BlockInterval
+		assert.Nil(t, block.Seal())
+		signBlock(block)
+		assert.Nil(t, bc.bkPool.Push(block))
+	}
+
+	var buf bytes.Buffer
+	assert.Equal(t, ErrInvalidArgument, bc.Export(&buf, 3, 2))
+	assert.Equal(t, ErrCannotFindBlockAtGivenHeight, bc.Export(&buf, 1, 100))
+
+	buf.Reset()
+	assert.Nil(t, bc.Export(&buf, 1, 0))
+
+	// corrupted files are rejected.
+	corrupted := append([]byte{}, buf.Bytes()...)
+	corrupted[0] ^= 0xff
+	fresh := testNeb(t).chain
+	_, err := fresh.Import(bytes.NewReader(corrupted))
+	assert.Equal(t, ErrInvalidChainExport, err)
+
+	fresh = testNeb(t).chain
+	count, err := fresh.Import(bytes.NewReader(buf.Bytes()))
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(4), count)
+	assert.Equal(t, bc.tailBlock.Hash(), fresh.TailBlock().Hash())
+
+	// the blocks already on chain are skipped.
+	count, err = fresh.Import(bytes.NewReader(buf.Bytes()))
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(4), count)
+	assert.Equal(t, bc.tailBlock.Hash(), fresh.TailBlock().Hash())
+
+	// a partial range continues from the blocks on chain.
+	buf.Reset()
+	assert.Nil(t, bc.Export(&buf, 2, 3))
+	partial := testNeb(t).chain
+	_, err = partial.Import(bytes.NewReader(buf.Bytes()))
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3), partial.TailBlock().Height())
+}
@@ -0,0 +1,122 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"sync"
+
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// ChainHost runs several BlockChain instances in one process, e.g. for
+// cross-chain experiments. The chains share one storage backend, each in
+// the namespace of its chain id. Compatibility heights are process wide,
+// so hosted chains follow the fork schedule of the primary chain.
+type ChainHost struct {
+	mu      sync.RWMutex
+	storage storage.Storage
+	chains  map[uint32]*BlockChain
+}
+
+// hostedNeblet serves a hosted chain its own storage namespace.
+type hostedNeblet struct {
+	Neblet
+	storage storage.Storage
+}
+
+// Storage returns the namespace of the hosted chain.
+func (n *hostedNeblet) Storage() storage.Storage {
+	return n.storage
+}
+
+// NewChainHost create a host sharing the storage among its chains.
+func NewChainHost(stor storage.Storage) *ChainHost {
+	return &ChainHost{
+		storage: stor,
+		chains:  make(map[uint32]*BlockChain),
+	}
+}
+
+// Add creates and setups the chain configured in neb, stored in the namespace of its
+// chain id. The caller starts the returned chain like the primary one.
+func (h *ChainHost) Add(neb Neblet) (*BlockChain, error) {
+	chainID := neb.Config().Chain.ChainId
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.chains[chainID]; ok {
+		return nil, ErrChainAlreadyHosted
+	}
+
+	hosted := &hostedNeblet{
+		Neblet:  neb,
+		storage: storage.NewChainStorage(h.storage, chainID),
+	}
+	bc, err := NewBlockChain(hosted)
+	if err != nil {
+		return nil, err
+	}
+	if err := bc.Setup(hosted); err != nil {
+		return nil, err
+	}
+	h.chains[chainID] = bc
+
+	logging.CLog().WithFields(logrus.Fields{
+		"chainID": chainID,
+		"tail":    bc.TailBlock(),
+	}).Info("Hosted BlockChain.")
+	return bc, nil
+}
+
+// Chain returns the hosted chain of chainID, nil if not hosted.
+func (h *ChainHost) Chain(chainID uint32) *BlockChain {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.chains[chainID]
+}
+
+// ChainIDs returns the ids of the hosted chains.
+func (h *ChainHost) ChainIDs() []uint32 {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	ids := make([]uint32, 0, len(h.chains))
+	for id := range h.chains {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// Remove stops the hosted chain of chainID and releases it, its data stays in storage.
+func (h *ChainHost) Remove(chainID uint32) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	bc, ok := h.chains[chainID]
+	if !ok {
+		return
+	}
+	bc.TransactionPool().Stop()
+	bc.BlockPool().Stop()
+	bc.Stop()
+	delete(h.chains, chainID)
+}
@@ -0,0 +1,71 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/neblet/pb"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChainHost(t *testing.T) {
+	neb := testNeb(t)
+
+	genesis := MockGenesisConf()
+	genesis.Meta.ChainId = neb.genesis.Meta.ChainId + 1
+	other := &mockNeb{
+		genesis:   genesis,
+		config:    &nebletpb.Config{Chain: &nebletpb.ChainConfig{ChainId: genesis.Meta.ChainId}},
+		emitter:   neb.emitter,
+		consensus: neb.consensus,
+		am:        neb.am,
+		ns:        neb.ns,
+		nvm:       neb.nvm,
+	}
+
+	db, err := storage.NewMemoryStorage()
+	assert.Nil(t, err)
+	host := NewChainHost(db)
+
+	bc1, err := host.Add(neb)
+	assert.Nil(t, err)
+	bc2, err := host.Add(other)
+	assert.Nil(t, err)
+	_, err = host.Add(other)
+	assert.Equal(t, ErrChainAlreadyHosted, err)
+
+	assert.Equal(t, bc1, host.Chain(neb.genesis.Meta.ChainId))
+	assert.Equal(t, bc2, host.Chain(genesis.Meta.ChainId))
+	assert.Equal(t, 2, len(host.ChainIDs()))
+	assert.NotEqual(t, bc1.GenesisBlock().Hash(), bc2.GenesisBlock().Hash())
+
+	// every chain writes into its own namespace only.
+	_, err = db.Get([]byte(Tail))
+	assert.Equal(t, storage.ErrKeyNotFound, err)
+	tail, err := storage.NewChainStorage(db, genesis.Meta.ChainId).Get([]byte(Tail))
+	assert.Nil(t, err)
+	assert.Equal(t, bc2.TailBlock().Hash(), byteutils.Hash(tail))
+
+	host.Remove(genesis.Meta.ChainId)
+	assert.Nil(t, host.Chain(genesis.Meta.ChainId))
+	assert.Equal(t, 1, len(host.ChainIDs()))
+}
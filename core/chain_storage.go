@@ -0,0 +1,48 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"github.com/nebulasio/go-nebulas/storage"
+)
+
+// chainStorage is the storage of a chain split in column families. The
+// block commits through its journal are all-or-nothing, state tries are
+// content addressed and written outside of it.
+type chainStorage struct {
+	storage        storage.Storage
+	headerStorage  storage.Storage
+	bodyStorage    storage.Storage
+	stateStorage   storage.Storage
+	receiptStorage storage.Storage
+
+	journal *storage.Journal
+}
+
+func newChainStorage(stor storage.Storage) *chainStorage {
+	journal := storage.NewJournal(stor, []byte(CommitJournal))
+	return &chainStorage{
+		storage:        journal.Wrap("default", stor),
+		headerStorage:  journal.Wrap(storage.FamilyHeaders, storage.FamilyOf(stor, storage.FamilyHeaders)),
+		bodyStorage:    journal.Wrap(storage.FamilyBodies, storage.FamilyOf(stor, storage.FamilyBodies)),
+		stateStorage:   storage.FamilyOf(stor, storage.FamilyState),
+		receiptStorage: journal.Wrap(storage.FamilyReceipts, storage.FamilyOf(stor, storage.FamilyReceipts)),
+		journal:        journal,
+	}
+}
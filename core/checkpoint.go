@@ -0,0 +1,499 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"sync"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/net"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// storage: key -> value
+// checkpoint_latest -> height of the latest checkpoint
+// checkpoint_ + height -> checkpoint with the votes of validators
+
+// Checkpoint keys in storage
+const (
+	CheckpointLatest = "checkpoint_latest"
+	CheckpointPrefix = "checkpoint_"
+)
+
+// CheckpointVote is a validator's signature on the canonical block at a checkpoint height.
+type CheckpointVote struct {
+	height    uint64
+	blockHash byteutils.Hash
+	alg       keystore.Algorithm
+	sign      byteutils.Hash
+}
+
+// ToProto converts domain CheckpointVote to proto CheckpointVote
+func (v *CheckpointVote) ToProto() (proto.Message, error) {
+	return &corepb.CheckpointVote{
+		Height:    v.height,
+		BlockHash: v.blockHash,
+		Alg:       uint32(v.alg),
+		Sign:      v.sign,
+	}, nil
+}
+
+// FromProto converts proto CheckpointVote to domain CheckpointVote
+func (v *CheckpointVote) FromProto(msg proto.Message) error {
+	if msg, ok := msg.(*corepb.CheckpointVote); ok {
+		if msg != nil {
+			v.height = msg.Height
+			v.blockHash = msg.BlockHash
+			v.alg = keystore.Algorithm(msg.Alg)
+			v.sign = msg.Sign
+			return nil
+		}
+	}
+	return ErrInvalidCheckpoint
+}
+
+// checkpointHash return the hash signed by validators for the block at the height.
+func checkpointHash(chainID uint32, height uint64, blockHash byteutils.Hash) byteutils.Hash {
+	return hash.Sha3256(
+		byteutils.FromUint32(chainID),
+		byteutils.FromUint64(height),
+		blockHash,
+	)
+}
+
+// Checkpoint is a block at a checkpoint height signed by a quorum of its dynasty,
+// the chain never switches to a fork below it.
+type Checkpoint struct {
+	height    uint64
+	blockHash byteutils.Hash
+	votes     []*CheckpointVote
+}
+
+// Height return the height of the checkpoint
+func (cp *Checkpoint) Height() uint64 {
+	return cp.height
+}
+
+// BlockHash return the hash of the checkpoint block
+func (cp *Checkpoint) BlockHash() byteutils.Hash {
+	return cp.blockHash
+}
+
+// ToProto converts domain Checkpoint to proto Checkpoint
+func (cp *Checkpoint) ToProto() (proto.Message, error) {
+	votes := make([]*corepb.CheckpointVote, len(cp.votes))
+	for i, v := range cp.votes {
+		pb, err := v.ToProto()
+		if err != nil {
+			return nil, err
+		}
+		votes[i] = pb.(*corepb.CheckpointVote)
+	}
+	return &corepb.Checkpoint{
+		Height:    cp.height,
+		BlockHash: cp.blockHash,
+		Votes:     votes,
+	}, nil
+}
+
+// FromProto converts proto Checkpoint to domain Checkpoint
+func (cp *Checkpoint) FromProto(msg proto.Message) error {
+	if msg, ok := msg.(*corepb.Checkpoint); ok {
+		if msg != nil {
+			cp.height = msg.Height
+			cp.blockHash = msg.BlockHash
+			cp.votes = make([]*CheckpointVote, len(msg.Votes))
+			for i, v := range msg.Votes {
+				vote := new(CheckpointVote)
+				if err := vote.FromProto(v); err != nil {
+					return err
+				}
+				cp.votes[i] = vote
+			}
+			return nil
+		}
+	}
+	return ErrInvalidCheckpoint
+}
+
+// checkpointQuorum return the number of votes needed in a dynasty of the size.
+func checkpointQuorum(dynastySize int) int {
+	return dynastySize*2/3 + 1
+}
+
+// CheckpointManager signs the canonical blocks at every interval heights once the tail reaches
+// them, collects the votes of validators and records the checkpoints. The votes are independent
+// of the LIB, a checkpoint may finalize the blocks above it.
+type CheckpointManager struct {
+	chain *BlockChain
+	ns    net.Service
+	am    AccountManager
+
+	interval uint64
+	signer   *Address
+
+	receiveVoteCh chan net.Message
+	quitCh        chan int
+
+	mu          sync.Mutex
+	latest      *Checkpoint
+	votedHeight uint64
+	votedBlock  byteutils.Hash
+	// height -> signer -> the latest vote of the signer at the height
+	votes map[uint64]map[string]*CheckpointVote
+}
+
+// NewCheckpointManager create a checkpoint manager, checkpoints are disabled if interval is 0.
+func NewCheckpointManager(chain *BlockChain, interval uint64) *CheckpointManager {
+	return &CheckpointManager{
+		chain:         chain,
+		interval:      interval,
+		receiveVoteCh: make(chan net.Message, 128),
+		quitCh:        make(chan int, 1),
+		votes:         make(map[uint64]map[string]*CheckpointVote),
+	}
+}
+
+// RegisterInNetwork register message subscriber in network.
+func (cm *CheckpointManager) RegisterInNetwork(ns net.Service) {
+	ns.Register(net.NewSubscriber(cm, cm.receiveVoteCh, false, MessageTypeCheckpointVote, net.MessageWeightZero))
+	cm.ns = ns
+}
+
+// Setup load the latest checkpoint, the local miner votes if it is a validator.
+func (cm *CheckpointManager) Setup(neb Neblet) error {
+	cm.am = neb.AccountManager()
+	if conf := neb.Config().Chain; conf.StartMine && len(conf.Miner) > 0 {
+		miner, err := AddressParse(conf.Miner)
+		if err != nil {
+			return err
+		}
+		cm.signer = miner
+	}
+
+	height, err := cm.chain.storage.Get([]byte(CheckpointLatest))
+	if err == storage.ErrKeyNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	cp, err := cm.loadCheckpoint(byteutils.Uint64(height))
+	if err != nil {
+		return err
+	}
+	cm.latest = cp
+	cm.votedHeight = cp.height
+	cm.votedBlock = cp.blockHash
+
+	logging.CLog().WithFields(logrus.Fields{
+		"height": cp.height,
+		"block":  cp.blockHash.Hex(),
+	}).Info("Latest Checkpoint.")
+	return nil
+}
+
+// Start start loop.
+func (cm *CheckpointManager) Start() {
+	if cm.interval == 0 {
+		return
+	}
+	logging.CLog().WithFields(logrus.Fields{
+		"interval": cm.interval,
+	}).Info("Starting CheckpointManager...")
+
+	go cm.loop()
+}
+
+// Stop stop loop.
+func (cm *CheckpointManager) Stop() {
+	if cm.interval == 0 {
+		return
+	}
+	logging.CLog().Info("Stopping CheckpointManager...")
+	cm.quitCh <- 0
+}
+
+func (cm *CheckpointManager) loop() {
+	logging.CLog().Info("Started CheckpointManager.")
+	for {
+		select {
+		case <-cm.quitCh:
+			logging.CLog().Info("Stopped CheckpointManager.")
+			return
+		case msg := <-cm.receiveVoteCh:
+			cm.handleReceivedVote(msg)
+		}
+	}
+}
+
+func checkpointKey(height uint64) []byte {
+	return append([]byte(CheckpointPrefix), byteutils.FromUint64(height)...)
+}
+
+func (cm *CheckpointManager) loadCheckpoint(height uint64) (*Checkpoint, error) {
+	value, err := cm.chain.storage.Get(checkpointKey(height))
+	if err != nil {
+		return nil, err
+	}
+	pb := new(corepb.Checkpoint)
+	if err := proto.Unmarshal(value, pb); err != nil {
+		return nil, err
+	}
+	cp := new(Checkpoint)
+	if err := cp.FromProto(pb); err != nil {
+		return nil, err
+	}
+	return cp, nil
+}
+
+// Latest return the latest checkpoint, nil if there is none.
+func (cm *CheckpointManager) Latest() *Checkpoint {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	return cm.latest
+}
+
+// GetCheckpoint return the checkpoint at the height.
+func (cm *CheckpointManager) GetCheckpoint(height uint64) (*Checkpoint, error) {
+	return cm.loadCheckpoint(height)
+}
+
+// onNewTail sign the canonical block at the last checkpoint height not beyond the tail,
+// a validator votes once at each checkpoint height, and again if the canonical block
+// at the height changed.
+func (cm *CheckpointManager) onNewTail(tail *Block) {
+	if cm.interval == 0 || cm.signer == nil {
+		return
+	}
+	height := tail.height / cm.interval * cm.interval
+	if height == 0 {
+		return
+	}
+	block := cm.chain.GetBlockOnCanonicalChainByHeight(height)
+	if block == nil {
+		return
+	}
+	cm.mu.Lock()
+	if height < cm.votedHeight || (height == cm.votedHeight && block.Hash().Equals(cm.votedBlock)) {
+		cm.mu.Unlock()
+		return
+	}
+	cm.votedHeight = height
+	cm.votedBlock = block.Hash()
+	cm.mu.Unlock()
+
+	if !cm.isValidator(block, cm.signer) {
+		return
+	}
+	sign, err := cm.am.SignHash(cm.signer, checkpointHash(cm.chain.chainID, height, block.Hash()), keystore.SECP256K1)
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"block": block,
+			"err":   err,
+		}).Debug("Failed to sign checkpoint.")
+		return
+	}
+	vote := &CheckpointVote{
+		height:    height,
+		blockHash: block.Hash(),
+		alg:       keystore.SECP256K1,
+		sign:      sign,
+	}
+	if _, err := cm.addVote(vote); err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"block": block,
+			"err":   err,
+		}).Debug("Failed to add checkpoint vote.")
+		return
+	}
+	cm.ns.Broadcast(MessageTypeCheckpointVote, vote, net.MessagePriorityNormal)
+}
+
+func (cm *CheckpointManager) isValidator(block *Block, addr *Address) bool {
+	dynasty, err := block.Dynasty()
+	if err != nil {
+		return false
+	}
+	for _, v := range dynasty {
+		if v.Equals(addr.Bytes()) {
+			return true
+		}
+	}
+	return false
+}
+
+func (cm *CheckpointManager) handleReceivedVote(msg net.Message) {
+	pb := new(corepb.CheckpointVote)
+	if err := proto.Unmarshal(msg.Data(), pb); err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"msgType": msg.MessageType(),
+			"msg":     msg,
+			"err":     err,
+		}).Debug("Failed to unmarshal data.")
+		return
+	}
+	vote := new(CheckpointVote)
+	if err := vote.FromProto(pb); err != nil {
+		return
+	}
+	added, err := cm.addVote(vote)
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"height": vote.height,
+			"block":  vote.blockHash.Hex(),
+			"pid":    msg.MessageFrom(),
+			"err":    err,
+		}).Debug("Failed to add checkpoint vote.")
+		return
+	}
+	if added {
+		cm.ns.Relay(MessageTypeCheckpointVote, vote, net.MessagePriorityNormal)
+	}
+}
+
+// verifyVote return the signer of the vote if it is a validator of the voted block.
+func (cm *CheckpointManager) verifyVote(vote *CheckpointVote) (*Block, *Address, error) {
+	if cm.interval == 0 || vote.height == 0 || vote.height%cm.interval != 0 {
+		return nil, nil, ErrInvalidCheckpoint
+	}
+	block := cm.chain.GetBlock(vote.blockHash)
+	if block == nil || block.height != vote.height {
+		return nil, nil, ErrCheckpointBlockNotFound
+	}
+	signer, err := RecoverSignerFromSignature(vote.alg, checkpointHash(cm.chain.chainID, vote.height, vote.blockHash), vote.sign)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !cm.isValidator(block, signer) {
+		return nil, nil, ErrInvalidCheckpointSigner
+	}
+	return block, signer, nil
+}
+
+// addVote record the vote, and the checkpoint once a quorum of the dynasty voted the block.
+// return false if the vote is known or out of date.
+func (cm *CheckpointManager) addVote(vote *CheckpointVote) (bool, error) {
+	block, signer, err := cm.verifyVote(vote)
+	if err != nil {
+		return false, err
+	}
+	return cm.recordVote(vote, block, signer)
+}
+
+// recordVote record the vote of the signer, a signer counts for one block at each height.
+// A validator votes again once the canonical block at the height changed, the new vote
+// replaces the one off the canonical chain. A vote conflicting with the one of the signer
+// on the canonical chain is a double vote.
+func (cm *CheckpointManager) recordVote(vote *CheckpointVote, block *Block, signer *Address) (bool, error) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	if cm.latest != nil && vote.height <= cm.latest.height {
+		return false, nil
+	}
+	bySigner, ok := cm.votes[vote.height]
+	if !ok {
+		bySigner = make(map[string]*CheckpointVote)
+		cm.votes[vote.height] = bySigner
+	}
+	if prev, ok := bySigner[signer.String()]; ok {
+		if prev.blockHash.Equals(vote.blockHash) {
+			return false, nil
+		}
+		canonical := cm.chain.GetBlockOnCanonicalChainByHeight(vote.height)
+		if canonical != nil && canonical.Hash().Equals(prev.blockHash) {
+			logging.VLog().WithFields(logrus.Fields{
+				"signer": signer.String(),
+				"height": vote.height,
+				"voted":  prev.blockHash.Hex(),
+				"block":  vote.blockHash.Hex(),
+			}).Warn("Found double checkpoint vote.")
+			return false, ErrDoubleCheckpointVote
+		}
+	}
+	bySigner[signer.String()] = vote
+
+	votes := make([]*CheckpointVote, 0, len(bySigner))
+	for _, v := range bySigner {
+		if v.blockHash.Equals(vote.blockHash) {
+			votes = append(votes, v)
+		}
+	}
+	dynasty, err := block.Dynasty()
+	if err != nil {
+		return true, err
+	}
+	if len(votes) < checkpointQuorum(len(dynasty)) {
+		return true, nil
+	}
+
+	// a quorum voted a block off the canonical chain, the node is on a minority fork.
+	canonical := cm.chain.GetBlockOnCanonicalChainByHeight(vote.height)
+	if canonical == nil || !canonical.Hash().Equals(vote.blockHash) {
+		logging.CLog().WithFields(logrus.Fields{
+			"height": vote.height,
+			"block":  vote.blockHash.Hex(),
+		}).Warn("Checkpoint block is not on canonical chain.")
+		return true, nil
+	}
+
+	cp := &Checkpoint{
+		height:    vote.height,
+		blockHash: vote.blockHash,
+		votes:     votes,
+	}
+	if err := cm.storeCheckpoint(cp); err != nil {
+		return true, err
+	}
+	cm.latest = cp
+	for height := range cm.votes {
+		if height <= cp.height {
+			delete(cm.votes, height)
+		}
+	}
+
+	logging.CLog().WithFields(logrus.Fields{
+		"height": cp.height,
+		"block":  cp.blockHash.Hex(),
+		"votes":  len(cp.votes),
+	}).Info("Succeed to record checkpoint.")
+	return true, nil
+}
+
+func (cm *CheckpointManager) storeCheckpoint(cp *Checkpoint) error {
+	pb, err := cp.ToProto()
+	if err != nil {
+		return err
+	}
+	value, err := proto.Marshal(pb)
+	if err != nil {
+		return err
+	}
+	if err := cm.chain.storage.Put(checkpointKey(cp.height), value); err != nil {
+		return err
+	}
+	return cm.chain.storage.Put([]byte(CheckpointLatest), byteutils.FromUint64(cp.height))
+}
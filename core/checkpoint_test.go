@@ -0,0 +1,207 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckpoint_Proto(t *testing.T) {
+	cp := &Checkpoint{
+		height:    100,
+		blockHash: []byte("block"),
+		votes: []*CheckpointVote{
+			{height: 100, blockHash: []byte("block"), alg: keystore.SECP256K1, sign: []byte("sign")},
+		},
+	}
+	pb, err := cp.ToProto()
+	assert.Nil(t, err)
+	data, err := proto.Marshal(pb)
+	assert.Nil(t, err)
+
+	msg := new(corepb.Checkpoint)
+	assert.Nil(t, proto.Unmarshal(data, msg))
+	got := new(Checkpoint)
+	assert.Nil(t, got.FromProto(msg))
+	assert.Equal(t, cp, got)
+
+	assert.Equal(t, ErrInvalidCheckpoint, got.FromProto(new(corepb.CheckpointVote)))
+	assert.Equal(t, 15, checkpointQuorum(21))
+}
+
+func TestCheckpointManager(t *testing.T) {
+	neb := testNeb(t)
+	bc := neb.chain
+	bc.checkpoints = NewCheckpointManager(bc, 1)
+	bc.checkpoints.RegisterInNetwork(neb.ns)
+	assert.Nil(t, bc.checkpoints.Setup(neb))
+	assert.Nil(t, bc.LatestCheckpoint())
+
+	coinbase1, _ := AddressParse(MockDynasty[1])
+	coinbase2, _ := AddressParse(MockDynasty[2])
+
+	block1, err := bc.NewBlock(coinbase1)
+	assert.Nil(t, err)
+	block1.header.timestamp = BlockInterval
+	fork1, err := bc.NewBlock(coinbase2)
+	assert.Nil(t, err)
+	fork1.header.timestamp = BlockInterval * 2
+	assert.Nil(t, block1.Seal())
+	signBlock(block1)
+	assert.Nil(t, fork1.Seal())
+	signBlock(fork1)
+	assert.Nil(t, bc.BlockPool().Push(block1))
+
+	block2, err := bc.NewBlock(coinbase2)
+	assert.Nil(t, err)
+	block2.header.timestamp = BlockInterval * 3
+	assert.Nil(t, block2.Seal())
+	signBlock(block2)
+	assert.Nil(t, bc.BlockPool().Push(block2))
+	assert.Nil(t, bc.BlockPool().Push(fork1))
+	assert.Equal(t, block2.Hash(), bc.TailBlock().Hash())
+
+	// the votes must be on a known block at a checkpoint height.
+	bc.checkpoints.interval = 2
+	_, err = bc.checkpoints.addVote(&CheckpointVote{height: 1, blockHash: block1.Hash()})
+	assert.Equal(t, ErrInvalidCheckpoint, err)
+	_, err = bc.checkpoints.addVote(&CheckpointVote{height: 4, blockHash: block1.Hash()})
+	assert.Equal(t, ErrCheckpointBlockNotFound, err)
+	bc.checkpoints.interval = 1
+
+	// a quorum of validators signed block1.
+	cp := &Checkpoint{height: block1.Height(), blockHash: block1.Hash()}
+	assert.Nil(t, bc.checkpoints.storeCheckpoint(cp))
+	bc.checkpoints.latest = cp
+
+	assert.Equal(t, ErrForkBelowCheckpoint, bc.SetTailBlock(fork1))
+	assert.Equal(t, block2.Hash(), bc.TailBlock().Hash())
+
+	// the checkpoint is loaded at restart.
+	restarted := NewCheckpointManager(bc, 1)
+	assert.Nil(t, restarted.Setup(neb))
+	assert.Equal(t, block1.Hash(), restarted.Latest().BlockHash())
+	loaded, err := restarted.GetCheckpoint(block1.Height())
+	assert.Nil(t, err)
+	assert.Equal(t, block1.Height(), loaded.Height())
+}
+
+func TestCheckpointAboveLIB(t *testing.T) {
+	neb := testNeb(t)
+	bc := neb.chain
+	bc.checkpoints = NewCheckpointManager(bc, 2)
+	bc.checkpoints.RegisterInNetwork(neb.ns)
+	assert.Nil(t, bc.checkpoints.Setup(neb))
+	bc.checkpoints.signer = mockAddress()
+
+	coinbase1, _ := AddressParse(MockDynasty[1])
+	coinbase2, _ := AddressParse(MockDynasty[2])
+
+	blocks := []*Block{}
+	for i := 1; i <= 3; i++ {
+		block, err := bc.NewBlock(coinbase1)
+		assert.Nil(t, err)
+		block.header.timestamp = BlockInterval * int64(i)
+		assert.Nil(t, block.Seal())
+		signBlock(block)
+		assert.Nil(t, bc.BlockPool().Push(block))
+		blocks = append(blocks, block)
+	}
+	fork, err := bc.NewBlockFromParent(coinbase2, blocks[0])
+	assert.Nil(t, err)
+	fork.header.timestamp = BlockInterval * 4
+	assert.Nil(t, fork.Seal())
+	signBlock(fork)
+	assert.Nil(t, bc.BlockPool().Push(fork))
+	assert.Equal(t, blocks[2].Hash(), bc.TailBlock().Hash())
+
+	// the checkpoint height is voted once the tail reaches it, while the LIB stays behind.
+	assert.Equal(t, bc.genesisBlock.Hash(), bc.LIB().Hash())
+	assert.Equal(t, blocks[1].Height(), bc.checkpoints.votedHeight)
+
+	// a quorum of validators signed blocks[1] above the LIB, the fork below it is rejected.
+	cp := &Checkpoint{height: blocks[1].Height(), blockHash: blocks[1].Hash()}
+	assert.Nil(t, bc.checkpoints.storeCheckpoint(cp))
+	bc.checkpoints.latest = cp
+	assert.True(t, bc.LIB().Height() < cp.Height())
+
+	assert.Equal(t, ErrForkBelowCheckpoint, bc.SetTailBlock(fork))
+	assert.Equal(t, blocks[2].Hash(), bc.TailBlock().Hash())
+}
+
+func TestCheckpointRevote(t *testing.T) {
+	neb := testNeb(t)
+	bc := neb.chain
+	bc.checkpoints = NewCheckpointManager(bc, 1)
+	bc.checkpoints.RegisterInNetwork(neb.ns)
+	assert.Nil(t, bc.checkpoints.Setup(neb))
+	bc.checkpoints.signer = mockAddress()
+
+	coinbase1, _ := AddressParse(MockDynasty[1])
+	coinbase2, _ := AddressParse(MockDynasty[2])
+
+	block1, err := bc.NewBlock(coinbase1)
+	assert.Nil(t, err)
+	block1.header.timestamp = BlockInterval
+	fork1, err := bc.NewBlock(coinbase2)
+	assert.Nil(t, err)
+	fork1.header.timestamp = BlockInterval * 2
+	assert.Nil(t, block1.Seal())
+	signBlock(block1)
+	assert.Nil(t, fork1.Seal())
+	signBlock(fork1)
+	assert.Nil(t, bc.BlockPool().Push(block1))
+	assert.Nil(t, bc.BlockPool().Push(fork1))
+
+	// the local validator votes again once the canonical block at the height changed.
+	assert.Nil(t, bc.SetTailBlock(fork1))
+	assert.Equal(t, fork1.Hash(), bc.checkpoints.votedBlock)
+	assert.Nil(t, bc.SetTailBlock(block1))
+	assert.Equal(t, block1.Hash(), bc.checkpoints.votedBlock)
+
+	// the mock dynasty is empty, a single vote is a quorum, but fork1 is off the canonical chain.
+	forkVote := &CheckpointVote{height: fork1.Height(), blockHash: fork1.Hash()}
+	added, err := bc.checkpoints.recordVote(forkVote, fork1, coinbase1)
+	assert.Nil(t, err)
+	assert.True(t, added)
+	added, err = bc.checkpoints.recordVote(forkVote, fork1, coinbase1)
+	assert.Nil(t, err)
+	assert.False(t, added)
+	assert.Nil(t, bc.LatestCheckpoint())
+
+	// a conflicting vote while the voted block is on the canonical chain is a double vote.
+	assert.Nil(t, bc.SetTailBlock(fork1))
+	vote := &CheckpointVote{height: block1.Height(), blockHash: block1.Hash()}
+	added, err = bc.checkpoints.recordVote(vote, block1, coinbase1)
+	assert.Equal(t, ErrDoubleCheckpointVote, err)
+	assert.False(t, added)
+
+	// the re-vote after the canonical block changed replaces the vote on fork1.
+	assert.Nil(t, bc.SetTailBlock(block1))
+	added, err = bc.checkpoints.recordVote(vote, block1, coinbase1)
+	assert.Nil(t, err)
+	assert.True(t, added)
+	assert.Equal(t, block1.Hash(), bc.LatestCheckpoint().BlockHash())
+	assert.Equal(t, 1, len(bc.LatestCheckpoint().votes))
+}
@@ -90,13 +90,15 @@ var (
 		"esprima.js":             {"1.0.0"},
 		"assert.js":              {"1.0.0"},
 		"instruction_counter.js": {"1.0.0"},
+		"validator.js":           {"1.1.0"},
 		"typescriptServices.js":  {"1.0.0"},
-		"blockchain.js":          {"1.0.0", "1.0.5"},
+		"blockchain.js":          {"1.0.0", "1.0.5", "1.1.0"},
 		"console.js":             {"1.0.0"},
 		"event.js":               {"1.0.0"},
-		"storage.js":             {"1.0.0"},
-		"crypto.js":              {"1.0.5"},
+		"storage.js":             {"1.0.0", "1.1.0"},
+		"crypto.js":              {"1.0.5", "1.1.0"},
 		"uint.js":                {"1.0.5"},
+		"decimal.js":             {"1.1.0"},
 	}
 
 	digitalized = make(map[string][]*version)
@@ -140,12 +142,84 @@ const (
 
 	//LocalNetNewNvmExeTimeoutConsumeGasHeight
 	LocalNewNvmExeTimeoutConsumeGasHeight uint64 = 2
+
+	// LocalDynamicGasLimitHeight
+	LocalDynamicGasLimitHeight uint64 = 2
+
+	// LocalContractCallHeight
+	LocalContractCallHeight uint64 = 2
+
+	// LocalWasmHeight
+	LocalWasmHeight uint64 = 2
+
+	// LocalExecutionLimitsHeight
+	LocalExecutionLimitsHeight uint64 = 2
+
+	// LocalStorageKeysHeight
+	LocalStorageKeysHeight uint64 = 2
+
+	// LocalBlockchainRandomHeight
+	LocalBlockchainRandomHeight uint64 = 2
+
+	// LocalContractUpgradeHeight
+	LocalContractUpgradeHeight uint64 = 2
+
+	// LocalContractTransferHeight
+	LocalContractTransferHeight uint64 = 2
+
+	// LocalContractModulesHeight
+	LocalContractModulesHeight uint64 = 2
+
+	// LocalBlockHistoryHeight
+	LocalBlockHistoryHeight uint64 = 2
+
+	// LocalSizedGasHeight
+	LocalSizedGasHeight uint64 = 2
+
+	// LocalContractValidationHeight
+	LocalContractValidationHeight uint64 = 2
+
+	// LocalContractABIHeight
+	LocalContractABIHeight uint64 = 2
+
+	// LocalLibSnapshotHeight
+	LocalLibSnapshotHeight uint64 = 2
+
+	// LocalEd25519Height
+	LocalEd25519Height uint64 = 2
+
+	// LocalMultisigHeight
+	LocalMultisigHeight uint64 = 2
+
+	// LocalKeyRotationHeight
+	LocalKeyRotationHeight uint64 = 2
+
+	// LocalEncryptedPayloadHeight
+	LocalEncryptedPayloadHeight uint64 = 2
+
+	// LocalVRFProposerHeight the lottery replaces the schedule after its liveness is tracked
+	// since LocalValidatorLivenessHeight, the missed slots of the lottery have no owner.
+	LocalVRFProposerHeight uint64 = 3
+
+	// LocalDoubleSignEvidenceHeight
+	LocalDoubleSignEvidenceHeight uint64 = 2
+
+	// LocalValidatorLivenessHeight
+	LocalValidatorLivenessHeight uint64 = 2
+
+	// LocalProofOfDevotionHeight
+	LocalProofOfDevotionHeight uint64 = 2
+
+	// LocalV8JSLib110Height the contracts deployed since this height use the 1.1.0 libs,
+	// which add Crypto.verify and Blockchain.random, not scheduled on testnet and mainnet yet
+	LocalV8JSLib110Height uint64 = 3
 )
 
 // var for local/develop
 var (
 	LocalV8JSLibVersionHeightSlice = heightOfVersionSlice{
 		{"1.0.5", LocalV8JSLibVersionControlHeight},
+		{"1.1.0", LocalV8JSLib110Height},
 	}
 )
 
@@ -262,49 +336,119 @@ var (
 
 	//NewNvmExeTimeoutConsumeGasHeight
 	NewNvmExeTimeoutConsumeGasHeight = TestNetNewNvmExeTimeoutConsumeGasHeight
+
+	// DynamicGasLimitHeight the block gas limit drifts toward the miner target since this height, not scheduled on testnet and mainnet yet
+	DynamicGasLimitHeight = TestNetChainConfig.Height(ForkDynamicGasLimit)
+
+	// VRFProposerHeight the proposer of a slot is elected by the VRF lottery since this height, not scheduled yet
+	VRFProposerHeight = TestNetChainConfig.Height(ForkVRFProposer)
+
+	// DoubleSignEvidenceHeight the evidence transactions are executed since this height, not scheduled yet
+	DoubleSignEvidenceHeight = TestNetChainConfig.Height(ForkDoubleSignEvidence)
+
+	// ValidatorLivenessHeight the validators missing too many slots are replaced since this height, not scheduled yet
+	ValidatorLivenessHeight = TestNetChainConfig.Height(ForkValidatorLiveness)
+
+	// ProofOfDevotionHeight the stake transactions are executed and the dynasties elected since this height, not scheduled yet
+	ProofOfDevotionHeight = TestNetChainConfig.Height(ForkProofOfDevotion)
+
+	// ContractCallHeight the contracts can call other contracts by Blockchain.call since this height, not scheduled on testnet and mainnet yet
+	ContractCallHeight = TestNetChainConfig.Height(ForkContractCall)
+
+	// WasmHeight the WebAssembly contracts can be deployed since this height, not scheduled on testnet and mainnet yet
+	WasmHeight = TestNetChainConfig.Height(ForkWasm)
+
+	// ExecutionLimitsHeight a contract execution hitting the timeout consumes all its gas since this height, not scheduled on testnet and mainnet yet
+	ExecutionLimitsHeight = TestNetChainConfig.Height(ForkExecutionLimits)
+
+	// StorageKeysHeight the keys put in contract storage are indexed and can be listed by Storage.keys since this height, not scheduled on testnet and mainnet yet
+	StorageKeysHeight = TestNetChainConfig.Height(ForkStorageKeys)
+
+	// BlockchainRandomHeight the contracts can draw random numbers from the VRF seed by Blockchain.random since this height, not scheduled on testnet and mainnet yet
+	BlockchainRandomHeight = TestNetChainConfig.Height(ForkBlockchainRandom)
+
+	// ContractUpgradeHeight the contracts deployed with an admin can be upgraded by it since this height, not scheduled on testnet and mainnet yet
+	ContractUpgradeHeight = TestNetChainConfig.Height(ForkContractUpgrade)
+
+	// ContractTransferHeight the transfers from contracts to contracts run the accept function of the receivers, guarded against reentrancy, since this height, not scheduled on testnet and mainnet yet
+	ContractTransferHeight = TestNetChainConfig.Height(ForkContractTransfer)
+
+	// ContractModulesHeight the contracts can be deployed with the modules they require since this height, not scheduled on testnet and mainnet yet
+	ContractModulesHeight = TestNetChainConfig.Height(ForkContractModules)
+
+	// BlockHistoryHeight the contracts can read the headers of the recent blocks by Blockchain.getBlockByHeight since this height, not scheduled on testnet and mainnet yet
+	BlockHistoryHeight = TestNetChainConfig.Height(ForkBlockHistory)
+
+	// SizedGasHeight the builtin lib functions charge gas in proportion to the size of the data they process since this height, not scheduled on testnet and mainnet yet
+	SizedGasHeight = TestNetChainConfig.Height(ForkSizedGas)
+
+	// ContractValidationHeight the sources of the contracts deployed or upgraded are validated statically since this height, not scheduled on testnet and mainnet yet
+	ContractValidationHeight = TestNetChainConfig.Height(ForkContractValidation)
+
+	// ContractABIHeight the contracts can be deployed or upgraded with an ABI describing their functions since this height, not scheduled on testnet and mainnet yet
+	ContractABIHeight = TestNetChainConfig.Height(ForkContractABI)
+
+	// LibSnapshotHeight the contracts run in the lib env restored from the lib snapshot since this height, not scheduled on testnet and mainnet yet
+	LibSnapshotHeight = TestNetChainConfig.Height(ForkLibSnapshot)
+
+	// Ed25519Height the txs can be signed by ed25519 keys since this height, not scheduled on testnet and mainnet yet
+	Ed25519Height = TestNetChainConfig.Height(ForkEd25519)
+
+	// MultisigHeight the multisig accounts can be created and spent from since this height, not scheduled on testnet and mainnet yet
+	MultisigHeight = TestNetChainConfig.Height(ForkMultisig)
+
+	// KeyRotationHeight the accounts can rotate their keys since this height, not scheduled on testnet and mainnet yet
+	KeyRotationHeight = TestNetChainConfig.Height(ForkKeyRotation)
+
+	// EncryptedPayloadHeight the txs can carry encrypted payloads since this height, not scheduled on testnet and mainnet yet
+	EncryptedPayloadHeight = TestNetChainConfig.Height(ForkEncryptedPayload)
 )
 
 // SetCompatibilityOptions set compatibility height according to chain_id
 func SetCompatibilityOptions(chainID uint32) {
 
+	config := GetChainConfig(chainID)
+	CurrentChainConfig = config
+
+	TransferFromContractEventRecordableHeight = config.Height(ForkTransferFromContractEventRecordable)
+	AcceptFuncAvailableHeight = config.Height(ForkAcceptFuncAvailable)
+	RandomAvailableHeight = config.Height(ForkRandomAvailable)
+	DateAvailableHeight = config.Height(ForkDateAvailable)
+	RecordCallContractResultHeight = config.Height(ForkRecordCallContractResult)
+	NvmMemoryLimitWithoutInjectHeight = config.Height(ForkNvmMemoryLimitWithoutInject)
+	WsResetRecordDependencyHeight = config.Height(ForkWsResetRecordDependency)
+	V8JSLibVersionControlHeight = config.Height(ForkV8JSLibVersionControl)
+	TransferFromContractFailureEventRecordableHeight = config.Height(ForkTransferFromContractFailureEventRecordable)
+	NewNvmExeTimeoutConsumeGasHeight = config.Height(ForkNewNvmExeTimeoutConsumeGas)
+	DynamicGasLimitHeight = config.Height(ForkDynamicGasLimit)
+	VRFProposerHeight = config.Height(ForkVRFProposer)
+	DoubleSignEvidenceHeight = config.Height(ForkDoubleSignEvidence)
+	ValidatorLivenessHeight = config.Height(ForkValidatorLiveness)
+	ProofOfDevotionHeight = config.Height(ForkProofOfDevotion)
+	ContractCallHeight = config.Height(ForkContractCall)
+	WasmHeight = config.Height(ForkWasm)
+	ExecutionLimitsHeight = config.Height(ForkExecutionLimits)
+	StorageKeysHeight = config.Height(ForkStorageKeys)
+	BlockchainRandomHeight = config.Height(ForkBlockchainRandom)
+	ContractUpgradeHeight = config.Height(ForkContractUpgrade)
+	ContractTransferHeight = config.Height(ForkContractTransfer)
+	ContractModulesHeight = config.Height(ForkContractModules)
+	BlockHistoryHeight = config.Height(ForkBlockHistory)
+	SizedGasHeight = config.Height(ForkSizedGas)
+	ContractValidationHeight = config.Height(ForkContractValidation)
+	ContractABIHeight = config.Height(ForkContractABI)
+	LibSnapshotHeight = config.Height(ForkLibSnapshot)
+	Ed25519Height = config.Height(ForkEd25519)
+	MultisigHeight = config.Height(ForkMultisig)
+	KeyRotationHeight = config.Height(ForkKeyRotation)
+	EncryptedPayloadHeight = config.Height(ForkEncryptedPayload)
+
 	if chainID == MainNetID {
-		TransferFromContractEventRecordableHeight = MainNetTransferFromContractEventRecordableHeight
-		AcceptFuncAvailableHeight = MainNetAcceptFuncAvailableHeight
-		RandomAvailableHeight = MainNetRandomAvailableHeight
-		DateAvailableHeight = MainNetDateAvailableHeight
-		RecordCallContractResultHeight = MainNetRecordCallContractResultHeight
-		NvmMemoryLimitWithoutInjectHeight = MainNetNvmMemoryLimitWithoutInjectHeight
-		WsResetRecordDependencyHeight = MainNetWsResetRecordDependencyHeight
-		V8JSLibVersionControlHeight = MainNetV8JSLibVersionControlHeight
 		V8JSLibVersionHeightSlice = MainNetV8JSLibVersionHeightSlice
-		TransferFromContractFailureEventRecordableHeight = MainNetTransferFromContractFailureEventRecordableHeight
-		NewNvmExeTimeoutConsumeGasHeight = MainNetNewNvmExeTimeoutConsumeGasHeight
 	} else if chainID == TestNetID {
-
-		TransferFromContractEventRecordableHeight = TestNetTransferFromContractEventRecordableHeight
-		AcceptFuncAvailableHeight = TestNetAcceptFuncAvailableHeight
-		RandomAvailableHeight = TestNetRandomAvailableHeight
-		DateAvailableHeight = TestNetDateAvailableHeight
-		RecordCallContractResultHeight = TestNetRecordCallContractResultHeight
-		NvmMemoryLimitWithoutInjectHeight = TestNetNvmMemoryLimitWithoutInjectHeight
-		WsResetRecordDependencyHeight = TestNetWsResetRecordDependencyHeight
-		V8JSLibVersionControlHeight = TestNetV8JSLibVersionControlHeight
 		V8JSLibVersionHeightSlice = TestNetV8JSLibVersionHeightSlice
-		TransferFromContractFailureEventRecordableHeight = TestNetTransferFromContractFailureEventRecordableHeight
-		NewNvmExeTimeoutConsumeGasHeight = TestNetNewNvmExeTimeoutConsumeGasHeight
 	} else {
-
-		TransferFromContractEventRecordableHeight = LocalTransferFromContractEventRecordableHeight
-		AcceptFuncAvailableHeight = LocalAcceptFuncAvailableHeight
-		RandomAvailableHeight = LocalRandomAvailableHeight
-		DateAvailableHeight = LocalDateAvailableHeight
-		RecordCallContractResultHeight = LocalRecordCallContractResultHeight
-		NvmMemoryLimitWithoutInjectHeight = LocalNvmMemoryLimitWithoutInjectHeight
-		WsResetRecordDependencyHeight = LocalWsResetRecordDependencyHeight
-		V8JSLibVersionControlHeight = LocalV8JSLibVersionControlHeight
 		V8JSLibVersionHeightSlice = LocalV8JSLibVersionHeightSlice
-		TransferFromContractFailureEventRecordableHeight = LocalTransferFromContractFailureEventRecordableHeight
-		NewNvmExeTimeoutConsumeGasHeight = LocalNewNvmExeTimeoutConsumeGasHeight
 	}
 
 	// sort V8JSLibVersionHeightSlice in descending order by height
@@ -323,6 +467,28 @@ func SetCompatibilityOptions(chainID uint32) {
 		"V8JSLibVersionHeightSlice":                 V8JSLibVersionHeightSlice,
 		"TransferFromContractFailureHeight":         TransferFromContractFailureEventRecordableHeight,
 		"NewNvmExeTimeoutConsumeGasHeight":          NewNvmExeTimeoutConsumeGasHeight,
+		"DynamicGasLimitHeight":                     DynamicGasLimitHeight,
+		"VRFProposerHeight":                         VRFProposerHeight,
+		"DoubleSignEvidenceHeight":                  DoubleSignEvidenceHeight,
+		"ValidatorLivenessHeight":                   ValidatorLivenessHeight,
+		"ProofOfDevotionHeight":                     ProofOfDevotionHeight,
+		"ContractCallHeight":                        ContractCallHeight,
+		"WasmHeight":                                WasmHeight,
+		"ExecutionLimitsHeight":                     ExecutionLimitsHeight,
+		"StorageKeysHeight":                         StorageKeysHeight,
+		"BlockchainRandomHeight":                    BlockchainRandomHeight,
+		"ContractUpgradeHeight":                     ContractUpgradeHeight,
+		"ContractTransferHeight":                    ContractTransferHeight,
+		"ContractModulesHeight":                     ContractModulesHeight,
+		"BlockHistoryHeight":                        BlockHistoryHeight,
+		"SizedGasHeight":                            SizedGasHeight,
+		"ContractValidationHeight":                  ContractValidationHeight,
+		"ContractABIHeight":                         ContractABIHeight,
+		"LibSnapshotHeight":                         LibSnapshotHeight,
+		"Ed25519Height":                             Ed25519Height,
+		"MultisigHeight":                            MultisigHeight,
+		"KeyRotationHeight":                         KeyRotationHeight,
+		"EncryptedPayloadHeight":                    EncryptedPayloadHeight,
 	}).Info("Set compatibility options.")
 
 	checkJSLib()
@@ -476,3 +642,22 @@ func GetMaxV8JSLibVersionAtHeight(blockHeight uint64) string {
 	}
 	return ""
 }
+
+// GetV8JSLibVersions return the versions of the js libs the contracts run
+// with on the chain, the default version included.
+func GetV8JSLibVersions() []string {
+	versions := []string{DefaultV8JSLibVersion}
+	for _, v := range V8JSLibVersionHeightSlice {
+		found := false
+		for _, ver := range versions {
+			if ver == v.version {
+				found = true
+				break
+			}
+		}
+		if !found {
+			versions = append(versions, v.version)
+		}
+	}
+	return versions
+}
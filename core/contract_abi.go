@@ -0,0 +1,110 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"encoding/json"
+	"regexp"
+)
+
+// MaxContractABISize the max size of the ABI deployed with a contract
+const MaxContractABISize = 16 * 1024
+
+// ContractABI describes the functions of a contract callable by txs, wallets
+// render the call forms and check the args by it. It is not enforced by the
+// nvm, the contract still checks its args.
+type ContractABI struct {
+	Functions []*ABIFunction `json:"functions"`
+}
+
+// ABIFunction a callable function of the contract, a payable function accepts
+// a value, a readonly one doesn't change the state and is meant to be called
+// by the Call api.
+type ABIFunction struct {
+	Name     string    `json:"name"`
+	Args     []*ABIArg `json:"args,omitempty"`
+	Returns  string    `json:"returns,omitempty"`
+	Payable  bool      `json:"payable,omitempty"`
+	ReadOnly bool      `json:"readonly,omitempty"`
+	Doc      string    `json:"doc,omitempty"`
+}
+
+// ABIArg an arg of a function, passed in order in the json array of the call args
+type ABIArg struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// the types of args and results, an array of a type is suffixed with [].
+var abiTypeRe = regexp.MustCompile(`^(string|number|bool|address|uint128|object|any)(\[\])?$`)
+
+// ParseContractABI parse and check the ABI deployed with a contract, the
+// function names should be unique, and the arg names unique in each function.
+func ParseContractABI(abi string) (*ContractABI, error) {
+	if len(abi) > MaxContractABISize {
+		return nil, ErrInvalidContractABI
+	}
+	contractABI := &ContractABI{}
+	if err := json.Unmarshal([]byte(abi), contractABI); err != nil {
+		return nil, ErrInvalidContractABI
+	}
+	if len(contractABI.Functions) == 0 {
+		return nil, ErrInvalidContractABI
+	}
+	funcs := make(map[string]bool)
+	for _, f := range contractABI.Functions {
+		if f == nil || !PublicFuncNameChecker.MatchString(f.Name) || funcs[f.Name] {
+			return nil, ErrInvalidContractABI
+		}
+		funcs[f.Name] = true
+		if len(f.Returns) > 0 && !abiTypeRe.MatchString(f.Returns) {
+			return nil, ErrInvalidContractABI
+		}
+		args := make(map[string]bool)
+		for _, arg := range f.Args {
+			if arg == nil || len(arg.Name) == 0 || args[arg.Name] || !abiTypeRe.MatchString(arg.Type) {
+				return nil, ErrInvalidContractABI
+			}
+			args[arg.Name] = true
+		}
+	}
+	return contractABI, nil
+}
+
+// ToBytes serialize the ABI, it is kept in the contract meta in this form.
+func (abi *ContractABI) ToBytes() ([]byte, error) {
+	return json.Marshal(abi)
+}
+
+// normalizeContractABI return the serialized form of the ABI to keep in the
+// contract meta, empty if there is no ABI.
+func normalizeContractABI(abi string) (string, error) {
+	if len(abi) == 0 {
+		return "", nil
+	}
+	contractABI, err := ParseContractABI(abi)
+	if err != nil {
+		return "", err
+	}
+	data, err := contractABI.ToBytes()
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,67 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseContractABI(t *testing.T) {
+	tests := []struct {
+		name string
+		abi  string
+		err  error
+	}{
+		{"valid", `{"functions":[{"name":"transfer","args":[{"name":"to","type":"address"},{"name":"value","type":"uint128"}],"returns":"bool","payable":true},{"name":"balanceOf","args":[{"name":"owner","type":"address"}],"returns":"uint128","readonly":true}]}`, nil},
+		{"array type", `{"functions":[{"name":"batch","args":[{"name":"to","type":"address[]"}]}]}`, nil},
+		{"not json", `functions`, ErrInvalidContractABI},
+		{"no function", `{"functions":[]}`, ErrInvalidContractABI},
+		{"invalid function name", `{"functions":[{"name":"_private"}]}`, ErrInvalidContractABI},
+		{"duplicated function", `{"functions":[{"name":"f"},{"name":"f"}]}`, ErrInvalidContractABI},
+		{"unknown type", `{"functions":[{"name":"f","args":[{"name":"a","type":"int256"}]}]}`, ErrInvalidContractABI},
+		{"duplicated arg", `{"functions":[{"name":"f","args":[{"name":"a","type":"string"},{"name":"a","type":"number"}]}]}`, ErrInvalidContractABI},
+		{"unknown result type", `{"functions":[{"name":"f","returns":"void"}]}`, ErrInvalidContractABI},
+		{"too large", `{"functions":[{"name":"f","doc":"` + strings.Repeat("a", MaxContractABISize) + `"}]}`, ErrInvalidContractABI},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseContractABI(tt.abi)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+
+	// the abi is kept in its serialized form.
+	abi, err := normalizeContractABI(`{ "functions": [ {"name": "get", "readonly": true, "returns": "string"} ] }`)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"functions":[{"name":"get","returns":"string","readonly":true}]}`, abi)
+	abi, err = normalizeContractABI("")
+	assert.Nil(t, err)
+	assert.Equal(t, "", abi)
+
+	_, err = LoadDeployPayload([]byte(`{"SourceType":"js","Source":"s","ABI":"{}"}`))
+	assert.Equal(t, ErrInvalidContractABI, err)
+	deploy, err := LoadDeployPayload([]byte(`{"SourceType":"js","Source":"s","ABI":"{\"functions\":[{\"name\":\"get\"}]}"}`))
+	assert.Nil(t, err)
+	assert.Equal(t, `{"functions":[{"name":"get"}]}`, deploy.ABI)
+	_, err = LoadUpgradePayload([]byte(`{"SourceType":"js","Source":"s","ABI":"[]"}`))
+	assert.Equal(t, ErrInvalidContractABI, err)
+}
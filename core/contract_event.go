@@ -0,0 +1,415 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/nebulasio/go-nebulas/core/state"
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// Events triggered by contracts through Event.Trigger are kept in the receipt
+// storage by block, so dapps can read the activity of a contract over a range
+// of blocks. Events of the contracts reached through Blockchain.call are kept
+// under the contract the tx deploys or calls.
+// storage: key -> value
+// cevent_ + block hash + contract address -> json of all contract events in the block
+// cevent_ + block hash + contract address + sha3(topic) -> json of the contract events with the topic in the block
+// cevent_ + block hash -> json of the contract addresses triggering events in the block
+// cbloom_ + block hash -> bloom of the contract addresses and topics of the events in the block
+// Blocks without events have no bloom.
+// The keys of a reverted block are left in storage, they are never reached
+// since the lookup goes by the canonical block hash at each height.
+
+// ContractEventPrefix the prefix of contract event key in storage.
+const ContractEventPrefix = "cevent_"
+
+// ContractEventBloomPrefix the prefix of the key of the contract events bloom in storage.
+const ContractEventBloomPrefix = "cbloom_"
+
+// MaxContractEventsBlockRange the most blocks scanned by a contract events query.
+var MaxContractEventsBlockRange = uint64(5000)
+
+// MaxEventsFilterEntries the most addresses, and the most topics, of an events filter.
+var MaxEventsFilterEntries = 100
+
+// ContractEvent event triggered by a contract on canonical chain, the topic is
+// the one given to Event.Trigger, without the TopicContractEvent namespace.
+type ContractEvent struct {
+	BlockHeight uint64 `json:"block_height"`
+	BlockHash   string `json:"block_hash"`
+	TxHash      string `json:"tx_hash"`
+	Address     string `json:"address"`
+	Topic       string `json:"topic"`
+	Data        string `json:"data"`
+}
+
+// contractEventKey return the key of the events, an empty topic for all events of the contract.
+func contractEventKey(blockHash byteutils.Hash, contract *Address, topic string) []byte {
+	key := append([]byte(ContractEventPrefix), blockHash...)
+	key = append(key, contract.Bytes()...)
+	if len(topic) > 0 {
+		key = append(key, hash.Sha3256([]byte(topic))...)
+	}
+	return key
+}
+
+// contractEventsIndexKey return the key of the contracts triggering events in the block.
+func contractEventsIndexKey(blockHash byteutils.Hash) []byte {
+	return append([]byte(ContractEventPrefix), blockHash...)
+}
+
+// contractEventsBloomKey return the key of the bloom of the events in the block.
+func contractEventsBloomKey(blockHash byteutils.Hash) []byte {
+	return append([]byte(ContractEventBloomPrefix), blockHash...)
+}
+
+// newContractEventsIndex return the contracts triggering the events, sorted, and the bloom of their addresses and topics.
+func newContractEventsIndex(grouped map[string][]*ContractEvent) ([]string, *Bloom, error) {
+	bloom := new(Bloom)
+	seen := make(map[string]bool)
+	contracts := []string{}
+	for _, events := range grouped {
+		for _, event := range events {
+			bloom.Add(bloomTopic(event.Topic))
+			if seen[event.Address] {
+				continue
+			}
+			contract, err := AddressParse(event.Address)
+			if err != nil {
+				return nil, nil, err
+			}
+			bloom.Add(contract.Bytes())
+			seen[event.Address] = true
+			contracts = append(contracts, event.Address)
+		}
+	}
+	sort.Strings(contracts)
+	return contracts, bloom, nil
+}
+
+// txContract return the contract a tx deploys or calls, nil for other txs.
+func txContract(tx *Transaction) (*Address, error) {
+	switch tx.Type() {
+	case TxPayloadDeployType:
+		return tx.GenerateContractAddress()
+	case TxPayloadCallType:
+		return tx.to, nil
+	}
+	return nil, nil
+}
+
+// NewContractEvents return the contract events in the block, grouped by their keys in storage.
+func NewContractEvents(block *Block) (map[string][]*ContractEvent, error) {
+	worldState, err := block.WorldState().Clone()
+	if err != nil {
+		return nil, err
+	}
+
+	grouped := make(map[string][]*ContractEvent)
+	for _, tx := range block.transactions {
+		contract, err := txContract(tx)
+		if err != nil {
+			return nil, err
+		}
+		if contract == nil {
+			continue
+		}
+		events, err := worldState.FetchEvents(tx.hash)
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range newContractEvents(block, tx, contract, events) {
+			for _, key := range [][]byte{
+				contractEventKey(block.Hash(), contract, ""),
+				contractEventKey(block.Hash(), contract, event.Topic),
+			} {
+				grouped[string(key)] = append(grouped[string(key)], event)
+			}
+		}
+	}
+	return grouped, nil
+}
+
+// newContractEvents return the contract events among the events of the tx.
+func newContractEvents(block *Block, tx *Transaction, contract *Address, events []*state.Event) []*ContractEvent {
+	prefix := TopicContractEvent + "."
+	var contractEvents []*ContractEvent
+	for _, e := range events {
+		if !strings.HasPrefix(e.Topic, prefix) {
+			continue
+		}
+		contractEvents = append(contractEvents, &ContractEvent{
+			BlockHeight: block.Height(),
+			BlockHash:   block.Hash().String(),
+			TxHash:      tx.hash.String(),
+			Address:     contract.String(),
+			Topic:       e.Topic[len(prefix):],
+			Data:        e.Data,
+		})
+	}
+	return contractEvents
+}
+
+// triggerContractEvents notify the contract events of the tx with the contract addresses.
+func (bc *BlockChain) triggerContractEvents(block *Block, tx *Transaction, events []*state.Event) {
+	contract, err := txContract(tx)
+	if err != nil || contract == nil {
+		return
+	}
+	for _, event := range newContractEvents(block, tx, contract, events) {
+		data, err := json.Marshal(event)
+		if err != nil {
+			logging.VLog().WithFields(logrus.Fields{
+				"tx":  tx,
+				"err": err,
+			}).Debug("Failed to marshal contract event.")
+			continue
+		}
+		bc.eventEmitter.Trigger(&state.Event{
+			Topic: TopicNewContractEvent,
+			Data:  string(data),
+		})
+	}
+}
+
+// storeContractEvents record the contract events in the block, called when the block is on canonical chain.
+func (bc *BlockChain) storeContractEvents(block *Block) {
+	grouped, err := NewContractEvents(block)
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"block": block,
+			"err":   err,
+		}).Debug("Failed to collect contract events.")
+		return
+	}
+	for key, events := range grouped {
+		value, err := json.Marshal(events)
+		if err != nil {
+			logging.VLog().WithFields(logrus.Fields{
+				"block": block,
+				"err":   err,
+			}).Debug("Failed to marshal contract events.")
+			continue
+		}
+		if err := bc.receiptStorage.Put([]byte(key), value); err != nil {
+			logging.VLog().WithFields(logrus.Fields{
+				"block": block,
+				"err":   err,
+			}).Debug("Failed to store contract events.")
+		}
+	}
+	if len(grouped) == 0 {
+		return
+	}
+
+	contracts, bloom, err := newContractEventsIndex(grouped)
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"block": block,
+			"err":   err,
+		}).Debug("Failed to index contract events.")
+		return
+	}
+	value, err := json.Marshal(contracts)
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"block": block,
+			"err":   err,
+		}).Debug("Failed to marshal contract events index.")
+		return
+	}
+	if err := bc.receiptStorage.Put(contractEventsIndexKey(block.Hash()), value); err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"block": block,
+			"err":   err,
+		}).Debug("Failed to store contract events index.")
+		return
+	}
+	if err := bc.receiptStorage.Put(contractEventsBloomKey(block.Hash()), bloom[:]); err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"block": block,
+			"err":   err,
+		}).Debug("Failed to store contract events bloom.")
+	}
+}
+
+// GetContractEvents return the events triggered by the contract in the canonical
+// blocks in [from, to], the oldest first. An empty topic matches all events.
+func (bc *BlockChain) GetContractEvents(from, to uint64, contract *Address, topic string) ([]*ContractEvent, error) {
+	if contract == nil {
+		return nil, ErrNilArgument
+	}
+	if from < bc.genesisBlock.height || from > to || to > bc.tailBlock.height || to-from >= MaxContractEventsBlockRange {
+		return nil, ErrInvalidEventsBlockRange
+	}
+
+	events := []*ContractEvent{}
+	for height := from; height <= to; height++ {
+		blockHash, err := bc.headerStorage.Get(byteutils.FromUint64(height))
+		if err != nil {
+			return nil, ErrCannotFindBlockAtGivenHeight
+		}
+		found, err := bc.loadContractEvents(blockHash, contract, topic)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, found...)
+	}
+	return events, nil
+}
+
+// GetEventsByFilter return the events triggered by any of the contracts with any
+// of the topics in the canonical blocks in [from, to], the oldest block first and
+// the events of a block grouped by contract. No contracts, or no topics, match all.
+// The blocks whose bloom misses the contracts or the topics are skipped without
+// reading their events. The blocks stored before the blooms are only reached by
+// the filters with contracts.
+func (bc *BlockChain) GetEventsByFilter(from, to uint64, contracts []*Address, topics []string) ([]*ContractEvent, error) {
+	if from < bc.genesisBlock.height || from > to || to > bc.tailBlock.height || to-from >= MaxContractEventsBlockRange {
+		return nil, ErrInvalidEventsBlockRange
+	}
+	if len(contracts) > MaxEventsFilterEntries || len(topics) > MaxEventsFilterEntries {
+		return nil, ErrInvalidEventsFilter
+	}
+	for _, contract := range contracts {
+		if contract == nil {
+			return nil, ErrNilArgument
+		}
+	}
+
+	events := []*ContractEvent{}
+	for height := from; height <= to; height++ {
+		blockHash, err := bc.headerStorage.Get(byteutils.FromUint64(height))
+		if err != nil {
+			return nil, ErrCannotFindBlockAtGivenHeight
+		}
+		candidates, err := bc.filterEventsContracts(blockHash, contracts, topics)
+		if err != nil {
+			return nil, err
+		}
+		for _, contract := range candidates {
+			found, err := bc.filterContractEvents(blockHash, contract, topics)
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, found...)
+		}
+	}
+	return events, nil
+}
+
+// filterEventsContracts return the contracts of the filter the block may have events of, by the bloom of the block.
+func (bc *BlockChain) filterEventsContracts(blockHash byteutils.Hash, contracts []*Address, topics []string) ([]*Address, error) {
+	value, err := bc.receiptStorage.Get(contractEventsBloomKey(blockHash))
+	if err == storage.ErrKeyNotFound {
+		return contracts, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	bloom := BytesToBloom(value)
+	if bloom == nil {
+		return contracts, nil
+	}
+
+	if len(topics) > 0 {
+		matched := false
+		for _, topic := range topics {
+			if bloom.Test(bloomTopic(topic)) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return nil, nil
+		}
+	}
+
+	if len(contracts) == 0 {
+		value, err := bc.receiptStorage.Get(contractEventsIndexKey(blockHash))
+		if err != nil {
+			return nil, err
+		}
+		var index []string
+		if err := json.Unmarshal(value, &index); err != nil {
+			return nil, err
+		}
+		for _, s := range index {
+			contract, err := AddressParse(s)
+			if err != nil {
+				return nil, err
+			}
+			contracts = append(contracts, contract)
+		}
+		return contracts, nil
+	}
+
+	var candidates []*Address
+	for _, contract := range contracts {
+		if bloom.Test(contract.Bytes()) {
+			candidates = append(candidates, contract)
+		}
+	}
+	return candidates, nil
+}
+
+// filterContractEvents return the events of the contract in the block with any of the topics, in the order of the txs.
+func (bc *BlockChain) filterContractEvents(blockHash byteutils.Hash, contract *Address, topics []string) ([]*ContractEvent, error) {
+	if len(topics) == 1 {
+		return bc.loadContractEvents(blockHash, contract, topics[0])
+	}
+	events, err := bc.loadContractEvents(blockHash, contract, "")
+	if err != nil || len(topics) == 0 {
+		return events, err
+	}
+	var found []*ContractEvent
+	for _, event := range events {
+		for _, topic := range topics {
+			if event.Topic == topic {
+				found = append(found, event)
+				break
+			}
+		}
+	}
+	return found, nil
+}
+
+// loadContractEvents return the events of the contract with the topic in the block, an empty topic for all events.
+func (bc *BlockChain) loadContractEvents(blockHash byteutils.Hash, contract *Address, topic string) ([]*ContractEvent, error) {
+	value, err := bc.receiptStorage.Get(contractEventKey(blockHash, contract, topic))
+	if err == storage.ErrKeyNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var events []*ContractEvent
+	if err := json.Unmarshal(value, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
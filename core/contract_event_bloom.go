@@ -0,0 +1,76 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+)
+
+// BloomByteLength the size of the bloom filter of the contract events in a block.
+const BloomByteLength = 256
+
+// bloomBitLength the bits of the bloom filter, a power of 2.
+const bloomBitLength = BloomByteLength * 8
+
+// Bloom the bloom filter of the contract addresses and topics of the events in
+// a block, a filter query skips the blocks whose bloom misses all of its
+// addresses or topics without reading their events.
+type Bloom [BloomByteLength]byte
+
+// bloomBits return the 3 bits the data sets, taken from the sha3 of the data.
+func bloomBits(data []byte) [3]uint {
+	h := hash.Sha3256(data)
+	var bits [3]uint
+	for i := range bits {
+		bits[i] = (uint(h[2*i])<<8 | uint(h[2*i+1])) & (bloomBitLength - 1)
+	}
+	return bits
+}
+
+// Add add the data to the bloom.
+func (b *Bloom) Add(data []byte) {
+	for _, bit := range bloomBits(data) {
+		b[bit/8] |= 1 << (bit % 8)
+	}
+}
+
+// Test return false if the data is surely not in the bloom.
+func (b *Bloom) Test(data []byte) bool {
+	for _, bit := range bloomBits(data) {
+		if b[bit/8]&(1<<(bit%8)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// BytesToBloom return the bloom of the bytes, nil if the length mismatches.
+func BytesToBloom(data []byte) *Bloom {
+	if len(data) != BloomByteLength {
+		return nil
+	}
+	b := new(Bloom)
+	copy(b[:], data)
+	return b
+}
+
+// bloomTopic return the entry of the topic in the bloom, apart from the addresses.
+func bloomTopic(topic string) []byte {
+	return append([]byte("topic:"), topic...)
+}
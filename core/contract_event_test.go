@@ -0,0 +1,138 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/core/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContractEvents(t *testing.T) {
+	neb := testNeb(t)
+	bc := neb.chain
+
+	block := bc.tailBlock
+	deployTx := mockDeployTransaction(bc.ChainID(), 1)
+	callTx := mockCallTransaction(bc.ChainID(), 2, "transfer", "")
+	normalTx := mockNormalTransaction(bc.ChainID(), 3)
+	block.transactions = Transactions{deployTx, callTx, normalTx}
+
+	assert.Nil(t, block.Begin())
+	ws := block.WorldState()
+	ws.RecordEvent(deployTx.Hash(), &state.Event{Topic: TopicContractEvent + ".Init", Data: "1"})
+	ws.RecordEvent(callTx.Hash(), &state.Event{Topic: TopicTransferFromContract, Data: "2"})
+	ws.RecordEvent(callTx.Hash(), &state.Event{Topic: TopicContractEvent + ".Transfer", Data: "3"})
+	ws.RecordEvent(callTx.Hash(), &state.Event{Topic: TopicContractEvent + ".Approve", Data: "4"})
+	ws.RecordEvent(normalTx.Hash(), &state.Event{Topic: TopicContractEvent + ".Transfer", Data: "5"})
+	assert.Nil(t, ws.Commit())
+	bc.storeContractEvents(block)
+
+	height := block.Height()
+	events, err := bc.GetContractEvents(height, height, callTx.to, "")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(events))
+	assert.Equal(t, "Transfer", events[0].Topic)
+	assert.Equal(t, "Approve", events[1].Topic)
+	assert.Equal(t, callTx.Hash().String(), events[0].TxHash)
+
+	events, err = bc.GetContractEvents(height, height, callTx.to, "Approve")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(events))
+	assert.Equal(t, "4", events[0].Data)
+	assert.Equal(t, height, events[0].BlockHeight)
+
+	contract, err := deployTx.GenerateContractAddress()
+	assert.Nil(t, err)
+	events, err = bc.GetContractEvents(height, height, contract, "Init")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(events))
+	assert.Equal(t, contract.String(), events[0].Address)
+
+	events, err = bc.GetContractEvents(height, height, normalTx.to, "")
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(events))
+
+	_, err = bc.GetContractEvents(height, height+1, callTx.to, "")
+	assert.Equal(t, ErrInvalidEventsBlockRange, err)
+	_, err = bc.GetContractEvents(height, height, nil, "")
+	assert.Equal(t, ErrNilArgument, err)
+}
+
+func TestGetEventsByFilter(t *testing.T) {
+	neb := testNeb(t)
+	bc := neb.chain
+
+	block := bc.tailBlock
+	deployTx := mockDeployTransaction(bc.ChainID(), 1)
+	callTx := mockCallTransaction(bc.ChainID(), 2, "transfer", "")
+	block.transactions = Transactions{deployTx, callTx}
+
+	assert.Nil(t, block.Begin())
+	ws := block.WorldState()
+	ws.RecordEvent(deployTx.Hash(), &state.Event{Topic: TopicContractEvent + ".Init", Data: "1"})
+	ws.RecordEvent(callTx.Hash(), &state.Event{Topic: TopicContractEvent + ".Transfer", Data: "2"})
+	ws.RecordEvent(callTx.Hash(), &state.Event{Topic: TopicContractEvent + ".Approve", Data: "3"})
+	ws.RecordEvent(callTx.Hash(), &state.Event{Topic: TopicContractEvent + ".Transfer", Data: "4"})
+	assert.Nil(t, ws.Commit())
+	bc.storeContractEvents(block)
+
+	contract, err := deployTx.GenerateContractAddress()
+	assert.Nil(t, err)
+	height := block.Height()
+
+	events, err := bc.GetEventsByFilter(height, height, nil, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, len(events))
+
+	events, err = bc.GetEventsByFilter(height, height, nil, []string{"Transfer", "Init"})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(events))
+
+	events, err = bc.GetEventsByFilter(height, height, []*Address{callTx.to}, []string{"Transfer", "Approve"})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(events))
+	assert.Equal(t, "2", events[0].Data)
+	assert.Equal(t, "3", events[1].Data)
+	assert.Equal(t, "4", events[2].Data)
+
+	events, err = bc.GetEventsByFilter(height, height, []*Address{contract}, []string{"Transfer"})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(events))
+
+	events, err = bc.GetEventsByFilter(height, height, nil, []string{"Unknown"})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(events))
+
+	bloom := BytesToBloom(nil)
+	assert.Nil(t, bloom)
+	value, err := bc.receiptStorage.Get(contractEventsBloomKey(block.Hash()))
+	assert.Nil(t, err)
+	bloom = BytesToBloom(value)
+	assert.True(t, bloom.Test(contract.Bytes()))
+	assert.True(t, bloom.Test(bloomTopic("Approve")))
+
+	_, err = bc.GetEventsByFilter(height, height+1, nil, nil)
+	assert.Equal(t, ErrInvalidEventsBlockRange, err)
+	_, err = bc.GetEventsByFilter(height, height, []*Address{nil}, nil)
+	assert.Equal(t, ErrNilArgument, err)
+	_, err = bc.GetEventsByFilter(height, height, nil, make([]string, MaxEventsFilterEntries+1))
+	assert.Equal(t, ErrInvalidEventsFilter, err)
+}
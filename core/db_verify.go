@@ -0,0 +1,286 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// Inconsistencies found by the database verification
+const (
+	DBIssueMissingBody    = "missing block"
+	DBIssueCorruptBody    = "corrupt block"
+	DBIssueHashMismatch   = "block hash mismatch"
+	DBIssueBrokenLink     = "parent hash mismatch"
+	DBIssueMissingReceipt = "missing receipt"
+	DBIssueMissingState   = "missing state root"
+	DBIssueTailMismatch   = "tail not on the height index"
+)
+
+// DBIssue an inconsistency found in the chain database.
+type DBIssue struct {
+	Height uint64
+	Hash   byteutils.Hash
+	Kind   string
+	Detail string
+}
+
+func (issue *DBIssue) String() string {
+	return fmt.Sprintf("height %d, hash %s: %s %s", issue.Height, issue.Hash, issue.Kind, issue.Detail)
+}
+
+// DBReport the result of a database verification.
+type DBReport struct {
+	// heights on the height index, from the genesis or the snapshot block up
+	From uint64
+	To   uint64
+
+	// the highest block all the blocks below which are consistent
+	LastConsistentHeight uint64
+	LastConsistentHash   byteutils.Hash
+
+	Issues []*DBIssue
+}
+
+// Consistent reports whether no inconsistency is found.
+func (r *DBReport) Consistent() bool {
+	return len(r.Issues) == 0
+}
+
+// DBVerifier walks the canonical chain in a storage without setting up a
+// chain, so that a database the node fails to start on can be checked.
+type DBVerifier struct {
+	chain *BlockChain
+}
+
+// NewDBVerifier create a verifier of the chain data in stor, freezer is nil if disabled.
+func NewDBVerifier(stor storage.Storage, freezer *storage.Freezer) *DBVerifier {
+	return &DBVerifier{
+		chain: &BlockChain{
+			chainStorage: newChainStorage(stor),
+			freezer:      freezer,
+		},
+	}
+}
+
+func (v *DBVerifier) loadBlock(hash byteutils.Hash) (*Block, error) {
+	value, err := v.chain.bodyStorage.Get(hash)
+	if err == storage.ErrKeyNotFound {
+		value, err = v.chain.loadFrozenBlock(hash)
+	}
+	if err != nil {
+		return nil, err
+	}
+	pbBlock := new(corepb.Block)
+	if err := proto.Unmarshal(value, pbBlock); err != nil {
+		return nil, err
+	}
+	block := new(Block)
+	if err := block.FromProto(pbBlock); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+// Verify rolls back the block commit interrupted if any, then walks the
+// height index checking the blocks, their hashes and links, receipts and
+// state roots.
+func (v *DBVerifier) Verify() (*DBReport, error) {
+	chain := v.chain
+	recovered, err := chain.journal.Recover()
+	if err != nil {
+		return nil, err
+	}
+	if recovered {
+		logging.CLog().Warn("Rolled back an interrupted block commit.")
+	}
+
+	genesis, err := v.loadBlock(GenesisHash)
+	if err != nil {
+		return nil, ErrMissingGenesisBlock
+	}
+
+	// the states at and below the pruned height are released.
+	pruned := uint64(0)
+	if value, err := chain.storage.Get([]byte(StatePrunerHeight)); err == nil {
+		pruned = byteutils.Uint64(value)
+	}
+
+	report := &DBReport{
+		From:                 genesis.height,
+		LastConsistentHeight: genesis.height,
+		LastConsistentHash:   GenesisHash,
+	}
+	if snapshot, err := chain.storage.Get([]byte(SnapshotHeight)); err == nil {
+		report.From = byteutils.Uint64(snapshot)
+	}
+
+	consistent := true
+	var parent byteutils.Hash
+	for height := report.From; ; height++ {
+		hash, err := chain.headerStorage.Get(byteutils.FromUint64(height))
+		if err == storage.ErrKeyNotFound {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		report.To = height
+
+		var issues []*DBIssue
+		if height == genesis.height {
+			if !hash.Equals(GenesisHash) {
+				issues = append(issues, &DBIssue{height, hash, DBIssueHashMismatch, "genesis expected"})
+			}
+		} else {
+			issues = v.verifyBlock(height, hash, parent, pruned)
+		}
+		report.Issues = append(report.Issues, issues...)
+		if len(issues) > 0 {
+			consistent = false
+		}
+		if consistent && height > genesis.height {
+			report.LastConsistentHeight = height
+			report.LastConsistentHash = hash
+		}
+		parent = hash
+	}
+
+	tail, err := chain.headerStorage.Get([]byte(Tail))
+	if err != nil && err != storage.ErrKeyNotFound {
+		return nil, err
+	}
+	if err == nil && !parent.Equals(tail) {
+		report.Issues = append(report.Issues, &DBIssue{report.To, tail, DBIssueTailMismatch, ""})
+	}
+	return report, nil
+}
+
+func (v *DBVerifier) verifyBlock(height uint64, hash byteutils.Hash, parent byteutils.Hash, pruned uint64) []*DBIssue {
+	issue := func(kind string, detail string) []*DBIssue {
+		return []*DBIssue{{height, hash, kind, detail}}
+	}
+
+	block, err := v.loadBlock(hash)
+	if err == storage.ErrKeyNotFound || err == storage.ErrFreezerItemNotFound {
+		return issue(DBIssueMissingBody, "")
+	}
+	if err != nil {
+		return issue(DBIssueCorruptBody, err.Error())
+	}
+	if block.height != height {
+		return issue(DBIssueCorruptBody, fmt.Sprintf("height %d on the block", block.height))
+	}
+	if h, err := block.calHash(); err != nil || !h.Equals(hash) {
+		return issue(DBIssueHashMismatch, "")
+	}
+	if parent != nil && !block.ParentHash().Equals(parent) {
+		return issue(DBIssueBrokenLink, "")
+	}
+
+	issues := []*DBIssue{}
+	for _, tx := range block.transactions {
+		value, err := v.chain.receiptStorage.Get(receiptKey(tx.hash))
+		if err == nil {
+			receipt := new(Receipt)
+			err = json.Unmarshal(value, receipt)
+			if err == nil && receipt.Hash != tx.hash.String() {
+				err = ErrInvalidTransactionHash
+			}
+		}
+		if err != nil {
+			issues = append(issues, &DBIssue{height, hash, DBIssueMissingReceipt, tx.hash.String()})
+		}
+	}
+	if height > pruned {
+		if _, err := v.chain.stateStorage.Get(block.StateRoot()); err != nil {
+			issues = append(issues, &DBIssue{height, hash, DBIssueMissingState, block.StateRoot().String()})
+		}
+	}
+	return issues
+}
+
+// Truncate sets the tail and the LIB back to the last consistent block, drops
+// the height index and the txs indexed above it, all-or-nothing.
+func (v *DBVerifier) Truncate(report *DBReport) error {
+	chain := v.chain
+	target := report.LastConsistentHeight
+
+	chain.journal.Begin()
+	err := v.truncate(report)
+	if err != nil {
+		chain.journal.Rollback()
+		return err
+	}
+	if err := chain.journal.Commit(); err != nil {
+		return err
+	}
+
+	logging.CLog().WithFields(logrus.Fields{
+		"height": target,
+		"hash":   report.LastConsistentHash,
+		"from":   report.To,
+	}).Warn("Truncated the chain back to the last consistent block.")
+	return nil
+}
+
+func (v *DBVerifier) truncate(report *DBReport) error {
+	chain := v.chain
+	target := report.LastConsistentHeight
+
+	if height, ok := chain.TransactionIndexHeight(); ok && height > target {
+		for h := height; h > target; h-- {
+			hash, err := chain.headerStorage.Get(byteutils.FromUint64(h))
+			if err != nil {
+				return err
+			}
+			// the txs of a lost block are unknown, leave their index behind.
+			block, err := v.loadBlock(hash)
+			if err != nil {
+				continue
+			}
+			if err := chain.unindexBlockTransactions(block); err != nil {
+				return err
+			}
+		}
+	}
+
+	for h := report.To; h > target; h-- {
+		if err := chain.headerStorage.Del(byteutils.FromUint64(h)); err != nil {
+			return err
+		}
+	}
+
+	if lib, err := chain.headerStorage.Get([]byte(LIB)); err == nil {
+		if block, err := v.loadBlock(lib); err != nil || block.height > target {
+			if err := chain.headerStorage.Put([]byte(LIB), report.LastConsistentHash); err != nil {
+				return err
+			}
+		}
+	}
+	return chain.headerStorage.Put([]byte(Tail), report.LastConsistentHash)
+}
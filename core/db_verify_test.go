@@ -0,0 +1,74 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDBVerifier(t *testing.T) {
+	neb := testNeb(t)
+	bc := neb.chain
+
+	blocks := []*Block{}
+	for i := 1; i <= 5; i++ {
+		addr, err := AddressParse(MockDynasty[i])
+		assert.Nil(t, err)
+		block, err := NewBlock(bc.ChainID(), addr, bc.tailBlock)
+		assert.Nil(t, err)
+		block.header.timestamp = bc.tailBlock.header.timestamp + BlockInterval
+		assert.Nil(t, block.Seal())
+		signBlock(block)
+		assert.Nil(t, bc.bkPool.Push(block))
+		blocks = append(blocks, block)
+	}
+
+	verifier := NewDBVerifier(neb.Storage(), nil)
+	report, err := verifier.Verify()
+	assert.Nil(t, err)
+	assert.True(t, report.Consistent())
+	assert.Equal(t, blocks[4].height, report.To)
+	assert.Equal(t, blocks[4].Hash(), report.LastConsistentHash)
+
+	// corrupt a block in the middle of the chain.
+	assert.Nil(t, bc.bodyStorage.Put(blocks[2].Hash(), []byte("corrupt")))
+	report, err = verifier.Verify()
+	assert.Nil(t, err)
+	assert.False(t, report.Consistent())
+	assert.Equal(t, 1, len(report.Issues))
+	assert.Equal(t, DBIssueCorruptBody, report.Issues[0].Kind)
+	assert.Equal(t, blocks[2].height, report.Issues[0].Height)
+	assert.Equal(t, blocks[1].height, report.LastConsistentHeight)
+	assert.Equal(t, blocks[1].Hash(), report.LastConsistentHash)
+
+	assert.Nil(t, verifier.Truncate(report))
+	tail, err := bc.headerStorage.Get([]byte(Tail))
+	assert.Nil(t, err)
+	assert.Equal(t, blocks[1].Hash(), byteutils.Hash(tail))
+	_, err = bc.headerStorage.Get(byteutils.FromUint64(blocks[2].height))
+	assert.NotNil(t, err)
+
+	report, err = verifier.Verify()
+	assert.Nil(t, err)
+	assert.True(t, report.Consistent())
+	assert.Equal(t, blocks[1].height, report.To)
+}
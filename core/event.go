@@ -19,6 +19,7 @@
 package core
 
 import (
+	"encoding/json"
 	"sync"
 
 	"time"
@@ -44,13 +45,131 @@ const (
 	// TopicRevertBlock the topic of revert block
 	TopicRevertBlock = "chain.revertBlock"
 
+	// TopicChainReorg the topic of canonical chain switched to another fork
+	TopicChainReorg = "chain.reorg"
+
 	// TopicDropTransaction drop tx (1): smaller nonce (2) expire txLifeTime
 	TopicDropTransaction = "chain.dropTransaction"
 
 	// TopicTransferFromContract transfer from contract
 	TopicTransferFromContract = "chain.transferFromContract"
+
+	// TopicNewDynasty the topic of a new tail block starting another dynasty
+	TopicNewDynasty = "chain.newDynasty"
+
+	// TopicSyncProgress the topic of the periodic progress of the active sync task
+	TopicSyncProgress = "chain.syncProgress"
+
+	// TopicDoubleSign the topic of a double sign evidence recorded on chain
+	TopicDoubleSign = "chain.doubleSign"
+
+	// TopicSlash the topic of a validator slashed and jailed
+	TopicSlash = "chain.slash"
+
+	// TopicStake the topic of a candidate deposit or a delegation changed
+	TopicStake = "chain.stake"
+
+	// TopicContractUpgrade the topic of a contract code replaced by its admin
+	TopicContractUpgrade = "chain.contractUpgrade"
+
+	// TopicContractEvent the namespace of the topics triggered by contracts
+	TopicContractEvent = "chain.contract"
+
+	// TopicNewContractEvent the topic of an event triggered by a contract in a new tail block
+	TopicNewContractEvent = "chain.newContractEvent"
 )
 
+// BlockEvent the payload of TopicNewTailBlock and TopicRevertBlock.
+type BlockEvent struct {
+	Height     uint64 `json:"height"`
+	Hash       string `json:"hash"`
+	ParentHash string `json:"parent_hash"`
+	AccRoot    string `json:"acc_root"`
+	Timestamp  int64  `json:"timestamp"`
+	Tx         int    `json:"tx"`
+	Miner      string `json:"miner"`
+	Random     string `json:"random"`
+}
+
+// PendingTransactionEvent the payload of TopicPendingTransaction.
+type PendingTransactionEvent struct {
+	ChainID   uint32 `json:"chainID"`
+	Hash      string `json:"hash"`
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Nonce     uint64 `json:"nonce"`
+	Value     string `json:"value"`
+	Timestamp int64  `json:"timestamp"`
+	GasPrice  string `json:"gasprice"`
+	GasLimit  string `json:"gaslimit"`
+	Data      string `json:"data"`
+	Type      string `json:"type"`
+}
+
+// NewDynastyEvent the payload of TopicNewDynasty.
+type NewDynastyEvent struct {
+	Height      uint64   `json:"height"`
+	Hash        string   `json:"hash"`
+	DynastyRoot string   `json:"dynasty_root"`
+	Miners      []string `json:"miners"`
+}
+
+// SyncProgress the payload of TopicSyncProgress, Remaining is the estimated
+// number of seconds left, 0 if unknown.
+type SyncProgress struct {
+	Syncing       bool   `json:"syncing"`
+	StartingBlock uint64 `json:"starting_block"`
+	CurrentBlock  uint64 `json:"current_block"`
+	HighestBlock  uint64 `json:"highest_block"`
+	PulledStates  uint64 `json:"pulled_states"`
+	KnownStates   uint64 `json:"known_states"`
+	Remaining     int64  `json:"remaining"`
+}
+
+// DoubleSignEvent the payload of TopicDoubleSign.
+type DoubleSignEvent struct {
+	Validator string `json:"validator"`
+	Timestamp int64  `json:"timestamp"`
+	First     string `json:"first"`
+	Second    string `json:"second"`
+	Reporter  string `json:"reporter"`
+}
+
+// ParseEventPayload decode the data of a chain event into the typed payload of its topic,
+// *BlockEvent, *PendingTransactionEvent, *NewDynastyEvent, *ChainReorg, *SyncProgress, *DoubleSignEvent,
+// *SlashEvent, *StakeEvent, *ContractUpgradeEvent or *ContractEvent.
+func ParseEventPayload(e *state.Event) (interface{}, error) {
+	var payload interface{}
+	switch e.Topic {
+	case TopicNewTailBlock, TopicRevertBlock:
+		payload = new(BlockEvent)
+	case TopicPendingTransaction:
+		payload = new(PendingTransactionEvent)
+	case TopicNewDynasty:
+		payload = new(NewDynastyEvent)
+	case TopicChainReorg:
+		payload = new(ChainReorg)
+	case TopicSyncProgress:
+		payload = new(SyncProgress)
+	case TopicDoubleSign:
+		payload = new(DoubleSignEvent)
+	case TopicSlash:
+		payload = new(SlashEvent)
+	case TopicStake:
+		payload = new(StakeEvent)
+	case TopicNewContractEvent:
+		payload = new(ContractEvent)
+	case TopicContractUpgrade:
+		payload = new(ContractUpgradeEvent)
+	default:
+		return nil, ErrUnsupportedEventTopic
+	}
+	if err := json.Unmarshal([]byte(e.Data), payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
 // EventSubscriber subscriber object
 type EventSubscriber struct {
 	eventCh chan *state.Event
@@ -124,6 +243,18 @@ func (emitter *EventEmitter) Register(subscribers ...*EventSubscriber) {
 	}
 }
 
+// Subscribe return a new subscriber registered on the topics.
+func (emitter *EventEmitter) Subscribe(size int, topics ...string) *EventSubscriber {
+	subscriber := NewEventSubscriber(size, topics)
+	emitter.Register(subscriber)
+	return subscriber
+}
+
+// Unsubscribe deregister the subscriber from all its topics.
+func (emitter *EventEmitter) Unsubscribe(subscriber *EventSubscriber) {
+	emitter.Deregister(subscriber)
+}
+
 // Deregister deregister event chan.
 func (emitter *EventEmitter) Deregister(subscribers ...*EventSubscriber) {
 	for _, v := range subscribers {
@@ -180,3 +180,56 @@ func TestEventEmitterWithRunningRegDereg(t *testing.T) {
 	emitter.Stop()
 	time.Sleep(time.Millisecond * 100)
 }
+
+func TestEventEmitter_Subscribe(t *testing.T) {
+	emitter := NewEventEmitter(1024)
+	emitter.Start()
+	defer emitter.Stop()
+
+	sub := emitter.Subscribe(16, TopicNewTailBlock, TopicNewDynasty)
+	emitter.Trigger(&state.Event{Topic: TopicNewDynasty, Data: `{"height": 2}`})
+	select {
+	case e := <-sub.EventChan():
+		assert.Equal(t, TopicNewDynasty, e.Topic)
+	case <-time.After(time.Second):
+		t.Fatal("event is not dispatched")
+	}
+
+	emitter.Unsubscribe(sub)
+	emitter.Trigger(&state.Event{Topic: TopicNewTailBlock, Data: "{}"})
+	select {
+	case <-sub.EventChan():
+		t.Fatal("event is dispatched after unsubscribe")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestParseEventPayload(t *testing.T) {
+	neb := testNeb(t)
+	bc := neb.chain
+
+	payload, err := ParseEventPayload(&state.Event{Topic: TopicNewTailBlock, Data: bc.tailBlock.String()})
+	assert.Nil(t, err)
+	block := payload.(*BlockEvent)
+	assert.Equal(t, bc.tailBlock.Height(), block.Height)
+	assert.Equal(t, bc.tailBlock.Hash().String(), block.Hash)
+
+	tx := mockNormalTransaction(bc.chainID, 1)
+	payload, err = ParseEventPayload(&state.Event{Topic: TopicPendingTransaction, Data: tx.JSONString()})
+	assert.Nil(t, err)
+	pending := payload.(*PendingTransactionEvent)
+	assert.Equal(t, tx.Hash().String(), pending.Hash)
+	assert.Equal(t, tx.from.String(), pending.From)
+	assert.Equal(t, tx.Nonce(), pending.Nonce)
+
+	payload, err = ParseEventPayload(&state.Event{Topic: TopicNewDynasty, Data: `{"height": 3, "miners": ["n1"]}`})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"n1"}, payload.(*NewDynastyEvent).Miners)
+
+	payload, err = ParseEventPayload(&state.Event{Topic: TopicSyncProgress, Data: `{"syncing": true, "highest_block": 7}`})
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(7), payload.(*SyncProgress).HighestBlock)
+
+	_, err = ParseEventPayload(&state.Event{Topic: TopicTransactionExecutionResult, Data: "{}"})
+	assert.Equal(t, ErrUnsupportedEventTopic, err)
+}
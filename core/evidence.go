@@ -0,0 +1,468 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"sync"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/common/dag"
+	"github.com/nebulasio/go-nebulas/common/dag/pb"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/net"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/sha3"
+)
+
+// A validator signing two different blocks for the same slot is caught by the
+// consensus when the second block arrives. The evidence is gossiped to the
+// network, and the validators report it on chain by an evidence transaction,
+// recorded in the storage of the offender's account:
+// evidence_ + slot timestamp -> hash of the evidence
+
+// EvidencePrefix the prefix of the evidences in the offender's account storage
+const EvidencePrefix = "evidence_"
+
+// MaxPendingEvidences the maximum count of the evidences waiting to be recorded
+const MaxPendingEvidences = 128
+
+func evidenceKey(timestamp int64) []byte {
+	return append([]byte(EvidencePrefix), byteutils.FromInt64(timestamp)...)
+}
+
+// DoubleSignEvidence proves a validator signed two different blocks for the same slot,
+// each block is carried by its signed header, that is enough to recompute its hash.
+type DoubleSignEvidence struct {
+	first  *corepb.SignedHeader
+	second *corepb.SignedHeader
+}
+
+// NewDoubleSignEvidence create the evidence of the two blocks, in the order of their hashes.
+func NewDoubleSignEvidence(a, b *Block) (*DoubleSignEvidence, error) {
+	if a == nil || b == nil {
+		return nil, ErrNilArgument
+	}
+	if byteutils.Less(b.Hash(), a.Hash()) {
+		a, b = b, a
+	}
+	first, err := signedHeader(a)
+	if err != nil {
+		return nil, err
+	}
+	second, err := signedHeader(b)
+	if err != nil {
+		return nil, err
+	}
+	return &DoubleSignEvidence{first: first, second: second}, nil
+}
+
+func signedHeader(block *Block) (*corepb.SignedHeader, error) {
+	header, err := block.header.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	dependency, err := block.dependency.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	pbDep, ok := dependency.(*dagpb.Dag)
+	if !ok {
+		return nil, dag.ErrInvalidProtoToDag
+	}
+	txHashes := make([][]byte, len(block.transactions))
+	for i, tx := range block.transactions {
+		txHashes[i] = tx.Hash()
+	}
+	return &corepb.SignedHeader{
+		Header:     header.(*corepb.BlockHeader),
+		Dependency: pbDep,
+		TxHashes:   txHashes,
+	}, nil
+}
+
+// hashSignedHeader recompute the hash of the block, the same as Block.calHash.
+func hashSignedHeader(sh *corepb.SignedHeader) (byteutils.Hash, error) {
+	header := sh.Header
+	hasher := sha3.New256()
+
+	consensusRoot, err := proto.Marshal(header.ConsensusRoot)
+	if err != nil {
+		return nil, err
+	}
+	dependency, err := proto.Marshal(sh.Dependency)
+	if err != nil {
+		return nil, err
+	}
+
+	hasher.Write(header.ParentHash)
+	hasher.Write(header.StateRoot)
+	hasher.Write(header.TxsRoot)
+	hasher.Write(header.EventsRoot)
+	hasher.Write(consensusRoot)
+	hasher.Write(dependency)
+	hasher.Write(header.Coinbase)
+	hasher.Write(byteutils.FromInt64(header.Timestamp))
+	hasher.Write(byteutils.FromUint32(header.ChainId))
+	hasher.Write(header.GasLimit)
+
+	for _, h := range sh.TxHashes {
+		hasher.Write(h)
+	}
+
+	return hasher.Sum(nil), nil
+}
+
+// verifySignedHeader return the signer of the header, the fields of fixed size are
+// checked so that no bytes can be shifted from one field to another in the hash.
+func verifySignedHeader(sh *corepb.SignedHeader, chainID uint32) (*Address, error) {
+	if sh == nil || sh.Header == nil || sh.Header.ConsensusRoot == nil || sh.Dependency == nil {
+		return nil, ErrInvalidEvidence
+	}
+	header := sh.Header
+	if header.ChainId != chainID || header.Timestamp != header.ConsensusRoot.Timestamp {
+		return nil, ErrInvalidEvidence
+	}
+	if len(header.ParentHash) != BlockHashLength || len(header.StateRoot) != BlockHashLength ||
+		len(header.TxsRoot) != BlockHashLength || len(header.EventsRoot) != BlockHashLength {
+		return nil, ErrInvalidEvidence
+	}
+	if _, err := AddressParseFromBytes(header.Coinbase); err != nil {
+		return nil, ErrInvalidEvidence
+	}
+	if len(header.GasLimit) != 0 && len(header.GasLimit) != util.Uint128Bytes {
+		return nil, ErrInvalidEvidence
+	}
+	for _, h := range sh.TxHashes {
+		if len(h) != TxHashByteLength {
+			return nil, ErrInvalidEvidence
+		}
+	}
+
+	blockHash, err := hashSignedHeader(sh)
+	if err != nil {
+		return nil, err
+	}
+	if !blockHash.Equals(header.Hash) {
+		return nil, ErrInvalidEvidence
+	}
+	return RecoverSignerFromSignature(keystore.Algorithm(header.Alg), header.Hash, header.Sign)
+}
+
+// ToProto converts domain DoubleSignEvidence to proto DoubleSignEvidence
+func (ev *DoubleSignEvidence) ToProto() (proto.Message, error) {
+	return &corepb.DoubleSignEvidence{
+		First:  ev.first,
+		Second: ev.second,
+	}, nil
+}
+
+// FromProto converts proto DoubleSignEvidence to domain DoubleSignEvidence
+func (ev *DoubleSignEvidence) FromProto(msg proto.Message) error {
+	if msg, ok := msg.(*corepb.DoubleSignEvidence); ok {
+		if msg != nil && msg.First != nil && msg.First.Header != nil &&
+			msg.Second != nil && msg.Second.Header != nil {
+			ev.first = msg.First
+			ev.second = msg.Second
+			return nil
+		}
+	}
+	return ErrInvalidEvidence
+}
+
+// Timestamp return the slot of the two blocks
+func (ev *DoubleSignEvidence) Timestamp() int64 {
+	return ev.first.Header.Timestamp
+}
+
+// Hashes return the hashes of the two blocks
+func (ev *DoubleSignEvidence) Hashes() (byteutils.Hash, byteutils.Hash) {
+	return ev.first.Header.Hash, ev.second.Header.Hash
+}
+
+// Hash return the hash of the evidence
+func (ev *DoubleSignEvidence) Hash() byteutils.Hash {
+	return hash.Sha3256(ev.first.Header.Hash, ev.second.Header.Hash)
+}
+
+// Verify return the validator who signed both blocks. The blocks must be of the chain,
+// in the same slot, and different.
+func (ev *DoubleSignEvidence) Verify(chainID uint32) (*Address, error) {
+	if ev.first == nil || ev.second == nil || ev.first.Header == nil || ev.second.Header == nil {
+		return nil, ErrInvalidEvidence
+	}
+	if ev.first.Header.Timestamp != ev.second.Header.Timestamp ||
+		!byteutils.Less(ev.first.Header.Hash, ev.second.Header.Hash) {
+		return nil, ErrInvalidEvidence
+	}
+	a, err := verifySignedHeader(ev.first, chainID)
+	if err != nil {
+		return nil, err
+	}
+	b, err := verifySignedHeader(ev.second, chainID)
+	if err != nil {
+		return nil, err
+	}
+	if !a.Equals(b) {
+		return nil, ErrInvalidEvidence
+	}
+	return a, nil
+}
+
+func isValidator(dynasty []byteutils.Hash, addr *Address) bool {
+	for _, v := range dynasty {
+		if v.Equals(addr.Bytes()) {
+			return true
+		}
+	}
+	return false
+}
+
+// EvidencePool collects the double sign evidences found by the consensus or received
+// from the network, and reports them on chain if the local miner is a validator.
+type EvidencePool struct {
+	chain *BlockChain
+	ns    net.Service
+	am    AccountManager
+
+	reporter *Address
+
+	receiveEvidenceCh chan net.Message
+	quitCh            chan int
+
+	mu sync.Mutex
+	// offender + slot -> evidence
+	pending map[string]*pendingEvidence
+}
+
+type pendingEvidence struct {
+	evidence *DoubleSignEvidence
+	offender *Address
+}
+
+// NewEvidencePool create an evidence pool.
+func NewEvidencePool(chain *BlockChain) *EvidencePool {
+	return &EvidencePool{
+		chain:             chain,
+		receiveEvidenceCh: make(chan net.Message, 128),
+		quitCh:            make(chan int, 1),
+		pending:           make(map[string]*pendingEvidence),
+	}
+}
+
+// RegisterInNetwork register message subscriber in network.
+func (pool *EvidencePool) RegisterInNetwork(ns net.Service) {
+	ns.Register(net.NewSubscriber(pool, pool.receiveEvidenceCh, false, MessageTypeEvidence, net.MessageWeightZero))
+	pool.ns = ns
+}
+
+// Setup the local miner reports the evidences if it is a validator.
+func (pool *EvidencePool) Setup(neb Neblet) error {
+	pool.am = neb.AccountManager()
+	if conf := neb.Config().Chain; conf.StartMine && len(conf.Miner) > 0 {
+		miner, err := AddressParse(conf.Miner)
+		if err != nil {
+			return err
+		}
+		pool.reporter = miner
+	}
+	return nil
+}
+
+// Start start loop.
+func (pool *EvidencePool) Start() {
+	logging.CLog().Info("Starting EvidencePool...")
+	go pool.loop()
+}
+
+// Stop stop loop.
+func (pool *EvidencePool) Stop() {
+	logging.CLog().Info("Stopping EvidencePool...")
+	pool.quitCh <- 0
+}
+
+func (pool *EvidencePool) loop() {
+	logging.CLog().Info("Started EvidencePool.")
+	for {
+		select {
+		case <-pool.quitCh:
+			logging.CLog().Info("Stopped EvidencePool.")
+			return
+		case msg := <-pool.receiveEvidenceCh:
+			pool.handleReceivedEvidence(msg)
+		}
+	}
+}
+
+// Pending return the evidences not recorded on the canonical chain yet.
+func (pool *EvidencePool) Pending() []*DoubleSignEvidence {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	evidences := make([]*DoubleSignEvidence, 0, len(pool.pending))
+	for _, v := range pool.pending {
+		evidences = append(evidences, v.evidence)
+	}
+	return evidences
+}
+
+// Report is called by the consensus with two blocks of a slot signed by the same validator.
+func (pool *EvidencePool) Report(a, b *Block) error {
+	ev, err := NewDoubleSignEvidence(a, b)
+	if err != nil {
+		return err
+	}
+	added, err := pool.add(ev)
+	if err != nil {
+		return err
+	}
+	if added {
+		pool.ns.Broadcast(MessageTypeEvidence, ev, net.MessagePriorityHigh)
+	}
+	return nil
+}
+
+func (pool *EvidencePool) handleReceivedEvidence(msg net.Message) {
+	pb := new(corepb.DoubleSignEvidence)
+	if err := proto.Unmarshal(msg.Data(), pb); err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"msgType": msg.MessageType(),
+			"msg":     msg,
+			"err":     err,
+		}).Debug("Failed to unmarshal data.")
+		return
+	}
+	ev := new(DoubleSignEvidence)
+	if err := ev.FromProto(pb); err != nil {
+		return
+	}
+	added, err := pool.add(ev)
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"evidence": ev.Hash().Hex(),
+			"pid":      msg.MessageFrom(),
+			"err":      err,
+		}).Debug("Failed to add double sign evidence.")
+		return
+	}
+	if added {
+		pool.ns.Relay(MessageTypeEvidence, ev, net.MessagePriorityHigh)
+	}
+}
+
+// recorded check if the evidence of the offender in the slot is recorded in the tail state.
+func (pool *EvidencePool) recorded(offender *Address, timestamp int64) bool {
+	acc, err := pool.chain.TailBlock().GetAccount(offender.address)
+	if err != nil {
+		return false
+	}
+	_, err = acc.Get(evidenceKey(timestamp))
+	return err == nil
+}
+
+// add verify the evidence and report it, return false if it is known or already recorded.
+func (pool *EvidencePool) add(ev *DoubleSignEvidence) (bool, error) {
+	offender, err := ev.Verify(pool.chain.ChainID())
+	if err != nil {
+		return false, err
+	}
+	dynasty, err := pool.chain.TailBlock().Dynasty()
+	if err != nil {
+		return false, err
+	}
+	if !isValidator(dynasty, offender) {
+		return false, ErrInvalidEvidenceSigner
+	}
+	if pool.recorded(offender, ev.Timestamp()) {
+		return false, nil
+	}
+
+	key := offender.String() + string(byteutils.FromInt64(ev.Timestamp()))
+	pool.mu.Lock()
+	if _, ok := pool.pending[key]; ok {
+		pool.mu.Unlock()
+		return false, nil
+	}
+	for k, v := range pool.pending {
+		if pool.recorded(v.offender, v.evidence.Timestamp()) {
+			delete(pool.pending, k)
+		}
+	}
+	if len(pool.pending) >= MaxPendingEvidences {
+		pool.mu.Unlock()
+		return false, ErrTooManyPendingEvidences
+	}
+	pool.pending[key] = &pendingEvidence{evidence: ev, offender: offender}
+	pool.mu.Unlock()
+
+	first, second := ev.Hashes()
+	logging.CLog().WithFields(logrus.Fields{
+		"validator": offender.String(),
+		"timestamp": ev.Timestamp(),
+		"first":     first.Hex(),
+		"second":    second.Hex(),
+	}).Warn("Found a validator signing two blocks in a slot.")
+
+	if pool.reporter != nil && isValidator(dynasty, pool.reporter) {
+		if err := pool.submit(ev, offender); err != nil {
+			logging.VLog().WithFields(logrus.Fields{
+				"evidence": ev.Hash().Hex(),
+				"reporter": pool.reporter,
+				"err":      err,
+			}).Debug("Failed to report double sign evidence.")
+		}
+	}
+	return true, nil
+}
+
+// submit report the evidence on chain by a transaction of the local miner to the offender.
+func (pool *EvidencePool) submit(ev *DoubleSignEvidence, offender *Address) error {
+	pb, err := ev.ToProto()
+	if err != nil {
+		return err
+	}
+	payload, err := proto.Marshal(pb)
+	if err != nil {
+		return err
+	}
+
+	txPool := pool.chain.TransactionPool()
+	nonce, ok := txPool.NextNonce(pool.reporter)
+	if !ok {
+		return ErrInvalidArgument
+	}
+	tx, err := NewTransaction(pool.chain.ChainID(), pool.reporter, offender, util.NewUint128(), nonce,
+		TxPayloadEvidenceType, payload, txPool.GasPrice(), TransactionMaxGas)
+	if err != nil {
+		return err
+	}
+	gasLimit, err := tx.GasCountOfTxBase()
+	if err != nil {
+		return err
+	}
+	tx.gasLimit = gasLimit
+	if err := pool.am.SignTransaction(pool.reporter, tx); err != nil {
+		return err
+	}
+	return txPool.PushAndBroadcast(tx)
+}
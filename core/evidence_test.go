@@ -0,0 +1,185 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/crypto"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func mockSignedBlock(t *testing.T, bc *BlockChain, signer *Address, timestamp int64) *Block {
+	key, err := keystore.DefaultKS.GetUnlocked(signer.String())
+	assert.Nil(t, err)
+	signature, err := crypto.NewSignature(keystore.SECP256K1)
+	assert.Nil(t, err)
+	assert.Nil(t, signature.InitSign(key.(keystore.PrivateKey)))
+
+	block, err := bc.NewBlock(mockAddress())
+	assert.Nil(t, err)
+	block.header.timestamp = timestamp
+	assert.Nil(t, block.Seal())
+	assert.Nil(t, block.Sign(signature))
+	return block
+}
+
+func TestDoubleSignEvidence(t *testing.T) {
+	neb := testNeb(t)
+	bc := neb.chain
+	signer := mockAddress()
+
+	a := mockSignedBlock(t, bc, signer, BlockInterval)
+	b := mockSignedBlock(t, bc, signer, BlockInterval)
+	assert.NotEqual(t, a.Hash(), b.Hash())
+
+	// the signed header is enough to recompute the hash of the block.
+	sh, err := signedHeader(a)
+	assert.Nil(t, err)
+	hash, err := hashSignedHeader(sh)
+	assert.Nil(t, err)
+	assert.Equal(t, a.Hash(), hash)
+
+	ev, err := NewDoubleSignEvidence(a, b)
+	assert.Nil(t, err)
+	offender, err := ev.Verify(bc.ChainID())
+	assert.Nil(t, err)
+	assert.Equal(t, signer, offender)
+	assert.Equal(t, int64(BlockInterval), ev.Timestamp())
+
+	// the evidence is the same in both orders.
+	reversed, err := NewDoubleSignEvidence(b, a)
+	assert.Nil(t, err)
+	assert.Equal(t, ev.Hash(), reversed.Hash())
+
+	pb, err := ev.ToProto()
+	assert.Nil(t, err)
+	data, err := proto.Marshal(pb)
+	assert.Nil(t, err)
+	payload, err := LoadEvidencePayload(data)
+	assert.Nil(t, err)
+	assert.Equal(t, ev.Hash(), payload.Evidence.Hash())
+	bytes, err := payload.ToBytes()
+	assert.Nil(t, err)
+	assert.Equal(t, data, bytes)
+	_, err = LoadEvidencePayload([]byte("evidence"))
+	assert.NotNil(t, err)
+	assert.Equal(t, ErrInvalidEvidence, new(DoubleSignEvidence).FromProto(new(corepb.DoubleSignEvidence)))
+
+	// other chain.
+	_, err = ev.Verify(bc.ChainID() + 1)
+	assert.Equal(t, ErrInvalidEvidence, err)
+
+	// the same block twice.
+	same, err := NewDoubleSignEvidence(a, a)
+	assert.Nil(t, err)
+	_, err = same.Verify(bc.ChainID())
+	assert.Equal(t, ErrInvalidEvidence, err)
+
+	// other slot.
+	c := mockSignedBlock(t, bc, signer, BlockInterval*2)
+	ev, err = NewDoubleSignEvidence(a, c)
+	assert.Nil(t, err)
+	_, err = ev.Verify(bc.ChainID())
+	assert.Equal(t, ErrInvalidEvidence, err)
+
+	// other signer.
+	d := mockSignedBlock(t, bc, mockAddress(), BlockInterval)
+	ev, err = NewDoubleSignEvidence(a, d)
+	assert.Nil(t, err)
+	_, err = ev.Verify(bc.ChainID())
+	assert.Equal(t, ErrInvalidEvidence, err)
+
+	// a header not matching its hash.
+	ev, err = NewDoubleSignEvidence(a, b)
+	assert.Nil(t, err)
+	ev.second.Header.Coinbase = ev.first.Header.Coinbase
+	_, err = ev.Verify(bc.ChainID())
+	assert.Equal(t, ErrInvalidEvidence, err)
+}
+
+func TestEvidencePool(t *testing.T) {
+	neb := testNeb(t)
+	bc := neb.chain
+	pool := NewEvidencePool(bc)
+	pool.RegisterInNetwork(neb.ns)
+	assert.Nil(t, pool.Setup(neb))
+
+	// only the validators of the dynasty are reported.
+	signer := mockAddress()
+	a := mockSignedBlock(t, bc, signer, BlockInterval)
+	b := mockSignedBlock(t, bc, signer, BlockInterval)
+	assert.Equal(t, ErrInvalidEvidenceSigner, pool.Report(a, b))
+	assert.Equal(t, 0, len(pool.Pending()))
+
+	ev, err := NewDoubleSignEvidence(a, b)
+	assert.Nil(t, err)
+	payload := NewEvidencePayload(ev)
+	tx := mockTransaction(bc.ChainID(), 0, TxPayloadEvidenceType, nil)
+	_, _, err = payload.Execute(TransactionMaxGas, tx, bc.tailBlock, bc.tailBlock.WorldState())
+	assert.Equal(t, ErrInvalidEvidenceSigner, err)
+}
+
+func TestEvidenceTransactionActivation(t *testing.T) {
+	neb := testNeb(t)
+	bc := neb.chain
+	SetCompatibilityOptions(bc.ChainID())
+	defer SetCompatibilityOptions(TestNetID)
+	assert.Equal(t, LocalDoubleSignEvidenceHeight, DoubleSignEvidenceHeight)
+
+	signer := mockAddress()
+	ev, err := NewDoubleSignEvidence(mockSignedBlock(t, bc, signer, BlockInterval), mockSignedBlock(t, bc, signer, BlockInterval))
+	assert.Nil(t, err)
+	data, err := NewEvidencePayload(ev).ToBytes()
+	assert.Nil(t, err)
+	from := mockAddress()
+	tx, err := NewTransaction(bc.ChainID(), from, from, util.NewUint128(), 1, TxPayloadEvidenceType, data, TransactionGasPrice, TransactionMaxGas)
+	assert.Nil(t, err)
+	assert.Nil(t, tx.Sign(mockSignature(t, from)))
+
+	block, err := bc.NewBlock(mockAddress())
+	assert.Nil(t, err)
+	assert.Equal(t, LocalDoubleSignEvidenceHeight, block.Height())
+	balance, _ := util.NewUint128FromString("1000000000000000000")
+	fromAcc, err := block.WorldState().GetOrCreateUserAccount(from.address)
+	assert.Nil(t, err)
+	assert.Nil(t, fromAcc.AddBalance(balance))
+
+	// the evidence is executed at the activation height, the signer is not a validator of the dynasty.
+	txWorldState, err := block.WorldState().Prepare(tx.Hash().String())
+	assert.Nil(t, err)
+	giveback, err := VerifyExecution(tx, block, txWorldState)
+	assert.False(t, giveback)
+	assert.Nil(t, err)
+	_, err = txWorldState.CheckAndUpdate()
+	assert.Nil(t, err)
+
+	events, err := block.WorldState().FetchEvents(tx.Hash())
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(events))
+	txEvent := TransactionEventV2{}
+	assert.Nil(t, json.Unmarshal([]byte(events[0].Data), &txEvent))
+	assert.Equal(t, int8(TxExecutionFailed), txEvent.Status)
+	assert.Equal(t, ErrInvalidEvidenceSigner.Error(), txEvent.Error)
+}
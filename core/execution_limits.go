@@ -0,0 +1,72 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// MinLimitsOfTotalMemorySize the heap V8 needs at least to run a contract.
+const MinLimitsOfTotalMemorySize uint64 = 6 * 1000 * 1000
+
+// ExecutionLimits the wall clock time and memory a contract execution can take,
+// set by the genesis conf of the chain. An execution over the limits is terminated.
+type ExecutionLimits struct {
+	TimeoutInMs uint64
+	MemorySize  uint64
+}
+
+// DefaultExecutionLimits the protocol default of the execution limits.
+var DefaultExecutionLimits = ExecutionLimits{
+	TimeoutInMs: 5000,
+	MemorySize:  DefaultLimitsOfTotalMemorySize,
+}
+
+var executionLimits = DefaultExecutionLimits
+
+// SetExecutionLimits read the execution limits of the genesis conf, the fields
+// not set keep the protocol default.
+func SetExecutionLimits(conf *corepb.GenesisExecutionLimits) error {
+	limits := DefaultExecutionLimits
+	if conf != nil {
+		if conf.TimeoutInMs > 0 {
+			limits.TimeoutInMs = conf.TimeoutInMs
+		}
+		if conf.MemorySize > 0 {
+			limits.MemorySize = conf.MemorySize
+		}
+	}
+	if limits.MemorySize < MinLimitsOfTotalMemorySize {
+		return ErrInvalidExecutionLimits
+	}
+	executionLimits = limits
+
+	logging.CLog().WithFields(logrus.Fields{
+		"timeoutInMs": limits.TimeoutInMs,
+		"memorySize":  limits.MemorySize,
+	}).Info("Set contract execution limits.")
+	return nil
+}
+
+// ContractExecutionLimits return the execution limits of the contracts on the chain.
+func ContractExecutionLimits() ExecutionLimits {
+	return executionLimits
+}
@@ -0,0 +1,41 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetExecutionLimits(t *testing.T) {
+	defer SetExecutionLimits(nil)
+
+	assert.Nil(t, SetExecutionLimits(nil))
+	assert.Equal(t, DefaultExecutionLimits, ContractExecutionLimits())
+
+	assert.Nil(t, SetExecutionLimits(&corepb.GenesisExecutionLimits{TimeoutInMs: 1000}))
+	assert.Equal(t, uint64(1000), ContractExecutionLimits().TimeoutInMs)
+	assert.Equal(t, DefaultExecutionLimits.MemorySize, ContractExecutionLimits().MemorySize)
+
+	conf := &corepb.GenesisExecutionLimits{MemorySize: MinLimitsOfTotalMemorySize - 1}
+	assert.Equal(t, ErrInvalidExecutionLimits, SetExecutionLimits(conf))
+	assert.Equal(t, uint64(1000), ContractExecutionLimits().TimeoutInMs)
+}
@@ -0,0 +1,414 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"sync"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/core/state"
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/net"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// The finality gadget runs two rounds of votes over the blocks on top of the
+// consensus. A validator prevotes every new tail block of its dynasty, once 2/3
+// of the dynasty prevoted a block it precommits the block, and once 2/3 of the
+// dynasty precommitted a block it becomes the latest irreversible block. A
+// validator votes at most once per height in each round.
+
+// Finality vote steps
+const (
+	FinalityPrevote   uint32 = 1
+	FinalityPrecommit uint32 = 2
+)
+
+// FinalityVote is a validator's signature on a block in a round of the finality gadget.
+type FinalityVote struct {
+	step      uint32
+	height    uint64
+	blockHash byteutils.Hash
+	alg       keystore.Algorithm
+	sign      byteutils.Hash
+}
+
+// ToProto converts domain FinalityVote to proto FinalityVote
+func (v *FinalityVote) ToProto() (proto.Message, error) {
+	return &corepb.FinalityVote{
+		Step:      v.step,
+		Height:    v.height,
+		BlockHash: v.blockHash,
+		Alg:       uint32(v.alg),
+		Sign:      v.sign,
+	}, nil
+}
+
+// FromProto converts proto FinalityVote to domain FinalityVote
+func (v *FinalityVote) FromProto(msg proto.Message) error {
+	if msg, ok := msg.(*corepb.FinalityVote); ok {
+		if msg != nil {
+			v.step = msg.Step
+			v.height = msg.Height
+			v.blockHash = msg.BlockHash
+			v.alg = keystore.Algorithm(msg.Alg)
+			v.sign = msg.Sign
+			return nil
+		}
+	}
+	return ErrInvalidFinalityVote
+}
+
+// finalityHash return the hash signed by validators for the block in the step.
+func finalityHash(chainID uint32, step uint32, height uint64, blockHash byteutils.Hash) byteutils.Hash {
+	return hash.Sha3256(
+		byteutils.FromUint32(chainID),
+		byteutils.FromUint32(step),
+		byteutils.FromUint64(height),
+		blockHash,
+	)
+}
+
+func finalityMessageType(step uint32) string {
+	if step == FinalityPrecommit {
+		return MessageTypeFinalityPrecommit
+	}
+	return MessageTypeFinalityPrevote
+}
+
+// FinalityGadget collects the prevotes and precommits of validators and
+// advances the latest irreversible block to the precommitted blocks.
+type FinalityGadget struct {
+	chain *BlockChain
+	ns    net.Service
+	am    AccountManager
+
+	enable bool
+	signer *Address
+
+	receiveVoteCh chan net.Message
+	newTailCh     chan *Block
+	quitCh        chan int
+
+	mu sync.Mutex
+	// step -> the highest height the local signer voted
+	votedHeight map[uint32]uint64
+	// step -> height -> block hash -> signer -> vote
+	votes map[uint32]map[uint64]map[string]map[string]*FinalityVote
+}
+
+// NewFinalityGadget create a finality gadget, it does nothing unless enabled.
+func NewFinalityGadget(chain *BlockChain, enable bool) *FinalityGadget {
+	return &FinalityGadget{
+		chain:         chain,
+		enable:        enable,
+		receiveVoteCh: make(chan net.Message, 128),
+		newTailCh:     make(chan *Block, 128),
+		quitCh:        make(chan int, 1),
+		votedHeight:   make(map[uint32]uint64),
+		votes:         make(map[uint32]map[uint64]map[string]map[string]*FinalityVote),
+	}
+}
+
+// RegisterInNetwork register message subscriber in network.
+func (fg *FinalityGadget) RegisterInNetwork(ns net.Service) {
+	ns.Register(net.NewSubscriber(fg, fg.receiveVoteCh, false, MessageTypeFinalityPrevote, net.MessageWeightZero))
+	ns.Register(net.NewSubscriber(fg, fg.receiveVoteCh, false, MessageTypeFinalityPrecommit, net.MessageWeightZero))
+	fg.ns = ns
+}
+
+// Setup the local miner votes if it is a validator.
+func (fg *FinalityGadget) Setup(neb Neblet) error {
+	fg.am = neb.AccountManager()
+	if conf := neb.Config().Chain; conf.StartMine && len(conf.Miner) > 0 {
+		miner, err := AddressParse(conf.Miner)
+		if err != nil {
+			return err
+		}
+		fg.signer = miner
+	}
+	return nil
+}
+
+// Start start loop.
+func (fg *FinalityGadget) Start() {
+	if !fg.enable {
+		return
+	}
+	logging.CLog().Info("Starting FinalityGadget...")
+
+	go fg.loop()
+}
+
+// Stop stop loop.
+func (fg *FinalityGadget) Stop() {
+	if !fg.enable {
+		return
+	}
+	logging.CLog().Info("Stopping FinalityGadget...")
+	fg.quitCh <- 0
+}
+
+func (fg *FinalityGadget) loop() {
+	logging.CLog().Info("Started FinalityGadget.")
+	for {
+		select {
+		case <-fg.quitCh:
+			logging.CLog().Info("Stopped FinalityGadget.")
+			return
+		case block := <-fg.newTailCh:
+			fg.prevote(block)
+		case msg := <-fg.receiveVoteCh:
+			fg.handleReceivedVote(msg)
+		}
+	}
+}
+
+// onNewTail prevote the new tail block later in the loop.
+func (fg *FinalityGadget) onNewTail(block *Block) {
+	if !fg.enable || fg.signer == nil {
+		return
+	}
+	select {
+	case fg.newTailCh <- block:
+	default:
+		logging.VLog().WithFields(logrus.Fields{
+			"block": block,
+		}).Debug("Finality gadget is busy, skip the prevote.")
+	}
+}
+
+func (fg *FinalityGadget) prevote(block *Block) {
+	if lib := fg.chain.LIB(); lib != nil && block.height <= lib.height {
+		return
+	}
+	fg.vote(FinalityPrevote, block)
+}
+
+// vote sign the block in the step if the local signer is its validator and has
+// not voted at its height yet.
+func (fg *FinalityGadget) vote(step uint32, block *Block) {
+	if fg.signer == nil || !fg.isValidator(block, fg.signer) {
+		return
+	}
+	fg.mu.Lock()
+	if block.height <= fg.votedHeight[step] {
+		fg.mu.Unlock()
+		return
+	}
+	fg.votedHeight[step] = block.height
+	fg.mu.Unlock()
+
+	sign, err := fg.am.SignHash(fg.signer, finalityHash(fg.chain.chainID, step, block.height, block.Hash()), keystore.SECP256K1)
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"step":  step,
+			"block": block,
+			"err":   err,
+		}).Debug("Failed to sign finality vote.")
+		return
+	}
+	vote := &FinalityVote{
+		step:      step,
+		height:    block.height,
+		blockHash: block.Hash(),
+		alg:       keystore.SECP256K1,
+		sign:      sign,
+	}
+	if _, err := fg.addVote(vote); err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"step":  step,
+			"block": block,
+			"err":   err,
+		}).Debug("Failed to add finality vote.")
+		return
+	}
+	fg.ns.Broadcast(finalityMessageType(step), vote, net.MessagePriorityNormal)
+}
+
+func (fg *FinalityGadget) isValidator(block *Block, addr *Address) bool {
+	dynasty, err := block.Dynasty()
+	if err != nil {
+		return false
+	}
+	for _, v := range dynasty {
+		if v.Equals(addr.Bytes()) {
+			return true
+		}
+	}
+	return false
+}
+
+func (fg *FinalityGadget) handleReceivedVote(msg net.Message) {
+	pb := new(corepb.FinalityVote)
+	if err := proto.Unmarshal(msg.Data(), pb); err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"msgType": msg.MessageType(),
+			"msg":     msg,
+			"err":     err,
+		}).Debug("Failed to unmarshal data.")
+		return
+	}
+	vote := new(FinalityVote)
+	if err := vote.FromProto(pb); err != nil {
+		return
+	}
+	if msg.MessageType() != finalityMessageType(vote.step) {
+		return
+	}
+	added, err := fg.addVote(vote)
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"step":   vote.step,
+			"height": vote.height,
+			"block":  vote.blockHash.Hex(),
+			"pid":    msg.MessageFrom(),
+			"err":    err,
+		}).Debug("Failed to add finality vote.")
+		return
+	}
+	if added {
+		fg.ns.Relay(msg.MessageType(), vote, net.MessagePriorityNormal)
+	}
+}
+
+// verifyVote return the signer of the vote if it is a validator of the voted block.
+func (fg *FinalityGadget) verifyVote(vote *FinalityVote) (*Block, *Address, error) {
+	if vote.step != FinalityPrevote && vote.step != FinalityPrecommit {
+		return nil, nil, ErrInvalidFinalityVote
+	}
+	block := fg.chain.GetBlock(vote.blockHash)
+	if block == nil || block.height != vote.height {
+		return nil, nil, ErrFinalityBlockNotFound
+	}
+	signer, err := RecoverSignerFromSignature(vote.alg, finalityHash(fg.chain.chainID, vote.step, vote.height, vote.blockHash), vote.sign)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !fg.isValidator(block, signer) {
+		return nil, nil, ErrInvalidFinalitySigner
+	}
+	return block, signer, nil
+}
+
+// addVote record the vote, a quorum of prevotes is followed by the precommit of
+// the local signer, and a quorum of precommits finalizes the block.
+// return false if the vote is known or out of date.
+func (fg *FinalityGadget) addVote(vote *FinalityVote) (bool, error) {
+	block, signer, err := fg.verifyVote(vote)
+	if err != nil {
+		return false, err
+	}
+	added, quorum, err := fg.recordVote(vote, block, signer)
+	if err != nil || !quorum {
+		return added, err
+	}
+	switch vote.step {
+	case FinalityPrevote:
+		fg.vote(FinalityPrecommit, block)
+	case FinalityPrecommit:
+		if err := fg.finalize(block); err != nil {
+			return added, err
+		}
+	}
+	return added, nil
+}
+
+// recordVote return true if the vote is new, and whether it completes a quorum
+// of the dynasty of the block.
+func (fg *FinalityGadget) recordVote(vote *FinalityVote, block *Block, signer *Address) (bool, bool, error) {
+	fg.mu.Lock()
+	defer fg.mu.Unlock()
+
+	if lib := fg.chain.LIB(); lib != nil && vote.height <= lib.height {
+		return false, false, nil
+	}
+	byHeight, ok := fg.votes[vote.step]
+	if !ok {
+		byHeight = make(map[uint64]map[string]map[string]*FinalityVote)
+		fg.votes[vote.step] = byHeight
+	}
+	byBlock, ok := byHeight[vote.height]
+	if !ok {
+		byBlock = make(map[string]map[string]*FinalityVote)
+		byHeight[vote.height] = byBlock
+	}
+	bySigner, ok := byBlock[vote.blockHash.Hex()]
+	if !ok {
+		bySigner = make(map[string]*FinalityVote)
+		byBlock[vote.blockHash.Hex()] = bySigner
+	}
+	if _, ok := bySigner[signer.String()]; ok {
+		return false, false, nil
+	}
+	bySigner[signer.String()] = vote
+
+	dynasty, err := block.Dynasty()
+	if err != nil {
+		return true, false, err
+	}
+	// the quorum is reached by exactly one vote, the later ones change nothing.
+	return true, len(bySigner) == checkpointQuorum(len(dynasty)), nil
+}
+
+// finalize make the block the latest irreversible block, if it is on the
+// canonical chain above the current one.
+func (fg *FinalityGadget) finalize(block *Block) error {
+	lib := fg.chain.LIB()
+	if lib != nil && block.height <= lib.height {
+		return nil
+	}
+	// a quorum precommitted a block off the canonical chain, the node is on a minority fork.
+	canonical := fg.chain.GetBlockOnCanonicalChainByHeight(block.height)
+	if canonical == nil || !canonical.Hash().Equals(block.Hash()) {
+		logging.CLog().WithFields(logrus.Fields{
+			"block": block,
+		}).Warn("Precommitted block is not on canonical chain.")
+		return nil
+	}
+	if err := fg.chain.StoreLIBHashToStorage(block); err != nil {
+		return err
+	}
+	fg.chain.SetLIB(block)
+
+	fg.mu.Lock()
+	for _, byHeight := range fg.votes {
+		for height := range byHeight {
+			if height <= block.height {
+				delete(byHeight, height)
+			}
+		}
+	}
+	fg.mu.Unlock()
+
+	logging.CLog().WithFields(logrus.Fields{
+		"lib.new": block,
+		"lib.old": lib,
+	}).Info("Succeed to finalize latest irreversible block.")
+
+	fg.chain.EventEmitter().Trigger(&state.Event{
+		Topic: TopicLibBlock,
+		Data:  block.String(),
+	})
+	return nil
+}
@@ -0,0 +1,143 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/crypto"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/stretchr/testify/assert"
+)
+
+func mockFinalityVote(t *testing.T, bc *BlockChain, signer *Address, step uint32, block *Block) *FinalityVote {
+	key, err := keystore.DefaultKS.GetUnlocked(signer.String())
+	assert.Nil(t, err)
+	signature, err := crypto.NewSignature(keystore.SECP256K1)
+	assert.Nil(t, err)
+	assert.Nil(t, signature.InitSign(key.(keystore.PrivateKey)))
+	sign, err := signature.Sign(finalityHash(bc.ChainID(), step, block.Height(), block.Hash()))
+	assert.Nil(t, err)
+	return &FinalityVote{
+		step:      step,
+		height:    block.Height(),
+		blockHash: block.Hash(),
+		alg:       keystore.SECP256K1,
+		sign:      sign,
+	}
+}
+
+func TestFinalityVote_Proto(t *testing.T) {
+	vote := &FinalityVote{
+		step:      FinalityPrecommit,
+		height:    100,
+		blockHash: []byte("block"),
+		alg:       keystore.SECP256K1,
+		sign:      []byte("sign"),
+	}
+	pb, err := vote.ToProto()
+	assert.Nil(t, err)
+	data, err := proto.Marshal(pb)
+	assert.Nil(t, err)
+
+	msg := new(corepb.FinalityVote)
+	assert.Nil(t, proto.Unmarshal(data, msg))
+	got := new(FinalityVote)
+	assert.Nil(t, got.FromProto(msg))
+	assert.Equal(t, vote, got)
+
+	assert.Equal(t, ErrInvalidFinalityVote, got.FromProto(new(corepb.CheckpointVote)))
+	assert.Equal(t, MessageTypeFinalityPrevote, finalityMessageType(FinalityPrevote))
+	assert.Equal(t, MessageTypeFinalityPrecommit, finalityMessageType(FinalityPrecommit))
+}
+
+func TestFinalityGadget(t *testing.T) {
+	neb := testNeb(t)
+	bc := neb.chain
+	bc.finality = NewFinalityGadget(bc, true)
+	bc.finality.RegisterInNetwork(neb.ns)
+	assert.Nil(t, bc.finality.Setup(neb))
+
+	coinbase1, _ := AddressParse(MockDynasty[1])
+	coinbase2, _ := AddressParse(MockDynasty[2])
+
+	block1, err := bc.NewBlock(coinbase1)
+	assert.Nil(t, err)
+	block1.header.timestamp = BlockInterval
+	fork1, err := bc.NewBlock(coinbase2)
+	assert.Nil(t, err)
+	fork1.header.timestamp = BlockInterval * 2
+	assert.Nil(t, block1.Seal())
+	signBlock(block1)
+	assert.Nil(t, fork1.Seal())
+	signBlock(fork1)
+	assert.Nil(t, bc.BlockPool().Push(block1))
+
+	block2, err := bc.NewBlock(coinbase2)
+	assert.Nil(t, err)
+	block2.header.timestamp = BlockInterval * 3
+	assert.Nil(t, block2.Seal())
+	signBlock(block2)
+	assert.Nil(t, bc.BlockPool().Push(block2))
+	assert.Nil(t, bc.BlockPool().Push(fork1))
+	assert.Equal(t, block2.Hash(), bc.TailBlock().Hash())
+
+	// the votes must be signed by a validator of a known block.
+	signer := mockAddress()
+	vote := mockFinalityVote(t, bc, signer, FinalityPrevote, block1)
+	vote.step = 3
+	_, err = bc.finality.addVote(vote)
+	assert.Equal(t, ErrInvalidFinalityVote, err)
+	vote = mockFinalityVote(t, bc, signer, FinalityPrevote, block1)
+	vote.height = block2.Height()
+	_, err = bc.finality.addVote(vote)
+	assert.Equal(t, ErrFinalityBlockNotFound, err)
+	_, err = bc.finality.addVote(mockFinalityVote(t, bc, signer, FinalityPrevote, block1))
+	assert.Equal(t, ErrInvalidFinalitySigner, err)
+
+	// the mock dynasty is empty, a single vote is a quorum.
+	vote = &FinalityVote{step: FinalityPrecommit, height: block1.Height(), blockHash: block1.Hash()}
+	added, quorum, err := bc.finality.recordVote(vote, block1, coinbase1)
+	assert.Nil(t, err)
+	assert.True(t, added)
+	assert.True(t, quorum)
+	added, quorum, err = bc.finality.recordVote(vote, block1, coinbase1)
+	assert.Nil(t, err)
+	assert.False(t, added)
+	assert.False(t, quorum)
+	added, quorum, err = bc.finality.recordVote(vote, block1, coinbase2)
+	assert.Nil(t, err)
+	assert.True(t, added)
+	assert.False(t, quorum)
+
+	// a block off the canonical chain is not finalized.
+	assert.Nil(t, bc.finality.finalize(fork1))
+	assert.Equal(t, bc.GenesisBlock().Hash(), bc.LIB().Hash())
+
+	assert.Nil(t, bc.finality.finalize(block1))
+	assert.Equal(t, block1.Hash(), bc.LIB().Hash())
+	assert.Equal(t, 0, len(bc.finality.votes[FinalityPrecommit]))
+
+	// the votes at and below the irreversible block are out of date.
+	added, _, err = bc.finality.recordVote(vote, block1, mockAddress())
+	assert.Nil(t, err)
+	assert.False(t, added)
+}
@@ -0,0 +1,246 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"github.com/nebulasio/go-nebulas/neblet/pb"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// storage: key -> value
+// freezer_base -> height of the block in the first freezer item
+// freezer_items -> number of the freezer items the blocks are moved into
+// freezer_ + block hash -> height of the block moved into the freezer
+
+// Freezer keys in storage
+const (
+	FreezerBase        = "freezer_base"
+	FreezerItems       = "freezer_items"
+	FrozenBlockPrefix  = "freezer_"
+	FreezerDirSuffix   = ".ancient"
+	FreezeBlocksAtOnce = 10000
+)
+
+func frozenBlockKey(hash byteutils.Hash) []byte {
+	return append([]byte(FrozenBlockPrefix), hash...)
+}
+
+// freezerDir returns the directory of the freezer files in the config.
+func (bc *BlockChain) freezerDir(conf *nebletpb.ChainConfig) string {
+	if len(conf.FreezerDir) > 0 {
+		return conf.FreezerDir
+	}
+	return conf.Datadir + FreezerDirSuffix
+}
+
+// setupFreezer opens the freezer if the threshold is set.
+func (bc *BlockChain) setupFreezer(threshold uint64, dir string) error {
+	if threshold == 0 {
+		return nil
+	}
+	open := storage.NewFreezer
+	if bc.readOnly {
+		open = storage.NewFreezerReadOnly
+	}
+	freezer, err := open(dir)
+	if err != nil {
+		return err
+	}
+	if !bc.readOnly {
+		if err := bc.reconcileFreezer(freezer); err != nil {
+			freezer.Close()
+			return err
+		}
+	}
+	bc.freezer = freezer
+	bc.freezerThreshold = threshold
+
+	logging.CLog().WithFields(logrus.Fields{
+		"dir":       dir,
+		"threshold": threshold,
+		"frozen":    freezer.Items(),
+	}).Info("Block freezer is enabled.")
+	return nil
+}
+
+// freezerBase returns the height of the block in the first freezer item.
+func (bc *BlockChain) freezerBase() (uint64, error) {
+	value, err := bc.headerStorage.Get([]byte(FreezerBase))
+	if err == nil {
+		return byteutils.Uint64(value), nil
+	}
+	if err != storage.ErrKeyNotFound {
+		return 0, err
+	}
+	// the genesis stays in the key-value store, so do the blocks below the snapshot the chain is started from.
+	base := bc.genesisBlock.height + 1
+	if snapshot, ok := bc.SnapshotHeight(); ok && snapshot > base {
+		base = snapshot
+	}
+	return base, nil
+}
+
+// frozenItems returns the number of the freezer items recorded in the same
+// batch as the blocks moved into them.
+func (bc *BlockChain) frozenItems() (uint64, bool, error) {
+	value, err := bc.headerStorage.Get([]byte(FreezerItems))
+	if err == storage.ErrKeyNotFound {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return byteutils.Uint64(value), true, nil
+}
+
+// reconcileFreezer drops the freezer items appended by a round whose blocks
+// were never recorded as frozen, e.g. the node crashed before the commit.
+func (bc *BlockChain) reconcileFreezer(freezer *storage.Freezer) error {
+	items, ok, err := bc.frozenItems()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		// nothing is frozen yet, or the freezer predates the record.
+		if _, err := bc.headerStorage.Get([]byte(FreezerBase)); err == nil {
+			return nil
+		} else if err != storage.ErrKeyNotFound {
+			return err
+		}
+	}
+	if freezer.Items() < items {
+		return ErrMissingFrozenBlocks
+	}
+	if freezer.Items() > items {
+		logging.CLog().WithFields(logrus.Fields{
+			"items":  freezer.Items(),
+			"frozen": items,
+		}).Warn("Dropped the freezer items not recorded as frozen.")
+	}
+	return freezer.Truncate(items)
+}
+
+// FreezeAncientBlocks moves the irreversible blocks older than the threshold
+// below the tail from the key-value store into the freezer.
+func (bc *BlockChain) FreezeAncientBlocks() error {
+	if bc.freezer == nil || bc.tailBlock.height <= bc.freezerThreshold {
+		return nil
+	}
+	limit := bc.tailBlock.height - bc.freezerThreshold
+	if lib := bc.lib.height; lib < limit {
+		limit = lib
+	}
+
+	base, err := bc.freezerBase()
+	if err != nil {
+		return err
+	}
+	from := base + bc.freezer.Items()
+	if from > limit {
+		return nil
+	}
+	if limit-from >= FreezeBlocksAtOnce {
+		limit = from + FreezeBlocksAtOnce - 1
+	}
+
+	// the items appended are dropped unless the blocks are recorded as frozen.
+	items := bc.freezer.Items()
+	committed := false
+	defer func() {
+		if committed {
+			return
+		}
+		if err := bc.freezer.Truncate(items); err != nil {
+			logging.VLog().WithFields(logrus.Fields{
+				"items": items,
+				"err":   err,
+			}).Error("Failed to drop the freezer items not recorded as frozen.")
+		}
+	}()
+
+	hashes := []byteutils.Hash{}
+	for h := from; h <= limit; h++ {
+		hash, err := bc.headerStorage.Get(byteutils.FromUint64(h))
+		if err != nil {
+			return err
+		}
+		value, err := bc.bodyStorage.Get(hash)
+		if err != nil {
+			return err
+		}
+		if err := bc.freezer.Append(h-base, value); err != nil {
+			return err
+		}
+		hashes = append(hashes, hash)
+	}
+	// the blocks are only dropped from the key-value store once on disk.
+	if err := bc.freezer.Sync(); err != nil {
+		return err
+	}
+
+	bc.journal.Begin()
+	if err := bc.headerStorage.Put([]byte(FreezerBase), byteutils.FromUint64(base)); err != nil {
+		bc.journal.Rollback()
+		return err
+	}
+	if err := bc.headerStorage.Put([]byte(FreezerItems), byteutils.FromUint64(bc.freezer.Items())); err != nil {
+		bc.journal.Rollback()
+		return err
+	}
+	for i, hash := range hashes {
+		if err := bc.headerStorage.Put(frozenBlockKey(hash), byteutils.FromUint64(from+uint64(i))); err != nil {
+			bc.journal.Rollback()
+			return err
+		}
+		if err := bc.bodyStorage.Del(hash); err != nil {
+			bc.journal.Rollback()
+			return err
+		}
+	}
+	if err := bc.journal.Commit(); err != nil {
+		return err
+	}
+	committed = true
+
+	metricsBlockFrozen.Inc(int64(len(hashes)))
+	logging.VLog().WithFields(logrus.Fields{
+		"from": from,
+		"to":   limit,
+	}).Info("Moved ancient blocks into the freezer.")
+	return nil
+}
+
+// loadFrozenBlock returns the encoded block moved into the freezer.
+func (bc *BlockChain) loadFrozenBlock(hash byteutils.Hash) ([]byte, error) {
+	if bc.freezer == nil {
+		return nil, storage.ErrKeyNotFound
+	}
+	value, err := bc.headerStorage.Get(frozenBlockKey(hash))
+	if err != nil {
+		return nil, err
+	}
+	base, err := bc.freezerBase()
+	if err != nil {
+		return nil, err
+	}
+	return bc.freezer.Retrieve(byteutils.Uint64(value) - base)
+}
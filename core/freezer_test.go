@@ -0,0 +1,84 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFreezeAncientBlocks(t *testing.T) {
+	neb := testNeb(t)
+	bc := neb.chain
+
+	dir, err := ioutil.TempDir("", "ancient")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	assert.Nil(t, bc.setupFreezer(2, dir))
+	defer func() { bc.freezer.Close() }()
+
+	blocks := []*Block{}
+	for i := 1; i <= 5; i++ {
+		addr, err := AddressParse(MockDynasty[i])
+		assert.Nil(t, err)
+		block, err := NewBlock(bc.ChainID(), addr, bc.tailBlock)
+		assert.Nil(t, err)
+		block.header.timestamp = bc.tailBlock.header.timestamp + BlockInterval
+		assert.Nil(t, block.Seal())
+		signBlock(block)
+		assert.Nil(t, bc.bkPool.Push(block))
+		blocks = append(blocks, block)
+	}
+
+	// only irreversible blocks are frozen.
+	assert.Nil(t, bc.FreezeAncientBlocks())
+	assert.Equal(t, uint64(0), bc.freezer.Items())
+
+	bc.lib = bc.tailBlock
+	assert.Nil(t, bc.FreezeAncientBlocks())
+	frozen := bc.tailBlock.height - 2 - bc.genesisBlock.height
+	assert.Equal(t, frozen, bc.freezer.Items())
+
+	bc.cachedBlocks.Purge()
+	for i, block := range blocks {
+		_, err := bc.bodyStorage.Get(block.Hash())
+		if uint64(i) < frozen {
+			assert.Equal(t, storage.ErrKeyNotFound, err)
+		} else {
+			assert.Nil(t, err)
+		}
+		loaded := bc.GetBlockOnCanonicalChainByHeight(block.height)
+		assert.NotNil(t, loaded)
+		assert.Equal(t, block.Hash(), loaded.Hash())
+	}
+
+	// nothing more to freeze.
+	assert.Nil(t, bc.FreezeAncientBlocks())
+	assert.Equal(t, frozen, bc.freezer.Items())
+
+	// the items appended but never recorded as frozen are dropped on startup.
+	assert.Nil(t, bc.freezer.Append(frozen, []byte("orphan")))
+	assert.Nil(t, bc.freezer.Close())
+	assert.Nil(t, bc.setupFreezer(2, dir))
+	assert.Equal(t, frozen, bc.freezer.Items())
+}
@@ -0,0 +1,130 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+)
+
+const (
+	// GasPriceOracleBlocks is the number of recent blocks the gas price oracle samples
+	GasPriceOracleBlocks = 20
+
+	// percentiles of the sampled block prices for each suggestion
+	gasPriceSafeLowPercentile  = 30
+	gasPriceStandardPercentile = 60
+	gasPriceFastPercentile     = 90
+)
+
+// GasPriceSuggestion the suggested gas prices to get a tx packed soon.
+type GasPriceSuggestion struct {
+	// SafeLow is likely to be packed within a few blocks
+	SafeLow *util.Uint128
+	// Standard is likely to be packed in the next blocks
+	Standard *util.Uint128
+	// Fast is very likely to be packed in the next block
+	Fast *util.Uint128
+}
+
+// GasPriceOracle suggests gas prices from the txs in the recent blocks.
+type GasPriceOracle struct {
+	chain  *BlockChain
+	blocks int
+
+	mu       sync.Mutex
+	lastTail byteutils.Hash
+	last     *GasPriceSuggestion
+}
+
+// NewGasPriceOracle create a gas price oracle sampling the last blocks of the chain.
+func NewGasPriceOracle(chain *BlockChain, blocks int) *GasPriceOracle {
+	if blocks <= 0 {
+		blocks = GasPriceOracleBlocks
+	}
+	return &GasPriceOracle{
+		chain:  chain,
+		blocks: blocks,
+	}
+}
+
+// Suggest return the suggested gas prices at the current tail, the result is cached until the tail changes.
+func (o *GasPriceOracle) Suggest() *GasPriceSuggestion {
+	tail := o.chain.TailBlock()
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.last != nil && o.lastTail.Equals(tail.Hash()) {
+		return o.last
+	}
+	o.last = o.suggest(tail)
+	o.lastTail = tail.Hash()
+	return o.last
+}
+
+// suggest sample the lowest price packed in each recent block, an empty block means
+// every price above the floor was acceptable.
+func (o *GasPriceOracle) suggest(tail *Block) *GasPriceSuggestion {
+	floor := o.chain.txPool.minGasPrice
+
+	prices := make([]*util.Uint128, 0, o.blocks)
+	block := tail
+	for i := 0; i < o.blocks && block != nil && !CheckGenesisBlock(block); i++ {
+		prices = append(prices, blockMinGasPrice(block, floor))
+		block = o.chain.GetBlock(block.ParentHash())
+	}
+	if len(prices) == 0 {
+		return &GasPriceSuggestion{SafeLow: floor, Standard: floor, Fast: floor}
+	}
+
+	sort.Slice(prices, func(i, j int) bool {
+		return prices[i].Cmp(prices[j]) < 0
+	})
+	return &GasPriceSuggestion{
+		SafeLow:  gasPricePercentile(prices, gasPriceSafeLowPercentile, floor),
+		Standard: gasPricePercentile(prices, gasPriceStandardPercentile, floor),
+		Fast:     gasPricePercentile(prices, gasPriceFastPercentile, floor),
+	}
+}
+
+// blockMinGasPrice return the lowest gas price of the txs in block, or floor if it's empty.
+func blockMinGasPrice(block *Block, floor *util.Uint128) *util.Uint128 {
+	if len(block.transactions) == 0 {
+		return floor
+	}
+	price := block.transactions[0].gasPrice
+	for _, tx := range block.transactions[1:] {
+		if tx.gasPrice.Cmp(price) < 0 {
+			price = tx.gasPrice
+		}
+	}
+	return price
+}
+
+// gasPricePercentile return the percentile of the sorted prices, never below floor.
+func gasPricePercentile(prices []*util.Uint128, percentile int, floor *util.Uint128) *util.Uint128 {
+	price := prices[(len(prices)-1)*percentile/100]
+	if price.Cmp(floor) < 0 {
+		return floor
+	}
+	return price
+}
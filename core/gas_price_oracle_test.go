@@ -0,0 +1,83 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGasPriceOracle_Suggest(t *testing.T) {
+	neb := testNeb(t)
+	bc := neb.chain
+	oracle := bc.GasPriceOracle()
+
+	// no blocks but genesis, suggest the floor.
+	suggestion := oracle.Suggest()
+	assert.Equal(t, TransactionGasPrice, suggestion.SafeLow)
+	assert.Equal(t, TransactionGasPrice, suggestion.Standard)
+	assert.Equal(t, TransactionGasPrice, suggestion.Fast)
+
+	from := mockAddress()
+	gasLimit, _ := util.NewUint128FromInt(200000)
+	parent := bc.GenesisBlock()
+	for i := int64(1); i <= 10; i++ {
+		var txs Transactions
+		// the 10th block is empty
+		if i < 10 {
+			for j := int64(0); j < 3; j++ {
+				price, _ := util.NewUint128FromInt(1000000 * (i + j))
+				tx, _ := NewTransaction(bc.ChainID(), from, from, util.NewUint128(), uint64(j+1), TxPayloadBinaryType, []byte("nas"), price, gasLimit)
+				txs = append(txs, tx)
+			}
+		}
+		block := &Block{
+			header: &BlockHeader{
+				hash:       byteutils.FromInt64(i),
+				parentHash: parent.Hash(),
+			},
+			height:       parent.height + 1,
+			transactions: txs,
+		}
+		bc.cachedBlocks.Add(block.Hash().Hex(), block)
+		parent = block
+	}
+
+	// block min prices sorted: 1(empty block floor),1,2,...,9 (* 10^6)
+	suggestion = oracle.suggest(parent)
+	safeLow, _ := util.NewUint128FromInt(2000000)
+	standard, _ := util.NewUint128FromInt(5000000)
+	fast, _ := util.NewUint128FromInt(8000000)
+	assert.Equal(t, safeLow, suggestion.SafeLow)
+	assert.Equal(t, standard, suggestion.Standard)
+	assert.Equal(t, fast, suggestion.Fast)
+
+	// only the last blocks are sampled, the prices sorted: 1,9 (* 10^6)
+	suggestion = NewGasPriceOracle(bc, 2).suggest(parent)
+	assert.Equal(t, TransactionGasPrice, suggestion.SafeLow)
+	assert.Equal(t, TransactionGasPrice, suggestion.Fast)
+
+	// the cached suggestion is refreshed on the new tail.
+	assert.Equal(t, TransactionGasPrice, oracle.Suggest().Fast)
+	bc.tailBlock = parent
+	assert.Equal(t, fast, oracle.Suggest().Fast)
+}
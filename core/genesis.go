@@ -21,10 +21,13 @@ package core
 import (
 	"fmt"
 	"io/ioutil"
+	"path/filepath"
+	"strings"
 
 	"github.com/nebulasio/go-nebulas/crypto/keystore"
 
 	"github.com/gogo/protobuf/proto"
+	"github.com/golang/protobuf/jsonpb"
 	"github.com/nebulasio/go-nebulas/common/dag"
 	"github.com/nebulasio/go-nebulas/consensus/pb"
 	"github.com/nebulasio/go-nebulas/core/pb"
@@ -41,7 +44,7 @@ var (
 	GenesisCoinbase, _ = NewAddressFromPublicKey(make([]byte, PublicKeyDataLength))
 )
 
-// LoadGenesisConf load genesis conf for file
+// LoadGenesisConf load genesis conf for file, in json if the file ends with .json, otherwise in proto text.
 func LoadGenesisConf(filePath string) (*corepb.Genesis, error) {
 	b, err := ioutil.ReadFile(filePath)
 	if err != nil {
@@ -53,22 +56,70 @@ func LoadGenesisConf(filePath string) (*corepb.Genesis, error) {
 	content := string(b)
 
 	genesis := new(corepb.Genesis)
-	if err := proto.UnmarshalText(content, genesis); err != nil {
+	if strings.ToLower(filepath.Ext(filePath)) == ".json" {
+		err = jsonpb.UnmarshalString(content, genesis)
+	} else {
+		err = proto.UnmarshalText(content, genesis)
+	}
+	if err != nil {
 		logging.CLog().WithFields(logrus.Fields{
 			"err": err,
 		}).Error("Failed to parse genesis file.")
 		return nil, err
 	}
+
+	if err := CheckGenesisConf(genesis); err != nil {
+		logging.CLog().WithFields(logrus.Fields{
+			"err": err,
+		}).Error("Found invalid genesis config.")
+		return nil, err
+	}
 	return genesis, nil
 }
 
+// CheckGenesisConf check the genesis conf is complete and well-formed
+func CheckGenesisConf(genesis *corepb.Genesis) error {
+	if genesis == nil || genesis.Meta == nil || genesis.Consensus == nil {
+		return ErrInvalidGenesisConf
+	}
+	if genesis.Consensus.Dpos == nil && genesis.Consensus.Poa == nil {
+		return ErrInvalidGenesisConf
+	}
+	if genesis.Meta.ChainId == 0 {
+		return ErrInvalidGenesisConf
+	}
+	for _, v := range GenesisValidators(genesis) {
+		if _, err := AddressParse(v); err != nil {
+			return err
+		}
+	}
+	for _, v := range genesis.TokenDistribution {
+		if _, err := AddressParse(v.Address); err != nil {
+			return err
+		}
+		if _, err := util.NewUint128FromString(v.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GenesisValidators return the initial validators in the genesis conf, the
+// signers of a poa chain or the dynasty of a dpos chain.
+func GenesisValidators(genesis *corepb.Genesis) []string {
+	if poa := genesis.GetConsensus().GetPoa(); poa != nil {
+		return poa.Signers
+	}
+	return genesis.GetConsensus().GetDpos().GetDynasty()
+}
+
 // NewGenesisBlock create genesis @Block from file.
 func NewGenesisBlock(conf *corepb.Genesis, chain *BlockChain) (*Block, error) {
 	if conf == nil || chain == nil {
 		return nil, ErrNilArgument
 	}
 
-	worldState, err := state.NewWorldState(chain.ConsensusHandler(), chain.storage)
+	worldState, err := state.NewWorldState(chain.ConsensusHandler(), chain.stateStorage)
 	if err != nil {
 		return nil, err
 	}
@@ -89,6 +140,7 @@ func NewGenesisBlock(conf *corepb.Genesis, chain *BlockChain) (*Block, error) {
 		storage:      chain.storage,
 		eventEmitter: chain.eventEmitter,
 		nvm:          chain.nvm,
+		tracer:       chain.tracer,
 		height:       1,
 		sealed:       false,
 	}
@@ -270,7 +322,8 @@ func CheckGenesisConfByDB(pGenesisDB *corepb.Genesis, pGenesis *corepb.Genesis)
 			return ErrGenesisNotEqualChainIDInDB
 		}
 
-		if len(pGenesis.Consensus.Dpos.Dynasty) != len(pGenesisDB.Consensus.Dpos.Dynasty) {
+		// the validators in storage are dumped as a dpos dynasty, whatever the consensus.
+		if len(GenesisValidators(pGenesis)) != len(pGenesisDB.Consensus.Dpos.Dynasty) {
 			return ErrGenesisNotEqualDynastyLenInDB
 		}
 
@@ -279,7 +332,7 @@ func CheckGenesisConfByDB(pGenesisDB *corepb.Genesis, pGenesis *corepb.Genesis)
 		}
 
 		// check dpos equal
-		for _, confDposAddr := range pGenesis.Consensus.Dpos.Dynasty {
+		for _, confDposAddr := range GenesisValidators(pGenesis) {
 			contains := false
 			for _, dposAddr := range pGenesisDB.Consensus.Dpos.Dynasty {
 				if dposAddr == confDposAddr {
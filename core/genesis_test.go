@@ -19,6 +19,9 @@
 package core
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -50,3 +53,41 @@ func TestInvalidAddressInTokenDistribution(t *testing.T) {
 	_, err := NewGenesisBlock(mockConf, chain)
 	assert.Equal(t, err, ErrInvalidAddressFormat)
 }
+
+func TestLoadGenesisConfInJSON(t *testing.T) {
+	dir, err := ioutil.TempDir("", "genesis")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	conf := MockGenesisConf()
+	content := `{
+		"meta": {"chain_id": 100},
+		"consensus": {"dpos": {"dynasty": ["` + conf.Consensus.Dpos.Dynasty[0] + `"], "jail_dynasties": "3"}},
+		"token_distribution": [{"address": "` + conf.TokenDistribution[0].Address + `", "value": "10000"}]
+	}`
+	path := filepath.Join(dir, "genesis.json")
+	assert.Nil(t, ioutil.WriteFile(path, []byte(content), 0644))
+
+	genesis, err := LoadGenesisConf(path)
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(100), genesis.Meta.ChainId)
+	assert.Equal(t, uint64(3), genesis.Consensus.Dpos.JailDynasties)
+	assert.Equal(t, conf.TokenDistribution[0].Address, genesis.TokenDistribution[0].Address)
+
+	// chain_id is required.
+	assert.Nil(t, ioutil.WriteFile(path, []byte(`{"consensus": {"dpos": {}}}`), 0644))
+	_, err = LoadGenesisConf(path)
+	assert.Equal(t, ErrInvalidGenesisConf, err)
+}
+
+func TestCheckGenesisConf(t *testing.T) {
+	conf := MockGenesisConf()
+	assert.Nil(t, CheckGenesisConf(conf))
+
+	conf.TokenDistribution[0].Value = "-1"
+	assert.NotNil(t, CheckGenesisConf(conf))
+
+	conf = MockGenesisConf()
+	conf.Consensus = nil
+	assert.Equal(t, ErrInvalidGenesisConf, CheckGenesisConf(conf))
+}
@@ -0,0 +1,149 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"github.com/nebulasio/go-nebulas/core/state"
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+)
+
+// An account rotates its controlling key by a rotatekey tx signed by its
+// current key, carrying the new public key and a proof signed by the new
+// key. The address of the account and its balance stay, the txs from it are
+// signed by the active key since then and the previous keys are retired.
+// Their sign is the signature of the active key followed by
+// RotatedSignatureFlag, so the signer is checked against the account state
+// rather than the address. The storage of the rotated account:
+// active_key -> the address of the active key, absent for the original key
+
+// Key rotation keys in account storage
+const (
+	ActiveKeyKey = "active_key"
+)
+
+const (
+	// RotatedSignatureLength the length of the sign of a tx signed by a rotated key
+	RotatedSignatureLength = 66
+
+	// RotatedSignatureFlag the last byte of the sign of a tx signed by a rotated key
+	RotatedSignatureFlag = 0x01
+)
+
+// ActiveKey return the address of the active key of the account, nil if the
+// account is controlled by its original key.
+func ActiveKey(acc state.Account) (*Address, error) {
+	value, err := acc.Get([]byte(ActiveKeyKey))
+	if err == storage.ErrKeyNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return AddressParseFromBytes(value)
+}
+
+// SetActiveKey set the address of the active key of the account, the
+// original key of the account is restored by its own address.
+func SetActiveKey(acc state.Account, key *Address) error {
+	if key.address.Equals(acc.Address()) {
+		active, err := ActiveKey(acc)
+		if err != nil || active == nil {
+			return err
+		}
+		return acc.Del([]byte(ActiveKeyKey))
+	}
+	return acc.Put([]byte(ActiveKeyKey), key.Bytes())
+}
+
+// RotateKeyProofHash the hash the new key signs to prove the account rotating
+// to it controls the key.
+func RotateKeyProofHash(from *Address, pub []byte) byteutils.Hash {
+	return hash.Sha3256(from.Bytes(), pub)
+}
+
+// SignRotated sign the tx by the active key of a rotated account.
+func (tx *Transaction) SignRotated(signature keystore.Signature) error {
+	if signature == nil {
+		return ErrNilArgument
+	}
+	if signature.Algorithm() != keystore.SECP256K1 {
+		return ErrInvalidSignatureAlg
+	}
+	hash, err := tx.calHash()
+	if err != nil {
+		return err
+	}
+	sign, err := signature.Sign(hash)
+	if err != nil {
+		return err
+	}
+	tx.hash = hash
+	tx.alg = keystore.SECP256K1
+	tx.sign = append(sign, RotatedSignatureFlag)
+	return nil
+}
+
+// isRotatedSign return if the tx is signed by a rotated key.
+func (tx *Transaction) isRotatedSign() bool {
+	return len(tx.sign) == RotatedSignatureLength && tx.sign[RotatedSignatureLength-1] == RotatedSignatureFlag
+}
+
+// rotatedSigner return the signer recovered from the sign of a rotated key,
+// which needs no state.
+func (tx *Transaction) rotatedSigner() (*Address, error) {
+	if tx.alg != keystore.SECP256K1 || tx.from.Type() != AccountAddress {
+		return nil, ErrInvalidSignatureAlg
+	}
+	return RecoverSignerFromSignature(tx.alg, tx.hash, tx.sign[:RotatedSignatureLength-1])
+}
+
+// signedByFrom return if the signer of the tx is checked against its from
+// address without state.
+func (tx *Transaction) signedByFrom() bool {
+	return tx.from.Type() != MultisigAddress && !tx.isRotatedSign()
+}
+
+// verifyActiveKey check the tx is signed by the active key of the account,
+// the retired keys can't sign.
+func (tx *Transaction) verifyActiveKey(acc state.Account) error {
+	active, err := ActiveKey(acc)
+	if err != nil {
+		return err
+	}
+	if !tx.isRotatedSign() {
+		if active != nil {
+			return ErrRetiredKey
+		}
+		return nil
+	}
+	if active == nil {
+		return ErrInvalidTransactionSigner
+	}
+	signer, err := tx.rotatedSigner()
+	if err != nil {
+		return err
+	}
+	if !signer.Equals(active) {
+		return ErrInvalidTransactionSigner
+	}
+	return nil
+}
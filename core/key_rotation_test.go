@@ -0,0 +1,118 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func mockRotateKeyPayload(t *testing.T, from, key *Address) *RotateKeyPayload {
+	priv, err := keystore.DefaultKS.GetUnlocked(key.String())
+	assert.Nil(t, err)
+	pub, err := priv.(keystore.PrivateKey).PublicKey().Encoded()
+	assert.Nil(t, err)
+	proof, err := mockSignature(t, key).Sign(RotateKeyProofHash(from, pub))
+	assert.Nil(t, err)
+	payload, err := NewRotateKeyPayload(pub, proof)
+	assert.Nil(t, err)
+	return payload
+}
+
+func TestRotateKeyPayload(t *testing.T) {
+	from, key := mockAddress(), mockAddress()
+	_, err := NewRotateKeyPayload([]byte{1, 2, 3}, make([]byte, 65))
+	assert.Equal(t, ErrInvalidKeyRotationPublicKey, err)
+
+	payload := mockRotateKeyPayload(t, from, key)
+	_, err = NewRotateKeyPayload(payload.PublicKey, payload.Proof[:64])
+	assert.Equal(t, ErrInvalidKeyRotationProof, err)
+
+	data, err := payload.ToBytes()
+	assert.Nil(t, err)
+	loaded, err := LoadRotateKeyPayload(data)
+	assert.Nil(t, err)
+	assert.Equal(t, payload, loaded)
+}
+
+func TestRotateKey_Execute(t *testing.T) {
+	neb := testNeb(t)
+	block := neb.chain.tailBlock
+	assert.Nil(t, block.Begin())
+	ws := block.WorldState()
+
+	from, key, other := mockAddress(), mockAddress(), mockAddress()
+
+	// the proof must be signed by the new key for the sender.
+	forged := mockRotateKeyPayload(t, other, key)
+	data, err := forged.ToBytes()
+	assert.Nil(t, err)
+	tx, err := NewTransaction(neb.chain.ChainID(), from, from, util.NewUint128(), 1, TxPayloadRotateKeyType, data, TransactionGasPrice, TransactionMaxGas)
+	assert.Nil(t, err)
+	_, _, err = forged.Execute(util.NewUint128(), tx, block, ws)
+	assert.Equal(t, ErrInvalidKeyRotationProof, err)
+
+	payload := mockRotateKeyPayload(t, from, key)
+	data, err = payload.ToBytes()
+	assert.Nil(t, err)
+	tx, err = NewTransaction(neb.chain.ChainID(), from, other, util.NewUint128(), 1, TxPayloadRotateKeyType, data, TransactionGasPrice, TransactionMaxGas)
+	assert.Nil(t, err)
+	_, _, err = payload.Execute(util.NewUint128(), tx, block, ws)
+	assert.Equal(t, ErrKeyRotationAddressNotEqual, err)
+
+	tx, err = NewTransaction(neb.chain.ChainID(), from, from, util.NewUint128(), 1, TxPayloadRotateKeyType, data, TransactionGasPrice, TransactionMaxGas)
+	assert.Nil(t, err)
+	_, result, err := payload.Execute(util.NewUint128(), tx, block, ws)
+	assert.Nil(t, err)
+	assert.Equal(t, key.String(), result)
+
+	acc, err := ws.GetOrCreateUserAccount(from.Bytes())
+	assert.Nil(t, err)
+	active, err := ActiveKey(acc)
+	assert.Nil(t, err)
+	assert.Equal(t, key, active)
+
+	spend, err := NewTransaction(neb.chain.ChainID(), from, other, util.NewUint128FromUint(1), 2, TxPayloadBinaryType, nil, TransactionGasPrice, TransactionMaxGas)
+	assert.Nil(t, err)
+
+	// the original key is retired.
+	assert.Nil(t, spend.Sign(mockSignature(t, from)))
+	assert.Nil(t, spend.VerifyIntegrity(neb.chain.ChainID()))
+	assert.Equal(t, ErrRetiredKey, spend.verifyActiveKey(acc))
+
+	assert.Nil(t, spend.SignRotated(mockSignature(t, key)))
+	assert.Nil(t, spend.VerifyIntegrity(neb.chain.ChainID()))
+	assert.Nil(t, spend.verifyActiveKey(acc))
+
+	assert.Nil(t, spend.SignRotated(mockSignature(t, other)))
+	assert.Equal(t, ErrInvalidTransactionSigner, spend.verifyActiveKey(acc))
+
+	// rotating back to the address restores the original key.
+	assert.Nil(t, SetActiveKey(acc, from))
+	active, err = ActiveKey(acc)
+	assert.Nil(t, err)
+	assert.Nil(t, active)
+	assert.Nil(t, spend.Sign(mockSignature(t, from)))
+	assert.Nil(t, spend.verifyActiveKey(acc))
+	assert.Nil(t, spend.SignRotated(mockSignature(t, key)))
+	assert.Equal(t, ErrInvalidTransactionSigner, spend.verifyActiveKey(acc))
+}
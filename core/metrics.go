@@ -40,14 +40,21 @@ var (
 	metricsLruCacheBlock       = metrics.NewGauge("neb.block.lru.blocks")
 	metricsLruTailBlock        = metrics.NewGauge("neb.block.lru.tailblock")
 
-	metricsDuplicatedBlock   = metrics.NewCounter("neb.block.duplicated")
-	metricsInvalidBlock      = metrics.NewCounter("neb.block.invalid")
-	metricsTxsInBlock        = metrics.NewGauge("neb.block.txs")
-	metricsBlockVerifiedTime = metrics.NewGauge("neb.block.executed")
-	metricsTxVerifiedTime    = metrics.NewGauge("neb.tx.executed")
-	metricsTxPackedCount     = metrics.NewGauge("neb.tx.packed")
-	metricsTxUnpackedCount   = metrics.NewGauge("neb.tx.unpacked")
-	metricsTxGivebackCount   = metrics.NewGauge("neb.tx.giveback")
+	metricsDuplicatedBlock         = metrics.NewCounter("neb.block.duplicated")
+	metricsExpiredOrphanBlock      = metrics.NewCounter("neb.block.orphan.expired")
+	metricsInvalidBlock            = metrics.NewCounter("neb.block.invalid")
+	metricsTxsInBlock              = metrics.NewGauge("neb.block.txs")
+	metricsBlockVerifiedTime       = metrics.NewGauge("neb.block.executed")
+	metricsTxVerifiedTime          = metrics.NewGauge("neb.tx.executed")
+	metricsTxIntegrityVerifiedTime = metrics.NewGauge("neb.tx.integrity.verified")
+	metricsTxPackedCount           = metrics.NewGauge("neb.tx.packed")
+	metricsTxUnpackedCount         = metrics.NewGauge("neb.tx.unpacked")
+	metricsTxGivebackCount         = metrics.NewGauge("neb.tx.giveback")
+	metricsTxReinjected            = metrics.NewCounter("neb.tx.reinjected")
+	metricsStatePrunedNodes        = metrics.NewCounter("neb.state.pruned")
+	metricsStateGCNodes            = metrics.NewCounter("neb.state.gc.nodes")
+	metricsStateGCBytes            = metrics.NewCounter("neb.state.gc.bytes")
+	metricsBlockFrozen             = metrics.NewCounter("neb.block.frozen")
 
 	// txpool metrics
 	metricsReceivedTx                      = metrics.NewGauge("neb.txpool.received")
@@ -59,6 +66,11 @@ var (
 	metricsTxPoolBelowGasPrice             = metrics.NewCounter("neb.txpool.below_gas_price")
 	metricsTxPoolOutOfGasLimit             = metrics.NewCounter("neb.txpool.out_of_gas_limit")
 	metricsTxPoolGasLimitLessOrEqualToZero = metrics.NewCounter("neb.txpool.gas_limit_less_equal_zero")
+	metricsTxPoolUnderpricedReplacement    = metrics.NewCounter("neb.txpool.underpriced_replacement")
+	metricsTxPoolAccountSlotsFull          = metrics.NewCounter("neb.txpool.account_slots_full")
+	metricsTxPoolQueueSlotsFull            = metrics.NewCounter("neb.txpool.queue_slots_full")
+	metricsTxPoolPromoted                  = metrics.NewCounter("neb.txpool.promoted")
+	metricsQueuedTx                        = metrics.NewGauge("neb.txpool.queued")
 
 	// transaction metrics
 	metricsTxSubmit     = metrics.NewMeter("neb.transaction.submit")
@@ -0,0 +1,167 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"github.com/nebulasio/go-nebulas/core/state"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+)
+
+// A multisig account is created by a multisig tx registering its owners and
+// threshold. The txs from a multisig account carry the secp256k1 signatures of
+// its owners concatenated in the sign, and are executed since enough owners
+// signed them. The storage of the multisig account:
+// multisig_owners -> the addresses of the owners concatenated
+// multisig_threshold -> the count of owners needed to spend
+
+// Multisig keys in account storage
+const (
+	MultisigOwnersKey    = "multisig_owners"
+	MultisigThresholdKey = "multisig_threshold"
+)
+
+const (
+	// MaxMultisigOwners the max count of owners of a multisig account
+	MaxMultisigOwners = 16
+
+	// MultisigSignatureLength the length of each owner's signature in the sign
+	MultisigSignatureLength = 65
+)
+
+// MultisigOwners return the owners and threshold of the multisig account.
+func MultisigOwners(acc state.Account) ([]*Address, uint32, error) {
+	value, err := acc.Get([]byte(MultisigOwnersKey))
+	if err != nil || len(value) == 0 || len(value)%AddressLength != 0 {
+		return nil, 0, ErrMultisigAccountNotFound
+	}
+	owners := make([]*Address, 0, len(value)/AddressLength)
+	for i := 0; i < len(value); i += AddressLength {
+		owner, err := AddressParseFromBytes(value[i : i+AddressLength])
+		if err != nil {
+			return nil, 0, err
+		}
+		owners = append(owners, owner)
+	}
+	threshold, err := acc.Get([]byte(MultisigThresholdKey))
+	if err != nil {
+		return nil, 0, ErrMultisigAccountNotFound
+	}
+	return owners, byteutils.Uint32(threshold), nil
+}
+
+// SetMultisigOwners register the owners and threshold of the multisig account.
+func SetMultisigOwners(acc state.Account, owners []*Address, threshold uint32) error {
+	value := make([]byte, 0, len(owners)*AddressLength)
+	for _, owner := range owners {
+		value = append(value, owner.Bytes()...)
+	}
+	if err := acc.Put([]byte(MultisigOwnersKey), value); err != nil {
+		return err
+	}
+	return acc.Put([]byte(MultisigThresholdKey), byteutils.FromUint32(threshold))
+}
+
+// SignMultisig sign the tx from a multisig account by its owners, the
+// signatures are concatenated in the order given.
+func (tx *Transaction) SignMultisig(signatures ...keystore.Signature) error {
+	if len(signatures) == 0 || len(signatures) > MaxMultisigOwners {
+		return ErrInvalidMultisigSignature
+	}
+	if tx.from.Type() != MultisigAddress {
+		return ErrMultisigAccountNotFound
+	}
+	hash, err := tx.calHash()
+	if err != nil {
+		return err
+	}
+	sign := make([]byte, 0, len(signatures)*MultisigSignatureLength)
+	for _, signature := range signatures {
+		if signature == nil {
+			return ErrNilArgument
+		}
+		if signature.Algorithm() != keystore.SECP256K1 {
+			return ErrInvalidSignatureAlg
+		}
+		s, err := signature.Sign(hash)
+		if err != nil {
+			return err
+		}
+		sign = append(sign, s...)
+	}
+	tx.hash = hash
+	tx.alg = keystore.SECP256K1
+	tx.sign = sign
+	return nil
+}
+
+// multisigSigners return the distinct signers recovered from the signatures
+// of a multisig tx, which need no state.
+func (tx *Transaction) multisigSigners() ([]*Address, error) {
+	if tx.alg != keystore.SECP256K1 {
+		return nil, ErrInvalidSignatureAlg
+	}
+	n := len(tx.sign) / MultisigSignatureLength
+	if n == 0 || n > MaxMultisigOwners || len(tx.sign)%MultisigSignatureLength != 0 {
+		return nil, ErrInvalidMultisigSignature
+	}
+
+	signers := make([]*Address, 0, n)
+	for i := 0; i < len(tx.sign); i += MultisigSignatureLength {
+		signer, err := RecoverSignerFromSignature(tx.alg, tx.hash, tx.sign[i:i+MultisigSignatureLength])
+		if err != nil {
+			return nil, err
+		}
+		if containsAddress(signers, signer) {
+			return nil, ErrInvalidMultisigSignature
+		}
+		signers = append(signers, signer)
+	}
+	return signers, nil
+}
+
+// verifyMultisig check the tx is signed by enough owners of the multisig account.
+func (tx *Transaction) verifyMultisig(acc state.Account) error {
+	owners, threshold, err := MultisigOwners(acc)
+	if err != nil {
+		return err
+	}
+	signers, err := tx.multisigSigners()
+	if err != nil {
+		return err
+	}
+	for _, signer := range signers {
+		if !containsAddress(owners, signer) {
+			return ErrInvalidTransactionSigner
+		}
+	}
+	if uint32(len(signers)) < threshold {
+		return ErrMultisigThresholdNotMet
+	}
+	return nil
+}
+
+func containsAddress(addrs []*Address, addr *Address) bool {
+	for _, a := range addrs {
+		if a.Equals(addr) {
+			return true
+		}
+	}
+	return false
+}
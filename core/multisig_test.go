@@ -0,0 +1,116 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/crypto"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func mockSignature(t *testing.T, addr *Address) keystore.Signature {
+	key, err := keystore.DefaultKS.GetUnlocked(addr.String())
+	assert.Nil(t, err)
+	signature, err := crypto.NewSignature(keystore.SECP256K1)
+	assert.Nil(t, err)
+	assert.Nil(t, signature.InitSign(key.(keystore.PrivateKey)))
+	return signature
+}
+
+func TestMultisigPayload(t *testing.T) {
+	a, b := mockAddress().String(), mockAddress().String()
+	_, err := NewMultisigPayload(nil, 1)
+	assert.Equal(t, ErrInvalidMultisigOwners, err)
+	_, err = NewMultisigPayload([]string{a, a}, 1)
+	assert.Equal(t, ErrInvalidMultisigOwners, err)
+	_, err = NewMultisigPayload([]string{a, b}, 3)
+	assert.Equal(t, ErrInvalidMultisigThreshold, err)
+	_, err = NewMultisigPayload([]string{a, b}, 0)
+	assert.Equal(t, ErrInvalidMultisigThreshold, err)
+
+	payload, err := NewMultisigPayload([]string{a, b}, 2)
+	assert.Nil(t, err)
+	data, err := payload.ToBytes()
+	assert.Nil(t, err)
+	loaded, err := LoadMultisigPayload(data)
+	assert.Nil(t, err)
+	assert.Equal(t, payload, loaded)
+}
+
+func TestMultisig_Execute(t *testing.T) {
+	neb := testNeb(t)
+	block := neb.chain.tailBlock
+	assert.Nil(t, block.Begin())
+	ws := block.WorldState()
+
+	creator := mockAddress()
+	owners := []*Address{mockAddress(), mockAddress(), mockAddress()}
+	payload, err := NewMultisigPayload([]string{owners[0].String(), owners[1].String(), owners[2].String()}, 2)
+	assert.Nil(t, err)
+	data, err := payload.ToBytes()
+	assert.Nil(t, err)
+	tx, err := NewTransaction(neb.chain.ChainID(), creator, creator, util.NewUint128(), 1, TxPayloadMultisigType, data, TransactionGasPrice, TransactionMaxGas)
+	assert.Nil(t, err)
+	_, result, err := payload.Execute(util.NewUint128(), tx, block, ws)
+	assert.Nil(t, err)
+
+	multisig, err := NewMultisigAddressFromData(creator.Bytes(), byteutils.FromUint64(1))
+	assert.Nil(t, err)
+	assert.Equal(t, multisig.String(), result)
+	parsed, err := AddressParse(result)
+	assert.Nil(t, err)
+	assert.Equal(t, MultisigAddress, parsed.Type())
+
+	_, _, err = payload.Execute(util.NewUint128(), tx, block, ws)
+	assert.Equal(t, ErrMultisigAccountExists, err)
+
+	acc, err := ws.GetOrCreateUserAccount(multisig.Bytes())
+	assert.Nil(t, err)
+	registered, threshold, err := MultisigOwners(acc)
+	assert.Nil(t, err)
+	assert.Equal(t, owners, registered)
+	assert.Equal(t, uint32(2), threshold)
+
+	spend, err := NewTransaction(neb.chain.ChainID(), multisig, mockAddress(), util.NewUint128FromUint(1), 1, TxPayloadBinaryType, nil, TransactionGasPrice, TransactionMaxGas)
+	assert.Nil(t, err)
+
+	assert.Nil(t, spend.SignMultisig(mockSignature(t, owners[0]), mockSignature(t, owners[2])))
+	assert.Nil(t, spend.VerifyIntegrity(neb.chain.ChainID()))
+	assert.Nil(t, spend.verifyMultisig(acc))
+
+	assert.Nil(t, spend.SignMultisig(mockSignature(t, owners[1])))
+	assert.Nil(t, spend.VerifyIntegrity(neb.chain.ChainID()))
+	assert.Equal(t, ErrMultisigThresholdNotMet, spend.verifyMultisig(acc))
+
+	assert.Nil(t, spend.SignMultisig(mockSignature(t, owners[1]), mockSignature(t, creator)))
+	assert.Equal(t, ErrInvalidTransactionSigner, spend.verifyMultisig(acc))
+
+	assert.Nil(t, spend.SignMultisig(mockSignature(t, owners[1]), mockSignature(t, owners[1])))
+	assert.Equal(t, ErrInvalidMultisigSignature, spend.VerifyIntegrity(neb.chain.ChainID()))
+
+	// a normal account is not multisig.
+	acc, err = ws.GetOrCreateUserAccount(creator.Bytes())
+	assert.Nil(t, err)
+	_, _, err = MultisigOwners(acc)
+	assert.Equal(t, ErrMultisigAccountNotFound, err)
+}
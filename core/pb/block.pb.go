@@ -18,6 +18,10 @@ It has these top-level messages:
 	NetBlock
 	DownloadBlock
 	Random
+	GetBlocks
+	Blocks
+	SignedHeader
+	DoubleSignEvidence
 */
 package corepb
 
@@ -95,7 +99,11 @@ func (m *Account) GetContractMeta() *ContractMeta {
 }
 
 type ContractMeta struct {
-	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Version     string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Admin       []byte `protobuf:"bytes,2,opt,name=admin,proto3" json:"admin,omitempty"`
+	CodeVersion uint64 `protobuf:"varint,3,opt,name=code_version,json=codeVersion,proto3" json:"code_version,omitempty"`
+	CodeTx      []byte `protobuf:"bytes,4,opt,name=code_tx,json=codeTx,proto3" json:"code_tx,omitempty"`
+	Abi         string `protobuf:"bytes,5,opt,name=abi,proto3" json:"abi,omitempty"`
 }
 
 func (m *ContractMeta) Reset()                    { *m = ContractMeta{} }
@@ -110,6 +118,34 @@ func (m *ContractMeta) GetVersion() string {
 	return ""
 }
 
+func (m *ContractMeta) GetAdmin() []byte {
+	if m != nil {
+		return m.Admin
+	}
+	return nil
+}
+
+func (m *ContractMeta) GetCodeVersion() uint64 {
+	if m != nil {
+		return m.CodeVersion
+	}
+	return 0
+}
+
+func (m *ContractMeta) GetCodeTx() []byte {
+	if m != nil {
+		return m.CodeTx
+	}
+	return nil
+}
+
+func (m *ContractMeta) GetAbi() string {
+	if m != nil {
+		return m.Abi
+	}
+	return ""
+}
+
 type Data struct {
 	Type    string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
 	Payload []byte `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
@@ -135,18 +171,19 @@ func (m *Data) GetPayload() []byte {
 }
 
 type Transaction struct {
-	Hash      []byte `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
-	From      []byte `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
-	To        []byte `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
-	Value     []byte `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
-	Nonce     uint64 `protobuf:"varint,5,opt,name=nonce,proto3" json:"nonce,omitempty"`
-	Timestamp int64  `protobuf:"varint,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	Data      *Data  `protobuf:"bytes,7,opt,name=data" json:"data,omitempty"`
-	ChainId   uint32 `protobuf:"varint,8,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
-	GasPrice  []byte `protobuf:"bytes,9,opt,name=gas_price,json=gasPrice,proto3" json:"gas_price,omitempty"`
-	GasLimit  []byte `protobuf:"bytes,10,opt,name=gas_limit,json=gasLimit,proto3" json:"gas_limit,omitempty"`
-	Alg       uint32 `protobuf:"varint,11,opt,name=alg,proto3" json:"alg,omitempty"`
-	Sign      []byte `protobuf:"bytes,12,opt,name=sign,proto3" json:"sign,omitempty"`
+	Hash             []byte `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	From             []byte `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To               []byte `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	Value            []byte `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	Nonce            uint64 `protobuf:"varint,5,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Timestamp        int64  `protobuf:"varint,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Data             *Data  `protobuf:"bytes,7,opt,name=data" json:"data,omitempty"`
+	ChainId          uint32 `protobuf:"varint,8,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	GasPrice         []byte `protobuf:"bytes,9,opt,name=gas_price,json=gasPrice,proto3" json:"gas_price,omitempty"`
+	GasLimit         []byte `protobuf:"bytes,10,opt,name=gas_limit,json=gasLimit,proto3" json:"gas_limit,omitempty"`
+	Alg              uint32 `protobuf:"varint,11,opt,name=alg,proto3" json:"alg,omitempty"`
+	Sign             []byte `protobuf:"bytes,12,opt,name=sign,proto3" json:"sign,omitempty"`
+	EncryptedPayload []byte `protobuf:"bytes,13,opt,name=encrypted_payload,json=encryptedPayload,proto3" json:"encrypted_payload,omitempty"`
 }
 
 func (m *Transaction) Reset()                    { *m = Transaction{} }
@@ -238,6 +275,13 @@ func (m *Transaction) GetSign() []byte {
 	return nil
 }
 
+func (m *Transaction) GetEncryptedPayload() []byte {
+	if m != nil {
+		return m.EncryptedPayload
+	}
+	return nil
+}
+
 type BlockHeader struct {
 	Hash          []byte                     `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
 	ParentHash    []byte                     `protobuf:"bytes,2,opt,name=parent_hash,json=parentHash,proto3" json:"parent_hash,omitempty"`
@@ -251,6 +295,7 @@ type BlockHeader struct {
 	EventsRoot    []byte                     `protobuf:"bytes,11,opt,name=events_root,json=eventsRoot,proto3" json:"events_root,omitempty"`
 	ConsensusRoot *consensuspb.ConsensusRoot `protobuf:"bytes,12,opt,name=consensus_root,json=consensusRoot" json:"consensus_root,omitempty"`
 	Random        *Random                    `protobuf:"bytes,13,opt,name=random" json:"random,omitempty"`
+	GasLimit      []byte                     `protobuf:"bytes,14,opt,name=gas_limit,json=gasLimit,proto3" json:"gas_limit,omitempty"`
 }
 
 func (m *BlockHeader) Reset()                    { *m = BlockHeader{} }
@@ -342,6 +387,13 @@ func (m *BlockHeader) GetRandom() *Random {
 	return nil
 }
 
+func (m *BlockHeader) GetGasLimit() []byte {
+	if m != nil {
+		return m.GasLimit
+	}
+	return nil
+}
+
 type Block struct {
 	Header       *BlockHeader   `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
 	Transactions []*Transaction `protobuf:"bytes,2,rep,name=transactions" json:"transactions,omitempty"`
@@ -494,6 +546,110 @@ func (m *Random) GetVrfProof() []byte {
 	return nil
 }
 
+type GetBlocks struct {
+	From    []byte `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	Count   uint32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Reverse bool   `protobuf:"varint,3,opt,name=reverse,proto3" json:"reverse,omitempty"`
+}
+
+func (m *GetBlocks) Reset()                    { *m = GetBlocks{} }
+func (m *GetBlocks) String() string            { return proto.CompactTextString(m) }
+func (*GetBlocks) ProtoMessage()               {}
+func (*GetBlocks) Descriptor() ([]byte, []int) { return fileDescriptorBlock, []int{10} }
+
+func (m *GetBlocks) GetFrom() []byte {
+	if m != nil {
+		return m.From
+	}
+	return nil
+}
+
+func (m *GetBlocks) GetCount() uint32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *GetBlocks) GetReverse() bool {
+	if m != nil {
+		return m.Reverse
+	}
+	return false
+}
+
+type Blocks struct {
+	Blocks []*Block `protobuf:"bytes,1,rep,name=blocks" json:"blocks,omitempty"`
+}
+
+func (m *Blocks) Reset()                    { *m = Blocks{} }
+func (m *Blocks) String() string            { return proto.CompactTextString(m) }
+func (*Blocks) ProtoMessage()               {}
+func (*Blocks) Descriptor() ([]byte, []int) { return fileDescriptorBlock, []int{11} }
+
+func (m *Blocks) GetBlocks() []*Block {
+	if m != nil {
+		return m.Blocks
+	}
+	return nil
+}
+
+type SignedHeader struct {
+	Header     *BlockHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	Dependency *dagpb.Dag   `protobuf:"bytes,2,opt,name=dependency" json:"dependency,omitempty"`
+	TxHashes   [][]byte     `protobuf:"bytes,3,rep,name=tx_hashes,json=txHashes" json:"tx_hashes,omitempty"`
+}
+
+func (m *SignedHeader) Reset()                    { *m = SignedHeader{} }
+func (m *SignedHeader) String() string            { return proto.CompactTextString(m) }
+func (*SignedHeader) ProtoMessage()               {}
+func (*SignedHeader) Descriptor() ([]byte, []int) { return fileDescriptorBlock, []int{12} }
+
+func (m *SignedHeader) GetHeader() *BlockHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *SignedHeader) GetDependency() *dagpb.Dag {
+	if m != nil {
+		return m.Dependency
+	}
+	return nil
+}
+
+func (m *SignedHeader) GetTxHashes() [][]byte {
+	if m != nil {
+		return m.TxHashes
+	}
+	return nil
+}
+
+type DoubleSignEvidence struct {
+	First  *SignedHeader `protobuf:"bytes,1,opt,name=first" json:"first,omitempty"`
+	Second *SignedHeader `protobuf:"bytes,2,opt,name=second" json:"second,omitempty"`
+}
+
+func (m *DoubleSignEvidence) Reset()                    { *m = DoubleSignEvidence{} }
+func (m *DoubleSignEvidence) String() string            { return proto.CompactTextString(m) }
+func (*DoubleSignEvidence) ProtoMessage()               {}
+func (*DoubleSignEvidence) Descriptor() ([]byte, []int) { return fileDescriptorBlock, []int{13} }
+
+func (m *DoubleSignEvidence) GetFirst() *SignedHeader {
+	if m != nil {
+		return m.First
+	}
+	return nil
+}
+
+func (m *DoubleSignEvidence) GetSecond() *SignedHeader {
+	if m != nil {
+		return m.Second
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Account)(nil), "corepb.Account")
 	proto.RegisterType((*ContractMeta)(nil), "corepb.ContractMeta")
@@ -505,60 +661,76 @@ func init() {
 	proto.RegisterType((*NetBlock)(nil), "corepb.NetBlock")
 	proto.RegisterType((*DownloadBlock)(nil), "corepb.DownloadBlock")
 	proto.RegisterType((*Random)(nil), "corepb.Random")
+	proto.RegisterType((*GetBlocks)(nil), "corepb.GetBlocks")
+	proto.RegisterType((*Blocks)(nil), "corepb.Blocks")
+	proto.RegisterType((*SignedHeader)(nil), "corepb.SignedHeader")
+	proto.RegisterType((*DoubleSignEvidence)(nil), "corepb.DoubleSignEvidence")
 }
 
 func init() { proto.RegisterFile("block.proto", fileDescriptorBlock) }
 
 var fileDescriptorBlock = []byte{
-	// 791 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0xdd, 0x8a, 0x2b, 0x45,
-	0x10, 0x26, 0xc9, 0xe4, 0xaf, 0x26, 0x59, 0x0e, 0xed, 0x22, 0xe3, 0xaa, 0x6c, 0x18, 0x51, 0x82,
-	0x62, 0x02, 0xab, 0xb0, 0x7a, 0xe7, 0xd1, 0x73, 0x71, 0x14, 0x95, 0xa5, 0xf5, 0x46, 0x10, 0x42,
-	0x4d, 0x4f, 0xef, 0x64, 0x30, 0xd3, 0x3d, 0x74, 0x77, 0xe2, 0xd9, 0x47, 0xf0, 0x5d, 0xbc, 0xf1,
-	0x3d, 0x7c, 0x28, 0xe9, 0xea, 0x9e, 0xec, 0xec, 0xf1, 0x80, 0x78, 0x35, 0xfd, 0xd5, 0x37, 0x5f,
-	0x53, 0xf5, 0x55, 0x55, 0x43, 0x5a, 0x1c, 0xb4, 0xf8, 0x6d, 0xd3, 0x1a, 0xed, 0x34, 0x9b, 0x08,
-	0x6d, 0x64, 0x5b, 0x5c, 0xdd, 0x56, 0xb5, 0xdb, 0x1f, 0x8b, 0x8d, 0xd0, 0xcd, 0x56, 0xc9, 0xe2,
-	0x78, 0x40, 0x5b, 0xeb, 0x6d, 0xa5, 0x3f, 0x8d, 0x60, 0x2b, 0x74, 0xd3, 0x68, 0xb5, 0x2d, 0xb1,
-	0xda, 0xb6, 0x85, 0xff, 0x84, 0x0b, 0xae, 0xbe, 0xf8, 0x6f, 0xa1, 0xb2, 0x52, 0xd9, 0xa3, 0xf5,
-	0x3a, 0xeb, 0xd0, 0xc9, 0xa0, 0xcc, 0xff, 0x1e, 0xc0, 0xf4, 0xb9, 0x10, 0xfa, 0xa8, 0x1c, 0xcb,
-	0x60, 0x8a, 0x65, 0x69, 0xa4, 0xb5, 0xd9, 0x60, 0x35, 0x58, 0x2f, 0x78, 0x07, 0x3d, 0x53, 0xe0,
-	0x01, 0x95, 0x90, 0xd9, 0x30, 0x30, 0x11, 0xb2, 0x4b, 0x18, 0x2b, 0xed, 0xe3, 0xa3, 0xd5, 0x60,
-	0x9d, 0xf0, 0x00, 0xd8, 0xbb, 0x30, 0x3f, 0xa1, 0xb1, 0xbb, 0x3d, 0xda, 0x7d, 0x96, 0x90, 0x62,
-	0xe6, 0x03, 0x2f, 0xd1, 0xee, 0xd9, 0x35, 0xa4, 0x45, 0x6d, 0xdc, 0x7e, 0xd7, 0x1e, 0x50, 0xc8,
-	0x6c, 0x4c, 0x34, 0x50, 0xe8, 0xce, 0x47, 0xd8, 0x97, 0xb0, 0x14, 0x5a, 0x39, 0x83, 0xc2, 0xed,
-	0x1a, 0xe9, 0x30, 0x9b, 0xac, 0x06, 0xeb, 0xf4, 0xe6, 0x72, 0x13, 0x6c, 0xda, 0x7c, 0x13, 0xc9,
-	0x1f, 0xa4, 0x43, 0xbe, 0x10, 0x3d, 0x94, 0xaf, 0x61, 0xd1, 0x67, 0x7d, 0xe2, 0x27, 0x69, 0x6c,
-	0xad, 0x15, 0x95, 0x34, 0xe7, 0x1d, 0xcc, 0x3f, 0x87, 0xe4, 0x05, 0x3a, 0x64, 0x0c, 0x12, 0xf7,
-	0xd0, 0xca, 0x48, 0xd3, 0xd9, 0xab, 0x5a, 0x7c, 0x38, 0x68, 0x2c, 0xbb, 0x72, 0x23, 0xcc, 0xff,
-	0x1c, 0x42, 0xfa, 0xb3, 0x41, 0x65, 0x51, 0xb8, 0x5a, 0x2b, 0xaf, 0xa6, 0x1a, 0x83, 0x5f, 0x74,
-	0xf6, 0xb1, 0x7b, 0xa3, 0x9b, 0x28, 0xa5, 0x33, 0xbb, 0x80, 0xa1, 0xd3, 0xe4, 0xd1, 0x82, 0x0f,
-	0x9d, 0xf6, 0xb6, 0x9d, 0xf0, 0x70, 0x94, 0xd1, 0x9c, 0x00, 0x1e, 0xcd, 0x1c, 0xf7, 0xcd, 0x7c,
-	0x0f, 0xe6, 0xae, 0x6e, 0xa4, 0x75, 0xd8, 0xb4, 0x64, 0xc5, 0x88, 0x3f, 0x06, 0xd8, 0x0a, 0x92,
-	0x12, 0x1d, 0x66, 0x53, 0xf2, 0x68, 0xd1, 0x79, 0xe4, 0x6b, 0xe3, 0xc4, 0xb0, 0x77, 0x60, 0x26,
-	0xf6, 0x58, 0xab, 0x5d, 0x5d, 0x66, 0xb3, 0xd5, 0x60, 0xbd, 0xe4, 0x53, 0xc2, 0xdf, 0x96, 0xbe,
-	0x4f, 0x15, 0xda, 0x5d, 0x6b, 0x6a, 0x21, 0xb3, 0x79, 0xe8, 0x53, 0x85, 0xf6, 0xce, 0xe3, 0x8e,
-	0x3c, 0xd4, 0x4d, 0xed, 0x32, 0x38, 0x93, 0xdf, 0x7b, 0xcc, 0x9e, 0xc1, 0x08, 0x0f, 0x55, 0x96,
-	0xd2, 0x7d, 0xfe, 0xe8, 0xcb, 0xb6, 0x75, 0xa5, 0xb2, 0x45, 0x28, 0xdb, 0x9f, 0xf3, 0x3f, 0x46,
-	0x90, 0x7e, 0xed, 0x07, 0xfd, 0xa5, 0xc4, 0x52, 0x9a, 0x37, 0xda, 0x75, 0x0d, 0x69, 0x8b, 0x46,
-	0x2a, 0x17, 0xa6, 0x25, 0xb8, 0x06, 0x21, 0x44, 0xf3, 0x72, 0x05, 0x33, 0xa1, 0x6b, 0x55, 0xa0,
-	0xed, 0xec, 0x3a, 0xe3, 0xa7, 0xde, 0x8c, 0x5f, 0xf7, 0xa6, 0x5f, 0xf9, 0xe4, 0x69, 0xe5, 0x31,
-	0xff, 0xe9, 0xbf, 0xf3, 0x9f, 0x3d, 0xe6, 0xcf, 0xde, 0x07, 0xa0, 0x65, 0xd9, 0x19, 0xad, 0x5d,
-	0x34, 0x68, 0x4e, 0x11, 0xae, 0xb5, 0xf3, 0xf7, 0xbb, 0x57, 0x36, 0x90, 0xc1, 0xa0, 0xa9, 0x7b,
-	0x65, 0x89, 0xba, 0x86, 0x54, 0x9e, 0xa4, 0x72, 0x91, 0x4d, 0x43, 0x55, 0x21, 0x44, 0x3f, 0x3c,
-	0x87, 0x8b, 0xf3, 0x52, 0x86, 0x7f, 0x16, 0xd4, 0xc1, 0xab, 0xcd, 0x39, 0x1c, 0x46, 0x3d, 0x9c,
-	0xbd, 0x86, 0x2f, 0x45, 0x1f, 0xb2, 0x8f, 0x60, 0x62, 0x50, 0x95, 0xba, 0xc9, 0x96, 0x24, 0xbd,
-	0xe8, 0x9a, 0xcf, 0x29, 0xca, 0x23, 0xfb, 0x5d, 0x32, 0x1b, 0x3d, 0x4b, 0xf2, 0xbf, 0x06, 0x30,
-	0xa6, 0x5e, 0xb0, 0x4f, 0x60, 0xb2, 0xa7, 0x7e, 0x50, 0x1f, 0xd2, 0x9b, 0xb7, 0x3a, 0x5d, 0xaf,
-	0x55, 0x3c, 0xfe, 0xc2, 0x6e, 0x61, 0xe1, 0x1e, 0x07, 0xde, 0x66, 0xc3, 0xd5, 0xa8, 0x2f, 0xe9,
-	0x2d, 0x03, 0x7f, 0xf2, 0x23, 0xfb, 0x18, 0xa0, 0x94, 0xad, 0x54, 0xa5, 0x54, 0xe2, 0x81, 0x46,
-	0x3f, 0xbd, 0x81, 0x4d, 0x89, 0x15, 0x4d, 0x67, 0xc5, 0x7b, 0x2c, 0x7b, 0xdb, 0x67, 0x54, 0x57,
-	0x7b, 0x47, 0x0d, 0x4e, 0x78, 0x44, 0xf9, 0xaf, 0x30, 0xff, 0x51, 0x3a, 0x4a, 0xcb, 0x9e, 0xf7,
-	0x2a, 0x6e, 0x2a, 0xed, 0xd5, 0x25, 0x8c, 0x0b, 0x74, 0x22, 0x8c, 0x4d, 0xc2, 0x03, 0x60, 0x1f,
-	0xc2, 0x84, 0x9e, 0x57, 0x9b, 0x8d, 0x28, 0xdb, 0xe5, 0x93, 0x02, 0x79, 0x24, 0xf3, 0x5f, 0x60,
-	0xd6, 0xdd, 0xfe, 0x3f, 0x2e, 0xff, 0x00, 0xc6, 0xa4, 0x8f, 0x25, 0xbd, 0x76, 0x77, 0xe0, 0xf2,
-	0x5b, 0x58, 0xbe, 0xd0, 0xbf, 0x2b, 0xff, 0x66, 0x9c, 0xef, 0x7f, 0xd3, 0x43, 0x41, 0x13, 0x37,
-	0xec, 0x6d, 0xcc, 0x57, 0x30, 0x09, 0xdd, 0xf3, 0xc3, 0x75, 0x32, 0xf7, 0x3b, 0x2b, 0x65, 0xd9,
-	0x3d, 0xc7, 0x27, 0x73, 0xff, 0x93, 0x94, 0xb4, 0xb6, 0x9e, 0x6a, 0x8d, 0xd6, 0xf7, 0x51, 0xed,
-	0xff, 0xbd, 0xf3, 0xb8, 0x98, 0xd0, 0xc3, 0xfe, 0xd9, 0x3f, 0x01, 0x00, 0x00, 0xff, 0xff, 0xa9,
-	0xc7, 0x49, 0xf3, 0x62, 0x06, 0x00, 0x00,
+	// 983 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0xdd, 0x8e, 0xe3, 0x34,
+	0x14, 0x56, 0xfa, 0x93, 0xb6, 0x27, 0xe9, 0x68, 0x30, 0x2b, 0x08, 0xb3, 0xa0, 0x29, 0x41, 0xa0,
+	0xd1, 0x2e, 0xb4, 0xd2, 0x80, 0x34, 0x70, 0xc7, 0xc2, 0x20, 0x06, 0xc4, 0xcf, 0xc8, 0xbb, 0x42,
+	0x42, 0x42, 0xaa, 0x1c, 0xc7, 0x4d, 0x23, 0x5a, 0x3b, 0x8a, 0xdd, 0xd2, 0xb9, 0xe7, 0x92, 0xe7,
+	0xe0, 0x9e, 0xf7, 0xe0, 0x05, 0x78, 0x1b, 0xe4, 0x63, 0xa7, 0x4d, 0x97, 0xd1, 0xa0, 0xbd, 0xaa,
+	0xbf, 0xf3, 0xd7, 0x73, 0xbe, 0x73, 0x8e, 0x1d, 0x88, 0xb2, 0x95, 0xe2, 0xbf, 0x4e, 0xab, 0x5a,
+	0x19, 0x45, 0x42, 0xae, 0x6a, 0x51, 0x65, 0x67, 0x57, 0x45, 0x69, 0x96, 0x9b, 0x6c, 0xca, 0xd5,
+	0x7a, 0x26, 0x45, 0xb6, 0x59, 0x31, 0x5d, 0xaa, 0x59, 0xa1, 0x3e, 0xf2, 0x60, 0xc6, 0xd5, 0x7a,
+	0xad, 0xe4, 0x2c, 0x67, 0xc5, 0xac, 0xca, 0xec, 0x8f, 0x0b, 0x70, 0xf6, 0xe9, 0xff, 0x3b, 0x4a,
+	0x2d, 0xa4, 0xde, 0x68, 0xeb, 0xa7, 0x0d, 0x33, 0xc2, 0x79, 0xa6, 0x7f, 0x07, 0x30, 0x78, 0xc6,
+	0xb9, 0xda, 0x48, 0x43, 0x12, 0x18, 0xb0, 0x3c, 0xaf, 0x85, 0xd6, 0x49, 0x30, 0x09, 0x2e, 0x62,
+	0xda, 0x40, 0xab, 0xc9, 0xd8, 0x8a, 0x49, 0x2e, 0x92, 0x8e, 0xd3, 0x78, 0x48, 0x1e, 0x41, 0x5f,
+	0x2a, 0x2b, 0xef, 0x4e, 0x82, 0x8b, 0x1e, 0x75, 0x80, 0x3c, 0x86, 0xd1, 0x96, 0xd5, 0x7a, 0xbe,
+	0x64, 0x7a, 0x99, 0xf4, 0xd0, 0x63, 0x68, 0x05, 0x37, 0x4c, 0x2f, 0xc9, 0x39, 0x44, 0x59, 0x59,
+	0x9b, 0xe5, 0xbc, 0x5a, 0x31, 0x2e, 0x92, 0x3e, 0xaa, 0x01, 0x45, 0xb7, 0x56, 0x42, 0x3e, 0x83,
+	0x31, 0x57, 0xd2, 0xd4, 0x8c, 0x9b, 0xf9, 0x5a, 0x18, 0x96, 0x84, 0x93, 0xe0, 0x22, 0xba, 0x7c,
+	0x34, 0x75, 0x34, 0x4d, 0xbf, 0xf4, 0xca, 0xef, 0x85, 0x61, 0x34, 0xe6, 0x2d, 0x94, 0xfe, 0x11,
+	0x40, 0xdc, 0x56, 0xdb, 0xcc, 0xb7, 0xa2, 0xd6, 0xa5, 0x92, 0x58, 0xd3, 0x88, 0x36, 0xd0, 0x66,
+	0xce, 0xf2, 0x75, 0x29, 0x7d, 0x45, 0x0e, 0x90, 0x77, 0x21, 0xe6, 0x2a, 0x17, 0xf3, 0xc6, 0xc9,
+	0x95, 0x15, 0x59, 0xd9, 0x4f, 0xde, 0xf1, 0x4d, 0x18, 0xa0, 0x89, 0xd9, 0xf9, 0xd2, 0x42, 0x0b,
+	0x5f, 0xec, 0xc8, 0x29, 0x74, 0x59, 0x56, 0x62, 0x41, 0x23, 0x6a, 0x8f, 0xe9, 0x27, 0xd0, 0xbb,
+	0x66, 0x86, 0x11, 0x02, 0x3d, 0x73, 0x57, 0x09, 0x9f, 0x02, 0x9e, 0x6d, 0x66, 0x15, 0xbb, 0x5b,
+	0x29, 0x96, 0x37, 0x9c, 0x7a, 0x98, 0xfe, 0xd3, 0x81, 0xe8, 0x45, 0xcd, 0xa4, 0x66, 0xdc, 0xd8,
+	0x3f, 0x24, 0xd0, 0x43, 0x22, 0x5d, 0x53, 0xf0, 0x6c, 0x65, 0x8b, 0x5a, 0xad, 0xbd, 0x2b, 0x9e,
+	0xc9, 0x09, 0x74, 0x8c, 0xc2, 0x8c, 0x63, 0xda, 0x31, 0xca, 0x56, 0xb8, 0x65, 0xab, 0x8d, 0xf0,
+	0x69, 0x3a, 0x70, 0xe8, 0x58, 0xbf, 0xdd, 0xb1, 0xb7, 0x61, 0x64, 0xca, 0xb5, 0xd0, 0x86, 0xad,
+	0x2b, 0xe4, 0xbb, 0x4b, 0x0f, 0x02, 0x32, 0x81, 0x5e, 0xce, 0x0c, 0x4b, 0x06, 0xd8, 0x88, 0xb8,
+	0x69, 0x84, 0xad, 0x8d, 0xa2, 0x86, 0xbc, 0x05, 0x43, 0xbe, 0x64, 0xa5, 0x9c, 0x97, 0x79, 0x32,
+	0x9c, 0x04, 0x17, 0x63, 0x3a, 0x40, 0xfc, 0x4d, 0x6e, 0x87, 0xa1, 0x60, 0x7a, 0x5e, 0xd5, 0x25,
+	0x17, 0xc9, 0xc8, 0x0d, 0x43, 0xc1, 0xf4, 0xad, 0xc5, 0x8d, 0x72, 0x55, 0xae, 0x4b, 0x93, 0xc0,
+	0x5e, 0xf9, 0x9d, 0xc5, 0x48, 0xe8, 0xaa, 0x48, 0x22, 0x8c, 0x67, 0x8f, 0xb6, 0x6c, 0x5d, 0x16,
+	0x32, 0x89, 0x5d, 0xd9, 0xf6, 0x4c, 0x9e, 0xc2, 0x6b, 0x42, 0xf2, 0xfa, 0xae, 0x32, 0x22, 0x9f,
+	0x37, 0x94, 0x8e, 0xd1, 0xe0, 0x74, 0xaf, 0xb8, 0xf5, 0xdc, 0xfe, 0xd9, 0x85, 0xe8, 0x0b, 0xbb,
+	0x7a, 0x37, 0x82, 0xe5, 0xa2, 0xbe, 0x97, 0xdb, 0x73, 0x88, 0x2a, 0x56, 0x0b, 0x69, 0xdc, 0xfc,
+	0x3a, 0x8a, 0xc1, 0x89, 0x70, 0x82, 0xcf, 0x60, 0xc8, 0x55, 0x29, 0x33, 0xa6, 0x1b, 0x6e, 0xf7,
+	0xf8, 0x98, 0xc8, 0xfe, 0xcb, 0x44, 0xb6, 0x69, 0x0a, 0x8f, 0x69, 0xf2, 0xc5, 0x0e, 0xfe, 0x5b,
+	0xec, 0xb0, 0x55, 0xec, 0x3b, 0x00, 0xb8, 0xbe, 0xf3, 0x5a, 0x29, 0xe3, 0xd9, 0x1c, 0xa1, 0x84,
+	0x2a, 0x65, 0x6c, 0x7c, 0xb3, 0xd3, 0x4e, 0xe9, 0xd8, 0x1c, 0x98, 0x9d, 0x46, 0xd5, 0x39, 0x44,
+	0x62, 0x2b, 0xa4, 0xf1, 0xda, 0xc8, 0x55, 0xe5, 0x44, 0x68, 0xf0, 0x0c, 0x4e, 0xf6, 0xd7, 0x84,
+	0xb3, 0x89, 0xb1, 0xdd, 0x67, 0xd3, 0xbd, 0xd8, 0x2d, 0x9f, 0x3b, 0x5b, 0x1f, 0x3a, 0xe6, 0x6d,
+	0x48, 0x3e, 0x80, 0xb0, 0x66, 0x32, 0x57, 0x6b, 0xe4, 0x3f, 0xba, 0x3c, 0x69, 0x26, 0x85, 0xa2,
+	0x94, 0x7a, 0xed, 0x71, 0xd7, 0x4f, 0x8e, 0xbb, 0xfe, 0x6d, 0x6f, 0xd8, 0x3d, 0xed, 0xa5, 0x7f,
+	0x05, 0xd0, 0xc7, 0x46, 0x91, 0xa7, 0x10, 0x2e, 0xb1, 0x59, 0xd8, 0xa4, 0xe8, 0xf2, 0xf5, 0x26,
+	0x68, 0xab, 0x8f, 0xd4, 0x9b, 0x90, 0x2b, 0x88, 0xcd, 0x61, 0x75, 0x74, 0xd2, 0x99, 0x74, 0xdb,
+	0x2e, 0xad, 0xb5, 0xa2, 0x47, 0x86, 0xe4, 0x09, 0x40, 0x2e, 0x2a, 0x21, 0x73, 0x21, 0xf9, 0x1d,
+	0x2e, 0x51, 0x74, 0x09, 0xd3, 0x9c, 0x15, 0x38, 0xe7, 0x05, 0x6d, 0x69, 0xc9, 0x1b, 0x36, 0xa3,
+	0xb2, 0x58, 0x1a, 0xec, 0x7e, 0x8f, 0x7a, 0x94, 0xfe, 0x02, 0xa3, 0x1f, 0x84, 0xc1, 0xb4, 0xf4,
+	0x7e, 0x43, 0xfd, 0xce, 0xdb, 0xb3, 0xdd, 0xbd, 0x8c, 0x19, 0xee, 0x66, 0xaa, 0x47, 0x1d, 0x20,
+	0xef, 0x43, 0x88, 0xaf, 0x81, 0x4e, 0xba, 0x98, 0xed, 0xf8, 0xa8, 0x40, 0xea, 0x95, 0xe9, 0xcf,
+	0x30, 0x6c, 0xa2, 0xbf, 0x42, 0xf0, 0xf7, 0xa0, 0x8f, 0xfe, 0xbe, 0xa4, 0x97, 0x62, 0x3b, 0x5d,
+	0x7a, 0x05, 0xe3, 0x6b, 0xf5, 0x9b, 0xb4, 0x1b, 0xb2, 0x8f, 0x7f, 0xdf, 0x95, 0x83, 0xe3, 0xd8,
+	0x39, 0x8c, 0x63, 0xfa, 0x39, 0x84, 0xae, 0xb5, 0x76, 0xf2, 0xb6, 0xf5, 0x62, 0xae, 0x85, 0xc8,
+	0x9b, 0xd7, 0x63, 0x5b, 0x2f, 0x9e, 0x0b, 0x81, 0x17, 0x80, 0x55, 0x55, 0xb5, 0x52, 0x0b, 0xef,
+	0x6d, 0x6d, 0x6f, 0x2d, 0x4e, 0x7f, 0x84, 0xd1, 0xd7, 0xf7, 0x72, 0x16, 0x1f, 0xca, 0xc2, 0xe7,
+	0x09, 0x3d, 0xc7, 0xb4, 0xbf, 0x7f, 0xab, 0x6a, 0x61, 0x2f, 0x69, 0xf7, 0xf2, 0x0c, 0x69, 0x03,
+	0xd3, 0x19, 0x84, 0x3e, 0xda, 0x81, 0xd7, 0xe0, 0x21, 0x5e, 0x7f, 0x0f, 0x20, 0x7e, 0x5e, 0x16,
+	0x52, 0xe4, 0xfe, 0x4e, 0x78, 0xa5, 0x81, 0x3b, 0x9e, 0x9b, 0xce, 0x83, 0x73, 0xf3, 0x18, 0x46,
+	0x66, 0x87, 0x97, 0x8a, 0x70, 0xbd, 0x8e, 0xe9, 0xd0, 0xec, 0x6e, 0x10, 0xa7, 0x12, 0xc8, 0xb5,
+	0xda, 0x64, 0x2b, 0x61, 0x73, 0xf9, 0x6a, 0x5b, 0x5a, 0x17, 0x41, 0x9e, 0x40, 0x7f, 0x51, 0xd6,
+	0xda, 0x24, 0xc1, 0xf1, 0x1b, 0xd8, 0x4e, 0x98, 0x3a, 0x13, 0xf2, 0x21, 0x84, 0x5a, 0x70, 0x25,
+	0xf3, 0xa4, 0xf3, 0x80, 0xb1, 0xb7, 0xc9, 0x42, 0xfc, 0x00, 0xf8, 0xf8, 0xdf, 0x01, 0x00, 0x43,
+	0xe1, 0x24, 0x9f, 0x8a, 0x08, 0x00, 0x00,
 }
@@ -11,8 +11,10 @@ It has these top-level messages:
 	Genesis
 	GenesisMeta
 	GenesisConsensus
+	GenesisExecutionLimits
 	GenesisConsensusDpos
 	GenesisTokenDistribution
+	GenesisConsensusPoa
 */
 package corepb
 
@@ -87,6 +89,10 @@ func (m *GenesisMeta) GetChainId() uint32 {
 type GenesisConsensus struct {
 	// ChainID.
 	Dpos *GenesisConsensusDpos `protobuf:"bytes,1,opt,name=dpos" json:"dpos,omitempty"`
+	// poa consensus, the chain runs the proof-of-authority engine if it is set.
+	Poa *GenesisConsensusPoa `protobuf:"bytes,2,opt,name=poa" json:"poa,omitempty"`
+	// limits of a contract execution, the protocol default if not set.
+	ExecutionLimits *GenesisExecutionLimits `protobuf:"bytes,3,opt,name=execution_limits,json=executionLimits" json:"execution_limits,omitempty"`
 }
 
 func (m *GenesisConsensus) Reset()                    { *m = GenesisConsensus{} }
@@ -101,15 +107,65 @@ func (m *GenesisConsensus) GetDpos() *GenesisConsensusDpos {
 	return nil
 }
 
+func (m *GenesisConsensus) GetPoa() *GenesisConsensusPoa {
+	if m != nil {
+		return m.Poa
+	}
+	return nil
+}
+
+func (m *GenesisConsensus) GetExecutionLimits() *GenesisExecutionLimits {
+	if m != nil {
+		return m.ExecutionLimits
+	}
+	return nil
+}
+
+type GenesisExecutionLimits struct {
+	// wall clock time a contract execution can take in ms, 0 for the protocol default.
+	TimeoutInMs uint64 `protobuf:"varint,1,opt,name=timeout_in_ms,json=timeoutInMs,proto3" json:"timeout_in_ms,omitempty"`
+	// memory a contract execution can take in bytes, 0 for the protocol default.
+	MemorySize uint64 `protobuf:"varint,2,opt,name=memory_size,json=memorySize,proto3" json:"memory_size,omitempty"`
+}
+
+func (m *GenesisExecutionLimits) Reset()                    { *m = GenesisExecutionLimits{} }
+func (m *GenesisExecutionLimits) String() string            { return proto.CompactTextString(m) }
+func (*GenesisExecutionLimits) ProtoMessage()               {}
+func (*GenesisExecutionLimits) Descriptor() ([]byte, []int) { return fileDescriptorGenesis, []int{3} }
+
+func (m *GenesisExecutionLimits) GetTimeoutInMs() uint64 {
+	if m != nil {
+		return m.TimeoutInMs
+	}
+	return 0
+}
+
+func (m *GenesisExecutionLimits) GetMemorySize() uint64 {
+	if m != nil {
+		return m.MemorySize
+	}
+	return 0
+}
+
 type GenesisConsensusDpos struct {
 	// dpos genesis dynasty address
 	Dynasty []string `protobuf:"bytes,1,rep,name=dynasty" json:"dynasty,omitempty"`
+	// percent of the deposit slashed for a double sign, 0 for the protocol default.
+	DoubleSignSlashPercent uint32 `protobuf:"varint,4,opt,name=double_sign_slash_percent,json=doubleSignSlashPercent,proto3" json:"double_sign_slash_percent,omitempty"`
+	// percent of the deposit slashed for a downtime, 0 for the protocol default.
+	DowntimeSlashPercent uint32 `protobuf:"varint,5,opt,name=downtime_slash_percent,json=downtimeSlashPercent,proto3" json:"downtime_slash_percent,omitempty"`
+	// dynasties a slashed validator is jailed for, 0 for the protocol default.
+	JailDynasties uint64 `protobuf:"varint,6,opt,name=jail_dynasties,json=jailDynasties,proto3" json:"jail_dynasties,omitempty"`
+	// dpos standby validators, promoted in order to replace the inactive members of the dynasty.
+	Standby []string `protobuf:"bytes,7,rep,name=standby" json:"standby,omitempty"`
+	// slots a validator may miss in a dynasty before it is replaced, 0 for the protocol default.
+	MaxMissedSlots uint32 `protobuf:"varint,8,opt,name=max_missed_slots,json=maxMissedSlots,proto3" json:"max_missed_slots,omitempty"`
 }
 
 func (m *GenesisConsensusDpos) Reset()                    { *m = GenesisConsensusDpos{} }
 func (m *GenesisConsensusDpos) String() string            { return proto.CompactTextString(m) }
 func (*GenesisConsensusDpos) ProtoMessage()               {}
-func (*GenesisConsensusDpos) Descriptor() ([]byte, []int) { return fileDescriptorGenesis, []int{3} }
+func (*GenesisConsensusDpos) Descriptor() ([]byte, []int) { return fileDescriptorGenesis, []int{4} }
 
 func (m *GenesisConsensusDpos) GetDynasty() []string {
 	if m != nil {
@@ -118,6 +174,41 @@ func (m *GenesisConsensusDpos) GetDynasty() []string {
 	return nil
 }
 
+func (m *GenesisConsensusDpos) GetDoubleSignSlashPercent() uint32 {
+	if m != nil {
+		return m.DoubleSignSlashPercent
+	}
+	return 0
+}
+
+func (m *GenesisConsensusDpos) GetDowntimeSlashPercent() uint32 {
+	if m != nil {
+		return m.DowntimeSlashPercent
+	}
+	return 0
+}
+
+func (m *GenesisConsensusDpos) GetJailDynasties() uint64 {
+	if m != nil {
+		return m.JailDynasties
+	}
+	return 0
+}
+
+func (m *GenesisConsensusDpos) GetStandby() []string {
+	if m != nil {
+		return m.Standby
+	}
+	return nil
+}
+
+func (m *GenesisConsensusDpos) GetMaxMissedSlots() uint32 {
+	if m != nil {
+		return m.MaxMissedSlots
+	}
+	return 0
+}
+
 type GenesisTokenDistribution struct {
 	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
 	Value   string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
@@ -126,7 +217,7 @@ type GenesisTokenDistribution struct {
 func (m *GenesisTokenDistribution) Reset()                    { *m = GenesisTokenDistribution{} }
 func (m *GenesisTokenDistribution) String() string            { return proto.CompactTextString(m) }
 func (*GenesisTokenDistribution) ProtoMessage()               {}
-func (*GenesisTokenDistribution) Descriptor() ([]byte, []int) { return fileDescriptorGenesis, []int{4} }
+func (*GenesisTokenDistribution) Descriptor() ([]byte, []int) { return fileDescriptorGenesis, []int{5} }
 
 func (m *GenesisTokenDistribution) GetAddress() string {
 	if m != nil {
@@ -142,33 +233,77 @@ func (m *GenesisTokenDistribution) GetValue() string {
 	return ""
 }
 
+type GenesisConsensusPoa struct {
+	// poa authorized signers address
+	Signers []string `protobuf:"bytes,1,rep,name=signers" json:"signers,omitempty"`
+	// poa minimum interval between blocks in ms, 0 for the protocol default.
+	PeriodInMs int64 `protobuf:"varint,2,opt,name=period_in_ms,json=periodInMs,proto3" json:"period_in_ms,omitempty"`
+}
+
+func (m *GenesisConsensusPoa) Reset()                    { *m = GenesisConsensusPoa{} }
+func (m *GenesisConsensusPoa) String() string            { return proto.CompactTextString(m) }
+func (*GenesisConsensusPoa) ProtoMessage()               {}
+func (*GenesisConsensusPoa) Descriptor() ([]byte, []int) { return fileDescriptorGenesis, []int{6} }
+
+func (m *GenesisConsensusPoa) GetSigners() []string {
+	if m != nil {
+		return m.Signers
+	}
+	return nil
+}
+
+func (m *GenesisConsensusPoa) GetPeriodInMs() int64 {
+	if m != nil {
+		return m.PeriodInMs
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*Genesis)(nil), "corepb.Genesis")
 	proto.RegisterType((*GenesisMeta)(nil), "corepb.GenesisMeta")
 	proto.RegisterType((*GenesisConsensus)(nil), "corepb.GenesisConsensus")
+	proto.RegisterType((*GenesisExecutionLimits)(nil), "corepb.GenesisExecutionLimits")
 	proto.RegisterType((*GenesisConsensusDpos)(nil), "corepb.GenesisConsensusDpos")
 	proto.RegisterType((*GenesisTokenDistribution)(nil), "corepb.GenesisTokenDistribution")
+	proto.RegisterType((*GenesisConsensusPoa)(nil), "corepb.GenesisConsensusPoa")
 }
 
 func init() { proto.RegisterFile("genesis.proto", fileDescriptorGenesis) }
 
 var fileDescriptorGenesis = []byte{
-	// 264 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x91, 0x51, 0x4b, 0xc3, 0x30,
-	0x14, 0x85, 0xa9, 0x9d, 0xab, 0xbd, 0x65, 0xa0, 0x71, 0x0f, 0x11, 0x7c, 0x28, 0x79, 0xb1, 0x4f,
-	0x65, 0x4c, 0xf0, 0x0f, 0x58, 0x10, 0x05, 0x11, 0x82, 0xef, 0x23, 0x6d, 0x2e, 0x1a, 0xd4, 0xa4,
-	0xf4, 0xa6, 0xc2, 0x7e, 0x9b, 0x7f, 0x4e, 0x96, 0xae, 0x38, 0x8a, 0x7b, 0x3c, 0x39, 0x5f, 0x2e,
-	0xe7, 0xdc, 0x0b, 0x8b, 0x37, 0xb4, 0x48, 0x86, 0xca, 0xb6, 0x73, 0xde, 0xb1, 0x79, 0xe3, 0x3a,
-	0x6c, 0x6b, 0xf1, 0x13, 0x41, 0xf2, 0x30, 0x38, 0xec, 0x06, 0x66, 0x5f, 0xe8, 0x15, 0x8f, 0xf2,
-	0xa8, 0xc8, 0xd6, 0x97, 0xe5, 0x80, 0x94, 0x7b, 0xfb, 0x19, 0xbd, 0x92, 0x01, 0x60, 0x77, 0x90,
-	0x36, 0xce, 0x12, 0x5a, 0xea, 0x89, 0x9f, 0x04, 0x9a, 0x4f, 0xe8, 0xfb, 0xd1, 0x97, 0x7f, 0x28,
-	0x7b, 0x01, 0xe6, 0xdd, 0x07, 0xda, 0x8d, 0x36, 0xe4, 0x3b, 0x53, 0xf7, 0xde, 0x38, 0xcb, 0xe3,
-	0x3c, 0x2e, 0xb2, 0x75, 0x3e, 0x19, 0xf0, 0xba, 0x03, 0xab, 0x03, 0x4e, 0x5e, 0xf8, 0xe9, 0x93,
-	0x28, 0x20, 0x3b, 0x48, 0xc7, 0xae, 0xe0, 0xac, 0x79, 0x57, 0xc6, 0x6e, 0x8c, 0x0e, 0x25, 0x16,
-	0x32, 0x09, 0xfa, 0x51, 0x8b, 0x0a, 0xce, 0xa7, 0xc9, 0xd8, 0x0a, 0x66, 0xba, 0x75, 0xb4, 0xef,
-	0x7b, 0x7d, 0xac, 0x41, 0xd5, 0x3a, 0x92, 0x81, 0x14, 0x2b, 0x58, 0xfe, 0xe7, 0x32, 0x0e, 0x89,
-	0xde, 0x5a, 0x45, 0x7e, 0xcb, 0xa3, 0x3c, 0x2e, 0x52, 0x39, 0x4a, 0xf1, 0x04, 0xfc, 0x58, 0xa1,
-	0xdd, 0x2f, 0xa5, 0x75, 0x87, 0x34, 0x44, 0x48, 0xe5, 0x28, 0xd9, 0x12, 0x4e, 0xbf, 0xd5, 0x67,
-	0x8f, 0x61, 0xb9, 0xa9, 0x1c, 0x44, 0x3d, 0x0f, 0xa7, 0xbb, 0xfd, 0x0d, 0x00, 0x00, 0xff, 0xff,
-	0xbe, 0xf4, 0x0e, 0x3f, 0xcb, 0x01, 0x00, 0x00,
+	// 514 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x93, 0xd1, 0x8a, 0x13, 0x31,
+	0x14, 0x86, 0xe9, 0xce, 0xec, 0xb6, 0x3d, 0xb5, 0x6b, 0xcd, 0x2e, 0x4b, 0x16, 0x45, 0xcb, 0x80,
+	0xd8, 0x1b, 0x8b, 0xac, 0x22, 0x78, 0x6d, 0x45, 0x5a, 0x2c, 0xae, 0xa9, 0xb7, 0x12, 0xd2, 0x26,
+	0x74, 0xa3, 0x33, 0xc9, 0x30, 0x27, 0xd5, 0x76, 0x9f, 0xc4, 0x77, 0x11, 0x7c, 0x36, 0x99, 0x64,
+	0x06, 0x77, 0x87, 0xf6, 0xf2, 0xfc, 0xe7, 0x3b, 0x93, 0xff, 0x3f, 0xc9, 0x40, 0x7f, 0xad, 0x8c,
+	0x42, 0x8d, 0xe3, 0xbc, 0xb0, 0xce, 0x92, 0x93, 0x95, 0x2d, 0x54, 0xbe, 0x4c, 0xfe, 0xb4, 0xa0,
+	0xfd, 0x31, 0x74, 0xc8, 0x0b, 0x88, 0x33, 0xe5, 0x04, 0x6d, 0x0d, 0x5b, 0xa3, 0xde, 0xd5, 0xd9,
+	0x38, 0x20, 0xe3, 0xaa, 0x3d, 0x57, 0x4e, 0x30, 0x0f, 0x90, 0xb7, 0xd0, 0x5d, 0x59, 0x83, 0xca,
+	0xe0, 0x06, 0xe9, 0x91, 0xa7, 0x69, 0x83, 0x7e, 0x5f, 0xf7, 0xd9, 0x7f, 0x94, 0x7c, 0x06, 0xe2,
+	0xec, 0x0f, 0x65, 0xb8, 0xd4, 0xe8, 0x0a, 0xbd, 0xdc, 0x38, 0x6d, 0x0d, 0x8d, 0x86, 0xd1, 0xa8,
+	0x77, 0x35, 0x6c, 0x7c, 0xe0, 0x6b, 0x09, 0x4e, 0xee, 0x70, 0xec, 0x91, 0x6b, 0x4a, 0xc9, 0x08,
+	0x7a, 0x77, 0xdc, 0x91, 0x4b, 0xe8, 0xac, 0x6e, 0x84, 0x36, 0x5c, 0x4b, 0x1f, 0xa2, 0xcf, 0xda,
+	0xbe, 0x9e, 0xca, 0xe4, 0x6f, 0x0b, 0x06, 0x4d, 0x6b, 0xe4, 0x15, 0xc4, 0x32, 0xb7, 0x58, 0x05,
+	0x7e, 0x72, 0x28, 0xc2, 0x24, 0xb7, 0xc8, 0x3c, 0x49, 0x5e, 0x42, 0x94, 0x5b, 0x51, 0x65, 0x7e,
+	0x7c, 0x68, 0xe0, 0xda, 0x0a, 0x56, 0x72, 0x64, 0x0a, 0x03, 0xb5, 0x55, 0x2b, 0x6f, 0x96, 0xa7,
+	0x3a, 0xd3, 0x0e, 0x69, 0xe4, 0x67, 0x9f, 0x36, 0x66, 0x3f, 0xd4, 0xd8, 0x27, 0x4f, 0xb1, 0x87,
+	0xea, 0xbe, 0x90, 0x7c, 0x83, 0x8b, 0xfd, 0x28, 0x49, 0xa0, 0xef, 0x74, 0xa6, 0xec, 0xc6, 0x71,
+	0x6d, 0x78, 0x16, 0xe2, 0xc4, 0xac, 0x57, 0x89, 0x53, 0x33, 0x47, 0xf2, 0x0c, 0x7a, 0x99, 0xca,
+	0x6c, 0xb1, 0xe3, 0xa8, 0x6f, 0x95, 0xf7, 0x1f, 0x33, 0x08, 0xd2, 0x42, 0xdf, 0xaa, 0xe4, 0xf7,
+	0x11, 0x9c, 0xef, 0xcb, 0x4d, 0x28, 0xb4, 0xe5, 0xce, 0x08, 0x74, 0x3b, 0xda, 0x1a, 0x46, 0xa3,
+	0x2e, 0xab, 0x4b, 0xf2, 0x0e, 0x2e, 0xa5, 0xdd, 0x2c, 0x53, 0xc5, 0x51, 0xaf, 0x0d, 0xc7, 0x54,
+	0xe0, 0x0d, 0xcf, 0x55, 0xb1, 0x52, 0xc6, 0xd1, 0xd8, 0xaf, 0xff, 0x22, 0x00, 0x0b, 0xbd, 0x36,
+	0x8b, 0xb2, 0x7d, 0x1d, 0xba, 0xe4, 0x0d, 0x5c, 0x48, 0xfb, 0xcb, 0x94, 0x0e, 0x1b, 0x73, 0xc7,
+	0x7e, 0xee, 0xbc, 0xee, 0xde, 0x9b, 0x7a, 0x0e, 0xa7, 0xdf, 0x85, 0x4e, 0x79, 0x30, 0xa0, 0x15,
+	0xd2, 0x13, 0x9f, 0xa3, 0x5f, 0xaa, 0x93, 0x5a, 0x2c, 0x1d, 0xa3, 0x13, 0x46, 0x2e, 0x77, 0xb4,
+	0x1d, 0x1c, 0x57, 0x25, 0x19, 0xc1, 0x20, 0x13, 0x5b, 0x9e, 0x69, 0x44, 0x25, 0x39, 0xa6, 0xd6,
+	0x21, 0xed, 0xf8, 0x03, 0x4f, 0x33, 0xb1, 0x9d, 0x7b, 0x79, 0x51, 0xaa, 0xb3, 0xb8, 0x73, 0x34,
+	0x88, 0x66, 0x71, 0x27, 0x1a, 0xc4, 0xc9, 0x0c, 0xe8, 0xa1, 0x37, 0x59, 0x9e, 0x25, 0xa4, 0x2c,
+	0x14, 0x86, 0xad, 0x77, 0x59, 0x5d, 0x92, 0x73, 0x38, 0xfe, 0x29, 0xd2, 0x4d, 0xd8, 0x75, 0x97,
+	0x85, 0x22, 0xf9, 0x02, 0x67, 0x7b, 0x1e, 0x8b, 0xb7, 0xac, 0xd7, 0x46, 0x15, 0x58, 0x2f, 0xb9,
+	0x2a, 0xc9, 0x10, 0x1e, 0xe4, 0xaa, 0xd0, 0x56, 0x56, 0x77, 0x5b, 0x7e, 0x2d, 0x62, 0x10, 0xb4,
+	0xf2, 0x6a, 0x97, 0x27, 0xfe, 0x87, 0x7e, 0xfd, 0x6f, 0x00, 0xd9, 0x25, 0x7d, 0x46, 0xe1, 0x03,
+	0x00, 0x00,
 }
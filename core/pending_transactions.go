@@ -0,0 +1,210 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+)
+
+var (
+	// BlockGasLimit the maximum sum of the gas limits of the txs in a block before DynamicGasLimit fork,
+	// the dynamic limit starts from it.
+	BlockGasLimit, _ = util.NewUint128FromString("500000000000")
+
+	// PendingRefillInterval the min interval to reload the pending view exhausted before the deadline of packing.
+	PendingRefillInterval = 50 * time.Millisecond
+)
+
+// txPriceHeap is a max heap of txs by gas price.
+type txPriceHeap []*Transaction
+
+func (h txPriceHeap) Len() int { return len(h) }
+
+func (h txPriceHeap) Less(i, j int) bool { return h[i].gasPrice.Cmp(h[j].gasPrice) > 0 }
+
+func (h txPriceHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *txPriceHeap) Push(x interface{}) { *h = append(*h, x.(*Transaction)) }
+
+func (h *txPriceHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	tx := old[n-1]
+	*h = old[:n-1]
+	return tx
+}
+
+// PendingTransactions is a sorted view of the pending txs for the block producer,
+// the txs with higher gas price come first while the txs from one account keep their nonce order.
+// It's not thread safe.
+type PendingTransactions struct {
+	pool    *TransactionPool
+	heads   txPriceHeap                        // the next tx of each account
+	txs     map[byteutils.HexHash]Transactions // the following txs of each account in nonce order
+	gasLeft *util.Uint128
+}
+
+// Pending return a sorted view of the pending txs whose gas price is above the block floor,
+// packing at most gasLimit gas.
+func (pool *TransactionPool) Pending(gasLimit *util.Uint128) *PendingTransactions {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	floor := pool.minGasPrice
+	if pool.blockMinGasPrice != nil {
+		floor = pool.blockMinGasPrice
+	}
+
+	pending := &PendingTransactions{
+		pool:    pool,
+		heads:   make(txPriceHeap, 0, len(pool.buckets)),
+		txs:     make(map[byteutils.HexHash]Transactions),
+		gasLeft: gasLimit,
+	}
+	for slot, bucket := range pool.buckets {
+		txs := make(Transactions, 0, bucket.Len())
+		for i := 0; i < bucket.Len(); i++ {
+			tx := bucket.Index(i).(*Transaction)
+			// the following txs can't be packed before this one.
+			if tx.gasPrice.Cmp(floor) < 0 {
+				break
+			}
+			txs = append(txs, tx)
+		}
+		if len(txs) == 0 {
+			continue
+		}
+		pending.heads = append(pending.heads, txs[0])
+		pending.txs[slot] = txs[1:]
+	}
+	heap.Init(&pending.heads)
+	return pending
+}
+
+// PopWithBlacklist take the tx with the highest gas price whose account is not in the blacklists out of
+// the pool, and reserve its gas limit in the block. The txs which don't fit into the block are skipped
+// with the following ones of the same account.
+func (p *PendingTransactions) PopWithBlacklist(fromBlacklist *sync.Map, toBlacklist *sync.Map) *Transaction {
+	var skipped []*Transaction
+	defer func() {
+		for _, tx := range skipped {
+			heap.Push(&p.heads, tx)
+		}
+	}()
+
+	for p.heads.Len() > 0 {
+		tx := heap.Pop(&p.heads).(*Transaction)
+		if tx.gasLimit.Cmp(p.gasLeft) > 0 {
+			delete(p.txs, tx.from.address.Hex())
+			continue
+		}
+		if fromBlacklist != nil {
+			if _, ok := fromBlacklist.Load(tx.from.address.Hex()); ok {
+				skipped = append(skipped, tx)
+				continue
+			}
+		}
+		if toBlacklist != nil {
+			if _, ok := toBlacklist.Load(tx.to.address.Hex()); ok {
+				skipped = append(skipped, tx)
+				continue
+			}
+		}
+
+		p.shift(tx)
+		// the tx is replaced or packed since the view is created.
+		if !p.pool.take(tx) {
+			continue
+		}
+		p.gasLeft, _ = p.gasLeft.Sub(tx.gasLimit)
+		return tx
+	}
+	return nil
+}
+
+// Refill reload the view from the pool, with the txs arrived or given back since,
+// keeping the gas left for the block.
+func (p *PendingTransactions) Refill() {
+	refilled := p.pool.Pending(p.gasLeft)
+	p.heads = refilled.heads
+	p.txs = refilled.txs
+}
+
+// shift put the following tx of the account to the heads.
+func (p *PendingTransactions) shift(tx *Transaction) {
+	slot := tx.from.address.Hex()
+	txs := p.txs[slot]
+	if len(txs) == 0 {
+		delete(p.txs, slot)
+		return
+	}
+	heap.Push(&p.heads, txs[0])
+	p.txs[slot] = txs[1:]
+}
+
+// Drop remove the following txs of the account, e.g. after its tx failed to be packed.
+func (p *PendingTransactions) Drop(addr *Address) {
+	slot := addr.address.Hex()
+	delete(p.txs, slot)
+	for i, tx := range p.heads {
+		if tx.from.address.Hex() == slot {
+			heap.Remove(&p.heads, i)
+			return
+		}
+	}
+}
+
+// Giveback put a tx which can be packed later back to the view, before the following txs of its account.
+func (p *PendingTransactions) Giveback(tx *Transaction) {
+	p.Refund(tx)
+
+	slot := tx.from.address.Hex()
+	for i, head := range p.heads {
+		if head.from.address.Hex() == slot {
+			heap.Remove(&p.heads, i)
+			p.txs[slot] = append(Transactions{head}, p.txs[slot]...)
+			break
+		}
+	}
+	heap.Push(&p.heads, tx)
+}
+
+// Refund give back the gas reserved by a tx which is not packed.
+func (p *PendingTransactions) Refund(tx *Transaction) {
+	p.gasLeft, _ = p.gasLeft.Add(tx.gasLimit)
+}
+
+// GasLeft return the gas left for more txs.
+func (p *PendingTransactions) GasLeft() *util.Uint128 {
+	return p.gasLeft
+}
+
+// Len return the count of the txs in the view.
+func (p *PendingTransactions) Len() int {
+	size := p.heads.Len()
+	for _, txs := range p.txs {
+		size += len(txs)
+	}
+	return size
+}
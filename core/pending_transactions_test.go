@@ -0,0 +1,133 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nebulasio/go-nebulas/crypto"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPendingTransactions(t *testing.T) {
+	ks := keystore.DefaultKS
+	var addrs []*Address
+	var signatures []keystore.Signature
+	for i := 0; i < 2; i++ {
+		priv := secp256k1.GeneratePrivateKey()
+		pubdata, _ := priv.PublicKey().Encoded()
+		addr, _ := NewAddressFromPublicKey(pubdata)
+		ks.SetKey(addr.String(), priv, []byte("passphrase"))
+		ks.Unlock(addr.String(), []byte("passphrase"), time.Second*60*60*24*365)
+		key, _ := ks.GetUnlocked(addr.String())
+		signature, _ := crypto.NewSignature(keystore.SECP256K1)
+		signature.InitSign(key.(keystore.PrivateKey))
+		addrs = append(addrs, addr)
+		signatures = append(signatures, signature)
+	}
+
+	neb := testNeb(t)
+	bc := neb.chain
+	txPool := bc.txPool
+
+	gasLimit, _ := util.NewUint128FromInt(200000)
+	price := func(n int64) *util.Uint128 {
+		p, _ := util.NewUint128FromInt(n)
+		p, _ = TransactionGasPrice.Mul(p)
+		return p
+	}
+	newTx := func(from int, nonce uint64, gasPrice *util.Uint128) *Transaction {
+		tx, _ := NewTransaction(bc.ChainID(), addrs[from], &Address{[]byte("to")}, util.NewUint128(), nonce, TxPayloadBinaryType, []byte("nas"), gasPrice, gasLimit)
+		assert.Nil(t, tx.Sign(signatures[from]))
+		return tx
+	}
+
+	// the nonce order of an account goes before the gas price.
+	tx1 := newTx(0, 1, price(2))
+	tx2 := newTx(0, 2, price(5))
+	tx3 := newTx(1, 1, price(3))
+	tx4 := newTx(1, 2, price(1))
+	for _, tx := range []*Transaction{tx1, tx2, tx3, tx4} {
+		assert.Nil(t, txPool.Push(tx))
+	}
+
+	pending := txPool.Pending(BlockGasLimit)
+	assert.Equal(t, 4, pending.Len())
+	assert.Equal(t, tx3.hash, pending.PopWithBlacklist(nil, nil).hash)
+	assert.Equal(t, tx1.hash, pending.PopWithBlacklist(nil, nil).hash)
+	assert.Equal(t, tx2.hash, pending.PopWithBlacklist(nil, nil).hash)
+	assert.Equal(t, tx4.hash, pending.PopWithBlacklist(nil, nil).hash)
+	assert.Nil(t, pending.PopWithBlacklist(nil, nil))
+	// the popped txs are taken out of the pool.
+	assert.True(t, txPool.Empty())
+
+	for _, tx := range []*Transaction{tx1, tx2, tx3, tx4} {
+		assert.Nil(t, txPool.Push(tx))
+	}
+
+	// the blacklisted accounts are skipped.
+	pending = txPool.Pending(BlockGasLimit)
+	blacklist := new(sync.Map)
+	blacklist.Store(addrs[1].address.Hex(), true)
+	assert.Equal(t, tx1.hash, pending.PopWithBlacklist(blacklist, nil).hash)
+
+	// the given back tx goes before the following txs of its account.
+	pending.Giveback(tx1)
+	assert.Nil(t, txPool.Push(tx1))
+	assert.Equal(t, tx1.hash, pending.PopWithBlacklist(blacklist, nil).hash)
+
+	// the dropped account is skipped.
+	pending.Drop(addrs[0])
+	assert.Nil(t, pending.PopWithBlacklist(blacklist, nil))
+	assert.Equal(t, tx3.hash, pending.PopWithBlacklist(nil, nil).hash)
+
+	// the txs not fitting into the block are skipped with the following ones.
+	txPool.Del(tx4)
+	txPool.Del(tx2)
+	for _, tx := range []*Transaction{newTx(0, 1, price(1)), newTx(1, 1, price(2))} {
+		assert.Nil(t, txPool.Push(tx))
+	}
+	pending = txPool.Pending(gasLimit)
+	assert.Equal(t, addrs[1].address, pending.PopWithBlacklist(nil, nil).from.address)
+	assert.Equal(t, 0, pending.GasLeft().Cmp(util.NewUint128()))
+	assert.Nil(t, pending.PopWithBlacklist(nil, nil))
+
+	// the exhausted view takes the txs arrived since with the gas left.
+	tx5 := newTx(0, 1, price(3))
+	pending = txPool.Pending(BlockGasLimit)
+	assert.Equal(t, addrs[0].address, pending.PopWithBlacklist(nil, nil).from.address)
+	assert.Nil(t, pending.PopWithBlacklist(nil, nil))
+	assert.Nil(t, txPool.Push(tx5))
+	gasLeft := pending.GasLeft()
+	pending.Refill()
+	assert.Equal(t, 1, pending.Len())
+	assert.Equal(t, tx5.hash, pending.PopWithBlacklist(nil, nil).hash)
+	expected, _ := gasLeft.Sub(gasLimit)
+	assert.Equal(t, 0, pending.GasLeft().Cmp(expected))
+
+	// the txs below the block floor are not packed.
+	assert.Nil(t, txPool.SetBlockMinGasPrice(price(2)))
+	pending = txPool.Pending(BlockGasLimit)
+	assert.Equal(t, 0, pending.Len())
+}
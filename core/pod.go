@@ -0,0 +1,260 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+
+	"github.com/nebulasio/go-nebulas/core/state"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+)
+
+// Proof-of-Devotion: an account registers as a candidate by bonding a deposit,
+// and the other accounts delegate their stake to the candidates. Both are taken
+// from the balances and locked until withdrawn. Since ProofOfDevotionHeight the
+// candidates with the most stake make up each new dynasty, see CandidateRankings.
+// The storage:
+// registry account: candidate_<candidate> -> the candidate address
+// candidate account: deposit -> the bonded deposit, votes -> the stake delegated to it
+// delegator account: delegated_<candidate> -> the stake delegated to the candidate
+
+// Keys in account storage
+const (
+	CandidateVotesKey = "votes"
+	CandidatePrefix   = "candidate_"
+	DelegatedPrefix   = "delegated_"
+)
+
+// Stake actions
+const (
+	RegisterAction = "register"
+	DelegateAction = "delegate"
+	WithdrawAction = "withdraw"
+)
+
+var (
+	// PoDRegistryAddress the account keeping the registered candidates, nobody owns its key.
+	PoDRegistryAddress, _ = NewContractAddressFromData([]byte("proof-of-devotion"), byteutils.FromUint64(0))
+
+	// MinCandidateDeposit the deposit a candidate bonds at least, 10000 NAS.
+	MinCandidateDeposit, _ = util.NewUint128FromString("10000000000000000000000")
+)
+
+// Candidate the stake of a registered candidate
+type Candidate struct {
+	Address *Address
+	Deposit *util.Uint128
+	Votes   *util.Uint128
+}
+
+// Stake return the deposit plus the votes of the candidate
+func (c *Candidate) Stake() *util.Uint128 {
+	stake, err := c.Deposit.Add(c.Votes)
+	if err != nil {
+		return c.Deposit
+	}
+	return stake
+}
+
+// StakeEvent the payload of TopicStake.
+type StakeEvent struct {
+	Action    string `json:"action"`
+	Account   string `json:"account"`
+	Candidate string `json:"candidate"`
+	Value     string `json:"value"`
+	Stake     string `json:"stake"`
+}
+
+func candidateKey(candidate *Address) []byte {
+	return append([]byte(CandidatePrefix), candidate.address...)
+}
+
+func delegatedKey(candidate *Address) []byte {
+	return append([]byte(DelegatedPrefix), candidate.address...)
+}
+
+// storageUint128 return the value at the key of the account storage, 0 if not found.
+func storageUint128(acc state.Account, key []byte) (*util.Uint128, error) {
+	value, err := acc.Get(key)
+	if err == storage.ErrKeyNotFound {
+		return util.NewUint128(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return util.NewUint128FromFixedSizeByteSlice(value)
+}
+
+func putStorageUint128(acc state.Account, key []byte, value *util.Uint128) error {
+	data, err := value.ToFixedSizeByteSlice()
+	if err != nil {
+		return err
+	}
+	return acc.Put(key, data)
+}
+
+// parseStakeValue parse the value staked or withdrawn by a tx, it should be positive.
+func parseStakeValue(value string) (*util.Uint128, error) {
+	v, err := util.NewUint128FromString(value)
+	if err != nil {
+		return nil, ErrInvalidStakeValue
+	}
+	if v.Cmp(util.NewUint128()) <= 0 {
+		return nil, ErrInvalidStakeValue
+	}
+	return v, nil
+}
+
+// lockStake take the value from the balance of the sender, which should still
+// afford the limited fee of the tx.
+func lockStake(acc state.Account, tx *Transaction, value *util.Uint128) error {
+	limitedFee, err := tx.gasLimit.Mul(tx.gasPrice)
+	if err != nil {
+		return err
+	}
+	required, err := limitedFee.Add(value)
+	if err != nil {
+		return err
+	}
+	if acc.Balance().Cmp(required) < 0 {
+		return ErrInsufficientBalance
+	}
+	return acc.SubBalance(value)
+}
+
+// CandidateVotes return the stake delegated to the candidate account.
+func CandidateVotes(acc state.Account) (*util.Uint128, error) {
+	return storageUint128(acc, []byte(CandidateVotesKey))
+}
+
+// isCandidate check if the candidate is registered.
+func isCandidate(ws WorldState, candidate *Address) (bool, error) {
+	registry, err := ws.GetOrCreateUserAccount(PoDRegistryAddress.address)
+	if err != nil {
+		return false, err
+	}
+	if _, err := registry.Get(candidateKey(candidate)); err != nil {
+		if err == storage.ErrKeyNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// candidateStake return the total stake of the candidate account.
+func candidateStake(acc state.Account) (*util.Uint128, error) {
+	deposit, err := ValidatorDeposit(acc)
+	if err != nil {
+		return nil, err
+	}
+	votes, err := CandidateVotes(acc)
+	if err != nil {
+		return nil, err
+	}
+	return deposit.Add(votes)
+}
+
+func recordStakeEvent(ws WorldState, tx *Transaction, action string, candidate *Address, value *util.Uint128, acc state.Account) error {
+	stake, err := candidateStake(acc)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(&StakeEvent{
+		Action:    action,
+		Account:   tx.from.String(),
+		Candidate: candidate.String(),
+		Value:     value.String(),
+		Stake:     stake.String(),
+	})
+	if err != nil {
+		return err
+	}
+	ws.RecordEvent(tx.hash, &state.Event{Topic: TopicStake, Data: string(data)})
+	return nil
+}
+
+// CandidateRankings return the registered candidates by their stake, highest
+// first, the jailed ones and those under the minimum deposit are left out. The
+// world state is read from a clone, it is left untouched.
+func CandidateRankings(worldState state.WorldState, height uint64) ([]*Candidate, error) {
+	ws, err := worldState.Clone()
+	if err != nil {
+		return nil, err
+	}
+	registry, err := ws.GetOrCreateUserAccount(PoDRegistryAddress.address)
+	if err != nil {
+		return nil, err
+	}
+	candidates := []*Candidate{}
+	iter, err := registry.Iterator([]byte(CandidatePrefix))
+	if err == storage.ErrKeyNotFound {
+		return candidates, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	registered := [][]byte{}
+	exist, err := iter.Next()
+	for exist {
+		registered = append(registered, iter.Value())
+		exist, err = iter.Next()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range registered {
+		addr, err := AddressParseFromBytes(v)
+		if err != nil {
+			return nil, err
+		}
+		acc, err := ws.GetOrCreateUserAccount(addr.address)
+		if err != nil {
+			return nil, err
+		}
+		deposit, err := ValidatorDeposit(acc)
+		if err != nil {
+			return nil, err
+		}
+		votes, err := CandidateVotes(acc)
+		if err != nil {
+			return nil, err
+		}
+		if deposit.Cmp(MinCandidateDeposit) >= 0 && height >= JailedUntil(acc) {
+			candidates = append(candidates, &Candidate{
+				Address: addr,
+				Deposit: deposit,
+				Votes:   votes,
+			})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if cmp := candidates[i].Stake().Cmp(candidates[j].Stake()); cmp != 0 {
+			return cmp > 0
+		}
+		return bytes.Compare(candidates[i].Address.address, candidates[j].Address.address) < 0
+	})
+	return candidates, nil
+}
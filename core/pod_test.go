@@ -0,0 +1,201 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func mockStakeTransaction(t *testing.T, chainID uint32, from *Address, payloadType string, payload TxPayload) *Transaction {
+	data, err := payload.ToBytes()
+	assert.Nil(t, err)
+	tx, err := NewTransaction(chainID, from, from, util.NewUint128(), 1, payloadType, data, TransactionGasPrice, TransactionMaxGas)
+	assert.Nil(t, err)
+	return tx
+}
+
+func TestLoadStakePayloads(t *testing.T) {
+	_, err := NewCandidatePayload("login", "100")
+	assert.Equal(t, ErrInvalidCandidatePayloadAction, err)
+	_, err = NewCandidatePayload(RegisterAction, "0")
+	assert.Equal(t, ErrInvalidStakeValue, err)
+	_, err = NewDelegatePayload("undo", mockAddress().String(), "100")
+	assert.Equal(t, ErrInvalidDelegatePayloadAction, err)
+	_, err = NewDelegatePayload(DelegateAction, "n1invalid", "100")
+	assert.NotNil(t, err)
+
+	delegatee := mockAddress().String()
+	payload, err := NewDelegatePayload(WithdrawAction, delegatee, "100")
+	assert.Nil(t, err)
+	data, err := payload.ToBytes()
+	assert.Nil(t, err)
+	loaded, err := LoadDelegatePayload(data)
+	assert.Nil(t, err)
+	assert.Equal(t, payload, loaded)
+}
+
+func TestStakePayloads_Execute(t *testing.T) {
+	minDeposit := MinCandidateDeposit
+	defer func() { MinCandidateDeposit = minDeposit }()
+	MinCandidateDeposit = util.NewUint128FromUint(100)
+
+	neb := testNeb(t)
+	block := neb.chain.tailBlock
+	assert.Nil(t, block.Begin())
+	ws := &eventsRecorder{WorldState: block.WorldState()}
+
+	funds, err := util.NewUint128FromString("1000000000000000000")
+	assert.Nil(t, err)
+	addrs := []*Address{mockAddress(), mockAddress(), mockAddress()}
+	for _, addr := range addrs {
+		acc, err := ws.GetOrCreateUserAccount(addr.Bytes())
+		assert.Nil(t, err)
+		assert.Nil(t, acc.AddBalance(funds))
+	}
+	candidate, other, delegator := addrs[0], addrs[1], addrs[2]
+
+	execute := func(from *Address, payloadType string, payload TxPayload) error {
+		tx := mockStakeTransaction(t, neb.chain.ChainID(), from, payloadType, payload)
+		_, _, err := payload.Execute(util.NewUint128(), tx, block, ws)
+		return err
+	}
+	register := func(from *Address, value string) error {
+		payload, err := NewCandidatePayload(RegisterAction, value)
+		assert.Nil(t, err)
+		return execute(from, TxPayloadCandidateType, payload)
+	}
+	delegate := func(from *Address, action string, to *Address, value string) error {
+		payload, err := NewDelegatePayload(action, to.String(), value)
+		assert.Nil(t, err)
+		return execute(from, TxPayloadDelegateType, payload)
+	}
+
+	assert.Equal(t, ErrDepositBelowMinimum, register(candidate, "99"))
+	assert.Nil(t, register(candidate, "100"))
+	assert.Nil(t, register(other, "150"))
+	acc, err := ws.GetOrCreateUserAccount(candidate.Bytes())
+	assert.Nil(t, err)
+	deposit, err := ValidatorDeposit(acc)
+	assert.Nil(t, err)
+	assert.Equal(t, util.NewUint128FromUint(100), deposit)
+	balance, err := funds.Sub(util.NewUint128FromUint(100))
+	assert.Nil(t, err)
+	assert.Equal(t, balance, acc.Balance())
+
+	assert.Equal(t, ErrInvalidDelegateToNonCandidate, delegate(delegator, DelegateAction, delegator, "10"))
+	assert.Equal(t, ErrInvalidUnDelegateFromNonDelegatee, delegate(delegator, WithdrawAction, candidate, "10"))
+	assert.Nil(t, delegate(delegator, DelegateAction, candidate, "80"))
+	assert.Equal(t, ErrInsufficientDelegation, delegate(delegator, WithdrawAction, candidate, "81"))
+	assert.Nil(t, delegate(delegator, WithdrawAction, candidate, "20"))
+	acc, err = ws.GetOrCreateUserAccount(candidate.Bytes())
+	assert.Nil(t, err)
+	votes, err := CandidateVotes(acc)
+	assert.Nil(t, err)
+	assert.Equal(t, util.NewUint128FromUint(60), votes)
+
+	payload, err := ParseEventPayload(ws.events[len(ws.events)-1])
+	assert.Nil(t, err)
+	assert.Equal(t, &StakeEvent{
+		Action:    WithdrawAction,
+		Account:   delegator.String(),
+		Candidate: candidate.String(),
+		Value:     "20",
+		Stake:     "160",
+	}, payload)
+
+	withdraw, err := NewCandidatePayload(WithdrawAction, "200")
+	assert.Nil(t, err)
+	assert.Equal(t, ErrInsufficientDeposit, execute(other, TxPayloadCandidateType, withdraw))
+	assert.Nil(t, block.WorldState().Commit())
+
+	// candidate stakes 100 + 60, other 150.
+	rankings, err := CandidateRankings(block.WorldState(), block.Height())
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(rankings))
+	assert.Equal(t, candidate.String(), rankings[0].Address.String())
+	assert.Equal(t, other.String(), rankings[1].Address.String())
+
+	// a jailed candidate is left out.
+	assert.Nil(t, block.Begin())
+	acc, err = ws.GetOrCreateUserAccount(candidate.Bytes())
+	assert.Nil(t, err)
+	assert.Nil(t, acc.Put([]byte(ValidatorJailKey), byteutils.FromUint64(block.Height()+1)))
+	assert.Nil(t, block.WorldState().Commit())
+	rankings, err = CandidateRankings(block.WorldState(), block.Height())
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(rankings))
+	assert.Equal(t, other.String(), rankings[0].Address.String())
+}
+
+func TestStakeTransactionActivation(t *testing.T) {
+	minDeposit := MinCandidateDeposit
+	defer func() { MinCandidateDeposit = minDeposit }()
+	MinCandidateDeposit = util.NewUint128FromUint(100)
+
+	neb := testNeb(t)
+	bc := neb.chain
+	SetCompatibilityOptions(bc.ChainID())
+	defer SetCompatibilityOptions(TestNetID)
+	assert.Equal(t, LocalProofOfDevotionHeight, ProofOfDevotionHeight)
+
+	candidate := mockAddress()
+	payload, err := NewCandidatePayload(RegisterAction, "100")
+	assert.Nil(t, err)
+	tx := mockStakeTransaction(t, bc.ChainID(), candidate, TxPayloadCandidateType, payload)
+	assert.Nil(t, tx.Sign(mockSignature(t, candidate)))
+
+	block, err := bc.NewBlock(mockAddress())
+	assert.Nil(t, err)
+	assert.Equal(t, LocalProofOfDevotionHeight, block.Height())
+	funds, err := util.NewUint128FromString("1000000000000000000")
+	assert.Nil(t, err)
+	acc, err := block.WorldState().GetOrCreateUserAccount(candidate.Bytes())
+	assert.Nil(t, err)
+	assert.Nil(t, acc.AddBalance(funds))
+
+	// the candidate registers at the activation height and runs for the next dynasty.
+	txWorldState, err := block.WorldState().Prepare(tx.Hash().String())
+	assert.Nil(t, err)
+	giveback, err := VerifyExecution(tx, block, txWorldState)
+	assert.False(t, giveback)
+	assert.Nil(t, err)
+	_, err = txWorldState.CheckAndUpdate()
+	assert.Nil(t, err)
+
+	events, err := block.WorldState().FetchEvents(tx.Hash())
+	assert.Nil(t, err)
+	for _, event := range events {
+		if event.Topic == TopicTransactionExecutionResult {
+			txEvent := TransactionEventV2{}
+			assert.Nil(t, json.Unmarshal([]byte(event.Data), &txEvent))
+			assert.Equal(t, int8(TxExecutionSuccess), txEvent.Status, txEvent.Error)
+		}
+	}
+	assert.Nil(t, block.WorldState().Commit())
+
+	rankings, err := CandidateRankings(block.WorldState(), block.Height())
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(rankings))
+	assert.Equal(t, candidate.String(), rankings[0].Address.String())
+}
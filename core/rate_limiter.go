@@ -0,0 +1,79 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"sync"
+	"time"
+)
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// peerRateLimiter allows each peer rate requests per second, in bursts of at most burst requests.
+type peerRateLimiter struct {
+	rate    float64
+	burst   float64
+	buckets map[string]*tokenBucket
+	mu      sync.Mutex
+}
+
+func newPeerRateLimiter(rate, burst float64) *peerRateLimiter {
+	return &peerRateLimiter{
+		rate:    rate,
+		burst:   burst,
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+// allow takes a token of the peer, it reports false if none is left.
+func (l *peerRateLimiter) allow(peer string, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[peer]
+	if !ok {
+		b = &tokenBucket{tokens: l.burst, last: now}
+		l.buckets[peer] = b
+	}
+	b.tokens += now.Sub(b.last).Seconds() * l.rate
+	if b.tokens > l.burst {
+		b.tokens = l.burst
+	}
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// evict drops the buckets of the peers which are full again.
+func (l *peerRateLimiter) evict(now time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for peer, b := range l.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, peer)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"encoding/json"
+
+	"github.com/nebulasio/go-nebulas/core/state"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// ReceiptPrefix the prefix of receipt key in storage.
+const ReceiptPrefix = "receipt_"
+
+// ReceiptEvent event emitted by a transaction execution.
+type ReceiptEvent struct {
+	Topic string `json:"topic"`
+	Data  string `json:"data"`
+}
+
+// Receipt the execution outcome of a transaction on canonical chain.
+type Receipt struct {
+	Hash            string          `json:"hash"`
+	BlockHash       string          `json:"block_hash"`
+	BlockHeight     uint64          `json:"block_height"`
+	Index           int             `json:"index"`
+	Status          int8            `json:"status"`
+	GasUsed         string          `json:"gas_used"`
+	Error           string          `json:"error"`
+	ExecuteResult   string          `json:"execute_result"`
+	ContractAddress string          `json:"contract_address"`
+	Events          []*ReceiptEvent `json:"events"`
+}
+
+func receiptKey(txHash byteutils.Hash) []byte {
+	return append([]byte(ReceiptPrefix), txHash...)
+}
+
+// NewReceipts return the receipts of the executed txs in the block.
+func NewReceipts(block *Block) ([]*Receipt, error) {
+	worldState, err := block.WorldState().Clone()
+	if err != nil {
+		return nil, err
+	}
+
+	receipts := make([]*Receipt, 0, len(block.transactions))
+	for idx, tx := range block.transactions {
+		events, err := worldState.FetchEvents(tx.hash)
+		if err != nil {
+			return nil, err
+		}
+		// the last event is the execution result.
+		if len(events) == 0 || events[len(events)-1].Topic != TopicTransactionExecutionResult {
+			logging.VLog().WithFields(logrus.Fields{
+				"tx":     tx.hash,
+				"block":  block,
+				"events": events,
+			}).Debug("Failed to locate the result event, skip the receipt.")
+			continue
+		}
+		receipt, err := newReceipt(block, idx, tx, events)
+		if err != nil {
+			return nil, err
+		}
+		receipts = append(receipts, receipt)
+	}
+	return receipts, nil
+}
+
+func newReceipt(block *Block, idx int, tx *Transaction, events []*state.Event) (*Receipt, error) {
+	receipt := &Receipt{
+		Hash:        tx.hash.String(),
+		BlockHash:   block.Hash().String(),
+		BlockHeight: block.Height(),
+		Index:       idx,
+		Status:      TxExecutionFailed,
+		Events:      make([]*ReceiptEvent, len(events)),
+	}
+	for i, e := range events {
+		receipt.Events[i] = &ReceiptEvent{Topic: e.Topic, Data: e.Data}
+	}
+
+	txEvent := TransactionEventV2{}
+	if err := json.Unmarshal([]byte(events[len(events)-1].Data), &txEvent); err != nil {
+		return nil, err
+	}
+	receipt.Status = txEvent.Status
+	receipt.GasUsed = txEvent.GasUsed
+	receipt.Error = txEvent.Error
+	receipt.ExecuteResult = txEvent.ExecuteResult
+
+	if tx.Type() == TxPayloadDeployType && receipt.Status == TxExecutionSuccess {
+		contract, err := tx.GenerateContractAddress()
+		if err != nil {
+			return nil, err
+		}
+		receipt.ContractAddress = contract.String()
+	}
+	return receipt, nil
+}
+
+// storeReceipts record the receipts of block's txs, called when the block is on canonical chain.
+func (bc *BlockChain) storeReceipts(block *Block) {
+	receipts, err := NewReceipts(block)
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"block": block,
+			"err":   err,
+		}).Debug("Failed to generate receipts.")
+		return
+	}
+	for _, receipt := range receipts {
+		value, err := json.Marshal(receipt)
+		if err != nil {
+			logging.VLog().WithFields(logrus.Fields{
+				"receipt": receipt,
+				"err":     err,
+			}).Debug("Failed to marshal receipt.")
+			continue
+		}
+		hash, err := byteutils.FromHex(receipt.Hash)
+		if err != nil {
+			continue
+		}
+		if err := bc.receiptStorage.Put(receiptKey(hash), value); err != nil {
+			logging.VLog().WithFields(logrus.Fields{
+				"receipt": receipt,
+				"err":     err,
+			}).Debug("Failed to store receipt.")
+		}
+	}
+}
+
+// deleteReceipts remove the receipts of block's txs, called when the block is reverted.
+func (bc *BlockChain) deleteReceipts(block *Block) {
+	for _, tx := range block.transactions {
+		if err := bc.receiptStorage.Del(receiptKey(tx.hash)); err != nil {
+			logging.VLog().WithFields(logrus.Fields{
+				"tx":    tx.hash,
+				"block": block,
+				"err":   err,
+			}).Debug("Failed to delete receipt.")
+		}
+	}
+}
+
+// GetReceipt return the receipt of the tx on canonical chain.
+func (bc *BlockChain) GetReceipt(txHash byteutils.Hash) (*Receipt, error) {
+	value, err := bc.receiptStorage.Get(receiptKey(txHash))
+	if err != nil {
+		return nil, err
+	}
+	receipt := new(Receipt)
+	if err := json.Unmarshal(value, receipt); err != nil {
+		return nil, err
+	}
+	return receipt, nil
+}
@@ -0,0 +1,75 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/core/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewReceipt(t *testing.T) {
+	neb := testNeb(t)
+	bc := neb.chain
+
+	block := bc.tailBlock
+	deployTx := mockDeployTransaction(bc.ChainID(), 1)
+	events := []*state.Event{
+		{Topic: TopicTransferFromContract, Data: `{"amount":"1"}`},
+		{Topic: TopicTransactionExecutionResult, Data: `{"hash":"","status":1,"gas_used":"20000","error":"","execute_result":"\"\""}`},
+	}
+
+	receipt, err := newReceipt(block, 0, deployTx, events)
+	assert.Nil(t, err)
+	assert.Equal(t, int8(TxExecutionSuccess), receipt.Status)
+	assert.Equal(t, "20000", receipt.GasUsed)
+	assert.Equal(t, block.Hash().String(), receipt.BlockHash)
+	assert.Equal(t, 2, len(receipt.Events))
+
+	contract, err := deployTx.GenerateContractAddress()
+	assert.Nil(t, err)
+	assert.Equal(t, contract.String(), receipt.ContractAddress)
+
+	// failed deploy has no contract.
+	events[1].Data = `{"hash":"","status":0,"gas_used":"20000","error":"out of gas limit","execute_result":""}`
+	receipt, err = newReceipt(block, 0, deployTx, events)
+	assert.Nil(t, err)
+	assert.Equal(t, int8(TxExecutionFailed), receipt.Status)
+	assert.Equal(t, "out of gas limit", receipt.Error)
+	assert.Equal(t, "", receipt.ContractAddress)
+}
+
+func TestStoreReceipts(t *testing.T) {
+	neb := testNeb(t)
+	bc := neb.chain
+
+	tx := mockNormalTransaction(bc.ChainID(), 1)
+	receipt := &Receipt{Hash: tx.Hash().String(), Status: TxExecutionSuccess, GasUsed: "20000"}
+	assert.Nil(t, bc.storage.Put(receiptKey(tx.Hash()), []byte(`{"hash":"`+receipt.Hash+`","status":1,"gas_used":"20000"}`)))
+
+	stored, err := bc.GetReceipt(tx.Hash())
+	assert.Nil(t, err)
+	assert.Equal(t, receipt.Hash, stored.Hash)
+	assert.Equal(t, receipt.GasUsed, stored.GasUsed)
+
+	bc.deleteReceipts(&Block{transactions: Transactions{tx}})
+	_, err = bc.GetReceipt(tx.Hash())
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,199 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"encoding/json"
+
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/core/state"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// A validator caught by a double sign or a downtime evidence loses a part of its
+// bonded deposit, which is burnt, and is jailed: it can't propose any block until
+// the height it is released at. The storage of the validator account:
+// deposit -> the bonded deposit
+// jailed_until -> the height the validator is released at
+
+// Validator keys in account storage
+const (
+	ValidatorDepositKey = "deposit"
+	ValidatorJailKey    = "jailed_until"
+)
+
+// Slash reasons
+const (
+	SlashDoubleSign = "doubleSign"
+	SlashDowntime   = "downtime"
+)
+
+// SlashingSchedule the penalties of the validators, set by the genesis conf of the chain.
+type SlashingSchedule struct {
+	DoubleSignPercent uint32
+	DowntimePercent   uint32
+	JailDynasties     uint64
+}
+
+// DefaultSlashingSchedule the protocol default of the penalties.
+var DefaultSlashingSchedule = SlashingSchedule{
+	DoubleSignPercent: 20,
+	DowntimePercent:   1,
+	JailDynasties:     2,
+}
+
+var (
+	slashingSchedule = DefaultSlashingSchedule
+	jailBlocks       uint64
+)
+
+// SetSlashingSchedule read the penalties of the genesis conf, the fields not set
+// keep the protocol default. A dynasty lasts blocksInDynasty blocks.
+func SetSlashingSchedule(conf *corepb.GenesisConsensusDpos, blocksInDynasty uint64) error {
+	schedule := DefaultSlashingSchedule
+	if conf != nil {
+		if conf.DoubleSignSlashPercent > 0 {
+			schedule.DoubleSignPercent = conf.DoubleSignSlashPercent
+		}
+		if conf.DowntimeSlashPercent > 0 {
+			schedule.DowntimePercent = conf.DowntimeSlashPercent
+		}
+		if conf.JailDynasties > 0 {
+			schedule.JailDynasties = conf.JailDynasties
+		}
+	}
+	if schedule.DoubleSignPercent > 100 || schedule.DowntimePercent > 100 {
+		return ErrInvalidSlashingSchedule
+	}
+	slashingSchedule = schedule
+	jailBlocks = schedule.JailDynasties * blocksInDynasty
+
+	logging.CLog().WithFields(logrus.Fields{
+		"doubleSignPercent": schedule.DoubleSignPercent,
+		"downtimePercent":   schedule.DowntimePercent,
+		"jailDynasties":     schedule.JailDynasties,
+		"jailBlocks":        jailBlocks,
+	}).Info("Set slashing schedule.")
+	return nil
+}
+
+// ValidatorDeposit return the bonded deposit of the validator account.
+func ValidatorDeposit(acc state.Account) (*util.Uint128, error) {
+	value, err := acc.Get([]byte(ValidatorDepositKey))
+	if err != nil {
+		return util.NewUint128(), nil
+	}
+	return util.NewUint128FromFixedSizeByteSlice(value)
+}
+
+// SetValidatorDeposit set the bonded deposit of the validator account.
+func SetValidatorDeposit(acc state.Account, deposit *util.Uint128) error {
+	value, err := deposit.ToFixedSizeByteSlice()
+	if err != nil {
+		return err
+	}
+	return acc.Put([]byte(ValidatorDepositKey), value)
+}
+
+// JailedUntil return the height the validator account is released at, 0 if it was never jailed.
+func JailedUntil(acc state.Account) uint64 {
+	value, err := acc.Get([]byte(ValidatorJailKey))
+	if err != nil {
+		return 0
+	}
+	return byteutils.Uint64(value)
+}
+
+// IsJailed check if the validator is jailed at the height in the state of the block.
+func IsJailed(block *Block, validator byteutils.Hash, height uint64) bool {
+	acc, err := block.GetAccount(validator)
+	if err != nil {
+		return false
+	}
+	return height < JailedUntil(acc)
+}
+
+// SlashEvent the payload of TopicSlash.
+type SlashEvent struct {
+	Validator   string `json:"validator"`
+	Reason      string `json:"reason"`
+	Slashed     string `json:"slashed"`
+	Deposit     string `json:"deposit"`
+	JailedUntil uint64 `json:"jailed_until"`
+}
+
+// Slash burn a part of the deposit of the validator for the reason, and jail it
+// from the height for the dynasties of the schedule.
+func Slash(ws WorldState, txHash byteutils.Hash, validator *Address, reason string, height uint64) error {
+	var percent uint32
+	switch reason {
+	case SlashDoubleSign:
+		percent = slashingSchedule.DoubleSignPercent
+	case SlashDowntime:
+		percent = slashingSchedule.DowntimePercent
+	default:
+		return ErrInvalidArgument
+	}
+
+	acc, err := ws.GetOrCreateUserAccount(validator.address)
+	if err != nil {
+		return err
+	}
+	deposit, err := ValidatorDeposit(acc)
+	if err != nil {
+		return err
+	}
+	slashed, err := deposit.Mul(util.NewUint128FromUint(uint64(percent)))
+	if err != nil {
+		return err
+	}
+	if slashed, err = slashed.Div(util.NewUint128FromUint(100)); err != nil {
+		return err
+	}
+	if deposit, err = deposit.Sub(slashed); err != nil {
+		return err
+	}
+	if err := SetValidatorDeposit(acc, deposit); err != nil {
+		return err
+	}
+
+	jailedUntil := height + jailBlocks
+	if released := JailedUntil(acc); released > jailedUntil {
+		jailedUntil = released
+	}
+	if err := acc.Put([]byte(ValidatorJailKey), byteutils.FromUint64(jailedUntil)); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(&SlashEvent{
+		Validator:   validator.String(),
+		Reason:      reason,
+		Slashed:     slashed.String(),
+		Deposit:     deposit.String(),
+		JailedUntil: jailedUntil,
+	})
+	if err != nil {
+		return err
+	}
+	ws.RecordEvent(txHash, &state.Event{Topic: TopicSlash, Data: string(data)})
+	return nil
+}
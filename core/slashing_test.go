@@ -0,0 +1,103 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/core/state"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/stretchr/testify/assert"
+)
+
+type eventsRecorder struct {
+	WorldState
+	events []*state.Event
+}
+
+func (ws *eventsRecorder) RecordEvent(txHash byteutils.Hash, event *state.Event) {
+	ws.events = append(ws.events, event)
+}
+
+func TestSetSlashingSchedule(t *testing.T) {
+	defer SetSlashingSchedule(nil, 0)
+
+	assert.Nil(t, SetSlashingSchedule(nil, 210))
+	assert.Equal(t, DefaultSlashingSchedule, slashingSchedule)
+	assert.Equal(t, DefaultSlashingSchedule.JailDynasties*210, jailBlocks)
+
+	conf := &corepb.GenesisConsensusDpos{DowntimeSlashPercent: 5, JailDynasties: 1}
+	assert.Nil(t, SetSlashingSchedule(conf, 210))
+	assert.Equal(t, DefaultSlashingSchedule.DoubleSignPercent, slashingSchedule.DoubleSignPercent)
+	assert.Equal(t, uint32(5), slashingSchedule.DowntimePercent)
+	assert.Equal(t, uint64(210), jailBlocks)
+
+	conf = &corepb.GenesisConsensusDpos{DoubleSignSlashPercent: 101}
+	assert.Equal(t, ErrInvalidSlashingSchedule, SetSlashingSchedule(conf, 210))
+}
+
+func TestSlash(t *testing.T) {
+	defer SetSlashingSchedule(nil, 0)
+	assert.Nil(t, SetSlashingSchedule(&corepb.GenesisConsensusDpos{DoubleSignSlashPercent: 20, DowntimeSlashPercent: 1, JailDynasties: 2}, 10))
+
+	neb := testNeb(t)
+	ws := &eventsRecorder{WorldState: neb.chain.tailBlock.WorldState()}
+	validator := mockAddress()
+	acc, err := ws.GetOrCreateUserAccount(validator.Bytes())
+	assert.Nil(t, err)
+
+	deposit, err := ValidatorDeposit(acc)
+	assert.Nil(t, err)
+	assert.Equal(t, util.NewUint128(), deposit)
+	assert.Equal(t, uint64(0), JailedUntil(acc))
+	assert.Nil(t, SetValidatorDeposit(acc, util.NewUint128FromUint(1000)))
+
+	txHash := []byte("tx")
+	assert.Nil(t, Slash(ws, txHash, validator, SlashDoubleSign, 100))
+	acc, err = ws.GetOrCreateUserAccount(validator.Bytes())
+	assert.Nil(t, err)
+	deposit, err = ValidatorDeposit(acc)
+	assert.Nil(t, err)
+	assert.Equal(t, util.NewUint128FromUint(800), deposit)
+	assert.Equal(t, uint64(120), JailedUntil(acc))
+
+	// a later slash never shortens the jail.
+	assert.Nil(t, Slash(ws, txHash, validator, SlashDowntime, 90))
+	acc, err = ws.GetOrCreateUserAccount(validator.Bytes())
+	assert.Nil(t, err)
+	deposit, err = ValidatorDeposit(acc)
+	assert.Nil(t, err)
+	assert.Equal(t, util.NewUint128FromUint(792), deposit)
+	assert.Equal(t, uint64(120), JailedUntil(acc))
+
+	assert.Equal(t, 2, len(ws.events))
+	payload, err := ParseEventPayload(ws.events[0])
+	assert.Nil(t, err)
+	assert.Equal(t, &SlashEvent{
+		Validator:   validator.String(),
+		Reason:      SlashDoubleSign,
+		Slashed:     "200",
+		Deposit:     "800",
+		JailedUntil: 120,
+	}, payload)
+
+	assert.Equal(t, ErrInvalidArgument, Slash(ws, txHash, validator, "unknown", 100))
+}
@@ -0,0 +1,400 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/common/trie"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/core/state"
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// snapshot file:
+// magic | version | block length | block
+// chunks: node count | payload length | payload | sha3(payload)
+// payload: node hash | node length | node, repeated node count times
+// the file ends with a chunk of zero node count.
+//
+// storage: key -> value
+// snapshot_height -> height of the snapshot block the chain is started from
+
+// State snapshot constants
+const (
+	SnapshotMagic     = "NEBSNAP"
+	SnapshotVersion   = uint32(2)
+	SnapshotHeight    = "snapshot_height"
+	SnapshotChunkSize = 4096
+
+	// maxSnapshotSectionSize limits the length read from the file, in case of corruption
+	maxSnapshotSectionSize = 1 << 26
+)
+
+type stateRoot struct {
+	root    []byte
+	resolve trie.LeafResolver
+}
+
+// blockStateRoots return the roots of tries which make up the block state.
+func blockStateRoots(block *Block) []*stateRoot {
+	return []*stateRoot{
+		{block.StateRoot(), state.ResolveAccountVars},
+		{block.TxsRoot(), nil},
+		{block.EventsRoot(), nil},
+		{block.ConsensusRoot().GetDynastyRoot(), nil},
+		{block.ConsensusRoot().GetLivenessRoot(), nil},
+	}
+}
+
+// SnapshotHeight return the height of the snapshot block the chain is started from.
+func (bc *BlockChain) SnapshotHeight() (uint64, bool) {
+	value, err := bc.storage.Get([]byte(SnapshotHeight))
+	if err != nil {
+		return 0, false
+	}
+	return byteutils.Uint64(value), true
+}
+
+type snapshotWriter struct {
+	w       io.Writer
+	payload bytes.Buffer
+	count   uint32
+	nodes   uint64
+}
+
+func (sw *snapshotWriter) writeUint32(v uint32) error {
+	buf := make([]byte, 4)
+	binary.BigEndian.PutUint32(buf, v)
+	_, err := sw.w.Write(buf)
+	return err
+}
+
+func (sw *snapshotWriter) writeSection(data []byte) error {
+	if err := sw.writeUint32(uint32(len(data))); err != nil {
+		return err
+	}
+	_, err := sw.w.Write(data)
+	return err
+}
+
+func (sw *snapshotWriter) addNode(h []byte, node []byte) error {
+	sw.payload.Write(h)
+	buf := make([]byte, 4)
+	binary.BigEndian.PutUint32(buf, uint32(len(node)))
+	sw.payload.Write(buf)
+	sw.payload.Write(node)
+	sw.count++
+	sw.nodes++
+	if sw.count >= SnapshotChunkSize {
+		return sw.flush()
+	}
+	return nil
+}
+
+func (sw *snapshotWriter) flush() error {
+	if sw.count == 0 {
+		return nil
+	}
+	if err := sw.writeUint32(sw.count); err != nil {
+		return err
+	}
+	if err := sw.writeSection(sw.payload.Bytes()); err != nil {
+		return err
+	}
+	if _, err := sw.w.Write(hash.Sha3256(sw.payload.Bytes())); err != nil {
+		return err
+	}
+	sw.payload.Reset()
+	sw.count = 0
+	return nil
+}
+
+// ExportSnapshot write the state of the canonical block at the height into w,
+// the trie nodes are written in checksummed chunks after the block.
+func (bc *BlockChain) ExportSnapshot(w io.Writer, height uint64) (*Block, error) {
+	block := bc.GetBlockOnCanonicalChainByHeight(height)
+	if block == nil {
+		return nil, ErrCannotFindBlockAtGivenHeight
+	}
+	pbBlock, err := block.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	data, err := proto.Marshal(pbBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	sw := &snapshotWriter{w: w}
+	if _, err := w.Write([]byte(SnapshotMagic)); err != nil {
+		return nil, err
+	}
+	if err := sw.writeUint32(SnapshotVersion); err != nil {
+		return nil, err
+	}
+	if err := sw.writeSection(data); err != nil {
+		return nil, err
+	}
+
+	visited := make(map[string]bool)
+	for _, v := range blockStateRoots(block) {
+		if err := trie.Walk(bc.stateStorage, v.root, v.resolve, visited, sw.addNode); err != nil {
+			return nil, err
+		}
+	}
+	if err := sw.flush(); err != nil {
+		return nil, err
+	}
+	if err := sw.writeUint32(0); err != nil {
+		return nil, err
+	}
+
+	logging.CLog().WithFields(logrus.Fields{
+		"block": block,
+		"nodes": sw.nodes,
+	}).Info("Exported state snapshot.")
+	return block, nil
+}
+
+func readSnapshotUint32(r io.Reader) (uint32, error) {
+	buf := make([]byte, 4)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint32(buf), nil
+}
+
+func readSnapshotSection(r io.Reader) ([]byte, error) {
+	size, err := readSnapshotUint32(r)
+	if err != nil {
+		return nil, err
+	}
+	if size > maxSnapshotSectionSize {
+		return nil, ErrInvalidSnapshot
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func readSnapshotBlock(r io.Reader) (*Block, error) {
+	magic := make([]byte, len(SnapshotMagic))
+	if _, err := io.ReadFull(r, magic); err != nil {
+		return nil, err
+	}
+	if string(magic) != SnapshotMagic {
+		return nil, ErrInvalidSnapshot
+	}
+	version, err := readSnapshotUint32(r)
+	if err != nil {
+		return nil, err
+	}
+	if version != SnapshotVersion {
+		return nil, ErrInvalidSnapshot
+	}
+
+	data, err := readSnapshotSection(r)
+	if err != nil {
+		return nil, err
+	}
+	pbBlock := new(corepb.Block)
+	if err := proto.Unmarshal(data, pbBlock); err != nil {
+		return nil, err
+	}
+	block := new(Block)
+	if err := block.FromProto(pbBlock); err != nil {
+		return nil, err
+	}
+	wantedHash, err := block.calHash()
+	if err != nil {
+		return nil, err
+	}
+	if !wantedHash.Equals(block.Hash()) {
+		return nil, ErrInvalidSnapshot
+	}
+	return block, nil
+}
+
+// importSnapshotChunks verify and store the trie nodes, return the number of nodes.
+// Each node must hash to the key it is stored under in the exporter.
+func (bc *BlockChain) importSnapshotChunks(r io.Reader) (uint64, error) {
+	nodes := uint64(0)
+	for {
+		count, err := readSnapshotUint32(r)
+		if err != nil {
+			return nodes, err
+		}
+		if count == 0 {
+			return nodes, nil
+		}
+		payload, err := readSnapshotSection(r)
+		if err != nil {
+			return nodes, err
+		}
+		checksum := make([]byte, 32)
+		if _, err := io.ReadFull(r, checksum); err != nil {
+			return nodes, err
+		}
+		if !bytes.Equal(checksum, hash.Sha3256(payload)) {
+			return nodes, ErrSnapshotChecksumMismatch
+		}
+
+		buf := bytes.NewReader(payload)
+		for i := uint32(0); i < count; i++ {
+			h := make([]byte, 32)
+			if _, err := io.ReadFull(buf, h); err != nil {
+				return nodes, ErrInvalidSnapshot
+			}
+			node, err := readSnapshotSection(buf)
+			if err != nil {
+				return nodes, ErrInvalidSnapshot
+			}
+			if !bytes.Equal(h, hash.Sha3256(node)) {
+				return nodes, ErrSnapshotNodeHashMismatch
+			}
+			if err := bc.stateStorage.Put(h, node); err != nil {
+				return nodes, err
+			}
+			nodes++
+		}
+		if buf.Len() > 0 {
+			return nodes, ErrInvalidSnapshot
+		}
+	}
+}
+
+// ImportSnapshot start a fresh chain from the state snapshot in r. The snapshot block
+// must be the trusted one if trusted is given, its ancestors are not imported.
+func (bc *BlockChain) ImportSnapshot(r io.Reader, trusted byteutils.Hash) (*Block, error) {
+	if !bc.tailBlock.Hash().Equals(bc.genesisBlock.Hash()) {
+		return nil, ErrSnapshotOnUsedChain
+	}
+
+	block, err := readSnapshotBlock(r)
+	if err != nil {
+		return nil, err
+	}
+	if block.ChainID() != bc.chainID {
+		return nil, ErrInvalidSnapshot
+	}
+	if len(trusted) > 0 && !trusted.Equals(block.Hash()) {
+		return nil, ErrUntrustedSnapshot
+	}
+
+	nodes, err := bc.importSnapshotChunks(r)
+	if err != nil {
+		return nil, err
+	}
+
+	tail, err := bc.StartFromState(block)
+	if err != nil {
+		return nil, err
+	}
+
+	logging.CLog().WithFields(logrus.Fields{
+		"block": tail,
+		"nodes": nodes,
+	}).Info("Imported state snapshot.")
+	return tail, nil
+}
+
+// StartFromState start a fresh chain from the block, whose state nodes are all in
+// the storage already, its ancestors are not stored.
+func (bc *BlockChain) StartFromState(block *Block) (*Block, error) {
+	if !bc.tailBlock.Hash().Equals(bc.genesisBlock.Hash()) {
+		return nil, ErrSnapshotOnUsedChain
+	}
+	if block.ChainID() != bc.chainID {
+		return nil, ErrInvalidSnapshot
+	}
+
+	// all the nodes of the block state must be there, the ones unused are harmless.
+	visited := make(map[string]bool)
+	for _, v := range blockStateRoots(block) {
+		err := trie.Walk(bc.stateStorage, v.root, v.resolve, visited, func(h []byte, node []byte) error {
+			return nil
+		})
+		if err == storage.ErrKeyNotFound {
+			return nil, ErrIncompleteSnapshot
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := bc.StoreBlockToStorage(block); err != nil {
+		return nil, err
+	}
+	if err := bc.headerStorage.Put(byteutils.FromUint64(block.height), block.Hash()); err != nil {
+		return nil, err
+	}
+	if err := bc.storage.Put([]byte(SnapshotHeight), byteutils.FromUint64(block.height)); err != nil {
+		return nil, err
+	}
+	if err := bc.StoreTailHashToStorage(block); err != nil {
+		return nil, err
+	}
+	if err := bc.StoreLIBHashToStorage(block); err != nil {
+		return nil, err
+	}
+
+	tail, err := LoadBlockFromStorage(block.Hash(), bc)
+	if err != nil {
+		return nil, err
+	}
+	bc.tailBlock = tail
+	bc.lib = tail
+
+	// the index continues from the snapshot block, txs before it are not indexed.
+	if _, ok := bc.TransactionIndexHeight(); ok {
+		if err := bc.indexBlockTransactions(tail); err != nil {
+			return nil, err
+		}
+	}
+	if bc.statePruner != nil {
+		if err := bc.statePruner.Setup(); err != nil {
+			return nil, err
+		}
+	}
+	return tail, nil
+}
+
+// NewStateSync return a NodeSync downloading the state nodes of the block.
+func (bc *BlockChain) NewStateSync(block *Block) *trie.NodeSync {
+	s := trie.NewNodeSync(bc.stateStorage)
+	for _, v := range blockStateRoots(block) {
+		s.AddRoot(v.root, v.resolve)
+	}
+	return s
+}
+
+// StateNode return the state trie node of the hash.
+func (bc *BlockChain) StateNode(h byteutils.Hash) ([]byte, error) {
+	return bc.stateStorage.Get(h)
+}
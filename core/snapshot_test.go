@@ -0,0 +1,96 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshot_ExportImport(t *testing.T) {
+	neb := testNeb(t)
+	bc := neb.chain
+
+	for i := 1; i <= 3; i++ {
+		addr, err := AddressParse(MockDynasty[i])
+		assert.Nil(t, err)
+		block, err := NewBlock(bc.ChainID(), addr, bc.tailBlock)
+		assert.Nil(t, err)
+		block.header.timestamp = bc.tailBlock.header.timestamp + BlockInterval
+		assert.Nil(t, block.Seal())
+		signBlock(block)
+		assert.Nil(t, bc.bkPool.Push(block))
+	}
+
+	var buf bytes.Buffer
+	exported, err := bc.ExportSnapshot(&buf, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), exported.Height())
+	_, err = bc.ExportSnapshot(&buf, 100)
+	assert.Equal(t, ErrCannotFindBlockAtGivenHeight, err)
+
+	// only the trusted block is accepted.
+	fresh := testNeb(t).chain
+	_, err = fresh.ImportSnapshot(bytes.NewReader(buf.Bytes()), bc.tailBlock.Hash())
+	assert.Equal(t, ErrUntrustedSnapshot, err)
+
+	// corrupted chunks are rejected.
+	corrupted := append([]byte{}, buf.Bytes()...)
+	corrupted[len(corrupted)-40] ^= 0xff
+	fresh = testNeb(t).chain
+	_, err = fresh.ImportSnapshot(bytes.NewReader(corrupted), exported.Hash())
+	assert.Equal(t, ErrSnapshotChecksumMismatch, err)
+
+	// a node not matching its hash is rejected, though its chunk is checksummed.
+	forged := append([]byte{}, buf.Bytes()...)
+	offset := len(SnapshotMagic) + 4
+	offset += 4 + int(binary.BigEndian.Uint32(forged[offset:]))
+	size := int(binary.BigEndian.Uint32(forged[offset+4:]))
+	payload := forged[offset+8 : offset+8+size]
+	payload[0] ^= 0xff
+	copy(forged[offset+8+size:], hash.Sha3256(payload))
+	fresh = testNeb(t).chain
+	_, err = fresh.ImportSnapshot(bytes.NewReader(forged), exported.Hash())
+	assert.Equal(t, ErrSnapshotNodeHashMismatch, err)
+
+	fresh = testNeb(t).chain
+	tail, err := fresh.ImportSnapshot(bytes.NewReader(buf.Bytes()), exported.Hash())
+	assert.Nil(t, err)
+	assert.Equal(t, exported.Hash(), tail.Hash())
+	assert.Equal(t, exported.Hash(), fresh.TailBlock().Hash())
+	assert.Equal(t, exported.Hash(), fresh.LIB().Hash())
+	height, ok := fresh.SnapshotHeight()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(2), height)
+
+	coinbase := exported.Coinbase().Bytes()
+	want, err := exported.GetAccount(coinbase)
+	assert.Nil(t, err)
+	got, err := fresh.TailBlock().GetAccount(coinbase)
+	assert.Nil(t, err)
+	assert.Equal(t, want.Balance(), got.Balance())
+
+	// a chain can be started from a snapshot only once.
+	_, err = fresh.ImportSnapshot(bytes.NewReader(buf.Bytes()), nil)
+	assert.Equal(t, ErrSnapshotOnUsedChain, err)
+}
@@ -94,6 +94,15 @@ func (acc *account) FromBytes(bytes []byte, storage storage.Storage) error {
 	return nil
 }
 
+// ResolveAccountVars return the root of the account's variables trie from the account bytes in accounts trie
+func ResolveAccountVars(bytes []byte) ([][]byte, error) {
+	pbAcc := &corepb.Account{}
+	if err := proto.Unmarshal(bytes, pbAcc); err != nil {
+		return nil, err
+	}
+	return [][]byte{pbAcc.VarsHash}, nil
+}
+
 // Balance return account's balance
 func (acc *account) Balance() *util.Uint128 {
 	return acc.balance
@@ -124,6 +133,11 @@ func (acc *account) ContractMeta() *corepb.ContractMeta {
 	return acc.contractMeta
 }
 
+// SetContractMeta replace the meta of a contract account, the upgrades of its code are kept here.
+func (acc *account) SetContractMeta(meta *corepb.ContractMeta) {
+	acc.contractMeta = meta
+}
+
 // Clone account
 func (acc *account) Clone() (Account, error) {
 	variables, err := acc.variables.Clone()
@@ -0,0 +1,119 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package state
+
+import (
+	"errors"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/common/trie"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+)
+
+// Errors
+var (
+	ErrInvalidProtoToMerkleProof = errors.New("protobuf message cannot be converted into MerkleProof")
+)
+
+// MerkleProof is the path of trie nodes from a state root to the leaf of a key,
+// e.g. an address in accounts trie or a storage key in an account's variables trie.
+type MerkleProof struct {
+	proof trie.MerkleProof
+}
+
+// Value return the value of the key proved
+func (p *MerkleProof) Value() []byte {
+	if len(p.proof) == 0 {
+		return nil
+	}
+	last := p.proof[len(p.proof)-1]
+	if len(last) != 3 {
+		return nil
+	}
+	return last[2]
+}
+
+// ToProto converts domain MerkleProof to proto MerkleProof
+func (p *MerkleProof) ToProto() (proto.Message, error) {
+	nodes := make([]*corepb.ProofNode, len(p.proof))
+	for i, val := range p.proof {
+		nodes[i] = &corepb.ProofNode{Val: val}
+	}
+	return &corepb.MerkleProof{Nodes: nodes}, nil
+}
+
+// FromProto converts proto MerkleProof to domain MerkleProof
+func (p *MerkleProof) FromProto(msg proto.Message) error {
+	if msg, ok := msg.(*corepb.MerkleProof); ok {
+		if msg != nil {
+			p.proof = make(trie.MerkleProof, len(msg.Nodes))
+			for i, n := range msg.Nodes {
+				if n == nil {
+					return ErrInvalidProtoToMerkleProof
+				}
+				p.proof[i] = n.Val
+			}
+			return nil
+		}
+	}
+	return ErrInvalidProtoToMerkleProof
+}
+
+// ToBytes converts domain MerkleProof to bytes
+func (p *MerkleProof) ToBytes() ([]byte, error) {
+	pb, err := p.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(pb)
+}
+
+// FromBytes converts bytes to MerkleProof
+func (p *MerkleProof) FromBytes(bytes []byte) error {
+	pb := new(corepb.MerkleProof)
+	if err := proto.Unmarshal(bytes, pb); err != nil {
+		return err
+	}
+	return p.FromProto(pb)
+}
+
+// Proof return the merkle proof of the key in the state trie with the root,
+// trie.ErrNotFound if the key is not in the trie.
+func Proof(stor storage.Storage, root byteutils.Hash, key []byte) (*MerkleProof, error) {
+	t, err := trie.NewTrie(root, stor, false)
+	if err != nil {
+		return nil, err
+	}
+	proof, err := t.Prove(key)
+	if err != nil {
+		return nil, err
+	}
+	return &MerkleProof{proof: proof}, nil
+}
+
+// Verify check the value of the key in the state trie with the root is proved by the proof,
+// neither the trie nor the serving node is needed.
+func Verify(root byteutils.Hash, key []byte, value []byte, proof *MerkleProof) error {
+	if proof == nil {
+		return trie.ErrInvalidProof
+	}
+	return trie.VerifyValue(root, key, value, proof.proof)
+}
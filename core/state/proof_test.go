@@ -0,0 +1,56 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package state
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/common/trie"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProof(t *testing.T) {
+	stor, _ := storage.NewMemoryStorage()
+	tr, _ := trie.NewTrie(nil, stor, false)
+	key1 := []byte{0x1f, 0x34, 0x56, 0x78}
+	key2 := []byte{0x1f, 0x35, 0x56, 0x78}
+	_, err := tr.Put(key1, []byte("value1"))
+	assert.Nil(t, err)
+	root, err := tr.Put(key2, []byte("value2"))
+	assert.Nil(t, err)
+
+	proof, err := Proof(stor, root, key1)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("value1"), proof.Value())
+	assert.Nil(t, Verify(root, key1, []byte("value1"), proof))
+	assert.Equal(t, trie.ErrInvalidProof, Verify(root, key1, []byte("value2"), proof))
+	assert.NotNil(t, Verify(root, key2, []byte("value1"), proof))
+	assert.Equal(t, trie.ErrInvalidProof, Verify(root, key1, []byte("value1"), nil))
+
+	bytes, err := proof.ToBytes()
+	assert.Nil(t, err)
+	decoded := new(MerkleProof)
+	assert.Nil(t, decoded.FromBytes(bytes))
+	assert.Equal(t, proof, decoded)
+	assert.Nil(t, Verify(root, key1, []byte("value1"), decoded))
+
+	_, err = Proof(stor, root, []byte{0x2f, 0x34, 0x56, 0x78})
+	assert.Equal(t, trie.ErrNotFound, err)
+}
@@ -69,6 +69,7 @@ type Account interface {
 	Del(key []byte) error
 	Iterator(prefix []byte) (Iterator, error)
 	ContractMeta() *corepb.ContractMeta
+	SetContractMeta(meta *corepb.ContractMeta)
 }
 
 // AccountState Interface
@@ -0,0 +1,332 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"bytes"
+	"errors"
+	"time"
+
+	"github.com/nebulasio/go-nebulas/common/trie"
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+var errStateGCStopped = errors.New("state gc stopped")
+
+// stateGCSweepBatch the nodes swept at most while the block pool is locked
+const stateGCSweepBatch = 256
+
+// StateGC reclaims in the background the trie nodes unreachable from the
+// states kept, e.g. the ones of abandoned forks or written before pruning
+// was enabled. Each run marks the nodes reachable from the kept states and
+// sweeps the others. A node is only deleted if it is found unreachable by
+// two runs in a row, so that the nodes of blocks being executed, whose
+// states are not linked to the chain yet, are not swept. The blocks write
+// their states under the lock of the block pool, the sweep holds it and marks
+// the heads linked since, so a node written again meanwhile is kept. The
+// nodes referred by the states of the pruner are never swept.
+type StateGC struct {
+	chain    *BlockChain
+	interval time.Duration
+	rate     uint32
+
+	// unreachable nodes found by the last run
+	candidates map[string]bool
+
+	ops     uint32
+	opsFrom time.Time
+
+	quitCh chan int
+}
+
+// NewStateGC create a state gc running every interval, touching at most rate
+// nodes per second, 0 for no limit.
+func NewStateGC(chain *BlockChain, interval time.Duration, rate uint32) *StateGC {
+	return &StateGC{
+		chain:      chain,
+		interval:   interval,
+		rate:       rate,
+		candidates: make(map[string]bool),
+		quitCh:     make(chan int, 1),
+	}
+}
+
+// Start start loop.
+func (gc *StateGC) Start() {
+	logging.CLog().WithFields(logrus.Fields{
+		"interval": gc.interval,
+		"rate":     gc.rate,
+	}).Info("Starting StateGC...")
+
+	go gc.loop()
+}
+
+// Stop stop loop.
+func (gc *StateGC) Stop() {
+	logging.CLog().Info("Stopping StateGC...")
+	gc.quitCh <- 0
+}
+
+func (gc *StateGC) loop() {
+	logging.CLog().Info("Started StateGC.")
+	ticker := time.NewTicker(gc.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-gc.quitCh:
+			logging.CLog().Info("Stopped StateGC.")
+			return
+		case <-ticker.C:
+			if err := gc.Run(); err != nil {
+				if err == errStateGCStopped {
+					logging.CLog().Info("Stopped StateGC.")
+					return
+				}
+				logging.CLog().WithFields(logrus.Fields{
+					"err": err,
+				}).Error("Failed to collect state garbage.")
+			}
+		}
+	}
+}
+
+// Run marks the nodes reachable from the kept states and deletes the
+// unreachable ones found by the previous run too.
+func (gc *StateGC) Run() error {
+	stor, ok := gc.chain.stateStorage.(storage.Iterable)
+	if !ok {
+		return storage.ErrNotIterable
+	}
+
+	startAt := time.Now()
+	reachable, err := gc.mark()
+	if err != nil {
+		return err
+	}
+
+	unreachable := []string{}
+	err = stor.Iterate(nil, func(key, value []byte) error {
+		if err := gc.throttle(); err != nil {
+			return err
+		}
+		if !isTrieNode(key, value) || reachable[string(key)] {
+			return nil
+		}
+		unreachable = append(unreachable, string(key))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	candidates := make(map[string]bool)
+	deleted, reclaimed := 0, 0
+	for start := 0; start < len(unreachable); start += stateGCSweepBatch {
+		end := start + stateGCSweepBatch
+		if end > len(unreachable) {
+			end = len(unreachable)
+		}
+		n, size, err := gc.sweep(stor, unreachable[start:end], reachable, candidates)
+		if err != nil {
+			return err
+		}
+		deleted += n
+		reclaimed += size
+		for i := 0; i < n; i++ {
+			if err := gc.throttle(); err != nil {
+				return err
+			}
+		}
+	}
+	gc.candidates = candidates
+
+	metricsStateGCNodes.Inc(int64(deleted))
+	metricsStateGCBytes.Inc(int64(reclaimed))
+	logging.CLog().WithFields(logrus.Fields{
+		"reachable":  len(reachable),
+		"candidates": len(candidates),
+		"deleted":    deleted,
+		"reclaimed":  reclaimed,
+		"elapsed":    time.Since(startAt),
+	}).Info("Collected state garbage.")
+	return nil
+}
+
+// sweep deletes the keys found unreachable by the previous run too, the others
+// become candidates. It holds the block pool so that no block writes its state
+// meanwhile, and first marks the heads linked since the keys were found.
+func (gc *StateGC) sweep(stor storage.Storage, keys []string, reachable, candidates map[string]bool) (int, int, error) {
+	pool := gc.chain.bkPool
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if err := gc.markHeads(reachable, gc.stopped); err != nil {
+		return 0, 0, err
+	}
+	deleted, reclaimed := 0, 0
+	for _, key := range keys {
+		if reachable[key] {
+			continue
+		}
+		if pruner := gc.chain.statePruner; pruner != nil {
+			referenced, err := pruner.referenced([]byte(key))
+			if err != nil {
+				return 0, 0, err
+			}
+			if referenced {
+				continue
+			}
+		}
+		if !gc.candidates[key] {
+			candidates[key] = true
+			continue
+		}
+		value, err := stor.Get([]byte(key))
+		if err == storage.ErrKeyNotFound {
+			continue
+		}
+		if err != nil {
+			return 0, 0, err
+		}
+		if err := stor.Del([]byte(key)); err != nil {
+			return 0, 0, err
+		}
+		deleted++
+		reclaimed += len(value)
+	}
+	return deleted, reclaimed, nil
+}
+
+// isTrieNode tells the trie nodes, addressed by the hash of their content,
+// apart from the other entries sharing the storage.
+func isTrieNode(key, value []byte) bool {
+	return len(key) == 32 && bytes.Equal(hash.Sha3256(value), key)
+}
+
+// mark returns the nodes reachable from the states kept: the canonical ones
+// not pruned yet, the pinned ones and the ones of forks above the canonical chain.
+func (gc *StateGC) mark() (map[string]bool, error) {
+	chain := gc.chain
+	reachable := make(map[string]bool)
+
+	tail := chain.TailBlock()
+	from := chain.genesisBlock.height
+	pruner := chain.statePruner
+	if pruner != nil {
+		from = pruner.PrunedHeight() + 1
+		if err := gc.markBlock(chain.genesisBlock, reachable, gc.throttle); err != nil {
+			return nil, err
+		}
+		if pruner.pinnedInterval > 0 {
+			for h := pruner.pinnedInterval; h < from; h += pruner.pinnedInterval {
+				if err := gc.markHeight(h, reachable); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	for h := from; h <= tail.height; h++ {
+		if err := gc.markHeight(h, reachable); err != nil {
+			return nil, err
+		}
+	}
+	if err := gc.markHeads(reachable, gc.throttle); err != nil {
+		return nil, err
+	}
+	return reachable, nil
+}
+
+func (gc *StateGC) markHeight(height uint64, reachable map[string]bool) error {
+	block := gc.chain.GetBlockOnCanonicalChainByHeight(height)
+	if block == nil {
+		// the chain is started from a snapshot above the height.
+		if snapshot, ok := gc.chain.SnapshotHeight(); ok && height < snapshot {
+			return nil
+		}
+		return ErrCannotFindBlockAtGivenHeight
+	}
+	return gc.markBlock(block, reachable, gc.throttle)
+}
+
+// markHeads marks the states of the tail and of the forks, down to the canonical chain.
+func (gc *StateGC) markHeads(reachable map[string]bool, touch func() error) error {
+	heads := append(gc.chain.DetachedTailBlocks(), gc.chain.TailBlock())
+	for _, block := range heads {
+		for block != nil {
+			if err := gc.markBlock(block, reachable, touch); err != nil {
+				return err
+			}
+			if gc.chain.GetBlockOnCanonicalChainByHash(block.Hash()) != nil {
+				break
+			}
+			block = gc.chain.GetBlock(block.ParentHash())
+		}
+	}
+	return nil
+}
+
+// markBlock marks the nodes of the block state, touch is called on each node walked.
+func (gc *StateGC) markBlock(block *Block, reachable map[string]bool, touch func() error) error {
+	for _, v := range blockStateRoots(block) {
+		err := trie.Walk(gc.chain.stateStorage, v.root, v.resolve, reachable, func(h []byte, node []byte) error {
+			return touch()
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stopped checks the stop signal, the steps holding the block pool never sleep.
+func (gc *StateGC) stopped() error {
+	select {
+	case <-gc.quitCh:
+		return errStateGCStopped
+	default:
+	}
+	return nil
+}
+
+// throttle counts a node touched, and sleeps out the second once rate nodes are touched in it.
+func (gc *StateGC) throttle() error {
+	if err := gc.stopped(); err != nil {
+		return err
+	}
+	if gc.rate == 0 {
+		return nil
+	}
+
+	if gc.ops == 0 {
+		gc.opsFrom = time.Now()
+	}
+	gc.ops++
+	if gc.ops < gc.rate {
+		return nil
+	}
+	gc.ops = 0
+	if elapsed := time.Since(gc.opsFrom); elapsed < time.Second {
+		time.Sleep(time.Second - elapsed)
+	}
+	return nil
+}
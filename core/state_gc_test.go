@@ -0,0 +1,100 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/common/trie"
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateGC(t *testing.T) {
+	neb := testNeb(t)
+	bc := neb.chain
+
+	for i := 1; i <= 3; i++ {
+		addr, err := AddressParse(MockDynasty[i])
+		assert.Nil(t, err)
+		block, err := NewBlock(bc.ChainID(), addr, bc.tailBlock)
+		assert.Nil(t, err)
+		block.header.timestamp = bc.tailBlock.header.timestamp + BlockInterval
+		assert.Nil(t, block.Seal())
+		signBlock(block)
+		assert.Nil(t, bc.bkPool.Push(block))
+		assert.Equal(t, block.Hash(), bc.tailBlock.Hash())
+	}
+
+	// a trie node no state refers to, e.g. left by an abandoned block.
+	orphan, err := trie.NewTrie(nil, bc.stateStorage, false)
+	assert.Nil(t, err)
+	_, err = orphan.Put(hash.Sha3256([]byte("orphan")), []byte("orphan"))
+	assert.Nil(t, err)
+	_, err = bc.stateStorage.Get(orphan.RootHash())
+	assert.Nil(t, err)
+
+	gc := NewStateGC(bc, 0, 0)
+
+	// the first run only marks the orphan as a candidate.
+	assert.Nil(t, gc.Run())
+	_, err = bc.stateStorage.Get(orphan.RootHash())
+	assert.Nil(t, err)
+	assert.True(t, gc.candidates[string(orphan.RootHash())])
+
+	assert.Nil(t, gc.Run())
+	_, err = bc.stateStorage.Get(orphan.RootHash())
+	assert.Equal(t, storage.ErrKeyNotFound, err)
+
+	// the states kept and the other entries are left.
+	for h := bc.genesisBlock.height; h <= bc.tailBlock.height; h++ {
+		block := bc.GetBlockOnCanonicalChainByHeight(h)
+		for _, v := range blockStateRoots(block) {
+			assert.Nil(t, trie.Walk(bc.stateStorage, v.root, v.resolve, make(map[string]bool), func([]byte, []byte) error {
+				return nil
+			}))
+		}
+	}
+	_, err = bc.storage.Get([]byte(Tail))
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(gc.candidates))
+}
+
+func TestStateGC_PrunerReferences(t *testing.T) {
+	neb := testNeb(t)
+	bc := neb.chain
+	pruner := NewStatePruner(bc, 0, 0)
+	assert.Nil(t, pruner.Setup())
+	bc.statePruner = pruner
+
+	// a node counted by the pruner is kept though no state refers to it.
+	orphan, err := trie.NewTrie(nil, bc.stateStorage, false)
+	assert.Nil(t, err)
+	_, err = orphan.Put(hash.Sha3256([]byte("orphan")), []byte("orphan"))
+	assert.Nil(t, err)
+	assert.Nil(t, pruner.refs.Reference(orphan.RootHash(), nil))
+
+	gc := NewStateGC(bc, 0, 0)
+	assert.Nil(t, gc.Run())
+	assert.Nil(t, gc.Run())
+	_, err = bc.stateStorage.Get(orphan.RootHash())
+	assert.Nil(t, err)
+	assert.False(t, gc.candidates[string(orphan.RootHash())])
+}
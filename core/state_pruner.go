@@ -0,0 +1,334 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"sync"
+
+	"github.com/nebulasio/go-nebulas/common/trie"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// State storage modes
+const (
+	StateModeArchive = "archive"
+	StateModePruned  = "pruned"
+
+	// DefaultStateRetention number of recent blocks whose states are kept in pruned mode
+	DefaultStateRetention = uint64(128)
+)
+
+// storage: key -> value
+// stateprune_generation -> generation of the reference counts
+// stateprune_tail -> the tail block whose state is referenced
+// stateprune_height -> states at and below the height are released, except the pinned ones
+// stateprune_forks -> hash and height of the blocks off canonical chain whose states are referenced
+// stateref_ + generation + node hash -> number of references to the trie node
+
+// State pruner keys in storage
+const (
+	StatePrunerGeneration = "stateprune_generation"
+	StatePrunerTail       = "stateprune_tail"
+	StatePrunerHeight     = "stateprune_height"
+	StatePrunerForks      = "stateprune_forks"
+	StateRefPrefix        = "stateref_"
+
+	// forkEntryLength length of a block hash and its height in stateprune_forks
+	forkEntryLength = BlockHashLength + 8
+)
+
+// StatePruner keeps the states of recent blocks on canonical chain, plus the genesis and
+// pinned ones, and deletes the trie nodes referred only by the states released.
+// The states of blocks off canonical chain are kept until the LIB passes them, the
+// nodes shared with a side fork are never deleted while the fork may be switched to.
+//
+// The trie nodes referred by states before pruning is enabled are never counted, they are
+// left to the background garbage collection.
+type StatePruner struct {
+	mu    sync.Mutex
+	chain *BlockChain
+
+	retention      uint64
+	pinnedInterval uint64
+
+	refs   *trie.RefCounter
+	height uint64
+
+	// blocks off canonical chain whose states are referenced, hash -> height
+	forks map[string]uint64
+}
+
+// NewStatePruner create a state pruner, the reference counts are loaded in Setup.
+func NewStatePruner(chain *BlockChain, retention uint64, pinnedInterval uint64) *StatePruner {
+	if retention == 0 {
+		retention = DefaultStateRetention
+	}
+	return &StatePruner{
+		chain:          chain,
+		retention:      retention,
+		pinnedInterval: pinnedInterval,
+		forks:          make(map[string]uint64),
+	}
+}
+
+// Setup load the reference counts, or count them again in a new generation
+// if they are out of date, e.g. the node ran in archive mode for a while.
+func (sp *StatePruner) Setup() error {
+	stor := sp.chain.storage
+	generation := uint64(0)
+	if value, err := stor.Get([]byte(StatePrunerGeneration)); err == nil {
+		generation = byteutils.Uint64(value)
+	} else if err != storage.ErrKeyNotFound {
+		return err
+	}
+
+	tail, errTail := stor.Get([]byte(StatePrunerTail))
+	height, errHeight := stor.Get([]byte(StatePrunerHeight))
+	if errTail == nil && errHeight == nil && sp.chain.tailBlock.Hash().Equals(tail) {
+		sp.refs = sp.newRefCounter(generation)
+		sp.height = byteutils.Uint64(height)
+		return sp.loadForks()
+	}
+
+	// the counts in the old generation are abandoned, nodes counted only there are leaked.
+	generation++
+	if err := stor.Put([]byte(StatePrunerGeneration), byteutils.FromUint64(generation)); err != nil {
+		return err
+	}
+	sp.refs = sp.newRefCounter(generation)
+	sp.forks = make(map[string]uint64)
+
+	logging.CLog().WithFields(logrus.Fields{
+		"generation": generation,
+		"tail":       sp.chain.tailBlock,
+		"retention":  sp.retention,
+	}).Info("Counting references of recent states for pruning.")
+
+	tailHeight := sp.chain.tailBlock.height
+	sp.height = sp.chain.genesisBlock.height
+	if tailHeight > sp.retention {
+		sp.height = tailHeight - sp.retention
+	}
+	for h := sp.chain.genesisBlock.height; h <= tailHeight; h++ {
+		if h <= sp.height && !sp.isPinned(h) {
+			continue
+		}
+		block, err := sp.canonicalBlock(h)
+		if err != nil {
+			return err
+		}
+		if block == nil {
+			continue
+		}
+		if err := sp.reference(block); err != nil {
+			return err
+		}
+	}
+	return sp.storeProgress(sp.chain.tailBlock)
+}
+
+func (sp *StatePruner) newRefCounter(generation uint64) *trie.RefCounter {
+	prefix := append([]byte(StateRefPrefix), byteutils.FromUint64(generation)...)
+	return trie.NewRefCounter(sp.chain.stateStorage, prefix)
+}
+
+// referenced reports whether the trie node is referred by a state the pruner keeps.
+func (sp *StatePruner) referenced(hash []byte) (bool, error) {
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+	count, err := sp.refs.Count(hash)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (sp *StatePruner) isPinned(height uint64) bool {
+	if height == sp.chain.genesisBlock.height {
+		return true
+	}
+	return sp.pinnedInterval > 0 && height%sp.pinnedInterval == 0
+}
+
+func (sp *StatePruner) loadForks() error {
+	sp.forks = make(map[string]uint64)
+	value, err := sp.chain.storage.Get([]byte(StatePrunerForks))
+	if err == storage.ErrKeyNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for len(value) >= forkEntryLength {
+		sp.forks[string(value[:BlockHashLength])] = byteutils.Uint64(value[BlockHashLength:forkEntryLength])
+		value = value[forkEntryLength:]
+	}
+	return nil
+}
+
+func (sp *StatePruner) storeForks() error {
+	value := make([]byte, 0, len(sp.forks)*forkEntryLength)
+	for hash, height := range sp.forks {
+		value = append(value, hash...)
+		value = append(value, byteutils.FromUint64(height)...)
+	}
+	return sp.chain.storage.Put([]byte(StatePrunerForks), value)
+}
+
+func (sp *StatePruner) storeProgress(tail *Block) error {
+	if err := sp.storeForks(); err != nil {
+		return err
+	}
+	if err := sp.chain.storage.Put([]byte(StatePrunerHeight), byteutils.FromUint64(sp.height)); err != nil {
+		return err
+	}
+	return sp.chain.storage.Put([]byte(StatePrunerTail), tail.Hash())
+}
+
+// PrunedHeight return the height at and below which the states are released
+func (sp *StatePruner) PrunedHeight() uint64 {
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+	return sp.height
+}
+
+// Track count the states of new blocks stored, they are off canonical chain until applied.
+func (sp *StatePruner) Track(blocks []*Block) error {
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+
+	for _, block := range blocks {
+		key := string(block.Hash())
+		if _, ok := sp.forks[key]; ok {
+			continue
+		}
+		if err := sp.reference(block); err != nil {
+			return err
+		}
+		sp.forks[key] = block.height
+	}
+	return sp.storeForks()
+}
+
+func (sp *StatePruner) reference(block *Block) error {
+	for _, v := range blockStateRoots(block) {
+		if err := sp.refs.Reference(v.root, v.resolve); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (sp *StatePruner) dereference(block *Block) (int, error) {
+	deleted := 0
+	for _, v := range blockStateRoots(block) {
+		n, err := sp.refs.Dereference(v.root, v.resolve)
+		deleted += n
+		if err != nil {
+			return deleted, err
+		}
+	}
+	return deleted, nil
+}
+
+// canonicalBlock return the block on canonical chain at the height, or nil
+// if the chain is started from a snapshot above the height.
+func (sp *StatePruner) canonicalBlock(height uint64) (*Block, error) {
+	block := sp.chain.GetBlockOnCanonicalChainByHeight(height)
+	if block != nil {
+		return block, nil
+	}
+	if snapshot, ok := sp.chain.SnapshotHeight(); ok && height < snapshot {
+		return nil, nil
+	}
+	return nil, ErrCannotFindBlockAtGivenHeight
+}
+
+// Prune count the states of blocks new on canonical chain, and release the ones older than
+// the retention and the side forks below the LIB. reverted and applied are from the higher to the lower.
+func (sp *StatePruner) Prune(reverted []*Block, applied []*Block, newTail *Block) error {
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+
+	// reference the new states first, the nodes shared with the released ones are kept.
+	for i := len(applied) - 1; i >= 0; i-- {
+		key := string(applied[i].Hash())
+		if _, ok := sp.forks[key]; ok {
+			delete(sp.forks, key)
+			continue
+		}
+		if err := sp.reference(applied[i]); err != nil {
+			return err
+		}
+	}
+
+	// the reverted blocks are a side fork now, kept until the LIB passes them.
+	for _, block := range reverted {
+		sp.forks[string(block.Hash())] = block.height
+	}
+
+	deleted := 0
+	if lib := sp.chain.LIB(); lib != nil {
+		for key, height := range sp.forks {
+			if height > lib.height {
+				continue
+			}
+			block := sp.chain.GetBlock(byteutils.Hash(key))
+			if block != nil {
+				n, err := sp.dereference(block)
+				deleted += n
+				if err != nil {
+					return err
+				}
+			}
+			delete(sp.forks, key)
+		}
+	}
+
+	for sp.height+sp.retention < newTail.height {
+		height := sp.height + 1
+		if !sp.isPinned(height) {
+			block, err := sp.canonicalBlock(height)
+			if err != nil {
+				return err
+			}
+			if block != nil {
+				n, err := sp.dereference(block)
+				deleted += n
+				if err != nil {
+					return err
+				}
+			}
+		}
+		sp.height = height
+	}
+
+	if deleted > 0 {
+		metricsStatePrunedNodes.Inc(int64(deleted))
+		logging.VLog().WithFields(logrus.Fields{
+			"tail":    newTail,
+			"height":  sp.height,
+			"deleted": deleted,
+		}).Debug("Pruned trie nodes of released states.")
+	}
+	return sp.storeProgress(newTail)
+}
@@ -0,0 +1,135 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/common/trie"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatePruner(t *testing.T) {
+	neb := testNeb(t)
+	bc := neb.chain
+
+	pruner := NewStatePruner(bc, 2, 0)
+	assert.Nil(t, pruner.Setup())
+	bc.statePruner = pruner
+	assert.Equal(t, bc.genesisBlock.height, pruner.PrunedHeight())
+
+	blocks := []*Block{}
+	for i := 1; i <= 4; i++ {
+		addr, err := AddressParse(MockDynasty[i])
+		assert.Nil(t, err)
+		block, err := NewBlock(bc.ChainID(), addr, bc.tailBlock)
+		assert.Nil(t, err)
+		block.header.timestamp = bc.tailBlock.header.timestamp + BlockInterval
+		assert.Nil(t, block.Seal())
+		signBlock(block)
+		assert.Nil(t, bc.bkPool.Push(block))
+		assert.Equal(t, block.Hash(), bc.tailBlock.Hash())
+		blocks = append(blocks, block)
+	}
+
+	// only the states of last 2 blocks and genesis are kept.
+	assert.Equal(t, bc.tailBlock.height-2, pruner.PrunedHeight())
+	_, err := bc.storage.Get(blocks[0].StateRoot())
+	assert.Equal(t, storage.ErrKeyNotFound, err)
+	_, err = bc.storage.Get(blocks[3].StateRoot())
+	assert.Nil(t, err)
+	_, err = bc.storage.Get(bc.genesisBlock.StateRoot())
+	assert.Nil(t, err)
+
+	// the counts are reused at restart.
+	restarted := NewStatePruner(bc, 2, 0)
+	assert.Nil(t, restarted.Setup())
+	assert.Equal(t, pruner.PrunedHeight(), restarted.PrunedHeight())
+}
+
+func mintPrunerTestBlock(t *testing.T, bc *BlockChain, parent *Block, miner int) *Block {
+	addr, err := AddressParse(MockDynasty[miner])
+	assert.Nil(t, err)
+	block, err := NewBlock(bc.ChainID(), addr, parent)
+	assert.Nil(t, err)
+	block.header.timestamp = parent.header.timestamp + BlockInterval
+	assert.Nil(t, block.Seal())
+	signBlock(block)
+	assert.Nil(t, bc.bkPool.Push(block))
+	return block
+}
+
+// assertStateComplete walks all the accounts of the state, failing on a missing trie node.
+func assertStateComplete(t *testing.T, bc *BlockChain, root []byte) {
+	accounts, err := trie.NewTrie(root, bc.stateStorage, false)
+	assert.Nil(t, err)
+	it, err := accounts.Iterator(nil)
+	assert.Nil(t, err)
+	exist, err := it.Next()
+	for exist {
+		exist, err = it.Next()
+	}
+	assert.Nil(t, err)
+}
+
+func TestStatePrunerWithFork(t *testing.T) {
+	neb := testNeb(t)
+	bc := neb.chain
+
+	pruner := NewStatePruner(bc, 1, 0)
+	assert.Nil(t, pruner.Setup())
+	bc.statePruner = pruner
+
+	b1 := mintPrunerTestBlock(t, bc, bc.tailBlock, 1)
+	b2 := mintPrunerTestBlock(t, bc, b1, 2)
+	b3 := mintPrunerTestBlock(t, bc, b2, 3)
+	assert.Equal(t, b3.Hash(), bc.tailBlock.Hash())
+
+	// a side fork on b1, sharing the nodes of b1 changed by b2.
+	fork := mintPrunerTestBlock(t, bc, b1, 4)
+	assert.Equal(t, b3.Hash(), bc.tailBlock.Hash())
+
+	b4 := mintPrunerTestBlock(t, bc, b3, 1)
+	b5 := mintPrunerTestBlock(t, bc, b4, 2)
+	assert.Equal(t, b5.Hash(), bc.tailBlock.Hash())
+	assert.Equal(t, b4.height, pruner.PrunedHeight())
+
+	// the canonical states are released, the live fork is kept.
+	_, err := bc.stateStorage.Get(b1.StateRoot())
+	assert.Equal(t, storage.ErrKeyNotFound, err)
+	_, err = bc.stateStorage.Get(b2.StateRoot())
+	assert.Equal(t, storage.ErrKeyNotFound, err)
+	assertStateComplete(t, bc, fork.StateRoot())
+	assertStateComplete(t, bc, b5.StateRoot())
+
+	// the forks are reloaded at restart.
+	restarted := NewStatePruner(bc, 1, 0)
+	assert.Nil(t, restarted.Setup())
+	assert.Equal(t, fork.height, restarted.forks[string(fork.Hash())])
+
+	// the fork is released once the LIB passes it.
+	bc.SetLIB(b3)
+	b6 := mintPrunerTestBlock(t, bc, b5, 3)
+	assert.Equal(t, b6.Hash(), bc.tailBlock.Hash())
+	_, err = bc.stateStorage.Get(fork.StateRoot())
+	assert.Equal(t, storage.ErrKeyNotFound, err)
+	assert.Equal(t, 0, len(pruner.forks))
+	assertStateComplete(t, bc, b6.StateRoot())
+}
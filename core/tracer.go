@@ -0,0 +1,293 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"encoding/json"
+	"strconv"
+	"sync"
+
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/core/state"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+)
+
+// Trace step types
+const (
+	TraceBalanceAdd = "balance_add"
+	TraceBalanceSub = "balance_sub"
+	TraceNonce      = "nonce"
+	TraceStoragePut = "storage_put"
+	TraceStorageDel = "storage_del"
+	TraceEvent      = "event"
+	TraceGas        = "gas"
+	TraceCall       = "call"
+
+	TraceStorageGet   = "storage_get"
+	TraceHostCall     = "host_call"
+	TraceInstructions = "instructions"
+	TraceException    = "exception"
+)
+
+const (
+	// TraceKeyPrefix prefix of the execution trace key in storage
+	TraceKeyPrefix = "trace_"
+)
+
+// TraceStep a single step recorded during a tx's execution.
+type TraceStep struct {
+	Type     string `json:"type"`
+	Address  string `json:"address,omitempty"`
+	Key      string `json:"key,omitempty"`
+	Value    string `json:"value,omitempty"`
+	Function string `json:"function,omitempty"`
+	Args     string `json:"args,omitempty"`
+}
+
+// ExecutionTrace all steps recorded during a tx's execution.
+type ExecutionTrace struct {
+	TxHash    string       `json:"tx_hash"`
+	BlockHash string       `json:"block_hash"`
+	Height    uint64       `json:"height"`
+	Steps     []*TraceStep `json:"steps"`
+}
+
+// CallTracer is implemented by the world states recording contract calls.
+type CallTracer interface {
+	TraceCall(from, to byteutils.Hash, function, args string)
+}
+
+// ExecutionTracer is implemented by the world states recording the steps of
+// contract executions, the nvm records the host functions called and the
+// instructions executed by each statement through it.
+type ExecutionTracer interface {
+	CallTracer
+	RecordStep(step *TraceStep)
+}
+
+// Tracer records the execution traces of the txs in blocks and keeps them in storage.
+type Tracer struct {
+	storage storage.Storage
+}
+
+// NewTracer create a new tracer.
+func NewTracer(storage storage.Storage) *Tracer {
+	return &Tracer{storage: storage}
+}
+
+// Trace returns the execution trace of the tx.
+func (t *Tracer) Trace(txHash byteutils.Hash) (*ExecutionTrace, error) {
+	bytes, err := t.storage.Get(traceKey(txHash))
+	if err != nil {
+		return nil, err
+	}
+	trace := new(ExecutionTrace)
+	if err := json.Unmarshal(bytes, trace); err != nil {
+		return nil, err
+	}
+	return trace, nil
+}
+
+// Store saves the traces recorded during the block's execution.
+func (t *Tracer) Store(block *Block) error {
+	for _, trace := range block.takeTraces() {
+		trace.BlockHash = block.Hash().String()
+		trace.Height = block.Height()
+		bytes, err := json.Marshal(trace)
+		if err != nil {
+			return err
+		}
+		hash, err := byteutils.FromHex(trace.TxHash)
+		if err != nil {
+			return err
+		}
+		if err := t.storage.Put(traceKey(hash), bytes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func traceKey(txHash byteutils.Hash) []byte {
+	return append([]byte(TraceKeyPrefix), txHash...)
+}
+
+func traceAddress(addr byteutils.Hash) string {
+	if a, err := AddressParseFromBytes(addr); err == nil {
+		return a.String()
+	}
+	return addr.Hex()
+}
+
+// tracedWorldState records every change made through the world state.
+type tracedWorldState struct {
+	WorldState
+
+	mu    sync.Mutex
+	trace *ExecutionTrace
+}
+
+func newTracedWorldState(ws WorldState, tx *Transaction) *tracedWorldState {
+	return &tracedWorldState{
+		WorldState: ws,
+		trace: &ExecutionTrace{
+			TxHash: tx.Hash().String(),
+			Steps:  make([]*TraceStep, 0),
+		},
+	}
+}
+
+func (ws *tracedWorldState) record(step *TraceStep) {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	ws.trace.Steps = append(ws.trace.Steps, step)
+}
+
+func (ws *tracedWorldState) wrap(acc state.Account, err error) (state.Account, error) {
+	if err != nil {
+		return nil, err
+	}
+	return &tracedAccount{Account: acc, ws: ws}, nil
+}
+
+// GetOrCreateUserAccount returns a traced user account.
+func (ws *tracedWorldState) GetOrCreateUserAccount(addr byteutils.Hash) (state.Account, error) {
+	return ws.wrap(ws.WorldState.GetOrCreateUserAccount(addr))
+}
+
+// GetContractAccount returns a traced contract account.
+func (ws *tracedWorldState) GetContractAccount(addr byteutils.Hash) (state.Account, error) {
+	return ws.wrap(ws.WorldState.GetContractAccount(addr))
+}
+
+// CreateContractAccount returns a traced new contract account.
+func (ws *tracedWorldState) CreateContractAccount(owner byteutils.Hash, birthPlace byteutils.Hash, contractMeta *corepb.ContractMeta) (state.Account, error) {
+	return ws.wrap(ws.WorldState.CreateContractAccount(owner, birthPlace, contractMeta))
+}
+
+// RecordEvent records the event in both the world state and the trace.
+func (ws *tracedWorldState) RecordEvent(txHash byteutils.Hash, event *state.Event) {
+	ws.record(&TraceStep{Type: TraceEvent, Key: event.Topic, Value: event.Data})
+	ws.WorldState.RecordEvent(txHash, event)
+}
+
+// RecordGas records the gas in both the world state and the trace.
+func (ws *tracedWorldState) RecordGas(from string, gas *util.Uint128) error {
+	ws.record(&TraceStep{Type: TraceGas, Address: from, Value: gas.String()})
+	return ws.WorldState.RecordGas(from, gas)
+}
+
+// TraceCall records a contract call.
+func (ws *tracedWorldState) TraceCall(from, to byteutils.Hash, function, args string) {
+	ws.record(&TraceStep{Type: TraceCall, Address: traceAddress(to), Key: traceAddress(from), Function: function, Args: args})
+}
+
+// RecordStep records a step of a contract execution.
+func (ws *tracedWorldState) RecordStep(step *TraceStep) {
+	ws.record(step)
+}
+
+// tracedAccount records every change made to the account.
+type tracedAccount struct {
+	state.Account
+
+	ws *tracedWorldState
+}
+
+// IncrNonce increases the nonce and records the new one.
+func (acc *tracedAccount) IncrNonce() {
+	acc.Account.IncrNonce()
+	acc.ws.record(&TraceStep{Type: TraceNonce, Address: traceAddress(acc.Address()), Value: strconv.FormatUint(acc.Nonce(), 10)})
+}
+
+// AddBalance adds the value and records it.
+func (acc *tracedAccount) AddBalance(value *util.Uint128) error {
+	if err := acc.Account.AddBalance(value); err != nil {
+		return err
+	}
+	acc.ws.record(&TraceStep{Type: TraceBalanceAdd, Address: traceAddress(acc.Address()), Value: value.String()})
+	return nil
+}
+
+// SubBalance subtracts the value and records it.
+func (acc *tracedAccount) SubBalance(value *util.Uint128) error {
+	if err := acc.Account.SubBalance(value); err != nil {
+		return err
+	}
+	acc.ws.record(&TraceStep{Type: TraceBalanceSub, Address: traceAddress(acc.Address()), Value: value.String()})
+	return nil
+}
+
+// Get gets the value of the key in the account's storage and records the read.
+func (acc *tracedAccount) Get(key []byte) ([]byte, error) {
+	value, err := acc.Account.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	acc.ws.record(&TraceStep{Type: TraceStorageGet, Address: traceAddress(acc.Address()), Key: string(key), Value: string(value)})
+	return value, nil
+}
+
+// Put puts the key/value in the account's storage and records it.
+func (acc *tracedAccount) Put(key []byte, value []byte) error {
+	if err := acc.Account.Put(key, value); err != nil {
+		return err
+	}
+	acc.ws.record(&TraceStep{Type: TraceStoragePut, Address: traceAddress(acc.Address()), Key: string(key), Value: string(value)})
+	return nil
+}
+
+// Del deletes the key from the account's storage and records it.
+func (acc *tracedAccount) Del(key []byte) error {
+	if err := acc.Account.Del(key); err != nil {
+		return err
+	}
+	acc.ws.record(&TraceStep{Type: TraceStorageDel, Address: traceAddress(acc.Address()), Key: string(key)})
+	return nil
+}
+
+func traceCall(ws WorldState, from, to *Address, function, args string) {
+	if tracer, ok := ws.(CallTracer); ok {
+		tracer.TraceCall(from.Bytes(), to.Bytes(), function, args)
+	}
+}
+
+// traceWorldState wraps the tx's world state to record its execution if the block is traced.
+func (block *Block) traceWorldState(ws WorldState, tx *Transaction) (WorldState, *ExecutionTrace) {
+	if block.tracer == nil {
+		return ws, nil
+	}
+	traced := newTracedWorldState(ws, tx)
+	return traced, traced.trace
+}
+
+// addTrace keeps the trace of a tx packed in the block, callers should hold the merge lock.
+func (block *Block) addTrace(trace *ExecutionTrace) {
+	if trace != nil {
+		block.traces = append(block.traces, trace)
+	}
+}
+
+func (block *Block) takeTraces() []*ExecutionTrace {
+	traces := block.traces
+	block.traces = nil
+	return traces
+}
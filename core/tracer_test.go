@@ -0,0 +1,93 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/core/state"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTracer(t *testing.T) {
+	neb := testNeb(t)
+	bc := neb.chain
+
+	block, err := bc.NewBlock(bc.tailBlock.header.coinbase)
+	assert.Nil(t, err)
+
+	tx := mockNormalTransaction(bc.chainID, 1)
+	txWorldState, err := block.WorldState().Prepare(tx.Hash().String())
+	assert.Nil(t, err)
+
+	// untraced blocks keep the world state as it is.
+	ws, trace := block.traceWorldState(txWorldState, tx)
+	assert.Equal(t, txWorldState, ws)
+	assert.Nil(t, trace)
+
+	block.tracer = NewTracer(bc.storage)
+	ws, trace = block.traceWorldState(txWorldState, tx)
+	assert.NotNil(t, trace)
+
+	acc, err := ws.GetOrCreateUserAccount(tx.from.Bytes())
+	assert.Nil(t, err)
+	value, _ := util.NewUint128FromInt(10)
+	assert.Nil(t, acc.AddBalance(value))
+	assert.Nil(t, acc.SubBalance(value))
+	acc.IncrNonce()
+	assert.Nil(t, acc.Put([]byte("key"), []byte("value")))
+	got, err := acc.Get([]byte("key"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("value"), got)
+	assert.Nil(t, acc.Del([]byte("key")))
+	ws.RecordEvent(tx.Hash(), &state.Event{Topic: "topic", Data: "data"})
+	assert.Nil(t, ws.RecordGas(tx.from.String(), value))
+	traceCall(ws, tx.from, tx.to, "transfer", "[]")
+	tracer, ok := ws.(ExecutionTracer)
+	assert.True(t, ok)
+	tracer.RecordStep(&TraceStep{Type: TraceHostCall, Function: "Blockchain.transfer", Value: "2000"})
+
+	types := []string{TraceBalanceAdd, TraceBalanceSub, TraceNonce, TraceStoragePut, TraceStorageGet, TraceStorageDel, TraceEvent, TraceGas, TraceCall, TraceHostCall}
+	assert.Equal(t, len(types), len(trace.Steps))
+	for i, step := range trace.Steps {
+		assert.Equal(t, types[i], step.Type)
+	}
+	assert.Equal(t, tx.from.String(), trace.Steps[0].Address)
+	assert.Equal(t, "1", trace.Steps[2].Value)
+	assert.Equal(t, "value", trace.Steps[3].Value)
+	assert.Equal(t, "value", trace.Steps[4].Value)
+	assert.Equal(t, "topic", trace.Steps[6].Key)
+	assert.Equal(t, tx.to.String(), trace.Steps[8].Address)
+	assert.Equal(t, "transfer", trace.Steps[8].Function)
+
+	block.addTrace(trace)
+	assert.Nil(t, block.tracer.Store(block))
+	assert.Equal(t, 0, len(block.traces))
+
+	stored, err := block.tracer.Trace(tx.Hash())
+	assert.Nil(t, err)
+	assert.Equal(t, trace.TxHash, stored.TxHash)
+	assert.Equal(t, block.Height(), stored.Height)
+	assert.Equal(t, len(trace.Steps), len(stored.Steps))
+
+	_, err = block.tracer.Trace(mockNormalTransaction(bc.chainID, 2).Hash())
+	assert.Equal(t, storage.ErrKeyNotFound, err)
+}
@@ -51,6 +51,9 @@ var (
 	// TransactionMaxGas max gas:50 * 10 ** 9
 	TransactionMaxGas, _ = util.NewUint128FromString("50000000000")
 
+	// MaxEstimateGasRounds the max simulations searching the gas limit a tx requires, 36 rounds cover the max gas
+	MaxEstimateGasRounds = 36
+
 	// TransactionGasPrice default gasPrice : 10**6
 	TransactionGasPrice, _ = util.NewUint128FromInt(1000000)
 
@@ -64,6 +67,8 @@ var (
 	MaxDataPayLoadLength = 128 * 1024
 	// MaxDataBinPayloadLength Max data length in binary transaction
 	MaxDataBinPayloadLength = 64
+	// MaxEncryptedPayloadLength Max encrypted payload length in transaction
+	MaxEncryptedPayloadLength = 4 * 1024
 
 	// MaxEventErrLength Max error length in event
 	MaxEventErrLength = 256
@@ -102,6 +107,9 @@ type Transaction struct {
 	gasPrice  *util.Uint128
 	gasLimit  *util.Uint128
 
+	// Payload encrypted to the public key of a recipient, opaque to the execution
+	encryptedPayload []byte
+
 	// Signature
 	alg  keystore.Algorithm
 	sign byteutils.Hash // Signature values
@@ -147,6 +155,20 @@ func (tx *Transaction) Data() []byte {
 	return tx.data.Payload
 }
 
+// EncryptedPayload return the encrypted payload of tx
+func (tx *Transaction) EncryptedPayload() []byte {
+	return tx.encryptedPayload
+}
+
+// SetEncryptedPayload set the payload encrypted to a recipient, tx should be signed after it.
+func (tx *Transaction) SetEncryptedPayload(payload []byte) error {
+	if len(payload) > MaxEncryptedPayloadLength {
+		return ErrTxEncryptedPayloadOutOfMaxLength
+	}
+	tx.encryptedPayload = payload
+	return nil
+}
+
 // ToProto converts domain Tx to proto Tx
 func (tx *Transaction) ToProto() (proto.Message, error) {
 	value, err := tx.value.ToFixedSizeByteSlice()
@@ -174,6 +196,8 @@ func (tx *Transaction) ToProto() (proto.Message, error) {
 		GasLimit:  gasLimit,
 		Alg:       uint32(tx.alg),
 		Sign:      tx.sign,
+
+		EncryptedPayload: tx.encryptedPayload,
 	}, nil
 }
 
@@ -240,6 +264,11 @@ func (tx *Transaction) FromProto(msg proto.Message) error {
 				return err
 			}
 
+			if len(msg.EncryptedPayload) > MaxEncryptedPayloadLength {
+				return ErrTxEncryptedPayloadOutOfMaxLength
+			}
+			tx.encryptedPayload = msg.EncryptedPayload
+
 			tx.alg = alg
 			tx.sign = msg.Sign
 			return nil
@@ -343,6 +372,16 @@ func (tx *Transaction) Hash() byteutils.Hash {
 	return tx.hash
 }
 
+// Alg return the signature algorithm of transaction.
+func (tx *Transaction) Alg() keystore.Algorithm {
+	return tx.alg
+}
+
+// Signature return the signature of transaction.
+func (tx *Transaction) Signature() byteutils.Hash {
+	return tx.sign
+}
+
 // GasPrice returns gasPrice
 func (tx *Transaction) GasPrice() *util.Uint128 {
 	return tx.gasPrice
@@ -374,9 +413,9 @@ func (tx *Transaction) GasCountOfTxBase() (*util.Uint128, error) {
 	return txGas, nil
 }
 
-// DataLen return the length of payload
+// DataLen return the length of payload, including the encrypted one
 func (tx *Transaction) DataLen() int {
-	return len(tx.data.Payload)
+	return len(tx.data.Payload) + len(tx.encryptedPayload)
 }
 
 // LoadPayload returns tx's payload
@@ -393,6 +432,18 @@ func (tx *Transaction) LoadPayload() (TxPayload, error) {
 		payload, err = LoadDeployPayload(tx.data.Payload)
 	case TxPayloadCallType:
 		payload, err = LoadCallPayload(tx.data.Payload)
+	case TxPayloadEvidenceType:
+		payload, err = LoadEvidencePayload(tx.data.Payload)
+	case TxPayloadCandidateType:
+		payload, err = LoadCandidatePayload(tx.data.Payload)
+	case TxPayloadDelegateType:
+		payload, err = LoadDelegatePayload(tx.data.Payload)
+	case TxPayloadUpgradeType:
+		payload, err = LoadUpgradePayload(tx.data.Payload)
+	case TxPayloadMultisigType:
+		payload, err = LoadMultisigPayload(tx.data.Payload)
+	case TxPayloadRotateKeyType:
+		payload, err = LoadRotateKeyPayload(tx.data.Payload)
 	default:
 		err = ErrInvalidTxPayloadType
 	}
@@ -452,11 +503,40 @@ func submitTx(tx *Transaction, block *Block, ws WorldState,
 
 // VerifyExecution transaction and return result.
 func VerifyExecution(tx *Transaction, block *Block, ws WorldState) (bool, error) {
+	if tx.alg == keystore.ED25519 && block.height < Ed25519Height {
+		// the tx can't be packed before the fork, won't giveback the tx
+		return false, ErrInvalidSignatureAlg
+	}
+	if (tx.from.Type() == MultisigAddress || tx.to.Type() == MultisigAddress) && block.height < MultisigHeight {
+		// the multisig accounts don't exist before the fork, won't giveback the tx
+		return false, ErrInvalidAddressType
+	}
+	if tx.isRotatedSign() && block.height < KeyRotationHeight {
+		// the keys can't be rotated before the fork, won't giveback the tx
+		return false, ErrInvalidTransactionSigner
+	}
+	if len(tx.encryptedPayload) > 0 && block.height < EncryptedPayloadHeight {
+		// the tx can't be packed before the fork, won't giveback the tx
+		return false, ErrTxEncryptedPayloadNotSupported
+	}
+
 	// step0. perpare accounts.
 	fromAcc, err := ws.GetOrCreateUserAccount(tx.from.address)
 	if err != nil {
 		return true, err
 	}
+	if tx.from.Type() == MultisigAddress {
+		if err := tx.verifyMultisig(fromAcc); err != nil {
+			// not signed by enough owners, won't giveback the tx
+			return false, err
+		}
+	}
+	if tx.from.Type() == AccountAddress && block.height >= KeyRotationHeight {
+		if err := tx.verifyActiveKey(fromAcc); err != nil {
+			// not signed by the active key, won't giveback the tx
+			return false, err
+		}
+	}
 	toAcc, err := ws.GetOrCreateUserAccount(tx.to.address)
 	if err != nil {
 		return true, err
@@ -495,6 +575,43 @@ func VerifyExecution(tx *Transaction, block *Block, ws WorldState) (bool, error)
 
 	// step3. check payload vaild.
 	payload, payloadErr := tx.LoadPayload()
+	if payloadErr == nil && tx.data.Type == TxPayloadEvidenceType && block.height < DoubleSignEvidenceHeight {
+		payloadErr = ErrInvalidTxPayloadType
+	}
+	if payloadErr == nil && (tx.data.Type == TxPayloadCandidateType || tx.data.Type == TxPayloadDelegateType) && block.height < ProofOfDevotionHeight {
+		payloadErr = ErrInvalidTxPayloadType
+	}
+	if payloadErr == nil && tx.data.Type == TxPayloadMultisigType && block.height < MultisigHeight {
+		payloadErr = ErrInvalidTxPayloadType
+	}
+	if payloadErr == nil && tx.data.Type == TxPayloadRotateKeyType && block.height < KeyRotationHeight {
+		payloadErr = ErrInvalidTxPayloadType
+	}
+	if deploy, ok := payload.(*DeployPayload); ok && deploy.SourceType == SourceTypeWasm && block.height < WasmHeight {
+		payloadErr = ErrInvalidDeploySourceType
+	}
+	if payloadErr == nil && block.height < ContractUpgradeHeight && tx.data.Type == TxPayloadUpgradeType {
+		payloadErr = ErrInvalidTxPayloadType
+	}
+	if deploy, ok := payload.(*DeployPayload); ok && payloadErr == nil {
+		_, payloadErr = deploy.AdminAt(block.height)
+	}
+	if payloadErr == nil && block.height < ContractModulesHeight {
+		if deploy, ok := payload.(*DeployPayload); ok && len(deploy.Modules) > 0 {
+			payloadErr = ErrInvalidTxPayloadType
+		}
+		if upgrade, ok := payload.(*UpgradePayload); ok && len(upgrade.Modules) > 0 {
+			payloadErr = ErrInvalidTxPayloadType
+		}
+	}
+	if payloadErr == nil && block.height < ContractABIHeight {
+		if deploy, ok := payload.(*DeployPayload); ok && len(deploy.ABI) > 0 {
+			payloadErr = ErrInvalidTxPayloadType
+		}
+		if upgrade, ok := payload.(*UpgradePayload); ok && len(upgrade.ABI) > 0 {
+			payloadErr = ErrInvalidTxPayloadType
+		}
+	}
 	if payloadErr != nil {
 		return submitTx(tx, block, ws, gasUsed, payloadErr, "Failed to load payload.", "")
 	}
@@ -577,6 +694,11 @@ func VerifyExecution(tx *Transaction, block *Block, ws WorldState) (bool, error)
 
 // simulateExecution simulate execution and return gasUsed, executionResult and executionErr, sysErr if occurred.
 func (tx *Transaction) simulateExecution(block *Block) (*SimulateResult, error) {
+	return tx.simulateExecutionWithGas(block, TransactionMaxGas)
+}
+
+// simulateExecutionWithGas simulate execution with the gas limit instead of the one of tx.
+func (tx *Transaction) simulateExecutionWithGas(block *Block, gasLimit *util.Uint128) (*SimulateResult, error) {
 	// hash is necessary in nvm
 	hash, err := tx.calHash()
 	if err != nil {
@@ -630,8 +752,12 @@ func (tx *Transaction) simulateExecution(block *Block) (*SimulateResult, error)
 		}
 
 		// execute.
+		limitedGas, err := gasLimit.Sub(gasUsed)
+		if err != nil {
+			return &SimulateResult{gasUsed, "Out of gas limit", ErrOutOfGasLimit}, nil
+		}
 		gasExecution := util.NewUint128()
-		gasExecution, result, exeErr = payload.Execute(TransactionMaxGas, tx, block, ws)
+		gasExecution, result, exeErr = payload.Execute(limitedGas, tx, block, ws)
 
 		// add gas.
 		executedGas, err := gasUsed.Add(gasExecution)
@@ -747,8 +873,39 @@ func (tx *Transaction) Sign(signature keystore.Signature) error {
 	return nil
 }
 
+// SigningHash return the hash the sender signs, which becomes the tx hash.
+func (tx *Transaction) SigningHash() (byteutils.Hash, error) {
+	return tx.calHash()
+}
+
+// SetSignature set the signature produced apart from the node over
+// SigningHash, e.g. by an offline signer, and check it is signed by the sender.
+func (tx *Transaction) SetSignature(alg keystore.Algorithm, sign []byte) error {
+	if err := crypto.CheckAlgorithm(alg); err != nil {
+		return err
+	}
+	hash, err := tx.calHash()
+	if err != nil {
+		return err
+	}
+	tx.hash = hash
+	tx.alg = alg
+	tx.sign = sign
+	return tx.verifySign()
+}
+
 // VerifyIntegrity return transaction verify result, including Hash and Signature.
 func (tx *Transaction) VerifyIntegrity(chainID uint32) error {
+	if err := tx.verifyHash(chainID); err != nil {
+		return err
+	}
+
+	// check Signature.
+	return tx.verifySign()
+
+}
+
+func (tx *Transaction) verifyHash(chainID uint32) error {
 	// check ChainID.
 	if tx.chainID != chainID {
 		return ErrInvalidChainID
@@ -762,17 +919,29 @@ func (tx *Transaction) VerifyIntegrity(chainID uint32) error {
 	if wantedHash.Equals(tx.hash) == false {
 		return ErrInvalidTransactionHash
 	}
-
-	// check Signature.
-	return tx.verifySign()
-
+	return nil
 }
 
 func (tx *Transaction) verifySign() error {
+	if tx.from.Type() == MultisigAddress {
+		// the owners are checked against the account state when executed.
+		_, err := tx.multisigSigners()
+		return err
+	}
+	if tx.isRotatedSign() {
+		// the active key is checked against the account state when executed.
+		_, err := tx.rotatedSigner()
+		return err
+	}
 	signer, err := RecoverSignerFromSignature(tx.alg, tx.hash, tx.sign)
 	if err != nil {
 		return err
 	}
+	return tx.verifySigner(signer)
+}
+
+// verifySigner check the signer recovered from the signature is the sender.
+func (tx *Transaction) verifySigner(signer *Address) error {
 	if !tx.from.Equals(signer) {
 		logging.VLog().WithFields(logrus.Fields{
 			"signer":  signer.String(),
@@ -792,7 +961,7 @@ func (tx *Transaction) GenerateContractAddress() (*Address, error) {
 }
 
 // CheckContract check if contract is valid
-func CheckContract(addr *Address, ws WorldState) (state.Account, error) {
+func CheckContract(addr *Address, ws ContractState) (state.Account, error) {
 	if addr == nil || ws == nil {
 		return nil, ErrNilArgument
 	}
@@ -877,7 +1046,7 @@ func AcceptTransaction(tx *Transaction, ws WorldState) (bool, error) {
 }
 
 // GetTransaction from txs Trie
-func GetTransaction(hash byteutils.Hash, ws WorldState) (*Transaction, error) {
+func GetTransaction(hash byteutils.Hash, ws ContractState) (*Transaction, error) {
 	if len(hash) != TxHashByteLength {
 		return nil, ErrInvalidArgument
 	}
@@ -896,6 +1065,42 @@ func GetTransaction(hash byteutils.Hash, ws WorldState) (*Transaction, error) {
 	return tx, nil
 }
 
+// LoadContract return the account and the deploy payload of a contract deployed successfully,
+// the source of an upgraded contract is the one of its latest upgrade.
+func LoadContract(addr *Address, ws ContractState) (state.Account, *DeployPayload, error) {
+	contract, err := CheckContract(addr, ws)
+	if err != nil {
+		return nil, nil, err
+	}
+	birthTx, err := GetTransaction(contract.BirthPlace(), ws)
+	if err != nil {
+		return nil, nil, err
+	}
+	deploy, err := LoadDeployPayload(birthTx.data.Payload)
+	if err != nil {
+		return nil, nil, err
+	}
+	if meta := contract.ContractMeta(); meta != nil && len(meta.CodeTx) > 0 {
+		upgradeTx, err := GetTransaction(meta.CodeTx, ws)
+		if err != nil {
+			return nil, nil, err
+		}
+		upgrade, err := LoadUpgradePayload(upgradeTx.data.Payload)
+		if err != nil {
+			return nil, nil, err
+		}
+		deploy = &DeployPayload{
+			SourceType: upgrade.SourceType,
+			Source:     upgrade.Source,
+			Args:       deploy.Args,
+			Admin:      deploy.Admin,
+			SourceMap:  upgrade.SourceMap,
+			Modules:    upgrade.Modules,
+		}
+	}
+	return contract, deploy, nil
+}
+
 // HashTransaction hash the transaction.
 func (tx *Transaction) calHash() (byteutils.Hash, error) {
 	hasher := sha3.New256()
@@ -926,6 +1131,10 @@ func (tx *Transaction) calHash() (byteutils.Hash, error) {
 	hasher.Write(byteutils.FromUint32(tx.chainID))
 	hasher.Write(gasPrice)
 	hasher.Write(gasLimit)
+	if len(tx.encryptedPayload) > 0 {
+		// the hash of the txs without it are kept.
+		hasher.Write(tx.encryptedPayload)
+	}
 
 	return hasher.Sum(nil), nil
 }
@@ -67,19 +67,12 @@ func (payload *BinaryPayload) Execute(limitedGas *util.Uint128, tx *Transaction,
 		}
 
 		// contract address is tx.to.
-		contract, err := CheckContract(tx.to, ws)
+		contract, deploy, err := LoadContract(tx.to, ws) // ToConfirm: move deploy payload in ctx.
 		if err != nil {
 			return util.NewUint128(), "", err
 		}
 
-		birthTx, err := GetTransaction(contract.BirthPlace(), ws)
-		if err != nil {
-			return util.NewUint128(), "", err
-		}
-		deploy, err := LoadDeployPayload(birthTx.data.Payload) // ToConfirm: move deploy payload in ctx.
-		if err != nil {
-			return util.NewUint128(), "", err
-		}
+		traceCall(ws, tx.from, tx.to, ContractAcceptFunc, "")
 
 		engine, err := block.nvm.CreateEngine(block, tx, contract, ws)
 		if err != nil {
@@ -87,10 +80,13 @@ func (payload *BinaryPayload) Execute(limitedGas *util.Uint128, tx *Transaction,
 		}
 		defer engine.Dispose()
 
-		if err := engine.SetExecutionLimits(limitedGas.Uint64(), DefaultLimitsOfTotalMemorySize); err != nil {
+		if err := engine.SetExecutionLimits(limitedGas.Uint64(), ContractExecutionLimits().MemorySize); err != nil {
 			return util.NewUint128(), "", err
 		}
 
+		engine.SetSourceMap(deploy.SourceMap)
+		engine.SetModules(deploy.Modules)
+
 		result, exeErr := engine.Call(deploy.Source, deploy.SourceType, ContractAcceptFunc, "")
 		gasCount := engine.ExecutionInstructions()
 		instructions, err := util.NewUint128FromInt(int64(gasCount))
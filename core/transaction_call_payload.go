@@ -111,24 +111,12 @@ func (payload *CallPayload) Execute(limitedGas *util.Uint128, tx *Transaction, b
 	}
 
 	// contract address is tx.to.
-	contract, err := CheckContract(tx.to, ws)
+	contract, deploy, err := LoadContract(tx.to, ws)
 	if err != nil {
 		return util.NewUint128(), "", err
 	}
 
-	birthTx, err := GetTransaction(contract.BirthPlace(), ws)
-	if err != nil {
-		return util.NewUint128(), "", err
-	}
-	/* // useless owner.
-	owner, err := ws.GetOrCreateUserAccount(birthTx.from.Bytes())
-	if err != nil {
-		return util.NewUint128(), "", err
-	} */
-	deploy, err := LoadDeployPayload(birthTx.data.Payload) // ToConfirm: move deploy payload in ctx.
-	if err != nil {
-		return util.NewUint128(), "", err
-	}
+	traceCall(ws, tx.from, tx.to, payload.Function, payload.Args)
 
 	engine, err := block.nvm.CreateEngine(block, tx, contract, ws)
 	if err != nil {
@@ -137,15 +125,18 @@ func (payload *CallPayload) Execute(limitedGas *util.Uint128, tx *Transaction, b
 	defer engine.Dispose()
 
 	if IsCompatibleStack(block.header.chainID, tx.hash) == true {
-		if err := engine.SetExecutionLimits(2000, DefaultLimitsOfTotalMemorySize); err != nil {
+		if err := engine.SetExecutionLimits(2000, ContractExecutionLimits().MemorySize); err != nil {
 			return util.NewUint128(), "", err
 		}
 	} else {
-		if err := engine.SetExecutionLimits(limitedGas.Uint64(), DefaultLimitsOfTotalMemorySize); err != nil {
+		if err := engine.SetExecutionLimits(limitedGas.Uint64(), ContractExecutionLimits().MemorySize); err != nil {
 			return util.NewUint128(), "", err
 		}
 	}
 
+	engine.SetSourceMap(deploy.SourceMap)
+	engine.SetModules(deploy.Modules)
+
 	result, exeErr := engine.Call(deploy.Source, deploy.SourceType, payload.Function, payload.Args)
 	gasCount := engine.ExecutionInstructions()
 	instructions, err := util.NewUint128FromInt(int64(gasCount))
@@ -0,0 +1,133 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"encoding/json"
+
+	"github.com/nebulasio/go-nebulas/util"
+)
+
+// CandidatePayload register the sender as a candidate by bonding the value to
+// its deposit, or withdraw the value from its deposit.
+type CandidatePayload struct {
+	Action string
+	Value  string
+}
+
+// LoadCandidatePayload from bytes
+func LoadCandidatePayload(bytes []byte) (*CandidatePayload, error) {
+	payload := &CandidatePayload{}
+	if err := json.Unmarshal(bytes, payload); err != nil {
+		return nil, ErrInvalidArgument
+	}
+	return NewCandidatePayload(payload.Action, payload.Value)
+}
+
+// NewCandidatePayload with action & value
+func NewCandidatePayload(action, value string) (*CandidatePayload, error) {
+	if action != RegisterAction && action != WithdrawAction {
+		return nil, ErrInvalidCandidatePayloadAction
+	}
+	if _, err := parseStakeValue(value); err != nil {
+		return nil, err
+	}
+	return &CandidatePayload{
+		Action: action,
+		Value:  value,
+	}, nil
+}
+
+// ToBytes serialize payload
+func (payload *CandidatePayload) ToBytes() ([]byte, error) {
+	return json.Marshal(payload)
+}
+
+// BaseGasCount returns base gas count
+func (payload *CandidatePayload) BaseGasCount() *util.Uint128 {
+	return util.NewUint128()
+}
+
+// Execute the payload in tx. The deposit of a candidate in the current dynasty
+// or jailed is locked, the candidate is unregistered once its deposit is empty.
+func (payload *CandidatePayload) Execute(limitedGas *util.Uint128, tx *Transaction, block *Block, ws WorldState) (*util.Uint128, string, error) {
+	if block == nil || tx == nil {
+		return util.NewUint128(), "", ErrNilArgument
+	}
+	value, err := parseStakeValue(payload.Value)
+	if err != nil {
+		return util.NewUint128(), "", err
+	}
+
+	acc, err := ws.GetOrCreateUserAccount(tx.from.address)
+	if err != nil {
+		return util.NewUint128(), "", err
+	}
+	registry, err := ws.GetOrCreateUserAccount(PoDRegistryAddress.address)
+	if err != nil {
+		return util.NewUint128(), "", err
+	}
+	deposit, err := ValidatorDeposit(acc)
+	if err != nil {
+		return util.NewUint128(), "", err
+	}
+
+	switch payload.Action {
+	case RegisterAction:
+		if deposit, err = deposit.Add(value); err != nil {
+			return util.NewUint128(), "", err
+		}
+		if deposit.Cmp(MinCandidateDeposit) < 0 {
+			return util.NewUint128(), "", ErrDepositBelowMinimum
+		}
+		if err := lockStake(acc, tx, value); err != nil {
+			return util.NewUint128(), "", err
+		}
+		if err := registry.Put(candidateKey(tx.from), tx.from.address); err != nil {
+			return util.NewUint128(), "", err
+		}
+	case WithdrawAction:
+		dynasty, err := ws.Dynasty()
+		if err != nil {
+			return util.NewUint128(), "", err
+		}
+		if isValidator(dynasty, tx.from) || block.height < JailedUntil(acc) {
+			return util.NewUint128(), "", ErrCandidateDepositLocked
+		}
+		if deposit, err = deposit.Sub(value); err != nil {
+			return util.NewUint128(), "", ErrInsufficientDeposit
+		}
+		if err := acc.AddBalance(value); err != nil {
+			return util.NewUint128(), "", err
+		}
+		if deposit.Cmp(util.NewUint128()) == 0 {
+			if err := registry.Del(candidateKey(tx.from)); err != nil {
+				return util.NewUint128(), "", err
+			}
+		}
+	}
+	if err := SetValidatorDeposit(acc, deposit); err != nil {
+		return util.NewUint128(), "", err
+	}
+
+	if err := recordStakeEvent(ws, tx, payload.Action, tx.from, value, acc); err != nil {
+		return util.NewUint128(), "", err
+	}
+	return util.NewUint128(), "", nil
+}
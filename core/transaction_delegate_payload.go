@@ -0,0 +1,153 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"encoding/json"
+
+	"github.com/nebulasio/go-nebulas/util"
+)
+
+// DelegatePayload delegate the value of the sender to a candidate, or withdraw
+// the value from the stake delegated to it.
+type DelegatePayload struct {
+	Action    string
+	Delegatee string
+	Value     string
+}
+
+// LoadDelegatePayload from bytes
+func LoadDelegatePayload(bytes []byte) (*DelegatePayload, error) {
+	payload := &DelegatePayload{}
+	if err := json.Unmarshal(bytes, payload); err != nil {
+		return nil, ErrInvalidArgument
+	}
+	return NewDelegatePayload(payload.Action, payload.Delegatee, payload.Value)
+}
+
+// NewDelegatePayload with action, delegatee & value
+func NewDelegatePayload(action, delegatee, value string) (*DelegatePayload, error) {
+	if action != DelegateAction && action != WithdrawAction {
+		return nil, ErrInvalidDelegatePayloadAction
+	}
+	if _, err := AddressParse(delegatee); err != nil {
+		return nil, err
+	}
+	if _, err := parseStakeValue(value); err != nil {
+		return nil, err
+	}
+	return &DelegatePayload{
+		Action:    action,
+		Delegatee: delegatee,
+		Value:     value,
+	}, nil
+}
+
+// ToBytes serialize payload
+func (payload *DelegatePayload) ToBytes() ([]byte, error) {
+	return json.Marshal(payload)
+}
+
+// BaseGasCount returns base gas count
+func (payload *DelegatePayload) BaseGasCount() *util.Uint128 {
+	return util.NewUint128()
+}
+
+// Execute the payload in tx. The stake is delegated to registered candidates
+// only, and can be withdrawn at any time.
+func (payload *DelegatePayload) Execute(limitedGas *util.Uint128, tx *Transaction, block *Block, ws WorldState) (*util.Uint128, string, error) {
+	if block == nil || tx == nil {
+		return util.NewUint128(), "", ErrNilArgument
+	}
+	value, err := parseStakeValue(payload.Value)
+	if err != nil {
+		return util.NewUint128(), "", err
+	}
+	delegatee, err := AddressParse(payload.Delegatee)
+	if err != nil {
+		return util.NewUint128(), "", err
+	}
+
+	acc, err := ws.GetOrCreateUserAccount(tx.from.address)
+	if err != nil {
+		return util.NewUint128(), "", err
+	}
+	candidate, err := ws.GetOrCreateUserAccount(delegatee.address)
+	if err != nil {
+		return util.NewUint128(), "", err
+	}
+	delegated, err := storageUint128(acc, delegatedKey(delegatee))
+	if err != nil {
+		return util.NewUint128(), "", err
+	}
+	votes, err := CandidateVotes(candidate)
+	if err != nil {
+		return util.NewUint128(), "", err
+	}
+
+	switch payload.Action {
+	case DelegateAction:
+		registered, err := isCandidate(ws, delegatee)
+		if err != nil {
+			return util.NewUint128(), "", err
+		}
+		if !registered {
+			return util.NewUint128(), "", ErrInvalidDelegateToNonCandidate
+		}
+		if err := lockStake(acc, tx, value); err != nil {
+			return util.NewUint128(), "", err
+		}
+		if delegated, err = delegated.Add(value); err != nil {
+			return util.NewUint128(), "", err
+		}
+		if votes, err = votes.Add(value); err != nil {
+			return util.NewUint128(), "", err
+		}
+	case WithdrawAction:
+		if delegated.Cmp(util.NewUint128()) == 0 {
+			return util.NewUint128(), "", ErrInvalidUnDelegateFromNonDelegatee
+		}
+		if delegated, err = delegated.Sub(value); err != nil {
+			return util.NewUint128(), "", ErrInsufficientDelegation
+		}
+		if votes, err = votes.Sub(value); err != nil {
+			return util.NewUint128(), "", ErrInsufficientDelegation
+		}
+		if err := acc.AddBalance(value); err != nil {
+			return util.NewUint128(), "", err
+		}
+	}
+
+	if delegated.Cmp(util.NewUint128()) == 0 {
+		err = acc.Del(delegatedKey(delegatee))
+	} else {
+		err = putStorageUint128(acc, delegatedKey(delegatee), delegated)
+	}
+	if err != nil {
+		return util.NewUint128(), "", err
+	}
+	if err := putStorageUint128(candidate, []byte(CandidateVotesKey), votes); err != nil {
+		return util.NewUint128(), "", err
+	}
+
+	if err := recordStakeEvent(ws, tx, payload.Action, delegatee, value, candidate); err != nil {
+		return util.NewUint128(), "", err
+	}
+	return util.NewUint128(), "", nil
+}
@@ -21,22 +21,35 @@ package core
 import (
 	"encoding/json"
 	"fmt"
+	"regexp"
 
 	"github.com/nebulasio/go-nebulas/core/pb"
 	"github.com/nebulasio/go-nebulas/core/state"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
 	"github.com/nebulasio/go-nebulas/util/logging"
 	"github.com/sirupsen/logrus"
 
 	"github.com/nebulasio/go-nebulas/util"
 )
 
-// DeployPayload carry contract deploy information
+// DeployPayload carry contract deploy information, the contract deployed with
+// an admin can be upgraded by it, see UpgradePayload. The optional source map
+// only maps the positions in exceptions back to the original sources. The
+// modules are the sources the contract requires by their path, in its source type.
+// The optional ABI describes the functions of the contract, see ContractABI.
 type DeployPayload struct {
 	SourceType string
 	Source     string
 	Args       string
+	Admin      string            `json:",omitempty"`
+	SourceMap  string            `json:",omitempty"`
+	Modules    map[string]string `json:",omitempty"`
+	ABI        string            `json:",omitempty"`
 }
 
+// the path of a contract module, its first part can't be the version of the js libs.
+var contractModuleRe = regexp.MustCompile(`^[A-Za-z_][\w-]*(/[\w-][\w.-]*)*\.js$`)
+
 // CheckContractArgs check contract args
 func CheckContractArgs(args string) error {
 	if len(args) > 0 {
@@ -48,13 +61,47 @@ func CheckContractArgs(args string) error {
 	return nil
 }
 
-// LoadDeployPayload from bytes
+// CheckContractModules check the modules deployed with a contract, they should
+// not take the place of the contract or the js libs.
+func CheckContractModules(sourceType string, modules map[string]string) error {
+	if len(modules) > 0 && sourceType == SourceTypeWasm {
+		return ErrInvalidContractModule
+	}
+	for path, source := range modules {
+		if !contractModuleRe.MatchString(path) || path == "contract.js" || len(source) == 0 {
+			return ErrInvalidContractModule
+		}
+		if _, ok := V8JSLibs[path]; ok {
+			return ErrInvalidContractModule
+		}
+	}
+	return nil
+}
+
+// LoadDeployPayload from bytes. The admin is not checked here, the field is
+// only taken since ContractUpgradeHeight, see DeployPayload.AdminAt.
 func LoadDeployPayload(bytes []byte) (*DeployPayload, error) {
 	payload := &DeployPayload{}
 	if err := json.Unmarshal(bytes, payload); err != nil {
 		return nil, ErrInvalidArgument
 	}
-	return NewDeployPayload(payload.Source, payload.SourceType, payload.Args)
+	deploy, err := NewDeployPayload(payload.Source, payload.SourceType, payload.Args)
+	if err != nil {
+		return nil, err
+	}
+	if err := CheckContractModules(payload.SourceType, payload.Modules); err != nil {
+		return nil, err
+	}
+	if len(payload.ABI) > 0 {
+		if _, err := ParseContractABI(payload.ABI); err != nil {
+			return nil, err
+		}
+	}
+	deploy.Admin = payload.Admin
+	deploy.SourceMap = payload.SourceMap
+	deploy.Modules = payload.Modules
+	deploy.ABI = payload.ABI
+	return deploy, nil
 }
 
 // NewDeployPayload with source & args
@@ -63,7 +110,14 @@ func NewDeployPayload(source, sourceType, args string) (*DeployPayload, error) {
 		return nil, ErrInvalidDeploySource
 	}
 
-	if sourceType != SourceTypeTypeScript && sourceType != SourceTypeJavaScript {
+	switch sourceType {
+	case SourceTypeJavaScript, SourceTypeTypeScript:
+	case SourceTypeWasm:
+		// the module is hex encoded.
+		if _, err := byteutils.FromHex(source); err != nil {
+			return nil, ErrInvalidDeploySource
+		}
+	default:
 		return nil, ErrInvalidDeploySourceType
 	}
 
@@ -78,6 +132,17 @@ func NewDeployPayload(source, sourceType, args string) (*DeployPayload, error) {
 	}, nil
 }
 
+// AdminAt return the admin of the contract deployed at the height, nil if it has
+// none. Before ContractUpgradeHeight the field is ignored, since the json of a
+// payload is matched case-insensitively and an "admin" in an old payload was
+// never meant to be one.
+func (payload *DeployPayload) AdminAt(height uint64) (*Address, error) {
+	if height < ContractUpgradeHeight || len(payload.Admin) == 0 {
+		return nil, nil
+	}
+	return AddressParse(payload.Admin)
+}
+
 // ToBytes serialize payload
 func (payload *DeployPayload) ToBytes() ([]byte, error) {
 	return json.Marshal(payload)
@@ -115,7 +180,21 @@ func (payload *DeployPayload) Execute(limitedGas *util.Uint128, tx *Transaction,
 	} */
 	var contract state.Account
 	v := GetMaxV8JSLibVersionAtHeight(block.Height())
-	if len(v) > 0 {
+	abi, err := normalizeContractABI(payload.ABI)
+	if err != nil {
+		return util.NewUint128(), "", err
+	}
+	admin, err := payload.AdminAt(block.Height())
+	if err != nil {
+		return util.NewUint128(), "", err
+	}
+	if admin != nil || len(abi) > 0 {
+		meta := &corepb.ContractMeta{Version: v, Abi: abi}
+		if admin != nil {
+			meta.Admin = admin.Bytes()
+		}
+		contract, err = ws.CreateContractAccount(addr.Bytes(), tx.Hash(), meta)
+	} else if len(v) > 0 {
 		contract, err = ws.CreateContractAccount(addr.Bytes(), tx.Hash(), &corepb.ContractMeta{Version: v})
 	} else {
 		contract, err = ws.CreateContractAccount(addr.Bytes(), tx.Hash(), nil)
@@ -124,16 +203,27 @@ func (payload *DeployPayload) Execute(limitedGas *util.Uint128, tx *Transaction,
 		return util.NewUint128(), "", err
 	}
 
+	traceCall(ws, tx.from, addr, ContractInitFunc, payload.Args)
+
+	if block.height >= ContractValidationHeight {
+		if err := block.nvm.ValidateContract(block, payload.Source, payload.SourceType, payload.Modules); err != nil {
+			return util.NewUint128(), "", err
+		}
+	}
+
 	engine, err := block.nvm.CreateEngine(block, tx, contract, ws)
 	if err != nil {
 		return util.NewUint128(), "", err
 	}
 	defer engine.Dispose()
 
-	if err := engine.SetExecutionLimits(limitedGas.Uint64(), DefaultLimitsOfTotalMemorySize); err != nil {
+	if err := engine.SetExecutionLimits(limitedGas.Uint64(), ContractExecutionLimits().MemorySize); err != nil {
 		return util.NewUint128(), "", err
 	}
 
+	engine.SetSourceMap(payload.SourceMap)
+	engine.SetModules(payload.Modules)
+
 	// Deploy and Init.
 	result, exeErr := engine.DeployAndInit(payload.Source, payload.SourceType, payload.Args)
 	gasCount := engine.ExecutionInstructions()
@@ -0,0 +1,117 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"encoding/json"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/core/state"
+	"github.com/nebulasio/go-nebulas/util"
+)
+
+// EvidencePayload carry a double sign evidence to record on chain
+type EvidencePayload struct {
+	Evidence *DoubleSignEvidence
+}
+
+// LoadEvidencePayload from bytes
+func LoadEvidencePayload(bytes []byte) (*EvidencePayload, error) {
+	pb := new(corepb.DoubleSignEvidence)
+	if err := proto.Unmarshal(bytes, pb); err != nil {
+		return nil, ErrInvalidArgument
+	}
+	ev := new(DoubleSignEvidence)
+	if err := ev.FromProto(pb); err != nil {
+		return nil, err
+	}
+	return NewEvidencePayload(ev), nil
+}
+
+// NewEvidencePayload with evidence
+func NewEvidencePayload(ev *DoubleSignEvidence) *EvidencePayload {
+	return &EvidencePayload{
+		Evidence: ev,
+	}
+}
+
+// ToBytes serialize payload
+func (payload *EvidencePayload) ToBytes() ([]byte, error) {
+	pb, err := payload.Evidence.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(pb)
+}
+
+// BaseGasCount returns base gas count
+func (payload *EvidencePayload) BaseGasCount() *util.Uint128 {
+	return util.NewUint128()
+}
+
+// Execute the payload in tx, record the evidence in the storage of the offender,
+// a validator of the current dynasty, once per slot, and slash the offender.
+func (payload *EvidencePayload) Execute(limitedGas *util.Uint128, tx *Transaction, block *Block, ws WorldState) (*util.Uint128, string, error) {
+	if block == nil || tx == nil || payload.Evidence == nil {
+		return util.NewUint128(), "", ErrNilArgument
+	}
+
+	offender, err := payload.Evidence.Verify(block.header.chainID)
+	if err != nil {
+		return util.NewUint128(), "", err
+	}
+	dynasty, err := ws.Dynasty()
+	if err != nil {
+		return util.NewUint128(), "", err
+	}
+	if !isValidator(dynasty, offender) {
+		return util.NewUint128(), "", ErrInvalidEvidenceSigner
+	}
+
+	acc, err := ws.GetOrCreateUserAccount(offender.address)
+	if err != nil {
+		return util.NewUint128(), "", err
+	}
+	key := evidenceKey(payload.Evidence.Timestamp())
+	if _, err := acc.Get(key); err == nil {
+		return util.NewUint128(), "", ErrEvidenceAlreadyRecorded
+	}
+	if err := acc.Put(key, payload.Evidence.Hash()); err != nil {
+		return util.NewUint128(), "", err
+	}
+
+	first, second := payload.Evidence.Hashes()
+	data, err := json.Marshal(&DoubleSignEvent{
+		Validator: offender.String(),
+		Timestamp: payload.Evidence.Timestamp(),
+		First:     first.String(),
+		Second:    second.String(),
+		Reporter:  tx.from.String(),
+	})
+	if err != nil {
+		return util.NewUint128(), "", err
+	}
+	ws.RecordEvent(tx.hash, &state.Event{Topic: TopicDoubleSign, Data: string(data)})
+
+	if err := Slash(ws, tx.hash, offender, SlashDoubleSign, block.height); err != nil {
+		return util.NewUint128(), "", err
+	}
+	return util.NewUint128(), "", nil
+}
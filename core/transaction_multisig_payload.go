@@ -0,0 +1,116 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"encoding/json"
+
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+)
+
+// MultisigPayload create a multisig account owned by the owners, the txs from
+// it need the signatures of threshold owners.
+type MultisigPayload struct {
+	Owners    []string
+	Threshold uint32
+}
+
+// LoadMultisigPayload from bytes
+func LoadMultisigPayload(bytes []byte) (*MultisigPayload, error) {
+	payload := &MultisigPayload{}
+	if err := json.Unmarshal(bytes, payload); err != nil {
+		return nil, ErrInvalidArgument
+	}
+	return NewMultisigPayload(payload.Owners, payload.Threshold)
+}
+
+// NewMultisigPayload with owners & threshold
+func NewMultisigPayload(owners []string, threshold uint32) (*MultisigPayload, error) {
+	if _, err := parseMultisigOwners(owners); err != nil {
+		return nil, err
+	}
+	if threshold == 0 || int(threshold) > len(owners) {
+		return nil, ErrInvalidMultisigThreshold
+	}
+	return &MultisigPayload{
+		Owners:    owners,
+		Threshold: threshold,
+	}, nil
+}
+
+// parseMultisigOwners the owners must be distinct account addresses.
+func parseMultisigOwners(owners []string) ([]*Address, error) {
+	if len(owners) == 0 || len(owners) > MaxMultisigOwners {
+		return nil, ErrInvalidMultisigOwners
+	}
+	addrs := make([]*Address, 0, len(owners))
+	for _, owner := range owners {
+		addr, err := AddressParse(owner)
+		if err != nil {
+			return nil, err
+		}
+		if addr.Type() != AccountAddress || containsAddress(addrs, addr) {
+			return nil, ErrInvalidMultisigOwners
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs, nil
+}
+
+// ToBytes serialize payload
+func (payload *MultisigPayload) ToBytes() ([]byte, error) {
+	return json.Marshal(payload)
+}
+
+// BaseGasCount returns base gas count
+func (payload *MultisigPayload) BaseGasCount() *util.Uint128 {
+	return util.NewUint128()
+}
+
+// Execute the payload in tx, create the multisig account at the address
+// generated from the tx's from & nonce.
+func (payload *MultisigPayload) Execute(limitedGas *util.Uint128, tx *Transaction, block *Block, ws WorldState) (*util.Uint128, string, error) {
+	if block == nil || tx == nil {
+		return util.NewUint128(), "", ErrNilArgument
+	}
+	if !tx.from.Equals(tx.to) {
+		return util.NewUint128(), "", ErrMultisigTransactionAddressNotEqual
+	}
+	owners, err := parseMultisigOwners(payload.Owners)
+	if err != nil {
+		return util.NewUint128(), "", err
+	}
+
+	addr, err := NewMultisigAddressFromData(tx.from.Bytes(), byteutils.FromUint64(tx.nonce))
+	if err != nil {
+		return util.NewUint128(), "", err
+	}
+	acc, err := ws.GetOrCreateUserAccount(addr.Bytes())
+	if err != nil {
+		return util.NewUint128(), "", err
+	}
+	if _, _, err := MultisigOwners(acc); err == nil {
+		return util.NewUint128(), "", ErrMultisigAccountExists
+	}
+	if err := SetMultisigOwners(acc, owners, payload.Threshold); err != nil {
+		return util.NewUint128(), "", err
+	}
+	return util.NewUint128(), addr.String(), nil
+}
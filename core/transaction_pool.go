@@ -41,6 +41,13 @@ var (
 	metricUpdateInterval = time.Second
 	txEvictInterval      = time.Minute
 	txLifetime           = time.Minute * 90
+
+	// txAccountSlots the maximum count of pending transactions from one account.
+	txAccountSlots = 1024
+	// txReplacePriceBump the minimum gas price bump (in percent) to replace a pending transaction with the same nonce.
+	txReplacePriceBump = uint64(10)
+	// txQueueAccountSlots the maximum count of queued future-nonce transactions from one account.
+	txQueueAccountSlots = 64
 )
 
 // TransactionPool cache txs, is thread safe
@@ -49,16 +56,24 @@ type TransactionPool struct {
 	quitCh            chan int
 
 	size              int
+	accountSlots      int
 	candidates        *sorted.Slice
 	buckets           map[byteutils.HexHash]*sorted.Slice
 	all               map[byteutils.HexHash]*Transaction
 	bucketsLastUpdate map[byteutils.HexHash]time.Time
 
+	// txs with a nonce gap, not candidates until the gap fills.
+	queueSlots      int
+	queue           map[byteutils.HexHash]*sorted.Slice
+	queueLastUpdate map[byteutils.HexHash]time.Time
+
 	ns net.Service
 	mu sync.RWMutex
 
-	minGasPrice *util.Uint128 // the lowest gasPrice.
-	maxGasLimit *util.Uint128 // the maximum gasLimit.
+	minGasPrice         *util.Uint128 // the lowest gasPrice.
+	blockMinGasPrice    *util.Uint128 // the lowest gasPrice of the txs packed into blocks, minGasPrice if nil.
+	blockGasLimitTarget *util.Uint128 // the gas limit the minted blocks drift toward, parent's if nil.
+	maxGasLimit         *util.Uint128 // the maximum gasLimit.
 
 	eventEmitter *EventEmitter
 	bc           *BlockChain
@@ -88,10 +103,14 @@ func NewTransactionPool(size int) (*TransactionPool, error) {
 		receivedMessageCh: make(chan net.Message, size),
 		quitCh:            make(chan int, 1),
 		size:              size,
+		accountSlots:      txAccountSlots,
 		candidates:        sorted.NewSlice(gasCmp),
 		buckets:           make(map[byteutils.HexHash]*sorted.Slice),
 		all:               make(map[byteutils.HexHash]*Transaction),
 		bucketsLastUpdate: make(map[byteutils.HexHash]time.Time),
+		queueSlots:        txQueueAccountSlots,
+		queue:             make(map[byteutils.HexHash]*sorted.Slice),
+		queueLastUpdate:   make(map[byteutils.HexHash]time.Time),
 		minGasPrice:       TransactionGasPrice,
 		maxGasLimit:       TransactionMaxGas,
 	}, nil
@@ -116,6 +135,44 @@ func (pool *TransactionPool) SetGasConfig(gasPrice, gasLimit *util.Uint128) erro
 	return nil
 }
 
+// SetGasFloor change the lowest gasPrice of the txs accepted by the pool at runtime,
+// the pending txs below the floor are kept until they're packed or expire.
+func (pool *TransactionPool) SetGasFloor(gasPrice *util.Uint128) error {
+	if gasPrice == nil || gasPrice.Cmp(util.NewUint128()) <= 0 || gasPrice.Cmp(TransactionMaxGasPrice) > 0 {
+		return ErrInvalidGasPrice
+	}
+
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	pool.minGasPrice = gasPrice
+	return nil
+}
+
+// SetBlockMinGasPrice config the lowest gasPrice of the txs packed into blocks.
+func (pool *TransactionPool) SetBlockMinGasPrice(gasPrice *util.Uint128) error {
+	if gasPrice == nil || gasPrice.Cmp(util.NewUint128()) <= 0 {
+		pool.blockMinGasPrice = nil
+	} else if gasPrice.Cmp(TransactionMaxGasPrice) <= 0 {
+		pool.blockMinGasPrice = gasPrice
+	} else {
+		return ErrInvalidGasPrice
+	}
+	return nil
+}
+
+// SetBlockGasLimitTarget config the gas limit the blocks minted by the node drift toward.
+func (pool *TransactionPool) SetBlockGasLimitTarget(target *util.Uint128) error {
+	if target == nil || target.Cmp(util.NewUint128()) <= 0 {
+		pool.blockGasLimitTarget = nil
+	} else if target.Cmp(MinBlockGasLimit) >= 0 {
+		pool.blockGasLimitTarget = target
+	} else {
+		return ErrInvalidBlockGasLimit
+	}
+	return nil
+}
+
 // RegisterInNetwork register message subscriber in network.
 func (pool *TransactionPool) RegisterInNetwork(ns net.Service) {
 	ns.Register(net.NewSubscriber(pool, pool.receivedMessageCh, true, MessageTypeNewTx, net.MessageWeightNewTx))
@@ -163,6 +220,7 @@ func (pool *TransactionPool) loop() {
 			metricsCachedTx.Update(int64(len(pool.all)))
 			metricsBucketTx.Update(int64(len(pool.buckets)))
 			metricsCandidates.Update(int64(pool.candidates.Len()))
+			metricsQueuedTx.Update(int64(len(pool.queue)))
 
 		case <-evictChan:
 			pool.evictExpiredTransactions()
@@ -326,8 +384,27 @@ func (pool *TransactionPool) Push(tx *Transaction) error {
 		return err
 	}
 
-	// cache the verified tx
-	pool.pushTx(tx)
+	// the retired keys of the rotated accounts can't sign.
+	if err := pool.verifyActiveKey(tx); err != nil {
+		metricsInvalidTx.Inc(1)
+		return err
+	}
+
+	if pool.isFutureTx(tx) {
+		// hold the tx until the nonce gap fills.
+		if err := pool.enqueueTx(tx); err != nil {
+			return err
+		}
+	} else {
+		// replace the pending tx with the same nonce, or make room in the account's bucket.
+		if err := pool.prepareSlot(tx); err != nil {
+			return err
+		}
+
+		// cache the verified tx
+		pool.pushTx(tx)
+		pool.promoteTxs(tx.from.address.Hex(), 0)
+	}
 	// drop max tx in longest bucket if full
 	if len(pool.all) > pool.size {
 		poollen := len(pool.all)
@@ -352,6 +429,270 @@ func (pool *TransactionPool) Push(tx *Transaction) error {
 	return nil
 }
 
+func (pool *TransactionPool) prepareSlot(tx *Transaction) error {
+	bucket, ok := pool.buckets[tx.from.address.Hex()]
+	if !ok {
+		return nil
+	}
+
+	for i := 0; i < bucket.Len(); i++ {
+		old := bucket.Index(i).(*Transaction)
+		if old.Nonce() != tx.Nonce() {
+			continue
+		}
+		if !isGasPriceBumped(old.gasPrice, tx.gasPrice) {
+			metricsTxPoolUnderpricedReplacement.Inc(1)
+			return ErrUnderpricedReplacement
+		}
+
+		logging.VLog().WithFields(logrus.Fields{
+			"old": old.StringWithoutData(),
+			"new": tx.StringWithoutData(),
+		}).Debug("Replace pending transaction.")
+
+		pool.removeTx(old)
+		pool.triggerDropEvent(old)
+		return nil
+	}
+
+	if bucket.Len() >= pool.accountSlots {
+		right := bucket.Right().(*Transaction)
+		if tx.Nonce() > right.Nonce() {
+			metricsTxPoolAccountSlotsFull.Inc(1)
+			return ErrTooManyAccountTransactions
+		}
+		// keep the lower nonces which can be packed earlier.
+		pool.removeTx(right)
+		pool.triggerDropEvent(right)
+	}
+	return nil
+}
+
+// nextNonce return the nonce following the pending txs of the account,
+// or following the account nonce in tail state if it has no pending txs.
+func (pool *TransactionPool) nextNonce(addr *Address) (uint64, bool) {
+	if bucket, ok := pool.buckets[addr.address.Hex()]; ok {
+		return bucket.Right().(*Transaction).Nonce() + 1, true
+	}
+	if pool.bc == nil || pool.bc.TailBlock() == nil {
+		return 0, false
+	}
+	acc, err := pool.bc.TailBlock().GetAccount(addr.address)
+	if err != nil {
+		return 0, false
+	}
+	return acc.Nonce() + 1, true
+}
+
+// verifyActiveKey check the tx is signed by the active key of the sender in tail state.
+func (pool *TransactionPool) verifyActiveKey(tx *Transaction) error {
+	if tx.from.Type() != AccountAddress || pool.bc.TailBlock() == nil {
+		return nil
+	}
+	if !tx.isRotatedSign() && pool.bc.TailBlock().height < KeyRotationHeight {
+		return nil
+	}
+	acc, err := pool.bc.TailBlock().GetAccount(tx.from.address)
+	if err != nil {
+		return err
+	}
+	return tx.verifyActiveKey(acc)
+}
+
+// NextNonce return the nonce of the next transaction of the account.
+func (pool *TransactionPool) NextNonce(addr *Address) (uint64, bool) {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+	return pool.nextNonce(addr)
+}
+
+// Content return the pending and the queued txs of the pool grouped by their senders, in nonce order.
+func (pool *TransactionPool) Content() (map[byteutils.HexHash][]*Transaction, map[byteutils.HexHash][]*Transaction) {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	return sortedSliceContent(pool.buckets), sortedSliceContent(pool.queue)
+}
+
+func sortedSliceContent(slices map[byteutils.HexHash]*sorted.Slice) map[byteutils.HexHash][]*Transaction {
+	content := make(map[byteutils.HexHash][]*Transaction, len(slices))
+	for addr, slice := range slices {
+		txs := make([]*Transaction, slice.Len())
+		for i := 0; i < slice.Len(); i++ {
+			txs[i] = slice.Index(i).(*Transaction)
+		}
+		content[addr] = txs
+	}
+	return content
+}
+
+// GasPrice return the lowest gasPrice of the txs packed into blocks.
+func (pool *TransactionPool) GasPrice() *util.Uint128 {
+	if pool.blockMinGasPrice != nil && pool.blockMinGasPrice.Cmp(pool.minGasPrice) > 0 {
+		return pool.blockMinGasPrice
+	}
+	return pool.minGasPrice
+}
+
+// isFutureTx return if there is a nonce gap between tx and the pending txs of its account.
+func (pool *TransactionPool) isFutureTx(tx *Transaction) bool {
+	next, ok := pool.nextNonce(tx.from)
+	return ok && tx.Nonce() > next
+}
+
+// enqueueTx put a future-nonce tx into the queue of its account.
+func (pool *TransactionPool) enqueueTx(tx *Transaction) error {
+	slot := tx.from.address.Hex()
+	queue, ok := pool.queue[slot]
+	if !ok {
+		queue = sorted.NewSlice(nonceCmp)
+	}
+
+	for i := 0; i < queue.Len(); i++ {
+		old := queue.Index(i).(*Transaction)
+		if old.Nonce() != tx.Nonce() {
+			continue
+		}
+		if !isGasPriceBumped(old.gasPrice, tx.gasPrice) {
+			metricsTxPoolUnderpricedReplacement.Inc(1)
+			return ErrUnderpricedReplacement
+		}
+		pool.removeQueuedTx(old)
+		pool.triggerDropEvent(old)
+		break
+	}
+
+	if queue.Len() >= pool.queueSlots {
+		right := queue.Right().(*Transaction)
+		if tx.Nonce() > right.Nonce() {
+			metricsTxPoolQueueSlotsFull.Inc(1)
+			return ErrTooManyQueuedTransactions
+		}
+		pool.removeQueuedTx(right)
+		pool.triggerDropEvent(right)
+	}
+
+	// a new queue, or the one deleted when it became empty above.
+	if _, ok := pool.queue[slot]; !ok {
+		pool.queue[slot] = queue
+		pool.queueLastUpdate[slot] = time.Now()
+	}
+	queue.Push(tx)
+	pool.all[tx.hash.Hex()] = tx
+
+	logging.VLog().WithFields(logrus.Fields{
+		"tx": tx.StringWithoutData(),
+	}).Debug("Queue future transaction.")
+	return nil
+}
+
+// removeQueuedTx remove a tx from the queue of its account.
+func (pool *TransactionPool) removeQueuedTx(tx *Transaction) {
+	slot := tx.from.address.Hex()
+	queue, ok := pool.queue[slot]
+	if !ok {
+		return
+	}
+	delete(pool.all, tx.hash.Hex())
+	queue.Del(tx)
+	if queue.Len() == 0 {
+		delete(pool.queue, slot)
+		delete(pool.queueLastUpdate, slot)
+	}
+}
+
+// promoteTxs move the queued txs of the account to pending once their nonce gap fills,
+// and drop the stale ones whose nonce is already pending or on chain.
+// minNext is the lowest next nonce known by caller, e.g. following a tx just put on chain.
+func (pool *TransactionPool) promoteTxs(slot byteutils.HexHash, minNext uint64) {
+	queue, ok := pool.queue[slot]
+	if !ok {
+		return
+	}
+
+	for queue.Len() > 0 {
+		if bucket, ok := pool.buckets[slot]; ok && bucket.Len() >= pool.accountSlots {
+			break
+		}
+		tx := queue.Left().(*Transaction)
+		next, ok := pool.nextNonce(tx.from)
+		if !ok || next < minNext {
+			next = minNext
+		}
+		if tx.Nonce() > next {
+			break
+		}
+		queue.PopLeft()
+		if tx.Nonce() < next {
+			delete(pool.all, tx.hash.Hex())
+			pool.triggerDropEvent(tx)
+			continue
+		}
+		pool.pushTx(tx)
+		metricsTxPoolPromoted.Inc(1)
+
+		logging.VLog().WithFields(logrus.Fields{
+			"tx": tx.StringWithoutData(),
+		}).Debug("Promote queued transaction.")
+	}
+
+	if queue.Len() == 0 {
+		delete(pool.queue, slot)
+		delete(pool.queueLastUpdate, slot)
+	}
+}
+
+// isGasPriceBumped return if price is at least txReplacePriceBump percent higher than old.
+func isGasPriceBumped(old, price *util.Uint128) bool {
+	hundred, _ := util.NewUint128FromInt(100)
+	bump, _ := util.NewUint128FromInt(int64(100 + txReplacePriceBump))
+
+	threshold, err := old.Mul(bump)
+	if err != nil {
+		return false
+	}
+	scaled, err := price.Mul(hundred)
+	if err != nil {
+		return false
+	}
+	return scaled.Cmp(threshold) >= 0
+}
+
+// removeTx remove any tx from its bucket, keeping the candidates up to date.
+func (pool *TransactionPool) removeTx(tx *Transaction) {
+	slot := tx.from.address.Hex()
+	bucket, ok := pool.buckets[slot]
+	if !ok {
+		return
+	}
+
+	delete(pool.all, tx.hash.Hex())
+	if bucket.Left() != tx {
+		bucket.Del(tx)
+		return
+	}
+
+	pool.candidates.Del(tx)
+	bucket.PopLeft()
+	if bucket.Len() != 0 {
+		pool.candidates.Push(bucket.Left())
+	} else {
+		delete(pool.buckets, slot)
+		delete(pool.bucketsLastUpdate, slot)
+	}
+}
+
+func (pool *TransactionPool) triggerDropEvent(tx *Transaction) {
+	if pool.eventEmitter == nil {
+		return
+	}
+	event := &state.Event{
+		Topic: TopicDropTransaction,
+		Data:  tx.JSONString(),
+	}
+	pool.eventEmitter.Trigger(event)
+}
+
 func (pool *TransactionPool) pushTx(tx *Transaction) {
 	slot := tx.from.address.Hex()
 	bucket, ok := pool.buckets[slot]
@@ -392,6 +733,12 @@ func (pool *TransactionPool) popTx(tx *Transaction) {
 }
 
 func (pool *TransactionPool) dropTx() {
+	// the queued txs can't be packed soon, drop them first.
+	if len(pool.queue) > 0 {
+		pool.dropQueuedTx()
+		return
+	}
+
 	var longestSlice *sorted.Slice
 	longestLen := 0
 	for _, v := range pool.buckets {
@@ -418,6 +765,24 @@ func (pool *TransactionPool) dropTx() {
 	}
 }
 
+func (pool *TransactionPool) dropQueuedTx() {
+	var longestQueue *sorted.Slice
+	for _, v := range pool.queue {
+		if longestQueue == nil || v.Len() > longestQueue.Len() {
+			longestQueue = v
+		}
+	}
+
+	drop := longestQueue.Right().(*Transaction)
+	logging.VLog().WithFields(logrus.Fields{
+		"tx":          drop.StringWithoutData(),
+		"longestsize": longestQueue.Len(),
+	}).Debug("Drop tx from longest queue.")
+
+	pool.removeQueuedTx(drop)
+	pool.triggerDropEvent(drop)
+}
+
 // PopWithBlacklist return a tx with highest gasprice and not in the blocklist
 func (pool *TransactionPool) PopWithBlacklist(fromBlacklist *sync.Map, toBlacklist *sync.Map) *Transaction {
 	pool.mu.Lock()
@@ -444,6 +809,18 @@ func (pool *TransactionPool) PopWithBlacklist(fromBlacklist *sync.Map, toBlackli
 	return nil
 }
 
+// take remove the tx from pool to pack it, return false if it's not in pool.
+func (pool *TransactionPool) take(tx *Transaction) bool {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if _, ok := pool.all[tx.hash.Hex()]; !ok {
+		return false
+	}
+	pool.removeTx(tx)
+	return true
+}
+
 // Pop a transaction from pool
 func (pool *TransactionPool) Pop() *Transaction {
 	pool.mu.Lock()
@@ -512,6 +889,9 @@ func (pool *TransactionPool) Del(tx *Transaction) {
 		//remove key of bucketsLastUpdate when bucket is empty
 		delete(pool.bucketsLastUpdate, tx.from.address.Hex())
 	}
+
+	// the queued txs following tx can be pending now.
+	pool.promoteTxs(tx.from.address.Hex(), tx.Nonce()+1)
 }
 
 // Empty return if the pool is empty
@@ -559,4 +939,20 @@ func (pool *TransactionPool) evictExpiredTransactions() {
 			}
 		}
 	}
+
+	for slot, queue := range pool.queue {
+		if time.Since(pool.queueLastUpdate[slot]) <= txLifetime {
+			continue
+		}
+		for i := 0; i < queue.Len(); i++ {
+			tx := queue.Index(i).(*Transaction)
+			delete(pool.all, tx.hash.Hex())
+			logging.VLog().WithFields(logrus.Fields{
+				"tx": tx.StringWithoutData(),
+			}).Debug("Remove expired queued transactions.")
+			pool.triggerDropEvent(tx)
+		}
+		delete(pool.queue, slot)
+		delete(pool.queueLastUpdate, slot)
+	}
 }
@@ -194,30 +194,32 @@ func TestTransactionPool(t *testing.T) {
 	// put tx with different chainID, should fail
 	assert.Nil(t, txs[4].Sign(signature1))
 	assert.NotNil(t, txPool.Push(txs[4]))
-	// put one new, replace txs[1]
+	// put one new with bumped gas price, replace txs[2]
 	assert.Equal(t, len(txPool.all), 3)
 	assert.Nil(t, txs[6].Sign(signature1))
 	assert.Nil(t, txPool.Push(txs[6]))
-	assert.Equal(t, len(txPool.all), 4)
-	// get from: other, nonce: 1, data: "da"
+	assert.Equal(t, len(txPool.all), 3)
+	assert.Nil(t, txPool.all[txs[2].hash.Hex()])
+	// get from: from, nonce: 1, data: "7"
 	tx := txPool.Pop()
 	assert.Equal(t, txs[6].data.Payload, tx.data.Payload)
 	// put one new
-	assert.Equal(t, len(txPool.all), 3)
+	assert.Equal(t, len(txPool.all), 2)
 	assert.Nil(t, txs[5].Sign(signature2))
 	assert.Nil(t, txPool.Push(txs[5]))
-	assert.Equal(t, len(txPool.all), 4)
-	// get 2 txs, txs[5], txs[0]
+	assert.Equal(t, len(txPool.all), 3)
+	// get 2 txs, txs[5], txs[1] promoted by it
 	tx = txPool.Pop()
 	assert.Equal(t, txs[5].from.address, tx.from.address)
 	assert.Equal(t, txs[5].Nonce(), tx.Nonce())
 	assert.Equal(t, txs[5].data, tx.data)
 	assert.Equal(t, txPool.Empty(), false)
-	txPool.Pop()
-	txPool.Pop()
-	txPool.Pop()
-	assert.Equal(t, txPool.Empty(), true)
+	tx = txPool.Pop()
+	assert.Equal(t, txs[1].hash, tx.hash)
+	// txs[0] is still queued for the nonce gap
 	assert.Nil(t, txPool.Pop())
+	assert.Equal(t, txPool.Empty(), false)
+	assert.NotNil(t, txPool.all[txs[0].hash.Hex()])
 }
 
 func TestGasConfig(t *testing.T) {
@@ -408,3 +410,153 @@ func TestTransactionPoolBucketUpdateTimeAndEvict(t *testing.T) {
 	assert.Equal(t, ok, false)
 
 }
+
+func TestTransactionPoolReplacementAndAccountSlots(t *testing.T) {
+	ks := keystore.DefaultKS
+	priv1 := secp256k1.GeneratePrivateKey()
+	pubdata1, _ := priv1.PublicKey().Encoded()
+	from, _ := NewAddressFromPublicKey(pubdata1)
+	ks.SetKey(from.String(), priv1, []byte("passphrase"))
+	ks.Unlock(from.String(), []byte("passphrase"), time.Second*60*60*24*365)
+	key1, _ := ks.GetUnlocked(from.String())
+	signature1, _ := crypto.NewSignature(keystore.SECP256K1)
+	signature1.InitSign(key1.(keystore.PrivateKey))
+
+	neb := testNeb(t)
+	bc := neb.chain
+	txPool := bc.txPool
+
+	gasLimit, _ := util.NewUint128FromInt(200000)
+	smallBump, _ := util.NewUint128FromInt(105)
+	lowPrice, _ := TransactionGasPrice.Mul(smallBump)
+	lowPrice, _ = lowPrice.Div(util.NewUint128FromUint(100))
+	gasCount, _ := util.NewUint128FromInt(2)
+	highPrice, _ := TransactionGasPrice.Mul(gasCount)
+
+	tx1, _ := NewTransaction(bc.ChainID(), from, &Address{[]byte("to")}, util.NewUint128(), 1, TxPayloadBinaryType, []byte("1"), TransactionGasPrice, gasLimit)
+	tx2, _ := NewTransaction(bc.ChainID(), from, &Address{[]byte("to")}, util.NewUint128(), 1, TxPayloadBinaryType, []byte("2"), lowPrice, gasLimit)
+	tx3, _ := NewTransaction(bc.ChainID(), from, &Address{[]byte("to")}, util.NewUint128(), 1, TxPayloadBinaryType, []byte("3"), highPrice, gasLimit)
+	tx4, _ := NewTransaction(bc.ChainID(), from, &Address{[]byte("to")}, util.NewUint128(), 2, TxPayloadBinaryType, []byte("4"), TransactionGasPrice, gasLimit)
+	tx5, _ := NewTransaction(bc.ChainID(), from, &Address{[]byte("to")}, util.NewUint128(), 3, TxPayloadBinaryType, []byte("5"), TransactionGasPrice, gasLimit)
+	for _, tx := range []*Transaction{tx1, tx2, tx3, tx4, tx5} {
+		assert.Nil(t, tx.Sign(signature1))
+	}
+
+	// same nonce without enough price bump is rejected.
+	assert.Nil(t, txPool.Push(tx1))
+	assert.Equal(t, ErrUnderpricedReplacement, txPool.Push(tx2))
+	assert.NotNil(t, txPool.all[tx1.hash.Hex()])
+
+	// same nonce with enough price bump replaces the pending one.
+	assert.Nil(t, txPool.Push(tx3))
+	assert.Nil(t, txPool.all[tx1.hash.Hex()])
+	assert.NotNil(t, txPool.all[tx3.hash.Hex()])
+	assert.Equal(t, 1, txPool.buckets[from.address.Hex()].Len())
+
+	// account slots are full, higher nonce is rejected.
+	txPool.accountSlots = 2
+	assert.Nil(t, txPool.Push(tx4))
+	assert.Equal(t, ErrTooManyAccountTransactions, txPool.Push(tx5))
+	assert.Equal(t, 2, len(txPool.all))
+
+	tx := txPool.Pop()
+	assert.Equal(t, tx3.hash, tx.hash)
+	tx = txPool.Pop()
+	assert.Equal(t, tx4.hash, tx.hash)
+	assert.True(t, txPool.Empty())
+}
+
+func TestTransactionPoolQueue(t *testing.T) {
+	ks := keystore.DefaultKS
+	priv1 := secp256k1.GeneratePrivateKey()
+	pubdata1, _ := priv1.PublicKey().Encoded()
+	from, _ := NewAddressFromPublicKey(pubdata1)
+	ks.SetKey(from.String(), priv1, []byte("passphrase"))
+	ks.Unlock(from.String(), []byte("passphrase"), time.Second*60*60*24*365)
+	key1, _ := ks.GetUnlocked(from.String())
+	signature1, _ := crypto.NewSignature(keystore.SECP256K1)
+	signature1.InitSign(key1.(keystore.PrivateKey))
+
+	neb := testNeb(t)
+	bc := neb.chain
+	txPool := bc.txPool
+
+	gasLimit, _ := util.NewUint128FromInt(200000)
+	var txs []*Transaction
+	for i := 1; i <= 5; i++ {
+		tx, _ := NewTransaction(bc.ChainID(), from, &Address{[]byte("to")}, util.NewUint128(), uint64(i), TxPayloadBinaryType, []byte("nas"), TransactionGasPrice, gasLimit)
+		assert.Nil(t, tx.Sign(signature1))
+		txs = append(txs, tx)
+	}
+
+	// future nonces are queued, not candidates.
+	txPool.queueSlots = 2
+	assert.Nil(t, txPool.Push(txs[2]))
+	assert.Nil(t, txPool.Push(txs[3]))
+	assert.Equal(t, ErrTooManyQueuedTransactions, txPool.Push(txs[4]))
+	assert.Equal(t, 2, txPool.queue[from.address.Hex()].Len())
+	assert.Equal(t, 2, len(txPool.all))
+	assert.Nil(t, txPool.Pop())
+
+	// the lower nonce takes the place of the highest queued one.
+	assert.Nil(t, txPool.Push(txs[1]))
+	assert.Nil(t, txPool.all[txs[3].hash.Hex()])
+	assert.Nil(t, txPool.Push(txs[0]))
+	assert.Equal(t, 3, len(txPool.all))
+	_, ok := txPool.queue[from.address.Hex()]
+	assert.False(t, ok)
+
+	// the gap fills, the queued ones are promoted.
+	for i := 0; i < 3; i++ {
+		tx := txPool.Pop()
+		assert.Equal(t, txs[i].hash, tx.hash)
+	}
+	assert.True(t, txPool.Empty())
+
+	// the queued ones following a tx put on chain are promoted.
+	assert.Nil(t, txPool.Push(txs[4]))
+	assert.Nil(t, txPool.Pop())
+	txPool.Del(txs[3])
+	assert.Equal(t, txs[4].hash, txPool.Pop().hash)
+}
+
+func TestTransactionPoolContentAndGasFloor(t *testing.T) {
+	ks := keystore.DefaultKS
+	priv1 := secp256k1.GeneratePrivateKey()
+	pubdata1, _ := priv1.PublicKey().Encoded()
+	from, _ := NewAddressFromPublicKey(pubdata1)
+	ks.SetKey(from.String(), priv1, []byte("passphrase"))
+	ks.Unlock(from.String(), []byte("passphrase"), time.Second*60*60*24*365)
+	key1, _ := ks.GetUnlocked(from.String())
+	signature1, _ := crypto.NewSignature(keystore.SECP256K1)
+	signature1.InitSign(key1.(keystore.PrivateKey))
+
+	neb := testNeb(t)
+	bc := neb.chain
+	txPool := bc.txPool
+
+	gasLimit, _ := util.NewUint128FromInt(200000)
+	var txs []*Transaction
+	for i := 1; i <= 4; i++ {
+		tx, _ := NewTransaction(bc.ChainID(), from, &Address{[]byte("to")}, util.NewUint128(), uint64(i), TxPayloadBinaryType, []byte("nas"), TransactionGasPrice, gasLimit)
+		assert.Nil(t, tx.Sign(signature1))
+		txs = append(txs, tx)
+	}
+
+	assert.Nil(t, txPool.Push(txs[1]))
+	assert.Nil(t, txPool.Push(txs[0]))
+	assert.Nil(t, txPool.Push(txs[3]))
+
+	pending, queued := txPool.Content()
+	assert.Equal(t, 1, len(pending))
+	assert.Equal(t, []*Transaction{txs[0], txs[1]}, pending[from.address.Hex()])
+	assert.Equal(t, 1, len(queued))
+	assert.Equal(t, []*Transaction{txs[3]}, queued[from.address.Hex()])
+
+	// txs below the new floor are refused, the pending ones are kept.
+	floor, _ := TransactionGasPrice.Add(util.NewUint128FromUint(1))
+	assert.Equal(t, ErrInvalidGasPrice, txPool.SetGasFloor(util.NewUint128()))
+	assert.Nil(t, txPool.SetGasFloor(floor))
+	assert.Equal(t, ErrBelowGasPrice, txPool.Push(txs[2]))
+	assert.Equal(t, 3, len(txPool.all))
+}
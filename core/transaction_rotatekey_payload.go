@@ -0,0 +1,97 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"encoding/json"
+
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/util"
+)
+
+// RotateKeyPayload rotate the controlling key of the sender to the public
+// key, the proof is the signature of RotateKeyProofHash by the new key.
+type RotateKeyPayload struct {
+	PublicKey []byte
+	Proof     []byte
+}
+
+// LoadRotateKeyPayload from bytes
+func LoadRotateKeyPayload(bytes []byte) (*RotateKeyPayload, error) {
+	payload := &RotateKeyPayload{}
+	if err := json.Unmarshal(bytes, payload); err != nil {
+		return nil, ErrInvalidArgument
+	}
+	return NewRotateKeyPayload(payload.PublicKey, payload.Proof)
+}
+
+// NewRotateKeyPayload with the new public key & its proof
+func NewRotateKeyPayload(pub, proof []byte) (*RotateKeyPayload, error) {
+	if _, err := NewAddressFromPublicKey(pub); err != nil {
+		return nil, ErrInvalidKeyRotationPublicKey
+	}
+	if len(proof) != RotatedSignatureLength-1 {
+		return nil, ErrInvalidKeyRotationProof
+	}
+	return &RotateKeyPayload{
+		PublicKey: pub,
+		Proof:     proof,
+	}, nil
+}
+
+// ToBytes serialize payload
+func (payload *RotateKeyPayload) ToBytes() ([]byte, error) {
+	return json.Marshal(payload)
+}
+
+// BaseGasCount returns base gas count
+func (payload *RotateKeyPayload) BaseGasCount() *util.Uint128 {
+	return util.NewUint128()
+}
+
+// Execute the payload in tx, the new key is active for the sender since then.
+func (payload *RotateKeyPayload) Execute(limitedGas *util.Uint128, tx *Transaction, block *Block, ws WorldState) (*util.Uint128, string, error) {
+	if block == nil || tx == nil {
+		return util.NewUint128(), "", ErrNilArgument
+	}
+	if !tx.from.Equals(tx.to) {
+		return util.NewUint128(), "", ErrKeyRotationAddressNotEqual
+	}
+	if tx.from.Type() != AccountAddress {
+		return util.NewUint128(), "", ErrInvalidAddressType
+	}
+
+	key, err := NewAddressFromPublicKey(payload.PublicKey)
+	if err != nil {
+		return util.NewUint128(), "", ErrInvalidKeyRotationPublicKey
+	}
+	signer, err := RecoverSignerFromSignature(keystore.SECP256K1, RotateKeyProofHash(tx.from, payload.PublicKey), payload.Proof)
+	if err != nil || !signer.Equals(key) {
+		return util.NewUint128(), "", ErrInvalidKeyRotationProof
+	}
+
+	acc, err := ws.GetOrCreateUserAccount(tx.from.Bytes())
+	if err != nil {
+		return util.NewUint128(), "", err
+	}
+	if err := SetActiveKey(acc, key); err != nil {
+		return util.NewUint128(), "", err
+	}
+	return util.NewUint128(), key.String(), nil
+}
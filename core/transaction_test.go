@@ -789,3 +789,136 @@ func TestTransactionString(t *testing.T) {
 		t.Errorf("tx JsonString() is not working as xpected")
 	}
 }
+
+func TestCheckContractModules(t *testing.T) {
+	source := "module.exports = {};"
+	assert.Nil(t, CheckContractModules(SourceTypeJavaScript, nil))
+	assert.Nil(t, CheckContractModules(SourceTypeJavaScript, map[string]string{"math.js": source, "util/token_v2.js": source}))
+	assert.Nil(t, CheckContractModules(SourceTypeTypeScript, map[string]string{"util/math.js": source}))
+
+	for _, path := range []string{"contract.js", "blockchain.js", "1.0.0/blockchain.js", "../math.js", "util/../math.js", "./math.js", "/math.js", "math.ts", "util/.js"} {
+		assert.Equal(t, ErrInvalidContractModule, CheckContractModules(SourceTypeJavaScript, map[string]string{path: source}), path)
+	}
+	assert.Equal(t, ErrInvalidContractModule, CheckContractModules(SourceTypeJavaScript, map[string]string{"math.js": ""}))
+	assert.Equal(t, ErrInvalidContractModule, CheckContractModules(SourceTypeWasm, map[string]string{"math.js": source}))
+
+	payload := &DeployPayload{SourceType: SourceTypeJavaScript, Source: source, Modules: map[string]string{"util/math.js": source}}
+	data, err := payload.ToBytes()
+	assert.Nil(t, err)
+	loaded, err := LoadDeployPayload(data)
+	assert.Nil(t, err)
+	assert.Equal(t, payload.Modules, loaded.Modules)
+}
+
+func TestTransaction_Ed25519(t *testing.T) {
+	ed25519Height := Ed25519Height
+	defer func() { Ed25519Height = ed25519Height }()
+
+	neb := testNeb(t)
+	bc := neb.chain
+	block, err := bc.NewBlock(mockAddress())
+	assert.Nil(t, err)
+
+	priv, err := crypto.NewPrivateKey(keystore.ED25519, nil)
+	assert.Nil(t, err)
+	pub, err := priv.PublicKey().Encoded()
+	assert.Nil(t, err)
+	from, err := NewAddressFromPublicKey(pub)
+	assert.Nil(t, err)
+	signature, err := crypto.NewSignature(keystore.ED25519)
+	assert.Nil(t, err)
+	assert.Nil(t, signature.InitSign(priv))
+
+	gasLimit, _ := util.NewUint128FromInt(200000)
+	tx, err := NewTransaction(bc.ChainID(), from, mockAddress(), util.NewUint128(), 1, TxPayloadBinaryType, []byte("nas"), TransactionGasPrice, gasLimit)
+	assert.Nil(t, err)
+	assert.Nil(t, tx.Sign(signature))
+	assert.Equal(t, keystore.ED25519, tx.alg)
+	assert.Nil(t, tx.VerifyIntegrity(bc.ChainID()))
+
+	// the signer is recovered from the signature.
+	other := mockAddress()
+	tx.from = other
+	assert.Equal(t, ErrInvalidTransactionSigner, tx.VerifyIntegrity(bc.ChainID()))
+	tx.from = from
+	tx.sign[0]++
+	assert.NotNil(t, tx.VerifyIntegrity(bc.ChainID()))
+	tx.sign[0]--
+
+	Ed25519Height = block.Height() + 1
+	giveback, err := VerifyExecution(tx, block, block.WorldState())
+	assert.False(t, giveback)
+	assert.Equal(t, ErrInvalidSignatureAlg, err)
+}
+
+func TestTransaction_EncryptedPayload(t *testing.T) {
+	encryptedPayloadHeight := EncryptedPayloadHeight
+	defer func() { EncryptedPayloadHeight = encryptedPayloadHeight }()
+
+	neb := testNeb(t)
+	bc := neb.chain
+	block, err := bc.NewBlock(mockAddress())
+	assert.Nil(t, err)
+
+	from, to := mockAddress(), mockAddress()
+	gasLimit, _ := util.NewUint128FromInt(200000)
+	tx, err := NewTransaction(bc.ChainID(), from, to, util.NewUint128(), 1, TxPayloadBinaryType, nil, TransactionGasPrice, gasLimit)
+	assert.Nil(t, err)
+	assert.Nil(t, tx.Sign(mockSignature(t, from)))
+	plainHash := tx.Hash()
+	baseGas, err := tx.GasCountOfTxBase()
+	assert.Nil(t, err)
+	assert.Equal(t, MinGasCountPerTransaction, baseGas)
+
+	assert.Equal(t, ErrTxEncryptedPayloadOutOfMaxLength, tx.SetEncryptedPayload(make([]byte, MaxEncryptedPayloadLength+1)))
+	assert.Nil(t, tx.SetEncryptedPayload([]byte("ciphertext")))
+	assert.Equal(t, ErrInvalidTransactionHash, tx.VerifyIntegrity(bc.ChainID()))
+	assert.Nil(t, tx.Sign(mockSignature(t, from)))
+	assert.Nil(t, tx.VerifyIntegrity(bc.ChainID()))
+	assert.NotEqual(t, plainHash, tx.Hash())
+
+	// the encrypted payload pays gas as data.
+	baseGas, err = tx.GasCountOfTxBase()
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(20010), baseGas.Uint64())
+
+	msg, err := tx.ToProto()
+	assert.Nil(t, err)
+	ntx := new(Transaction)
+	assert.Nil(t, ntx.FromProto(msg))
+	assert.Equal(t, []byte("ciphertext"), ntx.EncryptedPayload())
+	assert.Nil(t, ntx.VerifyIntegrity(bc.ChainID()))
+
+	EncryptedPayloadHeight = block.Height() + 1
+	giveback, err := VerifyExecution(tx, block, block.WorldState())
+	assert.False(t, giveback)
+	assert.Equal(t, ErrTxEncryptedPayloadNotSupported, err)
+}
+
+func TestTransaction_SetSignature(t *testing.T) {
+	from := mockAddress()
+	key, _ := keystore.DefaultKS.GetUnlocked(from.String())
+	signature, _ := crypto.NewSignature(keystore.SECP256K1)
+	signature.InitSign(key.(keystore.PrivateKey))
+
+	gasLimit, _ := util.NewUint128FromInt(200000)
+	tx, err := NewTransaction(1, from, mockAddress(), util.NewUint128(), 1, TxPayloadBinaryType, []byte("offline"), TransactionGasPrice, gasLimit)
+	assert.Nil(t, err)
+
+	hash, err := tx.SigningHash()
+	assert.Nil(t, err)
+	sign, err := signature.Sign(hash)
+	assert.Nil(t, err)
+
+	assert.Nil(t, tx.SetSignature(keystore.SECP256K1, sign))
+	assert.Equal(t, hash, tx.Hash())
+	assert.Nil(t, tx.VerifyIntegrity(1))
+
+	other := mockAddress()
+	key, _ = keystore.DefaultKS.GetUnlocked(other.String())
+	signature.InitSign(key.(keystore.PrivateKey))
+	sign, err = signature.Sign(hash)
+	assert.Nil(t, err)
+	assert.Equal(t, ErrInvalidTransactionSigner, tx.SetSignature(keystore.SECP256K1, sign))
+	assert.NotNil(t, tx.SetSignature(keystore.Algorithm(0), sign))
+}
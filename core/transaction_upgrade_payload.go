@@ -0,0 +1,152 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"encoding/json"
+
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/core/state"
+	"github.com/nebulasio/go-nebulas/util"
+)
+
+// UpgradePayload replace the source of the contract at tx.to, sent by the
+// admin nominated when the contract was deployed. The storage and the balance
+// of the contract are kept, the ABI is replaced by the one upgraded with, if any.
+type UpgradePayload struct {
+	SourceType string
+	Source     string
+	SourceMap  string            `json:",omitempty"`
+	Modules    map[string]string `json:",omitempty"`
+	ABI        string            `json:",omitempty"`
+}
+
+// ContractUpgradeEvent the payload of TopicContractUpgrade.
+type ContractUpgradeEvent struct {
+	Contract    string `json:"contract"`
+	Admin       string `json:"admin"`
+	CodeVersion uint64 `json:"code_version"`
+	LibVersion  string `json:"lib_version"`
+	Transaction string `json:"transaction"`
+}
+
+// LoadUpgradePayload from bytes
+func LoadUpgradePayload(bytes []byte) (*UpgradePayload, error) {
+	payload := &UpgradePayload{}
+	if err := json.Unmarshal(bytes, payload); err != nil {
+		return nil, ErrInvalidArgument
+	}
+	upgrade, err := NewUpgradePayload(payload.Source, payload.SourceType)
+	if err != nil {
+		return nil, err
+	}
+	if err := CheckContractModules(payload.SourceType, payload.Modules); err != nil {
+		return nil, err
+	}
+	if len(payload.ABI) > 0 {
+		if _, err := ParseContractABI(payload.ABI); err != nil {
+			return nil, err
+		}
+	}
+	upgrade.SourceMap = payload.SourceMap
+	upgrade.Modules = payload.Modules
+	upgrade.ABI = payload.ABI
+	return upgrade, nil
+}
+
+// NewUpgradePayload with source
+func NewUpgradePayload(source, sourceType string) (*UpgradePayload, error) {
+	if _, err := NewDeployPayload(source, sourceType, ""); err != nil {
+		return nil, err
+	}
+	return &UpgradePayload{
+		SourceType: sourceType,
+		Source:     source,
+	}, nil
+}
+
+// ToBytes serialize payload
+func (payload *UpgradePayload) ToBytes() ([]byte, error) {
+	return json.Marshal(payload)
+}
+
+// BaseGasCount returns base gas count
+func (payload *UpgradePayload) BaseGasCount() *util.Uint128 {
+	base, _ := util.NewUint128FromInt(60)
+	return base
+}
+
+// Execute the payload in tx. The code version of the contract is bumped and
+// the new source is loaded from this tx since then, see LoadContract.
+func (payload *UpgradePayload) Execute(limitedGas *util.Uint128, tx *Transaction, block *Block, ws WorldState) (*util.Uint128, string, error) {
+	if block == nil || tx == nil {
+		return util.NewUint128(), "", ErrNilArgument
+	}
+	if payload.SourceType == SourceTypeWasm && block.height < WasmHeight {
+		return util.NewUint128(), "", ErrInvalidDeploySourceType
+	}
+
+	contract, err := CheckContract(tx.to, ws)
+	if err != nil {
+		return util.NewUint128(), "", err
+	}
+	meta := contract.ContractMeta()
+	if meta == nil || len(meta.Admin) == 0 {
+		return util.NewUint128(), "", ErrContractNotUpgradeable
+	}
+	if !tx.from.address.Equals(meta.Admin) {
+		return util.NewUint128(), "", ErrInvalidUpgradeAdmin
+	}
+	if block.height >= ContractValidationHeight {
+		if err := block.nvm.ValidateContract(block, payload.Source, payload.SourceType, payload.Modules); err != nil {
+			return util.NewUint128(), "", err
+		}
+	}
+
+	// the upgraded source runs with the latest libs.
+	version := meta.Version
+	if v := GetMaxV8JSLibVersionAtHeight(block.height); len(v) > 0 {
+		version = v
+	}
+	abi, err := normalizeContractABI(payload.ABI)
+	if err != nil {
+		return util.NewUint128(), "", err
+	}
+	upgraded := &corepb.ContractMeta{
+		Version:     version,
+		Admin:       meta.Admin,
+		CodeVersion: meta.CodeVersion + 1,
+		CodeTx:      tx.hash,
+		Abi:         abi,
+	}
+	contract.SetContractMeta(upgraded)
+
+	data, err := json.Marshal(&ContractUpgradeEvent{
+		Contract:    tx.to.String(),
+		Admin:       tx.from.String(),
+		CodeVersion: upgraded.CodeVersion,
+		LibVersion:  upgraded.Version,
+		Transaction: tx.hash.String(),
+	})
+	if err != nil {
+		return util.NewUint128(), "", err
+	}
+	ws.RecordEvent(tx.hash, &state.Event{Topic: TopicContractUpgrade, Data: string(data)})
+	return util.NewUint128(), "", nil
+}
@@ -0,0 +1,133 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/core/state"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadUpgradePayloads(t *testing.T) {
+	_, err := NewUpgradePayload("", SourceTypeJavaScript)
+	assert.Equal(t, ErrInvalidDeploySource, err)
+	_, err = NewUpgradePayload("0x", "py")
+	assert.Equal(t, ErrInvalidDeploySourceType, err)
+
+	payload, err := NewUpgradePayload("module.exports = {};", SourceTypeJavaScript)
+	assert.Nil(t, err)
+	data, err := payload.ToBytes()
+	assert.Nil(t, err)
+	loaded, err := LoadUpgradePayload(data)
+	assert.Nil(t, err)
+	assert.Equal(t, payload, loaded)
+
+	// the admin of a deploy is optional, and should be a valid address since
+	// ContractUpgradeHeight, it is ignored before.
+	invalid, err := LoadDeployPayload([]byte(`{"SourceType":"js","Source":"s","admin":"n1invalid"}`))
+	assert.Nil(t, err)
+	admin, err := invalid.AdminAt(ContractUpgradeHeight - 1)
+	assert.Nil(t, err)
+	assert.Nil(t, admin)
+	_, err = invalid.AdminAt(ContractUpgradeHeight)
+	assert.NotNil(t, err)
+	deploy, err := LoadDeployPayload([]byte(`{"SourceType":"js","Source":"s"}`))
+	assert.Nil(t, err)
+	assert.Equal(t, "", deploy.Admin)
+	data, err = deploy.ToBytes()
+	assert.Nil(t, err)
+	assert.Equal(t, `{"SourceType":"js","Source":"s","Args":""}`, string(data))
+}
+
+func TestUpgradePayload_Execute(t *testing.T) {
+	neb := testNeb(t)
+	block := neb.chain.tailBlock
+	assert.Nil(t, block.Begin())
+	ws := &eventsRecorder{WorldState: block.WorldState()}
+
+	putTx := func(tx *Transaction) {
+		hash, err := tx.calHash()
+		assert.Nil(t, err)
+		tx.hash = hash
+		pbTx, err := tx.ToProto()
+		assert.Nil(t, err)
+		data, err := proto.Marshal(pbTx)
+		assert.Nil(t, err)
+		assert.Nil(t, ws.PutTx(tx.hash, data))
+	}
+
+	admin, other := mockAddress(), mockAddress()
+	deploy := &DeployPayload{SourceType: SourceTypeJavaScript, Source: "v1", Admin: admin.String()}
+	data, err := deploy.ToBytes()
+	assert.Nil(t, err)
+	birthTx, err := NewTransaction(neb.chain.ChainID(), admin, admin, util.NewUint128(), 1, TxPayloadDeployType, data, TransactionGasPrice, TransactionMaxGas)
+	assert.Nil(t, err)
+	putTx(birthTx)
+	addr, err := birthTx.GenerateContractAddress()
+	assert.Nil(t, err)
+	contract, err := ws.CreateContractAccount(addr.Bytes(), birthTx.hash, &corepb.ContractMeta{Version: "1.0.0", Admin: admin.Bytes()})
+	assert.Nil(t, err)
+	assert.Nil(t, contract.Put([]byte("key"), []byte("value")))
+	event, err := json.Marshal(&TransactionEvent{Hash: birthTx.hash.String(), Status: TxExecutionSuccess})
+	assert.Nil(t, err)
+	block.WorldState().RecordEvent(birthTx.hash, &state.Event{Topic: TopicTransactionExecutionResult, Data: string(event)})
+	assert.Nil(t, block.WorldState().Commit())
+	assert.Nil(t, block.Begin())
+
+	upgrade := func(from *Address, source string) (*Transaction, error) {
+		payload, err := NewUpgradePayload(source, SourceTypeJavaScript)
+		assert.Nil(t, err)
+		data, err := payload.ToBytes()
+		assert.Nil(t, err)
+		tx, err := NewTransaction(neb.chain.ChainID(), from, addr, util.NewUint128(), 2, TxPayloadUpgradeType, data, TransactionGasPrice, TransactionMaxGas)
+		assert.Nil(t, err)
+		putTx(tx)
+		_, _, err = payload.Execute(util.NewUint128(), tx, block, ws)
+		return tx, err
+	}
+
+	_, err = upgrade(other, "v2")
+	assert.Equal(t, ErrInvalidUpgradeAdmin, err)
+	tx, err := upgrade(admin, "v2")
+	assert.Nil(t, err)
+
+	contract, loaded, err := LoadContract(addr, ws)
+	assert.Nil(t, err)
+	assert.Equal(t, "v2", loaded.Source)
+	assert.Equal(t, admin.String(), loaded.Admin)
+	assert.Equal(t, uint64(1), contract.ContractMeta().CodeVersion)
+	value, err := contract.Get([]byte("key"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("value"), value)
+
+	payload, err := ParseEventPayload(ws.events[len(ws.events)-1])
+	assert.Nil(t, err)
+	assert.Equal(t, &ContractUpgradeEvent{
+		Contract:    addr.String(),
+		Admin:       admin.String(),
+		CodeVersion: 1,
+		LibVersion:  contract.ContractMeta().Version,
+		Transaction: tx.hash.String(),
+	}, payload)
+}
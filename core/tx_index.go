@@ -0,0 +1,223 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// storage: key -> value
+// addrtx_height -> the height up to which the canonical txs are indexed
+// addrtxcnt_ + address -> count of txs sent from or to the address
+// addrtx_ + address + seq -> tx hash
+
+// Transaction index keys in storage
+const (
+	TxIndexHeight      = "addrtx_height"
+	TxIndexPrefix      = "addrtx_"
+	TxIndexCountPrefix = "addrtxcnt_"
+)
+
+func txIndexCountKey(addr []byte) []byte {
+	return append([]byte(TxIndexCountPrefix), addr...)
+}
+
+func txIndexKey(addr []byte, seq uint64) []byte {
+	key := append([]byte(TxIndexPrefix), addr...)
+	return append(key, byteutils.FromUint64(seq)...)
+}
+
+// txIndexAddresses return the addresses a tx is indexed by.
+func txIndexAddresses(tx *Transaction) [][]byte {
+	if tx.from.Equals(tx.to) {
+		return [][]byte{tx.from.Bytes()}
+	}
+	return [][]byte{tx.from.Bytes(), tx.to.Bytes()}
+}
+
+func (bc *BlockChain) txIndexCount(addr []byte) (uint64, error) {
+	value, err := bc.storage.Get(txIndexCountKey(addr))
+	if err == storage.ErrKeyNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return byteutils.Uint64(value), nil
+}
+
+// TransactionIndexHeight return the height up to which the canonical txs are indexed by address.
+func (bc *BlockChain) TransactionIndexHeight() (uint64, bool) {
+	value, err := bc.storage.Get([]byte(TxIndexHeight))
+	if err != nil {
+		return 0, false
+	}
+	return byteutils.Uint64(value), true
+}
+
+func (bc *BlockChain) storeTransactionIndexHeight(height uint64) error {
+	return bc.storage.Put([]byte(TxIndexHeight), byteutils.FromUint64(height))
+}
+
+// initTransactionIndex enable the index on a new chain, old chains need backfill.
+func (bc *BlockChain) initTransactionIndex() error {
+	if _, ok := bc.TransactionIndexHeight(); ok {
+		return nil
+	}
+	if !bc.tailBlock.Hash().Equals(bc.genesisBlock.Hash()) {
+		logging.CLog().WithFields(logrus.Fields{
+			"tail": bc.tailBlock,
+		}).Warn("Transaction index by address is not built, run backfill to enable it.")
+		return nil
+	}
+	return bc.indexBlockTransactions(bc.genesisBlock)
+}
+
+// indexBlockTransactions append the block's txs to the index, the block must follow the indexed height.
+func (bc *BlockChain) indexBlockTransactions(block *Block) error {
+	for _, tx := range block.transactions {
+		for _, addr := range txIndexAddresses(tx) {
+			count, err := bc.txIndexCount(addr)
+			if err != nil {
+				return err
+			}
+			if err := bc.storage.Put(txIndexKey(addr, count), tx.hash); err != nil {
+				return err
+			}
+			if err := bc.storage.Put(txIndexCountKey(addr), byteutils.FromUint64(count+1)); err != nil {
+				return err
+			}
+		}
+	}
+	return bc.storeTransactionIndexHeight(block.height)
+}
+
+// unindexBlockTransactions remove the block's txs from the index, the block must be the last indexed one.
+func (bc *BlockChain) unindexBlockTransactions(block *Block) error {
+	for i := len(block.transactions) - 1; i >= 0; i-- {
+		tx := block.transactions[i]
+		for _, addr := range txIndexAddresses(tx) {
+			count, err := bc.txIndexCount(addr)
+			if err != nil {
+				return err
+			}
+			if count == 0 {
+				continue
+			}
+			hash, err := bc.storage.Get(txIndexKey(addr, count-1))
+			if err != nil {
+				return err
+			}
+			if !tx.hash.Equals(hash) {
+				logging.VLog().WithFields(logrus.Fields{
+					"tx":      tx.hash,
+					"indexed": byteutils.Hash(hash),
+					"block":   block,
+				}).Error("Unexpected tx in the index, skip to remove it.")
+				continue
+			}
+			if err := bc.storage.Del(txIndexKey(addr, count-1)); err != nil {
+				return err
+			}
+			if err := bc.storage.Put(txIndexCountKey(addr), byteutils.FromUint64(count-1)); err != nil {
+				return err
+			}
+		}
+	}
+	return bc.storeTransactionIndexHeight(block.height - 1)
+}
+
+// updateTransactionIndex keep the index up to date when the tail is changed,
+// reverted and applied are in (ancestor, tail], from the higher to the lower.
+func (bc *BlockChain) updateTransactionIndex(oldTail *Block, reverted []*Block, applied []*Block) error {
+	height, ok := bc.TransactionIndexHeight()
+	if !ok || height != oldTail.height {
+		// the index is not built or is behind, leave it to backfill.
+		return nil
+	}
+
+	for _, block := range reverted {
+		if err := bc.unindexBlockTransactions(block); err != nil {
+			return err
+		}
+	}
+	for i := len(applied) - 1; i >= 0; i-- {
+		if err := bc.indexBlockTransactions(applied[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BackfillTransactionIndex index the canonical txs from the indexed height to the tail.
+func (bc *BlockChain) BackfillTransactionIndex() (uint64, error) {
+	start := bc.genesisBlock.height
+	if height, ok := bc.TransactionIndexHeight(); ok {
+		start = height + 1
+	}
+
+	count := uint64(0)
+	for height := start; height <= bc.tailBlock.height; height++ {
+		block := bc.GetBlockOnCanonicalChainByHeight(height)
+		if block == nil {
+			return count, ErrCannotFindBlockAtGivenHeight
+		}
+		if err := bc.indexBlockTransactions(block); err != nil {
+			return count, err
+		}
+		count++
+
+		if count%1000 == 0 {
+			logging.CLog().WithFields(logrus.Fields{
+				"height": height,
+				"tail":   bc.tailBlock.height,
+			}).Info("Backfilling transaction index.")
+		}
+	}
+	return count, nil
+}
+
+// GetTransactionsByAddress return hashes of canonical txs sent from or to the address,
+// the newest first, and the total count of the address's txs.
+func (bc *BlockChain) GetTransactionsByAddress(addr *Address, offset uint64, limit uint64) ([]byteutils.Hash, uint64, error) {
+	if addr == nil {
+		return nil, 0, ErrNilArgument
+	}
+	if _, ok := bc.TransactionIndexHeight(); !ok {
+		return nil, 0, ErrTransactionIndexNotBuilt
+	}
+
+	total, err := bc.txIndexCount(addr.Bytes())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	hashes := []byteutils.Hash{}
+	for i := offset; i < total && i-offset < limit; i++ {
+		hash, err := bc.storage.Get(txIndexKey(addr.Bytes(), total-1-i))
+		if err != nil {
+			return nil, 0, err
+		}
+		hashes = append(hashes, hash)
+	}
+	return hashes, total, nil
+}
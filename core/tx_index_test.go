@@ -0,0 +1,83 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"math"
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransactionIndex(t *testing.T) {
+	neb := testNeb(t)
+	bc := neb.chain
+
+	height, ok := bc.TransactionIndexHeight()
+	assert.True(t, ok)
+	assert.Equal(t, bc.genesisBlock.height, height)
+
+	from := mockAddress()
+	to := mockAddress()
+	tx1, _ := NewTransaction(bc.ChainID(), from, to, util.NewUint128(), 1, TxPayloadBinaryType, nil, TransactionGasPrice, TransactionMaxGas)
+	tx2, _ := NewTransaction(bc.ChainID(), from, from, util.NewUint128(), 2, TxPayloadBinaryType, nil, TransactionGasPrice, TransactionMaxGas)
+	tx3, _ := NewTransaction(bc.ChainID(), from, to, util.NewUint128(), 3, TxPayloadBinaryType, nil, TransactionGasPrice, TransactionMaxGas)
+
+	block2 := &Block{height: height + 1, transactions: Transactions{tx1, tx2}}
+	block3 := &Block{height: height + 2, transactions: Transactions{tx3}}
+	assert.Nil(t, bc.indexBlockTransactions(block2))
+	assert.Nil(t, bc.indexBlockTransactions(block3))
+
+	hashes, total, err := bc.GetTransactionsByAddress(from, 0, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3), total)
+	assert.Equal(t, 2, len(hashes))
+	assert.Equal(t, tx3.Hash(), hashes[0])
+	assert.Equal(t, tx2.Hash(), hashes[1])
+
+	hashes, total, err = bc.GetTransactionsByAddress(from, 2, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3), total)
+	assert.Equal(t, 1, len(hashes))
+	assert.Equal(t, tx1.Hash(), hashes[0])
+
+	hashes, total, err = bc.GetTransactionsByAddress(to, 0, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), total)
+	assert.Equal(t, 2, len(hashes))
+
+	// a limit past the end of uint64 returns the rest.
+	hashes, total, err = bc.GetTransactionsByAddress(from, 1, math.MaxUint64)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3), total)
+	assert.Equal(t, 2, len(hashes))
+	assert.Equal(t, tx2.Hash(), hashes[0])
+
+	// the reverted block is removed from the index.
+	assert.Nil(t, bc.unindexBlockTransactions(block3))
+	hashes, total, err = bc.GetTransactionsByAddress(to, 0, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), total)
+	assert.Equal(t, tx1.Hash(), hashes[0])
+
+	height, ok = bc.TransactionIndexHeight()
+	assert.True(t, ok)
+	assert.Equal(t, block2.height, height)
+}
@@ -43,17 +43,26 @@ const (
 	TxPayloadBinaryType = "binary"
 	TxPayloadDeployType = "deploy"
 	TxPayloadCallType   = "call"
+
+	TxPayloadEvidenceType  = "evidence"
+	TxPayloadCandidateType = "candidate"
+	TxPayloadDelegateType  = "delegate"
+	TxPayloadUpgradeType   = "upgrade"
+	TxPayloadMultisigType  = "multisig"
+	TxPayloadRotateKeyType = "rotatekey"
 )
 
 // Const.
 const (
 	SourceTypeJavaScript = "js"
 	SourceTypeTypeScript = "ts"
+	SourceTypeWasm       = "wasm"
 )
 
 // Const
 const (
 	ContractAcceptFunc = "accept"
+	ContractInitFunc   = "init"
 )
 
 var (
@@ -84,6 +93,7 @@ var (
 
 	ErrInvalidConfigChainID          = errors.New("invalid chainID, genesis chainID not equal to chainID in config")
 	ErrCannotLoadGenesisConf         = errors.New("cannot load genesis conf")
+	ErrInvalidGenesisConf            = errors.New("invalid genesis conf, meta.chain_id and consensus.dpos or consensus.poa are required")
 	ErrGenesisNotEqualChainIDInDB    = errors.New("Failed to check. genesis chainID not equal in db")
 	ErrGenesisNotEqualDynastyInDB    = errors.New("Failed to check. genesis dynasty not equal in db")
 	ErrGenesisNotEqualTokenInDB      = errors.New("Failed to check. genesis TokenDistribution not equal in db")
@@ -92,6 +102,7 @@ var (
 
 	ErrLinkToWrongParentBlock = errors.New("link the block to a block who is not its parent")
 	ErrMissingParentBlock     = errors.New("cannot find the block's parent block in storage")
+	ErrMissingFrozenBlocks    = errors.New("the freezer has fewer items than the blocks recorded as frozen")
 	ErrInvalidBlockHash       = errors.New("invalid block hash")
 	ErrDoubleSealBlock        = errors.New("cannot seal a block twice")
 	ErrDuplicatedBlock        = errors.New("duplicated block")
@@ -106,12 +117,51 @@ var (
 	ErrInvalidTxPayloadType     = errors.New("invalid transaction data payload type")
 	ErrInvalidGasPrice          = errors.New("invalid gas price, should be in (0, 10^12]")
 	ErrInvalidGasLimit          = errors.New("invalid gas limit, should be in (0, 5*10^10]")
+	ErrInvalidSignatureAlg      = errors.New("invalid signature algorithm")
 
 	ErrNoTimeToPackTransactions       = errors.New("no time left to pack transactions in a block")
 	ErrTxDataPayLoadOutOfMaxLength    = errors.New("data's payload is out of max data length")
 	ErrTxDataBinPayLoadOutOfMaxLength = errors.New("data's payload is out of max data length in a binary tx")
 	ErrNilArgument                    = errors.New("argument(s) is nil")
 	ErrInvalidArgument                = errors.New("invalid argument(s)")
+	ErrTransactionIndexNotBuilt       = errors.New("transaction index by address is not built, run backfill first")
+	ErrInvalidEventsBlockRange        = errors.New("invalid block range of events, should be within the canonical chain and MaxContractEventsBlockRange")
+	ErrInvalidStateMode               = errors.New("invalid state mode, should be archive or pruned")
+	ErrForkBelowPrunedState           = errors.New("cannot switch to a fork below the pruned height")
+	ErrInvalidSnapshot                = errors.New("invalid state snapshot file")
+	ErrSnapshotChecksumMismatch       = errors.New("state snapshot chunk checksum mismatch")
+	ErrSnapshotNodeHashMismatch       = errors.New("state snapshot trie node hash mismatch")
+	ErrIncompleteSnapshot             = errors.New("state snapshot misses trie nodes of the block")
+	ErrUntrustedSnapshot              = errors.New("state snapshot block is not the trusted one")
+	ErrSnapshotOnUsedChain            = errors.New("state snapshot can only be imported into a fresh chain")
+	ErrInvalidChainExport             = errors.New("invalid chain export file")
+	ErrUnsupportedEventTopic          = errors.New("unsupported event topic")
+	ErrInvalidBlockGasLimit           = errors.New("block gas limit drifts too far from the parent's")
+	ErrBlockGasLimitExceeded          = errors.New("sum of the tx gas limits exceeds the block gas limit")
+	ErrChainAlreadyHosted             = errors.New("chain is already hosted")
+	ErrMissingGenesisBlock            = errors.New("genesis block is missing in storage")
+	ErrReadOnlyChain                  = errors.New("blockchain is opened read-only")
+	ErrInvalidCheckpoint              = errors.New("invalid checkpoint")
+	ErrCheckpointBlockNotFound        = errors.New("cannot find the checkpoint block")
+	ErrInvalidCheckpointSigner        = errors.New("checkpoint signer is not a validator of the block")
+	ErrForkBelowCheckpoint            = errors.New("cannot switch to a fork below the latest checkpoint")
+	ErrDoubleCheckpointVote           = errors.New("checkpoint signer voted another block on the canonical chain")
+	ErrInvalidEvidence                = errors.New("invalid double sign evidence")
+	ErrInvalidEvidenceSigner          = errors.New("double sign evidence signer is not a validator")
+	ErrEvidenceAlreadyRecorded        = errors.New("double sign evidence is already recorded")
+	ErrTooManyPendingEvidences        = errors.New("too many pending double sign evidences")
+	ErrInvalidFinalityVote            = errors.New("invalid finality vote")
+	ErrFinalityBlockNotFound          = errors.New("cannot find the finality vote block")
+	ErrInvalidFinalitySigner          = errors.New("finality vote signer is not a validator of the block")
+	ErrInvalidSlashingSchedule        = errors.New("invalid slashing schedule, percents should be in [0, 100]")
+	ErrInvalidExecutionLimits         = errors.New("invalid execution limits, memory size should be at least MinLimitsOfTotalMemorySize")
+	ErrInvalidStakeValue              = errors.New("invalid stake value, should be greater than 0")
+	ErrDepositBelowMinimum            = errors.New("candidate deposit is below the minimum")
+	ErrInsufficientDeposit            = errors.New("insufficient candidate deposit")
+	ErrInsufficientDelegation         = errors.New("insufficient stake delegated to the candidate")
+	ErrCandidateDepositLocked         = errors.New("cannot withdraw the deposit of a validator or a jailed candidate")
+	ErrInvalidEventsFilter            = errors.New("invalid events filter, should have at most MaxEventsFilterEntries addresses and topics")
+	ErrUnverifiableDynasty            = errors.New("the dynasty change is decided by the parent state, cannot verify it by headers")
 
 	ErrInsufficientBalance                = errors.New("insufficient balance")
 	ErrBelowGasPrice                      = errors.New("below the gas price")
@@ -126,10 +176,17 @@ var (
 	ErrContractDeployFailed               = errors.New("contract deploy failed")
 	ErrContractCheckFailed                = errors.New("contract check failed")
 	ErrContractTransactionAddressNotEqual = errors.New("contract transaction from-address not equal to to-address")
-
-	ErrDuplicatedTransaction = errors.New("duplicated transaction")
-	ErrSmallTransactionNonce = errors.New("cannot accept a transaction with smaller nonce")
-	ErrLargeTransactionNonce = errors.New("cannot accept a transaction with too bigger nonce")
+	ErrContractNotUpgradeable             = errors.New("contract is not upgradeable, it is deployed without an admin")
+	ErrInvalidUpgradeAdmin                = errors.New("only the admin of the contract can upgrade it")
+	ErrInvalidContractModule              = errors.New("invalid contract module, the path should be a .js file relative to the contract and not a js lib")
+	ErrInvalidContractABI                 = errors.New("invalid contract abi")
+
+	ErrDuplicatedTransaction      = errors.New("duplicated transaction")
+	ErrSmallTransactionNonce      = errors.New("cannot accept a transaction with smaller nonce")
+	ErrLargeTransactionNonce      = errors.New("cannot accept a transaction with too bigger nonce")
+	ErrUnderpricedReplacement     = errors.New("replacement transaction underpriced")
+	ErrTooManyAccountTransactions = errors.New("too many pending transactions from the account")
+	ErrTooManyQueuedTransactions  = errors.New("too many queued transactions from the account")
 
 	ErrInvalidAddress         = errors.New("address: invalid address")
 	ErrInvalidAddressFormat   = errors.New("address: invalid address format")
@@ -141,6 +198,24 @@ var (
 	ErrInvalidDelegateToNonCandidate     = errors.New("cannot delegate to non-candidate")
 	ErrInvalidUnDelegateFromNonDelegatee = errors.New("cannot un-delegate from non-delegatee")
 
+	ErrInvalidMultisigOwners              = errors.New("invalid multisig owners, should be distinct account addresses")
+	ErrInvalidMultisigThreshold           = errors.New("invalid multisig threshold, should be in [1, count of owners]")
+	ErrInvalidMultisigSignature           = errors.New("invalid multisig signature")
+	ErrMultisigTransactionAddressNotEqual = errors.New("multisig transaction from-address not equal to to-address")
+	ErrMultisigAccountNotFound            = errors.New("not a multisig account")
+	ErrMultisigAccountExists              = errors.New("multisig account already exists")
+	ErrMultisigThresholdNotMet            = errors.New("not enough owners signed the multisig transaction")
+
+	ErrInvalidKeyRotationPublicKey = errors.New("invalid public key of key rotation")
+	ErrInvalidKeyRotationProof     = errors.New("invalid proof of key rotation, should be signed by the new key")
+	ErrKeyRotationAddressNotEqual  = errors.New("key rotation transaction from-address not equal to to-address")
+	ErrRetiredKey                  = errors.New("the key of the account is retired by key rotation")
+
+	ErrTxEncryptedPayloadOutOfMaxLength = errors.New("encrypted payload is out of max length")
+	ErrTxEncryptedPayloadNotSupported   = errors.New("encrypted payload is not supported before the fork")
+
+	ErrInvalidForkID = errors.New("invalid fork id, should be the hash and the next fork height joined by -")
+
 	ErrCloneWorldState           = errors.New("Failed to clone world state")
 	ErrCloneAccountState         = errors.New("Failed to clone account state")
 	ErrCloneTxsState             = errors.New("Failed to clone txs state")
@@ -194,13 +269,70 @@ type TxPayload interface {
 // MessageType
 const (
 	MessageTypeNewBlock                   = "newblock"
-	MessageTypeParentBlockDownloadRequest = "dlblock"
-	MessageTypeBlockDownloadResponse      = "dlreply"
+	MessageTypeParentBlockDownloadRequest = "dlblock" // served for the peers of older versions
+	MessageTypeBlockDownloadResponse      = "dlreply" // served for the peers of older versions
+	MessageTypeBlocksRequest              = "getblocks"
+	MessageTypeBlocksResponse             = "blocks"
 	MessageTypeNewTx                      = "newtx"
+	MessageTypeCheckpointVote             = "checkpointvote"
+	MessageTypeEvidence                   = "evidence"
+	MessageTypeFinalityPrevote            = "prevote"
+	MessageTypeFinalityPrecommit          = "precommit"
 )
 
+// Blocks request limits, a peer may send BlocksRequestRate requests per second
+// in bursts of at most BlocksRequestBurst requests.
+const (
+	MaxBlocksPerRequest = 64
+	BlocksRequestRate   = 4
+	BlocksRequestBurst  = 16
+)
+
+// ElectedConsensusState is implemented by the consensus states whose proposer of a slot
+// is elected by a VRF lottery since VRFProposerHeight, the block carries the proposer and its proof.
+type ElectedConsensusState interface {
+	SetProposer(proposer byteutils.Hash, proof []byte)
+}
+
+// ElectiveConsensusState is implemented by the consensus states electing the members of a new
+// dynasty from the candidate rankings of the parent world state since ProofOfDevotionHeight.
+type ElectiveConsensusState interface {
+	ElectDynasty(height uint64, parent state.WorldState) error
+}
+
+// LivenessConsensusState is implemented by the consensus states counting the slots missed
+// by the validators since ValidatorLivenessHeight, the absent ones are replaced in the dynasty.
+type LivenessConsensusState interface {
+	TrackLiveness(height uint64) error
+}
+
+// HeaderVerifier is implemented by the consensus engines verifying a header without the chain
+// state, given the dynasties of the header and its parent, for the nodes following header chains.
+type HeaderVerifier interface {
+	// VerifyDynasty check the dynasty of the block may follow the one of its parent,
+	// ErrUnverifiableDynasty if only the parent state decides the change.
+	VerifyDynasty(parent *Block, block *Block, parentDynasty []byteutils.Hash, dynasty []byteutils.Hash) error
+	// VerifyProposer check the block is signed by the proposer of its slot in its dynasty.
+	VerifyProposer(block *Block, dynasty []byteutils.Hash) error
+}
+
+// Engine interface of the block level protocol of consensus algorithm: which
+// blocks are valid, and how a new block is made on its parent.
+type Engine interface {
+	// VerifyHeader check the block is minted by a validator allowed to.
+	VerifyHeader(*Block) error
+	// Prepare set the consensus fields of a new block, on the consensus state of its turn.
+	Prepare(*Block, state.ConsensusState) error
+	// Finalize the new block once the txs are collected, the roots of the header are computed.
+	Finalize(*Block) error
+	// Seal sign the finalized block by the miner.
+	Seal(*Block) error
+}
+
 // Consensus interface of consensus algorithm.
 type Consensus interface {
+	Engine
+
 	Setup(Neblet) error
 	Start()
 	Stop()
@@ -213,7 +345,6 @@ type Consensus interface {
 	SuspendMining()
 	Pending() bool
 
-	VerifyBlock(*Block) error
 	ForkChoice() error
 	UpdateLIB()
 
@@ -242,13 +373,17 @@ type AccountManager interface {
 	Accounts() []*Address
 
 	Unlock(*Address, []byte, time.Duration) error
+	UnlockWithUses(*Address, []byte, time.Duration, uint32) error
 	Lock(*Address) error
+	LockAll()
+	UnlockedAccounts() []*keystore.UnlockInfo
 
 	SignHash(*Address, byteutils.Hash, keystore.Algorithm) ([]byte, error)
 	SignBlock(*Address, *Block) error
 	GenerateRandomSeed(*Address, []byte, []byte) ([]byte, []byte, error)
 	SignTransaction(*Address, *Transaction) error
 	SignTransactionWithPassphrase(*Address, *Transaction, []byte) error
+	DecryptPayload(*Address, *Transaction) ([]byte, error)
 
 	Update(*Address, []byte, []byte) error
 	Load([]byte, []byte) (*Address, error)
@@ -260,11 +395,15 @@ type AccountManager interface {
 type NVM interface {
 	CreateEngine(block *Block, tx *Transaction, contract state.Account, ws WorldState) (SmartContractEngine, error)
 	CheckV8Run() error
+	CheckTypeScript(block *Block, source string) error
+	ValidateContract(block *Block, source, sourceType string, modules map[string]string) error
 }
 
 // SmartContractEngine interface
 type SmartContractEngine interface {
 	SetExecutionLimits(uint64, uint64) error
+	SetSourceMap(sourceMap string)
+	SetModules(modules map[string]string)
 	DeployAndInit(source, sourceType, args string) (string, error)
 	Call(source, sourceType, function, args string) (string, error)
 	ExecutionInstructions() uint64
@@ -287,6 +426,13 @@ type Neblet interface {
 	StartPprof(string) error
 }
 
+// ContractState the part of the world state a deployed contract is loaded from
+type ContractState interface {
+	GetContractAccount(addr byteutils.Hash) (state.Account, error)
+	GetTx(txHash byteutils.Hash) ([]byte, error)
+	FetchEvents(byteutils.Hash) ([]*state.Event, error)
+}
+
 // WorldState needed by core
 type WorldState interface {
 	GetOrCreateUserAccount(addr byteutils.Hash) (state.Account, error)
@@ -35,6 +35,22 @@ func NewCipher(alg uint8) *Cipher {
 	return c
 }
 
+// NewCipherWithParams returns a new cipher deriving the keys by the kdf params
+func NewCipherWithParams(alg uint8, params KDFParams) (*Cipher, error) {
+	c := new(Cipher)
+	switch alg {
+	case 1 << 4: //keysotore.SCRYPT
+		s, err := NewScrypt(params)
+		if err != nil {
+			return nil, err
+		}
+		c.encrypt = s
+	default:
+		return nil, ErrCipherInvalid
+	}
+	return c, nil
+}
+
 // Encrypt scrypt encrypt
 func (c *Cipher) Encrypt(data []byte, passphrase []byte) ([]byte, error) {
 	return c.encrypt.Encrypt(data, passphrase)
@@ -45,6 +61,11 @@ func (c *Cipher) EncryptKey(address string, data []byte, passphrase []byte) ([]b
 	return c.encrypt.EncryptKey(address, data, passphrase)
 }
 
+// EncryptKeyV3 encrypt key with address to the keystore v3 of ethereum
+func (c *Cipher) EncryptKeyV3(address string, data []byte, passphrase []byte) ([]byte, error) {
+	return c.encrypt.EncryptKeyV3(address, data, passphrase)
+}
+
 // Decrypt decrypts data, returning the origin data
 func (c *Cipher) Decrypt(data []byte, passphrase []byte) ([]byte, error) {
 	return c.encrypt.Decrypt(data, passphrase)
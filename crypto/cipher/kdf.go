@@ -0,0 +1,135 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package cipher
+
+import (
+	"encoding/hex"
+	"errors"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// Argon2idKDF name, the key files encrypted by it are of version 5
+	Argon2idKDF = "argon2id"
+
+	// StandardArgon2idTime time parameter of Argon2id, the number of passes
+	StandardArgon2idTime = 3
+
+	// StandardArgon2idMemory memory parameter of Argon2id in KiB
+	StandardArgon2idMemory = 64 * 1024
+
+	// StandardArgon2idThreads threads parameter of Argon2id
+	StandardArgon2idThreads = 4
+)
+
+var (
+	// ErrKDFParamsInvalid invalid parameters of the kdf
+	ErrKDFParamsInvalid = errors.New("invalid kdf parameters")
+)
+
+// KDFParams selects the key derivation function encrypting the keys and its
+// parameters, the zero value of a parameter takes the standard one.
+type KDFParams struct {
+	KDF string
+
+	// N, R, P the parameters of scrypt
+	N int
+	R int
+	P int
+
+	// Time, Memory in KiB, Threads the parameters of argon2id
+	Time    uint32
+	Memory  uint32
+	Threads uint8
+}
+
+// StandardKDFParams the default scrypt parameters
+var StandardKDFParams = KDFParams{
+	KDF: ScryptKDF,
+	N:   StandardScryptN,
+	R:   StandardScryptR,
+	P:   StandardScryptP,
+}
+
+// normalize fills the standard parameters for the zero ones and checks them.
+func (p KDFParams) normalize() (KDFParams, error) {
+	switch p.KDF {
+	case "", ScryptKDF:
+		p.KDF = ScryptKDF
+		if p.N == 0 {
+			p.N = StandardScryptN
+		}
+		if p.R == 0 {
+			p.R = StandardScryptR
+		}
+		if p.P == 0 {
+			p.P = StandardScryptP
+		}
+		// N must be a power of two greater than 1, and r * p < 2^30.
+		if p.N <= 1 || p.N&(p.N-1) != 0 || p.R < 0 || p.P < 0 || uint64(p.R)*uint64(p.P) >= 1<<30 {
+			return p, ErrKDFParamsInvalid
+		}
+	case Argon2idKDF:
+		if p.Time == 0 {
+			p.Time = StandardArgon2idTime
+		}
+		if p.Memory == 0 {
+			p.Memory = StandardArgon2idMemory
+		}
+		if p.Threads == 0 {
+			p.Threads = StandardArgon2idThreads
+		}
+		// argon2 needs at least 8 KiB of memory per thread.
+		if p.Memory < 8*uint32(p.Threads) {
+			return p, ErrKDFParamsInvalid
+		}
+	default:
+		return p, ErrKDFInvalid
+	}
+	return p, nil
+}
+
+// deriveKey derives the key from the passphrase, returning the kdfparams
+// recorded in the json blob.
+func (p KDFParams) deriveKey(passphrase, salt []byte) ([]byte, map[string]interface{}, error) {
+	params := make(map[string]interface{}, 5)
+	params["dklen"] = ScryptDKLen
+	params["salt"] = hex.EncodeToString(salt)
+
+	switch p.KDF {
+	case ScryptKDF:
+		derivedKey, err := scrypt.Key(passphrase, salt, p.N, p.R, p.P, ScryptDKLen)
+		if err != nil {
+			return nil, nil, err
+		}
+		params["n"] = p.N
+		params["r"] = p.R
+		params["p"] = p.P
+		return derivedKey, params, nil
+	case Argon2idKDF:
+		params["t"] = p.Time
+		params["m"] = p.Memory
+		params["p"] = p.Threads
+		return argon2.IDKey(passphrase, salt, p.Time, p.Memory, p.Threads, ScryptDKLen), params, nil
+	default:
+		return nil, nil, ErrKDFInvalid
+	}
+}
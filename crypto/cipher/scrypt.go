@@ -22,6 +22,7 @@ import (
 	"bytes"
 	"crypto/aes"
 	"crypto/cipher"
+	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
@@ -29,6 +30,8 @@ import (
 	"github.com/nebulasio/go-nebulas/crypto/hash"
 	"github.com/nebulasio/go-nebulas/crypto/utils"
 	uuid "github.com/satori/go.uuid"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/pbkdf2"
 	"golang.org/x/crypto/scrypt"
 )
 
@@ -36,6 +39,12 @@ const (
 	// ScryptKDF name
 	ScryptKDF = "scrypt"
 
+	// PBKDF2KDF name, only for decrypting ethereum keystore files
+	PBKDF2KDF = "pbkdf2"
+
+	// pbkdf2PRF the only prf of pbkdf2 in ethereum keystore files
+	pbkdf2PRF = "hmac-sha256"
+
 	// StandardScryptN N parameter of Scrypt encryption algorithm
 	StandardScryptN = 1 << 12 //TODO: check 1<<12 vs 1<<18
 
@@ -55,6 +64,10 @@ const (
 	version3       = 3
 	currentVersion = 4
 
+	// argon2idVersion the version of the key files encrypted by argon2id,
+	// which the older nodes refuse
+	argon2idVersion = 5
+
 	// mac calculate hash type
 	macHash = "sha3256"
 )
@@ -69,6 +82,9 @@ var (
 	// ErrCipherInvalid cipher not supported
 	ErrCipherInvalid = errors.New("cipher not supported")
 
+	// ErrPRFInvalid prf of pbkdf2 not supported
+	ErrPRFInvalid = errors.New("prf not supported")
+
 	// ErrDecrypt decrypt failed
 	ErrDecrypt = errors.New("could not decrypt key with given passphrase")
 )
@@ -84,7 +100,7 @@ type cryptoJSON struct {
 	KDF          string                 `json:"kdf"`
 	KDFParams    map[string]interface{} `json:"kdfparams"`
 	MAC          string                 `json:"mac"`
-	MACHash      string                 `json:"machash"`
+	MACHash      string                 `json:"machash,omitempty"`
 }
 
 type encryptedKeyJSON struct {
@@ -94,13 +110,57 @@ type encryptedKeyJSON struct {
 	Version int        `json:"version"`
 }
 
-// Scrypt scrypt encrypt
+// Scrypt scrypt encrypt, the kdf can be switched to argon2id by the params
 type Scrypt struct {
+	params KDFParams
+}
+
+// NewScrypt returns a scrypt encrypt deriving the keys with the params
+func NewScrypt(params KDFParams) (*Scrypt, error) {
+	params, err := params.normalize()
+	if err != nil {
+		return nil, err
+	}
+	return &Scrypt{params: params}, nil
+}
+
+// kdfParams the params of the encrypt, the standard scrypt ones by default.
+func (s *Scrypt) kdfParams() KDFParams {
+	if len(s.params.KDF) == 0 {
+		return StandardKDFParams
+	}
+	return s.params
 }
 
 // EncryptKey encrypt key with address
 func (s *Scrypt) EncryptKey(address string, data []byte, passphrase []byte) ([]byte, error) {
-	crypto, err := s.scryptEncrypt(data, passphrase, StandardScryptN, StandardScryptR, StandardScryptP)
+	params := s.kdfParams()
+	version := currentVersion
+	if params.KDF == Argon2idKDF {
+		version = argon2idVersion
+	}
+	crypto, err := s.encrypt(data, passphrase, params, version)
+	if err != nil {
+		return nil, err
+	}
+	encryptedKeyJSON := encryptedKeyJSON{
+		string(address),
+		*crypto,
+		uuid.NewV4().String(),
+		version,
+	}
+	return json.Marshal(encryptedKeyJSON)
+}
+
+// EncryptKeyV3 encrypt key with address to the keystore v3 of ethereum, the
+// address should be the ethereum address of the key.
+func (s *Scrypt) EncryptKeyV3(address string, data []byte, passphrase []byte) ([]byte, error) {
+	// ethereum keystore files don't support argon2id.
+	params := s.kdfParams()
+	if params.KDF != ScryptKDF {
+		params = StandardKDFParams
+	}
+	crypto, err := s.encrypt(data, passphrase, params, version3)
 	if err != nil {
 		return nil, err
 	}
@@ -108,14 +168,18 @@ func (s *Scrypt) EncryptKey(address string, data []byte, passphrase []byte) ([]b
 		string(address),
 		*crypto,
 		uuid.NewV4().String(),
-		currentVersion,
+		version3,
 	}
 	return json.Marshal(encryptedKeyJSON)
 }
 
 // Encrypt scrypt encrypt
 func (s *Scrypt) Encrypt(data []byte, passphrase []byte) ([]byte, error) {
-	return s.ScryptEncrypt(data, passphrase, StandardScryptN, StandardScryptR, StandardScryptP)
+	crypto, err := s.encrypt(data, passphrase, s.kdfParams(), currentVersion)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(crypto)
 }
 
 // ScryptEncrypt encrypts a key using the specified scrypt parameters into a json
@@ -124,16 +188,16 @@ func (s *Scrypt) Encrypt(data []byte, passphrase []byte) ([]byte, error) {
 // r and p must satisfy r * p < 2³⁰. If the parameters do not satisfy the
 // limits, the function returns a nil byte slice and an error.
 func (s *Scrypt) ScryptEncrypt(data []byte, passphrase []byte, N, r, p int) ([]byte, error) {
-	crypto, err := s.scryptEncrypt(data, passphrase, N, r, p)
+	crypto, err := s.encrypt(data, passphrase, KDFParams{KDF: ScryptKDF, N: N, R: r, P: p}, currentVersion)
 	if err != nil {
 		return nil, err
 	}
 	return json.Marshal(crypto)
 }
 
-func (s *Scrypt) scryptEncrypt(data []byte, passphrase []byte, N, r, p int) (*cryptoJSON, error) {
+func (s *Scrypt) encrypt(data []byte, passphrase []byte, params KDFParams, version int) (*cryptoJSON, error) {
 	salt := utils.RandomCSPRNG(ScryptDKLen)
-	derivedKey, err := scrypt.Key(passphrase, salt, N, r, p, ScryptDKLen)
+	derivedKey, kdfParamsJSON, err := params.deriveKey(passphrase, salt)
 	if err != nil {
 		return nil, err
 	}
@@ -147,13 +211,12 @@ func (s *Scrypt) scryptEncrypt(data []byte, passphrase []byte, N, r, p int) (*cr
 
 	//mac := hash.Sha3256(derivedKey[16:32], cipherText) // version3: deprecated
 	mac := hash.Sha3256(derivedKey[16:32], cipherText, iv, []byte(cipherName))
-
-	scryptParamsJSON := make(map[string]interface{}, 5)
-	scryptParamsJSON["n"] = N
-	scryptParamsJSON["r"] = r
-	scryptParamsJSON["p"] = p
-	scryptParamsJSON["dklen"] = ScryptDKLen
-	scryptParamsJSON["salt"] = hex.EncodeToString(salt)
+	machash := macHash
+	if version == version3 {
+		// the mac of ethereum keystore files
+		mac = hash.Keccak256(derivedKey[16:32], cipherText)
+		machash = ""
+	}
 
 	cipherParamsJSON := cipherparamsJSON{
 		IV: hex.EncodeToString(iv),
@@ -163,10 +226,10 @@ func (s *Scrypt) scryptEncrypt(data []byte, passphrase []byte, N, r, p int) (*cr
 		Cipher:       cipherName,
 		CipherText:   hex.EncodeToString(cipherText),
 		CipherParams: cipherParamsJSON,
-		KDF:          ScryptKDF,
-		KDFParams:    scryptParamsJSON,
+		KDF:          params.KDF,
+		KDFParams:    kdfParamsJSON,
 		MAC:          hex.EncodeToString(mac),
-		MACHash:      macHash,
+		MACHash:      machash,
 	}
 	return crypto, nil
 }
@@ -198,7 +261,7 @@ func (s *Scrypt) DecryptKey(keyjson []byte, passphrase []byte) ([]byte, error) {
 		return nil, err
 	}
 	version := keyJSON.Version
-	if version != currentVersion && version != version3 {
+	if version != currentVersion && version != version3 && version != argon2idVersion {
 		return nil, ErrVersionInvalid
 	}
 	return s.scryptDecrypt(&keyJSON.Crypto, passphrase, version)
@@ -240,13 +303,27 @@ func (s *Scrypt) scryptDecrypt(crypto *cryptoJSON, passphrase []byte, version in
 		if err != nil {
 			return nil, err
 		}
+	} else if crypto.KDF == PBKDF2KDF && version == version3 {
+		if prf, _ := crypto.KDFParams["prf"].(string); prf != pbkdf2PRF {
+			return nil, ErrPRFInvalid
+		}
+		c := ensureInt(crypto.KDFParams["c"])
+		derivedKey = pbkdf2.Key(passphrase, salt, c, dklen, sha256.New)
+	} else if crypto.KDF == Argon2idKDF && version != version3 {
+		t := ensureInt(crypto.KDFParams["t"])
+		m := ensureInt(crypto.KDFParams["m"])
+		p := ensureInt(crypto.KDFParams["p"])
+		if t <= 0 || m <= 0 || p <= 0 || p > 0xff {
+			return nil, ErrKDFParamsInvalid
+		}
+		derivedKey = argon2.IDKey(passphrase, salt, uint32(t), uint32(m), uint8(p), uint32(dklen))
 	} else {
 		return nil, ErrKDFInvalid
 	}
 
 	var calculatedMAC []byte
 
-	if version == currentVersion {
+	if version == currentVersion || version == argon2idVersion {
 		calculatedMAC = hash.Sha3256(derivedKey[16:32], cipherText, iv, []byte(crypto.Cipher))
 	} else if version == version3 {
 		calculatedMAC = hash.Sha3256(derivedKey[16:32], cipherText)
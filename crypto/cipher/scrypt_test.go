@@ -19,6 +19,7 @@
 package cipher
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
 
@@ -138,3 +139,127 @@ func TestScrypt_DecryptKey(t *testing.T) {
 		})
 	}
 }
+
+func TestScrypt_EthereumKeyV3(t *testing.T) {
+	// test vectors of ethereum keystore v3
+	passphrase := []byte("testpassword")
+	want, _ := byteutils.FromHex("7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d")
+	tests := []struct {
+		name string
+		key  string
+	}{
+		{
+			"scrypt",
+			`{
+				"crypto" : {
+					"cipher" : "aes-128-ctr",
+					"cipherparams" : {"iv" : "83dbcc02d8ccb40e466191a123791e0e"},
+					"ciphertext" : "d172bf743a674da9cdad04534d56926ef8358534d458fffccd4e6ad2fbde479c",
+					"kdf" : "scrypt",
+					"kdfparams" : {"dklen" : 32, "n" : 262144, "r" : 1, "p" : 8, "salt" : "ab0c7876052600dd703518d6fc3fe8984592145b591fc8fb5c6d43190334ba19"},
+					"mac" : "2103ac29920d71da29f15d75b4a16dbe95cfd7ff8faea1056c33131d846e3097"
+				},
+				"id" : "3198bc9c-6672-5ab3-d995-4942343ae5b6",
+				"version" : 3
+			}`,
+		},
+		{
+			"pbkdf2",
+			`{
+				"crypto" : {
+					"cipher" : "aes-128-ctr",
+					"cipherparams" : {"iv" : "6087dab2f9fdbbfaddc31a909735c1e6"},
+					"ciphertext" : "5318b4d5bcd28de64ee5559e671353e16f075ecae9f99c7a79a38af5f869aa46",
+					"kdf" : "pbkdf2",
+					"kdfparams" : {"c" : 262144, "dklen" : 32, "prf" : "hmac-sha256", "salt" : "ae3cd4e7013836a3df6bd7241b12db061dbe2c6785853cce422d148a624ce0bd"},
+					"mac" : "517ead924a9d0dc3124507e3393d175ce3ff7c1e96529c6c555ce9e51205e9b2"
+				},
+				"id" : "3198bc9c-6672-5ab3-d995-4942343ae5b6",
+				"version" : 3
+			}`,
+		},
+	}
+	s := new(Scrypt)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.DecryptKey([]byte(tt.key), passphrase)
+			if err != nil {
+				t.Errorf("DecryptKey() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(want, got) {
+				t.Errorf("DecryptKey() = %v, want %v", got, want)
+			}
+		})
+	}
+
+	keyjson, err := s.EncryptKeyV3("008aeeda4d805471df9b2a5b0f38a0c3bcba786b", want, passphrase)
+	if err != nil {
+		t.Errorf("EncryptKeyV3() error = %v", err)
+		return
+	}
+	got, err := s.DecryptKey(keyjson, passphrase)
+	if err != nil {
+		t.Errorf("DecryptKey() error = %v", err)
+		return
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("DecryptKey() = %v, want %v", got, want)
+	}
+}
+
+func TestScrypt_KDFParams(t *testing.T) {
+	passphrase := []byte("passphrase")
+	want, _ := byteutils.FromHex("0eb3be2db3a534c192be5570c6c42f59")
+
+	if _, err := NewScrypt(KDFParams{KDF: ScryptKDF, N: 1000}); err != ErrKDFParamsInvalid {
+		t.Errorf("NewScrypt() error = %v, want %v", err, ErrKDFParamsInvalid)
+	}
+	if _, err := NewScrypt(KDFParams{KDF: "bcrypt"}); err != ErrKDFInvalid {
+		t.Errorf("NewScrypt() error = %v, want %v", err, ErrKDFInvalid)
+	}
+
+	tests := []struct {
+		name    string
+		params  KDFParams
+		version int
+	}{
+		{"scrypt", KDFParams{KDF: ScryptKDF, N: 1 << 10}, currentVersion},
+		{"argon2id", KDFParams{KDF: Argon2idKDF, Time: 1, Memory: 1024, Threads: 2}, argon2idVersion},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewScrypt(tt.params)
+			if err != nil {
+				t.Errorf("NewScrypt() error = %v", err)
+				return
+			}
+			keyjson, err := s.EncryptKey("n1FF1nz6tarkDVwWQkMnnwFPuPKUaQTdptE", want, passphrase)
+			if err != nil {
+				t.Errorf("EncryptKey() error = %v", err)
+				return
+			}
+			key := new(encryptedKeyJSON)
+			if err := json.Unmarshal(keyjson, key); err != nil {
+				t.Errorf("Unmarshal() error = %v", err)
+				return
+			}
+			if key.Version != tt.version || key.Crypto.KDF != tt.params.KDF {
+				t.Errorf("EncryptKey() version = %v kdf = %v, want %v %v", key.Version, key.Crypto.KDF, tt.version, tt.params.KDF)
+			}
+
+			// decrypted by the standard one with the params in the file.
+			got, err := new(Scrypt).DecryptKey(keyjson, passphrase)
+			if err != nil {
+				t.Errorf("DecryptKey() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(want, got) {
+				t.Errorf("DecryptKey() = %v, want %v", got, want)
+			}
+			if _, err := s.DecryptKey(keyjson, []byte("wrong")); err != ErrDecrypt {
+				t.Errorf("DecryptKey() error = %v, want %v", err, ErrDecrypt)
+			}
+		})
+	}
+}
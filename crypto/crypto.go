@@ -22,6 +22,9 @@ import (
 	"errors"
 
 	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/bls"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/ed25519"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/schnorr"
 	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
 )
 
@@ -48,6 +51,51 @@ func NewPrivateKey(alg keystore.Algorithm, data []byte) (keystore.PrivateKey, er
 			return nil, err
 		}
 		return priv, nil
+	case keystore.ED25519:
+		var (
+			priv *ed25519.PrivateKey
+			err  error
+		)
+		if len(data) == 0 {
+			priv = ed25519.GeneratePrivateKey()
+		} else {
+			priv = new(ed25519.PrivateKey)
+			err = priv.Decode(data)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return priv, nil
+	case keystore.BLS12381:
+		var (
+			priv *bls.PrivateKey
+			err  error
+		)
+		if len(data) == 0 {
+			priv = bls.GeneratePrivateKey()
+		} else {
+			priv = new(bls.PrivateKey)
+			err = priv.Decode(data)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return priv, nil
+	case keystore.SCHNORR:
+		var (
+			priv *schnorr.PrivateKey
+			err  error
+		)
+		if len(data) == 0 {
+			priv = schnorr.GeneratePrivateKey()
+		} else {
+			priv = new(schnorr.PrivateKey)
+			err = priv.Decode(data)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return priv, nil
 	default:
 		return nil, ErrAlgorithmInvalid
 	}
@@ -58,6 +106,12 @@ func NewSignature(alg keystore.Algorithm) (keystore.Signature, error) {
 	switch alg {
 	case keystore.SECP256K1:
 		return new(secp256k1.Signature), nil
+	case keystore.ED25519:
+		return new(ed25519.Signature), nil
+	case keystore.BLS12381:
+		return new(bls.Signature), nil
+	case keystore.SCHNORR:
+		return new(schnorr.Signature), nil
 	default:
 		return nil, ErrAlgorithmInvalid
 	}
@@ -66,7 +120,7 @@ func NewSignature(alg keystore.Algorithm) (keystore.Signature, error) {
 // CheckAlgorithm check if support the input Algorithm
 func CheckAlgorithm(alg keystore.Algorithm) error {
 	switch alg {
-	case keystore.SECP256K1:
+	case keystore.SECP256K1, keystore.ED25519:
 		return nil
 	default:
 		return ErrAlgorithmInvalid
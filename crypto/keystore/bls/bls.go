@@ -0,0 +1,273 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package bls
+
+import (
+	"errors"
+	"math/big"
+
+	bls12381 "github.com/kilic/bls12-381"
+)
+
+// Keys and signatures use the minimal-pubkey-size variant of BLS12-381,
+// public keys are points in G1 and signatures are points in G2, both in
+// the compressed form.
+const (
+	// PrivateKeyLength private key length
+	PrivateKeyLength = 32
+
+	// PublicKeyLength compressed G1 point length
+	PublicKeyLength = 48
+
+	// SignatureLength compressed G2 point length
+	SignatureLength = 96
+)
+
+var (
+	// curveOrder the order r of the groups, private keys are scalars in [1, r)
+	curveOrder, _ = new(big.Int).SetString("73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001", 16)
+
+	// signatureDomain the domain separation tag of the message signatures
+	signatureDomain = []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_")
+
+	// possessionDomain the domain separation tag of the proofs of possession
+	possessionDomain = []byte("BLS_POP_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_")
+)
+
+var (
+	// ErrInvalidPrivateKey invalid private key
+	ErrInvalidPrivateKey = errors.New("invalid private key")
+
+	// ErrInvalidPublicKey invalid public key
+	ErrInvalidPublicKey = errors.New("invalid public key")
+
+	// ErrInvalidSignature invalid signature
+	ErrInvalidSignature = errors.New("invalid signature")
+
+	// ErrEmptyAggregation nothing to aggregate
+	ErrEmptyAggregation = errors.New("nothing to aggregate")
+
+	// ErrMismatchedMessages the count of messages and public keys differs
+	ErrMismatchedMessages = errors.New("messages and public keys mismatched")
+
+	// ErrDuplicateMessage the same message is signed twice in an aggregation
+	ErrDuplicateMessage = errors.New("duplicate message in aggregation")
+
+	// ErrRecoverUnsupported public key can't be recovered from bls signatures
+	ErrRecoverUnsupported = errors.New("bls signatures can't recover the public key")
+)
+
+func secretKey(priv []byte) (*bls12381.Fr, error) {
+	if len(priv) != PrivateKeyLength {
+		return nil, ErrInvalidPrivateKey
+	}
+	if n := new(big.Int).SetBytes(priv); n.Sign() == 0 || n.Cmp(curveOrder) >= 0 {
+		return nil, ErrInvalidPrivateKey
+	}
+	return bls12381.NewFr().FromBytes(priv), nil
+}
+
+func publicPoint(pub []byte) (*bls12381.PointG1, error) {
+	if len(pub) != PublicKeyLength {
+		return nil, ErrInvalidPublicKey
+	}
+	g1 := bls12381.NewG1()
+	p, err := g1.FromCompressed(pub)
+	if err != nil || g1.IsZero(p) {
+		return nil, ErrInvalidPublicKey
+	}
+	return p, nil
+}
+
+func signaturePoint(signature []byte) (*bls12381.PointG2, error) {
+	if len(signature) != SignatureLength {
+		return nil, ErrInvalidSignature
+	}
+	g2 := bls12381.NewG2()
+	p, err := g2.FromCompressed(signature)
+	if err != nil || g2.IsZero(p) {
+		return nil, ErrInvalidSignature
+	}
+	return p, nil
+}
+
+func sign(data []byte, priv []byte, domain []byte) ([]byte, error) {
+	sk, err := secretKey(priv)
+	if err != nil {
+		return nil, err
+	}
+	g2 := bls12381.NewG2()
+	h, err := g2.HashToCurve(data, domain)
+	if err != nil {
+		return nil, err
+	}
+	return g2.ToCompressed(g2.MulScalar(g2.New(), h, sk)), nil
+}
+
+// verify checks e(pub, H(data)) == e(g1, signature) as a single pairing product.
+func verify(data []byte, signature []byte, pub *bls12381.PointG1, domain []byte) (bool, error) {
+	sig, err := signaturePoint(signature)
+	if err != nil {
+		return false, err
+	}
+	h, err := bls12381.NewG2().HashToCurve(data, domain)
+	if err != nil {
+		return false, err
+	}
+	engine := bls12381.NewEngine()
+	engine.AddPair(pub, h)
+	engine.AddPairInv(engine.G1.One(), sig)
+	return engine.Check(), nil
+}
+
+// PublicKeyFromPrivate returns the compressed public key of the private key
+func PublicKeyFromPrivate(priv []byte) ([]byte, error) {
+	sk, err := secretKey(priv)
+	if err != nil {
+		return nil, err
+	}
+	g1 := bls12381.NewG1()
+	return g1.ToCompressed(g1.MulScalar(g1.New(), g1.One(), sk)), nil
+}
+
+// Sign sign the data with the private key
+func Sign(data []byte, priv []byte) ([]byte, error) {
+	return sign(data, priv, signatureDomain)
+}
+
+// Verify verify the signature of the data with the public key
+func Verify(data []byte, signature []byte, pub []byte) (bool, error) {
+	p, err := publicPoint(pub)
+	if err != nil {
+		return false, err
+	}
+	return verify(data, signature, p, signatureDomain)
+}
+
+// ProvePossession signs the public key of the private key. Aggregating the
+// public keys of a message is only safe against rogue key attacks when every
+// key has been registered with a valid proof of possession.
+func ProvePossession(priv []byte) ([]byte, error) {
+	pub, err := PublicKeyFromPrivate(priv)
+	if err != nil {
+		return nil, err
+	}
+	return sign(pub, priv, possessionDomain)
+}
+
+// VerifyPossession verify the proof of possession of the public key
+func VerifyPossession(pub []byte, proof []byte) (bool, error) {
+	p, err := publicPoint(pub)
+	if err != nil {
+		return false, err
+	}
+	return verify(pub, proof, p, possessionDomain)
+}
+
+// AggregateSignatures adds up the signatures into one signature
+func AggregateSignatures(signatures [][]byte) ([]byte, error) {
+	if len(signatures) == 0 {
+		return nil, ErrEmptyAggregation
+	}
+	g2 := bls12381.NewG2()
+	agg := g2.Zero()
+	for _, signature := range signatures {
+		sig, err := signaturePoint(signature)
+		if err != nil {
+			return nil, err
+		}
+		g2.Add(agg, agg, sig)
+	}
+	return g2.ToCompressed(agg), nil
+}
+
+// AggregatePublicKeys adds up the public keys into one public key
+func AggregatePublicKeys(pubs [][]byte) ([]byte, error) {
+	agg, err := aggregatePublicKeys(pubs)
+	if err != nil {
+		return nil, err
+	}
+	return bls12381.NewG1().ToCompressed(agg), nil
+}
+
+func aggregatePublicKeys(pubs [][]byte) (*bls12381.PointG1, error) {
+	if len(pubs) == 0 {
+		return nil, ErrEmptyAggregation
+	}
+	g1 := bls12381.NewG1()
+	agg := g1.Zero()
+	for _, pub := range pubs {
+		p, err := publicPoint(pub)
+		if err != nil {
+			return nil, err
+		}
+		g1.Add(agg, agg, p)
+	}
+	if g1.IsZero(agg) {
+		return nil, ErrInvalidPublicKey
+	}
+	return agg, nil
+}
+
+// VerifyAggregate verify the aggregated signature of the same data signed by
+// all the public keys with one pairing check, e.g. the votes of a block.
+// The public keys must have been checked by VerifyPossession.
+func VerifyAggregate(data []byte, signature []byte, pubs [][]byte) (bool, error) {
+	agg, err := aggregatePublicKeys(pubs)
+	if err != nil {
+		return false, err
+	}
+	return verify(data, signature, agg, signatureDomain)
+}
+
+// VerifyAggregateMulti verify the aggregated signature of distinct data each
+// signed by the public key at the same index, it costs a pairing per message.
+func VerifyAggregateMulti(data [][]byte, signature []byte, pubs [][]byte) (bool, error) {
+	if len(pubs) == 0 {
+		return false, ErrEmptyAggregation
+	}
+	if len(data) != len(pubs) {
+		return false, ErrMismatchedMessages
+	}
+	sig, err := signaturePoint(signature)
+	if err != nil {
+		return false, err
+	}
+
+	seen := make(map[string]bool, len(data))
+	engine := bls12381.NewEngine()
+	for i, pub := range pubs {
+		if seen[string(data[i])] {
+			return false, ErrDuplicateMessage
+		}
+		seen[string(data[i])] = true
+
+		p, err := publicPoint(pub)
+		if err != nil {
+			return false, err
+		}
+		h, err := engine.G2.HashToCurve(data[i], signatureDomain)
+		if err != nil {
+			return false, err
+		}
+		engine.AddPair(p, h)
+	}
+	engine.AddPairInv(engine.G1.One(), sig)
+	return engine.Check(), nil
+}
@@ -0,0 +1,137 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package bls
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignature(t *testing.T) {
+	priv := GeneratePrivateKey()
+	data, err := priv.Encoded()
+	assert.Nil(t, err)
+	assert.Equal(t, PrivateKeyLength, len(data))
+	decoded := new(PrivateKey)
+	assert.Nil(t, decoded.Decode(data))
+	assert.Equal(t, ErrInvalidPrivateKey, decoded.Decode(make([]byte, PrivateKeyLength)))
+
+	msg := hash.Sha3256([]byte("nebulas"))
+	signature := new(Signature)
+	assert.Nil(t, signature.InitSign(priv))
+	sig, err := signature.Sign(msg)
+	assert.Nil(t, err)
+	assert.Equal(t, SignatureLength, len(sig))
+
+	pub := priv.PublicKey()
+	assert.Nil(t, signature.InitVerify(pub))
+	ok, err := signature.Verify(msg, sig)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	ok, err = signature.Verify(hash.Sha3256([]byte("other")), sig)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	_, err = signature.RecoverPublic(msg, sig)
+	assert.Equal(t, ErrRecoverUnsupported, err)
+}
+
+func TestPossession(t *testing.T) {
+	priv := GeneratePrivateKey()
+	proof, err := priv.ProvePossession()
+	assert.Nil(t, err)
+
+	pub := priv.PublicKey().(*PublicKey)
+	ok, err := pub.VerifyPossession(proof)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	// a proof is not a signature of the public key.
+	encoded, _ := pub.Encoded()
+	sig, err := priv.Sign(encoded)
+	assert.Nil(t, err)
+	ok, err = pub.VerifyPossession(sig)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+}
+
+func TestAggregate(t *testing.T) {
+	msg := hash.Sha3256([]byte("block"))
+	var (
+		pubs [][]byte
+		sigs [][]byte
+		msgs [][]byte
+		muls [][]byte
+	)
+	for i := 0; i < 8; i++ {
+		priv := GeneratePrivateKey()
+		pub, _ := priv.PublicKey().Encoded()
+		pubs = append(pubs, pub)
+
+		sig, err := priv.Sign(msg)
+		assert.Nil(t, err)
+		sigs = append(sigs, sig)
+
+		m := hash.Sha3256([]byte{byte(i)})
+		msgs = append(msgs, m)
+		sig, err = priv.Sign(m)
+		assert.Nil(t, err)
+		muls = append(muls, sig)
+	}
+
+	agg, err := AggregateSignatures(sigs)
+	assert.Nil(t, err)
+	ok, err := VerifyAggregate(msg, agg, pubs)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	aggPub, err := AggregatePublicKeys(pubs)
+	assert.Nil(t, err)
+	ok, err = Verify(msg, agg, aggPub)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	// missing a voter
+	ok, err = VerifyAggregate(msg, agg, pubs[1:])
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	agg, err = AggregateSignatures(muls)
+	assert.Nil(t, err)
+	ok, err = VerifyAggregateMulti(msgs, agg, pubs)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	msgs[0], msgs[1] = msgs[1], msgs[0]
+	ok, err = VerifyAggregateMulti(msgs, agg, pubs)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	msgs[0] = msgs[1]
+	_, err = VerifyAggregateMulti(msgs, agg, pubs)
+	assert.Equal(t, ErrDuplicateMessage, err)
+	_, err = VerifyAggregateMulti(msgs[1:], agg, pubs)
+	assert.Equal(t, ErrMismatchedMessages, err)
+
+	_, err = AggregateSignatures(nil)
+	assert.Equal(t, ErrEmptyAggregation, err)
+}
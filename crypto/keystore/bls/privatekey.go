@@ -0,0 +1,109 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package bls
+
+import (
+	"crypto/rand"
+
+	bls12381 "github.com/kilic/bls12-381"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/crypto/utils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// PrivateKey bls12-381 privatekey
+type PrivateKey struct {
+	seckey *utils.SecureBuffer
+}
+
+// GeneratePrivateKey generate a new bls12-381 private key
+func GeneratePrivateKey() *PrivateKey {
+	for {
+		sk, err := bls12381.NewFr().Rand(rand.Reader)
+		if err != nil {
+			logging.VLog().WithFields(logrus.Fields{
+				"err": err,
+			}).Fatal("Failed to generate bls12-381 key.")
+		}
+		if !sk.IsZero() {
+			seckey := sk.ToBytes()
+			defer utils.ZeroBytes(seckey)
+			return &PrivateKey{seckey: utils.NewSecureBufferFrom(seckey)}
+		}
+	}
+}
+
+// Algorithm algorithm of key
+func (k *PrivateKey) Algorithm() keystore.Algorithm {
+	return keystore.BLS12381
+}
+
+// Encoded encoded to byte, a copy the caller should zero after use
+func (k *PrivateKey) Encoded() ([]byte, error) {
+	return k.seckey.Copy(), nil
+}
+
+// Decode decode data to key
+func (k *PrivateKey) Decode(data []byte) error {
+	if _, err := secretKey(data); err != nil {
+		return err
+	}
+	k.seckey.Destroy()
+	k.seckey = utils.NewSecureBufferFrom(data)
+	return nil
+}
+
+// Clear clear key content
+func (k *PrivateKey) Clear() {
+	k.seckey.Destroy()
+}
+
+// PublicKey returns publickey
+func (k *PrivateKey) PublicKey() keystore.PublicKey {
+	var pub []byte
+	err := k.seckey.Use(func(seckey []byte) (err error) {
+		pub, err = PublicKeyFromPrivate(seckey)
+		return err
+	})
+	if err != nil {
+		return nil
+	}
+	return NewPublicKey(pub)
+}
+
+// Sign sign data with privatekey
+func (k *PrivateKey) Sign(data []byte) ([]byte, error) {
+	var sig []byte
+	err := k.seckey.Use(func(seckey []byte) (err error) {
+		sig, err = Sign(data, seckey)
+		return err
+	})
+	return sig, err
+}
+
+// ProvePossession returns the proof of possession of the key
+func (k *PrivateKey) ProvePossession() ([]byte, error) {
+	var proof []byte
+	err := k.seckey.Use(func(seckey []byte) (err error) {
+		proof, err = ProvePossession(seckey)
+		return err
+	})
+	return proof, err
+}
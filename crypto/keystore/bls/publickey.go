@@ -0,0 +1,68 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package bls
+
+import (
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/crypto/utils"
+)
+
+// PublicKey bls12-381 public key
+type PublicKey struct {
+	pub []byte
+}
+
+// NewPublicKey new a public key with the compressed G1 point
+func NewPublicKey(pub []byte) *PublicKey {
+	return &PublicKey{pub}
+}
+
+// Algorithm algorithm of key
+func (k *PublicKey) Algorithm() keystore.Algorithm {
+	return keystore.BLS12381
+}
+
+// Encoded encoded to byte
+func (k *PublicKey) Encoded() ([]byte, error) {
+	return k.pub, nil
+}
+
+// Decode decode data to key
+func (k *PublicKey) Decode(data []byte) error {
+	if _, err := publicPoint(data); err != nil {
+		return err
+	}
+	k.pub = data
+	return nil
+}
+
+// Clear clear key content
+func (k *PublicKey) Clear() {
+	utils.ZeroBytes(k.pub)
+}
+
+// Verify verify data with the signature
+func (k *PublicKey) Verify(data []byte, signature []byte) (bool, error) {
+	return Verify(data, signature, k.pub)
+}
+
+// VerifyPossession verify the proof of possession of the key
+func (k *PublicKey) VerifyPossession(proof []byte) (bool, error) {
+	return VerifyPossession(k.pub, proof)
+}
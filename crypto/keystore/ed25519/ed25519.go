@@ -0,0 +1,87 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package ed25519
+
+import (
+	"bytes"
+	"errors"
+
+	"golang.org/x/crypto/ed25519"
+)
+
+// The signature of ed25519 is followed by the public key, the signer is
+// recovered from it like secp256k1 signatures.
+const (
+	// PrivateKeyLength the private key is the seed followed by the public key
+	PrivateKeyLength = ed25519.PrivateKeySize
+
+	// PublicKeyLength public key length
+	PublicKeyLength = ed25519.PublicKeySize
+
+	// SignatureLength the signature and the public key
+	SignatureLength = ed25519.SignatureSize + ed25519.PublicKeySize
+)
+
+var (
+	// ErrInvalidPrivateKey invalid private key
+	ErrInvalidPrivateKey = errors.New("invalid private key")
+
+	// ErrInvalidPublicKey invalid public key
+	ErrInvalidPublicKey = errors.New("invalid public key")
+
+	// ErrInvalidSignature invalid signature
+	ErrInvalidSignature = errors.New("invalid signature")
+)
+
+// Sign sign the data with the private key, returns the signature and the public key
+func Sign(data []byte, priv []byte) ([]byte, error) {
+	if len(priv) != PrivateKeyLength {
+		return nil, ErrInvalidPrivateKey
+	}
+	sig := ed25519.Sign(ed25519.PrivateKey(priv), data)
+	return append(sig, priv[32:]...), nil
+}
+
+// Verify verify the signature of the data with the public key
+func Verify(data []byte, signature []byte, pub []byte) (bool, error) {
+	if len(pub) != PublicKeyLength {
+		return false, ErrInvalidPublicKey
+	}
+	if len(signature) != SignatureLength {
+		return false, ErrInvalidSignature
+	}
+	if !bytes.Equal(pub, signature[ed25519.SignatureSize:]) {
+		return false, nil
+	}
+	return ed25519.Verify(ed25519.PublicKey(pub), data, signature[:ed25519.SignatureSize]), nil
+}
+
+// RecoverPublicKey return the public key following the signature once the
+// signature is verified.
+func RecoverPublicKey(data []byte, signature []byte) ([]byte, error) {
+	if len(signature) != SignatureLength {
+		return nil, ErrInvalidSignature
+	}
+	pub := make([]byte, PublicKeyLength)
+	copy(pub, signature[ed25519.SignatureSize:])
+	if !ed25519.Verify(ed25519.PublicKey(pub), data, signature[:ed25519.SignatureSize]) {
+		return nil, ErrInvalidSignature
+	}
+	return pub, nil
+}
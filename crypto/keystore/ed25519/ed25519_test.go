@@ -0,0 +1,71 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package ed25519
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignature(t *testing.T) {
+	priv := GeneratePrivateKey()
+	data, err := priv.Encoded()
+	assert.Nil(t, err)
+	decoded := new(PrivateKey)
+	assert.Nil(t, decoded.Decode(data))
+	assert.Equal(t, ErrInvalidPrivateKey, decoded.Decode(data[:32]))
+	tampered := append([]byte{}, data...)
+	tampered[PrivateKeyLength-1]++
+	assert.Equal(t, ErrInvalidPrivateKey, decoded.Decode(tampered))
+
+	msg := hash.Sha3256([]byte("nebulas"))
+	signature := new(Signature)
+	assert.Nil(t, signature.InitSign(priv))
+	sign, err := signature.Sign(msg)
+	assert.Nil(t, err)
+	assert.Equal(t, SignatureLength, len(sign))
+
+	pub, err := signature.RecoverPublic(msg, sign)
+	assert.Nil(t, err)
+	assert.Equal(t, priv.PublicKey(), pub)
+
+	verifier := new(Signature)
+	assert.Nil(t, verifier.InitVerify(priv.PublicKey()))
+	ok, err := verifier.Verify(msg, sign)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	ok, err = verifier.Verify(hash.Sha3256([]byte("nas")), sign)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+	_, err = signature.RecoverPublic(hash.Sha3256([]byte("nas")), sign)
+	assert.Equal(t, ErrInvalidSignature, err)
+
+	// the public key following the signature should be the signer.
+	other := GeneratePrivateKey()
+	otherPub, _ := other.PublicKey().Encoded()
+	copy(sign[64:], otherPub)
+	_, err = signature.RecoverPublic(msg, sign)
+	assert.Equal(t, ErrInvalidSignature, err)
+	ok, err = verifier.Verify(msg, sign)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+}
@@ -0,0 +1,101 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package ed25519
+
+import (
+	"crypto/rand"
+
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/crypto/utils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/ed25519"
+)
+
+// PrivateKey ed25519 privatekey
+type PrivateKey struct {
+	seckey *utils.SecureBuffer
+}
+
+// GeneratePrivateKey generate a new private key
+func GeneratePrivateKey() *PrivateKey {
+	_, seckey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"err": err,
+		}).Fatal("Failed to generate ed25519 key.")
+	}
+	defer utils.ZeroBytes(seckey)
+	return &PrivateKey{seckey: utils.NewSecureBufferFrom(seckey)}
+}
+
+// Algorithm algorithm name
+func (k *PrivateKey) Algorithm() keystore.Algorithm {
+	return keystore.ED25519
+}
+
+// Encoded encoded to byte, a copy the caller should zero after use
+func (k *PrivateKey) Encoded() ([]byte, error) {
+	return k.seckey.Copy(), nil
+}
+
+// Decode decode data to key, the seed followed by the public key
+func (k *PrivateKey) Decode(data []byte) error {
+	if len(data) != PrivateKeyLength {
+		return ErrInvalidPrivateKey
+	}
+	// the public key should be derived from the seed.
+	derived := ed25519.NewKeyFromSeed(data[:ed25519.SeedSize])
+	defer utils.ZeroBytes(derived)
+	for i := ed25519.SeedSize; i < PrivateKeyLength; i++ {
+		if derived[i] != data[i] {
+			return ErrInvalidPrivateKey
+		}
+	}
+	k.seckey.Destroy()
+	k.seckey = utils.NewSecureBufferFrom(data)
+	return nil
+}
+
+// Clear clear key content
+func (k *PrivateKey) Clear() {
+	k.seckey.Destroy()
+}
+
+// PublicKey returns publickey
+func (k *PrivateKey) PublicKey() keystore.PublicKey {
+	pub := make([]byte, PublicKeyLength)
+	if err := k.seckey.Use(func(seckey []byte) error {
+		copy(pub, seckey[ed25519.SeedSize:])
+		return nil
+	}); err != nil {
+		return nil
+	}
+	return NewPublicKey(pub)
+}
+
+// Sign sign hash with privatekey
+func (k *PrivateKey) Sign(hash []byte) ([]byte, error) {
+	var sig []byte
+	err := k.seckey.Use(func(seckey []byte) (err error) {
+		sig, err = Sign(hash, seckey)
+		return err
+	})
+	return sig, err
+}
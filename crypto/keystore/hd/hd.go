@@ -0,0 +1,233 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package hd
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
+	"github.com/nebulasio/go-nebulas/crypto/utils"
+)
+
+// BIP-32 hierarchical deterministic keys, https://github.com/bitcoin/bips/blob/master/bip-0032.mediawiki
+// and the BIP-44 path of nebulas, m/44'/2718'/account'/change/index.
+const (
+	// HardenedKeyStart the first index of the hardened child keys
+	HardenedKeyStart uint32 = 0x80000000
+
+	// NebulasCoinType the coin type of nebulas registered in SLIP-44
+	NebulasCoinType uint32 = 2718
+
+	// DefaultPath the path of the first key of the default account
+	DefaultPath = "m/44'/2718'/0'/0/0"
+)
+
+var (
+	// ErrInvalidSeedLength the seed should be 128 to 512 bits.
+	ErrInvalidSeedLength = errors.New("invalid seed length, need 128 to 512 bits")
+
+	// ErrInvalidDerivedKey the derived key is out of the curve order, try the next index.
+	ErrInvalidDerivedKey = errors.New("invalid derived key")
+
+	// ErrInvalidDerivationPath the path is not like m/44'/2718'/0'/0/0.
+	ErrInvalidDerivationPath = errors.New("invalid derivation path")
+
+	masterKeySeed = []byte("Bitcoin seed")
+)
+
+// ExtendedKey a private key and its chain code
+type ExtendedKey struct {
+	key       []byte
+	chainCode []byte
+	depth     uint8
+	index     uint32
+}
+
+// NewMasterKey derive the master key from the seed
+func NewMasterKey(seed []byte) (*ExtendedKey, error) {
+	if len(seed) < 16 || len(seed) > 64 {
+		return nil, ErrInvalidSeedLength
+	}
+	mac := hmac.New(sha512.New, masterKeySeed)
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	if !secp256k1.SeckeyVerify(sum[:32]) {
+		return nil, ErrInvalidDerivedKey
+	}
+	return &ExtendedKey{
+		key:       sum[:32],
+		chainCode: sum[32:],
+	}, nil
+}
+
+// NebulasPath return the BIP-44 path of the key at the index of the account
+func NebulasPath(account, index uint32) string {
+	return fmt.Sprintf("m/44'/%d'/%d'/0/%d", NebulasCoinType, account, index)
+}
+
+// ParsePath parse a path like m/44'/2718'/0'/0/0 to the child indexes
+func ParsePath(path string) ([]uint32, error) {
+	parts := strings.Split(strings.TrimSpace(path), "/")
+	if len(parts) == 0 || parts[0] != "m" {
+		return nil, ErrInvalidDerivationPath
+	}
+	indexes := []uint32{}
+	for _, part := range parts[1:] {
+		hardened := strings.HasSuffix(part, "'") || strings.HasSuffix(part, "h")
+		if hardened {
+			part = part[:len(part)-1]
+		}
+		index, err := strconv.ParseUint(part, 10, 32)
+		if err != nil || uint32(index) >= HardenedKeyStart {
+			return nil, ErrInvalidDerivationPath
+		}
+		if hardened {
+			index += uint64(HardenedKeyStart)
+		}
+		indexes = append(indexes, uint32(index))
+	}
+	return indexes, nil
+}
+
+// DerivePath derive the key at the path from the master key
+func (k *ExtendedKey) DerivePath(path string) (*ExtendedKey, error) {
+	indexes, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	key := k
+	for _, index := range indexes {
+		if key, err = key.Child(index); err != nil {
+			return nil, err
+		}
+	}
+	return key, nil
+}
+
+// Child derive the child key at the index, it's hardened since HardenedKeyStart
+func (k *ExtendedKey) Child(index uint32) (*ExtendedKey, error) {
+	data := make([]byte, 0, 37)
+	if index >= HardenedKeyStart {
+		data = append(data, 0)
+		data = append(data, k.key...)
+	} else {
+		pub, err := k.compressedPublicKey()
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, pub...)
+	}
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], index)
+	data = append(data, buf[:]...)
+
+	mac := hmac.New(sha512.New, k.chainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	n := secp256k1.S256().Params().N
+	il := new(big.Int).SetBytes(sum[:32])
+	if il.Cmp(n) >= 0 {
+		return nil, ErrInvalidDerivedKey
+	}
+	il.Add(il, new(big.Int).SetBytes(k.key))
+	il.Mod(il, n)
+	if il.Sign() == 0 {
+		return nil, ErrInvalidDerivedKey
+	}
+
+	key := make([]byte, 32)
+	raw := il.Bytes()
+	copy(key[32-len(raw):], raw)
+	return &ExtendedKey{
+		key:       key,
+		chainCode: sum[32:],
+		depth:     k.depth + 1,
+		index:     index,
+	}, nil
+}
+
+// compressedPublicKey the 33 bytes form of the public key
+func (k *ExtendedKey) compressedPublicKey() ([]byte, error) {
+	pub, err := secp256k1.GetPublicKey(k.key)
+	if err != nil {
+		return nil, err
+	}
+	// 0x04 || x || y
+	compressed := make([]byte, 33)
+	compressed[0] = 0x02 | pub[64]&1
+	copy(compressed[1:], pub[1:33])
+	return compressed, nil
+}
+
+// Depth return the depth of the key, 0 for the master key
+func (k *ExtendedKey) Depth() uint8 {
+	return k.depth
+}
+
+// Index return the child index of the key
+func (k *ExtendedKey) Index() uint32 {
+	return k.index
+}
+
+// ChainCode return the chain code of the key
+func (k *ExtendedKey) ChainCode() []byte {
+	return k.chainCode
+}
+
+// PrivateKey return the secp256k1 private key
+func (k *ExtendedKey) PrivateKey() (*secp256k1.PrivateKey, error) {
+	priv := new(secp256k1.PrivateKey)
+	data := make([]byte, len(k.key))
+	copy(data, k.key)
+	if err := priv.Decode(data); err != nil {
+		return nil, err
+	}
+	return priv, nil
+}
+
+// Clear clear the key content
+func (k *ExtendedKey) Clear() {
+	utils.ZeroBytes(k.key)
+}
+
+// NewKeyFromMnemonic derive the private key at the path from the mnemonic
+func NewKeyFromMnemonic(mnemonic, passphrase, path string) (*secp256k1.PrivateKey, error) {
+	seed, err := NewSeed(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	master, err := NewMasterKey(seed)
+	if err != nil {
+		return nil, err
+	}
+	key, err := master.DerivePath(path)
+	if err != nil {
+		return nil, err
+	}
+	defer key.Clear()
+	return key.PrivateKey()
+}
@@ -0,0 +1,110 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package hd
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMnemonic(t *testing.T) {
+	// test vectors of BIP-39 with the passphrase TREZOR
+	tests := []struct {
+		entropy  string
+		mnemonic string
+		seed     string
+	}{
+		{
+			"00000000000000000000000000000000",
+			"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			"c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+		},
+		{
+			"7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+			"legal winner thank year wave sausage worth useful legal winner thank yellow",
+			"2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6fa457fe1296106559a3c80937a1c1069be3a3a5bd381ee6260e8d9739fce1f607",
+		},
+		{
+			"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+			strings.Repeat("zoo ", 23) + "vote",
+			"dd48c104698c30cfe2b6142103248622fb7bb0ff692eebb00089b32d22484e1613912f0a5b694407be899ffd31ed3992c456cdf60f5d4564b8ba3f05a69890ad",
+		},
+	}
+	for _, tt := range tests {
+		entropy, _ := hex.DecodeString(tt.entropy)
+		mnemonic, err := NewMnemonic(entropy)
+		assert.Nil(t, err)
+		assert.Equal(t, tt.mnemonic, mnemonic)
+
+		decoded, err := MnemonicToEntropy(mnemonic)
+		assert.Nil(t, err)
+		assert.Equal(t, entropy, decoded)
+
+		seed, err := NewSeed(mnemonic, "TREZOR")
+		assert.Nil(t, err)
+		assert.Equal(t, tt.seed, hex.EncodeToString(seed))
+	}
+
+	_, err := NewMnemonic(make([]byte, 15))
+	assert.Equal(t, ErrInvalidEntropyLength, err)
+	assert.False(t, ValidateMnemonic(strings.Repeat("abandon ", 12)))
+	assert.False(t, ValidateMnemonic(strings.Repeat("abandon ", 11)+"nebulas"))
+
+	entropy, err := NewEntropy(DefaultEntropyBits)
+	assert.Nil(t, err)
+	mnemonic, err := NewMnemonic(entropy)
+	assert.Nil(t, err)
+	assert.Equal(t, 24, len(strings.Fields(mnemonic)))
+	assert.True(t, ValidateMnemonic(mnemonic))
+}
+
+func TestDerivePath(t *testing.T) {
+	// test vector 1 of BIP-32
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	master, err := NewMasterKey(seed)
+	assert.Nil(t, err)
+
+	tests := []struct {
+		path      string
+		key       string
+		chainCode string
+	}{
+		{"m", "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35", "873dff81c02f525623fd1fe5167eac3a55a049de3d314bb42ee227ffed37d508"},
+		{"m/0'", "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea", "47fdacbd0f1097043b78c63c20c34ef4ed9a111d980047ad16282c7ae6236141"},
+		{"m/0'/1", "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368", "2a7857631386ba23dacac34180dd1983734e444fdbf774041578e9b6adb37c19"},
+		{"m/0'/1/2'/2/1000000000", "471b76e389e528d6de6d816857e012c5455051cad6660850e58372a6c3e6e7c8", "c783e67b921d2beb8f6b389cc646d7263b4145701dadd2161548a8b078e65e9e"},
+	}
+	for _, tt := range tests {
+		key, err := master.DerivePath(tt.path)
+		assert.Nil(t, err)
+		assert.Equal(t, tt.key, hex.EncodeToString(key.key))
+		assert.Equal(t, tt.chainCode, hex.EncodeToString(key.ChainCode()))
+	}
+
+	indexes, err := ParsePath(NebulasPath(1, 2))
+	assert.Nil(t, err)
+	assert.Equal(t, []uint32{44 + HardenedKeyStart, NebulasCoinType + HardenedKeyStart, 1 + HardenedKeyStart, 0, 2}, indexes)
+	for _, path := range []string{"", "44'/0", "m/x", "m/2147483648"} {
+		_, err := ParsePath(path)
+		assert.Equal(t, ErrInvalidDerivationPath, err)
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package hd
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/nebulasio/go-nebulas/crypto/utils"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
+)
+
+// BIP-39 mnemonic, https://github.com/bitcoin/bips/blob/master/bip-0039.mediawiki
+const (
+	// DefaultEntropyBits the entropy of a 24 words mnemonic
+	DefaultEntropyBits = 256
+
+	seedIterations = 2048
+	seedLength     = 64
+)
+
+var (
+	// ErrInvalidEntropyLength the entropy should be 128 to 256 bits, a multiple of 32.
+	ErrInvalidEntropyLength = errors.New("invalid entropy length, need 128 to 256 bits, a multiple of 32")
+
+	// ErrInvalidMnemonic the mnemonic has unknown words or a wrong checksum.
+	ErrInvalidMnemonic = errors.New("invalid mnemonic")
+)
+
+func checkEntropyBits(bits int) error {
+	if bits < 128 || bits > 256 || bits%32 != 0 {
+		return ErrInvalidEntropyLength
+	}
+	return nil
+}
+
+// NewEntropy generate random entropy of the bits for a mnemonic
+func NewEntropy(bits int) ([]byte, error) {
+	if err := checkEntropyBits(bits); err != nil {
+		return nil, err
+	}
+	return utils.RandomCSPRNG(bits / 8), nil
+}
+
+// NewMnemonic encode the entropy to a mnemonic, each word carries 11 bits of the
+// entropy followed by its sha256 checksum.
+func NewMnemonic(entropy []byte) (string, error) {
+	bits := len(entropy) * 8
+	if err := checkEntropyBits(bits); err != nil {
+		return "", err
+	}
+	checksumBits := uint(bits / 32)
+	checksum := sha256.Sum256(entropy)
+
+	data := new(big.Int).SetBytes(entropy)
+	data.Lsh(data, checksumBits)
+	data.Or(data, big.NewInt(int64(checksum[0]>>(8-checksumBits))))
+
+	size := (bits + int(checksumBits)) / 11
+	words := make([]string, size)
+	mask := big.NewInt(2047)
+	for i := size - 1; i >= 0; i-- {
+		words[i] = englishWords[new(big.Int).And(data, mask).Int64()]
+		data.Rsh(data, 11)
+	}
+	return strings.Join(words, " "), nil
+}
+
+// MnemonicToEntropy decode the mnemonic and verify its checksum
+func MnemonicToEntropy(mnemonic string) ([]byte, error) {
+	words := strings.Fields(mnemonic)
+	size := len(words)
+	if size%3 != 0 || size < 12 || size > 24 {
+		return nil, ErrInvalidMnemonic
+	}
+
+	data := new(big.Int)
+	for _, word := range words {
+		index, ok := englishIndex[word]
+		if !ok {
+			return nil, ErrInvalidMnemonic
+		}
+		data.Lsh(data, 11)
+		data.Or(data, big.NewInt(int64(index)))
+	}
+
+	checksumBits := uint(size / 3)
+	checksum := new(big.Int).And(data, big.NewInt(1<<checksumBits-1)).Int64()
+	data.Rsh(data, checksumBits)
+
+	// keep the leading zeros of the entropy.
+	entropy := make([]byte, (size*11-int(checksumBits))/8)
+	raw := data.Bytes()
+	copy(entropy[len(entropy)-len(raw):], raw)
+
+	expected := sha256.Sum256(entropy)
+	if int64(expected[0]>>(8-checksumBits)) != checksum {
+		return nil, ErrInvalidMnemonic
+	}
+	return entropy, nil
+}
+
+// ValidateMnemonic check if the mnemonic is valid
+func ValidateMnemonic(mnemonic string) bool {
+	_, err := MnemonicToEntropy(mnemonic)
+	return err == nil
+}
+
+// NewSeed derive the seed of the hd keys from the mnemonic and an optional
+// passphrase, the mnemonic is validated first.
+func NewSeed(mnemonic, passphrase string) ([]byte, error) {
+	if !ValidateMnemonic(mnemonic) {
+		return nil, ErrInvalidMnemonic
+	}
+	password := norm.NFKD.String(strings.Join(strings.Fields(mnemonic), " "))
+	salt := norm.NFKD.String("mnemonic" + passphrase)
+	return pbkdf2.Key([]byte(password), []byte(salt), seedIterations, seedLength, sha512.New), nil
+}
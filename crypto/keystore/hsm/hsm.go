@@ -0,0 +1,327 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package hsm
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/miekg/pkcs11"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
+)
+
+// The keys are secp256k1 key pairs on a PKCS#11 token, addressed by the hex
+// CKA_ID of the pair. The private keys are generated on the token, sensitive
+// and not extractable, the token signs hashes by CKM_ECDSA only and the
+// recovery id of the signature is found on the host.
+const (
+	signatureLength = 65
+	hashLength      = 32
+)
+
+// oidSecp256k1 the DER encoded object identifier of secp256k1, 1.3.132.0.10
+var oidSecp256k1 = []byte{0x06, 0x05, 0x2b, 0x81, 0x04, 0x00, 0x0a}
+
+var (
+	// ErrTokenNotFound no token of the label is present
+	ErrTokenNotFound = errors.New("pkcs11 token not found")
+
+	// ErrKeyNotFound no key pair of the id is on the token
+	ErrKeyNotFound = errors.New("pkcs11 key not found")
+
+	// ErrKeyExists a key pair of the id is already on the token
+	ErrKeyExists = errors.New("pkcs11 key already exists")
+
+	// ErrModuleClosed the module is closed
+	ErrModuleClosed = errors.New("pkcs11 module closed")
+
+	// ErrInvalidKeyID the key id is not hex
+	ErrInvalidKeyID = errors.New("invalid pkcs11 key id, need hex")
+
+	// ErrInvalidPublicKey the public key on the token is not a secp256k1 point
+	ErrInvalidPublicKey = errors.New("invalid pkcs11 public key")
+
+	// ErrInvalidSignature the token returned a signature not of the key
+	ErrInvalidSignature = errors.New("invalid signature from pkcs11 token")
+
+	// ErrKeyOnToken the private key never leaves the token
+	ErrKeyOnToken = errors.New("private key is kept in the pkcs11 token")
+
+	// ErrInvalidHashLength only hashes of 32 bytes are signed
+	ErrInvalidHashLength = errors.New("invalid hash length, need 32 bytes")
+)
+
+// Module a session logged in a token of a PKCS#11 library
+type Module struct {
+	ctx     *pkcs11.Ctx
+	session pkcs11.SessionHandle
+	label   string
+	mutex   sync.Mutex
+}
+
+// Open load the PKCS#11 library and log in the token of the label with the pin
+func Open(library, label, pin string) (*Module, error) {
+	ctx := pkcs11.New(library)
+	if ctx == nil {
+		return nil, errors.New("failed to load pkcs11 library " + library)
+	}
+	if err := ctx.Initialize(); err != nil {
+		ctx.Destroy()
+		return nil, err
+	}
+
+	session, err := openSession(ctx, label, pin)
+	if err != nil {
+		ctx.Finalize()
+		ctx.Destroy()
+		return nil, err
+	}
+	return &Module{ctx: ctx, session: session, label: label}, nil
+}
+
+func openSession(ctx *pkcs11.Ctx, label, pin string) (pkcs11.SessionHandle, error) {
+	slots, err := ctx.GetSlotList(true)
+	if err != nil {
+		return 0, err
+	}
+	for _, slot := range slots {
+		info, err := ctx.GetTokenInfo(slot)
+		if err != nil || info.Label != label {
+			continue
+		}
+		session, err := ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION|pkcs11.CKF_RW_SESSION)
+		if err != nil {
+			return 0, err
+		}
+		if err := ctx.Login(session, pkcs11.CKU_USER, pin); err != nil && err != pkcs11.Error(pkcs11.CKR_USER_ALREADY_LOGGED_IN) {
+			ctx.CloseSession(session)
+			return 0, err
+		}
+		return session, nil
+	}
+	return 0, ErrTokenNotFound
+}
+
+// Label return the label of the token
+func (m *Module) Label() string {
+	return m.label
+}
+
+// Close log out the token and unload the library
+func (m *Module) Close() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.ctx == nil {
+		return nil
+	}
+	m.ctx.Logout(m.session)
+	m.ctx.CloseSession(m.session)
+	err := m.ctx.Finalize()
+	m.ctx.Destroy()
+	m.ctx = nil
+	return err
+}
+
+// GenerateKey generate a secp256k1 key pair of the id on the token, and
+// return its uncompressed public key.
+func (m *Module) GenerateKey(keyID string) ([]byte, error) {
+	id, err := decodeKeyID(keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.ctx == nil {
+		return nil, ErrModuleClosed
+	}
+	if _, err := m.findObject(pkcs11.CKO_PRIVATE_KEY, id); err != ErrKeyNotFound {
+		if err == nil {
+			return nil, ErrKeyExists
+		}
+		return nil, err
+	}
+
+	pubTemplate := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_PUBLIC_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_EC),
+		pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+		pkcs11.NewAttribute(pkcs11.CKA_VERIFY, true),
+		pkcs11.NewAttribute(pkcs11.CKA_EC_PARAMS, oidSecp256k1),
+		pkcs11.NewAttribute(pkcs11.CKA_ID, id),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, keyID),
+	}
+	privTemplate := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_PRIVATE_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_EC),
+		pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+		pkcs11.NewAttribute(pkcs11.CKA_PRIVATE, true),
+		pkcs11.NewAttribute(pkcs11.CKA_SIGN, true),
+		pkcs11.NewAttribute(pkcs11.CKA_SENSITIVE, true),
+		pkcs11.NewAttribute(pkcs11.CKA_EXTRACTABLE, false),
+		pkcs11.NewAttribute(pkcs11.CKA_ID, id),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, keyID),
+	}
+	mechanism := []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_EC_KEY_PAIR_GEN, nil)}
+	pubHandle, _, err := m.ctx.GenerateKeyPair(m.session, mechanism, pubTemplate, privTemplate)
+	if err != nil {
+		return nil, err
+	}
+	return m.publicKey(pubHandle)
+}
+
+// PublicKey return the uncompressed public key of the key pair of the id
+func (m *Module) PublicKey(keyID string) ([]byte, error) {
+	id, err := decodeKeyID(keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.ctx == nil {
+		return nil, ErrModuleClosed
+	}
+	handle, err := m.findObject(pkcs11.CKO_PUBLIC_KEY, id)
+	if err != nil {
+		return nil, err
+	}
+	return m.publicKey(handle)
+}
+
+// SignHash sign the hash by the private key of the id on the token, pub is
+// its public key the recovery id is found by. It returns r || s || v with
+// s in the lower half of the order.
+func (m *Module) SignHash(keyID string, pub, hash []byte) ([]byte, error) {
+	if len(hash) != hashLength {
+		return nil, ErrInvalidHashLength
+	}
+	id, err := decodeKeyID(keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.ctx == nil {
+		return nil, ErrModuleClosed
+	}
+	handle, err := m.findObject(pkcs11.CKO_PRIVATE_KEY, id)
+	if err != nil {
+		return nil, err
+	}
+	mechanism := []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_ECDSA, nil)}
+	if err := m.ctx.SignInit(m.session, mechanism, handle); err != nil {
+		return nil, err
+	}
+	sig, err := m.ctx.Sign(m.session, hash)
+	if err != nil {
+		return nil, err
+	}
+	return recoverableSignature(hash, sig, pub)
+}
+
+func (m *Module) findObject(class uint, id []byte) (pkcs11.ObjectHandle, error) {
+	template := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, class),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_EC),
+		pkcs11.NewAttribute(pkcs11.CKA_ID, id),
+	}
+	if err := m.ctx.FindObjectsInit(m.session, template); err != nil {
+		return 0, err
+	}
+	handles, _, err := m.ctx.FindObjects(m.session, 1)
+	m.ctx.FindObjectsFinal(m.session)
+	if err != nil {
+		return 0, err
+	}
+	if len(handles) == 0 {
+		return 0, ErrKeyNotFound
+	}
+	return handles[0], nil
+}
+
+func (m *Module) publicKey(handle pkcs11.ObjectHandle) ([]byte, error) {
+	attrs, err := m.ctx.GetAttributeValue(m.session, handle, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_EC_PARAMS, nil),
+		pkcs11.NewAttribute(pkcs11.CKA_EC_POINT, nil),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(attrs) != 2 || !bytes.Equal(attrs[0].Value, oidSecp256k1) {
+		return nil, ErrInvalidPublicKey
+	}
+	return parseECPoint(attrs[1].Value)
+}
+
+func decodeKeyID(keyID string) ([]byte, error) {
+	id, err := hex.DecodeString(keyID)
+	if err != nil || len(id) == 0 {
+		return nil, ErrInvalidKeyID
+	}
+	return id, nil
+}
+
+// parseECPoint return the uncompressed point of CKA_EC_POINT, which is a DER
+// octet string by the standard, some tokens return the raw point.
+func parseECPoint(data []byte) ([]byte, error) {
+	point := data
+	if len(data) > 2 && data[0] == 0x04 && int(data[1]) == len(data)-2 {
+		point = data[2:]
+	}
+	key, err := secp256k1.ToECDSAPublicKey(point)
+	if err != nil || key.X == nil {
+		return nil, ErrInvalidPublicKey
+	}
+	return point, nil
+}
+
+// recoverableSignature normalize the r || s signature of the token to the
+// lower s, and append the recovery id the public key is recovered by.
+func recoverableSignature(hash, sig, pub []byte) ([]byte, error) {
+	if len(sig) != signatureLength-1 {
+		return nil, ErrInvalidSignature
+	}
+	n := secp256k1.S256().Params().N
+	s := new(big.Int).SetBytes(sig[32:])
+	if s.Cmp(new(big.Int).Rsh(n, 1)) > 0 {
+		s.Sub(n, s)
+	}
+
+	out := make([]byte, signatureLength)
+	copy(out, sig[:32])
+	sBytes := s.Bytes()
+	copy(out[64-len(sBytes):64], sBytes)
+	for v := byte(0); v < 2; v++ {
+		out[64] = v
+		recovered, err := secp256k1.RecoverECDSAPublicKey(hash, out)
+		if err == nil && bytes.Equal(recovered, pub) {
+			return out, nil
+		}
+	}
+	return nil, ErrInvalidSignature
+}
@@ -0,0 +1,92 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package hsm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseECPoint(t *testing.T) {
+	pub, err := secp256k1.GetPublicKey(secp256k1.NewSeckey())
+	assert.Nil(t, err)
+
+	// DER octet string of the point.
+	point, err := parseECPoint(append([]byte{0x04, byte(len(pub))}, pub...))
+	assert.Nil(t, err)
+	assert.Equal(t, pub, point)
+
+	// raw point.
+	point, err = parseECPoint(pub)
+	assert.Nil(t, err)
+	assert.Equal(t, pub, point)
+
+	_, err = parseECPoint(pub[:33])
+	assert.Equal(t, ErrInvalidPublicKey, err)
+}
+
+func TestRecoverableSignature(t *testing.T) {
+	seckey := secp256k1.NewSeckey()
+	pub, err := secp256k1.GetPublicKey(seckey)
+	assert.Nil(t, err)
+	data := hash.Sha3256([]byte("hsm"))
+
+	sig, err := secp256k1.Sign(data, seckey)
+	assert.Nil(t, err)
+
+	// the token returns r || s without the recovery id.
+	out, err := recoverableSignature(data, sig[:64], pub)
+	assert.Nil(t, err)
+	assert.Equal(t, sig, out)
+
+	// s in the upper half is normalized.
+	n := secp256k1.S256().Params().N
+	s := new(big.Int).Sub(n, new(big.Int).SetBytes(sig[32:64]))
+	high := make([]byte, 64)
+	copy(high, sig[:32])
+	sBytes := s.Bytes()
+	copy(high[64-len(sBytes):], sBytes)
+	out, err = recoverableSignature(data, high, pub)
+	assert.Nil(t, err)
+	assert.Equal(t, sig, out)
+
+	// not a signature of the key.
+	other, err := secp256k1.GetPublicKey(secp256k1.NewSeckey())
+	assert.Nil(t, err)
+	_, err = recoverableSignature(data, sig[:64], other)
+	assert.Equal(t, ErrInvalidSignature, err)
+
+	_, err = recoverableSignature(data, sig, pub)
+	assert.Equal(t, ErrInvalidSignature, err)
+}
+
+func TestDecodeKeyID(t *testing.T) {
+	id, err := decodeKeyID("0a0b")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0x0a, 0x0b}, id)
+
+	_, err = decodeKeyID("")
+	assert.Equal(t, ErrInvalidKeyID, err)
+	_, err = decodeKeyID("xyz")
+	assert.Equal(t, ErrInvalidKeyID, err)
+}
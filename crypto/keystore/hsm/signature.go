@@ -0,0 +1,57 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package hsm
+
+import (
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
+)
+
+// Signature sign on a PKCS#11 token by the key pair of the id, it's verified
+// as a secp256k1 signature.
+type Signature struct {
+	secp256k1.Signature
+
+	module *Module
+	keyID  string
+	pub    []byte
+}
+
+// NewSignature returns a signature signing on the token by the key pair of
+// the id whose public key is pub
+func NewSignature(module *Module, keyID string, pub []byte) *Signature {
+	return &Signature{
+		module: module,
+		keyID:  keyID,
+		pub:    pub,
+	}
+}
+
+// InitSign the key is kept in the token, no private key is needed
+func (s *Signature) InitSign(priv keystore.PrivateKey) error {
+	return ErrKeyOnToken
+}
+
+// Sign sign the hash on the token
+func (s *Signature) Sign(data []byte) (out []byte, err error) {
+	if s.module == nil {
+		return nil, ErrModuleClosed
+	}
+	return s.module.SignHash(s.keyID, s.pub, data)
+}
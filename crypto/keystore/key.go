@@ -25,6 +25,18 @@ const (
 	// SECP256K1 a type of signer
 	SECP256K1 Algorithm = 1
 
+	// ED25519 a type of signer
+	ED25519 Algorithm = 2
+
+	// BLS12381 a type of signer with aggregatable signatures, it can't
+	// recover the signer and is not used to sign transactions
+	BLS12381 Algorithm = 3
+
+	// SCHNORR a type of signer with BIP-340 signatures over secp256k1 and
+	// multi-party aggregation, it can't recover the signer and is not used
+	// to sign transactions yet
+	SCHNORR Algorithm = 4
+
 	// SCRYPT a type of encrypt
 	SCRYPT Algorithm = 1 << 4
 )
@@ -85,3 +97,10 @@ type PublicKey interface {
 	// Clear clear key content
 	Clear()
 }
+
+// Decrypter a private key decrypting the data encrypted to its public key
+type Decrypter interface {
+
+	// Decrypt returns the plaintext of the ciphertext
+	Decrypt(ciphertext []byte) ([]byte, error)
+}
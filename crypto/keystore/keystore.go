@@ -44,6 +44,9 @@ var (
 
 	// ErrInvalidPassphrase invalid passphrase
 	ErrInvalidPassphrase = errors.New("passphrase is invalid")
+
+	// ErrDecryptNotSupported the key can't decrypt
+	ErrDecryptNotSupported = errors.New("key does not support decryption")
 )
 
 // unlock item
@@ -53,6 +56,26 @@ type unlocked struct {
 	key Key
 
 	timer *time.Timer
+
+	// expiry time of the unlock
+	expiry time.Time
+
+	// signings left before the key is locked, 0 for no limit
+	uses uint32
+
+	// closed once the key is locked
+	done chan struct{}
+}
+
+// UnlockInfo the state of an unlocked key
+type UnlockInfo struct {
+	Alias string
+
+	// Expiry time the key is locked again
+	Expiry time.Time
+
+	// Uses signings left before the key is locked, 0 for no limit
+	Uses uint32
 }
 
 // Keystore class represents a storage facility for cryptographic keys
@@ -92,6 +115,12 @@ func (ks *Keystore) ContainsAlias(a string) (bool, error) {
 
 // Unlock unlock key with ProtectionParameter
 func (ks *Keystore) Unlock(alias string, passphrase []byte, timeout time.Duration) error {
+	return ks.UnlockWithUses(alias, passphrase, timeout, 0)
+}
+
+// UnlockWithUses unlock key for the timeout or the given number of signings,
+// whichever comes first, 0 uses for no limit.
+func (ks *Keystore) UnlockWithUses(alias string, passphrase []byte, timeout time.Duration, uses uint32) error {
 	ks.mu.Lock()
 	defer ks.mu.Unlock()
 
@@ -103,11 +132,20 @@ func (ks *Keystore) Unlock(alias string, passphrase []byte, timeout time.Duratio
 	unlockedKey, ok := ks.unlocked[alias]
 	if ok == true {
 		unlockedKey.key = key
+		unlockedKey.expiry = time.Now().Add(timeout)
+		unlockedKey.uses = uses
 		unlockedKey.timer.Reset(timeout)
 	} else {
-		u := &unlocked{alias, key, time.NewTimer(timeout)}
+		u := &unlocked{
+			alias:  alias,
+			key:    key,
+			timer:  time.NewTimer(timeout),
+			expiry: time.Now().Add(timeout),
+			uses:   uses,
+			done:   make(chan struct{}),
+		}
 		ks.unlocked[alias] = u
-		go ks.expire(alias)
+		go ks.expire(u)
 	}
 	return nil
 }
@@ -118,27 +156,67 @@ func (ks *Keystore) Lock(alias string) error {
 	defer ks.mu.Unlock()
 
 	if u, ok := ks.unlocked[alias]; ok == true {
-		u.timer.Reset(time.Duration(0) * time.Nanosecond)
+		ks.lock(u)
 		return nil
 	}
 
 	return ErrNotUnlocked
 }
 
-func (ks *Keystore) expire(alias string) {
-	if u, ok := ks.unlocked[alias]; ok == true {
-		defer u.timer.Stop()
+// LockAll lock all the unlocked keys
+func (ks *Keystore) LockAll() {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	for _, u := range ks.unlocked {
+		ks.lock(u)
+	}
+}
+
+// lock clears the key and removes it from the unlocked ones, ks.mu must be held.
+func (ks *Keystore) lock(u *unlocked) {
+	if ks.unlocked[u.alias] != u {
+		return
+	}
+	u.timer.Stop()
+	u.key.Clear()
+	delete(ks.unlocked, u.alias)
+	close(u.done)
+}
+
+func (ks *Keystore) expire(u *unlocked) {
+	for {
 		select {
 		case <-u.timer.C:
 			ks.mu.Lock()
-			u.key.Clear()
-			delete(ks.unlocked, alias)
+			// unlocked again while waiting for the lock.
+			if time.Now().Before(u.expiry) {
+				ks.mu.Unlock()
+				continue
+			}
+			ks.lock(u)
 			ks.mu.Unlock()
+			return
+		case <-u.done:
+			return
 		}
 	}
 }
 
-// GetUnlocked returns a unlocked key
+// Unlocked returns the states of the unlocked keys
+func (ks *Keystore) Unlocked() []*UnlockInfo {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	infos := make([]*UnlockInfo, 0, len(ks.unlocked))
+	for _, u := range ks.unlocked {
+		infos = append(infos, &UnlockInfo{Alias: u.alias, Expiry: u.expiry, Uses: u.uses})
+	}
+	return infos
+}
+
+// GetUnlocked returns a unlocked key, it does not count as a use of the
+// unlock, signings should go through UseUnlocked.
 func (ks *Keystore) GetUnlocked(alias string) (Key, error) {
 	if len(alias) == 0 {
 		return nil, ErrNeedAlias
@@ -155,6 +233,45 @@ func (ks *Keystore) GetUnlocked(alias string) (Key, error) {
 	return key.key, nil
 }
 
+// UseUnlocked calls fn with the unlocked key, counting a use of the unlock.
+// The key is locked once its uses run out, fn must not keep it.
+func (ks *Keystore) UseUnlocked(alias string, fn func(key Key) error) error {
+	if len(alias) == 0 {
+		return ErrNeedAlias
+	}
+
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	u, ok := ks.unlocked[alias]
+	if ok == false {
+		return ErrNotUnlocked
+	}
+
+	err := fn(u.key)
+	if u.uses > 0 {
+		u.uses--
+		if u.uses == 0 {
+			ks.lock(u)
+		}
+	}
+	return err
+}
+
+// Decrypt decrypts the ciphertext encrypted to the public key of the
+// unlocked key, it does not count as a use of the unlock.
+func (ks *Keystore) Decrypt(alias string, ciphertext []byte) ([]byte, error) {
+	key, err := ks.GetUnlocked(alias)
+	if err != nil {
+		return nil, err
+	}
+	decrypter, ok := key.(Decrypter)
+	if !ok {
+		return nil, ErrDecryptNotSupported
+	}
+	return decrypter.Decrypt(ciphertext)
+}
+
 // SetKey assigns the given key to the given alias, protecting it with the given passphrase.
 func (ks *Keystore) SetKey(a string, k Key, passphrase []byte) error {
 	if ks.p == nil {
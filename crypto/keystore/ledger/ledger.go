@@ -0,0 +1,299 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package ledger
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/karalabe/hid"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/hd"
+)
+
+// Ledger devices talk with the host by APDUs over usb hid, each of them is
+// split into the frames of 64 bytes. The private keys are derived by BIP-44
+// paths and never leave the device, the nebulas app signs hashes on it.
+const (
+	ledgerVendorID  = 0x2c97
+	ledgerUsagePage = 0xffa0
+	ledgerInterface = 0
+
+	frameSize    = 64
+	frameChannel = 0x0101
+	frameTag     = 0x05
+)
+
+// APDUs of the nebulas app
+const (
+	claNebulas = 0xe0
+
+	// insGetPublicKey returns the length of the public key and the uncompressed public key.
+	insGetPublicKey = 0x02
+
+	// insSignHash returns the signature r || s || v of the hash.
+	insSignHash = 0x04
+
+	p1NonConfirm = 0x00
+	p1Confirm    = 0x01
+
+	swOK       = 0x9000
+	swRejected = 0x6985
+
+	signatureLength = 65
+	hashLength      = 32
+)
+
+var (
+	// ErrUnsupportedPlatform usb hid is not supported on the platform
+	ErrUnsupportedPlatform = errors.New("usb hid is not supported on this platform")
+
+	// ErrDeviceNotFound no ledger device is connected
+	ErrDeviceNotFound = errors.New("ledger device not found")
+
+	// ErrDeviceClosed the device is not opened
+	ErrDeviceClosed = errors.New("ledger device closed")
+
+	// ErrInvalidReply the reply of the device is malformed
+	ErrInvalidReply = errors.New("invalid reply from ledger device")
+
+	// ErrRequestRejected the user rejected the request on the device
+	ErrRequestRejected = errors.New("request rejected on ledger device")
+
+	// ErrKeyOnDevice the private key never leaves the device
+	ErrKeyOnDevice = errors.New("private key is kept in the ledger device")
+
+	// ErrInvalidHashLength only hashes of 32 bytes are signed
+	ErrInvalidHashLength = errors.New("invalid hash length, need 32 bytes")
+)
+
+// Device a ledger device running the nebulas app
+type Device struct {
+	info   hid.DeviceInfo
+	device *hid.Device
+	mutex  sync.Mutex
+}
+
+// Enumerate return the ledger devices connected
+func Enumerate() ([]*Device, error) {
+	if !hid.Supported() {
+		return nil, ErrUnsupportedPlatform
+	}
+	devices := []*Device{}
+	for _, info := range hid.Enumerate(ledgerVendorID, 0) {
+		// the nebulas app is on the interface of the ledger usage page only
+		if info.UsagePage == ledgerUsagePage || info.Interface == ledgerInterface {
+			devices = append(devices, &Device{info: info})
+		}
+	}
+	return devices, nil
+}
+
+// FirstDevice open the first ledger device connected
+func FirstDevice() (*Device, error) {
+	devices, err := Enumerate()
+	if err != nil {
+		return nil, err
+	}
+	if len(devices) == 0 {
+		return nil, ErrDeviceNotFound
+	}
+	if err := devices[0].Open(); err != nil {
+		return nil, err
+	}
+	return devices[0], nil
+}
+
+// Path return the platform path of the device
+func (d *Device) Path() string {
+	return d.info.Path
+}
+
+// Open open the device
+func (d *Device) Open() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.device != nil {
+		return nil
+	}
+	device, err := d.info.Open()
+	if err != nil {
+		return err
+	}
+	d.device = device
+	return nil
+}
+
+// Close close the device
+func (d *Device) Close() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.device == nil {
+		return nil
+	}
+	err := d.device.Close()
+	d.device = nil
+	return err
+}
+
+// PublicKey return the uncompressed public key at the BIP-44 path, the user
+// confirms the address on the device if confirm.
+func (d *Device) PublicKey(path string, confirm bool) ([]byte, error) {
+	data, err := encodePath(path)
+	if err != nil {
+		return nil, err
+	}
+	p1 := byte(p1NonConfirm)
+	if confirm {
+		p1 = p1Confirm
+	}
+	reply, err := d.exchange(insGetPublicKey, p1, 0, data)
+	if err != nil {
+		return nil, err
+	}
+	if len(reply) < 1 || len(reply) < 1+int(reply[0]) {
+		return nil, ErrInvalidReply
+	}
+	return reply[1 : 1+int(reply[0])], nil
+}
+
+// SignHash sign the hash by the key at the BIP-44 path, the user confirms
+// the signing on the device.
+func (d *Device) SignHash(path string, hash []byte) ([]byte, error) {
+	if len(hash) != hashLength {
+		return nil, ErrInvalidHashLength
+	}
+	data, err := encodePath(path)
+	if err != nil {
+		return nil, err
+	}
+	reply, err := d.exchange(insSignHash, p1Confirm, 0, append(data, hash...))
+	if err != nil {
+		return nil, err
+	}
+	if len(reply) != signatureLength {
+		return nil, ErrInvalidReply
+	}
+	return reply, nil
+}
+
+// encodePath the number of the indexes followed by the indexes in big endian
+func encodePath(path string) ([]byte, error) {
+	indexes, err := hd.ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	data := make([]byte, 1+4*len(indexes))
+	data[0] = byte(len(indexes))
+	for i, index := range indexes {
+		binary.BigEndian.PutUint32(data[1+4*i:], index)
+	}
+	return data, nil
+}
+
+// exchange send an APDU and return the reply without the status word
+func (d *Device) exchange(ins, p1, p2 byte, data []byte) ([]byte, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.device == nil {
+		return nil, ErrDeviceClosed
+	}
+
+	apdu := []byte{claNebulas, ins, p1, p2, byte(len(data))}
+	apdu = append(apdu, data...)
+	if err := writeFrames(d.device, apdu); err != nil {
+		return nil, err
+	}
+	reply, err := readFrames(d.device)
+	if err != nil {
+		return nil, err
+	}
+	if len(reply) < 2 {
+		return nil, ErrInvalidReply
+	}
+	sw := binary.BigEndian.Uint16(reply[len(reply)-2:])
+	switch sw {
+	case swOK:
+		return reply[:len(reply)-2], nil
+	case swRejected:
+		return nil, ErrRequestRejected
+	default:
+		return nil, fmt.Errorf("ledger device error, status %#04x", sw)
+	}
+}
+
+// frameHeader the channel, the tag and the sequence of a frame
+func frameHeader(seq int) []byte {
+	header := make([]byte, 5)
+	binary.BigEndian.PutUint16(header, frameChannel)
+	header[2] = frameTag
+	binary.BigEndian.PutUint16(header[3:], uint16(seq))
+	return header
+}
+
+// writeFrames write the APDU prefixed by its length in frames
+func writeFrames(device io.Writer, apdu []byte) error {
+	length := make([]byte, 2)
+	binary.BigEndian.PutUint16(length, uint16(len(apdu)))
+	payload := append(length, apdu...)
+
+	for seq := 0; len(payload) > 0; seq++ {
+		frame := make([]byte, frameSize)
+		header := frameHeader(seq)
+		copy(frame, header)
+		n := copy(frame[len(header):], payload)
+		payload = payload[n:]
+		if _, err := device.Write(frame); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readFrames read the reply, its length is in the first frame
+func readFrames(device io.Reader) ([]byte, error) {
+	var reply []byte
+	frame := make([]byte, frameSize)
+	for seq := 0; ; seq++ {
+		if _, err := device.Read(frame); err != nil {
+			return nil, err
+		}
+		header := frameHeader(seq)
+		for i := range header {
+			if frame[i] != header[i] {
+				return nil, ErrInvalidReply
+			}
+		}
+		payload := frame[len(header):]
+		if reply == nil {
+			reply = make([]byte, 0, int(binary.BigEndian.Uint16(payload)))
+			payload = payload[2:]
+		}
+		if left := cap(reply) - len(reply); left > len(payload) {
+			reply = append(reply, payload...)
+		} else {
+			return append(reply, payload[:left]...), nil
+		}
+	}
+}
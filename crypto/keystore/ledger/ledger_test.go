@@ -0,0 +1,66 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package ledger
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/crypto/keystore/hd"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFrames(t *testing.T) {
+	apdu := make([]byte, 150)
+	for i := range apdu {
+		apdu[i] = byte(i)
+	}
+	buf := new(bytes.Buffer)
+	assert.Nil(t, writeFrames(buf, apdu))
+	// 2 bytes length and 150 bytes apdu in frames of 59 bytes payload.
+	assert.Equal(t, 3*frameSize, buf.Len())
+	assert.Equal(t, []byte{0x01, 0x01, 0x05, 0x00, 0x02}, buf.Bytes()[2*frameSize:2*frameSize+5])
+
+	// the replies are framed the same way.
+	reply, err := readFrames(buf)
+	assert.Nil(t, err)
+	assert.Equal(t, apdu, reply)
+
+	buf.Reset()
+	assert.Nil(t, writeFrames(buf, apdu))
+	buf.Bytes()[frameSize+4] = 0x05
+	_, err = readFrames(buf)
+	assert.Equal(t, ErrInvalidReply, err)
+}
+
+func TestEncodePath(t *testing.T) {
+	data, err := encodePath(hd.DefaultPath)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{
+		0x05,
+		0x80, 0x00, 0x00, 0x2c,
+		0x80, 0x00, 0x0a, 0x9e,
+		0x80, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00,
+	}, data)
+
+	_, err = encodePath("44'/2718'")
+	assert.Equal(t, hd.ErrInvalidDerivationPath, err)
+}
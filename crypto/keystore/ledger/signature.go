@@ -0,0 +1,54 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package ledger
+
+import (
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
+)
+
+// Signature sign on a ledger device by the key at the BIP-44 path, it's
+// verified as a secp256k1 signature.
+type Signature struct {
+	secp256k1.Signature
+
+	device *Device
+	path   string
+}
+
+// NewSignature returns a signature signing on the device by the key at the path
+func NewSignature(device *Device, path string) *Signature {
+	return &Signature{
+		device: device,
+		path:   path,
+	}
+}
+
+// InitSign the key is kept in the device, no private key is needed
+func (s *Signature) InitSign(priv keystore.PrivateKey) error {
+	return ErrKeyOnDevice
+}
+
+// Sign sign the hash on the device
+func (s *Signature) Sign(data []byte) (out []byte, err error) {
+	if s.device == nil {
+		return nil, ErrDeviceClosed
+	}
+	return s.device.SignHash(s.path, data)
+}
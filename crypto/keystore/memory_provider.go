@@ -24,6 +24,7 @@ import (
 	"sync"
 
 	"github.com/nebulasio/go-nebulas/crypto/cipher"
+	"github.com/nebulasio/go-nebulas/crypto/utils"
 )
 
 var (
@@ -90,6 +91,7 @@ func (p *MemoryProvider) SetKey(a string, key Key, passphrase []byte) error {
 	if err != nil {
 		return err
 	}
+	defer utils.ZeroBytes(encoded)
 	data, err := p.cipher.Encrypt(encoded, passphrase)
 	if err != nil {
 		return err
@@ -124,6 +126,8 @@ func (p *MemoryProvider) GetKey(a string, passphrase []byte) (Key, error) {
 	if err != nil {
 		return nil, err
 	}
+	// the key copies the data into locked memory.
+	defer utils.ZeroBytes(data)
 	err = entry.key.Decode(data)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,265 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package remote
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1/vrf/secp256k1VRF"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// The signing service keeps the keys on a hardened host. The node posts the
+// requests in json to its https endpoint with a bearer token, optionally over
+// mutual tls, and the service approves or denies each of them, e.g. after an
+// operator reviews the metadata of the request. The replies are checked
+// against the public key of the account, a service signing by other keys is
+// not trusted.
+const (
+	// MethodPublicKey returns the public key of the account
+	MethodPublicKey = "public_key"
+
+	// MethodSignHash signs the hash by the account
+	MethodSignHash = "sign_hash"
+
+	// MethodRandomSeed evaluates the VRF of the account for a new block
+	MethodRandomSeed = "random_seed"
+
+	// DefaultTimeout of a request, including the time the operator reviews it
+	DefaultTimeout = 30 * time.Second
+)
+
+// Kinds of the requests in the metadata
+const (
+	KindHash        = "hash"
+	KindTransaction = "transaction"
+	KindBlock       = "block"
+	KindRandomSeed  = "random_seed"
+)
+
+var (
+	// ErrInsecureEndpoint the endpoint of the signing service is not https
+	ErrInsecureEndpoint = errors.New("remote signer endpoint must be https")
+
+	// ErrRequestDenied the signing service denied the request
+	ErrRequestDenied = errors.New("request denied by remote signer")
+
+	// ErrInvalidReply the reply of the signing service is malformed or not
+	// signed by the key of the account
+	ErrInvalidReply = errors.New("invalid reply from remote signer")
+
+	// ErrKeyRemote the key is kept by the signing service
+	ErrKeyRemote = errors.New("private key is kept by remote signer")
+)
+
+// Metadata describes a request for the signing service to display before it
+// is approved, the hash alone tells the reviewer nothing.
+type Metadata struct {
+	Kind   string            `json:"kind"`
+	Fields map[string]string `json:"fields,omitempty"`
+}
+
+// Request a request to the signing service, the bytes are hex encoded.
+type Request struct {
+	Method       string    `json:"method"`
+	Address      string    `json:"address"`
+	Hash         string    `json:"hash,omitempty"`
+	AncestorHash string    `json:"ancestor_hash,omitempty"`
+	ParentSeed   string    `json:"parent_seed,omitempty"`
+	Metadata     *Metadata `json:"metadata,omitempty"`
+}
+
+// Reply the reply of the signing service, the bytes are hex encoded.
+type Reply struct {
+	Approved  bool   `json:"approved"`
+	Reason    string `json:"reason,omitempty"`
+	PublicKey string `json:"public_key,omitempty"`
+	Signature string `json:"signature,omitempty"`
+	VrfSeed   string `json:"vrf_seed,omitempty"`
+	VrfProof  string `json:"vrf_proof,omitempty"`
+}
+
+// Client talks with a signing service
+type Client struct {
+	endpoint string
+	token    string
+	client   *http.Client
+}
+
+// NewClient returns a client of the signing service at the https endpoint,
+// authenticated by the bearer token and the client certificate in tlsConfig
+// if any.
+func NewClient(endpoint, token string, tlsConfig *tls.Config) (*Client, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "https" {
+		return nil, ErrInsecureEndpoint
+	}
+	return &Client{
+		endpoint: endpoint,
+		token:    token,
+		client: &http.Client{
+			Timeout:   DefaultTimeout,
+			Transport: &http.Transport{TLSClientConfig: tlsConfig},
+		},
+	}, nil
+}
+
+// Endpoint returns the endpoint of the signing service
+func (c *Client) Endpoint() string {
+	return c.endpoint
+}
+
+// PublicKey returns the uncompressed secp256k1 public key of the account
+func (c *Client) PublicKey(address string) ([]byte, error) {
+	reply, err := c.call(&Request{Method: MethodPublicKey, Address: address})
+	if err != nil {
+		return nil, err
+	}
+	pub, err := hex.DecodeString(reply.PublicKey)
+	if err != nil {
+		return nil, ErrInvalidReply
+	}
+	if _, err := secp256k1.ToECDSAPublicKey(pub); err != nil {
+		return nil, ErrInvalidReply
+	}
+	return pub, nil
+}
+
+// SignHash asks the signing service to sign the hash by the account whose
+// public key is pub, the signature must recover pub.
+func (c *Client) SignHash(address string, pub, data []byte, meta *Metadata) ([]byte, error) {
+	reply, err := c.call(&Request{
+		Method:   MethodSignHash,
+		Address:  address,
+		Hash:     hex.EncodeToString(data),
+		Metadata: meta,
+	})
+	if err != nil {
+		return nil, err
+	}
+	sig, err := hex.DecodeString(reply.Signature)
+	if err != nil {
+		return nil, ErrInvalidReply
+	}
+	recovered, err := secp256k1.RecoverECDSAPublicKey(data, sig)
+	if err != nil || !bytes.Equal(recovered, pub) {
+		return nil, ErrInvalidReply
+	}
+	return sig, nil
+}
+
+// GenerateRandomSeed asks the signing service to evaluate the VRF of the
+// account whose public key is pub, the proof must be verified by pub.
+func (c *Client) GenerateRandomSeed(address string, pub, ancestorHash, parentSeed []byte, meta *Metadata) ([]byte, []byte, error) {
+	reply, err := c.call(&Request{
+		Method:       MethodRandomSeed,
+		Address:      address,
+		AncestorHash: hex.EncodeToString(ancestorHash),
+		ParentSeed:   hex.EncodeToString(parentSeed),
+		Metadata:     meta,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	seed, err := hex.DecodeString(reply.VrfSeed)
+	if err != nil {
+		return nil, nil, ErrInvalidReply
+	}
+	proof, err := hex.DecodeString(reply.VrfProof)
+	if err != nil {
+		return nil, nil, ErrInvalidReply
+	}
+
+	ecdsaPub, err := secp256k1.ToECDSAPublicKey(pub)
+	if err != nil {
+		return nil, nil, err
+	}
+	verifier, err := secp256k1VRF.NewVRFVerifier(ecdsaPub)
+	if err != nil {
+		return nil, nil, err
+	}
+	index, err := verifier.ProofToHash(hash.Sha3256(ancestorHash, parentSeed), proof)
+	if err != nil || !bytes.Equal(index[:], seed) {
+		return nil, nil, ErrInvalidReply
+	}
+	return seed, proof, nil
+}
+
+func (c *Client) call(req *Request) (*Reply, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	httpReq, err := http.NewRequest("POST", c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if len(c.token) > 0 {
+		httpReq.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		logging.VLog().WithFields(logrus.Fields{
+			"endpoint": c.endpoint,
+			"method":   req.Method,
+			"status":   resp.Status,
+		}).Debug("Remote signer failed the request.")
+		return nil, ErrInvalidReply
+	}
+
+	reply := new(Reply)
+	if err := json.Unmarshal(data, reply); err != nil {
+		return nil, ErrInvalidReply
+	}
+	if !reply.Approved {
+		logging.VLog().WithFields(logrus.Fields{
+			"endpoint": c.endpoint,
+			"method":   req.Method,
+			"address":  req.Address,
+			"reason":   reply.Reason,
+		}).Info("Remote signer denied the request.")
+		return nil, ErrRequestDenied
+	}
+	return reply, nil
+}
@@ -0,0 +1,146 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package remote
+
+import (
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1/vrf/secp256k1VRF"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockService signs by seckey, approving the requests approve returns true for.
+func mockService(t *testing.T, token string, seckey []byte, approve func(*Request) bool) *httptest.Server {
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		req := new(Request)
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(req))
+
+		reply := &Reply{Approved: approve(req), Reason: "denied by test"}
+		switch req.Method {
+		case MethodPublicKey:
+			pub, err := secp256k1.GetPublicKey(seckey)
+			assert.Nil(t, err)
+			reply.PublicKey = hex.EncodeToString(pub)
+		case MethodSignHash:
+			data, _ := hex.DecodeString(req.Hash)
+			sig, err := secp256k1.Sign(data, seckey)
+			assert.Nil(t, err)
+			reply.Signature = hex.EncodeToString(sig)
+		case MethodRandomSeed:
+			ancestorHash, _ := hex.DecodeString(req.AncestorHash)
+			parentSeed, _ := hex.DecodeString(req.ParentSeed)
+			signer, err := secp256k1VRF.NewVRFSignerFromRawKey(seckey)
+			assert.Nil(t, err)
+			seed, proof := signer.Evaluate(hash.Sha3256(ancestorHash, parentSeed))
+			reply.VrfSeed = hex.EncodeToString(seed[:])
+			reply.VrfProof = hex.EncodeToString(proof)
+		}
+		json.NewEncoder(w).Encode(reply)
+	}))
+}
+
+func mockClient(t *testing.T, srv *httptest.Server, token string) *Client {
+	client, err := NewClient(srv.URL, token, srv.Client().Transport.(*http.Transport).TLSClientConfig)
+	assert.Nil(t, err)
+	return client
+}
+
+func TestRemote_Signer(t *testing.T) {
+	seckey := secp256k1.NewSeckey()
+	var last *Request
+	srv := mockService(t, "token", seckey, func(req *Request) bool {
+		last = req
+		return true
+	})
+	defer srv.Close()
+
+	signer, err := NewSigner(mockClient(t, srv, "token"), "n1address")
+	assert.Nil(t, err)
+	pub, _ := secp256k1.GetPublicKey(seckey)
+	assert.Equal(t, pub, signer.PublicKey())
+
+	data := hash.Sha3256([]byte("data"))
+	meta := &Metadata{Kind: KindTransaction, Fields: map[string]string{"value": "1"}}
+	sig, err := signer.Signature(meta).Sign(data)
+	assert.Nil(t, err)
+	assert.Equal(t, meta, last.Metadata)
+	assert.Equal(t, "n1address", last.Address)
+	ok, err := secp256k1.Verify(data, sig, pub)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, ErrKeyRemote, signer.Signature(meta).InitSign(nil))
+
+	seed, proof, err := signer.GenerateRandomSeed([]byte("ancestor"), []byte("parent"))
+	assert.Nil(t, err)
+	assert.Equal(t, 32, len(seed))
+	assert.NotNil(t, proof)
+	assert.Equal(t, KindRandomSeed, last.Metadata.Kind)
+}
+
+func TestRemote_Denied(t *testing.T) {
+	seckey := secp256k1.NewSeckey()
+	srv := mockService(t, "token", seckey, func(req *Request) bool {
+		return req.Method == MethodPublicKey
+	})
+	defer srv.Close()
+
+	signer, err := NewSigner(mockClient(t, srv, "token"), "n1address")
+	assert.Nil(t, err)
+	_, err = signer.SignHash(hash.Sha3256([]byte("data")))
+	assert.Equal(t, ErrRequestDenied, err)
+
+	_, err = NewSigner(mockClient(t, srv, "wrong"), "n1address")
+	assert.Equal(t, ErrInvalidReply, err)
+
+	_, err = NewClient("http://127.0.0.1:8685", "token", nil)
+	assert.Equal(t, ErrInsecureEndpoint, err)
+
+	// the client trusts only the service's certificate.
+	client, err := NewClient(srv.URL, "token", &tls.Config{})
+	assert.Nil(t, err)
+	_, err = client.PublicKey("n1address")
+	assert.NotNil(t, err)
+}
+
+func TestRemote_WrongKey(t *testing.T) {
+	seckey := secp256k1.NewSeckey()
+	srv := mockService(t, "token", seckey, func(req *Request) bool { return true })
+	defer srv.Close()
+
+	signer, err := NewSigner(mockClient(t, srv, "token"), "n1address")
+	assert.Nil(t, err)
+
+	// the service signs by another key than the one of the account.
+	signer.pub, _ = secp256k1.GetPublicKey(secp256k1.NewSeckey())
+	_, err = signer.SignHash(hash.Sha3256([]byte("data")))
+	assert.Equal(t, ErrInvalidReply, err)
+	_, _, err = signer.GenerateRandomSeed([]byte("ancestor"), []byte("parent"))
+	assert.Equal(t, ErrInvalidReply, err)
+}
@@ -0,0 +1,85 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package remote
+
+import (
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
+)
+
+// Signer signs by an account kept by the signing service
+type Signer struct {
+	client  *Client
+	address string
+	pub     []byte
+}
+
+// NewSigner returns the signer of the account, the caller should check the
+// public key derives the address.
+func NewSigner(client *Client, address string) (*Signer, error) {
+	pub, err := client.PublicKey(address)
+	if err != nil {
+		return nil, err
+	}
+	return &Signer{client: client, address: address, pub: pub}, nil
+}
+
+// Address returns the address of the account
+func (s *Signer) Address() string {
+	return s.address
+}
+
+// PublicKey returns the public key of the account
+func (s *Signer) PublicKey() []byte {
+	return s.pub
+}
+
+// SignHash signs the hash with no metadata to display
+func (s *Signer) SignHash(hash []byte) ([]byte, error) {
+	return s.client.SignHash(s.address, s.pub, hash, &Metadata{Kind: KindHash})
+}
+
+// GenerateRandomSeed evaluates the VRF of the account for a new block
+func (s *Signer) GenerateRandomSeed(ancestorHash, parentSeed []byte) ([]byte, []byte, error) {
+	return s.client.GenerateRandomSeed(s.address, s.pub, ancestorHash, parentSeed, &Metadata{Kind: KindRandomSeed})
+}
+
+// Signature returns a signature signing by the account, the metadata is
+// displayed by the signing service.
+func (s *Signer) Signature(meta *Metadata) *Signature {
+	return &Signature{signer: s, meta: meta}
+}
+
+// Signature sign by the signing service, it's verified as a secp256k1 signature.
+type Signature struct {
+	secp256k1.Signature
+
+	signer *Signer
+	meta   *Metadata
+}
+
+// InitSign the key is kept by the signing service, no private key is needed
+func (s *Signature) InitSign(priv keystore.PrivateKey) error {
+	return ErrKeyRemote
+}
+
+// Sign sign the hash by the signing service
+func (s *Signature) Sign(data []byte) ([]byte, error) {
+	return s.signer.client.SignHash(s.signer.address, s.signer.pub, data, s.meta)
+}
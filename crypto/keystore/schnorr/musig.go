@@ -0,0 +1,371 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package schnorr
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+
+	"github.com/nebulasio/go-nebulas/crypto/utils"
+)
+
+// Multi-party signing in the MuSig2 style. The public keys of the signers are
+// aggregated into one key with a coefficient per key, which defeats rogue key
+// attacks without proofs of possession. Every signer publishes two nonce
+// points in the first round, and signs in the second round once all the
+// nonces of the session are aggregated. The partial signatures add up to a
+// plain signature verified by Verify with the aggregated key.
+//
+// A secret nonce must sign at most once, PartialSign zeroes it after use.
+const (
+	// SecretNonceLength the two nonce scalars of a signer
+	SecretNonceLength = 64
+
+	// PublicNonceLength the two compressed nonce points of a signer
+	PublicNonceLength = 66
+
+	// PartialSignatureLength a partial signature scalar
+	PartialSignatureLength = 32
+)
+
+// tags of the hashes of the aggregation
+const (
+	tagKeyAggList  = "KeyAgg list"
+	tagKeyAggCoeff = "KeyAgg coefficient"
+	tagMusigNonce  = "MuSig/nonce"
+	tagNonceCoeff  = "MuSig/noncecoef"
+)
+
+var (
+	// ErrEmptyAggregation nothing to aggregate
+	ErrEmptyAggregation = errors.New("nothing to aggregate")
+
+	// ErrDuplicatePublicKey the same public key is aggregated twice
+	ErrDuplicatePublicKey = errors.New("duplicate public key in aggregation")
+
+	// ErrSignerNotFound the public key of the signer is not in the aggregation
+	ErrSignerNotFound = errors.New("signer not found in aggregation")
+
+	// ErrInvalidPublicNonce invalid public nonce
+	ErrInvalidPublicNonce = errors.New("invalid public nonce")
+
+	// ErrInvalidSecretNonce invalid or used secret nonce
+	ErrInvalidSecretNonce = errors.New("invalid or used secret nonce")
+
+	// ErrInvalidPartialSignature invalid partial signature
+	ErrInvalidPartialSignature = errors.New("invalid partial signature")
+)
+
+// keyAgg the aggregation of the public keys of the signers
+type keyAgg struct {
+	pubs   [][]byte
+	coeffs []*big.Int
+
+	// x, y the aggregated point
+	x, y *big.Int
+}
+
+func aggregate(pubs [][]byte) (*keyAgg, error) {
+	if len(pubs) == 0 {
+		return nil, ErrEmptyAggregation
+	}
+	seen := make(map[string]bool, len(pubs))
+	for _, pub := range pubs {
+		if seen[string(pub)] {
+			return nil, ErrDuplicatePublicKey
+		}
+		seen[string(pub)] = true
+	}
+
+	list := taggedHash(tagKeyAggList, pubs...)
+	agg := &keyAgg{pubs: pubs, coeffs: make([]*big.Int, len(pubs))}
+	for i, pub := range pubs {
+		px, py, err := liftX(pub)
+		if err != nil {
+			return nil, err
+		}
+		agg.coeffs[i] = scalar(taggedHash(tagKeyAggCoeff, list, pub))
+		ax, ay := mul(px, py, agg.coeffs[i])
+		agg.x, agg.y = add(agg.x, agg.y, ax, ay)
+	}
+	if agg.x == nil {
+		return nil, ErrInvalidPublicKey
+	}
+	return agg, nil
+}
+
+// index returns the index of the public key in the aggregation, -1 if absent
+func (agg *keyAgg) index(pub []byte) int {
+	for i, p := range agg.pubs {
+		if bytes.Equal(p, pub) {
+			return i
+		}
+	}
+	return -1
+}
+
+// AggregatePublicKeys aggregates the x-only public keys of the signers in
+// the order given, every signer must use the same order.
+func AggregatePublicKeys(pubs [][]byte) ([]byte, error) {
+	agg, err := aggregate(pubs)
+	if err != nil {
+		return nil, err
+	}
+	return bytes32(agg.x), nil
+}
+
+// GenerateNonce returns a fresh secret nonce of the signer for the message
+// and its public nonce to publish, the aggregated key binds the nonce to
+// the session.
+func GenerateNonce(priv []byte, aggPub []byte, data []byte) ([]byte, []byte, error) {
+	if _, err := secretScalar(priv); err != nil {
+		return nil, nil, err
+	}
+	rand := utils.RandomCSPRNG(32)
+	defer utils.ZeroBytes(rand)
+
+	secnonce := make([]byte, SecretNonceLength)
+	pubnonce := make([]byte, 0, PublicNonceLength)
+	for i := 0; i < 2; i++ {
+		k := scalar(taggedHash(tagMusigNonce, rand, priv, aggPub, data, []byte{byte(i)}))
+		if k.Sign() == 0 {
+			return nil, nil, ErrInvalidNonce
+		}
+		copy(secnonce[32*i:], bytes32(k))
+		pubnonce = append(pubnonce, compress(baseMul(k))...)
+	}
+	return secnonce, pubnonce, nil
+}
+
+// AggregateNonces adds up the public nonces of the signers
+func AggregateNonces(pubnonces [][]byte) ([]byte, error) {
+	if len(pubnonces) == 0 {
+		return nil, ErrEmptyAggregation
+	}
+	var x1, y1, x2, y2 *big.Int
+	for _, pubnonce := range pubnonces {
+		rx1, ry1, rx2, ry2, err := parseNonce(pubnonce)
+		if err != nil {
+			return nil, err
+		}
+		x1, y1 = add(x1, y1, rx1, ry1)
+		x2, y2 = add(x2, y2, rx2, ry2)
+	}
+	if x1 == nil || x2 == nil {
+		return nil, ErrInvalidPublicNonce
+	}
+	return append(compress(x1, y1), compress(x2, y2)...), nil
+}
+
+// Session a signing session of a message by the aggregated key with the
+// aggregated nonce.
+type Session struct {
+	agg  *keyAgg
+	data []byte
+
+	// b the coefficient of the second nonces
+	b *big.Int
+
+	// e the challenge
+	e *big.Int
+
+	// r the x coordinate of the final nonce point
+	r []byte
+
+	// negNonce the final nonce point has odd y, the nonces are negated
+	negNonce bool
+
+	// negKey the aggregated point has odd y, the keys are negated
+	negKey bool
+}
+
+// NewSession starts a signing session of the data by the public keys of the
+// signers, once the nonces of all the signers are aggregated.
+func NewSession(pubs [][]byte, aggnonce []byte, data []byte) (*Session, error) {
+	agg, err := aggregate(pubs)
+	if err != nil {
+		return nil, err
+	}
+	x1, y1, x2, y2, err := parseNonce(aggnonce)
+	if err != nil {
+		return nil, err
+	}
+	aggPub := bytes32(agg.x)
+	b := scalar(taggedHash(tagNonceCoeff, aggnonce, aggPub, data))
+
+	// R = R1 + b*R2, it's the generator in the negligible case of infinity
+	bx, by := mul(x2, y2, b)
+	rx, ry := add(x1, y1, bx, by)
+	if rx == nil {
+		rx, ry = curve.Gx, curve.Gy
+	}
+	r := bytes32(rx)
+	return &Session{
+		agg:      agg,
+		data:     data,
+		b:        b,
+		e:        scalar(taggedHash(tagChallenge, r, aggPub, data)),
+		r:        r,
+		negNonce: !hasEvenY(ry),
+		negKey:   !hasEvenY(agg.y),
+	}, nil
+}
+
+// PublicKey returns the aggregated public key of the session
+func (s *Session) PublicKey() []byte {
+	return bytes32(s.agg.x)
+}
+
+// PartialSign returns the partial signature of the signer with its secret
+// nonce of the session, the secret nonce is zeroed.
+func (s *Session) PartialSign(secnonce []byte, priv []byte) ([]byte, error) {
+	if len(secnonce) != SecretNonceLength {
+		return nil, ErrInvalidSecretNonce
+	}
+	defer utils.ZeroBytes(secnonce)
+
+	k1 := new(big.Int).SetBytes(secnonce[:32])
+	k2 := new(big.Int).SetBytes(secnonce[32:])
+	if k1.Sign() == 0 || k2.Sign() == 0 || k1.Cmp(curve.N) >= 0 || k2.Cmp(curve.N) >= 0 {
+		return nil, ErrInvalidSecretNonce
+	}
+	d, pub, err := keyPair(priv)
+	if err != nil {
+		return nil, err
+	}
+	i := s.agg.index(pub)
+	if i < 0 {
+		return nil, ErrSignerNotFound
+	}
+
+	// k = k1 + b*k2, negated with the final nonce point
+	k := new(big.Int).Mul(s.b, k2)
+	k.Add(k, k1)
+	if s.negNonce {
+		k.Neg(k)
+	}
+	// d*a*e, negated with the aggregated point
+	x := new(big.Int).Mul(d, s.agg.coeffs[i])
+	x.Mul(x, s.e)
+	if s.negKey {
+		x.Neg(x)
+	}
+	return bytes32(k.Add(k, x).Mod(k, curve.N)), nil
+}
+
+// PartialVerify verify the partial signature of the signer of the public key
+// and the public nonce.
+func (s *Session) PartialVerify(partial []byte, pubnonce []byte, pub []byte) (bool, error) {
+	if len(partial) != PartialSignatureLength {
+		return false, ErrInvalidPartialSignature
+	}
+	sig := new(big.Int).SetBytes(partial)
+	if sig.Cmp(curve.N) >= 0 {
+		return false, nil
+	}
+	i := s.agg.index(pub)
+	if i < 0 {
+		return false, ErrSignerNotFound
+	}
+	x1, y1, x2, y2, err := parseNonce(pubnonce)
+	if err != nil {
+		return false, err
+	}
+	px, py, err := liftX(pub)
+	if err != nil {
+		return false, err
+	}
+
+	// s*G = R + e*a*P with the negations of the signing
+	bx, by := mul(x2, y2, s.b)
+	rx, ry := add(x1, y1, bx, by)
+	if s.negNonce {
+		rx, ry = negate(rx, ry)
+	}
+	c := new(big.Int).Mul(s.agg.coeffs[i], s.e)
+	if s.negKey {
+		c.Neg(c)
+	}
+	cx, cy := mul(px, py, c)
+	ex, ey := add(rx, ry, cx, cy)
+	sx, sy := baseMul(sig)
+	if sx == nil || ex == nil {
+		return sx == nil && ex == nil, nil
+	}
+	return sx.Cmp(ex) == 0 && sy.Cmp(ey) == 0, nil
+}
+
+// Aggregate adds up the partial signatures of all the signers into the
+// signature of the aggregated key.
+func (s *Session) Aggregate(partials [][]byte) ([]byte, error) {
+	if len(partials) == 0 {
+		return nil, ErrEmptyAggregation
+	}
+	sum := new(big.Int)
+	for _, partial := range partials {
+		if len(partial) != PartialSignatureLength {
+			return nil, ErrInvalidPartialSignature
+		}
+		v := new(big.Int).SetBytes(partial)
+		if v.Cmp(curve.N) >= 0 {
+			return nil, ErrInvalidPartialSignature
+		}
+		sum.Add(sum, v)
+	}
+	sum.Mod(sum, curve.N)
+	return append(append([]byte{}, s.r...), bytes32(sum)...), nil
+}
+
+// compress the 33 bytes compressed form of the point
+func compress(x, y *big.Int) []byte {
+	prefix := byte(0x02)
+	if !hasEvenY(y) {
+		prefix = 0x03
+	}
+	return append([]byte{prefix}, bytes32(x)...)
+}
+
+// decompress the point of the compressed form
+func decompress(data []byte) (*big.Int, *big.Int, error) {
+	if len(data) != 33 || (data[0] != 0x02 && data[0] != 0x03) {
+		return nil, nil, ErrInvalidPublicNonce
+	}
+	x, y, err := liftX(data[1:])
+	if err != nil {
+		return nil, nil, ErrInvalidPublicNonce
+	}
+	if data[0] == 0x03 {
+		x, y = negate(x, y)
+	}
+	return x, y, nil
+}
+
+func parseNonce(data []byte) (x1, y1, x2, y2 *big.Int, err error) {
+	if len(data) != PublicNonceLength {
+		return nil, nil, nil, nil, ErrInvalidPublicNonce
+	}
+	if x1, y1, err = decompress(data[:33]); err != nil {
+		return nil, nil, nil, nil, err
+	}
+	if x2, y2, err = decompress(data[33:]); err != nil {
+		return nil, nil, nil, nil, err
+	}
+	return x1, y1, x2, y2, nil
+}
@@ -0,0 +1,104 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package schnorr
+
+import (
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/crypto/utils"
+)
+
+// PrivateKey schnorr secp256k1 privatekey
+type PrivateKey struct {
+	seckey *utils.SecureBuffer
+}
+
+// GeneratePrivateKey generate a new schnorr private key
+func GeneratePrivateKey() *PrivateKey {
+	seckey := NewSeckey()
+	defer utils.ZeroBytes(seckey)
+	return &PrivateKey{seckey: utils.NewSecureBufferFrom(seckey)}
+}
+
+// Algorithm algorithm of key
+func (k *PrivateKey) Algorithm() keystore.Algorithm {
+	return keystore.SCHNORR
+}
+
+// Encoded encoded to byte, a copy the caller should zero after use
+func (k *PrivateKey) Encoded() ([]byte, error) {
+	return k.seckey.Copy(), nil
+}
+
+// Decode decode data to key
+func (k *PrivateKey) Decode(data []byte) error {
+	if _, err := secretScalar(data); err != nil {
+		return err
+	}
+	k.seckey.Destroy()
+	k.seckey = utils.NewSecureBufferFrom(data)
+	return nil
+}
+
+// Clear clear key content
+func (k *PrivateKey) Clear() {
+	k.seckey.Destroy()
+}
+
+// PublicKey returns publickey
+func (k *PrivateKey) PublicKey() keystore.PublicKey {
+	var pub []byte
+	err := k.seckey.Use(func(seckey []byte) (err error) {
+		pub, err = PublicKeyFromPrivate(seckey)
+		return err
+	})
+	if err != nil {
+		return nil
+	}
+	return NewPublicKey(pub)
+}
+
+// Sign sign data with privatekey
+func (k *PrivateKey) Sign(data []byte) ([]byte, error) {
+	var sig []byte
+	err := k.seckey.Use(func(seckey []byte) (err error) {
+		sig, err = Sign(data, seckey)
+		return err
+	})
+	return sig, err
+}
+
+// GenerateNonce returns a fresh secret nonce and its public nonce of a
+// multi-party signing of the data by the aggregated key.
+func (k *PrivateKey) GenerateNonce(aggPub []byte, data []byte) (secnonce []byte, pubnonce []byte, err error) {
+	err = k.seckey.Use(func(seckey []byte) (err error) {
+		secnonce, pubnonce, err = GenerateNonce(seckey, aggPub, data)
+		return err
+	})
+	return secnonce, pubnonce, err
+}
+
+// PartialSign returns the partial signature of the session with the secret nonce
+func (k *PrivateKey) PartialSign(session *Session, secnonce []byte) ([]byte, error) {
+	var partial []byte
+	err := k.seckey.Use(func(seckey []byte) (err error) {
+		partial, err = session.PartialSign(secnonce, seckey)
+		return err
+	})
+	return partial, err
+}
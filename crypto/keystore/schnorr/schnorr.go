@@ -0,0 +1,258 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package schnorr
+
+import (
+	"crypto/sha256"
+	"errors"
+	"math/big"
+
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1/bitelliptic"
+	"github.com/nebulasio/go-nebulas/crypto/utils"
+)
+
+// Signatures follow BIP-340 over secp256k1. Public keys are the x coordinates
+// of the points with even y, signatures are the x coordinate of the nonce
+// point and the scalar s, so that s*G = R + e*P.
+const (
+	// PrivateKeyLength private key length
+	PrivateKeyLength = 32
+
+	// PublicKeyLength x-only public key length
+	PublicKeyLength = 32
+
+	// SignatureLength signature length
+	SignatureLength = 64
+)
+
+// tags of the hashes, each hash is domain separated by its tag
+const (
+	tagAux       = "BIP0340/aux"
+	tagNonce     = "BIP0340/nonce"
+	tagChallenge = "BIP0340/challenge"
+)
+
+var (
+	// ErrInvalidPrivateKey invalid private key
+	ErrInvalidPrivateKey = errors.New("invalid private key")
+
+	// ErrInvalidPublicKey invalid public key
+	ErrInvalidPublicKey = errors.New("invalid public key")
+
+	// ErrInvalidSignature invalid signature
+	ErrInvalidSignature = errors.New("invalid signature")
+
+	// ErrInvalidNonce the nonce is zero, try again with other aux randomness
+	ErrInvalidNonce = errors.New("invalid nonce")
+
+	// ErrRecoverUnsupported public key can't be recovered from schnorr signatures
+	ErrRecoverUnsupported = errors.New("schnorr signatures can't recover the public key")
+)
+
+var curve = bitelliptic.S256()
+
+// taggedHash sha256(sha256(tag) || sha256(tag) || data...)
+func taggedHash(tag string, data ...[]byte) []byte {
+	t := sha256.Sum256([]byte(tag))
+	h := sha256.New()
+	h.Write(t[:])
+	h.Write(t[:])
+	for _, d := range data {
+		h.Write(d)
+	}
+	return h.Sum(nil)
+}
+
+// scalar the hash as a scalar mod n
+func scalar(data []byte) *big.Int {
+	return new(big.Int).Mod(new(big.Int).SetBytes(data), curve.N)
+}
+
+// bytes32 the big endian bytes of n padded to 32 bytes
+func bytes32(n *big.Int) []byte {
+	out := make([]byte, 32)
+	b := n.Bytes()
+	copy(out[32-len(b):], b)
+	return out
+}
+
+// add returns the sum of the points, nil x stands for the point at infinity.
+func add(x1, y1, x2, y2 *big.Int) (*big.Int, *big.Int) {
+	if x1 == nil {
+		return x2, y2
+	}
+	if x2 == nil {
+		return x1, y1
+	}
+	if x1.Cmp(x2) == 0 {
+		if y1.Cmp(y2) == 0 {
+			return curve.Double(x1, y1)
+		}
+		return nil, nil
+	}
+	return curve.Add(x1, y1, x2, y2)
+}
+
+// mul returns k*(x, y), nil x stands for the point at infinity.
+func mul(x, y *big.Int, k *big.Int) (*big.Int, *big.Int) {
+	k = new(big.Int).Mod(k, curve.N)
+	if x == nil || k.Sign() == 0 {
+		return nil, nil
+	}
+	return curve.ScalarMult(x, y, k.Bytes())
+}
+
+// baseMul returns k*G
+func baseMul(k *big.Int) (*big.Int, *big.Int) {
+	return mul(curve.Gx, curve.Gy, k)
+}
+
+// negate returns -(x, y)
+func negate(x, y *big.Int) (*big.Int, *big.Int) {
+	if x == nil {
+		return nil, nil
+	}
+	return x, new(big.Int).Sub(curve.P, y)
+}
+
+func hasEvenY(y *big.Int) bool {
+	return y.Bit(0) == 0
+}
+
+// liftX returns the point of the x coordinate with even y
+func liftX(data []byte) (*big.Int, *big.Int, error) {
+	if len(data) != PublicKeyLength {
+		return nil, nil, ErrInvalidPublicKey
+	}
+	x := new(big.Int).SetBytes(data)
+	if x.Cmp(curve.P) >= 0 {
+		return nil, nil, ErrInvalidPublicKey
+	}
+	// y^2 = x^3 + 7, p = 3 mod 4 so y = c^((p+1)/4)
+	c := new(big.Int).Exp(x, big.NewInt(3), curve.P)
+	c.Add(c, curve.B)
+	c.Mod(c, curve.P)
+	e := new(big.Int).Add(curve.P, big.NewInt(1))
+	e.Rsh(e, 2)
+	y := new(big.Int).Exp(c, e, curve.P)
+	if new(big.Int).Exp(y, big.NewInt(2), curve.P).Cmp(c) != 0 {
+		return nil, nil, ErrInvalidPublicKey
+	}
+	if !hasEvenY(y) {
+		y.Sub(curve.P, y)
+	}
+	return x, y, nil
+}
+
+// secretScalar the private key as a scalar in [1, n)
+func secretScalar(priv []byte) (*big.Int, error) {
+	if len(priv) != PrivateKeyLength {
+		return nil, ErrInvalidPrivateKey
+	}
+	d := new(big.Int).SetBytes(priv)
+	if d.Sign() == 0 || d.Cmp(curve.N) >= 0 {
+		return nil, ErrInvalidPrivateKey
+	}
+	return d, nil
+}
+
+// keyPair returns the private scalar negated if needed so that its point has
+// even y, and the x-only public key.
+func keyPair(priv []byte) (*big.Int, []byte, error) {
+	d, err := secretScalar(priv)
+	if err != nil {
+		return nil, nil, err
+	}
+	x, y := baseMul(d)
+	if !hasEvenY(y) {
+		d.Sub(curve.N, d)
+	}
+	return d, bytes32(x), nil
+}
+
+// NewSeckey returns a random private key
+func NewSeckey() []byte {
+	return secp256k1.NewSeckey()
+}
+
+// PublicKeyFromPrivate returns the x-only public key of the private key
+func PublicKeyFromPrivate(priv []byte) ([]byte, error) {
+	_, pub, err := keyPair(priv)
+	return pub, err
+}
+
+// Sign sign the data with the private key and fresh aux randomness
+func Sign(data []byte, priv []byte) ([]byte, error) {
+	return sign(data, priv, utils.RandomCSPRNG(32))
+}
+
+func sign(data []byte, priv []byte, aux []byte) ([]byte, error) {
+	d, pub, err := keyPair(priv)
+	if err != nil {
+		return nil, err
+	}
+
+	t := bytes32(d)
+	defer utils.ZeroBytes(t)
+	for i, b := range taggedHash(tagAux, aux) {
+		t[i] ^= b
+	}
+	k := scalar(taggedHash(tagNonce, t, pub, data))
+	if k.Sign() == 0 {
+		return nil, ErrInvalidNonce
+	}
+	rx, ry := baseMul(k)
+	if !hasEvenY(ry) {
+		k.Sub(curve.N, k)
+	}
+	r := bytes32(rx)
+	e := scalar(taggedHash(tagChallenge, r, pub, data))
+
+	s := new(big.Int).Mul(e, d)
+	s.Add(s, k)
+	s.Mod(s, curve.N)
+	return append(r, bytes32(s)...), nil
+}
+
+// Verify verify the signature of the data with the x-only public key
+func Verify(data []byte, signature []byte, pub []byte) (bool, error) {
+	px, py, err := liftX(pub)
+	if err != nil {
+		return false, err
+	}
+	if len(signature) != SignatureLength {
+		return false, ErrInvalidSignature
+	}
+	r := new(big.Int).SetBytes(signature[:32])
+	s := new(big.Int).SetBytes(signature[32:])
+	if r.Cmp(curve.P) >= 0 || s.Cmp(curve.N) >= 0 {
+		return false, nil
+	}
+	e := scalar(taggedHash(tagChallenge, signature[:32], pub, data))
+
+	// R = s*G - e*P
+	sx, sy := baseMul(s)
+	ex, ey := negate(mul(px, py, e))
+	rx, ry := add(sx, sy, ex, ey)
+	if rx == nil || !hasEvenY(ry) || rx.Cmp(r) != 0 {
+		return false, nil
+	}
+	return true, nil
+}
@@ -0,0 +1,177 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package schnorr
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+	"github.com/stretchr/testify/assert"
+)
+
+func decodeHex(t *testing.T, s string) []byte {
+	data, err := hex.DecodeString(s)
+	assert.Nil(t, err)
+	return data
+}
+
+// test vectors of BIP-340
+func TestSignVectors(t *testing.T) {
+	tests := []struct {
+		seckey, pub, aux, msg, sig string
+	}{
+		{
+			"0000000000000000000000000000000000000000000000000000000000000003",
+			"F9308A019258C31049344F85F89D5229B531C845836F99B08601F113BCE036F9",
+			"0000000000000000000000000000000000000000000000000000000000000000",
+			"0000000000000000000000000000000000000000000000000000000000000000",
+			"E907831F80848D1069A5371B402410364BDF1C5F8307B0084C55F1CE2DCA821525F66A4A85EA8B71E482A74F382D2CE5EBEEE8FDB2172F477DF4900D310536C0",
+		},
+		{
+			"B7E151628AED2A6ABF7158809CF4F3C762E7160F38B4DA56A784D9045190CFEF",
+			"DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+			"0000000000000000000000000000000000000000000000000000000000000001",
+			"243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+			"6896BD60EEAE296DB48A229FF71DFE071BDE413E6D43F917DC8DCF8C78DE33418906D11AC976ABCCB20B091292BFF4EA897EFCB639EA871CFA95F6DE339E4B0A",
+		},
+	}
+	for _, tt := range tests {
+		seckey := decodeHex(t, tt.seckey)
+		pub, err := PublicKeyFromPrivate(seckey)
+		assert.Nil(t, err)
+		assert.Equal(t, decodeHex(t, tt.pub), pub)
+
+		msg := decodeHex(t, tt.msg)
+		sig, err := sign(msg, seckey, decodeHex(t, tt.aux))
+		assert.Nil(t, err)
+		assert.Equal(t, decodeHex(t, tt.sig), sig)
+
+		ok, err := Verify(msg, sig, pub)
+		assert.Nil(t, err)
+		assert.True(t, ok)
+	}
+}
+
+func TestSignVerify(t *testing.T) {
+	priv := GeneratePrivateKey()
+	pub := priv.PublicKey()
+	data := hash.Sha3256([]byte("schnorr"))
+
+	signature := new(Signature)
+	assert.Nil(t, signature.InitSign(priv))
+	sig, err := signature.Sign(data)
+	assert.Nil(t, err)
+	assert.Equal(t, SignatureLength, len(sig))
+
+	assert.Nil(t, signature.InitVerify(pub))
+	ok, err := signature.Verify(data, sig)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	ok, err = signature.Verify(hash.Sha3256([]byte("other")), sig)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	sig[63] ^= 0x01
+	ok, err = signature.Verify(data, sig)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	_, err = signature.RecoverPublic(data, sig)
+	assert.Equal(t, ErrRecoverUnsupported, err)
+
+	encoded, err := priv.Encoded()
+	assert.Nil(t, err)
+	decoded := new(PrivateKey)
+	assert.Nil(t, decoded.Decode(encoded))
+	assert.Equal(t, pub, decoded.PublicKey())
+
+	assert.Equal(t, ErrInvalidPrivateKey, decoded.Decode(make([]byte, PrivateKeyLength)))
+	assert.NotNil(t, new(PublicKey).Decode(make([]byte, PublicKeyLength-1)))
+}
+
+func TestMusig(t *testing.T) {
+	data := hash.Sha3256([]byte("musig"))
+	privs := make([]*PrivateKey, 3)
+	pubs := make([][]byte, len(privs))
+	for i := range privs {
+		privs[i] = GeneratePrivateKey()
+		pubs[i], _ = privs[i].PublicKey().Encoded()
+	}
+	aggPub, err := AggregatePublicKeys(pubs)
+	assert.Nil(t, err)
+
+	// round 1, the signers publish their nonces.
+	secnonces := make([][]byte, len(privs))
+	pubnonces := make([][]byte, len(privs))
+	for i, priv := range privs {
+		secnonces[i], pubnonces[i], err = priv.GenerateNonce(aggPub, data)
+		assert.Nil(t, err)
+	}
+	aggnonce, err := AggregateNonces(pubnonces)
+	assert.Nil(t, err)
+
+	// round 2, the signers sign the session.
+	session, err := NewSession(pubs, aggnonce, data)
+	assert.Nil(t, err)
+	assert.Equal(t, aggPub, session.PublicKey())
+	partials := make([][]byte, len(privs))
+	for i, priv := range privs {
+		partials[i], err = priv.PartialSign(session, secnonces[i])
+		assert.Nil(t, err)
+
+		ok, err := session.PartialVerify(partials[i], pubnonces[i], pubs[i])
+		assert.Nil(t, err)
+		assert.True(t, ok)
+	}
+	ok, err := session.PartialVerify(partials[0], pubnonces[1], pubs[1])
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	// the secret nonces are used up.
+	_, err = privs[0].PartialSign(session, secnonces[0])
+	assert.Equal(t, ErrInvalidSecretNonce, err)
+
+	sig, err := session.Aggregate(partials)
+	assert.Nil(t, err)
+	ok, err = Verify(data, sig, aggPub)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	// missing a partial signature.
+	sig, err = session.Aggregate(partials[:2])
+	assert.Nil(t, err)
+	ok, err = Verify(data, sig, aggPub)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	// the aggregated key depends on the order and the set of the keys.
+	other, err := AggregatePublicKeys([][]byte{pubs[1], pubs[0], pubs[2]})
+	assert.Nil(t, err)
+	assert.NotEqual(t, aggPub, other)
+	_, err = AggregatePublicKeys([][]byte{pubs[0], pubs[0]})
+	assert.Equal(t, ErrDuplicatePublicKey, err)
+
+	outsider := GeneratePrivateKey()
+	secnonce, _, err := outsider.GenerateNonce(aggPub, data)
+	assert.Nil(t, err)
+	_, err = outsider.PartialSign(session, secnonce)
+	assert.Equal(t, ErrSignerNotFound, err)
+}
@@ -0,0 +1,79 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package schnorr
+
+import (
+	"errors"
+
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+)
+
+// Signature signature schnorr secp256k1
+type Signature struct {
+	privateKey *PrivateKey
+
+	publicKey *PublicKey
+}
+
+// Algorithm schnorr algorithm
+func (s *Signature) Algorithm() keystore.Algorithm {
+	return keystore.SCHNORR
+}
+
+// InitSign schnorr init sign
+func (s *Signature) InitSign(priv keystore.PrivateKey) error {
+	key, ok := priv.(*PrivateKey)
+	if !ok {
+		return ErrInvalidPrivateKey
+	}
+	s.privateKey = key
+	return nil
+}
+
+// Sign schnorr sign
+func (s *Signature) Sign(data []byte) (out []byte, err error) {
+	if s.privateKey == nil {
+		return nil, errors.New("please get private key first")
+	}
+	return s.privateKey.Sign(data)
+}
+
+// RecoverPublic schnorr signatures don't carry the signer, the public key
+// must be known to verify them.
+func (s *Signature) RecoverPublic(data []byte, signature []byte) (keystore.PublicKey, error) {
+	return nil, ErrRecoverUnsupported
+}
+
+// InitVerify schnorr verify init
+func (s *Signature) InitVerify(pub keystore.PublicKey) error {
+	key, ok := pub.(*PublicKey)
+	if !ok {
+		return ErrInvalidPublicKey
+	}
+	s.publicKey = key
+	return nil
+}
+
+// Verify schnorr verify
+func (s *Signature) Verify(data []byte, signature []byte) (bool, error) {
+	if s.publicKey == nil {
+		return false, errors.New("please give public key first")
+	}
+	return s.publicKey.Verify(data, signature)
+}
@@ -0,0 +1,98 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package secp256k1
+
+import (
+	"bytes"
+)
+
+// Two ECDSA signatures sharing the nonce k share r as well. Of the same key
+// over different hashes they leak the private key, of different keys they
+// show the signers drew k from broken randomness. The deterministic nonces
+// of RFC 6979 never repeat for different hashes.
+
+// NonceUse a signature of the signer over the hash
+type NonceUse struct {
+	Signer    string
+	Hash      []byte
+	Signature []byte
+}
+
+// R returns the r of the signature, the x coordinate of the nonce point
+func (u *NonceUse) R() []byte {
+	return u.Signature[:32]
+}
+
+// NonceReuse two signatures sharing the nonce
+type NonceReuse struct {
+	First  *NonceUse
+	Second *NonceUse
+}
+
+// SameSigner returns if both signatures are of the signer, whose private
+// key can be solved from them.
+func (r *NonceReuse) SameSigner() bool {
+	return r.First.Signer == r.Second.Signer
+}
+
+// NonceAuditor detects the signatures sharing nonces among the signatures added
+type NonceAuditor struct {
+	seen   map[string]*NonceUse
+	reuses []*NonceReuse
+	count  int
+}
+
+// NewNonceAuditor returns an empty nonce auditor
+func NewNonceAuditor() *NonceAuditor {
+	return &NonceAuditor{seen: make(map[string]*NonceUse)}
+}
+
+// Add add the signature of the signer over the hash, it returns the reuse if
+// a signature added before shares the nonce.
+func (a *NonceAuditor) Add(signer string, hash []byte, signature []byte) (*NonceReuse, error) {
+	if len(signature) != 65 {
+		return nil, ErrInvalidSignature
+	}
+	a.count++
+
+	use := &NonceUse{Signer: signer, Hash: hash, Signature: signature}
+	r := string(use.R())
+	prev, ok := a.seen[r]
+	if !ok {
+		a.seen[r] = use
+		return nil, nil
+	}
+	// the same hash signed by the same signer again, e.g. a block seen twice.
+	if prev.Signer == signer && bytes.Equal(prev.Hash, hash) {
+		return nil, nil
+	}
+	reuse := &NonceReuse{First: prev, Second: use}
+	a.reuses = append(a.reuses, reuse)
+	return reuse, nil
+}
+
+// Count returns the number of the signatures added
+func (a *NonceAuditor) Count() int {
+	return a.count
+}
+
+// Reuses returns the reuses detected
+func (a *NonceAuditor) Reuses() []*NonceReuse {
+	return a.reuses
+}
@@ -0,0 +1,153 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package secp256k1
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+)
+
+const (
+	// minBatchSplit ranges not longer than it are verified sequentially
+	minBatchSplit = 16
+)
+
+var (
+	// VerifyBatchParallelNum num of workers verifying a batch of signatures
+	VerifyBatchParallelNum = runtime.NumCPU()
+
+	// ErrBatchLengthMismatch the counts of public keys, hashes and signatures differ
+	ErrBatchLengthMismatch = errors.New("mismatched batch length")
+)
+
+type batch struct {
+	pubkeys [][]byte
+	hashes  [][]byte
+	sigs    [][]byte
+
+	workers chan struct{}
+
+	mutex  sync.Mutex
+	failed int
+	err    error
+}
+
+// VerifyBatch verify the signatures of the hashes with the public keys. A nil
+// public key is recovered from the signature and filled back, so that the
+// caller can match it with the expected signer.
+// The batch is bisected into halves verified in parallel, and the ranges
+// behind a known invalid signature are aborted. It returns the index of the
+// first invalid signature with its error, or -1 if all of them are valid.
+func VerifyBatch(pubkeys, hashes, sigs [][]byte) (int, error) {
+	if len(pubkeys) != len(hashes) || len(hashes) != len(sigs) {
+		return -1, ErrBatchLengthMismatch
+	}
+
+	workers := VerifyBatchParallelNum
+	if workers < 1 {
+		workers = 1
+	}
+	b := &batch{
+		pubkeys: pubkeys,
+		hashes:  hashes,
+		sigs:    sigs,
+		workers: make(chan struct{}, workers-1),
+		failed:  len(sigs),
+	}
+	b.verify(0, len(sigs))
+
+	if b.err != nil {
+		return b.failed, b.err
+	}
+	return -1, nil
+}
+
+func (b *batch) verify(lo, hi int) {
+	if hi-lo <= minBatchSplit {
+		for i := lo; i < hi; i++ {
+			if b.aborted(i) {
+				return
+			}
+			if err := b.verifyOne(i); err != nil {
+				b.fail(i, err)
+				return
+			}
+		}
+		return
+	}
+
+	mid := lo + (hi-lo)/2
+	select {
+	case b.workers <- struct{}{}:
+		wg := new(sync.WaitGroup)
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-b.workers
+				wg.Done()
+			}()
+			b.verify(lo, mid)
+		}()
+		b.verify(mid, hi)
+		wg.Wait()
+	default:
+		// no idle worker, the upper half is skipped once the lower one fails.
+		b.verify(lo, mid)
+		b.verify(mid, hi)
+	}
+}
+
+func (b *batch) verifyOne(i int) error {
+	if len(b.sigs[i]) != 65 {
+		return ErrInvalidSignature
+	}
+	if len(b.pubkeys[i]) == 0 {
+		pub, err := RecoverECDSAPublicKey(b.hashes[i], b.sigs[i])
+		if err != nil {
+			return err
+		}
+		b.pubkeys[i] = pub
+		return nil
+	}
+	ok, err := Verify(b.hashes[i], b.sigs[i], b.pubkeys[i])
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// aborted returns true if an invalid signature before the index is found.
+func (b *batch) aborted(i int) bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.failed < i
+}
+
+func (b *batch) fail(i int, err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if i < b.failed {
+		b.failed = i
+		b.err = err
+	}
+}
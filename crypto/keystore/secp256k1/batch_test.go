@@ -0,0 +1,76 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package secp256k1
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyBatch(t *testing.T) {
+	var (
+		pubkeys [][]byte
+		hashes  [][]byte
+		sigs    [][]byte
+	)
+	for i := 0; i < 100; i++ {
+		seckey := NewSeckey()
+		pub, err := GetPublicKey(seckey)
+		assert.Nil(t, err)
+		msg := hash.Sha3256(byteutils.FromUint64(uint64(i)))
+		sig, err := Sign(msg, seckey)
+		assert.Nil(t, err)
+		pubkeys = append(pubkeys, pub)
+		hashes = append(hashes, msg)
+		sigs = append(sigs, sig)
+	}
+
+	parallelNum := VerifyBatchParallelNum
+	defer func() { VerifyBatchParallelNum = parallelNum }()
+
+	for _, num := range []int{1, 4} {
+		VerifyBatchParallelNum = num
+		idx, err := VerifyBatch(pubkeys, hashes, sigs)
+		assert.Nil(t, err)
+		assert.Equal(t, -1, idx)
+
+		// the signers are recovered for nil public keys.
+		recovered := make([][]byte, len(sigs))
+		idx, err = VerifyBatch(recovered, hashes, sigs)
+		assert.Nil(t, err)
+		assert.Equal(t, -1, idx)
+		assert.Equal(t, pubkeys, recovered)
+	}
+
+	// the first invalid signature is reported by all workers.
+	sigs[37], sigs[38] = sigs[38], sigs[37]
+	sigs[80], sigs[81] = sigs[81], sigs[80]
+	for _, num := range []int{1, 4} {
+		VerifyBatchParallelNum = num
+		idx, err := VerifyBatch(pubkeys, hashes, sigs)
+		assert.Equal(t, ErrInvalidSignature, err)
+		assert.Equal(t, 37, idx)
+	}
+
+	_, err := VerifyBatch(pubkeys[1:], hashes, sigs)
+	assert.Equal(t, ErrBatchLengthMismatch, err)
+}
@@ -0,0 +1,153 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package secp256k1
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/big"
+)
+
+// ECIES encrypts the data to a secp256k1 public key as ethereum's ecies does:
+// the ephemeral public key || iv || AES-128-CTR ciphertext || HMAC-SHA256 tag.
+// The keys are derived from the ECDH shared secret by the NIST SP 800-56A
+// concatenation KDF, the first 16 bytes encrypt and the SHA256 of the other
+// 16 bytes authenticates.
+const (
+	eciesPublicKeyLength = 65
+	eciesKeyLength       = 16
+	eciesTagLength       = sha256.Size
+
+	// ECIESOverhead the length the ciphertext is longer than the plaintext
+	ECIESOverhead = eciesPublicKeyLength + aes.BlockSize + eciesTagLength
+)
+
+var (
+	// ErrInvalidCiphertext the ciphertext is malformed or not encrypted to the key
+	ErrInvalidCiphertext = errors.New("invalid ecies ciphertext")
+)
+
+// Encrypt encrypts the plaintext to the uncompressed public key pub.
+func Encrypt(pub, plaintext []byte) ([]byte, error) {
+	x, y := elliptic.Unmarshal(S256(), pub)
+	if x == nil {
+		return nil, ErrInvalidPublicKey
+	}
+
+	ephemeral, err := NewECDSAPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	defer zeroKey(ephemeral)
+	seckey, err := FromECDSAPrivateKey(ephemeral)
+	if err != nil {
+		return nil, err
+	}
+	ke, km := eciesKeys(S256().ScalarMult(x, y, seckey))
+
+	iv := make([]byte, aes.BlockSize)
+	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(ke)
+	if err != nil {
+		return nil, err
+	}
+
+	ct := make([]byte, ECIESOverhead+len(plaintext))
+	copy(ct, elliptic.Marshal(S256(), ephemeral.X, ephemeral.Y))
+	copy(ct[eciesPublicKeyLength:], iv)
+	body := ct[eciesPublicKeyLength+aes.BlockSize : len(ct)-eciesTagLength]
+	cipher.NewCTR(block, iv).XORKeyStream(body, plaintext)
+	copy(ct[len(ct)-eciesTagLength:], eciesTag(km, ct[eciesPublicKeyLength:len(ct)-eciesTagLength]))
+	return ct, nil
+}
+
+// Decrypt decrypts the ciphertext encrypted to the public key of seckey.
+func Decrypt(seckey, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < ECIESOverhead || ciphertext[0] != 4 {
+		return nil, ErrInvalidCiphertext
+	}
+	x, y := elliptic.Unmarshal(S256(), ciphertext[:eciesPublicKeyLength])
+	if x == nil {
+		return nil, ErrInvalidCiphertext
+	}
+	ke, km := eciesKeys(S256().ScalarMult(x, y, seckey))
+
+	tagged := ciphertext[eciesPublicKeyLength : len(ciphertext)-eciesTagLength]
+	if subtle.ConstantTimeCompare(eciesTag(km, tagged), ciphertext[len(ciphertext)-eciesTagLength:]) != 1 {
+		return nil, ErrInvalidCiphertext
+	}
+	block, err := aes.NewCipher(ke)
+	if err != nil {
+		return nil, err
+	}
+	plaintext := make([]byte, len(tagged)-aes.BlockSize)
+	cipher.NewCTR(block, tagged[:aes.BlockSize]).XORKeyStream(plaintext, tagged[aes.BlockSize:])
+	return plaintext, nil
+}
+
+// Decrypt decrypts the ciphertext encrypted to the public key of the key.
+func (k *PrivateKey) Decrypt(ciphertext []byte) ([]byte, error) {
+	var plaintext []byte
+	err := k.seckey.Use(func(seckey []byte) (err error) {
+		plaintext, err = Decrypt(seckey, ciphertext)
+		return err
+	})
+	return plaintext, err
+}
+
+// eciesKeys derive the encryption key & the mac key from the shared point.
+func eciesKeys(x, _ *big.Int) ([]byte, []byte) {
+	z := paddedBigBytes(x, 32)
+	k := concatKDF(z, 2*eciesKeyLength)
+	km := sha256.Sum256(k[eciesKeyLength:])
+	return k[:eciesKeyLength], km[:]
+}
+
+// eciesTag the HMAC-SHA256 tag of the iv and the ciphertext.
+func eciesTag(km, data []byte) []byte {
+	mac := hmac.New(sha256.New, km)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// concatKDF NIST SP 800-56A concatenation KDF with SHA256.
+func concatKDF(z []byte, length int) []byte {
+	var (
+		counter [4]byte
+		out     []byte
+	)
+	for i := uint32(1); len(out) < length; i++ {
+		binary.BigEndian.PutUint32(counter[:], i)
+		h := sha256.New()
+		h.Write(counter[:])
+		h.Write(z)
+		out = h.Sum(out)
+	}
+	return out[:length]
+}
@@ -0,0 +1,56 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package secp256k1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestECIES(t *testing.T) {
+	priv := GeneratePrivateKey()
+	pub, err := priv.PublicKey().Encoded()
+	assert.Nil(t, err)
+
+	for _, plaintext := range [][]byte{{}, []byte("hello"), make([]byte, 1000)} {
+		ct, err := Encrypt(pub, plaintext)
+		assert.Nil(t, err)
+		assert.Equal(t, len(plaintext)+ECIESOverhead, len(ct))
+
+		pt, err := priv.Decrypt(ct)
+		assert.Nil(t, err)
+		assert.Equal(t, plaintext, pt)
+
+		ct[len(ct)-1] ^= 1
+		_, err = priv.Decrypt(ct)
+		assert.Equal(t, ErrInvalidCiphertext, err)
+	}
+
+	other := GeneratePrivateKey()
+	ct, err := Encrypt(pub, []byte("hello"))
+	assert.Nil(t, err)
+	_, err = other.Decrypt(ct)
+	assert.Equal(t, ErrInvalidCiphertext, err)
+
+	_, err = Encrypt([]byte{1, 2, 3}, []byte("hello"))
+	assert.Equal(t, ErrInvalidPublicKey, err)
+	_, err = priv.Decrypt(ct[:ECIESOverhead-1])
+	assert.Equal(t, ErrInvalidCiphertext, err)
+}
@@ -27,14 +27,15 @@ import (
 
 // PrivateKey ecdsa privatekey
 type PrivateKey struct {
-	seckey []byte
+	seckey *utils.SecureBuffer
 }
 
 // GeneratePrivateKey generate a new private key
 func GeneratePrivateKey() *PrivateKey {
 	priv := new(PrivateKey)
 	seckey := NewSeckey()
-	priv.seckey = seckey
+	priv.seckey = utils.NewSecureBufferFrom(seckey)
+	utils.ZeroBytes(seckey)
 	return priv
 }
 
@@ -43,28 +44,33 @@ func (k *PrivateKey) Algorithm() keystore.Algorithm {
 	return keystore.SECP256K1
 }
 
-// Encoded encoded to byte
+// Encoded encoded to byte, a copy the caller should zero after use
 func (k *PrivateKey) Encoded() ([]byte, error) {
-	return k.seckey, nil
+	return k.seckey.Copy(), nil
 }
 
-// Decode decode data to key
+// Decode decode data to key, data is copied into locked memory
 func (k *PrivateKey) Decode(data []byte) error {
 	if SeckeyVerify(data) == false {
 		return ErrInvalidPrivateKey
 	}
-	k.seckey = data
+	k.seckey.Destroy()
+	k.seckey = utils.NewSecureBufferFrom(data)
 	return nil
 }
 
 // Clear clear key content
 func (k *PrivateKey) Clear() {
-	utils.ZeroBytes(k.seckey)
+	k.seckey.Destroy()
 }
 
 // PublicKey returns publickey
 func (k *PrivateKey) PublicKey() keystore.PublicKey {
-	pub, err := GetPublicKey(k.seckey)
+	var pub []byte
+	err := k.seckey.Use(func(seckey []byte) (err error) {
+		pub, err = GetPublicKey(seckey)
+		return err
+	})
 	if err != nil {
 		logging.VLog().WithFields(logrus.Fields{
 			"err": err,
@@ -76,5 +82,10 @@ func (k *PrivateKey) PublicKey() keystore.PublicKey {
 
 // Sign sign hash with privatekey
 func (k *PrivateKey) Sign(hash []byte) ([]byte, error) {
-	return Sign(hash, k.seckey)
+	var sig []byte
+	err := k.seckey.Use(func(seckey []byte) (err error) {
+		sig, err = Sign(hash, seckey)
+		return err
+	})
+	return sig, err
 }
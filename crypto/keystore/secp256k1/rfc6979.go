@@ -0,0 +1,131 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package secp256k1
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"math/big"
+
+	"github.com/nebulasio/go-nebulas/crypto/utils"
+)
+
+// NonceMode how the nonce k of a signature is generated
+type NonceMode uint8
+
+const (
+	// NonceDeterministic k is derived from the private key and the hash by
+	// RFC 6979, signing the same hash twice gives the same signature and no
+	// randomness is needed.
+	NonceDeterministic NonceMode = iota
+
+	// NonceHedged k is derived by RFC 6979 with 32 bytes of fresh randomness
+	// as the additional data, it stays safe if the randomness is weak.
+	NonceHedged
+)
+
+// rfc6979 the HMAC-DRBG of RFC 6979 section 3.2 keyed as libsecp256k1 does,
+// by the private key, the hash and the optional additional data.
+type rfc6979 struct {
+	k, v  []byte
+	retry bool
+}
+
+func newRFC6979(seckey, hash, extra []byte) *rfc6979 {
+	data := make([]byte, 0, 96)
+	data = append(data, seckey...)
+	data = append(data, hash...)
+	data = append(data, extra...)
+
+	r := &rfc6979{k: make([]byte, 32), v: make([]byte, 32)}
+	for i := range r.v {
+		r.v[i] = 0x01
+	}
+	r.k = r.mac(r.k, r.v, []byte{0x00}, data)
+	r.v = r.mac(r.k, r.v)
+	r.k = r.mac(r.k, r.v, []byte{0x01}, data)
+	r.v = r.mac(r.k, r.v)
+	return r
+}
+
+func (r *rfc6979) mac(key []byte, data ...[]byte) []byte {
+	h := hmac.New(sha256.New, key)
+	for _, d := range data {
+		h.Write(d)
+	}
+	return h.Sum(nil)
+}
+
+func (r *rfc6979) generate() []byte {
+	if r.retry {
+		r.k = r.mac(r.k, r.v, []byte{0x00})
+		r.v = r.mac(r.k, r.v)
+	}
+	r.v = r.mac(r.k, r.v)
+	r.retry = true
+	return append([]byte{}, r.v...)
+}
+
+// NonceRFC6979 returns the nonce k of the signature of the hash by the
+// private key, with the optional 32 bytes of additional data of the hedged
+// mode. It is the nonce Sign uses, the caller should zero it after use.
+func NonceRFC6979(seckey, hash, extra []byte) ([]byte, error) {
+	if len(hash) != 32 {
+		return nil, ErrInvalidMsgLen
+	}
+	if !SeckeyVerify(seckey) {
+		return nil, ErrInvalidPrivateKey
+	}
+	if len(extra) != 0 && len(extra) != 32 {
+		return nil, ErrInvalidNonceData
+	}
+	n := S256().Params().N
+	drbg := newRFC6979(seckey, hash, extra)
+	for {
+		k := drbg.generate()
+		if v := new(big.Int).SetBytes(k); v.Sign() > 0 && v.Cmp(n) < 0 {
+			return k, nil
+		}
+	}
+}
+
+// VerifyDeterministic audits that the signature of the hash was signed by
+// the private key with the RFC 6979 nonce, i.e. in the deterministic mode
+// rather than with a random nonce.
+func VerifyDeterministic(hash, signature, seckey []byte) (bool, error) {
+	if len(signature) != 65 {
+		return false, ErrInvalidSignature
+	}
+	k, err := NonceRFC6979(seckey, hash, nil)
+	if err != nil {
+		return false, err
+	}
+	defer utils.ZeroBytes(k)
+
+	x, _ := S256().ScalarBaseMult(k)
+	r := new(big.Int).Mod(x, S256().Params().N)
+	if r.Cmp(new(big.Int).SetBytes(signature[:32])) != 0 {
+		return false, nil
+	}
+	pub, err := GetPublicKey(seckey)
+	if err != nil {
+		return false, err
+	}
+	return Verify(hash, signature, pub)
+}
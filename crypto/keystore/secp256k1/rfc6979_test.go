@@ -0,0 +1,130 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package secp256k1
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+	"github.com/stretchr/testify/assert"
+)
+
+// signWithK signs with the nonce k given, as a signer of broken randomness does
+func signWithK(hash, seckey []byte, k int64) []byte {
+	n := S256().Params().N
+	x, _ := S256().ScalarBaseMult(big.NewInt(k).Bytes())
+	r := new(big.Int).Mod(x, n)
+	s := new(big.Int).Mul(r, new(big.Int).SetBytes(seckey))
+	s.Add(s, new(big.Int).SetBytes(hash))
+	s.Mul(s, new(big.Int).ModInverse(big.NewInt(k), n))
+	s.Mod(s, n)
+
+	sig := make([]byte, 65)
+	rb, sb := r.Bytes(), s.Bytes()
+	copy(sig[32-len(rb):32], rb)
+	copy(sig[64-len(sb):64], sb)
+	return sig
+}
+
+func TestNonceRFC6979(t *testing.T) {
+	seckey := NewSeckey()
+	data := hash.Sha3256([]byte("rfc6979"))
+
+	// the nonce is the one libsecp256k1 signs with.
+	sig, err := Sign(data, seckey)
+	assert.Nil(t, err)
+	ok, err := VerifyDeterministic(data, sig, seckey)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	again, err := Sign(data, seckey)
+	assert.Nil(t, err)
+	assert.Equal(t, sig, again)
+
+	k1, err := NonceRFC6979(seckey, data, nil)
+	assert.Nil(t, err)
+	k2, err := NonceRFC6979(seckey, hash.Sha3256([]byte("other")), nil)
+	assert.Nil(t, err)
+	assert.NotEqual(t, k1, k2)
+
+	// hedged signatures differ and are not deterministic, but verify.
+	hedged, err := SignWithMode(data, seckey, NonceHedged)
+	assert.Nil(t, err)
+	assert.NotEqual(t, sig, hedged)
+	ok, err = VerifyDeterministic(data, hedged, seckey)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+	pub, err := GetPublicKey(seckey)
+	assert.Nil(t, err)
+	recovered, err := RecoverECDSAPublicKey(data, hedged)
+	assert.Nil(t, err)
+	assert.Equal(t, pub, recovered)
+
+	_, err = NonceRFC6979(seckey, data, []byte{0x01})
+	assert.Equal(t, ErrInvalidNonceData, err)
+	_, err = signWithNonceData(data, seckey, []byte{0x01})
+	assert.Equal(t, ErrInvalidNonceData, err)
+}
+
+func TestNonceAuditor(t *testing.T) {
+	alice, bob := NewSeckey(), NewSeckey()
+	h1 := hash.Sha3256([]byte("first"))
+	h2 := hash.Sha3256([]byte("second"))
+	h3 := hash.Sha3256([]byte("third"))
+
+	auditor := NewNonceAuditor()
+	for _, h := range [][]byte{h1, h2, h3} {
+		sig, err := Sign(h, alice)
+		assert.Nil(t, err)
+		reuse, err := auditor.Add("alice", h, sig)
+		assert.Nil(t, err)
+		assert.Nil(t, reuse)
+	}
+
+	// the same hash seen again is no reuse.
+	sig, err := Sign(h1, alice)
+	assert.Nil(t, err)
+	reuse, err := auditor.Add("alice", h1, sig)
+	assert.Nil(t, err)
+	assert.Nil(t, reuse)
+
+	// a weak signer repeats k.
+	reuse, err = auditor.Add("bob", h1, signWithK(h1, bob, 7))
+	assert.Nil(t, err)
+	assert.Nil(t, reuse)
+	reuse, err = auditor.Add("bob", h2, signWithK(h2, bob, 7))
+	assert.Nil(t, err)
+	assert.NotNil(t, reuse)
+	assert.True(t, reuse.SameSigner())
+	assert.Equal(t, h1, reuse.First.Hash)
+	assert.Equal(t, h2, reuse.Second.Hash)
+
+	// and across the keys.
+	reuse, err = auditor.Add("alice", h3, signWithK(h3, alice, 7))
+	assert.Nil(t, err)
+	assert.NotNil(t, reuse)
+	assert.False(t, reuse.SameSigner())
+
+	assert.Equal(t, 2, len(auditor.Reuses()))
+	assert.Equal(t, 7, auditor.Count())
+
+	_, err = auditor.Add("alice", h1, sig[:64])
+	assert.Equal(t, ErrInvalidSignature, err)
+}
@@ -69,6 +69,9 @@ var (
 
 	// ErrRecoverFailed recover failed
 	ErrRecoverFailed = errors.New("recovery failed")
+
+	// ErrInvalidNonceData invalid additional data of the nonce, need 32 bytes
+	ErrInvalidNonceData = errors.New("invalid nonce data, need 32 bytes")
 )
 
 var ctx *C.secp256k1_context
@@ -147,8 +150,21 @@ func RecoverECDSAPublicKey(msg []byte, signature []byte) ([]byte, error) {
 	return goBytes(output, C.int(outputLen)), nil
 }
 
-// Sign sign hash with private key
+// Sign sign hash with private key, the nonce is deterministic by RFC 6979
 func Sign(msg []byte, seckey []byte) ([]byte, error) {
+	return SignWithMode(msg, seckey, NonceDeterministic)
+}
+
+// SignWithMode sign hash with private key, the nonce is generated in the mode
+func SignWithMode(msg []byte, seckey []byte, mode NonceMode) ([]byte, error) {
+	var extra []byte
+	if mode == NonceHedged {
+		extra = utils.RandomCSPRNG(32)
+	}
+	return signWithNonceData(msg, seckey, extra)
+}
+
+func signWithNonceData(msg []byte, seckey []byte, extra []byte) ([]byte, error) {
 	if len(msg) != 32 {
 		return nil, ErrInvalidMsgLen
 	}
@@ -159,9 +175,16 @@ func Sign(msg []byte, seckey []byte) ([]byte, error) {
 
 	var (
 		noncefunc = C.secp256k1_nonce_function_rfc6979
+		noncedata unsafe.Pointer
 		sigstruct C.secp256k1_ecdsa_recoverable_signature
 	)
-	if C.secp256k1_ecdsa_sign_recoverable(ctx, &sigstruct, cBuf(msg), cBuf(seckey), noncefunc, nil) == 0 {
+	if len(extra) > 0 {
+		if len(extra) != 32 {
+			return nil, ErrInvalidNonceData
+		}
+		noncedata = unsafe.Pointer(&extra[0])
+	}
+	if C.secp256k1_ecdsa_sign_recoverable(ctx, &sigstruct, cBuf(msg), cBuf(seckey), noncefunc, noncedata) == 0 {
 		return nil, ErrSignFailed
 	}
 
@@ -0,0 +1,175 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package ed25519VRF
+
+import (
+	"math/big"
+)
+
+// The twisted Edwards curve -x^2 + y^2 = 1 + d*x^2*y^2 over GF(2^255-19),
+// the points are kept in the extended coordinates (X:Y:Z:T) with x = X/Z,
+// y = Y/Z and x*y = T/Z.
+
+var (
+	prime  = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(19))
+	order  = new(big.Int).Add(new(big.Int).Lsh(big.NewInt(1), 252), fromDecimal("27742317777372353535851937790883648493"))
+	curveD = mod(new(big.Int).Mul(big.NewInt(-121665), inverse(big.NewInt(121666))))
+	d2     = mod(new(big.Int).Lsh(curveD, 1))
+	sqrtM1 = new(big.Int).Exp(big.NewInt(2), new(big.Int).Rsh(new(big.Int).Sub(prime, big.NewInt(1)), 2), prime)
+	base   = func() *point {
+		y := mod(new(big.Int).Mul(big.NewInt(4), inverse(big.NewInt(5))))
+		p, _ := decodePoint(intToLE(y, 32))
+		return p
+	}()
+)
+
+type point struct {
+	x, y, z, t *big.Int
+}
+
+func fromDecimal(s string) *big.Int {
+	n, _ := new(big.Int).SetString(s, 10)
+	return n
+}
+
+func mod(n *big.Int) *big.Int {
+	return n.Mod(n, prime)
+}
+
+func inverse(n *big.Int) *big.Int {
+	return new(big.Int).Exp(n, new(big.Int).Sub(prime, big.NewInt(2)), prime)
+}
+
+func mul(a, b *big.Int) *big.Int {
+	return mod(new(big.Int).Mul(a, b))
+}
+
+// leToInt decodes a little-endian integer.
+func leToInt(b []byte) *big.Int {
+	be := make([]byte, len(b))
+	for i := range b {
+		be[len(b)-1-i] = b[i]
+	}
+	return new(big.Int).SetBytes(be)
+}
+
+// intToLE encodes a non-negative integer in size little-endian bytes.
+func intToLE(n *big.Int, size int) []byte {
+	be := n.Bytes()
+	le := make([]byte, size)
+	for i := 0; i < len(be) && i < size; i++ {
+		le[i] = be[len(be)-1-i]
+	}
+	return le
+}
+
+func identity() *point {
+	return &point{x: big.NewInt(0), y: big.NewInt(1), z: big.NewInt(1), t: big.NewInt(0)}
+}
+
+// add is the complete addition of the extended coordinates, add-2008-hwcd-3.
+func (p *point) add(q *point) *point {
+	a := mul(mod(new(big.Int).Sub(p.y, p.x)), mod(new(big.Int).Sub(q.y, q.x)))
+	b := mul(mod(new(big.Int).Add(p.y, p.x)), mod(new(big.Int).Add(q.y, q.x)))
+	c := mul(mul(p.t, d2), q.t)
+	d := mul(new(big.Int).Lsh(p.z, 1), q.z)
+	e := mod(new(big.Int).Sub(b, a))
+	f := mod(new(big.Int).Sub(d, c))
+	g := mod(new(big.Int).Add(d, c))
+	h := mod(new(big.Int).Add(b, a))
+	return &point{x: mul(e, f), y: mul(g, h), z: mul(f, g), t: mul(e, h)}
+}
+
+func (p *point) neg() *point {
+	return &point{
+		x: mod(new(big.Int).Neg(p.x)),
+		y: new(big.Int).Set(p.y),
+		z: new(big.Int).Set(p.z),
+		t: mod(new(big.Int).Neg(p.t)),
+	}
+}
+
+// scalarMult is the double-and-add from the most significant bit.
+func (p *point) scalarMult(k *big.Int) *point {
+	r := identity()
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		r = r.add(r)
+		if k.Bit(i) == 1 {
+			r = r.add(p)
+		}
+	}
+	return r
+}
+
+func scalarBaseMult(k *big.Int) *point {
+	return base.scalarMult(k)
+}
+
+func (p *point) mulByCofactor() *point {
+	return p.scalarMult(big.NewInt(8))
+}
+
+func (p *point) isIdentity() bool {
+	// x = 0 and y = z.
+	return p.x.Sign() == 0 && p.y.Cmp(p.z) == 0
+}
+
+// encode is the 32 bytes little-endian y with the sign of x in the top bit.
+func (p *point) encode() []byte {
+	zInv := inverse(p.z)
+	x := mul(p.x, zInv)
+	y := mul(p.y, zInv)
+	b := intToLE(y, 32)
+	b[31] |= byte(x.Bit(0) << 7)
+	return b
+}
+
+// decodePoint decodes an encoded point, the non-canonical encodings of y are rejected.
+func decodePoint(b []byte) (*point, bool) {
+	if len(b) != 32 {
+		return nil, false
+	}
+	yBytes := append([]byte{}, b...)
+	sign := uint(yBytes[31] >> 7)
+	yBytes[31] &= 0x7f
+	y := leToInt(yBytes)
+	if y.Cmp(prime) >= 0 {
+		return nil, false
+	}
+
+	// x^2 = (y^2 - 1) / (d*y^2 + 1)
+	yy := mul(y, y)
+	u := mod(new(big.Int).Sub(yy, big.NewInt(1)))
+	v := mod(new(big.Int).Add(mul(curveD, yy), big.NewInt(1)))
+	xx := mul(u, inverse(v))
+	x := new(big.Int).Exp(xx, new(big.Int).Rsh(new(big.Int).Add(prime, big.NewInt(3)), 3), prime)
+	if mul(x, x).Cmp(xx) != 0 {
+		x = mul(x, sqrtM1)
+	}
+	if mul(x, x).Cmp(xx) != 0 {
+		return nil, false
+	}
+	if x.Sign() == 0 && sign == 1 {
+		return nil, false
+	}
+	if x.Bit(0) != sign {
+		x = mod(new(big.Int).Neg(x))
+	}
+	return &point{x: x, y: y, z: big.NewInt(1), t: mul(x, y)}, true
+}
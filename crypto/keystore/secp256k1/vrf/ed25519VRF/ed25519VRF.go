@@ -0,0 +1,293 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+// Package ed25519VRF implements ECVRF-EDWARDS25519-SHA512-TAI of RFC 9381,
+// a verifiable random function over edwards25519.
+//
+// https://www.rfc-editor.org/rfc/rfc9381
+//
+// The keys are the ones of ed25519, the private key is the 32 bytes seed and
+// the public key the 32 bytes encoded point. The group arithmetic runs on
+// math/big and is not constant time, like the curve of the secp256k1 VRF.
+package ed25519VRF
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/sha512"
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1/vrf"
+)
+
+// Sizes of keys and proofs
+const (
+	SeedSize      = 32
+	PublicKeySize = 32
+	ProofSize     = 80
+	OutputSize    = 64
+)
+
+const suite = 0x03
+
+var (
+	// ErrInvalidVRF occurs when the VRF does not validate.
+	ErrInvalidVRF = errors.New("invalid VRF proof")
+	// ErrInvalidKey occurs when a key is not a valid ed25519 key.
+	ErrInvalidKey = errors.New("invalid ed25519 VRF key")
+	// ErrHashToCurve occurs when no counter hashes the input to a point.
+	ErrHashToCurve = errors.New("failed to hash the input to a curve point")
+)
+
+// PublicKey holds a public VRF key.
+type PublicKey struct {
+	encoded []byte
+	point   *point
+}
+
+// PrivateKey holds a private VRF key.
+type PrivateKey struct {
+	PublicKey
+	seed   []byte
+	scalar *big.Int
+	prefix []byte
+}
+
+// Proof is the proof of a VRF output, it is serialized to ProofSize bytes as
+// the encoded point Gamma, the 16 bytes challenge c and the 32 bytes scalar s.
+type Proof struct {
+	Gamma []byte
+	C     []byte
+	S     []byte
+}
+
+// Bytes serializes the proof.
+func (pi *Proof) Bytes() []byte {
+	buf := make([]byte, 0, ProofSize)
+	buf = append(buf, pi.Gamma...)
+	buf = append(buf, pi.C...)
+	return append(buf, pi.S...)
+}
+
+// DecodeProof deserializes a proof, the points and scalars it carries are
+// validated by ProofToHash.
+func DecodeProof(b []byte) (*Proof, error) {
+	if len(b) != ProofSize {
+		return nil, ErrInvalidVRF
+	}
+	return &Proof{
+		Gamma: append([]byte{}, b[:32]...),
+		C:     append([]byte{}, b[32:48]...),
+		S:     append([]byte{}, b[48:]...),
+	}, nil
+}
+
+// GenerateKey generates a fresh keypair for this VRF
+func GenerateKey() (vrf.PrivateKey, vrf.PublicKey) {
+	seed := make([]byte, SeedSize)
+	if _, err := io.ReadFull(rand.Reader, seed); err != nil {
+		return nil, nil
+	}
+	k, err := NewVRFSignerFromRawKey(seed)
+	if err != nil {
+		return nil, nil
+	}
+	return k, k.Public().(*PublicKey)
+}
+
+// NewVRFSignerFromRawKey returns the private key from the 32 bytes seed,
+// or the 64 bytes ed25519 private key made of the seed and the public key.
+func NewVRFSignerFromRawKey(b []byte) (vrf.PrivateKey, error) {
+	if len(b) != SeedSize && len(b) != SeedSize+PublicKeySize {
+		return nil, ErrInvalidKey
+	}
+	seed := append([]byte{}, b[:SeedSize]...)
+
+	h := sha512.Sum512(seed)
+	h[0] &= 248
+	h[31] &= 127
+	h[31] |= 64
+	scalar := leToInt(h[:32])
+	pub := scalarBaseMult(scalar)
+
+	k := &PrivateKey{
+		PublicKey: PublicKey{encoded: pub.encode(), point: pub},
+		seed:      seed,
+		scalar:    scalar,
+		prefix:    append([]byte{}, h[32:]...),
+	}
+	if len(b) == SeedSize+PublicKeySize && !bytes.Equal(b[SeedSize:], k.encoded) {
+		return nil, ErrInvalidKey
+	}
+	return k, nil
+}
+
+// NewVRFVerifierFromRawKey returns the public key from the 32 bytes encoded point.
+func NewVRFVerifierFromRawKey(b []byte) (vrf.PublicKey, error) {
+	pk, err := decodePublicKey(b)
+	if err != nil {
+		return nil, err
+	}
+	return pk, nil
+}
+
+func decodePublicKey(b []byte) (*PublicKey, error) {
+	p, ok := decodePoint(b)
+	// the keys of small order would let the prover pick the output.
+	if !ok || p.mulByCofactor().isIdentity() {
+		return nil, ErrInvalidKey
+	}
+	return &PublicKey{encoded: append([]byte{}, b...), point: p}, nil
+}
+
+// Public returns the corresponding public key.
+func (k *PrivateKey) Public() crypto.PublicKey {
+	return &k.PublicKey
+}
+
+// Bytes return the encoded public key.
+func (pk *PublicKey) Bytes() []byte {
+	return append([]byte{}, pk.encoded...)
+}
+
+// Prove returns the proof of the VRF evaluated at m.
+func (k *PrivateKey) Prove(m []byte) ([]byte, error) {
+	h, err := hashToCurve(k.encoded, m)
+	if err != nil {
+		return nil, err
+	}
+	hString := h.encode()
+	gamma := h.scalarMult(k.scalar)
+
+	// the nonce is derived from the key and the input as in RFC 8032.
+	nonce := sha512.New()
+	nonce.Write(k.prefix)
+	nonce.Write(hString)
+	kScalar := new(big.Int).Mod(leToInt(nonce.Sum(nil)), order)
+
+	c := challenge(k.encoded, hString, gamma.encode(), scalarBaseMult(kScalar).encode(), h.scalarMult(kScalar).encode())
+	s := new(big.Int).Mul(leToInt(c), k.scalar)
+	s.Add(s, kScalar)
+	s.Mod(s, order)
+
+	pi := &Proof{Gamma: gamma.encode(), C: c, S: intToLE(s, 32)}
+	return pi.Bytes(), nil
+}
+
+// Evaluate returns the first 32 bytes of the VRF output at m and its proof.
+func (k *PrivateKey) Evaluate(m []byte) (index [32]byte, proof []byte) {
+	nilIndex := [32]byte{}
+	proof, err := k.Prove(m)
+	if err != nil {
+		return nilIndex, nil
+	}
+	beta, err := proofToOutput(proof)
+	if err != nil {
+		return nilIndex, nil
+	}
+	copy(index[:], beta)
+	return index, proof
+}
+
+// Verify verifies the proof of m and returns the OutputSize bytes VRF output.
+func (pk *PublicKey) Verify(m, proof []byte) ([]byte, error) {
+	pi, err := DecodeProof(proof)
+	if err != nil {
+		return nil, err
+	}
+	gamma, ok := decodePoint(pi.Gamma)
+	if !ok {
+		return nil, ErrInvalidVRF
+	}
+	s := leToInt(pi.S)
+	if s.Cmp(order) >= 0 {
+		return nil, ErrInvalidVRF
+	}
+	c := leToInt(pi.C)
+
+	h, err := hashToCurve(pk.encoded, m)
+	if err != nil {
+		return nil, err
+	}
+
+	// U = [s]B - [c]Y, V = [s]H - [c]Gamma
+	u := scalarBaseMult(s).add(pk.point.scalarMult(c).neg())
+	v := h.scalarMult(s).add(gamma.scalarMult(c).neg())
+
+	if !bytes.Equal(challenge(pk.encoded, h.encode(), pi.Gamma, u.encode(), v.encode()), pi.C) {
+		return nil, ErrInvalidVRF
+	}
+	return proofToOutput(proof)
+}
+
+// ProofToHash verifies the proof of m and returns the first 32 bytes of the VRF output.
+func (pk *PublicKey) ProofToHash(m, proof []byte) (index [32]byte, err error) {
+	nilIndex := [32]byte{}
+	beta, err := pk.Verify(m, proof)
+	if err != nil {
+		return nilIndex, err
+	}
+	copy(index[:], beta)
+	return index, nil
+}
+
+// proofToOutput returns the VRF output carried by a proof, it must be verified before.
+func proofToOutput(proof []byte) ([]byte, error) {
+	if len(proof) != ProofSize {
+		return nil, ErrInvalidVRF
+	}
+	gamma, ok := decodePoint(proof[:32])
+	if !ok {
+		return nil, ErrInvalidVRF
+	}
+	h := sha512.New()
+	h.Write([]byte{suite, 0x03})
+	h.Write(gamma.mulByCofactor().encode())
+	h.Write([]byte{0x00})
+	return h.Sum(nil), nil
+}
+
+// hashToCurve is the try-and-increment method, the first counter whose hash
+// decodes to a point is taken, and the point is cleared of its cofactor.
+func hashToCurve(pk, m []byte) (*point, error) {
+	for ctr := 0; ctr < 256; ctr++ {
+		h := sha512.New()
+		h.Write([]byte{suite, 0x01})
+		h.Write(pk)
+		h.Write(m)
+		h.Write([]byte{byte(ctr), 0x00})
+		if p, ok := decodePoint(h.Sum(nil)[:32]); ok {
+			return p.mulByCofactor(), nil
+		}
+	}
+	return nil, ErrHashToCurve
+}
+
+func challenge(pk []byte, points ...[]byte) []byte {
+	h := sha512.New()
+	h.Write([]byte{suite, 0x02})
+	h.Write(pk)
+	for _, p := range points {
+		h.Write(p)
+	}
+	h.Write([]byte{0x00})
+	return h.Sum(nil)[:16]
+}
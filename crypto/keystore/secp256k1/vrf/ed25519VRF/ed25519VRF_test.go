@@ -0,0 +1,180 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package ed25519VRF
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1/vrf"
+)
+
+var (
+	_ vrf.PrivateKey = (*PrivateKey)(nil)
+	_ vrf.PublicKey  = (*PublicKey)(nil)
+)
+
+func h2b(h string) []byte {
+	b, err := hex.DecodeString(h)
+	if err != nil {
+		panic("Invalid hex")
+	}
+	return b
+}
+
+// the vectors of ECVRF-EDWARDS25519-SHA512-TAI in RFC 9381, appendix B.3.
+func TestVectors(t *testing.T) {
+	for _, tc := range []struct {
+		sk, pk, alpha, pi, beta string
+	}{
+		{
+			sk:    "9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60",
+			pk:    "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a",
+			alpha: "",
+			pi:    "8657106690b5526245a92b003bb079ccd1a92130477671f6fc01ad16f26f723f26f8a57ccaed74ee1b190bed1f479d9727d2d0f9b005a6e456a35d4fb0daab1268a1b0db10836d9826a528ca76567805",
+			beta:  "90cf1df3b703cce59e2a35b925d411164068269d7b2d29f3301c03dd757876ff66b71dda49d2de59d03450451af026798e8f81cd2e333de5cdf4f3e140fdd8ae",
+		},
+		{
+			sk:    "4ccd089b28ff96da9db6c346ec114e0f5b8a319f35aba624da8cf6ed4fb8a6fb",
+			pk:    "3d4017c3e843895a92b70aa74d1b7ebc9c982ccf2ec4968cc0cd55f12af4660c",
+			alpha: "72",
+			pi:    "f3141cd382dc42909d19ec5110469e4feae18300e94f304590abdced48aed5933bf0864a62558b3ed7f2fea45c92a465301b3bbf5e3e54ddf2d935be3b67926da3ef39226bbc355bdc9850112c8f4b02",
+			beta:  "eb4440665d3891d668e7e0fcaf587f1b4bd7fbfe99d0eb2211ccec90496310eb5e33821bc613efb94db5e5b54c70a848a0bef4553a41befc57663b56373a5031",
+		},
+		{
+			sk:    "c5aa8df43f9f837bedb7442f31dcb7b166d38535076f094b85ce3a2e0b4458f7",
+			pk:    "fc51cd8e6218a1a38da47ed00230f0580816ed13ba3303ac5deb911548908025",
+			alpha: "af82",
+			pi:    "9bc0f79119cc5604bf02d23b4caede71393cedfbb191434dd016d30177ccbf8096bb474e53895c362d8628ee9f9ea3c0e52c7a5c691b6c18c9979866568add7a2d41b00b05081ed0f58ee5e31b3a970e",
+			beta:  "645427e5d00c62a23fb703732fa5d892940935942101e456ecca7bb217c61c452118fec1219202a0edcf038bb6373241578be7217ba85a2687f7a0310b2df19f",
+		},
+	} {
+		k, err := NewVRFSignerFromRawKey(h2b(tc.sk))
+		if err != nil {
+			t.Fatalf("NewVRFSignerFromRawKey(%s): %v", tc.sk, err)
+		}
+		priv := k.(*PrivateKey)
+		if got, want := priv.Bytes(), h2b(tc.pk); !bytes.Equal(got, want) {
+			t.Errorf("public key of %s: %x, want %x", tc.sk, got, want)
+		}
+
+		alpha := h2b(tc.alpha)
+		pi, err := priv.Prove(alpha)
+		if err != nil {
+			t.Fatalf("Prove(%s): %v", tc.alpha, err)
+		}
+		if got, want := pi, h2b(tc.pi); !bytes.Equal(got, want) {
+			t.Errorf("Prove(%s): %x, want %x", tc.alpha, got, want)
+		}
+
+		pk, err := NewVRFVerifierFromRawKey(h2b(tc.pk))
+		if err != nil {
+			t.Fatalf("NewVRFVerifierFromRawKey(%s): %v", tc.pk, err)
+		}
+		beta, err := pk.(*PublicKey).Verify(alpha, pi)
+		if err != nil {
+			t.Errorf("Verify(%s): %v", tc.alpha, err)
+		}
+		if got, want := beta, h2b(tc.beta); !bytes.Equal(got, want) {
+			t.Errorf("Verify(%s): %x, want %x", tc.alpha, got, want)
+		}
+
+		index, proof := k.Evaluate(alpha)
+		if !bytes.Equal(proof, pi) || !bytes.Equal(index[:], beta[:32]) {
+			t.Errorf("Evaluate(%s): %x %x, want %x %x", tc.alpha, index, proof, beta[:32], pi)
+		}
+	}
+}
+
+func TestProofToHash(t *testing.T) {
+	k, pk := GenerateKey()
+	if k == nil || pk == nil {
+		t.Fatal("GenerateKey failure")
+	}
+	m := []byte("data")
+	index, proof := k.Evaluate(m)
+	if len(proof) != ProofSize {
+		t.Fatalf("Evaluate(%s): proof of %d bytes, want %d", m, len(proof), ProofSize)
+	}
+	got, err := pk.ProofToHash(m, proof)
+	if err != nil {
+		t.Errorf("ProofToHash(%s): %v", m, err)
+	}
+	if got != index {
+		t.Errorf("ProofToHash(%s): %x, want %x", m, got, index)
+	}
+
+	// other input.
+	if _, err := pk.ProofToHash([]byte("other"), proof); err != ErrInvalidVRF {
+		t.Errorf("ProofToHash(other): %v, want %v", err, ErrInvalidVRF)
+	}
+	// other key.
+	_, other := GenerateKey()
+	if _, err := other.ProofToHash(m, proof); err != ErrInvalidVRF {
+		t.Errorf("ProofToHash with other key: %v, want %v", err, ErrInvalidVRF)
+	}
+	// flipped bits and truncated proofs.
+	for i := 0; i < len(proof); i++ {
+		flipped := append([]byte{}, proof...)
+		flipped[i] ^= 0x01
+		if _, err := pk.ProofToHash(m, flipped); err == nil {
+			t.Errorf("ProofToHash(%s) with byte %d flipped: want error", m, i)
+		}
+	}
+	if _, err := pk.ProofToHash(m, proof[:ProofSize-1]); err != ErrInvalidVRF {
+		t.Errorf("ProofToHash(%s) truncated: %v, want %v", m, err, ErrInvalidVRF)
+	}
+}
+
+func TestProofSerialization(t *testing.T) {
+	k, _ := GenerateKey()
+	_, proof := k.Evaluate([]byte("data"))
+
+	pi, err := DecodeProof(proof)
+	if err != nil {
+		t.Fatalf("DecodeProof: %v", err)
+	}
+	if len(pi.Gamma) != 32 || len(pi.C) != 16 || len(pi.S) != 32 {
+		t.Errorf("DecodeProof: %d %d %d bytes, want 32 16 32", len(pi.Gamma), len(pi.C), len(pi.S))
+	}
+	if !bytes.Equal(pi.Bytes(), proof) {
+		t.Errorf("Bytes: %x, want %x", pi.Bytes(), proof)
+	}
+	if _, err := DecodeProof(proof[1:]); err != ErrInvalidVRF {
+		t.Errorf("DecodeProof truncated: %v, want %v", err, ErrInvalidVRF)
+	}
+}
+
+func TestInvalidKeys(t *testing.T) {
+	if _, err := NewVRFSignerFromRawKey(make([]byte, 31)); err != ErrInvalidKey {
+		t.Errorf("NewVRFSignerFromRawKey of 31 bytes: %v, want %v", err, ErrInvalidKey)
+	}
+	// the 64 bytes key must carry its own public key.
+	seed := h2b("9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60")
+	if _, err := NewVRFSignerFromRawKey(append(seed, make([]byte, 32)...)); err != ErrInvalidKey {
+		t.Errorf("NewVRFSignerFromRawKey with wrong public key: %v, want %v", err, ErrInvalidKey)
+	}
+	// the identity is of small order.
+	identity := make([]byte, 32)
+	identity[0] = 1
+	if _, err := NewVRFVerifierFromRawKey(identity); err != ErrInvalidKey {
+		t.Errorf("NewVRFVerifierFromRawKey(identity): %v, want %v", err, ErrInvalidKey)
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package secp256k1VRF
+
+import (
+	"runtime"
+	"sync"
+)
+
+// BatchItem is a proof to verify in a batch, with the key and the input it
+// was evaluated with.
+type BatchItem struct {
+	PublicKey *PublicKey
+	Message   []byte
+	Proof     []byte
+}
+
+// BatchError reports the first item of a batch failing the verification.
+type BatchError struct {
+	Index int
+	Err   error
+}
+
+func (e *BatchError) Error() string {
+	return e.Err.Error()
+}
+
+// VerifyMany verifies all the proofs of the batch, e.g. the proposer proofs
+// of an epoch, and returns their indexes in order.
+// The challenge of each proof is a hash of its commitments, so they can't be
+// folded into a single equation, the proofs are spread over the cpus instead.
+// The error is a *BatchError holding the lowest failing item.
+func VerifyMany(items []*BatchItem) ([][32]byte, error) {
+	indexes := make([][32]byte, len(items))
+	errs := make([]error, len(items))
+
+	workers := runtime.NumCPU()
+	if workers > len(items) {
+		workers = len(items)
+	}
+	next := make(chan int, len(items))
+	for i := range items {
+		next <- i
+	}
+	close(next)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				item := items[i]
+				if item == nil || item.PublicKey == nil || item.PublicKey.PublicKey == nil {
+					errs[i] = ErrInvalidVRF
+					continue
+				}
+				indexes[i], errs[i] = item.PublicKey.ProofToHash(item.Message, item.Proof)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, &BatchError{Index: i, Err: err}
+		}
+	}
+	return indexes, nil
+}
@@ -169,19 +169,9 @@ func (k PrivateKey) Evaluate(m []byte) (index [32]byte, proof []byte) {
 func (pk *PublicKey) ProofToHash(m, proof []byte) (index [32]byte, err error) {
 	nilIndex := [32]byte{}
 	// verifier checks that s == H2(m, [t]G + [s]([k]G), [t]H1(m) + [s]VRF_k(m))
-	if got, want := len(proof), 64+65; got != want {
-		return nilIndex, ErrInvalidVRF
-	}
-
-	// Parse proof into s, t, and vrf.
-	s := proof[0:32]
-	t := proof[32:64]
-	vrf := proof[64 : 64+65]
-
-	// uHx, uHy := elliptic.Unmarshal(curve, vrf)
-	uHx, uHy := curve.Unmarshal(vrf) //////???
-	if uHx == nil {
-		return nilIndex, ErrInvalidVRF
+	s, t, vrf, uHx, uHy, err := parseProof(proof)
+	if err != nil {
+		return nilIndex, err
 	}
 
 	// [t]G + [s]([k]G) = [t+ks]G
@@ -192,6 +182,9 @@ func (pk *PublicKey) ProofToHash(m, proof []byte) (index [32]byte, err error) {
 	// H = H1(m)
 	// [t]H + [s]VRF = [t+ks]H
 	Hx, Hy := H1(m)
+	if Hx == nil {
+		return nilIndex, ErrInvalidVRF
+	}
 	tHx, tHy := curve.ScalarMult(Hx, Hy, t)
 	sHx, sHy := curve.ScalarMult(uHx, uHy, s)
 	tksHx, tksHy := curve.Add(tHx, tHy, sHx, sHy)
@@ -220,6 +213,41 @@ func (pk *PublicKey) ProofToHash(m, proof []byte) (index [32]byte, err error) {
 	return sha256.Sum256(vrf), nil
 }
 
+// parseProof splits the proof into s, t and vrf, and rejects the encodings
+// that would make it malleable: s and t must be reduced modulo N, or t+N
+// would verify as well as t, and vrf must be a point of the curve with
+// reduced coordinates.
+func parseProof(proof []byte) (s, t, vrf []byte, x, y *big.Int, err error) {
+	if got, want := len(proof), 64+65; got != want {
+		return nil, nil, nil, nil, nil, ErrInvalidVRF
+	}
+
+	// Parse proof into s, t, and vrf.
+	s = proof[0:32]
+	t = proof[32:64]
+	vrf = proof[64 : 64+65]
+
+	if new(big.Int).SetBytes(s).Cmp(params.N) >= 0 || new(big.Int).SetBytes(t).Cmp(params.N) >= 0 {
+		return nil, nil, nil, nil, nil, ErrInvalidVRF
+	}
+
+	x, y = curve.Unmarshal(vrf)
+	if x == nil || x.Cmp(params.P) >= 0 || y.Cmp(params.P) >= 0 || !curve.IsOnCurve(x, y) {
+		return nil, nil, nil, nil, nil, ErrInvalidVRF
+	}
+	return s, t, vrf, x, y, nil
+}
+
+// ProofToIndex returns the index carried by the proof without verifying it,
+// the proof must be verified by ProofToHash before.
+func ProofToIndex(proof []byte) (index [32]byte, err error) {
+	nilIndex := [32]byte{}
+	if got, want := len(proof), 64+65; got != want {
+		return nilIndex, ErrInvalidVRF
+	}
+	return sha256.Sum256(proof[64 : 64+65]), nil
+}
+
 // NewFromWrappedKey creates a VRF signer object from an encrypted private key.
 // The opaque private key must resolve to an `ecdsa.PrivateKey` in order to work.
 // func NewFromWrappedKey(ctx context.Context, wrapped proto.Message) (vrf.PrivateKey, error) {
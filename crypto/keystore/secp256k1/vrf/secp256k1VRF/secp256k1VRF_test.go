@@ -360,3 +360,63 @@ func Test256VRF(t *testing.T) {
 		t.Errorf("verification failed")
 	}
 }
+
+func TestMalleableProof(t *testing.T) {
+	k, pk := GenerateKey()
+
+	data := []byte("data")
+	_, proof := k.Evaluate(data)
+	if _, err := pk.ProofToHash(data, proof); err != nil {
+		t.Fatalf("ProofToHash(%s, %x): %v, want nil", data, proof, err)
+	}
+
+	// t = N encodes the same scalar as t = 0.
+	n := params.N.Bytes()
+	tampered := append([]byte{}, proof...)
+	copy(tampered[32:64], n)
+	if _, err := pk.ProofToHash(data, tampered); err != ErrInvalidVRF {
+		t.Errorf("ProofToHash with t = N: %v, want %v", err, ErrInvalidVRF)
+	}
+
+	// vrf out of the curve.
+	tampered = append([]byte{}, proof...)
+	for i := 65; i < 64+65; i++ {
+		tampered[i] = 0
+	}
+	if _, err := pk.ProofToHash(data, tampered); err != ErrInvalidVRF {
+		t.Errorf("ProofToHash with vrf out of the curve: %v, want %v", err, ErrInvalidVRF)
+	}
+}
+
+func TestVerifyMany(t *testing.T) {
+	var items []*BatchItem
+	var want [][32]byte
+	for i := 0; i < 10; i++ {
+		k, pk := GenerateKey()
+		m := []byte(fmt.Sprintf("slot%d", i))
+		index, proof := k.Evaluate(m)
+		items = append(items, &BatchItem{PublicKey: pk.(*PublicKey), Message: m, Proof: proof})
+		want = append(want, index)
+	}
+
+	indexes, err := VerifyMany(items)
+	if err != nil {
+		t.Fatalf("VerifyMany: %v, want nil", err)
+	}
+	for i := range want {
+		if indexes[i] != want[i] {
+			t.Errorf("VerifyMany index %d: %x, want %x", i, indexes[i], want[i])
+		}
+	}
+
+	items[7].Message = []byte("other")
+	items[3].Proof = flipBit(items[3].Proof, 0)
+	_, err = VerifyMany(items)
+	if e, ok := err.(*BatchError); !ok || e.Index != 3 || e.Err != ErrInvalidVRF {
+		t.Errorf("VerifyMany: %v, want the error of item 3", err)
+	}
+
+	if indexes, err := VerifyMany(nil); err != nil || len(indexes) != 0 {
+		t.Errorf("VerifyMany(nil): %v %v, want nothing", indexes, err)
+	}
+}
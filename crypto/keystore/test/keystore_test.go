@@ -214,3 +214,49 @@ func TestKeystore_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestKeystore_UnlockWithUses(t *testing.T) {
+	priv, _ := crypto.NewPrivateKey(keystore.SECP256K1, nil)
+	ks := keystore.NewKeystore()
+	passphrase := []byte("passphrase")
+	assert.Nil(t, ks.SetKey("alias", priv, passphrase))
+
+	assert.Nil(t, ks.UnlockWithUses("alias", passphrase, time.Minute, 2))
+	infos := ks.Unlocked()
+	assert.Equal(t, 1, len(infos))
+	assert.Equal(t, "alias", infos[0].Alias)
+	assert.Equal(t, uint32(2), infos[0].Uses)
+	assert.True(t, infos[0].Expiry.After(time.Now()))
+
+	sign := func(key keystore.Key) error {
+		signature, err := crypto.NewSignature(keystore.SECP256K1)
+		if err != nil {
+			return err
+		}
+		if err := signature.InitSign(key.(keystore.PrivateKey)); err != nil {
+			return err
+		}
+		_, err = signature.Sign(make([]byte, 32))
+		return err
+	}
+	assert.Nil(t, ks.UseUnlocked("alias", sign))
+	assert.Equal(t, uint32(1), ks.Unlocked()[0].Uses)
+	assert.Nil(t, ks.UseUnlocked("alias", sign))
+	assert.Equal(t, 0, len(ks.Unlocked()))
+	assert.Equal(t, keystore.ErrNotUnlocked, ks.UseUnlocked("alias", sign))
+
+	// unlimited uses until locked.
+	assert.Nil(t, ks.Unlock("alias", passphrase, time.Minute))
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, ks.UseUnlocked("alias", sign))
+	}
+	ks.LockAll()
+	assert.Equal(t, 0, len(ks.Unlocked()))
+	assert.Equal(t, keystore.ErrNotUnlocked, ks.Lock("alias"))
+
+	// relocked on timeout.
+	assert.Nil(t, ks.UnlockWithUses("alias", passphrase, 100*time.Millisecond, 5))
+	time.Sleep(300 * time.Millisecond)
+	_, err := ks.GetUnlocked("alias")
+	assert.Equal(t, keystore.ErrNotUnlocked, err)
+}
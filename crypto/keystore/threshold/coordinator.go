@@ -0,0 +1,392 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package threshold
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"sort"
+
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1/bitelliptic"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1/vrf/secp256k1VRF"
+	"github.com/nebulasio/go-nebulas/crypto/utils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// The block-signing key of a validator is split into shares kept by holders on
+// different machines, so that no single machine stores the key. For each
+// block the coordinator in the node runs the rounds of the signature or the
+// VRF proof with 2t-1 of the holders that approve the request, see Request,
+// and combines their replies into a secp256k1 signature or a proof of the
+// secp256k1 VRF, which are verified as usual. Neither the coordinator nor any
+// holder ever recovers the key.
+
+var (
+	// ErrNotEnoughHolders less than 2t-1 holders approved the request
+	ErrNotEnoughHolders = errors.New("not enough share holders approved the request")
+
+	// ErrInvalidReply the reply of a holder is malformed
+	ErrInvalidReply = errors.New("invalid reply from the share holder")
+
+	// ErrInvalidSignature the combined signature or proof fails to verify
+	ErrInvalidSignature = errors.New("invalid signature combined from the holders")
+)
+
+// Coordinator runs the rounds with the holders to sign.
+type Coordinator struct {
+	threshold   int
+	commitments [][]byte
+	holders     []Holder
+}
+
+// NewCoordinator returns a coordinator of the holders, the threshold is the
+// count of the commitments returned by Split, at least 2*threshold-1 holders
+// are needed to sign.
+func NewCoordinator(commitments [][]byte, holders ...Holder) (*Coordinator, error) {
+	if len(commitments) == 0 || 2*len(commitments)-1 > len(holders) {
+		return nil, ErrInvalidThreshold
+	}
+	if _, err := secp256k1.ToECDSAPublicKey(commitments[0]); err != nil {
+		return nil, ErrInvalidCommitments
+	}
+	return &Coordinator{
+		threshold:   len(commitments),
+		commitments: commitments,
+		holders:     holders,
+	}, nil
+}
+
+// PublicKey returns the public key of the split key
+func (c *Coordinator) PublicKey() []byte {
+	return c.commitments[0]
+}
+
+// SignBlock signs the hash of the block by the holders, the block in proto
+// is checked by the holders against the hash.
+func (c *Coordinator) SignBlock(data, block []byte) ([]byte, error) {
+	if len(data) != secp256k1.EcdsaPrivateKeyLength {
+		return nil, secp256k1.ErrInvalidMsgLen
+	}
+	s, err := c.open(KindBlock, data, block)
+	if err != nil {
+		return nil, err
+	}
+	replies, err := s.multiply()
+	if err != nil {
+		return nil, err
+	}
+
+	// mu = k*a and A = a*G, so R = mu^-1 * A = k^-1 * G.
+	curve := bitelliptic.S256()
+	order := curve.Params().N
+	mu, err := s.interpolateScalars(replies)
+	if err != nil {
+		return nil, err
+	}
+	ax, ay, err := s.interpolatePoints(replies, 0)
+	if err != nil {
+		return nil, err
+	}
+	if mu.Sign() == 0 {
+		return nil, ErrInvalidSignature
+	}
+	rx, ry := curve.ScalarMult(ax, ay, scalarBytes(mu.ModInverse(mu, order)))
+	r := new(big.Int).Mod(rx, order)
+	if r.Sign() == 0 {
+		return nil, ErrInvalidSignature
+	}
+
+	replies, err = s.sign(r)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := s.interpolateScalars(replies)
+	if err != nil {
+		return nil, err
+	}
+	if sig.Sign() == 0 {
+		return nil, ErrInvalidSignature
+	}
+
+	// the recovery id is the parity of R.y and whether R.x overflows the
+	// order, flipped with the parity if s is normalized to the lower half.
+	recid := byte(ry.Bit(0))
+	if rx.Cmp(order) >= 0 {
+		recid |= 2
+	}
+	if sig.Cmp(new(big.Int).Rsh(order, 1)) > 0 {
+		sig.Sub(order, sig)
+		recid ^= 1
+	}
+	out := append(scalarBytes(r), scalarBytes(sig)...)
+	out = append(out, recid)
+
+	recovered, err := secp256k1.RecoverECDSAPublicKey(data, out)
+	if err != nil || !bytes.Equal(recovered, c.PublicKey()) {
+		return nil, ErrInvalidSignature
+	}
+	return out, nil
+}
+
+// GenerateRandomSeed evaluates the VRF of the key over the inputs by the
+// holders, the same as the account manager does with the key.
+func (c *Coordinator) GenerateRandomSeed(ancestorHash, parentSeed []byte) ([]byte, []byte, error) {
+	data := hash.Sha3256(ancestorHash, parentSeed)
+	payload := append(append([]byte{}, ancestorHash...), parentSeed...)
+	s, err := c.open(KindRandomSeed, data, payload)
+	if err != nil {
+		return nil, nil, err
+	}
+	replies, err := s.multiply()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the points are x*H, the VRF, and r*G, r*H of the nonce r.
+	curve := bitelliptic.S256()
+	params := curve.Params()
+	var points [3][2]*big.Int
+	for i := range points {
+		x, y, err := s.interpolatePoints(replies, i)
+		if err != nil {
+			return nil, nil, err
+		}
+		points[i] = [2]*big.Int{x, y}
+	}
+	hx, hy := secp256k1VRF.H1(data)
+	if hx == nil {
+		return nil, nil, secp256k1VRF.ErrEvaluateFailed
+	}
+	vrf := curve.Marshal(points[0][0], points[0][1])
+
+	// s = H2(G, H, [x]G, VRF, [r]G, [r]H), the same as secp256k1VRF.
+	var b bytes.Buffer
+	b.Write(curve.Marshal(params.Gx, params.Gy))
+	b.Write(curve.Marshal(hx, hy))
+	b.Write(c.PublicKey())
+	b.Write(vrf)
+	b.Write(curve.Marshal(points[1][0], points[1][1]))
+	b.Write(curve.Marshal(points[2][0], points[2][1]))
+	challenge := secp256k1VRF.H2(b.Bytes())
+
+	replies, err = s.sign(challenge)
+	if err != nil {
+		return nil, nil, err
+	}
+	t, err := s.interpolateScalars(replies)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	proof := append(scalarBytes(challenge), scalarBytes(t)...)
+	proof = append(proof, vrf...)
+	seed := sha256.Sum256(vrf)
+
+	pub, err := secp256k1.ToECDSAPublicKey(c.PublicKey())
+	if err != nil {
+		return nil, nil, err
+	}
+	verifier, err := secp256k1VRF.NewVRFVerifier(pub)
+	if err != nil {
+		return nil, nil, err
+	}
+	index, err := verifier.ProofToHash(data, proof)
+	if err != nil || index != seed {
+		return nil, nil, ErrInvalidSignature
+	}
+	return seed[:], proof, nil
+}
+
+// signingSession the signers of a session in the ascending order of their indexes
+type signingSession struct {
+	id      string
+	holders []Holder
+	indexes []uint32
+}
+
+func (s *signingSession) Len() int           { return len(s.holders) }
+func (s *signingSession) Less(i, j int) bool { return s.indexes[i] < s.indexes[j] }
+func (s *signingSession) Swap(i, j int) {
+	s.holders[i], s.holders[j] = s.holders[j], s.holders[i]
+	s.indexes[i], s.indexes[j] = s.indexes[j], s.indexes[i]
+}
+
+// open asks the holders to sign the data until 2t-1 of them approve.
+func (c *Coordinator) open(kind string, data, payload []byte) (*signingSession, error) {
+	count := 2*c.threshold - 1
+	s := &signingSession{
+		id:      hex.EncodeToString(utils.RandomCSPRNG(32)),
+		holders: make([]Holder, 0, count),
+		indexes: make([]uint32, 0, count),
+	}
+	req := &Request{
+		Method:  MethodOpen,
+		Session: s.id,
+		Kind:    kind,
+		Data:    hex.EncodeToString(data),
+		Payload: hex.EncodeToString(payload),
+	}
+	for i, holder := range c.holders {
+		if len(s.holders) == count {
+			break
+		}
+		reply, err := holder.Handle(req)
+		if err != nil {
+			logging.VLog().WithFields(logrus.Fields{
+				"holder": i,
+				"kind":   kind,
+				"err":    err,
+			}).Debug("Share holder didn't approve the request.")
+			continue
+		}
+		if reply.Index == 0 || hasIndex(s.indexes, reply.Index) {
+			logging.VLog().WithFields(logrus.Fields{
+				"holder": i,
+				"index":  reply.Index,
+			}).Warn("Share holder replied an invalid index.")
+			continue
+		}
+		s.holders = append(s.holders, holder)
+		s.indexes = append(s.indexes, reply.Index)
+	}
+	if len(s.holders) < count {
+		return nil, ErrNotEnoughHolders
+	}
+	sort.Sort(s)
+	return s, nil
+}
+
+// multiply runs the deal and the multiply rounds, relaying the deals to the
+// signers they are dealt to.
+func (s *signingSession) multiply() ([]*Reply, error) {
+	replies, err := s.call(func(i int) *Request {
+		return &Request{Method: MethodDeal, Session: s.id, Signers: s.indexes}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// deals[to][from] the shares dealt to the signer to by the signer from.
+	deals := make([][]*Deal, len(s.indexes))
+	for i := range deals {
+		deals[i] = make([]*Deal, len(s.indexes))
+	}
+	for from, reply := range replies {
+		if len(reply.Deals) != len(s.indexes) {
+			return nil, ErrInvalidReply
+		}
+		for to, deal := range reply.Deals {
+			if deal == nil || deal.From != s.indexes[from] || deal.To != s.indexes[to] {
+				return nil, ErrInvalidReply
+			}
+			deals[to][from] = deal
+		}
+	}
+
+	return s.call(func(i int) *Request {
+		return &Request{Method: MethodMultiply, Session: s.id, Deals: deals[i]}
+	})
+}
+
+// sign runs the sign round with the challenge.
+func (s *signingSession) sign(challenge *big.Int) ([]*Reply, error) {
+	return s.call(func(i int) *Request {
+		return &Request{Method: MethodSign, Session: s.id, Challenge: hex.EncodeToString(scalarBytes(challenge))}
+	})
+}
+
+// call sends the requests to all the signers, a session fails with any of them.
+func (s *signingSession) call(request func(i int) *Request) ([]*Reply, error) {
+	replies := make([]*Reply, len(s.holders))
+	for i, holder := range s.holders {
+		req := request(i)
+		reply, err := holder.Handle(req)
+		if err != nil {
+			logging.VLog().WithFields(logrus.Fields{
+				"index":  s.indexes[i],
+				"method": req.Method,
+				"err":    err,
+			}).Warn("Share holder failed the session.")
+			return nil, err
+		}
+		replies[i] = reply
+	}
+	return replies, nil
+}
+
+// interpolateScalars interpolates the scalars replied by the signers at zero.
+func (s *signingSession) interpolateScalars(replies []*Reply) (*big.Int, error) {
+	order := bitelliptic.S256().Params().N
+	sum := new(big.Int)
+	for i, reply := range replies {
+		b, err := hex.DecodeString(reply.Scalar)
+		if err != nil || len(b) != secp256k1.EcdsaPrivateKeyLength {
+			return nil, ErrInvalidReply
+		}
+		v := new(big.Int).SetBytes(b)
+		if v.Cmp(order) >= 0 {
+			return nil, ErrInvalidReply
+		}
+		v.Mul(v, lagrange(s.indexes[i], s.indexes))
+		sum.Add(sum, v)
+		sum.Mod(sum, order)
+	}
+	return sum, nil
+}
+
+// interpolatePoints interpolates the n-th points replied by the signers at zero.
+func (s *signingSession) interpolatePoints(replies []*Reply, n int) (*big.Int, *big.Int, error) {
+	curve := bitelliptic.S256()
+	var sumX, sumY *big.Int
+	for i, reply := range replies {
+		if len(reply.Points) <= n {
+			return nil, nil, ErrInvalidReply
+		}
+		b, err := hex.DecodeString(reply.Points[n])
+		if err != nil {
+			return nil, nil, ErrInvalidReply
+		}
+		px, py := curve.Unmarshal(b)
+		if px == nil || !curve.IsOnCurve(px, py) {
+			return nil, nil, ErrInvalidReply
+		}
+		px, py = curve.ScalarMult(px, py, scalarBytes(lagrange(s.indexes[i], s.indexes)))
+		if sumX == nil {
+			sumX, sumY = px, py
+		} else {
+			sumX, sumY = curve.Add(sumX, sumY, px, py)
+		}
+	}
+	return sumX, sumY, nil
+}
+
+func hasIndex(indexes []uint32, index uint32) bool {
+	for _, v := range indexes {
+		if v == index {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package threshold
+
+import (
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/nebulasio/go-nebulas/crypto/cipher"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+)
+
+// shareJSON the file of a share kept by a holder, the value is encrypted by
+// the passphrase of the holder the same as the key files.
+type shareJSON struct {
+	Index       uint32          `json:"index"`
+	Commitments []string        `json:"commitments"`
+	Crypto      json.RawMessage `json:"crypto"`
+}
+
+// EncryptShare returns the file of the share with the commitments, the value
+// is encrypted by the passphrase.
+func EncryptShare(share *Share, commitments [][]byte, passphrase []byte) ([]byte, error) {
+	if !VerifyShare(share, commitments) {
+		return nil, ErrInvalidShare
+	}
+	crypto, err := cipher.NewCipher(uint8(keystore.SCRYPT)).Encrypt(share.Value, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(&shareJSON{
+		Index:       share.Index,
+		Commitments: encodeCommitments(commitments),
+		Crypto:      crypto,
+	})
+}
+
+// DecryptShare returns the share and the commitments in the file, the share
+// is verified against the commitments.
+func DecryptShare(data, passphrase []byte) (*Share, [][]byte, error) {
+	file := new(shareJSON)
+	if err := json.Unmarshal(data, file); err != nil {
+		return nil, nil, err
+	}
+	commitments, err := decodeCommitments(file.Commitments)
+	if err != nil {
+		return nil, nil, err
+	}
+	value, err := cipher.NewCipher(uint8(keystore.SCRYPT)).Decrypt(file.Crypto, passphrase)
+	if err != nil {
+		return nil, nil, err
+	}
+	share := &Share{Index: file.Index, Value: value}
+	if !VerifyShare(share, commitments) {
+		return nil, nil, ErrInvalidShare
+	}
+	return share, commitments, nil
+}
+
+// MarshalCommitments returns the commitments file read by the coordinator
+func MarshalCommitments(commitments [][]byte) ([]byte, error) {
+	return json.Marshal(encodeCommitments(commitments))
+}
+
+// UnmarshalCommitments parses the commitments file
+func UnmarshalCommitments(data []byte) ([][]byte, error) {
+	var values []string
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	return decodeCommitments(values)
+}
+
+func encodeCommitments(commitments [][]byte) []string {
+	values := make([]string, len(commitments))
+	for i, c := range commitments {
+		values[i] = hex.EncodeToString(c)
+	}
+	return values
+}
+
+func decodeCommitments(values []string) ([][]byte, error) {
+	if len(values) == 0 {
+		return nil, ErrInvalidCommitments
+	}
+	commitments := make([][]byte, len(values))
+	for i, v := range values {
+		c, err := hex.DecodeString(v)
+		if err != nil {
+			return nil, ErrInvalidCommitments
+		}
+		commitments[i] = c
+	}
+	return commitments, nil
+}
@@ -0,0 +1,495 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package threshold
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1/bitelliptic"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1/vrf/secp256k1VRF"
+	"github.com/nebulasio/go-nebulas/crypto/utils"
+)
+
+// The coordinator runs the rounds with the signers, 2t-1 holders of the shares
+// of threshold t, for each signature or VRF proof. In open each signer
+// approves the data and joins the session. In deal each signer deals the
+// shares of its random polynomials to the others, encrypted by the key agreed
+// from the two key shares, so the coordinator relaying them learns nothing.
+// In multiply each signer sums the shares dealt to it into its shares of the
+// nonce k and the mask a, and replies k*a masked by a zero share and a*G, from
+// which the coordinator computes R = k^-1*G, or x*H, k*G and k*H for the VRF.
+// In sign each signer replies its share of s = k(m + r*x) masked by a zero
+// share, or of t = k - s*x for the VRF. The coordinator interpolates the
+// replies, no party ever holds the key or the nonce. The holders are trusted
+// to follow the rounds, a holder deviating from them fails the signature,
+// which the coordinator verifies. The data is opened with the payload it is
+// hashed from, e.g. the block, so that the policy of each holder checks what
+// it signs instead of trusting the coordinator.
+const (
+	// MethodOpen asks the holder to approve the data and join the session
+	MethodOpen = "open"
+
+	// MethodDeal asks the holder to deal the shares of its random polynomials
+	MethodDeal = "deal"
+
+	// MethodMultiply passes the holder the shares dealt to it
+	MethodMultiply = "multiply"
+
+	// MethodSign asks the holder for its share of the signature or the proof
+	MethodSign = "sign"
+
+	// SessionTimeout a session not signed within it is dropped by the holder
+	SessionTimeout = time.Minute
+)
+
+// Kinds of the data signed in a session
+const (
+	// KindBlock the hash of a block, the payload is the block in proto
+	KindBlock = "block"
+
+	// KindRandomSeed the VRF input, the payload is the ancestor hash followed by the parent seed
+	KindRandomSeed = "random_seed"
+)
+
+var (
+	// ErrRequestRefused the holder refuses to sign the data
+	ErrRequestRefused = errors.New("request refused by the share holder")
+
+	// ErrInvalidRequest the request is malformed or out of the order of the rounds
+	ErrInvalidRequest = errors.New("invalid request to the share holder")
+
+	// ErrInvalidDeal a share dealt to the holder fails to decrypt
+	ErrInvalidDeal = errors.New("invalid share dealt to the holder")
+
+	// ErrNoPolicy a holder is created without a policy
+	ErrNoPolicy = errors.New("share holder needs a policy to approve the requests")
+)
+
+// Policy decides whether the holder signs the data of the kind, the payload
+// is what the data is hashed from.
+type Policy func(kind string, data, payload []byte) bool
+
+// Deal the encrypted shares dealt by a signer to another, hex encoded.
+type Deal struct {
+	From uint32 `json:"from"`
+	To   uint32 `json:"to"`
+	Data string `json:"data"`
+}
+
+// Request a round of a session, the bytes are hex encoded.
+type Request struct {
+	Method    string   `json:"method"`
+	Session   string   `json:"session"`
+	Kind      string   `json:"kind,omitempty"`
+	Data      string   `json:"data,omitempty"`
+	Payload   string   `json:"payload,omitempty"`
+	Signers   []uint32 `json:"signers,omitempty"`
+	Deals     []*Deal  `json:"deals,omitempty"`
+	Challenge string   `json:"challenge,omitempty"`
+}
+
+// Reply the reply of a holder to a round, the bytes are hex encoded.
+type Reply struct {
+	Approved bool     `json:"approved"`
+	Reason   string   `json:"reason,omitempty"`
+	Index    uint32   `json:"index,omitempty"`
+	Deals    []*Deal  `json:"deals,omitempty"`
+	Scalar   string   `json:"scalar,omitempty"`
+	Points   []string `json:"points,omitempty"`
+}
+
+// Holder keeps a share of the key, e.g. on another machine.
+type Holder interface {
+	// Handle runs a round of a session, or returns an error if the holder refuses
+	Handle(req *Request) (*Reply, error)
+}
+
+// session the state of a holder in a session
+type session struct {
+	kind    string
+	data    []byte
+	created time.Time
+
+	sid     []byte
+	signers []uint32
+
+	// the shares of the nonce and the zero mask of the signature, set by multiply.
+	k *big.Int
+	c *big.Int
+}
+
+// LocalHolder a holder keeping the share in process.
+type LocalHolder struct {
+	mu sync.Mutex
+
+	share       *Share
+	commitments [][]byte
+	policy      Policy
+	sessions    map[string]*session
+}
+
+// NewLocalHolder returns a holder of the share, the policy decides whether
+// each request is signed.
+func NewLocalHolder(share *Share, commitments [][]byte, policy Policy) (*LocalHolder, error) {
+	if policy == nil {
+		return nil, ErrNoPolicy
+	}
+	if !VerifyShare(share, commitments) {
+		return nil, ErrInvalidShare
+	}
+	return &LocalHolder{
+		share:       share,
+		commitments: commitments,
+		policy:      policy,
+		sessions:    make(map[string]*session),
+	}, nil
+}
+
+// Index returns the index of the share
+func (h *LocalHolder) Index() uint32 {
+	return h.share.Index
+}
+
+// Handle runs a round of a session
+func (h *LocalHolder) Handle(req *Request) (*Reply, error) {
+	if req == nil {
+		return nil, ErrInvalidRequest
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := time.Now()
+	for id, s := range h.sessions {
+		if now.Sub(s.created) > SessionTimeout {
+			h.drop(id)
+		}
+	}
+
+	if req.Method == MethodOpen {
+		return h.open(req)
+	}
+	s, ok := h.sessions[req.Session]
+	if !ok {
+		return nil, ErrInvalidRequest
+	}
+	switch req.Method {
+	case MethodDeal:
+		return h.deal(s, req)
+	case MethodMultiply:
+		return h.multiply(s, req)
+	case MethodSign:
+		reply, err := h.sign(s, req)
+		// the nonce is used once, whether or not the round succeeds.
+		h.drop(req.Session)
+		return reply, err
+	}
+	return nil, ErrInvalidRequest
+}
+
+func (h *LocalHolder) open(req *Request) (*Reply, error) {
+	id, err := hex.DecodeString(req.Session)
+	if err != nil || len(id) != 32 {
+		return nil, ErrInvalidRequest
+	}
+	if _, ok := h.sessions[req.Session]; ok {
+		return nil, ErrInvalidRequest
+	}
+	if req.Kind != KindBlock && req.Kind != KindRandomSeed {
+		return nil, ErrInvalidRequest
+	}
+	data, err := hex.DecodeString(req.Data)
+	if err != nil || len(data) != 32 {
+		return nil, ErrInvalidRequest
+	}
+	payload, err := hex.DecodeString(req.Payload)
+	if err != nil {
+		return nil, ErrInvalidRequest
+	}
+	if !h.policy(req.Kind, data, payload) {
+		return nil, ErrRequestRefused
+	}
+	h.sessions[req.Session] = &session{
+		kind:    req.Kind,
+		data:    data,
+		created: time.Now(),
+	}
+	return &Reply{Approved: true, Index: h.share.Index}, nil
+}
+
+func (h *LocalHolder) deal(s *session, req *Request) (*Reply, error) {
+	if s.sid != nil || !h.validSigners(req.Signers) {
+		return nil, ErrInvalidRequest
+	}
+	s.signers = req.Signers
+	s.sid = sessionID(req.Session, s.kind, s.data, s.signers)
+
+	// the nonce k and the mask a are of the degree of the key, the products
+	// of them are masked by the zero polynomials b and c of the double degree.
+	t := len(h.commitments)
+	degrees := []int{t - 1, t - 1, 2*t - 2, 2*t - 2}
+	if s.kind == KindRandomSeed {
+		degrees = degrees[:1]
+	}
+	polys := make([][]*big.Int, 0, len(degrees))
+	defer func() {
+		for _, poly := range polys {
+			clearScalars(poly...)
+		}
+	}()
+	for i, degree := range degrees {
+		c := new(big.Int)
+		if i < 2 {
+			r, err := randomScalar()
+			if err != nil {
+				return nil, err
+			}
+			c = r
+		}
+		poly, err := randomPoly(c, degree)
+		if err != nil {
+			return nil, err
+		}
+		polys = append(polys, poly)
+	}
+
+	deals := make([]*Deal, 0, len(s.signers))
+	for _, to := range s.signers {
+		values := make([]byte, 0, len(polys)*secp256k1.EcdsaPrivateKeyLength)
+		for _, poly := range polys {
+			v := evalPoly(poly, to)
+			values = append(values, scalarBytes(v)...)
+			v.SetInt64(0)
+		}
+		data, err := h.seal(s.sid, h.share.Index, to, values)
+		utils.ZeroBytes(values)
+		if err != nil {
+			return nil, err
+		}
+		deals = append(deals, &Deal{From: h.share.Index, To: to, Data: hex.EncodeToString(data)})
+	}
+	return &Reply{Approved: true, Deals: deals}, nil
+}
+
+func (h *LocalHolder) multiply(s *session, req *Request) (*Reply, error) {
+	if s.sid == nil || s.k != nil || len(req.Deals) != len(s.signers) {
+		return nil, ErrInvalidRequest
+	}
+	count := 4
+	if s.kind == KindRandomSeed {
+		count = 1
+	}
+
+	// sums[0..3] are the shares of k, a, b and c.
+	sums := make([]*big.Int, count)
+	for i := range sums {
+		sums[i] = new(big.Int)
+	}
+	defer clearScalars(sums...)
+	order := bitelliptic.S256().Params().N
+	for i, deal := range req.Deals {
+		if deal == nil || deal.From != s.signers[i] || deal.To != h.share.Index {
+			return nil, ErrInvalidRequest
+		}
+		data, err := hex.DecodeString(deal.Data)
+		if err != nil {
+			return nil, ErrInvalidDeal
+		}
+		values, err := h.unseal(s.sid, deal.From, h.share.Index, data)
+		if err != nil || len(values) != count*secp256k1.EcdsaPrivateKeyLength {
+			return nil, ErrInvalidDeal
+		}
+		for j := range sums {
+			v := new(big.Int).SetBytes(values[j*secp256k1.EcdsaPrivateKeyLength : (j+1)*secp256k1.EcdsaPrivateKeyLength])
+			sums[j].Add(sums[j], v)
+			sums[j].Mod(sums[j], order)
+			v.SetInt64(0)
+		}
+		utils.ZeroBytes(values)
+	}
+
+	curve := bitelliptic.S256()
+	s.k = new(big.Int).Set(sums[0])
+	if s.kind == KindRandomSeed {
+		hx, hy := secp256k1VRF.H1(s.data)
+		if hx == nil {
+			return nil, ErrInvalidRequest
+		}
+		x := h.secret()
+		defer clearScalars(x)
+		gx, gy := curve.ScalarMult(hx, hy, scalarBytes(x))
+		ux, uy := curve.ScalarBaseMult(scalarBytes(s.k))
+		vx, vy := curve.ScalarMult(hx, hy, scalarBytes(s.k))
+		return &Reply{Approved: true, Points: []string{
+			hex.EncodeToString(curve.Marshal(gx, gy)),
+			hex.EncodeToString(curve.Marshal(ux, uy)),
+			hex.EncodeToString(curve.Marshal(vx, vy)),
+		}}, nil
+	}
+
+	s.c = new(big.Int).Set(sums[3])
+	v := new(big.Int).Mul(sums[0], sums[1])
+	v.Add(v, sums[2])
+	v.Mod(v, order)
+	ax, ay := curve.ScalarBaseMult(scalarBytes(sums[1]))
+	return &Reply{
+		Approved: true,
+		Scalar:   hex.EncodeToString(scalarBytes(v)),
+		Points:   []string{hex.EncodeToString(curve.Marshal(ax, ay))},
+	}, nil
+}
+
+func (h *LocalHolder) sign(s *session, req *Request) (*Reply, error) {
+	if s.k == nil {
+		return nil, ErrInvalidRequest
+	}
+	order := bitelliptic.S256().Params().N
+	challenge, err := hex.DecodeString(req.Challenge)
+	if err != nil || len(challenge) != secp256k1.EcdsaPrivateKeyLength {
+		return nil, ErrInvalidRequest
+	}
+	e := new(big.Int).SetBytes(challenge)
+	if e.Sign() == 0 || e.Cmp(order) >= 0 {
+		return nil, ErrInvalidRequest
+	}
+
+	x := h.secret()
+	defer clearScalars(x)
+	out := new(big.Int)
+	defer clearScalars(out)
+	if s.kind == KindRandomSeed {
+		// t = k - s*x
+		out.Mul(e, x)
+		out.Sub(s.k, out)
+	} else {
+		// s = k(m + r*x) + c
+		out.Mul(e, x)
+		out.Add(out, new(big.Int).SetBytes(s.data))
+		out.Mul(out, s.k)
+		out.Add(out, s.c)
+	}
+	out.Mod(out, order)
+	return &Reply{Approved: true, Scalar: hex.EncodeToString(scalarBytes(out))}, nil
+}
+
+// validSigners checks the signers are 2t-1 ascending indexes including the holder's.
+func (h *LocalHolder) validSigners(signers []uint32) bool {
+	if len(signers) != 2*len(h.commitments)-1 {
+		return false
+	}
+	self := false
+	for i, index := range signers {
+		if index == 0 || (i > 0 && index <= signers[i-1]) {
+			return false
+		}
+		if index == h.share.Index {
+			self = true
+		}
+	}
+	return self
+}
+
+func (h *LocalHolder) drop(id string) {
+	if s, ok := h.sessions[id]; ok {
+		clearScalars(s.k, s.c)
+		delete(h.sessions, id)
+	}
+}
+
+func (h *LocalHolder) secret() *big.Int {
+	return new(big.Int).SetBytes(h.share.Value)
+}
+
+// seal encrypts the shares dealt from a signer to another.
+func (h *LocalHolder) seal(sid []byte, from, to uint32, plaintext []byte) ([]byte, error) {
+	aead, err := h.pairCipher(sid, from, to)
+	if err != nil {
+		return nil, err
+	}
+	nonce := utils.RandomCSPRNG(aead.NonceSize())
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// unseal decrypts the shares dealt from a signer to another.
+func (h *LocalHolder) unseal(sid []byte, from, to uint32, data []byte) ([]byte, error) {
+	aead, err := h.pairCipher(sid, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < aead.NonceSize() {
+		return nil, ErrInvalidDeal
+	}
+	return aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], nil)
+}
+
+// pairCipher returns the cipher of the shares dealt from a signer to another
+// in the session, keyed by the Diffie-Hellman agreement of the key shares of
+// the holder and the other signer, which only the two of them compute.
+func (h *LocalHolder) pairCipher(sid []byte, from, to uint32) (cipher.AEAD, error) {
+	other := from
+	if other == h.share.Index {
+		other = to
+	}
+	px, py := publicShare(other, h.commitments)
+	if px == nil {
+		return nil, ErrInvalidCommitments
+	}
+	sx, _ := bitelliptic.S256().ScalarMult(px, py, h.share.Value)
+
+	index := make([]byte, 8)
+	binary.BigEndian.PutUint32(index, from)
+	binary.BigEndian.PutUint32(index[4:], to)
+	key := hash.Sha3256(scalarBytes(sx), sid, index)
+	defer utils.ZeroBytes(key)
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// sessionID binds the deals to the session, the data and the signers.
+func sessionID(session, kind string, data []byte, signers []uint32) []byte {
+	indexes := make([]byte, 4*len(signers))
+	for i, index := range signers {
+		binary.BigEndian.PutUint32(indexes[4*i:], index)
+	}
+	return hash.Sha3256([]byte(session), []byte(kind), data, indexes)
+}
+
+// randomScalar returns a random scalar in [1, N-1].
+func randomScalar() (*big.Int, error) {
+	order := bitelliptic.S256().Params().N
+	r, err := rand.Int(rand.Reader, new(big.Int).Sub(order, big.NewInt(1)))
+	if err != nil {
+		return nil, err
+	}
+	return r.Add(r, big.NewInt(1)), nil
+}
@@ -0,0 +1,181 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package threshold
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// A holder on another machine serves the rounds over https, the coordinator
+// posts the requests in json with a bearer token, optionally over mutual tls.
+const (
+	// DefaultTimeout of a round with a remote holder
+	DefaultTimeout = 10 * time.Second
+
+	// maxRequestSize the limit of a request read by the holder server, a
+	// request carries a block at most
+	maxRequestSize = 32 << 20
+)
+
+var (
+	// ErrInsecureEndpoint the endpoint of the holder is not https
+	ErrInsecureEndpoint = errors.New("share holder endpoint must be https")
+)
+
+// RemoteHolder a holder served by a HolderServer on another machine.
+type RemoteHolder struct {
+	endpoint string
+	token    string
+	client   *http.Client
+}
+
+// NewRemoteHolder returns the holder served at the https endpoint,
+// authenticated by the bearer token and the client certificate in tlsConfig
+// if any.
+func NewRemoteHolder(endpoint, token string, tlsConfig *tls.Config) (*RemoteHolder, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "https" {
+		return nil, ErrInsecureEndpoint
+	}
+	return &RemoteHolder{
+		endpoint: endpoint,
+		token:    token,
+		client: &http.Client{
+			Timeout:   DefaultTimeout,
+			Transport: &http.Transport{TLSClientConfig: tlsConfig},
+		},
+	}, nil
+}
+
+// Endpoint returns the endpoint of the holder
+func (h *RemoteHolder) Endpoint() string {
+	return h.endpoint
+}
+
+// Handle posts the round to the holder
+func (h *RemoteHolder) Handle(req *Request) (*Reply, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	httpReq, err := http.NewRequest("POST", h.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if len(h.token) > 0 {
+		httpReq.Header.Set("Authorization", "Bearer "+h.token)
+	}
+
+	resp, err := h.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		logging.VLog().WithFields(logrus.Fields{
+			"endpoint": h.endpoint,
+			"method":   req.Method,
+			"status":   resp.Status,
+		}).Debug("Share holder failed the request.")
+		return nil, ErrInvalidReply
+	}
+
+	reply := new(Reply)
+	if err := json.Unmarshal(data, reply); err != nil {
+		return nil, ErrInvalidReply
+	}
+	if !reply.Approved {
+		logging.VLog().WithFields(logrus.Fields{
+			"endpoint": h.endpoint,
+			"method":   req.Method,
+			"reason":   reply.Reason,
+		}).Info("Share holder refused the request.")
+		return nil, ErrRequestRefused
+	}
+	return reply, nil
+}
+
+// HolderServer serves the rounds of a holder over http, it should be listened
+// by tls, e.g. http.ListenAndServeTLS.
+type HolderServer struct {
+	holder Holder
+	token  string
+}
+
+// NewHolderServer returns the server of the holder, the requests must carry
+// the bearer token, or a verified client certificate if the token is empty.
+func NewHolderServer(holder Holder, token string) *HolderServer {
+	return &HolderServer{holder: holder, token: token}
+}
+
+// ServeHTTP handles a round posted by the coordinator
+func (s *HolderServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if len(s.token) > 0 {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte("Bearer "+s.token)) != 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+	} else if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	req := new(Request)
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxRequestSize)).Decode(req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	reply, err := s.holder.Handle(req)
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"method":  req.Method,
+			"session": req.Session,
+			"err":     err,
+		}).Debug("Refused the request of the coordinator.")
+		reply = &Reply{Approved: false, Reason: err.Error()}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(reply)
+}
@@ -0,0 +1,188 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package threshold
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1/bitelliptic"
+)
+
+var (
+	// ErrInvalidThreshold invalid threshold or count of shares
+	ErrInvalidThreshold = errors.New("invalid threshold, should be in [1, count of shares]")
+
+	// ErrInvalidShare invalid share
+	ErrInvalidShare = errors.New("invalid share")
+
+	// ErrInvalidCommitments invalid commitments of the shares
+	ErrInvalidCommitments = errors.New("invalid commitments")
+)
+
+// Share is the value of the secret polynomial at the index.
+type Share struct {
+	Index uint32
+	Value []byte
+}
+
+// Split splits the secp256k1 private key into n shares by Shamir's scheme of
+// the threshold, the holders of 2*threshold-1 shares sign together without
+// recovering the key, see Coordinator. It returns the Feldman commitments of
+// the polynomial with the shares, the first one is the public key, so that
+// every share can be verified without revealing the key.
+func Split(seckey []byte, threshold, n int) ([]*Share, [][]byte, error) {
+	if threshold < 1 || threshold > n || n > 0xffff {
+		return nil, nil, ErrInvalidThreshold
+	}
+	if !secp256k1.SeckeyVerify(seckey) {
+		return nil, nil, secp256k1.ErrInvalidPrivateKey
+	}
+
+	curve := bitelliptic.S256()
+	coeffs, err := randomPoly(new(big.Int).SetBytes(seckey), threshold-1)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer clearScalars(coeffs...)
+
+	commitments := make([][]byte, threshold)
+	for i, c := range coeffs {
+		commitments[i] = curve.Marshal(curve.ScalarBaseMult(scalarBytes(c)))
+	}
+
+	shares := make([]*Share, n)
+	for i := 0; i < n; i++ {
+		y := evalPoly(coeffs, uint32(i+1))
+		shares[i] = &Share{Index: uint32(i + 1), Value: scalarBytes(y)}
+		y.SetInt64(0)
+	}
+	return shares, commitments, nil
+}
+
+// VerifyShare checks the share lies on the committed polynomial:
+// share * G == sum(commitments[j] * index^j).
+func VerifyShare(share *Share, commitments [][]byte) bool {
+	if share == nil || len(share.Value) != secp256k1.EcdsaPrivateKeyLength {
+		return false
+	}
+	px, py := publicShare(share.Index, commitments)
+	if px == nil {
+		return false
+	}
+	sx, sy := bitelliptic.S256().ScalarBaseMult(share.Value)
+	return sx.Cmp(px) == 0 && sy.Cmp(py) == 0
+}
+
+// publicShare returns the public key of the share at the index,
+// sum(commitments[j] * index^j), or nil if the commitments are invalid.
+func publicShare(index uint32, commitments [][]byte) (*big.Int, *big.Int) {
+	if index == 0 || len(commitments) == 0 {
+		return nil, nil
+	}
+	curve := bitelliptic.S256()
+	order := curve.Params().N
+
+	x := big.NewInt(int64(index))
+	power := big.NewInt(1)
+	var sumX, sumY *big.Int
+	for _, commitment := range commitments {
+		cx, cy := curve.Unmarshal(commitment)
+		if cx == nil || !curve.IsOnCurve(cx, cy) {
+			return nil, nil
+		}
+		px, py := curve.ScalarMult(cx, cy, scalarBytes(power))
+		if sumX == nil {
+			sumX, sumY = px, py
+		} else {
+			sumX, sumY = curve.Add(sumX, sumY, px, py)
+		}
+		power.Mul(power, x)
+		power.Mod(power, order)
+	}
+	return sumX, sumY
+}
+
+// randomPoly returns the coefficients of a random polynomial of the degree
+// whose constant is c.
+func randomPoly(c *big.Int, degree int) ([]*big.Int, error) {
+	order := bitelliptic.S256().Params().N
+	coeffs := make([]*big.Int, degree+1)
+	coeffs[0] = c
+	for i := 1; i <= degree; i++ {
+		r, err := rand.Int(rand.Reader, order)
+		if err != nil {
+			clearScalars(coeffs[:i]...)
+			return nil, err
+		}
+		coeffs[i] = r
+	}
+	return coeffs, nil
+}
+
+// evalPoly evaluates the polynomial at x by Horner's method.
+func evalPoly(coeffs []*big.Int, x uint32) *big.Int {
+	order := bitelliptic.S256().Params().N
+	bx := big.NewInt(int64(x))
+	y := new(big.Int)
+	for j := len(coeffs) - 1; j >= 0; j-- {
+		y.Mul(y, bx)
+		y.Add(y, coeffs[j])
+		y.Mod(y, order)
+	}
+	return y
+}
+
+// lagrange returns the coefficient of the value at the index to interpolate
+// the polynomial through the indices at zero.
+func lagrange(index uint32, indices []uint32) *big.Int {
+	order := bitelliptic.S256().Params().N
+	num, den := big.NewInt(1), big.NewInt(1)
+	xi := big.NewInt(int64(index))
+	for _, other := range indices {
+		if other == index {
+			continue
+		}
+		xj := big.NewInt(int64(other))
+		num.Mul(num, xj)
+		num.Mod(num, order)
+		den.Mul(den, new(big.Int).Sub(xj, xi))
+		den.Mod(den, order)
+	}
+	return num.Mod(num.Mul(num, den.ModInverse(den, order)), order)
+}
+
+// clearScalars zeroes the secret scalars.
+func clearScalars(scalars ...*big.Int) {
+	for _, s := range scalars {
+		if s != nil {
+			s.SetInt64(0)
+		}
+	}
+}
+
+// scalarBytes returns the 32 bytes big-endian encoding of the scalar.
+func scalarBytes(n *big.Int) []byte {
+	out := make([]byte, secp256k1.EcdsaPrivateKeyLength)
+	b := n.Bytes()
+	copy(out[len(out)-len(b):], b)
+	return out
+}
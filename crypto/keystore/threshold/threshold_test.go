@@ -0,0 +1,284 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package threshold
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1/vrf/secp256k1VRF"
+	"github.com/stretchr/testify/assert"
+)
+
+func approveAll(kind string, data, payload []byte) bool {
+	return true
+}
+
+func mockHolders(t *testing.T, shares []*Share, commitments [][]byte) []Holder {
+	holders := make([]Holder, len(shares))
+	for i, share := range shares {
+		holder, err := NewLocalHolder(share, commitments, approveAll)
+		assert.Nil(t, err)
+		holders[i] = holder
+	}
+	return holders
+}
+
+func TestSplit(t *testing.T) {
+	seckey := secp256k1.NewSeckey()
+	pub, err := secp256k1.GetPublicKey(seckey)
+	assert.Nil(t, err)
+
+	_, _, err = Split(seckey, 4, 3)
+	assert.Equal(t, ErrInvalidThreshold, err)
+
+	shares, commitments, err := Split(seckey, 3, 5)
+	assert.Nil(t, err)
+	assert.Equal(t, 5, len(shares))
+	assert.Equal(t, 3, len(commitments))
+	assert.Equal(t, pub, commitments[0])
+
+	for _, share := range shares {
+		assert.True(t, VerifyShare(share, commitments))
+	}
+	tampered := &Share{Index: shares[0].Index, Value: shares[1].Value}
+	assert.False(t, VerifyShare(tampered, commitments))
+	_, err = NewLocalHolder(tampered, commitments, approveAll)
+	assert.Equal(t, ErrInvalidShare, err)
+	_, err = NewLocalHolder(shares[0], commitments, nil)
+	assert.Equal(t, ErrNoPolicy, err)
+}
+
+func TestCoordinator(t *testing.T) {
+	seckey := secp256k1.NewSeckey()
+	pub, err := secp256k1.GetPublicKey(seckey)
+	assert.Nil(t, err)
+	shares, commitments, err := Split(seckey, 2, 4)
+	assert.Nil(t, err)
+	holders := mockHolders(t, shares, commitments)
+
+	_, err = NewCoordinator(commitments, holders[:2]...)
+	assert.Equal(t, ErrInvalidThreshold, err)
+
+	// the policy checks the payload of the data.
+	var payloads [][]byte
+	policy := func(kind string, data, payload []byte) bool {
+		payloads = append(payloads, payload)
+		return true
+	}
+	holders[3], err = NewLocalHolder(shares[3], commitments, policy)
+	assert.Nil(t, err)
+
+	// the first holder refuses, the other three sign.
+	holders[0], err = NewLocalHolder(shares[0], commitments, func(kind string, data, payload []byte) bool { return false })
+	assert.Nil(t, err)
+	coordinator, err := NewCoordinator(commitments, holders...)
+	assert.Nil(t, err)
+	assert.Equal(t, pub, coordinator.PublicKey())
+
+	for i := 0; i < 8; i++ {
+		msg := hash.Sha3256([]byte{byte(i)})
+		sig, err := coordinator.SignBlock(msg, []byte("block"))
+		assert.Nil(t, err)
+		ok, err := secp256k1.Verify(msg, sig, pub)
+		assert.Nil(t, err)
+		assert.True(t, ok)
+		recovered, err := secp256k1.RecoverECDSAPublicKey(msg, sig)
+		assert.Nil(t, err)
+		assert.Equal(t, pub, recovered)
+	}
+
+	ancestorHash, parentSeed := []byte("ancestor"), []byte("parent")
+	seed, proof, err := coordinator.GenerateRandomSeed(ancestorHash, parentSeed)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("block"), payloads[0])
+	assert.Equal(t, []byte("ancestorparent"), payloads[len(payloads)-1])
+	verifier, err := secp256k1VRF.NewVRFVerifierFromRawKey(pub)
+	assert.Nil(t, err)
+	index, err := verifier.ProofToHash(hash.Sha3256(ancestorHash, parentSeed), proof)
+	assert.Nil(t, err)
+	assert.Equal(t, index[:], seed)
+
+	// the VRF is unique, the seed is the same as evaluated by the key.
+	signer, err := secp256k1VRF.NewVRFSignerFromRawKey(seckey)
+	assert.Nil(t, err)
+	expected, _ := signer.Evaluate(hash.Sha3256(ancestorHash, parentSeed))
+	assert.Equal(t, expected[:], seed)
+
+	// only two holders approve.
+	holders[1], err = NewLocalHolder(shares[1], commitments, func(kind string, data, payload []byte) bool { return kind != KindBlock })
+	assert.Nil(t, err)
+	coordinator, err = NewCoordinator(commitments, holders...)
+	assert.Nil(t, err)
+	_, err = coordinator.SignBlock(hash.Sha3256([]byte("block")), []byte("block"))
+	assert.Equal(t, ErrNotEnoughHolders, err)
+	_, _, err = coordinator.GenerateRandomSeed(ancestorHash, parentSeed)
+	assert.Nil(t, err)
+}
+
+func TestCoordinator_Threshold(t *testing.T) {
+	seckey := secp256k1.NewSeckey()
+	pub, err := secp256k1.GetPublicKey(seckey)
+	assert.Nil(t, err)
+	shares, commitments, err := Split(seckey, 3, 5)
+	assert.Nil(t, err)
+
+	// the holders in any order.
+	holders := mockHolders(t, []*Share{shares[4], shares[1], shares[3], shares[0], shares[2]}, commitments)
+	coordinator, err := NewCoordinator(commitments, holders...)
+	assert.Nil(t, err)
+	msg := hash.Sha3256([]byte("block"))
+	sig, err := coordinator.SignBlock(msg, []byte("block"))
+	assert.Nil(t, err)
+	recovered, err := secp256k1.RecoverECDSAPublicKey(msg, sig)
+	assert.Nil(t, err)
+	assert.Equal(t, pub, recovered)
+
+	// a holder of another key fails the session.
+	other, otherCommitments, err := Split(secp256k1.NewSeckey(), 3, 5)
+	assert.Nil(t, err)
+	holders[0], err = NewLocalHolder(other[4], otherCommitments, approveAll)
+	assert.Nil(t, err)
+	coordinator, err = NewCoordinator(commitments, holders...)
+	assert.Nil(t, err)
+	_, err = coordinator.SignBlock(msg, []byte("block"))
+	assert.NotNil(t, err)
+	_, _, err = coordinator.GenerateRandomSeed([]byte("ancestor"), []byte("parent"))
+	assert.NotNil(t, err)
+}
+
+func TestLocalHolder_Rounds(t *testing.T) {
+	shares, commitments, err := Split(secp256k1.NewSeckey(), 1, 1)
+	assert.Nil(t, err)
+	holder, err := NewLocalHolder(shares[0], commitments, approveAll)
+	assert.Nil(t, err)
+
+	session := "0101010101010101010101010101010101010101010101010101010101010101"
+	data := "0202020202020202020202020202020202020202020202020202020202020202"
+	challenge := "0303030303030303030303030303030303030303030303030303030303030303"
+
+	// the rounds out of order are refused.
+	_, err = holder.Handle(&Request{Method: MethodSign, Session: session, Challenge: challenge})
+	assert.Equal(t, ErrInvalidRequest, err)
+	_, err = holder.Handle(&Request{Method: MethodOpen, Session: session, Kind: "tx", Data: data})
+	assert.Equal(t, ErrInvalidRequest, err)
+
+	reply, err := holder.Handle(&Request{Method: MethodOpen, Session: session, Kind: KindBlock, Data: data})
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(1), reply.Index)
+	_, err = holder.Handle(&Request{Method: MethodOpen, Session: session, Kind: KindBlock, Data: data})
+	assert.Equal(t, ErrInvalidRequest, err)
+	_, err = holder.Handle(&Request{Method: MethodDeal, Session: session, Signers: []uint32{2}})
+	assert.Equal(t, ErrInvalidRequest, err)
+
+	reply, err = holder.Handle(&Request{Method: MethodDeal, Session: session, Signers: []uint32{1}})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(reply.Deals))
+	_, err = holder.Handle(&Request{Method: MethodDeal, Session: session, Signers: []uint32{1}})
+	assert.Equal(t, ErrInvalidRequest, err)
+
+	// a deal tampered by the coordinator fails to decrypt.
+	deal := *reply.Deals[0]
+	deal.Data = deal.Data[:len(deal.Data)-2] + "00"
+	_, err = holder.Handle(&Request{Method: MethodMultiply, Session: session, Deals: []*Deal{&deal}})
+	assert.Equal(t, ErrInvalidDeal, err)
+
+	_, err = holder.Handle(&Request{Method: MethodMultiply, Session: session, Deals: reply.Deals})
+	assert.Nil(t, err)
+	_, err = holder.Handle(&Request{Method: MethodSign, Session: session, Challenge: challenge})
+	assert.Nil(t, err)
+
+	// the nonce is used once.
+	_, err = holder.Handle(&Request{Method: MethodSign, Session: session, Challenge: challenge})
+	assert.Equal(t, ErrInvalidRequest, err)
+}
+
+func TestRemoteHolder(t *testing.T) {
+	seckey := secp256k1.NewSeckey()
+	pub, err := secp256k1.GetPublicKey(seckey)
+	assert.Nil(t, err)
+	shares, commitments, err := Split(seckey, 2, 3)
+	assert.Nil(t, err)
+
+	holders := mockHolders(t, shares, commitments)
+	for i, holder := range holders[1:] {
+		srv := httptest.NewTLSServer(NewHolderServer(holder, "token"))
+		defer srv.Close()
+		remote, err := NewRemoteHolder(srv.URL, "token", srv.Client().Transport.(*http.Transport).TLSClientConfig)
+		assert.Nil(t, err)
+		holders[i+1] = remote
+	}
+	coordinator, err := NewCoordinator(commitments, holders...)
+	assert.Nil(t, err)
+
+	msg := hash.Sha3256([]byte("block"))
+	sig, err := coordinator.SignBlock(msg, []byte("block"))
+	assert.Nil(t, err)
+	recovered, err := secp256k1.RecoverECDSAPublicKey(msg, sig)
+	assert.Nil(t, err)
+	assert.Equal(t, pub, recovered)
+	_, _, err = coordinator.GenerateRandomSeed([]byte("ancestor"), []byte("parent"))
+	assert.Nil(t, err)
+
+	_, err = NewRemoteHolder("http://127.0.0.1:8686", "token", nil)
+	assert.Equal(t, ErrInsecureEndpoint, err)
+
+	srv := httptest.NewTLSServer(NewHolderServer(holders[0], "token"))
+	defer srv.Close()
+	remote, err := NewRemoteHolder(srv.URL, "wrong", srv.Client().Transport.(*http.Transport).TLSClientConfig)
+	assert.Nil(t, err)
+	_, err = remote.Handle(&Request{Method: MethodOpen})
+	assert.Equal(t, ErrInvalidReply, err)
+
+	remote, err = NewRemoteHolder(srv.URL, "token", srv.Client().Transport.(*http.Transport).TLSClientConfig)
+	assert.Nil(t, err)
+	_, err = remote.Handle(&Request{Method: MethodOpen})
+	assert.Equal(t, ErrRequestRefused, err)
+
+	// a server without a token accepts the verified client certificates only.
+	open := httptest.NewTLSServer(NewHolderServer(holders[0], ""))
+	defer open.Close()
+	remote, err = NewRemoteHolder(open.URL, "", open.Client().Transport.(*http.Transport).TLSClientConfig)
+	assert.Nil(t, err)
+	_, err = remote.Handle(&Request{Method: MethodOpen})
+	assert.Equal(t, ErrInvalidReply, err)
+}
+
+func TestShareFile(t *testing.T) {
+	shares, commitments, err := Split(secp256k1.NewSeckey(), 2, 3)
+	assert.Nil(t, err)
+
+	data, err := EncryptShare(shares[1], commitments, []byte("passphrase"))
+	assert.Nil(t, err)
+	share, decoded, err := DecryptShare(data, []byte("passphrase"))
+	assert.Nil(t, err)
+	assert.Equal(t, shares[1], share)
+	assert.Equal(t, commitments, decoded)
+	_, _, err = DecryptShare(data, []byte("wrong"))
+	assert.NotNil(t, err)
+
+	data, err = MarshalCommitments(commitments)
+	assert.Nil(t, err)
+	decoded, err = UnmarshalCommitments(data)
+	assert.Nil(t, err)
+	assert.Equal(t, commitments, decoded)
+}
@@ -0,0 +1,162 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package utils
+
+import (
+	"errors"
+	"os"
+	"runtime"
+	"sync"
+	"unsafe"
+)
+
+var (
+	// ErrSecureBufferDestroyed the secure buffer is destroyed
+	ErrSecureBufferDestroyed = errors.New("secure buffer is destroyed")
+
+	// ErrMemoryLockUnsupported locking memory is not supported on the platform
+	ErrMemoryLockUnsupported = errors.New("memory lock is not supported")
+)
+
+// SecureBuffer keeps secret bytes, e.g. decrypted private keys, in memory
+// locked from swap and excluded from core dumps where the platform allows.
+// The bytes are zeroed when the buffer is destroyed, explicitly or once it
+// is collected.
+type SecureBuffer struct {
+	mu sync.RWMutex
+
+	// whole pages of the allocation covering data, locked as a unit so
+	// that unlocking them never unlocks the pages of other buffers.
+	region []byte
+	data   []byte
+	locked bool
+}
+
+// NewSecureBuffer returns a zeroed secure buffer of size bytes.
+func NewSecureBuffer(size int) *SecureBuffer {
+	page := os.Getpagesize()
+	n := (size + page - 1) / page * page
+	if n == 0 {
+		n = page
+	}
+
+	// the go heap does not move objects, align the region to the pages
+	// inside an allocation one page larger.
+	mem := make([]byte, n+page)
+	off := 0
+	if r := int(uintptr(unsafe.Pointer(&mem[0])) % uintptr(page)); r != 0 {
+		off = page - r
+	}
+
+	b := &SecureBuffer{region: mem[off : off+n : off+n]}
+	b.data = b.region[:size:size]
+	b.locked = lockMemory(b.region) == nil
+	runtime.SetFinalizer(b, (*SecureBuffer).Destroy)
+	return b
+}
+
+// NewSecureBufferFrom returns a secure buffer holding a copy of data, the
+// caller should zero data if it is no longer needed.
+func NewSecureBufferFrom(data []byte) *SecureBuffer {
+	b := NewSecureBuffer(len(data))
+	copy(b.data, data)
+	return b
+}
+
+// Len returns the length of the bytes, 0 after destroyed.
+func (b *SecureBuffer) Len() int {
+	if b == nil {
+		return 0
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.data)
+}
+
+// Locked returns whether the memory of the buffer is locked.
+func (b *SecureBuffer) Locked() bool {
+	if b == nil {
+		return false
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.locked
+}
+
+// Bytes returns the bytes held, nil after destroyed. The slice must not be
+// kept beyond the lifetime of the buffer, prefer Use.
+func (b *SecureBuffer) Bytes() []byte {
+	if b == nil {
+		return nil
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.data
+}
+
+// Copy returns a copy of the bytes in ordinary memory, the caller should
+// zero it after use.
+func (b *SecureBuffer) Copy() []byte {
+	if b == nil {
+		return nil
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.data == nil {
+		return nil
+	}
+	out := make([]byte, len(b.data))
+	copy(out, b.data)
+	return out
+}
+
+// Use calls fn with the bytes held, the buffer cannot be destroyed until fn
+// returns. fn must not keep the slice.
+func (b *SecureBuffer) Use(fn func(data []byte) error) error {
+	if b == nil {
+		return ErrSecureBufferDestroyed
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.data == nil {
+		return ErrSecureBufferDestroyed
+	}
+	return fn(b.data)
+}
+
+// Destroy zeroes the bytes and unlocks the memory, it is safe to call it
+// more than once.
+func (b *SecureBuffer) Destroy() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.region == nil {
+		return
+	}
+	ZeroBytes(b.region)
+	if b.locked {
+		unlockMemory(b.region)
+		b.locked = false
+	}
+	b.region = nil
+	b.data = nil
+	runtime.SetFinalizer(b, nil)
+}
@@ -0,0 +1,65 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecureBuffer(t *testing.T) {
+	data := []byte{1, 2, 3, 4, 5}
+	b := NewSecureBufferFrom(data)
+	assert.Equal(t, len(data), b.Len())
+	assert.Equal(t, data, b.Bytes())
+
+	out := b.Copy()
+	assert.Equal(t, data, out)
+	out[0] = 0
+	assert.Equal(t, byte(1), b.Bytes()[0])
+
+	held := b.Bytes()
+	assert.Nil(t, b.Use(func(d []byte) error {
+		assert.Equal(t, data, d)
+		return nil
+	}))
+
+	b.Destroy()
+	assert.Equal(t, make([]byte, len(data)), held)
+	assert.Nil(t, b.Bytes())
+	assert.Nil(t, b.Copy())
+	assert.Equal(t, 0, b.Len())
+	assert.False(t, b.Locked())
+	assert.Equal(t, ErrSecureBufferDestroyed, b.Use(func(d []byte) error { return nil }))
+	b.Destroy()
+
+	var empty *SecureBuffer
+	assert.Nil(t, empty.Bytes())
+	assert.Equal(t, ErrSecureBufferDestroyed, empty.Use(func(d []byte) error { return nil }))
+}
+
+func TestSecureBuffer_Sizes(t *testing.T) {
+	for _, size := range []int{0, 32, 4096, 5000} {
+		b := NewSecureBuffer(size)
+		assert.Equal(t, size, b.Len())
+		assert.Equal(t, make([]byte, size), b.Bytes())
+		b.Destroy()
+	}
+}
@@ -0,0 +1,382 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package light
+
+import (
+	"bytes"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/core/state"
+	"github.com/nebulasio/go-nebulas/light/pb"
+	"github.com/nebulasio/go-nebulas/net"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// Client syncs the header chain only and verifies the states served by full nodes
+// with merkle proofs against the state roots in headers.
+type Client struct {
+	chain      *core.BlockChain
+	netService net.Service
+	storage    storage.Storage
+	verifier   *Verifier
+	quitCh     chan bool
+	messageCh  chan net.Message
+
+	mu   sync.RWMutex
+	tail *core.Block
+
+	proofMu sync.Mutex
+	nextID  uint64
+	pending map[uint64]chan *lightpb.Proof
+}
+
+// NewClient return new Client, the header chain starts from the genesis of the chain,
+// the headers at the heights of the trusted checkpoints must have their hashes.
+func NewClient(chain *core.BlockChain, netService net.Service, checkpoints map[uint64]byteutils.Hash) *Client {
+	return &Client{
+		chain:      chain,
+		netService: netService,
+		storage:    chain.Storage(),
+		verifier:   NewVerifier(chain, checkpoints),
+		quitCh:     make(chan bool, 1),
+		messageCh:  make(chan net.Message, 128),
+		pending:    make(map[uint64]chan *lightpb.Proof),
+	}
+}
+
+// Setup load the tail of header chain.
+func (c *Client) Setup() error {
+	c.tail = c.chain.GenesisBlock()
+	if err := c.verifier.Trust(c.tail); err != nil {
+		return err
+	}
+	hash, err := c.storage.Get([]byte(LightTail))
+	if err == storage.ErrKeyNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	tail, err := c.loadHeader(hash)
+	if err != nil {
+		return err
+	}
+	c.tail = tail
+
+	logging.CLog().WithFields(logrus.Fields{
+		"tail": c.tail,
+	}).Info("Tail Header.")
+	return nil
+}
+
+// Start start light client.
+func (c *Client) Start() {
+	logging.CLog().Info("Starting Light Client...")
+
+	c.netService.Register(net.NewSubscriber(c, c.messageCh, false, net.HeadersResponse, net.MessageWeightZero))
+	c.netService.Register(net.NewSubscriber(c, c.messageCh, false, net.ProofResponse, net.MessageWeightZero))
+
+	go c.loop()
+}
+
+// Stop stop light client.
+func (c *Client) Stop() {
+	logging.CLog().Info("Stopping Light Client...")
+
+	c.netService.Deregister(net.NewSubscriber(c, c.messageCh, false, net.HeadersResponse, net.MessageWeightZero))
+	c.netService.Deregister(net.NewSubscriber(c, c.messageCh, false, net.ProofResponse, net.MessageWeightZero))
+
+	c.quitCh <- true
+}
+
+func (c *Client) loop() {
+	logging.CLog().Info("Started Light Client.")
+	ticker := time.NewTicker(HeadersSyncInterval)
+	defer ticker.Stop()
+
+	c.requestHeaders()
+	for {
+		select {
+		case <-c.quitCh:
+			logging.CLog().Info("Stopped Light Client.")
+			return
+		case <-ticker.C:
+			c.requestHeaders()
+		case message := <-c.messageCh:
+			switch message.MessageType() {
+			case net.HeadersResponse:
+				c.onHeaders(message)
+			case net.ProofResponse:
+				c.onProof(message)
+			}
+		}
+	}
+}
+
+// TailBlock return the tail of header chain, the block has no transactions.
+func (c *Client) TailBlock() *core.Block {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.tail
+}
+
+// GetHeaderByHeight return the header at the height on header chain.
+func (c *Client) GetHeaderByHeight(height uint64) (*core.Block, error) {
+	if height == c.chain.GenesisBlock().Height() {
+		return c.chain.GenesisBlock(), nil
+	}
+	hash, err := c.storage.Get(append([]byte(LightHeightPrefix), byteutils.FromUint64(height)...))
+	if err != nil {
+		return nil, ErrHeaderNotFound
+	}
+	return c.loadHeader(hash)
+}
+
+// GetHeader return the header with the hash.
+func (c *Client) GetHeader(hash byteutils.Hash) (*core.Block, error) {
+	if hash.Equals(c.chain.GenesisBlock().Hash()) {
+		return c.chain.GenesisBlock(), nil
+	}
+	return c.loadHeader(hash)
+}
+
+func (c *Client) loadHeader(hash []byte) (*core.Block, error) {
+	value, err := c.storage.Get(append([]byte(LightHeaderPrefix), hash...))
+	if err != nil {
+		return nil, ErrHeaderNotFound
+	}
+	pbBlock := new(corepb.Block)
+	if err := proto.Unmarshal(value, pbBlock); err != nil {
+		return nil, err
+	}
+	block := new(core.Block)
+	if err := block.FromProto(pbBlock); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+func (c *Client) requestHeaders() {
+	request := &lightpb.GetHeaders{
+		From:  c.TailBlock().Height() + 1,
+		Count: MaxHeadersPerResponse,
+	}
+	data, err := proto.Marshal(request)
+	if err != nil {
+		return
+	}
+	c.netService.SendMessageToPeers(net.HeadersRequest, data, net.MessagePriorityLow, new(net.RandomPeerFilter))
+}
+
+func (c *Client) storeHeader(block *core.Block, pbBlock *corepb.Block) error {
+	value, err := proto.Marshal(pbBlock)
+	if err != nil {
+		return err
+	}
+	if err := c.storage.Put(append([]byte(LightHeaderPrefix), block.Hash()...), value); err != nil {
+		return err
+	}
+	if err := c.storage.Put(append([]byte(LightHeightPrefix), byteutils.FromUint64(block.Height())...), block.Hash()); err != nil {
+		return err
+	}
+	return c.storage.Put([]byte(LightTail), block.Hash())
+}
+
+func (c *Client) onHeaders(message net.Message) {
+	headers := new(lightpb.Headers)
+	if err := proto.Unmarshal(message.Data(), headers); err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"err": err,
+			"pid": message.MessageFrom(),
+		}).Debug("Invalid Headers message data.")
+		return
+	}
+	if err := c.verifier.AddDynasties(headers.Dynasties); err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"err": err,
+			"pid": message.MessageFrom(),
+		}).Debug("Invalid dynasties in Headers message.")
+		return
+	}
+	if err := c.appendHeaders(headers.Blocks); err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"err": err,
+			"pid": message.MessageFrom(),
+		}).Debug("Failed to append headers.")
+	}
+}
+
+// appendHeaders append the headers following the tail. If the first one does not
+// follow the tail, the peer is on another fork and the tail steps back.
+func (c *Client) appendHeaders(blocks []*corepb.Block) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, pbBlock := range blocks {
+		if pbBlock.Header == nil {
+			return ErrInvalidHeader
+		}
+		if !c.tail.Hash().Equals(pbBlock.Header.ParentHash) {
+			if i == 0 && pbBlock.Height == c.tail.Height()+1 {
+				return c.revertTail()
+			}
+			return ErrInvalidHeader
+		}
+		block, err := c.verifier.VerifyHeader(c.tail, pbBlock)
+		if err != nil {
+			return err
+		}
+		if err := c.storeHeader(block, pbBlock); err != nil {
+			return err
+		}
+		c.tail = block
+	}
+	if len(blocks) > 0 {
+		metricsLightHeaderHeight.Update(int64(c.tail.Height()))
+		logging.VLog().WithFields(logrus.Fields{
+			"tail":  c.tail,
+			"count": len(blocks),
+		}).Debug("Appended headers.")
+	}
+	return nil
+}
+
+func (c *Client) revertTail() error {
+	genesis := c.chain.GenesisBlock()
+	if c.tail.Hash().Equals(genesis.Hash()) {
+		return ErrInvalidHeader
+	}
+	parent, err := c.GetHeader(c.tail.ParentHash())
+	if err != nil {
+		return err
+	}
+	if err := c.storage.Put([]byte(LightTail), parent.Hash()); err != nil {
+		return err
+	}
+	logging.VLog().WithFields(logrus.Fields{
+		"tail":   parent,
+		"revert": c.tail,
+	}).Debug("Reverted tail header.")
+	c.tail = parent
+	return nil
+}
+
+func (c *Client) onProof(message net.Message) {
+	proof := new(lightpb.Proof)
+	if err := proto.Unmarshal(message.Data(), proof); err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"err": err,
+			"pid": message.MessageFrom(),
+		}).Debug("Invalid Proof message data.")
+		return
+	}
+
+	c.proofMu.Lock()
+	ch, ok := c.pending[proof.Id]
+	delete(c.pending, proof.Id)
+	c.proofMu.Unlock()
+	if ok {
+		ch <- proof
+	}
+}
+
+// verifyStateValue check the value of the key is in the state trie with the root.
+func verifyStateValue(root []byte, key []byte, value []byte, pb *corepb.MerkleProof) error {
+	proof := new(state.MerkleProof)
+	if err := proof.FromProto(pb); err != nil {
+		return err
+	}
+	return state.Verify(root, key, value, proof)
+}
+
+// verifyProof check the account and its storage value are in the state of the header.
+func verifyProof(header *core.Block, proof *lightpb.Proof) (*corepb.Account, error) {
+	if len(proof.Account) == 0 {
+		return nil, ErrProofNotFound
+	}
+	if err := verifyStateValue(header.StateRoot(), proof.Address, proof.Account, proof.AccountProof); err != nil {
+		return nil, err
+	}
+	account := new(corepb.Account)
+	if err := proto.Unmarshal(proof.Account, account); err != nil {
+		return nil, err
+	}
+	if len(proof.Key) == 0 {
+		return account, nil
+	}
+	if len(proof.Value) == 0 {
+		return nil, ErrProofNotFound
+	}
+	if err := verifyStateValue(account.VarsHash, proof.Key, proof.Value, proof.StorageProof); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// GetProvedAccount request the account, and the contract storage value at the key if it is
+// given, in the state of the header from a full node, and verify them with the merkle proofs.
+func (c *Client) GetProvedAccount(blockHash byteutils.Hash, address byteutils.Hash, key []byte) (*corepb.Account, []byte, error) {
+	header, err := c.GetHeader(blockHash)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c.proofMu.Lock()
+	c.nextID++
+	id := c.nextID
+	ch := make(chan *lightpb.Proof, 1)
+	c.pending[id] = ch
+	c.proofMu.Unlock()
+
+	request := &lightpb.GetProof{
+		Id:        id,
+		BlockHash: blockHash,
+		Address:   address,
+		Key:       key,
+	}
+	data, err := proto.Marshal(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	c.netService.SendMessageToPeers(net.ProofRequest, data, net.MessagePriorityLow, new(net.RandomPeerFilter))
+
+	select {
+	case proof := <-ch:
+		if !blockHash.Equals(proof.BlockHash) || !address.Equals(proof.Address) || !bytes.Equal(key, proof.Key) {
+			return nil, nil, ErrProofNotFound
+		}
+		account, err := verifyProof(header, proof)
+		if err != nil {
+			return nil, nil, err
+		}
+		return account, proof.Value, nil
+	case <-time.After(ProofTimeout):
+		c.proofMu.Lock()
+		delete(c.pending, id)
+		c.proofMu.Unlock()
+		return nil, nil, ErrProofTimeout
+	}
+}
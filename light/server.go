@@ -0,0 +1,250 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package light
+
+import (
+	"bytes"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/common/trie"
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/core/state"
+	"github.com/nebulasio/go-nebulas/light/pb"
+	"github.com/nebulasio/go-nebulas/net"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// Server serves the headers and merkle proofs requested by light peers.
+type Server struct {
+	chain      *core.BlockChain
+	netService net.Service
+	quitCh     chan bool
+	messageCh  chan net.Message
+}
+
+// NewServer return new Server.
+func NewServer(chain *core.BlockChain, netService net.Service) *Server {
+	return &Server{
+		chain:      chain,
+		netService: netService,
+		quitCh:     make(chan bool, 1),
+		messageCh:  make(chan net.Message, 128),
+	}
+}
+
+// Start start light server.
+func (s *Server) Start() {
+	logging.CLog().Info("Starting Light Server...")
+
+	s.netService.Register(net.NewSubscriber(s, s.messageCh, false, net.HeadersRequest, net.MessageWeightZero))
+	s.netService.Register(net.NewSubscriber(s, s.messageCh, false, net.ProofRequest, net.MessageWeightZero))
+
+	go s.loop()
+}
+
+// Stop stop light server.
+func (s *Server) Stop() {
+	logging.CLog().Info("Stopping Light Server...")
+
+	s.netService.Deregister(net.NewSubscriber(s, s.messageCh, false, net.HeadersRequest, net.MessageWeightZero))
+	s.netService.Deregister(net.NewSubscriber(s, s.messageCh, false, net.ProofRequest, net.MessageWeightZero))
+
+	s.quitCh <- true
+}
+
+func (s *Server) loop() {
+	logging.CLog().Info("Started Light Server.")
+	for {
+		select {
+		case <-s.quitCh:
+			logging.CLog().Info("Stopped Light Server.")
+			return
+		case message := <-s.messageCh:
+			switch message.MessageType() {
+			case net.HeadersRequest:
+				s.onGetHeaders(message)
+			case net.ProofRequest:
+				s.onGetProof(message)
+			}
+		}
+	}
+}
+
+// headers return the canonical blocks from the height without transactions, with
+// the dynasties of the blocks and of the parent of the first one.
+func (s *Server) headers(from uint64, count uint32) (*lightpb.Headers, error) {
+	if count > MaxHeadersPerResponse {
+		count = MaxHeadersPerResponse
+	}
+	headers := &lightpb.Headers{Tail: s.chain.TailBlock().Height()}
+	if from > 0 {
+		if parent := s.chain.GetBlockOnCanonicalChainByHeight(from - 1); parent != nil {
+			s.addDynasty(headers, parent)
+		}
+	}
+	for h := from; h < from+uint64(count); h++ {
+		block := s.chain.GetBlockOnCanonicalChainByHeight(h)
+		if block == nil {
+			break
+		}
+		s.addDynasty(headers, block)
+		pb, err := block.ToProto()
+		if err != nil {
+			return nil, err
+		}
+		pbBlock := pb.(*corepb.Block)
+		pbBlock.Transactions = nil
+		headers.Blocks = append(headers.Blocks, pbBlock)
+	}
+	return headers, nil
+}
+
+// addDynasty add the dynasty of the block to the headers unless it is added, the
+// dynasty of a pruned state is left out, the light peer asks another one.
+func (s *Server) addDynasty(headers *lightpb.Headers, block *core.Block) {
+	root := block.ConsensusRoot().DynastyRoot
+	for _, dynasty := range headers.Dynasties {
+		if bytes.Equal(dynasty.Root, root) {
+			return
+		}
+	}
+	members, err := block.WorldState().Dynasty()
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"err":   err,
+			"block": block,
+		}).Debug("Failed to get the dynasty of the block.")
+		return
+	}
+	dynasty := &lightpb.Dynasty{Root: root}
+	for _, member := range members {
+		dynasty.Members = append(dynasty.Members, member)
+	}
+	headers.Dynasties = append(headers.Dynasties, dynasty)
+}
+
+func (s *Server) onGetHeaders(message net.Message) {
+	request := new(lightpb.GetHeaders)
+	if err := proto.Unmarshal(message.Data(), request); err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"err": err,
+			"pid": message.MessageFrom(),
+		}).Debug("Invalid GetHeaders message data.")
+		s.netService.ClosePeer(message.MessageFrom(), ErrInvalidGetHeadersMessageData)
+		return
+	}
+
+	headers, err := s.headers(request.From, request.Count)
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"err":  err,
+			"from": request.From,
+		}).Debug("Failed to collect headers.")
+		return
+	}
+	s.send(net.HeadersResponse, headers, message.MessageFrom())
+}
+
+// proveKey return the value of the key in the state trie and the merkle proof of it,
+// the value and proof are nil if the key is not in the trie.
+func proveKey(stor storage.Storage, root []byte, key []byte) ([]byte, *corepb.MerkleProof, error) {
+	proof, err := state.Proof(stor, root, key)
+	if err == trie.ErrNotFound {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	pb, err := proof.ToProto()
+	if err != nil {
+		return nil, nil, err
+	}
+	return proof.Value(), pb.(*corepb.MerkleProof), nil
+}
+
+// prove return the proofs of the account and its storage at the key in the block state.
+func (s *Server) prove(request *lightpb.GetProof) (*lightpb.Proof, error) {
+	proof := &lightpb.Proof{
+		Id:        request.Id,
+		BlockHash: request.BlockHash,
+		Address:   request.Address,
+		Key:       request.Key,
+	}
+	block := s.chain.GetBlock(request.BlockHash)
+	if block == nil {
+		return proof, nil
+	}
+
+	var err error
+	proof.Account, proof.AccountProof, err = proveKey(s.chain.StateStorage(), block.StateRoot(), request.Address)
+	if err != nil || proof.Account == nil || len(request.Key) == 0 {
+		return proof, err
+	}
+	account := new(corepb.Account)
+	if err := proto.Unmarshal(proof.Account, account); err != nil {
+		return nil, err
+	}
+	proof.Value, proof.StorageProof, err = proveKey(s.chain.StateStorage(), account.VarsHash, request.Key)
+	return proof, err
+}
+
+func (s *Server) onGetProof(message net.Message) {
+	request := new(lightpb.GetProof)
+	if err := proto.Unmarshal(message.Data(), request); err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"err": err,
+			"pid": message.MessageFrom(),
+		}).Debug("Invalid GetProof message data.")
+		s.netService.ClosePeer(message.MessageFrom(), ErrInvalidGetProofMessageData)
+		return
+	}
+
+	proof, err := s.prove(request)
+	if err != nil {
+		// the state may be pruned, the light peer times out and asks another one.
+		logging.VLog().WithFields(logrus.Fields{
+			"err":   err,
+			"block": request.BlockHash,
+		}).Debug("Failed to prove account.")
+		return
+	}
+	metricsLightServedProof.Inc(1)
+	s.send(net.ProofResponse, proof, message.MessageFrom())
+}
+
+func (s *Server) send(name string, msg proto.Message, peerID string) {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"err":  err,
+			"name": name,
+		}).Debug("Failed to serialize light message.")
+		return
+	}
+	if err := s.netService.SendMsg(name, data, peerID, net.MessagePriorityLow); err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"err":  err,
+			"name": name,
+			"pid":  peerID,
+		}).Debug("Failed to send light message.")
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package light
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/consensus/dpos"
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/light/pb"
+	"github.com/nebulasio/go-nebulas/neblet/pb"
+	"github.com/nebulasio/go-nebulas/net"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/stretchr/testify/assert"
+)
+
+type Neb struct {
+	config    *nebletpb.Config
+	chain     *core.BlockChain
+	ns        net.Service
+	genesis   *corepb.Genesis
+	storage   storage.Storage
+	consensus core.Consensus
+	emitter   *core.EventEmitter
+}
+
+func mockNeb(t *testing.T) *Neb {
+	// the backend keeps the state nodes in their column family as rocksdb does.
+	storage := storage.NewMemoryBackend()
+	genesisConf := MockGenesisConf()
+	dpos := dpos.NewDpos()
+	neb := &Neb{
+		genesis:   genesisConf,
+		storage:   storage,
+		emitter:   core.NewEventEmitter(1024),
+		consensus: dpos,
+		ns:        &mockNetService{},
+		config: &nebletpb.Config{
+			Chain: &nebletpb.ChainConfig{
+				ChainId: genesisConf.Meta.ChainId,
+			},
+		},
+	}
+
+	chain, err := core.NewBlockChain(neb)
+	assert.Nil(t, err)
+	neb.chain = chain
+	assert.Nil(t, dpos.Setup(neb))
+	assert.Nil(t, chain.Setup(neb))
+	return neb
+}
+
+func (n *Neb) Config() *nebletpb.Config            { return n.config }
+func (n *Neb) BlockChain() *core.BlockChain        { return n.chain }
+func (n *Neb) NetService() net.Service             { return n.ns }
+func (n *Neb) IsActiveSyncing() bool               { return false }
+func (n *Neb) AccountManager() core.AccountManager { return nil }
+func (n *Neb) Genesis() *corepb.Genesis            { return n.genesis }
+func (n *Neb) SetGenesis(genesis *corepb.Genesis)  { n.genesis = genesis }
+func (n *Neb) Storage() storage.Storage            { return n.storage }
+func (n *Neb) EventEmitter() *core.EventEmitter    { return n.emitter }
+func (n *Neb) Consensus() core.Consensus           { return n.consensus }
+func (n *Neb) Nvm() core.NVM                       { return nil }
+func (n *Neb) StartPprof(string) error             { return nil }
+
+var (
+	// must be order by address.hash
+	MockDynasty = []string{
+		"n1FF1nz6tarkDVwWQkMnnwFPuPKUaQTdptE",
+		"n1GmkKH6nBMw4rrjt16RrJ9WcgvKUtAZP1s",
+		"n1H4MYms9F55ehcvygwWE71J8tJC4CRr2so",
+		"n1JAy4X6KKLCNiTd7MWMRsVBjgdVq5WCCpf",
+		"n1LkDi2gGMqPrjYcczUiweyP4RxTB6Go1qS",
+		"n1LmP9K8pFF33fgdgHZonFEMsqZinJ4EUqk",
+		"n1MNXBKm6uJ5d76nJTdRvkPNVq85n6CnXAi",
+		"n1NrMKTYESZRCwPFDLFKiKREzZKaN1nhQvz",
+		"n1NwoSCDFwFL2981k6j9DPooigW33hjAgTa",
+		"n1PfACnkcfJoNm1Pbuz55pQCwueW1BYs83m",
+		"n1Q8mxXp4PtHaXtebhY12BnHEwu4mryEkXH",
+		"n1RYagU8n3JSuV4R7q4Qs5gQJ3pEmrZd6cJ",
+		"n1SAQy3ix1pZj8MPzNeVqpAmu1nCVqb5w8c",
+		"n1SHufJdxt2vRWGKAxwPETYfEq3MCQXnEXE",
+		"n1SSda41zGr9FKF5DJNE2ryY1ToNrndMauN",
+		"n1TmQtaCn3PNpk4f4ycwrBxCZFSVKvwBtzc",
+		"n1UM7z6MqnGyKEPvUpwrfxZpM1eB7UpzmLJ",
+		"n1UnCsJZjQiKyQiPBr7qG27exqCLuWUf1d7",
+		"n1XkoVVjswb5Gek3rRufqjKNpwrDdsnQ7Hq",
+		"n1cYKNHTeVW9v1NQRWuhZZn9ETbqAYozckh",
+		"n1dYu2BXgV3xgUh8LhZu8QDDNr15tz4hVDv",
+	}
+)
+
+// MockGenesisConf return mock genesis conf
+func MockGenesisConf() *corepb.Genesis {
+	return &corepb.Genesis{
+		Meta: &corepb.GenesisMeta{ChainId: 100},
+		Consensus: &corepb.GenesisConsensus{
+			Dpos: &corepb.GenesisConsensusDpos{
+				Dynasty: MockDynasty,
+			},
+		},
+		TokenDistribution: []*corepb.GenesisTokenDistribution{
+			&corepb.GenesisTokenDistribution{
+				Address: "n1FF1nz6tarkDVwWQkMnnwFPuPKUaQTdptE",
+				Value:   "5000000000000000000000000",
+			},
+			&corepb.GenesisTokenDistribution{
+				Address: "n1GmkKH6nBMw4rrjt16RrJ9WcgvKUtAZP1s",
+				Value:   "5000000000000000000000000",
+			},
+		},
+	}
+}
+
+type mockNetService struct{}
+
+func (n mockNetService) Start() error { return nil }
+func (n mockNetService) Stop()        {}
+
+func (n mockNetService) Node() *net.Node { return nil }
+
+func (n mockNetService) Register(...*net.Subscriber)   {}
+func (n mockNetService) Deregister(...*net.Subscriber) {}
+
+func (n mockNetService) Broadcast(name string, msg net.Serializable, priority int) {}
+func (n mockNetService) Relay(name string, msg net.Serializable, priority int)     {}
+func (n mockNetService) SendMsg(name string, msg []byte, target string, priority int) error {
+	return nil
+}
+
+func (n mockNetService) SendMessageToPeers(messageName string, data []byte, priority int, filter net.PeerFilterAlgorithm) []string {
+	return make([]string, 0)
+}
+func (n mockNetService) SendMessageToPeer(messageName string, data []byte, priority int, peerID string) error {
+	return nil
+}
+
+func (n mockNetService) ClosePeer(peerID string, reason error) {}
+
+func (n mockNetService) AddPeer(addr string) (string, error) { return "", nil }
+
+func (n mockNetService) RemovePeer(peerID string) error { return nil }
+
+func (n mockNetService) BroadcastNetworkID([]byte) {}
+
+func TestServer_Headers(t *testing.T) {
+	neb := mockNeb(t)
+	server := NewServer(neb.chain, neb.ns)
+
+	genesis := neb.chain.GenesisBlock()
+	headers, err := server.headers(genesis.Height(), 1000)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(headers.Blocks))
+	assert.Nil(t, headers.Blocks[0].Transactions)
+	hash, err := core.HashPbBlock(headers.Blocks[0])
+	assert.Nil(t, err)
+	assert.Equal(t, genesis.Hash(), hash)
+
+	// the headers not following the tail are rejected.
+	client := NewClient(neb.chain, neb.ns, nil)
+	assert.Nil(t, client.Setup())
+	assert.Equal(t, genesis.Hash(), client.TailBlock().Hash())
+	forged := proto.Clone(headers.Blocks[0]).(*corepb.Block)
+	forged.Height = genesis.Height() + 1
+	forged.Header.ParentHash = genesis.Hash()
+	assert.Equal(t, ErrInvalidHeader, client.appendHeaders([]*corepb.Block{forged}))
+	assert.Equal(t, genesis.Hash(), client.TailBlock().Hash())
+}
+
+func TestServer_Prove(t *testing.T) {
+	neb := mockNeb(t)
+	server := NewServer(neb.chain, neb.ns)
+	genesis := neb.chain.GenesisBlock()
+
+	addr, err := core.AddressParse(MockDynasty[0])
+	assert.Nil(t, err)
+	proof, err := server.prove(&lightpb.GetProof{Id: 1, BlockHash: genesis.Hash(), Address: addr.Bytes()})
+	assert.Nil(t, err)
+	account, err := verifyProof(genesis, proof)
+	assert.Nil(t, err)
+	balance, err := util.NewUint128FromFixedSizeByteSlice(account.Balance)
+	assert.Nil(t, err)
+	assert.Equal(t, "5000000000000000000000000", balance.String())
+
+	// the account tampered by the full node is detected.
+	tampered := proto.Clone(account).(*corepb.Account)
+	tampered.Balance = make([]byte, len(account.Balance))
+	proof.Account, _ = proto.Marshal(tampered)
+	_, err = verifyProof(genesis, proof)
+	assert.NotNil(t, err)
+
+	// the accounts not in state cannot be proved.
+	addr, err = core.AddressParse(MockDynasty[20])
+	assert.Nil(t, err)
+	proof, err = server.prove(&lightpb.GetProof{Id: 2, BlockHash: genesis.Hash(), Address: addr.Bytes()})
+	assert.Nil(t, err)
+	_, err = verifyProof(genesis, proof)
+	assert.Equal(t, ErrProofNotFound, err)
+}
@@ -0,0 +1,66 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package light
+
+import (
+	"errors"
+	"time"
+
+	"github.com/nebulasio/go-nebulas/metrics"
+)
+
+// Error Types
+var (
+	ErrInvalidGetHeadersMessageData = errors.New("invalid GetHeaders message data")
+	ErrInvalidGetProofMessageData   = errors.New("invalid GetProof message data")
+	ErrInvalidHeader                = errors.New("invalid block header")
+	ErrHeaderNotFound               = errors.New("cannot find the block header")
+	ErrProofTimeout                 = errors.New("timeout to wait for the merkle proof")
+	ErrProofNotFound                = errors.New("the full node cannot prove the key")
+	ErrInvalidDynasty               = errors.New("the dynasty members do not build the dynasty root")
+	ErrDynastyNotFound              = errors.New("cannot find the dynasty of the header")
+	ErrUntrustedHeader              = errors.New("the header does not match the trusted checkpoint")
+	ErrUnverifiableHeader           = errors.New("the consensus cannot verify headers without states")
+	ErrInvalidCheckpoint            = errors.New("invalid trusted checkpoint, should be height:hash")
+)
+
+// Contants
+const (
+	MaxHeadersPerResponse = 192
+	HeadersSyncInterval   = 5 * time.Second
+	ProofTimeout          = 10 * time.Second
+)
+
+// storage: key -> value
+// light_tail -> hash of the header chain tail
+// lightheight_ + height -> hash of the header at the height
+// lightheader_ + hash -> header, a block without transactions
+
+// Light client keys in storage
+const (
+	LightTail         = "light_tail"
+	LightHeightPrefix = "lightheight_"
+	LightHeaderPrefix = "lightheader_"
+)
+
+// Metrics
+var (
+	metricsLightHeaderHeight = metrics.NewGauge("neb.light.height")
+	metricsLightServedProof  = metrics.NewCounter("neb.light.proof")
+)
@@ -0,0 +1,205 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package light
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/nebulasio/go-nebulas/common/trie"
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/light/pb"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+)
+
+// Verifier verifies a header chain without the states of its blocks. A header is
+// signed by the proposer of its slot in its dynasty, the members of the dynasty
+// are served along with the headers and checked against the dynasty root, and
+// the dynasty follows the one of the parent by the rules of the consensus. A
+// dynasty change decided by the parent state is only accepted at a trusted
+// checkpoint, a header whose hash is configured.
+type Verifier struct {
+	chain       *core.BlockChain
+	checkpoints map[uint64]byteutils.Hash
+
+	mu sync.Mutex
+	// dynasty root -> members in the order of the trie
+	dynasties map[string][]byteutils.Hash
+}
+
+// NewVerifier return a verifier of the header chains of the chain, the headers
+// at the heights of the checkpoints must have their hashes.
+func NewVerifier(chain *core.BlockChain, checkpoints map[uint64]byteutils.Hash) *Verifier {
+	if checkpoints == nil {
+		checkpoints = make(map[uint64]byteutils.Hash)
+	}
+	return &Verifier{
+		chain:       chain,
+		checkpoints: checkpoints,
+		dynasties:   make(map[string][]byteutils.Hash),
+	}
+}
+
+// ParseCheckpoints parses the trusted checkpoints in "height:hash" format.
+func ParseCheckpoints(values []string) (map[uint64]byteutils.Hash, error) {
+	checkpoints := make(map[uint64]byteutils.Hash)
+	for _, v := range values {
+		parts := strings.Split(v, ":")
+		if len(parts) != 2 {
+			return nil, ErrInvalidCheckpoint
+		}
+		height, err := strconv.ParseUint(parts[0], 10, 64)
+		if err != nil {
+			return nil, ErrInvalidCheckpoint
+		}
+		hash, err := byteutils.FromHex(parts[1])
+		if err != nil || len(hash) == 0 {
+			return nil, ErrInvalidCheckpoint
+		}
+		checkpoints[height] = hash
+	}
+	return checkpoints, nil
+}
+
+// Trust add the dynasty of the block whose state is kept, e.g. the genesis or a sync point.
+func (v *Verifier) Trust(block *core.Block) error {
+	members, err := block.WorldState().Dynasty()
+	if err != nil {
+		return err
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.dynasties[byteutils.Hex(block.ConsensusRoot().DynastyRoot)] = members
+	return nil
+}
+
+// AddDynasties add the dynasties served along with the headers, their members
+// must build the dynasty roots.
+func (v *Verifier) AddDynasties(dynasties []*lightpb.Dynasty) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	for _, dynasty := range dynasties {
+		root := byteutils.Hex(dynasty.Root)
+		if _, ok := v.dynasties[root]; ok {
+			continue
+		}
+		stor, err := storage.NewMemoryStorage()
+		if err != nil {
+			return err
+		}
+		dynastyTrie, err := trie.NewTrie(nil, stor, false)
+		if err != nil {
+			return err
+		}
+		for _, member := range dynasty.Members {
+			if _, err := dynastyTrie.Put(member, member); err != nil {
+				return err
+			}
+		}
+		if !bytes.Equal(dynastyTrie.RootHash(), dynasty.Root) {
+			return ErrInvalidDynasty
+		}
+		members, err := traverseDynasty(dynastyTrie)
+		if err != nil {
+			return err
+		}
+		v.dynasties[root] = members
+	}
+	return nil
+}
+
+func (v *Verifier) dynasty(root byteutils.Hash) ([]byteutils.Hash, bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	members, ok := v.dynasties[byteutils.Hex(root)]
+	return members, ok
+}
+
+// VerifyHeader check the header follows the parent and is signed by the proposer
+// of its slot in its dynasty.
+func (v *Verifier) VerifyHeader(parent *core.Block, pbBlock *corepb.Block) (*core.Block, error) {
+	if pbBlock.Header == nil {
+		return nil, ErrInvalidHeader
+	}
+	block := new(core.Block)
+	if err := block.FromProto(pbBlock); err != nil {
+		return nil, err
+	}
+	hash, err := core.HashPbBlock(pbBlock)
+	if err != nil {
+		return nil, err
+	}
+	if !hash.Equals(block.Hash()) || block.ChainID() != v.chain.ChainID() ||
+		block.Height() != parent.Height()+1 || !block.ParentHash().Equals(parent.Hash()) ||
+		block.Timestamp() <= parent.Timestamp() || block.ConsensusRoot() == nil {
+		return nil, ErrInvalidHeader
+	}
+	checkpoint, trusted := v.checkpoints[block.Height()]
+	if trusted && !checkpoint.Equals(block.Hash()) {
+		return nil, ErrUntrustedHeader
+	}
+
+	engine, ok := v.chain.ConsensusHandler().(core.HeaderVerifier)
+	if !ok {
+		return nil, ErrUnverifiableHeader
+	}
+	parentDynasty, ok := v.dynasty(parent.ConsensusRoot().DynastyRoot)
+	if !ok {
+		return nil, ErrDynastyNotFound
+	}
+	dynasty, ok := v.dynasty(block.ConsensusRoot().DynastyRoot)
+	if !ok {
+		return nil, ErrDynastyNotFound
+	}
+	if err := engine.VerifyDynasty(parent, block, parentDynasty, dynasty); err != nil {
+		if err != core.ErrUnverifiableDynasty || !trusted {
+			return nil, err
+		}
+	}
+	if err := engine.VerifyProposer(block, dynasty); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+// traverseDynasty return the members of the dynasty trie in its order.
+func traverseDynasty(dynastyTrie *trie.Trie) ([]byteutils.Hash, error) {
+	members := []byteutils.Hash{}
+	iter, err := dynastyTrie.Iterator(nil)
+	if err == storage.ErrKeyNotFound {
+		return members, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	exist, err := iter.Next()
+	for exist {
+		members = append(members, iter.Value())
+		exist, err = iter.Next()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
+}
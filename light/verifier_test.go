@@ -0,0 +1,124 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package light
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/consensus/dpos"
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/crypto"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
+	"github.com/nebulasio/go-nebulas/light/pb"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockForgedHeader return a header on the genesis signed by a key out of the
+// dynasty, which claims to be the proposer of the slot.
+func mockForgedHeader(t *testing.T, neb *Neb) *corepb.Block {
+	key := secp256k1.GeneratePrivateKey()
+	pub, err := key.PublicKey().Encoded()
+	assert.Nil(t, err)
+	forger, err := core.NewAddressFromPublicKey(pub)
+	assert.Nil(t, err)
+
+	genesis := neb.chain.GenesisBlock()
+	consensusState, err := genesis.WorldState().NextConsensusState(dpos.BlockIntervalInMs / dpos.SecondInMs)
+	assert.Nil(t, err)
+	consensusState.(core.ElectedConsensusState).SetProposer(forger.Bytes(), nil)
+	block, err := core.NewBlock(neb.chain.ChainID(), forger, genesis)
+	assert.Nil(t, err)
+	block.WorldState().SetConsensusState(consensusState)
+	block.SetTimestamp(consensusState.TimeStamp())
+	assert.Nil(t, block.Seal())
+
+	signature, err := crypto.NewSignature(keystore.SECP256K1)
+	assert.Nil(t, err)
+	assert.Nil(t, signature.InitSign(key))
+	assert.Nil(t, block.Sign(signature))
+
+	pb, err := block.ToProto()
+	assert.Nil(t, err)
+	return pb.(*corepb.Block)
+}
+
+func TestVerifier_Dynasties(t *testing.T) {
+	neb := mockNeb(t)
+	server := NewServer(neb.chain, neb.ns)
+	genesis := neb.chain.GenesisBlock()
+
+	headers, err := server.headers(genesis.Height(), 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(headers.Dynasties))
+	assert.Equal(t, len(MockDynasty), len(headers.Dynasties[0].Members))
+
+	// the members not building the root are rejected.
+	verifier := NewVerifier(neb.chain, nil)
+	tampered := &lightpb.Dynasty{
+		Root:    headers.Dynasties[0].Root,
+		Members: headers.Dynasties[0].Members[1:],
+	}
+	assert.Equal(t, ErrInvalidDynasty, verifier.AddDynasties([]*lightpb.Dynasty{tampered}))
+	_, ok := verifier.dynasty(genesis.ConsensusRoot().DynastyRoot)
+	assert.False(t, ok)
+
+	assert.Nil(t, verifier.AddDynasties(headers.Dynasties))
+	members, ok := verifier.dynasty(genesis.ConsensusRoot().DynastyRoot)
+	assert.True(t, ok)
+	expected, err := genesis.WorldState().Dynasty()
+	assert.Nil(t, err)
+	assert.Equal(t, expected, members)
+}
+
+func TestVerifier_VerifyHeader(t *testing.T) {
+	neb := mockNeb(t)
+	genesis := neb.chain.GenesisBlock()
+	forged := mockForgedHeader(t, neb)
+
+	// the dynasty of the parent is needed.
+	verifier := NewVerifier(neb.chain, nil)
+	_, err := verifier.VerifyHeader(genesis, forged)
+	assert.Equal(t, ErrDynastyNotFound, err)
+
+	// a header signed by its own proposer out of the schedule is rejected.
+	assert.Nil(t, verifier.Trust(genesis))
+	_, err = verifier.VerifyHeader(genesis, forged)
+	assert.Equal(t, dpos.ErrInvalidBlockProposer, err)
+
+	// the header at a trusted checkpoint must have its hash.
+	verifier = NewVerifier(neb.chain, map[uint64]byteutils.Hash{forged.Height: genesis.Hash()})
+	assert.Nil(t, verifier.Trust(genesis))
+	_, err = verifier.VerifyHeader(genesis, forged)
+	assert.Equal(t, ErrUntrustedHeader, err)
+}
+
+func TestParseCheckpoints(t *testing.T) {
+	checkpoints, err := ParseCheckpoints([]string{"100:0a0b", "200:ff"})
+	assert.Nil(t, err)
+	assert.Equal(t, byteutils.Hash{0x0a, 0x0b}, checkpoints[100])
+	assert.Equal(t, byteutils.Hash{0xff}, checkpoints[200])
+
+	for _, v := range []string{"100", "a:0a0b", "100:zz", "100:", "100:0a:0b"} {
+		_, err := ParseCheckpoints([]string{v})
+		assert.Equal(t, ErrInvalidCheckpoint, err)
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package neblet
+
+import (
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/consensus/poa"
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/neblet/pb"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// DevAccountBalance the balance of each account funded in the dev genesis, 1 billion NAS.
+const DevAccountBalance = "1000000000000000000000000000"
+
+func devConfig() string {
+	content := `
+	network {
+		listen: ["127.0.0.1:8680"]
+	}
+
+	chain {
+		chain_id: 100
+		datadir: "dev.db"
+		keydir: "dev.keydir"
+		dev: true
+		signature_ciphers: ["ECC_SECP256K1"]
+	}
+
+	rpc {
+		rpc_listen: ["127.0.0.1:8684"]
+		http_listen: ["127.0.0.1:8685"]
+		http_module: ["api","admin"]
+	}
+
+	app {
+		log_level: "info"
+		log_file: "logs"
+		enable_crash_report: false
+	}
+
+	stats {
+		enable_metrics: false
+	}
+	`
+	return content
+}
+
+// DevConfig return the config of a dev chain, used when no config file is given in dev mode.
+func DevConfig() *nebletpb.Config {
+	pb := new(nebletpb.Config)
+	if err := proto.UnmarshalText(devConfig(), pb); err != nil {
+		logging.CLog().WithFields(logrus.Fields{
+			"err": err,
+		}).Fatal("Failed to parse the dev config.")
+	}
+	return pb
+}
+
+// setupDev prepare the miner and the genesis of a dev chain. The miner is the
+// first account of the keystore, a new one is created with the configured
+// passphrase if the keystore is empty. The dev chain runs alone, it is sealed by
+// the miner and neither seeds nor syncs.
+func (n *Neblet) setupDev() error {
+	chainConf := n.config.Chain
+	if len(chainConf.Miner) == 0 {
+		var miner *core.Address
+		if accounts := n.accountManager.Accounts(); len(accounts) > 0 {
+			miner = accounts[0]
+		} else {
+			addr, err := n.accountManager.NewAccount([]byte(chainConf.Passphrase))
+			if err != nil {
+				return err
+			}
+			miner = addr
+			logging.CLog().WithFields(logrus.Fields{
+				"miner":  miner,
+				"keydir": chainConf.Keydir,
+			}).Info("Created the dev miner account.")
+		}
+		chainConf.Miner = miner.String()
+	}
+	if len(chainConf.Coinbase) == 0 {
+		chainConf.Coinbase = chainConf.Miner
+	}
+	chainConf.StartMine = true
+	if n.config.Network != nil {
+		n.config.Network.Seed = nil
+	}
+
+	genesis := devGenesis(chainConf)
+	if err := core.CheckGenesisConf(genesis); err != nil {
+		return err
+	}
+	n.genesis = genesis
+
+	logging.CLog().WithFields(logrus.Fields{
+		"miner":    chainConf.Miner,
+		"accounts": len(genesis.TokenDistribution),
+		"period":   chainConf.DevPeriod,
+	}).Info("Running a dev chain.")
+	return nil
+}
+
+// devGenesis return a poa genesis with the miner as the only signer, the miner
+// and the dev accounts are funded with DevAccountBalance each.
+func devGenesis(conf *nebletpb.ChainConfig) *corepb.Genesis {
+	// blocks are sealed at most once a second when they wait for transactions.
+	period := poa.SecondInMs
+	if conf.DevPeriod > 0 {
+		period = int64(conf.DevPeriod) * poa.SecondInMs
+	}
+
+	distribution := []*corepb.GenesisTokenDistribution{}
+	funded := make(map[string]bool)
+	for _, addr := range append([]string{conf.Miner}, conf.DevAccounts...) {
+		if funded[addr] {
+			continue
+		}
+		funded[addr] = true
+		distribution = append(distribution, &corepb.GenesisTokenDistribution{
+			Address: addr,
+			Value:   DevAccountBalance,
+		})
+	}
+
+	return &corepb.Genesis{
+		Meta: &corepb.GenesisMeta{ChainId: conf.ChainId},
+		Consensus: &corepb.GenesisConsensus{
+			Poa: &corepb.GenesisConsensusPoa{
+				Signers:    []string{conf.Miner},
+				PeriodInMs: period,
+			},
+		},
+		TokenDistribution: distribution,
+	}
+}
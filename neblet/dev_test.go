@@ -0,0 +1,47 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package neblet
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDevGenesis(t *testing.T) {
+	conf := DevConfig()
+	assert.True(t, conf.Chain.Dev)
+
+	miner := "n1FF1nz6tarkDVwWQkMnnwFPuPKUaQTdptE"
+	conf.Chain.Miner = miner
+	conf.Chain.DevAccounts = []string{"n1GmkKH6nBMw4rrjt16RrJ9WcgvKUtAZP1s", miner}
+	genesis := devGenesis(conf.Chain)
+	assert.Nil(t, core.CheckGenesisConf(genesis))
+	assert.Equal(t, []string{miner}, core.GenesisValidators(genesis))
+	assert.Equal(t, int64(1000), genesis.Consensus.Poa.PeriodInMs)
+	// the miner is funded once.
+	assert.Equal(t, 2, len(genesis.TokenDistribution))
+	assert.Equal(t, miner, genesis.TokenDistribution[0].Address)
+	assert.Equal(t, DevAccountBalance, genesis.TokenDistribution[1].Value)
+
+	conf.Chain.DevPeriod = 3
+	genesis = devGenesis(conf.Chain)
+	assert.Equal(t, int64(3000), genesis.Consensus.Poa.PeriodInMs)
+}
@@ -21,8 +21,10 @@ import (
 
 	"github.com/nebulasio/go-nebulas/account"
 	"github.com/nebulasio/go-nebulas/consensus/dpos"
+	"github.com/nebulasio/go-nebulas/consensus/poa"
 	"github.com/nebulasio/go-nebulas/core"
 	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/light"
 	"github.com/nebulasio/go-nebulas/metrics"
 	"github.com/nebulasio/go-nebulas/neblet/pb"
 	nebnet "github.com/nebulasio/go-nebulas/net"
@@ -64,6 +66,11 @@ type Neblet struct {
 
 	syncService *nsync.Service
 
+	lightServer *light.Server
+
+	// nil unless in light mode
+	lightClient *light.Client
+
 	rpcServer rpc.GRPCServer
 
 	lock sync.RWMutex
@@ -88,18 +95,22 @@ func New(config *nebletpb.Config) (*Neblet, error) {
 		return nil, ErrConfigShouldHasChain
 	}
 
-	var err error
-	n.genesis, err = core.LoadGenesisConf(config.Chain.Genesis)
+	am, err := account.NewManager(n)
 	if err != nil {
-		logging.CLog().Error("Failed to load genesis config")
 		return nil, err
 	}
+	n.accountManager = am
 
-	am, err := account.NewManager(n)
+	// a dev chain generates its genesis, sealed by a miner from the keystore.
+	if config.Chain.Dev {
+		err = n.setupDev()
+	} else {
+		n.genesis, err = core.LoadGenesisConf(config.Chain.Genesis)
+	}
 	if err != nil {
+		logging.CLog().Error("Failed to load genesis config")
 		return nil, err
 	}
-	n.accountManager = am
 
 	// init random seed.
 	rand.Seed(time.Now().UTC().UnixNano())
@@ -115,13 +126,34 @@ func (n *Neblet) Setup() {
 	// storage
 	// n.storage, err = storage.NewDiskStorage(n.config.Chain.Datadir)
 	// n.storage, err = storage.NewMemoryStorage()
-	n.storage, err = storage.NewRocksStorage(n.config.Chain.Datadir)
+	open := storage.OpenBackend
+	if n.config.Chain.ReadOnly {
+		open = storage.OpenBackendReadOnly
+	}
+	db, err := open(n.config.Chain.StorageBackend, n.config.Chain.Datadir)
+	if err != nil {
+		logging.CLog().WithFields(logrus.Fields{
+			"dir":     n.config.Chain.Datadir,
+			"backend": n.config.Chain.StorageBackend,
+			"err":     err,
+		}).Fatal("Failed to open disk storage.")
+	}
+
+	// keys are namespaced by chain id, refuse databases written before namespaces existed.
+	legacy, err := storage.HasLegacyKey(db, []byte(core.Tail))
 	if err != nil {
 		logging.CLog().WithFields(logrus.Fields{
 			"dir": n.config.Chain.Datadir,
 			"err": err,
-		}).Fatal("Failed to open disk storage.")
+		}).Fatal("Failed to check the storage scheme.")
 	}
+	if legacy {
+		logging.CLog().WithFields(logrus.Fields{
+			"dir": n.config.Chain.Datadir,
+			"err": ErrIncompatibleStorageSchemeVersion,
+		}).Fatal("Found storage without chain namespace, run 'neb storage migrate' first.")
+	}
+	n.storage = storage.NewChainStorage(db, n.config.Chain.ChainId)
 
 	// net
 	n.netService, err = nebnet.NewNebService(n)
@@ -138,9 +170,20 @@ func (n *Neblet) Setup() {
 			"err": err,
 		}).Fatal("Failed to setup V8.")
 	}
+	nvm.SetupEnginePool(int(n.config.GetRpc().GetEnginePoolSize()))
+	if err = nvm.SetupExecutionPolicy(n.config.GetRpc().GetAllowedContracts(), n.config.GetRpc().GetDeniedContracts()); err != nil {
+		logging.CLog().WithFields(logrus.Fields{
+			"err": err,
+		}).Fatal("Failed to setup the execution policy.")
+	}
 	// core
 	n.eventEmitter = core.NewEventEmitter(40960)
-	n.consensus = dpos.NewDpos()
+	// the consensus engine is chosen by the genesis conf.
+	if n.genesis.GetConsensus().GetPoa() != nil {
+		n.consensus = poa.NewPoa()
+	} else {
+		n.consensus = dpos.NewDpos()
+	}
 	n.blockChain, err = core.NewBlockChain(n)
 	if err != nil {
 		logging.CLog().WithFields(logrus.Fields{
@@ -162,14 +205,44 @@ func (n *Neblet) Setup() {
 
 	// sync
 	n.syncService = nsync.NewService(n.blockChain, n.netService)
+	if err := n.syncService.SetSyncMode(n.config.Chain.SyncMode); err != nil {
+		logging.CLog().WithFields(logrus.Fields{
+			"mode": n.config.Chain.SyncMode,
+			"err":  err,
+		}).Fatal("Failed to setup sync service.")
+	}
 	n.blockChain.SetSyncService(n.syncService)
 
+	// light
+	n.lightServer = light.NewServer(n.blockChain, n.netService)
+	if n.config.Chain.LightNode {
+		checkpoints, err := light.ParseCheckpoints(n.config.Chain.LightCheckpoints)
+		if err != nil {
+			logging.CLog().WithFields(logrus.Fields{
+				"checkpoints": n.config.Chain.LightCheckpoints,
+				"err":         err,
+			}).Fatal("Failed to parse light checkpoints.")
+		}
+		n.lightClient = light.NewClient(n.blockChain, n.netService, checkpoints)
+		if err := n.lightClient.Setup(); err != nil {
+			logging.CLog().WithFields(logrus.Fields{
+				"err": err,
+			}).Fatal("Failed to setup light client.")
+		}
+	}
+
 	// rpc
 	n.rpcServer = rpc.NewServer(n)
 
 	logging.CLog().Info("Setuped Neblet.")
 }
 
+// offline return if the neblet stays off the network. A read-only node serves
+// queries on the storage, and a dev chain runs alone without peer discovery.
+func (n *Neblet) offline() bool {
+	return n.config.Chain.ReadOnly || n.config.Chain.Dev
+}
+
 // StartPprof start pprof http listen
 func (n *Neblet) StartPprof(listen string) error {
 	if len(listen) > 0 {
@@ -211,10 +284,12 @@ func (n *Neblet) Start() {
 		metrics.Start(n)
 	}
 
-	if err := n.netService.Start(); err != nil {
-		logging.CLog().WithFields(logrus.Fields{
-			"err": err,
-		}).Fatal("Failed to start net service.")
+	if !n.offline() {
+		if err := n.netService.Start(); err != nil {
+			logging.CLog().WithFields(logrus.Fields{
+				"err": err,
+			}).Fatal("Failed to start net service.")
+		}
 	}
 
 	if err := n.rpcServer.Start(); err != nil {
@@ -230,16 +305,36 @@ func (n *Neblet) Start() {
 	}
 
 	n.blockChain.Start()
+	n.eventEmitter.Start()
+
+	// light node syncs the headers only, it neither executes blocks nor mines.
+	if n.lightClient != nil {
+		n.lightClient.Start()
+
+		metricsNebstartGauge.Update(1)
+
+		logging.CLog().Info("Started Neblet in light mode.")
+		return
+	}
+
+	if n.config.Chain.ReadOnly {
+		metricsNebstartGauge.Update(1)
+
+		logging.CLog().Info("Started Neblet in read-only mode.")
+		return
+	}
+
 	n.blockChain.BlockPool().Start()
 	n.blockChain.TransactionPool().Start()
-	n.eventEmitter.Start()
 	n.syncService.Start()
+	n.lightServer.Start()
 
 	// start consensus
 	chainConf := n.config.Chain
 	if chainConf.StartMine {
 		n.consensus.Start()
-		if chainConf.EnableRemoteSignServer == false {
+		// the miner signing by the threshold share holders has no key file to unlock.
+		if chainConf.EnableRemoteSignServer == false && !chainConf.Dev && !isRemoteSignerAccount(chainConf, chainConf.Miner) && len(chainConf.ThresholdHolders) == 0 {
 			passphrase := chainConf.Passphrase
 			if len(passphrase) == 0 {
 				fmt.Println("***********************************************")
@@ -286,9 +381,19 @@ func (n *Neblet) Stop() {
 		n.consensus = nil
 	}
 
-	if n.syncService != nil {
-		n.syncService.Stop()
-		n.syncService = nil
+	if n.lightClient != nil {
+		n.lightClient.Stop()
+		n.lightClient = nil
+	} else if !n.config.Chain.ReadOnly {
+		if n.lightServer != nil {
+			n.lightServer.Stop()
+			n.lightServer = nil
+		}
+
+		if n.syncService != nil {
+			n.syncService.Stop()
+			n.syncService = nil
+		}
 	}
 
 	if n.eventEmitter != nil {
@@ -297,8 +402,10 @@ func (n *Neblet) Stop() {
 	}
 
 	if n.blockChain != nil {
-		n.blockChain.TransactionPool().Stop()
-		n.blockChain.BlockPool().Stop()
+		if !n.config.Chain.ReadOnly {
+			n.blockChain.TransactionPool().Stop()
+			n.blockChain.BlockPool().Stop()
+		}
 		n.blockChain.Stop()
 		n.blockChain = nil
 	}
@@ -309,7 +416,9 @@ func (n *Neblet) Stop() {
 	}
 
 	if n.netService != nil {
-		n.netService.Stop()
+		if !n.offline() {
+			n.netService.Stop()
+		}
 		n.netService = nil
 	}
 
@@ -339,6 +448,28 @@ func (n *Neblet) Config() *nebletpb.Config {
 	return n.config
 }
 
+// ForkID returns the fork id of the chain at the tail.
+func (n *Neblet) ForkID() string {
+	return core.GetChainConfig(n.config.Chain.ChainId).ForkID(n.tailHeight()).String()
+}
+
+// CheckForkID returns if a peer on the fork id is compatible with the chain at the tail.
+func (n *Neblet) CheckForkID(id string) bool {
+	remote, err := core.ParseForkID(id)
+	if err != nil {
+		return false
+	}
+	return core.GetChainConfig(n.config.Chain.ChainId).CheckForkID(n.tailHeight(), remote)
+}
+
+// tailHeight returns the height of the tail block, 0 before the chain is loaded.
+func (n *Neblet) tailHeight() uint64 {
+	if n.blockChain == nil || n.blockChain.TailBlock() == nil {
+		return 0
+	}
+	return n.blockChain.TailBlock().Height()
+}
+
 // Storage returns storage reference.
 func (n *Neblet) Storage() storage.Storage {
 	return n.storage
@@ -374,6 +505,11 @@ func (n *Neblet) SyncService() *nsync.Service {
 	return n.syncService
 }
 
+// LightClient return light client, nil unless in light mode
+func (n *Neblet) LightClient() *light.Client {
+	return n.lightClient
+}
+
 // IsActiveSyncing return if the neb is syncing blocks
 func (n *Neblet) IsActiveSyncing() bool {
 	if n.syncService == nil {
@@ -442,3 +578,18 @@ func (n *Neblet) TryStopProfiling() {
 		pprof.StopCPUProfile()
 	}
 }
+
+// isRemoteSignerAccount returns if the key of the address is kept by the
+// remote signer or the PKCS#11 token, no passphrase is needed.
+func isRemoteSignerAccount(conf *nebletpb.ChainConfig, addr string) bool {
+	if len(conf.RemoteSigner) > 0 {
+		for _, v := range conf.RemoteSignerAccounts {
+			if v == addr {
+				return true
+			}
+		}
+	}
+	// the addresses of the keys on the token are known after they are read,
+	// any miner is taken as on the token.
+	return len(conf.Pkcs11Library) > 0
+}
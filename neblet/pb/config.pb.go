@@ -12,6 +12,7 @@ It has these top-level messages:
 	NetworkConfig
 	ChainConfig
 	RPCConfig
+	RPCCredential
 	AppConfig
 	PprofConfig
 	MiscConfig
@@ -53,7 +54,7 @@ func (x StatsConfig_ReportingModule) String() string {
 	return proto.EnumName(StatsConfig_ReportingModule_name, int32(x))
 }
 func (StatsConfig_ReportingModule) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptorConfig, []int{7, 0}
+	return fileDescriptorConfig, []int{8, 0}
 }
 
 // Neblet global configurations.
@@ -122,7 +123,7 @@ func (m *Config) GetApp() *AppConfig {
 type NetworkConfig struct {
 	// Neb seed node address.
 	Seed []string `protobuf:"bytes,1,rep,name=seed" json:"seed"`
-	// Listen addresses.
+	// Listen addresses, "host:port" (IPv4 or [IPv6]) or multiaddr over tcp, e.g. "/ip6/::/tcp/8680".
 	Listen []string `protobuf:"bytes,2,rep,name=listen" json:"listen"`
 	// Network node privateKey address. If nil, generate a new node.
 	PrivateKey string `protobuf:"bytes,3,opt,name=private_key,json=privateKey,proto3" json:"private_key"`
@@ -204,10 +205,91 @@ type ChainConfig struct {
 	GasPrice string `protobuf:"bytes,26,opt,name=gas_price,json=gasPrice,proto3" json:"gas_price"`
 	// Max GasLimit.
 	GasLimit string `protobuf:"bytes,27,opt,name=gas_limit,json=gasLimit,proto3" json:"gas_limit"`
-	// Supported signature cipher list. ["ECC_SECP256K1"]
+	// Supported signature cipher list, the first one signs new accounts. ["ECC_SECP256K1", "ED25519"]
 	SignatureCiphers   []string `protobuf:"bytes,28,rep,name=signature_ciphers,json=signatureCiphers" json:"signature_ciphers"`
 	SuperNode          bool     `protobuf:"varint,30,opt,name=super_node,json=superNode,proto3" json:"super_node"`
 	UnsupportedKeyword string   `protobuf:"bytes,31,opt,name=unsupported_keyword,json=unsupportedKeyword,proto3" json:"unsupported_keyword"`
+	// State storage mode, "archive" keeps all states, "pruned" keeps the states of recent blocks only.
+	StateMode string `protobuf:"bytes,32,opt,name=state_mode,json=stateMode,proto3" json:"state_mode"`
+	// Number of recent blocks whose states are kept in pruned mode.
+	StateRetention uint64 `protobuf:"varint,33,opt,name=state_retention,json=stateRetention,proto3" json:"state_retention"`
+	// States of blocks at multiples of the interval are kept in pruned mode, 0 keeps none.
+	StatePinnedInterval uint64 `protobuf:"varint,34,opt,name=state_pinned_interval,json=statePinnedInterval,proto3" json:"state_pinned_interval"`
+	// Blocks between checkpoints signed by validators, 0 disables checkpoints.
+	CheckpointInterval uint64 `protobuf:"varint,35,opt,name=checkpoint_interval,json=checkpointInterval,proto3" json:"checkpoint_interval"`
+	// Sync block headers only and verify states with merkle proofs from full nodes.
+	LightNode bool `protobuf:"varint,36,opt,name=light_node,json=lightNode,proto3" json:"light_node"`
+	// Lowest GasPrice of the txs packed into the blocks minted by the node, default to gas_price.
+	BlockMinGasPrice string `protobuf:"bytes,37,opt,name=block_min_gas_price,json=blockMinGasPrice,proto3" json:"block_min_gas_price"`
+	// Record the execution traces of txs for the debug API.
+	TraceExecution bool `protobuf:"varint,38,opt,name=trace_execution,json=traceExecution,proto3" json:"trace_execution"`
+	// Gas limit the blocks minted by the node drift toward, at most 1/1024 of the parent one per block.
+	BlockGasLimitTarget string `protobuf:"bytes,39,opt,name=block_gas_limit_target,json=blockGasLimitTarget,proto3" json:"block_gas_limit_target"`
+	// Storage backend, rocksdb (default), leveldb or memory.
+	StorageBackend string `protobuf:"bytes,40,opt,name=storage_backend,json=storageBackend,proto3" json:"storage_backend"`
+	// Size in MB of the LRU cache of state trie nodes shared by block execution and RPC, 0 disables it.
+	TrieCacheSize uint32 `protobuf:"varint,41,opt,name=trie_cache_size,json=trieCacheSize,proto3" json:"trie_cache_size"`
+	// Seconds between the runs of the background state garbage collection, 0 disables it.
+	StateGcInterval uint32 `protobuf:"varint,42,opt,name=state_gc_interval,json=stateGcInterval,proto3" json:"state_gc_interval"`
+	// Trie nodes the state garbage collection touches per second at most, 0 for no limit.
+	StateGcRate uint32 `protobuf:"varint,43,opt,name=state_gc_rate,json=stateGcRate,proto3" json:"state_gc_rate"`
+	// Blocks kept in the key-value store below the tail, older irreversible ones are moved to the freezer, 0 disables the freezer.
+	FreezerThreshold uint64 `protobuf:"varint,44,opt,name=freezer_threshold,json=freezerThreshold,proto3" json:"freezer_threshold"`
+	// Directory of the freezer files, default to datadir with the .ancient suffix.
+	FreezerDir string `protobuf:"bytes,45,opt,name=freezer_dir,json=freezerDir,proto3" json:"freezer_dir"`
+	// Open the storage read-only, serving queries without syncing or mining.
+	ReadOnly bool `protobuf:"varint,46,opt,name=read_only,json=readOnly,proto3" json:"read_only"`
+	// Sync mode, "chunk" by default, "headers" to sync the header chain first, or "fast" to download the recent state of a fresh chain.
+	SyncMode string `protobuf:"bytes,47,opt,name=sync_mode,json=syncMode,proto3" json:"sync_mode"`
+	// Exchange prevote/precommit messages among validators to finalize blocks.
+	EnableFinality bool `protobuf:"varint,48,opt,name=enable_finality,json=enableFinality,proto3" json:"enable_finality"`
+	// Dev run a local chain with a generated genesis, blocks are sealed as soon as transactions arrive.
+	Dev bool `protobuf:"varint,49,opt,name=dev,proto3" json:"dev"`
+	// DevPeriod seal a block every period in seconds in dev mode, 0 to seal only when transactions arrive.
+	DevPeriod uint32 `protobuf:"varint,50,opt,name=dev_period,json=devPeriod,proto3" json:"dev_period"`
+	// DevAccounts the accounts funded in the dev genesis besides the miner.
+	DevAccounts []string `protobuf:"bytes,51,rep,name=dev_accounts,json=devAccounts" json:"dev_accounts"`
+	// KDF of the key files written by the node, "scrypt" by default or "argon2id".
+	KeystoreKdf string `protobuf:"bytes,52,opt,name=keystore_kdf,json=keystoreKdf,proto3" json:"keystore_kdf"`
+	// Parameters of scrypt, 0 for the standard ones.
+	KeystoreScryptN uint32 `protobuf:"varint,53,opt,name=keystore_scrypt_n,json=keystoreScryptN,proto3" json:"keystore_scrypt_n"`
+	KeystoreScryptR uint32 `protobuf:"varint,54,opt,name=keystore_scrypt_r,json=keystoreScryptR,proto3" json:"keystore_scrypt_r"`
+	KeystoreScryptP uint32 `protobuf:"varint,55,opt,name=keystore_scrypt_p,json=keystoreScryptP,proto3" json:"keystore_scrypt_p"`
+	// Parameters of argon2id, memory in KiB, 0 for the standard ones.
+	KeystoreArgon2IdTime    uint32 `protobuf:"varint,56,opt,name=keystore_argon2id_time,json=keystoreArgon2idTime,proto3" json:"keystore_argon2id_time"`
+	KeystoreArgon2IdMemory  uint32 `protobuf:"varint,57,opt,name=keystore_argon2id_memory,json=keystoreArgon2idMemory,proto3" json:"keystore_argon2id_memory"`
+	KeystoreArgon2IdThreads uint32 `protobuf:"varint,58,opt,name=keystore_argon2id_threads,json=keystoreArgon2idThreads,proto3" json:"keystore_argon2id_threads"`
+	// Https endpoint of the remote signing service keeping the keys of remote_signer_accounts.
+	RemoteSigner string `protobuf:"bytes,59,opt,name=remote_signer,json=remoteSigner,proto3" json:"remote_signer"`
+	// Bearer token authenticating the node to the remote signing service.
+	RemoteSignerToken string `protobuf:"bytes,60,opt,name=remote_signer_token,json=remoteSignerToken,proto3" json:"remote_signer_token"`
+	// CA certificate file of the remote signing service, default to the system ones.
+	RemoteSignerCa string `protobuf:"bytes,61,opt,name=remote_signer_ca,json=remoteSignerCa,proto3" json:"remote_signer_ca"`
+	// Client certificate and key files for mutual tls with the remote signing service.
+	RemoteSignerCert string `protobuf:"bytes,62,opt,name=remote_signer_cert,json=remoteSignerCert,proto3" json:"remote_signer_cert"`
+	RemoteSignerKey  string `protobuf:"bytes,63,opt,name=remote_signer_key,json=remoteSignerKey,proto3" json:"remote_signer_key"`
+	// Accounts signed by the remote signing service.
+	RemoteSignerAccounts []string `protobuf:"bytes,64,rep,name=remote_signer_accounts,json=remoteSignerAccounts" json:"remote_signer_accounts"`
+	// PKCS#11 library of the HSM keeping the keys of pkcs11_key_ids.
+	Pkcs11Library string `protobuf:"bytes,65,opt,name=pkcs11_library,json=pkcs11Library,proto3" json:"pkcs11_library"`
+	// Label of the token and the pin of its user.
+	Pkcs11TokenLabel string `protobuf:"bytes,66,opt,name=pkcs11_token_label,json=pkcs11TokenLabel,proto3" json:"pkcs11_token_label"`
+	Pkcs11Pin        string `protobuf:"bytes,67,opt,name=pkcs11_pin,json=pkcs11Pin,proto3" json:"pkcs11_pin"`
+	// Hex ids of the secp256k1 key pairs on the token.
+	Pkcs11KeyIds []string `protobuf:"bytes,68,rep,name=pkcs11_key_ids,json=pkcs11KeyIds" json:"pkcs11_key_ids"`
+	// Https endpoints of the holders of the shares of the miner's key, 2t-1 of them sign the blocks without recovering the key.
+	ThresholdHolders []string `protobuf:"bytes,69,rep,name=threshold_holders,json=thresholdHolders" json:"threshold_holders"`
+	// Bearer token authenticating the node to the holders.
+	ThresholdHolderToken string `protobuf:"bytes,70,opt,name=threshold_holder_token,json=thresholdHolderToken,proto3" json:"threshold_holder_token"`
+	// CA certificate file of the holders, default to the system ones.
+	ThresholdHolderCa string `protobuf:"bytes,71,opt,name=threshold_holder_ca,json=thresholdHolderCa,proto3" json:"threshold_holder_ca"`
+	// Client certificate and key files for mutual tls with the holders.
+	ThresholdHolderCert string `protobuf:"bytes,72,opt,name=threshold_holder_cert,json=thresholdHolderCert,proto3" json:"threshold_holder_cert"`
+	ThresholdHolderKey  string `protobuf:"bytes,73,opt,name=threshold_holder_key,json=thresholdHolderKey,proto3" json:"threshold_holder_key"`
+	// Commitments file of the shares written by "neb account split".
+	ThresholdCommitments string `protobuf:"bytes,74,opt,name=threshold_commitments,json=thresholdCommitments,proto3" json:"threshold_commitments"`
+	// Trusted checkpoints of the light node, "height:hash", the headers starting the dynasties elected by stakes must be among them.
+	LightCheckpoints []string `protobuf:"bytes,75,rep,name=light_checkpoints,json=lightCheckpoints" json:"light_checkpoints"`
 }
 
 func (m *ChainConfig) Reset()                    { *m = ChainConfig{} }
@@ -320,6 +402,314 @@ func (m *ChainConfig) GetUnsupportedKeyword() string {
 	return ""
 }
 
+func (m *ChainConfig) GetStateMode() string {
+	if m != nil {
+		return m.StateMode
+	}
+	return ""
+}
+
+func (m *ChainConfig) GetStateRetention() uint64 {
+	if m != nil {
+		return m.StateRetention
+	}
+	return 0
+}
+
+func (m *ChainConfig) GetStatePinnedInterval() uint64 {
+	if m != nil {
+		return m.StatePinnedInterval
+	}
+	return 0
+}
+
+func (m *ChainConfig) GetCheckpointInterval() uint64 {
+	if m != nil {
+		return m.CheckpointInterval
+	}
+	return 0
+}
+
+func (m *ChainConfig) GetLightNode() bool {
+	if m != nil {
+		return m.LightNode
+	}
+	return false
+}
+
+func (m *ChainConfig) GetBlockMinGasPrice() string {
+	if m != nil {
+		return m.BlockMinGasPrice
+	}
+	return ""
+}
+
+func (m *ChainConfig) GetTraceExecution() bool {
+	if m != nil {
+		return m.TraceExecution
+	}
+	return false
+}
+
+func (m *ChainConfig) GetBlockGasLimitTarget() string {
+	if m != nil {
+		return m.BlockGasLimitTarget
+	}
+	return ""
+}
+
+func (m *ChainConfig) GetStorageBackend() string {
+	if m != nil {
+		return m.StorageBackend
+	}
+	return ""
+}
+
+func (m *ChainConfig) GetTrieCacheSize() uint32 {
+	if m != nil {
+		return m.TrieCacheSize
+	}
+	return 0
+}
+
+func (m *ChainConfig) GetStateGcInterval() uint32 {
+	if m != nil {
+		return m.StateGcInterval
+	}
+	return 0
+}
+
+func (m *ChainConfig) GetStateGcRate() uint32 {
+	if m != nil {
+		return m.StateGcRate
+	}
+	return 0
+}
+
+func (m *ChainConfig) GetFreezerThreshold() uint64 {
+	if m != nil {
+		return m.FreezerThreshold
+	}
+	return 0
+}
+
+func (m *ChainConfig) GetFreezerDir() string {
+	if m != nil {
+		return m.FreezerDir
+	}
+	return ""
+}
+
+func (m *ChainConfig) GetReadOnly() bool {
+	if m != nil {
+		return m.ReadOnly
+	}
+	return false
+}
+
+func (m *ChainConfig) GetSyncMode() string {
+	if m != nil {
+		return m.SyncMode
+	}
+	return ""
+}
+
+func (m *ChainConfig) GetEnableFinality() bool {
+	if m != nil {
+		return m.EnableFinality
+	}
+	return false
+}
+
+func (m *ChainConfig) GetDev() bool {
+	if m != nil {
+		return m.Dev
+	}
+	return false
+}
+
+func (m *ChainConfig) GetDevPeriod() uint32 {
+	if m != nil {
+		return m.DevPeriod
+	}
+	return 0
+}
+
+func (m *ChainConfig) GetDevAccounts() []string {
+	if m != nil {
+		return m.DevAccounts
+	}
+	return nil
+}
+
+func (m *ChainConfig) GetKeystoreKdf() string {
+	if m != nil {
+		return m.KeystoreKdf
+	}
+	return ""
+}
+
+func (m *ChainConfig) GetKeystoreScryptN() uint32 {
+	if m != nil {
+		return m.KeystoreScryptN
+	}
+	return 0
+}
+
+func (m *ChainConfig) GetKeystoreScryptR() uint32 {
+	if m != nil {
+		return m.KeystoreScryptR
+	}
+	return 0
+}
+
+func (m *ChainConfig) GetKeystoreScryptP() uint32 {
+	if m != nil {
+		return m.KeystoreScryptP
+	}
+	return 0
+}
+
+func (m *ChainConfig) GetKeystoreArgon2IdTime() uint32 {
+	if m != nil {
+		return m.KeystoreArgon2IdTime
+	}
+	return 0
+}
+
+func (m *ChainConfig) GetKeystoreArgon2IdMemory() uint32 {
+	if m != nil {
+		return m.KeystoreArgon2IdMemory
+	}
+	return 0
+}
+
+func (m *ChainConfig) GetKeystoreArgon2IdThreads() uint32 {
+	if m != nil {
+		return m.KeystoreArgon2IdThreads
+	}
+	return 0
+}
+
+func (m *ChainConfig) GetRemoteSigner() string {
+	if m != nil {
+		return m.RemoteSigner
+	}
+	return ""
+}
+
+func (m *ChainConfig) GetRemoteSignerToken() string {
+	if m != nil {
+		return m.RemoteSignerToken
+	}
+	return ""
+}
+
+func (m *ChainConfig) GetRemoteSignerCa() string {
+	if m != nil {
+		return m.RemoteSignerCa
+	}
+	return ""
+}
+
+func (m *ChainConfig) GetRemoteSignerCert() string {
+	if m != nil {
+		return m.RemoteSignerCert
+	}
+	return ""
+}
+
+func (m *ChainConfig) GetRemoteSignerKey() string {
+	if m != nil {
+		return m.RemoteSignerKey
+	}
+	return ""
+}
+
+func (m *ChainConfig) GetRemoteSignerAccounts() []string {
+	if m != nil {
+		return m.RemoteSignerAccounts
+	}
+	return nil
+}
+
+func (m *ChainConfig) GetPkcs11Library() string {
+	if m != nil {
+		return m.Pkcs11Library
+	}
+	return ""
+}
+
+func (m *ChainConfig) GetPkcs11TokenLabel() string {
+	if m != nil {
+		return m.Pkcs11TokenLabel
+	}
+	return ""
+}
+
+func (m *ChainConfig) GetPkcs11Pin() string {
+	if m != nil {
+		return m.Pkcs11Pin
+	}
+	return ""
+}
+
+func (m *ChainConfig) GetPkcs11KeyIds() []string {
+	if m != nil {
+		return m.Pkcs11KeyIds
+	}
+	return nil
+}
+
+func (m *ChainConfig) GetThresholdHolders() []string {
+	if m != nil {
+		return m.ThresholdHolders
+	}
+	return nil
+}
+
+func (m *ChainConfig) GetThresholdHolderToken() string {
+	if m != nil {
+		return m.ThresholdHolderToken
+	}
+	return ""
+}
+
+func (m *ChainConfig) GetThresholdHolderCa() string {
+	if m != nil {
+		return m.ThresholdHolderCa
+	}
+	return ""
+}
+
+func (m *ChainConfig) GetThresholdHolderCert() string {
+	if m != nil {
+		return m.ThresholdHolderCert
+	}
+	return ""
+}
+
+func (m *ChainConfig) GetThresholdHolderKey() string {
+	if m != nil {
+		return m.ThresholdHolderKey
+	}
+	return ""
+}
+
+func (m *ChainConfig) GetThresholdCommitments() string {
+	if m != nil {
+		return m.ThresholdCommitments
+	}
+	return ""
+}
+
+func (m *ChainConfig) GetLightCheckpoints() []string {
+	if m != nil {
+		return m.LightCheckpoints
+	}
+	return nil
+}
+
 type RPCConfig struct {
 	// RPC listen addresses.
 	RpcListen []string `protobuf:"bytes,1,rep,name=rpc_listen,json=rpcListen" json:"rpc_listen"`
@@ -331,6 +721,43 @@ type RPCConfig struct {
 	HttpLimits       int32    `protobuf:"varint,5,opt,name=http_limits,json=httpLimits,proto3" json:"http_limits"`
 	// HTTP CORS allowed origins
 	HttpCors []string `protobuf:"bytes,6,rep,name=http_cors,json=httpCors" json:"http_cors"`
+	// Pre-warmed V8 engines kept for read-only contract calls, 0 to disable.
+	EnginePoolSize int32 `protobuf:"varint,7,opt,name=engine_pool_size,json=enginePoolSize,proto3" json:"engine_pool_size"`
+	// Contracts the read-only contract calls are restricted to, empty to allow all.
+	AllowedContracts []string `protobuf:"bytes,8,rep,name=allowed_contracts,json=allowedContracts" json:"allowed_contracts"`
+	// Contracts the read-only contract calls refuse to run.
+	DeniedContracts []string `protobuf:"bytes,9,rep,name=denied_contracts,json=deniedContracts" json:"denied_contracts"`
+	// Disable the gRPC server reflection listing the services to the clients.
+	DisableReflection bool `protobuf:"varint,10,opt,name=disable_reflection,json=disableReflection,proto3" json:"disable_reflection"`
+	// Websocket listen addresses pushing the subscribed events.
+	WsListen []string `protobuf:"bytes,11,rep,name=ws_listen,json=wsListen" json:"ws_listen"`
+	// Events buffered for a websocket connection, the clients falling behind are evicted.
+	WsBufferSize uint32 `protobuf:"varint,12,opt,name=ws_buffer_size,json=wsBufferSize,proto3" json:"ws_buffer_size"`
+	// Unix socket paths or local addresses the rpc server also listens on for the admin clients.
+	AdminListen []string `protobuf:"bytes,13,rep,name=admin_listen,json=adminListen" json:"admin_listen"`
+	// Serve the admin methods managing the node at runtime to the remote clients, by default only to the clients over loopback or unix socket.
+	RemoteAdmin bool `protobuf:"varint,14,opt,name=remote_admin,json=remoteAdmin,proto3" json:"remote_admin"`
+	// Credentials of the clients, the requests without a valid one are refused if any is configured.
+	Credentials []*RPCCredential `protobuf:"bytes,15,rep,name=credentials" json:"credentials"`
+	// Method sets served to the clients without credentials when credentials are configured, e.g. ["read-only"].
+	Anonymous []string `protobuf:"bytes,16,rep,name=anonymous" json:"anonymous"`
+	// Certificate and key files serving the rpc, http and websocket listeners over tls.
+	TlsCert string `protobuf:"bytes,17,opt,name=tls_cert,json=tlsCert,proto3" json:"tls_cert"`
+	TlsKey  string `protobuf:"bytes,18,opt,name=tls_key,json=tlsKey,proto3" json:"tls_key"`
+	// CA certificates file verifying the client certificates, the clients must present one if set.
+	TlsClientCa string `protobuf:"bytes,19,opt,name=tls_client_ca,json=tlsClientCa,proto3" json:"tls_client_ca"`
+	// Requests per second served to a client, identified by its credential or ip, 0 for no limit.
+	ClientRateLimit uint32 `protobuf:"varint,20,opt,name=client_rate_limit,json=clientRateLimit,proto3" json:"client_rate_limit"`
+	// Requests a client may burst, default to client_rate_limit.
+	ClientRateBurst uint32 `protobuf:"varint,21,opt,name=client_rate_burst,json=clientRateBurst,proto3" json:"client_rate_burst"`
+	// Requests per second of each heavy method, e.g. call and estimateGas, served to a client, 0 for no limit.
+	HeavyRateLimit uint32 `protobuf:"varint,22,opt,name=heavy_rate_limit,json=heavyRateLimit,proto3" json:"heavy_rate_limit"`
+	// Requests of a heavy method a client may burst, default to heavy_rate_limit.
+	HeavyRateBurst uint32 `protobuf:"varint,23,opt,name=heavy_rate_burst,json=heavyRateBurst,proto3" json:"heavy_rate_burst"`
+	// Max bytes of a request body, default to 64MB.
+	MaxRequestSize uint32 `protobuf:"varint,24,opt,name=max_request_size,json=maxRequestSize,proto3" json:"max_request_size"`
+	// Heavy methods executed at the same time at most, the others are refused, 0 for no limit.
+	MaxConcurrentExecutions uint32 `protobuf:"varint,25,opt,name=max_concurrent_executions,json=maxConcurrentExecutions,proto3" json:"max_concurrent_executions"`
 }
 
 func (m *RPCConfig) Reset()                    { *m = RPCConfig{} }
@@ -380,6 +807,183 @@ func (m *RPCConfig) GetHttpCors() []string {
 	return nil
 }
 
+func (m *RPCConfig) GetEnginePoolSize() int32 {
+	if m != nil {
+		return m.EnginePoolSize
+	}
+	return 0
+}
+
+func (m *RPCConfig) GetAllowedContracts() []string {
+	if m != nil {
+		return m.AllowedContracts
+	}
+	return nil
+}
+
+func (m *RPCConfig) GetDeniedContracts() []string {
+	if m != nil {
+		return m.DeniedContracts
+	}
+	return nil
+}
+
+func (m *RPCConfig) GetDisableReflection() bool {
+	if m != nil {
+		return m.DisableReflection
+	}
+	return false
+}
+
+func (m *RPCConfig) GetWsListen() []string {
+	if m != nil {
+		return m.WsListen
+	}
+	return nil
+}
+
+func (m *RPCConfig) GetWsBufferSize() uint32 {
+	if m != nil {
+		return m.WsBufferSize
+	}
+	return 0
+}
+
+func (m *RPCConfig) GetAdminListen() []string {
+	if m != nil {
+		return m.AdminListen
+	}
+	return nil
+}
+
+func (m *RPCConfig) GetRemoteAdmin() bool {
+	if m != nil {
+		return m.RemoteAdmin
+	}
+	return false
+}
+
+func (m *RPCConfig) GetCredentials() []*RPCCredential {
+	if m != nil {
+		return m.Credentials
+	}
+	return nil
+}
+
+func (m *RPCConfig) GetAnonymous() []string {
+	if m != nil {
+		return m.Anonymous
+	}
+	return nil
+}
+
+func (m *RPCConfig) GetTlsCert() string {
+	if m != nil {
+		return m.TlsCert
+	}
+	return ""
+}
+
+func (m *RPCConfig) GetTlsKey() string {
+	if m != nil {
+		return m.TlsKey
+	}
+	return ""
+}
+
+func (m *RPCConfig) GetTlsClientCa() string {
+	if m != nil {
+		return m.TlsClientCa
+	}
+	return ""
+}
+
+func (m *RPCConfig) GetClientRateLimit() uint32 {
+	if m != nil {
+		return m.ClientRateLimit
+	}
+	return 0
+}
+
+func (m *RPCConfig) GetClientRateBurst() uint32 {
+	if m != nil {
+		return m.ClientRateBurst
+	}
+	return 0
+}
+
+func (m *RPCConfig) GetHeavyRateLimit() uint32 {
+	if m != nil {
+		return m.HeavyRateLimit
+	}
+	return 0
+}
+
+func (m *RPCConfig) GetHeavyRateBurst() uint32 {
+	if m != nil {
+		return m.HeavyRateBurst
+	}
+	return 0
+}
+
+func (m *RPCConfig) GetMaxRequestSize() uint32 {
+	if m != nil {
+		return m.MaxRequestSize
+	}
+	return 0
+}
+
+func (m *RPCConfig) GetMaxConcurrentExecutions() uint32 {
+	if m != nil {
+		return m.MaxConcurrentExecutions
+	}
+	return 0
+}
+
+type RPCCredential struct {
+	// Name of the client in the logs.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name"`
+	// Api key the client sends as the bearer token.
+	ApiKey string `protobuf:"bytes,2,opt,name=api_key,json=apiKey,proto3" json:"api_key"`
+	// HMAC-SHA256 secret of the JWT bearer tokens of the client, their "exp" and "nbf" claims are checked if any.
+	JwtSecret string `protobuf:"bytes,3,opt,name=jwt_secret,json=jwtSecret,proto3" json:"jwt_secret"`
+	// Method sets allowed, "read-only", "send-tx" or "admin".
+	Allow []string `protobuf:"bytes,4,rep,name=allow" json:"allow"`
+}
+
+func (m *RPCCredential) Reset()                    { *m = RPCCredential{} }
+func (m *RPCCredential) String() string            { return proto.CompactTextString(m) }
+func (*RPCCredential) ProtoMessage()               {}
+func (*RPCCredential) Descriptor() ([]byte, []int) { return fileDescriptorConfig, []int{4} }
+
+func (m *RPCCredential) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *RPCCredential) GetApiKey() string {
+	if m != nil {
+		return m.ApiKey
+	}
+	return ""
+}
+
+func (m *RPCCredential) GetJwtSecret() string {
+	if m != nil {
+		return m.JwtSecret
+	}
+	return ""
+}
+
+func (m *RPCCredential) GetAllow() []string {
+	if m != nil {
+		return m.Allow
+	}
+	return nil
+}
+
 type AppConfig struct {
 	LogLevel string `protobuf:"bytes,1,opt,name=log_level,json=logLevel,proto3" json:"log_level"`
 	LogFile  string `protobuf:"bytes,2,opt,name=log_file,json=logFile,proto3" json:"log_file"`
@@ -395,7 +999,7 @@ type AppConfig struct {
 func (m *AppConfig) Reset()                    { *m = AppConfig{} }
 func (m *AppConfig) String() string            { return proto.CompactTextString(m) }
 func (*AppConfig) ProtoMessage()               {}
-func (*AppConfig) Descriptor() ([]byte, []int) { return fileDescriptorConfig, []int{4} }
+func (*AppConfig) Descriptor() ([]byte, []int) { return fileDescriptorConfig, []int{5} }
 
 func (m *AppConfig) GetLogLevel() string {
 	if m != nil {
@@ -458,7 +1062,7 @@ type PprofConfig struct {
 func (m *PprofConfig) Reset()                    { *m = PprofConfig{} }
 func (m *PprofConfig) String() string            { return proto.CompactTextString(m) }
 func (*PprofConfig) ProtoMessage()               {}
-func (*PprofConfig) Descriptor() ([]byte, []int) { return fileDescriptorConfig, []int{5} }
+func (*PprofConfig) Descriptor() ([]byte, []int) { return fileDescriptorConfig, []int{6} }
 
 func (m *PprofConfig) GetHttpListen() string {
 	if m != nil {
@@ -489,7 +1093,7 @@ type MiscConfig struct {
 func (m *MiscConfig) Reset()                    { *m = MiscConfig{} }
 func (m *MiscConfig) String() string            { return proto.CompactTextString(m) }
 func (*MiscConfig) ProtoMessage()               {}
-func (*MiscConfig) Descriptor() ([]byte, []int) { return fileDescriptorConfig, []int{6} }
+func (*MiscConfig) Descriptor() ([]byte, []int) { return fileDescriptorConfig, []int{7} }
 
 func (m *MiscConfig) GetDefaultKeystoreFileCiper() string {
 	if m != nil {
@@ -510,7 +1114,7 @@ type StatsConfig struct {
 func (m *StatsConfig) Reset()                    { *m = StatsConfig{} }
 func (m *StatsConfig) String() string            { return proto.CompactTextString(m) }
 func (*StatsConfig) ProtoMessage()               {}
-func (*StatsConfig) Descriptor() ([]byte, []int) { return fileDescriptorConfig, []int{7} }
+func (*StatsConfig) Descriptor() ([]byte, []int) { return fileDescriptorConfig, []int{8} }
 
 func (m *StatsConfig) GetEnableMetrics() bool {
 	if m != nil {
@@ -556,7 +1160,7 @@ type InfluxdbConfig struct {
 func (m *InfluxdbConfig) Reset()                    { *m = InfluxdbConfig{} }
 func (m *InfluxdbConfig) String() string            { return proto.CompactTextString(m) }
 func (*InfluxdbConfig) ProtoMessage()               {}
-func (*InfluxdbConfig) Descriptor() ([]byte, []int) { return fileDescriptorConfig, []int{8} }
+func (*InfluxdbConfig) Descriptor() ([]byte, []int) { return fileDescriptorConfig, []int{9} }
 
 func (m *InfluxdbConfig) GetHost() string {
 	if m != nil {
@@ -598,6 +1202,7 @@ func init() {
 	proto.RegisterType((*NetworkConfig)(nil), "nebletpb.NetworkConfig")
 	proto.RegisterType((*ChainConfig)(nil), "nebletpb.ChainConfig")
 	proto.RegisterType((*RPCConfig)(nil), "nebletpb.RPCConfig")
+	proto.RegisterType((*RPCCredential)(nil), "nebletpb.RPCCredential")
 	proto.RegisterType((*AppConfig)(nil), "nebletpb.AppConfig")
 	proto.RegisterType((*PprofConfig)(nil), "nebletpb.PprofConfig")
 	proto.RegisterType((*MiscConfig)(nil), "nebletpb.MiscConfig")
@@ -609,68 +1214,139 @@ func init() {
 func init() { proto.RegisterFile("config.proto", fileDescriptorConfig) }
 
 var fileDescriptorConfig = []byte{
-	// 1002 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x56, 0x4f, 0x6f, 0x1b, 0xb7,
-	0x13, 0xfd, 0x49, 0xfe, 0x13, 0xed, 0x48, 0x76, 0x14, 0xda, 0xb1, 0xe9, 0xf8, 0x57, 0xdb, 0x55,
-	0x61, 0x40, 0x40, 0x0a, 0x17, 0x75, 0x73, 0xe9, 0xa1, 0x87, 0x40, 0x40, 0x01, 0xc3, 0x76, 0x60,
-	0xac, 0xdb, 0xf3, 0x62, 0xb5, 0x3b, 0x5a, 0x11, 0x5e, 0x2d, 0x09, 0x92, 0x72, 0xe2, 0x5b, 0x2f,
-	0x3d, 0xf6, 0xeb, 0xb5, 0x9f, 0xa6, 0x40, 0x31, 0xb3, 0x5c, 0xfd, 0x43, 0x6e, 0x9c, 0xf7, 0x1e,
-	0x39, 0xcb, 0x99, 0xc7, 0x91, 0xa0, 0x97, 0xe9, 0x6a, 0xa2, 0x8a, 0x2b, 0x63, 0xb5, 0xd7, 0xa2,
-	0x53, 0xe1, 0xb8, 0x44, 0x6f, 0xc6, 0x83, 0xbf, 0xda, 0xb0, 0x3b, 0x62, 0x4a, 0xfc, 0x08, 0xaf,
-	0x2a, 0xf4, 0x9f, 0xb5, 0x7d, 0x92, 0xad, 0x8b, 0xd6, 0xb0, 0x7b, 0x7d, 0x7c, 0xd5, 0xc8, 0xae,
-	0x3e, 0xd5, 0x44, 0xad, 0x8c, 0x1b, 0x9d, 0x78, 0x0f, 0x3b, 0xd9, 0x34, 0x55, 0x95, 0x6c, 0xf3,
-	0x86, 0xb7, 0xcb, 0x0d, 0x23, 0x82, 0x83, 0xbc, 0xd6, 0x88, 0x4b, 0xd8, 0xb2, 0x26, 0x93, 0x5b,
-	0x2c, 0x3d, 0x58, 0x4a, 0xe3, 0x87, 0x51, 0x10, 0x12, 0x4f, 0x67, 0x3a, 0x9f, 0x7a, 0x27, 0xf3,
-	0xcd, 0x33, 0x1f, 0x09, 0x6e, 0xce, 0x64, 0x8d, 0x18, 0xc2, 0xf6, 0x4c, 0xb9, 0x4c, 0x22, 0x6b,
-	0x0f, 0x97, 0xda, 0x7b, 0xe5, 0xb2, 0x20, 0x65, 0x05, 0x65, 0x4f, 0x8d, 0x91, 0x93, 0xcd, 0xec,
-	0x1f, 0x8d, 0x69, 0xb2, 0xa7, 0xc6, 0x0c, 0xfe, 0x6e, 0xc1, 0xde, 0xda, 0x65, 0x85, 0x80, 0x6d,
-	0x87, 0x98, 0xcb, 0xd6, 0xc5, 0xd6, 0x30, 0x8a, 0x79, 0x2d, 0x8e, 0x60, 0xb7, 0x54, 0xce, 0x23,
-	0x5d, 0x9c, 0xd0, 0x10, 0x89, 0x73, 0xe8, 0x1a, 0xab, 0x9e, 0x53, 0x8f, 0xc9, 0x13, 0xbe, 0xf0,
-	0x55, 0xa3, 0x18, 0x02, 0x74, 0x8b, 0x2f, 0xe2, 0x1b, 0x80, 0x50, 0xbb, 0x44, 0xe5, 0x72, 0xfb,
-	0xa2, 0x35, 0xdc, 0x8b, 0xa3, 0x80, 0xdc, 0xe4, 0xe2, 0x3b, 0xd8, 0x73, 0xde, 0x62, 0x3a, 0x4b,
-	0x4a, 0x35, 0x53, 0xde, 0xc9, 0x9d, 0x8b, 0xd6, 0x70, 0x27, 0xee, 0xd5, 0xe0, 0x1d, 0x63, 0xe2,
-	0x03, 0x1c, 0x59, 0x74, 0x68, 0x9f, 0x31, 0x4f, 0xd6, 0xd5, 0xbb, 0xac, 0x3e, 0x6c, 0xd8, 0xc7,
-	0x95, 0x5d, 0x83, 0x3f, 0xb7, 0xa1, 0xbb, 0xd2, 0x14, 0x71, 0x02, 0x1d, 0x6e, 0x0b, 0x7d, 0x47,
-	0x8b, 0xbf, 0xe3, 0x15, 0xc7, 0x37, 0xb9, 0x90, 0xf0, 0xaa, 0xc0, 0x0a, 0x9d, 0x72, 0xdc, 0xd7,
-	0x28, 0x6e, 0x42, 0x62, 0xf2, 0xd4, 0xa7, 0xb9, 0xb2, 0xb2, 0x5b, 0x33, 0x21, 0xa4, 0x8a, 0x3c,
-	0xe1, 0x0b, 0x11, 0x3d, 0x26, 0x42, 0x44, 0x17, 0x76, 0x3e, 0xb5, 0x3e, 0x99, 0xa9, 0x0a, 0xe5,
-	0xe1, 0x45, 0x6b, 0xd8, 0x89, 0x23, 0x46, 0xee, 0x55, 0x85, 0xe2, 0x1d, 0x74, 0x32, 0xad, 0xaa,
-	0x71, 0xea, 0x50, 0xbe, 0xe5, 0x8d, 0x8b, 0x58, 0x1c, 0xc2, 0x0e, 0x6d, 0xb2, 0xf2, 0x88, 0x89,
-	0x3a, 0x10, 0x67, 0x00, 0x26, 0x75, 0xce, 0x4c, 0x2d, 0xed, 0x39, 0x0e, 0x15, 0x5e, 0x20, 0xe2,
-	0x67, 0x38, 0xc1, 0x2a, 0x1d, 0x97, 0x98, 0x58, 0x9c, 0x69, 0x8f, 0x89, 0x53, 0x45, 0x95, 0x70,
-	0x41, 0xac, 0x94, 0x9c, 0xff, 0xa8, 0x16, 0xc4, 0xcc, 0x3f, 0xaa, 0xa2, 0x7a, 0x64, 0x56, 0x7c,
-	0x0f, 0xe2, 0x2b, 0x7b, 0x4e, 0x38, 0x45, 0xdf, 0x6e, 0xaa, 0x4f, 0x21, 0x2a, 0x52, 0x97, 0x18,
-	0xab, 0x32, 0x94, 0xef, 0xea, 0x6f, 0x2f, 0x52, 0xf7, 0x40, 0x71, 0x43, 0x72, 0x5f, 0xe4, 0xe9,
-	0x82, 0xe4, 0x5e, 0x88, 0xf7, 0xf0, 0x86, 0x12, 0xa4, 0x7e, 0x6e, 0x31, 0xc9, 0x94, 0x99, 0xa2,
-	0x75, 0xf2, 0xff, 0x6c, 0xa4, 0xfe, 0x82, 0x18, 0xd5, 0x38, 0x17, 0x70, 0x6e, 0xd0, 0x26, 0x95,
-	0xce, 0x51, 0x9e, 0x85, 0x02, 0x12, 0xf2, 0x49, 0xe7, 0x28, 0x7e, 0x80, 0x83, 0x79, 0xe5, 0xe6,
-	0xc6, 0x68, 0xeb, 0x31, 0x27, 0xd7, 0x7d, 0xd6, 0x36, 0x97, 0xe7, 0x9c, 0x52, 0xac, 0x50, 0xb7,
-	0x35, 0x33, 0xf8, 0xa7, 0x05, 0xd1, 0xe2, 0xc5, 0xd1, 0xe9, 0xd6, 0x64, 0x49, 0x30, 0x73, 0x6d,
-	0xf1, 0xc8, 0x9a, 0xec, 0x6e, 0xe1, 0xe7, 0xa9, 0xf7, 0x26, 0x59, 0x33, 0x3b, 0x10, 0xb4, 0x21,
-	0x98, 0xe9, 0x7c, 0x5e, 0xa2, 0xdc, 0x5a, 0x0a, 0xee, 0x19, 0xa1, 0xbb, 0x66, 0xba, 0xaa, 0x30,
-	0xf3, 0x4a, 0x57, 0x8d, 0x4f, 0xb7, 0xd9, 0xa7, 0xfd, 0x25, 0x11, 0x9c, 0xbd, 0x4c, 0xb7, 0x62,
-	0xfe, 0x90, 0x8e, 0x05, 0xa7, 0x10, 0xb1, 0x20, 0xd3, 0x96, 0xdc, 0x4e, 0xc9, 0x3a, 0x04, 0x8c,
-	0xb4, 0x75, 0x83, 0x7f, 0x5b, 0x10, 0x2d, 0x5e, 0x33, 0x49, 0x4b, 0x5d, 0x24, 0x25, 0x3e, 0x63,
-	0xc9, 0x06, 0x8f, 0xe2, 0x4e, 0xa9, 0x8b, 0x3b, 0x8a, 0xc9, 0xfc, 0x44, 0x4e, 0x54, 0x89, 0x8d,
-	0xc5, 0x4b, 0x5d, 0xfc, 0xaa, 0x4a, 0x14, 0xc7, 0x40, 0xcb, 0x24, 0x2d, 0x90, 0x9f, 0xef, 0x5e,
-	0xbc, 0x5b, 0xea, 0xe2, 0x63, 0x81, 0xe2, 0x0a, 0x0e, 0x82, 0xb1, 0x32, 0x9b, 0xba, 0x69, 0x62,
-	0x91, 0x0a, 0xcb, 0x77, 0xe9, 0xc4, 0x6f, 0x6a, 0x6a, 0x44, 0x4c, 0xcc, 0x84, 0x18, 0x42, 0x7f,
-	0x55, 0x98, 0xcc, 0x6d, 0xc9, 0x37, 0x8a, 0xe2, 0xfd, 0x6c, 0x29, 0xfb, 0xdd, 0x96, 0x34, 0xf1,
-	0x8c, 0xb1, 0x7a, 0xc2, 0xef, 0x77, 0x6d, 0xe2, 0x3d, 0x10, 0xdc, 0x4c, 0x3c, 0xd6, 0xd0, 0x13,
-	0x7c, 0x46, 0xeb, 0x94, 0xae, 0x78, 0x40, 0x46, 0x71, 0x13, 0x0e, 0x2a, 0xe8, 0xae, 0xe8, 0x37,
-	0x7b, 0x57, 0x97, 0x60, 0xb5, 0x77, 0x67, 0x00, 0x99, 0x99, 0xd3, 0x8e, 0x65, 0x19, 0x56, 0x10,
-	0xe2, 0x67, 0x38, 0x6b, 0xf8, 0x30, 0xcb, 0x96, 0xc8, 0xe0, 0x16, 0x60, 0x39, 0x65, 0xc5, 0x2f,
-	0x70, 0x9a, 0xe3, 0x24, 0x9d, 0x97, 0x9e, 0x4c, 0xe8, 0xbc, 0xb6, 0xc8, 0xf5, 0x25, 0x83, 0xa3,
-	0x0d, 0xe9, 0x65, 0x90, 0xdc, 0x06, 0x05, 0x55, 0x7c, 0x44, 0xfc, 0xe0, 0x8f, 0x36, 0x74, 0x57,
-	0xe6, 0xbb, 0xb8, 0x84, 0xfd, 0x50, 0xed, 0x19, 0x7a, 0xab, 0x32, 0xc7, 0x27, 0x74, 0xe2, 0xbd,
-	0x1a, 0xbd, 0xaf, 0x41, 0xf1, 0x00, 0xfd, 0xba, 0xbc, 0xaa, 0x2a, 0x1a, 0x13, 0x92, 0x4b, 0xf7,
-	0xaf, 0x2f, 0xbf, 0xfa, 0xbb, 0x71, 0x15, 0x37, 0xea, 0xda, 0x9f, 0xf1, 0x6b, 0xbb, 0x0e, 0x88,
-	0x0f, 0xd0, 0x51, 0xd5, 0xa4, 0x9c, 0x7f, 0xc9, 0xc7, 0x3c, 0xe3, 0xba, 0xd7, 0x72, 0x79, 0xd2,
-	0x4d, 0x60, 0x42, 0x4b, 0x16, 0x4a, 0xf1, 0x2d, 0xf4, 0xc2, 0x77, 0x26, 0x3e, 0x2d, 0x9c, 0xec,
-	0xb1, 0x37, 0xbb, 0x01, 0xfb, 0x2d, 0x2d, 0xdc, 0xe0, 0x1c, 0x5e, 0x6f, 0x24, 0x17, 0x3d, 0xe8,
-	0x34, 0x27, 0xf6, 0xff, 0x37, 0xf8, 0x02, 0xfb, 0xeb, 0xe7, 0xd3, 0x4f, 0xcf, 0x54, 0x3b, 0x1f,
-	0x8a, 0xc7, 0x6b, 0xc2, 0xd8, 0x77, 0x6d, 0x36, 0x27, 0xaf, 0xc5, 0x3e, 0xb4, 0xf3, 0x71, 0xe8,
-	0x50, 0x3b, 0x1f, 0x93, 0x66, 0xee, 0xd0, 0xb2, 0x37, 0xa3, 0x98, 0xd7, 0x34, 0x69, 0x69, 0x4a,
-	0xf2, 0x74, 0xa8, 0x6d, 0xb8, 0x88, 0xc7, 0xbb, 0xfc, 0xaf, 0xe0, 0xa7, 0xff, 0x02, 0x00, 0x00,
-	0xff, 0xff, 0xb9, 0xef, 0xf9, 0x0f, 0x25, 0x08, 0x00, 0x00,
+	// 2133 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x58, 0x5d, 0x77, 0x1b, 0xb7,
+	0xd1, 0x7e, 0xf5, 0x61, 0x99, 0x04, 0x45, 0x4a, 0x82, 0x64, 0x09, 0x8e, 0xf3, 0x21, 0x33, 0x76,
+	0xac, 0xc4, 0xb1, 0x12, 0xcb, 0x7e, 0xdb, 0xd8, 0x6d, 0xda, 0xca, 0x4c, 0xec, 0xa8, 0xb2, 0x5c,
+	0x9d, 0x95, 0x7b, 0xbd, 0x07, 0xdc, 0x1d, 0x92, 0x08, 0x97, 0xd8, 0x0d, 0x00, 0x4a, 0xa2, 0xaf,
+	0xfa, 0x07, 0xfa, 0xef, 0x7a, 0x7a, 0xd3, 0xdf, 0xd2, 0x73, 0x7a, 0x66, 0x80, 0x5d, 0x7e, 0x58,
+	0x37, 0x3c, 0x8b, 0xe7, 0x79, 0x06, 0x1f, 0x33, 0x03, 0x60, 0x40, 0xb6, 0x9e, 0xe4, 0xba, 0xa7,
+	0xfa, 0x87, 0x85, 0xc9, 0x5d, 0xce, 0x6b, 0x1a, 0xba, 0x19, 0xb8, 0xa2, 0xdb, 0xfe, 0xe7, 0x32,
+	0x5b, 0xeb, 0x10, 0xc5, 0x9f, 0xb2, 0xdb, 0x1a, 0xdc, 0x55, 0x6e, 0x86, 0x62, 0x69, 0x7f, 0xe9,
+	0xa0, 0x71, 0xb4, 0x77, 0x58, 0xca, 0x0e, 0xdf, 0x79, 0xc2, 0x2b, 0xa3, 0x52, 0xc7, 0x1f, 0xb3,
+	0x5b, 0xc9, 0x40, 0x2a, 0x2d, 0x96, 0xc9, 0xe0, 0xce, 0xd4, 0xa0, 0x83, 0x70, 0x90, 0x7b, 0x0d,
+	0x7f, 0xc8, 0x56, 0x4c, 0x91, 0x88, 0x15, 0x92, 0x6e, 0x4f, 0xa5, 0xd1, 0x79, 0x27, 0x08, 0x91,
+	0xc7, 0x3e, 0xad, 0x93, 0xce, 0x8a, 0x74, 0xb1, 0xcf, 0x0b, 0x84, 0xcb, 0x3e, 0x49, 0xc3, 0x0f,
+	0xd8, 0xea, 0x48, 0xd9, 0x44, 0x00, 0x69, 0x77, 0xa6, 0xda, 0x33, 0x65, 0x93, 0x20, 0x25, 0x05,
+	0x8e, 0x2e, 0x8b, 0x42, 0xf4, 0x16, 0x47, 0x3f, 0x2e, 0x8a, 0x72, 0x74, 0x59, 0x14, 0xed, 0x7f,
+	0x2f, 0xb1, 0xe6, 0xdc, 0x62, 0x39, 0x67, 0xab, 0x16, 0x20, 0x15, 0x4b, 0xfb, 0x2b, 0x07, 0xf5,
+	0x88, 0xbe, 0xf9, 0x2e, 0x5b, 0xcb, 0x94, 0x75, 0x80, 0x0b, 0x47, 0x34, 0xb4, 0xf8, 0x17, 0xac,
+	0x51, 0x18, 0x75, 0x29, 0x1d, 0xc4, 0x43, 0x98, 0xd0, 0x52, 0xeb, 0x11, 0x0b, 0xd0, 0x29, 0x4c,
+	0xf8, 0x67, 0x8c, 0x05, 0xdf, 0xc5, 0x2a, 0x15, 0xab, 0xfb, 0x4b, 0x07, 0xcd, 0xa8, 0x1e, 0x90,
+	0x93, 0x94, 0x7f, 0xc9, 0x9a, 0xd6, 0x19, 0x90, 0xa3, 0x38, 0x53, 0x23, 0xe5, 0xac, 0xb8, 0xb5,
+	0xbf, 0x74, 0x70, 0x2b, 0x5a, 0xf7, 0xe0, 0x5b, 0xc2, 0xf8, 0x73, 0xb6, 0x6b, 0xc0, 0x82, 0xb9,
+	0x84, 0x34, 0x9e, 0x57, 0xaf, 0x91, 0x7a, 0xa7, 0x64, 0x2f, 0x66, 0xac, 0xda, 0xff, 0xe1, 0xac,
+	0x31, 0x13, 0x14, 0x7e, 0x97, 0xd5, 0x28, 0x2c, 0x38, 0x8f, 0x25, 0x9a, 0xc7, 0x6d, 0x6a, 0x9f,
+	0xa4, 0x5c, 0xb0, 0xdb, 0x7d, 0xd0, 0x60, 0x95, 0xa5, 0xb8, 0xd6, 0xa3, 0xb2, 0x89, 0x4c, 0x2a,
+	0x9d, 0x4c, 0x95, 0x11, 0x0d, 0xcf, 0x84, 0x26, 0x7a, 0x64, 0x08, 0x13, 0x24, 0xd6, 0x89, 0x08,
+	0x2d, 0x5c, 0xb0, 0x75, 0xd2, 0xb8, 0x78, 0xa4, 0x34, 0x88, 0x9d, 0xfd, 0xa5, 0x83, 0x5a, 0x54,
+	0x27, 0xe4, 0x4c, 0x69, 0xe0, 0x9f, 0xb0, 0x5a, 0x92, 0x2b, 0xdd, 0x95, 0x16, 0xc4, 0x1d, 0x32,
+	0xac, 0xda, 0x7c, 0x87, 0xdd, 0x42, 0x23, 0x23, 0x76, 0x89, 0xf0, 0x0d, 0xfe, 0x39, 0x63, 0x85,
+	0xb4, 0xb6, 0x18, 0x18, 0xb4, 0xd9, 0x0b, 0x1e, 0xae, 0x10, 0xfe, 0x82, 0xdd, 0x05, 0x2d, 0xbb,
+	0x19, 0xc4, 0x06, 0x46, 0xb9, 0x83, 0xd8, 0xaa, 0xbe, 0x8e, 0xc9, 0x21, 0x46, 0x08, 0x1a, 0x7f,
+	0xd7, 0x0b, 0x22, 0xe2, 0x2f, 0x54, 0x5f, 0x5f, 0x10, 0xcb, 0xbf, 0x65, 0xfc, 0x06, 0x9b, 0xbb,
+	0x34, 0xc4, 0xa6, 0x59, 0x54, 0xdf, 0x63, 0xf5, 0xbe, 0xb4, 0x71, 0x61, 0x54, 0x02, 0xe2, 0x13,
+	0x3f, 0xf7, 0xbe, 0xb4, 0xe7, 0xd8, 0x2e, 0x49, 0x8a, 0x8b, 0xb8, 0x57, 0x91, 0x14, 0x0b, 0xfe,
+	0x98, 0x6d, 0xe1, 0x00, 0xd2, 0x8d, 0x0d, 0xc4, 0x89, 0x2a, 0x06, 0x60, 0xac, 0xf8, 0x94, 0x12,
+	0x69, 0xb3, 0x22, 0x3a, 0x1e, 0x27, 0x07, 0x8e, 0x0b, 0x30, 0xb1, 0xce, 0x53, 0x10, 0x9f, 0x07,
+	0x07, 0x22, 0xf2, 0x2e, 0x4f, 0x81, 0x7f, 0xc7, 0xb6, 0xc7, 0xda, 0x8e, 0x8b, 0x22, 0x37, 0x0e,
+	0x52, 0xcc, 0xba, 0xab, 0xdc, 0xa4, 0xe2, 0x0b, 0x1a, 0x92, 0xcf, 0x50, 0xa7, 0x9e, 0x09, 0x01,
+	0x71, 0x10, 0x8f, 0xb0, 0xbf, 0x7d, 0xd2, 0xd5, 0x09, 0x39, 0xc3, 0xfe, 0x1e, 0xb1, 0x0d, 0x4f,
+	0x1b, 0x70, 0xa0, 0x9d, 0xca, 0xb5, 0xb8, 0xbf, 0xbf, 0x74, 0xb0, 0x1a, 0xb5, 0x08, 0x8e, 0x4a,
+	0x94, 0x1f, 0xb1, 0x3b, 0x5e, 0x58, 0x28, 0xad, 0x21, 0x8d, 0x95, 0x76, 0x60, 0x2e, 0x65, 0x26,
+	0xda, 0x24, 0xdf, 0x26, 0xf2, 0x9c, 0xb8, 0x93, 0x40, 0xe1, 0x64, 0x93, 0x01, 0x24, 0xc3, 0x22,
+	0x57, 0xda, 0x4d, 0x2d, 0xbe, 0x24, 0x0b, 0x3e, 0xa5, 0x2a, 0x83, 0xcf, 0x18, 0xcb, 0x54, 0x7f,
+	0xe0, 0xfc, 0xe2, 0x1f, 0xf8, 0xc5, 0x13, 0x42, 0x8b, 0x7f, 0xc2, 0xb6, 0xbb, 0x59, 0x9e, 0x0c,
+	0x31, 0xb9, 0xe2, 0x69, 0x30, 0x1e, 0xfa, 0x88, 0x11, 0x75, 0xa6, 0xf4, 0x9b, 0x32, 0x28, 0x8f,
+	0xd8, 0x86, 0x33, 0x32, 0x81, 0x18, 0xae, 0x21, 0x19, 0xd3, 0xda, 0xbe, 0xa2, 0x2e, 0x5b, 0x04,
+	0xff, 0x5c, 0xa2, 0xfc, 0x19, 0xdb, 0xf5, 0xfd, 0x56, 0x31, 0x8c, 0x9d, 0x34, 0x7d, 0x70, 0xe2,
+	0x11, 0x75, 0xed, 0x47, 0x7d, 0x13, 0xe2, 0xf9, 0x9e, 0x28, 0xef, 0xb9, 0xdc, 0xc8, 0x3e, 0xc4,
+	0x5d, 0x99, 0x0c, 0x41, 0xa7, 0xe2, 0x80, 0xd4, 0xad, 0x00, 0xbf, 0xf2, 0x28, 0xff, 0x0a, 0xa7,
+	0xa1, 0x20, 0x4e, 0x64, 0x32, 0xc0, 0x54, 0xfb, 0x00, 0xe2, 0x6b, 0xda, 0x80, 0x4d, 0x84, 0x3b,
+	0x88, 0x5e, 0xa8, 0x0f, 0xc0, 0xbf, 0x61, 0x5b, 0xde, 0xc3, 0xfd, 0x64, 0xea, 0xab, 0x6f, 0x48,
+	0xe9, 0x63, 0xf4, 0x26, 0xa9, 0x1c, 0xd5, 0x66, 0xcd, 0x4a, 0x6b, 0xa4, 0x03, 0xf1, 0x98, 0x74,
+	0x8d, 0xa0, 0x8b, 0xa4, 0x03, 0x4c, 0xbb, 0x9e, 0x01, 0xf8, 0x00, 0x26, 0x76, 0x03, 0x03, 0x76,
+	0x90, 0x67, 0xa9, 0xf8, 0x96, 0x7c, 0xbf, 0x19, 0x88, 0xf7, 0x25, 0x8e, 0x27, 0x59, 0x29, 0xc6,
+	0x4d, 0xfd, 0xc4, 0xef, 0xb3, 0x00, 0xfd, 0xa4, 0x28, 0xfd, 0x0d, 0xc8, 0x34, 0xce, 0x75, 0x36,
+	0x11, 0x87, 0xe4, 0xc6, 0x1a, 0x02, 0x7f, 0xd3, 0xd9, 0x04, 0x49, 0x3b, 0xd1, 0x89, 0xcf, 0xb1,
+	0xef, 0x7c, 0xfa, 0x23, 0x50, 0xa6, 0x58, 0xd8, 0xa1, 0x3d, 0xa5, 0x65, 0xa6, 0xdc, 0x44, 0x7c,
+	0xef, 0xc3, 0xe0, 0xe1, 0xd7, 0x01, 0xe5, 0x9b, 0x6c, 0x25, 0x85, 0x4b, 0xf1, 0x94, 0x48, 0xfc,
+	0xc4, 0x7c, 0x48, 0xe1, 0x32, 0x2e, 0xc0, 0xa8, 0x3c, 0x15, 0x47, 0xfe, 0xf8, 0x4c, 0xe1, 0xf2,
+	0x9c, 0x00, 0x7e, 0x9f, 0xad, 0x23, 0x2d, 0x93, 0x24, 0x1f, 0x6b, 0x67, 0xc5, 0x33, 0xda, 0x53,
+	0x8d, 0x14, 0x2e, 0x8f, 0x03, 0x84, 0x92, 0x21, 0x4c, 0x30, 0x22, 0x10, 0x0f, 0xd3, 0x9e, 0x78,
+	0x4e, 0x93, 0x6b, 0x94, 0xd8, 0x69, 0xda, 0x43, 0xbf, 0x57, 0x12, 0x9b, 0x98, 0x49, 0xe1, 0x62,
+	0x2d, 0xfe, 0xdf, 0xfb, 0xbd, 0x24, 0x2e, 0x08, 0x7f, 0x77, 0x93, 0xd6, 0x88, 0xdf, 0xdd, 0xa4,
+	0x8d, 0x6e, 0xd2, 0x16, 0xe2, 0xf7, 0x37, 0x69, 0xcf, 0xf1, 0x8c, 0xaf, 0xb4, 0xd2, 0xf4, 0x73,
+	0x7d, 0xa4, 0xd2, 0xd8, 0xa9, 0x11, 0x88, 0x1f, 0xc8, 0x60, 0xa7, 0x64, 0x8f, 0x03, 0xf9, 0x5e,
+	0x8d, 0x80, 0xff, 0xc0, 0xc4, 0xc7, 0x56, 0x23, 0x18, 0xe5, 0x66, 0x22, 0x5e, 0x90, 0xdd, 0xee,
+	0xa2, 0xdd, 0x19, 0xb1, 0xfc, 0x25, 0xbb, 0x7b, 0xc3, 0x78, 0x03, 0x0c, 0xa8, 0x15, 0x2f, 0xc9,
+	0x74, 0xef, 0xa3, 0x21, 0x3d, 0x8d, 0x97, 0xd6, 0xcc, 0xb1, 0x09, 0x46, 0xfc, 0x81, 0x7c, 0xba,
+	0x3e, 0x3d, 0x31, 0xc1, 0xf0, 0x43, 0xb6, 0x3d, 0x27, 0x8a, 0x5d, 0x3e, 0x04, 0x2d, 0xfe, 0x48,
+	0xd2, 0xad, 0x59, 0xe9, 0x7b, 0x24, 0xf8, 0x01, 0xdb, 0x9c, 0xd7, 0x27, 0x52, 0xfc, 0xe8, 0xb7,
+	0xd3, 0xac, 0xb8, 0x23, 0x17, 0x4e, 0x6d, 0x54, 0x82, 0x71, 0xe2, 0x4f, 0x8b, 0xa7, 0x36, 0x98,
+	0x0e, 0x18, 0x87, 0x41, 0x98, 0x57, 0xe3, 0x3d, 0xfd, 0x67, 0x12, 0x6f, 0xcc, 0x8a, 0xf1, 0xb2,
+	0xa6, 0x8b, 0x76, 0x56, 0x5b, 0x25, 0xd6, 0x5f, 0x28, 0xb1, 0x76, 0x66, 0x0d, 0xaa, 0x0c, 0x7b,
+	0xc8, 0x5a, 0xc5, 0x30, 0xb1, 0x4f, 0x9f, 0xc6, 0x99, 0xea, 0x1a, 0x69, 0x26, 0xe2, 0x98, 0xba,
+	0x6f, 0x7a, 0xf4, 0xad, 0x07, 0x71, 0xda, 0x41, 0x46, 0x9e, 0x88, 0x33, 0xd9, 0x85, 0x4c, 0xbc,
+	0xf2, 0xd3, 0xf6, 0x0c, 0x79, 0xe2, 0x2d, 0xe2, 0x98, 0xf8, 0x41, 0x5d, 0x28, 0x2d, 0x3a, 0xfe,
+	0xd4, 0xf6, 0xc8, 0xb9, 0xd2, 0xfc, 0x41, 0x35, 0xe6, 0x10, 0x26, 0xb1, 0x4a, 0xad, 0xf8, 0x89,
+	0x66, 0xb8, 0xee, 0xd1, 0x53, 0x98, 0x9c, 0xa4, 0x16, 0x0f, 0x80, 0x6a, 0xe3, 0xc7, 0xf8, 0x83,
+	0xf7, 0xce, 0xcf, 0xfe, 0xde, 0xa9, 0x88, 0x5f, 0x3c, 0x8e, 0x8b, 0x5f, 0x14, 0x87, 0x98, 0xbd,
+	0xa6, 0xd1, 0x77, 0x16, 0x2c, 0x7c, 0xd8, 0x0e, 0xd9, 0xf6, 0x47, 0x56, 0x89, 0x14, 0x6f, 0x7c,
+	0x98, 0x17, 0x4c, 0x3a, 0x12, 0x6f, 0x91, 0x8f, 0xf5, 0x18, 0xbf, 0x5f, 0xfc, 0x41, 0xbb, 0x68,
+	0x81, 0x21, 0xfc, 0x9e, 0xed, 0x7c, 0x64, 0x83, 0x51, 0x3c, 0x21, 0x13, 0xbe, 0x60, 0x82, 0x81,
+	0x7c, 0x36, 0x3b, 0x4a, 0x92, 0x8f, 0x46, 0xca, 0x8d, 0x00, 0xe3, 0xf8, 0xd7, 0x85, 0xa5, 0x74,
+	0xa6, 0x1c, 0x7a, 0xcb, 0xdf, 0x3d, 0xd3, 0x7b, 0xc9, 0x8a, 0x53, 0xef, 0x2d, 0x22, 0x3a, 0x53,
+	0xbc, 0xfd, 0xaf, 0xdb, 0xac, 0x5e, 0xd5, 0xb1, 0x18, 0x2d, 0x53, 0x24, 0x71, 0x28, 0x11, 0x7d,
+	0xe1, 0x58, 0x37, 0x45, 0xf2, 0xb6, 0xaa, 0x12, 0x07, 0xce, 0x15, 0xf1, 0x5c, 0x09, 0xc9, 0x10,
+	0x5a, 0x10, 0x8c, 0xf2, 0x74, 0x9c, 0x81, 0x58, 0x99, 0x0a, 0xce, 0x08, 0xc1, 0xb9, 0x25, 0xb9,
+	0xd6, 0x90, 0xe0, 0x75, 0x55, 0x56, 0x7f, 0xab, 0x54, 0xfd, 0x6d, 0x4e, 0x89, 0x50, 0x2f, 0x4e,
+	0x87, 0x9b, 0x29, 0x29, 0xc3, 0x70, 0x24, 0xb8, 0xc7, 0xea, 0x24, 0x48, 0x72, 0x83, 0x35, 0x24,
+	0x0e, 0x56, 0x43, 0xa0, 0x93, 0x1b, 0xac, 0xb0, 0x37, 0x41, 0xf7, 0x95, 0x86, 0xb8, 0xc8, 0xf3,
+	0xcc, 0x5f, 0x57, 0xb7, 0xa9, 0x8b, 0x96, 0xc7, 0xcf, 0xf3, 0x3c, 0xa3, 0xfb, 0xea, 0x31, 0xdb,
+	0x92, 0x59, 0x96, 0x5f, 0x01, 0xfa, 0x58, 0xe3, 0x95, 0xea, 0xac, 0xa8, 0x79, 0x87, 0x05, 0xa2,
+	0x53, 0xe2, 0xfc, 0x6b, 0xb6, 0x99, 0x82, 0x56, 0x73, 0xda, 0x3a, 0x69, 0x37, 0x3c, 0x3e, 0x95,
+	0x3e, 0x61, 0x3c, 0x55, 0x36, 0x94, 0x74, 0xbd, 0xcc, 0xaf, 0x4d, 0x30, 0xba, 0x15, 0xb6, 0x02,
+	0x13, 0x55, 0x04, 0xae, 0xe6, 0xca, 0x96, 0xbe, 0x6d, 0xf8, 0xd5, 0x5c, 0xd9, 0xe0, 0xd9, 0x07,
+	0xac, 0x75, 0x65, 0xe3, 0xee, 0xb8, 0xd7, 0x03, 0xe3, 0xd7, 0xb2, 0x4e, 0x87, 0xdb, 0xfa, 0x95,
+	0x7d, 0x45, 0x20, 0xad, 0xe4, 0x3e, 0x5b, 0x97, 0x29, 0xd6, 0x14, 0xa1, 0x97, 0xa6, 0xbf, 0x47,
+	0x08, 0x0b, 0x1d, 0xdd, 0x67, 0xe1, 0x7c, 0x8b, 0x09, 0x15, 0x2d, 0x9a, 0x4e, 0xc3, 0x63, 0xc7,
+	0x08, 0xf1, 0x17, 0xac, 0x91, 0x18, 0x48, 0xb1, 0x5e, 0x92, 0x99, 0x15, 0x1b, 0xfb, 0x2b, 0xf3,
+	0x6f, 0x2a, 0xcc, 0x97, 0x8a, 0x8f, 0x66, 0xb5, 0xfc, 0x53, 0x56, 0x97, 0x3a, 0xd7, 0x93, 0x51,
+	0x3e, 0xb6, 0x62, 0xd3, 0xe7, 0x4f, 0x05, 0x60, 0xe9, 0xee, 0x32, 0xeb, 0xf7, 0xc9, 0x96, 0x2f,
+	0xc3, 0x5d, 0x66, 0x69, 0x6f, 0xec, 0x31, 0xfc, 0xa4, 0xed, 0xc0, 0x7d, 0x1d, 0xee, 0x32, 0x8b,
+	0x5b, 0xa0, 0xcd, 0x9a, 0x64, 0x93, 0x29, 0xd0, 0x0e, 0xb7, 0xe4, 0xb6, 0xbf, 0xf8, 0xd0, 0x90,
+	0xb0, 0x8e, 0xc4, 0xb3, 0x31, 0xf0, 0x06, 0x4b, 0x09, 0x5f, 0xbc, 0xee, 0xf8, 0x0b, 0xca, 0x13,
+	0x58, 0x47, 0xf8, 0x1a, 0x76, 0x41, 0xdb, 0x1d, 0x1b, 0xeb, 0xc4, 0x9d, 0x45, 0xed, 0x2b, 0x84,
+	0x31, 0x85, 0x06, 0x20, 0x2f, 0x27, 0xb3, 0xdd, 0xee, 0x92, 0xb4, 0x45, 0xf8, 0xb4, 0xd7, 0x79,
+	0xa5, 0xef, 0x74, 0x6f, 0x41, 0x59, 0xf5, 0x39, 0x92, 0xd7, 0xb1, 0x81, 0xdf, 0xc6, 0x60, 0x9d,
+	0x0f, 0xa5, 0xf0, 0xca, 0x91, 0xbc, 0x8e, 0x3c, 0x4c, 0xc1, 0x7c, 0xc9, 0xee, 0xa2, 0x32, 0xc9,
+	0x75, 0x32, 0x36, 0x06, 0x67, 0x5c, 0x95, 0x7f, 0x96, 0x8a, 0xfb, 0x66, 0xb4, 0x37, 0x92, 0xd7,
+	0x9d, 0x8a, 0xaf, 0xea, 0x40, 0xdb, 0xfe, 0x8d, 0x35, 0xe7, 0xa2, 0x84, 0x8f, 0x41, 0x2d, 0x47,
+	0x40, 0x2f, 0xa6, 0x7a, 0x44, 0xdf, 0xe8, 0x73, 0x59, 0x28, 0xf2, 0xb9, 0x7f, 0x2e, 0xad, 0xc9,
+	0x42, 0x85, 0xc7, 0xde, 0xaf, 0x57, 0x2e, 0xb6, 0x90, 0x18, 0x70, 0xe1, 0x31, 0x58, 0xff, 0xf5,
+	0xca, 0x5d, 0x10, 0x80, 0xef, 0x1b, 0xda, 0x16, 0x62, 0x95, 0x02, 0xec, 0x1b, 0xed, 0xff, 0x2e,
+	0xb1, 0x7a, 0xf5, 0x26, 0xc5, 0x64, 0xce, 0xf2, 0x7e, 0x9c, 0xc1, 0x25, 0x64, 0x61, 0xd0, 0x5a,
+	0x96, 0xf7, 0xdf, 0x62, 0x1b, 0xf3, 0x00, 0xc9, 0x9e, 0xca, 0xa0, 0x7c, 0xa8, 0x65, 0x79, 0xff,
+	0xb5, 0xca, 0x68, 0x4e, 0x48, 0xc9, 0x3e, 0xd0, 0xb8, 0xcd, 0x68, 0x2d, 0xcb, 0xfb, 0xc7, 0x7d,
+	0xc0, 0x03, 0x3a, 0x14, 0x5f, 0x89, 0x91, 0x76, 0x10, 0x1b, 0xc0, 0xe7, 0x01, 0x9d, 0x1d, 0xb5,
+	0x68, 0xcb, 0x53, 0x1d, 0x64, 0x22, 0x22, 0xd0, 0xcf, 0xb3, 0xc2, 0x78, 0x6c, 0x32, 0x3a, 0x41,
+	0xea, 0x51, 0x2b, 0x99, 0xca, 0xfe, 0x6e, 0x32, 0x7c, 0xb7, 0x17, 0x85, 0xc9, 0x7b, 0x62, 0x6d,
+	0xf1, 0xdd, 0x7e, 0x8e, 0x70, 0xf9, 0x6e, 0x27, 0x0d, 0x3e, 0x24, 0x2f, 0xc1, 0x58, 0xdc, 0xc8,
+	0xa9, 0x9f, 0x79, 0x68, 0xb6, 0x35, 0x6b, 0xcc, 0xe8, 0x17, 0xcf, 0x4a, 0xef, 0x82, 0xd9, 0xb3,
+	0xf2, 0x73, 0xc6, 0x92, 0x62, 0x8c, 0x16, 0x53, 0x37, 0xcc, 0x20, 0xc8, 0x8f, 0x60, 0x54, 0xf2,
+	0xe1, 0x45, 0x3e, 0x45, 0xda, 0xa7, 0x8c, 0x4d, 0xff, 0x2b, 0xe0, 0x3f, 0xb2, 0x7b, 0x29, 0xf4,
+	0xe4, 0x38, 0x73, 0x71, 0x55, 0x0f, 0xa1, 0x0c, 0x9f, 0x69, 0x60, 0xc2, 0xf0, 0x22, 0x48, 0x4e,
+	0x83, 0x02, 0x3d, 0xde, 0x41, 0xbe, 0xfd, 0x8f, 0x65, 0xd6, 0x98, 0xf9, 0x97, 0x02, 0x6b, 0x81,
+	0xe0, 0xed, 0x11, 0x38, 0xa3, 0x12, 0x4b, 0x3d, 0xd4, 0xa2, 0xa6, 0x47, 0xcf, 0x3c, 0xc8, 0xcf,
+	0xb1, 0xd8, 0x41, 0x3f, 0x2a, 0xdd, 0x2f, 0x0f, 0x7d, 0xbc, 0x15, 0x5a, 0x47, 0x0f, 0x6f, 0xfc,
+	0xf7, 0xe3, 0x30, 0x2a, 0xd5, 0xfe, 0x3e, 0xc0, 0xd2, 0x65, 0x0e, 0xe0, 0xcf, 0x59, 0x4d, 0xe9,
+	0x5e, 0x36, 0xbe, 0x4e, 0xbb, 0xf4, 0x52, 0x6f, 0x1c, 0x89, 0x69, 0x4f, 0x27, 0x81, 0x09, 0x21,
+	0xa9, 0x94, 0x78, 0xa8, 0x85, 0x79, 0xc6, 0x4e, 0xf6, 0xad, 0x58, 0xf7, 0xe7, 0x5e, 0xc0, 0xde,
+	0xcb, 0xbe, 0x6d, 0x7f, 0xc1, 0x36, 0x16, 0x06, 0xe7, 0xeb, 0xac, 0x56, 0xf6, 0xb8, 0xf9, 0x7f,
+	0xed, 0x6b, 0xd6, 0x9a, 0xef, 0x1f, 0xf7, 0xcc, 0x20, 0xb7, 0xae, 0xdc, 0x33, 0xf8, 0x8d, 0x18,
+	0xe5, 0xdd, 0x32, 0x25, 0x27, 0x7d, 0xf3, 0x16, 0x5b, 0x4e, 0xbb, 0x21, 0x42, 0xcb, 0x69, 0x17,
+	0x35, 0x63, 0x0b, 0x86, 0x72, 0xb3, 0x1e, 0xd1, 0x37, 0xfe, 0x5f, 0x80, 0x6f, 0x7d, 0x7a, 0xe3,
+	0xfa, 0x34, 0xac, 0xda, 0xdd, 0x35, 0xfa, 0x6f, 0xeb, 0xd9, 0xff, 0x06, 0x00, 0x27, 0xf0, 0xd8,
+	0x6d, 0xeb, 0x12, 0x00, 0x00,
 }
@@ -0,0 +1,152 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package net
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// Clock guard configuration
+var (
+	// MaxClockDrift the offset of the local clock from the median of the peers
+	// above which the local clock is considered drifted.
+	MaxClockDrift = 2 * time.Second
+
+	// MinClockSamples the number of peers reporting their clock needed to judge the local clock.
+	MinClockSamples = 3
+
+	// ClockPingInterval the interval to ping the peers for their clock.
+	ClockPingInterval = 60 * time.Second
+
+	// MaxPingRoundTrip the round trip above which a pong is too late to sample the peer's clock.
+	MaxPingRoundTrip = 5 * time.Second
+)
+
+// nowInMs return the local clock in ms.
+func nowInMs() int64 {
+	return time.Now().UnixNano() / int64(time.Millisecond)
+}
+
+// ClockGuard keeps the offset of the clock of each peer from the local clock,
+// sampled from the timestamps in hello, ok and pong messages. Slot-based
+// consensus relies on the local clock, validators should not mint once the
+// local clock drifts from the median of the peers.
+type ClockGuard struct {
+	mu      sync.Mutex
+	offsets map[string]time.Duration
+	drifted bool
+}
+
+// NewClockGuard return a new clock guard.
+func NewClockGuard() *ClockGuard {
+	return &ClockGuard{
+		offsets: make(map[string]time.Duration),
+	}
+}
+
+// AddSample record the offset of the peer's clock, which was peerTs in ms when
+// the local clock was localTs in ms.
+func (c *ClockGuard) AddSample(pid string, peerTs, localTs int64) {
+	if peerTs <= 0 {
+		// peers of old versions don't report their clock.
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.offsets[pid] = time.Duration(peerTs-localTs) * time.Millisecond
+	c.check()
+}
+
+// RemovePeer forget the clock of a disconnected peer.
+func (c *ClockGuard) RemovePeer(pid string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.offsets[pid]; ok {
+		delete(c.offsets, pid)
+		c.check()
+	}
+}
+
+// Offset return the median offset of the peers' clocks from the local clock
+// and the number of peers sampled.
+func (c *ClockGuard) Offset() (time.Duration, int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.median(), len(c.offsets)
+}
+
+// Drifted return if the local clock drifts from the peers over MaxClockDrift.
+func (c *ClockGuard) Drifted() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.drifted
+}
+
+func (c *ClockGuard) median() time.Duration {
+	if len(c.offsets) == 0 {
+		return 0
+	}
+	offsets := make([]time.Duration, 0, len(c.offsets))
+	for _, v := range c.offsets {
+		offsets = append(offsets, v)
+	}
+	sort.Slice(offsets, func(i, j int) bool { return offsets[i] < offsets[j] })
+
+	mid := len(offsets) / 2
+	if len(offsets)%2 == 0 {
+		return (offsets[mid-1] + offsets[mid]) / 2
+	}
+	return offsets[mid]
+}
+
+func (c *ClockGuard) check() {
+	if len(c.offsets) < MinClockSamples {
+		// too few peers to judge, keep the last decision.
+		return
+	}
+	offset := c.median()
+	drifted := offset > MaxClockDrift || offset < -MaxClockDrift
+	if drifted == c.drifted {
+		return
+	}
+	c.drifted = drifted
+
+	if drifted {
+		logging.CLog().WithFields(logrus.Fields{
+			"offset":  offset,
+			"max":     MaxClockDrift,
+			"samples": len(c.offsets),
+		}).Error("Local clock drifts from the peers, mining is refused until the clock is synchronized. Please check the NTP service.")
+	} else {
+		logging.CLog().WithFields(logrus.Fields{
+			"offset":  offset,
+			"samples": len(c.offsets),
+		}).Info("Local clock is synchronized with the peers again.")
+	}
+}
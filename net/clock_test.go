@@ -0,0 +1,61 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package net
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClockGuard(t *testing.T) {
+	c := NewClockGuard()
+	now := nowInMs()
+
+	// old peers report no clock.
+	c.AddSample("a", 0, now)
+	_, samples := c.Offset()
+	assert.Equal(t, 0, samples)
+
+	drift := int64(MaxClockDrift/time.Millisecond) + 1000
+	c.AddSample("a", now+drift, now)
+	c.AddSample("b", now+drift, now)
+	assert.False(t, c.Drifted(), "too few samples to judge")
+
+	c.AddSample("c", now-drift, now)
+	assert.True(t, c.Drifted())
+	offset, samples := c.Offset()
+	assert.Equal(t, 3, samples)
+	assert.Equal(t, time.Duration(drift)*time.Millisecond, offset)
+
+	// the median follows the majority.
+	c.AddSample("b", now, now)
+	c.AddSample("d", now+100, now)
+	assert.False(t, c.Drifted())
+	offset, _ = c.Offset()
+	assert.Equal(t, 50*time.Millisecond, offset)
+
+	c.RemovePeer("d")
+	c.RemovePeer("c")
+	offset, samples = c.Offset()
+	assert.Equal(t, 2, samples)
+	assert.Equal(t, time.Duration(drift)*time.Millisecond/2, offset)
+	assert.False(t, c.Drifted(), "too few samples, keep the last decision")
+}
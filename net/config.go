@@ -60,14 +60,22 @@ type Config struct {
 	Listen               []string
 	MaxSyncNodes         int
 	ChainID              uint32
+	ForkFilter           ForkFilter
 	RoutingTableDir      string
 	StreamLimits         int32
 	ReservedStreamLimits int32
 }
 
+// ForkFilter tells the fork id of the local chain, and if a peer on the fork id is compatible.
+type ForkFilter interface {
+	ForkID() string
+	CheckForkID(id string) bool
+}
+
 // Neblet interface breaks cycle import dependency.
 type Neblet interface {
 	Config() *nebletpb.Config
+	ForkFilter
 }
 
 // NewP2PConfig return new config object.
@@ -94,6 +102,9 @@ func NewP2PConfig(n Neblet) *Config {
 	// Chain ID.
 	config.ChainID = chainConf.ChainId
 
+	// Fork ID, peers on different forks are refused in handshake.
+	config.ForkFilter = n
+
 	// routing table dir.
 	// TODO: @robin using diff dir for temp files.
 	if checkPathConfig(chainConf.Datadir) == false {
@@ -152,6 +163,7 @@ func NewConfigFromDefaults() *Config {
 		DefaultListen,
 		DefaultMaxSyncNodes,
 		DefaultChainID,
+		nil,
 		DefaultRoutingTableDir,
 		DefaultMaxStreamNum,
 		DefaultReservedStreamNum,
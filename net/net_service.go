@@ -19,6 +19,8 @@
 package net
 
 import (
+	peer "github.com/libp2p/go-libp2p-peer"
+	"github.com/multiformats/go-multiaddr"
 	"github.com/nebulasio/go-nebulas/util/logging"
 	"github.com/sirupsen/logrus"
 )
@@ -131,3 +133,51 @@ func (ns *NebService) SendMessageToPeer(messageName string, data []byte, priorit
 func (ns *NebService) ClosePeer(peerID string, reason error) {
 	ns.node.streamManager.CloseStream(peerID, reason)
 }
+
+// AddPeer add a peer by its ipfs address, e.g. "/ip4/127.0.0.1/tcp/8680/ipfs/QmP7...",
+// and connect to it. The id of the peer is returned.
+func (ns *NebService) AddPeer(addr string) (string, error) {
+	ipfsAddr, err := multiaddr.NewMultiaddr(addr)
+	if err != nil {
+		return "", err
+	}
+	pid, peerAddr, err := ParseFromIPFSAddr(ipfsAddr)
+	if err != nil {
+		return "", err
+	}
+	if pid == ns.node.id {
+		return "", ErrAddSelfAsPeer
+	}
+
+	ns.node.routeTable.AddPeer(pid, peerAddr)
+	ns.node.routeTable.SyncWithPeer(pid)
+
+	logging.VLog().WithFields(logrus.Fields{
+		"pid":  pid.Pretty(),
+		"addr": peerAddr,
+	}).Info("Added peer.")
+	return pid.Pretty(), nil
+}
+
+// RemovePeer disconnect a peer and remove it from route table, it may be
+// added back later by the route table sync of other peers.
+func (ns *NebService) RemovePeer(peerID string) error {
+	pid, err := peer.IDB58Decode(peerID)
+	if err != nil {
+		return err
+	}
+	stream := ns.node.streamManager.Find(pid)
+	if stream == nil && ns.node.routeTable.routeTable.Find(pid) == "" {
+		return ErrPeerIsNotFound
+	}
+
+	if stream != nil {
+		ns.node.streamManager.CloseStream(peerID, ErrPeerIsRemoved)
+	}
+	ns.node.routeTable.RemovePeer(pid)
+
+	logging.VLog().WithFields(logrus.Fields{
+		"pid": peerID,
+	}).Info("Removed peer.")
+	return nil
+}
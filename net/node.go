@@ -22,8 +22,6 @@ import (
 	"context"
 
 	"errors"
-	"fmt"
-	"net"
 
 	crypto "github.com/libp2p/go-libp2p-crypto"
 	libnet "github.com/libp2p/go-libp2p-net"
@@ -61,6 +59,7 @@ type Node struct {
 	host          *basichost.BasicHost
 	streamManager *StreamManager
 	routeTable    *RouteTable
+	clock         *ClockGuard
 }
 
 // NewNode return new Node according to the config.
@@ -79,6 +78,7 @@ func NewNode(config *Config) (*Node, error) {
 		config:        config,
 		context:       context.Background(),
 		streamManager: NewStreamManager(config),
+		clock:         NewClockGuard(),
 		synchronizing: false,
 	}
 
@@ -168,6 +168,11 @@ func (node *Node) ID() string {
 	return node.id.Pretty()
 }
 
+// Clock return the guard of the local clock against the peers
+func (node *Node) Clock() *ClockGuard {
+	return node.clock
+}
+
 // IsSynchronizing return node synchronizing
 func (node *Node) IsSynchronizing() bool {
 	return node.synchronizing
@@ -183,6 +188,22 @@ func (node *Node) PeersCount() int32 {
 	return node.streamManager.Count()
 }
 
+// ExternalAddrs return the listening addresses which can be reached by remote peers,
+// loopback and link-local addresses are excluded.
+func (node *Node) ExternalAddrs() []multiaddr.Multiaddr {
+	if node.host == nil {
+		return nil
+	}
+
+	addrs := make([]multiaddr.Multiaddr, 0)
+	for _, addr := range node.host.Addrs() {
+		if isExternalAddress(addr) {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
+}
+
 // RouteTable return route table.
 func (node *Node) RouteTable() *RouteTable {
 	return node.routeTable
@@ -218,22 +239,7 @@ func initP2PSwarmNetwork(config *Config, node *Node) error {
 	// init p2p multiaddr and swarm network.
 	multiaddrs := make([]multiaddr.Multiaddr, len(config.Listen))
 	for idx, v := range node.config.Listen {
-		tcpAddr, err := net.ResolveTCPAddr("tcp", v)
-		if err != nil {
-			logging.CLog().WithFields(logrus.Fields{
-				"err":    err,
-				"listen": v,
-			}).Error("Failed to bind node socket.")
-			return err
-		}
-
-		addr, err := multiaddr.NewMultiaddr(
-			fmt.Sprintf(
-				"/ip4/%s/tcp/%d",
-				tcpAddr.IP,
-				tcpAddr.Port,
-			),
-		)
+		addr, err := ParseListenAddress(v)
 		if err != nil {
 			logging.CLog().WithFields(logrus.Fields{
 				"err":    err,
@@ -245,9 +251,21 @@ func initP2PSwarmNetwork(config *Config, node *Node) error {
 		multiaddrs[idx] = addr
 	}
 
+	// the swarm listens on the given addresses before a transport can be added,
+	// so the unix sockets are listened after the unix transport is added.
+	tcpAddrs := make([]multiaddr.Multiaddr, 0, len(multiaddrs))
+	unixAddrs := make([]multiaddr.Multiaddr, 0)
+	for _, addr := range multiaddrs {
+		if isUnixAddress(addr) {
+			unixAddrs = append(unixAddrs, addr)
+		} else {
+			tcpAddrs = append(tcpAddrs, addr)
+		}
+	}
+
 	network, err := swarm.NewNetwork(
 		node.context,
-		multiaddrs,
+		tcpAddrs,
 		node.id,
 		node.routeTable.peerStore,
 		nil, // TODO: @robin integrate metrics.Reporter.
@@ -260,6 +278,17 @@ func initP2PSwarmNetwork(config *Config, node *Node) error {
 		}).Error("Failed to create swarm network.")
 		return err
 	}
+	if len(unixAddrs) > 0 {
+		network.Swarm().AddTransport(newUnixTransport())
+		if err := network.Swarm().Listen(unixAddrs...); err != nil {
+			logging.CLog().WithFields(logrus.Fields{
+				"err":            err,
+				"listen address": unixAddrs,
+			}).Error("Failed to listen on unix sockets.")
+			network.Close()
+			return err
+		}
+	}
 	node.network = network
 	return nil
 }
@@ -282,6 +311,12 @@ func (node *Node) SendMessageToPeer(messageName string, data []byte, priority in
 	return stream.SendMessage(messageName, data, priority)
 }
 
+// MarkAnnounced record the peer already has the message identified by key,
+// e.g. the peer sent us the block, so broadcast and relay will skip it.
+func (node *Node) MarkAnnounced(peerID string, key string) {
+	node.streamManager.MarkAnnounced(peerID, key)
+}
+
 // BroadcastMessage broadcast message.
 func (node *Node) BroadcastMessage(messageName string, data Serializable, priority int) {
 	// node can not broadcast or relay message if it is in synchronizing.
@@ -10,6 +10,8 @@ It is generated from these files:
 It has these top-level messages:
 	Hello
 	OK
+	Ping
+	Pong
 	Peers
 	PeerInfo
 */
@@ -33,6 +35,9 @@ const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 type Hello struct {
 	NodeId        string `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
 	ClientVersion string `protobuf:"bytes,2,opt,name=client_version,json=clientVersion,proto3" json:"client_version,omitempty"`
+	ForkId        string `protobuf:"bytes,3,opt,name=fork_id,json=forkId,proto3" json:"fork_id,omitempty"`
+	// the clock of the sender in ms.
+	Timestamp int64 `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 }
 
 func (m *Hello) Reset()                    { *m = Hello{} }
@@ -54,9 +59,26 @@ func (m *Hello) GetClientVersion() string {
 	return ""
 }
 
+func (m *Hello) GetForkId() string {
+	if m != nil {
+		return m.ForkId
+	}
+	return ""
+}
+
+func (m *Hello) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
 type OK struct {
 	NodeId        string `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
 	ClientVersion string `protobuf:"bytes,2,opt,name=client_version,json=clientVersion,proto3" json:"client_version,omitempty"`
+	ForkId        string `protobuf:"bytes,3,opt,name=fork_id,json=forkId,proto3" json:"fork_id,omitempty"`
+	// the clock of the sender in ms.
+	Timestamp int64 `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 }
 
 func (m *OK) Reset()                    { *m = OK{} }
@@ -78,6 +100,63 @@ func (m *OK) GetClientVersion() string {
 	return ""
 }
 
+func (m *OK) GetForkId() string {
+	if m != nil {
+		return m.ForkId
+	}
+	return ""
+}
+
+func (m *OK) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+type Ping struct {
+	// the clock of the sender in ms.
+	Timestamp int64 `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+}
+
+func (m *Ping) Reset()                    { *m = Ping{} }
+func (m *Ping) String() string            { return proto.CompactTextString(m) }
+func (*Ping) ProtoMessage()               {}
+func (*Ping) Descriptor() ([]byte, []int) { return fileDescriptorMessage, []int{2} }
+
+func (m *Ping) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+type Pong struct {
+	// the timestamp of the ping answered.
+	PingTimestamp int64 `protobuf:"varint,1,opt,name=ping_timestamp,json=pingTimestamp,proto3" json:"ping_timestamp,omitempty"`
+	// the clock of the sender in ms.
+	Timestamp int64 `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+}
+
+func (m *Pong) Reset()                    { *m = Pong{} }
+func (m *Pong) String() string            { return proto.CompactTextString(m) }
+func (*Pong) ProtoMessage()               {}
+func (*Pong) Descriptor() ([]byte, []int) { return fileDescriptorMessage, []int{3} }
+
+func (m *Pong) GetPingTimestamp() int64 {
+	if m != nil {
+		return m.PingTimestamp
+	}
+	return 0
+}
+
+func (m *Pong) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
 type Peers struct {
 	Peers []*PeerInfo `protobuf:"bytes,1,rep,name=peers" json:"peers,omitempty"`
 }
@@ -85,7 +164,7 @@ type Peers struct {
 func (m *Peers) Reset()                    { *m = Peers{} }
 func (m *Peers) String() string            { return proto.CompactTextString(m) }
 func (*Peers) ProtoMessage()               {}
-func (*Peers) Descriptor() ([]byte, []int) { return fileDescriptorMessage, []int{2} }
+func (*Peers) Descriptor() ([]byte, []int) { return fileDescriptorMessage, []int{4} }
 
 func (m *Peers) GetPeers() []*PeerInfo {
 	if m != nil {
@@ -102,7 +181,7 @@ type PeerInfo struct {
 func (m *PeerInfo) Reset()                    { *m = PeerInfo{} }
 func (m *PeerInfo) String() string            { return proto.CompactTextString(m) }
 func (*PeerInfo) ProtoMessage()               {}
-func (*PeerInfo) Descriptor() ([]byte, []int) { return fileDescriptorMessage, []int{3} }
+func (*PeerInfo) Descriptor() ([]byte, []int) { return fileDescriptorMessage, []int{5} }
 
 func (m *PeerInfo) GetId() string {
 	if m != nil {
@@ -121,6 +200,8 @@ func (m *PeerInfo) GetAddrs() []string {
 func init() {
 	proto.RegisterType((*Hello)(nil), "netpb.Hello")
 	proto.RegisterType((*OK)(nil), "netpb.OK")
+	proto.RegisterType((*Ping)(nil), "netpb.Ping")
+	proto.RegisterType((*Pong)(nil), "netpb.Pong")
 	proto.RegisterType((*Peers)(nil), "netpb.Peers")
 	proto.RegisterType((*PeerInfo)(nil), "netpb.PeerInfo")
 }
@@ -128,17 +209,21 @@ func init() {
 func init() { proto.RegisterFile("message.proto", fileDescriptorMessage) }
 
 var fileDescriptorMessage = []byte{
-	// 188 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0xcd, 0x4d, 0x2d, 0x2e,
-	0x4e, 0x4c, 0x4f, 0xd5, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0xcd, 0x4b, 0x2d, 0x29, 0x48,
-	0x52, 0x72, 0xe7, 0x62, 0xf5, 0x48, 0xcd, 0xc9, 0xc9, 0x17, 0x12, 0xe7, 0x62, 0xcf, 0xcb, 0x4f,
-	0x49, 0x8d, 0xcf, 0x4c, 0x91, 0x60, 0x54, 0x60, 0xd4, 0xe0, 0x0c, 0x62, 0x03, 0x71, 0x3d, 0x53,
-	0x84, 0x54, 0xb9, 0xf8, 0x92, 0x73, 0x32, 0x53, 0xf3, 0x4a, 0xe2, 0xcb, 0x52, 0x8b, 0x8a, 0x33,
-	0xf3, 0xf3, 0x24, 0x98, 0xc0, 0xf2, 0xbc, 0x10, 0xd1, 0x30, 0x88, 0xa0, 0x92, 0x0b, 0x17, 0x93,
-	0xbf, 0x37, 0xc5, 0xa6, 0xe8, 0x71, 0xb1, 0x06, 0xa4, 0xa6, 0x16, 0x15, 0x0b, 0xa9, 0x72, 0xb1,
-	0x16, 0x80, 0x18, 0x12, 0x8c, 0x0a, 0xcc, 0x1a, 0xdc, 0x46, 0xfc, 0x7a, 0x60, 0xe7, 0xea, 0x81,
-	0x24, 0x3d, 0xf3, 0xd2, 0xf2, 0x83, 0x20, 0xb2, 0x4a, 0x06, 0x5c, 0x1c, 0x30, 0x21, 0x21, 0x3e,
-	0x2e, 0x26, 0xb8, 0xb5, 0x4c, 0x99, 0x29, 0x42, 0x22, 0x5c, 0xac, 0x89, 0x29, 0x29, 0x45, 0xc5,
-	0x12, 0x4c, 0x0a, 0xcc, 0x1a, 0x9c, 0x41, 0x10, 0x4e, 0x12, 0x1b, 0xd8, 0xfb, 0xc6, 0x80, 0x00,
-	0x00, 0x00, 0xff, 0xff, 0x69, 0x57, 0x77, 0xcd, 0x0f, 0x01, 0x00, 0x00,
+	// 252 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x91, 0x41, 0x4b, 0xc4, 0x30,
+	0x10, 0x85, 0x69, 0xda, 0xae, 0x76, 0xa4, 0x2b, 0x04, 0xc1, 0x1c, 0x3c, 0x94, 0xe2, 0x42, 0x4f,
+	0x45, 0xf4, 0x4f, 0x58, 0xf6, 0x60, 0x29, 0xe2, 0xb5, 0x74, 0xcd, 0x6c, 0x09, 0xb6, 0x49, 0x48,
+	0x82, 0x17, 0xc1, 0xdf, 0x2e, 0x69, 0x58, 0x65, 0xfb, 0x07, 0xbc, 0xe5, 0x7d, 0xef, 0xbd, 0x49,
+	0xc8, 0x40, 0x3e, 0xa3, 0xb5, 0xc3, 0x88, 0xb5, 0x36, 0xca, 0x29, 0x9a, 0x4a, 0x74, 0xfa, 0x50,
+	0x7e, 0x43, 0xfa, 0x8c, 0xd3, 0xa4, 0xe8, 0x2d, 0x5c, 0x48, 0xc5, 0xb1, 0x17, 0x9c, 0x45, 0x45,
+	0x54, 0x65, 0xdd, 0xc6, 0xcb, 0x86, 0xd3, 0x1d, 0x6c, 0xdf, 0x27, 0x81, 0xd2, 0xf5, 0x9f, 0x68,
+	0xac, 0x50, 0x92, 0x91, 0xc5, 0xcf, 0x03, 0x7d, 0x0b, 0xd0, 0xf7, 0x8f, 0xca, 0x7c, 0xf8, 0x7e,
+	0x1c, 0xfa, 0x5e, 0x36, 0x9c, 0xde, 0x41, 0xe6, 0xc4, 0x8c, 0xd6, 0x0d, 0xb3, 0x66, 0x49, 0x11,
+	0x55, 0x71, 0xf7, 0x07, 0xca, 0x2f, 0x20, 0x2f, 0xfb, 0xff, 0xba, 0xfc, 0x1e, 0x92, 0x56, 0xc8,
+	0xf1, 0x3c, 0x15, 0xad, 0x53, 0x7b, 0x48, 0x5a, 0x25, 0x47, 0xff, 0x16, 0x2d, 0xe4, 0xd8, 0xaf,
+	0xa3, 0xb9, 0xa7, 0xaf, 0x27, 0x78, 0x3e, 0x8c, 0xac, 0x87, 0xd5, 0x90, 0xb6, 0x88, 0xc6, 0xd2,
+	0x1d, 0xa4, 0xda, 0x1f, 0x58, 0x54, 0xc4, 0xd5, 0xd5, 0xe3, 0x75, 0xbd, 0xec, 0xa3, 0xf6, 0x66,
+	0x23, 0x8f, 0xaa, 0x0b, 0x6e, 0xf9, 0x00, 0x97, 0x27, 0x44, 0xb7, 0x40, 0x7e, 0x3f, 0x88, 0x08,
+	0x4e, 0x6f, 0x20, 0x1d, 0x38, 0x37, 0x96, 0x91, 0x22, 0xae, 0xb2, 0x2e, 0x88, 0xc3, 0x66, 0xd9,
+	0xef, 0xd3, 0xcf, 0x00, 0xbd, 0xa0, 0x92, 0xbf, 0xf0, 0x01, 0x00, 0x00,
 }
@@ -51,3 +51,31 @@ func OKMessageFromProto(data []byte) (*OK, error) {
 
 	return pb, nil
 }
+
+// PingMessageFromProto parse the data into Ping message
+func PingMessageFromProto(data []byte) (*Ping, error) {
+	pb := new(Ping)
+
+	if err := proto.Unmarshal(data, pb); err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"err": err,
+		}).Debug("Failed to unmarshal Ping message.")
+		return nil, err
+	}
+
+	return pb, nil
+}
+
+// PongMessageFromProto parse the data into Pong message
+func PongMessageFromProto(data []byte) (*Pong, error) {
+	pb := new(Pong)
+
+	if err := proto.Unmarshal(data, pb); err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"err": err,
+		}).Debug("Failed to unmarshal Pong message.")
+		return nil, err
+	}
+
+	return pb, nil
+}
@@ -21,6 +21,7 @@ package net
 import (
 	"math"
 	"math/rand"
+	"sort"
 )
 
 // ChainSyncPeersFilter will filter some peers randomly
@@ -36,6 +37,33 @@ func (filter *ChainSyncPeersFilter) Filter(peers PeersSlice) PeersSlice {
 	return peers[:selection]
 }
 
+// RankedPeersFilter will filter the best ranked peers, as many as ChainSyncPeersFilter,
+// the peers Rank reports false for are left out.
+type RankedPeersFilter struct {
+	Rank func(pid string) (int, bool)
+}
+
+// Filter implemets PeerFilterAlgorithm interface
+func (filter *RankedPeersFilter) Filter(peers PeersSlice) PeersSlice {
+	ranked := make(PeersSlice, 0, len(peers))
+	ranks := make(map[interface{}]int)
+	for _, v := range peers {
+		if rank, ok := filter.Rank(v.(*Stream).pid.Pretty()); ok {
+			ranks[v] = rank
+			ranked = append(ranked, v)
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranks[ranked[i]] > ranks[ranked[j]]
+	})
+
+	selection := int(math.Sqrt(float64(len(peers))))
+	if selection > len(ranked) {
+		selection = len(ranked)
+	}
+	return ranked[:selection]
+}
+
 // RandomPeerFilter will filter a peer randomly
 type RandomPeerFilter struct {
 }
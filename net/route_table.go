@@ -216,6 +216,13 @@ func (table *RouteTable) RemovePeerStream(s *Stream) {
 	table.onRouteTableChange()
 }
 
+// RemovePeer remove a peer and its addresses from route table.
+func (table *RouteTable) RemovePeer(pid peer.ID) {
+	table.peerStore.SetAddrs(pid, table.peerStore.Addrs(pid), 0)
+	table.routeTable.Remove(pid)
+	table.onRouteTableChange()
+}
+
 func (table *RouteTable) onRouteTableChange() {
 	table.latestUpdatedAt = time.Now().Unix()
 }
@@ -26,8 +26,10 @@ import (
 	"time"
 
 	"github.com/gogo/protobuf/proto"
+	lru "github.com/hashicorp/golang-lru"
 	libnet "github.com/libp2p/go-libp2p-net"
 	peer "github.com/libp2p/go-libp2p-peer"
+	peerstore "github.com/libp2p/go-libp2p-peerstore"
 	ma "github.com/multiformats/go-multiaddr"
 	netpb "github.com/nebulasio/go-nebulas/net/pb"
 	"github.com/nebulasio/go-nebulas/util/logging"
@@ -44,9 +46,17 @@ const (
 	SYNCROUTE      = "syncroute"
 	ROUTETABLE     = "routetable"
 	RECVEDMSG      = "recvedmsg"
+	PING           = "ping"
+	PONG           = "pong"
 	CurrentVersion = 0x0
 )
 
+// Stream Announcement
+const (
+	// DefaultAnnouncedCacheSize number of announced keys remembered per peer.
+	DefaultAnnouncedCacheSize = 1024
+)
+
 // Stream Status
 const (
 	streamStatusInit = iota
@@ -79,6 +89,7 @@ type Stream struct {
 	latestWriteAt             int64
 	msgCount                  map[string]int
 	reservedFlag              []byte
+	announced                 *lru.Cache
 }
 
 // NewStream return a new Stream
@@ -92,6 +103,7 @@ func NewStreamFromPID(pid peer.ID, node *Node) *Stream {
 }
 
 func newStreamInstance(pid peer.ID, addr ma.Multiaddr, stream libnet.Stream, node *Node) *Stream {
+	announced, _ := lru.New(DefaultAnnouncedCacheSize)
 	return &Stream{
 		pid:                       pid,
 		addr:                      addr,
@@ -109,6 +121,7 @@ func newStreamInstance(pid peer.ID, addr ma.Multiaddr, stream libnet.Stream, nod
 		latestWriteAt:             0,
 		msgCount:                  make(map[string]int),
 		reservedFlag:              DefaultReserved,
+		announced:                 announced,
 	}
 }
 
@@ -156,6 +169,22 @@ func (s *Stream) String() string {
 	return fmt.Sprintf("Peer Stream: %s,%s", s.pid.Pretty(), addrStr)
 }
 
+// MarkAnnounced record the peer already has the message identified by key.
+func (s *Stream) MarkAnnounced(key string) {
+	if s.announced == nil || key == "" {
+		return
+	}
+	s.announced.Add(key, true)
+}
+
+// HasAnnounced return if the message identified by key was announced to or from the peer.
+func (s *Stream) HasAnnounced(key string) bool {
+	if s.announced == nil || key == "" {
+		return false
+	}
+	return s.announced.Contains(key)
+}
+
 // SendProtoMessage send proto msg to buffer
 func (s *Stream) SendProtoMessage(messageName string, pb proto.Message, priority int) error {
 	data, err := proto.Marshal(pb)
@@ -470,6 +499,10 @@ func (s *Stream) handleMessage(message *NebMessage) error {
 		return s.onSyncRoute(message)
 	case ROUTETABLE:
 		return s.onRouteTable(message)
+	case PING:
+		return s.onPing(message)
+	case PONG:
+		return s.onPong(message)
 	default:
 		data, err := s.getData(message)
 		if err != nil {
@@ -506,6 +539,7 @@ func (s *Stream) close(reason error) {
 	// cleanup.
 	s.node.streamManager.RemoveStream(s)
 	s.node.routeTable.RemovePeerStream(s)
+	s.node.clock.RemovePeer(s.pid.Pretty())
 
 	// quit.
 	s.quitWriteCh <- true
@@ -534,6 +568,8 @@ func (s *Stream) Hello() error {
 	msg := &netpb.Hello{
 		NodeId:        s.node.id.String(),
 		ClientVersion: ClientVersion,
+		ForkId:        s.forkID(),
+		Timestamp:     nowInMs(),
 	}
 	return s.WriteProtoMessage(HELLO, msg, ReservedCompressionClientFlag)
 }
@@ -555,10 +591,24 @@ func (s *Stream) onHello(message *NebMessage) error {
 		return ErrShouldCloseConnectionAndExitLoop
 	}
 
+	if !CheckForkIDCompatibility(s.node.config.ForkFilter, msg.ForkId) {
+		// peer on another fork, bye().
+		logging.VLog().WithFields(logrus.Fields{
+			"pid":        s.pid.Pretty(),
+			"address":    s.addr,
+			"fork_id":    s.forkID(),
+			"ok.fork_id": msg.ForkId,
+		}).Warn("Incompatible fork id.")
+		return ErrShouldCloseConnectionAndExitLoop
+	}
+
 	if (message.Reserved()[2] & ReservedCompressionClientFlag) > 0 {
 		s.reservedFlag = CurrentReserved
 	}
 
+	// the one-way latency is left in the offset, pings refine it later.
+	s.node.clock.AddSample(s.pid.Pretty(), msg.Timestamp, nowInMs())
+
 	// add to route table.
 	s.node.routeTable.AddPeerStream(s)
 
@@ -574,6 +624,8 @@ func (s *Stream) Ok() error {
 	resp := &netpb.OK{
 		NodeId:        s.node.id.String(),
 		ClientVersion: ClientVersion,
+		ForkId:        s.forkID(),
+		Timestamp:     nowInMs(),
 	}
 
 	return s.WriteProtoMessage(OK, resp, ReservedCompressionClientFlag)
@@ -596,10 +648,23 @@ func (s *Stream) onOk(message *NebMessage) error {
 		return ErrShouldCloseConnectionAndExitLoop
 	}
 
+	if !CheckForkIDCompatibility(s.node.config.ForkFilter, msg.ForkId) {
+		// peer on another fork, bye().
+		logging.VLog().WithFields(logrus.Fields{
+			"pid":        s.pid.Pretty(),
+			"address":    s.addr,
+			"fork_id":    s.forkID(),
+			"ok.fork_id": msg.ForkId,
+		}).Warn("Incompatible fork id.")
+		return ErrShouldCloseConnectionAndExitLoop
+	}
+
 	if (message.Reserved()[2] & ReservedCompressionClientFlag) > 0 {
 		s.reservedFlag = CurrentReserved
 	}
 
+	s.node.clock.AddSample(s.pid.Pretty(), msg.Timestamp, nowInMs())
+
 	// add to route table.
 	s.node.routeTable.AddPeerStream(s)
 
@@ -609,6 +674,46 @@ func (s *Stream) onOk(message *NebMessage) error {
 	return nil
 }
 
+// Ping ask the peer for its clock
+func (s *Stream) Ping() error {
+	return s.SendProtoMessage(PING, &netpb.Ping{Timestamp: nowInMs()}, MessagePriorityHigh)
+}
+
+func (s *Stream) onPing(message *NebMessage) error {
+	data, err := s.getData(message)
+	if err != nil {
+		return err
+	}
+	msg, err := netpb.PingMessageFromProto(data)
+	if err != nil {
+		return ErrShouldCloseConnectionAndExitLoop
+	}
+	return s.SendProtoMessage(PONG, &netpb.Pong{
+		PingTimestamp: msg.Timestamp,
+		Timestamp:     nowInMs(),
+	}, MessagePriorityHigh)
+}
+
+func (s *Stream) onPong(message *NebMessage) error {
+	data, err := s.getData(message)
+	if err != nil {
+		return err
+	}
+	msg, err := netpb.PongMessageFromProto(data)
+	if err != nil {
+		return ErrShouldCloseConnectionAndExitLoop
+	}
+
+	// the peer's clock is taken at the middle of the round trip.
+	now := nowInMs()
+	rtt := now - msg.PingTimestamp
+	if rtt < 0 || time.Duration(rtt)*time.Millisecond > MaxPingRoundTrip {
+		return nil
+	}
+	s.node.clock.AddSample(s.pid.Pretty(), msg.Timestamp, msg.PingTimestamp+rtt/2)
+	return nil
+}
+
 // SyncRoute send sync route request
 func (s *Stream) SyncRoute() error {
 	return s.SendMessage(SYNCROUTE, []byte{}, MessagePriorityHigh)
@@ -623,6 +728,14 @@ func (s *Stream) RouteTable() error {
 	// get random peers from routeTable
 	peers := s.node.routeTable.GetRandomPeers(s.pid)
 
+	// advertise our own external addresses as well.
+	if addrs := s.node.ExternalAddrs(); len(addrs) > 0 {
+		if len(peers) >= s.node.routeTable.maxPeersCountForSyncResp {
+			peers = peers[:s.node.routeTable.maxPeersCountForSyncResp-1]
+		}
+		peers = append(peers, peerstore.PeerInfo{ID: s.node.id, Addrs: addrs})
+	}
+
 	// prepare the protobuf message.
 	msg := &netpb.Peers{
 		Peers: make([]*netpb.PeerInfo, len(peers)),
@@ -724,3 +837,20 @@ func ByteSliceEqualBCE(a, b []byte) bool {
 
 	return true
 }
+
+// forkID return the fork id of the local chain, empty without fork schedule.
+func (s *Stream) forkID() string {
+	if s.node.config.ForkFilter == nil {
+		return ""
+	}
+	return s.node.config.ForkFilter.ForkID()
+}
+
+// CheckForkIDCompatibility if the peer on the fork id is compatible with the local fork schedule
+// An empty fork id is from the client without fork schedule, which is compatible with all.
+func CheckForkIDCompatibility(filter ForkFilter, id string) bool {
+	if filter == nil || len(id) == 0 || len(filter.ForkID()) == 0 {
+		return true
+	}
+	return filter.CheckForkID(id)
+}
@@ -194,6 +194,7 @@ func (sm *StreamManager) loop() {
 	logging.CLog().Info("Started NebService StreamManager.")
 
 	ticker := time.NewTicker(CleanupInterval)
+	pingTicker := time.NewTicker(ClockPingInterval)
 	for {
 		select {
 		case <-sm.quitCh:
@@ -201,10 +202,23 @@ func (sm *StreamManager) loop() {
 			return
 		case <-ticker.C:
 			sm.cleanup()
+		case <-pingTicker.C:
+			sm.ping()
 		}
 	}
 }
 
+// ping the connected peers for their clock
+func (sm *StreamManager) ping() {
+	sm.allStreams.Range(func(key, value interface{}) bool {
+		stream := value.(*Stream)
+		if stream.IsHandshakeSucceed() {
+			stream.Ping()
+		}
+		return true
+	})
+}
+
 // BroadcastMessage broadcast the message
 func (sm *StreamManager) BroadcastMessage(messageName string, messageContent Serializable, priority int) {
 	pb, _ := messageContent.ToProto()
@@ -214,11 +228,14 @@ func (sm *StreamManager) BroadcastMessage(messageName string, messageContent Ser
 	}
 
 	dataCheckSum := crc32.ChecksumIEEE(data)
+	announcement := announcementKey(messageContent)
 
 	sm.allStreams.Range(func(key, value interface{}) bool {
 		stream := value.(*Stream)
-		if stream.IsHandshakeSucceed() && !HasRecvMessage(stream, dataCheckSum) {
-			stream.SendMessage(messageName, data, priority)
+		if stream.IsHandshakeSucceed() && !HasRecvMessage(stream, dataCheckSum) && !stream.HasAnnounced(announcement) {
+			if err := stream.SendMessage(messageName, data, priority); err == nil {
+				stream.MarkAnnounced(announcement)
+			}
 		}
 		return true
 	})
@@ -233,11 +250,14 @@ func (sm *StreamManager) RelayMessage(messageName string, messageContent Seriali
 	}
 
 	dataCheckSum := crc32.ChecksumIEEE(data)
+	announcement := announcementKey(messageContent)
 
 	sm.allStreams.Range(func(key, value interface{}) bool {
 		stream := value.(*Stream)
-		if stream.IsHandshakeSucceed() && !HasRecvMessage(stream, dataCheckSum) {
-			stream.SendMessage(messageName, data, priority)
+		if stream.IsHandshakeSucceed() && !HasRecvMessage(stream, dataCheckSum) && !stream.HasAnnounced(announcement) {
+			if err := stream.SendMessage(messageName, data, priority); err == nil {
+				stream.MarkAnnounced(announcement)
+			}
 		}
 		return true
 	})
@@ -268,6 +288,14 @@ func (sm *StreamManager) SendMessageToPeers(messageName string, data []byte, pri
 	return selectedPeersPrettyID
 }
 
+// MarkAnnounced record the peer already has the message identified by key.
+func (sm *StreamManager) MarkAnnounced(peerID string, key string) {
+	stream := sm.FindByPeerID(peerID)
+	if stream != nil {
+		stream.MarkAnnounced(key)
+	}
+}
+
 // CloseStream with the given pid and reason
 func (sm *StreamManager) CloseStream(peerID string, reason error) {
 	stream := sm.FindByPeerID(peerID)
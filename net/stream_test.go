@@ -0,0 +1,70 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package net
+
+import (
+	"testing"
+
+	peer "github.com/libp2p/go-libp2p-peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamAnnounced(t *testing.T) {
+	pid, _ := peer.IDFromString("s1")
+	s := NewStreamFromPID(pid, nil)
+
+	assert.False(t, s.HasAnnounced("hash1"))
+	s.MarkAnnounced("hash1")
+	assert.True(t, s.HasAnnounced("hash1"))
+
+	// empty key is never recorded.
+	s.MarkAnnounced("")
+	assert.False(t, s.HasAnnounced(""))
+
+	// the oldest keys are evicted.
+	for i := 0; i < DefaultAnnouncedCacheSize; i++ {
+		s.MarkAnnounced(string(rune(i + 0x4e00)))
+	}
+	assert.False(t, s.HasAnnounced("hash1"))
+}
+
+type mockForkFilter struct {
+	id         string
+	compatible map[string]bool
+}
+
+func (f *mockForkFilter) ForkID() string {
+	return f.id
+}
+
+func (f *mockForkFilter) CheckForkID(id string) bool {
+	return f.compatible[id]
+}
+
+func TestCheckForkIDCompatibility(t *testing.T) {
+	filter := &mockForkFilter{id: "a1b2-100", compatible: map[string]bool{"a1b2-100": true, "a1b2-200": true}}
+
+	assert.True(t, CheckForkIDCompatibility(nil, ""))
+	assert.True(t, CheckForkIDCompatibility(nil, "a1b2-100"))
+	assert.True(t, CheckForkIDCompatibility(filter, ""))
+	assert.True(t, CheckForkIDCompatibility(&mockForkFilter{}, "c3d4-0"))
+	assert.True(t, CheckForkIDCompatibility(filter, "a1b2-100"))
+	assert.True(t, CheckForkIDCompatibility(filter, "a1b2-200"))
+	assert.False(t, CheckForkIDCompatibility(filter, "c3d4-0"))
+}
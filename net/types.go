@@ -36,10 +36,24 @@ const (
 
 // Sync Message Type
 const (
-	ChunkHeadersRequest  = "sync"      // ChainSync
-	ChunkHeadersResponse = "chunks"    // ChainChunks
-	ChunkDataRequest     = "getchunk"  // ChainGetChunk
-	ChunkDataResponse    = "chunkdata" // ChainChunkData
+	ChunkHeadersRequest  = "sync"        // ChainSync
+	ChunkHeadersResponse = "chunks"      // ChainChunks
+	ChunkDataRequest     = "getchunk"    // ChainGetChunk
+	ChunkDataResponse    = "chunkdata"   // ChainChunkData
+	PivotRequest         = "getpivot"    // GetPivot
+	PivotResponse        = "pivot"       // Pivot
+	StateNodesRequest    = "getnodes"    // GetStateNodes
+	StateNodesResponse   = "nodes"       // StateNodes
+	AncestorRequest      = "getancestor" // GetAncestor
+	AncestorResponse     = "ancestor"    // Ancestor
+)
+
+// Light Message Type
+const (
+	HeadersRequest  = "getheaders" // GetHeaders
+	HeadersResponse = "headers"    // Headers
+	ProofRequest    = "getproof"   // GetProof
+	ProofResponse   = "proof"      // Proof
 )
 
 // Sync Errors
@@ -47,6 +61,13 @@ var (
 	ErrPeersIsNotEnough = errors.New("peers is not enough")
 )
 
+// Admin Errors
+var (
+	ErrAddSelfAsPeer  = errors.New("cannot add the node itself as a peer")
+	ErrPeerIsRemoved  = errors.New("peer is removed by admin")
+	ErrPeerIsNotFound = errors.New("peer is not found")
+)
+
 // MessageType a string for message type.
 type MessageType string
 
@@ -64,6 +85,19 @@ type Serializable interface {
 	FromProto(proto.Message) error
 }
 
+// Announcement is implemented by messages which should be sent to a peer only once,
+// such as new blocks. AnnouncementKey identifies the message, e.g. the block hash.
+type Announcement interface {
+	AnnouncementKey() string
+}
+
+func announcementKey(msg Serializable) string {
+	if a, ok := msg.(Announcement); ok {
+		return a.AnnouncementKey()
+	}
+	return ""
+}
+
 // PeersSlice is a slice which contains peers
 type PeersSlice []interface{}
 
@@ -91,6 +125,9 @@ type Service interface {
 
 	ClosePeer(peerID string, reason error)
 
+	AddPeer(addr string) (string, error)
+	RemovePeer(peerID string) error
+
 	BroadcastNetworkID([]byte)
 }
 
@@ -0,0 +1,150 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package net
+
+import (
+	"context"
+	"net"
+	"os"
+	"time"
+
+	transport "github.com/libp2p/go-libp2p-transport"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+// unixTransport the swarm transport of the unix socket addresses, e.g.
+// "/unix/tmp/neb.sock", the swarm only ships the tcp and websocket ones.
+type unixTransport struct{}
+
+func newUnixTransport() *unixTransport {
+	return &unixTransport{}
+}
+
+// isUnixAddress return true if the address is a unix socket path.
+func isUnixAddress(addr ma.Multiaddr) bool {
+	protocols := addr.Protocols()
+	return len(protocols) == 1 && protocols[0].Code == ma.P_UNIX
+}
+
+func unixAddrToMultiaddr(addr net.Addr) (ma.Multiaddr, error) {
+	return ma.NewMultiaddr("/unix" + addr.String())
+}
+
+// Matches return true if the address is a unix socket path.
+func (t *unixTransport) Matches(addr ma.Multiaddr) bool {
+	return isUnixAddress(addr)
+}
+
+// Dialer return the dialer of the unix socket addresses.
+func (t *unixTransport) Dialer(laddr ma.Multiaddr, opts ...transport.DialOpt) (transport.Dialer, error) {
+	return &unixDialer{transport: t}, nil
+}
+
+// Listen listens on the socket path, a stale socket file left by a process
+// not listening on it any more is removed first.
+func (t *unixTransport) Listen(laddr ma.Multiaddr) (transport.Listener, error) {
+	network, path, err := listenDialArgs(laddr)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(path); err == nil {
+		conn, err := net.DialTimeout(network, path, time.Second*1)
+		if err == nil {
+			conn.Close()
+			return nil, ErrListenPortIsNotAvailable
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+	}
+
+	list, err := net.Listen(network, path)
+	if err != nil {
+		return nil, err
+	}
+	return &unixListener{Listener: list, laddr: laddr, transport: t}, nil
+}
+
+type unixDialer struct {
+	transport *unixTransport
+}
+
+func (d *unixDialer) Dial(raddr ma.Multiaddr) (transport.Conn, error) {
+	return d.DialContext(context.Background(), raddr)
+}
+
+func (d *unixDialer) DialContext(ctx context.Context, raddr ma.Multiaddr) (transport.Conn, error) {
+	network, path, err := listenDialArgs(raddr)
+	if err != nil {
+		return nil, err
+	}
+	var dialer net.Dialer
+	c, err := dialer.DialContext(ctx, network, path)
+	if err != nil {
+		return nil, err
+	}
+	laddr, err := unixAddrToMultiaddr(c.LocalAddr())
+	if err != nil {
+		// the dialing side of a unix socket is unnamed.
+		laddr = raddr
+	}
+	return &unixConn{Conn: c, laddr: laddr, raddr: raddr, transport: d.transport}, nil
+}
+
+func (d *unixDialer) Matches(addr ma.Multiaddr) bool {
+	return isUnixAddress(addr)
+}
+
+type unixListener struct {
+	net.Listener
+	laddr     ma.Multiaddr
+	transport *unixTransport
+}
+
+func (l *unixListener) Accept() (transport.Conn, error) {
+	c, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	// the accepted side of a unix socket has no address of the peer.
+	return &unixConn{Conn: c, laddr: l.laddr, raddr: l.laddr, transport: l.transport}, nil
+}
+
+func (l *unixListener) Multiaddr() ma.Multiaddr {
+	return l.laddr
+}
+
+type unixConn struct {
+	net.Conn
+	laddr     ma.Multiaddr
+	raddr     ma.Multiaddr
+	transport *unixTransport
+}
+
+func (c *unixConn) LocalMultiaddr() ma.Multiaddr {
+	return c.laddr
+}
+
+func (c *unixConn) RemoteMultiaddr() ma.Multiaddr {
+	return c.raddr
+}
+
+func (c *unixConn) Transport() transport.Transport {
+	return c.transport
+}
@@ -20,6 +20,7 @@ package net
 
 import (
 	"errors"
+	"fmt"
 	"net"
 	"os"
 	"strings"
@@ -33,6 +34,7 @@ import (
 var (
 	ErrListenPortIsNotAvailable = errors.New("listen port is not available")
 	ErrConfigLackNetWork        = errors.New("config.conf should has network")
+	ErrUnsupportedListenAddress = errors.New("unsupported listen address")
 )
 
 // ParseFromIPFSAddr return pid and address parsed from ipfs address
@@ -56,10 +58,92 @@ func ParseFromIPFSAddr(ipfsAddr ma.Multiaddr) (peer.ID, ma.Multiaddr, error) {
 	return id, addr, nil
 }
 
+// ParseListenAddress parse a network.listen entry to multiaddr.
+// The entry is either a "host:port" pair, e.g. "0.0.0.0:8680" or "[::]:8680",
+// or a multiaddr, e.g. "/ip6/::/tcp/8680" or the unix socket "/unix/tmp/neb.sock".
+// The multiaddrs of other transports, e.g. udp, are rejected.
+func ParseListenAddress(v string) (ma.Multiaddr, error) {
+	if len(v) == 0 {
+		return nil, ErrUnsupportedListenAddress
+	}
+	if strings.HasPrefix(v, "/") {
+		addr, err := ma.NewMultiaddr(v)
+		if err != nil {
+			return nil, err
+		}
+		if _, _, err := listenDialArgs(addr); err != nil {
+			return nil, err
+		}
+		return addr, nil
+	}
+
+	tcpAddr, err := net.ResolveTCPAddr("tcp", v)
+	if err != nil {
+		return nil, err
+	}
+	return tcpAddrToMultiaddr(tcpAddr)
+}
+
+func tcpAddrToMultiaddr(addr *net.TCPAddr) (ma.Multiaddr, error) {
+	ip := addr.IP
+	if ip == nil {
+		ip = net.IPv4zero
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		return ma.NewMultiaddr(fmt.Sprintf("/ip4/%s/tcp/%d", ip4, addr.Port))
+	}
+	return ma.NewMultiaddr(fmt.Sprintf("/ip6/%s/tcp/%d", ip, addr.Port))
+}
+
+// listenDialArgs return the network and address to dial a listen address,
+// ErrUnsupportedListenAddress if it is neither ip4 or ip6 over tcp nor a unix socket.
+func listenDialArgs(addr ma.Multiaddr) (string, string, error) {
+	if isUnixAddress(addr) {
+		path, err := addr.ValueForProtocol(ma.P_UNIX)
+		if err != nil || len(path) == 0 {
+			return "", "", ErrUnsupportedListenAddress
+		}
+		return "unix", path, nil
+	}
+
+	protocols := addr.Protocols()
+	if len(protocols) != 2 || protocols[1].Code != ma.P_TCP {
+		return "", "", ErrUnsupportedListenAddress
+	}
+
+	ip, err := addr.ValueForProtocol(ma.P_IP4)
+	if err != nil {
+		if ip, err = addr.ValueForProtocol(ma.P_IP6); err != nil {
+			return "", "", ErrUnsupportedListenAddress
+		}
+	}
+	port, err := addr.ValueForProtocol(ma.P_TCP)
+	if err != nil {
+		return "", "", ErrUnsupportedListenAddress
+	}
+	return "tcp", net.JoinHostPort(ip, port), nil
+}
+
+// isExternalAddress return true if the address can be reached by remote peers.
+func isExternalAddress(addr ma.Multiaddr) bool {
+	network, address, err := listenDialArgs(addr)
+	if err != nil || network != "tcp" {
+		return false
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return false
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsUnspecified() {
+		return false
+	}
+	return true
+}
+
 func verifyListenAddress(listen []string) error {
 	for _, v := range listen {
-		_, err := net.ResolveTCPAddr("tcp", v)
-		if err != nil {
+		if _, err := ParseListenAddress(v); err != nil {
 			return err
 		}
 	}
@@ -80,7 +164,15 @@ func checkPathConfig(path string) bool {
 
 func checkPortAvailable(listen []string) error {
 	for _, v := range listen {
-		conn, err := net.DialTimeout("tcp", v, time.Second*1)
+		addr, err := ParseListenAddress(v)
+		if err != nil {
+			return err
+		}
+		network, address, err := listenDialArgs(addr)
+		if err != nil {
+			return err
+		}
+		conn, err := net.DialTimeout(network, address, time.Second*1)
 		if err == nil {
 			conn.Close()
 			return ErrListenPortIsNotAvailable
@@ -0,0 +1,109 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package net
+
+import (
+	"testing"
+
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseListenAddress(t *testing.T) {
+	tests := []struct {
+		name    string
+		listen  string
+		want    string
+		wantErr bool
+	}{
+		{"ipv4", "127.0.0.1:8680", "/ip4/127.0.0.1/tcp/8680", false},
+		{"ipv4 any", ":8680", "/ip4/0.0.0.0/tcp/8680", false},
+		{"ipv6", "[::1]:8680", "/ip6/::1/tcp/8680", false},
+		{"ipv6 any", "[::]:8680", "/ip6/::/tcp/8680", false},
+		{"dns", "localhost:8680", "", false},
+		{"multiaddr ipv4", "/ip4/0.0.0.0/tcp/8680", "/ip4/0.0.0.0/tcp/8680", false},
+		{"multiaddr ipv6", "/ip6/::/tcp/8680", "/ip6/::/tcp/8680", false},
+		{"multiaddr dns", "/dns4/localhost/tcp/8680", "", true},
+		{"multiaddr unix", "/unix/tmp/neb.sock", "/unix/tmp/neb.sock", false},
+		{"multiaddr udp", "/ip4/127.0.0.1/udp/8680", "", true},
+		{"multiaddr without port", "/ip4/127.0.0.1", "", true},
+		{"multiaddr with peer id", "/ip4/127.0.0.1/tcp/8680/ipfs/QmPyr2ZEGZdCkd7DHUrvNqcqbcp6Gr5Wmg1h5wMqmnTGfg", "", true},
+		{"invalid multiaddr", "/ip4/256.0.0.1/tcp/8680", "", true},
+		{"invalid", "127.0.0.1", "", true},
+		{"invalid port", "127.0.0.1:port", "", true},
+		{"empty", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, err := ParseListenAddress(tt.listen)
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			if len(tt.want) == 0 {
+				// resolved by the hosts of the machine, ip4 or ip6.
+				_, _, err = listenDialArgs(addr)
+				assert.Nil(t, err)
+				return
+			}
+			assert.Equal(t, tt.want, addr.String())
+		})
+	}
+}
+
+func TestIsExternalAddress(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"/ip4/127.0.0.1/tcp/8680", false},
+		{"/ip4/0.0.0.0/tcp/8680", false},
+		{"/ip6/::1/tcp/8680", false},
+		{"/ip6/fe80::1/tcp/8680", false},
+		{"/ip4/47.92.203.173/tcp/8680", true},
+		{"/ip6/2001:db8::1/tcp/8680", true},
+		{"/unix/tmp/neb.sock", false},
+	}
+	for _, tt := range tests {
+		addr, err := ma.NewMultiaddr(tt.addr)
+		assert.Nil(t, err)
+		assert.Equal(t, tt.want, isExternalAddress(addr), tt.addr)
+	}
+}
+
+func TestListenDialArgs(t *testing.T) {
+	tests := []struct {
+		addr    string
+		network string
+		address string
+	}{
+		{"/ip4/127.0.0.1/tcp/8680", "tcp", "127.0.0.1:8680"},
+		{"/ip6/::1/tcp/8680", "tcp", "[::1]:8680"},
+		{"/unix/tmp/neb.sock", "unix", "/tmp/neb.sock"},
+	}
+	for _, tt := range tests {
+		addr, err := ma.NewMultiaddr(tt.addr)
+		assert.Nil(t, err)
+		network, address, err := listenDialArgs(addr)
+		assert.Nil(t, err)
+		assert.Equal(t, tt.network, network, tt.addr)
+		assert.Equal(t, tt.address, address, tt.addr)
+	}
+}
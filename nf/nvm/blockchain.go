@@ -45,6 +45,7 @@ func GetTxByHashFunc(handler unsafe.Pointer, hash *C.char, gasCnt *C.size_t) *C.
 	if engine == nil || engine.ctx.block == nil {
 		return nil
 	}
+	defer traceHostCall(engine, "Blockchain.getTransactionByHash", gasCnt)
 
 	// calculate Gas.
 	*gasCnt = C.size_t(GetTxByHashGasBase)
@@ -95,6 +96,7 @@ func GetAccountStateFunc(handler unsafe.Pointer, address *C.char, gasCnt *C.size
 		logging.VLog().Error("Unexpected error: failed to get engine")
 		return C.NVM_UNEXPECTED_ERR
 	}
+	defer traceHostCall(engine, "Blockchain.getAccountState", gasCnt)
 
 	// calculate Gas.
 	*gasCnt = C.size_t(GetAccountStateGasBase)
@@ -160,6 +162,10 @@ func recordTransferFailureEvent(errNo int, from string, to string, value string,
 			errMsg = "failed to parse transfer amount"
 		case TransferSubBalance:
 			errMsg = "failed to sub balace from contract address"
+		case TransferReentrantCall:
+			errMsg = "reentrant transfer to a contract in transfer"
+		case TransferToContractFailed:
+			errMsg = "failed to run the accept function of the contract"
 		default:
 			logging.VLog().WithFields(logrus.Fields{
 				"from":   from,
@@ -206,63 +212,101 @@ func TransferFunc(handler unsafe.Pointer, to *C.char, v *C.char, gasCnt *C.size_
 		engine.ctx.state == nil || engine.ctx.tx == nil {
 		logging.VLog().Fatal("Unexpected error: failed to get engine.")
 	}
+	defer traceHostCall(engine, "Blockchain.transfer", gasCnt)
 
-	wsState := engine.ctx.state
-	height := engine.ctx.block.Height()
-	txHash := engine.ctx.tx.Hash()
+	if engine.ctx.denyStateChange() {
+		return C.NVM_STATIC_CALL_ERR
+	}
+
+	// forward the gas left to the receiver contract.
+	gasLimit := uint64(0)
+	if used := engine.executedInstructions() + TransferGasBase; used < engine.limitsOfExecutionInstructions {
+		gasLimit = engine.limitsOfExecutionInstructions - used
+	}
+	ret, gas := transferFromContract(engine.ctx, C.GoString(to), C.GoString(v), gasLimit)
 
-	cAddr, err := core.AddressParseFromBytes(engine.ctx.contract.Address())
+	// calculate Gas.
+	*gasCnt = C.size_t(TransferGasBase + gas)
+	return ret
+}
+
+// transferFromContract transfer the value from the contract of the context to the address.
+// Since ContractTransferHeight the accept function of a receiver contract runs with
+// the gas limit, the sender is locked meanwhile so it can't be entered again. It
+// returns the gas the receiver used.
+func transferFromContract(ctx *Context, to string, v string, gasLimit uint64) (int, uint64) {
+	wsState := ctx.state
+	height := ctx.block.Height()
+	txHash := ctx.tx.Hash()
+
+	cAddr, err := core.AddressParseFromBytes(ctx.contract.Address())
 	if err != nil {
 		logging.VLog().WithFields(logrus.Fields{
-			"txhash":  engine.ctx.tx.Hash().String(),
-			"address": engine.ctx.contract.Address(),
+			"txhash":  ctx.tx.Hash().String(),
+			"address": ctx.contract.Address(),
 			"err":     err,
 		}).Fatal("Unexpected error: failed to parse contract address")
 	}
 
-	// calculate Gas.
-	*gasCnt = C.size_t(TransferGasBase)
-
-	addr, err := core.AddressParse(C.GoString(to))
+	addr, err := core.AddressParse(to)
 	if err != nil {
 		logging.VLog().WithFields(logrus.Fields{
-			"handler":   uint64(uintptr(handler)),
-			"toAddress": C.GoString(to),
+			"toAddress": to,
 		}).Debug("TransferFunc parse address failed.")
 		recordTransferFailureEvent(TransferAddressParseErr, cAddr.String(), "", "", height, wsState, txHash)
-		return TransferAddressParseErr
+		return TransferAddressParseErr, 0
 	}
 
-	toAcc, err := engine.ctx.state.GetOrCreateUserAccount(addr.Bytes())
-	if err != nil {
-		logging.VLog().WithFields(logrus.Fields{
-			"handler": uint64(uintptr(handler)),
-			"address": addr,
-			"err":     err,
-		}).Fatal("GetAccountStateFunc get account state failed.")
+	var (
+		toAcc  Account
+		deploy *core.DeployPayload
+	)
+	toContract := addr.Type() == core.ContractAddress && height >= core.ContractTransferHeight
+	if toContract {
+		if ctx.locked(addr) {
+			logging.VLog().WithFields(logrus.Fields{
+				"from": cAddr,
+				"to":   addr,
+			}).Debug("TransferFunc reentrant transfer.")
+			recordTransferFailureEvent(TransferReentrantCall, cAddr.String(), addr.String(), "", height, wsState, txHash)
+			return TransferReentrantCall, 0
+		}
+		if toAcc, deploy, err = core.LoadContract(addr, ctx.state); err != nil {
+			logging.VLog().WithFields(logrus.Fields{
+				"address": addr,
+				"err":     err,
+			}).Debug("TransferFunc failed to load contract.")
+			recordTransferFailureEvent(TransferToContractFailed, cAddr.String(), addr.String(), "", height, wsState, txHash)
+			return TransferToContractFailed, 0
+		}
+	} else {
+		if toAcc, err = ctx.state.GetOrCreateUserAccount(addr.Bytes()); err != nil {
+			logging.VLog().WithFields(logrus.Fields{
+				"address": addr,
+				"err":     err,
+			}).Fatal("GetAccountStateFunc get account state failed.")
+		}
 	}
 
-	amount, err := util.NewUint128FromString(C.GoString(v))
+	amount, err := util.NewUint128FromString(v)
 	if err != nil {
 		logging.VLog().WithFields(logrus.Fields{
-			"handler": uint64(uintptr(handler)),
 			"address": addr,
 			"err":     err,
 		}).Debug("GetAmountFunc get amount failed.")
 		recordTransferFailureEvent(TransferStringToBigIntErr, cAddr.String(), addr.String(), "", height, wsState, txHash)
-		return TransferStringToBigIntErr
+		return TransferStringToBigIntErr, 0
 	}
 	// update balance
 	if amount.Cmp(util.NewUint128()) > 0 {
-		err = engine.ctx.contract.SubBalance(amount)
+		err = ctx.contract.SubBalance(amount)
 		if err != nil {
 			logging.VLog().WithFields(logrus.Fields{
-				"handler": uint64(uintptr(handler)),
-				"key":     C.GoString(to),
-				"err":     err,
+				"key": to,
+				"err": err,
 			}).Debug("TransferFunc SubBalance failed.")
 			recordTransferFailureEvent(TransferSubBalance, cAddr.String(), addr.String(), amount.String(), height, wsState, txHash)
-			return TransferSubBalance
+			return TransferSubBalance, 0
 		}
 
 		err = toAcc.AddBalance(amount)
@@ -278,8 +322,19 @@ func TransferFunc(handler unsafe.Pointer, to *C.char, v *C.char, gasCnt *C.size_
 		}
 	}
 
+	gas := uint64(0)
+	if toContract {
+		ctx.locks[cAddr.String()] = true
+		_, gas, err = runNestedContract(ctx, cAddr, addr, toAcc, deploy, amount, gasLimit, core.ContractAcceptFunc, "")
+		delete(ctx.locks, cAddr.String())
+		if err != nil {
+			recordTransferFailureEvent(TransferToContractFailed, cAddr.String(), addr.String(), amount.String(), height, wsState, txHash)
+			return TransferToContractFailed, gas
+		}
+	}
+
 	recordTransferFailureEvent(TransferFuncSuccess, cAddr.String(), addr.String(), amount.String(), height, wsState, txHash)
-	return TransferFuncSuccess
+	return TransferFuncSuccess, gas
 }
 
 // VerifyAddressFunc verify address is valid
@@ -422,3 +477,93 @@ func GetPreBlockSeedFunc(handler unsafe.Pointer, offset C.ulonglong,
 	*result = C.CString(byteutils.Hex(pbBlock.GetHeader().GetRandom().GetVrfSeed()))
 	return C.NVM_SUCCESS
 }
+
+// GetBlockByHeightFunc returns the header of the block at the height, json encoded.
+// Only the blocks before the current one and within maxBlockOffset can be read,
+// so the result is the same on every node executing the block.
+//export GetBlockByHeightFunc
+func GetBlockByHeightFunc(handler unsafe.Pointer, height C.ulonglong,
+	gasCnt *C.size_t, result **C.char, exceptionInfo **C.char) int {
+	*result = nil
+	*exceptionInfo = nil
+
+	engine, _ := getEngineByStorageHandler(uint64(uintptr(handler)))
+	if engine == nil || engine.ctx == nil || engine.ctx.block == nil || engine.ctx.state == nil {
+		logging.VLog().Error("Unexpected error: failed to get engine.")
+		return C.NVM_UNEXPECTED_ERR
+	}
+	defer traceHostCall(engine, "Blockchain.getBlockByHeight", gasCnt)
+
+	// calculate Gas.
+	*gasCnt = C.size_t(GetBlockByHeightGasBase)
+
+	current := engine.ctx.block.Height()
+	if current < core.BlockHistoryHeight {
+		*exceptionInfo = C.CString("Blockchain.getBlockByHeight(), not available at this height")
+		return C.NVM_EXCEPTION_ERR
+	}
+	h := uint64(height)
+	if h == 0 || h >= current || current-h > uint64(maxBlockOffset) {
+		*exceptionInfo = C.CString("Blockchain.getBlockByHeight(), height out of range")
+		return C.NVM_EXCEPTION_ERR
+	}
+
+	header, err := blockHeaderByHeight(engine.ctx.state, h)
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"height": h,
+			"err":    err,
+		}).Error("Unexpected error: Failed to get block header by height")
+		return C.NVM_UNEXPECTED_ERR
+	}
+
+	bytes, err := json.Marshal(header)
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"header": header,
+			"err":    err,
+		}).Error("Unexpected error: Failed to marshal block header")
+		return C.NVM_UNEXPECTED_ERR
+	}
+	*result = C.CString(string(bytes))
+	return C.NVM_SUCCESS
+}
+
+// blockHeaderByHeight load the header of the block at the height on the chain of the world state.
+func blockHeaderByHeight(ws WorldState, height uint64) (*SerializableBlockHeader, error) {
+	blockHash, err := ws.GetBlockHashByHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	bytes, err := ws.GetBlock(blockHash)
+	if err != nil {
+		return nil, err
+	}
+	pbBlock := new(corepb.Block)
+	if err := proto.Unmarshal(bytes, pbBlock); err != nil {
+		return nil, err
+	}
+	pbHeader := pbBlock.GetHeader()
+	if pbHeader == nil {
+		return nil, core.ErrInvalidProtoToBlockHeader
+	}
+
+	coinbase := ""
+	if len(pbHeader.Coinbase) > 0 {
+		addr, err := core.AddressParseFromBytes(pbHeader.Coinbase)
+		if err != nil {
+			return nil, err
+		}
+		coinbase = addr.String()
+	}
+	return &SerializableBlockHeader{
+		Hash:       byteutils.Hex(blockHash),
+		ParentHash: byteutils.Hex(pbHeader.ParentHash),
+		Height:     height,
+		Timestamp:  pbHeader.Timestamp,
+		Coinbase:   coinbase,
+		StateRoot:  byteutils.Hex(pbHeader.StateRoot),
+		TxsRoot:    byteutils.Hex(pbHeader.TxsRoot),
+		EventsRoot: byteutils.Hex(pbHeader.EventsRoot),
+	}, nil
+}
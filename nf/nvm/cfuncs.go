@@ -32,6 +32,7 @@ char *AttachLibVersionDelegateFunc(void *handler, const char *libname);
 char *StorageGetFunc(void *handler, const char *key, size_t *gasCnt);
 int StoragePutFunc(void *handler, const char *key, const char *value, size_t *gasCnt);
 int StorageDelFunc(void *handler, const char *key, size_t *gasCnt);
+int StorageKeysFunc(void *handler, const char *prefix, int offset, int limit, size_t *gasCnt, char **result, char **info);
 
 // blockchain.
 char *GetTxByHashFunc(void *handler, const char *hash, size_t *gasCnt);
@@ -40,9 +41,15 @@ int TransferFunc(void *handler, const char *to, const char *value, size_t *gasCn
 int VerifyAddressFunc(void *handler, const char *address, size_t *gasCnt);
 int GetPreBlockHashFunc(void *handler, unsigned long long offset, size_t *gasCnt, char **result, char **info);
 int GetPreBlockSeedFunc(void *handler, unsigned long long offset, size_t *gasCnt, char **result, char **info);
+int RunContractFunc(void *handler, const char *address, const char *funcName, const char *args, const char *value, size_t *gasCnt, char **result, char **info);
+int GetRandomFunc(void *handler, size_t *gasCnt, char **result, char **info);
+int GetBlockByHeightFunc(void *handler, unsigned long long height, size_t *gasCnt, char **result, char **info);
 
 // event.
-void EventTriggerFunc(void *handler, const char *topic, const char *data, size_t *gasCnt);
+int EventTriggerFunc(void *handler, const char *topic, const char *data, size_t *gasCnt);
+
+// tracer.
+void TraceInstructionsFunc(void *handler, const char *function, int line, int column, size_t count);
 
 // crypto
 char *Sha256Func(const char *data, size_t *gasCnt);
@@ -51,6 +58,9 @@ char *Ripemd160Func(const char *data, size_t *gasCnt);
 char *RecoverAddressFunc(int alg, const char *data, const char *sign, size_t *gasCnt);
 char *Md5Func(const char *data, size_t *gasCnt);
 char *Base64Func(const char *data, size_t *gasCnt);
+int VerifySignatureFunc(int alg, const char *data, const char *sign, const char *address, size_t *gasCnt);
+
+char *DecimalFunc(const char *version, const char *op, const char *a, const char *b, size_t *gasCnt);
 
 // The gateway functions.
 void V8Log_cgo(int level, const char *msg) {
@@ -74,6 +84,9 @@ int StoragePutFunc_cgo(void *handler, const char *key, const char *value, size_t
 int StorageDelFunc_cgo(void *handler, const char *key, size_t *gasCnt) {
 	return StorageDelFunc(handler, key, gasCnt);
 };
+int StorageKeysFunc_cgo(void *handler, const char *prefix, int offset, int limit, size_t *gasCnt, char **result, char **info) {
+	return StorageKeysFunc(handler, prefix, offset, limit, gasCnt, result, info);
+};
 
 char *GetTxByHashFunc_cgo(void *handler, const char *hash, size_t *gasCnt) {
 	return GetTxByHashFunc(handler, hash, gasCnt);
@@ -96,10 +109,26 @@ int GetPreBlockSeedFunc_cgo(void *handler, unsigned long long offset, size_t *ga
 	return GetPreBlockSeedFunc(handler, offset, gasCnt, result, info);
 }
 
-void EventTriggerFunc_cgo(void *handler, const char *topic, const char *data, size_t *gasCnt) {
-	EventTriggerFunc(handler, topic, data, gasCnt);
+int RunContractFunc_cgo(void *handler, const char *address, const char *funcName, const char *args, const char *value, size_t *gasCnt, char **result, char **info) {
+	return RunContractFunc(handler, address, funcName, args, value, gasCnt, result, info);
+}
+
+int GetRandomFunc_cgo(void *handler, size_t *gasCnt, char **result, char **info) {
+	return GetRandomFunc(handler, gasCnt, result, info);
+}
+
+int GetBlockByHeightFunc_cgo(void *handler, unsigned long long height, size_t *gasCnt, char **result, char **info) {
+	return GetBlockByHeightFunc(handler, height, gasCnt, result, info);
+}
+
+int EventTriggerFunc_cgo(void *handler, const char *topic, const char *data, size_t *gasCnt) {
+	return EventTriggerFunc(handler, topic, data, gasCnt);
 };
 
+void TraceInstructionsFunc_cgo(void *handler, const char *function, int line, int column, size_t count) {
+	TraceInstructionsFunc(handler, function, line, column, count);
+}
+
 char *Sha256Func_cgo(const char *data, size_t *gasCnt) {
 	return Sha256Func(data, gasCnt);
 }
@@ -118,6 +147,13 @@ char *Md5Func_cgo(const char *data, size_t *gasCnt) {
 char *Base64Func_cgo(const char *data, size_t *gasCnt) {
 	return Base64Func(data, gasCnt);
 }
+int VerifySignatureFunc_cgo(int alg, const char *data, const char *sign, const char *address, size_t *gasCnt) {
+	return VerifySignatureFunc(alg, data, sign, address, gasCnt);
+}
+
+char *DecimalFunc_cgo(const char *version, const char *op, const char *a, const char *b, size_t *gasCnt) {
+	return DecimalFunc(version, op, a, b, gasCnt);
+}
 
 */
 import "C"
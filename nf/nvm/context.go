@@ -38,6 +38,18 @@ type SerializableBlock struct {
 	Seed      string `json:"seed,omitempty"`
 }
 
+// SerializableBlockHeader serializable header of a block in the history
+type SerializableBlockHeader struct {
+	Hash       string `json:"hash"`
+	ParentHash string `json:"parentHash"`
+	Height     uint64 `json:"height"`
+	Timestamp  int64  `json:"timestamp"`
+	Coinbase   string `json:"coinbase"`
+	StateRoot  string `json:"stateRoot"`
+	TxsRoot    string `json:"txsRoot"`
+	EventsRoot string `json:"eventsRoot"`
+}
+
 // SerializableTransaction serializable transaction
 type SerializableTransaction struct {
 	Hash      string `json:"hash"`
@@ -56,6 +68,15 @@ type Context struct {
 	tx       Transaction
 	contract Account
 	state    WorldState
+	depth    int    // the depth of contract calls, 0 for the contract called by the tx.
+	readOnly bool   // static call, the contract can only read the state.
+	exeErr   error  // the first error failing the whole execution, even if the contract caught it.
+	randoms  uint64 // the count of numbers drawn by Blockchain.random.
+
+	// the contracts paying to a contract by Blockchain.transfer, they can't be
+	// entered again until the accept function of the receiver returns. It is
+	// shared by the nested contexts.
+	locks map[string]bool
 }
 
 // NewContext create a engine context
@@ -68,10 +89,28 @@ func NewContext(block Block, tx Transaction, contract Account, state WorldState)
 		tx:       tx,
 		contract: contract,
 		state:    state,
+		locks:    make(map[string]bool),
 	}
 	return ctx, nil
 }
 
+// locked check if the contract is paying to another contract.
+func (ctx *Context) locked(addr *core.Address) bool {
+	return ctx.locks[addr.String()]
+}
+
+// denyStateChange check if the state can't be changed in static call, the
+// execution fails once the contract tries.
+func (ctx *Context) denyStateChange() bool {
+	if !ctx.readOnly {
+		return false
+	}
+	if ctx.exeErr == nil {
+		ctx.exeErr = ErrStateChangeInStaticCall
+	}
+	return true
+}
+
 func toSerializableAccount(acc Account) *SerializableAccount {
 	sAcc := &SerializableAccount{
 		Nonce:   acc.Nonce(),
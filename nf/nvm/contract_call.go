@@ -0,0 +1,227 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package nvm
+
+/*
+#include "v8/lib/nvm_error.h"
+*/
+import "C"
+
+import (
+	"fmt"
+	"unsafe"
+
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// contractCallTransaction is the tx seen by a contract called by another contract,
+// it is sent from the caller contract with the value and the gas forwarded.
+type contractCallTransaction struct {
+	Transaction
+	from     *core.Address
+	to       *core.Address
+	value    *util.Uint128
+	gasLimit *util.Uint128
+}
+
+func (tx *contractCallTransaction) From() *core.Address {
+	return tx.from
+}
+
+func (tx *contractCallTransaction) To() *core.Address {
+	return tx.to
+}
+
+func (tx *contractCallTransaction) Value() *util.Uint128 {
+	return tx.value
+}
+
+func (tx *contractCallTransaction) GasLimit() *util.Uint128 {
+	return tx.gasLimit
+}
+
+// RunContractFunc call a function of another contract in a nested engine.
+// The callee runs with the gas left to the caller, and the caller is charged
+// ContractCallGasBase plus the gas the callee used. A failed call throws in the
+// caller and reverts the whole tx. The callee of a static call is read-only too.
+//export RunContractFunc
+func RunContractFunc(handler unsafe.Pointer, address *C.char, funcName *C.char, args *C.char, v *C.char,
+	gasCnt *C.size_t, result **C.char, exceptionInfo **C.char) int {
+	*result = nil
+	*exceptionInfo = nil
+	engine, _ := getEngineByStorageHandler(uint64(uintptr(handler)))
+	if engine == nil || engine.ctx == nil || engine.ctx.block == nil ||
+		engine.ctx.state == nil || engine.ctx.tx == nil {
+		logging.VLog().Error("Unexpected error: failed to get engine.")
+		return C.NVM_UNEXPECTED_ERR
+	}
+	defer traceHostCall(engine, "Blockchain.call", gasCnt)
+	ctx := engine.ctx
+
+	// calculate Gas.
+	*gasCnt = C.size_t(ContractCallGasBase)
+
+	if ctx.block.Height() < core.ContractCallHeight {
+		*exceptionInfo = C.CString("Blockchain.call(), not available at this height")
+		return C.NVM_EXCEPTION_ERR
+	}
+	if ctx.depth+1 > MaxContractCallDepth {
+		*exceptionInfo = C.CString(fmt.Sprintf("Blockchain.call(), %s", ErrExceedMaxContractCallDepth))
+		return C.NVM_EXCEPTION_ERR
+	}
+
+	addr, err := core.AddressParse(C.GoString(address))
+	if err != nil {
+		*exceptionInfo = C.CString("Blockchain.call(), parse address failed")
+		return C.NVM_EXCEPTION_ERR
+	}
+	if ctx.block.Height() >= core.ContractTransferHeight && ctx.locked(addr) {
+		*exceptionInfo = C.CString(fmt.Sprintf("Blockchain.call(), %s", ErrReentrantCall))
+		return C.NVM_EXCEPTION_ERR
+	}
+	amount, err := util.NewUint128FromString(C.GoString(v))
+	if err != nil {
+		*exceptionInfo = C.CString("Blockchain.call(), invalid value")
+		return C.NVM_EXCEPTION_ERR
+	}
+	callee, deploy, err := core.LoadContract(addr, ctx.state)
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"address": addr,
+			"err":     err,
+		}).Debug("RunContractFunc failed to load contract.")
+		*exceptionInfo = C.CString("Blockchain.call(), contract check failed")
+		return C.NVM_EXCEPTION_ERR
+	}
+	caller, err := core.AddressParseFromBytes(ctx.contract.Address())
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"txhash":  ctx.tx.Hash().String(),
+			"address": ctx.contract.Address(),
+			"err":     err,
+		}).Error("Unexpected error: failed to parse contract address")
+		return C.NVM_UNEXPECTED_ERR
+	}
+
+	// forward the gas left to the callee.
+	used := engine.executedInstructions() + ContractCallGasBase
+	if used >= engine.limitsOfExecutionInstructions {
+		*exceptionInfo = C.CString(fmt.Sprintf("Blockchain.call(), %s", ErrInsufficientGas))
+		return C.NVM_EXCEPTION_ERR
+	}
+	gasLimit := engine.limitsOfExecutionInstructions - used
+
+	if amount.Cmp(util.NewUint128()) > 0 {
+		if ctx.denyStateChange() {
+			*exceptionInfo = C.CString("Blockchain.call(), transfer value is not allowed in static call")
+			return C.NVM_EXCEPTION_ERR
+		}
+		if err := ctx.contract.SubBalance(amount); err != nil {
+			*exceptionInfo = C.CString("Blockchain.call(), insufficient balance")
+			return C.NVM_EXCEPTION_ERR
+		}
+		if err := callee.AddBalance(amount); err != nil {
+			logging.VLog().WithFields(logrus.Fields{
+				"address": addr,
+				"amount":  amount,
+				"err":     err,
+			}).Error("Unexpected error: failed to add balance")
+			return C.NVM_UNEXPECTED_ERR
+		}
+	}
+
+	ret, gas, exeErr := runNestedContract(ctx, caller, addr, callee, deploy, amount, gasLimit, C.GoString(funcName), C.GoString(args))
+	*gasCnt = C.size_t(ContractCallGasBase + gas)
+	if exeErr != nil {
+		if exeErr == core.ErrUnexpected {
+			return C.NVM_UNEXPECTED_ERR
+		}
+		*exceptionInfo = C.CString(fmt.Sprintf("Blockchain.call(), %s", exeErr))
+		return C.NVM_EXCEPTION_ERR
+	}
+
+	*result = C.CString(ret)
+	return C.NVM_SUCCESS
+}
+
+// runNestedContract run the function of the callee in a nested engine with the
+// gas limit, the value is already paid to the callee. It returns the gas the
+// callee used, the error is kept in the context to fail the whole tx.
+func runNestedContract(ctx *Context, caller, addr *core.Address, callee Account, deploy *core.DeployPayload,
+	amount *util.Uint128, gasLimit uint64, function, args string) (string, uint64, error) {
+	if err := checkExecutionPolicy(ctx.block, callee.Address()); err != nil {
+		if ctx.exeErr == nil {
+			ctx.exeErr = err
+		}
+		return "", 0, err
+	}
+	newEngine := NewV8Engine
+	if ctx.readOnly {
+		newEngine = newPooledV8Engine
+	}
+	nested := newEngine(&Context{
+		block: ctx.block,
+		tx: &contractCallTransaction{
+			Transaction: ctx.tx,
+			from:        caller,
+			to:          addr,
+			value:       amount,
+			gasLimit:    util.NewUint128FromUint(gasLimit),
+		},
+		contract: callee,
+		state:    ctx.state,
+		depth:    ctx.depth + 1,
+		readOnly: ctx.readOnly,
+		locks:    ctx.locks,
+	})
+	defer nested.Dispose()
+
+	if err := nested.SetExecutionLimits(gasLimit, core.ContractExecutionLimits().MemorySize); err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"gasLimit": gasLimit,
+			"err":      err,
+		}).Error("Unexpected error: failed to set execution limits")
+		return "", 0, core.ErrUnexpected
+	}
+	nested.SetSourceMap(deploy.SourceMap)
+	nested.SetModules(deploy.Modules)
+	ret, exeErr := nested.Call(deploy.Source, deploy.SourceType, function, args)
+	gas := nested.ExecutionInstructions()
+
+	if exeErr != nil {
+		if exeErr == core.ErrExecutionFailed && len(ret) > 0 {
+			exeErr = fmt.Errorf("Call: %s", ret)
+		}
+		if ctx.exeErr == nil {
+			ctx.exeErr = exeErr
+		}
+		logging.VLog().WithFields(logrus.Fields{
+			"caller": caller,
+			"callee": addr,
+			"func":   function,
+			"depth":  nested.ctx.depth,
+			"err":    exeErr,
+		}).Debug("Contract call failed.")
+		return ret, gas, exeErr
+	}
+	return ret, gas, nil
+}
@@ -61,14 +61,8 @@ func Ripemd160Func(data *C.char, gasCnt *C.size_t) *C.char {
 	return C.CString(byteutils.Hex(r))
 }
 
-// RecoverAddressFunc ..
-//export RecoverAddressFunc
-func RecoverAddressFunc(alg int, data, sign *C.char, gasCnt *C.size_t) *C.char {
-	d := C.GoString(data)
-	s := C.GoString(sign)
-
-	*gasCnt = C.size_t(CryptoRecoverAddressGasBase)
-
+// recoverAddress recover the signer address of the hex hash from the hex sign.
+func recoverAddress(alg int, d, s string) (*core.Address, error) {
 	plain, err := byteutils.FromHex(d)
 	if err != nil {
 		logging.VLog().WithFields(logrus.Fields{
@@ -77,7 +71,7 @@ func RecoverAddressFunc(alg int, data, sign *C.char, gasCnt *C.size_t) *C.char {
 			"alg":  alg,
 			"err":  err,
 		}).Debug("convert hash to byte array error.")
-		return nil
+		return nil, err
 	}
 	cipher, err := byteutils.FromHex(s)
 	if err != nil {
@@ -87,7 +81,7 @@ func RecoverAddressFunc(alg int, data, sign *C.char, gasCnt *C.size_t) *C.char {
 			"alg":  alg,
 			"err":  err,
 		}).Debug("convert sign to byte array error.")
-		return nil
+		return nil, err
 	}
 	addr, err := core.RecoverSignerFromSignature(keystore.Algorithm(alg), plain, cipher)
 	if err != nil {
@@ -97,12 +91,46 @@ func RecoverAddressFunc(alg int, data, sign *C.char, gasCnt *C.size_t) *C.char {
 			"alg":  alg,
 			"err":  err,
 		}).Debug("recover address error.")
+		return nil, err
+	}
+	return addr, nil
+}
+
+// RecoverAddressFunc ..
+//export RecoverAddressFunc
+func RecoverAddressFunc(alg int, data, sign *C.char, gasCnt *C.size_t) *C.char {
+	d := C.GoString(data)
+	s := C.GoString(sign)
+
+	*gasCnt = C.size_t(CryptoRecoverAddressGasBase)
+
+	addr, err := recoverAddress(alg, d, s)
+	if err != nil {
 		return nil
 	}
 
 	return C.CString(addr.String())
 }
 
+// VerifySignatureFunc check if the hash is signed by the address, 1 for true, 0 for false.
+//export VerifySignatureFunc
+func VerifySignatureFunc(alg int, data, sign, address *C.char, gasCnt *C.size_t) int {
+	d := C.GoString(data)
+	s := C.GoString(sign)
+
+	*gasCnt = C.size_t(CryptoVerifySignatureGasBase)
+
+	expected, err := core.AddressParse(C.GoString(address))
+	if err != nil {
+		return 0
+	}
+	addr, err := recoverAddress(alg, d, s)
+	if err != nil || !addr.Equals(expected) {
+		return 0
+	}
+	return 1
+}
+
 // Md5Func ..
 //export Md5Func
 func Md5Func(data *C.char, gasCnt *C.size_t) *C.char {
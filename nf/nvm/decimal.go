@@ -0,0 +1,176 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package nvm
+
+import "C"
+
+import (
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// The decimals of the 1.1.0 decimal lib keep DecimalScale fractional digits,
+// the products, quotients and powers are rounded toward zero, and a number of
+// more than DecimalMaxDigits digits, fractional digits included, overflows. A
+// later lib version changing these semantics has to be dispatched on its
+// version here.
+const (
+	DecimalVersion110 = "1.1.0"
+	DecimalScale      = 18
+	DecimalMaxDigits  = 96
+	DecimalMaxPower   = 256
+)
+
+var (
+	decimalRe   = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+	decimalUnit = new(big.Int).Exp(big.NewInt(10), big.NewInt(DecimalScale), nil)
+)
+
+// parseDecimal parse the decimal string to an integer scaled by DecimalScale.
+func parseDecimal(s string) (*big.Int, error) {
+	if !decimalRe.MatchString(s) {
+		return nil, ErrInvalidDecimal
+	}
+	parts := strings.SplitN(s, ".", 2)
+	fraction := ""
+	if len(parts) == 2 {
+		fraction = parts[1]
+	}
+	if len(fraction) > DecimalScale {
+		return nil, ErrInvalidDecimal
+	}
+	if len(parts[0]) > DecimalMaxDigits+1 {
+		return nil, ErrDecimalOverflow
+	}
+	v, ok := new(big.Int).SetString(parts[0]+fraction+strings.Repeat("0", DecimalScale-len(fraction)), 10)
+	if !ok {
+		return nil, ErrInvalidDecimal
+	}
+	return checkDecimal(v)
+}
+
+func checkDecimal(v *big.Int) (*big.Int, error) {
+	if len(new(big.Int).Abs(v).String()) > DecimalMaxDigits {
+		return nil, ErrDecimalOverflow
+	}
+	return v, nil
+}
+
+// formatDecimal format the scaled integer, without the trailing zeros of its fraction.
+func formatDecimal(v *big.Int) string {
+	digits := new(big.Int).Abs(v).String()
+	if len(digits) <= DecimalScale {
+		digits = strings.Repeat("0", DecimalScale-len(digits)+1) + digits
+	}
+	i := len(digits) - DecimalScale
+	s := digits[:i]
+	if fraction := strings.TrimRight(digits[i:], "0"); len(fraction) > 0 {
+		s += "." + fraction
+	}
+	if v.Sign() < 0 {
+		s = "-" + s
+	}
+	return s
+}
+
+// calculateDecimal apply the operation to the scaled integers.
+func calculateDecimal(op string, a, b *big.Int) (*big.Int, error) {
+	r := new(big.Int)
+	switch op {
+	case "add":
+		r.Add(a, b)
+	case "sub":
+		r.Sub(a, b)
+	case "mul":
+		r.Mul(a, b).Quo(r, decimalUnit)
+	case "div":
+		if b.Sign() == 0 {
+			return nil, ErrDecimalDivisionByZero
+		}
+		r.Mul(a, decimalUnit).Quo(r, b)
+	case "mod":
+		if b.Sign() == 0 {
+			return nil, ErrDecimalDivisionByZero
+		}
+		r.Rem(a, b)
+	case "pow":
+		n, rem := new(big.Int).QuoRem(b, decimalUnit, new(big.Int))
+		if rem.Sign() != 0 || n.Sign() < 0 {
+			return nil, ErrInvalidDecimal
+		}
+		if n.Cmp(big.NewInt(DecimalMaxPower)) > 0 {
+			return nil, ErrDecimalOverflow
+		}
+		if n.Sign() == 0 {
+			return r.Set(decimalUnit), nil
+		}
+		// a^n / unit^(n-1), the power is exact before it is rounded once.
+		scale := new(big.Int).Exp(decimalUnit, new(big.Int).Sub(n, big.NewInt(1)), nil)
+		r.Exp(a, n, nil).Quo(r, scale)
+	default:
+		return nil, ErrInvalidDecimal
+	}
+	return checkDecimal(r)
+}
+
+// DecimalFunc calculate the operation of the decimal lib of the version, the
+// result is nil if the operation fails.
+//export DecimalFunc
+func DecimalFunc(version, op, a, b *C.char, gasCnt *C.size_t) *C.char {
+	*gasCnt = C.size_t(DecimalGasBase)
+
+	v, o := C.GoString(version), C.GoString(op)
+	if v != DecimalVersion110 {
+		logging.VLog().WithFields(logrus.Fields{
+			"version": v,
+		}).Debug("Unsupported decimal version.")
+		return nil
+	}
+	x, err := parseDecimal(C.GoString(a))
+	if err != nil {
+		return nil
+	}
+	y, err := parseDecimal(C.GoString(b))
+	if err != nil {
+		return nil
+	}
+	if o == "cmp" {
+		return C.CString(strconv.Itoa(x.Cmp(y)))
+	}
+	if o == "pow" {
+		// the power costs a multiplication per exponent.
+		if n := new(big.Int).Quo(y, decimalUnit); n.IsUint64() && n.Uint64() <= DecimalMaxPower {
+			*gasCnt += C.size_t(n.Uint64() * DecimalGasBase)
+		}
+	}
+	r, err := calculateDecimal(o, x, y)
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"op":  o,
+			"err": err,
+		}).Debug("Failed to calculate decimal.")
+		return nil
+	}
+	return C.CString(formatDecimal(r))
+}
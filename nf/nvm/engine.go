@@ -19,6 +19,8 @@
 package nvm
 
 import (
+	"fmt"
+
 	"github.com/nebulasio/go-nebulas/core"
 	"github.com/nebulasio/go-nebulas/core/state"
 )
@@ -37,6 +39,14 @@ func (nvm *NebulasVM) CreateEngine(block *core.Block, tx *core.Transaction, cont
 	if err != nil {
 		return nil, err
 	}
+	if block.ReadOnly() {
+		if err := checkExecutionPolicy(block, contract.Address()); err != nil {
+			return nil, err
+		}
+		engine := newPooledV8Engine(ctx)
+		engine.SetReadOnly(true)
+		return engine, nil
+	}
 	return NewV8Engine(ctx), nil
 }
 
@@ -52,3 +62,22 @@ func (nvm *NebulasVM) CheckV8Run() error {
 	engine.Dispose()
 	return err
 }
+
+// CheckTypeScript transpile the typescript source of a contract deployed in the
+// block, with the transpiler of the js libs the contract would be pinned to. The
+// diagnostics of the transpiler are returned if the source is invalid.
+func (nvm *NebulasVM) CheckTypeScript(block *core.Block, source string) error {
+	engine := NewV8Engine(&Context{
+		block:    block,
+		contract: state.MockAccount(core.GetMaxV8JSLibVersionAtHeight(block.Height())),
+		tx:       nil,
+		state:    nil,
+	})
+	defer engine.Dispose()
+
+	_, _, diagnostics, err := engine.transpileTypeScript(source)
+	if err != nil && len(diagnostics) > 0 {
+		return fmt.Errorf("%s: %s", err, diagnostics)
+	}
+	return err
+}
@@ -0,0 +1,90 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package nvm
+
+/*
+#include "v8/engine.h"
+*/
+import "C"
+
+import (
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// EnginePool keep pre-warmed V8 isolates for the read-only executions, so the
+// RPC calls don't create an isolate each. An isolate is checked out for a call
+// and recycled once the engine is disposed, each execution still runs in a new
+// context created from the startup snapshot, nothing is left by the previous one.
+type EnginePool struct {
+	isolates chan *C.V8Engine
+}
+
+var readOnlyEnginePool *EnginePool
+
+// SetupEnginePool pre-warm size isolates for the read-only executions, 0 to disable the pool.
+func SetupEnginePool(size int) {
+	if size <= 0 || readOnlyEnginePool != nil {
+		return
+	}
+	v8engineOnce.Do(func() {
+		InitV8Engine()
+	})
+
+	pool := &EnginePool{isolates: make(chan *C.V8Engine, size)}
+	for i := 0; i < size; i++ {
+		pool.isolates <- C.CreateEngine()
+	}
+	readOnlyEnginePool = pool
+
+	logging.CLog().WithFields(logrus.Fields{
+		"size": size,
+	}).Info("Pre-warmed the engine pool for read-only executions.")
+}
+
+// get check out an isolate, a new one is created if the pool is drained.
+func (p *EnginePool) get() *C.V8Engine {
+	select {
+	case e := <-p.isolates:
+		return e
+	default:
+		return C.CreateEngine()
+	}
+}
+
+// put recycle the isolate, it's deleted if the pool is full.
+func (p *EnginePool) put(e *C.V8Engine) {
+	C.ResetEngine(e)
+	select {
+	case p.isolates <- e:
+	default:
+		C.DeleteEngine(e)
+	}
+}
+
+// newPooledV8Engine return new V8Engine instance on an isolate of the pool, or
+// a new isolate if the pool is not set up.
+func newPooledV8Engine(ctx *Context) *V8Engine {
+	if readOnlyEnginePool == nil {
+		return NewV8Engine(ctx)
+	}
+	engine := newV8Engine(ctx, readOnlyEnginePool.get())
+	engine.pool = readOnlyEnginePool
+	return engine
+}
@@ -34,6 +34,7 @@ char *AttachLibVersionDelegateFunc_cgo(void *handler, const char *libname);
 char *StorageGetFunc_cgo(void *handler, const char *key, size_t *gasCnt);
 int StoragePutFunc_cgo(void *handler, const char *key, const char *value, size_t *gasCnt);
 int StorageDelFunc_cgo(void *handler, const char *key, size_t *gasCnt);
+int StorageKeysFunc_cgo(void *handler, const char *prefix, int offset, int limit, size_t *gasCnt, char **result, char **info);
 
 char *GetTxByHashFunc_cgo(void *handler, const char *hash);
 char *GetAccountStateFunc_cgo(void *handler, const char *address);
@@ -41,6 +42,9 @@ int TransferFunc_cgo(void *handler, const char *to, const char *value);
 int VerifyAddressFunc_cgo(void *handler, const char *address);
 char *GetPreBlockHashFunc_cgo(void *handler, unsigned long long offset, size_t *gasCnt);
 char *GetPreBlockSeedFunc_cgo(void *handler, unsigned long long offset, size_t *gasCnt);
+int RunContractFunc_cgo(void *handler, const char *address, const char *funcName, const char *args, const char *value, size_t *gasCnt, char **result, char **info);
+int GetRandomFunc_cgo(void *handler, size_t *gasCnt, char **result, char **info);
+int GetBlockByHeightFunc_cgo(void *handler, unsigned long long height, size_t *gasCnt, char **result, char **info);
 
 char *Sha256Func_cgo(const char *data, size_t *gasCnt);
 char *Sha3256Func_cgo(const char *data, size_t *gasCnt);
@@ -48,8 +52,13 @@ char *Ripemd160Func_cgo(const char *data, size_t *gasCnt);
 char *RecoverAddressFunc_cgo(int alg, const char *data, const char *sign, size_t *gasCnt);
 char *Md5Func_cgo(const char *data, size_t *gasCnt);
 char *Base64Func_cgo(const char *data, size_t *gasCnt);
+int VerifySignatureFunc_cgo(int alg, const char *data, const char *sign, const char *address, size_t *gasCnt);
 
-void EventTriggerFunc_cgo(void *handler, const char *topic, const char *data, size_t *gasCnt);
+char *DecimalFunc_cgo(const char *version, const char *op, const char *a, const char *b, size_t *gasCnt);
+
+int EventTriggerFunc_cgo(void *handler, const char *topic, const char *data, size_t *gasCnt);
+
+void TraceInstructionsFunc_cgo(void *handler, const char *function, int line, int column, size_t count);
 
 */
 import "C"
@@ -76,6 +85,9 @@ const (
 	// ExecutionTimeoutInSeconds max v8 execution timeout.
 	ExecutionTimeoutInSeconds = 5
 	TimeoutGasLimitCost       = 100000000
+
+	// contractModuleID the module the contract source is required as.
+	contractModuleID = "contract.js"
 )
 
 //engine_v8 private data
@@ -104,6 +116,10 @@ type V8Engine struct {
 	actualTotalMemorySize                   uint64
 	lcsHandler                              uint64
 	gcsHandler                              uint64
+	pool                                    *EnginePool          // the pool the isolate is recycled to, nil if not pooled.
+	profile                                 *instructionsProfile // the instructions executed by each statement, nil if not traced.
+	sourceMap                               *sourceMap           // maps the positions in exceptions and traces, nil if not deployed.
+	contractModules                         map[string]string    // the modules deployed with the contract, by path.
 }
 
 type sourceModuleItem struct {
@@ -127,7 +143,7 @@ func InitV8Engine() {
 	C.InitializeExecutionEnvDelegate((C.AttachLibVersionDelegate)(unsafe.Pointer(C.AttachLibVersionDelegateFunc_cgo)))
 
 	// Storage.
-	C.InitializeStorage((C.StorageGetFunc)(unsafe.Pointer(C.StorageGetFunc_cgo)), (C.StoragePutFunc)(unsafe.Pointer(C.StoragePutFunc_cgo)), (C.StorageDelFunc)(unsafe.Pointer(C.StorageDelFunc_cgo)))
+	C.InitializeStorage((C.StorageGetFunc)(unsafe.Pointer(C.StorageGetFunc_cgo)), (C.StoragePutFunc)(unsafe.Pointer(C.StoragePutFunc_cgo)), (C.StorageDelFunc)(unsafe.Pointer(C.StorageDelFunc_cgo)), (C.StorageKeysFunc)(unsafe.Pointer(C.StorageKeysFunc_cgo)))
 
 	// Blockchain.
 	C.InitializeBlockchain((C.GetTxByHashFunc)(unsafe.Pointer(C.GetTxByHashFunc_cgo)),
@@ -136,18 +152,31 @@ func InitV8Engine() {
 		(C.VerifyAddressFunc)(unsafe.Pointer(C.VerifyAddressFunc_cgo)),
 		(C.GetPreBlockHashFunc)(unsafe.Pointer(C.GetPreBlockHashFunc_cgo)),
 		(C.GetPreBlockSeedFunc)(unsafe.Pointer(C.GetPreBlockSeedFunc_cgo)),
+		(C.RunContractFunc)(unsafe.Pointer(C.RunContractFunc_cgo)),
+		(C.GetRandomFunc)(unsafe.Pointer(C.GetRandomFunc_cgo)),
+		(C.GetBlockByHeightFunc)(unsafe.Pointer(C.GetBlockByHeightFunc_cgo)),
 	)
 
 	// Event.
 	C.InitializeEvent((C.EventTriggerFunc)(unsafe.Pointer(C.EventTriggerFunc_cgo)))
 
+	// Tracer.
+	C.InitializeTracer((C.TraceInstructionsFunc)(unsafe.Pointer(C.TraceInstructionsFunc_cgo)))
+
 	// Crypto
 	C.InitializeCrypto((C.Sha256Func)(unsafe.Pointer(C.Sha256Func_cgo)),
 		(C.Sha3256Func)(unsafe.Pointer(C.Sha3256Func_cgo)),
 		(C.Ripemd160Func)(unsafe.Pointer(C.Ripemd160Func_cgo)),
 		(C.RecoverAddressFunc)(unsafe.Pointer(C.RecoverAddressFunc_cgo)),
 		(C.Md5Func)(unsafe.Pointer(C.Md5Func_cgo)),
-		(C.Base64Func)(unsafe.Pointer(C.Base64Func_cgo)))
+		(C.Base64Func)(unsafe.Pointer(C.Base64Func_cgo)),
+		(C.VerifySignatureFunc)(unsafe.Pointer(C.VerifySignatureFunc_cgo)))
+
+	// Decimal
+	C.InitializeDecimal((C.DecimalFunc)(unsafe.Pointer(C.DecimalFunc_cgo)))
+
+	// Lib snapshot, the isolates are created from it since then.
+	createLibSnapshot()
 }
 
 // DisposeV8Engine dispose the v8 engine.
@@ -160,11 +189,15 @@ func NewV8Engine(ctx *Context) *V8Engine {
 	v8engineOnce.Do(func() {
 		InitV8Engine()
 	})
+	return newV8Engine(ctx, C.CreateEngine())
+}
 
+// newV8Engine return new V8Engine instance running on the isolate of v8engine.
+func newV8Engine(ctx *Context, v8engine *C.V8Engine) *V8Engine {
 	engine := &V8Engine{
 		ctx:      ctx,
 		modules:  NewModules(),
-		v8engine: C.CreateEngine(),
+		v8engine: v8engine,
 		strictDisallowUsageOfInstructionCounter: 1, // enable by default.
 		enableLimits:                            true,
 		limitsOfExecutionInstructions:           0,
@@ -193,6 +226,7 @@ func NewV8Engine(ctx *Context) *V8Engine {
 	})()
 	// engine.v8engine.lcs = C.uintptr_t(engine.lcsHandler)
 	// engine.v8engine.gcs = C.uintptr_t(engine.gcsHandler)
+	engine.SetTimeOut(core.ContractExecutionLimits().TimeoutInMs * 1000)
 	return engine
 }
 
@@ -212,6 +246,10 @@ func (e *V8Engine) Dispose() {
 	delete(engines, e.v8engine)
 	enginesLock.Unlock()
 
+	if e.pool != nil {
+		e.pool.put(e.v8engine)
+		return
+	}
 	C.DeleteEngine(e.v8engine)
 }
 
@@ -234,6 +272,12 @@ func (e *V8Engine) SetTimeOut(timeout uint64) {
 	e.v8engine.timeout = C.int(timeout) //TODO:
 }
 
+// SetReadOnly set the engine in static call mode, any attempt to write storage,
+// transfer value or trigger events throws.
+func (e *V8Engine) SetReadOnly(readOnly bool) {
+	e.ctx.readOnly = readOnly
+}
+
 // SetExecutionLimits set execution limits of V8 Engine, prevent Halting Problem.
 func (e *V8Engine) SetExecutionLimits(limitsOfExecutionInstructions, limitsOfTotalMemorySize uint64) error {
 	e.v8engine.limits_of_executed_instructions = C.size_t(limitsOfExecutionInstructions)
@@ -264,20 +308,57 @@ func (e *V8Engine) ExecutionInstructions() uint64 {
 	return e.actualCountOfExecutionInstructions
 }
 
+// executedInstructions returns the instructions counted so far, read while the script is running.
+func (e *V8Engine) executedInstructions() uint64 {
+	return uint64(e.v8engine.stats.count_of_executed_instructions)
+}
+
 // TranspileTypeScript transpile typescript to javascript and return it.
 func (e *V8Engine) TranspileTypeScript(source string) (string, int, error) {
+	jsSource, lineOffset, _, err := e.transpileTypeScript(source)
+	return jsSource, lineOffset, err
+}
+
+// transpileTypeScript transpile typescript to javascript, the diagnostics of
+// the transpiler are returned if it fails.
+func (e *V8Engine) transpileTypeScript(source string) (string, int, string, error) {
 	cSource := C.CString(source)
 	defer C.free(unsafe.Pointer(cSource))
 
 	lineOffset := C.int(0)
-	jsSource := C.TranspileTypeScriptModuleThread(e.v8engine, cSource, &lineOffset)
+	var cError *C.char
+	jsSource := C.TranspileTypeScriptModuleThread(e.v8engine, cSource, &lineOffset, &cError)
 	if jsSource == nil {
-		return "", 0, ErrTranspileTypeScriptFailed
+		diagnostics := ""
+		if cError != nil {
+			diagnostics = C.GoString(cError)
+			C.free(unsafe.Pointer(cError))
+		}
+		return "", 0, diagnostics, ErrTranspileTypeScriptFailed
 	}
 
 	defer C.free(unsafe.Pointer(jsSource))
-	return C.GoString(jsSource), int(lineOffset), nil
+	return C.GoString(jsSource), int(lineOffset), "", nil
+
+}
+
+// validateSource run the static validation on the javascript source and return
+// the diagnostics found.
+func (e *V8Engine) validateSource(source string) ([]*ContractDiagnostic, error) {
+	cSource := C.CString(source)
+	defer C.free(unsafe.Pointer(cSource))
 
+	cDiagnostics := C.ValidateContractSourceThread(e.v8engine, cSource)
+	if cDiagnostics == nil {
+		return nil, ErrValidateContractFailed
+	}
+	defer C.free(unsafe.Pointer(cDiagnostics))
+
+	diagnostics := []*ContractDiagnostic{}
+	if err := json.Unmarshal([]byte(C.GoString(cDiagnostics)), &diagnostics); err != nil {
+		return nil, err
+	}
+	return diagnostics, nil
 }
 
 // InjectTracingInstructions process the source to inject tracing instructions.
@@ -323,8 +404,12 @@ func (e *V8Engine) RunScriptSource(source string, sourceLineOffset int) (string,
 	// 		C.uintptr_t(e.gcsHandler))
 	// 	done <- true
 	// }()
+	e.setGasSchedule()
+	e.v8engine.lib_context = C.int(libSnapshotContext(e.ctx))
 	ret = C.RunScriptSourceThread(&cResult, e.v8engine, cSource, C.int(sourceLineOffset), C.uintptr_t(e.lcsHandler),
 		C.uintptr_t(e.gcsHandler))
+	e.v8engine.lib_context = 0
+	e.clearGasSchedule()
 	e.CollectTracingStats()
 
 	//set err
@@ -337,6 +422,9 @@ func (e *V8Engine) RunScriptSource(source string, sourceLineOffset int) (string,
 				"ctx": ctx,
 			}).Error("Unexpected: Failed to get current height")
 			err = core.ErrUnexpected
+		} else if ctx.block.Height() >= core.ExecutionLimitsHeight {
+			// a runaway contract is terminated like running out of gas.
+			e.actualCountOfExecutionInstructions = e.limitsOfExecutionInstructions
 		} else if ctx.block.Height() >= core.NewNvmExeTimeoutConsumeGasHeight {
 			if TimeoutGasLimitCost > e.limitsOfExecutionInstructions {
 				e.actualCountOfExecutionInstructions = e.limitsOfExecutionInstructions
@@ -398,14 +486,22 @@ func (e *V8Engine) RunContractScript(source, sourceType, function, args string)
 
 	switch sourceType {
 	case core.SourceTypeJavaScript:
+		if err := e.addContractModules(sourceType); err != nil {
+			return "", err
+		}
 		runnableSource, sourceLineOffset, err = e.prepareRunnableContractScript(source, function, args)
 	case core.SourceTypeTypeScript:
+		if err := e.addContractModules(sourceType); err != nil {
+			return "", err
+		}
 		// transpile to javascript.
 		jsSource, _, err := e.TranspileTypeScript(source)
 		if err != nil {
 			return "", err
 		}
 		runnableSource, sourceLineOffset, err = e.prepareRunnableContractScript(jsSource, function, args)
+	case core.SourceTypeWasm:
+		return e.RunWasmContract(source, function, args)
 	default:
 		return "", ErrUnsupportedSourceType
 	}
@@ -415,7 +511,7 @@ func (e *V8Engine) RunContractScript(source, sourceType, function, args string)
 	}
 	if e.ctx.block.Height() >= core.NvmMemoryLimitWithoutInjectHeight {
 		e.CollectTracingStats()
-		mem := e.actualTotalMemorySize + core.DefaultLimitsOfTotalMemorySize
+		mem := e.actualTotalMemorySize + core.ContractExecutionLimits().MemorySize
 		logging.VLog().WithFields(logrus.Fields{
 			"actualTotalMemorySize": e.actualTotalMemorySize,
 			"limit":                 mem,
@@ -425,7 +521,18 @@ func (e *V8Engine) RunContractScript(source, sourceType, function, args string)
 			return "", err
 		}
 	}
-	return e.RunScriptSource(runnableSource, sourceLineOffset)
+	e.startProfile()
+	result, err := e.RunScriptSource(runnableSource, sourceLineOffset)
+	e.stopProfile()
+	if err != nil {
+		result = e.traceException(result)
+	}
+	if err == nil && e.ctx.exeErr != nil {
+		// a failed contract call or a state change in static call fails the
+		// whole execution, even if the contract caught the exception.
+		return result, e.ctx.exeErr
+	}
+	return result, err
 }
 
 // AddModule add module.
@@ -473,8 +580,7 @@ func (e *V8Engine) prepareRunnableContractScript(source, function, args string)
 	sourceLineOffset := 0
 
 	// add module.
-	const ModuleID string = "contract.js"
-	if err := e.AddModule(ModuleID, source, sourceLineOffset); err != nil {
+	if err := e.AddModule(contractModuleID, source, sourceLineOffset); err != nil {
 		return "", 0, err
 	}
 
@@ -510,7 +616,7 @@ func (e *V8Engine) prepareRunnableContractScript(source, function, args string)
 									var __instance = new __contract();
 									__instance["%s"].apply(__instance, JSON.parse("%s"));`,
 		formatArgs(string(blockJSON)), formatArgs(string(txJSON)),
-		ModuleID, function, formatArgs(string(argsInput)))
+		contractModuleID, function, formatArgs(string(argsInput)))
 	return runnableSource, 0, nil
 }
 
@@ -24,6 +24,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"math/big"
 	"os"
 	"strings"
@@ -90,6 +91,11 @@ func (block *testBlock) DateAvailable() bool {
 	return true
 }
 
+// ReadOnly
+func (block *testBlock) ReadOnly() bool {
+	return false
+}
+
 // GetTransaction mock
 func (block *testBlock) GetTransaction(hash byteutils.Hash) (*core.Transaction, error) {
 	return nil, nil
@@ -1578,7 +1584,7 @@ func mockNeb(t *testing.T) *Neb {
 	// storage, _ := storage.NewDiskStorage("test.db")
 	// storage, err := storage.NewRocksStorage("rocks.db")
 	// assert.Nil(t, err)
-	storage, _ := storage.NewMemoryStorage()
+	storage := storage.NewMemoryBackend()
 	eventEmitter := core.NewEventEmitter(1024)
 	genesisConf := MockGenesisConf()
 	consensus := dpos.NewDpos()
@@ -1775,6 +1781,10 @@ func (n mockNetService) SendMessageToPeer(messageName string, data []byte, prior
 
 func (n mockNetService) ClosePeer(peerID string, reason error) {}
 
+func (n mockNetService) AddPeer(addr string) (string, error) { return "", nil }
+
+func (n mockNetService) RemovePeer(peerID string) error { return nil }
+
 func (n mockNetService) BroadcastNetworkID([]byte) {}
 
 type contract struct {
@@ -1987,3 +1997,683 @@ func TestMultiLibVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestCryptoVerify(t *testing.T) {
+	data, err := ioutil.ReadFile("test/test_crypto_1.1.0.js")
+	assert.Nil(t, err, "filepath read error")
+	mem, _ := storage.NewMemoryStorage()
+	context, _ := state.NewWorldState(dpos.NewDpos(), mem)
+	addr, _ := core.AddressParse("n1p8cwrrfrbFe71eda1PQ6y4WnX3gp8bYze")
+	contract, _ := context.CreateContractAccount(addr.Bytes(), nil, &corepb.ContractMeta{Version: "1.1.0"})
+	ctx, err := NewContext(mockBlockForLib(2000000), mockTransaction(), contract, context)
+	assert.Nil(t, err)
+
+	engine := NewV8Engine(ctx)
+	engine.SetExecutionLimits(10000000, 10000000)
+	result, err := engine.RunScriptSource(string(data), 0)
+	assert.Nil(t, err)
+	assert.Equal(t, "\"\"", result)
+	engine.Dispose()
+
+	// the contracts deployed with 1.0.5 keep the crypto lib without verify.
+	contract, _ = context.CreateContractAccount([]byte("account2"), nil, &corepb.ContractMeta{Version: "1.0.5"})
+	ctx, err = NewContext(mockBlockForLib(2000000), mockTransaction(), contract, context)
+	assert.Nil(t, err)
+	engine = NewV8Engine(ctx)
+	engine.SetExecutionLimits(10000000, 10000000)
+	_, err = engine.RunScriptSource("if (typeof require('crypto.js').verify !== 'undefined') { throw new Error('verify should be undefined'); }", 0)
+	assert.Nil(t, err)
+	engine.Dispose()
+}
+
+func TestRandomBytes(t *testing.T) {
+	mem, _ := storage.NewMemoryStorage()
+	context, _ := state.NewWorldState(dpos.NewDpos(), mem)
+	contract, _ := context.CreateContractAccount([]byte("account2"), nil, nil)
+	block := mockBlockForLib(2000000)
+	tx := mockTransaction()
+
+	ctx, err := NewContext(block, tx, contract, context)
+	assert.Nil(t, err)
+	first, second := randomBytes(ctx), randomBytes(ctx)
+	assert.Equal(t, 32, len(first))
+	assert.NotEqual(t, first, second)
+
+	// the same draws on every node.
+	ctx, err = NewContext(block, tx, contract, context)
+	assert.Nil(t, err)
+	assert.Equal(t, first, randomBytes(ctx))
+	assert.Equal(t, second, randomBytes(ctx))
+
+	// a nested call draws other numbers.
+	ctx, err = NewContext(block, tx, contract, context)
+	assert.Nil(t, err)
+	ctx.depth = 1
+	assert.NotEqual(t, first, randomBytes(ctx))
+}
+
+func TestDecimal(t *testing.T) {
+	data, err := ioutil.ReadFile("test/test_decimal.js")
+	assert.Nil(t, err, "filepath read error")
+	mem, _ := storage.NewMemoryStorage()
+	context, _ := state.NewWorldState(dpos.NewDpos(), mem)
+	contract, _ := context.CreateContractAccount([]byte("account1"), nil, &corepb.ContractMeta{Version: "1.1.0"})
+	ctx, err := NewContext(mockBlockForLib(2000000), mockTransaction(), contract, context)
+	assert.Nil(t, err)
+
+	engine := NewV8Engine(ctx)
+	engine.SetExecutionLimits(10000000, 10000000)
+	_, err = engine.RunScriptSource(string(data), 0)
+	assert.Nil(t, err)
+	engine.Dispose()
+
+	// the contracts deployed with 1.0.5 can't require the decimal lib.
+	contract, _ = context.CreateContractAccount([]byte("account2"), nil, &corepb.ContractMeta{Version: "1.0.5"})
+	ctx, err = NewContext(mockBlockForLib(2000000), mockTransaction(), contract, context)
+	assert.Nil(t, err)
+	engine = NewV8Engine(ctx)
+	engine.SetExecutionLimits(10000000, 10000000)
+	_, err = engine.RunScriptSource("require('decimal.js');", 0)
+	assert.NotNil(t, err)
+	engine.Dispose()
+
+	v, err := parseDecimal("-12.5")
+	assert.Nil(t, err)
+	assert.Equal(t, "-12.5", formatDecimal(v))
+	_, err = parseDecimal("1" + strings.Repeat("0", DecimalMaxDigits))
+	assert.Equal(t, ErrDecimalOverflow, err)
+	_, err = calculateDecimal("div", v, big.NewInt(0))
+	assert.Equal(t, ErrDecimalDivisionByZero, err)
+}
+
+func TestContractCall(t *testing.T) {
+	neb := mockNeb(t)
+	core.SetCompatibilityOptions(neb.chain.ChainID())
+	defer core.SetCompatibilityOptions(core.TestNetID)
+	// the blocks are minted by the schedule, not by the dpos upgrades.
+	core.VRFProposerHeight = math.MaxUint64
+	core.ValidatorLivenessHeight = math.MaxUint64
+
+	tail := neb.chain.TailBlock()
+	manager, err := account.NewManager(neb)
+	assert.Nil(t, err)
+
+	a, _ := core.AddressParse("n1FF1nz6tarkDVwWQkMnnwFPuPKUaQTdptE")
+	assert.Nil(t, manager.Unlock(a, []byte("passphrase"), keystore.YearUnlockDuration))
+	b, _ := core.AddressParse("n1GmkKH6nBMw4rrjt16RrJ9WcgvKUtAZP1s")
+	assert.Nil(t, manager.Unlock(b, []byte("passphrase"), keystore.YearUnlockDuration))
+	c, _ := core.AddressParse("n1H4MYms9F55ehcvygwWE71J8tJC4CRr2so")
+	assert.Nil(t, manager.Unlock(c, []byte("passphrase"), keystore.YearUnlockDuration))
+
+	elapsedSecond := dpos.BlockIntervalInMs / dpos.SecondInMs
+	consensusState, err := tail.WorldState().NextConsensusState(elapsedSecond)
+	assert.Nil(t, err)
+	block, err := core.NewBlock(neb.chain.ChainID(), b, tail)
+	assert.Nil(t, err)
+	block.WorldState().SetConsensusState(consensusState)
+	block.SetTimestamp(consensusState.TimeStamp())
+
+	nonce := uint64(0)
+	gasLimit, _ := util.NewUint128FromInt(1000000)
+	contracts := []string{}
+	for _, path := range []string{"./test/contract_call_callee.js", "./test/contract_call_caller.js"} {
+		data, err := ioutil.ReadFile(path)
+		assert.Nil(t, err, "contract path read error")
+		deploy, _ := core.NewDeployPayload(string(data), "js", "")
+		payloadDeploy, _ := deploy.ToBytes()
+
+		nonce++
+		txDeploy, err := core.NewTransaction(neb.chain.ChainID(), a, a, util.NewUint128(), nonce, core.TxPayloadDeployType, payloadDeploy, core.TransactionGasPrice, gasLimit)
+		assert.Nil(t, err)
+		assert.Nil(t, manager.SignTransaction(a, txDeploy))
+		assert.Nil(t, neb.chain.TransactionPool().Push(txDeploy))
+
+		contractAddr, err := txDeploy.GenerateContractAddress()
+		assert.Nil(t, err)
+		contracts = append(contracts, contractAddr.String())
+	}
+	callee, caller := contracts[0], contracts[1]
+
+	block.CollectTransactions((time.Now().Unix() + 1) * dpos.SecondInMs)
+	assert.Nil(t, block.Seal())
+	assert.Nil(t, manager.SignBlock(b, block))
+	assert.Nil(t, neb.chain.BlockPool().Push(block))
+
+	tests := []struct {
+		function string
+		args     string
+		status   int8
+	}{
+		{"proxy", fmt.Sprintf("[\"%s\", \"hello\"]", callee), core.TxExecutionSuccess},
+		{"proxyCatch", fmt.Sprintf("[\"%s\"]", callee), core.TxExecutionFailed},
+		{"recurse", fmt.Sprintf("[\"%s\", %d]", caller, MaxContractCallDepth), core.TxExecutionSuccess},
+		{"recurse", fmt.Sprintf("[\"%s\", %d]", caller, MaxContractCallDepth+1), core.TxExecutionFailed},
+	}
+
+	tail = neb.chain.TailBlock()
+	consensusState, err = tail.WorldState().NextConsensusState(elapsedSecond)
+	assert.Nil(t, err)
+	block, err = core.NewBlock(neb.chain.ChainID(), c, tail)
+	assert.Nil(t, err)
+	block.WorldState().SetConsensusState(consensusState)
+	block.SetTimestamp(consensusState.TimeStamp())
+
+	to, _ := core.AddressParse(caller)
+	txs := []*core.Transaction{}
+	for _, tt := range tests {
+		callPayload, err := core.NewCallPayload(tt.function, tt.args)
+		assert.Nil(t, err)
+		payloadCall, _ := callPayload.ToBytes()
+
+		nonce++
+		txCall, err := core.NewTransaction(neb.chain.ChainID(), a, to, util.NewUint128(), nonce, core.TxPayloadCallType, payloadCall, core.TransactionGasPrice, gasLimit)
+		assert.Nil(t, err)
+		assert.Nil(t, manager.SignTransaction(a, txCall))
+		assert.Nil(t, neb.chain.TransactionPool().Push(txCall))
+		txs = append(txs, txCall)
+	}
+
+	block.CollectTransactions((time.Now().Unix() + 1) * dpos.SecondInMs)
+	assert.Nil(t, block.Seal())
+	assert.Nil(t, manager.SignBlock(c, block))
+	assert.Nil(t, neb.chain.BlockPool().Push(block))
+
+	for i, tt := range tests {
+		event, err := neb.chain.TailBlock().FetchExecutionResultEvent(txs[i].Hash())
+		assert.Nil(t, err)
+		txEvent := core.TransactionEvent{}
+		assert.Nil(t, json.Unmarshal([]byte(event.Data), &txEvent))
+		assert.Equal(t, tt.status, txEvent.Status, tt.function)
+	}
+}
+
+func TestContractTransfer(t *testing.T) {
+	neb := mockNeb(t)
+	core.SetCompatibilityOptions(neb.chain.ChainID())
+	defer core.SetCompatibilityOptions(core.TestNetID)
+	// the blocks are minted by the schedule, not by the dpos upgrades.
+	core.VRFProposerHeight = math.MaxUint64
+	core.ValidatorLivenessHeight = math.MaxUint64
+
+	tail := neb.chain.TailBlock()
+	manager, err := account.NewManager(neb)
+	assert.Nil(t, err)
+
+	a, _ := core.AddressParse("n1FF1nz6tarkDVwWQkMnnwFPuPKUaQTdptE")
+	assert.Nil(t, manager.Unlock(a, []byte("passphrase"), keystore.YearUnlockDuration))
+	b, _ := core.AddressParse("n1GmkKH6nBMw4rrjt16RrJ9WcgvKUtAZP1s")
+	assert.Nil(t, manager.Unlock(b, []byte("passphrase"), keystore.YearUnlockDuration))
+	c, _ := core.AddressParse("n1H4MYms9F55ehcvygwWE71J8tJC4CRr2so")
+	assert.Nil(t, manager.Unlock(c, []byte("passphrase"), keystore.YearUnlockDuration))
+
+	elapsedSecond := dpos.BlockIntervalInMs / dpos.SecondInMs
+	consensusState, err := tail.WorldState().NextConsensusState(elapsedSecond)
+	assert.Nil(t, err)
+	block, err := core.NewBlock(neb.chain.ChainID(), b, tail)
+	assert.Nil(t, err)
+	block.WorldState().SetConsensusState(consensusState)
+	block.SetTimestamp(consensusState.TimeStamp())
+
+	data, err := ioutil.ReadFile("./test/contract_transfer.js")
+	assert.Nil(t, err, "contract path read error")
+	deploy, _ := core.NewDeployPayload(string(data), "js", "")
+	payloadDeploy, _ := deploy.ToBytes()
+
+	nonce := uint64(0)
+	gasLimit, _ := util.NewUint128FromInt(1000000)
+	contracts := []*core.Address{}
+	for i := 0; i < 2; i++ {
+		nonce++
+		txDeploy, err := core.NewTransaction(neb.chain.ChainID(), a, a, util.NewUint128(), nonce, core.TxPayloadDeployType, payloadDeploy, core.TransactionGasPrice, gasLimit)
+		assert.Nil(t, err)
+		assert.Nil(t, manager.SignTransaction(a, txDeploy))
+		assert.Nil(t, neb.chain.TransactionPool().Push(txDeploy))
+
+		contractAddr, err := txDeploy.GenerateContractAddress()
+		assert.Nil(t, err)
+		contracts = append(contracts, contractAddr)
+	}
+	payer, receiver := contracts[0], contracts[1]
+
+	block.CollectTransactions((time.Now().Unix() + 1) * dpos.SecondInMs)
+	assert.Nil(t, block.Seal())
+	assert.Nil(t, manager.SignBlock(b, block))
+	assert.Nil(t, neb.chain.BlockPool().Push(block))
+
+	tests := []struct {
+		to       *core.Address
+		function string
+		args     string
+		status   int8
+	}{
+		{payer, "pay", fmt.Sprintf("[\"%s\", \"3\"]", receiver), core.TxExecutionSuccess},
+		{receiver, "setReenter", "[true]", core.TxExecutionSuccess},
+		{payer, "pay", fmt.Sprintf("[\"%s\", \"3\"]", receiver), core.TxExecutionFailed},
+	}
+
+	tail = neb.chain.TailBlock()
+	consensusState, err = tail.WorldState().NextConsensusState(elapsedSecond)
+	assert.Nil(t, err)
+	block, err = core.NewBlock(neb.chain.ChainID(), c, tail)
+	assert.Nil(t, err)
+	block.WorldState().SetConsensusState(consensusState)
+	block.SetTimestamp(consensusState.TimeStamp())
+
+	txs := []*core.Transaction{}
+	for _, tt := range tests {
+		callPayload, err := core.NewCallPayload(tt.function, tt.args)
+		assert.Nil(t, err)
+		payloadCall, _ := callPayload.ToBytes()
+
+		nonce++
+		txCall, err := core.NewTransaction(neb.chain.ChainID(), a, tt.to, util.NewUint128FromUint(10), nonce, core.TxPayloadCallType, payloadCall, core.TransactionGasPrice, gasLimit)
+		assert.Nil(t, err)
+		assert.Nil(t, manager.SignTransaction(a, txCall))
+		assert.Nil(t, neb.chain.TransactionPool().Push(txCall))
+		txs = append(txs, txCall)
+	}
+
+	block.CollectTransactions((time.Now().Unix() + 1) * dpos.SecondInMs)
+	assert.Nil(t, block.Seal())
+	assert.Nil(t, manager.SignBlock(c, block))
+	assert.Nil(t, neb.chain.BlockPool().Push(block))
+
+	for i, tt := range tests {
+		event, err := neb.chain.TailBlock().FetchExecutionResultEvent(txs[i].Hash())
+		assert.Nil(t, err)
+		txEvent := core.TransactionEvent{}
+		assert.Nil(t, json.Unmarshal([]byte(event.Data), &txEvent))
+		assert.Equal(t, tt.status, txEvent.Status, tt.function)
+	}
+
+	// the receiver got 10 from the setReenter tx and 3 from the payer, the reentrant payment is reverted.
+	ws, err := neb.chain.TailBlock().WorldState().Clone()
+	assert.Nil(t, err)
+	acc, err := ws.GetContractAccount(receiver.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, "13", acc.Balance().String())
+}
+
+func TestStaticCall(t *testing.T) {
+	data, err := ioutil.ReadFile("./test/contract_call_callee.js")
+	assert.Nil(t, err, "contract path read error")
+
+	mem, _ := storage.NewMemoryStorage()
+	context, _ := state.NewWorldState(dpos.NewDpos(), mem)
+	addr, _ := core.AddressParse("n1p8cwrrfrbFe71eda1PQ6y4WnX3gp8bYze")
+	contract, _ := context.CreateContractAccount(addr.Bytes(), nil, &corepb.ContractMeta{Version: "1.0.5"})
+	ctx, err := NewContext(mockBlockForLib(2000000), mockTransaction(), contract, context)
+	assert.Nil(t, err)
+
+	engine := NewV8Engine(ctx)
+	engine.SetExecutionLimits(100000, 10000000)
+	_, err = engine.DeployAndInit(string(data), "js", "")
+	assert.Nil(t, err)
+	engine.Dispose()
+
+	tests := []struct {
+		function string
+		args     string
+		success  bool
+	}{
+		{"get", "", true},
+		{"set", "[\"hello\"]", false},
+		{"fail", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.function, func(t *testing.T) {
+			ctx, err := NewContext(mockBlockForLib(2000000), mockTransaction(), contract, context)
+			assert.Nil(t, err)
+			engine := NewV8Engine(ctx)
+			engine.SetReadOnly(true)
+			engine.SetExecutionLimits(100000, 10000000)
+			_, err = engine.Call(string(data), "js", tt.function, tt.args)
+			if tt.success {
+				assert.Nil(t, err)
+			} else {
+				assert.NotNil(t, err)
+			}
+			engine.Dispose()
+		})
+	}
+}
+
+func TestInstructionsProfile(t *testing.T) {
+	profile := newInstructionsProfile()
+	profile.add("transfer", 12, 9, 10)
+	profile.add("balanceOf", 8, 5, 3)
+	profile.add("transfer", 12, 9, 7)
+
+	assert.Equal(t, []statementPosition{{12, 9}, {8, 5}}, profile.positions)
+	assert.Equal(t, uint64(17), profile.counts[statementPosition{12, 9}])
+	assert.Equal(t, "balanceOf", profile.functions[statementPosition{8, 5}])
+
+	// the executions on untraced world states are not profiled.
+	assert.Nil(t, executionTracer(nil))
+	assert.Nil(t, executionTracer(&Context{}))
+}
+
+func TestSourceMap(t *testing.T) {
+	_, err := parseSourceMap(`{"version":2,"sources":["token.ts"],"mappings":"AAAA"}`)
+	assert.Equal(t, ErrInvalidSourceMap, err)
+	_, err = parseSourceMap(`{"version":3,"sources":["token.ts"],"mappings":"AAAA,C"}`)
+	assert.Nil(t, err)
+	_, err = parseSourceMap(`{"version":3,"sources":["token.ts"],"mappings":"AAAA,ACAA"}`)
+	assert.Equal(t, ErrInvalidSourceMap, err)
+
+	values, err := decodeVLQ("AAgBC")
+	assert.Nil(t, err)
+	assert.Equal(t, []int{0, 0, 16, 1}, values)
+	_, err = decodeVLQ("g")
+	assert.Equal(t, ErrInvalidSourceMap, err)
+
+	sm, err := parseSourceMap(`{"version":3,"sources":["token.ts"],"mappings":"AAAA,IAAI;AACA;;AAEE"}`)
+	assert.Nil(t, err)
+	pos, ok := sm.Original(1, 3)
+	assert.True(t, ok)
+	assert.Equal(t, "token.ts:1:1", pos)
+	pos, ok = sm.Original(1, 9)
+	assert.True(t, ok)
+	assert.Equal(t, "token.ts:1:5", pos)
+	pos, ok = sm.Original(2, 1)
+	assert.True(t, ok)
+	assert.Equal(t, "token.ts:2:5", pos)
+	_, ok = sm.Original(3, 1)
+	assert.False(t, ok)
+	pos, ok = sm.Original(4, 2)
+	assert.True(t, ok)
+	assert.Equal(t, "token.ts:4:7", pos)
+
+	e := &V8Engine{}
+	e.SetSourceMap("{")
+	assert.Nil(t, e.sourceMap)
+	assert.Equal(t, "4:2", e.originalPosition(4, 2))
+}
+
+func TestCheckTypeScript(t *testing.T) {
+	nvm := NewNebulasVM()
+	block := core.MockBlock(nil, core.NvmMemoryLimitWithoutInjectHeight)
+
+	data, err := ioutil.ReadFile("./test/test_greeter.ts")
+	assert.Nil(t, err)
+	assert.Nil(t, nvm.CheckTypeScript(block, string(data)))
+
+	err = nvm.CheckTypeScript(block, "class Greeter {\n    greet(: string) {}\n}")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), ErrTranspileTypeScriptFailed.Error())
+	assert.Contains(t, err.Error(), "_contract.ts:2:")
+}
+
+func TestContractModules(t *testing.T) {
+	source := `var Contract = function() {};
+Contract.prototype = {
+	init: function() {},
+	double: function(x) {
+		return require("./util/math.js").double(x);
+	},
+	missing: function() {
+		return require("util/missing.js");
+	}
+};
+module.exports = Contract;`
+	modules := map[string]string{
+		"util/math.js": `module.exports = { double: function(x) { return 2 * x; } };`,
+	}
+
+	mem, _ := storage.NewMemoryStorage()
+	context, _ := state.NewWorldState(dpos.NewDpos(), mem)
+	contract, _ := context.CreateContractAccount([]byte("account2"), nil, nil)
+	ctx, err := NewContext(mockBlock(), mockTransaction(), contract, context)
+	assert.Nil(t, err)
+
+	engine := NewV8Engine(ctx)
+	engine.SetExecutionLimits(10000, 10000000)
+	engine.SetModules(modules)
+	result, err := engine.Call(source, core.SourceTypeJavaScript, "double", "[2]")
+	assert.Nil(t, err)
+	assert.Equal(t, "4", result)
+	engine.Dispose()
+
+	engine = NewV8Engine(ctx)
+	engine.SetExecutionLimits(10000, 10000000)
+	engine.SetModules(modules)
+	_, err = engine.Call(source, core.SourceTypeJavaScript, "missing", "")
+	assert.Equal(t, core.ErrExecutionFailed, err)
+	engine.Dispose()
+}
+
+type readOnlyBlock struct {
+	*testBlock
+}
+
+func (block *readOnlyBlock) ReadOnly() bool {
+	return true
+}
+
+func TestExecutionPolicy(t *testing.T) {
+	defer SetupExecutionPolicy(nil, nil)
+	denied, err := core.AddressParse("n1FkntVUMPAsESuCAAPK711omQk19JotBjM")
+	assert.Nil(t, err)
+	allowed, err := core.AddressParse("n1JNHZJEUvfBYfjDRD14Q73FX62nJAzXkMR")
+	assert.Nil(t, err)
+	block := &readOnlyBlock{&testBlock{1}}
+
+	assert.NotNil(t, SetupExecutionPolicy(nil, []string{"n1invalid"}))
+	assert.Nil(t, SetupExecutionPolicy(nil, []string{denied.String()}))
+	assert.Equal(t, ErrContractExecutionDenied, checkExecutionPolicy(block, denied.Bytes()))
+	assert.Nil(t, checkExecutionPolicy(block, allowed.Bytes()))
+	// the executions on chain are not affected.
+	assert.Nil(t, checkExecutionPolicy(mockBlock(), denied.Bytes()))
+
+	assert.Nil(t, SetupExecutionPolicy([]string{allowed.String()}, nil))
+	assert.Equal(t, ErrContractExecutionDenied, checkExecutionPolicy(block, denied.Bytes()))
+	assert.Nil(t, checkExecutionPolicy(block, allowed.Bytes()))
+}
+
+func TestGetBlockByHeight(t *testing.T) {
+	source := `var Contract = function() {};
+Contract.prototype = {
+	init: function() {},
+	header: function(height) {
+		return Blockchain.getBlockByHeight(height);
+	},
+	available: function() {
+		return typeof Blockchain.getBlockByHeight === "function";
+	}
+};
+module.exports = Contract;`
+
+	blockHistoryHeight := core.BlockHistoryHeight
+	defer func() { core.BlockHistoryHeight = blockHistoryHeight }()
+	core.BlockHistoryHeight = 0
+
+	coinbase, err := core.AddressParse("n1FkntVUMPAsESuCAAPK711omQk19JotBjM")
+	assert.Nil(t, err)
+	curBlock := mockBlockForLib(2000000)
+	preBlock := &corepb.Block{
+		Header: &corepb.BlockHeader{
+			ParentHash: []byte("parentHash"),
+			Coinbase:   coinbase.Bytes(),
+			Timestamp:  1540000000,
+			StateRoot:  []byte("stateRoot"),
+		},
+	}
+	blockBytes, err := proto.Marshal(preBlock)
+	assert.Nil(t, err)
+	mem, _ := storage.NewMemoryStorage()
+	mem.Put(byteutils.FromUint64(curBlock.Height()-1), []byte("blockHash"))
+	mem.Put([]byte("blockHash"), blockBytes)
+
+	context, _ := state.NewWorldState(dpos.NewDpos(), mem)
+	contract, _ := context.CreateContractAccount([]byte("account1"), nil, &corepb.ContractMeta{Version: "1.1.0"})
+	ctx, err := NewContext(curBlock, mockTransaction(), contract, context)
+	assert.Nil(t, err)
+
+	header, err := blockHeaderByHeight(ctx.state, curBlock.Height()-1)
+	assert.Nil(t, err)
+	assert.Equal(t, &SerializableBlockHeader{
+		Hash:       byteutils.Hex([]byte("blockHash")),
+		ParentHash: byteutils.Hex([]byte("parentHash")),
+		Height:     curBlock.Height() - 1,
+		Timestamp:  1540000000,
+		Coinbase:   coinbase.String(),
+		StateRoot:  byteutils.Hex([]byte("stateRoot")),
+	}, header)
+
+	tests := []struct {
+		args   string
+		result string
+		err    error
+	}{
+		{"[1999999]", `{"hash":"` + header.Hash + `","parentHash":"` + header.ParentHash + `","height":1999999,"timestamp":1540000000,"coinbase":"` + coinbase.String() + `","stateRoot":"` + header.StateRoot + `","txsRoot":"","eventsRoot":""}`, nil},
+		{"[2000000]", "getBlockByHeight: block not exist", core.ErrExecutionFailed},
+		{"[0]", "getBlockByHeight: invalid height", core.ErrExecutionFailed},
+		{"[1]", "Blockchain.getBlockByHeight(), height out of range", core.ErrExecutionFailed},
+	}
+	for _, tt := range tests {
+		engine := NewV8Engine(ctx)
+		engine.SetExecutionLimits(100000, 10000000)
+		result, err := engine.Call(source, core.SourceTypeJavaScript, "header", tt.args)
+		assert.Equal(t, tt.err, err, tt.args)
+		assert.Equal(t, tt.result, result, tt.args)
+		engine.Dispose()
+	}
+
+	// not available before the fork.
+	core.BlockHistoryHeight = curBlock.Height() + 1
+	engine := NewV8Engine(ctx)
+	engine.SetExecutionLimits(100000, 10000000)
+	result, err := engine.Call(source, core.SourceTypeJavaScript, "header", "[1999999]")
+	assert.Equal(t, core.ErrExecutionFailed, err)
+	assert.Equal(t, "Blockchain.getBlockByHeight(), not available at this height", result)
+	engine.Dispose()
+
+	// the contracts deployed with 1.0.5 keep the blockchain lib without it.
+	contract, _ = context.CreateContractAccount([]byte("account2"), nil, &corepb.ContractMeta{Version: "1.0.5"})
+	ctx, err = NewContext(curBlock, mockTransaction(), contract, context)
+	assert.Nil(t, err)
+	engine = NewV8Engine(ctx)
+	engine.SetExecutionLimits(100000, 10000000)
+	result, err = engine.Call(source, core.SourceTypeJavaScript, "available", "")
+	assert.Nil(t, err)
+	assert.Equal(t, "false", result)
+	engine.Dispose()
+}
+
+func TestSizedGas(t *testing.T) {
+	sizedGasHeight := core.SizedGasHeight
+	defer func() { core.SizedGasHeight = sizedGasHeight }()
+
+	mem, _ := storage.NewMemoryStorage()
+	context, _ := state.NewWorldState(dpos.NewDpos(), mem)
+	contract, _ := context.CreateContractAccount([]byte("account1"), nil, &corepb.ContractMeta{Version: "1.1.0"})
+	ctx, err := NewContext(mockBlockForLib(2000000), mockTransaction(), contract, context)
+	assert.Nil(t, err)
+
+	run := func(source string) uint64 {
+		engine := NewV8Engine(ctx)
+		defer engine.Dispose()
+		engine.SetExecutionLimits(100000000, 100000000)
+		_, err := engine.RunScriptSource(source, 0)
+		assert.Nil(t, err)
+		return engine.ExecutionInstructions()
+	}
+	small := `var s = "x".repeat(10); JSON.parse(JSON.stringify(s)); require("crypto.js").sha256(s);`
+	large := `var s = "x".repeat(100000); JSON.parse(JSON.stringify(s)); require("crypto.js").sha256(s);`
+
+	// the flat charges before the fork, besides the bytes hashed.
+	core.SizedGasHeight = ctx.block.Height() + 1
+	flat := run(large) - run(small)
+	assert.Equal(t, uint64(100000-10), flat)
+	assert.Equal(t, uint64(5), storagePutGas(ctx, "key", []byte("va")))
+
+	core.SizedGasHeight = ctx.block.Height()
+	sized := run(large) - run(small)
+	// repeat, stringify, parse & sha256 each process the string.
+	assert.True(t, sized >= flat+(100000-10)*(StringGasPerByte+2*JSONGasPerByte+CryptoHashGasPerByte))
+	assert.Equal(t, uint64(StoragePutGasBase+5*StoragePutGasPerByte), storagePutGas(ctx, "key", []byte("va")))
+}
+
+func TestValidateContract(t *testing.T) {
+	nvm := NewNebulasVM()
+	// the validator.js is taken from the versioned libs.
+	block := core.MockBlock(nil, core.V8JSLibVersionControlHeight)
+
+	data, err := ioutil.ReadFile("./test/NRC20.js")
+	assert.Nil(t, err)
+	assert.Nil(t, nvm.ValidateContract(block, string(data), core.SourceTypeJavaScript, nil))
+	data, err = ioutil.ReadFile("./test/test_greeter.ts")
+	assert.Nil(t, err)
+	assert.Nil(t, nvm.ValidateContract(block, string(data), core.SourceTypeTypeScript, nil))
+
+	source := `var Contract = function() {};
+Contract.prototype = {
+	init: function() { this.eval = 1; },
+	draw: function(s) {
+		return Math.random() + Date.now() + /(a+)+$/.test(s);
+	}
+};
+module.exports = Contract;`
+	modules := map[string]string{
+		"util/eval.js":  `module.exports = eval("1");`,
+		"util/alias.js": "var m = Math;\nmodule.exports = (function(){}).constructor(\"return 1\") + new RegExp(m);",
+		"util/big.js":   "var s = \"" + strings.Repeat("x", ContractSourceMaxSize) + "\";",
+	}
+	err = nvm.ValidateContract(block, source, core.SourceTypeJavaScript, modules)
+	verr, ok := err.(*ContractValidationError)
+	assert.True(t, ok)
+	assert.Equal(t, []*ContractDiagnostic{
+		{contractModuleID, "Math.random", "Math.random is not allowed", 5, 9},
+		{contractModuleID, "Date.now", "Date.now is not allowed", 5, 25},
+		{contractModuleID, "regex", "the pattern has nested quantifiers", 5, 38},
+		{"util/alias.js", "alias", "Math can only be used by its members", 1, 8},
+		{"util/alias.js", "eval", "calling the constructor property is not allowed", 2, 17},
+		{"util/alias.js", "regex", "the pattern of RegExp should be a string literal", 2, 58},
+		{"util/big.js", "size", fmt.Sprintf("the source is larger than %d bytes", ContractSourceMaxSize), 0, 0},
+		{"util/eval.js", "eval", "eval is not allowed", 1, 17},
+	}, verr.Diagnostics)
+	assert.Contains(t, err.Error(), ErrInvalidContractSource.Error())
+
+	err = nvm.ValidateContract(block, "var a = ;", core.SourceTypeJavaScript, nil)
+	assert.Contains(t, err.Error(), `"rule":"syntax"`)
+	// wasm contracts are not checked.
+	assert.Nil(t, nvm.ValidateContract(block, source, core.SourceTypeWasm, nil))
+}
+
+func TestLibSnapshot(t *testing.T) {
+	libSnapshotHeight := core.LibSnapshotHeight
+	defer func() { core.LibSnapshotHeight = libSnapshotHeight }()
+
+	mem, _ := storage.NewMemoryStorage()
+	context, _ := state.NewWorldState(dpos.NewDpos(), mem)
+	contract, _ := context.CreateContractAccount([]byte("account1"), nil, &corepb.ContractMeta{Version: "1.0.5"})
+	ctx, err := NewContext(mockBlockForLib(2000000), mockTransaction(), contract, context)
+	assert.Nil(t, err)
+
+	run := func() (string, uint64) {
+		engine := NewV8Engine(ctx)
+		defer engine.Dispose()
+		engine.SetExecutionLimits(100000000, 100000000)
+		result, err := engine.RunScriptSource(`LocalContractStorage.set("key", new BigNumber(2).pow(10).toString(10));
+[LocalContractStorage.get("key"), new Uint(10).toString(), typeof _native_blockchain];`, 0)
+		assert.Nil(t, err)
+		return result, engine.ExecutionInstructions()
+	}
+
+	core.LibSnapshotHeight = ctx.block.Height() + 1
+	assert.Equal(t, 0, libSnapshotContext(ctx))
+	built, builtGas := run()
+
+	core.LibSnapshotHeight = ctx.block.Height()
+	assert.True(t, libSnapshotContext(ctx) > 0)
+	restored, restoredGas := run()
+	assert.Equal(t, built, restored)
+	assert.Equal(t, builtGas, restoredGas)
+	assert.Equal(t, `["1024","10","object"]`, restored)
+
+	// the lib envs of the versions not on the chain are set up for each execution.
+	contract, _ = context.CreateContractAccount([]byte("account2"), nil, &corepb.ContractMeta{Version: "9.9.9"})
+	ctx, err = NewContext(mockBlockForLib(2000000), mockTransaction(), contract, context)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, libSnapshotContext(ctx))
+}
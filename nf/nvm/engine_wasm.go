@@ -0,0 +1,129 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package nvm
+
+import (
+	"encoding/json"
+
+	"github.com/nebulasio/go-nebulas/common/trie"
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/core/state"
+	"github.com/nebulasio/go-nebulas/nf/wasm"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// wasmHost the chain functions of a wasm contract, on the context of the engine.
+// They cost the same gas as the ones of the js contracts.
+type wasmHost struct {
+	ctx   *Context
+	limit uint64 // the gas limit of the execution.
+}
+
+func (h *wasmHost) Context() string {
+	data, err := json.Marshal(&struct {
+		Block       *SerializableBlock       `json:"block"`
+		Transaction *SerializableTransaction `json:"transaction"`
+	}{
+		Block:       toSerializableBlock(h.ctx.block),
+		Transaction: toSerializableTransaction(h.ctx.tx),
+	})
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+func (h *wasmHost) StorageGet(key []byte) ([]byte, uint64, error) {
+	value, err := h.ctx.contract.Get(trie.HashDomains(DefaultDomainKey, string(key)))
+	if err == ErrKeyNotFound {
+		return nil, 0, nil
+	}
+	return value, 0, err
+}
+
+func (h *wasmHost) StoragePut(key, value []byte) (uint64, error) {
+	if h.ctx.denyStateChange() {
+		return 0, ErrStateChangeInStaticCall
+	}
+	return uint64(len(key) + len(value)), h.ctx.contract.Put(trie.HashDomains(DefaultDomainKey, string(key)), value)
+}
+
+func (h *wasmHost) StorageDel(key []byte) (uint64, error) {
+	if h.ctx.denyStateChange() {
+		return 0, ErrStateChangeInStaticCall
+	}
+	err := h.ctx.contract.Del(trie.HashDomains(DefaultDomainKey, string(key)))
+	if err == ErrKeyNotFound {
+		err = nil
+	}
+	return 0, err
+}
+
+func (h *wasmHost) Transfer(to, value string) (uint64, error) {
+	if h.ctx.denyStateChange() {
+		return TransferGasBase, ErrStateChangeInStaticCall
+	}
+	// the receiver contract runs with the whole limit, the wasm engine fails
+	// once the gas it used runs over.
+	ret, gas := transferFromContract(h.ctx, to, value, h.limit)
+	if ret != TransferFuncSuccess {
+		return TransferGasBase + gas, ErrTransferFromContractFailed
+	}
+	return TransferGasBase + gas, nil
+}
+
+func (h *wasmHost) TriggerEvent(topic, data string) (uint64, error) {
+	if h.ctx.denyStateChange() {
+		return 0, ErrStateChangeInStaticCall
+	}
+	h.ctx.state.RecordEvent(h.ctx.tx.Hash(), &state.Event{Topic: EventNameSpaceContract + "." + topic, Data: data})
+	return uint64(EventBaseGasCount + len(topic) + len(data)), nil
+}
+
+// RunWasmContract run the function of the hex encoded wasm module, the execution
+// limits of the engine are applied.
+func (e *V8Engine) RunWasmContract(source, function, args string) (string, error) {
+	code, err := byteutils.FromHex(source)
+	if err != nil {
+		return "", ErrUnsupportedSourceType
+	}
+	engine := wasm.NewEngine(&wasmHost{ctx: e.ctx, limit: e.limitsOfExecutionInstructions})
+	if err := engine.SetExecutionLimits(e.limitsOfExecutionInstructions, e.limitsOfTotalMemorySize); err != nil {
+		return "", ErrLimitHasEmpty
+	}
+
+	result, err := engine.Run(code, function, args)
+	e.actualCountOfExecutionInstructions = engine.ExecutionInstructions()
+	switch err {
+	case nil:
+	case wasm.ErrInsufficientGas:
+		err = ErrInsufficientGas
+	case wasm.ErrExecutionFailed, wasm.ErrExecutionReverted, wasm.ErrMemoryOutOfBounds:
+		err = core.ErrExecutionFailed
+	case wasm.ErrInvalidModule, wasm.ErrFunctionNotFound:
+		logging.VLog().WithFields(logrus.Fields{
+			"function": function,
+			"err":      err,
+		}).Debug("Failed to load wasm contract.")
+		result, err = err.Error(), core.ErrExecutionFailed
+	}
+	return result, err
+}
@@ -18,6 +18,9 @@
 
 package nvm
 
+/*
+#include "v8/lib/nvm_error.h"
+*/
 import "C"
 import (
 	"unsafe"
@@ -58,7 +61,7 @@ type TransferFromContractFailureEvent struct {
 
 // EventTriggerFunc export EventTriggerFunc
 //export EventTriggerFunc
-func EventTriggerFunc(handler unsafe.Pointer, topic, data *C.char, gasCnt *C.size_t) {
+func EventTriggerFunc(handler unsafe.Pointer, topic, data *C.char, gasCnt *C.size_t) int {
 	gTopic := C.GoString(topic)
 	gData := C.GoString(data)
 
@@ -69,7 +72,11 @@ func EventTriggerFunc(handler unsafe.Pointer, topic, data *C.char, gasCnt *C.siz
 			"topic":    gTopic,
 			"data":     gData,
 		}).Error("Event.Trigger delegate handler does not found.")
-		return
+		return C.NVM_SUCCESS
+	}
+	defer traceHostCall(e, "Event.Trigger", gasCnt)
+	if e.ctx.denyStateChange() {
+		return C.NVM_STATIC_CALL_ERR
 	}
 
 	// calculate Gas.
@@ -78,4 +85,5 @@ func EventTriggerFunc(handler unsafe.Pointer, topic, data *C.char, gasCnt *C.siz
 	contractTopic := EventNameSpaceContract + "." + gTopic
 	event := &state.Event{Topic: contractTopic, Data: gData}
 	e.ctx.state.RecordEvent(e.ctx.tx.Hash(), event)
+	return C.NVM_SUCCESS
 }
@@ -0,0 +1,96 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package nvm
+
+import (
+	"sync"
+
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// executionPolicy the local policy of the node on the read-only executions of
+// contracts, which are initiated by RPC. The executions on chain never check it.
+type executionPolicy struct {
+	allowed map[string]bool // the contracts allowed, empty to allow all but the denied ones.
+	denied  map[string]bool
+}
+
+var (
+	localPolicy     = &executionPolicy{}
+	localPolicyLock = sync.RWMutex{}
+)
+
+func parseContracts(contracts []string) (map[string]bool, error) {
+	addrs := make(map[string]bool, len(contracts))
+	for _, v := range contracts {
+		addr, err := core.AddressParse(v)
+		if err != nil {
+			return nil, err
+		}
+		addrs[addr.String()] = true
+	}
+	return addrs, nil
+}
+
+// SetupExecutionPolicy restrict the read-only executions to the allowed
+// contracts, if any, and refuse to run the denied contracts.
+func SetupExecutionPolicy(allowed, denied []string) error {
+	allowedAddrs, err := parseContracts(allowed)
+	if err != nil {
+		return err
+	}
+	deniedAddrs, err := parseContracts(denied)
+	if err != nil {
+		return err
+	}
+
+	localPolicyLock.Lock()
+	defer localPolicyLock.Unlock()
+	localPolicy = &executionPolicy{allowed: allowedAddrs, denied: deniedAddrs}
+
+	logging.CLog().WithFields(logrus.Fields{
+		"allowed": len(allowedAddrs),
+		"denied":  len(deniedAddrs),
+	}).Info("Set the execution policy of read-only executions.")
+	return nil
+}
+
+// checkExecutionPolicy check if the node runs the contract in the block.
+func checkExecutionPolicy(block Block, contract byteutils.Hash) error {
+	if !block.ReadOnly() {
+		return nil
+	}
+	addr, err := core.AddressParseFromBytes(contract)
+	if err != nil {
+		return err
+	}
+
+	localPolicyLock.RLock()
+	defer localPolicyLock.RUnlock()
+	if localPolicy.denied[addr.String()] || (len(localPolicy.allowed) > 0 && !localPolicy.allowed[addr.String()]) {
+		logging.VLog().WithFields(logrus.Fields{
+			"contract": addr.String(),
+		}).Debug("Refused to run the contract by the execution policy.")
+		return ErrContractExecutionDenied
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package nvm
+
+import "C"
+
+import (
+	"github.com/nebulasio/go-nebulas/core"
+)
+
+// sizedGas check if the builtin lib functions charge gas in proportion to the
+// size of the data they process, since core.SizedGasHeight.
+func sizedGas(ctx *Context) bool {
+	return ctx != nil && ctx.block != nil && ctx.block.Height() >= core.SizedGasHeight
+}
+
+// setGasSchedule let the engine charge JSON, string ops and hashing by size for
+// the contract execution. The injection and transpilation running on the same
+// engine are left flat, their results are cached.
+func (e *V8Engine) setGasSchedule() {
+	if !sizedGas(e.ctx) {
+		return
+	}
+	e.v8engine.gas_schedule.json_per_byte = C.size_t(JSONGasPerByte)
+	e.v8engine.gas_schedule.string_per_byte = C.size_t(StringGasPerByte)
+	e.v8engine.gas_schedule.hash_per_byte = C.size_t(CryptoHashGasPerByte)
+}
+
+func (e *V8Engine) clearGasSchedule() {
+	e.v8engine.gas_schedule.json_per_byte = 0
+	e.v8engine.gas_schedule.string_per_byte = 0
+	e.v8engine.gas_schedule.hash_per_byte = 0
+}
+
+// storagePutGas return the gas of writing the value at the key.
+func storagePutGas(ctx *Context, key string, value []byte) uint64 {
+	size := uint64(len(key) + len(value))
+	if sizedGas(ctx) {
+		return StoragePutGasBase + size*StoragePutGasPerByte
+	}
+	return size
+}
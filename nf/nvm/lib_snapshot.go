@@ -0,0 +1,95 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package nvm
+
+/*
+#include <stdlib.h>
+#include "v8/engine.h"
+*/
+import "C"
+
+import (
+	"unsafe"
+
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/core/state"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// libSnapshots the index + 1 of the lib env of each js lib version in the lib
+// snapshot, empty if the snapshot is not created.
+var libSnapshots = make(map[string]int)
+
+// createLibSnapshot set up the lib env of each js lib version once at start,
+// the isolates are created from the snapshot keeping them, and the executions
+// restore the lib env instead of loading the libs each.
+func createLibSnapshot() {
+	versions := core.GetV8JSLibVersions()
+	height := core.CurrentChainConfig.Height(core.ForkV8JSLibVersionControl)
+
+	// the engines choose the libs of the versions while the lib envs are set up.
+	engines := make([]*V8Engine, len(versions))
+	for i, version := range versions {
+		engines[i] = newV8Engine(&Context{
+			block:    core.MockBlock(nil, height),
+			contract: state.MockAccount(version),
+		}, C.CreateEngine())
+	}
+	defer func() {
+		for _, engine := range engines {
+			engine.Dispose()
+		}
+	}()
+
+	cEngines := (**C.V8Engine)(C.malloc(C.size_t(len(engines)) * C.size_t(unsafe.Sizeof(uintptr(0)))))
+	defer C.free(unsafe.Pointer(cEngines))
+	slice := (*[1 << 10]*C.V8Engine)(unsafe.Pointer(cEngines))[:len(engines):len(engines)]
+	for i, engine := range engines {
+		slice[i] = engine.v8engine
+	}
+
+	if ret := C.CreateLibSnapshot(cEngines, C.int(len(engines))); ret != C.NVM_SUCCESS {
+		logging.CLog().WithFields(logrus.Fields{
+			"versions": versions,
+		}).Error("Failed to create the lib snapshot, the lib envs are set up for each execution.")
+		return
+	}
+	for i, version := range versions {
+		libSnapshots[version] = i + 1
+	}
+	logging.CLog().WithFields(logrus.Fields{
+		"versions": versions,
+	}).Info("Created the lib snapshot.")
+}
+
+// libSnapshotContext return the index + 1 of the lib env the contract runs
+// with in the lib snapshot, since core.LibSnapshotHeight. 0 to set up the lib
+// env in a new context.
+func libSnapshotContext(ctx *Context) int {
+	if ctx == nil || ctx.block == nil || ctx.contract == nil || ctx.block.Height() < core.LibSnapshotHeight {
+		return 0
+	}
+	// the same version as AttachLibVersionDelegateFunc picks.
+	version := core.DefaultV8JSLibVersion
+	if meta := ctx.contract.ContractMeta(); meta != nil && core.CurrentChainConfig.IsActive(core.ForkV8JSLibVersionControl, ctx.block.Height()) {
+		version = meta.Version
+	}
+	return libSnapshots[version]
+}
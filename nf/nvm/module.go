@@ -23,6 +23,7 @@ import "C"
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 	"unsafe"
 
@@ -80,6 +81,37 @@ func (ms Modules) Get(id string) *Module {
 	return ms[id]
 }
 
+// SetModules set the modules deployed with the contract, the contract requires
+// them by their path.
+func (e *V8Engine) SetModules(modules map[string]string) {
+	e.contractModules = modules
+}
+
+// addContractModules add the modules deployed with the contract, in the order
+// of their paths, a typescript contract has its modules transpiled.
+func (e *V8Engine) addContractModules(sourceType string) error {
+	paths := make([]string, 0, len(e.contractModules))
+	for path := range e.contractModules {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		source := e.contractModules[path]
+		if sourceType == core.SourceTypeTypeScript {
+			jsSource, _, err := e.TranspileTypeScript(source)
+			if err != nil {
+				return err
+			}
+			source = jsSource
+		}
+		if err := e.AddModule(path, source, 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // RequireDelegateFunc delegate func for require.
 //export RequireDelegateFunc
 func RequireDelegateFunc(handler unsafe.Pointer, filename *C.char, lineOffset *C.size_t) *C.char {
@@ -132,8 +164,8 @@ func AttachLibVersionDelegateFunc(handler unsafe.Pointer, require *C.char) *C.ch
 		return nil
 	}
 
-	// block after core.V8JSLibVersionControlHeight, inclusive
-	if e.ctx.block.Height() >= core.V8JSLibVersionControlHeight {
+	// block after core.ForkV8JSLibVersionControl, inclusive
+	if core.CurrentChainConfig.IsActive(core.ForkV8JSLibVersionControl, e.ctx.block.Height()) {
 		if e.ctx.contract == nil {
 			logging.VLog().WithFields(logrus.Fields{
 				"libname": libname,
@@ -183,7 +215,7 @@ func AttachLibVersionDelegateFunc(handler unsafe.Pointer, require *C.char) *C.ch
 }
 
 func attachDefaultVersionLib(libname string) *C.char {
-	// block created before core.V8JSLibVersionControlHeight, default lib version: 1.0.0
+	// block created before core.ForkV8JSLibVersionControl, default lib version: 1.0.0
 	if !strings.HasPrefix(libname, JSLibRootName) {
 		if strings.HasPrefix(libname, "/") {
 			libname = "lib" + libname
@@ -0,0 +1,78 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package nvm
+
+/*
+#include "v8/lib/nvm_error.h"
+*/
+import "C"
+
+import (
+	"unsafe"
+
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+)
+
+// GetRandomFunc draw 32 random bytes for Blockchain.random, hex encoded. The
+// entropy comes from the VRF seed of the block, mixed with the tx hash, the
+// contract, the depth of the call and the count of numbers drawn before, so the
+// numbers are unpredictable before the block is proposed, the same on every
+// node and different for each draw.
+//export GetRandomFunc
+func GetRandomFunc(handler unsafe.Pointer, gasCnt *C.size_t, result **C.char, exceptionInfo **C.char) int {
+	*result = nil
+	*exceptionInfo = nil
+	engine, _ := getEngineByStorageHandler(uint64(uintptr(handler)))
+	if engine == nil || engine.ctx == nil || engine.ctx.block == nil || engine.ctx.tx == nil {
+		logging.VLog().Error("Unexpected error: failed to get engine.")
+		return C.NVM_UNEXPECTED_ERR
+	}
+	defer traceHostCall(engine, "Blockchain.random", gasCnt)
+	ctx := engine.ctx
+
+	// calculate Gas.
+	*gasCnt = C.size_t(GetRandomGasBase)
+
+	if ctx.block.Height() < core.BlockchainRandomHeight {
+		*exceptionInfo = C.CString("Blockchain.random(), not available at this height")
+		return C.NVM_EXCEPTION_ERR
+	}
+	if !ctx.block.RandomAvailable() {
+		*exceptionInfo = C.CString("Blockchain.random(), seed is not available in this block")
+		return C.NVM_EXCEPTION_ERR
+	}
+
+	r := randomBytes(ctx)
+	*result = C.CString(byteutils.Hex(r))
+	return C.NVM_SUCCESS
+}
+
+func randomBytes(ctx *Context) []byte {
+	ctx.randoms++
+	return hash.Sha3256(
+		[]byte(ctx.block.RandomSeed()),
+		ctx.tx.Hash(),
+		ctx.contract.Address(),
+		byteutils.FromUint64(uint64(ctx.depth)),
+		byteutils.FromUint64(ctx.randoms),
+	)
+}
@@ -0,0 +1,202 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package nvm
+
+import "C"
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+const base64VLQChars = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
+
+// sourceMap a source map v3 deployed with a contract, it maps the positions in
+// the deployed source back to the original sources of the developer. It only
+// serves the error messages and the traces, the execution never depends on it.
+type sourceMap struct {
+	sources []string
+	lines   [][]sourceMapping // the mappings of each generated line, by column.
+}
+
+type sourceMapping struct {
+	column       int
+	source       int
+	sourceLine   int
+	sourceColumn int
+}
+
+// parseSourceMap parse the json of a source map v3.
+func parseSourceMap(data string) (*sourceMap, error) {
+	raw := &struct {
+		Version  int      `json:"version"`
+		Sources  []string `json:"sources"`
+		Mappings string   `json:"mappings"`
+	}{}
+	if err := json.Unmarshal([]byte(data), raw); err != nil || raw.Version != 3 {
+		return nil, ErrInvalidSourceMap
+	}
+
+	sm := &sourceMap{sources: raw.Sources}
+	source, sourceLine, sourceColumn := 0, 0, 0
+	for _, group := range strings.Split(raw.Mappings, ";") {
+		mappings := []sourceMapping{}
+		column := 0
+		for _, segment := range strings.Split(group, ",") {
+			if len(segment) == 0 {
+				continue
+			}
+			fields, err := decodeVLQ(segment)
+			if err != nil {
+				return nil, err
+			}
+			column += fields[0]
+			if len(fields) == 1 {
+				// the generated code has no original source.
+				continue
+			}
+			if len(fields) < 4 {
+				return nil, ErrInvalidSourceMap
+			}
+			source += fields[1]
+			sourceLine += fields[2]
+			sourceColumn += fields[3]
+			if source < 0 || source >= len(sm.sources) {
+				return nil, ErrInvalidSourceMap
+			}
+			mappings = append(mappings, sourceMapping{
+				column:       column,
+				source:       source,
+				sourceLine:   sourceLine,
+				sourceColumn: sourceColumn,
+			})
+		}
+		sort.SliceStable(mappings, func(i, j int) bool { return mappings[i].column < mappings[j].column })
+		sm.lines = append(sm.lines, mappings)
+	}
+	return sm, nil
+}
+
+// decodeVLQ decode the base64 VLQ values of a mapping segment.
+func decodeVLQ(segment string) ([]int, error) {
+	values := []int{}
+	value, shift := 0, uint(0)
+	for i := 0; i < len(segment); i++ {
+		digit := strings.IndexByte(base64VLQChars, segment[i])
+		if digit < 0 || shift > 30 {
+			return nil, ErrInvalidSourceMap
+		}
+		value += (digit & 31) << shift
+		if digit&32 != 0 {
+			shift += 5
+			continue
+		}
+		if value&1 != 0 {
+			values = append(values, -(value >> 1))
+		} else {
+			values = append(values, value>>1)
+		}
+		value, shift = 0, 0
+	}
+	if shift != 0 {
+		return nil, ErrInvalidSourceMap
+	}
+	return values, nil
+}
+
+// Original return the original position "source:line:column" of the 1-based
+// line and column in the deployed source.
+func (sm *sourceMap) Original(line, column int) (string, bool) {
+	if sm == nil || line < 1 || line > len(sm.lines) {
+		return "", false
+	}
+	mappings := sm.lines[line-1]
+	i := sort.Search(len(mappings), func(i int) bool { return mappings[i].column > column-1 }) - 1
+	if i < 0 {
+		return "", false
+	}
+	m := mappings[i]
+	return fmt.Sprintf("%s:%d:%d", sm.sources[m.source], m.sourceLine+1, m.sourceColumn+1), true
+}
+
+// SetSourceMap set the source map deployed with the contract, an invalid one is
+// ignored since it never fails the execution.
+func (e *V8Engine) SetSourceMap(data string) {
+	e.sourceMap = nil
+	if len(data) == 0 {
+		return
+	}
+	sm, err := parseSourceMap(data)
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"err": err,
+		}).Debug("Ignore the source map of the contract.")
+		return
+	}
+	e.sourceMap = sm
+}
+
+// originalPosition return the original position of the line and column in the
+// deployed source, or "line:column" if it is not mapped. The instrumented
+// source keeps the lines of the deployed source, so are the mapped lines.
+func (e *V8Engine) originalPosition(line, column int) string {
+	if pos, ok := e.sourceMap.Original(line, column); ok {
+		return pos
+	}
+	return fmt.Sprintf("%d:%d", line, column)
+}
+
+// traceException map the position of the exception thrown by the contract back
+// to the original source. The result of a tx is kept in its events, so the
+// position is appended to the result of the simulations only.
+func (e *V8Engine) traceException(result string) string {
+	if e.sourceMap == nil || e.v8engine.exception_line <= 0 {
+		return result
+	}
+	if !strings.HasSuffix(C.GoString(&e.v8engine.exception_source[0]), contractModuleID) {
+		return result
+	}
+	pos, ok := e.sourceMap.Original(int(e.v8engine.exception_line), int(e.v8engine.exception_column))
+	if !ok {
+		return result
+	}
+	logging.VLog().WithFields(logrus.Fields{
+		"result":   result,
+		"position": pos,
+	}).Debug("Contract threw an exception.")
+
+	if tracer := executionTracer(e.ctx); tracer != nil {
+		tracer.RecordStep(&core.TraceStep{
+			Type:    core.TraceException,
+			Address: contractAddress(e.ctx),
+			Key:     pos,
+			Value:   result,
+		})
+	}
+	if e.ctx.block.ReadOnly() {
+		return fmt.Sprintf("%s (at %s)", result, pos)
+	}
+	return result
+}
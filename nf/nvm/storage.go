@@ -18,14 +18,21 @@
 
 package nvm
 
+/*
+#include "v8/lib/nvm_error.h"
+*/
 import "C"
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"regexp"
+	"strings"
 	"unsafe"
 
 	"github.com/nebulasio/go-nebulas/common/trie"
+	"github.com/nebulasio/go-nebulas/core"
 	"github.com/nebulasio/go-nebulas/util/logging"
 	"github.com/sirupsen/logrus"
 )
@@ -39,8 +46,14 @@ var (
 		};
 	*/
 	StorageKeyPattern = regexp.MustCompile("^@([a-zA-Z_$][a-zA-Z0-9_]+?)\\[(.*?)\\]$")
+	// StorageMapPrefixPattern the pattern of a prefix of the Map-ItemKeys of a Map
+	StorageMapPrefixPattern = regexp.MustCompile("^@([a-zA-Z_$][a-zA-Z0-9_]+?)\\[")
 	// DefaultDomainKey the default domain key
 	DefaultDomainKey = "_"
+	// StorageKeysDomain the domain of the index of the keys, no field is named like it
+	StorageKeysDomain = "#keys"
+	// StorageKeysCompleteDomain the domain of the mark of a contract whose keys are all indexed
+	StorageKeysCompleteDomain = "#keys-complete"
 	// ErrInvalidStorageKey invalid storage key error
 	ErrInvalidStorageKey = errors.New("invalid storage key")
 )
@@ -60,14 +73,102 @@ func parseStorageKey(key string) (string, string, error) {
 	return matches[0][1], matches[0][2], nil
 }
 
+// Since core.StorageKeysHeight every key put in the contract storage is indexed,
+// the index entry is stored at HashDomains(StorageKeysDomain, domainKey, itemKey)
+// with the key as its value, so the keys of a Map are listed under one prefix.
+func storageKeysIndexed(engine *V8Engine) bool {
+	return engine.ctx.block != nil && engine.ctx.block.Height() >= core.StorageKeysHeight
+}
+
+func storageKeyIndex(domainKey, itemKey string) []byte {
+	return trie.HashDomains(StorageKeysDomain, domainKey, itemKey)
+}
+
+// storageKeysComplete return true if every key in the contract storage is indexed.
+// The keys put before core.StorageKeysHeight are not, and they can't be indexed
+// later since only their hashes are stored, so a contract is marked complete by
+// its first put since the height if its storage is still empty.
+func storageKeysComplete(account Account) (bool, error) {
+	_, err := account.Get(trie.HashDomains(StorageKeysCompleteDomain))
+	if err == nil {
+		return true, nil
+	}
+	if err != ErrKeyNotFound {
+		return false, err
+	}
+	_, err = account.Iterator(nil)
+	if err == ErrKeyNotFound {
+		return true, nil
+	}
+	return false, err
+}
+
+// markStorageKeysComplete mark the contract complete if it has no key put before
+// core.StorageKeysHeight, it is called before a key is put.
+func markStorageKeysComplete(account Account) error {
+	if _, err := account.Get(trie.HashDomains(StorageKeysCompleteDomain)); err != ErrKeyNotFound {
+		return err
+	}
+	if _, err := account.Iterator(nil); err != ErrKeyNotFound {
+		return err
+	}
+	return account.Put(trie.HashDomains(StorageKeysCompleteDomain), []byte{1})
+}
+
+// storageKeysIndexPrefix return the narrowest prefix of the index entries of the
+// keys starting with prefix. Only the ItemKeys are in the default domain.
+func storageKeysIndexPrefix(prefix string) []byte {
+	if matches := StorageMapPrefixPattern.FindStringSubmatch(prefix); matches != nil {
+		return trie.HashDomainsPrefix(StorageKeysDomain, matches[1])
+	}
+	if len(prefix) > 0 && !strings.HasPrefix(prefix, "@") {
+		return trie.HashDomainsPrefix(StorageKeysDomain, DefaultDomainKey)
+	}
+	return trie.HashDomainsPrefix(StorageKeysDomain)
+}
+
+// storageKeys list the keys starting with prefix in the contract storage, in the
+// order of their index entries, which is the same on every node. The first offset
+// keys are skipped and at most limit keys are returned, scanned counts the index
+// entries read.
+func storageKeys(account Account, prefix string, offset, limit int) ([]string, int, error) {
+	keys := []string{}
+	iter, err := account.Iterator(storageKeysIndexPrefix(prefix))
+	if err == ErrKeyNotFound {
+		return keys, 0, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	scanned, skipped := 0, 0
+	exist, err := iter.Next()
+	for exist && len(keys) < limit {
+		scanned++
+		if key := string(iter.Value()); strings.HasPrefix(key, prefix) {
+			if skipped < offset {
+				skipped++
+			} else {
+				keys = append(keys, key)
+			}
+		}
+		exist, err = iter.Next()
+	}
+	if err != nil {
+		return nil, scanned, err
+	}
+	return keys, scanned, nil
+}
+
 // StorageGetFunc export StorageGetFunc
 //export StorageGetFunc
 func StorageGetFunc(handler unsafe.Pointer, key *C.char, gasCnt *C.size_t) *C.char {
-	_, storage := getEngineByStorageHandler(uint64(uintptr(handler)))
+	engine, storage := getEngineByStorageHandler(uint64(uintptr(handler)))
 	if storage == nil {
 		logging.VLog().Error("Failed to get storage handler.")
 		return nil
 	}
+	defer traceHostCall(engine, "Storage.get", gasCnt)
 
 	k := C.GoString(key)
 
@@ -102,17 +203,21 @@ func StorageGetFunc(handler unsafe.Pointer, key *C.char, gasCnt *C.size_t) *C.ch
 // StoragePutFunc export StoragePutFunc
 //export StoragePutFunc
 func StoragePutFunc(handler unsafe.Pointer, key *C.char, value *C.char, gasCnt *C.size_t) int {
-	_, storage := getEngineByStorageHandler(uint64(uintptr(handler)))
+	engine, storage := getEngineByStorageHandler(uint64(uintptr(handler)))
 	if storage == nil {
 		logging.VLog().Error("Failed to get storage handler.")
 		return 1
 	}
+	defer traceHostCall(engine, "Storage.put", gasCnt)
+	if engine.ctx.denyStateChange() {
+		return C.NVM_STATIC_CALL_ERR
+	}
 
 	k := C.GoString(key)
 	v := []byte(C.GoString(value))
 
 	// calculate Gas.
-	*gasCnt = C.size_t(len(k) + len(v))
+	*gasCnt = C.size_t(storagePutGas(engine.ctx, k, v))
 
 	domainKey, itemKey, err := parseStorageKey(k)
 	if err != nil {
@@ -124,6 +229,17 @@ func StoragePutFunc(handler unsafe.Pointer, key *C.char, value *C.char, gasCnt *
 		return 1
 	}
 
+	if storageKeysIndexed(engine) {
+		if err := markStorageKeysComplete(storage); err != nil {
+			logging.VLog().WithFields(logrus.Fields{
+				"handler": uint64(uintptr(handler)),
+				"key":     k,
+				"err":     err,
+			}).Debug("StoragePutFunc mark keys complete failed.")
+			return 1
+		}
+	}
+
 	err = storage.Put(trie.HashDomains(domainKey, itemKey), v)
 	if err != nil && err != ErrKeyNotFound {
 		logging.VLog().WithFields(logrus.Fields{
@@ -134,17 +250,32 @@ func StoragePutFunc(handler unsafe.Pointer, key *C.char, value *C.char, gasCnt *
 		return 1
 	}
 
+	if storageKeysIndexed(engine) {
+		if err := storage.Put(storageKeyIndex(domainKey, itemKey), []byte(k)); err != nil {
+			logging.VLog().WithFields(logrus.Fields{
+				"handler": uint64(uintptr(handler)),
+				"key":     k,
+				"err":     err,
+			}).Debug("StoragePutFunc index key failed.")
+			return 1
+		}
+	}
+
 	return 0
 }
 
 // StorageDelFunc export StorageDelFunc
 //export StorageDelFunc
 func StorageDelFunc(handler unsafe.Pointer, key *C.char, gasCnt *C.size_t) int {
-	_, storage := getEngineByStorageHandler(uint64(uintptr(handler)))
+	engine, storage := getEngineByStorageHandler(uint64(uintptr(handler)))
 	if storage == nil {
 		logging.VLog().Error("Failed to get storage handler.")
 		return 1
 	}
+	defer traceHostCall(engine, "Storage.del", gasCnt)
+	if engine.ctx.denyStateChange() {
+		return C.NVM_STATIC_CALL_ERR
+	}
 
 	k := C.GoString(key)
 
@@ -171,5 +302,74 @@ func StorageDelFunc(handler unsafe.Pointer, key *C.char, gasCnt *C.size_t) int {
 		return 1
 	}
 
+	if storageKeysIndexed(engine) {
+		err = storage.Del(storageKeyIndex(domainKey, itemKey))
+		if err != nil && err != ErrKeyNotFound {
+			logging.VLog().WithFields(logrus.Fields{
+				"handler": uint64(uintptr(handler)),
+				"key":     k,
+				"err":     err,
+			}).Debug("StorageDelFunc del key index failed.")
+			return 1
+		}
+	}
+
 	return 0
 }
+
+// StorageKeysFunc export StorageKeysFunc, list the keys starting with prefix in
+// the contract storage. Every index entry read costs StorageKeysGasPerItem.
+//export StorageKeysFunc
+func StorageKeysFunc(handler unsafe.Pointer, prefix *C.char, offset C.int, limit C.int,
+	gasCnt *C.size_t, result **C.char, exceptionInfo **C.char) int {
+	*result = nil
+	*exceptionInfo = nil
+	engine, storage := getEngineByStorageHandler(uint64(uintptr(handler)))
+	if storage == nil || engine.ctx.block == nil {
+		logging.VLog().Error("Failed to get storage handler.")
+		return C.NVM_UNEXPECTED_ERR
+	}
+	defer traceHostCall(engine, "Storage.keys", gasCnt)
+
+	// calculate Gas.
+	*gasCnt = C.size_t(StorageKeysGasBase)
+
+	if !storageKeysIndexed(engine) {
+		*exceptionInfo = C.CString("Storage.keys(), not available at this height")
+		return C.NVM_EXCEPTION_ERR
+	}
+	complete, err := storageKeysComplete(storage)
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"handler": uint64(uintptr(handler)),
+			"err":     err,
+		}).Error("StorageKeysFunc check keys complete failed.")
+		return C.NVM_UNEXPECTED_ERR
+	}
+	if !complete {
+		*exceptionInfo = C.CString("Storage.keys(), not available to the contract holding keys put before it is available")
+		return C.NVM_EXCEPTION_ERR
+	}
+	if offset < 0 || limit <= 0 || limit > MaxStorageKeysLimit {
+		*exceptionInfo = C.CString(fmt.Sprintf("Storage.keys(), offset should not be negative and limit should be in [1, %d]", MaxStorageKeysLimit))
+		return C.NVM_EXCEPTION_ERR
+	}
+
+	p := C.GoString(prefix)
+	keys, scanned, err := storageKeys(storage, p, int(offset), int(limit))
+	*gasCnt += C.size_t(scanned * StorageKeysGasPerItem)
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"handler": uint64(uintptr(handler)),
+			"prefix":  p,
+			"err":     err,
+		}).Error("StorageKeysFunc list keys failed.")
+		return C.NVM_UNEXPECTED_ERR
+	}
+	data, err := json.Marshal(keys)
+	if err != nil {
+		return C.NVM_UNEXPECTED_ERR
+	}
+	*result = C.CString(string(data))
+	return C.NVM_SUCCESS
+}
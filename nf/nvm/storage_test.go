@@ -19,8 +19,13 @@
 package nvm
 
 import (
+	"sort"
 	"testing"
 
+	"github.com/nebulasio/go-nebulas/common/trie"
+	"github.com/nebulasio/go-nebulas/consensus/dpos"
+	"github.com/nebulasio/go-nebulas/core/state"
+	"github.com/nebulasio/go-nebulas/storage"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -74,3 +79,79 @@ func TestStorageKeyPattern(t *testing.T) {
 		})
 	}
 }
+
+func TestStorageKeys(t *testing.T) {
+	mem, _ := storage.NewMemoryStorage()
+	context, _ := state.NewWorldState(dpos.NewDpos(), mem)
+	contract, err := context.CreateContractAccount([]byte("account2"), nil, nil)
+	assert.Nil(t, err)
+
+	keys, scanned, err := storageKeys(contract, "", 0, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(keys))
+	assert.Equal(t, 0, scanned)
+
+	for _, key := range []string{"total", "owner", "@balances[a]", "@balances[b]", "@balances[c]", "@allowed[a]"} {
+		domainKey, itemKey, err := parseStorageKey(key)
+		assert.Nil(t, err)
+		assert.Nil(t, contract.Put(trie.HashDomains(domainKey, itemKey), []byte("value")))
+		assert.Nil(t, contract.Put(storageKeyIndex(domainKey, itemKey), []byte(key)))
+	}
+
+	keys, scanned, err = storageKeys(contract, "", 0, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, 6, len(keys))
+	assert.Equal(t, 6, scanned)
+
+	balances, scanned, err := storageKeys(contract, "@balances[", 0, 10)
+	assert.Nil(t, err)
+	sorted := append([]string{}, balances...)
+	sort.Strings(sorted)
+	assert.Equal(t, []string{"@balances[a]", "@balances[b]", "@balances[c]"}, sorted)
+	assert.Equal(t, 3, scanned)
+
+	page, _, err := storageKeys(contract, "@balances[", 1, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, balances[1:2], page)
+
+	keys, scanned, err = storageKeys(contract, "to", 0, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"total"}, keys)
+	assert.Equal(t, 2, scanned)
+}
+
+func TestStorageKeysComplete(t *testing.T) {
+	mem, _ := storage.NewMemoryStorage()
+	context, _ := state.NewWorldState(dpos.NewDpos(), mem)
+
+	// a contract put keys before the index is not complete.
+	legacy, err := context.CreateContractAccount([]byte("account1"), nil, nil)
+	assert.Nil(t, err)
+	complete, err := storageKeysComplete(legacy)
+	assert.Nil(t, err)
+	assert.True(t, complete)
+	assert.Nil(t, legacy.Put(trie.HashDomains(DefaultDomainKey, "total"), []byte("value")))
+	assert.Nil(t, markStorageKeysComplete(legacy))
+	complete, err = storageKeysComplete(legacy)
+	assert.Nil(t, err)
+	assert.False(t, complete)
+
+	// a contract marked before its first put stays complete.
+	contract, err := context.CreateContractAccount([]byte("account2"), nil, nil)
+	assert.Nil(t, err)
+	for _, key := range []string{"total", "@balances[a]"} {
+		domainKey, itemKey, err := parseStorageKey(key)
+		assert.Nil(t, err)
+		assert.Nil(t, markStorageKeysComplete(contract))
+		assert.Nil(t, contract.Put(trie.HashDomains(domainKey, itemKey), []byte("value")))
+		assert.Nil(t, contract.Put(storageKeyIndex(domainKey, itemKey), []byte(key)))
+	}
+	complete, err = storageKeysComplete(contract)
+	assert.Nil(t, err)
+	assert.True(t, complete)
+
+	// the mark is not listed as a key.
+	keys, _, err := storageKeys(contract, "", 0, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(keys))
+}
@@ -0,0 +1,33 @@
+'use strict';
+
+var CalleeContract = function () {
+    LocalContractStorage.defineProperty(this, "value");
+};
+
+CalleeContract.prototype = {
+    init: function () {
+        this.value = "";
+    },
+
+    set: function (value) {
+        this.value = value;
+        return {
+            value: value,
+            from: Blockchain.transaction.from
+        };
+    },
+
+    get: function () {
+        return this.value;
+    },
+
+    fail: function () {
+        this.value = "failed";
+        throw new Error("callee failed.");
+    },
+
+    accept: function () {
+    }
+};
+
+module.exports = CalleeContract;
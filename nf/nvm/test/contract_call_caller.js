@@ -0,0 +1,34 @@
+'use strict';
+
+var CallerContract = function () {
+};
+
+CallerContract.prototype = {
+    init: function () {
+    },
+
+    proxy: function (callee, value) {
+        var result = Blockchain.call(callee, "set", [value]);
+        if (result.from !== Blockchain.transaction.to) {
+            throw new Error("unexpected caller " + result.from);
+        }
+        return result.value;
+    },
+
+    proxyCatch: function (callee) {
+        try {
+            Blockchain.call(callee, "fail", []);
+        } catch (e) {
+            return "caught";
+        }
+    },
+
+    recurse: function (self, n) {
+        if (n <= 0) {
+            return 0;
+        }
+        return Blockchain.call(self, "recurse", [self, n - 1]) + 1;
+    }
+};
+
+module.exports = CallerContract;
@@ -0,0 +1,37 @@
+'use strict';
+
+var TransferContract = function () {
+    LocalContractStorage.defineProperty(this, "received");
+    LocalContractStorage.defineProperty(this, "reenter");
+};
+
+TransferContract.prototype = {
+    init: function () {
+        this.received = "0";
+        this.reenter = false;
+    },
+
+    setReenter: function (reenter) {
+        this.reenter = reenter;
+    },
+
+    pay: function (to, value) {
+        if (!Blockchain.transfer(to, value)) {
+            throw new Error("transfer failed.");
+        }
+    },
+
+    getReceived: function () {
+        return this.received;
+    },
+
+    accept: function () {
+        this.received = new BigNumber(this.received).plus(Blockchain.transaction.value).toString(10);
+        if (this.reenter) {
+            // the payer is locked until accept returns.
+            Blockchain.call(Blockchain.transaction.from, "pay", [Blockchain.transaction.to, "1"]);
+        }
+    }
+};
+
+module.exports = TransferContract;
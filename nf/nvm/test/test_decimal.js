@@ -0,0 +1,68 @@
+// Copyright (C) 2018 go-nebulas authors
+// 
+// This file is part of the go-nebulas library.
+// 
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+// 
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+// 
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+// 
+
+
+'use strict';
+
+function eq(a, b) {
+    if (a !== b) {
+        throw new Error("Not equal: " + a + " <--> " + b);
+    }
+}
+
+function throws(f, message) {
+    try {
+        f();
+    } catch (err) {
+        if (err.message !== message) {
+            throw err;
+        }
+        return;
+    }
+    throw new Error("should throw: " + message);
+}
+
+var Decimal = require('decimal.js');
+
+eq(Decimal.VERSION, "1.1.0");
+eq(new Decimal("0.1").plus("0.2").toString(), "0.3");
+eq(new Decimal("1.50").toString(), "1.5");
+eq(new Decimal(-3).minus("0.25").toString(), "-3.25");
+eq(new Decimal("2.5").times("0.3").toString(), "0.75");
+
+// rounded toward zero at 18 digits.
+eq(new Decimal(1).div(3).toString(), "0.333333333333333333");
+eq(new Decimal(-2).div(3).toString(), "-0.666666666666666666");
+eq(new Decimal("0.000000000000000001").times("0.5").toString(), "0");
+eq(new Decimal("1.1").pow(2).toString(), "1.21");
+eq(new Decimal("7.5").mod(2).toString(), "1.5");
+eq(new Decimal("-7.5").mod(2).toString(), "-1.5");
+eq(new Decimal("1").pow(0).toString(), "1");
+
+eq(new Decimal("1.0").eq(1), true);
+eq(new Decimal("-0.5").lt(0), true);
+eq(new Decimal("2").cmp("10"), -1);
+eq(new Decimal("0.00").isZero(), true);
+eq(JSON.stringify({v: new Decimal("12.340")}), '{"v":"12.34"}');
+
+throws(function() { new Decimal(0.1); }, "[Decimal Error] number should be a safe integer, use a string for fractions");
+throws(function() { new Decimal("1e3"); }, "[Decimal Error] invalid number");
+throws(function() { new Decimal("0.0000000000000000001"); }, "[Decimal Error] add overflow or invalid operand");
+throws(function() { new Decimal(1).div("0.0"); }, "[Decimal Error] division by zero");
+throws(function() { new Decimal(10).pow(97); }, "[Decimal Error] pow overflow or invalid operand");
+throws(function() { new Decimal(2).pow(0.5); }, "[Decimal Error] exponent should be a non-negative integer");
@@ -0,0 +1,137 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package nvm
+
+import "C"
+
+import (
+	"strconv"
+	"unsafe"
+
+	"github.com/nebulasio/go-nebulas/core"
+)
+
+// statementPosition the line and column of a statement in the deployed source.
+type statementPosition struct {
+	line   int
+	column int
+}
+
+// instructionsProfile sums the instructions executed by each statement of a
+// traced execution, in the order the statements are first reached.
+type instructionsProfile struct {
+	positions []statementPosition
+	functions map[statementPosition]string
+	counts    map[statementPosition]uint64
+}
+
+func newInstructionsProfile() *instructionsProfile {
+	return &instructionsProfile{
+		positions: make([]statementPosition, 0),
+		functions: make(map[statementPosition]string),
+		counts:    make(map[statementPosition]uint64),
+	}
+}
+
+func (p *instructionsProfile) add(function string, line, column int, count uint64) {
+	pos := statementPosition{line: line, column: column}
+	if _, ok := p.counts[pos]; !ok {
+		p.positions = append(p.positions, pos)
+		p.functions[pos] = function
+	}
+	p.counts[pos] += count
+}
+
+// executionTracer return the tracer of the world state, nil if the execution is not traced.
+func executionTracer(ctx *Context) core.ExecutionTracer {
+	if ctx == nil {
+		return nil
+	}
+	if tracer, ok := ctx.state.(core.ExecutionTracer); ok {
+		return tracer
+	}
+	return nil
+}
+
+func contractAddress(ctx *Context) string {
+	if addr, err := core.AddressParseFromBytes(ctx.contract.Address()); err == nil {
+		return addr.String()
+	}
+	return ctx.contract.Address().Hex()
+}
+
+// startProfile report the instructions executed by each statement to the engine
+// while the execution is traced.
+func (e *V8Engine) startProfile() {
+	if executionTracer(e.ctx) == nil {
+		return
+	}
+	e.profile = newInstructionsProfile()
+	e.v8engine.tracing = 1
+	e.v8engine.traced_instructions = e.v8engine.stats.count_of_executed_instructions
+}
+
+// stopProfile record the instructions executed by each statement in the trace.
+func (e *V8Engine) stopProfile() {
+	if e.profile == nil {
+		return
+	}
+	e.v8engine.tracing = 0
+	tracer := executionTracer(e.ctx)
+	addr := contractAddress(e.ctx)
+	for _, pos := range e.profile.positions {
+		tracer.RecordStep(&core.TraceStep{
+			Type:     core.TraceInstructions,
+			Address:  addr,
+			Key:      e.originalPosition(pos.line, pos.column),
+			Function: e.profile.functions[pos],
+			Value:    strconv.FormatUint(e.profile.counts[pos], 10),
+		})
+	}
+	e.profile = nil
+}
+
+// traceHostCall record the host function called by the contract and the gas it
+// cost, if the execution is traced.
+func traceHostCall(e *V8Engine, function string, gasCnt *C.size_t) {
+	if e == nil {
+		return
+	}
+	tracer := executionTracer(e.ctx)
+	if tracer == nil {
+		return
+	}
+	tracer.RecordStep(&core.TraceStep{
+		Type:     core.TraceHostCall,
+		Address:  contractAddress(e.ctx),
+		Function: function,
+		Value:    strconv.FormatUint(uint64(*gasCnt), 10),
+	})
+}
+
+// TraceInstructionsFunc add the instructions executed to the statement at the
+// line and column of the contract.
+//export TraceInstructionsFunc
+func TraceInstructionsFunc(handler unsafe.Pointer, function *C.char, line, column C.int, count C.size_t) {
+	e := getEngineByEngineHandler(handler)
+	if e == nil || e.profile == nil {
+		return
+	}
+	e.profile.add(C.GoString(function), int(line), int(column), uint64(count))
+}
@@ -28,11 +28,22 @@ var (
 	ErrLimitHasEmpty                   = errors.New("limit args has empty")
 	ErrSetMemorySmall                  = errors.New("set memory small than v8 limit")
 	ErrDisallowCallNotStandardFunction = errors.New("disallow call not standard function")
+	ErrExceedMaxContractCallDepth      = errors.New("exceed max contract call depth")
+	ErrStateChangeInStaticCall         = errors.New("state change is not allowed in static call")
+	ErrTransferFromContractFailed      = errors.New("transfer from contract failed")
+	ErrReentrantCall                   = errors.New("reentrant call to a contract in transfer")
+	ErrInvalidSourceMap                = errors.New("invalid source map")
+	ErrInvalidDecimal                  = errors.New("invalid decimal")
+	ErrDecimalOverflow                 = errors.New("decimal overflow")
+	ErrDecimalDivisionByZero           = errors.New("decimal division by zero")
+	ErrContractExecutionDenied         = errors.New("contract execution denied by the node policy")
+	ErrInvalidContractSource           = errors.New("invalid contract source")
+	ErrValidateContractFailed          = errors.New("validate contract failed")
 )
 
 //define
 var (
-	EventNameSpaceContract = "chain.contract" //ToRefine: move to core
+	EventNameSpaceContract = core.TopicContractEvent
 )
 
 //common err
@@ -51,6 +62,8 @@ const (
 	TransferAddBalance
 	TransferRecordEventFailed
 	TransferAddressFailed
+	TransferReentrantCall
+	TransferToContractFailed
 )
 
 //the max recent block number can query
@@ -62,22 +75,46 @@ const (
 // define gas consume
 const (
 	// crypto
-	CryptoSha256GasBase         = 20000
-	CryptoSha3256GasBase        = 20000
-	CryptoRipemd160GasBase      = 20000
-	CryptoRecoverAddressGasBase = 100000
-	CryptoMd5GasBase            = 6000
-	CryptoBase64GasBase         = 3000
+	CryptoSha256GasBase          = 20000
+	CryptoSha3256GasBase         = 20000
+	CryptoRipemd160GasBase       = 20000
+	CryptoRecoverAddressGasBase  = 100000
+	CryptoMd5GasBase             = 6000
+	CryptoBase64GasBase          = 3000
+	CryptoVerifySignatureGasBase = 100000
 
 	//In blockChain
-	GetTxByHashGasBase     = 1000
-	GetAccountStateGasBase = 2000
-	TransferGasBase        = 2000
-	VerifyAddressGasBase   = 100
-	GetPreBlockHashGasBase = 2000
-	GetPreBlockSeedGasBase = 2000
+	GetTxByHashGasBase      = 1000
+	GetAccountStateGasBase  = 2000
+	TransferGasBase         = 2000
+	VerifyAddressGasBase    = 100
+	GetPreBlockHashGasBase  = 2000
+	GetPreBlockSeedGasBase  = 2000
+	ContractCallGasBase     = 5000
+	GetRandomGasBase        = 2000
+	GetBlockByHeightGasBase = 3000
+
+	// In storage
+	StorageKeysGasBase    = 1000
+	StorageKeysGasPerItem = 100
+
+	// decimal
+	DecimalGasBase = 100
+
+	// per byte of the data processed, since core.SizedGasHeight
+	JSONGasPerByte       = 1
+	StringGasPerByte     = 1
+	CryptoHashGasPerByte = 3
+	StoragePutGasBase    = 100
+	StoragePutGasPerByte = 4
 )
 
+// MaxStorageKeysLimit the max count of keys listed by Storage.keys at a time.
+const MaxStorageKeysLimit = 1000
+
+// MaxContractCallDepth the max depth of contracts calling contracts by Blockchain.call.
+const MaxContractCallDepth = 3
+
 // Block interface breaks cycle import dependency and hides unused services.
 type Block interface {
 	Hash() byteutils.Hash
@@ -86,6 +123,7 @@ type Block interface {
 	RandomSeed() string
 	RandomAvailable() bool
 	DateAvailable() bool
+	ReadOnly() bool // the block of the simulations, never on chain.
 }
 
 // Transaction interface breaks cycle import dependency and hides unused services.
@@ -110,13 +148,16 @@ type Account interface {
 	Put(key []byte, value []byte) error
 	Get(key []byte) ([]byte, error)
 	Del(key []byte) error
+	Iterator(prefix []byte) (state.Iterator, error)
 	ContractMeta() *corepb.ContractMeta
 }
 
 // WorldState interface breaks cycle import dependency and hides unused services.
 type WorldState interface {
 	GetOrCreateUserAccount(addr byteutils.Hash) (state.Account, error)
+	GetContractAccount(addr byteutils.Hash) (state.Account, error)
 	GetTx(txHash byteutils.Hash) ([]byte, error)
+	FetchEvents(byteutils.Hash) ([]*state.Event, error)
 	RecordEvent(txHash byteutils.Hash, event *state.Event)
 	GetBlockHashByHeight(height uint64) ([]byte, error)
 	GetBlock(txHash byteutils.Hash) ([]byte, error)
@@ -24,6 +24,7 @@
 #include "lib/global.h"
 #include "lib/instruction_counter.h"
 #include "lib/logger.h"
+#include "lib/snapshot.h"
 #include "lib/tracing.h"
 #include "lib/typescript.h"
 #include "v8_data_inc.h"
@@ -46,6 +47,9 @@ void PrintAndReturnException(char **exception, Local<Context> context,
                              TryCatch &trycatch);
 void EngineLimitsCheckDelegate(Isolate *isolate, size_t count,
                                void *listenerContext);
+void TraceInstructions(Isolate *isolate, V8Engine *e, size_t count);
+
+static TraceInstructionsFunc sTraceInstructions = NULL;
 
 #define ExecuteTimeOut  5*1000*1000
 #define STRINGIZE2(s) #s
@@ -79,7 +83,12 @@ void Initialize() {
   SetInstructionCounterIncrListener(EngineLimitsCheckDelegate);
 }
 
+void InitializeTracer(TraceInstructionsFunc trace) {
+  sTraceInstructions = trace;
+}
+
 void Dispose() {
+  DisposeLibSnapshot();
   V8::Dispose();
   V8::ShutdownPlatform();
   if (platformPtr) {
@@ -93,6 +102,7 @@ V8Engine *CreateEngine() {
 
   Isolate::CreateParams create_params;
   create_params.array_buffer_allocator = allocator;
+  SetLibSnapshotParams(&create_params);
 
   Isolate *isolate = Isolate::New(create_params);
 
@@ -115,6 +125,36 @@ void DeleteEngine(V8Engine *e) {
   free(e);
 }
 
+// ResetEngine clear the limits and the stats of the engine to run another
+// execution on its isolate, the garbage of the previous one is collected.
+void ResetEngine(V8Engine *e) {
+  Isolate *isolate = static_cast<Isolate *>(e->isolate);
+  {
+    Locker locker(isolate);
+    Isolate::Scope isolate_scope(isolate);
+    isolate->LowMemoryNotification();
+  }
+  static_cast<ArrayBufferAllocator *>(e->allocator)
+      ->reset_peak_allocated_size();
+
+  e->limits_of_executed_instructions = 0;
+  e->limits_of_total_memory_size = 0;
+  e->is_requested_terminate_execution = false;
+  e->is_unexpected_error_happen = false;
+  e->testing = 0;
+  e->timeout = ExecuteTimeOut;
+  e->tracing = 0;
+  e->traced_instructions = 0;
+  e->exception_line = 0;
+  e->exception_column = 0;
+  e->lib_context = 0;
+  e->lcs_handler = 0;
+  e->gcs_handler = 0;
+  memset(e->exception_source, 0, sizeof(e->exception_source));
+  memset(&(e->gas_schedule), 0, sizeof(V8GasSchedule));
+  memset(&(e->stats), 0, sizeof(V8EngineStats));
+}
+
 int ExecuteSourceDataDelegate(char **result, Isolate *isolate,
                               const char *source, int source_line_offset,
                               Local<Context> context, TryCatch &trycatch,
@@ -178,10 +218,12 @@ char *TranspileTypeScriptModule(V8Engine *e, const char *source,
   TypeScriptContext tContext;
   tContext.source_line_offset = 0;
   tContext.js_source = NULL;
+  tContext.error = NULL;
 
   Execute(NULL, e, source, 0, 0L, 0L, TypeScriptTranspileDelegate,
           (void *)&tContext);
 
+  free(tContext.error);
   *source_line_offset = tContext.source_line_offset;
   return static_cast<char *>(tContext.js_source);
 }
@@ -204,11 +246,19 @@ int Execute(char **result, V8Engine *e, const char *source,
   // Create a stack-allocated handle scope.
   HandleScope handle_scope(isolate);
 
-  // Create global object template.
-  Local<ObjectTemplate> globalTpl = CreateGlobalObjectTemplate(isolate);
-
-  // Create a new context.
-  Local<Context> context = Context::New(isolate, NULL, globalTpl);
+  // Create a new context, or restore the lib env from the lib snapshot.
+  Local<Context> context;
+  if (e->lib_context > 0) {
+    if (!NewLibSnapshotContext(isolate, e->lib_context - 1).ToLocal(&context)) {
+      LogErrorf("Failed to restore the lib env %d from the lib snapshot.",
+                e->lib_context - 1);
+      return NVM_UNEXPECTED_ERR;
+    }
+  } else {
+    // Create global object template.
+    Local<ObjectTemplate> globalTpl = CreateGlobalObjectTemplate(isolate);
+    context = Context::New(isolate, NULL, globalTpl);
+  }
 
   // disable eval().
   context->AllowCodeGenerationFromStrings(false);
@@ -221,8 +271,8 @@ int Execute(char **result, V8Engine *e, const char *source,
   // Continue put objects to global object.
   SetGlobalObjectProperties(isolate, context, e, lcsHandler, gcsHandler);
 
-  // Setup execution env.
-  if (SetupExecutionEnv(isolate, context)) {
+  // Setup execution env, the restored one is set up already.
+  if (e->lib_context == 0 && SetupExecutionEnv(isolate, context)) {
     PrintAndReturnException(result, context, trycatch);
     return NVM_EXCEPTION_ERR;
   }
@@ -280,6 +330,16 @@ void PrintAndReturnException(char **exception, Local<Context> context,
 
     asprintf(&source_info, "%s:%d\n%s\n%s\n", *filename, linenum, *sourceline,
              arrow);
+
+    // keep the position of the exception returned, the engine maps it back
+    // to the original source of the contract.
+    V8Engine *e = GetV8EngineInstance(context);
+    if (exception != NULL && e != NULL) {
+      e->exception_line = linenum;
+      e->exception_column = message->GetStartColumn(context).FromMaybe(0) + 1;
+      strncpy(e->exception_source, *filename ? *filename : "",
+              sizeof(e->exception_source) - 1);
+    }
   }
 
   if (source_info == NULL) {
@@ -355,11 +415,37 @@ void EngineLimitsCheckDelegate(Isolate *isolate, size_t count,
                                void *listenerContext) {
   V8Engine *e = static_cast<V8Engine *>(listenerContext);
 
+  if (e->tracing && sTraceInstructions != NULL) {
+    TraceInstructions(isolate, e, count);
+  }
+
   if (IsEngineLimitsExceeded(e)) {
     TerminateExecution(e);
   }
 }
 
+// TraceInstructions report the instructions executed since the last report to
+// the statement running on the top frame.
+void TraceInstructions(Isolate *isolate, V8Engine *e, size_t count) {
+  size_t delta = count - e->traced_instructions;
+  e->traced_instructions = count;
+  if (delta == 0) {
+    return;
+  }
+
+  HandleScope handle_scope(isolate);
+  Local<StackTrace> stackTrace = StackTrace::CurrentStackTrace(isolate, 1);
+  if (stackTrace->GetFrameCount() == 0) {
+    sTraceInstructions(e, "", 0, 0, delta);
+    return;
+  }
+
+  Local<StackFrame> frame = stackTrace->GetFrame(0);
+  String::Utf8Value function(frame->GetFunctionName());
+  sTraceInstructions(e, *function ? *function : "", frame->GetLineNumber(),
+                     frame->GetColumn(), delta);
+}
+
 int IsEngineLimitsExceeded(V8Engine *e) {
   // TODO: read memory stats everytime may impact the performance.
   ReadMemoryStatistics(e);
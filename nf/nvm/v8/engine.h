@@ -48,6 +48,7 @@ enum OptType {
   INSTRUCTION     = 1,
   INSTRUCTIONTS  = 2,
   RUNSCRIPT       = 3,
+  VALIDATE        = 4,
 };
 
 // log
@@ -56,10 +57,15 @@ EXPORT const char *GetLogLevelText(int level);
 EXPORT void InitializeLogger(LogFunc f);
 
 // event.
-typedef void (*EventTriggerFunc)(void *handler, const char *topic,
-                                 const char *data, size_t *counterVal);
+typedef int (*EventTriggerFunc)(void *handler, const char *topic,
+                                const char *data, size_t *counterVal);
 EXPORT void InitializeEvent(EventTriggerFunc trigger);
 
+// tracer
+typedef void (*TraceInstructionsFunc)(void *handler, const char *function,
+                                      int line, int column, size_t count);
+EXPORT void InitializeTracer(TraceInstructionsFunc trace);
+
 // storage
 typedef char *(*StorageGetFunc)(void *handler, const char *key,
                                 size_t *counterVal);
@@ -67,8 +73,11 @@ typedef int (*StoragePutFunc)(void *handler, const char *key, const char *value,
                               size_t *counterVal);
 typedef int (*StorageDelFunc)(void *handler, const char *key,
                               size_t *counterVal);
+typedef int (*StorageKeysFunc)(void *handler, const char *prefix, int offset,
+                               int limit, size_t *counterVal, char **result,
+                               char **info);
 EXPORT void InitializeStorage(StorageGetFunc get, StoragePutFunc put,
-                              StorageDelFunc del);
+                              StorageDelFunc del, StorageKeysFunc keys);
 
 // blockchain
 typedef char *(*GetTxByHashFunc)(void *handler, const char *hash,
@@ -83,6 +92,13 @@ typedef int (*GetPreBlockHashFunc)(void *handler, unsigned long long offset, siz
 
 typedef int (*GetPreBlockSeedFunc)(void *handler, unsigned long long offset, size_t *counterVal, char **result, char **info);
 
+typedef int (*RunContractFunc)(void *handler, const char *address, const char *funcName, const char *args,
+                               const char *value, size_t *counterVal, char **result, char **info);
+
+typedef int (*GetRandomFunc)(void *handler, size_t *counterVal, char **result, char **info);
+
+typedef int (*GetBlockByHeightFunc)(void *handler, unsigned long long height, size_t *counterVal, char **result, char **info);
+
 
 
 EXPORT void InitializeBlockchain(GetTxByHashFunc getTx,
@@ -90,7 +106,10 @@ EXPORT void InitializeBlockchain(GetTxByHashFunc getTx,
                                  TransferFunc transfer,
                                  VerifyAddressFunc verifyAddress,
                                  GetPreBlockHashFunc getPreBlockHash,
-                                 GetPreBlockSeedFunc getPreBlockSeed);
+                                 GetPreBlockSeedFunc getPreBlockSeed,
+                                 RunContractFunc runContract,
+                                 GetRandomFunc getRandom,
+                                 GetBlockByHeightFunc getBlockByHeight);
 
 // crypto
 typedef char *(*Sha256Func)(const char *data, size_t *counterVal);
@@ -100,13 +119,21 @@ typedef char *(*RecoverAddressFunc)(int alg, const char *data, const char *sign,
                                  size_t *counterVal);
 typedef char *(*Md5Func)(const char *data, size_t *counterVal);
 typedef char *(*Base64Func)(const char *data, size_t *counterVal);
+typedef int (*VerifySignatureFunc)(int alg, const char *data, const char *sign,
+                                   const char *address, size_t *counterVal);
 
 EXPORT void InitializeCrypto(Sha256Func sha256,
                                  Sha3256Func sha3256,
                                  Ripemd160Func ripemd160,
                                  RecoverAddressFunc recoverAddress,
                                  Md5Func md5,
-                                 Base64Func base64);
+                                 Base64Func base64,
+                                 VerifySignatureFunc verifySignature);
+
+// decimal
+typedef char *(*DecimalFunc)(const char *version, const char *op,
+                             const char *a, const char *b, size_t *counterVal);
+EXPORT void InitializeDecimal(DecimalFunc decimal);
 
 // version
 EXPORT char *GetV8Version();
@@ -135,6 +162,14 @@ typedef struct V8EngineStats {
   size_t peak_array_buffer_size;
 } V8EngineStats;
 
+// the gas charged for each byte of the data processed by the builtin lib
+// functions, all 0 for the flat charges before the fork.
+typedef struct V8GasSchedule {
+  size_t json_per_byte;
+  size_t string_per_byte;
+  size_t hash_per_byte;
+} V8GasSchedule;
+
 typedef struct V8Engine {
 
   void *isolate;
@@ -145,6 +180,18 @@ typedef struct V8Engine {
   bool is_unexpected_error_happen;
   int testing;
   int timeout;
+  int tracing;
+  size_t traced_instructions;
+  int exception_line;
+  int exception_column;
+  char exception_source[64];
+  V8GasSchedule gas_schedule;
+  // the index + 1 of the lib env in the lib snapshot to run the script in,
+  // 0 to build the lib env in a new context.
+  int lib_context;
+  // the storages of the running script, see _native_storage_handlers.
+  uintptr_t lcs_handler;
+  uintptr_t gcs_handler;
   
   V8EngineStats stats;
  
@@ -161,6 +208,7 @@ typedef struct v8ThreadContextOutput {
   int ret;  //output
   int line_offset;
   char *result; //output
+  char *error;  //output, the exception of a failed transpilation
 } v8ThreadContextOutput;
 typedef struct v8ThreadContext_ {
   V8Engine *e; 
@@ -193,16 +241,22 @@ EXPORT void TerminateExecution(V8Engine *e);
 
 EXPORT void DeleteEngine(V8Engine *e);
 
+EXPORT void ResetEngine(V8Engine *e);
+
+// lib snapshot
+EXPORT int CreateLibSnapshot(V8Engine **engines, int count);
+
 EXPORT void ExecuteLoop(const char *file);
 
 EXPORT char *InjectTracingInstructionsThread(V8Engine *e, const char *source,
                                 int *source_line_offset,
                                 int allow_usage);
 EXPORT char *TranspileTypeScriptModuleThread(V8Engine *e, const char *source,
-                                int *source_line_offset);
+                                int *source_line_offset, char **error);
 EXPORT int RunScriptSourceThread(char **result, V8Engine *e, const char *source,
                     int source_line_offset, uintptr_t lcs_handler,
                     uintptr_t gcs_handler);
+EXPORT char *ValidateContractSourceThread(V8Engine *e, const char *source);
 
 bool CreateScriptThread(v8ThreadContext *pc);
 void SetRunScriptArgs(v8ThreadContext *pc, V8Engine *e, int opt, const char *source, int line_offset, int allow_usage);
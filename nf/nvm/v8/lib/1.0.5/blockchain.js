@@ -128,6 +128,32 @@ Blockchain.prototype = {
         }
 
         return this.nativeBlockchain.getPreBlockSeed(offset);
+    },
+
+    call: function (address, func, args, value) {
+        if (!address || !func) {
+            throw "call: invalid address or function";
+        }
+        if (args === undefined || args === null) {
+            args = [];
+        }
+        if (!(args instanceof Array)) {
+            throw "call: args should be an array";
+        }
+        if (value === undefined || value === null) {
+            value = 0;
+        }
+        if (!Uint.isUint(value)) {
+            if (!(value instanceof BigNumber)) {
+                value = new BigNumber(value);
+            }
+            if (value.isNaN() || value.isNegative() || !value.isFinite()) {
+                throw new Error("invalid value");
+            }
+        }
+
+        var result = this.nativeBlockchain.call(address, func, JSON.stringify(args), value.toString(10));
+        return JSON.parse(result);
     }
 };
 module.exports = new Blockchain();
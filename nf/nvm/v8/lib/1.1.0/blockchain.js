@@ -0,0 +1,180 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+'use strict';
+
+var Blockchain = function () {
+    Object.defineProperty(this, "nativeBlockchain", {
+        configurable: false,
+        enumerable: false,
+        get: function(){
+            return _native_blockchain;
+        }
+    });
+};
+
+Blockchain.prototype = {
+    AccountAddress: 0x57,
+    ContractAddress: 0x58,
+
+    blockParse: function (str) {
+        var block = JSON.parse(str);
+        if (block != null) {
+            var fb = Object.freeze(block);
+            Object.defineProperty(this, "block", {
+                configurable: false,
+                enumerable: false,
+                get: function(){
+                    return fb;
+                }
+            });
+        }
+    },
+    transactionParse: function (str) {
+        var tx = JSON.parse(str);
+        if (tx != null) {
+            var value = tx.value === undefined || tx.value.length === 0 ? "0" : tx.value;
+            tx.value = new BigNumber(value);
+            var gasPrice = tx.gasPrice === undefined || tx.gasPrice.length === 0 ? "0" : tx.gasPrice;
+            tx.gasPrice = new BigNumber(gasPrice);
+            var gasLimit = tx.gasLimit === undefined || tx.gasLimit.length === 0 ? "0" : tx.gasLimit;
+            tx.gasLimit = new BigNumber(gasLimit);
+            
+            var ft = Object.freeze(tx);
+            Object.defineProperty(this, "transaction", {
+                configurable: false,
+                enumerable: false,
+                get: function(){
+                    return ft;
+                }
+            });
+        }
+    },
+    transfer: function (address, value) {
+        if (!Uint.isUint(value)) {
+            if (!(value instanceof BigNumber)) {
+                value = new BigNumber(value);
+            }
+            if (value.isNaN() || value.isNegative() || !value.isFinite()) {
+                throw new Error("invalid value");
+            }
+        }
+       
+        var ret = this.nativeBlockchain.transfer(address, value.toString(10));
+        return ret == 0;
+    },
+
+    verifyAddress: function (address) {
+        return this.nativeBlockchain.verifyAddress(address);
+    },
+
+    getAccountState: function(address) {
+        if (address) {
+            var result =  this.nativeBlockchain.getAccountState(address);
+            if (result) {
+                return JSON.parse(result);
+            } else {
+                throw "getAccountState: invalid address";
+            }
+        } else {
+            throw "getAccountState:  inValid address";
+        }
+    },
+    
+    getPreBlockHash: function (offset) {
+        offset = parseInt(offset);
+        if (!offset) {
+            throw "getPreBlockHash: invalid offset"
+        }
+        
+        if (offset <= 0) {
+            throw "getPreBlockHash: offset should large than 0"
+        }
+
+        if (offset >= this.block.height) {
+            throw "getPreBlockHash: block not exist"
+        }
+        
+        return this.nativeBlockchain.getPreBlockHash(offset);
+    },
+
+    getPreBlockSeed: function (offset) {
+        offset = parseInt(offset);
+        if (!offset) {
+            throw "getPreBlockSeed: invalid offset"
+        }
+        
+        if (offset <= 0) {
+            throw "getPreBlockSeed: offset should large than 0"
+        }
+        
+        if (offset >= this.block.height) {
+            throw "getPreBlockSeed: block not exist"
+        }
+
+        return this.nativeBlockchain.getPreBlockSeed(offset);
+    },
+
+    call: function (address, func, args, value) {
+        if (!address || !func) {
+            throw "call: invalid address or function";
+        }
+        if (args === undefined || args === null) {
+            args = [];
+        }
+        if (!(args instanceof Array)) {
+            throw "call: args should be an array";
+        }
+        if (value === undefined || value === null) {
+            value = 0;
+        }
+        if (!Uint.isUint(value)) {
+            if (!(value instanceof BigNumber)) {
+                value = new BigNumber(value);
+            }
+            if (value.isNaN() || value.isNegative() || !value.isFinite()) {
+                throw new Error("invalid value");
+            }
+        }
+
+        var result = this.nativeBlockchain.call(address, func, JSON.stringify(args), value.toString(10));
+        return JSON.parse(result);
+    },
+
+    // a number in [0, 1) drawn from the VRF seed of the block, different for each call.
+    random: function () {
+        var hex = this.nativeBlockchain.random();
+        // the first 52 bits fit the mantissa of a double.
+        return parseInt(hex.substring(0, 13), 16) / Math.pow(2, 52);
+    },
+
+    // the header of a recent block before the current one, by its height.
+    getBlockByHeight: function (height) {
+        height = parseInt(height);
+        if (!height || height <= 0) {
+            throw "getBlockByHeight: invalid height";
+        }
+
+        if (height >= this.block.height) {
+            throw "getBlockByHeight: block not exist";
+        }
+
+        return JSON.parse(this.nativeBlockchain.getBlockByHeight(height));
+    }
+};
+module.exports = new Blockchain();
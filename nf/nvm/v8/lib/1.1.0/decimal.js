@@ -0,0 +1,143 @@
+// Copyright (C) 2018 go-nebulas authors
+// 
+// This file is part of the go-nebulas library.
+// 
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+// 
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+// 
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+// 
+
+
+'use strict';
+
+// the semantics of the decimals are fixed by the version of this lib, the
+// numbers keep 18 fractional digits, the products, quotients and powers are
+// rounded toward zero, and a number of more than 96 digits, fractional digits
+// included, overflows.
+const VERSION = "1.1.0";
+const DecimalRegex = /^-?[0-9]+(\.[0-9]+)?$/;
+
+function calculate(op, a, b) {
+    var r = _native_decimal.calculate(VERSION, op, a, b);
+    if (r === null) {
+        throw new Error('[Decimal Error] ' + op + ' overflow or invalid operand');
+    }
+    return r;
+}
+
+class Decimal {
+    constructor(n) {
+        var value;
+        if (n instanceof Decimal) {
+            value = n._value;
+        } else if (typeof n === 'number') {
+            // floating point numbers are not accepted, their fractions are inexact.
+            if (!Number.isSafeInteger(n)) {
+                throw new Error('[Decimal Error] number should be a safe integer, use a string for fractions');
+            }
+            value = n.toString();
+        } else if (typeof n === 'string' && DecimalRegex.test(n)) {
+            value = n;
+        } else {
+            throw new Error('[Decimal Error] invalid number');
+        }
+
+        Object.defineProperty(this, '_value', {
+            value: calculate('add', value, '0')
+        });
+    }
+
+    _operand(o) {
+        return o instanceof Decimal ? o._value : new Decimal(o)._value;
+    }
+
+    plus(o) {
+        return new Decimal(calculate('add', this._value, this._operand(o)));
+    }
+
+    minus(o) {
+        return new Decimal(calculate('sub', this._value, this._operand(o)));
+    }
+
+    times(o) {
+        return new Decimal(calculate('mul', this._value, this._operand(o)));
+    }
+
+    div(o) {
+        var d = this._operand(o);
+        if (calculate('cmp', d, '0') === '0') {
+            throw new Error('[Decimal Error] division by zero');
+        }
+        return new Decimal(calculate('div', this._value, d));
+    }
+
+    mod(o) {
+        var d = this._operand(o);
+        if (calculate('cmp', d, '0') === '0') {
+            throw new Error('[Decimal Error] division by zero');
+        }
+        return new Decimal(calculate('mod', this._value, d));
+    }
+
+    pow(n) {
+        if (!Number.isSafeInteger(n) || n < 0) {
+            throw new Error('[Decimal Error] exponent should be a non-negative integer');
+        }
+        return new Decimal(calculate('pow', this._value, n.toString()));
+    }
+
+    cmp(o) {
+        return parseInt(calculate('cmp', this._value, this._operand(o)));
+    }
+
+    eq(o) {
+        return this.cmp(o) === 0;
+    }
+
+    gt(o) {
+        return this.cmp(o) > 0;
+    }
+
+    gte(o) {
+        return this.cmp(o) >= 0;
+    }
+
+    lt(o) {
+        return this.cmp(o) < 0;
+    }
+
+    lte(o) {
+        return this.cmp(o) <= 0;
+    }
+
+    isZero() {
+        return this.cmp('0') === 0;
+    }
+
+    isNegative() {
+        return this.cmp('0') < 0;
+    }
+
+    toString() {
+        return this._value;
+    }
+
+    toJSON() {
+        return this._value;
+    }
+
+    static get VERSION() {
+        return VERSION;
+    }
+}
+
+module.exports = Decimal;
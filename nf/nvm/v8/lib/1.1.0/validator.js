@@ -0,0 +1,241 @@
+// Copyright (C) 2018 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+// The validation is best effort: it rejects the usual ways to reach eval, the
+// Function constructor, Math.random and Date.now, and the regexes which may
+// backtrack exponentially, but a source can always reach them in ways no static
+// check follows, e.g. through the computed members of an arbitrary object. The
+// guards of the engine at runtime are the authoritative ones, this pass only
+// turns the common mistakes into diagnostics before the contract is deployed.
+'use strict';
+
+// esprima.js is kept with the 1.0.0 libs.
+const module_path_prefix = (typeof process !== 'undefined') && (process.release.name === 'node') ? '../1.0.0/' : '';
+const esprima = require(module_path_prefix + 'esprima.js');
+
+// the max nesting depth of the syntax tree of a source.
+const MaxDepth = 128;
+
+// the members of the globals which make the executions differ between nodes.
+const DisallowedMembers = {
+    Math: {random: "Math.random"},
+    Date: {now: "Date.now"},
+};
+
+function diagnostic(rule, message, node) {
+    return {
+        rule: rule,
+        message: message,
+        line: node && node.loc ? node.loc.start.line : 0,
+        column: node && node.loc ? node.loc.start.column : 0,
+    };
+};
+
+// staticString return the value of an expression built of string literals, e.g.
+// "ran" + "dom", null if it is only known at runtime.
+function staticString(node) {
+    switch (node.type) {
+        case 'Literal':
+            return typeof node.value === 'string' ? node.value : null;
+        case 'TemplateLiteral':
+            return node.expressions.length === 0 ? node.quasis[0].value.cooked : null;
+        case 'BinaryExpression':
+            if (node.operator !== '+') {
+                return null;
+            }
+            var left = staticString(node.left);
+            var right = staticString(node.right);
+            return left === null || right === null ? null : left + right;
+    }
+    return null;
+};
+
+// propertyName return the name of the member, null if it is only known at runtime.
+function propertyName(node) {
+    if (!node.computed) {
+        return node.property.name;
+    }
+    if (node.property.type === 'Literal' && !node.property.regex) {
+        return String(node.property.value);
+    }
+    return staticString(node.property);
+};
+
+// hasNestedQuantifier check if a repeated group of the pattern repeats something
+// itself, like (a+)+ or (a*b?)*, which backtracks exponentially on a mismatch.
+function hasNestedQuantifier(pattern) {
+    var opened = [];
+    var repeated = false;
+    for (var i = 0; i < pattern.length; i++) {
+        var c = pattern[i];
+        if (c === '\\') {
+            i++;
+        } else if (c === '[') {
+            for (i++; i < pattern.length && pattern[i] !== ']'; i++) {
+                if (pattern[i] === '\\') {
+                    i++;
+                }
+            }
+        } else if (c === '(') {
+            opened.push(repeated);
+            repeated = false;
+        } else if (c === ')') {
+            var inner = repeated;
+            repeated = (opened.length > 0 && opened.pop()) || inner;
+            var next = pattern[i + 1];
+            if (next === '*' || next === '+' || next === '{') {
+                if (inner) {
+                    return true;
+                }
+                repeated = true;
+            }
+        } else if (c === '*' || c === '+' || c === '{') {
+            repeated = true;
+        }
+    }
+    return false;
+};
+
+// isPropertyKey check if the identifier names a property rather than refers to a variable.
+function isPropertyKey(node, parent, key) {
+    if (!parent) {
+        return false;
+    }
+    if (parent.type === 'MemberExpression') {
+        return key === 'property' && !parent.computed;
+    }
+    if (parent.type === 'Property' || parent.type === 'MethodDefinition') {
+        return key === 'key' && !parent.computed;
+    }
+    return false;
+};
+
+// isCallee check if the node is the function called or constructed by its parent.
+function isCallee(parent, key) {
+    return parent && (parent.type === 'CallExpression' || parent.type === 'NewExpression') && key === 'callee';
+};
+
+// isAllowedGlobalUse check if the global with disallowed members is used by a
+// member, or for Date, called or constructed, rather than aliased.
+function isAllowedGlobalUse(node, parent, key) {
+    if (parent && parent.type === 'MemberExpression' && key === 'object') {
+        return true;
+    }
+    return node.name === 'Date' && isCallee(parent, key);
+};
+
+function checkNode(node, parent, key, diagnostics) {
+    switch (node.type) {
+        case 'Identifier':
+            if (isPropertyKey(node, parent, key)) {
+                break;
+            }
+            if (node.name === 'eval') {
+                diagnostics.push(diagnostic("eval", "eval is not allowed", node));
+            } else if (node.name === 'Function') {
+                diagnostics.push(diagnostic("eval", "the Function constructor is not allowed", node));
+            } else if (DisallowedMembers.hasOwnProperty(node.name) && !isAllowedGlobalUse(node, parent, key)) {
+                diagnostics.push(diagnostic("alias", node.name + " can only be used by its members", node));
+            }
+            break;
+        case 'CallExpression':
+        case 'NewExpression':
+            if (node.callee.type !== 'Identifier' || node.callee.name !== 'RegExp' || node.arguments.length === 0) {
+                break;
+            }
+            if (node.arguments[0].type === 'Literal' && node.arguments[0].regex) {
+                // checked as a literal.
+                break;
+            }
+            var pattern = staticString(node.arguments[0]);
+            if (pattern === null) {
+                diagnostics.push(diagnostic("regex", "the pattern of RegExp should be a string literal", node));
+            } else if (hasNestedQuantifier(pattern)) {
+                diagnostics.push(diagnostic("regex", "the pattern has nested quantifiers", node));
+            }
+            break;
+        case 'MemberExpression':
+            var name = propertyName(node);
+            if (name === 'constructor' && isCallee(parent, key)) {
+                diagnostics.push(diagnostic("eval", "calling the constructor property is not allowed", node));
+            }
+            if (node.object.type !== 'Identifier' || !DisallowedMembers.hasOwnProperty(node.object.name)) {
+                break;
+            }
+            var members = DisallowedMembers[node.object.name];
+            if (name === null) {
+                diagnostics.push(diagnostic("member", "the computed members of " + node.object.name + " are not allowed", node));
+            } else if (members.hasOwnProperty(name)) {
+                diagnostics.push(diagnostic(members[name], members[name] + " is not allowed", node));
+            }
+            break;
+        case 'Literal':
+            if (node.regex && hasNestedQuantifier(node.regex.pattern)) {
+                diagnostics.push(diagnostic("regex", "the pattern has nested quantifiers", node));
+            }
+            break;
+    }
+};
+
+function walk(node, parent, key, depth, diagnostics) {
+    if (depth > MaxDepth) {
+        diagnostics.push(diagnostic("depth", "the source is nested deeper than " + MaxDepth, node));
+        return false;
+    }
+    checkNode(node, parent, key, diagnostics);
+
+    for (var k in node) {
+        if (!node.hasOwnProperty(k) || k === 'loc' || k === 'range') {
+            continue;
+        }
+        var children = Array.isArray(node[k]) ? node[k] : [node[k]];
+        for (var i = 0; i < children.length; i++) {
+            var child = children[i];
+            if (child && typeof child.type === 'string' &&
+                !walk(child, node, k, depth + 1, diagnostics)) {
+                return false;
+            }
+        }
+    }
+    return true;
+};
+
+// validate the source of a contract statically, the problems found are returned
+// as diagnostics with the rule broken and the position in the source.
+function validate(source) {
+    var ast;
+    try {
+        ast = esprima.parseScript(source, {
+            loc: true
+        });
+    } catch (e) {
+        return [{
+            rule: "syntax",
+            message: e.description || String(e),
+            line: e.lineNumber || 0,
+            column: e.column || 0,
+        }];
+    }
+
+    var diagnostics = [];
+    walk(ast, null, null, 0, diagnostics);
+    return diagnostics;
+};
+
+exports["hasNestedQuantifier"] = hasNestedQuantifier;
+exports["validate"] = validate;
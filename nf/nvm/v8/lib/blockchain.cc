@@ -30,18 +30,27 @@ static TransferFunc sTransfer = NULL;
 static VerifyAddressFunc sVerifyAddress = NULL;
 static GetPreBlockHashFunc sGetPreBlockHash = NULL;
 static GetPreBlockSeedFunc sGetPreBlockSeed = NULL;
+static RunContractFunc sRunContract = NULL;
+static GetRandomFunc sGetRandom = NULL;
+static GetBlockByHeightFunc sGetBlockByHeight = NULL;
 
 void InitializeBlockchain(GetTxByHashFunc getTx, GetAccountStateFunc getAccount,
                           TransferFunc transfer,
                           VerifyAddressFunc verifyAddress,
                           GetPreBlockHashFunc getPreBlockHash,
-                          GetPreBlockSeedFunc getPreBlockSeed) {
+                          GetPreBlockSeedFunc getPreBlockSeed,
+                          RunContractFunc runContract,
+                          GetRandomFunc getRandom,
+                          GetBlockByHeightFunc getBlockByHeight) {
   sGetTxByHash = getTx;
   sGetAccountState = getAccount;
   sTransfer = transfer;
   sVerifyAddress = verifyAddress;
   sGetPreBlockHash = getPreBlockHash;
   sGetPreBlockSeed = getPreBlockSeed;
+  sRunContract = runContract;
+  sGetRandom = getRandom;
+  sGetBlockByHeight = getBlockByHeight;
 }
 
 void NewBlockchainInstance(Isolate *isolate, Local<Context> context,
@@ -82,6 +91,21 @@ void NewBlockchainInstance(Isolate *isolate, Local<Context> context,
               static_cast<PropertyAttribute>(PropertyAttribute::DontDelete |
                                               PropertyAttribute::ReadOnly));
 
+  blockTpl->Set(String::NewFromUtf8(isolate, "call"),
+              FunctionTemplate::New(isolate, ContractCallCallback),
+              static_cast<PropertyAttribute>(PropertyAttribute::DontDelete |
+                                              PropertyAttribute::ReadOnly));
+
+  blockTpl->Set(String::NewFromUtf8(isolate, "random"),
+              FunctionTemplate::New(isolate, GetRandomCallback),
+              static_cast<PropertyAttribute>(PropertyAttribute::DontDelete |
+                                              PropertyAttribute::ReadOnly));
+
+  blockTpl->Set(String::NewFromUtf8(isolate, "getBlockByHeight"),
+              FunctionTemplate::New(isolate, GetBlockByHeightCallback),
+              static_cast<PropertyAttribute>(PropertyAttribute::DontDelete |
+                                              PropertyAttribute::ReadOnly));
+
   Local<Object> instance = blockTpl->NewInstance(context).ToLocalChecked();
   instance->SetInternalField(0, External::New(isolate, handler));
 
@@ -198,6 +222,11 @@ void TransferCallback(const FunctionCallbackInfo<Value> &info) {
 
   int ret = sTransfer(handler->Value(), *String::Utf8Value(address->ToString()),
                       *String::Utf8Value(amount->ToString()), &cnt);
+  if (ret == NVM_STATIC_CALL_ERR) {
+    isolate->ThrowException(String::NewFromUtf8(
+        isolate, "Blockchain.transfer() is not allowed in static call"));
+    return;
+  }
   info.GetReturnValue().Set(ret);
 
   // record storage usage.
@@ -346,3 +375,146 @@ void GetPreBlockSeedCallback(const FunctionCallbackInfo<Value> &info) {
   // record storage usage.
   IncrCounter(isolate, isolate->GetCurrentContext(), cnt);
 }
+
+// ContractCallCallback
+void ContractCallCallback(const FunctionCallbackInfo<Value> &info) {
+  int err = NVM_SUCCESS;
+  Isolate *isolate = info.GetIsolate();
+  if (NULL == isolate) {
+    LogFatalf("Unexpected error: failed to get isolate");
+  }
+  Local<Object> thisArg = info.Holder();
+  Local<External> handler = Local<External>::Cast(thisArg->GetInternalField(0));
+
+  if (info.Length() != 4) {
+    isolate->ThrowException(String::NewFromUtf8(
+        isolate, "Blockchain.call() requires 4 arguments"));
+    return;
+  }
+
+  for (int i = 0; i < 4; i++) {
+    if (!info[i]->IsString()) {
+      isolate->ThrowException(
+          String::NewFromUtf8(isolate, "Blockchain.call(), arguments must be string"));
+      return;
+    }
+  }
+
+  size_t cnt = 0;
+  char *result = NULL;
+  char *exceptionInfo = NULL;
+  err = sRunContract(handler->Value(), *String::Utf8Value(info[0]->ToString()),
+                     *String::Utf8Value(info[1]->ToString()),
+                     *String::Utf8Value(info[2]->ToString()),
+                     *String::Utf8Value(info[3]->ToString()), &cnt, &result,
+                     &exceptionInfo);
+
+  DEAL_ERROR_FROM_GOLANG(err);
+
+  if (result != NULL) {
+    free(result);
+    result = NULL;
+  }
+
+  if (exceptionInfo != NULL) {
+    free(exceptionInfo);
+    exceptionInfo = NULL;
+  }
+
+  // record the gas of the called contract.
+  IncrCounter(isolate, isolate->GetCurrentContext(), cnt);
+}
+
+// GetRandomCallback
+void GetRandomCallback(const FunctionCallbackInfo<Value> &info) {
+  int err = NVM_SUCCESS;
+  Isolate *isolate = info.GetIsolate();
+  if (NULL == isolate) {
+    LogFatalf("Unexpected error: failed to get isolate");
+  }
+  Local<Object> thisArg = info.Holder();
+  Local<External> handler = Local<External>::Cast(thisArg->GetInternalField(0));
+
+  if (info.Length() != 0) {
+    isolate->ThrowException(String::NewFromUtf8(
+        isolate, "Blockchain.random() requires no arguments"));
+    return;
+  }
+
+  size_t cnt = 0;
+  char *result = NULL;
+  char *exceptionInfo = NULL;
+  err = sGetRandom(handler->Value(), &cnt, &result, &exceptionInfo);
+
+  DEAL_ERROR_FROM_GOLANG(err);
+
+  if (result != NULL) {
+    free(result);
+    result = NULL;
+  }
+
+  if (exceptionInfo != NULL) {
+    free(exceptionInfo);
+    exceptionInfo = NULL;
+  }
+
+  // record storage usage.
+  IncrCounter(isolate, isolate->GetCurrentContext(), cnt);
+}
+
+// GetBlockByHeightCallback
+void GetBlockByHeightCallback(const FunctionCallbackInfo<Value> &info) {
+  int err = NVM_SUCCESS;
+  Isolate *isolate = info.GetIsolate();
+  if (NULL == isolate) {
+    LogFatalf("Unexpected error: failed to get isolate");
+  }
+  Local<Object> thisArg = info.Holder();
+  Local<External> handler = Local<External>::Cast(thisArg->GetInternalField(0));
+
+  if (info.Length() != 1) {
+    isolate->ThrowException(String::NewFromUtf8(
+        isolate, "Blockchain.getBlockByHeight() requires 1 arguments"));
+    return;
+  }
+
+  Local<Value> height = info[0];
+  if (!height->IsNumber()) {
+    isolate->ThrowException(String::NewFromUtf8(
+        isolate, "Blockchain.getBlockByHeight(), the argument must be a number"));
+    return;
+  }
+
+  double v = Number::Cast(*height)->Value();
+  if (v > ULLONG_MAX || v <= 0) {
+    isolate->ThrowException(String::NewFromUtf8(
+        isolate, "Blockchain.getBlockByHeight(), argument out of range"));
+    return;
+  }
+
+  if (v != (double)(unsigned long long)v) {
+    isolate->ThrowException(String::NewFromUtf8(
+        isolate, "Blockchain.getBlockByHeight(), argument must be integer"));
+    return;
+  }
+
+  size_t cnt = 0;
+  char *result = NULL;
+  char *exceptionInfo = NULL;
+  err = sGetBlockByHeight(handler->Value(), (unsigned long long)(v), &cnt, &result, &exceptionInfo);
+
+  DEAL_ERROR_FROM_GOLANG(err);
+
+  if (result != NULL) {
+    free(result);
+    result = NULL;
+  }
+
+  if (exceptionInfo != NULL) {
+    free(exceptionInfo);
+    exceptionInfo = NULL;
+  }
+
+  // record storage usage.
+  IncrCounter(isolate, isolate->GetCurrentContext(), cnt);
+}
@@ -34,6 +34,9 @@ void TransferCallback(const FunctionCallbackInfo<Value> &info);
 void VerifyAddressCallback(const FunctionCallbackInfo<Value> &info);
 void GetPreBlockHashCallback(const FunctionCallbackInfo<Value> &info); 
 void GetPreBlockSeedCallback(const FunctionCallbackInfo<Value> &info); 
+void ContractCallCallback(const FunctionCallbackInfo<Value> &info);
+void GetRandomCallback(const FunctionCallbackInfo<Value> &info);
+void GetBlockByHeightCallback(const FunctionCallbackInfo<Value> &info);
 
 
 #endif //_NEBULAS_NF_NVM_V8_LIB_BLOCKCHAIN_H_
@@ -18,6 +18,7 @@
 
 #include "crypto.h"
 #include "../engine.h"
+#include "gas_schedule.h"
 #include "instruction_counter.h"
 
 static Sha256Func sSha256 = NULL;
@@ -26,19 +27,22 @@ static Ripemd160Func sRipemd160 = NULL;
 static RecoverAddressFunc sRecoverAddress = NULL;
 static Md5Func sMd5 = NULL;
 static Base64Func sBase64 = NULL;
+static VerifySignatureFunc sVerifySignature = NULL;
 
 void InitializeCrypto(Sha256Func sha256,
                                  Sha3256Func sha3256,
                                  Ripemd160Func ripemd160,
                                  RecoverAddressFunc recoverAddress,
                                  Md5Func md5,
-                                 Base64Func base64) {
+                                 Base64Func base64,
+                                 VerifySignatureFunc verifySignature) {
     sSha256 = sha256;
     sSha3256 = sha3256;
     sRipemd160 = ripemd160;
     sRecoverAddress = recoverAddress;
     sMd5 = md5;
     sBase64 = base64;
+    sVerifySignature = verifySignature;
 }
 
 void NewCryptoInstance(Isolate *isolate, Local<Context> context) {
@@ -74,6 +78,11 @@ void NewCryptoInstance(Isolate *isolate, Local<Context> context) {
                 static_cast<PropertyAttribute>(PropertyAttribute::DontDelete |
                                                PropertyAttribute::ReadOnly));
 
+  cryptoTpl->Set(String::NewFromUtf8(isolate, "verifySignature"),
+                FunctionTemplate::New(isolate, VerifySignatureCallback),
+                static_cast<PropertyAttribute>(PropertyAttribute::DontDelete |
+                                               PropertyAttribute::ReadOnly));
+
   Local<Object> instance = cryptoTpl->NewInstance(context).ToLocalChecked();
 
   context->Global()->DefineOwnProperty(
@@ -100,7 +109,8 @@ void Sha256Callback(const FunctionCallbackInfo<Value> &info) {
 
   size_t cnt = 0;
 
-  char *value = sSha256(*String::Utf8Value(data->ToString()), &cnt);
+  String::Utf8Value plain(data->ToString());
+  char *value = sSha256(*plain, &cnt);
   if (value == NULL) {
     info.GetReturnValue().SetNull();
   } else {
@@ -110,6 +120,7 @@ void Sha256Callback(const FunctionCallbackInfo<Value> &info) {
 
   // record storage usage.
   IncrCounter(isolate, isolate->GetCurrentContext(), cnt);
+  IncrHashGas(isolate, isolate->GetCurrentContext(), plain.length());
 }
 
 // Sha3256Callback
@@ -130,7 +141,8 @@ void Sha3256Callback(const FunctionCallbackInfo<Value> &info) {
 
   size_t cnt = 0;
 
-  char *value = sSha3256(*String::Utf8Value(data->ToString()), &cnt);
+  String::Utf8Value plain(data->ToString());
+  char *value = sSha3256(*plain, &cnt);
   if (value == NULL) {
     info.GetReturnValue().SetNull();
   } else {
@@ -140,6 +152,7 @@ void Sha3256Callback(const FunctionCallbackInfo<Value> &info) {
 
   // record storage usage.
   IncrCounter(isolate, isolate->GetCurrentContext(), cnt);
+  IncrHashGas(isolate, isolate->GetCurrentContext(), plain.length());
 }
 
 // Ripemd160Callback
@@ -160,7 +173,8 @@ void Ripemd160Callback(const FunctionCallbackInfo<Value> &info) {
 
   size_t cnt = 0;
 
-  char *value = sRipemd160(*String::Utf8Value(data->ToString()), &cnt);
+  String::Utf8Value plain(data->ToString());
+  char *value = sRipemd160(*plain, &cnt);
   if (value == NULL) {
     info.GetReturnValue().SetNull();
   } else {
@@ -170,6 +184,7 @@ void Ripemd160Callback(const FunctionCallbackInfo<Value> &info) {
 
   // record storage usage.
   IncrCounter(isolate, isolate->GetCurrentContext(), cnt);
+  IncrHashGas(isolate, isolate->GetCurrentContext(), plain.length());
 }
 
 // RecoverAddressCallback
@@ -236,7 +251,8 @@ void Md5Callback(const FunctionCallbackInfo<Value> &info) {
 
   size_t cnt = 0;
 
-  char *value = sMd5(*String::Utf8Value(data->ToString()), &cnt);
+  String::Utf8Value plain(data->ToString());
+  char *value = sMd5(*plain, &cnt);
   if (value == NULL) {
     info.GetReturnValue().SetNull();
   } else {
@@ -246,6 +262,7 @@ void Md5Callback(const FunctionCallbackInfo<Value> &info) {
 
   // record storage usage.
   IncrCounter(isolate, isolate->GetCurrentContext(), cnt);
+  IncrHashGas(isolate, isolate->GetCurrentContext(), plain.length());
 }
 
 // Base64Callback
@@ -276,4 +293,40 @@ void Base64Callback(const FunctionCallbackInfo<Value> &info) {
 
   // record storage usage.
   IncrCounter(isolate, isolate->GetCurrentContext(), cnt);
-}
\ No newline at end of file
+}
+// VerifySignatureCallback
+void VerifySignatureCallback(const FunctionCallbackInfo<Value> &info) {
+  Isolate *isolate = info.GetIsolate();
+
+  if (info.Length() != 4) {
+    isolate->ThrowException(String::NewFromUtf8(
+        isolate, "verifySignature() requires 4 arguments"));
+    return;
+  }
+
+  Local<Value> alg = info[0];
+  if (!alg->IsInt32()) {
+    isolate->ThrowException(
+        String::NewFromUtf8(isolate, "verifySignature(): 1st arg should be integer"));
+    return;
+  }
+
+  for (int i = 1; i < 4; i++) {
+    if (!info[i]->IsString()) {
+      isolate->ThrowException(String::NewFromUtf8(
+          isolate, "verifySignature(): hash, sign & address should be string"));
+      return;
+    }
+  }
+
+  size_t cnt = 0;
+
+  int ret = sVerifySignature(alg->ToInt32()->Int32Value(),
+                             *String::Utf8Value(info[1]->ToString()),
+                             *String::Utf8Value(info[2]->ToString()),
+                             *String::Utf8Value(info[3]->ToString()), &cnt);
+  info.GetReturnValue().Set(Boolean::New(isolate, ret == 1));
+
+  // record storage usage.
+  IncrCounter(isolate, isolate->GetCurrentContext(), cnt);
+}
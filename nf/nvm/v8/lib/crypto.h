@@ -31,5 +31,6 @@ void Ripemd160Callback(const FunctionCallbackInfo<Value> &info);
 void RecoverAddressCallback(const FunctionCallbackInfo<Value> &info);
 void Md5Callback(const FunctionCallbackInfo<Value> &info);
 void Base64Callback(const FunctionCallbackInfo<Value> &info);
+void VerifySignatureCallback(const FunctionCallbackInfo<Value> &info);
 
 #endif //_NEBULAS_NF_NVM_V8_LIB_CRYPTO_H_
\ No newline at end of file
@@ -0,0 +1,77 @@
+// Copyright (C) 2018 go-nebulas authors
+// 
+// This file is part of the go-nebulas library.
+// 
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+// 
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+// 
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+// 
+
+
+#include "decimal.h"
+#include "../engine.h"
+#include "instruction_counter.h"
+
+static DecimalFunc sDecimal = NULL;
+
+void InitializeDecimal(DecimalFunc decimal) { sDecimal = decimal; }
+
+void NewDecimalInstance(Isolate *isolate, Local<Context> context) {
+  Local<ObjectTemplate> decimalTpl = ObjectTemplate::New(isolate);
+
+  decimalTpl->Set(String::NewFromUtf8(isolate, "calculate"),
+                  FunctionTemplate::New(isolate, DecimalCalculateCallback),
+                  static_cast<PropertyAttribute>(PropertyAttribute::DontDelete |
+                                                 PropertyAttribute::ReadOnly));
+
+  Local<Object> instance = decimalTpl->NewInstance(context).ToLocalChecked();
+
+  context->Global()->DefineOwnProperty(
+      context, String::NewFromUtf8(isolate, "_native_decimal"), instance,
+      static_cast<PropertyAttribute>(PropertyAttribute::DontDelete |
+                                     PropertyAttribute::ReadOnly));
+}
+
+// DecimalCalculateCallback
+void DecimalCalculateCallback(const FunctionCallbackInfo<Value> &info) {
+  Isolate *isolate = info.GetIsolate();
+
+  if (info.Length() != 4) {
+    isolate->ThrowException(String::NewFromUtf8(
+        isolate, "calculate() requires 4 arguments"));
+    return;
+  }
+
+  for (int i = 0; i < 4; i++) {
+    if (!info[i]->IsString()) {
+      isolate->ThrowException(String::NewFromUtf8(
+          isolate, "calculate(): version, op & operands should be string"));
+      return;
+    }
+  }
+
+  size_t cnt = 0;
+
+  char *value = sDecimal(*String::Utf8Value(info[0]->ToString()),
+                         *String::Utf8Value(info[1]->ToString()),
+                         *String::Utf8Value(info[2]->ToString()),
+                         *String::Utf8Value(info[3]->ToString()), &cnt);
+  if (value == NULL) {
+    info.GetReturnValue().SetNull();
+  } else {
+    info.GetReturnValue().Set(String::NewFromUtf8(isolate, value));
+    free(value);
+  }
+
+  // record the calculation.
+  IncrCounter(isolate, isolate->GetCurrentContext(), cnt);
+}
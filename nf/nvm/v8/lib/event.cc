@@ -66,7 +66,12 @@ void EventTriggerCallback(const FunctionCallbackInfo<Value> &info) {
   String::Utf8Value sData(data);
 
   size_t cnt = 0;
-  TRIGGER(e, *sTopic, *sData, &cnt);
+  int ret = TRIGGER(e, *sTopic, *sData, &cnt);
+  if (ret == NVM_STATIC_CALL_ERR) {
+    isolate->ThrowException(Exception::Error(String::NewFromUtf8(
+        isolate, "Event.Trigger() is not allowed in static call")));
+    return;
+  }
 
   // record event usage.
   IncrCounter(isolate, isolate->GetCurrentContext(), cnt);
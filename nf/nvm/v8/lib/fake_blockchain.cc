@@ -70,3 +70,18 @@ int GetPreBlockSeed(void *handler, unsigned long long offset, size_t *gasCnt, ch
   return NVM_SUCCESS;
 }
 
+int RunContract(void *handler, const char *address, const char *funcName, const char *args,
+                const char *value, size_t *gasCnt, char **result, char **info) {
+  *gasCnt = 1000;
+  return NVM_SUCCESS;
+}
+
+int GetRandom(void *handler, size_t *gasCnt, char **result, char **info) {
+  *gasCnt = 1000;
+  return NVM_SUCCESS;
+}
+
+int GetBlockByHeight(void *handler, unsigned long long height, size_t *gasCnt, char **result, char **info) {
+  *gasCnt = 1000;
+  return NVM_SUCCESS;
+}
@@ -28,6 +28,10 @@ int Transfer(void *handler, const char *to, const char *value, size_t *gasCnt);
 int VerifyAddress(void *handler, const char *address, size_t *gasCnt);
 int GetPreBlockHash(void *handler, unsigned long long offset, size_t *counterVal, char **result, char **info);
 int GetPreBlockSeed(void *handler, unsigned long long offset, size_t *counterVal, char **result, char **info);
+int RunContract(void *handler, const char *address, const char *funcName, const char *args,
+                const char *value, size_t *counterVal, char **result, char **info);
+int GetRandom(void *handler, size_t *counterVal, char **result, char **info);
+int GetBlockByHeight(void *handler, unsigned long long height, size_t *counterVal, char **result, char **info);
 
 
 #endif //_NEBULAS_NF_NVM_V8_LIB_FAKE_BLOCKCHAIN_H_
@@ -0,0 +1,144 @@
+// Copyright (C) 2018 go-nebulas authors
+// 
+// This file is part of the go-nebulas library.
+// 
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+// 
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+// 
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+// 
+
+
+#include "gas_schedule.h"
+#include "global.h"
+#include "instruction_counter.h"
+
+#include <vector>
+
+// the builtins of String.prototype charged by the size of the strings.
+static const char *sStringBuiltins[] = {
+    "concat",  "padEnd", "padStart",    "repeat",
+    "replace", "split",  "toLowerCase", "toUpperCase", NULL};
+
+// SizeOf return the length of the value if it is a string, 0 otherwise.
+static size_t SizeOf(Local<Value> value) {
+  if (value.IsEmpty()) {
+    return 0;
+  }
+  if (value->IsString()) {
+    return Local<String>::Cast(value)->Length();
+  }
+  if (value->IsStringObject()) {
+    return StringObject::Cast(*value)->ValueOf()->Length();
+  }
+  return 0;
+}
+
+// WrapBuiltin replace the builtin function of the holder by one charging the
+// gas for each byte of the longest string it processes.
+static void WrapBuiltin(Isolate *isolate, Local<Context> context,
+                        Local<Object> holder, const char *name,
+                        size_t perByte) {
+  Local<String> key = String::NewFromUtf8(isolate, name);
+  Local<Value> builtin;
+  if (!holder->Get(context, key).ToLocal(&builtin) || !builtin->IsFunction()) {
+    return;
+  }
+
+  Local<Array> data = Array::New(isolate, 2);
+  data->Set(0, builtin);
+  data->Set(1, Number::New(isolate, (double)perByte));
+
+  Local<Function> wrapper;
+  if (!Function::New(context, SizedBuiltinCallback, data,
+                     Local<Function>::Cast(builtin)->Length())
+           .ToLocal(&wrapper)) {
+    return;
+  }
+  holder->DefineOwnProperty(context, key, wrapper, PropertyAttribute::DontEnum);
+}
+
+static Local<Object> GetObject(Isolate *isolate, Local<Context> context,
+                               Local<Object> holder, const char *name) {
+  return Local<Object>::Cast(
+      holder->Get(context, String::NewFromUtf8(isolate, name))
+          .ToLocalChecked());
+}
+
+void NewGasScheduleInstance(Isolate *isolate, Local<Context> context,
+                            V8Engine *e) {
+  Local<Object> global = context->Global();
+
+  if (e->gas_schedule.json_per_byte > 0) {
+    Local<Object> json = GetObject(isolate, context, global, "JSON");
+    WrapBuiltin(isolate, context, json, "parse", e->gas_schedule.json_per_byte);
+    WrapBuiltin(isolate, context, json, "stringify",
+                e->gas_schedule.json_per_byte);
+  }
+
+  if (e->gas_schedule.string_per_byte > 0) {
+    Local<Object> stringProto = GetObject(
+        isolate, context, GetObject(isolate, context, global, "String"),
+        "prototype");
+    for (int i = 0; sStringBuiltins[i] != NULL; i++) {
+      WrapBuiltin(isolate, context, stringProto, sStringBuiltins[i],
+                  e->gas_schedule.string_per_byte);
+    }
+
+    Local<Object> arrayProto = GetObject(
+        isolate, context, GetObject(isolate, context, global, "Array"),
+        "prototype");
+    WrapBuiltin(isolate, context, arrayProto, "join",
+                e->gas_schedule.string_per_byte);
+  }
+}
+
+void IncrHashGas(Isolate *isolate, Local<Context> context, size_t size) {
+  V8Engine *e = GetV8EngineInstance(context);
+  if (e == NULL) {
+    return;
+  }
+  IncrCounter(isolate, context, size * e->gas_schedule.hash_per_byte);
+}
+
+// SizedBuiltinCallback call the wrapped builtin, then charge the gas for the
+// longest string among the receiver, the arguments and the result.
+void SizedBuiltinCallback(const FunctionCallbackInfo<Value> &info) {
+  Isolate *isolate = info.GetIsolate();
+  Local<Context> context = isolate->GetCurrentContext();
+  Local<Array> data = Local<Array>::Cast(info.Data());
+  Local<Function> builtin = Local<Function>::Cast(data->Get(0));
+  size_t perByte = (size_t)Number::Cast(*data->Get(1))->Value();
+
+  std::vector<Local<Value>> args;
+  size_t size = SizeOf(info.This());
+  for (int i = 0; i < info.Length(); i++) {
+    args.push_back(info[i]);
+    size_t n = SizeOf(info[i]);
+    if (n > size) {
+      size = n;
+    }
+  }
+
+  // the exception of the builtin is thrown to the caller.
+  Local<Value> result;
+  if (!builtin->Call(context, info.This(), (int)args.size(), args.data())
+           .ToLocal(&result)) {
+    return;
+  }
+  size_t n = SizeOf(result);
+  if (n > size) {
+    size = n;
+  }
+  info.GetReturnValue().Set(result);
+
+  IncrCounter(isolate, context, size * perByte);
+}
@@ -25,6 +25,8 @@
 #include "require_callback.h"
 #include "storage_object.h"
 #include "crypto.h"
+#include "decimal.h"
+#include "gas_schedule.h"
 
 Local<ObjectTemplate> CreateGlobalObjectTemplate(Isolate *isolate) {
   Local<ObjectTemplate> globalTpl = ObjectTemplate::New(isolate);
@@ -46,11 +48,19 @@ void SetGlobalObjectProperties(Isolate *isolate, Local<Context> context,
   Local<Object> global = context->Global();
   global->SetInternalField(0, External::New(isolate, e));
 
-  NewStorageTypeInstance(isolate, context, lcsHandler, gcsHandler);
+  // the storages are resolved by e, the handlers restored from the lib
+  // snapshot are kept.
+  e->lcs_handler = reinterpret_cast<uintptr_t>(lcsHandler);
+  e->gcs_handler = reinterpret_cast<uintptr_t>(gcsHandler);
+  if (e->lib_context == 0) {
+    NewStorageTypeInstance(isolate, context);
+  }
   NewInstructionCounterInstance(isolate, context,
                                 &(e->stats.count_of_executed_instructions), e);
   NewBlockchainInstance(isolate, context, lcsHandler);
   NewCryptoInstance(isolate, context);
+  NewDecimalInstance(isolate, context);
+  NewGasScheduleInstance(isolate, context, e);
 }
 
 V8Engine *GetV8EngineInstance(Local<Context> context) {
@@ -58,6 +58,7 @@ enum nvmErrno {
   NVM_GAS_LIMIT_ERR = -3,
   NVM_UNEXPECTED_ERR = -4,
   NVM_EXE_TIMEOUT_ERR = -5,
+  NVM_STATIC_CALL_ERR = -6,
 };
 
 #endif //_NEBULAS_NF_NVM_V8_ENGINE_ERROR_H_
\ No newline at end of file
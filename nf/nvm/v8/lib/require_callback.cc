@@ -72,6 +72,22 @@ static int readSource(Local<Context> context, const char *filename, char **data,
   return 0;
 }
 
+// isContractModule check if the path is a module deployed with the contract,
+// which is served by the require delegate instead of the versioned libs.
+static bool isContractModule(Local<Context> context, const char *filename) {
+  if (sRequireDelegate == NULL) {
+    return false;
+  }
+  size_t lineOffset = 0;
+  V8Engine *e = GetV8EngineInstance(context);
+  char *content = sRequireDelegate(e, filename, &lineOffset);
+  if (content == NULL) {
+    return false;
+  }
+  free(content);
+  return true;
+}
+
 static void attachVersion(char *out, int maxoutlen, Local<Context> context, const char *libname) {
 
   char *verlib = NULL;
@@ -117,7 +133,8 @@ void RequireCallback(const v8::FunctionCallbackInfo<v8::Value> &info) {
     return;
   }
   char *abPath = NULL;
-  if (strcmp(*filename, LIB_WHITE)) { // if needed, check array instead.
+  if (strcmp(*filename, LIB_WHITE) &&
+      !isContractModule(context, *filename)) { // if needed, check array instead.
     char versionlizedPath[MAX_VERSIONED_PATH_LEN] = {0};
     attachVersion(versionlizedPath, MAX_VERSIONED_PATH_LEN, context, *filename);
     abPath = realpath(versionlizedPath, NULL);
@@ -0,0 +1,118 @@
+// Copyright (C) 2018 go-nebulas authors
+// 
+// This file is part of the go-nebulas library.
+// 
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+// 
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+// 
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+// 
+
+
+#include "snapshot.h"
+#include "../engine.h"
+#include "event.h"
+#include "execution_env.h"
+#include "global.h"
+#include "log_callback.h"
+#include "logger.h"
+#include "require_callback.h"
+#include "storage_object.h"
+
+#include <stdlib.h>
+
+// the lib snapshot keeps a context for each version of the js libs, with the
+// lib env set up by execution_env.js. The natives of an execution are set to
+// the context restored from it, see SetGlobalObjectProperties.
+static StartupData sLibSnapshot = {NULL, 0};
+
+// the natives of the global object template and the storage handlers, which
+// are referred by the lib env in the snapshot.
+static const intptr_t *libExternalReferences() {
+  static intptr_t references[] = {
+      reinterpret_cast<intptr_t>(RequireCallback),
+      reinterpret_cast<intptr_t>(LogCallback),
+      reinterpret_cast<intptr_t>(EventTriggerCallback),
+      reinterpret_cast<intptr_t>(StorageConstructor),
+      reinterpret_cast<intptr_t>(StorageGetCallback),
+      reinterpret_cast<intptr_t>(StoragePutCallback),
+      reinterpret_cast<intptr_t>(StorageDelCallback),
+      reinterpret_cast<intptr_t>(StorageKeysCallback),
+      reinterpret_cast<intptr_t>(StorageHandlerSlot(false)),
+      reinterpret_cast<intptr_t>(StorageHandlerSlot(true)),
+      0};
+  return references;
+}
+
+int CreateLibSnapshot(V8Engine **engines, int count) {
+  if (sLibSnapshot.data != NULL) {
+    return NVM_SUCCESS;
+  }
+
+  SnapshotCreator creator(libExternalReferences());
+  Isolate *isolate = creator.GetIsolate();
+  {
+    HandleScope handle_scope(isolate);
+    creator.SetDefaultContext(Context::New(isolate));
+
+    for (int i = 0; i < count; i++) {
+      Local<ObjectTemplate> globalTpl = CreateGlobalObjectTemplate(isolate);
+      Local<Context> context = Context::New(isolate, NULL, globalTpl);
+      Context::Scope context_scope(context);
+      TryCatch trycatch(isolate);
+
+      // the engine picks the libs of its version, it can't be kept.
+      Local<Object> global = context->Global();
+      global->SetInternalField(0, External::New(isolate, engines[i]));
+      NewStorageTypeInstance(isolate, context);
+      if (SetupExecutionEnv(isolate, context)) {
+        String::Utf8Value exception(trycatch.Exception());
+        LogErrorf("Failed to set up the lib env %d of the lib snapshot: %s", i,
+                  *exception ? *exception : "");
+        return NVM_UNEXPECTED_ERR;
+      }
+      global->SetInternalField(0, Undefined(isolate));
+
+      creator.AddContext(context);
+    }
+  }
+
+  sLibSnapshot =
+      creator.CreateBlob(SnapshotCreator::FunctionCodeHandling::kKeep);
+  if (sLibSnapshot.data == NULL) {
+    LogErrorf("Failed to create the lib snapshot.");
+    return NVM_UNEXPECTED_ERR;
+  }
+  LogInfof("Created the lib snapshot of %d lib envs, %d bytes.", count,
+           sLibSnapshot.raw_size);
+  return NVM_SUCCESS;
+}
+
+void SetLibSnapshotParams(Isolate::CreateParams *params) {
+  if (sLibSnapshot.data == NULL) {
+    return;
+  }
+  params->snapshot_blob = &sLibSnapshot;
+  params->external_references = libExternalReferences();
+}
+
+MaybeLocal<Context> NewLibSnapshotContext(Isolate *isolate, int index) {
+  if (sLibSnapshot.data == NULL) {
+    return MaybeLocal<Context>();
+  }
+  return Context::FromSnapshot(isolate, index);
+}
+
+void DisposeLibSnapshot() {
+  delete[] sLibSnapshot.data;
+  sLibSnapshot.data = NULL;
+  sLibSnapshot.raw_size = 0;
+}
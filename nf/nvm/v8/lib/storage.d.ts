@@ -76,6 +76,11 @@ interface ContractStorage {
     // the value will be serialized to string by calling `descriptor.stringify`.
     // return 0 for success, otherwise failure.
     set(key: string, value: any): number;
+
+    // list at most `limit` keys starting with `prefix`, skipping the first `offset` ones.
+    // the keys of a StorageMap are named "@fieldName[key]".
+    // only in the 1.1.0 libs, and not to the contracts holding keys put before it is available.
+    keys(prefix: string, offset: number, limit: number): string[];
 }
 
 interface StorageMapConstructor {
@@ -96,6 +101,10 @@ interface StorageMap {
     // the value will be serialized to string by calling `descriptor.stringify`.
     // return 0 for success, otherwise failure.
     set(key: string, value: any): number;
+
+    // list at most `limit` keys of the map, skipping the first `offset` ones.
+    // only in the 1.1.0 libs, see ContractStorage.keys.
+    keys(offset: number, limit: number): string[];
 }
 
 declare const lcs: ContractStorage;
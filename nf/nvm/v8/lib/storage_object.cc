@@ -19,13 +19,22 @@
 
 #include "storage_object.h"
 #include "../engine.h"
+#include "global.h"
 #include "instruction_counter.h"
 #include "logger.h"
+#include <limits.h>
 #include <math.h>
 
 static StorageGetFunc GET = NULL;
 static StoragePutFunc PUT = NULL;
 static StorageDelFunc DEL = NULL;
+static StorageKeysFunc KEYS = NULL;
+
+// the members of _native_storage_handlers point to these slots instead of the
+// storages of an execution, so the lib env holding them can be kept in the lib
+// snapshot. The storages are resolved by the running engine.
+static char sLcsSlot = 0;
+static char sGcsSlot = 0;
 
 void NewStorageType(Isolate *isolate, Local<ObjectTemplate> globalTpl) {
   Local<FunctionTemplate> type =
@@ -55,26 +64,30 @@ void NewStorageType(Isolate *isolate, Local<ObjectTemplate> globalTpl) {
       FunctionTemplate::New(isolate, StorageDelCallback),
       static_cast<PropertyAttribute>(PropertyAttribute::DontDelete |
                                      PropertyAttribute::ReadOnly));
+  instanceTpl->Set(
+      String::NewFromUtf8(isolate, "keys"),
+      FunctionTemplate::New(isolate, StorageKeysCallback),
+      static_cast<PropertyAttribute>(PropertyAttribute::DontDelete |
+                                     PropertyAttribute::ReadOnly));
 
   globalTpl->Set(className, type,
                  static_cast<PropertyAttribute>(PropertyAttribute::DontDelete |
                                                 PropertyAttribute::ReadOnly));
 }
 
-void NewStorageTypeInstance(Isolate *isolate, Local<Context> context,
-                            void *lcsHandler, void *gcsHandler) {
+void NewStorageTypeInstance(Isolate *isolate, Local<Context> context) {
   Local<ObjectTemplate> storageHandlerTpl = ObjectTemplate::New(isolate);
   Local<Object> handlers =
       storageHandlerTpl->NewInstance(context).ToLocalChecked();
 
   handlers->DefineOwnProperty(
       context, String::NewFromUtf8(isolate, "lcs"),
-      External::New(isolate, lcsHandler),
+      External::New(isolate, &sLcsSlot),
       static_cast<PropertyAttribute>(PropertyAttribute::DontDelete |
                                      PropertyAttribute::ReadOnly));
   handlers->DefineOwnProperty(
       context, String::NewFromUtf8(isolate, "gcs"),
-      External::New(isolate, gcsHandler),
+      External::New(isolate, &sGcsSlot),
       static_cast<PropertyAttribute>(PropertyAttribute::DontDelete |
                                      PropertyAttribute::ReadOnly));
 
@@ -85,11 +98,33 @@ void NewStorageTypeInstance(Isolate *isolate, Local<Context> context,
                                      PropertyAttribute::ReadOnly));
 }
 
+void *StorageHandlerSlot(bool global) {
+  return global ? &sGcsSlot : &sLcsSlot;
+}
+
+// resolveHandler return the storage of the running execution the native
+// storage is constructed with, NULL if not found.
+static void *resolveHandler(Isolate *isolate, Local<Object> thisArg) {
+  Local<External> slot = Local<External>::Cast(thisArg->GetInternalField(0));
+  V8Engine *e = GetV8EngineInstance(isolate->GetCurrentContext());
+  if (e == NULL) {
+    return NULL;
+  }
+  if (slot->Value() == &sLcsSlot) {
+    return reinterpret_cast<void *>(e->lcs_handler);
+  }
+  if (slot->Value() == &sGcsSlot) {
+    return reinterpret_cast<void *>(e->gcs_handler);
+  }
+  return NULL;
+}
+
 void InitializeStorage(StorageGetFunc get, StoragePutFunc put,
-                       StorageDelFunc del) {
+                       StorageDelFunc del, StorageKeysFunc keys) {
   GET = get;
   PUT = put;
   DEL = del;
+  KEYS = keys;
 }
 
 void StorageConstructor(const FunctionCallbackInfo<Value> &info) {
@@ -116,7 +151,12 @@ void StorageConstructor(const FunctionCallbackInfo<Value> &info) {
 void StorageGetCallback(const FunctionCallbackInfo<Value> &info) {
   Isolate *isolate = info.GetIsolate();
   Local<Object> thisArg = info.Holder();
-  Local<External> handler = Local<External>::Cast(thisArg->GetInternalField(0));
+  void *handler = resolveHandler(isolate, thisArg);
+  if (handler == NULL) {
+    isolate->ThrowException(
+        String::NewFromUtf8(isolate, "storage handler not found"));
+    return;
+  }
 
   if (info.Length() != 1) {
     isolate->ThrowException(
@@ -132,7 +172,7 @@ void StorageGetCallback(const FunctionCallbackInfo<Value> &info) {
 
   size_t cnt = 0;
   char *value =
-      GET(handler->Value(), *String::Utf8Value(key->ToString()), &cnt);
+      GET(handler, *String::Utf8Value(key->ToString()), &cnt);
   if (value == NULL) {
     info.GetReturnValue().SetNull();
   } else {
@@ -147,7 +187,12 @@ void StorageGetCallback(const FunctionCallbackInfo<Value> &info) {
 void StoragePutCallback(const FunctionCallbackInfo<Value> &info) {
   Isolate *isolate = info.GetIsolate();
   Local<Object> thisArg = info.Holder();
-  Local<External> handler = Local<External>::Cast(thisArg->GetInternalField(0));
+  void *handler = resolveHandler(isolate, thisArg);
+  if (handler == NULL) {
+    isolate->ThrowException(
+        String::NewFromUtf8(isolate, "storage handler not found"));
+    return;
+  }
 
   if (info.Length() != 2) {
     isolate->ThrowException(
@@ -172,8 +217,13 @@ void StoragePutCallback(const FunctionCallbackInfo<Value> &info) {
   Local<String> val_str = value->ToString();
 
   size_t cnt = 0;
-  int ret = PUT(handler->Value(), *String::Utf8Value(key_str),
+  int ret = PUT(handler, *String::Utf8Value(key_str),
                 *String::Utf8Value(val_str), &cnt);
+  if (ret == NVM_STATIC_CALL_ERR) {
+    isolate->ThrowException(String::NewFromUtf8(
+        isolate, "Storage.put() is not allowed in static call"));
+    return;
+  }
   info.GetReturnValue().Set(ret);
 
   // record storage usage.
@@ -183,7 +233,12 @@ void StoragePutCallback(const FunctionCallbackInfo<Value> &info) {
 void StorageDelCallback(const FunctionCallbackInfo<Value> &info) {
   Isolate *isolate = info.GetIsolate();
   Local<Object> thisArg = info.Holder();
-  Local<External> handler = Local<External>::Cast(thisArg->GetInternalField(0));
+  void *handler = resolveHandler(isolate, thisArg);
+  if (handler == NULL) {
+    isolate->ThrowException(
+        String::NewFromUtf8(isolate, "storage handler not found"));
+    return;
+  }
 
   if (info.Length() != 1) {
     isolate->ThrowException(
@@ -198,9 +253,76 @@ void StorageDelCallback(const FunctionCallbackInfo<Value> &info) {
   }
 
   size_t cnt = 0;
-  int ret = DEL(handler->Value(), *String::Utf8Value(key->ToString()), &cnt);
+  int ret = DEL(handler, *String::Utf8Value(key->ToString()), &cnt);
+  if (ret == NVM_STATIC_CALL_ERR) {
+    isolate->ThrowException(String::NewFromUtf8(
+        isolate, "Storage.del() is not allowed in static call"));
+    return;
+  }
   info.GetReturnValue().Set(ret);
 
   // record storage usage.
   IncrCounter(isolate, isolate->GetCurrentContext(), cnt);
 }
+
+void StorageKeysCallback(const FunctionCallbackInfo<Value> &info) {
+  int err = NVM_SUCCESS;
+  Isolate *isolate = info.GetIsolate();
+  Local<Object> thisArg = info.Holder();
+  void *handler = resolveHandler(isolate, thisArg);
+  if (handler == NULL) {
+    isolate->ThrowException(
+        String::NewFromUtf8(isolate, "storage handler not found"));
+    return;
+  }
+
+  if (info.Length() != 3) {
+    isolate->ThrowException(String::NewFromUtf8(
+        isolate, "Storage.keys() requires 3 arguments"));
+    return;
+  }
+
+  Local<Value> prefix = info[0];
+  if (!prefix->IsString()) {
+    isolate->ThrowException(
+        String::NewFromUtf8(isolate, "prefix must be string"));
+    return;
+  }
+
+  double bounds[2];
+  for (int i = 0; i < 2; i++) {
+    if (!info[i + 1]->IsNumber()) {
+      isolate->ThrowException(String::NewFromUtf8(
+          isolate, "Storage.keys(), offset and limit must be number"));
+      return;
+    }
+    bounds[i] = Number::Cast(*info[i + 1])->Value();
+    if (bounds[i] < 0 || bounds[i] > INT_MAX ||
+        bounds[i] != (double)(int)bounds[i]) {
+      isolate->ThrowException(String::NewFromUtf8(
+          isolate, "Storage.keys(), offset and limit must be integer in range"));
+      return;
+    }
+  }
+
+  size_t cnt = 0;
+  char *result = NULL;
+  char *exceptionInfo = NULL;
+  err = KEYS(handler, *String::Utf8Value(prefix->ToString()),
+             (int)bounds[0], (int)bounds[1], &cnt, &result, &exceptionInfo);
+
+  DEAL_ERROR_FROM_GOLANG(err);
+
+  if (result != NULL) {
+    free(result);
+    result = NULL;
+  }
+
+  if (exceptionInfo != NULL) {
+    free(exceptionInfo);
+    exceptionInfo = NULL;
+  }
+
+  // record storage usage.
+  IncrCounter(isolate, isolate->GetCurrentContext(), cnt);
+}
@@ -25,12 +25,13 @@
 using namespace v8;
 
 void NewStorageType(Isolate *isolate, Local<ObjectTemplate> globalTpl);
-void NewStorageTypeInstance(Isolate *isolate, Local<Context> context,
-                            void *lcsHandler, void *gcsHandler);
+void NewStorageTypeInstance(Isolate *isolate, Local<Context> context);
+void *StorageHandlerSlot(bool global);
 
 void StorageConstructor(const FunctionCallbackInfo<Value> &info);
 void StorageGetCallback(const FunctionCallbackInfo<Value> &info);
 void StoragePutCallback(const FunctionCallbackInfo<Value> &info);
 void StorageDelCallback(const FunctionCallbackInfo<Value> &info);
+void StorageKeysCallback(const FunctionCallbackInfo<Value> &info);
 
 #endif // _NEBULAS_NF_NVM_V8_LIB_STORAGE_OBJECT_H_
@@ -23,7 +23,8 @@
 
 #include <string.h>
 
-extern void PrintException(Local<Context> context, TryCatch &trycatch);
+extern void PrintAndReturnException(char **exception, Local<Context> context,
+                                    TryCatch &trycatch);
 
 static char ts_transpile_source_template[] =
     "(function(){\n"
@@ -39,6 +40,7 @@ int TypeScriptTranspileDelegate(char **result, Isolate *isolate,
   TypeScriptContext *tContext =
       static_cast<TypeScriptContext *>(delegateContext);
   tContext->js_source = NULL;
+  tContext->error = NULL;
 
   std::string s(source);
   s = ReplaceAll(s, "\\", "\\\\");
@@ -62,14 +64,15 @@ int TypeScriptTranspileDelegate(char **result, Isolate *isolate,
   MaybeLocal<Script> script = Script::Compile(context, src, &sourceSrcOrigin);
 
   if (script.IsEmpty()) {
-    PrintException(context, trycatch);
+    PrintAndReturnException(&tContext->error, context, trycatch);
     return 1;
   }
 
   // Run the script to get the result.
   MaybeLocal<Value> ret = script.ToLocalChecked()->Run(context);
   if (ret.IsEmpty()) {
-    PrintException(context, trycatch);
+    // the diagnostics of the transpiler are thrown, return them to the node.
+    PrintAndReturnException(&tContext->error, context, trycatch);
     return 1;
   }
 
@@ -28,6 +28,7 @@ using namespace v8;
 typedef struct {
   int source_line_offset;
   char *js_source;
+  char *error;
 } TypeScriptContext;
 
 int TypeScriptTranspileDelegate(char **result, Isolate *isolate,
@@ -0,0 +1,86 @@
+// Copyright (C) 2018 go-nebulas authors
+// 
+// This file is part of the go-nebulas library.
+// 
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+// 
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+// 
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+// 
+
+
+#include "validator.h"
+#include "logger.h"
+#include "util.h"
+
+#include <string.h>
+
+extern void PrintException(Local<Context> context, TryCatch &trycatch);
+
+static char validate_source_template[] =
+    "(function(){\n"
+    "const validator = require(\"validator.js\");\n"
+    "const source = \"%s\";\n"
+    "return JSON.stringify(validator.validate(source));\n"
+    "})();";
+
+int ValidateContractDelegate(char **result, Isolate *isolate,
+                             const char *source, int source_line_offset,
+                             Local<Context> context, TryCatch &trycatch,
+                             void *delegateContext) {
+  ValidatorContext *vContext = static_cast<ValidatorContext *>(delegateContext);
+  vContext->diagnostics = NULL;
+
+  std::string s(source);
+  s = ReplaceAll(s, "\\", "\\\\");
+  s = ReplaceAll(s, "\n", "\\n");
+  s = ReplaceAll(s, "\r", "\\r");
+  s = ReplaceAll(s, "\"", "\\\"");
+
+  char *validateSource = NULL;
+  asprintf(&validateSource, validate_source_template, s.c_str());
+
+  // Create a string containing the JavaScript source code.
+  Local<String> src =
+      String::NewFromUtf8(isolate, validateSource, NewStringType::kNormal)
+          .ToLocalChecked();
+  free(validateSource);
+
+  // Compile the source code.
+  ScriptOrigin sourceSrcOrigin(
+      String::NewFromUtf8(isolate, "_validate_contract.js"),
+      Integer::New(isolate, source_line_offset));
+  MaybeLocal<Script> script = Script::Compile(context, src, &sourceSrcOrigin);
+
+  if (script.IsEmpty()) {
+    PrintException(context, trycatch);
+    return 1;
+  }
+
+  // Run the script to get the diagnostics.
+  MaybeLocal<Value> ret = script.ToLocalChecked()->Run(context);
+  if (ret.IsEmpty()) {
+    PrintException(context, trycatch);
+    return 1;
+  }
+
+  Local<Value> checked_ret = ret.ToLocalChecked();
+  if (!checked_ret->IsString()) {
+    LogErrorf("validator.js:validate() should return the diagnostics.");
+    return 1;
+  }
+
+  String::Utf8Value str(checked_ret);
+  vContext->diagnostics = (char *)malloc(str.length() + 1);
+  strcpy(vContext->diagnostics, *str);
+
+  return 0;
+}
@@ -53,10 +53,11 @@ void logFunc(int level, const char *msg) {
           msg);
 }
 
-void eventTriggerFunc(void *handler, const char *topic, const char *data,
-                      size_t *cnt) {
+int eventTriggerFunc(void *handler, const char *topic, const char *data,
+                     size_t *cnt) {
   fprintf(stdout, "[Event] [%s] %s\n", topic, data);
   *cnt = 20 + strlen(topic) + strlen(data);
+  return NVM_SUCCESS;
 }
 
 void help(const char *name) {
@@ -237,8 +238,8 @@ int main(int argc, const char *argv[]) {
   InitializeLogger(logFunc);
   InitializeRequireDelegate(RequireDelegateFunc, AttachLibVersionDelegateFunc);
   InitializeExecutionEnvDelegate(AttachLibVersionDelegateFunc);
-  InitializeStorage(StorageGet, StoragePut, StorageDel);
-  InitializeBlockchain(GetTxByHash, GetAccountState, Transfer, VerifyAddress, GetPreBlockHash, GetPreBlockSeed);
+  InitializeStorage(StorageGet, StoragePut, StorageDel, StorageKeys);
+  InitializeBlockchain(GetTxByHash, GetAccountState, Transfer, VerifyAddress, GetPreBlockHash, GetPreBlockSeed, RunContract, GetRandom, GetBlockByHeight);
   InitializeEvent(eventTriggerFunc);
 
   int argcIdx = 1;
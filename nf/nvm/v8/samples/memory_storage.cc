@@ -18,10 +18,12 @@
 //
 #include "memory_storage.h"
 
+#include <algorithm>
 #include <atomic>
 #include <mutex>
 #include <string>
 #include <unordered_map>
+#include <vector>
 
 #include <stdio.h>
 #include <stdlib.h>
@@ -85,3 +87,35 @@ int StorageDel(void *handler, const char *key, size_t *cnt) {
 
   return 0;
 }
+
+int StorageKeys(void *handler, const char *prefix, int offset, int limit,
+                size_t *cnt, char **result, char **info) {
+  string sPrefix = genKey(handler, prefix);
+  size_t handlerLen = genKey(handler, "").length();
+  vector<string> keys;
+
+  mapMutex.lock();
+  for (auto it = memoryMap.begin(); it != memoryMap.end(); ++it) {
+    if (it->first.compare(0, sPrefix.length(), sPrefix) == 0) {
+      keys.push_back(it->first.substr(handlerLen));
+    }
+  }
+  mapMutex.unlock();
+
+  // the keys are not escaped, the samples use plain keys only.
+  sort(keys.begin(), keys.end());
+  string ret = "[";
+  for (int i = offset; i < (int)keys.size() && i < offset + limit; i++) {
+    if (i > offset) {
+      ret.append(",");
+    }
+    ret.append("\"").append(keys[i]).append("\"");
+  }
+  ret.append("]");
+
+  *cnt = keys.size();
+  *info = NULL;
+  *result = (char *)calloc(ret.length() + 1, sizeof(char));
+  strncpy(*result, ret.c_str(), ret.length());
+  return 0;
+}
@@ -21,6 +21,7 @@
 #include "engine_int.h"
 #include "lib/tracing.h"
 #include "lib/typescript.h"
+#include "lib/validator.h"
 #include "lib/logger.h"
 #include "lib/nvm_error.h"
 
@@ -58,7 +59,7 @@ char *InjectTracingInstructionsThread(V8Engine *e, const char *source,
 }
 
 char *TranspileTypeScriptModuleThread(V8Engine *e, const char *source,
-                                int *source_line_offset) {
+                                int *source_line_offset, char **error) {
   v8ThreadContext ctx;
   memset(&ctx, 0x00, sizeof(ctx));
   SetRunScriptArgs(&ctx, e, INSTRUCTIONTS, source, *source_line_offset, 1);
@@ -67,6 +68,7 @@ char *TranspileTypeScriptModuleThread(V8Engine *e, const char *source,
     return NULL;
   }
   *source_line_offset = ctx.output.line_offset;
+  *error = ctx.output.error;
   return ctx.output.result;
 }
 int RunScriptSourceThread(char **result, V8Engine *e, const char *source,
@@ -87,6 +89,19 @@ int RunScriptSourceThread(char **result, V8Engine *e, const char *source,
   return ctx.output.ret;
 }
 
+// ValidateContractSourceThread return the diagnostics of the source in json,
+// NULL if the validation failed to run.
+char *ValidateContractSourceThread(V8Engine *e, const char *source) {
+  v8ThreadContext ctx;
+  memset(&ctx, 0x00, sizeof(ctx));
+  SetRunScriptArgs(&ctx, e, VALIDATE, source, 0, 1);
+  bool btn = CreateScriptThread(&ctx);
+  if (btn == false) {
+    return NULL;
+  }
+  return ctx.output.result;
+}
+
 void *ExecuteThread(void *args) {
   v8ThreadContext *ctx = (v8ThreadContext*)args;
   if (ctx->input.opt == INSTRUCTION) {
@@ -104,12 +119,22 @@ void *ExecuteThread(void *args) {
     TypeScriptContext tContext;
     tContext.source_line_offset = 0;
     tContext.js_source = NULL;
+    tContext.error = NULL;
 
     Execute(NULL, ctx->e, ctx->input.source, 0, 0L, 0L, TypeScriptTranspileDelegate,
             (void *)&tContext);
 
     ctx->output.line_offset = tContext.source_line_offset;
     ctx->output.result = static_cast<char *>(tContext.js_source);
+    ctx->output.error = tContext.error;
+  } else if (ctx->input.opt == VALIDATE) {
+    ValidatorContext vContext;
+    vContext.diagnostics = NULL;
+
+    Execute(NULL, ctx->e, ctx->input.source, 0, 0L, 0L, ValidateContractDelegate,
+            (void *)&vContext);
+
+    ctx->output.result = vContext.diagnostics;
   } else {
     ctx->output.ret = Execute(&ctx->output.result, ctx->e, ctx->input.source, ctx->input.line_offset, (void *)ctx->input.lcs,
                 (void *)ctx->input.gcs, ExecuteSourceDataDelegate, NULL);
@@ -0,0 +1,135 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package nvm
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/core/state"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// ContractSourceMaxSize the max size of the source of a contract or of a module it requires.
+	ContractSourceMaxSize = 64 * 1024
+
+	// ContractValidatorVersion the version of the js libs the validator.js is
+	// taken from, whatever the version of the libs at the height is.
+	ContractValidatorVersion = "1.1.0"
+)
+
+// ContractDiagnostic a problem found by the static validation of a contract.
+type ContractDiagnostic struct {
+	Module  string `json:"module"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+}
+
+// ContractValidationError the diagnostics of a contract failing the static validation.
+type ContractValidationError struct {
+	Diagnostics []*ContractDiagnostic
+}
+
+func (err *ContractValidationError) Error() string {
+	data, _ := json.Marshal(err.Diagnostics)
+	return fmt.Sprintf("%s: %s", ErrInvalidContractSource, data)
+}
+
+// ValidateContract check the source and the modules of a contract deployed in
+// the block statically. Sources using eval, Math.random or Date.now, regexes
+// which may backtrack exponentially and oversized sources are rejected with a
+// *ContractValidationError listing the diagnostics. The validation is best
+// effort, a source can always reach these in ways no static check follows, the
+// guards of the engine at runtime are the authoritative ones.
+func (nvm *NebulasVM) ValidateContract(block *core.Block, source, sourceType string, modules map[string]string) error {
+	if sourceType != core.SourceTypeJavaScript && sourceType != core.SourceTypeTypeScript {
+		return nil
+	}
+	engine := NewV8Engine(&Context{
+		block:    block,
+		contract: state.MockAccount(ContractValidatorVersion),
+		tx:       nil,
+		state:    nil,
+	})
+	defer engine.Dispose()
+
+	paths := make([]string, 0, len(modules))
+	for path := range modules {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	diagnostics, err := engine.validateContractSource(contractModuleID, source, sourceType)
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		found, err := engine.validateContractSource(path, modules[path], sourceType)
+		if err != nil {
+			return err
+		}
+		diagnostics = append(diagnostics, found...)
+	}
+	if len(diagnostics) > 0 {
+		return &ContractValidationError{Diagnostics: diagnostics}
+	}
+	return nil
+}
+
+// validateContractSource validate the source of the module, the typescript
+// sources are checked once transpiled.
+func (e *V8Engine) validateContractSource(module, source, sourceType string) ([]*ContractDiagnostic, error) {
+	if len(source) > ContractSourceMaxSize {
+		return []*ContractDiagnostic{{
+			Module:  module,
+			Rule:    "size",
+			Message: fmt.Sprintf("the source is larger than %d bytes", ContractSourceMaxSize),
+		}}, nil
+	}
+	if sourceType == core.SourceTypeTypeScript {
+		js, _, diagnostics, err := e.transpileTypeScript(source)
+		if err != nil {
+			return []*ContractDiagnostic{{
+				Module:  module,
+				Rule:    "syntax",
+				Message: diagnostics,
+			}}, nil
+		}
+		source = js
+	}
+
+	diagnostics, err := e.validateSource(source)
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"module": module,
+			"err":    err,
+		}).Error("Unexpected error: failed to validate contract source.")
+		return nil, core.ErrUnexpected
+	}
+	for _, d := range diagnostics {
+		d.Module = module
+	}
+	return diagnostics, nil
+}
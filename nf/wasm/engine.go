@@ -0,0 +1,225 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package wasm
+
+import (
+	"errors"
+
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/perlin-network/life/compiler"
+	"github.com/perlin-network/life/exec"
+	"github.com/sirupsen/logrus"
+)
+
+// A contract is a WebAssembly module exporting its functions without params
+// or results, it reaches the chain through the functions imported from the
+// "env" module, see Host. Floating point instructions are rejected to keep
+// the execution deterministic, and every instruction costs 1 gas.
+
+// Error Types
+var (
+	ErrInvalidModule     = errors.New("invalid wasm module")
+	ErrFunctionNotFound  = errors.New("function not found in wasm module")
+	ErrLimitHasEmpty     = errors.New("limit args has empty")
+	ErrInsufficientGas   = errors.New("insufficient gas")
+	ErrMemoryOutOfBounds = errors.New("wasm memory access out of bounds")
+	ErrExecutionFailed   = errors.New("wasm execution failed")
+	ErrExecutionReverted = errors.New("wasm execution reverted")
+)
+
+const (
+	// HostModule the module of the functions imported from the chain.
+	HostModule = "env"
+
+	// PageSize the size of a wasm memory page.
+	PageSize = 65536
+
+	// MaxMemoryPages the max memory pages of a module, 64M.
+	MaxMemoryPages = 1024
+
+	// MaxCallStackDepth the max depth of the wasm call stack.
+	MaxCallStackDepth = 1024
+
+	// NotFound returned by storage_read if the key is not found.
+	NotFound = -1
+)
+
+// Host the chain functions a contract imports, each returns the gas it costs.
+// A failed host function reverts the execution.
+type Host interface {
+	// Context return the json of the block and the transaction.
+	Context() string
+	// StorageGet return the value of the key, nil if not found.
+	StorageGet(key []byte) ([]byte, uint64, error)
+	StoragePut(key, value []byte) (uint64, error)
+	StorageDel(key []byte) (uint64, error)
+	// Transfer the value from the contract to the address.
+	Transfer(to, value string) (uint64, error)
+	// TriggerEvent record an event of the contract.
+	TriggerEvent(topic, data string) (uint64, error)
+}
+
+// Engine run wasm contracts.
+type Engine struct {
+	host   Host
+	vm     *exec.VirtualMachine
+	args   string
+	result string
+	err    error // the error aborting the execution in a host function.
+
+	limitsOfExecutionInstructions      uint64
+	limitsOfTotalMemorySize            uint64
+	actualCountOfExecutionInstructions uint64
+}
+
+// NewEngine return new Engine instance.
+func NewEngine(host Host) *Engine {
+	return &Engine{host: host}
+}
+
+// SetExecutionLimits set the gas and the memory a execution can take.
+func (e *Engine) SetExecutionLimits(limitsOfExecutionInstructions, limitsOfTotalMemorySize uint64) error {
+	if limitsOfExecutionInstructions == 0 || limitsOfTotalMemorySize < PageSize {
+		return ErrLimitHasEmpty
+	}
+	e.limitsOfExecutionInstructions = limitsOfExecutionInstructions
+	e.limitsOfTotalMemorySize = limitsOfTotalMemorySize
+	return nil
+}
+
+// ExecutionInstructions returns the execution instructions
+func (e *Engine) ExecutionInstructions() uint64 {
+	return e.actualCountOfExecutionInstructions
+}
+
+// Validate check the code is a module in the deterministic subset importing only the host functions.
+func Validate(code []byte) error {
+	_, err := compile(code, vmConfig(0, MaxMemoryPages*PageSize), &resolver{engine: NewEngine(nil)})
+	return err
+}
+
+// vmConfig return the config of the vm, no floating point and bounded memory.
+func vmConfig(gasLimit, memorySize uint64) exec.VMConfig {
+	pages := memorySize / PageSize
+	if pages > MaxMemoryPages {
+		pages = MaxMemoryPages
+	}
+	return exec.VMConfig{
+		DefaultMemoryPages:   1,
+		MaxMemoryPages:       int(pages),
+		DefaultTableSize:     PageSize,
+		MaxCallStackDepth:    MaxCallStackDepth,
+		GasLimit:             gasLimit,
+		DisableFloatingPoint: true,
+	}
+}
+
+func compile(code []byte, config exec.VMConfig, resolver exec.ImportResolver) (vm *exec.VirtualMachine, err error) {
+	// the resolver panics on unknown imports.
+	defer func() {
+		if r := recover(); r != nil {
+			logging.VLog().WithFields(logrus.Fields{
+				"err": r,
+			}).Debug("Failed to resolve wasm imports.")
+			vm, err = nil, ErrInvalidModule
+		}
+	}()
+	vm, err = exec.NewVirtualMachine(code, config, resolver, &compiler.SimpleGasPolicy{GasPerInstruction: 1})
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"err": err,
+		}).Debug("Failed to compile wasm module.")
+		return nil, ErrInvalidModule
+	}
+	return vm, nil
+}
+
+// Run the function of the module with the json args, return the result set by the contract.
+func (e *Engine) Run(code []byte, function, args string) (string, error) {
+	if e.limitsOfExecutionInstructions == 0 {
+		return "", ErrLimitHasEmpty
+	}
+	vm, err := compile(code, vmConfig(e.limitsOfExecutionInstructions, e.limitsOfTotalMemorySize), &resolver{engine: e})
+	if err != nil {
+		return "", err
+	}
+	entry, ok := vm.GetFunctionExport(function)
+	if !ok {
+		return "", ErrFunctionNotFound
+	}
+
+	e.vm, e.args, e.result, e.err = vm, args, "", nil
+	_, err = vm.Run(entry)
+	e.actualCountOfExecutionInstructions = vm.Gas
+
+	if vm.Gas > e.limitsOfExecutionInstructions {
+		e.actualCountOfExecutionInstructions = e.limitsOfExecutionInstructions
+		return "", ErrInsufficientGas
+	}
+	if e.err != nil {
+		return e.result, e.err
+	}
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"function": function,
+			"err":      err,
+		}).Debug("Wasm execution trapped.")
+		return err.Error(), ErrExecutionFailed
+	}
+	if len(e.result) == 0 {
+		return "\"\"", nil // default JSON String.
+	}
+	return e.result, nil
+}
+
+// abort stop the execution with the error, the vm recovers the panic.
+func (e *Engine) abort(err error) {
+	if e.err == nil {
+		e.err = err
+	}
+	panic(err)
+}
+
+func (e *Engine) charge(gas uint64, err error) {
+	e.vm.Gas += gas
+	if err != nil {
+		e.abort(err)
+	}
+}
+
+func (e *Engine) param(i int) int {
+	return int(uint32(e.vm.GetCurrentFrame().Locals[i]))
+}
+
+// read return the bytes at [ptr, ptr+length) of the memory.
+func (e *Engine) read(ptr, length int) []byte {
+	if ptr+length > len(e.vm.Memory) {
+		e.abort(ErrMemoryOutOfBounds)
+	}
+	data := make([]byte, length)
+	copy(data, e.vm.Memory[ptr:ptr+length])
+	return data
+}
+
+func (e *Engine) write(ptr int, data []byte) {
+	if ptr+len(data) > len(e.vm.Memory) {
+		e.abort(ErrMemoryOutOfBounds)
+	}
+	copy(e.vm.Memory[ptr:], data)
+}
@@ -0,0 +1,94 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package wasm
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/stretchr/testify/assert"
+)
+
+// (module (import "env" "set_result" (func $set_result (param i32 i32)))
+// (memory 1) (data (i32.const 0) "\"hi\"")
+// (func (export "get") (call $set_result (i32.const 0) (i32.const 4)))
+// (func (export "loop") (loop (br 0))))
+const resultModule = "0061736d0100000001090260027f7f0060000002120103656e760a7365745f726573756c74000003030201010503010001070e02036765740001046c6f6f7000020a120208004100410410000b070003400c000b0b0b0a010041000b0422686922"
+
+// (module (func (export "get") (drop (f32.const 0))))
+const floatModule = "0061736d01000000010401600000030201000707010367657400000a0a01080043000000001a0b"
+
+// (module (import "env" "time" (func)))
+const unknownImportModule = "0061736d01000000010401600000020c0103656e760474696d650000"
+
+type mockHost struct {
+	storage map[string][]byte
+}
+
+func (h *mockHost) Context() string { return "{}" }
+
+func (h *mockHost) StorageGet(key []byte) ([]byte, uint64, error) {
+	return h.storage[string(key)], 0, nil
+}
+
+func (h *mockHost) StoragePut(key, value []byte) (uint64, error) {
+	h.storage[string(key)] = value
+	return uint64(len(key) + len(value)), nil
+}
+
+func (h *mockHost) StorageDel(key []byte) (uint64, error) {
+	delete(h.storage, string(key))
+	return 0, nil
+}
+
+func (h *mockHost) Transfer(to, value string) (uint64, error) { return 0, nil }
+
+func (h *mockHost) TriggerEvent(topic, data string) (uint64, error) { return 0, nil }
+
+func mockCode(t *testing.T, module string) []byte {
+	code, err := byteutils.FromHex(module)
+	assert.Nil(t, err)
+	return code
+}
+
+func TestEngine_Run(t *testing.T) {
+	engine := NewEngine(&mockHost{storage: make(map[string][]byte)})
+	_, err := engine.Run(mockCode(t, resultModule), "get", "")
+	assert.Equal(t, ErrLimitHasEmpty, err)
+	assert.Nil(t, engine.SetExecutionLimits(10000, MaxMemoryPages*PageSize))
+
+	result, err := engine.Run(mockCode(t, resultModule), "get", "")
+	assert.Nil(t, err)
+	assert.Equal(t, "\"hi\"", result)
+	assert.True(t, engine.ExecutionInstructions() > 0)
+
+	_, err = engine.Run(mockCode(t, resultModule), "loop", "")
+	assert.Equal(t, ErrInsufficientGas, err)
+	assert.Equal(t, uint64(10000), engine.ExecutionInstructions())
+
+	_, err = engine.Run(mockCode(t, resultModule), "set", "")
+	assert.Equal(t, ErrFunctionNotFound, err)
+}
+
+func TestValidate(t *testing.T) {
+	assert.Nil(t, Validate(mockCode(t, resultModule)))
+	assert.Equal(t, ErrInvalidModule, Validate(mockCode(t, floatModule)))
+	assert.Equal(t, ErrInvalidModule, Validate(mockCode(t, unknownImportModule)))
+	assert.Equal(t, ErrInvalidModule, Validate([]byte("function init() {}")))
+}
@@ -0,0 +1,126 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package wasm
+
+import (
+	"fmt"
+
+	"github.com/perlin-network/life/exec"
+)
+
+// The functions imported from the "env" module, the params are i32:
+// args_len() -> i32, args_read(ptr): the json args of the call.
+// context_len() -> i32, context_read(ptr): the json of the block and the transaction.
+// storage_read(key_ptr, key_len, value_ptr, value_cap) -> i32: the length of
+// the value, NotFound if missing, the value is copied only if value_cap is enough.
+// storage_write(key_ptr, key_len, value_ptr, value_len)
+// storage_delete(key_ptr, key_len)
+// transfer(addr_ptr, addr_len, value_ptr, value_len): the value is a decimal string.
+// event(topic_ptr, topic_len, data_ptr, data_len)
+// set_result(ptr, len): the json result of the call.
+// revert(ptr, len): stop the execution with the message, the changes are reverted.
+
+type resolver struct {
+	engine *Engine
+}
+
+// ResolveFunc return the host function of the import, panic if unknown.
+func (r *resolver) ResolveFunc(module, field string) exec.FunctionImport {
+	if module != HostModule {
+		panic(fmt.Errorf("unknown import module %s", module))
+	}
+	e := r.engine
+	switch field {
+	case "args_len":
+		return func(vm *exec.VirtualMachine) int64 {
+			return int64(len(e.args))
+		}
+	case "args_read":
+		return func(vm *exec.VirtualMachine) int64 {
+			e.write(e.param(0), []byte(e.args))
+			return 0
+		}
+	case "context_len":
+		return func(vm *exec.VirtualMachine) int64 {
+			return int64(len(e.host.Context()))
+		}
+	case "context_read":
+		return func(vm *exec.VirtualMachine) int64 {
+			e.write(e.param(0), []byte(e.host.Context()))
+			return 0
+		}
+	case "storage_read":
+		return func(vm *exec.VirtualMachine) int64 {
+			key := e.read(e.param(0), e.param(1))
+			value, gas, err := e.host.StorageGet(key)
+			e.charge(gas, err)
+			if value == nil {
+				return NotFound
+			}
+			if len(value) <= e.param(3) {
+				e.write(e.param(2), value)
+			}
+			return int64(len(value))
+		}
+	case "storage_write":
+		return func(vm *exec.VirtualMachine) int64 {
+			key := e.read(e.param(0), e.param(1))
+			value := e.read(e.param(2), e.param(3))
+			e.charge(e.host.StoragePut(key, value))
+			return 0
+		}
+	case "storage_delete":
+		return func(vm *exec.VirtualMachine) int64 {
+			key := e.read(e.param(0), e.param(1))
+			e.charge(e.host.StorageDel(key))
+			return 0
+		}
+	case "transfer":
+		return func(vm *exec.VirtualMachine) int64 {
+			to := e.read(e.param(0), e.param(1))
+			value := e.read(e.param(2), e.param(3))
+			e.charge(e.host.Transfer(string(to), string(value)))
+			return 0
+		}
+	case "event":
+		return func(vm *exec.VirtualMachine) int64 {
+			topic := e.read(e.param(0), e.param(1))
+			data := e.read(e.param(2), e.param(3))
+			e.charge(e.host.TriggerEvent(string(topic), string(data)))
+			return 0
+		}
+	case "set_result":
+		return func(vm *exec.VirtualMachine) int64 {
+			e.result = string(e.read(e.param(0), e.param(1)))
+			return 0
+		}
+	case "revert":
+		return func(vm *exec.VirtualMachine) int64 {
+			e.result = string(e.read(e.param(0), e.param(1)))
+			e.abort(ErrExecutionReverted)
+			return 0
+		}
+	}
+	panic(fmt.Errorf("unknown import %s.%s", module, field))
+}
+
+// ResolveGlobal panic, no global is imported.
+func (r *resolver) ResolveGlobal(module, field string) int64 {
+	panic(fmt.Errorf("unknown import global %s.%s", module, field))
+}
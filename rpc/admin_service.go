@@ -19,16 +19,27 @@
 package rpc
 
 import (
+	"encoding/json"
+	"errors"
+	"sort"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/nebulasio/go-nebulas/core"
 	"github.com/nebulasio/go-nebulas/crypto/keystore"
+	"github.com/nebulasio/go-nebulas/crypto/keystore/secp256k1"
 	"github.com/nebulasio/go-nebulas/net"
 	"github.com/nebulasio/go-nebulas/rpc/pb"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/util"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/nebulasio/go-nebulas/util/logging"
 	"golang.org/x/net/context"
 )
 
+// MaxAuditNonceBlocks the most blocks audited by an AuditNonces request
+const MaxAuditNonceBlocks = 10000
+
 // AdminService implements the RPC admin service interface.
 type AdminService struct {
 	server GRPCServer
@@ -74,7 +85,7 @@ func (s *AdminService) UnlockAccount(ctx context.Context, req *rpcpb.UnlockAccou
 	if duration == 0 {
 		duration = keystore.DefaultUnlockDuration
 	}
-	err = neb.AccountManager().Unlock(addr, []byte(req.Passphrase), duration)
+	err = neb.AccountManager().UnlockWithUses(addr, []byte(req.Passphrase), duration, req.Uses)
 	if err != nil {
 		metricsUnlockFailed.Mark(1)
 		return nil, err
@@ -101,6 +112,30 @@ func (s *AdminService) LockAccount(ctx context.Context, req *rpcpb.LockAccountRe
 	return &rpcpb.LockAccountResponse{Result: true}, nil
 }
 
+// UnlockedAccounts return the unlocked accounts and when they are locked again
+func (s *AdminService) UnlockedAccounts(ctx context.Context, req *rpcpb.NonParamsRequest) (*rpcpb.UnlockedAccountsResponse, error) {
+	neb := s.server.Neblet()
+
+	infos := neb.AccountManager().UnlockedAccounts()
+	accounts := make([]*rpcpb.UnlockedAccount, 0, len(infos))
+	for _, info := range infos {
+		accounts = append(accounts, &rpcpb.UnlockedAccount{
+			Address: info.Alias,
+			Expiry:  info.Expiry.Unix(),
+			Uses:    info.Uses,
+		})
+	}
+	return &rpcpb.UnlockedAccountsResponse{Accounts: accounts}, nil
+}
+
+// LockAllAccounts lock all the unlocked accounts
+func (s *AdminService) LockAllAccounts(ctx context.Context, req *rpcpb.NonParamsRequest) (*rpcpb.LockAccountResponse, error) {
+	neb := s.server.Neblet()
+
+	neb.AccountManager().LockAll()
+	return &rpcpb.LockAccountResponse{Result: true}, nil
+}
+
 // SendTransaction is the RPC API handler.
 func (s *AdminService) SendTransaction(ctx context.Context, req *rpcpb.TransactionRequest) (*rpcpb.SendTransactionResponse, error) {
 	neb := s.server.Neblet()
@@ -254,3 +289,246 @@ func (s *AdminService) NodeInfo(ctx context.Context, req *rpcpb.NonParamsRequest
 
 	return resp, nil
 }
+
+// TraceTransaction is the RPC API handler.
+func (s *AdminService) TraceTransaction(ctx context.Context, req *rpcpb.HashRequest) (*rpcpb.TraceResponse, error) {
+	neb := s.server.Neblet()
+
+	tracer := neb.BlockChain().Tracer()
+	if tracer == nil {
+		return nil, errors.New("execution tracing is disabled")
+	}
+
+	if len(req.Hash) == 0 {
+		return nil, errors.New("please input valid hash")
+	}
+
+	txhash, err := byteutils.FromHex(req.Hash)
+	if err != nil {
+		return nil, err
+	}
+
+	trace, err := tracer.Trace(txhash)
+	if err == storage.ErrKeyNotFound {
+		return nil, errors.New("trace not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	bytes, err := json.Marshal(trace)
+	if err != nil {
+		return nil, err
+	}
+	return &rpcpb.TraceResponse{Trace: string(bytes)}, nil
+}
+
+// AuditNonces is the RPC API handler.
+func (s *AdminService) AuditNonces(ctx context.Context, req *rpcpb.AuditNoncesRequest) (*rpcpb.AuditNoncesResponse, error) {
+	chain := s.server.Neblet().BlockChain()
+
+	to := req.To
+	if to == 0 || to > chain.TailBlock().Height() {
+		to = chain.TailBlock().Height()
+	}
+	if req.From > to {
+		return nil, errors.New("invalid block height range")
+	}
+	if to-req.From >= MaxAuditNonceBlocks {
+		return nil, errors.New("too many blocks to audit")
+	}
+
+	auditor := secp256k1.NewNonceAuditor()
+	resp := new(rpcpb.AuditNoncesResponse)
+	add := func(signer *core.Address, hash, sign byteutils.Hash) error {
+		reuse, err := auditor.Add(signer.String(), hash, sign)
+		if err != nil || reuse == nil {
+			return err
+		}
+		resp.Reuses = append(resp.Reuses, &rpcpb.NonceReuse{
+			R:            byteutils.Hex(reuse.First.R()),
+			FirstSigner:  reuse.First.Signer,
+			FirstHash:    byteutils.Hex(reuse.First.Hash),
+			SecondSigner: reuse.Second.Signer,
+			SecondHash:   byteutils.Hex(reuse.Second.Hash),
+			SameSigner:   reuse.SameSigner(),
+		})
+		return nil
+	}
+
+	for height := req.From; height <= to; height++ {
+		block := chain.GetBlockOnCanonicalChainByHeight(height)
+		if block == nil {
+			return nil, errors.New("block not found")
+		}
+		// the genesis block is not signed.
+		if block.Alg() == keystore.SECP256K1 {
+			signer, err := core.RecoverSignerFromSignature(block.Alg(), block.Hash(), block.Signature())
+			if err != nil {
+				return nil, err
+			}
+			if err := add(signer, block.Hash(), block.Signature()); err != nil {
+				return nil, err
+			}
+		}
+		for _, tx := range block.Transactions() {
+			if tx.Alg() != keystore.SECP256K1 {
+				continue
+			}
+			if err := add(tx.From(), tx.Hash(), tx.Signature()); err != nil {
+				return nil, err
+			}
+		}
+	}
+	resp.Signatures = uint64(auditor.Count())
+	return resp, nil
+}
+
+// DecryptPayload is the RPC API handler.
+func (s *AdminService) DecryptPayload(ctx context.Context, req *rpcpb.DecryptPayloadRequest) (*rpcpb.DecryptPayloadResponse, error) {
+	neb := s.server.Neblet()
+
+	addr, err := core.AddressParse(req.Address)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := byteutils.FromHex(req.Hash)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := neb.BlockChain().GetTransaction(hash)
+	if err != nil && err != storage.ErrKeyNotFound {
+		return nil, err
+	}
+	if tx == nil {
+		tx = neb.BlockChain().TransactionPool().GetTransaction(hash)
+		if tx == nil {
+			return nil, errors.New("transaction not found")
+		}
+	}
+
+	payload, err := neb.AccountManager().DecryptPayload(addr, tx)
+	if err != nil {
+		return nil, err
+	}
+	return &rpcpb.DecryptPayloadResponse{Payload: payload}, nil
+}
+
+// AddPeer is the RPC API handler.
+func (s *AdminService) AddPeer(ctx context.Context, req *rpcpb.AddPeerRequest) (*rpcpb.PeerResponse, error) {
+	neb := s.server.Neblet()
+
+	id, err := neb.NetService().AddPeer(req.Address)
+	if err != nil {
+		return nil, err
+	}
+	return &rpcpb.PeerResponse{Id: id}, nil
+}
+
+// RemovePeer is the RPC API handler.
+func (s *AdminService) RemovePeer(ctx context.Context, req *rpcpb.RemovePeerRequest) (*rpcpb.PeerResponse, error) {
+	neb := s.server.Neblet()
+
+	if err := neb.NetService().RemovePeer(req.Id); err != nil {
+		return nil, err
+	}
+	return &rpcpb.PeerResponse{Id: req.Id}, nil
+}
+
+// StartMining is the RPC API handler.
+func (s *AdminService) StartMining(ctx context.Context, req *rpcpb.StartMiningRequest) (*rpcpb.MiningResponse, error) {
+	neb := s.server.Neblet()
+
+	if err := neb.Consensus().EnableMining(req.Passphrase); err != nil {
+		return nil, err
+	}
+	return &rpcpb.MiningResponse{Result: true}, nil
+}
+
+// StopMining is the RPC API handler.
+func (s *AdminService) StopMining(ctx context.Context, req *rpcpb.NonParamsRequest) (*rpcpb.MiningResponse, error) {
+	neb := s.server.Neblet()
+
+	if err := neb.Consensus().DisableMining(); err != nil {
+		return nil, err
+	}
+	return &rpcpb.MiningResponse{Result: true}, nil
+}
+
+// TxPoolContent is the RPC API handler.
+func (s *AdminService) TxPoolContent(ctx context.Context, req *rpcpb.NonParamsRequest) (*rpcpb.TxPoolContentResponse, error) {
+	neb := s.server.Neblet()
+
+	pending, queued := neb.BlockChain().TransactionPool().Content()
+
+	accounts := make(map[string]*rpcpb.TxPoolAccount)
+	account := func(txs []*core.Transaction) *rpcpb.TxPoolAccount {
+		addr := txs[0].From().String()
+		if _, ok := accounts[addr]; !ok {
+			accounts[addr] = &rpcpb.TxPoolAccount{Address: addr}
+		}
+		return accounts[addr]
+	}
+	for _, txs := range pending {
+		acc := account(txs)
+		for _, tx := range txs {
+			acc.Pending = append(acc.Pending, pooledTransactionResponse(tx))
+		}
+	}
+	for _, txs := range queued {
+		acc := account(txs)
+		for _, tx := range txs {
+			acc.Queued = append(acc.Queued, pooledTransactionResponse(tx))
+		}
+	}
+
+	resp := &rpcpb.TxPoolContentResponse{}
+	for _, acc := range accounts {
+		resp.Accounts = append(resp.Accounts, acc)
+	}
+	sort.Slice(resp.Accounts, func(i, j int) bool {
+		return resp.Accounts[i].Address < resp.Accounts[j].Address
+	})
+	return resp, nil
+}
+
+func pooledTransactionResponse(tx *core.Transaction) *rpcpb.TransactionResponse {
+	return &rpcpb.TransactionResponse{
+		ChainId:   tx.ChainID(),
+		Hash:      tx.Hash().String(),
+		From:      tx.From().String(),
+		To:        tx.To().String(),
+		Value:     tx.Value().String(),
+		Nonce:     tx.Nonce(),
+		Timestamp: tx.Timestamp(),
+		Type:      tx.Type(),
+		Data:      tx.Data(),
+		GasPrice:  tx.GasPrice().String(),
+		GasLimit:  tx.GasLimit().String(),
+		Status:    core.TxExecutionPendding,
+
+		EncryptedPayload: tx.EncryptedPayload(),
+	}
+}
+
+// SetGasFloor is the RPC API handler.
+func (s *AdminService) SetGasFloor(ctx context.Context, req *rpcpb.SetGasFloorRequest) (*rpcpb.SetGasFloorResponse, error) {
+	neb := s.server.Neblet()
+
+	gasPrice, err := util.NewUint128FromString(req.GasPrice)
+	if err != nil {
+		return nil, err
+	}
+	if err := neb.BlockChain().TransactionPool().SetGasFloor(gasPrice); err != nil {
+		return nil, err
+	}
+	return &rpcpb.SetGasFloorResponse{GasPrice: gasPrice.String()}, nil
+}
+
+// SetLogLevel is the RPC API handler.
+func (s *AdminService) SetLogLevel(ctx context.Context, req *rpcpb.SetLogLevelRequest) (*rpcpb.SetLogLevelResponse, error) {
+	if err := logging.SetLevel(req.Level); err != nil {
+		return nil, err
+	}
+	return &rpcpb.SetLogLevelResponse{Level: req.Level}, nil
+}
@@ -20,6 +20,7 @@ package rpc
 
 import (
 	"errors"
+	"strconv"
 
 	"github.com/nebulasio/go-nebulas/storage"
 	"github.com/nebulasio/go-nebulas/util/logging"
@@ -30,6 +31,7 @@ import (
 	"github.com/gogo/protobuf/proto"
 	"github.com/nebulasio/go-nebulas/core"
 	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/crypto/keystore"
 	"github.com/nebulasio/go-nebulas/net"
 	"github.com/nebulasio/go-nebulas/rpc/pb"
 	"github.com/nebulasio/go-nebulas/util"
@@ -40,6 +42,12 @@ import (
 //the max number of block can be dumped once
 const maxDumpBlockCount = 10
 
+// MaxAccountStates the most accounts queried by a GetAccountStates request
+const MaxAccountStates = 1000
+
+// MaxTransactionsByAddress the most txs returned by a GetTransactionsByAddress request
+const MaxTransactionsByAddress = 100
+
 // APIService implements the RPC API service interface.
 type APIService struct {
 	server GRPCServer
@@ -94,6 +102,52 @@ func (s *APIService) GetAccountState(ctx context.Context, req *rpcpb.GetAccountS
 	return &rpcpb.GetAccountStateResponse{Balance: acc.Balance().String(), Nonce: acc.Nonce(), Type: uint32(addr.Type())}, nil
 }
 
+// GetAccountStates is the RPC API handler.
+func (s *APIService) GetAccountStates(ctx context.Context, req *rpcpb.GetAccountStatesRequest) (*rpcpb.GetAccountStatesResponse, error) {
+
+	neb := s.server.Neblet()
+
+	if len(req.Addresses) > MaxAccountStates {
+		return nil, errors.New("too many addresses, the max is " + strconv.Itoa(MaxAccountStates))
+	}
+	addrs := make([]*core.Address, len(req.Addresses))
+	for i, v := range req.Addresses {
+		addr, err := core.AddressParse(v)
+		if err != nil {
+			metricsAccountStateFailed.Mark(1)
+			return nil, errors.New("invalid address " + v)
+		}
+		addrs[i] = addr
+	}
+
+	// all the states are read from the same block.
+	block := neb.BlockChain().TailBlock()
+	if req.Height > 0 {
+		block = neb.BlockChain().GetBlockOnCanonicalChainByHeight(req.Height)
+		if block == nil {
+			metricsAccountStateFailed.Mark(1)
+			return nil, errors.New("block not found")
+		}
+	}
+
+	resp := &rpcpb.GetAccountStatesResponse{States: make([]*rpcpb.AccountState, len(addrs))}
+	for i, addr := range addrs {
+		acc, err := block.GetAccount(addr.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		resp.States[i] = &rpcpb.AccountState{
+			Address: addr.String(),
+			Balance: acc.Balance().String(),
+			Nonce:   acc.Nonce(),
+			Type:    uint32(addr.Type()),
+		}
+	}
+
+	metricsAccountStateSuccess.Mark(1)
+	return resp, nil
+}
+
 // Call is the RPC API handler.
 func (s *APIService) Call(ctx context.Context, req *rpcpb.TransactionRequest) (*rpcpb.CallResponse, error) {
 	neb := s.server.Neblet()
@@ -102,7 +156,7 @@ func (s *APIService) Call(ctx context.Context, req *rpcpb.TransactionRequest) (*
 		return nil, err
 	}
 
-	result, err := neb.BlockChain().SimulateTransactionExecution(tx)
+	result, err := neb.BlockChain().StaticCallTransaction(tx)
 	if err != nil {
 		return nil, err
 	}
@@ -156,6 +210,9 @@ func parseTransaction(neb core.Neblet, reqTx *rpcpb.TransactionRequest) (*core.T
 	if err != nil {
 		return nil, err
 	}
+	if err := tx.SetEncryptedPayload(reqTx.EncryptedPayload); err != nil {
+		return nil, err
+	}
 	return tx, nil
 }
 
@@ -248,6 +305,10 @@ func handleTransactionResponse(neb core.Neblet, tx *core.Transaction) (resp *rpc
 		}
 	}()
 
+	if neb.BlockChain().ReadOnly() {
+		return nil, core.ErrReadOnlyChain
+	}
+
 	tailBlock := neb.BlockChain().TailBlock()
 	acc, err := tailBlock.GetAccount(tx.From().Bytes())
 	if err != nil {
@@ -276,6 +337,30 @@ func handleTransactionResponse(neb core.Neblet, tx *core.Transaction) (resp *rpc
 		if !tx.From().Equals(tx.To()) {
 			return nil, core.ErrContractTransactionAddressNotEqual
 		}
+		// typed contracts are transpiled by the node, reject the invalid ones
+		// before they are broadcast.
+		deploy, err := core.LoadDeployPayload(tx.Data())
+		if err != nil {
+			return nil, err
+		}
+		if deploy.SourceType == core.SourceTypeTypeScript {
+			if err := neb.Nvm().CheckTypeScript(tailBlock, deploy.Source); err != nil {
+				return nil, err
+			}
+		}
+		if tailBlock.Height()+1 >= core.ContractValidationHeight {
+			if err := neb.Nvm().ValidateContract(tailBlock, deploy.Source, deploy.SourceType, deploy.Modules); err != nil {
+				return nil, err
+			}
+		}
+	} else if tx.Type() == core.TxPayloadUpgradeType && tailBlock.Height()+1 >= core.ContractValidationHeight {
+		upgrade, err := core.LoadUpgradePayload(tx.Data())
+		if err != nil {
+			return nil, err
+		}
+		if err := neb.Nvm().ValidateContract(tailBlock, upgrade.Source, upgrade.SourceType, upgrade.Modules); err != nil {
+			return nil, err
+		}
 	} else if tx.Type() == core.TxPayloadCallType {
 		if _, err := tailBlock.CheckContract(tx.To()); err != nil {
 			return nil, err
@@ -319,6 +404,97 @@ func (s *APIService) SendRawTransaction(ctx context.Context, req *rpcpb.SendRawT
 	return handleTransactionResponse(neb, tx)
 }
 
+// BuildTransaction build the unsigned transaction to sign offline, the nonce,
+// gas price and gas limit not given are filled by the pending nonce of the
+// sender, the standard suggested gas price and the estimated gas.
+func (s *APIService) BuildTransaction(ctx context.Context, req *rpcpb.TransactionRequest) (*rpcpb.BuildTransactionResponse, error) {
+	neb := s.server.Neblet()
+
+	reqTx := *req
+	if reqTx.Nonce == 0 {
+		from, err := core.AddressParse(reqTx.From)
+		if err != nil {
+			return nil, err
+		}
+		nonce, ok := neb.BlockChain().TransactionPool().NextNonce(from)
+		if !ok {
+			return nil, errors.New("cannot find the nonce of the sender")
+		}
+		reqTx.Nonce = nonce
+	}
+	if len(reqTx.GasPrice) == 0 {
+		reqTx.GasPrice = neb.BlockChain().GasPriceOracle().Suggest().Standard.String()
+	}
+	estimate := len(reqTx.GasLimit) == 0
+	if estimate {
+		reqTx.GasLimit = core.TransactionMaxGas.String()
+	}
+
+	tx, err := parseTransaction(neb, &reqTx)
+	if err != nil {
+		return nil, err
+	}
+	if estimate {
+		result, err := neb.BlockChain().EstimateGas(tx)
+		if err != nil {
+			return nil, err
+		}
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		reqTx.GasLimit = result.GasUsed.String()
+		if tx, err = parseTransaction(neb, &reqTx); err != nil {
+			return nil, err
+		}
+	}
+
+	hash, err := tx.SigningHash()
+	if err != nil {
+		return nil, err
+	}
+	pbMsg, err := tx.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	data, err := proto.Marshal(pbMsg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rpcpb.BuildTransactionResponse{
+		Data:     data,
+		Hash:     hash.String(),
+		Nonce:    tx.Nonce(),
+		GasPrice: tx.GasPrice().String(),
+		GasLimit: tx.GasLimit().String(),
+		ChainId:  tx.ChainID(),
+	}, nil
+}
+
+// SendSignedTransaction assemble the transaction built by BuildTransaction
+// with the signature produced offline, then submit it to the tx pool.
+func (s *APIService) SendSignedTransaction(ctx context.Context, req *rpcpb.SendSignedTransactionRequest) (*rpcpb.SendTransactionResponse, error) {
+	neb := s.server.Neblet()
+
+	pbTx := new(corepb.Transaction)
+	if err := proto.Unmarshal(req.Data, pbTx); err != nil {
+		metricsSendTxFailed.Mark(1)
+		return nil, err
+	}
+	pbTx.Alg = req.Alg
+	tx := new(core.Transaction)
+	if err := tx.FromProto(pbTx); err != nil {
+		metricsSendTxFailed.Mark(1)
+		return nil, err
+	}
+	if err := tx.SetSignature(keystore.Algorithm(req.Alg), req.Signature); err != nil {
+		metricsSendTxFailed.Mark(1)
+		return nil, err
+	}
+
+	return handleTransactionResponse(neb, tx)
+}
+
 // GetBlockByHash get block info by the block hash
 func (s *APIService) GetBlockByHash(ctx context.Context, req *rpcpb.GetBlockByHashRequest) (*rpcpb.BlockResponse, error) {
 
@@ -420,6 +596,37 @@ func (s *APIService) GetTransactionReceipt(ctx context.Context, req *rpcpb.GetTr
 	return s.toTransactionResponse(tx)
 }
 
+// GetTransactionsByAddress get a page of the canonical transactions sent from or to the address
+func (s *APIService) GetTransactionsByAddress(ctx context.Context, req *rpcpb.GetTransactionsByAddressRequest) (*rpcpb.GetTransactionsByAddressResponse, error) {
+	neb := s.server.Neblet()
+
+	addr, err := core.AddressParse(req.Address)
+	if err != nil {
+		return nil, err
+	}
+	limit := req.Limit
+	if limit == 0 || limit > MaxTransactionsByAddress {
+		limit = MaxTransactionsByAddress
+	}
+
+	hashes, total, err := neb.BlockChain().GetTransactionsByAddress(addr, req.Offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([]*rpcpb.TransactionResponse, len(hashes))
+	for idx, hash := range hashes {
+		tx, err := neb.BlockChain().GetTransaction(hash)
+		if err != nil {
+			return nil, err
+		}
+		if txs[idx], err = s.toTransactionResponse(tx); err != nil {
+			return nil, err
+		}
+	}
+	return &rpcpb.GetTransactionsByAddressResponse{Transactions: txs, Total: total}, nil
+}
+
 // GetTransactionByContract get transaction info by the contract address
 func (s *APIService) GetTransactionByContract(ctx context.Context, req *rpcpb.GetTransactionByContractRequest) (*rpcpb.TransactionResponse, error) {
 
@@ -453,6 +660,31 @@ func (s *APIService) toTransactionResponse(tx *core.Transaction) (*rpcpb.Transac
 		execute_result string
 	)
 	neb := s.server.Neblet()
+
+	// use the receipt recorded on canonical chain if any.
+	if receipt, err := neb.BlockChain().GetReceipt(tx.Hash()); err == nil {
+		return &rpcpb.TransactionResponse{
+			ChainId:         tx.ChainID(),
+			Hash:            tx.Hash().String(),
+			From:            tx.From().String(),
+			To:              tx.To().String(),
+			Value:           tx.Value().String(),
+			Nonce:           tx.Nonce(),
+			Timestamp:       tx.Timestamp(),
+			Type:            tx.Type(),
+			Data:            tx.Data(),
+			GasPrice:        tx.GasPrice().String(),
+			GasLimit:        tx.GasLimit().String(),
+			ContractAddress: receipt.ContractAddress,
+			Status:          int32(receipt.Status),
+			GasUsed:         receipt.GasUsed,
+			ExecuteError:    receipt.Error,
+			ExecuteResult:   receipt.ExecuteResult,
+
+			EncryptedPayload: tx.EncryptedPayload(),
+		}, nil
+	}
+
 	event, err := neb.BlockChain().TailBlock().FetchExecutionResultEvent(tx.Hash())
 	if err != nil && err != core.ErrNotFoundTransactionResultEvent {
 		return nil, err
@@ -501,6 +733,8 @@ func (s *APIService) toTransactionResponse(tx *core.Transaction) (*rpcpb.Transac
 		GasUsed:       gasUsed,
 		ExecuteError:  execute_error,
 		ExecuteResult: execute_result,
+
+		EncryptedPayload: tx.EncryptedPayload(),
 	}
 
 	if tx.Type() == core.TxPayloadDeployType {
@@ -518,9 +752,8 @@ func (s *APIService) Subscribe(req *rpcpb.SubscribeRequest, gs rpcpb.ApiService_
 
 	neb := s.server.Neblet()
 
-	eventSub := core.NewEventSubscriber(1024, req.Topics)
-	neb.EventEmitter().Register(eventSub)
-	defer neb.EventEmitter().Deregister(eventSub)
+	eventSub := neb.EventEmitter().Subscribe(1024, req.Topics...)
+	defer neb.EventEmitter().Unsubscribe(eventSub)
 
 	var err error
 	for {
@@ -540,7 +773,13 @@ func (s *APIService) Subscribe(req *rpcpb.SubscribeRequest, gs rpcpb.ApiService_
 func (s *APIService) GetGasPrice(ctx context.Context, req *rpcpb.NonParamsRequest) (*rpcpb.GasPriceResponse, error) {
 	neb := s.server.Neblet()
 	gasPrice := neb.BlockChain().GasPrice()
-	return &rpcpb.GasPriceResponse{GasPrice: gasPrice.String()}, nil
+	suggestion := neb.BlockChain().GasPriceOracle().Suggest()
+	return &rpcpb.GasPriceResponse{
+		GasPrice: gasPrice.String(),
+		SafeLow:  suggestion.SafeLow.String(),
+		Standard: suggestion.Standard.String(),
+		Fast:     suggestion.Fast.String(),
+	}, nil
 }
 
 // EstimateGas Compute the smart contract gas consumption.
@@ -551,7 +790,7 @@ func (s *APIService) EstimateGas(ctx context.Context, req *rpcpb.TransactionRequ
 		return nil, err
 	}
 
-	result, err := neb.BlockChain().SimulateTransactionExecution(tx)
+	result, err := neb.BlockChain().EstimateGas(tx)
 	if err != nil {
 		return nil, err
 	}
@@ -560,7 +799,7 @@ func (s *APIService) EstimateGas(ctx context.Context, req *rpcpb.TransactionRequ
 	if result.Err != nil {
 		errMsg = result.Err.Error()
 	}
-	return &rpcpb.GasResponse{Gas: result.GasUsed.String(), Err: errMsg}, nil
+	return &rpcpb.GasResponse{Gas: result.GasUsed.String(), Err: errMsg, Result: result.Msg}, nil
 }
 
 // GetEventsByHash return events by tx hash.
@@ -627,3 +866,79 @@ func (s *APIService) GetDynasty(ctx context.Context, req *rpcpb.ByBlockHeightReq
 	}
 	return &rpcpb.GetDynastyResponse{Miners: result}, nil
 }
+
+// GetContractEvents is the RPC API handler.
+func (s *APIService) GetContractEvents(ctx context.Context, req *rpcpb.ContractEventsRequest) (*rpcpb.ContractEventsResponse, error) {
+	neb := s.server.Neblet()
+
+	addr, err := core.AddressParse(req.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := neb.BlockChain().GetContractEvents(req.From, req.To, addr, req.Topic)
+	if err != nil {
+		return nil, err
+	}
+	return contractEventsResponse(result), nil
+}
+
+// GetEventsByFilter is the RPC API handler.
+func (s *APIService) GetEventsByFilter(ctx context.Context, req *rpcpb.EventsFilterRequest) (*rpcpb.ContractEventsResponse, error) {
+	neb := s.server.Neblet()
+
+	addrs := make([]*core.Address, len(req.Addresses))
+	for idx, v := range req.Addresses {
+		addr, err := core.AddressParse(v)
+		if err != nil {
+			return nil, err
+		}
+		addrs[idx] = addr
+	}
+
+	result, err := neb.BlockChain().GetEventsByFilter(req.FromBlock, req.ToBlock, addrs, req.Topics)
+	if err != nil {
+		return nil, err
+	}
+	return contractEventsResponse(result), nil
+}
+
+func contractEventsResponse(result []*core.ContractEvent) *rpcpb.ContractEventsResponse {
+	events := make([]*rpcpb.ContractEvent, len(result))
+	for idx, v := range result {
+		events[idx] = &rpcpb.ContractEvent{
+			BlockHeight: v.BlockHeight,
+			BlockHash:   v.BlockHash,
+			TxHash:      v.TxHash,
+			Address:     v.Address,
+			Topic:       v.Topic,
+			Data:        v.Data,
+		}
+	}
+	return &rpcpb.ContractEventsResponse{Events: events}
+}
+
+// GetContractABI is the RPC API handler.
+func (s *APIService) GetContractABI(ctx context.Context, req *rpcpb.GetAccountStateRequest) (*rpcpb.ContractABIResponse, error) {
+	neb := s.server.Neblet()
+
+	addr, err := core.AddressParse(req.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	block := neb.BlockChain().TailBlock()
+	if req.Height > 0 {
+		block = neb.BlockChain().GetBlockOnCanonicalChainByHeight(req.Height)
+		if block == nil {
+			return nil, errors.New("block not found")
+		}
+	}
+
+	contract, err := block.CheckContract(addr)
+	if err != nil {
+		return nil, err
+	}
+	meta := contract.ContractMeta()
+	return &rpcpb.ContractABIResponse{Abi: meta.GetAbi(), CodeVersion: meta.GetCodeVersion()}, nil
+}
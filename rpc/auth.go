@@ -0,0 +1,281 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package rpc
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/nebulasio/go-nebulas/neblet/pb"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Method sets the credentials are granted
+const (
+	MethodSetReadOnly = "read-only"
+	MethodSetSendTx   = "send-tx"
+	MethodSetAdmin    = "admin"
+)
+
+// Auth errors
+var (
+	ErrUnknownMethodSet   = errors.New("unknown rpc method set")
+	ErrEmptyCredential    = errors.New("rpc credential has neither api key nor jwt secret")
+	ErrMissingCredential  = errors.New("missing rpc credential")
+	ErrInvalidCredential  = errors.New("invalid rpc credential")
+	ErrMethodNotAllowed   = errors.New("rpc method not allowed by the credential")
+	ErrInvalidJWT         = errors.New("invalid jwt")
+	ErrUnsupportedJWTAlgo = errors.New("unsupported jwt algorithm, HS256 only")
+	ErrExpiredJWT         = errors.New("jwt is expired or not valid yet")
+)
+
+// methodSet return the method set a grpc method belongs to.
+func methodSet(method string) string {
+	switch {
+	case strings.HasPrefix(method, "/rpcpb.AdminService/"):
+		return MethodSetAdmin
+	case method == "/rpcpb.ApiService/SendRawTransaction", method == "/rpcpb.ApiService/SendSignedTransaction":
+		return MethodSetSendTx
+	default:
+		return MethodSetReadOnly
+	}
+}
+
+type rpcCredential struct {
+	name      string
+	apiKey    []byte
+	jwtSecret []byte
+	allow     map[string]bool
+}
+
+// rpcAuth authorizes the requests by the bearer tokens, an api key or a JWT
+// signed by the secret of a credential. A nil rpcAuth authorizes all.
+type rpcAuth struct {
+	credentials []*rpcCredential
+	anonymous   map[string]bool
+}
+
+func newMethodSets(sets []string) (map[string]bool, error) {
+	allow := make(map[string]bool)
+	for _, v := range sets {
+		switch v {
+		case MethodSetReadOnly, MethodSetSendTx, MethodSetAdmin:
+			allow[v] = true
+		default:
+			return nil, ErrUnknownMethodSet
+		}
+	}
+	return allow, nil
+}
+
+// newRPCAuth returns the authorizer of the credentials in config, nil if none.
+func newRPCAuth(config *nebletpb.RPCConfig) (*rpcAuth, error) {
+	if len(config.Credentials) == 0 {
+		return nil, nil
+	}
+	anonymous, err := newMethodSets(config.Anonymous)
+	if err != nil {
+		return nil, err
+	}
+	auth := &rpcAuth{anonymous: anonymous}
+	for _, v := range config.Credentials {
+		if len(v.ApiKey) == 0 && len(v.JwtSecret) == 0 {
+			return nil, ErrEmptyCredential
+		}
+		allow, err := newMethodSets(v.Allow)
+		if err != nil {
+			return nil, err
+		}
+		auth.credentials = append(auth.credentials, &rpcCredential{
+			name:      v.Name,
+			apiKey:    []byte(v.ApiKey),
+			jwtSecret: []byte(v.JwtSecret),
+			allow:     allow,
+		})
+	}
+	return auth, nil
+}
+
+// authorize check the bearer token is allowed to call the method.
+func (a *rpcAuth) authorize(token, method string) error {
+	if a == nil {
+		return nil
+	}
+	set := methodSet(method)
+	if len(token) == 0 {
+		if a.anonymous[set] {
+			return nil
+		}
+		return ErrMissingCredential
+	}
+
+	cred, err := a.credential(token)
+	if err != nil {
+		return err
+	}
+	if !cred.allow[set] {
+		logging.VLog().WithFields(logrus.Fields{
+			"method":     method,
+			"credential": cred.name,
+		}).Debug("Refused rpc method not allowed by the credential.")
+		return ErrMethodNotAllowed
+	}
+	return nil
+}
+
+func (a *rpcAuth) credential(token string) (*rpcCredential, error) {
+	if strings.Count(token, ".") == 2 {
+		err := ErrInvalidCredential
+		for _, v := range a.credentials {
+			if len(v.jwtSecret) == 0 {
+				continue
+			}
+			if err = verifyJWT(token, v.jwtSecret, time.Now()); err == nil {
+				return v, nil
+			}
+		}
+		return nil, err
+	}
+	for _, v := range a.credentials {
+		if len(v.apiKey) > 0 && subtle.ConstantTimeCompare(v.apiKey, []byte(token)) == 1 {
+			return v, nil
+		}
+	}
+	return nil, ErrInvalidCredential
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+}
+
+type jwtClaims struct {
+	Exp int64 `json:"exp"`
+	Nbf int64 `json:"nbf"`
+}
+
+// verifyJWT verify the HS256 signature of the JWT and its time claims.
+func verifyJWT(token string, secret []byte, now time.Time) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return ErrInvalidJWT
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return ErrInvalidJWT
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(sig, mac.Sum(nil)) {
+		return ErrInvalidJWT
+	}
+
+	header := new(jwtHeader)
+	if err := decodeJWTPart(parts[0], header); err != nil {
+		return err
+	}
+	if header.Alg != "HS256" {
+		return ErrUnsupportedJWTAlgo
+	}
+	claims := new(jwtClaims)
+	if err := decodeJWTPart(parts[1], claims); err != nil {
+		return err
+	}
+	if (claims.Exp > 0 && now.Unix() >= claims.Exp) || (claims.Nbf > 0 && now.Unix() < claims.Nbf) {
+		return ErrExpiredJWT
+	}
+	return nil
+}
+
+func decodeJWTPart(part string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return ErrInvalidJWT
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return ErrInvalidJWT
+	}
+	return nil
+}
+
+// bearerToken return the token in an "authorization: Bearer <token>" header.
+func bearerToken(values ...string) string {
+	for _, v := range values {
+		if len(v) > 7 && strings.EqualFold(v[:7], "bearer ") {
+			return strings.TrimSpace(v[7:])
+		}
+	}
+	return ""
+}
+
+func grpcBearerToken(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	return bearerToken(md["authorization"]...)
+}
+
+// httpBearerToken return the bearer token of the request, or its "token"
+// query parameter for the clients unable to set the headers, e.g. browser websockets.
+func httpBearerToken(r *http.Request) string {
+	if token := bearerToken(r.Header.Get("Authorization")); len(token) > 0 {
+		return token
+	}
+	return r.URL.Query().Get("token")
+}
+
+func authStatus(err error) error {
+	switch err {
+	case ErrMethodNotAllowed:
+		return status.Error(codes.PermissionDenied, err.Error())
+	default:
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+}
+
+func authUnary(auth *rpcAuth) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := auth.authorize(grpcBearerToken(ctx), info.FullMethod); err != nil {
+			return nil, authStatus(err)
+		}
+		return handler(ctx, req)
+	}
+}
+
+func authStream(auth *rpcAuth) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := auth.authorize(grpcBearerToken(ss.Context()), info.FullMethod); err != nil {
+			return authStatus(err)
+		}
+		return handler(srv, ss)
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package rpc
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/nebulasio/go-nebulas/neblet/pb"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+func signJWT(header, claims string, secret []byte) string {
+	enc := base64.RawURLEncoding
+	data := enc.EncodeToString([]byte(header)) + "." + enc.EncodeToString([]byte(claims))
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(data))
+	return data + "." + enc.EncodeToString(mac.Sum(nil))
+}
+
+func TestNewRPCAuth(t *testing.T) {
+	auth, err := newRPCAuth(&nebletpb.RPCConfig{})
+	assert.Nil(t, err)
+	assert.Nil(t, auth)
+	assert.Nil(t, auth.authorize("", "/rpcpb.AdminService/NodeInfo"))
+
+	_, err = newRPCAuth(&nebletpb.RPCConfig{Credentials: []*nebletpb.RPCCredential{{Allow: []string{MethodSetAdmin}}}})
+	assert.Equal(t, ErrEmptyCredential, err)
+	_, err = newRPCAuth(&nebletpb.RPCConfig{Credentials: []*nebletpb.RPCCredential{{ApiKey: "key", Allow: []string{"all"}}}})
+	assert.Equal(t, ErrUnknownMethodSet, err)
+	_, err = newRPCAuth(&nebletpb.RPCConfig{
+		Credentials: []*nebletpb.RPCCredential{{ApiKey: "key"}},
+		Anonymous:   []string{"readonly"},
+	})
+	assert.Equal(t, ErrUnknownMethodSet, err)
+}
+
+func TestRPCAuthorize(t *testing.T) {
+	secret := []byte("jwt secret")
+	auth, err := newRPCAuth(&nebletpb.RPCConfig{
+		Credentials: []*nebletpb.RPCCredential{
+			{Name: "wallet", ApiKey: "wallet key", Allow: []string{MethodSetReadOnly, MethodSetSendTx}},
+			{Name: "ops", JwtSecret: string(secret), Allow: []string{MethodSetAdmin}},
+		},
+		Anonymous: []string{MethodSetReadOnly},
+	})
+	assert.Nil(t, err)
+
+	now := time.Now().Unix()
+	header := `{"alg":"HS256","typ":"JWT"}`
+	validJWT := signJWT(header, `{"sub":"ops"}`, secret)
+	expiredJWT := signJWT(header, `{"exp":`+strconv.FormatInt(now-10, 10)+`}`, secret)
+	futureJWT := signJWT(header, `{"nbf":`+strconv.FormatInt(now+100, 10)+`}`, secret)
+	noneJWT := signJWT(`{"alg":"none"}`, `{}`, secret)
+	forgedJWT := signJWT(header, `{}`, []byte("other secret"))
+
+	getNebState := "/rpcpb.ApiService/GetNebState"
+	sendRawTx := "/rpcpb.ApiService/SendRawTransaction"
+	nodeInfo := "/rpcpb.AdminService/NodeInfo"
+
+	tests := []struct {
+		name   string
+		token  string
+		method string
+		err    error
+	}{
+		{"anonymous read-only", "", getNebState, nil},
+		{"anonymous send-tx", "", sendRawTx, ErrMissingCredential},
+		{"anonymous signed tx", "", "/rpcpb.ApiService/SendSignedTransaction", ErrMissingCredential},
+		{"anonymous admin", "", nodeInfo, ErrMissingCredential},
+		{"api key send-tx", "wallet key", sendRawTx, nil},
+		{"api key admin", "wallet key", nodeInfo, ErrMethodNotAllowed},
+		{"wrong api key", "wallet", getNebState, ErrInvalidCredential},
+		{"jwt admin", validJWT, nodeInfo, nil},
+		{"jwt read-only", validJWT, getNebState, ErrMethodNotAllowed},
+		{"expired jwt", expiredJWT, nodeInfo, ErrExpiredJWT},
+		{"not yet valid jwt", futureJWT, nodeInfo, ErrExpiredJWT},
+		{"unsupported jwt algorithm", noneJWT, nodeInfo, ErrUnsupportedJWTAlgo},
+		{"forged jwt", forgedJWT, nodeInfo, ErrInvalidJWT},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.err, auth.authorize(tt.token, tt.method))
+		})
+	}
+}
+
+func TestAuthUnary(t *testing.T) {
+	auth, err := newRPCAuth(&nebletpb.RPCConfig{
+		Credentials: []*nebletpb.RPCCredential{{ApiKey: "key", Allow: []string{MethodSetAdmin}}},
+	})
+	assert.Nil(t, err)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: "/rpcpb.AdminService/NodeInfo"}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer key"))
+	resp, err := authUnary(auth)(ctx, nil, info, handler)
+	assert.Nil(t, err)
+	assert.Equal(t, "ok", resp)
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer other"))
+	_, err = authUnary(auth)(ctx, nil, info, handler)
+	assert.Equal(t, codes.Unauthenticated, grpc.Code(err))
+
+	_, err = authUnary(auth)(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/rpcpb.ApiService/GetNebState"}, handler)
+	assert.Equal(t, codes.Unauthenticated, grpc.Code(err))
+}
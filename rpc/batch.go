@@ -0,0 +1,157 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package rpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// The http clients post an array of requests to BatchPath and receive the
+// array of their responses in the same order in one round trip, e.g.
+// [{"method":"POST","path":"/v1/user/accountstate","body":{"address":"n1..."}},
+// {"method":"GET","path":"/v1/user/nebstate"}]. Each request is served as if
+// it is sent alone, authorized and rate limited by its own.
+const (
+	BatchPath        = "/v1/batch"
+	MaxBatchRequests = 100
+)
+
+// BatchRequest a request in a batch
+type BatchRequest struct {
+	Method string          `json:"method"`
+	Path   string          `json:"path"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+// BatchResponse the response of a request in a batch
+type BatchResponse struct {
+	Status int             `json:"status"`
+	Body   json.RawMessage `json:"body"`
+}
+
+type batchResponseWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (w *batchResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *batchResponseWriter) Write(data []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.body.Write(data)
+}
+
+func (w *batchResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+func writeBatchError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(errorBody{Err: msg})
+}
+
+// batchHandler serves the batches posted to BatchPath by h, the other
+// requests are passed to h.
+func batchHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != BatchPath {
+			h.ServeHTTP(w, r)
+			return
+		}
+		if r.Method != "POST" {
+			writeBatchError(w, http.StatusMethodNotAllowed, "batch must be posted")
+			return
+		}
+
+		var reqs []*BatchRequest
+		if err := json.NewDecoder(r.Body).Decode(&reqs); err != nil {
+			writeBatchError(w, http.StatusBadRequest, "invalid batch: "+err.Error())
+			return
+		}
+		if len(reqs) == 0 || len(reqs) > MaxBatchRequests {
+			writeBatchError(w, http.StatusBadRequest, "batch must have 1 to "+strconv.Itoa(MaxBatchRequests)+" requests")
+			return
+		}
+
+		resps := make([]*BatchResponse, len(reqs))
+		for i, req := range reqs {
+			resps[i] = serveBatchRequest(h, r, req)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resps)
+	})
+}
+
+func serveBatchRequest(h http.Handler, batch *http.Request, req *BatchRequest) *BatchResponse {
+	fail := func(status int, msg string) *BatchResponse {
+		body, _ := json.Marshal(errorBody{Err: msg})
+		return &BatchResponse{Status: status, Body: body}
+	}
+	if !strings.HasPrefix(req.Path, "/v1/") || strings.HasPrefix(req.Path, BatchPath) {
+		return fail(http.StatusBadRequest, "invalid path "+req.Path)
+	}
+	method := strings.ToUpper(req.Method)
+	if len(method) == 0 {
+		method = "POST"
+	}
+
+	var body []byte
+	if len(req.Body) > 0 && string(req.Body) != "null" {
+		body = req.Body
+	}
+	sub, err := http.NewRequest(method, req.Path, bytes.NewReader(body))
+	if err != nil {
+		return fail(http.StatusBadRequest, err.Error())
+	}
+	sub = sub.WithContext(batch.Context())
+	for k, v := range batch.Header {
+		sub.Header[k] = v
+	}
+	sub.Header.Set("Content-Type", "application/json")
+	sub.RemoteAddr = batch.RemoteAddr
+	sub.Host = batch.Host
+
+	rw := &batchResponseWriter{header: make(http.Header)}
+	h.ServeHTTP(rw, sub)
+	if rw.status == 0 {
+		rw.status = http.StatusOK
+	}
+
+	resp := &BatchResponse{Status: rw.status, Body: json.RawMessage("null")}
+	if data := bytes.TrimSpace(rw.body.Bytes()); len(data) > 0 {
+		if json.Valid(data) {
+			resp.Body = data
+		} else {
+			resp.Body, _ = json.Marshal(string(data))
+		}
+	}
+	return resp
+}
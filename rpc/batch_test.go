@@ -0,0 +1,81 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package rpc
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchHandler(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/echo", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Write([]byte(`{"method":"` + r.Method + `","auth":"` + r.Header.Get("Authorization") + `","remote":"` + r.RemoteAddr + `","body":` + string(body) + `}`))
+	})
+	mux.HandleFunc("/v1/fail", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"error":"too many requests"}`))
+	})
+	mux.HandleFunc("/v1/text", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("plain"))
+	})
+	h := batchHandler(mux)
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", BatchPath, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer key")
+		req.RemoteAddr = "10.0.0.1:1234"
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		return w
+	}
+
+	w := post(`[{"method":"POST","path":"/v1/echo","body":{"a":1}},{"path":"/v1/fail"},{"method":"get","path":"/v1/text"},{"path":"/v1/batch"},{"path":"/v1/none"}]`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resps []*BatchResponse
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &resps))
+	assert.Equal(t, 5, len(resps))
+
+	assert.Equal(t, http.StatusOK, resps[0].Status)
+	assert.JSONEq(t, `{"method":"POST","auth":"Bearer key","remote":"10.0.0.1:1234","body":{"a":1}}`, string(resps[0].Body))
+	assert.Equal(t, http.StatusTooManyRequests, resps[1].Status)
+	assert.JSONEq(t, `{"error":"too many requests"}`, string(resps[1].Body))
+	assert.Equal(t, http.StatusOK, resps[2].Status)
+	assert.Equal(t, `"plain"`, string(resps[2].Body))
+	assert.Equal(t, http.StatusBadRequest, resps[3].Status)
+	assert.Equal(t, http.StatusNotFound, resps[4].Status)
+
+	assert.Equal(t, http.StatusBadRequest, post(`{"path":"/v1/echo"}`).Code)
+	assert.Equal(t, http.StatusBadRequest, post(`[]`).Code)
+	assert.Equal(t, http.StatusBadRequest, post("["+strings.Repeat(`{"path":"/v1/text"},`, MaxBatchRequests)+`{"path":"/v1/text"}]`).Code)
+
+	// the other requests are passed through.
+	req := httptest.NewRequest("POST", "/v1/echo", strings.NewReader(`2`))
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"body":2`)
+}
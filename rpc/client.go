@@ -1,16 +1,28 @@
 package rpc
 
 import (
+	"crypto/tls"
+
 	"github.com/nebulasio/go-nebulas/util/logging"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 // Dial returns a client connection.
 func Dial(target string) (*grpc.ClientConn, error) {
-	// TODO: support secure connection.
 	conn, err := grpc.Dial(target, grpc.WithInsecure())
 	if err != nil {
 		logging.VLog().Debug("rpc.Dial() failed: ", err)
 	}
 	return conn, err
 }
+
+// DialTLS returns a client connection over tls, the config carries the CA
+// verifying the server and the client certificate if the server requires one.
+func DialTLS(target string, config *tls.Config) (*grpc.ClientConn, error) {
+	conn, err := grpc.Dial(target, grpc.WithTransportCredentials(credentials.NewTLS(config)))
+	if err != nil {
+		logging.VLog().Debug("rpc.DialTLS() failed: ", err)
+	}
+	return conn, err
+}
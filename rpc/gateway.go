@@ -14,6 +14,7 @@ import (
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 )
 
 // const
@@ -39,8 +40,16 @@ func Run(config *nebletpb.RPCConfig) error {
 	mux := runtime.NewServeMux(runtime.WithMarshalerOption(runtime.MIMEWildcard,
 		&runtime.JSONPb{OrigName: true, EmitDefaults: true}),
 		runtime.WithProtoErrorHandler(errorHandler))
-	opts := []grpc.DialOption{grpc.WithInsecure(),
-		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(MaxGateWayRecvMsgSize))}
+	tlsConfig, err := newServerTLSConfig(config)
+	if err != nil {
+		return err
+	}
+	opts := []grpc.DialOption{grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(MaxGateWayRecvMsgSize))}
+	if tlsConfig != nil {
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(newGatewayTLSConfig(tlsConfig))))
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+	}
 
 	echoEndpoint := flag.String("rpc", config.RpcListen[0], "")
 	for _, v := range config.HttpModule {
@@ -53,7 +62,13 @@ func Run(config *nebletpb.RPCConfig) error {
 	}
 
 	for _, v := range config.HttpListen {
-		err := http.ListenAndServe(v, allowCORS(mux, config))
+		handler := allowCORS(batchHandler(localAdminHTTP(mux, config.RemoteAdmin)), config)
+		if tlsConfig != nil {
+			server := &http.Server{Addr: v, Handler: handler, TLSConfig: tlsConfig}
+			err = server.ListenAndServeTLS("", "")
+		} else {
+			err = http.ListenAndServe(v, handler)
+		}
 		if err != nil {
 			return err
 		}
@@ -68,9 +83,13 @@ func allowCORS(h http.Handler, config *nebletpb.RPCConfig) http.Handler {
 		httpLimit = DefaultHTTPLimit
 	}
 	httpCh := make(chan bool, httpLimit)
+	maxRequestSize := int64(MaxRecvMsgSize)
+	if config.MaxRequestSize > 0 {
+		maxRequestSize = int64(config.MaxRequestSize)
+	}
 
 	c := cors.New(cors.Options{
-		AllowedHeaders: []string{"Content-Type", "Accept"},
+		AllowedHeaders: []string{"Content-Type", "Accept", "Authorization"},
 		AllowedMethods: []string{"GET", "HEAD", "POST", "PUT", "DELETE"},
 		AllowedOrigins: config.HttpCors,
 		MaxAge:         600,
@@ -81,6 +100,7 @@ func allowCORS(h http.Handler, config *nebletpb.RPCConfig) http.Handler {
 		select {
 		case httpCh <- true:
 			defer func() { <-httpCh }()
+			r.Body = http.MaxBytesReader(w, r.Body, maxRequestSize)
 			if len(config.HttpCors) == 0 {
 				h.ServeHTTP(w, r)
 			} else {
@@ -0,0 +1,105 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package rpc
+
+import (
+	"errors"
+	"net"
+	"net/http"
+
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// ErrRemoteAdmin the admin method is served to the local clients only
+var ErrRemoteAdmin = errors.New("admin method is only served to local clients")
+
+// localAdminMethods the admin methods managing the node at runtime and their
+// http paths, served to the clients over loopback or unix socket only unless
+// remote_admin is set.
+var localAdminMethods = map[string]string{
+	"/rpcpb.AdminService/AddPeer":       "/v1/admin/peer/add",
+	"/rpcpb.AdminService/RemovePeer":    "/v1/admin/peer/remove",
+	"/rpcpb.AdminService/StartMining":   "/v1/admin/mining/start",
+	"/rpcpb.AdminService/StopMining":    "/v1/admin/mining/stop",
+	"/rpcpb.AdminService/TxPoolContent": "/v1/admin/txpool/content",
+	"/rpcpb.AdminService/SetGasFloor":   "/v1/admin/txpool/gasFloor",
+	"/rpcpb.AdminService/SetLogLevel":   "/v1/admin/logLevel",
+}
+
+var localAdminPaths = make(map[string]bool)
+
+func init() {
+	for _, path := range localAdminMethods {
+		localAdminPaths[path] = true
+	}
+}
+
+// isLocalAddr return if the client address is a loopback or unix socket one.
+func isLocalAddr(addr net.Addr) bool {
+	switch addr := addr.(type) {
+	case *net.UnixAddr:
+		return true
+	case *net.TCPAddr:
+		return addr.IP.IsLoopback()
+	}
+	return false
+}
+
+func localAdminUnary(remoteAdmin bool) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if _, ok := localAdminMethods[info.FullMethod]; ok && !remoteAdmin {
+			if p, ok := peer.FromContext(ctx); !ok || !isLocalAddr(p.Addr) {
+				logging.VLog().WithFields(logrus.Fields{
+					"method": info.FullMethod,
+					"peer":   p,
+				}).Warn("Refused remote admin request.")
+				return nil, status.Error(codes.PermissionDenied, ErrRemoteAdmin.Error())
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// localAdminHTTP refuses the remote http requests to the local admin methods,
+// the gateway itself is a local client of the rpc server.
+func localAdminHTTP(h http.Handler, remoteAdmin bool) http.Handler {
+	if remoteAdmin {
+		return h
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if localAdminPaths[r.URL.Path] {
+			host, _, err := net.SplitHostPort(r.RemoteAddr)
+			if ip := net.ParseIP(host); err != nil || ip == nil || !ip.IsLoopback() {
+				logging.VLog().WithFields(logrus.Fields{
+					"path":   r.URL.Path,
+					"remote": r.RemoteAddr,
+				}).Warn("Refused remote admin request.")
+				http.Error(w, "{\"error\":\""+ErrRemoteAdmin.Error()+"\"}", http.StatusForbidden)
+				return
+			}
+		}
+		h.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,94 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package rpc
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+)
+
+func TestLocalAdminUnary(t *testing.T) {
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+	local := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 1234}})
+	unix := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.UnixAddr{Name: "/tmp/neb.ipc", Net: "unix"}})
+	remote := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 1234}})
+	admin := &grpc.UnaryServerInfo{FullMethod: "/rpcpb.AdminService/SetLogLevel"}
+	api := &grpc.UnaryServerInfo{FullMethod: "/rpcpb.ApiService/GetNebState"}
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		info        *grpc.UnaryServerInfo
+		remoteAdmin bool
+		denied      bool
+	}{
+		{"loopback admin", local, admin, false, false},
+		{"unix socket admin", unix, admin, false, false},
+		{"remote admin", remote, admin, false, true},
+		{"no peer admin", context.Background(), admin, false, true},
+		{"remote api", remote, api, false, false},
+		{"remote admin allowed", remote, admin, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := localAdminUnary(tt.remoteAdmin)(tt.ctx, nil, tt.info, handler)
+			if tt.denied {
+				assert.Equal(t, codes.PermissionDenied, grpc.Code(err))
+				assert.Nil(t, resp)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, "ok", resp)
+			}
+		})
+	}
+}
+
+func TestLocalAdminHTTP(t *testing.T) {
+	h := localAdminHTTP(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}), false)
+
+	tests := []struct {
+		path   string
+		remote string
+		code   int
+	}{
+		{"/v1/admin/logLevel", "127.0.0.1:1234", http.StatusOK},
+		{"/v1/admin/logLevel", "[::1]:1234", http.StatusOK},
+		{"/v1/admin/logLevel", "10.0.0.1:1234", http.StatusForbidden},
+		{"/v1/admin/nodeinfo", "10.0.0.1:1234", http.StatusOK},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("POST", tt.path, nil)
+		req.RemoteAddr = tt.remote
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		assert.Equal(t, tt.code, w.Code, tt.path+" from "+tt.remote)
+	}
+}
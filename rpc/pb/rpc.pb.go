@@ -48,9 +48,40 @@ It has these top-level messages:
 	GasResponse
 	EventsResponse
 	Event
+	ContractEventsRequest
+	ContractEventsResponse
+	ContractEvent
+	ContractABIResponse
 	PprofRequest
 	PprofResponse
 	GetConfigResponse
+	TraceResponse
+	UnlockedAccount
+	UnlockedAccountsResponse
+	AuditNoncesRequest
+	NonceReuse
+	AuditNoncesResponse
+	DecryptPayloadRequest
+	DecryptPayloadResponse
+	AddPeerRequest
+	RemovePeerRequest
+	PeerResponse
+	StartMiningRequest
+	MiningResponse
+	TxPoolAccount
+	TxPoolContentResponse
+	SetGasFloorRequest
+	SetGasFloorResponse
+	SetLogLevelRequest
+	SetLogLevelResponse
+	GetAccountStatesRequest
+	AccountState
+	GetAccountStatesResponse
+	EventsFilterRequest
+	BuildTransactionResponse
+	SendSignedTransactionRequest
+	GetTransactionsByAddressRequest
+	GetTransactionsByAddressResponse
 */
 package rpcpb
 
@@ -474,6 +505,8 @@ type TransactionRequest struct {
 	Binary []byte `protobuf:"bytes,10,opt,name=binary,proto3" json:"binary,omitempty"`
 	// transaction payload type, enum:binary, deploy, call
 	Type string `protobuf:"bytes,20,opt,name=type,proto3" json:"type,omitempty"`
+	// payload ECIES-encrypted to the public key of a recipient, opaque to the execution
+	EncryptedPayload []byte `protobuf:"bytes,21,opt,name=encrypted_payload,json=encryptedPayload,proto3" json:"encrypted_payload,omitempty"`
 }
 
 func (m *TransactionRequest) Reset()                    { *m = TransactionRequest{} }
@@ -544,6 +577,13 @@ func (m *TransactionRequest) GetType() string {
 	return ""
 }
 
+func (m *TransactionRequest) GetEncryptedPayload() []byte {
+	if m != nil {
+		return m.EncryptedPayload
+	}
+	return nil
+}
+
 type ContractRequest struct {
 	// contract source code.
 	Source string `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
@@ -904,6 +944,8 @@ type TransactionResponse struct {
 	ExecuteError string `protobuf:"bytes,15,opt,name=execute_error,json=executeError,proto3" json:"execute_error,omitempty"`
 	// contract execute result
 	ExecuteResult string `protobuf:"bytes,16,opt,name=execute_result,json=executeResult,proto3" json:"execute_result,omitempty"`
+	// payload ECIES-encrypted to the public key of a recipient
+	EncryptedPayload []byte `protobuf:"bytes,17,opt,name=encrypted_payload,json=encryptedPayload,proto3" json:"encrypted_payload,omitempty"`
 }
 
 func (m *TransactionResponse) Reset()                    { *m = TransactionResponse{} }
@@ -1023,6 +1065,13 @@ func (m *TransactionResponse) GetExecuteResult() string {
 	return ""
 }
 
+func (m *TransactionResponse) GetEncryptedPayload() []byte {
+	if m != nil {
+		return m.EncryptedPayload
+	}
+	return nil
+}
+
 type NewAccountRequest struct {
 	Passphrase string `protobuf:"bytes,1,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
 }
@@ -1059,6 +1108,8 @@ type UnlockAccountRequest struct {
 	Address    string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
 	Passphrase string `protobuf:"bytes,2,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
 	Duration   uint64 `protobuf:"varint,3,opt,name=duration,proto3" json:"duration,omitempty"`
+	// signings allowed before the account is locked again, 0 for no limit.
+	Uses uint32 `protobuf:"varint,4,opt,name=uses,proto3" json:"uses,omitempty"`
 }
 
 func (m *UnlockAccountRequest) Reset()                    { *m = UnlockAccountRequest{} }
@@ -1087,6 +1138,13 @@ func (m *UnlockAccountRequest) GetDuration() uint64 {
 	return 0
 }
 
+func (m *UnlockAccountRequest) GetUses() uint32 {
+	if m != nil {
+		return m.Uses
+	}
+	return 0
+}
+
 type UnlockAccountResponse struct {
 	Result bool `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
 }
@@ -1321,6 +1379,12 @@ func (m *SendTransactionPassphraseRequest) GetPassphrase() string {
 
 type GasPriceResponse struct {
 	GasPrice string `protobuf:"bytes,1,opt,name=gas_price,json=gasPrice,proto3" json:"gas_price,omitempty"`
+	// Suggested gas price likely to be packed within a few blocks.
+	SafeLow string `protobuf:"bytes,2,opt,name=safe_low,json=safeLow,proto3" json:"safe_low,omitempty"`
+	// Suggested gas price likely to be packed in the next blocks.
+	Standard string `protobuf:"bytes,3,opt,name=standard,proto3" json:"standard,omitempty"`
+	// Suggested gas price very likely to be packed in the next block.
+	Fast string `protobuf:"bytes,4,opt,name=fast,proto3" json:"fast,omitempty"`
 }
 
 func (m *GasPriceResponse) Reset()                    { *m = GasPriceResponse{} }
@@ -1335,6 +1399,27 @@ func (m *GasPriceResponse) GetGasPrice() string {
 	return ""
 }
 
+func (m *GasPriceResponse) GetSafeLow() string {
+	if m != nil {
+		return m.SafeLow
+	}
+	return ""
+}
+
+func (m *GasPriceResponse) GetStandard() string {
+	if m != nil {
+		return m.Standard
+	}
+	return ""
+}
+
+func (m *GasPriceResponse) GetFast() string {
+	if m != nil {
+		return m.Fast
+	}
+	return ""
+}
+
 // Request message of GetTransactionByHash rpc.
 type HashRequest struct {
 	// Hex string of block/transaction hash.
@@ -1356,6 +1441,8 @@ func (m *HashRequest) GetHash() string {
 type GasResponse struct {
 	Gas string `protobuf:"bytes,1,opt,name=gas,proto3" json:"gas,omitempty"`
 	Err string `protobuf:"bytes,2,opt,name=err,proto3" json:"err,omitempty"`
+	// the result of the simulated execution.
+	Result string `protobuf:"bytes,3,opt,name=result,proto3" json:"result,omitempty"`
 }
 
 func (m *GasResponse) Reset()                    { *m = GasResponse{} }
@@ -1377,6 +1464,13 @@ func (m *GasResponse) GetErr() string {
 	return ""
 }
 
+func (m *GasResponse) GetResult() string {
+	if m != nil {
+		return m.Result
+	}
+	return ""
+}
+
 type EventsResponse struct {
 	Events []*Event `protobuf:"bytes,1,rep,name=events" json:"events,omitempty"`
 }
@@ -1417,6 +1511,148 @@ func (m *Event) GetData() string {
 	return ""
 }
 
+type ContractEventsRequest struct {
+	// contract address.
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// topic given to Event.Trigger, empty for all topics.
+	Topic string `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
+	// the range of block heights, [from, to].
+	From uint64 `protobuf:"varint,3,opt,name=from,proto3" json:"from,omitempty"`
+	To   uint64 `protobuf:"varint,4,opt,name=to,proto3" json:"to,omitempty"`
+}
+
+func (m *ContractEventsRequest) Reset()                    { *m = ContractEventsRequest{} }
+func (m *ContractEventsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ContractEventsRequest) ProtoMessage()               {}
+func (*ContractEventsRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{40} }
+
+func (m *ContractEventsRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *ContractEventsRequest) GetTopic() string {
+	if m != nil {
+		return m.Topic
+	}
+	return ""
+}
+
+func (m *ContractEventsRequest) GetFrom() uint64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *ContractEventsRequest) GetTo() uint64 {
+	if m != nil {
+		return m.To
+	}
+	return 0
+}
+
+type ContractEventsResponse struct {
+	Events []*ContractEvent `protobuf:"bytes,1,rep,name=events" json:"events,omitempty"`
+}
+
+func (m *ContractEventsResponse) Reset()                    { *m = ContractEventsResponse{} }
+func (m *ContractEventsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ContractEventsResponse) ProtoMessage()               {}
+func (*ContractEventsResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{41} }
+
+func (m *ContractEventsResponse) GetEvents() []*ContractEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+type ContractEvent struct {
+	BlockHeight uint64 `protobuf:"varint,1,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	BlockHash   string `protobuf:"bytes,2,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	TxHash      string `protobuf:"bytes,3,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+	Address     string `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`
+	Topic       string `protobuf:"bytes,5,opt,name=topic,proto3" json:"topic,omitempty"`
+	Data        string `protobuf:"bytes,6,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (m *ContractEvent) Reset()                    { *m = ContractEvent{} }
+func (m *ContractEvent) String() string            { return proto.CompactTextString(m) }
+func (*ContractEvent) ProtoMessage()               {}
+func (*ContractEvent) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{42} }
+
+func (m *ContractEvent) GetBlockHeight() uint64 {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return 0
+}
+
+func (m *ContractEvent) GetBlockHash() string {
+	if m != nil {
+		return m.BlockHash
+	}
+	return ""
+}
+
+func (m *ContractEvent) GetTxHash() string {
+	if m != nil {
+		return m.TxHash
+	}
+	return ""
+}
+
+func (m *ContractEvent) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *ContractEvent) GetTopic() string {
+	if m != nil {
+		return m.Topic
+	}
+	return ""
+}
+
+func (m *ContractEvent) GetData() string {
+	if m != nil {
+		return m.Data
+	}
+	return ""
+}
+
+// Response message of GetContractABI rpc.
+type ContractABIResponse struct {
+	// the json ABI of the contract, empty if it is deployed without one.
+	Abi string `protobuf:"bytes,1,opt,name=abi,proto3" json:"abi,omitempty"`
+	// the times the contract is upgraded.
+	CodeVersion uint64 `protobuf:"varint,2,opt,name=code_version,json=codeVersion,proto3" json:"code_version,omitempty"`
+}
+
+func (m *ContractABIResponse) Reset()                    { *m = ContractABIResponse{} }
+func (m *ContractABIResponse) String() string            { return proto.CompactTextString(m) }
+func (*ContractABIResponse) ProtoMessage()               {}
+func (*ContractABIResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{43} }
+
+func (m *ContractABIResponse) GetAbi() string {
+	if m != nil {
+		return m.Abi
+	}
+	return ""
+}
+
+func (m *ContractABIResponse) GetCodeVersion() uint64 {
+	if m != nil {
+		return m.CodeVersion
+	}
+	return 0
+}
+
 type PprofRequest struct {
 	Listen string `protobuf:"bytes,1,opt,name=listen,proto3" json:"listen,omitempty"`
 }
@@ -1424,7 +1660,7 @@ type PprofRequest struct {
 func (m *PprofRequest) Reset()                    { *m = PprofRequest{} }
 func (m *PprofRequest) String() string            { return proto.CompactTextString(m) }
 func (*PprofRequest) ProtoMessage()               {}
-func (*PprofRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{40} }
+func (*PprofRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{44} }
 
 func (m *PprofRequest) GetListen() string {
 	if m != nil {
@@ -1440,7 +1676,7 @@ type PprofResponse struct {
 func (m *PprofResponse) Reset()                    { *m = PprofResponse{} }
 func (m *PprofResponse) String() string            { return proto.CompactTextString(m) }
 func (*PprofResponse) ProtoMessage()               {}
-func (*PprofResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{41} }
+func (*PprofResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{45} }
 
 func (m *PprofResponse) GetResult() bool {
 	if m != nil {
@@ -1457,7 +1693,7 @@ type GetConfigResponse struct {
 func (m *GetConfigResponse) Reset()                    { *m = GetConfigResponse{} }
 func (m *GetConfigResponse) String() string            { return proto.CompactTextString(m) }
 func (*GetConfigResponse) ProtoMessage()               {}
-func (*GetConfigResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{42} }
+func (*GetConfigResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{46} }
 
 func (m *GetConfigResponse) GetConfig() *nebletpb.Config {
 	if m != nil {
@@ -1466,148 +1702,916 @@ func (m *GetConfigResponse) GetConfig() *nebletpb.Config {
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*SubscribeRequest)(nil), "rpcpb.SubscribeRequest")
-	proto.RegisterType((*SubscribeResponse)(nil), "rpcpb.SubscribeResponse")
-	proto.RegisterType((*NonParamsRequest)(nil), "rpcpb.NonParamsRequest")
-	proto.RegisterType((*NodeInfoResponse)(nil), "rpcpb.NodeInfoResponse")
-	proto.RegisterType((*RouteTable)(nil), "rpcpb.RouteTable")
-	proto.RegisterType((*GetNebStateResponse)(nil), "rpcpb.GetNebStateResponse")
-	proto.RegisterType((*AccountsResponse)(nil), "rpcpb.AccountsResponse")
-	proto.RegisterType((*GetAccountStateRequest)(nil), "rpcpb.GetAccountStateRequest")
-	proto.RegisterType((*GetAccountStateResponse)(nil), "rpcpb.GetAccountStateResponse")
-	proto.RegisterType((*CallResponse)(nil), "rpcpb.CallResponse")
-	proto.RegisterType((*ByBlockHeightRequest)(nil), "rpcpb.ByBlockHeightRequest")
-	proto.RegisterType((*GetDynastyResponse)(nil), "rpcpb.GetDynastyResponse")
-	proto.RegisterType((*TransactionRequest)(nil), "rpcpb.TransactionRequest")
-	proto.RegisterType((*ContractRequest)(nil), "rpcpb.ContractRequest")
-	proto.RegisterType((*SendRawTransactionRequest)(nil), "rpcpb.SendRawTransactionRequest")
-	proto.RegisterType((*SendTransactionResponse)(nil), "rpcpb.SendTransactionResponse")
-	proto.RegisterType((*GetBlockByHashRequest)(nil), "rpcpb.GetBlockByHashRequest")
-	proto.RegisterType((*GetBlockByHeightRequest)(nil), "rpcpb.GetBlockByHeightRequest")
-	proto.RegisterType((*GetTransactionByHashRequest)(nil), "rpcpb.GetTransactionByHashRequest")
-	proto.RegisterType((*GetTransactionByContractRequest)(nil), "rpcpb.GetTransactionByContractRequest")
-	proto.RegisterType((*BlockResponse)(nil), "rpcpb.BlockResponse")
-	proto.RegisterType((*TransactionResponse)(nil), "rpcpb.TransactionResponse")
-	proto.RegisterType((*NewAccountRequest)(nil), "rpcpb.NewAccountRequest")
-	proto.RegisterType((*NewAccountResponse)(nil), "rpcpb.NewAccountResponse")
-	proto.RegisterType((*UnlockAccountRequest)(nil), "rpcpb.UnlockAccountRequest")
-	proto.RegisterType((*UnlockAccountResponse)(nil), "rpcpb.UnlockAccountResponse")
-	proto.RegisterType((*LockAccountRequest)(nil), "rpcpb.LockAccountRequest")
-	proto.RegisterType((*LockAccountResponse)(nil), "rpcpb.LockAccountResponse")
-	proto.RegisterType((*SignHashRequest)(nil), "rpcpb.SignHashRequest")
-	proto.RegisterType((*SignHashResponse)(nil), "rpcpb.SignHashResponse")
-	proto.RegisterType((*GenerateRandomSeedRequest)(nil), "rpcpb.GenerateRandomSeedRequest")
-	proto.RegisterType((*GenerateRandomSeedResponse)(nil), "rpcpb.GenerateRandomSeedResponse")
-	proto.RegisterType((*SignTransactionPassphraseRequest)(nil), "rpcpb.SignTransactionPassphraseRequest")
-	proto.RegisterType((*SignTransactionPassphraseResponse)(nil), "rpcpb.SignTransactionPassphraseResponse")
-	proto.RegisterType((*SendTransactionPassphraseRequest)(nil), "rpcpb.SendTransactionPassphraseRequest")
-	proto.RegisterType((*GasPriceResponse)(nil), "rpcpb.GasPriceResponse")
-	proto.RegisterType((*HashRequest)(nil), "rpcpb.HashRequest")
-	proto.RegisterType((*GasResponse)(nil), "rpcpb.GasResponse")
-	proto.RegisterType((*EventsResponse)(nil), "rpcpb.EventsResponse")
-	proto.RegisterType((*Event)(nil), "rpcpb.Event")
-	proto.RegisterType((*PprofRequest)(nil), "rpcpb.PprofRequest")
-	proto.RegisterType((*PprofResponse)(nil), "rpcpb.PprofResponse")
-	proto.RegisterType((*GetConfigResponse)(nil), "rpcpb.GetConfigResponse")
+type TraceResponse struct {
+	// JSON string of the execution trace.
+	Trace string `protobuf:"bytes,1,opt,name=trace,proto3" json:"trace,omitempty"`
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// Client API for ApiService service
+func (m *TraceResponse) Reset()                    { *m = TraceResponse{} }
+func (m *TraceResponse) String() string            { return proto.CompactTextString(m) }
+func (*TraceResponse) ProtoMessage()               {}
+func (*TraceResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{47} }
 
-type ApiServiceClient interface {
-	// Return the state of the neb.
-	GetNebState(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*GetNebStateResponse, error)
-	// Return the latest irreversible block.
-	LatestIrreversibleBlock(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*BlockResponse, error)
-	// Return the state of the account.
-	GetAccountState(ctx context.Context, in *GetAccountStateRequest, opts ...grpc.CallOption) (*GetAccountStateResponse, error)
-	// Call transaction
-	Call(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*CallResponse, error)
-	// Submit the signed transaction.
-	SendRawTransaction(ctx context.Context, in *SendRawTransactionRequest, opts ...grpc.CallOption) (*SendTransactionResponse, error)
-	// Get block info by the block hash.
-	GetBlockByHash(ctx context.Context, in *GetBlockByHashRequest, opts ...grpc.CallOption) (*BlockResponse, error)
-	// Get block info by the block height.
-	GetBlockByHeight(ctx context.Context, in *GetBlockByHeightRequest, opts ...grpc.CallOption) (*BlockResponse, error)
-	// Get transactionReceipt info by tansaction hash.
-	GetTransactionReceipt(ctx context.Context, in *GetTransactionByHashRequest, opts ...grpc.CallOption) (*TransactionResponse, error)
-	// Get transactionReceipt info by tansaction hash.
-	GetTransactionByContract(ctx context.Context, in *GetTransactionByContractRequest, opts ...grpc.CallOption) (*TransactionResponse, error)
-	// Subscribe message
-	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (ApiService_SubscribeClient, error)
-	// Get GasPrice
-	GetGasPrice(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*GasPriceResponse, error)
-	// EstimateGas
-	EstimateGas(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*GasResponse, error)
-	GetEventsByHash(ctx context.Context, in *HashRequest, opts ...grpc.CallOption) (*EventsResponse, error)
-	GetDynasty(ctx context.Context, in *ByBlockHeightRequest, opts ...grpc.CallOption) (*GetDynastyResponse, error)
+func (m *TraceResponse) GetTrace() string {
+	if m != nil {
+		return m.Trace
+	}
+	return ""
 }
 
-type apiServiceClient struct {
-	cc *grpc.ClientConn
+type UnlockedAccount struct {
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// unix time the account is locked again.
+	Expiry int64 `protobuf:"varint,2,opt,name=expiry,proto3" json:"expiry,omitempty"`
+	// signings left before the account is locked, 0 for no limit.
+	Uses uint32 `protobuf:"varint,3,opt,name=uses,proto3" json:"uses,omitempty"`
 }
 
-func NewApiServiceClient(cc *grpc.ClientConn) ApiServiceClient {
-	return &apiServiceClient{cc}
-}
+func (m *UnlockedAccount) Reset()                    { *m = UnlockedAccount{} }
+func (m *UnlockedAccount) String() string            { return proto.CompactTextString(m) }
+func (*UnlockedAccount) ProtoMessage()               {}
+func (*UnlockedAccount) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{48} }
 
-func (c *apiServiceClient) GetNebState(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*GetNebStateResponse, error) {
-	out := new(GetNebStateResponse)
-	err := grpc.Invoke(ctx, "/rpcpb.ApiService/GetNebState", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *UnlockedAccount) GetAddress() string {
+	if m != nil {
+		return m.Address
 	}
-	return out, nil
+	return ""
 }
 
-func (c *apiServiceClient) LatestIrreversibleBlock(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*BlockResponse, error) {
-	out := new(BlockResponse)
-	err := grpc.Invoke(ctx, "/rpcpb.ApiService/LatestIrreversibleBlock", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *UnlockedAccount) GetExpiry() int64 {
+	if m != nil {
+		return m.Expiry
 	}
-	return out, nil
+	return 0
 }
 
-func (c *apiServiceClient) GetAccountState(ctx context.Context, in *GetAccountStateRequest, opts ...grpc.CallOption) (*GetAccountStateResponse, error) {
-	out := new(GetAccountStateResponse)
-	err := grpc.Invoke(ctx, "/rpcpb.ApiService/GetAccountState", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *UnlockedAccount) GetUses() uint32 {
+	if m != nil {
+		return m.Uses
 	}
-	return out, nil
+	return 0
 }
 
-func (c *apiServiceClient) Call(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*CallResponse, error) {
-	out := new(CallResponse)
-	err := grpc.Invoke(ctx, "/rpcpb.ApiService/Call", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+// Response message of UnlockedAccounts rpc.
+type UnlockedAccountsResponse struct {
+	Accounts []*UnlockedAccount `protobuf:"bytes,1,rep,name=accounts" json:"accounts,omitempty"`
 }
 
-func (c *apiServiceClient) SendRawTransaction(ctx context.Context, in *SendRawTransactionRequest, opts ...grpc.CallOption) (*SendTransactionResponse, error) {
-	out := new(SendTransactionResponse)
-	err := grpc.Invoke(ctx, "/rpcpb.ApiService/SendRawTransaction", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *UnlockedAccountsResponse) Reset()                    { *m = UnlockedAccountsResponse{} }
+func (m *UnlockedAccountsResponse) String() string            { return proto.CompactTextString(m) }
+func (*UnlockedAccountsResponse) ProtoMessage()               {}
+func (*UnlockedAccountsResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{49} }
+
+func (m *UnlockedAccountsResponse) GetAccounts() []*UnlockedAccount {
+	if m != nil {
+		return m.Accounts
 	}
-	return out, nil
+	return nil
 }
 
-func (c *apiServiceClient) GetBlockByHash(ctx context.Context, in *GetBlockByHashRequest, opts ...grpc.CallOption) (*BlockResponse, error) {
-	out := new(BlockResponse)
-	err := grpc.Invoke(ctx, "/rpcpb.ApiService/GetBlockByHash", in, out, c.cc, opts...)
-	if err != nil {
+// Request message of AuditNonces rpc.
+type AuditNoncesRequest struct {
+	// first block height to audit.
+	From uint64 `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`
+	// last block height to audit, 0 for the tail block.
+	To uint64 `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`
+}
+
+func (m *AuditNoncesRequest) Reset()                    { *m = AuditNoncesRequest{} }
+func (m *AuditNoncesRequest) String() string            { return proto.CompactTextString(m) }
+func (*AuditNoncesRequest) ProtoMessage()               {}
+func (*AuditNoncesRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{50} }
+
+func (m *AuditNoncesRequest) GetFrom() uint64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *AuditNoncesRequest) GetTo() uint64 {
+	if m != nil {
+		return m.To
+	}
+	return 0
+}
+
+type NonceReuse struct {
+	// Hex string of the r shared by the signatures.
+	R string `protobuf:"bytes,1,opt,name=r,proto3" json:"r,omitempty"`
+	// signer and hash of the transaction or the block signed first.
+	FirstSigner string `protobuf:"bytes,2,opt,name=first_signer,json=firstSigner,proto3" json:"first_signer,omitempty"`
+	FirstHash   string `protobuf:"bytes,3,opt,name=first_hash,json=firstHash,proto3" json:"first_hash,omitempty"`
+	// signer and hash of the transaction or the block signed later.
+	SecondSigner string `protobuf:"bytes,4,opt,name=second_signer,json=secondSigner,proto3" json:"second_signer,omitempty"`
+	SecondHash   string `protobuf:"bytes,5,opt,name=second_hash,json=secondHash,proto3" json:"second_hash,omitempty"`
+	// the private key of the signer is leaked by the signatures.
+	SameSigner bool `protobuf:"varint,6,opt,name=same_signer,json=sameSigner,proto3" json:"same_signer,omitempty"`
+}
+
+func (m *NonceReuse) Reset()                    { *m = NonceReuse{} }
+func (m *NonceReuse) String() string            { return proto.CompactTextString(m) }
+func (*NonceReuse) ProtoMessage()               {}
+func (*NonceReuse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{51} }
+
+func (m *NonceReuse) GetR() string {
+	if m != nil {
+		return m.R
+	}
+	return ""
+}
+
+func (m *NonceReuse) GetFirstSigner() string {
+	if m != nil {
+		return m.FirstSigner
+	}
+	return ""
+}
+
+func (m *NonceReuse) GetFirstHash() string {
+	if m != nil {
+		return m.FirstHash
+	}
+	return ""
+}
+
+func (m *NonceReuse) GetSecondSigner() string {
+	if m != nil {
+		return m.SecondSigner
+	}
+	return ""
+}
+
+func (m *NonceReuse) GetSecondHash() string {
+	if m != nil {
+		return m.SecondHash
+	}
+	return ""
+}
+
+func (m *NonceReuse) GetSameSigner() bool {
+	if m != nil {
+		return m.SameSigner
+	}
+	return false
+}
+
+// Response message of AuditNonces rpc.
+type AuditNoncesResponse struct {
+	// secp256k1 signatures audited.
+	Signatures uint64 `protobuf:"varint,1,opt,name=signatures,proto3" json:"signatures,omitempty"`
+	// signatures sharing nonces.
+	Reuses []*NonceReuse `protobuf:"bytes,2,rep,name=reuses" json:"reuses,omitempty"`
+}
+
+func (m *AuditNoncesResponse) Reset()                    { *m = AuditNoncesResponse{} }
+func (m *AuditNoncesResponse) String() string            { return proto.CompactTextString(m) }
+func (*AuditNoncesResponse) ProtoMessage()               {}
+func (*AuditNoncesResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{52} }
+
+func (m *AuditNoncesResponse) GetSignatures() uint64 {
+	if m != nil {
+		return m.Signatures
+	}
+	return 0
+}
+
+func (m *AuditNoncesResponse) GetReuses() []*NonceReuse {
+	if m != nil {
+		return m.Reuses
+	}
+	return nil
+}
+
+type DecryptPayloadRequest struct {
+	// Hex string of the account whose key the payload is encrypted to.
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// Hex string of the transaction hash.
+	Hash string `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+}
+
+func (m *DecryptPayloadRequest) Reset()                    { *m = DecryptPayloadRequest{} }
+func (m *DecryptPayloadRequest) String() string            { return proto.CompactTextString(m) }
+func (*DecryptPayloadRequest) ProtoMessage()               {}
+func (*DecryptPayloadRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{53} }
+
+func (m *DecryptPayloadRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *DecryptPayloadRequest) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+type DecryptPayloadResponse struct {
+	Payload []byte `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+}
+
+func (m *DecryptPayloadResponse) Reset()                    { *m = DecryptPayloadResponse{} }
+func (m *DecryptPayloadResponse) String() string            { return proto.CompactTextString(m) }
+func (*DecryptPayloadResponse) ProtoMessage()               {}
+func (*DecryptPayloadResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{54} }
+
+func (m *DecryptPayloadResponse) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+type AddPeerRequest struct {
+	// Ipfs address of the peer, e.g. "/ip4/127.0.0.1/tcp/8680/ipfs/QmP7HDFcYmJL12Ez4ZNVCKjKedfE7f48f1LAkUc3Whz4jP".
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+}
+
+func (m *AddPeerRequest) Reset()                    { *m = AddPeerRequest{} }
+func (m *AddPeerRequest) String() string            { return proto.CompactTextString(m) }
+func (*AddPeerRequest) ProtoMessage()               {}
+func (*AddPeerRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{55} }
+
+func (m *AddPeerRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+type RemovePeerRequest struct {
+	// Id of the peer.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (m *RemovePeerRequest) Reset()                    { *m = RemovePeerRequest{} }
+func (m *RemovePeerRequest) String() string            { return proto.CompactTextString(m) }
+func (*RemovePeerRequest) ProtoMessage()               {}
+func (*RemovePeerRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{56} }
+
+func (m *RemovePeerRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type PeerResponse struct {
+	// Id of the peer.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (m *PeerResponse) Reset()                    { *m = PeerResponse{} }
+func (m *PeerResponse) String() string            { return proto.CompactTextString(m) }
+func (*PeerResponse) ProtoMessage()               {}
+func (*PeerResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{57} }
+
+func (m *PeerResponse) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type StartMiningRequest struct {
+	// Passphrase of the miner.
+	Passphrase string `protobuf:"bytes,1,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+}
+
+func (m *StartMiningRequest) Reset()                    { *m = StartMiningRequest{} }
+func (m *StartMiningRequest) String() string            { return proto.CompactTextString(m) }
+func (*StartMiningRequest) ProtoMessage()               {}
+func (*StartMiningRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{58} }
+
+func (m *StartMiningRequest) GetPassphrase() string {
+	if m != nil {
+		return m.Passphrase
+	}
+	return ""
+}
+
+type MiningResponse struct {
+	Result bool `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+}
+
+func (m *MiningResponse) Reset()                    { *m = MiningResponse{} }
+func (m *MiningResponse) String() string            { return proto.CompactTextString(m) }
+func (*MiningResponse) ProtoMessage()               {}
+func (*MiningResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{59} }
+
+func (m *MiningResponse) GetResult() bool {
+	if m != nil {
+		return m.Result
+	}
+	return false
+}
+
+type TxPoolAccount struct {
+	// Hex string of the sender.
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// Txs waiting to be packed, in nonce order.
+	Pending []*TransactionResponse `protobuf:"bytes,2,rep,name=pending" json:"pending,omitempty"`
+	// Txs behind a nonce gap, in nonce order.
+	Queued []*TransactionResponse `protobuf:"bytes,3,rep,name=queued" json:"queued,omitempty"`
+}
+
+func (m *TxPoolAccount) Reset()                    { *m = TxPoolAccount{} }
+func (m *TxPoolAccount) String() string            { return proto.CompactTextString(m) }
+func (*TxPoolAccount) ProtoMessage()               {}
+func (*TxPoolAccount) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{60} }
+
+func (m *TxPoolAccount) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *TxPoolAccount) GetPending() []*TransactionResponse {
+	if m != nil {
+		return m.Pending
+	}
+	return nil
+}
+
+func (m *TxPoolAccount) GetQueued() []*TransactionResponse {
+	if m != nil {
+		return m.Queued
+	}
+	return nil
+}
+
+type TxPoolContentResponse struct {
+	Accounts []*TxPoolAccount `protobuf:"bytes,1,rep,name=accounts" json:"accounts,omitempty"`
+}
+
+func (m *TxPoolContentResponse) Reset()                    { *m = TxPoolContentResponse{} }
+func (m *TxPoolContentResponse) String() string            { return proto.CompactTextString(m) }
+func (*TxPoolContentResponse) ProtoMessage()               {}
+func (*TxPoolContentResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{61} }
+
+func (m *TxPoolContentResponse) GetAccounts() []*TxPoolAccount {
+	if m != nil {
+		return m.Accounts
+	}
+	return nil
+}
+
+type SetGasFloorRequest struct {
+	// Lowest gasPrice of the txs accepted by the pool.
+	GasPrice string `protobuf:"bytes,1,opt,name=gas_price,json=gasPrice,proto3" json:"gas_price,omitempty"`
+}
+
+func (m *SetGasFloorRequest) Reset()                    { *m = SetGasFloorRequest{} }
+func (m *SetGasFloorRequest) String() string            { return proto.CompactTextString(m) }
+func (*SetGasFloorRequest) ProtoMessage()               {}
+func (*SetGasFloorRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{62} }
+
+func (m *SetGasFloorRequest) GetGasPrice() string {
+	if m != nil {
+		return m.GasPrice
+	}
+	return ""
+}
+
+type SetGasFloorResponse struct {
+	GasPrice string `protobuf:"bytes,1,opt,name=gas_price,json=gasPrice,proto3" json:"gas_price,omitempty"`
+}
+
+func (m *SetGasFloorResponse) Reset()                    { *m = SetGasFloorResponse{} }
+func (m *SetGasFloorResponse) String() string            { return proto.CompactTextString(m) }
+func (*SetGasFloorResponse) ProtoMessage()               {}
+func (*SetGasFloorResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{63} }
+
+func (m *SetGasFloorResponse) GetGasPrice() string {
+	if m != nil {
+		return m.GasPrice
+	}
+	return ""
+}
+
+type SetLogLevelRequest struct {
+	// Level of the verbose log, "panic", "fatal", "error", "warn", "info" or "debug".
+	Level string `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`
+}
+
+func (m *SetLogLevelRequest) Reset()                    { *m = SetLogLevelRequest{} }
+func (m *SetLogLevelRequest) String() string            { return proto.CompactTextString(m) }
+func (*SetLogLevelRequest) ProtoMessage()               {}
+func (*SetLogLevelRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{64} }
+
+func (m *SetLogLevelRequest) GetLevel() string {
+	if m != nil {
+		return m.Level
+	}
+	return ""
+}
+
+type SetLogLevelResponse struct {
+	Level string `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`
+}
+
+func (m *SetLogLevelResponse) Reset()                    { *m = SetLogLevelResponse{} }
+func (m *SetLogLevelResponse) String() string            { return proto.CompactTextString(m) }
+func (*SetLogLevelResponse) ProtoMessage()               {}
+func (*SetLogLevelResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{65} }
+
+func (m *SetLogLevelResponse) GetLevel() string {
+	if m != nil {
+		return m.Level
+	}
+	return ""
+}
+
+// Request message of GetAccountStates rpc.
+type GetAccountStatesRequest struct {
+	// Hex strings of the account addresses.
+	Addresses []string `protobuf:"bytes,1,rep,name=addresses" json:"addresses,omitempty"`
+	// block account states with height. If not specified, use 0 as tail height.
+	Height uint64 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+}
+
+func (m *GetAccountStatesRequest) Reset()                    { *m = GetAccountStatesRequest{} }
+func (m *GetAccountStatesRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetAccountStatesRequest) ProtoMessage()               {}
+func (*GetAccountStatesRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{66} }
+
+func (m *GetAccountStatesRequest) GetAddresses() []string {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+func (m *GetAccountStatesRequest) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+type AccountState struct {
+	// Hex string of the account address.
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// Current balance in unit of 1/(10^18) nas.
+	Balance string `protobuf:"bytes,2,opt,name=balance,proto3" json:"balance,omitempty"`
+	// Current transaction count.
+	Nonce uint64 `protobuf:"varint,3,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	// Account type
+	Type uint32 `protobuf:"varint,4,opt,name=type,proto3" json:"type,omitempty"`
+}
+
+func (m *AccountState) Reset()                    { *m = AccountState{} }
+func (m *AccountState) String() string            { return proto.CompactTextString(m) }
+func (*AccountState) ProtoMessage()               {}
+func (*AccountState) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{67} }
+
+func (m *AccountState) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *AccountState) GetBalance() string {
+	if m != nil {
+		return m.Balance
+	}
+	return ""
+}
+
+func (m *AccountState) GetNonce() uint64 {
+	if m != nil {
+		return m.Nonce
+	}
+	return 0
+}
+
+func (m *AccountState) GetType() uint32 {
+	if m != nil {
+		return m.Type
+	}
+	return 0
+}
+
+// Response message of GetAccountStates rpc.
+type GetAccountStatesResponse struct {
+	// States of the accounts in the order of the request.
+	States []*AccountState `protobuf:"bytes,1,rep,name=states" json:"states,omitempty"`
+}
+
+func (m *GetAccountStatesResponse) Reset()                    { *m = GetAccountStatesResponse{} }
+func (m *GetAccountStatesResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetAccountStatesResponse) ProtoMessage()               {}
+func (*GetAccountStatesResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{68} }
+
+func (m *GetAccountStatesResponse) GetStates() []*AccountState {
+	if m != nil {
+		return m.States
+	}
+	return nil
+}
+
+// Request message of GetEventsByFilter rpc.
+type EventsFilterRequest struct {
+	// the range of block heights, [from_block, to_block].
+	FromBlock uint64 `protobuf:"varint,1,opt,name=from_block,json=fromBlock,proto3" json:"from_block,omitempty"`
+	ToBlock   uint64 `protobuf:"varint,2,opt,name=to_block,json=toBlock,proto3" json:"to_block,omitempty"`
+	// contract addresses, empty for all contracts.
+	Addresses []string `protobuf:"bytes,3,rep,name=addresses" json:"addresses,omitempty"`
+	// topics given to Event.Trigger, empty for all topics.
+	Topics []string `protobuf:"bytes,4,rep,name=topics" json:"topics,omitempty"`
+}
+
+func (m *EventsFilterRequest) Reset()                    { *m = EventsFilterRequest{} }
+func (m *EventsFilterRequest) String() string            { return proto.CompactTextString(m) }
+func (*EventsFilterRequest) ProtoMessage()               {}
+func (*EventsFilterRequest) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{69} }
+
+func (m *EventsFilterRequest) GetFromBlock() uint64 {
+	if m != nil {
+		return m.FromBlock
+	}
+	return 0
+}
+
+func (m *EventsFilterRequest) GetToBlock() uint64 {
+	if m != nil {
+		return m.ToBlock
+	}
+	return 0
+}
+
+func (m *EventsFilterRequest) GetAddresses() []string {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+func (m *EventsFilterRequest) GetTopics() []string {
+	if m != nil {
+		return m.Topics
+	}
+	return nil
+}
+
+// Response message of BuildTransaction rpc.
+type BuildTransactionResponse struct {
+	// Unsigned data of the transaction.
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// Hex string of the hash to sign, which becomes the transaction hash.
+	Hash string `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	// Nonce, gas price, gas limit and chain id of the transaction, filled if not given.
+	Nonce    uint64 `protobuf:"varint,3,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	GasPrice string `protobuf:"bytes,4,opt,name=gas_price,json=gasPrice,proto3" json:"gas_price,omitempty"`
+	GasLimit string `protobuf:"bytes,5,opt,name=gas_limit,json=gasLimit,proto3" json:"gas_limit,omitempty"`
+	ChainId  uint32 `protobuf:"varint,6,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+}
+
+func (m *BuildTransactionResponse) Reset()                    { *m = BuildTransactionResponse{} }
+func (m *BuildTransactionResponse) String() string            { return proto.CompactTextString(m) }
+func (*BuildTransactionResponse) ProtoMessage()               {}
+func (*BuildTransactionResponse) Descriptor() ([]byte, []int) { return fileDescriptorRpc, []int{70} }
+
+func (m *BuildTransactionResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *BuildTransactionResponse) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *BuildTransactionResponse) GetNonce() uint64 {
+	if m != nil {
+		return m.Nonce
+	}
+	return 0
+}
+
+func (m *BuildTransactionResponse) GetGasPrice() string {
+	if m != nil {
+		return m.GasPrice
+	}
+	return ""
+}
+
+func (m *BuildTransactionResponse) GetGasLimit() string {
+	if m != nil {
+		return m.GasLimit
+	}
+	return ""
+}
+
+func (m *BuildTransactionResponse) GetChainId() uint32 {
+	if m != nil {
+		return m.ChainId
+	}
+	return 0
+}
+
+// Request message of SendSignedTransaction rpc.
+type SendSignedTransactionRequest struct {
+	// Unsigned data of the transaction returned by BuildTransaction.
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// Signature algorithm.
+	Alg uint32 `protobuf:"varint,2,opt,name=alg,proto3" json:"alg,omitempty"`
+	// Signature of the hash by the sender.
+	Signature []byte `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (m *SendSignedTransactionRequest) Reset()         { *m = SendSignedTransactionRequest{} }
+func (m *SendSignedTransactionRequest) String() string { return proto.CompactTextString(m) }
+func (*SendSignedTransactionRequest) ProtoMessage()    {}
+func (*SendSignedTransactionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptorRpc, []int{71}
+}
+
+func (m *SendSignedTransactionRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *SendSignedTransactionRequest) GetAlg() uint32 {
+	if m != nil {
+		return m.Alg
+	}
+	return 0
+}
+
+func (m *SendSignedTransactionRequest) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// Request message of GetTransactionsByAddress rpc.
+type GetTransactionsByAddressRequest struct {
+	// Hex string of the account address.
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// Txs skipped from the newest one.
+	Offset uint64 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Txs returned at most, 0 or above MaxTransactionsByAddress for MaxTransactionsByAddress.
+	Limit uint64 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (m *GetTransactionsByAddressRequest) Reset()         { *m = GetTransactionsByAddressRequest{} }
+func (m *GetTransactionsByAddressRequest) String() string { return proto.CompactTextString(m) }
+func (*GetTransactionsByAddressRequest) ProtoMessage()    {}
+func (*GetTransactionsByAddressRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptorRpc, []int{72}
+}
+
+func (m *GetTransactionsByAddressRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *GetTransactionsByAddressRequest) GetOffset() uint64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *GetTransactionsByAddressRequest) GetLimit() uint64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+// Response message of GetTransactionsByAddress rpc.
+type GetTransactionsByAddressResponse struct {
+	// Txs sent from or to the address, the newest first.
+	Transactions []*TransactionResponse `protobuf:"bytes,1,rep,name=transactions" json:"transactions,omitempty"`
+	// Count of all the txs of the address.
+	Total uint64 `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+}
+
+func (m *GetTransactionsByAddressResponse) Reset()         { *m = GetTransactionsByAddressResponse{} }
+func (m *GetTransactionsByAddressResponse) String() string { return proto.CompactTextString(m) }
+func (*GetTransactionsByAddressResponse) ProtoMessage()    {}
+func (*GetTransactionsByAddressResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptorRpc, []int{73}
+}
+
+func (m *GetTransactionsByAddressResponse) GetTransactions() []*TransactionResponse {
+	if m != nil {
+		return m.Transactions
+	}
+	return nil
+}
+
+func (m *GetTransactionsByAddressResponse) GetTotal() uint64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*SubscribeRequest)(nil), "rpcpb.SubscribeRequest")
+	proto.RegisterType((*SubscribeResponse)(nil), "rpcpb.SubscribeResponse")
+	proto.RegisterType((*NonParamsRequest)(nil), "rpcpb.NonParamsRequest")
+	proto.RegisterType((*NodeInfoResponse)(nil), "rpcpb.NodeInfoResponse")
+	proto.RegisterType((*RouteTable)(nil), "rpcpb.RouteTable")
+	proto.RegisterType((*GetNebStateResponse)(nil), "rpcpb.GetNebStateResponse")
+	proto.RegisterType((*AccountsResponse)(nil), "rpcpb.AccountsResponse")
+	proto.RegisterType((*GetAccountStateRequest)(nil), "rpcpb.GetAccountStateRequest")
+	proto.RegisterType((*GetAccountStateResponse)(nil), "rpcpb.GetAccountStateResponse")
+	proto.RegisterType((*CallResponse)(nil), "rpcpb.CallResponse")
+	proto.RegisterType((*ByBlockHeightRequest)(nil), "rpcpb.ByBlockHeightRequest")
+	proto.RegisterType((*GetDynastyResponse)(nil), "rpcpb.GetDynastyResponse")
+	proto.RegisterType((*TransactionRequest)(nil), "rpcpb.TransactionRequest")
+	proto.RegisterType((*ContractRequest)(nil), "rpcpb.ContractRequest")
+	proto.RegisterType((*SendRawTransactionRequest)(nil), "rpcpb.SendRawTransactionRequest")
+	proto.RegisterType((*SendTransactionResponse)(nil), "rpcpb.SendTransactionResponse")
+	proto.RegisterType((*GetBlockByHashRequest)(nil), "rpcpb.GetBlockByHashRequest")
+	proto.RegisterType((*GetBlockByHeightRequest)(nil), "rpcpb.GetBlockByHeightRequest")
+	proto.RegisterType((*GetTransactionByHashRequest)(nil), "rpcpb.GetTransactionByHashRequest")
+	proto.RegisterType((*GetTransactionByContractRequest)(nil), "rpcpb.GetTransactionByContractRequest")
+	proto.RegisterType((*BlockResponse)(nil), "rpcpb.BlockResponse")
+	proto.RegisterType((*TransactionResponse)(nil), "rpcpb.TransactionResponse")
+	proto.RegisterType((*NewAccountRequest)(nil), "rpcpb.NewAccountRequest")
+	proto.RegisterType((*NewAccountResponse)(nil), "rpcpb.NewAccountResponse")
+	proto.RegisterType((*UnlockAccountRequest)(nil), "rpcpb.UnlockAccountRequest")
+	proto.RegisterType((*UnlockAccountResponse)(nil), "rpcpb.UnlockAccountResponse")
+	proto.RegisterType((*LockAccountRequest)(nil), "rpcpb.LockAccountRequest")
+	proto.RegisterType((*LockAccountResponse)(nil), "rpcpb.LockAccountResponse")
+	proto.RegisterType((*SignHashRequest)(nil), "rpcpb.SignHashRequest")
+	proto.RegisterType((*SignHashResponse)(nil), "rpcpb.SignHashResponse")
+	proto.RegisterType((*GenerateRandomSeedRequest)(nil), "rpcpb.GenerateRandomSeedRequest")
+	proto.RegisterType((*GenerateRandomSeedResponse)(nil), "rpcpb.GenerateRandomSeedResponse")
+	proto.RegisterType((*SignTransactionPassphraseRequest)(nil), "rpcpb.SignTransactionPassphraseRequest")
+	proto.RegisterType((*SignTransactionPassphraseResponse)(nil), "rpcpb.SignTransactionPassphraseResponse")
+	proto.RegisterType((*SendTransactionPassphraseRequest)(nil), "rpcpb.SendTransactionPassphraseRequest")
+	proto.RegisterType((*GasPriceResponse)(nil), "rpcpb.GasPriceResponse")
+	proto.RegisterType((*HashRequest)(nil), "rpcpb.HashRequest")
+	proto.RegisterType((*GasResponse)(nil), "rpcpb.GasResponse")
+	proto.RegisterType((*EventsResponse)(nil), "rpcpb.EventsResponse")
+	proto.RegisterType((*Event)(nil), "rpcpb.Event")
+	proto.RegisterType((*ContractEventsRequest)(nil), "rpcpb.ContractEventsRequest")
+	proto.RegisterType((*ContractEventsResponse)(nil), "rpcpb.ContractEventsResponse")
+	proto.RegisterType((*ContractEvent)(nil), "rpcpb.ContractEvent")
+	proto.RegisterType((*ContractABIResponse)(nil), "rpcpb.ContractABIResponse")
+	proto.RegisterType((*PprofRequest)(nil), "rpcpb.PprofRequest")
+	proto.RegisterType((*PprofResponse)(nil), "rpcpb.PprofResponse")
+	proto.RegisterType((*GetConfigResponse)(nil), "rpcpb.GetConfigResponse")
+	proto.RegisterType((*TraceResponse)(nil), "rpcpb.TraceResponse")
+	proto.RegisterType((*UnlockedAccount)(nil), "rpcpb.UnlockedAccount")
+	proto.RegisterType((*UnlockedAccountsResponse)(nil), "rpcpb.UnlockedAccountsResponse")
+	proto.RegisterType((*AuditNoncesRequest)(nil), "rpcpb.AuditNoncesRequest")
+	proto.RegisterType((*NonceReuse)(nil), "rpcpb.NonceReuse")
+	proto.RegisterType((*AuditNoncesResponse)(nil), "rpcpb.AuditNoncesResponse")
+	proto.RegisterType((*DecryptPayloadRequest)(nil), "rpcpb.DecryptPayloadRequest")
+	proto.RegisterType((*DecryptPayloadResponse)(nil), "rpcpb.DecryptPayloadResponse")
+	proto.RegisterType((*AddPeerRequest)(nil), "rpcpb.AddPeerRequest")
+	proto.RegisterType((*RemovePeerRequest)(nil), "rpcpb.RemovePeerRequest")
+	proto.RegisterType((*PeerResponse)(nil), "rpcpb.PeerResponse")
+	proto.RegisterType((*StartMiningRequest)(nil), "rpcpb.StartMiningRequest")
+	proto.RegisterType((*MiningResponse)(nil), "rpcpb.MiningResponse")
+	proto.RegisterType((*TxPoolAccount)(nil), "rpcpb.TxPoolAccount")
+	proto.RegisterType((*TxPoolContentResponse)(nil), "rpcpb.TxPoolContentResponse")
+	proto.RegisterType((*SetGasFloorRequest)(nil), "rpcpb.SetGasFloorRequest")
+	proto.RegisterType((*SetGasFloorResponse)(nil), "rpcpb.SetGasFloorResponse")
+	proto.RegisterType((*SetLogLevelRequest)(nil), "rpcpb.SetLogLevelRequest")
+	proto.RegisterType((*SetLogLevelResponse)(nil), "rpcpb.SetLogLevelResponse")
+	proto.RegisterType((*GetAccountStatesRequest)(nil), "rpcpb.GetAccountStatesRequest")
+	proto.RegisterType((*AccountState)(nil), "rpcpb.AccountState")
+	proto.RegisterType((*GetAccountStatesResponse)(nil), "rpcpb.GetAccountStatesResponse")
+	proto.RegisterType((*EventsFilterRequest)(nil), "rpcpb.EventsFilterRequest")
+	proto.RegisterType((*BuildTransactionResponse)(nil), "rpcpb.BuildTransactionResponse")
+	proto.RegisterType((*SendSignedTransactionRequest)(nil), "rpcpb.SendSignedTransactionRequest")
+	proto.RegisterType((*GetTransactionsByAddressRequest)(nil), "rpcpb.GetTransactionsByAddressRequest")
+	proto.RegisterType((*GetTransactionsByAddressResponse)(nil), "rpcpb.GetTransactionsByAddressResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for ApiService service
+
+type ApiServiceClient interface {
+	// Return the state of the neb.
+	GetNebState(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*GetNebStateResponse, error)
+	// Return the latest irreversible block.
+	LatestIrreversibleBlock(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*BlockResponse, error)
+	// Return the state of the account.
+	GetAccountState(ctx context.Context, in *GetAccountStateRequest, opts ...grpc.CallOption) (*GetAccountStateResponse, error)
+	// Call transaction
+	Call(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*CallResponse, error)
+	// Submit the signed transaction.
+	SendRawTransaction(ctx context.Context, in *SendRawTransactionRequest, opts ...grpc.CallOption) (*SendTransactionResponse, error)
+	// Get block info by the block hash.
+	GetBlockByHash(ctx context.Context, in *GetBlockByHashRequest, opts ...grpc.CallOption) (*BlockResponse, error)
+	// Get block info by the block height.
+	GetBlockByHeight(ctx context.Context, in *GetBlockByHeightRequest, opts ...grpc.CallOption) (*BlockResponse, error)
+	// Get transactionReceipt info by tansaction hash.
+	GetTransactionReceipt(ctx context.Context, in *GetTransactionByHashRequest, opts ...grpc.CallOption) (*TransactionResponse, error)
+	// Get transactionReceipt info by tansaction hash.
+	GetTransactionByContract(ctx context.Context, in *GetTransactionByContractRequest, opts ...grpc.CallOption) (*TransactionResponse, error)
+	// Subscribe message
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (ApiService_SubscribeClient, error)
+	// Get GasPrice
+	GetGasPrice(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*GasPriceResponse, error)
+	// EstimateGas
+	EstimateGas(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*GasResponse, error)
+	GetEventsByHash(ctx context.Context, in *HashRequest, opts ...grpc.CallOption) (*EventsResponse, error)
+	GetDynasty(ctx context.Context, in *ByBlockHeightRequest, opts ...grpc.CallOption) (*GetDynastyResponse, error)
+	// Return the events triggered by a contract in a range of blocks.
+	GetContractEvents(ctx context.Context, in *ContractEventsRequest, opts ...grpc.CallOption) (*ContractEventsResponse, error)
+	// Return the ABI deployed with a contract.
+	GetContractABI(ctx context.Context, in *GetAccountStateRequest, opts ...grpc.CallOption) (*ContractABIResponse, error)
+	// Return the states of the accounts at once.
+	GetAccountStates(ctx context.Context, in *GetAccountStatesRequest, opts ...grpc.CallOption) (*GetAccountStatesResponse, error)
+	// Return the events triggered by any of the contracts with any of the topics in a range of blocks.
+	GetEventsByFilter(ctx context.Context, in *EventsFilterRequest, opts ...grpc.CallOption) (*ContractEventsResponse, error)
+	// Build an unsigned transaction and return the hash to sign offline.
+	BuildTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*BuildTransactionResponse, error)
+	// Assemble the transaction with a signature produced offline and submit it.
+	SendSignedTransaction(ctx context.Context, in *SendSignedTransactionRequest, opts ...grpc.CallOption) (*SendTransactionResponse, error)
+	// Return a page of the canonical transactions sent from or to an address.
+	GetTransactionsByAddress(ctx context.Context, in *GetTransactionsByAddressRequest, opts ...grpc.CallOption) (*GetTransactionsByAddressResponse, error)
+}
+
+type apiServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewApiServiceClient(cc *grpc.ClientConn) ApiServiceClient {
+	return &apiServiceClient{cc}
+}
+
+func (c *apiServiceClient) GetNebState(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*GetNebStateResponse, error) {
+	out := new(GetNebStateResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.ApiService/GetNebState", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) LatestIrreversibleBlock(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*BlockResponse, error) {
+	out := new(BlockResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.ApiService/LatestIrreversibleBlock", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) GetAccountState(ctx context.Context, in *GetAccountStateRequest, opts ...grpc.CallOption) (*GetAccountStateResponse, error) {
+	out := new(GetAccountStateResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.ApiService/GetAccountState", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) Call(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*CallResponse, error) {
+	out := new(CallResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.ApiService/Call", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) SendRawTransaction(ctx context.Context, in *SendRawTransactionRequest, opts ...grpc.CallOption) (*SendTransactionResponse, error) {
+	out := new(SendTransactionResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.ApiService/SendRawTransaction", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) GetBlockByHash(ctx context.Context, in *GetBlockByHashRequest, opts ...grpc.CallOption) (*BlockResponse, error) {
+	out := new(BlockResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.ApiService/GetBlockByHash", in, out, c.cc, opts...)
+	if err != nil {
 		return nil, err
 	}
 	return out, nil
@@ -1708,6 +2712,69 @@ func (c *apiServiceClient) GetDynasty(ctx context.Context, in *ByBlockHeightRequ
 	return out, nil
 }
 
+func (c *apiServiceClient) GetContractEvents(ctx context.Context, in *ContractEventsRequest, opts ...grpc.CallOption) (*ContractEventsResponse, error) {
+	out := new(ContractEventsResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.ApiService/GetContractEvents", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) GetContractABI(ctx context.Context, in *GetAccountStateRequest, opts ...grpc.CallOption) (*ContractABIResponse, error) {
+	out := new(ContractABIResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.ApiService/GetContractABI", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) GetAccountStates(ctx context.Context, in *GetAccountStatesRequest, opts ...grpc.CallOption) (*GetAccountStatesResponse, error) {
+	out := new(GetAccountStatesResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.ApiService/GetAccountStates", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) GetEventsByFilter(ctx context.Context, in *EventsFilterRequest, opts ...grpc.CallOption) (*ContractEventsResponse, error) {
+	out := new(ContractEventsResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.ApiService/GetEventsByFilter", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) BuildTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*BuildTransactionResponse, error) {
+	out := new(BuildTransactionResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.ApiService/BuildTransaction", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) SendSignedTransaction(ctx context.Context, in *SendSignedTransactionRequest, opts ...grpc.CallOption) (*SendTransactionResponse, error) {
+	out := new(SendTransactionResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.ApiService/SendSignedTransaction", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) GetTransactionsByAddress(ctx context.Context, in *GetTransactionsByAddressRequest, opts ...grpc.CallOption) (*GetTransactionsByAddressResponse, error) {
+	out := new(GetTransactionsByAddressResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.ApiService/GetTransactionsByAddress", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for ApiService service
 
 type ApiServiceServer interface {
@@ -1737,6 +2804,20 @@ type ApiServiceServer interface {
 	EstimateGas(context.Context, *TransactionRequest) (*GasResponse, error)
 	GetEventsByHash(context.Context, *HashRequest) (*EventsResponse, error)
 	GetDynasty(context.Context, *ByBlockHeightRequest) (*GetDynastyResponse, error)
+	// Return the events triggered by a contract in a range of blocks.
+	GetContractEvents(context.Context, *ContractEventsRequest) (*ContractEventsResponse, error)
+	// Return the ABI deployed with a contract.
+	GetContractABI(context.Context, *GetAccountStateRequest) (*ContractABIResponse, error)
+	// Return the states of the accounts at once.
+	GetAccountStates(context.Context, *GetAccountStatesRequest) (*GetAccountStatesResponse, error)
+	// Return the events triggered by any of the contracts with any of the topics in a range of blocks.
+	GetEventsByFilter(context.Context, *EventsFilterRequest) (*ContractEventsResponse, error)
+	// Build an unsigned transaction and return the hash to sign offline.
+	BuildTransaction(context.Context, *TransactionRequest) (*BuildTransactionResponse, error)
+	// Assemble the transaction with a signature produced offline and submit it.
+	SendSignedTransaction(context.Context, *SendSignedTransactionRequest) (*SendTransactionResponse, error)
+	// Return a page of the canonical transactions sent from or to an address.
+	GetTransactionsByAddress(context.Context, *GetTransactionsByAddressRequest) (*GetTransactionsByAddressResponse, error)
 }
 
 func RegisterApiServiceServer(s *grpc.Server, srv ApiServiceServer) {
@@ -1998,6 +3079,132 @@ func _ApiService_GetDynasty_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ApiService_GetContractEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ContractEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetContractEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetContractEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetContractEvents(ctx, req.(*ContractEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_GetContractABI_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetContractABI(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetContractABI",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetContractABI(ctx, req.(*GetAccountStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_GetAccountStates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountStatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetAccountStates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetAccountStates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetAccountStates(ctx, req.(*GetAccountStatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_GetEventsByFilter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EventsFilterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetEventsByFilter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetEventsByFilter",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetEventsByFilter(ctx, req.(*EventsFilterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_BuildTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).BuildTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/BuildTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).BuildTransaction(ctx, req.(*TransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_SendSignedTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendSignedTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).SendSignedTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/SendSignedTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).SendSignedTransaction(ctx, req.(*SendSignedTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_GetTransactionsByAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionsByAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetTransactionsByAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetTransactionsByAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetTransactionsByAddress(ctx, req.(*GetTransactionsByAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ApiService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "rpcpb.ApiService",
 	HandlerType: (*ApiServiceServer)(nil),
@@ -2054,6 +3261,34 @@ var _ApiService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetDynasty",
 			Handler:    _ApiService_GetDynasty_Handler,
 		},
+		{
+			MethodName: "GetContractEvents",
+			Handler:    _ApiService_GetContractEvents_Handler,
+		},
+		{
+			MethodName: "GetContractABI",
+			Handler:    _ApiService_GetContractABI_Handler,
+		},
+		{
+			MethodName: "GetAccountStates",
+			Handler:    _ApiService_GetAccountStates_Handler,
+		},
+		{
+			MethodName: "GetEventsByFilter",
+			Handler:    _ApiService_GetEventsByFilter_Handler,
+		},
+		{
+			MethodName: "BuildTransaction",
+			Handler:    _ApiService_BuildTransaction_Handler,
+		},
+		{
+			MethodName: "SendSignedTransaction",
+			Handler:    _ApiService_SendSignedTransaction_Handler,
+		},
+		{
+			MethodName: "GetTransactionsByAddress",
+			Handler:    _ApiService_GetTransactionsByAddress_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -2090,118 +3325,250 @@ type AdminServiceClient interface {
 	GetConfig(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*GetConfigResponse, error)
 	// Return the p2p node info.
 	NodeInfo(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*NodeInfoResponse, error)
+	// Return the execution trace of the transaction.
+	TraceTransaction(ctx context.Context, in *HashRequest, opts ...grpc.CallOption) (*TraceResponse, error)
+	// UnlockedAccounts return the unlocked accounts and when they are locked again.
+	UnlockedAccounts(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*UnlockedAccountsResponse, error)
+	// LockAllAccounts lock all the unlocked accounts.
+	LockAllAccounts(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*LockAccountResponse, error)
+	// AuditNonces detect the secp256k1 signatures of the blocks and their transactions sharing nonces.
+	AuditNonces(ctx context.Context, in *AuditNoncesRequest, opts ...grpc.CallOption) (*AuditNoncesResponse, error)
+	// Decrypt the encrypted payload of a transaction by an unlocked account.
+	DecryptPayload(ctx context.Context, in *DecryptPayloadRequest, opts ...grpc.CallOption) (*DecryptPayloadResponse, error)
+	// AddPeer connect to a peer by its ipfs address.
+	AddPeer(ctx context.Context, in *AddPeerRequest, opts ...grpc.CallOption) (*PeerResponse, error)
+	// RemovePeer disconnect a peer and drop it from the route table.
+	RemovePeer(ctx context.Context, in *RemovePeerRequest, opts ...grpc.CallOption) (*PeerResponse, error)
+	// StartMining enable mining with the passphrase of the miner.
+	StartMining(ctx context.Context, in *StartMiningRequest, opts ...grpc.CallOption) (*MiningResponse, error)
+	// StopMining disable mining.
+	StopMining(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*MiningResponse, error)
+	// TxPoolContent return the pending and queued txs in the pool.
+	TxPoolContent(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*TxPoolContentResponse, error)
+	// SetGasFloor change the lowest gasPrice of the txs accepted by the pool.
+	SetGasFloor(ctx context.Context, in *SetGasFloorRequest, opts ...grpc.CallOption) (*SetGasFloorResponse, error)
+	// SetLogLevel change the level of the verbose log.
+	SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelResponse, error)
+}
+
+type adminServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAdminServiceClient(cc *grpc.ClientConn) AdminServiceClient {
+	return &adminServiceClient{cc}
+}
+
+func (c *adminServiceClient) Accounts(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*AccountsResponse, error) {
+	out := new(AccountsResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/Accounts", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) NewAccount(ctx context.Context, in *NewAccountRequest, opts ...grpc.CallOption) (*NewAccountResponse, error) {
+	out := new(NewAccountResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/NewAccount", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) UnlockAccount(ctx context.Context, in *UnlockAccountRequest, opts ...grpc.CallOption) (*UnlockAccountResponse, error) {
+	out := new(UnlockAccountResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/UnlockAccount", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) LockAccount(ctx context.Context, in *LockAccountRequest, opts ...grpc.CallOption) (*LockAccountResponse, error) {
+	out := new(LockAccountResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/LockAccount", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SendTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*SendTransactionResponse, error) {
+	out := new(SendTransactionResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/SendTransaction", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SignHash(ctx context.Context, in *SignHashRequest, opts ...grpc.CallOption) (*SignHashResponse, error) {
+	out := new(SignHashResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/SignHash", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GenerateRandomSeed(ctx context.Context, in *GenerateRandomSeedRequest, opts ...grpc.CallOption) (*GenerateRandomSeedResponse, error) {
+	out := new(GenerateRandomSeedResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/GenerateRandomSeed", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SignTransactionWithPassphrase(ctx context.Context, in *SignTransactionPassphraseRequest, opts ...grpc.CallOption) (*SignTransactionPassphraseResponse, error) {
+	out := new(SignTransactionPassphraseResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/SignTransactionWithPassphrase", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-type adminServiceClient struct {
-	cc *grpc.ClientConn
+func (c *adminServiceClient) SendTransactionWithPassphrase(ctx context.Context, in *SendTransactionPassphraseRequest, opts ...grpc.CallOption) (*SendTransactionResponse, error) {
+	out := new(SendTransactionResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/SendTransactionWithPassphrase", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func NewAdminServiceClient(cc *grpc.ClientConn) AdminServiceClient {
-	return &adminServiceClient{cc}
+func (c *adminServiceClient) StartPprof(ctx context.Context, in *PprofRequest, opts ...grpc.CallOption) (*PprofResponse, error) {
+	out := new(PprofResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/StartPprof", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (c *adminServiceClient) Accounts(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*AccountsResponse, error) {
-	out := new(AccountsResponse)
-	err := grpc.Invoke(ctx, "/rpcpb.AdminService/Accounts", in, out, c.cc, opts...)
+func (c *adminServiceClient) GetConfig(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*GetConfigResponse, error) {
+	out := new(GetConfigResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/GetConfig", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *adminServiceClient) NewAccount(ctx context.Context, in *NewAccountRequest, opts ...grpc.CallOption) (*NewAccountResponse, error) {
-	out := new(NewAccountResponse)
-	err := grpc.Invoke(ctx, "/rpcpb.AdminService/NewAccount", in, out, c.cc, opts...)
+func (c *adminServiceClient) NodeInfo(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*NodeInfoResponse, error) {
+	out := new(NodeInfoResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/NodeInfo", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *adminServiceClient) UnlockAccount(ctx context.Context, in *UnlockAccountRequest, opts ...grpc.CallOption) (*UnlockAccountResponse, error) {
-	out := new(UnlockAccountResponse)
-	err := grpc.Invoke(ctx, "/rpcpb.AdminService/UnlockAccount", in, out, c.cc, opts...)
+func (c *adminServiceClient) TraceTransaction(ctx context.Context, in *HashRequest, opts ...grpc.CallOption) (*TraceResponse, error) {
+	out := new(TraceResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/TraceTransaction", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *adminServiceClient) LockAccount(ctx context.Context, in *LockAccountRequest, opts ...grpc.CallOption) (*LockAccountResponse, error) {
+func (c *adminServiceClient) UnlockedAccounts(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*UnlockedAccountsResponse, error) {
+	out := new(UnlockedAccountsResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/UnlockedAccounts", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) LockAllAccounts(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*LockAccountResponse, error) {
 	out := new(LockAccountResponse)
-	err := grpc.Invoke(ctx, "/rpcpb.AdminService/LockAccount", in, out, c.cc, opts...)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/LockAllAccounts", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *adminServiceClient) SendTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*SendTransactionResponse, error) {
-	out := new(SendTransactionResponse)
-	err := grpc.Invoke(ctx, "/rpcpb.AdminService/SendTransaction", in, out, c.cc, opts...)
+func (c *adminServiceClient) AuditNonces(ctx context.Context, in *AuditNoncesRequest, opts ...grpc.CallOption) (*AuditNoncesResponse, error) {
+	out := new(AuditNoncesResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/AuditNonces", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *adminServiceClient) SignHash(ctx context.Context, in *SignHashRequest, opts ...grpc.CallOption) (*SignHashResponse, error) {
-	out := new(SignHashResponse)
-	err := grpc.Invoke(ctx, "/rpcpb.AdminService/SignHash", in, out, c.cc, opts...)
+func (c *adminServiceClient) DecryptPayload(ctx context.Context, in *DecryptPayloadRequest, opts ...grpc.CallOption) (*DecryptPayloadResponse, error) {
+	out := new(DecryptPayloadResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/DecryptPayload", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *adminServiceClient) GenerateRandomSeed(ctx context.Context, in *GenerateRandomSeedRequest, opts ...grpc.CallOption) (*GenerateRandomSeedResponse, error) {
-	out := new(GenerateRandomSeedResponse)
-	err := grpc.Invoke(ctx, "/rpcpb.AdminService/GenerateRandomSeed", in, out, c.cc, opts...)
+func (c *adminServiceClient) AddPeer(ctx context.Context, in *AddPeerRequest, opts ...grpc.CallOption) (*PeerResponse, error) {
+	out := new(PeerResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/AddPeer", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *adminServiceClient) SignTransactionWithPassphrase(ctx context.Context, in *SignTransactionPassphraseRequest, opts ...grpc.CallOption) (*SignTransactionPassphraseResponse, error) {
-	out := new(SignTransactionPassphraseResponse)
-	err := grpc.Invoke(ctx, "/rpcpb.AdminService/SignTransactionWithPassphrase", in, out, c.cc, opts...)
+func (c *adminServiceClient) RemovePeer(ctx context.Context, in *RemovePeerRequest, opts ...grpc.CallOption) (*PeerResponse, error) {
+	out := new(PeerResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/RemovePeer", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *adminServiceClient) SendTransactionWithPassphrase(ctx context.Context, in *SendTransactionPassphraseRequest, opts ...grpc.CallOption) (*SendTransactionResponse, error) {
-	out := new(SendTransactionResponse)
-	err := grpc.Invoke(ctx, "/rpcpb.AdminService/SendTransactionWithPassphrase", in, out, c.cc, opts...)
+func (c *adminServiceClient) StartMining(ctx context.Context, in *StartMiningRequest, opts ...grpc.CallOption) (*MiningResponse, error) {
+	out := new(MiningResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/StartMining", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *adminServiceClient) StartPprof(ctx context.Context, in *PprofRequest, opts ...grpc.CallOption) (*PprofResponse, error) {
-	out := new(PprofResponse)
-	err := grpc.Invoke(ctx, "/rpcpb.AdminService/StartPprof", in, out, c.cc, opts...)
+func (c *adminServiceClient) StopMining(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*MiningResponse, error) {
+	out := new(MiningResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/StopMining", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *adminServiceClient) GetConfig(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*GetConfigResponse, error) {
-	out := new(GetConfigResponse)
-	err := grpc.Invoke(ctx, "/rpcpb.AdminService/GetConfig", in, out, c.cc, opts...)
+func (c *adminServiceClient) TxPoolContent(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*TxPoolContentResponse, error) {
+	out := new(TxPoolContentResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/TxPoolContent", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *adminServiceClient) NodeInfo(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*NodeInfoResponse, error) {
-	out := new(NodeInfoResponse)
-	err := grpc.Invoke(ctx, "/rpcpb.AdminService/NodeInfo", in, out, c.cc, opts...)
+func (c *adminServiceClient) SetGasFloor(ctx context.Context, in *SetGasFloorRequest, opts ...grpc.CallOption) (*SetGasFloorResponse, error) {
+	out := new(SetGasFloorResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/SetGasFloor", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelResponse, error) {
+	out := new(SetLogLevelResponse)
+	err := grpc.Invoke(ctx, "/rpcpb.AdminService/SetLogLevel", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -2233,6 +3600,30 @@ type AdminServiceServer interface {
 	GetConfig(context.Context, *NonParamsRequest) (*GetConfigResponse, error)
 	// Return the p2p node info.
 	NodeInfo(context.Context, *NonParamsRequest) (*NodeInfoResponse, error)
+	// Return the execution trace of the transaction.
+	TraceTransaction(context.Context, *HashRequest) (*TraceResponse, error)
+	// UnlockedAccounts return the unlocked accounts and when they are locked again.
+	UnlockedAccounts(context.Context, *NonParamsRequest) (*UnlockedAccountsResponse, error)
+	// LockAllAccounts lock all the unlocked accounts.
+	LockAllAccounts(context.Context, *NonParamsRequest) (*LockAccountResponse, error)
+	// AuditNonces detect the secp256k1 signatures of the blocks and their transactions sharing nonces.
+	AuditNonces(context.Context, *AuditNoncesRequest) (*AuditNoncesResponse, error)
+	// Decrypt the encrypted payload of a transaction by an unlocked account.
+	DecryptPayload(context.Context, *DecryptPayloadRequest) (*DecryptPayloadResponse, error)
+	// AddPeer connect to a peer by its ipfs address.
+	AddPeer(context.Context, *AddPeerRequest) (*PeerResponse, error)
+	// RemovePeer disconnect a peer and drop it from the route table.
+	RemovePeer(context.Context, *RemovePeerRequest) (*PeerResponse, error)
+	// StartMining enable mining with the passphrase of the miner.
+	StartMining(context.Context, *StartMiningRequest) (*MiningResponse, error)
+	// StopMining disable mining.
+	StopMining(context.Context, *NonParamsRequest) (*MiningResponse, error)
+	// TxPoolContent return the pending and queued txs in the pool.
+	TxPoolContent(context.Context, *NonParamsRequest) (*TxPoolContentResponse, error)
+	// SetGasFloor change the lowest gasPrice of the txs accepted by the pool.
+	SetGasFloor(context.Context, *SetGasFloorRequest) (*SetGasFloorResponse, error)
+	// SetLogLevel change the level of the verbose log.
+	SetLogLevel(context.Context, *SetLogLevelRequest) (*SetLogLevelResponse, error)
 }
 
 func RegisterAdminServiceServer(s *grpc.Server, srv AdminServiceServer) {
@@ -2455,6 +3846,222 @@ func _AdminService_NodeInfo_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AdminService_TraceTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).TraceTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/TraceTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).TraceTransaction(ctx, req.(*HashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_UnlockedAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NonParamsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).UnlockedAccounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/UnlockedAccounts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).UnlockedAccounts(ctx, req.(*NonParamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_LockAllAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NonParamsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).LockAllAccounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/LockAllAccounts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).LockAllAccounts(ctx, req.(*NonParamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_AuditNonces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuditNoncesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).AuditNonces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/AuditNonces",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).AuditNonces(ctx, req.(*AuditNoncesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_DecryptPayload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DecryptPayloadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).DecryptPayload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/DecryptPayload",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).DecryptPayload(ctx, req.(*DecryptPayloadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_AddPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddPeerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).AddPeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/AddPeer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).AddPeer(ctx, req.(*AddPeerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_RemovePeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemovePeerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).RemovePeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/RemovePeer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).RemovePeer(ctx, req.(*RemovePeerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_StartMining_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartMiningRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).StartMining(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/StartMining",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).StartMining(ctx, req.(*StartMiningRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_StopMining_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NonParamsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).StopMining(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/StopMining",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).StopMining(ctx, req.(*NonParamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_TxPoolContent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NonParamsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).TxPoolContent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/TxPoolContent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).TxPoolContent(ctx, req.(*NonParamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SetGasFloor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetGasFloorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetGasFloor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/SetGasFloor",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetGasFloor(ctx, req.(*SetGasFloorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SetLogLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLogLevelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetLogLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/SetLogLevel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetLogLevel(ctx, req.(*SetLogLevelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _AdminService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "rpcpb.AdminService",
 	HandlerType: (*AdminServiceServer)(nil),
@@ -2507,6 +4114,54 @@ var _AdminService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "NodeInfo",
 			Handler:    _AdminService_NodeInfo_Handler,
 		},
+		{
+			MethodName: "TraceTransaction",
+			Handler:    _AdminService_TraceTransaction_Handler,
+		},
+		{
+			MethodName: "UnlockedAccounts",
+			Handler:    _AdminService_UnlockedAccounts_Handler,
+		},
+		{
+			MethodName: "LockAllAccounts",
+			Handler:    _AdminService_LockAllAccounts_Handler,
+		},
+		{
+			MethodName: "AuditNonces",
+			Handler:    _AdminService_AuditNonces_Handler,
+		},
+		{
+			MethodName: "DecryptPayload",
+			Handler:    _AdminService_DecryptPayload_Handler,
+		},
+		{
+			MethodName: "AddPeer",
+			Handler:    _AdminService_AddPeer_Handler,
+		},
+		{
+			MethodName: "RemovePeer",
+			Handler:    _AdminService_RemovePeer_Handler,
+		},
+		{
+			MethodName: "StartMining",
+			Handler:    _AdminService_StartMining_Handler,
+		},
+		{
+			MethodName: "StopMining",
+			Handler:    _AdminService_StopMining_Handler,
+		},
+		{
+			MethodName: "TxPoolContent",
+			Handler:    _AdminService_TxPoolContent_Handler,
+		},
+		{
+			MethodName: "SetGasFloor",
+			Handler:    _AdminService_SetGasFloor_Handler,
+		},
+		{
+			MethodName: "SetLogLevel",
+			Handler:    _AdminService_SetLogLevel_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "rpc.proto",
@@ -2515,157 +4170,236 @@ var _AdminService_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("rpc.proto", fileDescriptorRpc) }
 
 var fileDescriptorRpc = []byte{
-	// 2417 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x59, 0x4b, 0x6f, 0x1b, 0xc9,
-	0xf1, 0x07, 0xf5, 0x24, 0x8b, 0x94, 0x44, 0xb7, 0x5e, 0x23, 0xea, 0x61, 0xa9, 0xbd, 0x7f, 0x5b,
-	0x6b, 0xec, 0x8a, 0x6b, 0x19, 0xf0, 0x3f, 0xf0, 0x62, 0x03, 0xd8, 0x8e, 0x57, 0xeb, 0xc0, 0x30,
-	0x94, 0x91, 0x37, 0x59, 0x20, 0x71, 0x88, 0xe6, 0xb0, 0x49, 0x4e, 0x76, 0x34, 0xc3, 0x4c, 0x37,
-	0x65, 0xcb, 0x39, 0x04, 0xf0, 0x39, 0x39, 0xe5, 0x92, 0x43, 0x90, 0xcf, 0x90, 0x2f, 0x90, 0x4f,
-	0x91, 0x43, 0xae, 0x39, 0xe4, 0x73, 0x04, 0x41, 0x57, 0x77, 0xcf, 0x8b, 0x43, 0x31, 0xce, 0x21,
-	0xb7, 0xe9, 0xea, 0xee, 0xaa, 0xea, 0x7a, 0xfc, 0xba, 0xaa, 0x07, 0x6a, 0xf1, 0xc8, 0x3b, 0x19,
-	0xc5, 0x91, 0x8c, 0xc8, 0x62, 0x3c, 0xf2, 0x46, 0xdd, 0xd6, 0xde, 0x20, 0x8a, 0x06, 0x01, 0x6f,
-	0xb3, 0x91, 0xdf, 0x66, 0x61, 0x18, 0x49, 0x26, 0xfd, 0x28, 0x14, 0x7a, 0x51, 0xeb, 0x07, 0x03,
-	0x5f, 0x0e, 0xc7, 0xdd, 0x13, 0x2f, 0xba, 0x6c, 0x87, 0xbc, 0x3b, 0x0e, 0x98, 0xf0, 0xa3, 0xf6,
-	0x20, 0xfa, 0xdc, 0x0c, 0xda, 0x5e, 0x14, 0x0a, 0x1e, 0x8a, 0xb1, 0x68, 0x8f, 0xba, 0x6d, 0x21,
-	0x99, 0xe4, 0x66, 0xe7, 0xc3, 0xd9, 0x3b, 0x63, 0xae, 0x36, 0x75, 0x83, 0xc8, 0xfb, 0xde, 0x6c,
-	0x7a, 0x34, 0x6b, 0x53, 0xc8, 0xbb, 0x01, 0x97, 0x6a, 0x9b, 0x17, 0x85, 0x7d, 0x7f, 0xa0, 0xf7,
-	0xd1, 0xfb, 0xd0, 0xbc, 0x18, 0x77, 0x85, 0x17, 0xfb, 0x5d, 0xee, 0xf2, 0x5f, 0x8f, 0xb9, 0x90,
-	0x64, 0x0b, 0x96, 0x64, 0x34, 0xf2, 0x3d, 0xe1, 0x54, 0x0e, 0xe7, 0x8f, 0x6b, 0xae, 0x19, 0xd1,
-	0xaf, 0xe0, 0x56, 0x66, 0xad, 0x18, 0xa9, 0x03, 0x90, 0x0d, 0x58, 0xc4, 0x69, 0xa7, 0x72, 0x58,
-	0x39, 0xae, 0xb9, 0x7a, 0x40, 0x08, 0x2c, 0xf4, 0x98, 0x64, 0xce, 0x1c, 0x12, 0xf1, 0x9b, 0x12,
-	0x68, 0xbe, 0x8a, 0xc2, 0x73, 0x16, 0xb3, 0x4b, 0x61, 0x44, 0xd1, 0x3f, 0xcd, 0x29, 0x62, 0x8f,
-	0xbf, 0x08, 0xfb, 0x51, 0xc2, 0x72, 0x15, 0xe6, 0xfc, 0x9e, 0xe1, 0x37, 0xe7, 0xf7, 0xc8, 0x0e,
-	0x54, 0xbd, 0x21, 0xf3, 0xc3, 0x8e, 0xdf, 0x43, 0x86, 0x2b, 0xee, 0x32, 0x8e, 0x5f, 0xf4, 0x48,
-	0x0b, 0xaa, 0x5e, 0xe4, 0x87, 0x5d, 0x26, 0xb8, 0x33, 0x8f, 0x1b, 0x92, 0x31, 0xd9, 0x07, 0x18,
-	0x71, 0x1e, 0x77, 0xbc, 0x68, 0x1c, 0x4a, 0x67, 0x01, 0x37, 0xd6, 0x14, 0xe5, 0x99, 0x22, 0x10,
-	0x0a, 0x0d, 0x71, 0x1d, 0x7a, 0xc3, 0x38, 0x0a, 0xfd, 0xf7, 0xbc, 0xe7, 0x2c, 0x1e, 0x56, 0x8e,
-	0xab, 0x6e, 0x8e, 0x46, 0x6e, 0x43, 0xbd, 0x3b, 0xf6, 0xbe, 0xe7, 0xb2, 0x23, 0xfc, 0xf7, 0xdc,
-	0x59, 0x3a, 0xac, 0x1c, 0x2f, 0xba, 0xa0, 0x49, 0x17, 0xfe, 0x7b, 0x4e, 0x3e, 0x85, 0x26, 0xda,
-	0xd1, 0x8b, 0x82, 0xce, 0x15, 0x8f, 0x85, 0x1f, 0x85, 0x0e, 0xa0, 0x1e, 0x6b, 0x96, 0xfe, 0x53,
-	0x4d, 0x26, 0xa7, 0x50, 0x8f, 0xa3, 0xb1, 0xe4, 0x1d, 0xc9, 0xba, 0x01, 0x77, 0xea, 0x87, 0xf3,
-	0xc7, 0xf5, 0xd3, 0x5b, 0x27, 0x18, 0x4b, 0x27, 0xae, 0x9a, 0x79, 0xad, 0x26, 0x5c, 0x88, 0x93,
-	0x6f, 0xfa, 0x08, 0x20, 0x9d, 0x99, 0xb0, 0x8b, 0x03, 0xcb, 0xac, 0xd7, 0x8b, 0xb9, 0x10, 0xce,
-	0x1c, 0x3a, 0xca, 0x0e, 0xe9, 0xdf, 0x2b, 0xb0, 0x7e, 0xc6, 0xe5, 0x2b, 0xde, 0xbd, 0x50, 0x81,
-	0x95, 0x58, 0x36, 0x6b, 0xc9, 0x4a, 0xde, 0x92, 0x04, 0x16, 0x24, 0xf3, 0x03, 0xeb, 0x31, 0xf5,
-	0x4d, 0x9a, 0x30, 0x1f, 0xf8, 0x5d, 0x63, 0x58, 0xf5, 0xa9, 0x42, 0x63, 0xc8, 0xfd, 0xc1, 0x50,
-	0xdb, 0x73, 0xc1, 0x35, 0xa3, 0x52, 0x3b, 0x2c, 0x95, 0xdb, 0xa1, 0x68, 0xf7, 0xe5, 0x12, 0xbb,
-	0x3b, 0xb0, 0x6c, 0xb9, 0x54, 0x91, 0x8b, 0x1d, 0xd2, 0x2f, 0xa0, 0xf9, 0xc4, 0x43, 0x8f, 0x8a,
-	0xe4, 0x54, 0x7b, 0x50, 0x33, 0x07, 0xe7, 0x36, 0x64, 0x53, 0x02, 0xfd, 0x31, 0x6c, 0x9d, 0x71,
-	0x69, 0x36, 0x19, 0x73, 0xe8, 0x38, 0xcf, 0xd8, 0x4f, 0x1b, 0xd5, 0x0e, 0x33, 0xc7, 0x9c, 0xcb,
-	0x1e, 0x93, 0xbe, 0x81, 0xed, 0x09, 0x5e, 0x46, 0x09, 0x07, 0x96, 0xbb, 0x2c, 0x60, 0xa1, 0xc7,
-	0x2d, 0x33, 0x33, 0x54, 0x19, 0x12, 0x46, 0x8a, 0xae, 0x79, 0xe9, 0x01, 0xda, 0xfb, 0x7a, 0xa4,
-	0xa3, 0x76, 0xc5, 0xc5, 0x6f, 0xfa, 0x2b, 0x68, 0x3c, 0x63, 0x41, 0x90, 0xf0, 0xdc, 0x82, 0xa5,
-	0x98, 0x8b, 0x71, 0x20, 0x0d, 0x4b, 0x33, 0x52, 0x61, 0xc9, 0xdf, 0x71, 0x4f, 0x05, 0x13, 0x8f,
-	0x63, 0xe3, 0x32, 0x30, 0xa4, 0xe7, 0x71, 0x4c, 0x8e, 0xa0, 0xc1, 0x85, 0xf4, 0x2f, 0x99, 0xe4,
-	0x9d, 0x01, 0x13, 0xc6, 0x83, 0x75, 0x4b, 0x3b, 0x63, 0x82, 0x9e, 0xc0, 0xc6, 0xd3, 0xeb, 0xa7,
-	0x0a, 0x41, 0xbe, 0xc1, 0xb3, 0x65, 0x92, 0xdf, 0x1c, 0xbd, 0x92, 0x3b, 0xfa, 0x67, 0x40, 0xce,
-	0xb8, 0xfc, 0xd1, 0x75, 0xc8, 0x84, 0xbc, 0xce, 0x6a, 0x78, 0xe9, 0x87, 0x3c, 0x4e, 0xa0, 0x42,
-	0x8f, 0xe8, 0xbf, 0x2a, 0x40, 0x5e, 0xc7, 0x2c, 0x14, 0xcc, 0x53, 0xa0, 0x68, 0x99, 0x13, 0x58,
-	0xe8, 0xc7, 0xd1, 0xa5, 0x39, 0x0e, 0x7e, 0xab, 0xa8, 0x96, 0x91, 0x39, 0xc3, 0x9c, 0x8c, 0x94,
-	0xb9, 0xae, 0x58, 0x30, 0xb6, 0xf9, 0xac, 0x07, 0xa9, 0x11, 0x17, 0xb2, 0x46, 0xdc, 0x85, 0xda,
-	0x80, 0x89, 0xce, 0x28, 0xf6, 0x3d, 0x8e, 0x09, 0x5c, 0x73, 0xab, 0x03, 0x26, 0xce, 0xd5, 0xd8,
-	0x4e, 0x06, 0xfe, 0xa5, 0x2f, 0x4d, 0x30, 0xaa, 0xc9, 0x97, 0x6a, 0x4c, 0x4e, 0x15, 0x70, 0x84,
-	0x32, 0x66, 0x9e, 0xc4, 0x08, 0xac, 0x9f, 0x6e, 0x99, 0x54, 0x7c, 0x66, 0xc8, 0x46, 0x67, 0x37,
-	0x59, 0xa7, 0x0e, 0xdb, 0xf5, 0x43, 0x16, 0x5f, 0x63, 0x8a, 0x37, 0x5c, 0x33, 0x4a, 0x5c, 0xb9,
-	0x61, 0x52, 0x47, 0xb9, 0xf2, 0x3d, 0xac, 0x15, 0x18, 0xa9, 0xed, 0x22, 0x1a, 0xc7, 0x49, 0x80,
-	0x98, 0x91, 0xf2, 0xa6, 0xfe, 0xea, 0x20, 0x17, 0xe3, 0x4d, 0x4d, 0x7a, 0x7d, 0x3d, 0xe2, 0x0a,
-	0xe4, 0xfa, 0xe3, 0x10, 0x0d, 0x69, 0x41, 0xce, 0x8e, 0x95, 0x6c, 0x16, 0x0f, 0x04, 0x9a, 0xa5,
-	0xe6, 0xe2, 0x37, 0x6d, 0xc3, 0xce, 0x05, 0x0f, 0x7b, 0x2e, 0x7b, 0x5b, 0xee, 0x02, 0x44, 0xe6,
-	0x0a, 0x1e, 0x41, 0x23, 0xf3, 0x2f, 0x60, 0x5b, 0x6d, 0xc8, 0xad, 0x4e, 0x1d, 0x2c, 0xdf, 0x0d,
-	0x99, 0x18, 0x5a, 0xa5, 0xf5, 0x48, 0x25, 0xbc, 0xb5, 0x4b, 0x27, 0x05, 0x21, 0x4c, 0x78, 0x4b,
-	0x7f, 0x62, 0xc0, 0xa8, 0x03, 0x9b, 0x67, 0x5c, 0x62, 0xa8, 0x3d, 0xbd, 0xfe, 0x86, 0x89, 0x61,
-	0x46, 0x95, 0x0c, 0x67, 0xfc, 0x26, 0xa7, 0xb0, 0xd9, 0x1f, 0x07, 0x41, 0xa7, 0xef, 0x07, 0x41,
-	0x47, 0xa6, 0x0a, 0x21, 0xf3, 0xaa, 0xbb, 0xae, 0x26, 0xbf, 0xf6, 0x83, 0x20, 0xa3, 0x2b, 0xe5,
-	0x98, 0x95, 0x56, 0xc0, 0x7f, 0x12, 0xcd, 0xff, 0x95, 0x98, 0x07, 0xb0, 0x7b, 0xc6, 0x65, 0x86,
-	0x32, 0xf3, 0x34, 0xf4, 0x4b, 0xb8, 0x5d, 0xdc, 0x52, 0x8c, 0x8a, 0xa9, 0x20, 0x44, 0xff, 0xbc,
-	0x00, 0x2b, 0x78, 0xa8, 0xc4, 0x19, 0x65, 0x06, 0xbb, 0x0d, 0xf5, 0x11, 0x8b, 0x79, 0x28, 0x3b,
-	0x38, 0x65, 0xa2, 0x47, 0x93, 0x94, 0x7a, 0x19, 0x13, 0xcc, 0xe7, 0x4c, 0x50, 0x9e, 0x51, 0xd9,
-	0x0b, 0x75, 0xb1, 0x70, 0xa1, 0xee, 0x41, 0x4d, 0xfa, 0x97, 0x5c, 0x48, 0x76, 0x39, 0xc2, 0x84,
-	0x9a, 0x77, 0x53, 0x42, 0xee, 0x6e, 0x59, 0xce, 0xdf, 0x2d, 0xfb, 0x00, 0x58, 0xe0, 0x74, 0xe2,
-	0x28, 0x92, 0x06, 0xd1, 0x6b, 0x48, 0x71, 0xa3, 0x48, 0xaa, 0x9d, 0xf2, 0x9d, 0xd0, 0x93, 0x35,
-	0x6d, 0x03, 0xf9, 0x4e, 0xe0, 0x94, 0x42, 0xba, 0x2b, 0x1e, 0x4a, 0x33, 0x0b, 0x06, 0xe9, 0x90,
-	0x84, 0x0b, 0x9e, 0xc0, 0x6a, 0x52, 0x48, 0xe9, 0x35, 0x75, 0xcc, 0xe6, 0xd6, 0x49, 0x42, 0xd6,
-	0x39, 0xad, 0xbf, 0xd5, 0x1e, 0x77, 0xc5, 0xcb, 0x0e, 0x95, 0x21, 0x10, 0xb5, 0x9c, 0x86, 0x06,
-	0x1c, 0x1c, 0x90, 0x03, 0x80, 0x98, 0x85, 0xbd, 0xe8, 0xf2, 0x82, 0xf3, 0x9e, 0xb3, 0xa2, 0x05,
-	0xa7, 0x14, 0x72, 0x08, 0x75, 0x3d, 0x3a, 0x8f, 0xa3, 0xa8, 0xef, 0xac, 0x6a, 0x84, 0xcd, 0x90,
-	0x94, 0xee, 0xbe, 0xe8, 0xf4, 0xfd, 0x90, 0x05, 0xbe, 0xbc, 0x76, 0xd6, 0x30, 0xb2, 0xc0, 0x17,
-	0x5f, 0x1b, 0x0a, 0xf9, 0x21, 0x34, 0x32, 0xa1, 0x27, 0x9c, 0x1e, 0x96, 0x04, 0x2d, 0x83, 0x43,
-	0x25, 0xd9, 0xe8, 0xe6, 0xd6, 0xd3, 0xbf, 0xce, 0xc3, 0x7a, 0x59, 0xce, 0x96, 0x85, 0x89, 0x03,
-	0xd6, 0x1b, 0xc5, 0x12, 0xca, 0x62, 0xf2, 0xfc, 0x04, 0x26, 0x2f, 0x4c, 0x62, 0xf2, 0x62, 0x29,
-	0x26, 0x2f, 0x65, 0x23, 0x28, 0x17, 0x25, 0xcb, 0xc5, 0x28, 0xb1, 0x58, 0x59, 0x4d, 0xb1, 0x32,
-	0x81, 0xa4, 0x5a, 0x0a, 0x49, 0x79, 0x64, 0x87, 0x9b, 0x90, 0xbd, 0x5e, 0x40, 0xf6, 0x32, 0x64,
-	0x6a, 0x94, 0x22, 0x13, 0x22, 0xb2, 0x64, 0x72, 0x2c, 0xd0, 0xbf, 0x8b, 0xae, 0x19, 0xa9, 0x80,
-	0x54, 0xfc, 0xc7, 0x82, 0xf7, 0x8c, 0x63, 0x97, 0x07, 0x4c, 0x7c, 0x2b, 0x78, 0x8f, 0xdc, 0x81,
-	0x95, 0xcc, 0xd5, 0x1b, 0xc5, 0xe8, 0xd6, 0x9a, 0xdb, 0x48, 0x2f, 0xdf, 0x28, 0x26, 0xff, 0x07,
-	0xab, 0x76, 0x91, 0xb9, 0xbf, 0x9b, 0xb8, 0xca, 0x6e, 0x75, 0x91, 0x48, 0x1f, 0xc2, 0xad, 0x57,
-	0xfc, 0xad, 0xa9, 0x26, 0x2c, 0x1e, 0x1c, 0x00, 0x8c, 0x98, 0x10, 0xa3, 0x61, 0xac, 0x52, 0xb0,
-	0x62, 0xd3, 0xd9, 0x52, 0xe8, 0x09, 0x90, 0xec, 0xa6, 0xb4, 0xfa, 0x98, 0x82, 0x22, 0x01, 0x6c,
-	0x7c, 0x1b, 0x2a, 0x14, 0x29, 0xc8, 0x99, 0x5e, 0xfc, 0xe4, 0x35, 0x98, 0x2b, 0x6a, 0xa0, 0x20,
-	0xa2, 0x37, 0x8e, 0x59, 0x72, 0x1d, 0x2d, 0xb8, 0xc9, 0x98, 0xb6, 0x61, 0xb3, 0x20, 0xad, 0xb4,
-	0x94, 0xa9, 0xda, 0x52, 0x46, 0x1d, 0xe7, 0xe5, 0x47, 0x28, 0x47, 0x3f, 0x87, 0xf5, 0x97, 0x1f,
-	0xc1, 0xfe, 0x27, 0xb0, 0x76, 0xe1, 0x0f, 0xc2, 0x2c, 0x4e, 0x4f, 0x3f, 0xb8, 0xcd, 0x9b, 0x39,
-	0x1d, 0x87, 0x98, 0x37, 0x4d, 0x98, 0x67, 0xc1, 0xc0, 0x54, 0x69, 0xea, 0x93, 0xde, 0x85, 0x66,
-	0xca, 0x32, 0xcd, 0xb8, 0x89, 0x4b, 0xf5, 0x37, 0xb0, 0x73, 0xc6, 0x43, 0x1e, 0x2b, 0x94, 0x4b,
-	0x60, 0x63, 0xb6, 0x12, 0x29, 0x9e, 0x0b, 0x05, 0x3c, 0x5a, 0x17, 0x83, 0xe7, 0x08, 0x3c, 0x77,
-	0x60, 0x45, 0x95, 0x95, 0x42, 0x46, 0xb1, 0x86, 0xfc, 0x79, 0x5c, 0xd2, 0xb0, 0x44, 0xa5, 0x18,
-	0x7d, 0x0d, 0xad, 0x32, 0xe1, 0x69, 0x1b, 0x70, 0x15, 0xf7, 0xb5, 0x00, 0xad, 0xf2, 0xf2, 0x55,
-	0xdc, 0x47, 0xee, 0xbb, 0x50, 0x53, 0x53, 0x23, 0x04, 0x35, 0x2d, 0x5c, 0xad, 0x45, 0x44, 0xa3,
-	0xbf, 0x85, 0x43, 0x75, 0xf4, 0x0c, 0xe6, 0x9c, 0x27, 0x61, 0x61, 0x4f, 0xf6, 0x25, 0xd4, 0xb3,
-	0xf7, 0x69, 0x05, 0xd1, 0x78, 0xa7, 0x0c, 0xd3, 0x74, 0x79, 0x95, 0x5d, 0x3d, 0x2b, 0xf4, 0xe8,
-	0xff, 0xc3, 0xd1, 0x0d, 0x0a, 0xdc, 0xe0, 0x0c, 0xa5, 0x79, 0xbe, 0xc2, 0xf9, 0x1f, 0x6b, 0xde,
-	0x86, 0xe6, 0x99, 0x81, 0xaf, 0x44, 0xd1, 0x1c, 0xc6, 0x55, 0xf2, 0x18, 0x47, 0x8f, 0xa0, 0x3e,
-	0xab, 0xba, 0x78, 0x00, 0xf5, 0x33, 0x96, 0xb6, 0x41, 0x4d, 0x98, 0x57, 0xb5, 0xbe, 0x5e, 0xa1,
-	0x3e, 0x15, 0x25, 0xed, 0x0f, 0xd4, 0x27, 0x7d, 0x04, 0xab, 0xcf, 0xf5, 0xe5, 0x69, 0x77, 0x7d,
-	0x02, 0x4b, 0xfa, 0x3a, 0xc5, 0x0a, 0xbe, 0x7e, 0xda, 0x30, 0x07, 0xc6, 0x65, 0xae, 0x99, 0xa3,
-	0x0f, 0x60, 0x11, 0x09, 0x1f, 0xd1, 0xee, 0xdf, 0x85, 0xc6, 0xf9, 0x28, 0x8e, 0xfa, 0x99, 0x52,
-	0x2c, 0xf0, 0x85, 0xe4, 0xa1, 0xad, 0x24, 0xf5, 0x88, 0xde, 0x83, 0x15, 0xb3, 0x6e, 0x46, 0x2e,
-	0x7f, 0x05, 0xb7, 0xce, 0xb8, 0x7c, 0x86, 0xaf, 0x17, 0xc9, 0xe2, 0x63, 0x58, 0xd2, 0xef, 0x19,
-	0xc6, 0x5f, 0xcd, 0x13, 0xfd, 0xd0, 0xa1, 0x2f, 0x7d, 0xb5, 0xd2, 0xcc, 0x9f, 0xfe, 0xa3, 0x0e,
-	0xf0, 0x64, 0xe4, 0x5f, 0xf0, 0xf8, 0x4a, 0xdd, 0x21, 0x6f, 0xa0, 0x9e, 0xe9, 0x90, 0xc9, 0xb6,
-	0x39, 0x76, 0xf1, 0x85, 0xa2, 0x65, 0xaf, 0xe3, 0x92, 0x76, 0x9a, 0xee, 0x7c, 0xf8, 0xdb, 0x3f,
-	0xff, 0x30, 0xb7, 0x4e, 0x6e, 0xb5, 0xaf, 0x1e, 0xb4, 0xc7, 0x82, 0xc7, 0xed, 0x90, 0x77, 0xb1,
-	0xae, 0x21, 0xbf, 0x84, 0xed, 0x97, 0x4c, 0x72, 0x21, 0x5f, 0xc4, 0x31, 0xc7, 0xe6, 0xb5, 0x1b,
-	0x70, 0xac, 0xe6, 0xa6, 0x8b, 0xda, 0x30, 0x13, 0xb9, 0xa2, 0x8f, 0x6e, 0xa0, 0x90, 0x55, 0xd2,
-	0x48, 0x84, 0xa8, 0x46, 0x3c, 0x86, 0xb5, 0x42, 0x27, 0x4a, 0xf6, 0x53, 0x4d, 0x4b, 0xba, 0xdd,
-	0xd6, 0xc1, 0xb4, 0x69, 0x23, 0xe7, 0x10, 0xe5, 0xb4, 0xe8, 0x66, 0x22, 0x87, 0x99, 0x46, 0x5b,
-	0x2d, 0x7b, 0x5c, 0xb9, 0x4f, 0xce, 0x61, 0x41, 0xb5, 0xa7, 0x64, 0x7a, 0x4e, 0xb4, 0xd6, 0x6d,
-	0x13, 0x95, 0x69, 0x63, 0xa9, 0x83, 0x9c, 0x09, 0x5d, 0x49, 0x38, 0x7b, 0x2c, 0x08, 0x14, 0xc7,
-	0xf7, 0x40, 0x26, 0x3b, 0x15, 0x72, 0x68, 0x98, 0x4c, 0x6d, 0x62, 0x92, 0xb3, 0x4c, 0xe9, 0x5a,
-	0x28, 0x45, 0x89, 0x7b, 0x74, 0x3b, 0x91, 0x18, 0xb3, 0xb7, 0x99, 0x74, 0x55, 0xb2, 0x87, 0xb0,
-	0x9a, 0x6f, 0x4b, 0xc8, 0x5e, 0x6a, 0xa1, 0xc9, 0x6e, 0x65, 0x8a, 0x77, 0x26, 0x25, 0x0d, 0x72,
-	0xbb, 0x95, 0xa4, 0x10, 0x9a, 0xc5, 0xfe, 0x84, 0x1c, 0x4c, 0xca, 0xca, 0x36, 0x2e, 0x53, 0xa4,
-	0x7d, 0x82, 0xd2, 0x0e, 0xe8, 0x4e, 0x99, 0x34, 0xdc, 0xaf, 0xe4, 0x7d, 0xa8, 0x60, 0xc7, 0x95,
-	0x33, 0x8c, 0xc7, 0xfd, 0x91, 0x24, 0x34, 0x95, 0x3a, 0xad, 0x8f, 0x69, 0xdd, 0x50, 0x7f, 0xd2,
-	0x4f, 0x51, 0xfe, 0x1d, 0x7a, 0x90, 0x95, 0x3f, 0x29, 0x47, 0x29, 0xf1, 0xbb, 0x0a, 0x38, 0xd3,
-	0x7a, 0x1f, 0x72, 0x77, 0x8a, 0x1e, 0x85, 0xe6, 0xe8, 0x46, 0x5d, 0x3e, 0x43, 0x5d, 0xee, 0xd2,
-	0xa3, 0x29, 0xba, 0xa4, 0xdc, 0x94, 0x3a, 0x1d, 0xa8, 0x25, 0x6f, 0x97, 0x49, 0x06, 0x16, 0x5f,
-	0x3e, 0x5b, 0xce, 0xe4, 0x84, 0x91, 0xb6, 0x8f, 0xd2, 0xb6, 0x29, 0x49, 0xa4, 0x09, 0xbb, 0xe6,
-	0x71, 0xe5, 0xfe, 0x17, 0x15, 0x83, 0x27, 0x16, 0xe3, 0xa7, 0x27, 0xb9, 0x9d, 0x28, 0xde, 0x06,
-	0x74, 0x0f, 0x25, 0x6c, 0x91, 0x8d, 0xec, 0x79, 0x12, 0x7e, 0x6f, 0xa0, 0xfe, 0x3c, 0x7d, 0xbd,
-	0xb9, 0x29, 0x05, 0x49, 0x2a, 0x20, 0xe1, 0x7d, 0x1b, 0x79, 0xef, 0xd0, 0x94, 0x77, 0xe6, 0x29,
-	0x48, 0x99, 0x87, 0x21, 0x9c, 0xe8, 0xab, 0xc1, 0x64, 0x83, 0xe5, 0x93, 0x8d, 0x8d, 0xcd, 0xec,
-	0xe5, 0x90, 0xb2, 0xbf, 0x83, 0xec, 0xf7, 0xa9, 0x93, 0x55, 0x3d, 0xcb, 0x4c, 0x8b, 0x80, 0xf4,
-	0x01, 0x89, 0xec, 0xda, 0xf8, 0x2e, 0x79, 0x83, 0x6a, 0xed, 0xa4, 0xe1, 0x51, 0x78, 0x70, 0xa2,
-	0xbb, 0x28, 0x6a, 0x93, 0x36, 0x13, 0x51, 0x3d, 0xbd, 0xe2, 0x71, 0xe5, 0xfe, 0xe9, 0x5f, 0x00,
-	0x1a, 0x4f, 0x7a, 0x97, 0x7e, 0x68, 0x41, 0xfe, 0x3b, 0xa8, 0xda, 0xd7, 0xc2, 0xd9, 0x1e, 0x29,
-	0xbe, 0x2b, 0xd2, 0x16, 0xca, 0xda, 0x20, 0xe8, 0x73, 0xa6, 0xf8, 0x26, 0x90, 0x48, 0x3c, 0x80,
-	0xb4, 0x0c, 0x27, 0x36, 0x6e, 0x26, 0xca, 0xf9, 0xe4, 0x28, 0x93, 0x35, 0x7b, 0x1e, 0x70, 0x73,
-	0xec, 0xdb, 0x21, 0x7f, 0xab, 0x4c, 0x16, 0xc1, 0x4a, 0xae, 0x9a, 0x4e, 0xac, 0x56, 0x56, 0xd1,
-	0xb7, 0xf6, 0xca, 0x27, 0xcb, 0x7c, 0x94, 0x97, 0x36, 0xc6, 0x0d, 0x4a, 0xe0, 0x00, 0xea, 0x99,
-	0xea, 0x3a, 0x89, 0xb2, 0xc9, 0x0a, 0x3d, 0xc9, 0xcc, 0x92, 0x62, 0x9c, 0x1e, 0xa1, 0xa8, 0x5d,
-	0xba, 0x35, 0x29, 0xca, 0x0a, 0x0a, 0x61, 0xad, 0x80, 0xdd, 0x37, 0x85, 0xf4, 0x2c, 0xb8, 0x2f,
-	0xb1, 0x64, 0x01, 0xec, 0x7f, 0x0e, 0x55, 0x5b, 0xb4, 0x13, 0xfb, 0xd0, 0x57, 0x68, 0x0c, 0x92,
-	0x38, 0x28, 0x56, 0xf7, 0xf4, 0x00, 0xd9, 0x3b, 0x74, 0x3d, 0x65, 0x2f, 0xfc, 0x41, 0xd8, 0x1e,
-	0x9a, 0xc8, 0xfe, 0x50, 0x01, 0x32, 0x59, 0x6d, 0x27, 0xd7, 0xd8, 0xd4, 0x2e, 0xa0, 0x75, 0x74,
-	0xc3, 0x0a, 0x23, 0xfb, 0x1e, 0xca, 0x3e, 0xa2, 0x7b, 0xa9, 0xec, 0xc1, 0xc4, 0x6a, 0xa5, 0xc4,
-	0xef, 0x2b, 0xb0, 0x5f, 0xa8, 0x8d, 0x7f, 0xe6, 0xcb, 0x61, 0x5a, 0xe6, 0x92, 0x7b, 0x99, 0xf3,
-	0xdd, 0x54, 0x08, 0xb7, 0x8e, 0x67, 0x2f, 0xcc, 0x17, 0x40, 0x74, 0x35, 0x6f, 0x19, 0xa5, 0xcf,
-	0x1f, 0x95, 0x3e, 0x79, 0x7f, 0x4d, 0xd3, 0x67, 0x46, 0x61, 0x3e, 0xd3, 0xfd, 0x27, 0xa8, 0xc5,
-	0x31, 0xbd, 0x53, 0xea, 0xfe, 0xbc, 0x54, 0xa5, 0xda, 0x05, 0xc0, 0x85, 0x64, 0xb1, 0xc4, 0xb2,
-	0x93, 0xd8, 0x92, 0x25, 0x5b, 0xac, 0x26, 0xd7, 0x6f, 0xae, 0x32, 0xb5, 0x80, 0x40, 0xd7, 0x52,
-	0x41, 0x23, 0xb5, 0x40, 0x47, 0x58, 0x2d, 0xa9, 0x4e, 0xa7, 0x63, 0x8d, 0x93, 0x22, 0x5b, 0xbe,
-	0x90, 0xb5, 0xc0, 0x46, 0xd6, 0xb3, 0x8e, 0xb6, 0xfc, 0xbe, 0x83, 0xaa, 0xfd, 0x4b, 0x36, 0x1b,
-	0xc7, 0x8a, 0xff, 0xd3, 0xca, 0x70, 0x2c, 0x8c, 0x7a, 0xdc, 0x0f, 0xfb, 0x51, 0x77, 0x09, 0x7f,
-	0xcf, 0x3c, 0xfc, 0x77, 0x00, 0x00, 0x00, 0xff, 0xff, 0xee, 0x93, 0x9a, 0x0e, 0xdf, 0x1c, 0x00,
+	// 3681 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x5a, 0xdf, 0x6e, 0xdc, 0xc6,
+	0xd5, 0xc7, 0xae, 0x56, 0x7f, 0xf6, 0xec, 0x4a, 0x5a, 0x8d, 0xfe, 0xad, 0x68, 0x49, 0x96, 0x47,
+	0x89, 0xad, 0x38, 0x89, 0x14, 0x2b, 0x41, 0xbe, 0xc0, 0x41, 0x3e, 0x40, 0x76, 0x6c, 0xd9, 0x81,
+	0x3f, 0x7f, 0x2a, 0xe5, 0x34, 0x01, 0x5a, 0x67, 0xcb, 0x25, 0x67, 0x57, 0x4c, 0x28, 0x72, 0x43,
+	0x72, 0x65, 0xc9, 0xbd, 0x68, 0x11, 0xa0, 0xed, 0x4d, 0x7b, 0x95, 0x9b, 0x16, 0x28, 0xfa, 0x02,
+	0x45, 0xfb, 0x12, 0x7d, 0x84, 0xa2, 0xe8, 0x0b, 0xf4, 0xaa, 0x4f, 0x51, 0xcc, 0x99, 0x19, 0x72,
+	0xc8, 0x25, 0x77, 0xed, 0x5c, 0xf4, 0x8e, 0x73, 0xe6, 0xcc, 0x39, 0x33, 0x67, 0xce, 0xfc, 0xce,
+	0x99, 0x33, 0x84, 0x7a, 0x38, 0xb0, 0xf7, 0x07, 0x61, 0x10, 0x07, 0x64, 0x3a, 0x1c, 0xd8, 0x83,
+	0xae, 0xb1, 0xd9, 0x0f, 0x82, 0xbe, 0xc7, 0x0e, 0xac, 0x81, 0x7b, 0x60, 0xf9, 0x7e, 0x10, 0x5b,
+	0xb1, 0x1b, 0xf8, 0x91, 0x60, 0x32, 0x3e, 0xea, 0xbb, 0xf1, 0xd9, 0xb0, 0xbb, 0x6f, 0x07, 0xe7,
+	0x07, 0x3e, 0xeb, 0x0e, 0x3d, 0x2b, 0x72, 0x83, 0x83, 0x7e, 0xf0, 0xae, 0x6c, 0x1c, 0xd8, 0x81,
+	0x1f, 0x31, 0x3f, 0x1a, 0x46, 0x07, 0x83, 0xee, 0x41, 0x14, 0x5b, 0x31, 0x93, 0x23, 0xdf, 0x9f,
+	0x3c, 0x32, 0x64, 0x7c, 0x50, 0xd7, 0x0b, 0xec, 0x6f, 0xe4, 0xa0, 0x0f, 0x27, 0x0d, 0xf2, 0x59,
+	0xd7, 0x63, 0x31, 0x1f, 0x66, 0x07, 0x7e, 0xcf, 0xed, 0x8b, 0x71, 0xf4, 0x36, 0xb4, 0x4e, 0x87,
+	0xdd, 0xc8, 0x0e, 0xdd, 0x2e, 0x33, 0xd9, 0xb7, 0x43, 0x16, 0xc5, 0x64, 0x0d, 0x66, 0xe2, 0x60,
+	0xe0, 0xda, 0x51, 0xbb, 0xb2, 0x33, 0xb5, 0x57, 0x37, 0x65, 0x8b, 0x7e, 0x02, 0x4b, 0x1a, 0x6f,
+	0x34, 0xe0, 0x0b, 0x20, 0x2b, 0x30, 0x8d, 0xdd, 0xed, 0xca, 0x4e, 0x65, 0xaf, 0x6e, 0x8a, 0x06,
+	0x21, 0x50, 0x73, 0xac, 0xd8, 0x6a, 0x57, 0x91, 0x88, 0xdf, 0x94, 0x40, 0xeb, 0x69, 0xe0, 0x9f,
+	0x58, 0xa1, 0x75, 0x1e, 0x49, 0x55, 0xf4, 0x8f, 0x55, 0x4e, 0x74, 0xd8, 0x63, 0xbf, 0x17, 0x24,
+	0x22, 0x17, 0xa0, 0xea, 0x3a, 0x52, 0x5e, 0xd5, 0x75, 0xc8, 0x06, 0xcc, 0xd9, 0x67, 0x96, 0xeb,
+	0x77, 0x5c, 0x07, 0x05, 0xce, 0x9b, 0xb3, 0xd8, 0x7e, 0xec, 0x10, 0x03, 0xe6, 0xec, 0xc0, 0xf5,
+	0xbb, 0x56, 0xc4, 0xda, 0x53, 0x38, 0x20, 0x69, 0x93, 0x2d, 0x80, 0x01, 0x63, 0x61, 0xc7, 0x0e,
+	0x86, 0x7e, 0xdc, 0xae, 0xe1, 0xc0, 0x3a, 0xa7, 0xdc, 0xe7, 0x04, 0x42, 0xa1, 0x19, 0x5d, 0xf9,
+	0xf6, 0x59, 0x18, 0xf8, 0xee, 0x4b, 0xe6, 0xb4, 0xa7, 0x77, 0x2a, 0x7b, 0x73, 0x66, 0x86, 0x46,
+	0xae, 0x43, 0xa3, 0x3b, 0xb4, 0xbf, 0x61, 0x71, 0x27, 0x72, 0x5f, 0xb2, 0xf6, 0xcc, 0x4e, 0x65,
+	0x6f, 0xda, 0x04, 0x41, 0x3a, 0x75, 0x5f, 0x32, 0xf2, 0x16, 0xb4, 0xd0, 0x8e, 0x76, 0xe0, 0x75,
+	0x2e, 0x58, 0x18, 0xb9, 0x81, 0xdf, 0x06, 0x9c, 0xc7, 0xa2, 0xa2, 0xff, 0x58, 0x90, 0xc9, 0x21,
+	0x34, 0xc2, 0x60, 0x18, 0xb3, 0x4e, 0x6c, 0x75, 0x3d, 0xd6, 0x6e, 0xec, 0x4c, 0xed, 0x35, 0x0e,
+	0x97, 0xf6, 0xd1, 0x97, 0xf6, 0x4d, 0xde, 0xf3, 0x8c, 0x77, 0x98, 0x10, 0x26, 0xdf, 0xf4, 0x43,
+	0x80, 0xb4, 0x67, 0xc4, 0x2e, 0x6d, 0x98, 0xb5, 0x1c, 0x27, 0x64, 0x51, 0xd4, 0xae, 0xe2, 0x46,
+	0xa9, 0x26, 0xfd, 0x67, 0x05, 0x96, 0x8f, 0x59, 0xfc, 0x94, 0x75, 0x4f, 0xb9, 0x63, 0x25, 0x96,
+	0xd5, 0x2d, 0x59, 0xc9, 0x5a, 0x92, 0x40, 0x2d, 0xb6, 0x5c, 0x4f, 0xed, 0x18, 0xff, 0x26, 0x2d,
+	0x98, 0xf2, 0xdc, 0xae, 0x34, 0x2c, 0xff, 0xe4, 0xae, 0x71, 0xc6, 0xdc, 0xfe, 0x99, 0xb0, 0x67,
+	0xcd, 0x94, 0xad, 0x42, 0x3b, 0xcc, 0x14, 0xdb, 0x21, 0x6f, 0xf7, 0xd9, 0x02, 0xbb, 0xb7, 0x61,
+	0x56, 0x49, 0x99, 0x43, 0x29, 0xaa, 0x49, 0xdf, 0x83, 0xd6, 0x91, 0x8d, 0x3b, 0x1a, 0x25, 0xab,
+	0xda, 0x84, 0xba, 0x5c, 0x38, 0x53, 0x2e, 0x9b, 0x12, 0xe8, 0x67, 0xb0, 0x76, 0xcc, 0x62, 0x39,
+	0x48, 0x9a, 0x43, 0xf8, 0xb9, 0x66, 0x3f, 0x61, 0x54, 0xd5, 0xd4, 0x96, 0x59, 0xd5, 0x97, 0x49,
+	0x9f, 0xc3, 0xfa, 0x88, 0x2c, 0x39, 0x89, 0x36, 0xcc, 0x76, 0x2d, 0xcf, 0xf2, 0x6d, 0xa6, 0x84,
+	0xc9, 0x26, 0x3f, 0x21, 0x7e, 0xc0, 0xe9, 0x42, 0x96, 0x68, 0xa0, 0xbd, 0xaf, 0x06, 0xc2, 0x6b,
+	0xe7, 0x4d, 0xfc, 0xa6, 0x5f, 0x43, 0xf3, 0xbe, 0xe5, 0x79, 0x89, 0xcc, 0x35, 0x98, 0x09, 0x59,
+	0x34, 0xf4, 0x62, 0x29, 0x52, 0xb6, 0xb8, 0x5b, 0xb2, 0x4b, 0x66, 0x73, 0x67, 0x62, 0x61, 0x28,
+	0xb7, 0x0c, 0x24, 0xe9, 0x41, 0x18, 0x92, 0x1b, 0xd0, 0x64, 0x51, 0xec, 0x9e, 0x5b, 0x31, 0xeb,
+	0xf4, 0xad, 0x48, 0xee, 0x60, 0x43, 0xd1, 0x8e, 0xad, 0x88, 0xee, 0xc3, 0xca, 0xbd, 0xab, 0x7b,
+	0x1c, 0x41, 0x1e, 0xe1, 0xda, 0xb4, 0xc3, 0x2f, 0x97, 0x5e, 0xc9, 0x2c, 0xfd, 0x1d, 0x20, 0xc7,
+	0x2c, 0xfe, 0xf4, 0xca, 0xb7, 0xa2, 0xf8, 0x4a, 0x9f, 0xe1, 0xb9, 0xeb, 0xb3, 0x30, 0x81, 0x0a,
+	0xd1, 0xa2, 0x7f, 0xae, 0x02, 0x79, 0x16, 0x5a, 0x7e, 0x64, 0xd9, 0x1c, 0x14, 0x95, 0x70, 0x02,
+	0xb5, 0x5e, 0x18, 0x9c, 0xcb, 0xe5, 0xe0, 0x37, 0xf7, 0xea, 0x38, 0x90, 0x6b, 0xa8, 0xc6, 0x01,
+	0x37, 0xd7, 0x85, 0xe5, 0x0d, 0xd5, 0x79, 0x16, 0x8d, 0xd4, 0x88, 0x35, 0xdd, 0x88, 0xd7, 0xa0,
+	0xde, 0xb7, 0xa2, 0xce, 0x20, 0x74, 0x6d, 0x86, 0x07, 0xb8, 0x6e, 0xce, 0xf5, 0xad, 0xe8, 0x24,
+	0x74, 0xd3, 0x4e, 0xcf, 0x3d, 0x77, 0xe3, 0xf6, 0x4c, 0xd2, 0xf9, 0x84, 0xb7, 0xc9, 0x21, 0x07,
+	0x0e, 0x3f, 0x0e, 0x2d, 0x3b, 0x46, 0x0f, 0x6c, 0x1c, 0xae, 0xc9, 0xa3, 0x78, 0x5f, 0x92, 0xe5,
+	0x9c, 0xcd, 0x84, 0x8f, 0x2f, 0xb6, 0xeb, 0xfa, 0x56, 0x78, 0x85, 0x47, 0xbc, 0x69, 0xca, 0x56,
+	0xb2, 0x95, 0x2b, 0xf2, 0xe8, 0x5c, 0x0d, 0x18, 0x79, 0x1b, 0x96, 0x98, 0x6f, 0x87, 0x57, 0x83,
+	0x98, 0x39, 0x9d, 0x81, 0x75, 0xe5, 0x05, 0x96, 0xd3, 0x5e, 0xc5, 0x61, 0xad, 0xa4, 0xe3, 0x44,
+	0xd0, 0xe9, 0x4b, 0x58, 0xcc, 0x69, 0xe5, 0xba, 0xa2, 0x60, 0x18, 0x26, 0xde, 0x24, 0x5b, 0x7c,
+	0xeb, 0xc5, 0x57, 0x07, 0x55, 0xca, 0xad, 0x17, 0xa4, 0x67, 0x5c, 0xb1, 0x01, 0x73, 0xbd, 0xa1,
+	0x8f, 0x56, 0x57, 0x88, 0xa8, 0xda, 0x7c, 0xa2, 0x56, 0xd8, 0x8f, 0xd0, 0x86, 0x75, 0x13, 0xbf,
+	0xe9, 0x01, 0x6c, 0x9c, 0x32, 0xdf, 0x31, 0xad, 0x17, 0xc5, 0xfb, 0x85, 0x30, 0x5e, 0xc1, 0x89,
+	0xe3, 0x37, 0xfd, 0x29, 0xac, 0xf3, 0x01, 0x19, 0xee, 0xd4, 0x1b, 0xe2, 0xcb, 0x33, 0x2b, 0x3a,
+	0x53, 0x93, 0x16, 0x2d, 0x8e, 0x0e, 0xca, 0x88, 0x9d, 0x14, 0xb1, 0x10, 0x1d, 0x14, 0xfd, 0x48,
+	0x90, 0x69, 0x07, 0x56, 0x8f, 0x59, 0x8c, 0x7e, 0x79, 0xef, 0xea, 0x91, 0x15, 0x9d, 0x69, 0x53,
+	0xd1, 0x24, 0xe3, 0x37, 0x39, 0x84, 0xd5, 0xde, 0xd0, 0xf3, 0x3a, 0x3d, 0xd7, 0xf3, 0x3a, 0x71,
+	0x3a, 0x21, 0x14, 0x3e, 0x67, 0x2e, 0xf3, 0xce, 0x87, 0xae, 0xe7, 0x69, 0x73, 0xa5, 0x0c, 0xd6,
+	0x35, 0x05, 0xaf, 0xe2, 0xfa, 0x3f, 0x48, 0xcd, 0x1d, 0xb8, 0x76, 0xcc, 0x62, 0x8d, 0x32, 0x71,
+	0x35, 0xf4, 0x63, 0xb8, 0x9e, 0x1f, 0x92, 0xf7, 0x8a, 0x52, 0xc4, 0xa2, 0x7f, 0xaa, 0xc1, 0x3c,
+	0x2e, 0x2a, 0xd9, 0x8c, 0x22, 0x83, 0x5d, 0x87, 0xc6, 0xc0, 0x0a, 0x99, 0x1f, 0x77, 0xb0, 0x4b,
+	0x7a, 0x8f, 0x20, 0xf1, 0xe9, 0x69, 0x26, 0x98, 0xca, 0x98, 0xa0, 0xf8, 0xf8, 0xe9, 0xd1, 0x77,
+	0x3a, 0x17, 0x7d, 0x37, 0xa1, 0x1e, 0xbb, 0xe7, 0x2c, 0x8a, 0xad, 0xf3, 0x01, 0x9e, 0xbe, 0x29,
+	0x33, 0x25, 0x64, 0x02, 0xd1, 0x6c, 0x36, 0x10, 0x6d, 0x01, 0x60, 0x36, 0xd4, 0x09, 0x83, 0x20,
+	0x96, 0xf0, 0x5f, 0x47, 0x8a, 0x19, 0x04, 0x31, 0x1f, 0x19, 0x5f, 0x46, 0xa2, 0xb3, 0x2e, 0x6c,
+	0x10, 0x5f, 0x46, 0xd8, 0xc5, 0x61, 0xf1, 0x82, 0xf9, 0xb1, 0xec, 0x05, 0x09, 0x8b, 0x48, 0x42,
+	0x86, 0x23, 0x58, 0x48, 0xb2, 0x2e, 0xc1, 0xd3, 0xc0, 0xa3, 0x6f, 0xec, 0x27, 0x64, 0x01, 0x00,
+	0xe2, 0x9b, 0x8f, 0x31, 0xe7, 0x6d, 0xbd, 0xc9, 0x0d, 0x81, 0x10, 0xd7, 0x6e, 0x0a, 0x74, 0xc2,
+	0x06, 0xd9, 0x06, 0x08, 0x2d, 0xdf, 0x09, 0xce, 0x4f, 0x19, 0x73, 0xda, 0xf3, 0x42, 0x71, 0x4a,
+	0x21, 0x3b, 0xd0, 0x10, 0xad, 0x93, 0x30, 0x08, 0x7a, 0xed, 0x05, 0x01, 0xc7, 0x1a, 0x89, 0xcf,
+	0xdd, 0x8d, 0x3a, 0x3d, 0xd7, 0xb7, 0x3c, 0x37, 0xbe, 0x6a, 0x2f, 0xa2, 0x67, 0x81, 0x1b, 0x3d,
+	0x94, 0x14, 0xf2, 0xbf, 0xd0, 0xd4, 0x5c, 0x2f, 0x6a, 0x3b, 0x98, 0x3f, 0x18, 0x12, 0xb4, 0x0a,
+	0x4e, 0xa3, 0x99, 0xe1, 0xa7, 0xff, 0x9e, 0x82, 0xe5, 0xa2, 0x33, 0x5b, 0xe4, 0x26, 0x6d, 0x50,
+	0xbb, 0x91, 0xcf, 0xb7, 0x14, 0x80, 0x4f, 0x8d, 0x00, 0x78, 0x6d, 0x14, 0xc0, 0xa7, 0x0b, 0x01,
+	0x7c, 0x46, 0xf7, 0xa0, 0x8c, 0x97, 0xcc, 0xe6, 0xbd, 0x44, 0x01, 0xeb, 0x9c, 0x06, 0xac, 0x0a,
+	0x92, 0xea, 0x29, 0x24, 0x65, 0xc3, 0x00, 0x8c, 0x0b, 0x03, 0x8d, 0x5c, 0x18, 0x28, 0x42, 0xa6,
+	0x66, 0x21, 0x32, 0x21, 0x22, 0xc7, 0x56, 0x3c, 0x8c, 0x70, 0x7f, 0xa7, 0x4d, 0xd9, 0xe2, 0x0e,
+	0xc9, 0xe5, 0x0f, 0x23, 0xe6, 0xc8, 0x8d, 0x9d, 0xed, 0x5b, 0xd1, 0xe7, 0x11, 0x73, 0xc8, 0x2e,
+	0xcc, 0x6b, 0x71, 0x3a, 0x08, 0x71, 0x5b, 0xeb, 0x66, 0x33, 0x8d, 0xd4, 0x41, 0x48, 0xde, 0x84,
+	0x05, 0xc5, 0x24, 0x83, 0x7d, 0x0b, 0xb9, 0xd4, 0x50, 0x13, 0x89, 0xc5, 0x01, 0x65, 0xa9, 0x24,
+	0xa0, 0xbc, 0x0f, 0x4b, 0x4f, 0xd9, 0x0b, 0x99, 0xa7, 0x28, 0xf0, 0xd8, 0x06, 0x18, 0x58, 0x51,
+	0x34, 0x38, 0x0b, 0xf9, 0x79, 0xad, 0xa8, 0xb3, 0xaf, 0x28, 0x74, 0x1f, 0x88, 0x3e, 0x28, 0xcd,
+	0x6b, 0x4a, 0x20, 0xe7, 0x97, 0x15, 0x58, 0xf9, 0xdc, 0xe7, 0x98, 0x93, 0x53, 0x54, 0x3a, 0x24,
+	0x37, 0x85, 0x6a, 0x7e, 0x0a, 0x1c, 0x50, 0x9c, 0x61, 0x68, 0x25, 0xc1, 0xab, 0x66, 0x26, 0x6d,
+	0xbe, 0xf1, 0x43, 0x9e, 0xe0, 0x89, 0x44, 0x1e, 0xbf, 0xe9, 0x01, 0xac, 0xe6, 0x66, 0x50, 0x98,
+	0x39, 0xcd, 0xa9, 0xcc, 0x89, 0xaf, 0xf1, 0xc9, 0x6b, 0x4c, 0x98, 0xbe, 0x0b, 0xcb, 0x4f, 0x5e,
+	0x43, 0xfc, 0x8f, 0x60, 0xf1, 0xd4, 0xed, 0xfb, 0x3a, 0xd2, 0x97, 0x1b, 0x43, 0x9d, 0xbc, 0xaa,
+	0xf0, 0x64, 0xfe, 0xcd, 0x33, 0x6e, 0xcb, 0xeb, 0xcb, 0xa4, 0x90, 0x7f, 0xd2, 0x9b, 0xd0, 0x4a,
+	0x45, 0xa6, 0x67, 0x76, 0x24, 0x2c, 0xff, 0x1c, 0x36, 0x8e, 0x99, 0xcf, 0x42, 0x8e, 0x93, 0x09,
+	0xf0, 0x4c, 0x9e, 0x44, 0x1a, 0x11, 0x22, 0x0e, 0x5d, 0x62, 0x2e, 0x32, 0x22, 0x20, 0x74, 0xed,
+	0xc2, 0xbc, 0xe5, 0xdb, 0x2c, 0x8a, 0x83, 0x50, 0x04, 0x8d, 0x29, 0x64, 0x69, 0x2a, 0x22, 0x9f,
+	0x18, 0x7d, 0x06, 0x46, 0x91, 0xf2, 0xf4, 0xd6, 0x71, 0x11, 0xf6, 0x84, 0x02, 0x31, 0xe5, 0xd9,
+	0x8b, 0xb0, 0x87, 0xd2, 0xaf, 0x41, 0x9d, 0x77, 0x0d, 0x10, 0x16, 0x85, 0x72, 0xce, 0x8b, 0x98,
+	0x48, 0x7f, 0x01, 0x3b, 0x7c, 0xe9, 0x1a, 0x6a, 0x9d, 0x24, 0xae, 0xa2, 0x56, 0xf6, 0x31, 0x34,
+	0xf4, 0x88, 0x5c, 0x41, 0x3c, 0xdf, 0x28, 0x42, 0x45, 0xe4, 0x37, 0x75, 0xee, 0x49, 0xee, 0x48,
+	0xff, 0x07, 0x6e, 0x8c, 0x99, 0xc0, 0x98, 0xcd, 0xe0, 0x33, 0xcf, 0xe6, 0x48, 0xff, 0xe5, 0x99,
+	0xbf, 0x84, 0xd6, 0xb1, 0x04, 0xc0, 0x64, 0xa2, 0x19, 0x94, 0xac, 0xe4, 0x50, 0x72, 0x03, 0xe6,
+	0x22, 0xab, 0xc7, 0x3a, 0x5e, 0xf0, 0x42, 0x8a, 0x9b, 0xe5, 0xed, 0x27, 0xc1, 0x0b, 0x7e, 0x28,
+	0xa3, 0xd8, 0xf2, 0x1d, 0x2b, 0x74, 0x54, 0x46, 0xa9, 0xda, 0x18, 0x0f, 0xac, 0x28, 0x56, 0x19,
+	0x25, 0xff, 0xa6, 0x37, 0xa0, 0x31, 0x29, 0xd5, 0x79, 0x0c, 0x8d, 0x63, 0x2b, 0xbd, 0xc0, 0xb5,
+	0x60, 0x8a, 0xdf, 0x52, 0x04, 0x07, 0xff, 0xe4, 0x94, 0xf4, 0x66, 0xc3, 0x3f, 0xb5, 0x23, 0x37,
+	0xa5, 0xdf, 0x85, 0xe8, 0x87, 0xb0, 0xf0, 0x40, 0x44, 0x78, 0x25, 0xed, 0x0d, 0x98, 0x11, 0x31,
+	0x1f, 0xef, 0x24, 0x8d, 0xc3, 0xa6, 0xb4, 0x29, 0xb2, 0x99, 0xb2, 0x8f, 0xde, 0x81, 0x69, 0x24,
+	0xbc, 0x46, 0x01, 0xe3, 0x1b, 0x58, 0x55, 0x09, 0x99, 0x52, 0x39, 0xe9, 0x78, 0x25, 0xc2, 0xab,
+	0x39, 0xe1, 0x49, 0x14, 0xad, 0x8d, 0x44, 0xd1, 0x1a, 0x8f, 0xa2, 0xf4, 0x21, 0xac, 0xe5, 0x95,
+	0xc9, 0xf5, 0xbd, 0x93, 0x5b, 0xdf, 0x4a, 0xee, 0xe2, 0x92, 0x5d, 0xe7, 0x5f, 0x2b, 0x30, 0x9f,
+	0xe9, 0xe1, 0x97, 0x43, 0xac, 0x1c, 0x75, 0x32, 0xc9, 0x6e, 0xa3, 0x9b, 0xde, 0x05, 0x79, 0x0e,
+	0x26, 0x59, 0xd2, 0x34, 0xb1, 0x2e, 0x18, 0x38, 0x4a, 0xad, 0xc3, 0x6c, 0x7c, 0x99, 0xa2, 0x01,
+	0x26, 0xfa, 0x8f, 0x64, 0xe2, 0xa0, 0x0c, 0x51, 0x2b, 0x31, 0xc4, 0x74, 0x91, 0x95, 0x67, 0x34,
+	0x2b, 0x7f, 0x06, 0xcb, 0x6a, 0xbe, 0x47, 0xf7, 0x1e, 0xeb, 0x3e, 0x62, 0x75, 0x5d, 0xe5, 0x23,
+	0x56, 0xd7, 0xe5, 0xeb, 0xb0, 0x03, 0x87, 0x25, 0xf5, 0x06, 0x71, 0xbd, 0x6e, 0x70, 0x9a, 0xac,
+	0x35, 0xd0, 0x9b, 0xd0, 0x3c, 0x19, 0x84, 0x41, 0x4f, 0xcb, 0xf0, 0x3d, 0x37, 0x8a, 0x99, 0xaf,
+	0x2e, 0x28, 0xa2, 0x45, 0x6f, 0xc1, 0xbc, 0xe4, 0x9b, 0x00, 0xf0, 0x9f, 0xc0, 0xd2, 0x31, 0x8b,
+	0xef, 0x63, 0x05, 0x2d, 0x61, 0xde, 0x83, 0x19, 0x51, 0x53, 0x93, 0x87, 0xb8, 0xb5, 0x2f, 0x8a,
+	0x6d, 0x62, 0x4f, 0x38, 0xa7, 0xec, 0xa7, 0x6f, 0xc2, 0xfc, 0xb3, 0xd0, 0xb2, 0xb3, 0xd5, 0x33,
+	0x4e, 0x48, 0x9c, 0x8f, 0x37, 0xe8, 0x17, 0xb0, 0x28, 0xc2, 0x1a, 0x73, 0x64, 0xe4, 0x19, 0x5f,
+	0xab, 0x60, 0x97, 0x03, 0x37, 0xbc, 0x42, 0x03, 0x4c, 0x99, 0xb2, 0x95, 0xc4, 0xcb, 0x29, 0x2d,
+	0x5e, 0x3e, 0x85, 0x76, 0x4e, 0x70, 0xea, 0x56, 0x87, 0x30, 0x67, 0x49, 0x9a, 0x74, 0x2c, 0x75,
+	0x23, 0xce, 0x0d, 0x31, 0x13, 0x3e, 0xfa, 0x11, 0x90, 0xa3, 0xa1, 0xe3, 0xc6, 0x4f, 0x79, 0x32,
+	0x17, 0x15, 0xdd, 0xf2, 0x6b, 0x23, 0xb7, 0x7c, 0xe1, 0xde, 0x7f, 0xab, 0x00, 0xe0, 0x28, 0x93,
+	0x0d, 0x23, 0x46, 0x9a, 0x50, 0x09, 0xe5, 0xc2, 0x2a, 0x58, 0xbe, 0xe8, 0xb9, 0x61, 0xc4, 0xab,
+	0x6e, 0x7d, 0x9e, 0x6b, 0x0b, 0x07, 0x6c, 0x20, 0xed, 0x14, 0x49, 0xdc, 0x43, 0x05, 0x8b, 0xe6,
+	0x85, 0x75, 0xa4, 0xa0, 0x23, 0xee, 0xc2, 0x7c, 0xc4, 0xec, 0xc0, 0x77, 0x94, 0x08, 0xe1, 0x8e,
+	0x4d, 0x41, 0x94, 0x32, 0xf8, 0x5d, 0x5a, 0x30, 0xa1, 0x90, 0x69, 0x79, 0x97, 0x46, 0xd2, 0x23,
+	0x79, 0x5d, 0x8a, 0xac, 0x73, 0xa6, 0x64, 0xcc, 0x88, 0xa4, 0x9c, 0x93, 0x84, 0x04, 0xfa, 0x33,
+	0x58, 0xce, 0xac, 0x5f, 0x9a, 0x72, 0x1b, 0x80, 0x0f, 0xb1, 0xe2, 0x61, 0xc8, 0x22, 0x69, 0x06,
+	0x8d, 0x42, 0xde, 0xe2, 0xde, 0x85, 0x9b, 0x53, 0xcd, 0x54, 0x01, 0x53, 0x83, 0x98, 0x92, 0x81,
+	0x3e, 0x80, 0xd5, 0x4f, 0x19, 0x66, 0x77, 0x32, 0xb7, 0x7b, 0xbd, 0xbc, 0x42, 0x01, 0xee, 0x21,
+	0xac, 0xe5, 0xc5, 0xa4, 0xf9, 0x9d, 0xca, 0x26, 0x65, 0x6c, 0x96, 0x4d, 0x7a, 0x1b, 0x16, 0x8e,
+	0x1c, 0xe7, 0x84, 0xb1, 0x70, 0x72, 0x9e, 0xb4, 0x0b, 0x4b, 0x26, 0x3b, 0x0f, 0x2e, 0x98, 0xce,
+	0x9e, 0xab, 0x57, 0xd2, 0x6d, 0x68, 0x8a, 0xee, 0xe2, 0x3a, 0x2f, 0xfd, 0x00, 0xc8, 0x69, 0x6c,
+	0x85, 0xf1, 0xff, 0xb9, 0xbe, 0xeb, 0xf7, 0x5f, 0x35, 0x6d, 0xdd, 0x83, 0x05, 0x35, 0x60, 0xc2,
+	0xe1, 0xfd, 0xbe, 0x02, 0xf3, 0xcf, 0x2e, 0x4f, 0x82, 0xc0, 0x9b, 0x7c, 0xaa, 0x3e, 0x80, 0xd9,
+	0x01, 0xf3, 0x1d, 0xd7, 0xef, 0xb7, 0xab, 0x13, 0x6f, 0x5a, 0x8a, 0x95, 0x1c, 0xc2, 0xcc, 0xb7,
+	0x43, 0x36, 0x64, 0x3c, 0x50, 0x4e, 0x1a, 0x24, 0x39, 0xe9, 0x63, 0x58, 0x15, 0x93, 0xe2, 0xa8,
+	0xc7, 0xb4, 0x24, 0xf3, 0xbd, 0x91, 0x03, 0xa9, 0x90, 0x3e, 0xb3, 0x08, 0xed, 0x38, 0xde, 0x01,
+	0x72, 0xca, 0xe2, 0x63, 0x2b, 0x7a, 0xe8, 0x05, 0x41, 0xb2, 0x0d, 0xe3, 0xe2, 0x3e, 0x3d, 0x84,
+	0xe5, 0xcc, 0x90, 0x57, 0xc8, 0x15, 0xe8, 0x6d, 0x54, 0xf3, 0x24, 0xe8, 0x3f, 0x61, 0x17, 0xcc,
+	0x53, 0x6a, 0x56, 0x60, 0xda, 0xe3, 0x6d, 0x05, 0x65, 0xd8, 0xa0, 0x6f, 0xc3, 0x72, 0x86, 0x37,
+	0xc5, 0xbd, 0x02, 0xe6, 0xff, 0x1f, 0x29, 0xaf, 0x26, 0x98, 0x32, 0xb6, 0xc6, 0x5b, 0x5a, 0xaf,
+	0xf5, 0xa0, 0xa9, 0x4b, 0x1b, 0xb3, 0xdf, 0x5a, 0xf9, 0xb6, 0x5a, 0x52, 0xbe, 0x9d, 0x2a, 0x2a,
+	0xdf, 0xd6, 0xb4, 0xf2, 0xed, 0x31, 0xb4, 0x47, 0xa7, 0x2f, 0x17, 0xfc, 0xb6, 0xb8, 0x3d, 0x32,
+	0xb5, 0x95, 0xcb, 0x72, 0x2b, 0x75, 0x6e, 0x53, 0xb2, 0xd0, 0x5f, 0x55, 0x60, 0x59, 0x04, 0xfd,
+	0x87, 0xae, 0x17, 0xa7, 0x07, 0x8a, 0x83, 0x5e, 0x18, 0x9c, 0x77, 0x30, 0x12, 0x4b, 0x5c, 0xa9,
+	0x73, 0x0a, 0x56, 0x7e, 0xb0, 0x34, 0x12, 0xc8, 0x4e, 0x61, 0x87, 0xd9, 0x38, 0x10, 0x5d, 0x19,
+	0xf3, 0x4d, 0x15, 0x98, 0x4f, 0x3e, 0xf8, 0xd4, 0x32, 0x0f, 0x3e, 0x7f, 0xa9, 0x40, 0xfb, 0xde,
+	0xd0, 0xf5, 0x9c, 0x92, 0xc2, 0x41, 0x3e, 0xef, 0x2d, 0x82, 0x9e, 0x12, 0xfb, 0x65, 0x1c, 0xac,
+	0x36, 0xee, 0xca, 0x3e, 0x9d, 0xbb, 0xb2, 0xeb, 0xa5, 0xa3, 0x99, 0x4c, 0x75, 0x82, 0x76, 0x61,
+	0x93, 0xa7, 0xdd, 0x08, 0xce, 0xce, 0xab, 0x95, 0x33, 0xd5, 0x8d, 0xab, 0x9a, 0xdc, 0xb8, 0xb8,
+	0xad, 0x12, 0xac, 0x96, 0xb7, 0x9d, 0x94, 0x40, 0xdd, 0x7c, 0x95, 0x2e, 0xba, 0x77, 0x25, 0x4b,
+	0x04, 0xaf, 0xf4, 0xae, 0x10, 0xf4, 0x7a, 0x11, 0x4b, 0xfc, 0x54, 0xb4, 0xf0, 0x38, 0xe0, 0x62,
+	0xa5, 0x8d, 0xb0, 0x41, 0x2f, 0x61, 0xa7, 0x5c, 0x95, 0xdc, 0x85, 0x7c, 0x59, 0xa8, 0xf2, 0x7a,
+	0x65, 0x21, 0x91, 0x97, 0xc5, 0x96, 0xa7, 0x1e, 0x27, 0xb0, 0x71, 0xf8, 0x0f, 0x02, 0x70, 0x34,
+	0x70, 0x4f, 0x59, 0x78, 0xc1, 0xf7, 0xe3, 0x39, 0x34, 0xb4, 0xd7, 0x24, 0xb2, 0x9e, 0x86, 0xab,
+	0xcc, 0x6b, 0x9e, 0xa1, 0xd4, 0x16, 0x3c, 0x3d, 0xd1, 0x8d, 0xef, 0xfe, 0xfe, 0xaf, 0xef, 0xab,
+	0xcb, 0x64, 0xe9, 0xe0, 0xe2, 0xce, 0xc1, 0x30, 0x62, 0x21, 0x7f, 0x91, 0x44, 0x7f, 0x27, 0x5f,
+	0xc1, 0xfa, 0x13, 0xee, 0xf7, 0xf1, 0xe3, 0x30, 0x64, 0x98, 0xcd, 0x75, 0x3d, 0x26, 0xfc, 0xb6,
+	0x54, 0x95, 0x82, 0xc2, 0x4c, 0xcd, 0x93, 0xae, 0xa0, 0x92, 0x05, 0xd2, 0x4c, 0x94, 0xf0, 0x47,
+	0xab, 0x10, 0x16, 0x73, 0xe7, 0x92, 0x6c, 0xa5, 0x33, 0x2d, 0x78, 0x19, 0x32, 0xb6, 0xcb, 0xba,
+	0xa5, 0x9e, 0x1d, 0xd4, 0x63, 0xd0, 0xd5, 0x44, 0x8f, 0xc2, 0x60, 0xce, 0x76, 0xb7, 0x72, 0x9b,
+	0x9c, 0x40, 0x8d, 0x3f, 0xe5, 0x90, 0xf2, 0x0b, 0x9d, 0xa1, 0x20, 0x40, 0x7f, 0xf2, 0xa1, 0x6d,
+	0x94, 0x4c, 0xe8, 0x7c, 0x22, 0xd9, 0xb6, 0x3c, 0x8f, 0x4b, 0x7c, 0x09, 0x64, 0xb4, 0x50, 0x4f,
+	0x76, 0xa4, 0x90, 0xd2, 0x1a, 0xbe, 0xb1, 0xad, 0x71, 0x14, 0xf8, 0x03, 0xa5, 0xa8, 0x71, 0x93,
+	0xae, 0x27, 0x1a, 0x43, 0xeb, 0x85, 0xe6, 0x23, 0x5c, 0xf7, 0x19, 0x2c, 0x64, 0xab, 0xf2, 0x64,
+	0x33, 0xb5, 0xd0, 0x68, 0xb1, 0xbe, 0x64, 0x77, 0x46, 0x35, 0xf5, 0x33, 0xa3, 0xb9, 0x26, 0x1f,
+	0x5a, 0xf9, 0xf2, 0x3c, 0xd9, 0x1e, 0xd5, 0xa5, 0xd7, 0xed, 0x4b, 0xb4, 0xbd, 0x81, 0xda, 0xb6,
+	0xe9, 0x46, 0x91, 0x36, 0x1c, 0xcf, 0xf5, 0x7d, 0x57, 0xc1, 0x07, 0x87, 0x8c, 0x61, 0x6c, 0xe6,
+	0x0e, 0x62, 0x42, 0x53, 0xad, 0x65, 0x65, 0x7c, 0x63, 0xcc, 0x39, 0xa3, 0x6f, 0xa1, 0xfe, 0x5d,
+	0xba, 0xad, 0xeb, 0x1f, 0xd5, 0xc3, 0x27, 0xf1, 0xdb, 0x0a, 0x46, 0x8e, 0xc2, 0xd2, 0x3f, 0xb9,
+	0x59, 0x32, 0x8f, 0xdc, 0xdb, 0xc0, 0xd8, 0xb9, 0xbc, 0x83, 0x73, 0xb9, 0x49, 0x6f, 0x94, 0xcc,
+	0x25, 0x95, 0xc6, 0xa7, 0xd3, 0x81, 0x7a, 0xf2, 0xce, 0x9f, 0x9c, 0xc0, 0xfc, 0x5f, 0x02, 0x46,
+	0x7b, 0xb4, 0x43, 0x6a, 0xdb, 0x42, 0x6d, 0xeb, 0x94, 0x24, 0xda, 0x22, 0xc5, 0x73, 0xb7, 0x72,
+	0xfb, 0xbd, 0x8a, 0xc4, 0x13, 0x55, 0xa0, 0x28, 0x3f, 0xe4, 0xaa, 0x23, 0x5f, 0xca, 0xa0, 0x9b,
+	0xa8, 0x61, 0x8d, 0xac, 0xe8, 0xeb, 0x49, 0xe4, 0x3d, 0x87, 0xc6, 0x83, 0xf4, 0xa5, 0x73, 0xdc,
+	0x11, 0x24, 0xa9, 0x82, 0x44, 0xf6, 0x75, 0x94, 0xbd, 0x41, 0x53, 0xd9, 0xda, 0xb3, 0x29, 0x37,
+	0x8f, 0x85, 0x70, 0x22, 0xe2, 0xb3, 0x3c, 0x0d, 0x4a, 0x8e, 0xee, 0x1b, 0xab, 0x7a, 0xd9, 0x21,
+	0x15, 0xbf, 0x8b, 0xe2, 0xb7, 0x68, 0x5b, 0x9f, 0xba, 0x2e, 0x4c, 0xa8, 0x80, 0xf4, 0xb1, 0x95,
+	0x5c, 0x53, 0xfe, 0x5d, 0xf0, 0x5e, 0x6b, 0x6c, 0xa4, 0xee, 0x91, 0x7b, 0x9c, 0xa5, 0xd7, 0x50,
+	0xd5, 0x2a, 0x6d, 0x25, 0xaa, 0x1c, 0xc1, 0xc1, 0x55, 0xbc, 0x50, 0x37, 0x59, 0xad, 0xc4, 0x90,
+	0x9c, 0xea, 0xc2, 0x32, 0x87, 0xb1, 0x55, 0xd2, 0x2b, 0xd5, 0xbd, 0x89, 0xea, 0xae, 0x53, 0x43,
+	0x5f, 0x59, 0x96, 0x97, 0x2b, 0x0e, 0x10, 0x4b, 0xb4, 0x2b, 0xfe, 0x24, 0x30, 0x36, 0x72, 0x6a,
+	0xb5, 0xaa, 0x40, 0x31, 0xa4, 0x68, 0x8c, 0x5c, 0xe1, 0x05, 0xb4, 0x72, 0x92, 0x23, 0x52, 0x02,
+	0xf0, 0xc9, 0x52, 0xaf, 0x97, 0xf6, 0x4b, 0xc5, 0x37, 0x50, 0xf1, 0x35, 0xba, 0x56, 0x18, 0x01,
+	0x70, 0xa1, 0x43, 0xb4, 0xb0, 0xda, 0x5a, 0x91, 0xc9, 0x11, 0x23, 0xe3, 0x15, 0x99, 0xf4, 0xee,
+	0x07, 0xd9, 0x37, 0x2b, 0x9e, 0xab, 0x8d, 0xa1, 0x95, 0xcf, 0xd9, 0xc6, 0x1d, 0x01, 0xb5, 0xd2,
+	0xb2, 0x3c, 0xaf, 0x00, 0x47, 0xbb, 0x39, 0x56, 0xae, 0xf5, 0x37, 0x15, 0x58, 0x2d, 0xcc, 0xbd,
+	0xc8, 0xae, 0x16, 0x7f, 0xca, 0x32, 0xb3, 0x89, 0x41, 0x6a, 0x14, 0x4c, 0xa3, 0x22, 0x71, 0x7c,
+	0x26, 0x7f, 0x18, 0x01, 0xd3, 0x34, 0x6d, 0x2a, 0x01, 0xd3, 0x91, 0x14, 0xce, 0xb8, 0x35, 0x91,
+	0xef, 0x15, 0x91, 0x35, 0x1d, 0x72, 0xb7, 0x72, 0xfb, 0xf0, 0xd7, 0x2b, 0xd0, 0x3c, 0x72, 0xce,
+	0x5d, 0x5f, 0x65, 0x56, 0x5f, 0xc2, 0x9c, 0x2a, 0xc4, 0x4c, 0x86, 0xc1, 0x7c, 0xc9, 0x86, 0x1a,
+	0xa8, 0x7c, 0x85, 0x20, 0xd0, 0x5a, 0x5c, 0x6e, 0xe2, 0x85, 0xc4, 0x06, 0x48, 0x5f, 0x73, 0x88,
+	0x02, 0xeb, 0x91, 0x57, 0x21, 0x63, 0xa3, 0xa0, 0xa7, 0x28, 0xcb, 0xc9, 0x88, 0x3f, 0xf0, 0xd9,
+	0x0b, 0x71, 0x96, 0xe7, 0x33, 0xef, 0x2f, 0x09, 0x54, 0x15, 0xbd, 0x0b, 0x19, 0x9b, 0xc5, 0x9d,
+	0x45, 0xc0, 0x98, 0xd5, 0x36, 0xc4, 0x01, 0x5c, 0x61, 0x1f, 0x1a, 0xda, 0x7b, 0x4c, 0xe2, 0xd7,
+	0xa3, 0x6f, 0x3a, 0x86, 0x51, 0xd4, 0x55, 0x74, 0x78, 0xb3, 0xaa, 0x94, 0x22, 0x1f, 0x16, 0x73,
+	0xbe, 0x38, 0xee, 0x10, 0x4d, 0x72, 0xdf, 0x02, 0x4b, 0xe6, 0x32, 0xac, 0x9f, 0xc0, 0x9c, 0x7a,
+	0xe6, 0x21, 0xaa, 0xee, 0x96, 0x7b, 0x4a, 0x32, 0xd6, 0x47, 0xe8, 0x52, 0xfc, 0x36, 0x8a, 0x6f,
+	0xd3, 0xe5, 0x54, 0x3c, 0xbf, 0xb0, 0x1c, 0x9c, 0xc9, 0x70, 0xf2, 0x5d, 0x05, 0xc8, 0xe8, 0xfb,
+	0x4c, 0x92, 0x3b, 0x96, 0xbe, 0x1b, 0x19, 0x37, 0xc6, 0x70, 0x48, 0xdd, 0xb7, 0x50, 0xf7, 0x0d,
+	0xba, 0x99, 0xea, 0xee, 0x8f, 0x70, 0xf3, 0x49, 0xfc, 0xae, 0x02, 0x5b, 0xb9, 0xd7, 0x94, 0x2f,
+	0xdc, 0xf8, 0x2c, 0x7d, 0x18, 0x21, 0xb7, 0xb4, 0xf5, 0x8d, 0x7b, 0x3a, 0x31, 0xf6, 0x26, 0x33,
+	0x66, 0x6f, 0x1d, 0x74, 0x21, 0x6b, 0x19, 0x3e, 0x9f, 0xdf, 0xf3, 0xf9, 0x64, 0xf7, 0xab, 0x6c,
+	0x3e, 0x13, 0x9e, 0x72, 0x26, 0x6e, 0xff, 0x3e, 0xce, 0x62, 0x8f, 0xee, 0x16, 0x6e, 0x7f, 0x56,
+	0x2b, 0x9f, 0xda, 0x29, 0x00, 0x16, 0xc2, 0xb0, 0x26, 0x4d, 0xd4, 0x3d, 0x41, 0xaf, 0x64, 0x1b,
+	0x2b, 0x59, 0x62, 0x16, 0x10, 0xe8, 0x62, 0xaa, 0x68, 0xc0, 0x19, 0x84, 0x87, 0xd5, 0x93, 0xd2,
+	0x75, 0x39, 0xd6, 0xb4, 0x53, 0xe0, 0xcb, 0x56, 0xb9, 0x55, 0x36, 0x41, 0x96, 0xf5, 0x8d, 0x56,
+	0xf2, 0xbe, 0x84, 0x39, 0xf5, 0x1b, 0xe7, 0x64, 0x1c, 0xcb, 0xff, 0xf0, 0x59, 0x84, 0x63, 0x7e,
+	0xe0, 0x30, 0x97, 0x4b, 0xb3, 0xa1, 0x85, 0x25, 0x73, 0xfd, 0x24, 0x16, 0xa5, 0x5b, 0x2b, 0xe9,
+	0xe9, 0xb4, 0x59, 0x71, 0xcc, 0x4c, 0x2c, 0x9f, 0x91, 0xc6, 0x6d, 0xf3, 0x2d, 0xb4, 0xf2, 0x75,
+	0xf1, 0xf2, 0x65, 0x5c, 0x2f, 0x2e, 0x8b, 0x8f, 0xa4, 0x78, 0xe4, 0xda, 0x28, 0x2c, 0x4b, 0x28,
+	0x63, 0x0e, 0xf1, 0x60, 0x11, 0xa1, 0xc9, 0xf3, 0x26, 0x6b, 0x1c, 0x87, 0x65, 0x99, 0xf0, 0x3c,
+	0x8a, 0x65, 0x47, 0xe2, 0xf2, 0xd8, 0x83, 0x86, 0x56, 0xa8, 0x4e, 0xa0, 0x6c, 0xb4, 0x78, 0x6f,
+	0x18, 0x45, 0x5d, 0x63, 0x02, 0x42, 0xca, 0xc6, 0xf5, 0x84, 0xb0, 0x90, 0xad, 0x33, 0x27, 0x29,
+	0x65, 0x61, 0x15, 0xdb, 0xd8, 0x2a, 0xe9, 0x2d, 0x8f, 0x09, 0x4e, 0x86, 0x93, 0xeb, 0x7c, 0x06,
+	0xb3, 0xb2, 0x4e, 0x4d, 0x54, 0xce, 0x9d, 0xad, 0x5b, 0x27, 0x37, 0x6d, 0xbd, 0xfa, 0x9c, 0xbd,
+	0xa5, 0xc8, 0xb3, 0xc2, 0x78, 0x1e, 0xe7, 0xa0, 0xd4, 0xe7, 0x00, 0x69, 0x45, 0x3b, 0x89, 0x9f,
+	0x23, 0x45, 0xee, 0x62, 0xd9, 0x05, 0x86, 0x42, 0xd9, 0x21, 0x0e, 0xe7, 0xe2, 0xbb, 0xd0, 0xd0,
+	0x6a, 0xdd, 0xc9, 0x86, 0x8c, 0xd6, 0xbf, 0x93, 0x7b, 0x44, 0xb6, 0xc8, 0x5d, 0x14, 0xc3, 0xce,
+	0x91, 0xe3, 0x20, 0xe2, 0x32, 0xb8, 0x8e, 0xaf, 0x38, 0x8c, 0x04, 0x03, 0xa9, 0xa2, 0xd4, 0xbb,
+	0x4a, 0x14, 0x14, 0xac, 0x21, 0x51, 0x10, 0x0c, 0x44, 0x55, 0x60, 0x3e, 0x53, 0xb9, 0x2e, 0x57,
+	0xb1, 0x99, 0x29, 0x5c, 0xe7, 0x0a, 0xdd, 0x4a, 0x13, 0xd1, 0x76, 0x39, 0xbe, 0x1c, 0x04, 0x81,
+	0x77, 0x60, 0x0b, 0x4e, 0xf2, 0x35, 0x34, 0xb4, 0x2a, 0x75, 0x6a, 0xad, 0x91, 0x62, 0xb7, 0x61,
+	0x14, 0x75, 0x95, 0x1f, 0x15, 0xa9, 0xa7, 0x2f, 0x59, 0xf9, 0xaa, 0x6c, 0x68, 0x68, 0x15, 0x6b,
+	0x5d, 0x57, 0xae, 0xe2, 0x6d, 0x18, 0x45, 0x5d, 0xe5, 0xde, 0xe5, 0x49, 0x9e, 0xbb, 0x95, 0xdb,
+	0xdd, 0x19, 0xfc, 0x2b, 0xfa, 0xfd, 0xff, 0x0c, 0x00, 0xbc, 0xde, 0xc3, 0xa5, 0x56, 0x30, 0x00,
 	0x00,
 }
@@ -0,0 +1,230 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package rpc
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nebulasio/go-nebulas/neblet/pb"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Rate limit errors
+var (
+	ErrRateLimited       = errors.New("too many requests, please try again later")
+	ErrTooManyExecutions = errors.New("too many executions in progress, please try again later")
+)
+
+// heavyMethods the methods executing contracts or scanning blocks, limited
+// apart from the others.
+var heavyMethods = map[string]bool{
+	"/rpcpb.ApiService/Call":                     true,
+	"/rpcpb.ApiService/BuildTransaction":         true,
+	"/rpcpb.ApiService/EstimateGas":              true,
+	"/rpcpb.ApiService/GetContractEvents":        true,
+	"/rpcpb.ApiService/GetEventsByFilter":        true,
+	"/rpcpb.ApiService/GetTransactionsByAddress": true,
+}
+
+const bucketPruneInterval = time.Minute
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// rateLimiter keeps a token bucket for each key, refilled at rate tokens per
+// second up to burst. A nil rateLimiter allows all.
+type rateLimiter struct {
+	rate  float64
+	burst float64
+
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastPrune time.Time
+}
+
+func newRateLimiter(rate, burst uint32) *rateLimiter {
+	if rate == 0 {
+		return nil
+	}
+	if burst == 0 {
+		burst = rate
+	}
+	return &rateLimiter{
+		rate:    float64(rate),
+		burst:   float64(burst),
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+// allow take a token from the bucket of the key if any.
+func (l *rateLimiter) allow(key string, now time.Time) bool {
+	if l == nil {
+		return true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastPrune) >= bucketPruneInterval {
+		l.prune(now)
+	}
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: l.burst, last: now}
+		l.buckets[key] = bucket
+	}
+	if elapsed := now.Sub(bucket.last).Seconds(); elapsed > 0 {
+		bucket.tokens += elapsed * l.rate
+		if bucket.tokens > l.burst {
+			bucket.tokens = l.burst
+		}
+		bucket.last = now
+	}
+	if bucket.tokens < 1 {
+		return false
+	}
+	bucket.tokens--
+	return true
+}
+
+// prune drop the buckets refilled to full, they're the same as new ones.
+func (l *rateLimiter) prune(now time.Time) {
+	for key, bucket := range l.buckets {
+		if bucket.tokens+now.Sub(bucket.last).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastPrune = now
+}
+
+// rpcLimits limits the requests of the clients and the executions of the
+// heavy methods.
+type rpcLimits struct {
+	auth       *rpcAuth
+	clients    *rateLimiter
+	heavy      *rateLimiter
+	executions chan struct{}
+}
+
+func newRPCLimits(config *nebletpb.RPCConfig, auth *rpcAuth) *rpcLimits {
+	limits := &rpcLimits{
+		auth:    auth,
+		clients: newRateLimiter(config.ClientRateLimit, config.ClientRateBurst),
+		heavy:   newRateLimiter(config.HeavyRateLimit, config.HeavyRateBurst),
+	}
+	if config.MaxConcurrentExecutions > 0 {
+		limits.executions = make(chan struct{}, config.MaxConcurrentExecutions)
+	}
+	return limits
+}
+
+// clientKey identifies the client by its credential, or its ip. The ip of
+// the http clients is forwarded by the gateway from loopback.
+func (l *rpcLimits) clientKey(ctx context.Context) string {
+	if token := grpcBearerToken(ctx); l.auth != nil && len(token) > 0 {
+		if cred, err := l.auth.credential(token); err == nil {
+			if len(cred.name) > 0 {
+				return "credential:" + cred.name
+			}
+			return "credential:" + token
+		}
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	if !isLocalAddr(p.Addr) {
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			return host
+		}
+		return p.Addr.String()
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if fwd := md["x-forwarded-for"]; len(fwd) > 0 {
+			// the last one is appended by the gateway, the others are told by the client.
+			ips := strings.Split(fwd[len(fwd)-1], ",")
+			return strings.TrimSpace(ips[len(ips)-1])
+		}
+	}
+	return p.Addr.String()
+}
+
+// acquire check the rate limits of the client and takes an execution slot
+// for the heavy methods, the returned func releases the slot.
+func (l *rpcLimits) acquire(ctx context.Context, method string) (func(), error) {
+	key := l.clientKey(ctx)
+	now := time.Now()
+	if !l.clients.allow(key, now) {
+		return nil, ErrRateLimited
+	}
+	if !heavyMethods[method] {
+		return func() {}, nil
+	}
+	if !l.heavy.allow(key+method, now) {
+		return nil, ErrRateLimited
+	}
+	if l.executions == nil {
+		return func() {}, nil
+	}
+	select {
+	case l.executions <- struct{}{}:
+		return func() { <-l.executions }, nil
+	default:
+		return nil, ErrTooManyExecutions
+	}
+}
+
+func rateLimitUnary(limits *rpcLimits) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		release, err := limits.acquire(ctx, info.FullMethod)
+		if err != nil {
+			logging.VLog().WithFields(logrus.Fields{
+				"method": info.FullMethod,
+				"err":    err,
+			}).Debug("Refused rate limited rpc request.")
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
+		defer release()
+		return handler(ctx, req)
+	}
+}
+
+func rateLimitStream(limits *rpcLimits) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		release, err := limits.acquire(ss.Context(), info.FullMethod)
+		if err != nil {
+			return status.Error(codes.ResourceExhausted, err.Error())
+		}
+		defer release()
+		return handler(srv, ss)
+	}
+}
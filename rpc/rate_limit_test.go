@@ -0,0 +1,116 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package rpc
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/nebulasio/go-nebulas/neblet/pb"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestRateLimiter(t *testing.T) {
+	var none *rateLimiter
+	assert.True(t, none.allow("a", time.Now()))
+	assert.Nil(t, newRateLimiter(0, 10))
+
+	l := newRateLimiter(2, 3)
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		assert.True(t, l.allow("a", now))
+	}
+	assert.False(t, l.allow("a", now))
+	assert.True(t, l.allow("b", now))
+
+	// refilled at 2 tokens per second.
+	assert.True(t, l.allow("a", now.Add(500*time.Millisecond)))
+	assert.False(t, l.allow("a", now.Add(500*time.Millisecond)))
+	assert.True(t, l.allow("a", now.Add(time.Second)))
+
+	// no more than burst after idle.
+	later := now.Add(time.Hour)
+	for i := 0; i < 3; i++ {
+		assert.True(t, l.allow("b", later))
+	}
+	assert.False(t, l.allow("b", later))
+
+	// the refilled buckets are pruned.
+	assert.Equal(t, 1, len(l.buckets))
+}
+
+func TestRPCLimitsAcquire(t *testing.T) {
+	limits := newRPCLimits(&nebletpb.RPCConfig{
+		ClientRateLimit:         10,
+		HeavyRateLimit:          2,
+		MaxConcurrentExecutions: 1,
+	}, nil)
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 1234}})
+	call := "/rpcpb.ApiService/Call"
+
+	release, err := limits.acquire(ctx, call)
+	assert.Nil(t, err)
+	_, err = limits.acquire(ctx, "/rpcpb.ApiService/EstimateGas")
+	assert.Equal(t, ErrTooManyExecutions, err)
+	release()
+
+	release, err = limits.acquire(ctx, call)
+	assert.Nil(t, err)
+	release()
+	_, err = limits.acquire(ctx, call)
+	assert.Equal(t, ErrRateLimited, err)
+
+	// the refused requests count too.
+	for i := 0; i < 6; i++ {
+		_, err = limits.acquire(ctx, "/rpcpb.ApiService/GetNebState")
+		assert.Nil(t, err)
+	}
+	_, err = limits.acquire(ctx, "/rpcpb.ApiService/GetNebState")
+	assert.Equal(t, ErrRateLimited, err)
+}
+
+func TestRPCLimitsClientKey(t *testing.T) {
+	auth, err := newRPCAuth(&nebletpb.RPCConfig{
+		Credentials: []*nebletpb.RPCCredential{{Name: "wallet", ApiKey: "key", Allow: []string{MethodSetReadOnly}}},
+		Anonymous:   []string{MethodSetReadOnly},
+	})
+	assert.Nil(t, err)
+	limits := newRPCLimits(&nebletpb.RPCConfig{}, auth)
+
+	remote := &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 1234}}
+	local := &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 1234}}
+
+	ctx := peer.NewContext(context.Background(), remote)
+	assert.Equal(t, "10.0.0.1", limits.clientKey(ctx))
+
+	// the forwarded ip is trusted from the gateway only.
+	md := metadata.Pairs("x-forwarded-for", "1.1.1.1, 10.0.0.2")
+	assert.Equal(t, "10.0.0.1", limits.clientKey(metadata.NewIncomingContext(ctx, md)))
+	ctx = peer.NewContext(context.Background(), local)
+	assert.Equal(t, "10.0.0.2", limits.clientKey(metadata.NewIncomingContext(ctx, md)))
+
+	md = metadata.Pairs("authorization", "Bearer key")
+	assert.Equal(t, "credential:wallet", limits.clientKey(metadata.NewIncomingContext(ctx, md)))
+	md = metadata.Pairs("authorization", "Bearer other")
+	assert.Equal(t, "127.0.0.1:1234", limits.clientKey(metadata.NewIncomingContext(ctx, md)))
+}
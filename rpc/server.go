@@ -3,6 +3,8 @@ package rpc
 import (
 	"errors"
 	"net"
+	"os"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 	"golang.org/x/net/netutil"
@@ -14,6 +16,7 @@ import (
 	"github.com/nebulasio/go-nebulas/rpc/pb"
 	"github.com/nebulasio/go-nebulas/util/logging"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/reflection"
 )
 
@@ -59,6 +62,8 @@ type Server struct {
 
 	rpcServer *grpc.Server
 
+	wsServer *WSServer
+
 	rpcConfig *nebletpb.RPCConfig
 }
 
@@ -68,19 +73,47 @@ func NewServer(neblet core.Neblet) *Server {
 	if cfg == nil {
 		logging.CLog().Fatal("Failed to find rpc config in config file.")
 	}
-	rpc := grpc.NewServer(grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(loggingStream)),
-		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(loggingUnary)),
-		grpc.MaxRecvMsgSize(MaxRecvMsgSize))
+	auth, err := newRPCAuth(cfg)
+	if err != nil {
+		logging.CLog().WithFields(logrus.Fields{
+			"err": err,
+		}).Fatal("Invalid rpc credentials in config file.")
+	}
+	tlsConfig, err := newServerTLSConfig(cfg)
+	if err != nil {
+		logging.CLog().WithFields(logrus.Fields{
+			"err": err,
+		}).Fatal("Invalid rpc tls config in config file.")
+	}
+	limits := newRPCLimits(cfg, auth)
+	maxRecvMsgSize := MaxRecvMsgSize
+	if cfg.MaxRequestSize > 0 {
+		maxRecvMsgSize = int(cfg.MaxRequestSize)
+	}
+	opts := []grpc.ServerOption{grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(loggingStream, authStream(auth), rateLimitStream(limits))),
+		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(loggingUnary, authUnary(auth), rateLimitUnary(limits), localAdminUnary(cfg.RemoteAdmin))),
+		grpc.MaxRecvMsgSize(maxRecvMsgSize)}
+	if tlsConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	rpc := grpc.NewServer(opts...)
 
 	srv := &Server{neblet: neblet, rpcServer: rpc, rpcConfig: cfg}
+	if len(cfg.WsListen) > 0 {
+		srv.wsServer = NewWSServer(neblet.EventEmitter(), cfg)
+		srv.wsServer.auth = auth
+		srv.wsServer.tlsConfig = tlsConfig
+	}
 	api := &APIService{server: srv}
 	admin := &AdminService{server: srv}
 
 	rpcpb.RegisterApiServiceServer(rpc, api)
 	rpcpb.RegisterAdminServiceServer(rpc, admin)
-	// Register reflection service on gRPC server.
-	// TODO: Enable reflection only for testing mode.
-	reflection.Register(rpc)
+	// Register reflection service on gRPC server, the clients in any language
+	// discover the services without the proto files.
+	if !cfg.DisableReflection {
+		reflection.Register(rpc)
+	}
 
 	return srv
 }
@@ -98,12 +131,31 @@ func (s *Server) Start() error {
 			return err
 		}
 	}
+	for _, v := range s.rpcConfig.AdminListen {
+		if err := s.start(v); err != nil {
+			return err
+		}
+	}
 
+	if s.wsServer != nil {
+		return s.wsServer.Start(s.rpcConfig.WsListen)
+	}
 	return nil
 }
 
 func (s *Server) start(addr string) error {
-	listener, err := net.Listen("tcp", addr)
+	// a path is listened as unix socket, accessible to the owner only.
+	network := "tcp"
+	if strings.Contains(addr, "/") {
+		network = "unix"
+		os.Remove(addr)
+	}
+	listener, err := net.Listen(network, addr)
+	if err == nil && network == "unix" {
+		if err = os.Chmod(addr, 0600); err != nil {
+			listener.Close()
+		}
+	}
 	if err != nil {
 		logging.CLog().WithFields(logrus.Fields{
 			"err": err,
@@ -162,6 +214,9 @@ func (s *Server) Stop() {
 	}).Info("Stopping RPC GRPCServer and Gateway...")
 
 	s.rpcServer.Stop()
+	if s.wsServer != nil {
+		s.wsServer.Stop()
+	}
 
 	logging.CLog().Info("Stopped RPC GRPCServer and Gateway.")
 }
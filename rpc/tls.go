@@ -0,0 +1,121 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package rpc
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io/ioutil"
+
+	"github.com/nebulasio/go-nebulas/neblet/pb"
+)
+
+// TLS errors
+var (
+	ErrMissingTLSKeyPair   = errors.New("both tls_cert and tls_key are required")
+	ErrInvalidTLSClientCA  = errors.New("no certificate found in tls_client_ca")
+	ErrMissingClientCert   = errors.New("missing client certificate")
+	ErrUnexpectedServerTLS = errors.New("unexpected rpc server certificate")
+)
+
+// newServerTLSConfig returns the tls config of the listeners, nil if tls is
+// not configured. The client certificates are verified by tls_client_ca if
+// set, besides the certificate of the node itself used by the gateway.
+func newServerTLSConfig(config *nebletpb.RPCConfig) (*tls.Config, error) {
+	if len(config.TlsCert) == 0 && len(config.TlsKey) == 0 {
+		if len(config.TlsClientCa) > 0 {
+			return nil, ErrMissingTLSKeyPair
+		}
+		return nil, nil
+	}
+	if len(config.TlsCert) == 0 || len(config.TlsKey) == 0 {
+		return nil, ErrMissingTLSKeyPair
+	}
+	cert, err := tls.LoadX509KeyPair(config.TlsCert, config.TlsKey)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if len(config.TlsClientCa) > 0 {
+		pem, err := ioutil.ReadFile(config.TlsClientCa)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, ErrInvalidTLSClientCA
+		}
+		tlsConfig.ClientAuth = tls.RequireAnyClientCert
+		tlsConfig.VerifyPeerCertificate = verifyClientCert(pool, cert.Certificate[0])
+	}
+	return tlsConfig, nil
+}
+
+func verifyClientCert(roots *x509.CertPool, self []byte) func([][]byte, [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return ErrMissingClientCert
+		}
+		if bytes.Equal(rawCerts[0], self) {
+			return nil
+		}
+		certs := make([]*x509.Certificate, len(rawCerts))
+		for i, raw := range rawCerts {
+			cert, err := x509.ParseCertificate(raw)
+			if err != nil {
+				return err
+			}
+			certs[i] = cert
+		}
+		opts := x509.VerifyOptions{
+			Roots:         roots,
+			Intermediates: x509.NewCertPool(),
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		}
+		for _, cert := range certs[1:] {
+			opts.Intermediates.AddCert(cert)
+		}
+		_, err := certs[0].Verify(opts)
+		return err
+	}
+}
+
+// newGatewayTLSConfig returns the tls config the gateway dials the rpc server
+// of the node with. The server is the node itself, its certificate is pinned
+// instead of verified by name, and presented as the client certificate.
+func newGatewayTLSConfig(server *tls.Config) *tls.Config {
+	self := server.Certificates[0].Certificate[0]
+	return &tls.Config{
+		Certificates:       server.Certificates,
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 || !bytes.Equal(rawCerts[0], self) {
+				return ErrUnexpectedServerTLS
+			}
+			return nil
+		},
+	}
+}
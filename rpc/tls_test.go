@@ -0,0 +1,174 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package rpc
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nebulasio/go-nebulas/neblet/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	der  []byte
+}
+
+func newTestCert(t *testing.T, name string, usage x509.ExtKeyUsage, parent *testCert) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	assert.Nil(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	signer, signerKey := tmpl, key
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+		tmpl.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature
+	} else {
+		tmpl.ExtKeyUsage = []x509.ExtKeyUsage{usage}
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signerKey)
+	assert.Nil(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.Nil(t, err)
+	return &testCert{cert: cert, key: key, der: der}
+}
+
+func (c *testCert) write(t *testing.T, dir, name string) (string, string) {
+	certFile := filepath.Join(dir, name+".crt")
+	keyFile := filepath.Join(dir, name+".key")
+	keyDer, err := x509.MarshalECPrivateKey(c.key)
+	assert.Nil(t, err)
+	assert.Nil(t, ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.der}), 0600))
+	assert.Nil(t, ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+	return certFile, keyFile
+}
+
+func (c *testCert) tlsCertificate() tls.Certificate {
+	return tls.Certificate{Certificate: [][]byte{c.der}, PrivateKey: c.key}
+}
+
+// tlsHandshake connects the client to the server, which replies a byte once
+// the handshake completes.
+func tlsHandshake(server, client *tls.Config) error {
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", server)
+	if err != nil {
+		return err
+	}
+	defer listener.Close()
+
+	errCh := make(chan error, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			errCh <- err
+			return
+		}
+		defer conn.Close()
+		if err = conn.(*tls.Conn).Handshake(); err == nil {
+			_, err = conn.Write([]byte{1})
+		}
+		errCh <- err
+	}()
+
+	conn, err := tls.Dial("tcp", listener.Addr().String(), client)
+	if err == nil {
+		// the client finishes before the server verifies its certificate.
+		_, err = conn.Read(make([]byte, 1))
+		conn.Close()
+	}
+	if serverErr := <-errCh; serverErr != nil {
+		return serverErr
+	}
+	return err
+}
+
+func TestServerTLSConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rpc-tls")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	ca := newTestCert(t, "ca", 0, nil)
+	server := newTestCert(t, "server", x509.ExtKeyUsageServerAuth, ca)
+	client := newTestCert(t, "client", x509.ExtKeyUsageClientAuth, ca)
+	otherCA := newTestCert(t, "other ca", 0, nil)
+	other := newTestCert(t, "other", x509.ExtKeyUsageClientAuth, otherCA)
+	caFile, _ := ca.write(t, dir, "ca")
+	certFile, keyFile := server.write(t, dir, "server")
+
+	tlsConfig, err := newServerTLSConfig(&nebletpb.RPCConfig{})
+	assert.Nil(t, err)
+	assert.Nil(t, tlsConfig)
+	_, err = newServerTLSConfig(&nebletpb.RPCConfig{TlsCert: certFile})
+	assert.Equal(t, ErrMissingTLSKeyPair, err)
+	_, err = newServerTLSConfig(&nebletpb.RPCConfig{TlsClientCa: caFile})
+	assert.Equal(t, ErrMissingTLSKeyPair, err)
+	_, err = newServerTLSConfig(&nebletpb.RPCConfig{TlsCert: certFile, TlsKey: keyFile, TlsClientCa: keyFile})
+	assert.Equal(t, ErrInvalidTLSClientCA, err)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+
+	// server authentication only.
+	tlsConfig, err = newServerTLSConfig(&nebletpb.RPCConfig{TlsCert: certFile, TlsKey: keyFile})
+	assert.Nil(t, err)
+	assert.Nil(t, tlsHandshake(tlsConfig, &tls.Config{RootCAs: roots, ServerName: "127.0.0.1"}))
+
+	// mutual tls.
+	tlsConfig, err = newServerTLSConfig(&nebletpb.RPCConfig{TlsCert: certFile, TlsKey: keyFile, TlsClientCa: caFile})
+	assert.Nil(t, err)
+	assert.Nil(t, tlsHandshake(tlsConfig, &tls.Config{
+		RootCAs:      roots,
+		ServerName:   "127.0.0.1",
+		Certificates: []tls.Certificate{client.tlsCertificate()},
+	}))
+	assert.NotNil(t, tlsHandshake(tlsConfig, &tls.Config{RootCAs: roots, ServerName: "127.0.0.1"}))
+	assert.NotNil(t, tlsHandshake(tlsConfig, &tls.Config{
+		RootCAs:      roots,
+		ServerName:   "127.0.0.1",
+		Certificates: []tls.Certificate{other.tlsCertificate()},
+	}))
+
+	// the gateway presents the certificate of the node and pins the server one.
+	assert.Nil(t, tlsHandshake(tlsConfig, newGatewayTLSConfig(tlsConfig)))
+	otherServer := &tls.Config{Certificates: []tls.Certificate{newTestCert(t, "server", x509.ExtKeyUsageServerAuth, ca).tlsCertificate()}}
+	assert.NotNil(t, tlsHandshake(otherServer, newGatewayTLSConfig(tlsConfig)))
+}
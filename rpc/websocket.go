@@ -0,0 +1,421 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package rpc
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/neblet/pb"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// The websocket endpoint pushes the chain events to the clients. A client
+// sends {"id":1,"method":"subscribe","topic":"newTailBlock"} and receives
+// {"id":1,"subscription":"1"}, then the events of the topic as
+// {"subscription":"1","topic":"chain.newTailBlock","data":"..."} until it
+// sends {"id":2,"method":"unsubscribe","subscription":"1"}. The contract
+// events are filtered by "address" and "event_topic". The events of a
+// connection are buffered, a client falling behind the buffer is evicted
+// rather than slowing the node down.
+
+// Websocket methods
+const (
+	WSMethodSubscribe   = "subscribe"
+	WSMethodUnsubscribe = "unsubscribe"
+)
+
+// Const
+const (
+	// DefaultWSBufferSize the events buffered for a connection by default
+	DefaultWSBufferSize = 1024
+
+	// MaxWSSubscriptions the most subscriptions of a connection
+	MaxWSSubscriptions = 32
+
+	// MaxWSMessageSize the max size of a message from the clients
+	MaxWSMessageSize = 64 * 1024
+
+	wsWriteWait  = 10 * time.Second
+	wsPongWait   = 60 * time.Second
+	wsPingPeriod = wsPongWait * 9 / 10
+)
+
+// Errors
+var (
+	ErrWSUnknownMethod          = errors.New("unknown websocket method")
+	ErrWSUnknownTopic           = errors.New("unknown topic")
+	ErrWSTooManySubscriptions   = errors.New("too many subscriptions")
+	ErrWSSubscriptionNotFound   = errors.New("subscription not found")
+	ErrWSFilterNotContractTopic = errors.New("address and event_topic only filter contract events")
+)
+
+// wsTopics the short names of the topics
+var wsTopics = map[string]string{
+	"newTailBlock":       core.TopicNewTailBlock,
+	"pendingTransaction": core.TopicPendingTransaction,
+	"contractEvent":      core.TopicNewContractEvent,
+}
+
+// WSRequest a request from the websocket clients.
+type WSRequest struct {
+	ID           uint64 `json:"id"`
+	Method       string `json:"method"`
+	Topic        string `json:"topic,omitempty"`
+	Address      string `json:"address,omitempty"`
+	EventTopic   string `json:"event_topic,omitempty"`
+	Subscription string `json:"subscription,omitempty"`
+}
+
+// WSResponse a reply to a request, or an event of a subscription if ID is 0.
+type WSResponse struct {
+	ID           uint64 `json:"id,omitempty"`
+	Subscription string `json:"subscription,omitempty"`
+	Topic        string `json:"topic,omitempty"`
+	Data         string `json:"data,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+// WSServer serves the subscriptions over websocket.
+type WSServer struct {
+	emitter    *core.EventEmitter
+	bufferSize int
+	upgrader   websocket.Upgrader
+	connCh     chan bool
+	auth       *rpcAuth
+	tlsConfig  *tls.Config
+
+	mu        sync.Mutex
+	listeners []net.Listener
+}
+
+// NewWSServer returns a websocket server pushing the events of the emitter.
+func NewWSServer(emitter *core.EventEmitter, config *nebletpb.RPCConfig) *WSServer {
+	bufferSize := int(config.WsBufferSize)
+	if bufferSize == 0 {
+		bufferSize = DefaultWSBufferSize
+	}
+	connectionLimits := config.ConnectionLimits
+	if connectionLimits == 0 {
+		connectionLimits = DefaultConnectionLimits
+	}
+	cors := config.HttpCors
+	return &WSServer{
+		emitter:    emitter,
+		bufferSize: bufferSize,
+		upgrader: websocket.Upgrader{
+			CheckOrigin: func(r *http.Request) bool {
+				origin := r.Header.Get("Origin")
+				if len(origin) == 0 {
+					return true
+				}
+				for _, v := range cors {
+					if v == "*" || v == origin {
+						return true
+					}
+				}
+				return false
+			},
+		},
+		connCh: make(chan bool, connectionLimits),
+	}
+}
+
+// Start listens on the addresses and serves the websocket clients.
+func (s *WSServer) Start(addrs []string) error {
+	for _, addr := range addrs {
+		listener, err := net.Listen("tcp", addr)
+		if err != nil {
+			logging.CLog().WithFields(logrus.Fields{
+				"err": err,
+			}).Error("Failed to listen to websocket server")
+			return err
+		}
+		if s.tlsConfig != nil {
+			listener = tls.NewListener(listener, s.tlsConfig)
+		}
+		s.mu.Lock()
+		s.listeners = append(s.listeners, listener)
+		s.mu.Unlock()
+
+		logging.CLog().WithFields(logrus.Fields{
+			"address": addr,
+		}).Info("Started websocket server.")
+
+		go func() {
+			if err := http.Serve(listener, s); err != nil {
+				logging.CLog().WithFields(logrus.Fields{
+					"err": err,
+				}).Info("Websocket server exited.")
+			}
+		}()
+	}
+	return nil
+}
+
+// Stop closes the listeners.
+func (s *WSServer) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, listener := range s.listeners {
+		listener.Close()
+	}
+	s.listeners = nil
+}
+
+// ServeHTTP upgrades the request to websocket and serves the subscriptions.
+func (s *WSServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	select {
+	case s.connCh <- true:
+		defer func() { <-s.connCh }()
+	default:
+		statusUnavailableHandler(w, r)
+		return
+	}
+
+	// the subscriptions are authorized as the Subscribe rpc.
+	if err := s.auth.authorize(httpBearerToken(r), "/rpcpb.ApiService/Subscribe"); err != nil {
+		http.Error(w, "{\"error\":\""+err.Error()+"\"}", http.StatusUnauthorized)
+		return
+	}
+
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"err": err,
+		}).Debug("Failed to upgrade to websocket.")
+		return
+	}
+	newWSConn(s, conn).run()
+}
+
+type wsSubscription struct {
+	id         string
+	subscriber *core.EventSubscriber
+	address    string
+	eventTopic string
+	quitCh     chan struct{}
+}
+
+// match return if the event passes the filters of the subscription.
+func (sub *wsSubscription) match(data string) bool {
+	if len(sub.address) == 0 && len(sub.eventTopic) == 0 {
+		return true
+	}
+	event := new(core.ContractEvent)
+	if err := json.Unmarshal([]byte(data), event); err != nil {
+		return false
+	}
+	return (len(sub.address) == 0 || sub.address == event.Address) &&
+		(len(sub.eventTopic) == 0 || sub.eventTopic == event.Topic)
+}
+
+type wsConn struct {
+	server *WSServer
+	conn   *websocket.Conn
+	sendCh chan *WSResponse
+	quitCh chan struct{}
+	once   sync.Once
+
+	mu     sync.Mutex
+	subs   map[string]*wsSubscription
+	nextID uint64
+}
+
+func newWSConn(server *WSServer, conn *websocket.Conn) *wsConn {
+	return &wsConn{
+		server: server,
+		conn:   conn,
+		sendCh: make(chan *WSResponse, server.bufferSize),
+		quitCh: make(chan struct{}),
+		subs:   make(map[string]*wsSubscription),
+	}
+}
+
+func (c *wsConn) run() {
+	defer c.close()
+	go c.writeLoop()
+
+	c.conn.SetReadLimit(MaxWSMessageSize)
+	c.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+	for {
+		req := new(WSRequest)
+		if err := c.conn.ReadJSON(req); err != nil {
+			return
+		}
+		if !c.send(c.handle(req)) {
+			return
+		}
+	}
+}
+
+func (c *wsConn) handle(req *WSRequest) *WSResponse {
+	var (
+		id  string
+		err error
+	)
+	switch req.Method {
+	case WSMethodSubscribe:
+		id, err = c.subscribe(req)
+	case WSMethodUnsubscribe:
+		id, err = req.Subscription, c.unsubscribe(req.Subscription)
+	default:
+		err = ErrWSUnknownMethod
+	}
+	if err != nil {
+		return &WSResponse{ID: req.ID, Error: err.Error()}
+	}
+	return &WSResponse{ID: req.ID, Subscription: id}
+}
+
+func (c *wsConn) subscribe(req *WSRequest) (string, error) {
+	topic, ok := wsTopics[req.Topic]
+	if !ok {
+		if !strings.HasPrefix(req.Topic, "chain.") {
+			return "", ErrWSUnknownTopic
+		}
+		topic = req.Topic
+	}
+	if (len(req.Address) > 0 || len(req.EventTopic) > 0) && topic != core.TopicNewContractEvent {
+		return "", ErrWSFilterNotContractTopic
+	}
+	if len(req.Address) > 0 {
+		if _, err := core.AddressParse(req.Address); err != nil {
+			return "", err
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.subs) >= MaxWSSubscriptions {
+		return "", ErrWSTooManySubscriptions
+	}
+	c.nextID++
+	sub := &wsSubscription{
+		id:         strconv.FormatUint(c.nextID, 10),
+		subscriber: c.server.emitter.Subscribe(DefaultWSBufferSize, topic),
+		address:    req.Address,
+		eventTopic: req.EventTopic,
+		quitCh:     make(chan struct{}),
+	}
+	c.subs[sub.id] = sub
+	go c.forward(sub)
+	return sub.id, nil
+}
+
+func (c *wsConn) unsubscribe(id string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	sub, ok := c.subs[id]
+	if !ok {
+		return ErrWSSubscriptionNotFound
+	}
+	delete(c.subs, id)
+	c.server.emitter.Unsubscribe(sub.subscriber)
+	close(sub.quitCh)
+	return nil
+}
+
+// forward the events of the subscription to the connection.
+func (c *wsConn) forward(sub *wsSubscription) {
+	for {
+		select {
+		case <-sub.quitCh:
+			return
+		case <-c.quitCh:
+			return
+		case e := <-sub.subscriber.EventChan():
+			if !sub.match(e.Data) {
+				continue
+			}
+			if !c.send(&WSResponse{Subscription: sub.id, Topic: e.Topic, Data: e.Data}) {
+				return
+			}
+		}
+	}
+}
+
+// send queue the message, the connection is evicted if its buffer is full.
+func (c *wsConn) send(resp *WSResponse) bool {
+	select {
+	case <-c.quitCh:
+		return false
+	default:
+	}
+	select {
+	case c.sendCh <- resp:
+		return true
+	default:
+		logging.VLog().WithFields(logrus.Fields{
+			"remote": c.conn.RemoteAddr(),
+			"buffer": c.server.bufferSize,
+		}).Warn("Evicted slow websocket client.")
+		c.close()
+		return false
+	}
+}
+
+func (c *wsConn) writeLoop() {
+	ticker := time.NewTicker(wsPingPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.quitCh:
+			return
+		case resp := <-c.sendCh:
+			c.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := c.conn.WriteJSON(resp); err != nil {
+				c.close()
+				return
+			}
+		case <-ticker.C:
+			if err := c.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait)); err != nil {
+				c.close()
+				return
+			}
+		}
+	}
+}
+
+func (c *wsConn) close() {
+	c.once.Do(func() {
+		close(c.quitCh)
+		c.conn.Close()
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		for id, sub := range c.subs {
+			c.server.emitter.Unsubscribe(sub.subscriber)
+			delete(c.subs, id)
+		}
+	})
+}
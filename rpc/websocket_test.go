@@ -0,0 +1,117 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package rpc
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/core/state"
+	"github.com/nebulasio/go-nebulas/neblet/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func mockWSServer(t *testing.T, bufferSize uint32) (*core.EventEmitter, *websocket.Conn, func()) {
+	emitter := core.NewEventEmitter(1024)
+	emitter.Start()
+	srv := httptest.NewServer(NewWSServer(emitter, &nebletpb.RPCConfig{WsBufferSize: bufferSize}))
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	assert.Nil(t, err)
+	return emitter, conn, func() {
+		conn.Close()
+		srv.Close()
+		emitter.Stop()
+	}
+}
+
+func wsCall(t *testing.T, conn *websocket.Conn, req *WSRequest) *WSResponse {
+	assert.Nil(t, conn.WriteJSON(req))
+	resp := new(WSResponse)
+	assert.Nil(t, conn.ReadJSON(resp))
+	assert.Equal(t, req.ID, resp.ID)
+	return resp
+}
+
+func TestWSServer_Subscribe(t *testing.T) {
+	emitter, conn, closeFn := mockWSServer(t, 0)
+	defer closeFn()
+
+	resp := wsCall(t, conn, &WSRequest{ID: 1, Method: "subscribe", Topic: "unknown"})
+	assert.Equal(t, ErrWSUnknownTopic.Error(), resp.Error)
+	resp = wsCall(t, conn, &WSRequest{ID: 2, Method: "subscribe", Topic: "newTailBlock", Address: "n1"})
+	assert.Equal(t, ErrWSFilterNotContractTopic.Error(), resp.Error)
+
+	blocks := wsCall(t, conn, &WSRequest{ID: 3, Method: "subscribe", Topic: "newTailBlock"})
+	assert.Empty(t, blocks.Error)
+	events := wsCall(t, conn, &WSRequest{ID: 4, Method: "subscribe", Topic: "contractEvent", EventTopic: "transfer"})
+	assert.Empty(t, events.Error)
+	time.Sleep(10 * time.Millisecond)
+
+	emitter.Trigger(&state.Event{Topic: core.TopicNewTailBlock, Data: `{"height":2}`})
+	resp = new(WSResponse)
+	assert.Nil(t, conn.ReadJSON(resp))
+	assert.Equal(t, blocks.Subscription, resp.Subscription)
+	assert.Equal(t, core.TopicNewTailBlock, resp.Topic)
+	assert.Equal(t, `{"height":2}`, resp.Data)
+
+	// the contract events are filtered by topic.
+	for _, topic := range []string{"approve", "transfer"} {
+		data, err := json.Marshal(&core.ContractEvent{Topic: topic})
+		assert.Nil(t, err)
+		emitter.Trigger(&state.Event{Topic: core.TopicNewContractEvent, Data: string(data)})
+	}
+	resp = new(WSResponse)
+	assert.Nil(t, conn.ReadJSON(resp))
+	assert.Equal(t, events.Subscription, resp.Subscription)
+	assert.Contains(t, resp.Data, `"topic":"transfer"`)
+
+	resp = wsCall(t, conn, &WSRequest{ID: 5, Method: "unsubscribe", Subscription: blocks.Subscription})
+	assert.Empty(t, resp.Error)
+	resp = wsCall(t, conn, &WSRequest{ID: 6, Method: "unsubscribe", Subscription: blocks.Subscription})
+	assert.Equal(t, ErrWSSubscriptionNotFound.Error(), resp.Error)
+}
+
+func TestWSServer_EvictSlowClient(t *testing.T) {
+	emitter, conn, closeFn := mockWSServer(t, 2)
+	defer closeFn()
+
+	resp := wsCall(t, conn, &WSRequest{ID: 1, Method: "subscribe", Topic: "pendingTransaction"})
+	assert.Empty(t, resp.Error)
+	time.Sleep(10 * time.Millisecond)
+
+	// the client reads nothing while the events pile up.
+	data := strings.Repeat("x", 1024*1024)
+	for i := 0; i < 64; i++ {
+		emitter.Trigger(&state.Event{Topic: core.TopicPendingTransaction, Data: data})
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var err error
+	for err == nil {
+		_, _, err = conn.ReadMessage()
+	}
+	_, ok := err.(*websocket.CloseError)
+	assert.True(t, ok || strings.Contains(err.Error(), "EOF") || strings.Contains(err.Error(), "reset"), err.Error())
+}
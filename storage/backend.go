@@ -0,0 +1,156 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package storage
+
+import "errors"
+
+// Storage backends selectable by the storage_backend chain config.
+const (
+	BackendRocksDB = "rocksdb"
+	BackendLevelDB = "leveldb"
+	BackendMemory  = "memory"
+)
+
+// Column families the chain data is split into by backends supporting them.
+const (
+	FamilyHeaders  = "headers"
+	FamilyBodies   = "bodies"
+	FamilyState    = "state"
+	FamilyReceipts = "receipts"
+)
+
+// ChainFamilies lists the column families apart from the default one.
+var ChainFamilies = []string{FamilyHeaders, FamilyBodies, FamilyState, FamilyReceipts}
+
+// Error types
+var (
+	ErrUnsupportedBackend = errors.New("unsupported storage backend")
+	ErrReadOnly           = errors.New("storage is opened read-only")
+)
+
+// FamilyStorage is implemented by storages able to hand out column family views.
+type FamilyStorage interface {
+	Storage
+
+	// Family returns the view of the column family, storages without column
+	// families return themselves.
+	Family(name string) Storage
+}
+
+// Backend is a key-value store the node can run on.
+type Backend interface {
+	Iterable
+	FamilyStorage
+
+	// Families returns the column families kept apart from the default one.
+	Families() []string
+
+	// Close releases the backend.
+	Close() error
+}
+
+// OpenBackend opens the backend of the given name at path, rocksdb if name is empty.
+func OpenBackend(name, path string) (Backend, error) {
+	switch name {
+	case "", BackendRocksDB:
+		return NewRocksStorage(path)
+	case BackendLevelDB:
+		return NewDiskStorage(path)
+	case BackendMemory:
+		return NewMemoryBackend(), nil
+	default:
+		return nil, ErrUnsupportedBackend
+	}
+}
+
+// OpenBackendReadOnly opens the existing backend of the given name at path
+// read-only, writes through it fail with ErrReadOnly.
+func OpenBackendReadOnly(name, path string) (Backend, error) {
+	var (
+		b   Backend
+		err error
+	)
+	switch name {
+	case "", BackendRocksDB:
+		b, err = NewRocksStorageReadOnly(path)
+	case BackendLevelDB:
+		b, err = NewDiskStorageReadOnly(path)
+	default:
+		// a memory backend is empty on opening, nothing to read.
+		return nil, ErrUnsupportedBackend
+	}
+	if err != nil {
+		return nil, err
+	}
+	return NewReadOnlyBackend(b), nil
+}
+
+// FamilyOf returns the column family view of s, or s itself if it has no column families.
+func FamilyOf(s Storage, name string) Storage {
+	if fs, ok := s.(FamilyStorage); ok {
+		return fs.Family(name)
+	}
+	return s
+}
+
+// CopyBackend copies every entry of src into dst, column family by column family,
+// and returns the number of entries copied.
+func CopyBackend(src, dst Backend) (int, error) {
+	dst.EnableBatch()
+	defer dst.DisableBatch()
+
+	copied := 0
+	copyFamily := func(from Iterable, to Storage) error {
+		return from.Iterate(nil, func(key, value []byte) error {
+			if err := to.Put(key, value); err != nil {
+				return err
+			}
+			copied++
+			if copied%ChainMigrateBatchSize == 0 {
+				return to.Flush()
+			}
+			return nil
+		})
+	}
+
+	if err := copyFamily(src, dst); err != nil {
+		return copied, err
+	}
+	if err := dst.Flush(); err != nil {
+		return copied, err
+	}
+
+	for _, name := range src.Families() {
+		from, ok := src.Family(name).(Iterable)
+		if !ok {
+			return copied, ErrNotIterable
+		}
+		to := dst.Family(name)
+		to.EnableBatch()
+		err := copyFamily(from, to)
+		if err == nil {
+			err = to.Flush()
+		}
+		to.DisableBatch()
+		if err != nil {
+			return copied, err
+		}
+	}
+	return copied, nil
+}
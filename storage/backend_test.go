@@ -0,0 +1,65 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package storage
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenBackend(t *testing.T) {
+	s, err := OpenBackend(BackendMemory, "")
+	assert.Nil(t, err)
+	assert.Nil(t, s.Close())
+
+	_, err = OpenBackend("sqlite", "")
+	assert.Equal(t, ErrUnsupportedBackend, err)
+}
+
+func TestCopyBackend(t *testing.T) {
+	path := "copy_backend.db"
+	defer os.RemoveAll(path)
+
+	src, err := NewMemoryStorage()
+	assert.Nil(t, err)
+	entries := map[string]string{
+		"blockchain_tail": "tail",
+		"blockchain_lib":  "lib",
+		"genesis":         "genesis",
+	}
+	for k, v := range entries {
+		assert.Nil(t, src.Put([]byte(k), []byte(v)))
+	}
+
+	dst, err := OpenBackend(BackendLevelDB, path)
+	assert.Nil(t, err)
+	defer dst.Close()
+
+	copied, err := CopyBackend(src, dst)
+	assert.Nil(t, err)
+	assert.Equal(t, len(entries), copied)
+	for k, v := range entries {
+		value, err := dst.Get([]byte(k))
+		assert.Nil(t, err)
+		assert.Equal(t, []byte(v), value)
+	}
+	assert.Equal(t, dst, FamilyOf(dst, FamilyState))
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/syndtr/goleveldb/leveldb/filter"
 	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
 )
 
 // DiskStorage the nodes in trie.
@@ -44,11 +45,24 @@ type batchOpt struct {
 
 // NewDiskStorage init a storage
 func NewDiskStorage(path string) (*DiskStorage, error) {
+	return newDiskStorage(path, false)
+}
+
+// NewDiskStorageReadOnly opens the existing storage at path read-only. leveldb
+// still locks the database, so it can't be opened beside a running node, only
+// on a copy of its data directory.
+func NewDiskStorageReadOnly(path string) (*DiskStorage, error) {
+	return newDiskStorage(path, true)
+}
+
+func newDiskStorage(path string, readOnly bool) (*DiskStorage, error) {
 	db, err := leveldb.OpenFile(path, &opt.Options{
 		OpenFilesCacheCapacity: 500,
 		BlockCacheCapacity:     8 * opt.MiB,
 		BlockSize:              4 * opt.MiB,
 		Filter:                 filter.NewBloomFilter(10),
+		ReadOnly:               readOnly,
+		ErrorIfMissing:         readOnly,
 	})
 
 	if err != nil {
@@ -160,3 +174,29 @@ func (storage *DiskStorage) DisableBatch() {
 	storage.batchOpts = make(map[string]*batchOpt)
 	storage.enableBatch = false
 }
+
+// Iterate calls fn for every entry whose key starts with prefix.
+func (storage *DiskStorage) Iterate(prefix []byte, fn func(key, value []byte) error) error {
+	iter := storage.db.NewIterator(util.BytesPrefix(prefix), nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		// the iterator reuses its buffers, hand out copies.
+		key := append([]byte{}, iter.Key()...)
+		value := append([]byte{}, iter.Value()...)
+		if err := fn(key, value); err != nil {
+			return err
+		}
+	}
+	return iter.Error()
+}
+
+// Family returns the storage itself, it has no column families.
+func (storage *DiskStorage) Family(name string) Storage {
+	return storage
+}
+
+// Families returns no column families.
+func (storage *DiskStorage) Families() []string {
+	return nil
+}
@@ -0,0 +1,240 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package storage
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Freezer file names in its directory
+const (
+	FreezerDataFile  = "freezer.dat"
+	FreezerIndexFile = "freezer.idx"
+)
+
+// freezerIndexSize is the size of an index entry: offset uint64 + length uint32.
+const freezerIndexSize = 12
+
+// Error types
+var (
+	ErrFreezerItemNotFound  = errors.New("freezer item not found")
+	ErrFreezerOutOfSequence = errors.New("freezer items must be appended in sequence")
+)
+
+// Freezer keeps immutable items numbered from 0 in an append-only data file,
+// with a fixed-size index entry per item. Items half-written by a crash are
+// truncated when the freezer is opened.
+type Freezer struct {
+	mu       sync.RWMutex
+	data     *os.File
+	index    *os.File
+	items    uint64
+	size     uint64
+	readOnly bool
+}
+
+// NewFreezer opens or creates the freezer in dir.
+func NewFreezer(dir string) (*Freezer, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return openFreezer(dir, os.O_RDWR|os.O_CREATE, false)
+}
+
+// NewFreezerReadOnly opens the existing freezer in dir read-only, items
+// half-written are ignored instead of truncated.
+func NewFreezerReadOnly(dir string) (*Freezer, error) {
+	return openFreezer(dir, os.O_RDONLY, true)
+}
+
+func openFreezer(dir string, flag int, readOnly bool) (*Freezer, error) {
+	data, err := os.OpenFile(filepath.Join(dir, FreezerDataFile), flag, 0600)
+	if err != nil {
+		return nil, err
+	}
+	index, err := os.OpenFile(filepath.Join(dir, FreezerIndexFile), flag, 0600)
+	if err != nil {
+		data.Close()
+		return nil, err
+	}
+
+	f := &Freezer{data: data, index: index, readOnly: readOnly}
+	if err := f.repair(); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return f, nil
+}
+
+// repair drops the index entries whose data is incomplete and the data
+// beyond the last complete entry, a read-only freezer only skips them.
+func (f *Freezer) repair() error {
+	stat, err := f.index.Stat()
+	if err != nil {
+		return err
+	}
+	items := uint64(stat.Size()) / freezerIndexSize
+
+	stat, err = f.data.Stat()
+	if err != nil {
+		return err
+	}
+	dataSize := uint64(stat.Size())
+
+	size := uint64(0)
+	for items > 0 {
+		offset, length, err := f.readIndex(items - 1)
+		if err != nil {
+			return err
+		}
+		if offset+uint64(length) <= dataSize {
+			size = offset + uint64(length)
+			break
+		}
+		items--
+	}
+
+	f.items = items
+	f.size = size
+	if f.readOnly {
+		return nil
+	}
+	if err := f.index.Truncate(int64(items * freezerIndexSize)); err != nil {
+		return err
+	}
+	return f.data.Truncate(int64(size))
+}
+
+func (f *Freezer) readIndex(number uint64) (uint64, uint32, error) {
+	buf := make([]byte, freezerIndexSize)
+	if _, err := f.index.ReadAt(buf, int64(number*freezerIndexSize)); err != nil {
+		return 0, 0, err
+	}
+	return binary.BigEndian.Uint64(buf[:8]), binary.BigEndian.Uint32(buf[8:]), nil
+}
+
+// Items returns the number of items, i.e. the number of the next item.
+func (f *Freezer) Items() uint64 {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.items
+}
+
+// Append adds the item of the number, which must be the next one.
+func (f *Freezer) Append(number uint64, item []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.readOnly {
+		return ErrReadOnly
+	}
+	if number != f.items {
+		return ErrFreezerOutOfSequence
+	}
+
+	// data first, an index entry is only written for complete data.
+	if _, err := f.data.WriteAt(item, int64(f.size)); err != nil {
+		return err
+	}
+	buf := make([]byte, freezerIndexSize)
+	binary.BigEndian.PutUint64(buf[:8], f.size)
+	binary.BigEndian.PutUint32(buf[8:], uint32(len(item)))
+	if _, err := f.index.WriteAt(buf, int64(f.items*freezerIndexSize)); err != nil {
+		return err
+	}
+
+	f.items++
+	f.size += uint64(len(item))
+	return nil
+}
+
+// Truncate drops the items from the number on, e.g. the items appended but
+// never recorded as frozen by the caller.
+func (f *Freezer) Truncate(items uint64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.readOnly {
+		return ErrReadOnly
+	}
+	if items >= f.items {
+		return nil
+	}
+	size, _, err := f.readIndex(items)
+	if err != nil {
+		return err
+	}
+	if err := f.index.Truncate(int64(items * freezerIndexSize)); err != nil {
+		return err
+	}
+	if err := f.data.Truncate(int64(size)); err != nil {
+		return err
+	}
+	f.items = items
+	f.size = size
+	return nil
+}
+
+// Retrieve returns the item of the number.
+func (f *Freezer) Retrieve(number uint64) ([]byte, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if number >= f.items {
+		return nil, ErrFreezerItemNotFound
+	}
+	offset, length, err := f.readIndex(number)
+	if err != nil {
+		return nil, err
+	}
+	item := make([]byte, length)
+	if _, err := f.data.ReadAt(item, int64(offset)); err != nil && err != io.EOF {
+		return nil, err
+	}
+	return item, nil
+}
+
+// Sync flushes the appended items to disk.
+func (f *Freezer) Sync() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.data.Sync(); err != nil {
+		return err
+	}
+	return f.index.Sync()
+}
+
+// Close the freezer files.
+func (f *Freezer) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	errData := f.data.Close()
+	errIndex := f.index.Close()
+	if errData != nil {
+		return errData
+	}
+	return errIndex
+}
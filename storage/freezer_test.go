@@ -0,0 +1,99 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFreezer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "freezer")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	f, err := NewFreezer(dir)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), f.Items())
+
+	assert.Nil(t, f.Append(0, []byte("block0")))
+	assert.Nil(t, f.Append(1, []byte("block1")))
+	assert.Equal(t, ErrFreezerOutOfSequence, f.Append(3, []byte("block3")))
+	assert.Nil(t, f.Sync())
+
+	item, err := f.Retrieve(1)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("block1"), item)
+	_, err = f.Retrieve(2)
+	assert.Equal(t, ErrFreezerItemNotFound, err)
+	assert.Nil(t, f.Close())
+
+	// a crash left half of an index entry and of an item behind.
+	index, err := os.OpenFile(filepath.Join(dir, FreezerIndexFile), os.O_APPEND|os.O_WRONLY, 0600)
+	assert.Nil(t, err)
+	_, err = index.Write([]byte{0, 0, 0})
+	assert.Nil(t, err)
+	index.Close()
+	data, err := os.OpenFile(filepath.Join(dir, FreezerDataFile), os.O_APPEND|os.O_WRONLY, 0600)
+	assert.Nil(t, err)
+	_, err = data.Write([]byte("blo"))
+	assert.Nil(t, err)
+	data.Close()
+
+	// a read-only freezer skips the half-written entry and leaves it be.
+	ro, err := NewFreezerReadOnly(dir)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), ro.Items())
+	assert.Equal(t, ErrReadOnly, ro.Append(2, []byte("block2")))
+	assert.Nil(t, ro.Close())
+	stat, err := os.Stat(filepath.Join(dir, FreezerIndexFile))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2*freezerIndexSize+3), stat.Size())
+
+	f, err = NewFreezer(dir)
+	assert.Nil(t, err)
+	defer f.Close()
+	assert.Equal(t, uint64(2), f.Items())
+	item, err = f.Retrieve(0)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("block0"), item)
+
+	assert.Nil(t, f.Append(2, []byte("block2")))
+	item, err = f.Retrieve(2)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("block2"), item)
+
+	// the truncated items are appended again.
+	assert.Nil(t, f.Truncate(3))
+	assert.Nil(t, f.Truncate(1))
+	assert.Equal(t, uint64(1), f.Items())
+	_, err = f.Retrieve(1)
+	assert.Equal(t, ErrFreezerItemNotFound, err)
+	assert.Nil(t, f.Append(1, []byte("item1")))
+	item, err = f.Retrieve(1)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("item1"), item)
+	stat, err = os.Stat(filepath.Join(dir, FreezerDataFile))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(len("block0")+len("item1")), stat.Size())
+}
@@ -0,0 +1,283 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+)
+
+// Error types
+var (
+	ErrJournalNotBegun   = errors.New("journal commit not begun")
+	ErrUnknownJournalLog = errors.New("journal record refers to an unknown storage")
+)
+
+// Journal makes the writes into a set of storages one all-or-nothing commit.
+// Between Begin and Commit the writes through its views are buffered. Commit
+// saves the previous values of the touched keys in a single undo record,
+// applies the writes and drops the record. Recover rolls back a commit
+// interrupted in between, e.g. by a crash. Commits are serialized, writes
+// of other goroutines through the views during a commit join it.
+type Journal struct {
+	commit sync.Mutex
+	mu     sync.Mutex
+	db     Storage
+	key    []byte
+	active bool
+	views  map[string]*journalView
+}
+
+// journalOp a buffered write.
+type journalOp struct {
+	key     []byte
+	value   []byte
+	deleted bool
+}
+
+// journalEntry the value of a key before the commit.
+type journalEntry struct {
+	Storage string `json:"storage"`
+	Key     []byte `json:"key"`
+	Value   []byte `json:"value"`
+	Exists  bool   `json:"exists"`
+}
+
+// NewJournal create a journal keeping its undo record at key in db.
+func NewJournal(db Storage, key []byte) *Journal {
+	return &Journal{
+		db:    db,
+		key:   key,
+		views: make(map[string]*journalView),
+	}
+}
+
+// Wrap returns the view of s whose writes join the commits of the journal,
+// name identifies s in the undo record and must stay the same across restarts.
+func (j *Journal) Wrap(name string, s Storage) Storage {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	view := &journalView{
+		journal: j,
+		storage: s,
+		name:    name,
+		ops:     make(map[string]*journalOp),
+	}
+	j.views[name] = view
+	return view
+}
+
+// Begin waits for the running commit and starts buffering the writes.
+func (j *Journal) Begin() {
+	j.commit.Lock()
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.active = true
+}
+
+// Commit applies the buffered writes all-or-nothing.
+func (j *Journal) Commit() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if !j.active {
+		return ErrJournalNotBegun
+	}
+	defer j.reset()
+
+	entries := []*journalEntry{}
+	for _, view := range j.views {
+		for _, op := range view.ops {
+			entry := &journalEntry{Storage: view.name, Key: op.key}
+			value, err := view.storage.Get(op.key)
+			if err == nil {
+				entry.Value = value
+				entry.Exists = true
+			} else if err != ErrKeyNotFound {
+				return err
+			}
+			entries = append(entries, entry)
+		}
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+
+	record, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	if err := j.db.Put(j.key, record); err != nil {
+		return err
+	}
+
+	for _, view := range j.views {
+		for _, op := range view.ops {
+			if op.deleted {
+				err = view.storage.Del(op.key)
+			} else {
+				err = view.storage.Put(op.key, op.value)
+			}
+			if err != nil {
+				// undo the writes already applied.
+				if _, e := j.recover(); e != nil {
+					return e
+				}
+				return err
+			}
+		}
+	}
+	return j.db.Del(j.key)
+}
+
+// Rollback drops the buffered writes.
+func (j *Journal) Rollback() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.active {
+		j.reset()
+	}
+}
+
+// Recover rolls back the commit interrupted before its undo record was
+// dropped, and reports whether there was one.
+func (j *Journal) Recover() (bool, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.recover()
+}
+
+// Pending reports whether the undo record of an interrupted commit is left,
+// without rolling it back.
+func (j *Journal) Pending() (bool, error) {
+	_, err := j.db.Get(j.key)
+	if err == ErrKeyNotFound {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (j *Journal) recover() (bool, error) {
+	record, err := j.db.Get(j.key)
+	if err == ErrKeyNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	entries := []*journalEntry{}
+	if err := json.Unmarshal(record, &entries); err != nil {
+		return false, err
+	}
+	for _, entry := range entries {
+		view, ok := j.views[entry.Storage]
+		if !ok {
+			return false, ErrUnknownJournalLog
+		}
+		if entry.Exists {
+			err = view.storage.Put(entry.Key, entry.Value)
+		} else {
+			err = view.storage.Del(entry.Key)
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+	return true, j.db.Del(j.key)
+}
+
+// reset drops the buffered writes and lets the next commit begin.
+func (j *Journal) reset() {
+	for _, view := range j.views {
+		view.ops = make(map[string]*journalOp)
+	}
+	j.active = false
+	j.commit.Unlock()
+}
+
+// journalView passes the writes through to its storage outside of commits
+// and buffers them in the journal inside, reads see the buffered writes.
+type journalView struct {
+	journal *Journal
+	storage Storage
+	name    string
+	ops     map[string]*journalOp
+}
+
+// Get return the value to the key in Storage.
+func (view *journalView) Get(key []byte) ([]byte, error) {
+	view.journal.mu.Lock()
+	if view.journal.active {
+		if op, ok := view.ops[byteutils.Hex(key)]; ok {
+			view.journal.mu.Unlock()
+			if op.deleted {
+				return nil, ErrKeyNotFound
+			}
+			return op.value, nil
+		}
+	}
+	view.journal.mu.Unlock()
+	return view.storage.Get(key)
+}
+
+// Put put the key-value entry to Storage.
+func (view *journalView) Put(key []byte, value []byte) error {
+	view.journal.mu.Lock()
+	if view.journal.active {
+		view.ops[byteutils.Hex(key)] = &journalOp{key: key, value: value}
+		view.journal.mu.Unlock()
+		return nil
+	}
+	view.journal.mu.Unlock()
+	return view.storage.Put(key, value)
+}
+
+// Del delete the key entry in Storage.
+func (view *journalView) Del(key []byte) error {
+	view.journal.mu.Lock()
+	if view.journal.active {
+		view.ops[byteutils.Hex(key)] = &journalOp{key: key, deleted: true}
+		view.journal.mu.Unlock()
+		return nil
+	}
+	view.journal.mu.Unlock()
+	return view.storage.Del(key)
+}
+
+// EnableBatch enable batch write.
+func (view *journalView) EnableBatch() {
+	view.storage.EnableBatch()
+}
+
+// DisableBatch disable batch write.
+func (view *journalView) DisableBatch() {
+	view.storage.DisableBatch()
+}
+
+// Flush write and flush pending batch write.
+func (view *journalView) Flush() error {
+	return view.storage.Flush()
+}
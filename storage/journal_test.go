@@ -0,0 +1,136 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errMockPut = errors.New("mock put failure")
+
+// failingStorage fails the puts of a key.
+type failingStorage struct {
+	*MemoryStorage
+	key string
+}
+
+func (s *failingStorage) Put(key []byte, value []byte) error {
+	if string(key) == s.key {
+		return errMockPut
+	}
+	return s.MemoryStorage.Put(key, value)
+}
+
+func TestJournalCommit(t *testing.T) {
+	db, _ := NewMemoryStorage()
+	headers, _ := NewMemoryStorage()
+	j := NewJournal(db, []byte("journal"))
+	view := j.Wrap("headers", headers)
+
+	assert.Nil(t, view.Put([]byte("a"), []byte("1")))
+	v, err := headers.Get([]byte("a"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("1"), v)
+
+	j.Begin()
+	assert.Nil(t, view.Put([]byte("a"), []byte("2")))
+	assert.Nil(t, view.Put([]byte("b"), []byte("2")))
+	v, err = view.Get([]byte("a"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("2"), v)
+	v, _ = headers.Get([]byte("a"))
+	assert.Equal(t, []byte("1"), v)
+	assert.Nil(t, j.Commit())
+
+	v, _ = headers.Get([]byte("a"))
+	assert.Equal(t, []byte("2"), v)
+	v, _ = headers.Get([]byte("b"))
+	assert.Equal(t, []byte("2"), v)
+	_, err = db.Get([]byte("journal"))
+	assert.Equal(t, ErrKeyNotFound, err)
+
+	j.Begin()
+	assert.Nil(t, view.Del([]byte("a")))
+	_, err = view.Get([]byte("a"))
+	assert.Equal(t, ErrKeyNotFound, err)
+	j.Rollback()
+	v, _ = view.Get([]byte("a"))
+	assert.Equal(t, []byte("2"), v)
+
+	assert.Equal(t, ErrJournalNotBegun, j.Commit())
+}
+
+func TestJournalCommitFailure(t *testing.T) {
+	db, _ := NewMemoryStorage()
+	mem, _ := NewMemoryStorage()
+	headers := &failingStorage{MemoryStorage: mem, key: "b"}
+	j := NewJournal(db, []byte("journal"))
+	view := j.Wrap("headers", headers)
+	assert.Nil(t, view.Put([]byte("a"), []byte("1")))
+
+	j.Begin()
+	assert.Nil(t, view.Put([]byte("a"), []byte("2")))
+	assert.Nil(t, view.Put([]byte("b"), []byte("2")))
+	assert.Equal(t, errMockPut, j.Commit())
+
+	// nothing of the failed commit is left.
+	v, _ := headers.Get([]byte("a"))
+	assert.Equal(t, []byte("1"), v)
+	_, err := db.Get([]byte("journal"))
+	assert.Equal(t, ErrKeyNotFound, err)
+}
+
+func TestJournalRecover(t *testing.T) {
+	db, _ := NewMemoryStorage()
+	headers, _ := NewMemoryStorage()
+	j := NewJournal(db, []byte("journal"))
+	j.Wrap("headers", headers)
+
+	recovered, err := j.Recover()
+	assert.Nil(t, err)
+	assert.False(t, recovered)
+
+	// a crash left the undo record and half of the writes behind.
+	assert.Nil(t, headers.Put([]byte("a"), []byte("1")))
+	record, _ := json.Marshal([]*journalEntry{
+		{Storage: "headers", Key: []byte("a"), Value: []byte("0"), Exists: true},
+		{Storage: "headers", Key: []byte("b")},
+	})
+	assert.Nil(t, db.Put([]byte("journal"), record))
+	assert.Nil(t, headers.Put([]byte("b"), []byte("1")))
+
+	recovered, err = j.Recover()
+	assert.Nil(t, err)
+	assert.True(t, recovered)
+	v, _ := headers.Get([]byte("a"))
+	assert.Equal(t, []byte("0"), v)
+	_, err = headers.Get([]byte("b"))
+	assert.Equal(t, ErrKeyNotFound, err)
+	_, err = db.Get([]byte("journal"))
+	assert.Equal(t, ErrKeyNotFound, err)
+
+	record, _ = json.Marshal([]*journalEntry{{Storage: "bodies", Key: []byte("a")}})
+	assert.Nil(t, db.Put([]byte("journal"), record))
+	_, err = j.Recover()
+	assert.Equal(t, ErrUnknownJournalLog, err)
+}
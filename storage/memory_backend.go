@@ -0,0 +1,278 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+)
+
+// Error types
+var (
+	ErrInvalidSnapshot = errors.New("invalid memory backend snapshot")
+)
+
+// MemoryBackend is a Backend kept in memory for tests and simulations. It
+// behaves as the rocksdb backend does: the column families are apart from
+// the default one and fall back to it on reads, batched writes are only
+// visible once flushed, and entries are iterated in key order. The whole
+// content can be snapshotted and rolled back.
+type MemoryBackend struct {
+	*memoryView
+
+	mu        sync.RWMutex
+	data      map[string]map[string][]byte
+	snapshots []map[string]map[string][]byte
+	families  map[string]*memoryView
+}
+
+// memoryView is the view of the default or a column family of a MemoryBackend.
+type memoryView struct {
+	backend     *MemoryBackend
+	family      string
+	enableBatch bool
+	mutex       sync.Mutex
+	batchOpts   map[string]*batchOpt
+}
+
+// NewMemoryBackend create an empty memory backend.
+func NewMemoryBackend() *MemoryBackend {
+	b := &MemoryBackend{
+		data:     make(map[string]map[string][]byte),
+		families: make(map[string]*memoryView),
+	}
+	b.memoryView = b.newView("")
+	for _, name := range ChainFamilies {
+		b.families[name] = b.newView(name)
+	}
+	return b
+}
+
+func (b *MemoryBackend) newView(family string) *memoryView {
+	b.data[family] = make(map[string][]byte)
+	return &memoryView{
+		backend:   b,
+		family:    family,
+		batchOpts: make(map[string]*batchOpt),
+	}
+}
+
+// Family returns the view of the column family, the backend itself for unknown names.
+func (b *MemoryBackend) Family(name string) Storage {
+	if family, ok := b.families[name]; ok {
+		return family
+	}
+	return b
+}
+
+// Families returns the column families kept apart from the default one.
+func (b *MemoryBackend) Families() []string {
+	return ChainFamilies
+}
+
+// Close does nothing, the content lives as long as the backend.
+func (b *MemoryBackend) Close() error {
+	return nil
+}
+
+// Snapshot saves the content of the backend and returns the id to roll back to it.
+func (b *MemoryBackend) Snapshot() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.snapshots = append(b.snapshots, copyMemoryData(b.data))
+	return len(b.snapshots) - 1
+}
+
+// Rollback restores the content saved by the snapshot id, which is dropped
+// with the snapshots taken after it. Writes pending in batches are discarded.
+func (b *MemoryBackend) Rollback(id int) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if id < 0 || id >= len(b.snapshots) {
+		return ErrInvalidSnapshot
+	}
+	b.data = b.snapshots[id]
+	b.snapshots = b.snapshots[:id]
+
+	for _, view := range append([]*memoryView{b.memoryView}, b.familyViews()...) {
+		view.mutex.Lock()
+		view.batchOpts = make(map[string]*batchOpt)
+		view.mutex.Unlock()
+	}
+	return nil
+}
+
+func (b *MemoryBackend) familyViews() []*memoryView {
+	views := make([]*memoryView, 0, len(b.families))
+	for _, view := range b.families {
+		views = append(views, view)
+	}
+	return views
+}
+
+func copyMemoryData(data map[string]map[string][]byte) map[string]map[string][]byte {
+	copied := make(map[string]map[string][]byte, len(data))
+	for family, entries := range data {
+		m := make(map[string][]byte, len(entries))
+		for k, v := range entries {
+			m[k] = v
+		}
+		copied[family] = m
+	}
+	return copied
+}
+
+// Get return value to the key in the view
+func (view *memoryView) Get(key []byte) ([]byte, error) {
+	b := view.backend
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if value, ok := b.data[view.family][string(key)]; ok {
+		return append([]byte{}, value...), nil
+	}
+	if value, ok := b.data[""][string(key)]; ok {
+		return append([]byte{}, value...), nil
+	}
+	return nil, ErrKeyNotFound
+}
+
+// Put put the key-value entry to the view
+func (view *memoryView) Put(key []byte, value []byte) error {
+	if view.enableBatch {
+		view.mutex.Lock()
+		defer view.mutex.Unlock()
+
+		view.batchOpts[byteutils.Hex(key)] = &batchOpt{
+			key:     key,
+			value:   value,
+			deleted: false,
+		}
+
+		return nil
+	}
+
+	b := view.backend
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	view.put(key, value)
+	return nil
+}
+
+// Del delete the key in the view.
+func (view *memoryView) Del(key []byte) error {
+	if view.enableBatch {
+		view.mutex.Lock()
+		defer view.mutex.Unlock()
+
+		view.batchOpts[byteutils.Hex(key)] = &batchOpt{
+			key:     key,
+			deleted: true,
+		}
+
+		return nil
+	}
+
+	b := view.backend
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	view.del(key)
+	return nil
+}
+
+// put and del are called with the lock of the backend held.
+func (view *memoryView) put(key []byte, value []byte) {
+	view.backend.data[view.family][string(key)] = append([]byte{}, value...)
+}
+
+func (view *memoryView) del(key []byte) {
+	// keys of a family may be left in the default one, drop them from there too.
+	delete(view.backend.data[view.family], string(key))
+	delete(view.backend.data[""], string(key))
+}
+
+// EnableBatch enable batch write.
+func (view *memoryView) EnableBatch() {
+	view.enableBatch = true
+}
+
+// Flush write and flush pending batch write.
+func (view *memoryView) Flush() error {
+	view.mutex.Lock()
+	defer view.mutex.Unlock()
+
+	if !view.enableBatch {
+		return nil
+	}
+
+	b := view.backend
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, opt := range view.batchOpts {
+		if opt.deleted {
+			view.del(opt.key)
+		} else {
+			view.put(opt.key, opt.value)
+		}
+	}
+	view.batchOpts = make(map[string]*batchOpt)
+	return nil
+}
+
+// DisableBatch disable batch write.
+func (view *memoryView) DisableBatch() {
+	view.mutex.Lock()
+	defer view.mutex.Unlock()
+
+	view.batchOpts = make(map[string]*batchOpt)
+	view.enableBatch = false
+}
+
+// Iterate calls fn for every entry of the view whose key starts with prefix, in key order.
+func (view *memoryView) Iterate(prefix []byte, fn func(key, value []byte) error) error {
+	b := view.backend
+	b.mu.RLock()
+	entries := b.data[view.family]
+	keys := make([]string, 0, len(entries))
+	for k := range entries {
+		if bytes.HasPrefix([]byte(k), prefix) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	values := make([][]byte, len(keys))
+	for i, k := range keys {
+		values[i] = entries[k]
+	}
+	b.mu.RUnlock()
+
+	// fn may write into the backend, it is called without the lock.
+	for i, k := range keys {
+		if err := fn([]byte(k), values[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryBackend(t *testing.T) {
+	b := NewMemoryBackend()
+	bodies := b.Family(FamilyBodies)
+
+	// families fall back to the default one and drop its keys on delete.
+	assert.Nil(t, b.Put([]byte("legacy"), []byte("block")))
+	value, err := bodies.Get([]byte("legacy"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("block"), value)
+	assert.Nil(t, bodies.Del([]byte("legacy")))
+	_, err = b.Get([]byte("legacy"))
+	assert.Equal(t, ErrKeyNotFound, err)
+
+	assert.Nil(t, bodies.Put([]byte("b"), []byte("2")))
+	assert.Nil(t, bodies.Put([]byte("a"), []byte("1")))
+	_, err = b.Get([]byte("a"))
+	assert.Equal(t, ErrKeyNotFound, err)
+
+	// batched writes are visible once flushed.
+	bodies.EnableBatch()
+	assert.Nil(t, bodies.Put([]byte("c"), []byte("3")))
+	_, err = bodies.Get([]byte("c"))
+	assert.Equal(t, ErrKeyNotFound, err)
+	assert.Nil(t, bodies.Flush())
+	bodies.DisableBatch()
+	value, err = bodies.Get([]byte("c"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("3"), value)
+
+	keys := []string{}
+	assert.Nil(t, bodies.(Iterable).Iterate(nil, func(key, value []byte) error {
+		keys = append(keys, string(key))
+		return nil
+	}))
+	assert.Equal(t, []string{"a", "b", "c"}, keys)
+}
+
+func TestMemoryBackendSnapshot(t *testing.T) {
+	b := NewMemoryBackend()
+	state := b.Family(FamilyState)
+	assert.Nil(t, state.Put([]byte("root"), []byte("1")))
+
+	first := b.Snapshot()
+	assert.Nil(t, state.Put([]byte("root"), []byte("2")))
+	assert.Nil(t, b.Put([]byte("tail"), []byte("2")))
+
+	second := b.Snapshot()
+	assert.Nil(t, state.Del([]byte("root")))
+
+	assert.Nil(t, b.Rollback(second))
+	value, err := state.Get([]byte("root"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("2"), value)
+
+	assert.Nil(t, b.Rollback(first))
+	value, err = state.Get([]byte("root"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("1"), value)
+	_, err = b.Get([]byte("tail"))
+	assert.Equal(t, ErrKeyNotFound, err)
+
+	// the snapshots rolled back to are dropped.
+	assert.Equal(t, ErrInvalidSnapshot, b.Rollback(second))
+	assert.Equal(t, ErrInvalidSnapshot, b.Rollback(first))
+}
@@ -19,6 +19,7 @@
 package storage
 
 import (
+	"bytes"
 	"sync"
 
 	"github.com/nebulasio/go-nebulas/util/byteutils"
@@ -77,3 +78,31 @@ func (db *MemoryStorage) Flush() error {
 // DisableBatch disable batch write.
 func (db *MemoryStorage) DisableBatch() {
 }
+
+// Iterate calls fn for every entry whose key starts with prefix.
+func (db *MemoryStorage) Iterate(prefix []byte, fn func(key, value []byte) error) error {
+	var err error
+	db.data.Range(func(k, v interface{}) bool {
+		key, e := byteutils.FromHex(k.(string))
+		if e != nil {
+			err = e
+			return false
+		}
+		if !bytes.HasPrefix(key, prefix) {
+			return true
+		}
+		err = fn(key, v.([]byte))
+		return err == nil
+	})
+	return err
+}
+
+// Family returns the storage itself, it has no column families.
+func (db *MemoryStorage) Family(name string) Storage {
+	return db
+}
+
+// Families returns no column families.
+func (db *MemoryStorage) Families() []string {
+	return nil
+}
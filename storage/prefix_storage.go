@@ -0,0 +1,167 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// ChainKeyPrefix leads every key written into a chain namespace.
+var ChainKeyPrefix = []byte("chain_")
+
+// ChainMigrateBatchSize is the number of keys moved between two flushes in MigrateToChainStorage.
+const ChainMigrateBatchSize = 10000
+
+// PrefixStorage scopes a shared storage to the keys starting with a fixed prefix.
+type PrefixStorage struct {
+	db     Storage
+	prefix []byte
+}
+
+// NewPrefixStorage returns a storage whose keys are all stored with the given prefix in db.
+func NewPrefixStorage(db Storage, prefix []byte) *PrefixStorage {
+	return &PrefixStorage{
+		db:     db,
+		prefix: append([]byte{}, prefix...),
+	}
+}
+
+// ChainPrefix returns the key prefix of the chain namespace of chainID.
+func ChainPrefix(chainID uint32) []byte {
+	prefix := make([]byte, len(ChainKeyPrefix)+4)
+	copy(prefix, ChainKeyPrefix)
+	binary.BigEndian.PutUint32(prefix[len(ChainKeyPrefix):], chainID)
+	return prefix
+}
+
+// NewChainStorage returns the namespace of chainID in db, so that several chains can share one database.
+func NewChainStorage(db Storage, chainID uint32) *PrefixStorage {
+	return NewPrefixStorage(db, ChainPrefix(chainID))
+}
+
+// Prefix returns the key prefix of the storage.
+func (s *PrefixStorage) Prefix() []byte {
+	return s.prefix
+}
+
+// Backend returns the underlying storage.
+func (s *PrefixStorage) Backend() Storage {
+	return s.db
+}
+
+func (s *PrefixStorage) key(key []byte) []byte {
+	k := make([]byte, len(s.prefix)+len(key))
+	copy(k, s.prefix)
+	copy(k[len(s.prefix):], key)
+	return k
+}
+
+// Get return the value to the key in Storage.
+func (s *PrefixStorage) Get(key []byte) ([]byte, error) {
+	return s.db.Get(s.key(key))
+}
+
+// Put put the key-value entry to Storage.
+func (s *PrefixStorage) Put(key []byte, value []byte) error {
+	return s.db.Put(s.key(key), value)
+}
+
+// Del delete the key entry in Storage.
+func (s *PrefixStorage) Del(key []byte) error {
+	return s.db.Del(s.key(key))
+}
+
+// EnableBatch enable batch write.
+func (s *PrefixStorage) EnableBatch() {
+	s.db.EnableBatch()
+}
+
+// DisableBatch disable batch write.
+func (s *PrefixStorage) DisableBatch() {
+	s.db.DisableBatch()
+}
+
+// Flush write and flush pending batch write.
+func (s *PrefixStorage) Flush() error {
+	return s.db.Flush()
+}
+
+// Family returns the namespace in the column family of the underlying storage.
+func (s *PrefixStorage) Family(name string) Storage {
+	return NewPrefixStorage(FamilyOf(s.db, name), s.prefix)
+}
+
+// Iterate calls fn for every entry of the namespace whose key starts with prefix,
+// keys are handed out without the namespace prefix.
+func (s *PrefixStorage) Iterate(prefix []byte, fn func(key, value []byte) error) error {
+	db, ok := s.db.(Iterable)
+	if !ok {
+		return ErrNotIterable
+	}
+	return db.Iterate(s.key(prefix), func(key, value []byte) error {
+		return fn(key[len(s.prefix):], value)
+	})
+}
+
+// HasLegacyKey reports whether key is still stored outside of any chain namespace in db.
+func HasLegacyKey(db Storage, key []byte) (bool, error) {
+	if bytes.HasPrefix(key, ChainKeyPrefix) {
+		return false, nil
+	}
+	_, err := db.Get(key)
+	if err == ErrKeyNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// MigrateToChainStorage moves every key written before chain namespaces existed
+// into the namespace of chainID, and returns the number of keys moved.
+func MigrateToChainStorage(db Iterable, chainID uint32) (int, error) {
+	dst := NewChainStorage(db, chainID)
+
+	db.EnableBatch()
+	defer db.DisableBatch()
+
+	moved := 0
+	err := db.Iterate(nil, func(key, value []byte) error {
+		if bytes.HasPrefix(key, ChainKeyPrefix) {
+			return nil
+		}
+		if err := dst.Put(key, value); err != nil {
+			return err
+		}
+		if err := db.Del(key); err != nil {
+			return err
+		}
+		moved++
+		if moved%ChainMigrateBatchSize == 0 {
+			return db.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return moved, err
+	}
+	return moved, db.Flush()
+}
@@ -0,0 +1,102 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChainStorage(t *testing.T) {
+	db, err := NewMemoryStorage()
+	assert.Nil(t, err)
+
+	s1 := NewChainStorage(db, 1)
+	s2 := NewChainStorage(db, 1001)
+
+	key := []byte("blockchain_tail")
+	assert.Nil(t, s1.Put(key, []byte("mainnet")))
+	assert.Nil(t, s2.Put(key, []byte("testnet")))
+
+	v, err := s1.Get(key)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("mainnet"), v)
+	v, err = s2.Get(key)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("testnet"), v)
+
+	_, err = db.Get(key)
+	assert.Equal(t, ErrKeyNotFound, err)
+
+	assert.Nil(t, s1.Del(key))
+	_, err = s1.Get(key)
+	assert.Equal(t, ErrKeyNotFound, err)
+	_, err = s2.Get(key)
+	assert.Nil(t, err)
+
+	var keys [][]byte
+	assert.Nil(t, s2.Iterate(nil, func(k, v []byte) error {
+		keys = append(keys, k)
+		return nil
+	}))
+	assert.Equal(t, [][]byte{key}, keys)
+}
+
+func TestMigrateToChainStorage(t *testing.T) {
+	db, err := NewMemoryStorage()
+	assert.Nil(t, err)
+
+	legacy := map[string]string{
+		"blockchain_tail": "tail",
+		"blockchain_lib":  "lib",
+		"genesis":         "genesis",
+	}
+	for k, v := range legacy {
+		assert.Nil(t, db.Put([]byte(k), []byte(v)))
+	}
+	other := NewChainStorage(db, 1001)
+	assert.Nil(t, other.Put([]byte("blockchain_tail"), []byte("other")))
+
+	found, err := HasLegacyKey(db, []byte("blockchain_tail"))
+	assert.Nil(t, err)
+	assert.True(t, found)
+
+	moved, err := MigrateToChainStorage(db, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, len(legacy), moved)
+
+	found, err = HasLegacyKey(db, []byte("blockchain_tail"))
+	assert.Nil(t, err)
+	assert.False(t, found)
+
+	s := NewChainStorage(db, 1)
+	for k, v := range legacy {
+		value, err := s.Get([]byte(k))
+		assert.Nil(t, err)
+		assert.Equal(t, []byte(v), value)
+	}
+	value, err := other.Get([]byte("blockchain_tail"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("other"), value)
+
+	moved, err = MigrateToChainStorage(db, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, moved)
+}
@@ -0,0 +1,82 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package storage
+
+// readOnlyStorage refuses the writes into the storage it wraps.
+type readOnlyStorage struct {
+	Storage
+}
+
+// Put refuses the write.
+func (s *readOnlyStorage) Put(key []byte, value []byte) error {
+	return ErrReadOnly
+}
+
+// Del refuses the write.
+func (s *readOnlyStorage) Del(key []byte) error {
+	return ErrReadOnly
+}
+
+// Flush has nothing to write, every write is refused.
+func (s *readOnlyStorage) Flush() error {
+	return nil
+}
+
+// Iterate calls fn for every entry whose key starts with prefix.
+func (s *readOnlyStorage) Iterate(prefix []byte, fn func(key, value []byte) error) error {
+	iterable, ok := s.Storage.(Iterable)
+	if !ok {
+		return ErrNotIterable
+	}
+	return iterable.Iterate(prefix, fn)
+}
+
+// readOnlyBackend refuses the writes into the backend it wraps and its column families.
+type readOnlyBackend struct {
+	Backend
+}
+
+// NewReadOnlyBackend wraps b so that writes into it and its column families fail with ErrReadOnly.
+func NewReadOnlyBackend(b Backend) Backend {
+	return &readOnlyBackend{b}
+}
+
+// Put refuses the write.
+func (b *readOnlyBackend) Put(key []byte, value []byte) error {
+	return ErrReadOnly
+}
+
+// Del refuses the write.
+func (b *readOnlyBackend) Del(key []byte) error {
+	return ErrReadOnly
+}
+
+// Flush has nothing to write, every write is refused.
+func (b *readOnlyBackend) Flush() error {
+	return nil
+}
+
+// Family returns the read-only view of the column family.
+func (b *readOnlyBackend) Family(name string) Storage {
+	family := b.Backend.Family(name)
+	if family == b.Backend {
+		return b
+	}
+	return &readOnlyStorage{family}
+}
@@ -0,0 +1,66 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package storage
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenBackendReadOnly(t *testing.T) {
+	path := "read_only_rocks.db"
+	defer os.RemoveAll(path)
+
+	// the node keeps the database open while it is read.
+	db, err := OpenBackend(BackendRocksDB, path)
+	assert.Nil(t, err)
+	defer db.Close()
+	assert.Nil(t, db.Put([]byte("blockchain_tail"), []byte("tail")))
+	assert.Nil(t, db.Family(FamilyBodies).Put([]byte("block"), []byte("body")))
+
+	ro, err := OpenBackendReadOnly(BackendRocksDB, path)
+	assert.Nil(t, err)
+	defer ro.Close()
+
+	value, err := ro.Get([]byte("blockchain_tail"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("tail"), value)
+	value, err = ro.Family(FamilyBodies).Get([]byte("block"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("body"), value)
+
+	assert.Equal(t, ErrReadOnly, ro.Put([]byte("blockchain_tail"), []byte("new")))
+	assert.Equal(t, ErrReadOnly, ro.Del([]byte("blockchain_tail")))
+	assert.Equal(t, ErrReadOnly, ro.Family(FamilyBodies).Put([]byte("block"), []byte("new")))
+	assert.Equal(t, ErrReadOnly, NewChainStorage(ro, 1).Put([]byte("key"), []byte("value")))
+
+	keys := 0
+	assert.Nil(t, ro.Family(FamilyBodies).(Iterable).Iterate(nil, func(key, value []byte) error {
+		keys++
+		return nil
+	}))
+	assert.Equal(t, 1, keys)
+
+	_, err = OpenBackendReadOnly(BackendLevelDB, "missing.db")
+	assert.NotNil(t, err)
+	_, err = OpenBackendReadOnly(BackendMemory, "")
+	assert.Equal(t, ErrUnsupportedBackend, err)
+}
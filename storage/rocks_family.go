@@ -0,0 +1,160 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package storage
+
+import (
+	"sync"
+
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/tecbot/gorocksdb"
+)
+
+// rocksFamily is the view of a column family of a RocksStorage. Keys written
+// before the column families existed live in the default family, reads fall
+// back to it and deletes drop them from there too.
+type rocksFamily struct {
+	storage     *RocksStorage
+	cf          *gorocksdb.ColumnFamilyHandle
+	enableBatch bool
+	mutex       sync.Mutex
+	batchOpts   map[string]*batchOpt
+}
+
+func newRocksFamily(storage *RocksStorage, cf *gorocksdb.ColumnFamilyHandle) *rocksFamily {
+	return &rocksFamily{
+		storage:   storage,
+		cf:        cf,
+		batchOpts: make(map[string]*batchOpt),
+	}
+}
+
+// Get return value to the key in the column family
+func (family *rocksFamily) Get(key []byte) ([]byte, error) {
+	slice, err := family.storage.db.GetCF(family.storage.ro, family.cf, key)
+	if err != nil {
+		return nil, err
+	}
+	defer slice.Free()
+
+	if slice.Data() == nil {
+		return family.storage.Get(key)
+	}
+	return append([]byte{}, slice.Data()...), nil
+}
+
+// Put put the key-value entry to the column family
+func (family *rocksFamily) Put(key []byte, value []byte) error {
+	if family.enableBatch {
+		family.mutex.Lock()
+		defer family.mutex.Unlock()
+
+		family.batchOpts[byteutils.Hex(key)] = &batchOpt{
+			key:     key,
+			value:   value,
+			deleted: false,
+		}
+
+		return nil
+	}
+
+	return family.storage.db.PutCF(family.storage.wo, family.cf, key, value)
+}
+
+// Del delete the key in the column family.
+func (family *rocksFamily) Del(key []byte) error {
+	if family.enableBatch {
+		family.mutex.Lock()
+		defer family.mutex.Unlock()
+
+		family.batchOpts[byteutils.Hex(key)] = &batchOpt{
+			key:     key,
+			deleted: true,
+		}
+
+		return nil
+	}
+
+	wb := gorocksdb.NewWriteBatch()
+	defer wb.Destroy()
+
+	wb.DeleteCF(family.cf, key)
+	wb.Delete(key)
+	return family.storage.db.Write(family.storage.wo, wb)
+}
+
+// EnableBatch enable batch write.
+func (family *rocksFamily) EnableBatch() {
+	family.enableBatch = true
+}
+
+// Flush write and flush pending batch write.
+func (family *rocksFamily) Flush() error {
+	family.mutex.Lock()
+	defer family.mutex.Unlock()
+
+	if !family.enableBatch {
+		return nil
+	}
+
+	wb := gorocksdb.NewWriteBatch()
+	defer wb.Destroy()
+
+	for _, opt := range family.batchOpts {
+		if opt.deleted {
+			wb.DeleteCF(family.cf, opt.key)
+			wb.Delete(opt.key)
+		} else {
+			wb.PutCF(family.cf, opt.key, opt.value)
+		}
+	}
+	family.batchOpts = make(map[string]*batchOpt)
+
+	return family.storage.db.Write(family.storage.wo, wb)
+}
+
+// DisableBatch disable batch write.
+func (family *rocksFamily) DisableBatch() {
+	family.mutex.Lock()
+	defer family.mutex.Unlock()
+
+	family.batchOpts = make(map[string]*batchOpt)
+	family.enableBatch = false
+}
+
+// Iterate calls fn for every entry of the column family whose key starts with prefix.
+func (family *rocksFamily) Iterate(prefix []byte, fn func(key, value []byte) error) error {
+	ro := gorocksdb.NewDefaultReadOptions()
+	ro.SetFillCache(false)
+	defer ro.Destroy()
+
+	iter := family.storage.db.NewIteratorCF(ro, family.cf)
+	defer iter.Close()
+
+	for iter.Seek(prefix); iter.ValidForPrefix(prefix); iter.Next() {
+		key := iter.Key()
+		value := iter.Value()
+		err := fn(append([]byte{}, key.Data()...), append([]byte{}, value.Data()...))
+		key.Free()
+		value.Free()
+		if err != nil {
+			return err
+		}
+	}
+	return iter.Err()
+}
@@ -21,10 +21,23 @@ type RocksStorage struct {
 	wo *gorocksdb.WriteOptions
 
 	cache *gorocksdb.Cache
+
+	families map[string]*rocksFamily
 }
 
 // NewRocksStorage init a storage
 func NewRocksStorage(path string) (*RocksStorage, error) {
+	return newRocksStorage(path, false)
+}
+
+// NewRocksStorageReadOnly opens the storage at path read-only, without taking
+// the lock of the database, so it can be opened beside a running node. It
+// sees the data as of opening.
+func NewRocksStorageReadOnly(path string) (*RocksStorage, error) {
+	return newRocksStorage(path, true)
+}
+
+func newRocksStorage(path string, readOnly bool) (*RocksStorage, error) {
 
 	filter := gorocksdb.NewBloomFilter(10)
 	bbto := gorocksdb.NewDefaultBlockBasedTableOptions()
@@ -34,12 +47,47 @@ func NewRocksStorage(path string) (*RocksStorage, error) {
 	bbto.SetBlockCache(cache)
 	opts := gorocksdb.NewDefaultOptions()
 	opts.SetBlockBasedTableFactory(bbto)
-	opts.SetCreateIfMissing(true)
+	opts.SetCreateIfMissing(!readOnly)
 	opts.SetMaxOpenFiles(500)
 	opts.SetWriteBufferSize(64 * opt.MiB) //Default: 4MB
 	opts.IncreaseParallelism(4)           //flush and compaction thread
 
-	db, err := gorocksdb.OpenDb(opts, path)
+	opts.SetCreateIfMissingColumnFamilies(!readOnly)
+
+	families := ChainFamilies
+	if readOnly {
+		// families can't be created read-only, databases written before they
+		// existed are served by the default family alone.
+		existing, err := gorocksdb.ListColumnFamilies(opts, path)
+		if err != nil {
+			return nil, err
+		}
+		families = []string{}
+		for _, name := range ChainFamilies {
+			for _, e := range existing {
+				if name == e {
+					families = append(families, name)
+					break
+				}
+			}
+		}
+	}
+
+	names := append([]string{"default"}, families...)
+	cfOpts := make([]*gorocksdb.Options, len(names))
+	for i := range cfOpts {
+		cfOpts[i] = opts
+	}
+	var (
+		db      *gorocksdb.DB
+		handles []*gorocksdb.ColumnFamilyHandle
+		err     error
+	)
+	if readOnly {
+		db, handles, err = gorocksdb.OpenDbForReadOnlyColumnFamilies(opts, path, names, cfOpts, false)
+	} else {
+		db, handles, err = gorocksdb.OpenDbColumnFamilies(opts, path, names, cfOpts)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -51,6 +99,12 @@ func NewRocksStorage(path string) (*RocksStorage, error) {
 		batchOpts:   make(map[string]*batchOpt),
 		ro:          gorocksdb.NewDefaultReadOptions(),
 		wo:          gorocksdb.NewDefaultWriteOptions(),
+		families:    make(map[string]*rocksFamily),
+	}
+	// handles[0] is the default family served by the storage itself.
+	handles[0].Destroy()
+	for i, name := range families {
+		storage.families[name] = newRocksFamily(storage, handles[i+1])
 	}
 
 	//go RecordMetrics(storage)
@@ -110,10 +164,32 @@ func (storage *RocksStorage) Del(key []byte) error {
 
 // Close levelDB
 func (storage *RocksStorage) Close() error {
+	for _, family := range storage.families {
+		family.cf.Destroy()
+	}
 	storage.db.Close()
 	return nil
 }
 
+// Family returns the view of the column family, the storage itself for unknown names.
+func (storage *RocksStorage) Family(name string) Storage {
+	if family, ok := storage.families[name]; ok {
+		return family
+	}
+	return storage
+}
+
+// Families returns the column families kept apart from the default one.
+func (storage *RocksStorage) Families() []string {
+	families := []string{}
+	for _, name := range ChainFamilies {
+		if _, ok := storage.families[name]; ok {
+			families = append(families, name)
+		}
+	}
+	return families
+}
+
 // EnableBatch enable batch write.
 func (storage *RocksStorage) EnableBatch() {
 	storage.enableBatch = true
@@ -162,6 +238,28 @@ func (storage *RocksStorage) DisableBatch() {
 	storage.enableBatch = false
 }
 
+// Iterate calls fn for every entry whose key starts with prefix.
+func (storage *RocksStorage) Iterate(prefix []byte, fn func(key, value []byte) error) error {
+	ro := gorocksdb.NewDefaultReadOptions()
+	ro.SetFillCache(false)
+	defer ro.Destroy()
+
+	iter := storage.db.NewIterator(ro)
+	defer iter.Close()
+
+	for iter.Seek(prefix); iter.ValidForPrefix(prefix); iter.Next() {
+		key := iter.Key()
+		value := iter.Value()
+		err := fn(append([]byte{}, key.Data()...), append([]byte{}, value.Data()...))
+		key.Free()
+		value.Free()
+		if err != nil {
+			return err
+		}
+	}
+	return iter.Err()
+}
+
 // RecordMetrics record rocksdb metrics
 func RecordMetrics(storage *RocksStorage) {
 	metricsUpdateChan := time.NewTicker(5 * time.Second).C
@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"os"
 	"reflect"
 	"testing"
 
@@ -43,3 +44,46 @@ func TestNewRocksStorage(t *testing.T) {
 	val, err := s.Get(key)
 	assert.Equal(t, val, value)
 }
+
+func TestRocksStorageFamily(t *testing.T) {
+	path := "./rock_family.db/"
+	defer os.RemoveAll(path)
+
+	s, err := NewRocksStorage(path)
+	assert.Nil(t, err)
+	defer s.Close()
+
+	// keys written before the column families are still readable from them.
+	key := []byte("key")
+	assert.Nil(t, s.Put(key, []byte("legacy")))
+	state := s.Family(FamilyState)
+	val, err := state.Get(key)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("legacy"), val)
+
+	assert.Nil(t, state.Put(key, []byte("state")))
+	val, err = state.Get(key)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("state"), val)
+	_, err = s.Family(FamilyBodies).Get(key)
+	assert.Nil(t, err)
+
+	assert.Nil(t, state.Del(key))
+	_, err = state.Get(key)
+	assert.Equal(t, ErrKeyNotFound, err)
+	_, err = s.Get(key)
+	assert.Equal(t, ErrKeyNotFound, err)
+
+	bodies := s.Family(FamilyBodies)
+	bodies.EnableBatch()
+	assert.Nil(t, bodies.Put(key, []byte("body")))
+	_, err = bodies.Get(key)
+	assert.Equal(t, ErrKeyNotFound, err)
+	assert.Nil(t, bodies.Flush())
+	bodies.DisableBatch()
+	val, err = bodies.Get(key)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("body"), val)
+	_, err = s.Family(FamilyReceipts).Get(key)
+	assert.Equal(t, ErrKeyNotFound, err)
+}
@@ -23,6 +23,7 @@ import "errors"
 // const
 var (
 	ErrKeyNotFound = errors.New("not found")
+	ErrNotIterable = errors.New("storage does not support iteration")
 )
 
 // Storage interface of Storage.
@@ -45,3 +46,12 @@ type Storage interface {
 	// Flush write and flush pending batch write.
 	Flush() error
 }
+
+// Iterable is implemented by storages able to walk over all their entries,
+// which offline tools such as the chain namespace migration rely on.
+type Iterable interface {
+	Storage
+
+	// Iterate calls fn for every entry whose key starts with prefix, stopping at the first error.
+	Iterate(prefix []byte, fn func(key, value []byte) error) error
+}
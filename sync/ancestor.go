@@ -0,0 +1,212 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package sync
+
+import (
+	"errors"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/net"
+	"github.com/nebulasio/go-nebulas/sync/pb"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// A node whose tail is on a fork the network abandoned cannot sync from it,
+// the peers do not know its sync point. After a failed chain sync round the
+// task sends the sync peers a locator, the hashes of its canonical chain from
+// the tail back to the LIB at exponentially growing distances, and each peer
+// answers with the highest one on its own canonical chain. Once most of them
+// agree on an ancestor below the sync point, the task resyncs forward from it.
+
+// Errors
+var (
+	ErrInvalidAncestorSourcePeer     = errors.New("invalid ancestor source peer")
+	ErrInvalidAncestorMessageData    = errors.New("invalid Ancestor message data")
+	ErrWrongAncestorMessageData      = errors.New("wrong Ancestor message data")
+	ErrInvalidGetAncestorMessageData = errors.New("invalid GetAncestor message data")
+	ErrTooLongAncestorLocator        = errors.New("too long ancestor locator")
+	ErrNoCommonAncestorAboveLIB      = errors.New("no common ancestor with the network above the LIB")
+)
+
+// ancestorLocator returns the hashes of the canonical chain from the tail back
+// to the LIB, the distance between them doubling after the first ten.
+func ancestorLocator(bc *core.BlockChain) [][]byte {
+	tail := bc.TailBlock().Height()
+	lib := bc.LIB().Height()
+
+	locator := [][]byte{}
+	step := uint64(1)
+	for height := tail; len(locator) < MaxAncestorLocatorSize-1; {
+		block := bc.GetBlockOnCanonicalChainByHeight(height)
+		if block == nil {
+			break
+		}
+		locator = append(locator, block.Hash())
+		if height <= lib+step {
+			break
+		}
+		height -= step
+		if len(locator) >= 10 {
+			step *= 2
+		}
+	}
+
+	// the LIB is always the last one.
+	if len(locator) == 0 || !bc.LIB().Hash().Equals(locator[len(locator)-1]) {
+		locator = append(locator, bc.LIB().Hash())
+	}
+	return locator
+}
+
+// findAncestor returns the first block of the locator on the canonical chain, nil if none is.
+func findAncestor(bc *core.BlockChain, locator [][]byte) *core.Block {
+	for _, h := range locator {
+		block := bc.GetBlock(h)
+		if block == nil {
+			continue
+		}
+		if canonical := bc.GetBlockOnCanonicalChainByHeight(block.Height()); canonical != nil && canonical.Hash().Equals(block.Hash()) {
+			return block
+		}
+	}
+	return nil
+}
+
+func (st *Task) resetAncestor() {
+	st.ancestorPeers = nil
+	st.ancestorVotes = make(map[string][]string)
+}
+
+// ancestorRequest asks the sync peers for the common ancestor of the local chain.
+func (st *Task) ancestorRequest() {
+	// lock.
+	st.syncMutex.Lock()
+	defer st.syncMutex.Unlock()
+
+	st.resetAncestor()
+
+	request := &syncpb.GetAncestor{
+		Locator: ancestorLocator(st.blockChain),
+	}
+	data, err := proto.Marshal(request)
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"err": err,
+		}).Debug("Failed to serialize GetAncestor message")
+		return
+	}
+
+	st.ancestorPeers = st.netService.SendMessageToPeers(net.AncestorRequest, data,
+		net.MessagePriorityLow, st.scores.filter())
+}
+
+// processAncestor counts the common ancestor a sync peer reports, the ancestor
+// is agreed once most of the sync peers report it.
+func (st *Task) processAncestor(message net.Message) {
+	// lock.
+	st.syncMutex.Lock()
+	defer st.syncMutex.Unlock()
+
+	from := message.MessageFrom()
+	isAncestorPeer := false
+	for _, prettyID := range st.ancestorPeers {
+		if prettyID == from {
+			isAncestorPeer = true
+			break
+		}
+	}
+	if !isAncestorPeer {
+		logging.VLog().WithFields(logrus.Fields{
+			"err": ErrInvalidAncestorSourcePeer,
+			"pid": from,
+		}).Debug("Invalid Ancestor message source peer.")
+		return
+	}
+	for _, peers := range st.ancestorVotes {
+		for _, peer := range peers {
+			if peer == from {
+				return
+			}
+		}
+	}
+
+	ancestor := new(syncpb.Ancestor)
+	if err := proto.Unmarshal(message.Data(), ancestor); err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"err": err,
+			"pid": from,
+		}).Debug("Invalid Ancestor message data.")
+		st.netService.ClosePeer(from, ErrInvalidAncestorMessageData)
+		return
+	}
+
+	// the peers knowing none of the locator vote for none, the ancestor
+	// reported must be a block of the locator sent.
+	key := ""
+	var block *core.Block
+	if len(ancestor.Hash) > 0 {
+		block = st.blockChain.GetBlockOnCanonicalChainByHash(ancestor.Hash)
+		if block == nil || block.Height() != ancestor.Height || block.Height() < st.blockChain.LIB().Height() {
+			logging.VLog().WithFields(logrus.Fields{
+				"err":    ErrWrongAncestorMessageData,
+				"hash":   byteutils.Hex(ancestor.Hash),
+				"height": ancestor.Height,
+				"pid":    from,
+			}).Debug("Wrong Ancestor message data.")
+			st.netService.ClosePeer(from, ErrWrongAncestorMessageData)
+			return
+		}
+		key = block.Hash().Hex()
+	}
+	st.ancestorVotes[key] = append(st.ancestorVotes[key], from)
+	st.scores.reward(from)
+
+	if len(st.ancestorVotes[key]) < len(st.ancestorPeers)/2+1 {
+		return
+	}
+
+	if key == "" {
+		logging.CLog().WithFields(logrus.Fields{
+			"err":   ErrNoCommonAncestorAboveLIB,
+			"lib":   st.blockChain.LIB(),
+			"peers": st.ancestorVotes[key],
+		}).Error("The local chain diverged from the network below the LIB, it cannot be resynced automatically.")
+		st.resetAncestor()
+		return
+	}
+
+	if block.Height() < st.syncPointBlock.Height() {
+		st.commonAncestor = block
+	}
+	st.resetAncestor()
+}
+
+// takeCommonAncestor returns the common ancestor agreed since the last call, nil if none is.
+func (st *Task) takeCommonAncestor() *core.Block {
+	// lock.
+	st.syncMutex.Lock()
+	defer st.syncMutex.Unlock()
+
+	ancestor := st.commonAncestor
+	st.commonAncestor = nil
+	return ancestor
+}
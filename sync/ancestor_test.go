@@ -0,0 +1,97 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package sync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/consensus/dpos"
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/net"
+	"github.com/nebulasio/go-nebulas/sync/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func mockAncestor(t *testing.T, block *core.Block) []byte {
+	ancestor := new(syncpb.Ancestor)
+	if block != nil {
+		ancestor.Hash = block.Hash()
+		ancestor.Height = block.Height()
+	}
+	data, err := proto.Marshal(ancestor)
+	assert.Nil(t, err)
+	return data
+}
+
+func TestTask_CommonAncestor(t *testing.T) {
+	neb := mockNeb(t)
+	chain := neb.chain
+
+	blocks := []*core.Block{}
+	for i := 0; i < 40; i++ {
+		context, err := chain.TailBlock().WorldState().NextConsensusState(dpos.BlockIntervalInMs / dpos.SecondInMs)
+		assert.Nil(t, err)
+		coinbase, err := core.AddressParseFromBytes(context.Proposer())
+		assert.Nil(t, err)
+		assert.Nil(t, neb.am.Unlock(coinbase, []byte("passphrase"), time.Second*60*60*24*365))
+		block, err := chain.NewBlock(coinbase)
+		assert.Nil(t, err)
+		block.WorldState().SetConsensusState(context)
+		block.SetTimestamp(chain.TailBlock().Timestamp() + dpos.BlockIntervalInMs/dpos.SecondInMs)
+		assert.Nil(t, block.Seal())
+		assert.Nil(t, neb.am.SignBlock(coinbase, block))
+		assert.Nil(t, chain.BlockPool().Push(block))
+		blocks = append(blocks, block)
+	}
+
+	// the locator runs from the tail to the LIB.
+	locator := ancestorLocator(chain)
+	assert.Equal(t, []byte(chain.TailBlock().Hash()), locator[0])
+	assert.Equal(t, []byte(chain.LIB().Hash()), locator[len(locator)-1])
+	assert.True(t, len(locator) < 40)
+
+	unknown := []byte("unknown block hash")
+	assert.Equal(t, blocks[20].Hash(), findAncestor(chain, [][]byte{unknown, blocks[20].Hash(), blocks[10].Hash()}).Hash())
+	assert.Nil(t, findAncestor(chain, [][]byte{unknown}))
+
+	st := NewTask(chain, neb.ns, NewChunk(chain), SyncModeChunk)
+	st.ancestorPeers = []string{"a", "b", "c"}
+
+	// x is not asked, a votes once.
+	st.processAncestor(net.NewBaseMessage(net.AncestorResponse, "x", mockAncestor(t, blocks[20])))
+	st.processAncestor(net.NewBaseMessage(net.AncestorResponse, "a", mockAncestor(t, blocks[20])))
+	st.processAncestor(net.NewBaseMessage(net.AncestorResponse, "a", mockAncestor(t, blocks[20])))
+	st.processAncestor(net.NewBaseMessage(net.AncestorResponse, "c", mockAncestor(t, nil)))
+	assert.Nil(t, st.commonAncestor)
+
+	st.processAncestor(net.NewBaseMessage(net.AncestorResponse, "b", mockAncestor(t, blocks[20])))
+	assert.Equal(t, blocks[20].Hash(), st.commonAncestor.Hash())
+
+	// the task resyncs forward from the common ancestor.
+	st.setSyncPointToLastChunk()
+	assert.Equal(t, blocks[20].Hash(), st.syncPointBlock.Hash())
+	assert.Nil(t, st.takeCommonAncestor())
+
+	// the ancestor at the sync point is no divergence.
+	st.ancestorPeers = []string{"a"}
+	st.processAncestor(net.NewBaseMessage(net.AncestorResponse, "a", mockAncestor(t, blocks[20])))
+	assert.Nil(t, st.commonAncestor)
+}
@@ -239,6 +239,10 @@ func (n mockNetService) SendMessageToPeer(messageName string, data []byte, prior
 
 func (n mockNetService) ClosePeer(peerID string, reason error) {}
 
+func (n mockNetService) AddPeer(addr string) (string, error) { return "", nil }
+
+func (n mockNetService) RemovePeer(peerID string) error { return nil }
+
 func (n mockNetService) BroadcastNetworkID([]byte) {}
 
 func TestChunk_generateChunkMeta(t *testing.T) {
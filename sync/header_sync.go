@@ -0,0 +1,314 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package sync
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/common/trie"
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/light"
+	"github.com/nebulasio/go-nebulas/light/pb"
+	"github.com/nebulasio/go-nebulas/net"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/nebulasio/go-nebulas/sync/pb"
+	"github.com/nebulasio/go-nebulas/util/byteutils"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// In the headers-first mode a sync subtask asks the sync peers for the
+// headers following the sync point instead of chunk headers. The header
+// chains served are verified, the heaviest one is selected and split into
+// chunk headers, whose blocks are then fetched from the peers serving it as
+// in the chunk mode.
+
+// headersRequest asks the sync peers for the headers following the sync point.
+func (st *Task) headersRequest() {
+	logging.VLog().WithFields(logrus.Fields{
+		"syncPointBlockHeight": st.syncPointBlock.Height(),
+		"syncPointBlockHash":   st.syncPointBlock.Hash().String(),
+	}).Infof("Starting HeadersSync at %d times.", st.chainSyncRetryCount)
+
+	st.chainSyncRetryCount++
+
+	request := &lightpb.GetHeaders{
+		From:  st.syncPointBlock.Height() + 1,
+		Count: light.MaxHeadersPerResponse,
+	}
+	data, err := proto.Marshal(request)
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"err":       err,
+			"syncpoint": st.syncPointBlock,
+		}).Debug("Failed to serialize GetHeaders message")
+		return
+	}
+
+	st.chainSyncPeers = st.netService.SendMessageToPeers(net.HeadersRequest, data,
+		net.MessagePriorityLow, st.scores.filter())
+}
+
+// processHeaders verifies the header chain a sync peer serves from the sync point.
+func (st *Task) processHeaders(message net.Message) {
+	// lock.
+	st.syncMutex.Lock()
+	defer st.syncMutex.Unlock()
+
+	if !st.headersFirst || st.maxConsistentChunkHeaders != nil {
+		return
+	}
+
+	from := message.MessageFrom()
+	if !st.isChainSyncPeer(from) {
+		logging.VLog().WithFields(logrus.Fields{
+			"err": ErrInvalidChunkHeaderSourcePeer,
+			"pid": from,
+		}).Debug("Invalid Headers message source peer.")
+		st.netService.ClosePeer(from, ErrInvalidChunkHeaderSourcePeer)
+		return
+	}
+	if _, ok := st.headerChains[from]; ok || st.forkedHeaderPeers[from] {
+		return
+	}
+
+	headers := new(lightpb.Headers)
+	if err := proto.Unmarshal(message.Data(), headers); err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"err": err,
+			"pid": from,
+		}).Debug("Invalid Headers message data.")
+		st.netService.ClosePeer(from, ErrInvalidHeadersMessageData)
+		return
+	}
+
+	// the dynasties served are checked against their roots, the one of the sync point is in its state.
+	verifier := light.NewVerifier(st.blockChain, nil)
+	if err := verifier.Trust(st.syncPointBlock); err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"err":       err,
+			"syncpoint": st.syncPointBlock,
+		}).Debug("Failed to get the dynasty of the sync point.")
+		return
+	}
+	if err := verifier.AddDynasties(headers.Dynasties); err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"err": err,
+			"pid": from,
+		}).Debug("Wrong Headers message data.")
+		st.netService.ClosePeer(from, ErrWrongHeadersMessageData)
+		st.scores.ban(from, time.Now())
+		return
+	}
+
+	chain := []*core.Block{}
+	parent := st.syncPointBlock
+	for i, pbBlock := range headers.Blocks {
+		// the peer's canonical chain forks below the sync point.
+		if i == 0 && pbBlock.Header != nil && !bytes.Equal(pbBlock.Header.ParentHash, parent.Hash()) {
+			st.forkedHeaderPeers[from] = true
+			st.scores.penalize(from)
+			logging.VLog().WithFields(logrus.Fields{
+				"pid":       from,
+				"syncpoint": st.syncPointBlock,
+			}).Debug("Sync peer is on another fork.")
+			st.checkHeaderChains()
+			return
+		}
+		block, err := verifier.VerifyHeader(parent, pbBlock)
+		if err == core.ErrUnverifiableDynasty || err == light.ErrDynastyNotFound {
+			// the elected dynasty is decided by the state, the chain is cut before it
+			// and verified again once the blocks below are synced.
+			logging.VLog().WithFields(logrus.Fields{
+				"err":    err,
+				"pid":    from,
+				"height": pbBlock.Height,
+			}).Debug("Cut the header chain at an unknown dynasty.")
+			break
+		}
+		if err != nil {
+			logging.VLog().WithFields(logrus.Fields{
+				"err": err,
+				"pid": from,
+			}).Debug("Wrong Headers message data.")
+			st.netService.ClosePeer(from, ErrWrongHeadersMessageData)
+			st.scores.ban(from, time.Now())
+			return
+		}
+		chain = append(chain, block)
+		parent = block
+	}
+	st.headerChains[from] = chain
+	st.scores.reward(from)
+	if headers.Tail > st.highestBlockHeight {
+		st.highestBlockHeight = headers.Tail
+	}
+
+	logging.VLog().WithFields(logrus.Fields{
+		"pid":   from,
+		"count": len(chain),
+		"tail":  headers.Tail,
+	}).Debug("Processed Headers message data.")
+
+	st.checkHeaderChains()
+}
+
+// checkHeaderChains selects the header chain once every sync peer answered.
+func (st *Task) checkHeaderChains() {
+	if len(st.headerChains)+len(st.forkedHeaderPeers) == len(st.chainSyncPeers) {
+		st.selectHeaderChain()
+	}
+}
+
+func (st *Task) isChainSyncPeer(pid string) bool {
+	for _, prettyID := range st.chainSyncPeers {
+		if prettyID == pid {
+			return true
+		}
+	}
+	return false
+}
+
+// headerChainWeight return the number of distinct validators whose signatures
+// are verified in the header chain, and the number of the signatures.
+func headerChainWeight(chain []*core.Block) (int, int) {
+	signers := make(map[string]bool)
+	for _, block := range chain {
+		signer, err := core.RecoverSignerFromSignature(block.Alg(), block.Hash(), block.Signature())
+		if err != nil {
+			continue
+		}
+		signers[signer.String()] = true
+	}
+	return len(signers), len(chain)
+}
+
+// heavier reports whether the weight (a, b) is greater than (c, d).
+func heavier(a, b, c, d int) bool {
+	return a > c || a == c && b > d
+}
+
+// selectHeaderChain selects the heaviest header chain served, all of them are
+// verified against the proposer schedule. The one signed by the most validators
+// is the heaviest, then the one with the most signatures, then the one served by
+// the most peers. Its blocks are split into the chunks to fetch from the peers
+// serving it. It reports false if no peer on the fork of the sync point answered.
+func (st *Task) selectHeaderChain() bool {
+	if st.maxConsistentChunkHeaders != nil {
+		return true
+	}
+	if len(st.headerChains) == 0 {
+		return false
+	}
+
+	servers := func(tip *core.Block) []string {
+		peers := []string{}
+		for peer, chain := range st.headerChains {
+			if tip == nil || uint64(len(chain)) >= tip.Height()-st.syncPointBlock.Height() &&
+				chain[tip.Height()-st.syncPointBlock.Height()-1].Hash().Equals(tip.Hash()) {
+				peers = append(peers, peer)
+			}
+		}
+		return peers
+	}
+
+	var best []*core.Block
+	var bestPeers []string
+	bestSigners, bestSignatures := -1, -1
+	for _, chain := range st.headerChains {
+		signers, signatures := headerChainWeight(chain)
+		if heavier(bestSigners, bestSignatures, signers, signatures) {
+			continue
+		}
+		var tip *core.Block
+		if len(chain) > 0 {
+			tip = chain[len(chain)-1]
+		}
+		peers := servers(tip)
+		if heavier(signers, signatures, bestSigners, bestSignatures) || len(peers) > len(bestPeers) ||
+			len(peers) == len(bestPeers) && tip != nil && bytes.Compare(tip.Hash(), best[len(best)-1].Hash()) < 0 {
+			best = chain
+			bestPeers = peers
+			bestSigners, bestSignatures = signers, signatures
+		}
+	}
+
+	chunkHeaders, err := newChunkHeaders(best)
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"err": err,
+		}).Debug("Failed to split the header chain into chunks.")
+		return false
+	}
+
+	st.maxConsistentChunkHeaders = chunkHeaders
+	st.maxConsistentChunkHeadersCount = len(bestPeers)
+	st.maxConsistentChunkHeadersChainSyncPeers[byteutils.Hex(chunkHeaders.Root)] = bestPeers
+
+	logging.VLog().WithFields(logrus.Fields{
+		"headers": len(best),
+		"peers":   bestPeers,
+		"highest": st.highestBlockHeight,
+	}).Debug("Selected the heaviest header chain.")
+
+	st.chainSyncDoneCh <- true
+	return true
+}
+
+// newChunkHeaders splits the blocks into chunk headers of ChunkSize blocks.
+func newChunkHeaders(blocks []*core.Block) (*syncpb.ChunkHeaders, error) {
+	if len(blocks) == 0 {
+		return &syncpb.ChunkHeaders{}, nil
+	}
+
+	stor, err := storage.NewMemoryStorage()
+	if err != nil {
+		return nil, err
+	}
+	chunksTrie, err := trie.NewTrie(nil, stor, false)
+	if err != nil {
+		return nil, err
+	}
+
+	chunkHeaders := []*syncpb.ChunkHeader{}
+	for start := 0; start < len(blocks); start += core.ChunkSize {
+		end := start + core.ChunkSize
+		if end > len(blocks) {
+			end = len(blocks)
+		}
+		blocksTrie, err := trie.NewTrie(nil, stor, false)
+		if err != nil {
+			return nil, err
+		}
+		headers := [][]byte{}
+		for _, block := range blocks[start:end] {
+			headers = append(headers, block.Hash())
+			if _, err := blocksTrie.Put(block.Hash(), block.Hash()); err != nil {
+				return nil, err
+			}
+		}
+		chunkHeaders = append(chunkHeaders, &syncpb.ChunkHeader{Headers: headers, Root: blocksTrie.RootHash()})
+		if _, err := chunksTrie.Put(blocksTrie.RootHash(), blocksTrie.RootHash()); err != nil {
+			return nil, err
+		}
+	}
+	return &syncpb.ChunkHeaders{ChunkHeaders: chunkHeaders, Root: chunksTrie.RootHash()}, nil
+}
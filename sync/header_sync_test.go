@@ -0,0 +1,110 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package sync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/consensus/dpos"
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/light/pb"
+	"github.com/nebulasio/go-nebulas/net"
+	"github.com/stretchr/testify/assert"
+)
+
+func mockHeaders(t *testing.T, blocks []*core.Block, tail uint64) []byte {
+	headers := &lightpb.Headers{Tail: tail}
+	for _, block := range blocks {
+		pb, err := block.ToProto()
+		assert.Nil(t, err)
+		pbBlock := pb.(*corepb.Block)
+		pbBlock.Transactions = nil
+		headers.Blocks = append(headers.Blocks, pbBlock)
+	}
+	data, err := proto.Marshal(headers)
+	assert.Nil(t, err)
+	return data
+}
+
+func TestTask_HeadersFirst(t *testing.T) {
+	neb := mockNeb(t)
+	chain := neb.chain
+
+	blocks := []*core.Block{}
+	for i := 0; i < 40; i++ {
+		context, err := chain.TailBlock().WorldState().NextConsensusState(dpos.BlockIntervalInMs / dpos.SecondInMs)
+		assert.Nil(t, err)
+		coinbase, err := core.AddressParseFromBytes(context.Proposer())
+		assert.Nil(t, err)
+		assert.Nil(t, neb.am.Unlock(coinbase, []byte("passphrase"), time.Second*60*60*24*365))
+		block, err := chain.NewBlock(coinbase)
+		assert.Nil(t, err)
+		block.WorldState().SetConsensusState(context)
+		block.SetTimestamp(chain.TailBlock().Timestamp() + dpos.BlockIntervalInMs/dpos.SecondInMs)
+		assert.Nil(t, block.Seal())
+		assert.Nil(t, neb.am.SignBlock(coinbase, block))
+		assert.Nil(t, chain.BlockPool().Push(block))
+		blocks = append(blocks, block)
+	}
+
+	// the chains are weighted by the validators signing them.
+	signers, signatures := headerChainWeight(blocks)
+	assert.Equal(t, dpos.DynastySize, signers)
+	assert.Equal(t, 40, signatures)
+	signers, signatures = headerChainWeight(blocks[:20])
+	assert.Equal(t, 20, signers)
+	assert.Equal(t, 20, signatures)
+
+	neb2 := mockNeb(t)
+	st := NewTask(neb2.chain, neb2.ns, NewChunk(neb2.chain), SyncModeHeaders)
+	st.chainSyncPeers = []string{"a", "b", "c", "d"}
+
+	// c is behind, d is on another fork of the sync point.
+	st.processHeaders(net.NewBaseMessage(net.HeadersResponse, "a", mockHeaders(t, blocks, 40)))
+	st.processHeaders(net.NewBaseMessage(net.HeadersResponse, "c", mockHeaders(t, blocks[:20], 20)))
+	st.processHeaders(net.NewBaseMessage(net.HeadersResponse, "d", mockHeaders(t, blocks[1:], 40)))
+	assert.Nil(t, st.maxConsistentChunkHeaders)
+	assert.True(t, st.forkedHeaderPeers["d"])
+
+	st.processHeaders(net.NewBaseMessage(net.HeadersResponse, "b", mockHeaders(t, blocks, 40)))
+	assert.NotNil(t, st.maxConsistentChunkHeaders)
+	assert.Equal(t, uint64(40), st.highestBlockHeight)
+	assert.Equal(t, 2, st.maxConsistentChunkHeadersCount)
+	assert.Equal(t, 2, len(st.maxConsistentChunkHeaders.ChunkHeaders))
+	assert.True(t, <-st.chainSyncDoneCh)
+
+	ok, err := verifyChunkHeaders(st.maxConsistentChunkHeaders)
+	assert.True(t, ok)
+	assert.Nil(t, err)
+
+	// the peers serve the chunks of the selected header chain.
+	ck := NewChunk(chain)
+	for _, header := range st.maxConsistentChunkHeaders.ChunkHeaders {
+		data, err := ck.generateChunkData(header)
+		assert.Nil(t, err)
+		ok, err := verifyChunkData(header, data)
+		assert.True(t, ok)
+		assert.Nil(t, err)
+		assert.Nil(t, st.chunk.processChunkData(data))
+	}
+	assert.Equal(t, blocks[39].Hash(), neb2.chain.TailBlock().Hash())
+}
@@ -12,6 +12,12 @@ It has these top-level messages:
 	ChunkHeader
 	ChunkHeaders
 	ChunkData
+	GetPivot
+	Pivot
+	GetStateNodes
+	StateNodes
+	GetAncestor
+	Ancestor
 */
 package syncpb
 
@@ -119,30 +125,139 @@ func (m *ChunkData) GetRoot() []byte {
 	return nil
 }
 
+type GetPivot struct {
+}
+
+func (m *GetPivot) Reset()                    { *m = GetPivot{} }
+func (m *GetPivot) String() string            { return proto.CompactTextString(m) }
+func (*GetPivot) ProtoMessage()               {}
+func (*GetPivot) Descriptor() ([]byte, []int) { return fileDescriptorSync, []int{4} }
+
+type Pivot struct {
+	Block *corepb.Block `protobuf:"bytes,1,opt,name=block" json:"block,omitempty"`
+}
+
+func (m *Pivot) Reset()                    { *m = Pivot{} }
+func (m *Pivot) String() string            { return proto.CompactTextString(m) }
+func (*Pivot) ProtoMessage()               {}
+func (*Pivot) Descriptor() ([]byte, []int) { return fileDescriptorSync, []int{5} }
+
+func (m *Pivot) GetBlock() *corepb.Block {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+type GetStateNodes struct {
+	Hashes [][]byte `protobuf:"bytes,1,rep,name=hashes" json:"hashes,omitempty"`
+}
+
+func (m *GetStateNodes) Reset()                    { *m = GetStateNodes{} }
+func (m *GetStateNodes) String() string            { return proto.CompactTextString(m) }
+func (*GetStateNodes) ProtoMessage()               {}
+func (*GetStateNodes) Descriptor() ([]byte, []int) { return fileDescriptorSync, []int{6} }
+
+func (m *GetStateNodes) GetHashes() [][]byte {
+	if m != nil {
+		return m.Hashes
+	}
+	return nil
+}
+
+type StateNodes struct {
+	Nodes [][]byte `protobuf:"bytes,1,rep,name=nodes" json:"nodes,omitempty"`
+}
+
+func (m *StateNodes) Reset()                    { *m = StateNodes{} }
+func (m *StateNodes) String() string            { return proto.CompactTextString(m) }
+func (*StateNodes) ProtoMessage()               {}
+func (*StateNodes) Descriptor() ([]byte, []int) { return fileDescriptorSync, []int{7} }
+
+func (m *StateNodes) GetNodes() [][]byte {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+type GetAncestor struct {
+	Locator [][]byte `protobuf:"bytes,1,rep,name=locator" json:"locator,omitempty"`
+}
+
+func (m *GetAncestor) Reset()                    { *m = GetAncestor{} }
+func (m *GetAncestor) String() string            { return proto.CompactTextString(m) }
+func (*GetAncestor) ProtoMessage()               {}
+func (*GetAncestor) Descriptor() ([]byte, []int) { return fileDescriptorSync, []int{8} }
+
+func (m *GetAncestor) GetLocator() [][]byte {
+	if m != nil {
+		return m.Locator
+	}
+	return nil
+}
+
+type Ancestor struct {
+	Hash   []byte `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Height uint64 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+}
+
+func (m *Ancestor) Reset()                    { *m = Ancestor{} }
+func (m *Ancestor) String() string            { return proto.CompactTextString(m) }
+func (*Ancestor) ProtoMessage()               {}
+func (*Ancestor) Descriptor() ([]byte, []int) { return fileDescriptorSync, []int{9} }
+
+func (m *Ancestor) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *Ancestor) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*Sync)(nil), "syncpb.Sync")
 	proto.RegisterType((*ChunkHeader)(nil), "syncpb.ChunkHeader")
 	proto.RegisterType((*ChunkHeaders)(nil), "syncpb.ChunkHeaders")
 	proto.RegisterType((*ChunkData)(nil), "syncpb.ChunkData")
+	proto.RegisterType((*GetPivot)(nil), "syncpb.GetPivot")
+	proto.RegisterType((*Pivot)(nil), "syncpb.Pivot")
+	proto.RegisterType((*GetStateNodes)(nil), "syncpb.GetStateNodes")
+	proto.RegisterType((*StateNodes)(nil), "syncpb.StateNodes")
+	proto.RegisterType((*GetAncestor)(nil), "syncpb.GetAncestor")
+	proto.RegisterType((*Ancestor)(nil), "syncpb.Ancestor")
 }
 
 func init() { proto.RegisterFile("sync.proto", fileDescriptorSync) }
 
 var fileDescriptorSync = []byte{
-	// 236 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x90, 0x41, 0x4b, 0x03, 0x31,
-	0x10, 0x85, 0x59, 0x2d, 0x2b, 0x4e, 0xb7, 0x08, 0xf1, 0xb2, 0x78, 0x2a, 0x0b, 0x4a, 0x2f, 0x26,
-	0x60, 0x0f, 0x1e, 0xbc, 0xa9, 0x48, 0xcf, 0xeb, 0xd1, 0x43, 0x49, 0x62, 0x68, 0x96, 0xae, 0x99,
-	0x90, 0x64, 0x0f, 0xfd, 0xf7, 0x92, 0x69, 0x0b, 0x5b, 0xd8, 0xdb, 0x7b, 0x33, 0xf3, 0x3e, 0x78,
-	0x03, 0x10, 0x0f, 0x4e, 0x73, 0x1f, 0x30, 0x21, 0x2b, 0xb3, 0xf6, 0xea, 0x61, 0xbd, 0xeb, 0x92,
-	0x1d, 0x14, 0xd7, 0xf8, 0x27, 0x9c, 0x51, 0x43, 0x2f, 0x63, 0x87, 0x62, 0x87, 0xcf, 0x27, 0x23,
-	0x34, 0x06, 0x23, 0xbc, 0x12, 0xaa, 0x47, 0xbd, 0x3f, 0x86, 0x1b, 0x0e, 0xb3, 0xef, 0x83, 0xd3,
-	0xec, 0x09, 0xee, 0x92, 0xec, 0xfa, 0x2d, 0xed, 0xb6, 0x56, 0x46, 0x5b, 0x17, 0xcb, 0x62, 0x55,
-	0xb5, 0x8b, 0x3c, 0x7e, 0xcf, 0xd3, 0x8d, 0x8c, 0xb6, 0x79, 0x83, 0xf9, 0x87, 0x1d, 0xdc, 0x7e,
-	0x63, 0xe4, 0xaf, 0x09, 0xac, 0x86, 0x1b, 0x4b, 0x2a, 0xd6, 0xc5, 0xf2, 0x7a, 0x55, 0xb5, 0x67,
-	0xcb, 0x18, 0xcc, 0x02, 0x62, 0xaa, 0xaf, 0x88, 0x42, 0xba, 0xf9, 0x81, 0x6a, 0x14, 0x8e, 0xec,
-	0x15, 0x2a, 0x3d, 0xf2, 0x84, 0x98, 0xbf, 0xdc, 0xf3, 0x63, 0x21, 0x3e, 0xba, 0x6d, 0x2f, 0x0e,
-	0x27, 0xe1, 0x5f, 0x70, 0x4b, 0x81, 0x4f, 0x99, 0x24, 0x7b, 0x84, 0x92, 0x9a, 0x9c, 0x99, 0x0b,
-	0x9e, 0xcb, 0x7b, 0xc5, 0xa9, 0x49, 0x7b, 0x5a, 0x4e, 0x71, 0x54, 0x49, 0x8f, 0x59, 0xff, 0x07,
-	0x00, 0x00, 0xff, 0xff, 0x73, 0x84, 0xc9, 0xac, 0x63, 0x01, 0x00, 0x00,
+	// 337 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x52, 0x4d, 0x4b, 0xeb, 0x40,
+	0x14, 0xa5, 0xef, 0xa5, 0x79, 0x7d, 0xb7, 0x29, 0x0f, 0xe6, 0x89, 0x04, 0x57, 0x65, 0x44, 0xdb,
+	0x85, 0x26, 0x60, 0x41, 0x17, 0xae, 0xfc, 0xc0, 0x76, 0x25, 0x92, 0x2e, 0x5d, 0x94, 0x99, 0xe9,
+	0xd0, 0x09, 0x8d, 0xb9, 0x21, 0x33, 0x15, 0xfa, 0xef, 0x25, 0x37, 0x49, 0x89, 0xd8, 0xdd, 0x39,
+	0xf7, 0x9e, 0x7b, 0xc8, 0x39, 0x19, 0x00, 0xbb, 0xcf, 0x55, 0x54, 0x94, 0xe8, 0x90, 0xf9, 0x15,
+	0x2e, 0xe4, 0xd9, 0x6c, 0x93, 0x3a, 0xb3, 0x93, 0x91, 0xc2, 0x8f, 0x38, 0xd7, 0x72, 0x97, 0x09,
+	0x9b, 0x62, 0xbc, 0xc1, 0xeb, 0x86, 0xc4, 0x0a, 0x4b, 0x1d, 0x17, 0x32, 0x96, 0x19, 0xaa, 0x6d,
+	0x7d, 0xcc, 0x23, 0xf0, 0x96, 0xfb, 0x5c, 0xb1, 0x4b, 0xf8, 0xe7, 0x44, 0x9a, 0xad, 0x68, 0xb7,
+	0x32, 0xc2, 0x9a, 0xb0, 0x37, 0xee, 0x4d, 0x83, 0x64, 0x54, 0x8d, 0x1f, 0xab, 0xe9, 0x42, 0x58,
+	0xc3, 0xef, 0x61, 0xf8, 0x64, 0x76, 0xf9, 0x76, 0xa1, 0xc5, 0x5a, 0x97, 0x2c, 0x84, 0x3f, 0x86,
+	0x90, 0x0d, 0x7b, 0xe3, 0xdf, 0xd3, 0x20, 0x69, 0x29, 0x63, 0xe0, 0x95, 0x88, 0x2e, 0xfc, 0x45,
+	0x2e, 0x84, 0xf9, 0x3b, 0x04, 0x9d, 0x63, 0xcb, 0xee, 0x20, 0x50, 0x1d, 0x4e, 0x16, 0xc3, 0x9b,
+	0xff, 0x51, 0x1d, 0x28, 0xea, 0x68, 0x93, 0x6f, 0xc2, 0xa3, 0xe6, 0x2f, 0xf0, 0x97, 0x0e, 0x9e,
+	0x85, 0x13, 0xec, 0x02, 0x7c, 0x4a, 0xd2, 0x7a, 0x8e, 0xa2, 0x2a, 0x7c, 0x21, 0x23, 0x4a, 0x92,
+	0x34, 0xcb, 0xa3, 0x3e, 0x00, 0x83, 0xb9, 0x76, 0x6f, 0xe9, 0x27, 0x3a, 0x7e, 0x05, 0x7d, 0x02,
+	0xec, 0x1c, 0xfa, 0x74, 0x42, 0xa5, 0xfc, 0xb0, 0xab, 0x77, 0x7c, 0x02, 0xa3, 0xb9, 0x76, 0x4b,
+	0x27, 0x9c, 0x7e, 0xc5, 0xb5, 0xb6, 0xec, 0x14, 0xfc, 0xaa, 0x49, 0xdd, 0x96, 0xd3, 0x30, 0xce,
+	0x01, 0x3a, 0xaa, 0x13, 0xe8, 0xe7, 0xb8, 0x3e, 0x88, 0x6a, 0xc2, 0x27, 0x30, 0x9c, 0x6b, 0xf7,
+	0x90, 0x2b, 0x6d, 0x1d, 0x52, 0xd1, 0x19, 0x2a, 0xe1, 0xb0, 0x6c, 0x8b, 0x6e, 0x28, 0xbf, 0x85,
+	0xc1, 0x41, 0xc5, 0xc0, 0xeb, 0xfc, 0x3a, 0xc2, 0xf4, 0x11, 0x3a, 0xdd, 0x98, 0x3a, 0xa5, 0x97,
+	0x34, 0x4c, 0xfa, 0xf4, 0x00, 0x66, 0x5f, 0x03, 0x00, 0x55, 0x79, 0x76, 0xdc, 0x4b, 0x02, 0x00,
+	0x00,
 }
@@ -0,0 +1,108 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package sync
+
+import (
+	"sync"
+	"time"
+
+	"github.com/nebulasio/go-nebulas/net"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// peerScores tracks the liveness of the sync peers across the sync tasks. A peer
+// serving data in time gains a point, one timing out loses SyncPeerTimeoutPenalty,
+// and a peer serving invalid data is blacklisted for SyncPeerBlacklistDuration.
+// The sync requests go to the best scored peers which are not blacklisted, so
+// that a stale peer is rotated out for the next best one.
+type peerScores struct {
+	mu        sync.Mutex
+	scores    map[string]int
+	blacklist map[string]time.Time
+}
+
+func newPeerScores() *peerScores {
+	return &peerScores{
+		scores:    make(map[string]int),
+		blacklist: make(map[string]time.Time),
+	}
+}
+
+// reward records that the peer served valid data in time.
+func (s *peerScores) reward(peer string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.scores[peer] < MaxSyncPeerScore {
+		s.scores[peer]++
+	}
+}
+
+// penalize records that the peer timed out or is on another fork.
+func (s *peerScores) penalize(peer string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.scores[peer] -= SyncPeerTimeoutPenalty
+	if s.scores[peer] < -MaxSyncPeerScore {
+		s.scores[peer] = -MaxSyncPeerScore
+	}
+}
+
+// ban blacklists the peer which served invalid data.
+func (s *peerScores) ban(peer string, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.scores, peer)
+	s.blacklist[peer] = now.Add(SyncPeerBlacklistDuration * time.Second)
+
+	logging.VLog().WithFields(logrus.Fields{
+		"pid": peer,
+	}).Info("Blacklisted a sync peer serving invalid data.")
+}
+
+func (s *peerScores) isBanned(peer string, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	until, ok := s.blacklist[peer]
+	if ok && now.After(until) {
+		delete(s.blacklist, peer)
+		return false
+	}
+	return ok
+}
+
+// rank returns the score of the peer, false if it is blacklisted.
+func (s *peerScores) rank(peer string) (int, bool) {
+	if s.isBanned(peer, time.Now()) {
+		return 0, false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.scores[peer], true
+}
+
+// filter selects the best scored sync peers which are not blacklisted.
+func (s *peerScores) filter() net.PeerFilterAlgorithm {
+	return &net.RankedPeersFilter{Rank: s.rank}
+}
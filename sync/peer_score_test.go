@@ -0,0 +1,64 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package sync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeerScores(t *testing.T) {
+	s := newPeerScores()
+
+	s.reward("a")
+	s.reward("a")
+	s.penalize("b")
+	rank, ok := s.rank("a")
+	assert.True(t, ok)
+	assert.Equal(t, 2, rank)
+	rank, ok = s.rank("b")
+	assert.True(t, ok)
+	assert.Equal(t, -SyncPeerTimeoutPenalty, rank)
+	rank, ok = s.rank("c")
+	assert.True(t, ok)
+	assert.Equal(t, 0, rank)
+
+	// the scores are bounded.
+	for i := 0; i < 2*MaxSyncPeerScore; i++ {
+		s.reward("c")
+		s.penalize("b")
+	}
+	rank, _ = s.rank("c")
+	assert.Equal(t, MaxSyncPeerScore, rank)
+	rank, _ = s.rank("b")
+	assert.Equal(t, -MaxSyncPeerScore, rank)
+
+	// the peers blacklisted are left out until it expires.
+	now := time.Now()
+	s.ban("a", now)
+	_, ok = s.rank("a")
+	assert.False(t, ok)
+	assert.True(t, s.isBanned("a", now.Add(time.Minute)))
+	assert.False(t, s.isBanned("a", now.Add((SyncPeerBlacklistDuration+1)*time.Second)))
+	rank, ok = s.rank("a")
+	assert.True(t, ok)
+	assert.Equal(t, 0, rank)
+}
@@ -0,0 +1,227 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package sync
+
+import (
+	"time"
+)
+
+// chunkRequest a chunk data request in flight.
+type chunkRequest struct {
+	peer string
+	at   time.Time
+}
+
+// chunkAssignment a chunk to request from a peer.
+type chunkAssignment struct {
+	index int
+	peer  string
+}
+
+// downloadScheduler spreads the chunks of a sync round over the peers serving
+// the agreed chunk headers. The chunks are requested in order, at most
+// ConcurrentSyncChunkDataCount in flight and MaxChunkRequestsPerPeer per peer,
+// and a chunk timed out or served wrong by a peer is requested from another.
+// A peer timing out in MaxChunkTimeoutsPerPeer rounds in a row is stale and dropped.
+type downloadScheduler struct {
+	peers    []string
+	total    int
+	next     int
+	timeout  time.Duration
+	pending  map[int]*chunkRequest
+	done     map[int]string
+	failed   map[int]map[string]bool
+	inflight map[string]int
+	retry    []int
+	timeouts map[string]int
+	timedOut []string
+}
+
+func newDownloadScheduler(total int, peers []string, timeout time.Duration) *downloadScheduler {
+	return &downloadScheduler{
+		peers:    append([]string{}, peers...),
+		total:    total,
+		timeout:  timeout,
+		pending:  make(map[int]*chunkRequest),
+		done:     make(map[int]string),
+		failed:   make(map[int]map[string]bool),
+		inflight: make(map[string]int),
+		timeouts: make(map[string]int),
+	}
+}
+
+// schedule returns the chunks to request now: the ones to retry first, then
+// the next ones in order while there is room.
+func (s *downloadScheduler) schedule(now time.Time) []*chunkAssignment {
+	stale := make(map[string]bool)
+	for index, req := range s.pending {
+		if now.Sub(req.at) >= s.timeout {
+			stale[req.peer] = true
+			s.timedOut = append(s.timedOut, req.peer)
+			s.fail(index, req.peer)
+		}
+	}
+	for peer := range stale {
+		s.timeouts[peer]++
+		if s.timeouts[peer] >= MaxChunkTimeoutsPerPeer {
+			s.removePeer(peer)
+		}
+	}
+
+	assignments := []*chunkAssignment{}
+	assign := func(index int) bool {
+		peer := s.pickPeer(index)
+		if len(peer) == 0 {
+			return false
+		}
+		s.pending[index] = &chunkRequest{peer: peer, at: now}
+		s.inflight[peer]++
+		assignments = append(assignments, &chunkAssignment{index: index, peer: peer})
+		return true
+	}
+
+	retry := s.retry
+	s.retry = nil
+	for i, index := range retry {
+		if len(s.pending) >= ConcurrentSyncChunkDataCount || !assign(index) {
+			s.retry = append(s.retry, retry[i:]...)
+			return assignments
+		}
+	}
+	for s.next < s.total && len(s.pending) < ConcurrentSyncChunkDataCount {
+		if !assign(s.next) {
+			break
+		}
+		s.next++
+	}
+	return assignments
+}
+
+// pickPeer returns the least busy peer with room which has not failed the
+// chunk, or "" if none. Once every peer failed it, they are tried again.
+func (s *downloadScheduler) pickPeer(index int) string {
+	pick := func() string {
+		best := ""
+		for _, peer := range s.peers {
+			if s.failed[index][peer] || s.inflight[peer] >= MaxChunkRequestsPerPeer {
+				continue
+			}
+			if len(best) == 0 || s.inflight[peer] < s.inflight[best] {
+				best = peer
+			}
+		}
+		return best
+	}
+
+	peer := pick()
+	if len(peer) == 0 && len(s.failed[index]) >= len(s.peers) {
+		delete(s.failed, index)
+		peer = pick()
+	}
+	return peer
+}
+
+// fail records that the peer timed out or served the chunk wrong, the chunk is retried.
+func (s *downloadScheduler) fail(index int, peer string) {
+	req, ok := s.pending[index]
+	if !ok || req.peer != peer {
+		return
+	}
+	s.release(index)
+	if s.failed[index] == nil {
+		s.failed[index] = make(map[string]bool)
+	}
+	s.failed[index][peer] = true
+	s.retry = append(s.retry, index)
+}
+
+// finish records that the chunk is received from the peer and verified.
+func (s *downloadScheduler) finish(index int, peer string) {
+	if _, ok := s.pending[index]; ok {
+		s.release(index)
+	} else {
+		// served after its request timed out and was retried elsewhere.
+		for i, r := range s.retry {
+			if r == index {
+				s.retry = append(s.retry[:i], s.retry[i+1:]...)
+				break
+			}
+		}
+	}
+	s.done[index] = peer
+	delete(s.failed, index)
+	delete(s.timeouts, peer)
+}
+
+// drainTimedOut returns the peers of the requests timed out since the last call.
+func (s *downloadScheduler) drainTimedOut() []string {
+	peers := s.timedOut
+	s.timedOut = nil
+	return peers
+}
+
+// reject records that the blocks of the chunk received failed to process, the
+// chunk is requested again from another peer. It returns the peer which served it.
+func (s *downloadScheduler) reject(index int) string {
+	peer, ok := s.done[index]
+	if !ok {
+		return ""
+	}
+	delete(s.done, index)
+	s.failed[index] = map[string]bool{peer: true}
+	s.retry = append(s.retry, index)
+	return peer
+}
+
+func (s *downloadScheduler) release(index int) {
+	s.inflight[s.pending[index].peer]--
+	delete(s.pending, index)
+}
+
+// removePeer stops requesting from the peer, its chunks in flight are retried.
+func (s *downloadScheduler) removePeer(peer string) {
+	for i, p := range s.peers {
+		if p == peer {
+			s.peers = append(s.peers[:i], s.peers[i+1:]...)
+			break
+		}
+	}
+	for index, req := range s.pending {
+		if req.peer == peer {
+			s.fail(index, peer)
+		}
+	}
+	delete(s.inflight, peer)
+}
+
+// isDone reports whether the chunk is received.
+func (s *downloadScheduler) isDone(index int) bool {
+	_, ok := s.done[index]
+	return ok
+}
+
+// finished reports whether all the chunks are received.
+func (s *downloadScheduler) finished() bool {
+	return len(s.done) == s.total
+}
+
+// hasPeers reports whether any peer is left to request from.
+func (s *downloadScheduler) hasPeers() bool {
+	return len(s.peers) > 0
+}
@@ -0,0 +1,121 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package sync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDownloadScheduler(t *testing.T) {
+	now := time.Now()
+	s := newDownloadScheduler(12, []string{"a", "b", "c"}, 10*time.Second)
+
+	// the chunks are spread over the peers, in order and within the limits.
+	assignments := s.schedule(now)
+	assert.Equal(t, 3*MaxChunkRequestsPerPeer, len(assignments))
+	for i, a := range assignments {
+		assert.Equal(t, i, a.index)
+	}
+	for _, peer := range []string{"a", "b", "c"} {
+		assert.Equal(t, MaxChunkRequestsPerPeer, s.inflight[peer])
+	}
+	assert.Equal(t, 0, len(s.schedule(now)))
+
+	// a chunk received frees room for the next one.
+	s.finish(0, assignments[0].peer)
+	next := s.schedule(now)
+	assert.Equal(t, 1, len(next))
+	assert.Equal(t, 3*MaxChunkRequestsPerPeer, next[0].index)
+	assert.Equal(t, assignments[0].peer, next[0].peer)
+
+	// the chunks timed out are requested from other peers first.
+	s.finish(1, assignments[1].peer)
+	s.finish(2, assignments[2].peer)
+	s.finish(3, assignments[3].peer)
+	s.pending[4].at = now.Add(-time.Minute)
+	retried := s.schedule(now)
+	assert.True(t, len(retried) > 0)
+	assert.Equal(t, 4, retried[0].index)
+	assert.NotEqual(t, assignments[4].peer, retried[0].peer)
+
+	// a peer removed hands its chunks over.
+	s.removePeer("a")
+	for _, a := range s.schedule(now) {
+		assert.NotEqual(t, "a", a.peer)
+	}
+	for _, req := range s.pending {
+		assert.NotEqual(t, "a", req.peer)
+	}
+
+	// a chunk rejected is requested again from another peer.
+	assert.Equal(t, assignments[1].peer, s.reject(1))
+	assert.False(t, s.isDone(1))
+	s.finish(4, retried[0].peer)
+
+	for len(s.pending) > 0 || len(s.retry) > 0 || s.next < s.total {
+		for index, req := range s.pending {
+			s.finish(index, req.peer)
+		}
+		s.schedule(now)
+	}
+	assert.True(t, s.finished())
+}
+
+func TestDownloadScheduler_StalePeer(t *testing.T) {
+	now := time.Now()
+	s := newDownloadScheduler(100, []string{"a", "b"}, 10*time.Second)
+
+	// a peer timing out in a row is dropped, its chunks go to the other peer.
+	for i := 0; i < MaxChunkTimeoutsPerPeer; i++ {
+		s.schedule(now)
+		assert.Equal(t, []string{"a", "b"}, s.peers)
+		for index, req := range s.pending {
+			if req.peer == "a" {
+				req.at = now.Add(-time.Minute)
+			} else {
+				s.finish(index, req.peer)
+			}
+		}
+	}
+	s.schedule(now)
+	assert.Equal(t, []string{"b"}, s.peers)
+	assert.Equal(t, MaxChunkTimeoutsPerPeer*MaxChunkRequestsPerPeer, len(s.drainTimedOut()))
+	assert.Equal(t, 0, len(s.drainTimedOut()))
+	for _, req := range s.pending {
+		assert.Equal(t, "b", req.peer)
+	}
+
+	// the count restarts once the peer serves a chunk.
+	s = newDownloadScheduler(100, []string{"a"}, 10*time.Second)
+	for i := 0; i <= MaxChunkTimeoutsPerPeer; i++ {
+		s.schedule(now)
+		for index, req := range s.pending {
+			if i == 1 {
+				s.finish(index, req.peer)
+			} else {
+				req.at = now.Add(-time.Minute)
+			}
+		}
+	}
+	s.schedule(now)
+	assert.True(t, s.hasPeers())
+}
@@ -0,0 +1,469 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package sync
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/nebulasio/go-nebulas/common/trie"
+	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+	"github.com/nebulasio/go-nebulas/net"
+	"github.com/nebulasio/go-nebulas/sync/pb"
+	"github.com/nebulasio/go-nebulas/util/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// In the fast mode a task started on a fresh chain first asks the sync peers
+// for their pivot block, the canonical block at the latest multiple of
+// FastSyncPivotInterval below their LIB. Once most of them agree on a pivot,
+// its state trie nodes are downloaded from the peers serving it, each node is
+// verified against the hash it is requested by. The chain is then started
+// from the pivot block, and the blocks following it are synced and executed
+// as in the chunk mode.
+
+// Errors
+var (
+	ErrInvalidPivotSourcePeer       = errors.New("invalid pivot source peer")
+	ErrInvalidPivotMessageData      = errors.New("invalid Pivot message data")
+	ErrWrongPivotMessageData        = errors.New("wrong Pivot message data")
+	ErrInvalidStateNodesMessageData = errors.New("invalid StateNodes message data")
+	ErrWrongStateNodesMessageData   = errors.New("wrong StateNodes message data")
+	ErrUnrequestedStateNodes        = errors.New("state nodes are not requested from the peer")
+	ErrEmptyStateNodes              = errors.New("peer does not serve the state nodes requested")
+)
+
+type stateRequest struct {
+	hashes [][]byte
+	at     time.Time
+}
+
+// stateDownload assigns the state nodes missing to the peers serving the pivot,
+// each peer has at most one request in flight. The peers whose requests time
+// out are dropped, and the nodes they did not deliver are requested again.
+type stateDownload struct {
+	sync     *trie.NodeSync
+	timeout  time.Duration
+	peers    map[string]*stateRequest
+	timedOut []string
+}
+
+func newStateDownload(sync *trie.NodeSync, peers []string, timeout time.Duration) *stateDownload {
+	d := &stateDownload{
+		sync:    sync,
+		timeout: timeout,
+		peers:   make(map[string]*stateRequest),
+	}
+	for _, peer := range peers {
+		d.peers[peer] = nil
+	}
+	return d
+}
+
+// schedule drops the peers timed out, and returns the hashes of the nodes to
+// request from each idle peer.
+func (d *stateDownload) schedule(now time.Time) (map[string][][]byte, error) {
+	for peer, req := range d.peers {
+		if req != nil && now.Sub(req.at) >= d.timeout {
+			d.removePeer(peer)
+			d.timedOut = append(d.timedOut, peer)
+		}
+	}
+
+	requests := make(map[string][][]byte)
+	for peer, req := range d.peers {
+		if req != nil {
+			continue
+		}
+		hashes, err := d.sync.Missing(MaxStateNodesPerRequest)
+		if err != nil {
+			return nil, err
+		}
+		if len(hashes) == 0 {
+			break
+		}
+		d.peers[peer] = &stateRequest{hashes: hashes, at: now}
+		requests[peer] = hashes
+	}
+	return requests, nil
+}
+
+// deliver processes the nodes a peer returns for its request, the ones
+// it did not return are requested again.
+func (d *stateDownload) deliver(peer string, nodes [][]byte) error {
+	req := d.peers[peer]
+	if req == nil {
+		return ErrUnrequestedStateNodes
+	}
+	d.peers[peer] = nil
+	defer d.sync.Retry(req.hashes)
+
+	requested := make(map[string]bool)
+	for _, h := range req.hashes {
+		requested[string(h)] = true
+	}
+	for _, node := range nodes {
+		if !requested[string(hash.Sha3256(node))] {
+			return ErrWrongStateNodesMessageData
+		}
+		if err := d.sync.Process(node); err != nil {
+			return err
+		}
+	}
+	if len(nodes) == 0 {
+		return ErrEmptyStateNodes
+	}
+	return nil
+}
+
+func (d *stateDownload) removePeer(peer string) {
+	if req := d.peers[peer]; req != nil {
+		d.sync.Retry(req.hashes)
+	}
+	delete(d.peers, peer)
+}
+
+// drainTimedOut returns the peers dropped on timeout since the last call.
+func (d *stateDownload) drainTimedOut() []string {
+	peers := d.timedOut
+	d.timedOut = nil
+	return peers
+}
+
+func (d *stateDownload) hasPeers() bool {
+	return len(d.peers) > 0
+}
+
+func (d *stateDownload) finished() bool {
+	return d.sync.Pending() == 0
+}
+
+// syncState downloads the state of the pivot block agreed by the sync peers and
+// starts the chain from it. The task falls back to sync from the current tail
+// if no pivot is agreed or the state cannot be downloaded. It reports false if
+// the task is stopped.
+func (st *Task) syncState() bool {
+	st.pivotRequest()
+
+	pivotTicker := time.NewTicker(10 * time.Second)
+	defer pivotTicker.Stop()
+
+PIVOT_STEP:
+	for {
+		select {
+		case <-st.quitCh:
+			logging.VLog().Info("Stopped sync loop.")
+			return false
+		case <-pivotTicker.C:
+			if st.pivotRequestCount >= MaxPivotRequestCount {
+				logging.CLog().Warn("No pivot agreed by the sync peers. Fall back to full sync.")
+				return true
+			}
+			st.resetPivot()
+			st.pivotRequest()
+		case <-st.pivotDoneCh:
+			break PIVOT_STEP
+		}
+	}
+
+	if st.pivot == nil {
+		logging.CLog().WithFields(logrus.Fields{
+			"tail": st.syncPointBlock,
+		}).Info("No recent pivot to sync the state of. Fall back to full sync.")
+		return true
+	}
+
+	logging.CLog().WithFields(logrus.Fields{
+		"pivot": st.pivot,
+		"peers": st.pivotPeers,
+	}).Info("Starting to download the state of the pivot block.")
+
+	st.sendStateNodesRequests()
+
+	stateTicker := time.NewTicker(GetStateNodesTimeout * time.Second)
+	defer stateTicker.Stop()
+
+STATE_STEP:
+	for {
+		select {
+		case <-st.quitCh:
+			logging.VLog().Info("Stopped sync loop.")
+			return false
+		case <-stateTicker.C:
+			if !st.checkStateNodesTimeout() {
+				logging.CLog().WithFields(logrus.Fields{
+					"pivot": st.pivot,
+				}).Warn("No peer left to download the state from. Fall back to full sync.")
+				return true
+			}
+		case <-st.stateSyncDoneCh:
+			break STATE_STEP
+		}
+	}
+
+	tail, err := st.blockChain.StartFromState(st.pivot)
+	if err != nil {
+		logging.CLog().WithFields(logrus.Fields{
+			"pivot": st.pivot,
+			"err":   err,
+		}).Error("Failed to start the chain from the pivot block. Fall back to full sync.")
+		return true
+	}
+	st.setSyncPointToNewTail()
+
+	logging.CLog().WithFields(logrus.Fields{
+		"tail":  tail,
+		"nodes": st.stateDownload.sync.Nodes(),
+	}).Info("Downloaded the state of the pivot block. Move to ChainSync.")
+	return true
+}
+
+func (st *Task) resetPivot() {
+	st.syncMutex.Lock()
+	defer st.syncMutex.Unlock()
+
+	st.pivotPeers = nil
+	st.pivotVotes = make(map[string][]string)
+	st.pivotBlocks = make(map[string]*core.Block)
+}
+
+func (st *Task) pivotRequest() {
+	st.syncMutex.Lock()
+	defer st.syncMutex.Unlock()
+
+	logging.VLog().Infof("Starting PivotSync at %d times.", st.pivotRequestCount)
+
+	st.pivotRequestCount++
+
+	data, err := proto.Marshal(new(syncpb.GetPivot))
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"err": err,
+		}).Debug("Failed to serialize GetPivot message")
+		return
+	}
+
+	st.pivotPeers = st.netService.SendMessageToPeers(net.PivotRequest, data,
+		net.MessagePriorityLow, st.scores.filter())
+}
+
+// processPivot counts the pivot a sync peer serves, the pivot is selected once
+// most of the sync peers agree on it.
+func (st *Task) processPivot(message net.Message) {
+	// lock.
+	st.syncMutex.Lock()
+	defer st.syncMutex.Unlock()
+
+	if !st.fastSync || st.pivotSelected {
+		return
+	}
+
+	from := message.MessageFrom()
+	isPivotPeer := false
+	for _, prettyID := range st.pivotPeers {
+		if prettyID == from {
+			isPivotPeer = true
+			break
+		}
+	}
+	if !isPivotPeer {
+		logging.VLog().WithFields(logrus.Fields{
+			"err": ErrInvalidPivotSourcePeer,
+			"pid": from,
+		}).Debug("Invalid Pivot message source peer.")
+		st.netService.ClosePeer(from, ErrInvalidPivotSourcePeer)
+		return
+	}
+	for _, peers := range st.pivotVotes {
+		for _, peer := range peers {
+			if peer == from {
+				return
+			}
+		}
+	}
+
+	pivot := new(syncpb.Pivot)
+	if err := proto.Unmarshal(message.Data(), pivot); err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"err": err,
+			"pid": from,
+		}).Debug("Invalid Pivot message data.")
+		st.netService.ClosePeer(from, ErrInvalidPivotMessageData)
+		return
+	}
+
+	// the peers without a pivot vote for none.
+	key := ""
+	if pivot.Block != nil {
+		block, err := verifyPivot(st.blockChain.ChainID(), pivot.Block)
+		if err != nil {
+			logging.VLog().WithFields(logrus.Fields{
+				"err": err,
+				"pid": from,
+			}).Debug("Wrong Pivot message data.")
+			st.netService.ClosePeer(from, ErrWrongPivotMessageData)
+			st.scores.ban(from, time.Now())
+			return
+		}
+		key = block.Hash().Hex()
+		st.pivotBlocks[key] = block
+	}
+	st.pivotVotes[key] = append(st.pivotVotes[key], from)
+
+	logging.VLog().WithFields(logrus.Fields{
+		"pivot": st.pivotBlocks[key],
+		"count": len(st.pivotVotes[key]),
+		"pid":   from,
+	}).Debug("Processed Pivot message data.")
+
+	if len(st.pivotVotes[key]) < len(st.pivotPeers)/2+1 {
+		return
+	}
+
+	st.pivotSelected = true
+	st.pivotPeers = st.pivotVotes[key]
+	if block := st.pivotBlocks[key]; block != nil && block.Height() > st.syncPointBlock.Height() {
+		st.pivot = block
+	}
+	st.pivotDoneCh <- true
+}
+
+func verifyPivot(chainID uint32, pbBlock *corepb.Block) (*core.Block, error) {
+	block := new(core.Block)
+	if err := block.FromProto(pbBlock); err != nil {
+		return nil, err
+	}
+	h, err := core.HashPbBlock(pbBlock)
+	if err != nil {
+		return nil, err
+	}
+	if !h.Equals(block.Hash()) || block.ChainID() != chainID {
+		return nil, ErrWrongPivotMessageData
+	}
+	return block, nil
+}
+
+func (st *Task) sendStateNodesRequests() {
+	// lock.
+	st.syncMutex.Lock()
+	defer st.syncMutex.Unlock()
+
+	st.stateDownload = newStateDownload(st.blockChain.NewStateSync(st.pivot), st.pivotPeers, GetStateNodesTimeout*time.Second)
+	st.dispatchStateNodesRequests()
+}
+
+// checkStateNodesTimeout requests the nodes timed out from other peers,
+// and reports whether any peer is left.
+func (st *Task) checkStateNodesTimeout() bool {
+	// lock.
+	st.syncMutex.Lock()
+	defer st.syncMutex.Unlock()
+
+	if st.stateDownload == nil {
+		return true
+	}
+	st.dispatchStateNodesRequests()
+	return st.stateDownload.hasPeers()
+}
+
+// dispatchStateNodesRequests sends the requests the download assigns to the peers,
+// or signals the state is downloaded.
+func (st *Task) dispatchStateNodesRequests() {
+	if st.stateDownload.finished() {
+		logging.VLog().Info("Received all the state nodes.")
+		st.stateSyncDoneCh <- true
+		return
+	}
+
+	requests, err := st.stateDownload.schedule(time.Now())
+	for _, peer := range st.stateDownload.drainTimedOut() {
+		st.scores.penalize(peer)
+	}
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"err": err,
+		}).Warn("Failed to schedule the state nodes to request.")
+		return
+	}
+	for peer, hashes := range requests {
+		data, err := proto.Marshal(&syncpb.GetStateNodes{Hashes: hashes})
+		if err != nil {
+			logging.VLog().WithFields(logrus.Fields{
+				"err": err,
+			}).Warn("Failed to marshal GetStateNodes.")
+			continue
+		}
+
+		st.netService.SendMessageToPeer(net.StateNodesRequest, data, net.MessagePriorityLow, peer)
+
+		logging.VLog().WithFields(logrus.Fields{
+			"peer": peer,
+		}).Debugf("Send to get %d state nodes.", len(hashes))
+	}
+}
+
+func (st *Task) processStateNodes(message net.Message) {
+	// lock.
+	st.syncMutex.Lock()
+	defer st.syncMutex.Unlock()
+
+	if st.stateDownload == nil || st.stateDownload.finished() {
+		return
+	}
+
+	from := message.MessageFrom()
+	nodes := new(syncpb.StateNodes)
+	if err := proto.Unmarshal(message.Data(), nodes); err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"err": err,
+			"pid": from,
+		}).Debug("Invalid StateNodes message data.")
+		st.netService.ClosePeer(from, ErrInvalidStateNodesMessageData)
+		st.stateDownload.removePeer(from)
+		st.dispatchStateNodesRequests()
+		return
+	}
+
+	err := st.stateDownload.deliver(from, nodes.Nodes)
+	if err == ErrUnrequestedStateNodes {
+		// the response of a peer dropped on timeout.
+		return
+	}
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"err": err,
+			"pid": from,
+		}).Debug("Wrong StateNodes message data.")
+		// the peers having pruned the pivot state are not faulty.
+		if err != ErrEmptyStateNodes {
+			st.netService.ClosePeer(from, err)
+			st.scores.ban(from, time.Now())
+		} else {
+			st.scores.penalize(from)
+		}
+		st.stateDownload.removePeer(from)
+	} else {
+		st.scores.reward(from)
+	}
+
+	st.dispatchStateNodesRequests()
+}
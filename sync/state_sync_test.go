@@ -0,0 +1,102 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package sync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nebulasio/go-nebulas/common/trie"
+	"github.com/nebulasio/go-nebulas/crypto/hash"
+	"github.com/nebulasio/go-nebulas/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateDownload(t *testing.T) {
+	stor, _ := storage.NewMemoryStorage()
+	tr, _ := trie.NewTrie(nil, stor, false)
+	var root []byte
+	for i := 0; i < 1000; i++ {
+		var err error
+		root, err = tr.Put(hash.Sha3256([]byte{byte(i >> 8), byte(i)}), []byte("value"))
+		assert.Nil(t, err)
+	}
+	serve := func(hashes [][]byte) [][]byte {
+		nodes := [][]byte{}
+		for _, h := range hashes {
+			node, err := stor.Get(h)
+			assert.Nil(t, err)
+			nodes = append(nodes, node)
+		}
+		return nodes
+	}
+
+	synced, _ := storage.NewMemoryStorage()
+	s := trie.NewNodeSync(synced)
+	s.AddRoot(root, nil)
+	now := time.Now()
+	d := newStateDownload(s, []string{"a", "b", "c"}, GetStateNodesTimeout*time.Second)
+
+	// only the root is requested at first, the peer without it is dropped.
+	requests, err := d.schedule(now)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(requests))
+	for peer := range requests {
+		assert.Equal(t, ErrEmptyStateNodes, d.deliver(peer, nil))
+		d.removePeer(peer)
+	}
+
+	// the nodes not requested are rejected.
+	requests, err = d.schedule(now)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(requests))
+	for peer := range requests {
+		assert.Equal(t, ErrWrongStateNodesMessageData, d.deliver(peer, [][]byte{[]byte("node")}))
+		d.removePeer(peer)
+	}
+	assert.Equal(t, ErrUnrequestedStateNodes, d.deliver("b", nil))
+
+	for !d.finished() {
+		requests, err := d.schedule(now)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(requests))
+		for peer, hashes := range requests {
+			assert.True(t, len(hashes) <= MaxStateNodesPerRequest)
+			assert.Nil(t, d.deliver(peer, serve(hashes)))
+		}
+	}
+	assert.True(t, d.hasPeers())
+	assert.Nil(t, trie.Walk(synced, root, nil, make(map[string]bool), func(h []byte, bytes []byte) error {
+		return nil
+	}))
+
+	// the peers timed out are dropped, their nodes are requested again.
+	synced, _ = storage.NewMemoryStorage()
+	s = trie.NewNodeSync(synced)
+	s.AddRoot(root, nil)
+	d = newStateDownload(s, []string{"a"}, GetStateNodesTimeout*time.Second)
+	requests, err = d.schedule(now)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(requests))
+	requests, err = d.schedule(now.Add(GetStateNodesTimeout * time.Second))
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(requests))
+	assert.False(t, d.hasPeers())
+	assert.Equal(t, 1, s.Pending())
+}
@@ -19,6 +19,7 @@
 package sync
 
 import (
+	"encoding/json"
 	"errors"
 	"sync"
 	"time"
@@ -27,6 +28,8 @@ import (
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/nebulasio/go-nebulas/core"
+	"github.com/nebulasio/go-nebulas/core/pb"
+	"github.com/nebulasio/go-nebulas/core/state"
 	"github.com/nebulasio/go-nebulas/net"
 	"github.com/nebulasio/go-nebulas/sync/pb"
 	"github.com/nebulasio/go-nebulas/util/logging"
@@ -37,6 +40,8 @@ import (
 var (
 	ErrInvalidChainSyncMessageData     = errors.New("invalid ChainSync message data")
 	ErrInvalidChainGetChunkMessageData = errors.New("invalid ChainGetChunk message data")
+	ErrInvalidGetStateNodesMessageData = errors.New("invalid GetStateNodes message data")
+	ErrTooManyStateNodesRequested      = errors.New("too many state nodes requested")
 )
 
 // Service manage sync tasks
@@ -44,6 +49,8 @@ type Service struct {
 	blockChain *core.BlockChain
 	netService net.Service
 	chunk      *Chunk
+	mode       string
+	scores     *peerScores
 	quitCh     chan bool
 	messageCh  chan net.Message
 
@@ -57,12 +64,28 @@ func NewService(blockChain *core.BlockChain, netService net.Service) *Service {
 		blockChain: blockChain,
 		netService: netService,
 		chunk:      NewChunk(blockChain),
+		mode:       SyncModeChunk,
+		scores:     newPeerScores(),
 		quitCh:     make(chan bool, 1),
 		activeTask: nil,
 		messageCh:  make(chan net.Message, 128),
 	}
 }
 
+// SetSyncMode set the mode of the sync tasks, SyncModeChunk if mode is empty.
+// The fast mode only applies to the tasks started on a fresh chain.
+func (ss *Service) SetSyncMode(mode string) error {
+	switch mode {
+	case "":
+		mode = SyncModeChunk
+	case SyncModeChunk, SyncModeHeaders, SyncModeFast:
+	default:
+		return ErrInvalidSyncMode
+	}
+	ss.mode = mode
+	return nil
+}
+
 // Start start sync service.
 func (ss *Service) Start() {
 	logging.VLog().Info("Starting Sync Service.")
@@ -73,6 +96,13 @@ func (ss *Service) Start() {
 	netService.Register(net.NewSubscriber(ss, ss.messageCh, false, net.ChunkHeadersResponse, net.MessageWeightChainChunks))
 	netService.Register(net.NewSubscriber(ss, ss.messageCh, false, net.ChunkDataRequest, net.MessageWeightZero))
 	netService.Register(net.NewSubscriber(ss, ss.messageCh, false, net.ChunkDataResponse, net.MessageWeightChainChunkData))
+	netService.Register(net.NewSubscriber(ss, ss.messageCh, false, net.HeadersResponse, net.MessageWeightZero))
+	netService.Register(net.NewSubscriber(ss, ss.messageCh, false, net.PivotRequest, net.MessageWeightZero))
+	netService.Register(net.NewSubscriber(ss, ss.messageCh, false, net.PivotResponse, net.MessageWeightZero))
+	netService.Register(net.NewSubscriber(ss, ss.messageCh, false, net.StateNodesRequest, net.MessageWeightZero))
+	netService.Register(net.NewSubscriber(ss, ss.messageCh, false, net.StateNodesResponse, net.MessageWeightZero))
+	netService.Register(net.NewSubscriber(ss, ss.messageCh, false, net.AncestorRequest, net.MessageWeightZero))
+	netService.Register(net.NewSubscriber(ss, ss.messageCh, false, net.AncestorResponse, net.MessageWeightZero))
 
 	// start loop().
 	go ss.startLoop()
@@ -86,6 +116,13 @@ func (ss *Service) Stop() {
 	netService.Deregister(net.NewSubscriber(ss, ss.messageCh, false, net.ChunkHeadersResponse, net.MessageWeightChainChunks))
 	netService.Deregister(net.NewSubscriber(ss, ss.messageCh, false, net.ChunkDataRequest, net.MessageWeightZero))
 	netService.Deregister(net.NewSubscriber(ss, ss.messageCh, false, net.ChunkDataResponse, net.MessageWeightChainChunkData))
+	netService.Deregister(net.NewSubscriber(ss, ss.messageCh, false, net.HeadersResponse, net.MessageWeightZero))
+	netService.Deregister(net.NewSubscriber(ss, ss.messageCh, false, net.PivotRequest, net.MessageWeightZero))
+	netService.Deregister(net.NewSubscriber(ss, ss.messageCh, false, net.PivotResponse, net.MessageWeightZero))
+	netService.Deregister(net.NewSubscriber(ss, ss.messageCh, false, net.StateNodesRequest, net.MessageWeightZero))
+	netService.Deregister(net.NewSubscriber(ss, ss.messageCh, false, net.StateNodesResponse, net.MessageWeightZero))
+	netService.Deregister(net.NewSubscriber(ss, ss.messageCh, false, net.AncestorRequest, net.MessageWeightZero))
+	netService.Deregister(net.NewSubscriber(ss, ss.messageCh, false, net.AncestorResponse, net.MessageWeightZero))
 
 	ss.StopActiveSync()
 
@@ -102,11 +139,13 @@ func (ss *Service) StartActiveSync() bool {
 		return false
 	}
 
-	ss.activeTask = NewTask(ss.blockChain, ss.netService, ss.chunk)
+	ss.activeTask = NewTask(ss.blockChain, ss.netService, ss.chunk, ss.mode)
+	ss.activeTask.scores = ss.scores
 	ss.activeTask.Start()
 
 	logging.CLog().WithFields(logrus.Fields{
 		"syncpoint": ss.activeTask.syncPointBlock,
+		"mode":      ss.mode,
 	}).Info("Started Active Sync Task.")
 	return true
 }
@@ -145,14 +184,59 @@ func (ss *Service) WaitingForFinish() {
 	ss.activeTask = nil
 }
 
+// Status return the progress of the active sync task, or the tail if no task is active.
+func (ss *Service) Status() *core.SyncProgress {
+	task := ss.activeTask
+	if task == nil {
+		tail := ss.blockChain.TailBlock().Height()
+		return &core.SyncProgress{
+			Syncing:       false,
+			StartingBlock: tail,
+			CurrentBlock:  tail,
+			HighestBlock:  tail,
+		}
+	}
+	return task.progress()
+}
+
+// triggerProgressEvent notify the progress of the active sync task.
+func (ss *Service) triggerProgressEvent() {
+	progress := ss.Status()
+	if !progress.Syncing {
+		return
+	}
+
+	logging.CLog().WithFields(logrus.Fields{
+		"current":   progress.CurrentBlock,
+		"highest":   progress.HighestBlock,
+		"states":    progress.PulledStates,
+		"remaining": time.Duration(progress.Remaining) * time.Second,
+	}).Info("Sync progress.")
+
+	data, err := json.Marshal(progress)
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"err": err,
+		}).Debug("Failed to marshal sync progress event.")
+		return
+	}
+	ss.blockChain.EventEmitter().Trigger(&state.Event{
+		Topic: core.TopicSyncProgress,
+		Data:  string(data),
+	})
+}
+
 func (ss *Service) startLoop() {
 	logging.CLog().Info("Started Sync Service.")
 	timerChan := time.NewTicker(time.Second).C
+	progressChan := time.NewTicker(SyncProgressInterval * time.Second).C
 
 	for {
 		select {
 		case <-timerChan:
 			metricsCachedSync.Update(int64(len(ss.messageCh)))
+		case <-progressChan:
+			ss.triggerProgressEvent()
 		case <-ss.quitCh:
 			if ss.activeTask != nil {
 				ss.activeTask.Stop()
@@ -169,6 +253,20 @@ func (ss *Service) startLoop() {
 				ss.onChunkDataRequest(message)
 			case net.ChunkDataResponse:
 				ss.onChunkDataResponse(message)
+			case net.HeadersResponse:
+				ss.onHeadersResponse(message)
+			case net.PivotRequest:
+				ss.onPivotRequest(message)
+			case net.PivotResponse:
+				ss.onPivotResponse(message)
+			case net.StateNodesRequest:
+				ss.onStateNodesRequest(message)
+			case net.StateNodesResponse:
+				ss.onStateNodesResponse(message)
+			case net.AncestorRequest:
+				ss.onAncestorRequest(message)
+			case net.AncestorResponse:
+				ss.onAncestorResponse(message)
 			default:
 				logging.VLog().WithFields(logrus.Fields{
 					"messageName": message.MessageType(),
@@ -253,6 +351,147 @@ func (ss *Service) onChunkDataResponse(message net.Message) {
 	ss.activeTask.processChunkData(message)
 }
 
+func (ss *Service) onHeadersResponse(message net.Message) {
+	if ss.activeTask == nil {
+		return
+	}
+
+	ss.activeTask.processHeaders(message)
+}
+
+func (ss *Service) onPivotRequest(message net.Message) {
+	if ss.IsActiveSyncing() {
+		return
+	}
+
+	// the pivot is the canonical block at the latest multiple of FastSyncPivotInterval
+	// below the LIB, none if it is the genesis or below the snapshot the chain starts from.
+	pivot := new(syncpb.Pivot)
+	height := ss.blockChain.LIB().Height() / FastSyncPivotInterval * FastSyncPivotInterval
+	if height > 0 {
+		if block := ss.blockChain.GetBlockOnCanonicalChainByHeight(height); block != nil {
+			pbBlock, err := block.ToProto()
+			if err != nil {
+				logging.VLog().WithFields(logrus.Fields{
+					"err":   err,
+					"block": block,
+				}).Debug("Failed to convert the pivot block.")
+				return
+			}
+			pivot.Block = pbBlock.(*corepb.Block)
+		}
+	}
+
+	data, err := proto.Marshal(pivot)
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"err": err,
+		}).Debug("Failed to marshal syncpb.Pivot.")
+		return
+	}
+
+	ss.netService.SendMessageToPeer(net.PivotResponse, data, net.MessagePriorityLow, message.MessageFrom())
+}
+
+func (ss *Service) onPivotResponse(message net.Message) {
+	if ss.activeTask == nil {
+		return
+	}
+
+	ss.activeTask.processPivot(message)
+}
+
+func (ss *Service) onStateNodesRequest(message net.Message) {
+	if ss.IsActiveSyncing() {
+		return
+	}
+
+	request := new(syncpb.GetStateNodes)
+	if err := proto.Unmarshal(message.Data(), request); err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"err": err,
+			"pid": message.MessageFrom(),
+		}).Debug("Invalid GetStateNodes message data.")
+		ss.netService.ClosePeer(message.MessageFrom(), ErrInvalidGetStateNodesMessageData)
+		return
+	}
+	if len(request.Hashes) > MaxStateNodesPerRequest {
+		ss.netService.ClosePeer(message.MessageFrom(), ErrTooManyStateNodesRequested)
+		return
+	}
+
+	// the nodes pruned or unknown are left out.
+	nodes := new(syncpb.StateNodes)
+	for _, h := range request.Hashes {
+		if node, err := ss.blockChain.StateNode(h); err == nil {
+			nodes.Nodes = append(nodes.Nodes, node)
+		}
+	}
+
+	data, err := proto.Marshal(nodes)
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"err": err,
+		}).Debug("Failed to marshal syncpb.StateNodes.")
+		return
+	}
+
+	ss.netService.SendMessageToPeer(net.StateNodesResponse, data, net.MessagePriorityLow, message.MessageFrom())
+}
+
+func (ss *Service) onStateNodesResponse(message net.Message) {
+	if ss.activeTask == nil {
+		return
+	}
+
+	ss.activeTask.processStateNodes(message)
+}
+
+func (ss *Service) onAncestorRequest(message net.Message) {
+	if ss.IsActiveSyncing() {
+		return
+	}
+
+	request := new(syncpb.GetAncestor)
+	if err := proto.Unmarshal(message.Data(), request); err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"err": err,
+			"pid": message.MessageFrom(),
+		}).Debug("Invalid GetAncestor message data.")
+		ss.netService.ClosePeer(message.MessageFrom(), ErrInvalidGetAncestorMessageData)
+		return
+	}
+	if len(request.Locator) > MaxAncestorLocatorSize {
+		ss.netService.ClosePeer(message.MessageFrom(), ErrTooLongAncestorLocator)
+		return
+	}
+
+	// none of the locator on the canonical chain, answer with an empty ancestor.
+	ancestor := new(syncpb.Ancestor)
+	if block := findAncestor(ss.blockChain, request.Locator); block != nil {
+		ancestor.Hash = block.Hash()
+		ancestor.Height = block.Height()
+	}
+
+	data, err := proto.Marshal(ancestor)
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"err": err,
+		}).Debug("Failed to marshal syncpb.Ancestor.")
+		return
+	}
+
+	ss.netService.SendMessageToPeer(net.AncestorResponse, data, net.MessagePriorityLow, message.MessageFrom())
+}
+
+func (ss *Service) onAncestorResponse(message net.Message) {
+	if ss.activeTask == nil {
+		return
+	}
+
+	ss.activeTask.processAncestor(message)
+}
+
 func (ss *Service) chunkHeadersResponse(peerID string, chunks *syncpb.ChunkHeaders) {
 	data, err := proto.Marshal(chunks)
 	if err != nil {
@@ -22,7 +22,6 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
-	"math/rand"
 	"sync"
 	"time"
 
@@ -36,11 +35,6 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-const (
-	chunkDataStatusFinished = int64(-1)
-	chunkDataStatusNotStart = int64(0)
-)
-
 // Errors
 var (
 	ErrInvalidChainChunksMessageData    = errors.New("invalid ChainChunks message data")
@@ -48,6 +42,8 @@ var (
 	ErrInvalidChainChunkDataMessageData = errors.New("invalid ChainChunkData message data")
 	ErrWrongChainChunkDataMessageData   = errors.New("wrong ChainChunkData message data")
 	ErrInvalidChunkHeaderSourcePeer     = errors.New("invalid chunk headers source peer")
+	ErrInvalidHeadersMessageData        = errors.New("invalid Headers message data")
+	ErrWrongHeadersMessageData          = errors.New("wrong Headers message data")
 )
 
 // Task is a sync task
@@ -66,19 +62,49 @@ type Task struct {
 	chunkHeadersRootHashCounter             map[string]int
 	receivedChunkHeadersRootHashPeers       map[string]bool
 
+	// headers-first mode fields.
+	headersFirst       bool
+	headerChains       map[string][]*core.Block
+	forkedHeaderPeers  map[string]bool
+	highestBlockHeight uint64
+
+	// fast mode fields.
+	fastSync          bool
+	pivotRequestCount int
+	pivotPeers        []string
+	pivotVotes        map[string][]string
+	pivotBlocks       map[string]*core.Block
+	pivotSelected     bool
+	pivot             *core.Block
+	pivotDoneCh       chan bool
+	stateDownload     *stateDownload
+	stateSyncDoneCh   chan bool
+
+	// divergence fields.
+	ancestorPeers  []string
+	ancestorVotes  map[string][]string
+	commonAncestor *core.Block
+
 	chainSyncDoneCh               chan bool
-	chainChunkDataSyncPosition    int
 	chainChunkDataProcessPosition int
 	chainChunkData                map[int]*syncpb.ChunkData
-	chainChunkDataStatus          map[int]int64
+	scheduler                     *downloadScheduler
 	chinGetChunkDataDoneCh        chan bool
 
+	// the liveness scores of the sync peers, shared by the tasks of a service.
+	scores *peerScores
+
+	// progress fields.
+	startingBlockHeight uint64
+	startTime           time.Time
+
 	// debug fields.
 	chainSyncRetryCount int
 }
 
-// NewTask return a new sync task
-func NewTask(blockChain *core.BlockChain, netService net.Service, chunk *Chunk) *Task {
+// NewTask return a new sync task in the sync mode, the fast mode only applies to a fresh chain.
+func NewTask(blockChain *core.BlockChain, netService net.Service, chunk *Chunk, mode string) *Task {
+	fresh := blockChain.TailBlock().Hash().Equals(blockChain.GenesisBlock().Hash())
 	return &Task{
 		quitCh:                                  make(chan bool, 1),
 		statusCh:                                make(chan bool, 1),
@@ -92,11 +118,22 @@ func NewTask(blockChain *core.BlockChain, netService net.Service, chunk *Chunk)
 		maxConsistentChunkHeadersChainSyncPeers: make(map[string][]string),
 		chunkHeadersRootHashCounter:             make(map[string]int),
 		receivedChunkHeadersRootHashPeers:       make(map[string]bool),
+		headersFirst:                            mode == SyncModeHeaders,
+		headerChains:                            make(map[string][]*core.Block),
+		forkedHeaderPeers:                       make(map[string]bool),
+		fastSync:                                mode == SyncModeFast && fresh,
+		pivotVotes:                              make(map[string][]string),
+		pivotBlocks:                             make(map[string]*core.Block),
+		pivotDoneCh:                             make(chan bool, 1),
+		stateSyncDoneCh:                         make(chan bool, 1),
+		ancestorVotes:                           make(map[string][]string),
+		scores:                                  newPeerScores(),
+		startingBlockHeight:                     blockChain.TailBlock().Height(),
+		startTime:                               time.Now(),
 		chainSyncDoneCh:                         make(chan bool, 1),
-		chainChunkDataSyncPosition:              0,
 		chainChunkDataProcessPosition:           0,
 		chainChunkData:                          make(map[int]*syncpb.ChunkData),
-		chainChunkDataStatus:                    make(map[int]int64),
+		scheduler:                               nil,
 		chinGetChunkDataDoneCh:                  make(chan bool, 1),
 		// debug fields.
 		chainSyncRetryCount: 0,
@@ -114,9 +151,13 @@ func (st *Task) Stop() {
 }
 
 func (st *Task) startSyncLoop() {
+	if st.fastSync && !st.syncState() {
+		return
+	}
+
 	for {
 		// start chain sync.
-		st.chunkHeadersRequest()
+		st.chainSyncRequest()
 
 		syncTicker := time.NewTicker(10 * time.Second)
 
@@ -127,10 +168,12 @@ func (st *Task) startSyncLoop() {
 				logging.VLog().Info("Stopped sync loop.")
 				return
 			case <-syncTicker.C:
-				if !st.hasEnoughChunkHeaders() {
+				if !st.isChainSyncDone() {
+					st.penalizeSilentPeers()
 					st.reset()
 					st.setSyncPointToLastChunk()
-					st.chunkHeadersRequest()
+					st.chainSyncRequest()
+					st.ancestorRequest()
 					continue
 				}
 			case <-st.chainSyncDoneCh:
@@ -161,7 +204,16 @@ func (st *Task) startSyncLoop() {
 				return
 			case <-getChunkTimeoutTicker.C:
 				// for the timeout peer, send message again.
-				st.checkChainGetChunkTimeout()
+				if !st.checkChainGetChunkTimeout() {
+					// every peer serving the chunks is dropped, start over from the blocks applied.
+					logging.CLog().WithFields(logrus.Fields{
+						"from": st.syncPointBlock,
+						"to":   st.blockChain.TailBlock(),
+					}).Warn("No peer left to get chunk data from. Restart the sync subtask.")
+					st.reset()
+					st.setSyncPointToNewTail()
+					break SYNC_STEP_2
+				}
 			case <-st.chinGetChunkDataDoneCh:
 				// finished.
 				logging.VLog().Info("GetChainData Finished.")
@@ -181,6 +233,47 @@ func (st *Task) startSyncLoop() {
 	}
 }
 
+// progress return the progress of the task, the remaining time is estimated
+// from the rate the blocks are synced at since the task started.
+func (st *Task) progress() *core.SyncProgress {
+	// lock.
+	st.syncMutex.Lock()
+	defer st.syncMutex.Unlock()
+
+	current := st.blockChain.TailBlock().Height()
+	if st.maxConsistentChunkHeaders != nil {
+		target := st.syncPointBlock.Height()
+		for _, chunkHeader := range st.maxConsistentChunkHeaders.ChunkHeaders {
+			target += uint64(len(chunkHeader.Headers))
+		}
+		if target > st.highestBlockHeight {
+			st.highestBlockHeight = target
+		}
+	}
+	if st.pivot != nil && st.pivot.Height() > st.highestBlockHeight {
+		st.highestBlockHeight = st.pivot.Height()
+	}
+	if current > st.highestBlockHeight {
+		st.highestBlockHeight = current
+	}
+
+	progress := &core.SyncProgress{
+		Syncing:       true,
+		StartingBlock: st.startingBlockHeight,
+		CurrentBlock:  current,
+		HighestBlock:  st.highestBlockHeight,
+	}
+	if st.stateDownload != nil {
+		progress.PulledStates = st.stateDownload.sync.Nodes()
+		progress.KnownStates = progress.PulledStates + uint64(st.stateDownload.sync.Pending())
+	}
+	if current > st.startingBlockHeight {
+		elapsed := time.Since(st.startTime).Seconds()
+		progress.Remaining = int64(elapsed * float64(st.highestBlockHeight-current) / float64(current-st.startingBlockHeight))
+	}
+	return progress
+}
+
 func (st *Task) reset() {
 	st.syncMutex.Lock()
 	defer st.syncMutex.Unlock()
@@ -191,10 +284,11 @@ func (st *Task) reset() {
 	st.maxConsistentChunkHeadersChainSyncPeers = make(map[string][]string)
 	st.chunkHeadersRootHashCounter = make(map[string]int)
 	st.receivedChunkHeadersRootHashPeers = make(map[string]bool)
-	st.chainChunkDataStatus = make(map[int]int64)
-	st.chainChunkDataSyncPosition = 0
+	st.headerChains = make(map[string][]*core.Block)
+	st.forkedHeaderPeers = make(map[string]bool)
 	st.chainChunkDataProcessPosition = 0
 	st.chainChunkData = make(map[int]*syncpb.ChunkData)
+	st.scheduler = nil
 }
 
 func (st *Task) setSyncPointToNewTail() {
@@ -203,6 +297,16 @@ func (st *Task) setSyncPointToNewTail() {
 }
 
 func (st *Task) setSyncPointToLastChunk() {
+	// the local chain diverged from the network, resync from the common ancestor.
+	if ancestor := st.takeCommonAncestor(); ancestor != nil {
+		logging.CLog().WithFields(logrus.Fields{
+			"syncpoint": st.syncPointBlock,
+			"ancestor":  ancestor,
+		}).Warn("The local chain diverged from the network. Resync from the common ancestor.")
+		st.syncPointBlock = ancestor
+		return
+	}
+
 	if st.chainSyncRetryCount < 2 {
 		// for the first retry, keep current tail.
 		// TODO: for testing perpose, could be deleted.
@@ -215,9 +319,35 @@ func (st *Task) setSyncPointToLastChunk() {
 		lastChunkBlockHeight = st.syncPointBlock.Height() - uint64(core.ChunkSize)
 	}
 
+	// blocks at and below the latest checkpoint are final, never sync from below it.
+	if cp := st.blockChain.LatestCheckpoint(); cp != nil && lastChunkBlockHeight < cp.Height() {
+		lastChunkBlockHeight = cp.Height()
+	}
+
 	st.syncPointBlock = st.blockChain.GetBlockOnCanonicalChainByHeight(lastChunkBlockHeight)
 }
 
+func (st *Task) chainSyncRequest() {
+	if st.headersFirst {
+		st.headersRequest()
+		return
+	}
+	st.chunkHeadersRequest()
+}
+
+// isChainSyncDone reports whether the chunks to fetch are agreed, in the
+// headers-first mode the header chain is selected among the ones received.
+func (st *Task) isChainSyncDone() bool {
+	if !st.headersFirst {
+		return st.hasEnoughChunkHeaders()
+	}
+
+	// lock.
+	st.syncMutex.Lock()
+	defer st.syncMutex.Unlock()
+	return st.selectHeaderChain()
+}
+
 func (st *Task) chunkHeadersRequest() {
 	logging.VLog().WithFields(logrus.Fields{
 		"syncPointBlockHeight": st.syncPointBlock.Height(),
@@ -241,7 +371,7 @@ func (st *Task) chunkHeadersRequest() {
 
 	// send message to peers.
 	st.chainSyncPeers = st.netService.SendMessageToPeers(net.ChunkHeadersRequest, data,
-		net.MessagePriorityLow, new(net.ChainSyncPeersFilter))
+		net.MessagePriorityLow, st.scores.filter())
 }
 
 func (st *Task) processChunkHeaders(message net.Message) {
@@ -297,6 +427,7 @@ func (st *Task) processChunkHeaders(message net.Message) {
 			"pid": message.MessageFrom(),
 		}).Debug("Wrong ChainChunkHeaders message data.")
 		st.netService.ClosePeer(message.MessageFrom(), ErrWrongChainChunksMessageData)
+		st.scores.ban(message.MessageFrom(), time.Now())
 		return
 	}
 
@@ -314,6 +445,7 @@ func (st *Task) processChunkHeaders(message net.Message) {
 	count := st.chunkHeadersRootHashCounter[rootHash] + 1
 	st.chunkHeadersRootHashCounter[rootHash] += count
 	st.receivedChunkHeadersRootHashPeers[hashPeerKey] = true
+	st.scores.reward(message.MessageFrom())
 	st.maxConsistentChunkHeadersChainSyncPeers[rootHash] = append(st.maxConsistentChunkHeadersChainSyncPeers[rootHash], message.MessageFrom())
 
 	isMax := false
@@ -331,6 +463,15 @@ func (st *Task) processChunkHeaders(message net.Message) {
 	}).Debug("Processed ChainChunkHeaders message data.")
 
 	if st.hasEnoughChunkHeaders() {
+		// the peers serving other chunk headers are on a dead fork.
+		for root, peers := range st.maxConsistentChunkHeadersChainSyncPeers {
+			if root == byteutils.Hex(st.maxConsistentChunkHeaders.Root) {
+				continue
+			}
+			for _, peer := range peers {
+				st.scores.penalize(peer)
+			}
+		}
 		st.chainSyncDoneCh <- true
 	}
 }
@@ -352,41 +493,37 @@ func (st *Task) sendChunkDataRequest() {
 		return
 	}
 
-	currentSyncChunkDataCount := 0
-	chainChunkDataSyncPosition := 0
-	for i := 0; i < len(st.maxConsistentChunkHeaders.ChunkHeaders) && currentSyncChunkDataCount < ConcurrentSyncChunkDataCount; i++ {
-		if st.chainChunkDataStatus[i] == chunkDataStatusNotStart {
-			currentSyncChunkDataCount++
-			chainChunkDataSyncPosition = i
-			st.chunkDataRequest(i)
-		}
-	}
-
-	st.chainChunkDataSyncPosition = chainChunkDataSyncPosition
+	peers := st.maxConsistentChunkHeadersChainSyncPeers[byteutils.Hex(st.maxConsistentChunkHeaders.Root)]
+	st.scheduler = newDownloadScheduler(len(st.maxConsistentChunkHeaders.ChunkHeaders), peers, GetChunkDataTimeout*time.Second)
+	st.dispatchChunkDataRequests()
 }
 
-func (st *Task) checkChainGetChunkTimeout() {
+// checkChainGetChunkTimeout requests the chunks timed out from other peers,
+// and reports whether any peer is left.
+func (st *Task) checkChainGetChunkTimeout() bool {
 	// lock.
 	st.syncMutex.Lock()
 	defer st.syncMutex.Unlock()
 
-	for i := 0; i <= st.chainChunkDataSyncPosition; i++ {
-		t := st.chainChunkDataStatus[i]
-
-		if t == chunkDataStatusFinished || t == chunkDataStatusNotStart {
-			continue
-		}
-
-		logging.VLog().WithFields(logrus.Fields{
-			"rootHash": byteutils.Hex(st.maxConsistentChunkHeaders.Root),
-			"timout":   time.Now().Unix() - st.chainChunkDataStatus[i],
-		}).Debugf("Get Chunk %d Timout. Retry.", i)
+	if st.scheduler == nil {
+		return true
+	}
+	st.dispatchChunkDataRequests()
+	return st.scheduler.hasPeers()
+}
 
-		st.chunkDataRequest(i)
+// dispatchChunkDataRequests sends the requests the scheduler assigns to the peers.
+func (st *Task) dispatchChunkDataRequests() {
+	assignments := st.scheduler.schedule(time.Now())
+	for _, peer := range st.scheduler.drainTimedOut() {
+		st.scores.penalize(peer)
+	}
+	for _, a := range assignments {
+		st.chunkDataRequest(a.index, a.peer)
 	}
 }
 
-func (st *Task) chunkDataRequest(chunkHeaderIndex int) {
+func (st *Task) chunkDataRequest(chunkHeaderIndex int, peer string) {
 	chunkHeader := st.maxConsistentChunkHeaders.ChunkHeaders[chunkHeaderIndex]
 	data, err := proto.Marshal(chunkHeader)
 	if err != nil {
@@ -396,15 +533,10 @@ func (st *Task) chunkDataRequest(chunkHeaderIndex int) {
 		return
 	}
 
-	// random
-	peers := st.maxConsistentChunkHeadersChainSyncPeers[byteutils.Hex(st.maxConsistentChunkHeaders.Root)]
-	idx := rand.Intn(len(peers))
-	st.netService.SendMessageToPeer(net.ChunkDataRequest, data, net.MessagePriorityLow, peers[idx])
-
-	st.chainChunkDataStatus[chunkHeaderIndex] = time.Now().Unix()
+	st.netService.SendMessageToPeer(net.ChunkDataRequest, data, net.MessagePriorityLow, peer)
 
 	logging.VLog().WithFields(logrus.Fields{
-		"peers": peers,
+		"peer": peer,
 	}).Debugf("Send to get chain chunk %d.", chunkHeaderIndex)
 }
 
@@ -414,7 +546,7 @@ func (st *Task) processChunkData(message net.Message) {
 	defer st.syncMutex.Unlock()
 
 	// if maxConsistentChunkHeaders is nil, return
-	if st.maxConsistentChunkHeaders == nil || st.maxConsistentChunkHeaders.ChunkHeaders == nil {
+	if st.maxConsistentChunkHeaders == nil || st.maxConsistentChunkHeaders.ChunkHeaders == nil || st.scheduler == nil {
 		logging.VLog().WithFields(logrus.Fields{
 			"pid": message.MessageFrom(),
 		}).Debug("Invalid ChainChunkData message data.")
@@ -452,7 +584,7 @@ func (st *Task) processChunkData(message net.Message) {
 		return
 	}
 
-	if st.chainChunkDataStatus[chunkDataIndex] == chunkDataStatusFinished {
+	if st.scheduler.isDone(chunkDataIndex) {
 		logging.VLog().WithFields(logrus.Fields{
 			"pid": message.MessageFrom(),
 		}).Debug("Duplicated ChainChunkData message data.")
@@ -465,46 +597,71 @@ func (st *Task) processChunkData(message net.Message) {
 			"pid": message.MessageFrom(),
 		}).Debug("Wrong ChainChunkData message data, retry.")
 		st.netService.ClosePeer(message.MessageFrom(), err)
-		st.chunkDataRequest(chunkDataIndex)
+		st.scores.ban(message.MessageFrom(), time.Now())
+		st.scheduler.fail(chunkDataIndex, message.MessageFrom())
+		st.scheduler.removePeer(message.MessageFrom())
+		st.dispatchChunkDataRequests()
 		return
 	}
+	st.scores.reward(message.MessageFrom())
 
+	// the chunks are received in any order, their blocks are pushed in order.
+	st.scheduler.finish(chunkDataIndex, message.MessageFrom())
 	st.chainChunkData[chunkDataIndex] = chunkData
 	chunk, ok := st.chainChunkData[st.chainChunkDataProcessPosition]
 	for ok {
-		// startAt := time.Now().Unix()
 		if err := st.chunk.processChunkData(chunk); err != nil {
+			peer := st.scheduler.reject(st.chainChunkDataProcessPosition)
 			logging.VLog().WithFields(logrus.Fields{
-				"err": err,
-				"pid": message.MessageFrom(),
+				"err":   err,
+				"pid":   peer,
+				"chunk": st.chainChunkDataProcessPosition,
 			}).Debug("Wrong ChainChunkData message data, retry.")
-			st.netService.ClosePeer(message.MessageFrom(), err)
-			st.chunkDataRequest(chunkDataIndex)
-			return
+			delete(st.chainChunkData, st.chainChunkDataProcessPosition)
+			st.netService.ClosePeer(peer, err)
+			st.scores.ban(peer, time.Now())
+			st.scheduler.removePeer(peer)
+			break
 		}
 
+		delete(st.chainChunkData, st.chainChunkDataProcessPosition)
 		st.chainChunkDataProcessPosition++
 		chunk, ok = st.chainChunkData[st.chainChunkDataProcessPosition]
 	}
 
-	// mark done.
-	st.chainChunkDataStatus[chunkDataIndex] = chunkDataStatusFinished
+	if st.chainChunkDataProcessPosition == len(st.maxConsistentChunkHeaders.ChunkHeaders) {
+		logging.VLog().Info("Received enough chunk data.")
+		st.chinGetChunkDataDoneCh <- true
+		return
+	}
 
-	// sync next chunk.
-	st.sendChainGetChunkForNext()
+	// sync next chunks.
+	st.dispatchChunkDataRequests()
 }
 
-func (st *Task) sendChainGetChunkForNext() {
-	nextPos := st.chainChunkDataSyncPosition + 1
-	if nextPos >= len(st.maxConsistentChunkHeaders.ChunkHeaders) {
-		if st.hasFinishedGetAllChunkData() {
-			st.chinGetChunkDataDoneCh <- true
+// penalizeSilentPeers penalizes the sync peers which did not answer the chain sync request.
+func (st *Task) penalizeSilentPeers() {
+	// lock.
+	st.syncMutex.Lock()
+	defer st.syncMutex.Unlock()
+
+	responded := make(map[string]bool)
+	for peer := range st.headerChains {
+		responded[peer] = true
+	}
+	for peer := range st.forkedHeaderPeers {
+		responded[peer] = true
+	}
+	for _, peers := range st.maxConsistentChunkHeadersChainSyncPeers {
+		for _, peer := range peers {
+			responded[peer] = true
+		}
+	}
+	for _, peer := range st.chainSyncPeers {
+		if !responded[peer] {
+			st.scores.penalize(peer)
 		}
-		return
 	}
-
-	st.chainChunkDataSyncPosition = nextPos
-	st.chunkDataRequest(nextPos)
 }
 
 func (st *Task) hasEnoughChunkHeaders() bool {
@@ -515,24 +672,3 @@ func (st *Task) hasEnoughChunkHeaders() bool {
 
 	return chainSyncPeersCount > 0 && st.maxConsistentChunkHeadersCount >= int(chainSyncPeersCount/2)+1
 }
-
-func (st *Task) hasFinishedGetAllChunkData() bool {
-	total := len(st.maxConsistentChunkHeaders.ChunkHeaders)
-	missing := 0
-	for i := 0; i < total; i++ {
-		if st.chainChunkDataStatus[i] != chunkDataStatusFinished {
-			missing++
-		}
-	}
-
-	if missing > 0 {
-		logging.VLog().WithFields(logrus.Fields{
-			"totalSyncingChunkHeaders": total,
-			"missingCount":             missing,
-		}).Debug("Waiting for ChunkData.")
-		return false
-	}
-
-	logging.VLog().Info("Received enough chunk data.")
-	return true
-}
@@ -0,0 +1,55 @@
+// Copyright (C) 2017 go-nebulas authors
+//
+// This file is part of the go-nebulas library.
+//
+// the go-nebulas library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-nebulas library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-nebulas library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package sync
+
+import (
+	"testing"
+
+	"github.com/nebulasio/go-nebulas/sync/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTask_Progress(t *testing.T) {
+	neb := mockNeb(t)
+	ss := NewService(neb.chain, neb.ns)
+
+	progress := ss.Status()
+	assert.False(t, progress.Syncing)
+	assert.Equal(t, neb.chain.TailBlock().Height(), progress.HighestBlock)
+
+	st := NewTask(neb.chain, neb.ns, ss.chunk, SyncModeChunk)
+	ss.activeTask = st
+	st.maxConsistentChunkHeaders = &syncpb.ChunkHeaders{
+		ChunkHeaders: []*syncpb.ChunkHeader{
+			{Headers: make([][]byte, 32)},
+			{Headers: make([][]byte, 5)},
+		},
+	}
+
+	progress = ss.Status()
+	assert.True(t, progress.Syncing)
+	assert.Equal(t, st.syncPointBlock.Height(), progress.StartingBlock)
+	assert.Equal(t, st.syncPointBlock.Height(), progress.CurrentBlock)
+	assert.Equal(t, st.syncPointBlock.Height()+37, progress.HighestBlock)
+	assert.Equal(t, int64(0), progress.Remaining)
+
+	// the highest block known is kept once the chunks are synced.
+	st.reset()
+	assert.Equal(t, st.syncPointBlock.Height()+37, ss.Status().HighestBlock)
+}
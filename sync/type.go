@@ -34,6 +34,14 @@ var (
 	ErrWrongChunkDataSize       = errors.New("wrong chunk data size")
 	ErrInvalidBlockHashInChunk  = errors.New("invalid block hash in chunk data")
 	ErrWrongBlockHashInChunk    = errors.New("wrong block hash in chunk data compared with chunk header")
+	ErrInvalidSyncMode          = errors.New("invalid sync mode")
+)
+
+// Sync modes
+const (
+	SyncModeChunk   = "chunk"
+	SyncModeHeaders = "headers"
+	SyncModeFast    = "fast"
 )
 
 // Contants
@@ -41,6 +49,17 @@ const (
 	MaxChunkPerSyncRequest       = 10
 	ConcurrentSyncChunkDataCount = 10
 	GetChunkDataTimeout          = 10 // 10s.
+	MaxChunkRequestsPerPeer      = 3
+	FastSyncPivotInterval        = 1024
+	MaxPivotRequestCount         = 3
+	MaxStateNodesPerRequest      = 384
+	GetStateNodesTimeout         = 10 // 10s.
+	SyncProgressInterval         = 8  // 8s.
+	MaxChunkTimeoutsPerPeer      = 3
+	MaxSyncPeerScore             = 100
+	SyncPeerTimeoutPenalty       = 5
+	SyncPeerBlacklistDuration    = 1800 // 30min.
+	MaxAncestorLocatorSize       = 64
 )
 
 // Metrics
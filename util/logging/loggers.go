@@ -19,6 +19,7 @@
 package logging
 
 import (
+	"errors"
 	"os"
 
 	"github.com/sirupsen/logrus"
@@ -34,6 +35,9 @@ const (
 	DebugLevel = "debug"
 )
 
+// ErrInvalidLogLevel the level is not one of the above
+var ErrInvalidLogLevel = errors.New("invalid log level")
+
 type emptyWriter struct{}
 
 func (ew emptyWriter) Write(p []byte) (int, error) {
@@ -78,6 +82,17 @@ func convertLevel(level string) logrus.Level {
 	}
 }
 
+// SetLevel change the level of the verbose logger at runtime.
+func SetLevel(level string) error {
+	switch level {
+	case PanicLevel, FatalLevel, ErrorLevel, WarnLevel, InfoLevel, DebugLevel:
+	default:
+		return ErrInvalidLogLevel
+	}
+	VLog().SetLevel(convertLevel(level))
+	return nil
+}
+
 // Init loggers
 func Init(path string, level string, age uint32) {
 	fileHooker := NewFileRotateHooker(path, age)